@@ -15,6 +15,7 @@ import (
 	"fmt"
 	"math/big"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/mrz1836/sigil/internal/chain"
@@ -27,6 +28,7 @@ var (
 	ErrTokenBadLength   = errors.New("invalid token length")
 	ErrChainDenied      = errors.New("agent not authorized for chain")
 	ErrAddrDenied       = errors.New("destination address not in agent allowlist")
+	ErrAssetDenied      = errors.New("asset not in agent allowlist")
 	ErrPerTxLimit       = errors.New("amount exceeds per-transaction limit")
 	ErrDailyLimitExceed = errors.New("amount would exceed daily limit")
 	ErrDailyOverflow    = errors.New("daily limit overflow")
@@ -37,6 +39,7 @@ var (
 	ErrAgentExpired     = errors.New("agent has expired")
 	ErrDecryptFailed    = errors.New("decrypting seed: wrong token or corrupted agent file")
 	ErrTokenNoMatch     = errors.New("token does not match any agent")
+	ErrRuleNotFound     = errors.New("policy rule not found")
 )
 
 // Token prefix for agent tokens.
@@ -82,6 +85,9 @@ type Credential struct {
 
 	// Xpubs maps chain IDs to their xpub strings for read-only access.
 	Xpubs map[chain.ID]string `json:"xpubs,omitempty"`
+
+	// Version is the credential file format version.
+	Version int `json:"version"`
 }
 
 // IsExpired returns true if the agent credential has expired.
@@ -126,6 +132,39 @@ type Policy struct {
 
 	// AllowedAddrs is a list of allowed destination addresses. Empty means any address.
 	AllowedAddrs []string `json:"allowed_addrs,omitempty"`
+
+	// AllowedAssets is a list of assets the agent may send. Each entry is a
+	// chain ID ("bsv", "eth") for that chain's native coin, or an ERC-20
+	// contract address. Empty means any asset on the agent's chains.
+	AllowedAssets []string `json:"allowed_assets,omitempty"`
+
+	// HostFingerprint binds the agent to one machine (see HostFingerprint).
+	// Sends from any other machine are rejected. Empty means any host.
+	HostFingerprint string `json:"host_fingerprint,omitempty"`
+
+	// Rules scope sends of individual assets to addresses and limits of
+	// their own (see Rule). Assets without rules are limited by the fields
+	// above alone.
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// AllowsAsset reports whether the policy permits sending an asset. token is
+// the ERC-20 contract address, or "" for the chain's native coin.
+func (p *Policy) AllowsAsset(chainID chain.ID, token string) bool {
+	if len(p.AllowedAssets) == 0 {
+		return true
+	}
+	asset := string(chainID)
+	if token != "" {
+		asset = token
+	}
+	for _, allowed := range p.AllowedAssets {
+		// Contract addresses are compared case-insensitively, like ETH recipients
+		if strings.EqualFold(allowed, asset) {
+			return true
+		}
+	}
+	return false
 }
 
 // MaxPerTxWeiBig returns MaxPerTxWei as a *big.Int. Returns nil if unset or zero.
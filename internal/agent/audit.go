@@ -0,0 +1,162 @@
+package agent
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// Audit decisions.
+const (
+	// DecisionAllowed records an action that was carried out.
+	DecisionAllowed = "allowed"
+	// DecisionDenied records an action the agent's policy refused.
+	DecisionDenied = "denied"
+	// DecisionFailed records an allowed action that failed, such as a send
+	// whose broadcast was rejected.
+	DecisionFailed = "failed"
+)
+
+// ErrAuditTampered indicates an audit log entry was changed or removed
+// after it was written.
+var ErrAuditTampered = errors.New("agent audit log integrity check failed: possible tampering")
+
+// AuditEntry is one action an agent took or was refused.
+type AuditEntry struct {
+	Time     time.Time `json:"time"`
+	Action   string    `json:"action"` // e.g. "tx send"
+	Source   string    `json:"source,omitempty"`
+	Chain    chain.ID  `json:"chain,omitempty"`
+	To       string    `json:"to,omitempty"`
+	Amount   string    `json:"amount,omitempty"`
+	Token    string    `json:"token,omitempty"`
+	Fee      string    `json:"fee,omitempty"`
+	TxHash   string    `json:"tx_hash,omitempty"`
+	Decision string    `json:"decision"`
+	Reason   string    `json:"reason,omitempty"`
+
+	// Prev is the SHA-256 of the previous line of the log, chaining the
+	// entries so that a changed or removed entry is detected.
+	Prev string `json:"prev"`
+}
+
+// AppendAudit appends entry to the audit log of an agent. The log is a
+// JSON Lines file that is only ever appended to.
+func (s *FileStore) AppendAudit(walletName, agentID string, entry *AuditEntry) error {
+	if !walletNameRegex.MatchString(walletName) {
+		return fmt.Errorf("%w: %q", ErrInvalidWallet, walletName)
+	}
+	path := s.auditPath(walletName, agentID)
+	if path == "" {
+		return ErrInvalidAgentPath
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.MkdirAll(s.basePath, agentDirPermissions); err != nil {
+		return fmt.Errorf("creating agents directory: %w", err)
+	}
+
+	prev, err := lastLineHash(path)
+	if err != nil {
+		return err
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	entry.Prev = prev
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshaling audit entry: %w", err)
+	}
+
+	//nolint:gosec // G304: Path constructed from validated wallet name and agent ID
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, agentFilePermissions)
+	if err != nil {
+		return fmt.Errorf("opening audit log: %w", err)
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing audit log: %w", err)
+	}
+	return f.Close()
+}
+
+// ReadAudit returns the audit log of an agent, oldest first. A missing log
+// has no entries. A broken hash chain returns the entries read so far with
+// ErrAuditTampered.
+func (s *FileStore) ReadAudit(walletName, agentID string) ([]AuditEntry, error) {
+	if !walletNameRegex.MatchString(walletName) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidWallet, walletName)
+	}
+	path := s.auditPath(walletName, agentID)
+	if path == "" {
+		return nil, ErrInvalidAgentPath
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	//nolint:gosec // G304: Path constructed from validated wallet name and agent ID
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading audit log: %w", err)
+	}
+
+	var entries []AuditEntry
+	prev := ""
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, len(data)+1)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Bytes()
+		var entry AuditEntry
+		if err := json.Unmarshal(line, &entry); err != nil {
+			return entries, fmt.Errorf("%w: line %d is not an audit entry", ErrAuditTampered, n)
+		}
+		if entry.Prev != prev {
+			return entries, fmt.Errorf("%w: line %d does not follow line %d", ErrAuditTampered, n, n-1)
+		}
+		entries = append(entries, entry)
+		prev = hashLine(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return entries, fmt.Errorf("reading audit log: %w", err)
+	}
+	return entries, nil
+}
+
+// lastLineHash returns the hash of the last line of the log at path, or ""
+// when it is empty or missing.
+func lastLineHash(path string) (string, error) {
+	//nolint:gosec // G304: Path is from validated internal store
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading audit log: %w", err)
+	}
+	data = bytes.TrimRight(data, "\n")
+	if len(data) == 0 {
+		return "", nil
+	}
+	return hashLine(data[bytes.LastIndexByte(data, '\n')+1:]), nil
+}
+
+// hashLine returns the hex SHA-256 of an audit log line.
+func hashLine(line []byte) string {
+	sum := sha256.Sum256(line)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,111 @@
+package agent
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestFileStore_Audit(t *testing.T) {
+	t.Parallel()
+
+	store := setupTestStore(t)
+
+	entries, err := store.ReadAudit("test-wallet", "agt_abc123")
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("ReadAudit() of a missing log = %v, %v; want no entries", entries, err)
+	}
+
+	for _, e := range []*AuditEntry{
+		{Action: "tx send", Chain: chain.BSV, To: "1abc", Amount: "0.001", TxHash: "aa", Decision: DecisionAllowed},
+		{Action: "tx send", Chain: chain.BSV, To: "1def", Amount: "5", Decision: DecisionDenied, Reason: "per-tx limit"},
+	} {
+		if err := store.AppendAudit("test-wallet", "agt_abc123", e); err != nil {
+			t.Fatalf("AppendAudit() error = %v", err)
+		}
+	}
+
+	entries, err = store.ReadAudit("test-wallet", "agt_abc123")
+	if err != nil {
+		t.Fatalf("ReadAudit() error = %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("ReadAudit() returned %d entries, want 2", len(entries))
+	}
+	if entries[0].TxHash != "aa" || entries[1].Decision != DecisionDenied {
+		t.Errorf("ReadAudit() = %+v", entries)
+	}
+	if entries[0].Time.IsZero() || entries[0].Prev != "" || entries[1].Prev == "" {
+		t.Errorf("entries are not timestamped and chained: %+v", entries)
+	}
+
+	info, err := os.Stat(store.auditPath("test-wallet", "agt_abc123"))
+	if err != nil {
+		t.Fatalf("Stat() error = %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("audit log mode = %o, want 600", info.Mode().Perm())
+	}
+
+	// Deleting the agent keeps its audit log
+	if err := store.Delete("test-wallet", "agt_abc123"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if entries, _ = store.ReadAudit("test-wallet", "agt_abc123"); len(entries) != 2 {
+		t.Errorf("audit log has %d entries after Delete(), want 2", len(entries))
+	}
+}
+
+func TestFileStore_AuditTampered(t *testing.T) {
+	t.Parallel()
+
+	store := setupTestStore(t)
+	for _, amount := range []string{"1", "2", "3"} {
+		if err := store.AppendAudit("test-wallet", "agt_abc123", &AuditEntry{Action: "tx send", Amount: amount, Decision: DecisionAllowed}); err != nil {
+			t.Fatalf("AppendAudit() error = %v", err)
+		}
+	}
+	path := store.auditPath("test-wallet", "agt_abc123")
+	data, err := os.ReadFile(path) //nolint:gosec // test file
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+
+	tests := []struct {
+		name string
+		log  string
+		want int
+	}{
+		{"entry edited", lines[0] + strings.Replace(lines[1], `"amount":"2"`, `"amount":"1"`, 1) + lines[2], 2},
+		{"entry removed", lines[0] + lines[2], 1},
+		{"garbage", lines[0] + "not json\n", 1},
+	}
+	for _, tc := range tests {
+		if err := os.WriteFile(path, []byte(tc.log), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		entries, err := store.ReadAudit("test-wallet", "agt_abc123")
+		if !errors.Is(err, ErrAuditTampered) {
+			t.Errorf("%s: ReadAudit() error = %v, want ErrAuditTampered", tc.name, err)
+		}
+		if len(entries) != tc.want {
+			t.Errorf("%s: ReadAudit() returned %d entries, want %d", tc.name, len(entries), tc.want)
+		}
+	}
+}
+
+func TestFileStore_AuditInvalidPath(t *testing.T) {
+	t.Parallel()
+
+	store := setupTestStore(t)
+	if err := store.AppendAudit("../bad", "agt_1", &AuditEntry{}); !errors.Is(err, ErrInvalidWallet) {
+		t.Errorf("AppendAudit() error = %v, want ErrInvalidWallet", err)
+	}
+	if _, err := store.ReadAudit("test-wallet", "../../x"); !errors.Is(err, ErrInvalidAgentPath) {
+		t.Errorf("ReadAudit() error = %v, want ErrInvalidAgentPath", err)
+	}
+}
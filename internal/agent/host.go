@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Sentinel errors for host binding.
+var (
+	ErrHostMismatch       = errors.New("agent token is bound to another host")
+	ErrNoMachineID        = errors.New("machine ID not available")
+	ErrInvalidFingerprint = errors.New("invalid host fingerprint")
+)
+
+// hostFingerprintLength is the number of hex chars in a host fingerprint
+// (128 bits of the machine ID hash).
+const hostFingerprintLength = 32
+
+// hostFingerprintDomain separates host fingerprints from other hashes of the
+// machine ID, so the fingerprint does not reveal the ID itself.
+const hostFingerprintDomain = "sigil-agent-host:"
+
+// HostFingerprint returns the fingerprint of this machine, a hash of its
+// operating-system machine ID. It is stable across reboots and sigil
+// upgrades but differs between machines.
+func HostFingerprint() (string, error) {
+	id, err := machineID()
+	if err != nil {
+		return "", err
+	}
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", ErrNoMachineID
+	}
+	return FingerprintMachineID(id), nil
+}
+
+// FingerprintMachineID hashes a machine ID into a host fingerprint.
+func FingerprintMachineID(id string) string {
+	h := sha256.Sum256([]byte(hostFingerprintDomain + strings.ToLower(strings.TrimSpace(id))))
+	return hex.EncodeToString(h[:])[:hostFingerprintLength]
+}
+
+// ParseFingerprint validates a host fingerprint and returns it lower-cased.
+func ParseFingerprint(s string) (string, error) {
+	fp := strings.ToLower(strings.TrimSpace(s))
+	if len(fp) != hostFingerprintLength {
+		return "", fmt.Errorf("%w: want %d hex chars, got %d", ErrInvalidFingerprint, hostFingerprintLength, len(fp))
+	}
+	if _, err := hex.DecodeString(fp); err != nil {
+		return "", fmt.Errorf("%w: %q is not hex", ErrInvalidFingerprint, s)
+	}
+	return fp, nil
+}
+
+// CheckHost rejects use of a host-bound credential from a machine whose
+// fingerprint differs from the bound one. Unbound credentials pass.
+func CheckHost(cred *Credential, fingerprint string) error {
+	bound := cred.Policy.HostFingerprint
+	if bound == "" {
+		return nil
+	}
+	if subtle.ConstantTimeCompare([]byte(bound), []byte(strings.ToLower(fingerprint))) != 1 {
+		return fmt.Errorf("%w: agent %s is bound to host %s", ErrHostMismatch, cred.ID, bound)
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+//go:build darwin
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"time"
+)
+
+// platformUUIDPattern extracts IOPlatformUUID from ioreg output.
+//
+//nolint:gochecknoglobals // Compiled once, read-only
+var platformUUIDPattern = regexp.MustCompile(`"IOPlatformUUID" = "([0-9A-Fa-f-]+)"`)
+
+// machineID returns the hardware platform UUID reported by ioreg.
+func machineID() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, "ioreg", "-rd1", "-c", "IOPlatformExpertDevice").Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNoMachineID, err)
+	}
+	m := platformUUIDPattern.FindSubmatch(out)
+	if m == nil {
+		return "", ErrNoMachineID
+	}
+	return string(m[1]), nil
+}
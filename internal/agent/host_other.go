@@ -0,0 +1,32 @@
+//go:build !darwin && !windows
+
+package agent
+
+import (
+	"os"
+	"strings"
+)
+
+// machineIDPaths are the files that hold the machine ID, in order of
+// preference: systemd, D-Bus, and the BSD host ID.
+//
+//nolint:gochecknoglobals // Fixed list of well-known system paths
+var machineIDPaths = []string{
+	"/etc/machine-id",
+	"/var/lib/dbus/machine-id",
+	"/etc/hostid",
+}
+
+// machineID reads the machine ID from the first of machineIDPaths present.
+func machineID() (string, error) {
+	for _, path := range machineIDPaths {
+		data, err := os.ReadFile(path) //nolint:gosec // G304: fixed system paths
+		if err != nil {
+			continue
+		}
+		if id := strings.TrimSpace(string(data)); id != "" {
+			return id, nil
+		}
+	}
+	return "", ErrNoMachineID
+}
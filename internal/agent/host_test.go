@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestFingerprintMachineID(t *testing.T) {
+	t.Parallel()
+
+	fp := FingerprintMachineID("4c4c4544-0032-3410-8051-b4c04f4e3332")
+	if len(fp) != hostFingerprintLength {
+		t.Fatalf("len = %d, want %d", len(fp), hostFingerprintLength)
+	}
+	if strings.Contains(fp, "4c4c4544") {
+		t.Error("fingerprint leaks the machine ID")
+	}
+	if got := FingerprintMachineID(" 4C4C4544-0032-3410-8051-B4C04F4E3332\n"); got != fp {
+		t.Errorf("case and whitespace changed the fingerprint: %s != %s", got, fp)
+	}
+	if FingerprintMachineID("another-machine") == fp {
+		t.Error("different machines share a fingerprint")
+	}
+}
+
+func TestParseFingerprint(t *testing.T) {
+	t.Parallel()
+
+	fp := FingerprintMachineID("machine")
+	got, err := ParseFingerprint(" " + strings.ToUpper(fp) + " ")
+	if err != nil {
+		t.Fatalf("ParseFingerprint() error = %v", err)
+	}
+	if got != fp {
+		t.Errorf("ParseFingerprint() = %s, want %s", got, fp)
+	}
+
+	for _, bad := range []string{"", "abc", strings.Repeat("z", hostFingerprintLength), fp + "00"} {
+		if _, err := ParseFingerprint(bad); !errors.Is(err, ErrInvalidFingerprint) {
+			t.Errorf("ParseFingerprint(%q) error = %v, want ErrInvalidFingerprint", bad, err)
+		}
+	}
+}
+
+func TestCheckHost(t *testing.T) {
+	t.Parallel()
+
+	bound := FingerprintMachineID("server")
+	cred := &Credential{ID: "agt_test", Policy: Policy{HostFingerprint: bound}}
+
+	if err := CheckHost(cred, bound); err != nil {
+		t.Errorf("CheckHost() on the bound host error = %v", err)
+	}
+	if err := CheckHost(cred, strings.ToUpper(bound)); err != nil {
+		t.Errorf("CheckHost() rejected an upper-case fingerprint: %v", err)
+	}
+	if err := CheckHost(cred, FingerprintMachineID("laptop")); !errors.Is(err, ErrHostMismatch) {
+		t.Errorf("CheckHost() on another host error = %v, want ErrHostMismatch", err)
+	}
+	if err := CheckHost(&Credential{ID: "agt_any"}, FingerprintMachineID("laptop")); err != nil {
+		t.Errorf("CheckHost() on an unbound agent error = %v", err)
+	}
+}
+
+func TestHostBindingCoveredByPolicyHMAC(t *testing.T) {
+	t.Parallel()
+
+	token := "sigil_agt_testtoken" //nolint:gosec // G101: Test token
+	policy := &Policy{MaxPerTxSat: 50000, HostFingerprint: FingerprintMachineID("server")}
+	hmacStr, err := ComputePolicyHMAC(policy, token)
+	if err != nil {
+		t.Fatalf("ComputePolicyHMAC() error = %v", err)
+	}
+
+	policy.HostFingerprint = ""
+	valid, err := VerifyPolicyHMAC(policy, token, hmacStr)
+	if err != nil {
+		t.Fatalf("VerifyPolicyHMAC() error = %v", err)
+	}
+	if valid {
+		t.Error("removing the host binding did not break the policy HMAC")
+	}
+}
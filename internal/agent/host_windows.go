@@ -0,0 +1,24 @@
+//go:build windows
+
+package agent
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/windows/registry"
+)
+
+// machineID returns the MachineGuid set when Windows was installed.
+func machineID() (string, error) {
+	key, err := registry.OpenKey(registry.LOCAL_MACHINE, `SOFTWARE\Microsoft\Cryptography`, registry.QUERY_VALUE|registry.WOW64_64KEY)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNoMachineID, err)
+	}
+	defer func() { _ = key.Close() }()
+
+	id, _, err := key.GetStringValue("MachineGuid")
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrNoMachineID, err)
+	}
+	return id, nil
+}
@@ -0,0 +1,412 @@
+package agent
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// counterFilePermissions is the permission mode for counter files.
+	counterFilePermissions = 0o600
+
+	// ledgerVersion is the current format of the spend ledger. Version 1
+	// was a per-day counter.
+	ledgerVersion = 2
+)
+
+// DailyWindow is the rolling window the daily limits are counted over: a
+// send is allowed when it and every spend in the window before it stay
+// within the limit.
+const DailyWindow = 24 * time.Hour
+
+// ErrCounterTampered indicates a counter file was found but its integrity check failed.
+// This may indicate tampering and causes the counter to be treated as at-limit (deny).
+var ErrCounterTampered = fmt.Errorf("daily counter integrity check failed: possible tampering")
+
+// Spend is one send recorded in an agent's spend ledger. Amount is in the
+// smallest unit of the asset sent.
+type Spend struct {
+	Time   time.Time `json:"time"`
+	Chain  chain.ID  `json:"chain"`
+	Amount string    `json:"amount"`
+	// Token is the ERC-20 contract sent, or "" for the native coin.
+	Token string `json:"token,omitempty"`
+	// To is the destination, or "" when the send paid several recipients
+	// or was recorded before destinations were.
+	To string `json:"to,omitempty"`
+}
+
+// spendLedger is the on-disk spend ledger of an agent: its spends within
+// DailyWindow, signed with an HMAC keyed by the agent's token.
+type spendLedger struct {
+	Version int     `json:"version"`
+	Spends  []Spend `json:"spends"`
+	HMAC    string  `json:"hmac"`
+
+	// tampered marks a ledger that could not be read or failed its
+	// integrity check. It denies every spend against a daily limit.
+	tampered bool
+	// legacy is the version 1 counter the ledger was read from, whose HMAC
+	// covers its own fields.
+	legacy *legacyCounter
+}
+
+// legacyCounter is the version 1 counter: one day's UTC totals.
+type legacyCounter struct {
+	Date     string `json:"date"`
+	SpentSat uint64 `json:"spent_sat"`
+	SpentWei string `json:"spent_wei"`
+	HMAC     string `json:"hmac"`
+}
+
+// DailySpend is what an agent spent in the DailyWindow before a time.
+type DailySpend struct {
+	// SpentSat is spent on BSV.
+	SpentSat uint64
+	// SpentWei is spent on ETH. Token sends count only toward the policy
+	// rules of their token.
+	SpentWei *big.Int
+	// ReleaseSat and ReleaseWei are when the oldest spend in the window
+	// leaves it, freeing allowance, or zero when nothing was spent.
+	ReleaseSat time.Time
+	ReleaseWei time.Time
+	// Tampered is set when the ledger failed its integrity check. Every
+	// spend against a daily limit is denied until the agent is replaced.
+	Tampered bool
+}
+
+// Allowance is an agent's daily allowance in one limit unit.
+type Allowance struct {
+	Unit string
+	// MaxDaily is the daily limit, nil when unlimited.
+	MaxDaily *big.Int
+	// Spent is what was sent in the DailyWindow.
+	Spent *big.Int
+	// Remaining is what may still be sent now, nil when unlimited.
+	Remaining *big.Int
+	// NextRelease is when the oldest spend in the window leaves it, or zero.
+	NextRelease time.Time
+}
+
+// Allowances returns the agent's allowance in each limit unit that has a
+// daily limit or spends. A tampered ledger leaves nothing to spend.
+func (d *DailySpend) Allowances(policy *Policy) []Allowance {
+	units := []Allowance{
+		{Unit: LimitUnitSat, MaxDaily: satLimit(policy.MaxDailySat), Spent: new(big.Int).SetUint64(d.SpentSat), NextRelease: d.ReleaseSat},
+		{Unit: LimitUnitWei, MaxDaily: policy.MaxDailyWeiBig(), Spent: d.SpentWei, NextRelease: d.ReleaseWei},
+	}
+	list := make([]Allowance, 0, len(units))
+	for _, a := range units {
+		if d.Tampered {
+			a.Spent, a.NextRelease = new(big.Int), time.Time{}
+		}
+		if a.MaxDaily == nil && a.Spent.Sign() == 0 {
+			continue
+		}
+		if a.MaxDaily != nil {
+			a.Remaining = new(big.Int).Sub(a.MaxDaily, a.Spent)
+			if d.Tampered || a.Remaining.Sign() < 0 {
+				a.Remaining.SetInt64(0)
+			}
+		}
+		list = append(list, a)
+	}
+	return list
+}
+
+// CheckDailyLimit checks if a spend of amountSmallest would take the agent
+// over its daily limit for chainID, counting every spend in the DailyWindow
+// before now. counterPath is the agent's ledger, and token verifies its
+// HMAC.
+func CheckDailyLimit(counterPath, token string, cred *Credential, chainID chain.ID, amountSmallest *big.Int) error {
+	policy := &cred.Policy
+	if !HasLimits(chainID) {
+		return fmt.Errorf("%w: %q has no agent spending limits", ErrChainDenied, chainID)
+	}
+	limit, unit := satLimit(policy.MaxDailySat), LimitUnitSat
+	if chainID == chain.ETH {
+		limit, unit = policy.MaxDailyWeiBig(), LimitUnitWei
+	}
+	if limit == nil {
+		return nil // No daily limit
+	}
+
+	now := time.Now()
+	ledger := loadLedger(counterPath, token)
+	if ledger.tampered {
+		return fmt.Errorf("%w: %w", ErrDailyLimitExceed, ErrCounterTampered)
+	}
+	spent := ledger.summarize(now)
+	spentUnit, release := new(big.Int).SetUint64(spent.SpentSat), spent.ReleaseSat
+	if unit == LimitUnitWei {
+		spentUnit, release = spent.SpentWei, spent.ReleaseWei
+	}
+
+	newTotal := new(big.Int).Add(spentUnit, amountSmallest)
+	if newTotal.Cmp(limit) <= 0 {
+		return nil
+	}
+	remaining := new(big.Int).Sub(limit, spentUnit)
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+	msg := fmt.Sprintf("%s %s would exceed limit of %s %s (spent in the last 24h: %s %s, remaining: %s %s)",
+		amountSmallest, unit, limit, unit, spentUnit, unit, remaining, unit)
+	if !release.IsZero() {
+		msg += fmt.Sprintf("; more is allowed from %s", release.UTC().Format(time.RFC3339))
+	}
+	return fmt.Errorf("%w: %s", ErrDailyLimitExceed, msg)
+}
+
+// RecordSpend records a completed spend in the agent's ledger, dropping
+// spends that have left the DailyWindow.
+func RecordSpend(counterPath, token string, chainID chain.ID, amountSmallest *big.Int) error {
+	return RecordAssetSpend(counterPath, token, Spend{Chain: chainID, Amount: amountSmallest.String()})
+}
+
+// RecordAssetSpend records a completed spend, with the asset and destination
+// the policy rules count it by, in the agent's ledger. The spend is stamped
+// with the current time.
+func RecordAssetSpend(counterPath, token string, spend Spend) error {
+	if counterPath == "" {
+		return nil
+	}
+	ledger := loadLedger(counterPath, token)
+	if ledger.tampered {
+		// Keep the evidence; the ledger already denies every limited spend
+		return ErrCounterTampered
+	}
+	spend.Time = time.Now().UTC()
+	ledger.prune(spend.Time)
+	ledger.Spends = append(ledger.Spends, spend)
+	return saveLedger(counterPath, token, ledger)
+}
+
+// GetDailySpent returns what an agent spent in the DailyWindow before now.
+// A ledger that fails verification with token reports the spends as maxed.
+func GetDailySpent(counterPath, token string) (satSpent uint64, weiSpent string) {
+	ledger := loadLedger(counterPath, token)
+	if ledger.tampered {
+		return ^uint64(0), "999999999999999999999999999999999999"
+	}
+	spent := ledger.summarize(time.Now())
+	if spent.SpentWei.Sign() > 0 {
+		weiSpent = spent.SpentWei.String()
+	}
+	return spent.SpentSat, weiSpent
+}
+
+// PeekDailySpent returns what an agent spent in the DailyWindow before now
+// without verifying the ledger's HMAC, which needs the agent's token. It is
+// for display to the wallet owner; limits are enforced with CheckDailyLimit.
+func PeekDailySpent(counterPath string, now time.Time) *DailySpend {
+	ledger, err := readLedger(counterPath)
+	if err != nil {
+		return &DailySpend{SpentWei: new(big.Int), Tampered: true}
+	}
+	return ledger.summarize(now)
+}
+
+// PeekSpends returns the spends in an agent's ledger without verifying its
+// HMAC, for display to the wallet owner. tampered is set when the ledger
+// cannot be read.
+func PeekSpends(counterPath string) (spends []Spend, tampered bool) {
+	ledger, err := readLedger(counterPath)
+	if err != nil || ledger.tampered {
+		return nil, true
+	}
+	return ledger.Spends, false
+}
+
+// summarize sums the native coin spends in the DailyWindow before now.
+// Token amounts are in the token's units, so they are left out.
+func (l *spendLedger) summarize(now time.Time) *DailySpend {
+	spent := &DailySpend{SpentWei: new(big.Int)}
+	since := now.Add(-DailyWindow)
+	sat := new(big.Int)
+	for _, sp := range l.Spends {
+		if !sp.Time.After(since) || sp.Token != "" {
+			continue
+		}
+		amount, ok := new(big.Int).SetString(sp.Amount, 10)
+		if !ok {
+			continue
+		}
+		release := sp.Time.Add(DailyWindow)
+		switch {
+		case sp.Chain == chain.ETH:
+			spent.SpentWei.Add(spent.SpentWei, amount)
+			spent.ReleaseWei = earliest(spent.ReleaseWei, release)
+		case sp.Chain == chain.BSV:
+			sat.Add(sat, amount)
+			spent.ReleaseSat = earliest(spent.ReleaseSat, release)
+		}
+	}
+	if sat.IsUint64() {
+		spent.SpentSat = sat.Uint64()
+	} else {
+		spent.SpentSat = ^uint64(0)
+	}
+	return spent
+}
+
+// prune drops the spends that have left the DailyWindow before now.
+func (l *spendLedger) prune(now time.Time) {
+	since := now.Add(-DailyWindow)
+	kept := l.Spends[:0]
+	for _, sp := range l.Spends {
+		if sp.Time.After(since) {
+			kept = append(kept, sp)
+		}
+	}
+	l.Spends = kept
+}
+
+// loadLedger loads and verifies an agent's ledger. A missing file, or an
+// empty path, is an empty ledger.
+//
+// Security: If the file exists but cannot be read, does not parse, or fails
+// its HMAC check, the ledger is marked tampered and denies every spend
+// against a daily limit. Deleting the file resets the limits, as it did for
+// the daily counter; the agents directory is only writable by the owner.
+func loadLedger(counterPath, token string) *spendLedger {
+	ledger, err := readLedger(counterPath)
+	if err != nil || ledger.tampered {
+		return &spendLedger{Version: ledgerVersion, tampered: true}
+	}
+	if ledger.HMAC == "" && len(ledger.Spends) == 0 {
+		return ledger // Missing file
+	}
+	if !hmac.Equal([]byte(computeLedgerHMAC(ledger, token)), []byte(ledger.HMAC)) {
+		return &spendLedger{Version: ledgerVersion, tampered: true}
+	}
+	return ledger
+}
+
+// readLedger reads an agent's ledger without verifying it, converting a
+// version 1 counter. A legacy counter whose HMAC fails is marked tampered.
+func readLedger(counterPath string) (*spendLedger, error) {
+	empty := &spendLedger{Version: ledgerVersion}
+	if counterPath == "" {
+		return empty, nil
+	}
+
+	//nolint:gosec // G304: Path is from validated internal store
+	data, err := os.ReadFile(counterPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return empty, nil
+		}
+		return nil, fmt.Errorf("reading spend ledger: %w", err)
+	}
+
+	var ledger spendLedger
+	if err := json.Unmarshal(data, &ledger); err != nil {
+		return nil, fmt.Errorf("parsing spend ledger: %w", err)
+	}
+	switch {
+	case ledger.Version == 0:
+		return convertLegacyCounter(counterPath, data)
+	case ledger.Version > ledgerVersion:
+		return nil, fmt.Errorf("%w: spend ledger version %d is newer than supported", ErrCounterTampered, ledger.Version)
+	}
+	return &ledger, nil
+}
+
+// convertLegacyCounter reads a version 1 counter as a ledger. The day's
+// totals count as spends at the time the file was last written, the most
+// recent they could have been made. A counter from before today has no
+// spends left in the DailyWindow that its day did not already allow.
+func convertLegacyCounter(counterPath string, data []byte) (*spendLedger, error) {
+	var legacy legacyCounter
+	if err := json.Unmarshal(data, &legacy); err != nil {
+		return nil, fmt.Errorf("parsing daily counter: %w", err)
+	}
+	info, err := os.Stat(counterPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading daily counter: %w", err)
+	}
+	at := info.ModTime().UTC()
+	if at.Format("2006-01-02") != legacy.Date {
+		// Written on another day than it counts: only trust the end of it
+		day, parseErr := time.Parse("2006-01-02", legacy.Date)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parsing daily counter: %w", parseErr)
+		}
+		at = day.Add(24*time.Hour - time.Second)
+	}
+
+	ledger := &spendLedger{Version: ledgerVersion, HMAC: legacy.HMAC}
+	if legacy.SpentSat > 0 {
+		ledger.Spends = append(ledger.Spends, Spend{Time: at, Chain: chain.BSV, Amount: fmt.Sprint(legacy.SpentSat)})
+	}
+	if legacy.SpentWei != "" && legacy.SpentWei != "0" {
+		ledger.Spends = append(ledger.Spends, Spend{Time: at, Chain: chain.ETH, Amount: legacy.SpentWei})
+	}
+	// The legacy HMAC covers the legacy fields; keep them for verification
+	ledger.legacy = &legacy
+	return ledger, nil
+}
+
+// saveLedger writes the ledger to disk with its HMAC.
+func saveLedger(counterPath, token string, ledger *spendLedger) error {
+	ledger.Version = ledgerVersion
+	ledger.legacy = nil
+	ledger.HMAC = computeLedgerHMAC(ledger, token)
+
+	data, err := json.MarshalIndent(ledger, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling spend ledger: %w", err)
+	}
+	return fileutil.WriteAtomic(counterPath, data, counterFilePermissions)
+}
+
+// computeLedgerHMAC computes the HMAC of a ledger's spends, or of the
+// legacy counter it was read from.
+func computeLedgerHMAC(ledger *spendLedger, token string) string {
+	var payload string
+	if ledger.legacy != nil {
+		payload = fmt.Sprintf("%s:%d:%s", ledger.legacy.Date, ledger.legacy.SpentSat, ledger.legacy.SpentWei)
+	} else {
+		parts := make([]string, 0, len(ledger.Spends)+1)
+		parts = append(parts, fmt.Sprint(ledger.Version))
+		for _, sp := range ledger.Spends {
+			part := fmt.Sprintf("%d:%s:%s", sp.Time.UnixNano(), sp.Chain, sp.Amount)
+			if sp.Token != "" || sp.To != "" {
+				// Left out when empty, so earlier ledgers still verify
+				part += fmt.Sprintf(":%s:%s", sp.Token, sp.To)
+			}
+			parts = append(parts, part)
+		}
+		payload = strings.Join(parts, "|")
+	}
+	mac := hmac.New(sha256.New, []byte(token))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// HasLimits reports whether agent spending limits can be set for chainID:
+// the satoshi limits are for BSV and the wei limits for ETH. A satoshi of
+// another chain is worth something else, so its sends are not counted
+// against the BSV limits, and agents are not allowed to make them.
+func HasLimits(chainID chain.ID) bool {
+	return chainID == chain.BSV || chainID == chain.ETH
+}
+
+// earliest returns the earlier of t and u, treating zero as unset.
+func earliest(t, u time.Time) time.Time {
+	if t.IsZero() || u.Before(t) {
+		return u
+	}
+	return t
+}
@@ -0,0 +1,190 @@
+package agent
+
+import (
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// writeTestLedger saves a signed ledger holding spends.
+func writeTestLedger(t *testing.T, path, token string, spends ...Spend) {
+	t.Helper()
+	if err := saveLedger(path, token, &spendLedger{Spends: spends}); err != nil {
+		t.Fatalf("saveLedger() error: %v", err)
+	}
+}
+
+func TestCheckDailyLimit_RollingWindow(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	counterPath := filepath.Join(dir, "rolling.counter")
+	token := "rolling-token"
+	cred := &Credential{Policy: Policy{MaxDailySat: 100000}}
+	now := time.Now().UTC()
+
+	// 60000 sat a day ago has left the window; 30000 an hour ago has not.
+	// BTC satoshis do not count toward the BSV limit.
+	writeTestLedger(t, counterPath, token,
+		Spend{Time: now.Add(-DailyWindow - time.Minute), Chain: chain.BSV, Amount: "60000"},
+		Spend{Time: now.Add(-time.Hour), Chain: chain.BSV, Amount: "30000"},
+		Spend{Time: now.Add(-time.Hour), Chain: chain.BTC, Amount: "50000"},
+	)
+
+	if err := CheckDailyLimit(counterPath, token, cred, chain.BSV, big.NewInt(70000)); err != nil {
+		t.Errorf("CheckDailyLimit() error within the window's allowance: %v", err)
+	}
+	err := CheckDailyLimit(counterPath, token, cred, chain.BSV, big.NewInt(70001))
+	if !errors.Is(err, ErrDailyLimitExceed) {
+		t.Fatalf("CheckDailyLimit() error = %v, want ErrDailyLimitExceed", err)
+	}
+	for _, id := range []chain.ID{chain.BTC, chain.BCH, chain.LTC} {
+		if err := CheckDailyLimit(counterPath, token, cred, id, big.NewInt(1)); !errors.Is(err, ErrChainDenied) {
+			t.Errorf("CheckDailyLimit(%s) error = %v, want ErrChainDenied", id, err)
+		}
+	}
+
+	spent := PeekDailySpent(counterPath, now)
+	if spent.SpentSat != 30000 || spent.Tampered {
+		t.Errorf("PeekDailySpent() = %+v, want 30000 sat", spent)
+	}
+	if want := now.Add(-time.Hour).Add(DailyWindow); !spent.ReleaseSat.Equal(want) {
+		t.Errorf("ReleaseSat = %v, want %v", spent.ReleaseSat, want)
+	}
+	if !spent.ReleaseWei.IsZero() {
+		t.Errorf("ReleaseWei = %v, want zero without ETH spends", spent.ReleaseWei)
+	}
+
+	// Recording prunes the expired spend
+	if err := RecordSpend(counterPath, token, chain.BSV, big.NewInt(1000)); err != nil {
+		t.Fatalf("RecordSpend() error: %v", err)
+	}
+	ledger := loadLedger(counterPath, token)
+	if ledger.tampered || len(ledger.Spends) != 3 {
+		t.Errorf("ledger = %+v, want the three spends in the window", ledger)
+	}
+}
+
+func TestCheckDailyLimit_TokenSpends(t *testing.T) {
+	t.Parallel()
+
+	counterPath := filepath.Join(t.TempDir(), "token.counter")
+	token := "token-token"
+	cred := &Credential{Policy: Policy{MaxDailyWei: "1000"}}
+	now := time.Now().UTC()
+
+	// A token spend is in the token's units and does not count as wei
+	writeTestLedger(t, counterPath, token,
+		Spend{Time: now.Add(-time.Hour), Chain: chain.ETH, Token: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", Amount: "5000000"},
+		Spend{Time: now.Add(-time.Hour), Chain: chain.ETH, Amount: "400"},
+	)
+
+	if err := CheckDailyLimit(counterPath, token, cred, chain.ETH, big.NewInt(600)); err != nil {
+		t.Errorf("CheckDailyLimit() error with token spends in the window: %v", err)
+	}
+	spent := PeekDailySpent(counterPath, now)
+	if spent.SpentWei.Cmp(big.NewInt(400)) != 0 {
+		t.Errorf("SpentWei = %s, want 400", spent.SpentWei)
+	}
+}
+
+func TestLoadLedger_TamperDetection(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	counterPath := filepath.Join(dir, "tamper.counter")
+	token := "tamper-token"
+	cred := &Credential{Policy: Policy{MaxDailySat: 100000}}
+
+	if err := RecordSpend(counterPath, token, chain.BSV, big.NewInt(90000)); err != nil {
+		t.Fatalf("RecordSpend() error: %v", err)
+	}
+
+	// A wrong token fails verification
+	if ledger := loadLedger(counterPath, "other-token"); !ledger.tampered {
+		t.Error("ledger verified with the wrong token")
+	}
+
+	// Dropping the spend breaks the HMAC and denies every limited spend
+	writeTestLedger(t, counterPath, "forged-token")
+	err := CheckDailyLimit(counterPath, token, cred, chain.BSV, big.NewInt(1))
+	if !errors.Is(err, ErrDailyLimitExceed) || !errors.Is(err, ErrCounterTampered) {
+		t.Errorf("CheckDailyLimit() error = %v, want ErrDailyLimitExceed and ErrCounterTampered", err)
+	}
+	if sat, _ := GetDailySpent(counterPath, token); sat != ^uint64(0) {
+		t.Errorf("GetDailySpent() sat = %d, want maxed", sat)
+	}
+	if err := RecordSpend(counterPath, token, chain.BSV, big.NewInt(1)); !errors.Is(err, ErrCounterTampered) {
+		t.Errorf("RecordSpend() error = %v, want ErrCounterTampered", err)
+	}
+
+	// Unparseable and newer ledgers deny too
+	for _, data := range []string{"not json", `{"version":99,"spends":[],"hmac":""}`} {
+		_ = os.WriteFile(counterPath, []byte(data), 0o600)
+		if ledger := loadLedger(counterPath, token); !ledger.tampered {
+			t.Errorf("ledger %q not treated as tampered", data)
+		}
+		if spent := PeekDailySpent(counterPath, time.Now()); !spent.Tampered {
+			t.Errorf("PeekDailySpent(%q) not tampered", data)
+		}
+	}
+}
+
+func TestLoadLedger_LegacyCounter(t *testing.T) {
+	t.Parallel()
+
+	token := "legacy-token"
+	now := time.Now().UTC()
+	legacy := func(t *testing.T, date string, sat uint64, wei, mac string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "legacy.counter")
+		lc := &legacyCounter{Date: date, SpentSat: sat, SpentWei: wei}
+		if mac == "" {
+			mac = computeLedgerHMAC(&spendLedger{legacy: lc}, token)
+		}
+		data := `{"date":"` + date + `","spent_sat":` + big.NewInt(int64(sat)).String() +
+			`,"spent_wei":"` + wei + `","hmac":"` + mac + `"}`
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatalf("writing counter: %v", err)
+		}
+		return path
+	}
+
+	t.Run("today", func(t *testing.T) {
+		t.Parallel()
+		path := legacy(t, now.Format("2006-01-02"), 40000, "5000", "")
+		sat, wei := GetDailySpent(path, token)
+		if sat != 40000 || wei != "5000" {
+			t.Errorf("GetDailySpent() = %d, %q; want 40000, 5000", sat, wei)
+		}
+		// Recording rewrites the counter as a ledger
+		if err := RecordSpend(path, token, chain.BSV, big.NewInt(1000)); err != nil {
+			t.Fatalf("RecordSpend() error: %v", err)
+		}
+		ledger := loadLedger(path, token)
+		if ledger.tampered || ledger.Version != ledgerVersion || len(ledger.Spends) != 3 {
+			t.Errorf("migrated ledger = %+v", ledger)
+		}
+	})
+
+	t.Run("old day", func(t *testing.T) {
+		t.Parallel()
+		path := legacy(t, now.Add(-3*DailyWindow).Format("2006-01-02"), 40000, "", "")
+		if sat, _ := GetDailySpent(path, token); sat != 0 {
+			t.Errorf("GetDailySpent() sat = %d, want 0 for an old counter", sat)
+		}
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		t.Parallel()
+		path := legacy(t, now.Format("2006-01-02"), 999999, "", "fake")
+		if ledger := loadLedger(path, token); !ledger.tampered {
+			t.Error("legacy counter with a bad HMAC not treated as tampered")
+		}
+	})
+}
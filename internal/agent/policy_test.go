@@ -1,11 +1,11 @@
 package agent
 
 import (
+	"errors"
 	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
-	"time"
 
 	"github.com/mrz1836/sigil/internal/chain"
 )
@@ -31,6 +31,22 @@ func TestValidateTransaction_ChainAuth(t *testing.T) {
 	}
 }
 
+func TestValidateTransaction_ChainWithoutLimits(t *testing.T) {
+	t.Parallel()
+
+	// The satoshi limits are for BSV, so other UTXO chains are refused
+	cred := &Credential{
+		Chains: []chain.ID{chain.BSV, chain.BTC, chain.BCH, chain.LTC},
+		Policy: Policy{MaxPerTxSat: 1000},
+	}
+	for _, id := range []chain.ID{chain.BTC, chain.BCH, chain.LTC} {
+		err := ValidateTransaction(cred, id, "1ABC", big.NewInt(1))
+		if !errors.Is(err, ErrChainDenied) {
+			t.Errorf("ValidateTransaction(%s) error = %v, want ErrChainDenied", id, err)
+		}
+	}
+}
+
 func TestValidateTransaction_AddressAllowlist(t *testing.T) {
 	t.Parallel()
 
@@ -54,6 +70,29 @@ func TestValidateTransaction_AddressAllowlist(t *testing.T) {
 	}
 }
 
+func TestValidateTransaction_AddressAllowlist_ETHCaseInsensitive(t *testing.T) {
+	t.Parallel()
+
+	cred := &Credential{
+		Chains: []chain.ID{chain.ETH, chain.BSV},
+		Policy: Policy{
+			AllowedAddrs: []string{"0x742d35cc6634c0532925a3b844bc454e4438f44e", "1allowed"},
+		},
+	}
+
+	// A checksummed recipient matches a lowercase allowlist entry
+	err := ValidateTransaction(cred, chain.ETH, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", big.NewInt(1000))
+	if err != nil {
+		t.Errorf("ValidateTransaction() error for checksummed ETH address: %v", err)
+	}
+
+	// BSV addresses are case-sensitive
+	err = ValidateTransaction(cred, chain.BSV, "1ALLOWED", big.NewInt(1000))
+	if err == nil {
+		t.Error("ValidateTransaction() expected error for BSV address with different case")
+	}
+}
+
 func TestValidateTransaction_EmptyAllowlist(t *testing.T) {
 	t.Parallel()
 
@@ -326,91 +365,71 @@ func TestGetDailySpent_NoCounter(t *testing.T) {
 	}
 }
 
-func TestLoadCounter_DailyReset(t *testing.T) {
+func TestCheckAsset(t *testing.T) {
 	t.Parallel()
 
-	dir := t.TempDir()
-	counterPath := filepath.Join(dir, "reset.counter")
-	token := "reset-token"
-
-	// Record a spend
-	if err := RecordSpend(counterPath, token, chain.BSV, big.NewInt(50000)); err != nil {
-		t.Fatalf("RecordSpend() error: %v", err)
+	const usdc = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	cred := &Credential{
+		Chains: []chain.ID{chain.BSV, chain.ETH},
+		Policy: Policy{AllowedAssets: []string{"bsv", usdc}},
 	}
 
-	// Verify spend was recorded
-	sat, _ := GetDailySpent(counterPath, token)
-	if sat != 50000 {
-		t.Fatalf("GetDailySpent() sat = %d, want 50000", sat)
+	tests := []struct {
+		name    string
+		chainID chain.ID
+		token   string
+		allowed bool
+	}{
+		{"native bsv", chain.BSV, "", true},
+		{"native eth", chain.ETH, "", false},
+		{"usdc", chain.ETH, usdc, true},
+		{"usdc lowercase", chain.ETH, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", true},
+		{"other token", chain.ETH, "0xdAC17F958D2ee523a2206206994597C13D831ec7", false},
 	}
 
-	// The counter uses todayDate(), so we can't easily test date rollover
-	// without mocking time. Instead, verify the counter loads with today's date.
-	counter := loadCounter(counterPath, token)
-	if counter.Date != todayDate() {
-		t.Errorf("counter.Date = %q, want %q", counter.Date, todayDate())
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckAsset(cred, tc.chainID, tc.token)
+			if tc.allowed && err != nil {
+				t.Errorf("CheckAsset() unexpected error: %v", err)
+			}
+			if !tc.allowed && !errors.Is(err, ErrAssetDenied) {
+				t.Errorf("CheckAsset() error = %v, want ErrAssetDenied", err)
+			}
+		})
 	}
 }
 
-func TestCounterHMAC_TamperDetection(t *testing.T) {
+func TestCheckAsset_EmptyAllowlist(t *testing.T) {
 	t.Parallel()
 
-	dir := t.TempDir()
-	counterPath := filepath.Join(dir, "tamper.counter")
-	token := "tamper-token"
-
-	// Record a spend
-	if err := RecordSpend(counterPath, token, chain.BSV, big.NewInt(10000)); err != nil {
-		t.Fatalf("RecordSpend() error: %v", err)
+	cred := &Credential{Chains: []chain.ID{chain.ETH}}
+	if err := CheckAsset(cred, chain.ETH, ""); err != nil {
+		t.Errorf("CheckAsset() error with empty allowlist: %v", err)
 	}
-
-	// Tamper with the counter file - change spent amount
-	tampered := []byte(`{"date":"` + todayDate() + `","spent_sat":999999,"spent_wei":"","hmac":"fake"}`)
-	_ = os.WriteFile(counterPath, tampered, 0o600)
-
-	// Loading with wrong HMAC should max out the counter to block further spending
-	counter := loadCounter(counterPath, token)
-	if counter.SpentSat != ^uint64(0) {
-		t.Errorf("tampered counter should be maxed out (deny spending), got %d", counter.SpentSat)
+	if err := CheckAsset(cred, chain.ETH, "0xdAC17F958D2ee523a2206206994597C13D831ec7"); err != nil {
+		t.Errorf("CheckAsset() error with empty allowlist: %v", err)
 	}
 }
 
-func TestTodayDate(t *testing.T) {
+func TestComputePolicyHMAC_AllowedAssets(t *testing.T) {
 	t.Parallel()
 
-	date := todayDate()
-	if len(date) != 10 {
-		t.Errorf("todayDate() = %q, expected YYYY-MM-DD format", date)
-	}
+	token := "sigil_agt_test"
+	restricted := &Policy{AllowedAssets: []string{"bsv"}}
+	open := &Policy{}
 
-	// Should match Go's UTC date
-	expected := time.Now().UTC().Format("2006-01-02")
-	if date != expected {
-		t.Errorf("todayDate() = %q, want %q", date, expected)
+	a, err := ComputePolicyHMAC(restricted, token)
+	if err != nil {
+		t.Fatalf("ComputePolicyHMAC() error = %v", err)
 	}
-}
-
-func TestDailyCounter_SpentWeiBig(t *testing.T) {
-	t.Parallel()
-
-	tests := []struct {
-		name     string
-		spentWei string
-		want     int64
-	}{
-		{"empty", "", 0},
-		{"zero", "0", 0},
-		{"valid", "1000000000000000", 1000000000000000},
-		{"invalid", "not-a-number", 0},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			dc := &DailyCounter{SpentWei: tt.spentWei}
-			got := dc.spentWeiBig()
-			if got.Int64() != tt.want {
-				t.Errorf("spentWeiBig() = %v, want %v", got, tt.want)
-			}
-		})
+	b, err := ComputePolicyHMAC(open, token)
+	if err != nil {
+		t.Fatalf("ComputePolicyHMAC() error = %v", err)
+	}
+	if a == b {
+		t.Error("removing the asset allowlist must change the policy HMAC")
 	}
 }
@@ -0,0 +1,320 @@
+package agent
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/txjournal"
+)
+
+// Anomaly kinds flagged by a report.
+const (
+	// AnomalyBurst flags BurstSends or more sends within BurstWindow.
+	AnomalyBurst = "burst"
+	// AnomalyNewDestination flags a send to an address the wallet had never
+	// sent to before.
+	AnomalyNewDestination = "new_destination"
+)
+
+// Burst detection thresholds.
+const (
+	BurstWindow = 10 * time.Minute
+	BurstSends  = 5
+)
+
+// Limit units of a report's limit usage.
+const (
+	LimitUnitSat = "sat" // BSV
+	LimitUnitWei = "wei"
+)
+
+// Report summarizes what an agent did over a period, for periodic review by
+// the wallet owner.
+type Report struct {
+	Since time.Time
+	Until time.Time
+
+	// Transactions are the agent's sends in the period, oldest first.
+	Transactions []ReportTx
+	// Totals are the amounts sent per chain and asset, excluding reverted
+	// transactions.
+	Totals []AssetTotal
+	// Limits compares the agent's native sends with its policy limits. It
+	// is empty when the credential is gone.
+	Limits []LimitUsage
+	// Denials are the sends the agent's policy refused, oldest first.
+	Denials []AuditEntry
+	// Failed counts sends the policy allowed that were not broadcast.
+	Failed int
+	// Anomalies are patterns worth a closer look, oldest first.
+	Anomalies []Anomaly
+}
+
+// ReportTx is a journal entry made by the agent, with its anomaly flags.
+type ReportTx struct {
+	txjournal.Entry
+
+	NewDestination bool
+	Burst          bool
+}
+
+// AssetTotal is what the agent sent of one asset on one chain. Amount and
+// Fees are in the smallest unit; fees are counted on the native row only.
+type AssetTotal struct {
+	Chain    chain.ID
+	Token    string // ERC-20 contract, "" for the native coin
+	Symbol   string
+	Decimals int
+	Sends    int
+	Amount   *big.Int
+	Fees     *big.Int
+}
+
+// LimitUsage compares the native amounts sent in one limit unit with the
+// policy's limits. A nil limit is unlimited.
+type LimitUsage struct {
+	Unit      string
+	MaxPerTx  *big.Int
+	LargestTx *big.Int
+	MaxDaily  *big.Int
+	// PeakDaily is the most sent in any DailyWindow, the period the daily
+	// limit is counted over, and PeakAt the send that ended that window.
+	PeakDaily *big.Int
+	PeakAt    time.Time
+}
+
+// Anomaly is a pattern in the agent's sends worth a closer look.
+type Anomaly struct {
+	Kind   string
+	Time   time.Time
+	Chain  chain.ID
+	To     string // new destinations
+	Count  int    // sends in a burst
+	Detail string
+}
+
+// BuildReport summarizes the sends of agent id between since and until from
+// the wallet's journal and the agent's audit log. The whole journal is
+// searched for earlier sends to each destination. cred may be nil when the
+// agent has been revoked, leaving the limits out.
+//
+//nolint:gocognit // Report aggregation walks every entry once per summary
+func BuildReport(id string, cred *Credential, journal []txjournal.Entry, audit []AuditEntry, since, until time.Time) *Report {
+	r := &Report{Since: since, Until: until}
+
+	// The journal lists newest first; walk it oldest first
+	sorted := make([]txjournal.Entry, len(journal))
+	copy(sorted, journal)
+	sort.SliceStable(sorted, func(a, b int) bool { return sorted[a].CreatedAt.Before(sorted[b].CreatedAt) })
+
+	seen := make(map[string]bool)
+	for i := range sorted {
+		e := &sorted[i]
+		if !e.Sent() {
+			continue
+		}
+		key := string(e.Chain) + ":" + strings.ToLower(e.To)
+		known := seen[key]
+		seen[key] = true
+		if e.Agent != id || e.CreatedAt.Before(since) || e.CreatedAt.After(until) {
+			continue
+		}
+		tx := ReportTx{Entry: *e, NewDestination: !known && !e.Internal}
+		if tx.NewDestination {
+			r.Anomalies = append(r.Anomalies, Anomaly{
+				Kind:   AnomalyNewDestination,
+				Time:   e.CreatedAt,
+				Chain:  e.Chain,
+				To:     e.To,
+				Detail: "first send from the wallet to this address",
+			})
+		}
+		r.Transactions = append(r.Transactions, tx)
+	}
+
+	r.Anomalies = append(r.Anomalies, markBursts(r.Transactions)...)
+	sort.SliceStable(r.Anomalies, func(a, b int) bool { return r.Anomalies[a].Time.Before(r.Anomalies[b].Time) })
+
+	r.Totals = assetTotals(r.Transactions)
+	if cred != nil {
+		r.Limits = limitUsage(&cred.Policy, r.Transactions)
+	}
+
+	for _, e := range audit {
+		if e.Time.Before(since) || e.Time.After(until) {
+			continue
+		}
+		switch e.Decision {
+		case DecisionDenied:
+			r.Denials = append(r.Denials, e)
+		case DecisionFailed:
+			r.Failed++
+		}
+	}
+	return r
+}
+
+// markBursts flags the transactions of every run of BurstSends or more sends
+// within BurstWindow and returns one anomaly per run.
+func markBursts(txs []ReportTx) []Anomaly {
+	start := 0
+	for end := range txs {
+		for txs[end].CreatedAt.Sub(txs[start].CreatedAt) > BurstWindow {
+			start++
+		}
+		if end-start+1 >= BurstSends {
+			for i := start; i <= end; i++ {
+				txs[i].Burst = true
+			}
+		}
+	}
+
+	var anomalies []Anomaly
+	for i := 0; i < len(txs); i++ {
+		if !txs[i].Burst {
+			continue
+		}
+		run := Anomaly{Kind: AnomalyBurst, Time: txs[i].CreatedAt, Count: 1}
+		for i+1 < len(txs) && txs[i+1].Burst && txs[i+1].CreatedAt.Sub(txs[i].CreatedAt) <= BurstWindow {
+			i++
+			run.Count++
+		}
+		run.Detail = fmt.Sprintf("%d sends in %s", run.Count, txs[i].CreatedAt.Sub(run.Time).Round(time.Second))
+		anomalies = append(anomalies, run)
+	}
+	return anomalies
+}
+
+// assetTotals sums the transactions per chain and asset, skipping reverted
+// ones, sorted by chain then symbol.
+func assetTotals(txs []ReportTx) []AssetTotal {
+	totals := make(map[string]*AssetTotal)
+	get := func(e *txjournal.Entry, token, symbol string, decimals int) *AssetTotal {
+		key := string(e.Chain) + ":" + strings.ToLower(token)
+		t, ok := totals[key]
+		if !ok {
+			t = &AssetTotal{Chain: e.Chain, Token: token, Symbol: symbol, Decimals: decimals, Amount: new(big.Int), Fees: new(big.Int)}
+			totals[key] = t
+		}
+		return t
+	}
+
+	for i := range txs {
+		e := &txs[i].Entry
+		if e.Status == txjournal.StatusReverted {
+			continue
+		}
+		native := get(e, "", strings.ToUpper(string(e.Chain)), nativeDecimals(e.Chain))
+		addUnits(native.Fees, e.Fee)
+		asset := native
+		if e.Token != "" {
+			asset = get(e, e.Token, e.Symbol, e.Decimals)
+		}
+		asset.Sends++
+		addUnits(asset.Amount, e.Amount)
+	}
+
+	list := make([]AssetTotal, 0, len(totals))
+	for _, t := range totals {
+		if t.Sends > 0 || t.Fees.Sign() > 0 {
+			list = append(list, *t)
+		}
+	}
+	sort.Slice(list, func(a, b int) bool {
+		if list[a].Chain != list[b].Chain {
+			return list[a].Chain < list[b].Chain
+		}
+		if (list[a].Token == "") != (list[b].Token == "") {
+			return list[a].Token == ""
+		}
+		return list[a].Symbol < list[b].Symbol
+	})
+	return list
+}
+
+// limitUsage compares the native sends of txs with the limits of policy, per
+// limit unit. Units with neither limits nor sends are left out.
+func limitUsage(policy *Policy, txs []ReportTx) []LimitUsage {
+	usage := []LimitUsage{
+		{Unit: LimitUnitSat, MaxPerTx: satLimit(policy.MaxPerTxSat), MaxDaily: satLimit(policy.MaxDailySat)},
+		{Unit: LimitUnitWei, MaxPerTx: policy.MaxPerTxWeiBig(), MaxDaily: policy.MaxDailyWeiBig()},
+	}
+	// The spends of each unit in the window ending at the latest one
+	window := make([][]timedAmount, len(usage))
+	sums := make([]*big.Int, len(usage))
+	for i := range usage {
+		usage[i].LargestTx, usage[i].PeakDaily = new(big.Int), new(big.Int)
+		sums[i] = new(big.Int)
+	}
+
+	active := make([]bool, len(usage))
+	for i := range txs {
+		e := &txs[i].Entry
+		if e.Token != "" || e.Status == txjournal.StatusReverted || !HasLimits(e.Chain) {
+			continue
+		}
+		u := 0
+		if e.Chain == chain.ETH {
+			u = 1
+		}
+		amount, ok := new(big.Int).SetString(e.Amount, 10)
+		if !ok {
+			continue
+		}
+		active[u] = true
+		if amount.Cmp(usage[u].LargestTx) > 0 {
+			usage[u].LargestTx = amount
+		}
+		for len(window[u]) > 0 && !window[u][0].at.After(e.CreatedAt.Add(-DailyWindow)) {
+			sums[u].Sub(sums[u], window[u][0].amount)
+			window[u] = window[u][1:]
+		}
+		window[u] = append(window[u], timedAmount{at: e.CreatedAt, amount: amount})
+		sums[u].Add(sums[u], amount)
+		if sums[u].Cmp(usage[u].PeakDaily) > 0 {
+			usage[u].PeakDaily.Set(sums[u])
+			usage[u].PeakAt = e.CreatedAt
+		}
+	}
+
+	list := make([]LimitUsage, 0, len(usage))
+	for i, u := range usage {
+		if active[i] || u.MaxPerTx != nil || u.MaxDaily != nil {
+			list = append(list, u)
+		}
+	}
+	return list
+}
+
+// timedAmount is an amount sent at a time.
+type timedAmount struct {
+	at     time.Time
+	amount *big.Int
+}
+
+// satLimit returns a satoshi limit as a *big.Int, or nil when it is zero.
+func satLimit(sat uint64) *big.Int {
+	if sat == 0 {
+		return nil
+	}
+	return new(big.Int).SetUint64(sat)
+}
+
+// nativeDecimals returns the decimals of a chain's native coin.
+func nativeDecimals(chainID chain.ID) int {
+	if chainID == chain.ETH {
+		return 18
+	}
+	return 8
+}
+
+// addUnits adds a base-10 integer string to total, ignoring malformed values.
+func addUnits(total *big.Int, units string) {
+	if n, ok := new(big.Int).SetString(units, 10); ok {
+		total.Add(total, n)
+	}
+}
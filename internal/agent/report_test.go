@@ -0,0 +1,138 @@
+package agent
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/txjournal"
+)
+
+func TestBuildReport(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-7 * 24 * time.Hour)
+	send := func(hash, agentID, to, amount string, at time.Time) txjournal.Entry {
+		return txjournal.Entry{
+			Hash: hash, Chain: chain.BSV, To: to, Amount: amount, Fee: "10", Symbol: "BSV", Decimals: 8,
+			Status: txjournal.StatusConfirmed, Direction: txjournal.DirectionSent, Agent: agentID, CreatedAt: at,
+		}
+	}
+
+	journal := []txjournal.Entry{
+		// Before the period: makes 1known a known destination
+		send("old", "", "1known", "100", since.Add(-time.Hour)),
+		send("a1", "agt_1", "1known", "1000", now.Add(-3*24*time.Hour)),
+		send("a2", "agt_1", "1new", "2000", now.Add(-2*24*time.Hour)),
+		send("other", "agt_2", "1other", "9000", now.Add(-2*24*time.Hour)),
+		send("rev", "agt_1", "1known", "50000", now.Add(-2*24*time.Hour)),
+	}
+	journal[4].Status = txjournal.StatusReverted
+	// A burst of five sends a minute apart on one day
+	burstStart := now.Add(-24 * time.Hour)
+	for i := range BurstSends {
+		journal = append(journal, send("b"+strconv.Itoa(i), "agt_1", "1known", "500", burstStart.Add(time.Duration(i)*time.Minute)))
+	}
+
+	audit := []AuditEntry{
+		{Time: since.Add(-time.Hour), Decision: DecisionDenied},
+		{Time: now.Add(-time.Hour), Decision: DecisionDenied, Reason: "over limit"},
+		{Time: now.Add(-time.Hour), Decision: DecisionFailed},
+		{Time: now.Add(-time.Hour), Decision: DecisionAllowed},
+	}
+	cred := &Credential{Policy: Policy{MaxPerTxSat: 5000, MaxDailySat: 10000}}
+
+	r := BuildReport("agt_1", cred, journal, audit, since, now)
+
+	if len(r.Transactions) != 8 {
+		t.Fatalf("report has %d transactions, want 8", len(r.Transactions))
+	}
+	if r.Transactions[0].Hash != "a1" || r.Transactions[0].NewDestination {
+		t.Errorf("first transaction = %+v, want a1 to a known destination", r.Transactions[0])
+	}
+	if !r.Transactions[1].NewDestination {
+		t.Error("send to 1new not flagged as a new destination")
+	}
+	for _, tx := range r.Transactions[3:] {
+		if !tx.Burst {
+			t.Errorf("burst send %s not flagged", tx.Hash)
+		}
+	}
+
+	if len(r.Totals) != 1 {
+		t.Fatalf("report has %d totals, want 1", len(r.Totals))
+	}
+	// Reverted sends are left out of the totals
+	if tot := r.Totals[0]; tot.Sends != 7 || tot.Amount.Int64() != 5500 || tot.Fees.Int64() != 70 {
+		t.Errorf("BSV total = %d sends, %s sat, %s fees; want 7, 5500, 70", tot.Sends, tot.Amount, tot.Fees)
+	}
+
+	if len(r.Limits) != 1 {
+		t.Fatalf("report has %d limits, want 1", len(r.Limits))
+	}
+	if l := r.Limits[0]; l.Unit != LimitUnitSat || l.LargestTx.Int64() != 2000 || l.PeakDaily.Int64() != 2500 ||
+		!l.PeakAt.Equal(burstStart.Add((BurstSends-1)*time.Minute)) || l.MaxDaily.Int64() != 10000 {
+		t.Errorf("sat limits = %+v", l)
+	}
+
+	if len(r.Denials) != 1 || r.Denials[0].Reason != "over limit" || r.Failed != 1 {
+		t.Errorf("denials = %+v, failed = %d; want the one denial in the period and 1 failure", r.Denials, r.Failed)
+	}
+
+	kinds := make(map[string]int)
+	for _, a := range r.Anomalies {
+		kinds[a.Kind]++
+		if a.Kind == AnomalyBurst && a.Count != BurstSends {
+			t.Errorf("burst counts %d sends, want %d", a.Count, BurstSends)
+		}
+	}
+	if kinds[AnomalyBurst] != 1 || kinds[AnomalyNewDestination] != 1 {
+		t.Errorf("anomalies = %+v, want one burst and one new destination", r.Anomalies)
+	}
+
+	// A revoked agent's report has no limits
+	if r := BuildReport("agt_1", nil, journal, nil, since, now); r.Limits != nil {
+		t.Errorf("revoked agent limits = %+v, want none", r.Limits)
+	}
+}
+
+func TestMarkBursts(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	at := func(minutes ...int) []ReportTx {
+		txs := make([]ReportTx, len(minutes))
+		for i, m := range minutes {
+			txs[i].CreatedAt = start.Add(time.Duration(m) * time.Minute)
+		}
+		return txs
+	}
+
+	tests := []struct {
+		name   string
+		txs    []ReportTx
+		counts []int
+	}{
+		{"too few", at(0, 1, 2, 3), nil},
+		{"spread out", at(0, 3, 6, 9, 12, 15), nil},
+		{"one burst", at(0, 1, 2, 3, 4), []int{5}},
+		{"overlapping windows join", at(0, 1, 2, 3, 4, 8, 9, 10, 11, 12), []int{10}},
+		{"two bursts", at(0, 1, 2, 3, 4, 60, 61, 62, 63, 64), []int{5, 5}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			anomalies := markBursts(tc.txs)
+			if len(anomalies) != len(tc.counts) {
+				t.Fatalf("markBursts() = %+v, want %d bursts", anomalies, len(tc.counts))
+			}
+			for i, a := range anomalies {
+				if a.Count != tc.counts[i] {
+					t.Errorf("burst %d counts %d sends, want %d", i, a.Count, tc.counts[i])
+				}
+			}
+		})
+	}
+}
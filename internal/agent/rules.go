@@ -0,0 +1,267 @@
+package agent
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// rulePrefix starts every rule ID.
+const rulePrefix = "r"
+
+// Rule scopes what an agent may send of one asset on one chain: to which
+// addresses, and how much per transaction and per DailyWindow.
+//
+// A send of an asset that has rules must be allowed by at least one of them:
+// a rule allows the destinations in its Addresses, or any destination when
+// it has none. Every rule that allows the destination also limits the send.
+// The policy's flat limits and allowlists still apply on top of the rules.
+type Rule struct {
+	// ID identifies the rule within its policy (e.g., "r1").
+	ID string `json:"id"`
+
+	// Chain is the chain the rule applies to.
+	Chain chain.ID `json:"chain"`
+
+	// Token is the ERC-20 contract the rule applies to, or "" for the
+	// chain's native coin.
+	Token string `json:"token,omitempty"`
+
+	// Symbol and Decimals describe the asset, for display.
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+
+	// Addresses are the destinations the rule allows. Empty means any address.
+	Addresses []string `json:"addresses,omitempty"`
+
+	// MaxPerTx is the most one transaction may send, in the asset's
+	// smallest unit ("" = unlimited). Stored as string to avoid JSON
+	// precision loss with large numbers.
+	MaxPerTx string `json:"max_per_tx,omitempty"`
+
+	// MaxDaily is the most that may be sent in the DailyWindow, in the
+	// asset's smallest unit ("" = unlimited).
+	MaxDaily string `json:"max_daily,omitempty"`
+}
+
+// Transfer is one payment of a send, checked against the policy rules.
+type Transfer struct {
+	To     string
+	Amount *big.Int
+}
+
+// AppliesTo reports whether the rule covers an asset. token is the ERC-20
+// contract address, or "" for the chain's native coin.
+func (r *Rule) AppliesTo(chainID chain.ID, token string) bool {
+	return r.Chain == chainID && strings.EqualFold(r.Token, token)
+}
+
+// Allows reports whether the rule allows sending to an address.
+func (r *Rule) Allows(to string) bool {
+	if len(r.Addresses) == 0 {
+		return true
+	}
+	for _, addr := range r.Addresses {
+		// ETH addresses are case-insensitive; casing only carries the EIP-55 checksum.
+		if addr == to || (r.Chain == chain.ETH && strings.EqualFold(addr, to)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxPerTxBig returns MaxPerTx as a *big.Int. Returns nil if unset or zero.
+func (r *Rule) MaxPerTxBig() *big.Int {
+	return ruleLimit(r.MaxPerTx)
+}
+
+// MaxDailyBig returns MaxDaily as a *big.Int. Returns nil if unset or zero.
+func (r *Rule) MaxDailyBig() *big.Int {
+	return ruleLimit(r.MaxDaily)
+}
+
+// HasLimit reports whether the rule limits the amounts sent.
+func (r *Rule) HasLimit() bool {
+	return r.MaxPerTxBig() != nil || r.MaxDailyBig() != nil
+}
+
+// Counts reports whether a spend from the ledger counts toward the rule's
+// daily limit. A spend without a recorded destination, such as one paying
+// several recipients, counts toward every rule of its asset.
+func (r *Rule) Counts(sp *Spend) bool {
+	return r.AppliesTo(sp.Chain, sp.Token) && (sp.To == "" || r.Allows(sp.To))
+}
+
+// SpentIn returns what the spends counted by the rule add up to in the
+// DailyWindow before now.
+func (r *Rule) SpentIn(spends []Spend, now time.Time) *big.Int {
+	since := now.Add(-DailyWindow)
+	spent := new(big.Int)
+	for i := range spends {
+		sp := &spends[i]
+		if !sp.Time.After(since) || !r.Counts(sp) {
+			continue
+		}
+		if amount, ok := new(big.Int).SetString(sp.Amount, 10); ok {
+			spent.Add(spent, amount)
+		}
+	}
+	return spent
+}
+
+// Format formats an amount in the smallest unit of the rule's asset for
+// display, e.g. "100 USDC".
+func (r *Rule) Format(amount *big.Int) string {
+	return strings.TrimSuffix(chain.FormatDecimalAmount(amount, r.Decimals), ".0") + " " + r.Symbol
+}
+
+// RulesFor returns the rules of the policy covering an asset.
+func (p *Policy) RulesFor(chainID chain.ID, token string) []Rule {
+	var rules []Rule
+	for _, r := range p.Rules {
+		if r.AppliesTo(chainID, token) {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// HasRuleLimit reports whether any rule limits the amounts of an asset sent.
+func (p *Policy) HasRuleLimit(chainID chain.ID, token string) bool {
+	for _, r := range p.RulesFor(chainID, token) {
+		if r.HasLimit() {
+			return true
+		}
+	}
+	return false
+}
+
+// AddRule adds a rule to the policy under the next free ID, which it
+// returns.
+func (p *Policy) AddRule(rule Rule) string {
+	next := 1
+	for _, r := range p.Rules {
+		if n, err := strconv.Atoi(strings.TrimPrefix(r.ID, rulePrefix)); err == nil && n >= next {
+			next = n + 1
+		}
+	}
+	rule.ID = rulePrefix + strconv.Itoa(next)
+	p.Rules = append(p.Rules, rule)
+	return rule.ID
+}
+
+// RemoveRule removes the rule with the given ID from the policy.
+func (p *Policy) RemoveRule(id string) error {
+	for i, r := range p.Rules {
+		if r.ID == id {
+			p.Rules = append(p.Rules[:i:i], p.Rules[i+1:]...)
+			if len(p.Rules) == 0 {
+				p.Rules = nil
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %q", ErrRuleNotFound, id)
+}
+
+// EvaluateRules checks the transfers of one send of an asset against the
+// rules of policy. spends are the agent's earlier spends; those in the
+// DailyWindow before now count toward the daily limits. token is the ERC-20
+// contract address, or "" for the chain's native coin.
+//
+// ETH sends one transaction per recipient, so its per-transaction limits
+// apply to each transfer; the other chains pay every recipient in one
+// transaction, whose transfers a rule allows are limited together.
+//
+//nolint:gocognit // Each rule is checked for address, per-tx, and daily limits
+func EvaluateRules(policy *Policy, chainID chain.ID, token string, transfers []Transfer, spends []Spend, now time.Time) error {
+	rules := policy.RulesFor(chainID, token)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	for _, t := range transfers {
+		allowed := false
+		for i := range rules {
+			allowed = allowed || rules[i].Allows(t.To)
+		}
+		if !allowed {
+			return fmt.Errorf("%w: %q (no policy rule for %s allows it)", ErrAddrDenied, t.To, rules[0].Symbol)
+		}
+	}
+
+	for i := range rules {
+		r := &rules[i]
+		sent := new(big.Int)
+		for _, t := range transfers {
+			if !r.Allows(t.To) {
+				continue
+			}
+			sent.Add(sent, t.Amount)
+			if maxTx := r.MaxPerTxBig(); maxTx != nil && chainID == chain.ETH && t.Amount.Cmp(maxTx) > 0 {
+				return fmt.Errorf("%w: rule %s: %s exceeds limit of %s",
+					ErrPerTxLimit, r.ID, r.Format(t.Amount), r.Format(maxTx))
+			}
+		}
+		if maxTx := r.MaxPerTxBig(); maxTx != nil && chainID != chain.ETH && sent.Cmp(maxTx) > 0 {
+			return fmt.Errorf("%w: rule %s: %s exceeds limit of %s",
+				ErrPerTxLimit, r.ID, r.Format(sent), r.Format(maxTx))
+		}
+
+		maxDaily := r.MaxDailyBig()
+		if maxDaily == nil || sent.Sign() == 0 {
+			continue
+		}
+		spent := r.SpentIn(spends, now)
+		if new(big.Int).Add(spent, sent).Cmp(maxDaily) > 0 {
+			remaining := new(big.Int).Sub(maxDaily, spent)
+			if remaining.Sign() < 0 {
+				remaining.SetInt64(0)
+			}
+			return fmt.Errorf("%w: rule %s: %s would exceed limit of %s (spent in the last 24h: %s, remaining: %s)",
+				ErrDailyLimitExceed, r.ID, r.Format(sent), r.Format(maxDaily), r.Format(spent), r.Format(remaining))
+		}
+	}
+	return nil
+}
+
+// CheckRules checks the transfers of one send of an asset against the
+// agent's policy rules, counting the spends in its ledger at counterPath
+// toward the daily limits. token verifies the ledger's HMAC; a ledger that
+// fails verification denies every send limited by a daily rule.
+func CheckRules(counterPath, token string, cred *Credential, chainID chain.ID, contract string, transfers []Transfer) error {
+	rules := cred.Policy.RulesFor(chainID, contract)
+	if len(rules) == 0 {
+		return nil
+	}
+
+	var spends []Spend
+	for i := range rules {
+		if rules[i].MaxDailyBig() == nil {
+			continue
+		}
+		ledger := loadLedger(counterPath, token)
+		if ledger.tampered {
+			return fmt.Errorf("%w: %w", ErrDailyLimitExceed, ErrCounterTampered)
+		}
+		spends = ledger.Spends
+		break
+	}
+	return EvaluateRules(&cred.Policy, chainID, contract, transfers, spends, time.Now())
+}
+
+// ruleLimit parses a rule limit. Returns nil if unset, zero, or malformed.
+func ruleLimit(limit string) *big.Int {
+	if limit == "" || limit == "0" {
+		return nil
+	}
+	v, ok := new(big.Int).SetString(limit, 10)
+	if !ok || v.Sign() <= 0 {
+		return nil
+	}
+	return v
+}
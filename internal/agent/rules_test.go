@@ -0,0 +1,217 @@
+package agent
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+const (
+	testUSDC     = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	testPayee    = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	testOtherETH = "0x8ba1f109551bD432803012645Ac136ddd64DBA72"
+)
+
+func usdcRule(id string, addrs []string, maxPerTx, maxDaily string) Rule {
+	return Rule{ID: id, Chain: chain.ETH, Token: testUSDC, Symbol: "USDC", Decimals: 6, Addresses: addrs, MaxPerTx: maxPerTx, MaxDaily: maxDaily}
+}
+
+func TestEvaluateRules_NoRulesAllowsAll(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{Rules: []Rule{usdcRule("r1", []string{testPayee}, "", "")}}
+	transfers := []Transfer{{To: testOtherETH, Amount: big.NewInt(1)}}
+
+	// Native ETH has no rules
+	if err := EvaluateRules(policy, chain.ETH, "", transfers, nil, time.Now()); err != nil {
+		t.Errorf("EvaluateRules() error for asset without rules: %v", err)
+	}
+}
+
+func TestEvaluateRules_AddressScope(t *testing.T) {
+	t.Parallel()
+
+	bsv := Rule{ID: "r1", Chain: chain.BSV, Symbol: "BSV", Decimals: 8, Addresses: []string{"1A", "1B", "1C"}}
+	policy := &Policy{Rules: []Rule{bsv}}
+	now := time.Now()
+
+	if err := EvaluateRules(policy, chain.BSV, "", []Transfer{{To: "1B", Amount: big.NewInt(5000)}}, nil, now); err != nil {
+		t.Errorf("EvaluateRules() error for allowed address: %v", err)
+	}
+	err := EvaluateRules(policy, chain.BSV, "", []Transfer{{To: "1A", Amount: big.NewInt(1)}, {To: "1D", Amount: big.NewInt(1)}}, nil, now)
+	if !errors.Is(err, ErrAddrDenied) {
+		t.Errorf("EvaluateRules() error = %v, want ErrAddrDenied", err)
+	}
+
+	// ETH addresses match case-insensitively, and token contracts too
+	eth := &Policy{Rules: []Rule{usdcRule("r1", []string{testPayee}, "", "")}}
+	transfers := []Transfer{{To: "0x742d35cc6634c0532925a3b844bc454e4438f44e", Amount: big.NewInt(1)}}
+	if err := EvaluateRules(eth, chain.ETH, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", transfers, nil, now); err != nil {
+		t.Errorf("EvaluateRules() error for lowercase address: %v", err)
+	}
+}
+
+func TestEvaluateRules_PerTxLimit(t *testing.T) {
+	t.Parallel()
+
+	policy := &Policy{Rules: []Rule{usdcRule("r1", nil, "100000000", "")}}
+	now := time.Now()
+
+	// ETH limits each transfer on its own
+	transfers := []Transfer{{To: testPayee, Amount: big.NewInt(60_000_000)}, {To: testOtherETH, Amount: big.NewInt(60_000_000)}}
+	if err := EvaluateRules(policy, chain.ETH, testUSDC, transfers, nil, now); err != nil {
+		t.Errorf("EvaluateRules() error for transfers under the limit: %v", err)
+	}
+	err := EvaluateRules(policy, chain.ETH, testUSDC, []Transfer{{To: testPayee, Amount: big.NewInt(150_000_000)}}, nil, now)
+	if !errors.Is(err, ErrPerTxLimit) {
+		t.Fatalf("EvaluateRules() error = %v, want ErrPerTxLimit", err)
+	}
+	if want := "rule r1: 150 USDC exceeds limit of 100 USDC"; !strings.Contains(err.Error(), want) {
+		t.Errorf("EvaluateRules() error = %q, want it to contain %q", err, want)
+	}
+
+	// A BSV transaction paying several recipients is limited as a whole
+	bsv := &Policy{Rules: []Rule{{ID: "r1", Chain: chain.BSV, Symbol: "BSV", Decimals: 8, MaxPerTx: "10000"}}}
+	err = EvaluateRules(bsv, chain.BSV, "", []Transfer{{To: "1A", Amount: big.NewInt(6000)}, {To: "1B", Amount: big.NewInt(6000)}}, nil, now)
+	if !errors.Is(err, ErrPerTxLimit) {
+		t.Errorf("EvaluateRules() error = %v, want ErrPerTxLimit", err)
+	}
+}
+
+func TestEvaluateRules_DailyLimitPerAddress(t *testing.T) {
+	t.Parallel()
+
+	// At most 100 USDC per day to the payee, and 1000 USDC per day anywhere
+	policy := &Policy{Rules: []Rule{
+		usdcRule("r1", []string{testPayee}, "", "100000000"),
+		usdcRule("r2", nil, "", "1000000000"),
+	}}
+	now := time.Now()
+	spends := []Spend{
+		{Time: now.Add(-time.Hour), Chain: chain.ETH, Token: testUSDC, To: testPayee, Amount: "80000000"},
+		{Time: now.Add(-time.Hour), Chain: chain.ETH, Token: testUSDC, To: testOtherETH, Amount: "500000000"},
+		// Outside the window, another asset, and native ETH do not count
+		{Time: now.Add(-25 * time.Hour), Chain: chain.ETH, Token: testUSDC, To: testPayee, Amount: "80000000"},
+		{Time: now.Add(-time.Hour), Chain: chain.ETH, Token: "0xdAC17F958D2ee523a2206206994597C13D831ec7", To: testPayee, Amount: "80000000"},
+		{Time: now.Add(-time.Hour), Chain: chain.ETH, To: testPayee, Amount: "80000000"},
+	}
+
+	if err := EvaluateRules(policy, chain.ETH, testUSDC, []Transfer{{To: testPayee, Amount: big.NewInt(20_000_000)}}, spends, now); err != nil {
+		t.Errorf("EvaluateRules() error within the daily limit: %v", err)
+	}
+	err := EvaluateRules(policy, chain.ETH, testUSDC, []Transfer{{To: testPayee, Amount: big.NewInt(30_000_000)}}, spends, now)
+	if !errors.Is(err, ErrDailyLimitExceed) {
+		t.Fatalf("EvaluateRules() error = %v, want ErrDailyLimitExceed", err)
+	}
+	if want := "rule r1: 30 USDC would exceed limit of 100 USDC (spent in the last 24h: 80 USDC, remaining: 20 USDC)"; !strings.Contains(err.Error(), want) {
+		t.Errorf("EvaluateRules() error = %q, want it to contain %q", err, want)
+	}
+
+	// Other destinations are only limited by the wider rule
+	if err := EvaluateRules(policy, chain.ETH, testUSDC, []Transfer{{To: testOtherETH, Amount: big.NewInt(400_000_000)}}, spends, now); err != nil {
+		t.Errorf("EvaluateRules() error within the wider limit: %v", err)
+	}
+	err = EvaluateRules(policy, chain.ETH, testUSDC, []Transfer{{To: testOtherETH, Amount: big.NewInt(450_000_000)}}, spends, now)
+	if !errors.Is(err, ErrDailyLimitExceed) {
+		t.Errorf("EvaluateRules() error = %v, want ErrDailyLimitExceed", err)
+	}
+
+	// A spend without a destination counts toward every rule of the asset
+	spends = append(spends, Spend{Time: now.Add(-time.Minute), Chain: chain.ETH, Token: testUSDC, Amount: "20000000"})
+	err = EvaluateRules(policy, chain.ETH, testUSDC, []Transfer{{To: testPayee, Amount: big.NewInt(1)}}, spends, now)
+	if !errors.Is(err, ErrDailyLimitExceed) {
+		t.Errorf("EvaluateRules() error = %v, want ErrDailyLimitExceed", err)
+	}
+}
+
+func TestCheckRules_Ledger(t *testing.T) {
+	t.Parallel()
+
+	counterPath := filepath.Join(t.TempDir(), "test.counter")
+	token := "sigil_agt_rules"
+	cred := &Credential{
+		Chains: []chain.ID{chain.ETH},
+		Policy: Policy{Rules: []Rule{usdcRule("r1", []string{testPayee}, "", "100000000")}},
+	}
+	send := []Transfer{{To: testPayee, Amount: big.NewInt(60_000_000)}}
+
+	if err := CheckRules(counterPath, token, cred, chain.ETH, testUSDC, send); err != nil {
+		t.Fatalf("CheckRules() error = %v", err)
+	}
+	if err := RecordAssetSpend(counterPath, token, Spend{Chain: chain.ETH, Token: testUSDC, To: testPayee, Amount: "60000000"}); err != nil {
+		t.Fatalf("RecordAssetSpend() error = %v", err)
+	}
+	if err := CheckRules(counterPath, token, cred, chain.ETH, testUSDC, send); !errors.Is(err, ErrDailyLimitExceed) {
+		t.Errorf("CheckRules() error = %v, want ErrDailyLimitExceed", err)
+	}
+
+	// The destination and token are covered by the ledger HMAC
+	if err := CheckRules(counterPath, "sigil_agt_other", cred, chain.ETH, testUSDC, send); !errors.Is(err, ErrCounterTampered) {
+		t.Errorf("CheckRules() with wrong token error = %v, want ErrCounterTampered", err)
+	}
+}
+
+func TestPolicy_AddRemoveRule(t *testing.T) {
+	t.Parallel()
+
+	var policy Policy
+	if id := policy.AddRule(usdcRule("", nil, "", "")); id != "r1" {
+		t.Errorf("AddRule() = %q, want r1", id)
+	}
+	if id := policy.AddRule(usdcRule("", nil, "", "")); id != "r2" {
+		t.Errorf("AddRule() = %q, want r2", id)
+	}
+	if err := policy.RemoveRule("r1"); err != nil {
+		t.Fatalf("RemoveRule() error = %v", err)
+	}
+	// IDs are not reused while a later rule remains
+	if id := policy.AddRule(usdcRule("", nil, "", "")); id != "r3" {
+		t.Errorf("AddRule() = %q, want r3", id)
+	}
+	if err := policy.RemoveRule("r9"); !errors.Is(err, ErrRuleNotFound) {
+		t.Errorf("RemoveRule() error = %v, want ErrRuleNotFound", err)
+	}
+	if len(policy.Rules) != 2 || policy.Rules[0].ID != "r2" || policy.Rules[1].ID != "r3" {
+		t.Errorf("Rules = %+v, want r2 and r3", policy.Rules)
+	}
+}
+
+func TestFileStore_UpdatePolicy(t *testing.T) {
+	t.Parallel()
+
+	store := setupTestStore(t)
+	token, _ := GenerateToken()
+	cred := createTestCredential("policy-test", "rules", []chain.ID{chain.ETH})
+	cred.ID = TokenID(token)
+	seed := []byte("test-seed-32-bytes-long-enough!!")
+	if err := store.CreateCredential(cred, token, seed); err != nil {
+		t.Fatalf("CreateCredential() error = %v", err)
+	}
+
+	policy := cred.Policy
+	policy.AddRule(usdcRule("", []string{testPayee}, "", "100000000"))
+	other, _ := GenerateToken()
+	if err := store.UpdatePolicy("policy-test", cred.ID, other, &policy); !errors.Is(err, ErrPolicyTampered) {
+		t.Fatalf("UpdatePolicy() with wrong token error = %v, want ErrPolicyTampered", err)
+	}
+	if err := store.UpdatePolicy("policy-test", cred.ID, token, &policy); err != nil {
+		t.Fatalf("UpdatePolicy() error = %v", err)
+	}
+
+	loadedSeed, loaded, err := store.Load("policy-test", cred.ID, token)
+	if err != nil {
+		t.Fatalf("Load() after UpdatePolicy error = %v", err)
+	}
+	defer zeroBytes(loadedSeed)
+	if string(loadedSeed) != string(seed) {
+		t.Error("UpdatePolicy() changed the encrypted seed")
+	}
+	if len(loaded.Policy.Rules) != 1 || loaded.Policy.Rules[0].ID != "r1" {
+		t.Errorf("Load() rules = %+v, want r1", loaded.Policy.Rules)
+	}
+}
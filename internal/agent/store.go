@@ -24,6 +24,10 @@ const (
 	agentFileExtension   = ".agent"
 )
 
+// CredentialVersion is the agent credential file format version this build
+// writes.
+const CredentialVersion = 1
+
 // walletNameRegex mirrors the pattern from session/manager.go.
 var walletNameRegex = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
 
@@ -70,6 +74,7 @@ func (s *FileStore) CreateCredential(cred *Credential, token string, seed []byte
 		return fmt.Errorf("computing policy HMAC: %w", err)
 	}
 	cred.PolicyHMAC = policyHMAC
+	cred.Version = CredentialVersion
 
 	// Ensure directory exists
 	if mkdirErr := os.MkdirAll(s.basePath, agentDirPermissions); mkdirErr != nil {
@@ -147,6 +152,61 @@ func (s *FileStore) Load(walletName, agentID, token string) ([]byte, *Credential
 	return seed, &cred, nil
 }
 
+// UpdatePolicy replaces the policy of an agent credential and re-signs it
+// with token, which must verify the current policy. The encrypted seed and
+// the rest of the credential are kept.
+func (s *FileStore) UpdatePolicy(walletName, agentID, token string, policy *Policy) error {
+	if !walletNameRegex.MatchString(walletName) {
+		return fmt.Errorf("%w: %q", ErrInvalidWallet, walletName)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	agentPath := s.agentPath(walletName, agentID)
+	if agentPath == "" {
+		return ErrInvalidAgentPath
+	}
+
+	//nolint:gosec // G304: Path constructed from validated wallet name and agent ID
+	data, err := os.ReadFile(agentPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %q for wallet %q", ErrAgentNotFound, agentID, walletName)
+		}
+		return fmt.Errorf("reading agent file: %w", err)
+	}
+
+	var cred Credential
+	if unmarshalErr := json.Unmarshal(data, &cred); unmarshalErr != nil {
+		return fmt.Errorf("parsing agent file: %w", unmarshalErr)
+	}
+
+	valid, err := VerifyPolicyHMAC(&cred.Policy, token, cred.PolicyHMAC)
+	if err != nil {
+		return fmt.Errorf("verifying policy integrity: %w", err)
+	}
+	if !valid {
+		return ErrPolicyTampered
+	}
+
+	policyHMAC, err := ComputePolicyHMAC(policy, token)
+	if err != nil {
+		return fmt.Errorf("computing policy HMAC: %w", err)
+	}
+	cred.Policy = *policy
+	cred.PolicyHMAC = policyHMAC
+
+	data, err = json.MarshalIndent(&cred, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling agent credential: %w", err)
+	}
+	if writeErr := fileutil.WriteAtomic(agentPath, data, agentFilePermissions); writeErr != nil {
+		return fmt.Errorf("writing agent file: %w", writeErr)
+	}
+	return nil
+}
+
 // LoadByToken finds the agent credential for a wallet that matches the given token.
 // It tries all agent files for the wallet until it finds one that decrypts successfully.
 // Returns the decrypted seed and credential.
@@ -231,7 +291,8 @@ func (s *FileStore) List(walletName string) ([]*Credential, error) {
 	return agents, nil
 }
 
-// Delete removes an agent credential and its counter file.
+// Delete removes an agent credential and its counter file. The agent's
+// audit log is kept, so what a revoked agent did can still be reviewed.
 func (s *FileStore) Delete(walletName, agentID string) error {
 	if !walletNameRegex.MatchString(walletName) {
 		return fmt.Errorf("%w: %q", ErrInvalidWallet, walletName)
@@ -310,3 +371,17 @@ func (s *FileStore) counterPath(walletName, agentID string) string {
 
 	return cleanPath
 }
+
+// auditPath returns the full path for an agent's audit log.
+func (s *FileStore) auditPath(walletName, agentID string) string {
+	filename := walletName + "-" + agentID + ".audit"
+	path := filepath.Join(s.basePath, filename)
+
+	cleanPath := filepath.Clean(path)
+	expectedSuffix := string(filepath.Separator) + filename
+	if !strings.HasSuffix(cleanPath, expectedSuffix) {
+		return ""
+	}
+
+	return cleanPath
+}
@@ -0,0 +1,234 @@
+package agent
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+	"github.com/mrz1836/sigil/internal/sigilcrypto"
+)
+
+// Sentinel errors for the agent store vault.
+var (
+	ErrVaultNotFound      = errors.New("agent store is not initialized for this wallet")
+	ErrVaultBadPassphrase = errors.New("wrong agent store passphrase or corrupted vault")
+	ErrVaultVersion       = errors.New("agent store vault version is newer than supported")
+)
+
+const (
+	// vaultFileExtension is the extension of a wallet's agent store vault.
+	vaultFileExtension = ".vault"
+
+	// VaultVersion is the vault file format version this build writes.
+	VaultVersion = 1
+
+	// masterKeyLength is the size of the random vault master key in bytes.
+	masterKeyLength = 32
+)
+
+// vaultFile is the on-disk agent store vault for one wallet.
+//
+// The wallet seed is encrypted with a random master key, and the master key
+// is encrypted with the agent store passphrase. Agents can then be
+// provisioned with the passphrase alone, without the wallet password, and
+// changing the passphrase only re-wraps the master key.
+type vaultFile struct {
+	Version       int       `json:"version"`
+	WalletName    string    `json:"wallet_name"`
+	WrappedKey    []byte    `json:"wrapped_key"`
+	EncryptedSeed []byte    `json:"encrypted_seed"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// InitVault creates the agent store vault for a wallet, replacing any
+// existing one, so that agents can later be created with the passphrase
+// instead of the wallet password.
+func (s *FileStore) InitVault(walletName string, seed []byte, passphrase string) error {
+	if !walletNameRegex.MatchString(walletName) {
+		return fmt.Errorf("%w: %q", ErrInvalidWallet, walletName)
+	}
+
+	masterKey, err := sigilcrypto.RandomBytes(masterKeyLength)
+	if err != nil {
+		return fmt.Errorf("generating agent store master key: %w", err)
+	}
+	defer zeroBytes(masterKey)
+
+	encryptedSeed, err := sigilcrypto.Encrypt(seed, hex.EncodeToString(masterKey))
+	if err != nil {
+		return fmt.Errorf("encrypting seed with agent store key: %w", err)
+	}
+	wrappedKey, err := sigilcrypto.Encrypt(masterKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("encrypting agent store key: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	return s.writeVault(&vaultFile{
+		Version:       VaultVersion,
+		WalletName:    walletName,
+		WrappedKey:    wrappedKey,
+		EncryptedSeed: encryptedSeed,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+}
+
+// HasVault reports whether the wallet has an agent store vault.
+func (s *FileStore) HasVault(walletName string) bool {
+	if !walletNameRegex.MatchString(walletName) {
+		return false
+	}
+	path := s.vaultPath(walletName)
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// UnlockVault decrypts the wallet seed from the agent store vault.
+// The caller MUST zero the returned seed when done.
+func (s *FileStore) UnlockVault(walletName, passphrase string) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	vault, err := s.readVault(walletName)
+	if err != nil {
+		return nil, err
+	}
+
+	masterKey, err := sigilcrypto.Decrypt(vault.WrappedKey, passphrase)
+	if err != nil {
+		return nil, ErrVaultBadPassphrase
+	}
+	defer zeroBytes(masterKey)
+
+	seed, err := sigilcrypto.Decrypt(vault.EncryptedSeed, hex.EncodeToString(masterKey))
+	if err != nil {
+		return nil, ErrVaultBadPassphrase
+	}
+	return seed, nil
+}
+
+// ChangeVaultPassphrase re-encrypts the vault master key with a new
+// passphrase. The encrypted seed is left untouched.
+func (s *FileStore) ChangeVaultPassphrase(walletName, oldPassphrase, newPassphrase string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	vault, err := s.readVault(walletName)
+	if err != nil {
+		return err
+	}
+
+	masterKey, err := sigilcrypto.Decrypt(vault.WrappedKey, oldPassphrase)
+	if err != nil {
+		return ErrVaultBadPassphrase
+	}
+	defer zeroBytes(masterKey)
+
+	wrappedKey, err := sigilcrypto.Encrypt(masterKey, newPassphrase)
+	if err != nil {
+		return fmt.Errorf("encrypting agent store key: %w", err)
+	}
+	vault.WrappedKey = wrappedKey
+	vault.UpdatedAt = time.Now().UTC()
+	return s.writeVault(vault)
+}
+
+// DeleteVault removes a wallet's agent store vault. Existing agents keep
+// working; only creating new agents needs the wallet password again.
+func (s *FileStore) DeleteVault(walletName string) error {
+	if !walletNameRegex.MatchString(walletName) {
+		return fmt.Errorf("%w: %q", ErrInvalidWallet, walletName)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	path := s.vaultPath(walletName)
+	if path == "" {
+		return ErrInvalidAgentPath
+	}
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %q", ErrVaultNotFound, walletName)
+		}
+		return fmt.Errorf("removing agent store vault: %w", err)
+	}
+	return nil
+}
+
+// readVault reads a wallet's vault without locking.
+func (s *FileStore) readVault(walletName string) (*vaultFile, error) {
+	if !walletNameRegex.MatchString(walletName) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidWallet, walletName)
+	}
+	path := s.vaultPath(walletName)
+	if path == "" {
+		return nil, ErrInvalidAgentPath
+	}
+
+	//nolint:gosec // G304: Path constructed from validated wallet name
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %q", ErrVaultNotFound, walletName)
+		}
+		return nil, fmt.Errorf("reading agent store vault: %w", err)
+	}
+
+	var vault vaultFile
+	if err := json.Unmarshal(data, &vault); err != nil {
+		return nil, fmt.Errorf("parsing agent store vault: %w", err)
+	}
+	if vault.Version > VaultVersion {
+		return nil, fmt.Errorf("%w: version %d (supported %d)", ErrVaultVersion, vault.Version, VaultVersion)
+	}
+	return &vault, nil
+}
+
+// writeVault writes a vault atomically without locking.
+func (s *FileStore) writeVault(vault *vaultFile) error {
+	path := s.vaultPath(vault.WalletName)
+	if path == "" {
+		return fmt.Errorf("%w for wallet %q", ErrInvalidAgentPath, vault.WalletName)
+	}
+	if err := os.MkdirAll(s.basePath, agentDirPermissions); err != nil {
+		return fmt.Errorf("creating agents directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(vault, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling agent store vault: %w", err)
+	}
+	if err := fileutil.WriteAtomic(path, data, agentFilePermissions); err != nil {
+		return fmt.Errorf("writing agent store vault: %w", err)
+	}
+	return nil
+}
+
+// vaultPath returns the full path of a wallet's vault file.
+func (s *FileStore) vaultPath(walletName string) string {
+	filename := walletName + vaultFileExtension
+	path := filepath.Join(s.basePath, filename)
+
+	cleanPath := filepath.Clean(path)
+	expectedSuffix := string(filepath.Separator) + filename
+	if !strings.HasSuffix(cleanPath, expectedSuffix) {
+		return ""
+	}
+
+	return cleanPath
+}
@@ -0,0 +1,107 @@
+package agent
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestFileStore_Vault(t *testing.T) {
+	t.Parallel()
+
+	store := setupTestStore(t)
+	seed := []byte("test-seed-32-bytes-long-enough!!")
+
+	if store.HasVault("test-wallet") {
+		t.Fatal("HasVault() = true before InitVault")
+	}
+	if _, err := store.UnlockVault("test-wallet", "store-pass"); !errors.Is(err, ErrVaultNotFound) {
+		t.Fatalf("UnlockVault() error = %v, want ErrVaultNotFound", err)
+	}
+
+	if err := store.InitVault("test-wallet", seed, "store-pass"); err != nil {
+		t.Fatalf("InitVault() error = %v", err)
+	}
+	if !store.HasVault("test-wallet") {
+		t.Fatal("HasVault() = false after InitVault")
+	}
+
+	info, err := os.Stat(store.vaultPath("test-wallet"))
+	if err != nil {
+		t.Fatalf("stat vault: %v", err)
+	}
+	if info.Mode().Perm() != agentFilePermissions {
+		t.Errorf("vault permissions = %o, want %o", info.Mode().Perm(), agentFilePermissions)
+	}
+
+	got, err := store.UnlockVault("test-wallet", "store-pass")
+	if err != nil {
+		t.Fatalf("UnlockVault() error = %v", err)
+	}
+	if !bytes.Equal(got, seed) {
+		t.Errorf("UnlockVault() seed = %q, want %q", got, seed)
+	}
+
+	if _, err := store.UnlockVault("test-wallet", "wrong"); !errors.Is(err, ErrVaultBadPassphrase) {
+		t.Errorf("UnlockVault(wrong) error = %v, want ErrVaultBadPassphrase", err)
+	}
+
+	// The vault is not an agent credential
+	agents, err := store.List("test-wallet")
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(agents) != 0 {
+		t.Errorf("List() = %d agents, want 0", len(agents))
+	}
+}
+
+func TestFileStore_ChangeVaultPassphrase(t *testing.T) {
+	t.Parallel()
+
+	store := setupTestStore(t)
+	seed := []byte("test-seed-32-bytes-long-enough!!")
+	if err := store.InitVault("test-wallet", seed, "old-pass"); err != nil {
+		t.Fatalf("InitVault() error = %v", err)
+	}
+
+	if err := store.ChangeVaultPassphrase("test-wallet", "wrong", "new-pass"); !errors.Is(err, ErrVaultBadPassphrase) {
+		t.Fatalf("ChangeVaultPassphrase(wrong) error = %v, want ErrVaultBadPassphrase", err)
+	}
+	if err := store.ChangeVaultPassphrase("test-wallet", "old-pass", "new-pass"); err != nil {
+		t.Fatalf("ChangeVaultPassphrase() error = %v", err)
+	}
+
+	if _, err := store.UnlockVault("test-wallet", "old-pass"); !errors.Is(err, ErrVaultBadPassphrase) {
+		t.Errorf("old passphrase still unlocks: %v", err)
+	}
+	got, err := store.UnlockVault("test-wallet", "new-pass")
+	if err != nil {
+		t.Fatalf("UnlockVault(new) error = %v", err)
+	}
+	if !bytes.Equal(got, seed) {
+		t.Errorf("seed changed after passphrase change")
+	}
+}
+
+func TestFileStore_DeleteVault(t *testing.T) {
+	t.Parallel()
+
+	store := setupTestStore(t)
+	if err := store.InitVault("test-wallet", []byte("seed"), "pass"); err != nil {
+		t.Fatalf("InitVault() error = %v", err)
+	}
+	if err := store.DeleteVault("test-wallet"); err != nil {
+		t.Fatalf("DeleteVault() error = %v", err)
+	}
+	if store.HasVault("test-wallet") {
+		t.Error("HasVault() = true after DeleteVault")
+	}
+	if err := store.DeleteVault("test-wallet"); !errors.Is(err, ErrVaultNotFound) {
+		t.Errorf("DeleteVault() twice error = %v, want ErrVaultNotFound", err)
+	}
+	if err := store.InitVault("../escape", []byte("seed"), "pass"); !errors.Is(err, ErrInvalidWallet) {
+		t.Errorf("InitVault(bad name) error = %v, want ErrInvalidWallet", err)
+	}
+}
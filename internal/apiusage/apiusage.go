@@ -0,0 +1,562 @@
+// Package apiusage estimates how much of each API key's quota sigil has used.
+//
+// Chain clients for quota-limited providers wrap their HTTP transport with
+// Wrap. Every request is counted per provider and key in hourly buckets, and
+// rate-limit responses and headers are noted. The counts are saved to a small
+// JSON file so the estimate spans commands. When a key passes the warning
+// share of its daily quota, or a provider's rate-limit headers show it nearly
+// spent, a warning is emitted once per process, before requests start failing.
+//
+// Tracking is process-wide and off by default. When it is disabled, Wrap
+// returns the transport it was given.
+package apiusage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// ProviderEtherscan is the Etherscan API.
+	ProviderEtherscan = "etherscan"
+
+	// ProviderWhatsOnChain is the WhatsOnChain API.
+	ProviderWhatsOnChain = "whatsonchain"
+
+	// Anonymous identifies requests made without an API key.
+	Anonymous = "anonymous"
+
+	// Window is the span usage is estimated over.
+	Window = 24 * time.Hour
+
+	// DefaultWarnPercent is the share of a quota at which warnings start.
+	DefaultWarnPercent = 80
+
+	// fileVersion is the current usage file format version.
+	fileVersion = 1
+
+	// fileMode keeps the usage file private; it names key fingerprints.
+	fileMode = 0o600
+)
+
+// ErrUnsupportedVersion indicates the usage file was written by a newer sigil.
+var ErrUnsupportedVersion = errors.New("unsupported usage file version")
+
+// Bucket counts the requests made in one hour.
+type Bucket struct {
+	Hour     time.Time `json:"hour"`
+	Requests int64     `json:"requests"`
+	Limited  int64     `json:"limited,omitempty"`
+}
+
+// KeyUsage is the recorded usage of one API key at one provider.
+type KeyUsage struct {
+	// Provider is the API provider, e.g. ProviderEtherscan.
+	Provider string `json:"provider"`
+
+	// Key is the key's Fingerprint, or Anonymous.
+	Key string `json:"key"`
+
+	// Buckets are hourly counts, oldest first, covering at most Window.
+	Buckets []Bucket `json:"buckets"`
+
+	// LastRequest is when the key was last used.
+	LastRequest time.Time `json:"last_request"`
+
+	// LastLimited is when the provider last refused a request for rate.
+	LastLimited time.Time `json:"last_limited,omitzero"`
+
+	// HeaderLimit and HeaderRemaining are the last rate-limit headers seen,
+	// at HeaderAt. HeaderAt is zero when the provider sends none.
+	HeaderLimit     int64     `json:"header_limit,omitempty"`
+	HeaderRemaining int64     `json:"header_remaining,omitempty"`
+	HeaderAt        time.Time `json:"header_at,omitzero"`
+}
+
+// usageFile is the on-disk format.
+type usageFile struct {
+	Version int         `json:"version"`
+	Keys    []*KeyUsage `json:"keys"`
+}
+
+// Options configures a Tracker.
+type Options struct {
+	// Path is the usage file. Empty keeps counts in memory only.
+	Path string
+
+	// Quotas are daily request quotas per provider. A provider without one
+	// is counted but only warned about from its rate-limit headers.
+	Quotas map[string]int
+
+	// WarnPercent is the share of a quota at which to warn. Zero means
+	// DefaultWarnPercent.
+	WarnPercent int
+
+	// Warn receives warnings. Nil discards them.
+	Warn func(format string, args ...any)
+
+	// Now returns the current time. Nil means time.Now.
+	Now func() time.Time
+}
+
+// Tracker counts requests per provider and key.
+type Tracker struct {
+	opts Options
+
+	mu sync.Mutex
+	// saved is the usage read from the file when the tracker was created.
+	saved map[string]*KeyUsage
+	// recorded is the usage added by this process, merged on Save.
+	recorded map[string]*KeyUsage
+	// warned holds the keys already warned about.
+	warned map[string]bool
+}
+
+// New creates a tracker, reading existing counts from opts.Path. A missing
+// file starts empty; an unreadable or corrupt one is returned as an error
+// along with an empty tracker, which replaces the file on Save.
+func New(opts Options) (*Tracker, error) {
+	if opts.WarnPercent <= 0 {
+		opts.WarnPercent = DefaultWarnPercent
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	t := &Tracker{
+		opts:     opts,
+		saved:    map[string]*KeyUsage{},
+		recorded: map[string]*KeyUsage{},
+		warned:   map[string]bool{},
+	}
+	if opts.Path == "" {
+		return t, nil
+	}
+
+	keys, err := Load(opts.Path)
+	for _, u := range keys {
+		t.saved[id(u.Provider, u.Key)] = u
+	}
+	return t, err
+}
+
+// Path returns the usage file under the sigil home directory.
+func Path(home string) string {
+	return filepath.Join(home, "cache", "api_usage.json")
+}
+
+// Load reads the usage file at path. A missing file has no usage.
+func Load(path string) ([]*KeyUsage, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the sigil home directory
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading usage file: %w", err)
+	}
+
+	var f usageFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parsing usage file: %w", err)
+	}
+	if f.Version > fileVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, f.Version)
+	}
+	return f.Keys, nil
+}
+
+// Fingerprint identifies an API key without revealing it: the first 8 hex
+// digits of its SHA-256 hash, or Anonymous for an empty key.
+func Fingerprint(apiKey string) string {
+	if apiKey == "" {
+		return Anonymous
+	}
+	sum := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(sum[:4])
+}
+
+// Wrap returns rt with every request counted against provider and apiKey.
+// A nil rt means http.DefaultTransport.
+func (t *Tracker) Wrap(rt http.RoundTripper, provider, apiKey string) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &Transport{base: rt, tracker: t, provider: provider, key: Fingerprint(apiKey)}
+}
+
+// RecordLimited notes a rate-limit refusal that the provider reported in a
+// successful HTTP response, such as Etherscan's "Max rate limit reached".
+func (t *Tracker) RecordLimited(provider, apiKey string) {
+	t.mu.Lock()
+	u := t.recordedUsage(provider, Fingerprint(apiKey))
+	now := t.opts.Now()
+	u.bucket(now).Limited++
+	u.LastLimited = now
+	t.mu.Unlock()
+}
+
+// observe counts one request and its response, then warns if the key is
+// close to its quota.
+func (t *Tracker) observe(provider, key string, resp *http.Response) {
+	now := t.opts.Now()
+
+	t.mu.Lock()
+	u := t.recordedUsage(provider, key)
+	b := u.bucket(now)
+	b.Requests++
+	u.LastRequest = now
+	if resp != nil {
+		if resp.StatusCode == http.StatusTooManyRequests {
+			b.Limited++
+			u.LastLimited = now
+		}
+		if limit, remaining, ok := rateLimitHeaders(resp.Header); ok {
+			u.HeaderLimit, u.HeaderRemaining, u.HeaderAt = limit, remaining, now
+		}
+	}
+
+	var msg string
+	if k := id(provider, key); !t.warned[k] {
+		if msg = t.warning(t.combined(provider, key), now); msg != "" {
+			t.warned[k] = true
+		}
+	}
+	t.mu.Unlock()
+
+	if msg != "" && t.opts.Warn != nil {
+		t.opts.Warn("%s", msg)
+	}
+}
+
+// warning returns the warning for u, or "" when it is below the threshold.
+func (t *Tracker) warning(u *KeyUsage, now time.Time) string {
+	name := DisplayName(u.Provider)
+	if quota := t.opts.Quotas[u.Provider]; quota > 0 {
+		used := u.Requests(now)
+		if pct := used * 100 / int64(quota); pct >= int64(t.opts.WarnPercent) {
+			return fmt.Sprintf("~%d%% of daily %s quota used (%d of %d requests in the last 24h, key %s); see sigil provider usage",
+				pct, name, used, quota, u.Key)
+		}
+	}
+	if u.HeaderLimit > 0 && !u.HeaderAt.IsZero() {
+		spent := u.HeaderLimit - u.HeaderRemaining
+		if pct := spent * 100 / u.HeaderLimit; pct >= int64(t.opts.WarnPercent) {
+			return fmt.Sprintf("~%d%% of %s rate limit used (%d of %d requests left, key %s)",
+				pct, name, u.HeaderRemaining, u.HeaderLimit, u.Key)
+		}
+	}
+	return ""
+}
+
+// Usage returns the saved and recorded usage of every key, sorted by
+// provider and key.
+func (t *Tracker) Usage() []*KeyUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	seen := map[string]bool{}
+	var all []*KeyUsage
+	for _, m := range []map[string]*KeyUsage{t.saved, t.recorded} {
+		for k, u := range m {
+			if !seen[k] {
+				seen[k] = true
+				all = append(all, t.combined(u.Provider, u.Key))
+			}
+		}
+	}
+	sortUsage(all)
+	return all
+}
+
+// Save merges the usage recorded by this process into the usage file. The
+// file is read again first, so counts from commands that ran meanwhile are
+// kept. Buckets older than Window are dropped.
+func (t *Tracker) Save() error {
+	if t.opts.Path == "" {
+		return nil
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.recorded) == 0 {
+		return nil
+	}
+
+	current, err := Load(t.opts.Path)
+	if err != nil && !errors.Is(err, ErrUnsupportedVersion) {
+		// A corrupt file is replaced rather than blocking every command.
+		current = nil
+	} else if err != nil {
+		return err
+	}
+
+	byID := make(map[string]*KeyUsage, len(current)+len(t.recorded))
+	for _, u := range current {
+		byID[id(u.Provider, u.Key)] = u
+	}
+	for k, r := range t.recorded {
+		if u, ok := byID[k]; ok {
+			u.merge(r)
+		} else {
+			byID[k] = r.clone()
+		}
+	}
+
+	now := t.opts.Now()
+	keys := make([]*KeyUsage, 0, len(byID))
+	for _, u := range byID {
+		u.prune(now)
+		keys = append(keys, u)
+	}
+	sortUsage(keys)
+
+	data, err := json.MarshalIndent(usageFile{Version: fileVersion, Keys: keys}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding usage file: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(t.opts.Path), 0o700); err != nil {
+		return fmt.Errorf("creating usage directory: %w", err)
+	}
+	if err := fileutil.WriteAtomic(t.opts.Path, data, fileMode); err != nil {
+		return fmt.Errorf("writing usage file: %w", err)
+	}
+
+	t.recorded = map[string]*KeyUsage{}
+	for k, u := range byID {
+		t.saved[k] = u
+	}
+	return nil
+}
+
+// recordedUsage returns this process's usage of a key, creating it.
+// The caller must hold t.mu.
+func (t *Tracker) recordedUsage(provider, key string) *KeyUsage {
+	k := id(provider, key)
+	u, ok := t.recorded[k]
+	if !ok {
+		u = &KeyUsage{Provider: provider, Key: key}
+		t.recorded[k] = u
+	}
+	return u
+}
+
+// combined returns the saved and recorded usage of a key merged into a copy.
+// The caller must hold t.mu.
+func (t *Tracker) combined(provider, key string) *KeyUsage {
+	k := id(provider, key)
+	u := &KeyUsage{Provider: provider, Key: key}
+	if s, ok := t.saved[k]; ok {
+		u.merge(s)
+	}
+	if r, ok := t.recorded[k]; ok {
+		u.merge(r)
+	}
+	return u
+}
+
+// Requests returns the requests made in the Window before now.
+func (u *KeyUsage) Requests(now time.Time) int64 {
+	var n int64
+	for _, b := range u.Buckets {
+		if inWindow(b.Hour, now) {
+			n += b.Requests
+		}
+	}
+	return n
+}
+
+// Limited returns the rate-limit refusals in the Window before now.
+func (u *KeyUsage) Limited(now time.Time) int64 {
+	var n int64
+	for _, b := range u.Buckets {
+		if inWindow(b.Hour, now) {
+			n += b.Limited
+		}
+	}
+	return n
+}
+
+// bucket returns the bucket for the hour of now, appending it if needed.
+func (u *KeyUsage) bucket(now time.Time) *Bucket {
+	hour := now.UTC().Truncate(time.Hour)
+	for i := range u.Buckets {
+		if u.Buckets[i].Hour.Equal(hour) {
+			return &u.Buckets[i]
+		}
+	}
+	u.Buckets = append(u.Buckets, Bucket{Hour: hour})
+	sort.Slice(u.Buckets, func(i, j int) bool { return u.Buckets[i].Hour.Before(u.Buckets[j].Hour) })
+	for i := range u.Buckets {
+		if u.Buckets[i].Hour.Equal(hour) {
+			return &u.Buckets[i]
+		}
+	}
+	return nil // unreachable: the bucket was just appended
+}
+
+// merge adds other's counts to u and keeps the latest timestamps.
+func (u *KeyUsage) merge(other *KeyUsage) {
+	for _, b := range other.Buckets {
+		dst := u.bucket(b.Hour)
+		dst.Requests += b.Requests
+		dst.Limited += b.Limited
+	}
+	if other.LastRequest.After(u.LastRequest) {
+		u.LastRequest = other.LastRequest
+	}
+	if other.LastLimited.After(u.LastLimited) {
+		u.LastLimited = other.LastLimited
+	}
+	if other.HeaderAt.After(u.HeaderAt) {
+		u.HeaderLimit, u.HeaderRemaining, u.HeaderAt = other.HeaderLimit, other.HeaderRemaining, other.HeaderAt
+	}
+}
+
+// clone returns a deep copy of u.
+func (u *KeyUsage) clone() *KeyUsage {
+	c := *u
+	c.Buckets = append([]Bucket(nil), u.Buckets...)
+	return &c
+}
+
+// prune drops buckets that have left the Window.
+func (u *KeyUsage) prune(now time.Time) {
+	kept := u.Buckets[:0]
+	for _, b := range u.Buckets {
+		if inWindow(b.Hour, now) {
+			kept = append(kept, b)
+		}
+	}
+	u.Buckets = kept
+}
+
+// inWindow reports whether the bucket starting at hour overlaps the Window
+// before now.
+func inWindow(hour, now time.Time) bool {
+	return hour.Add(time.Hour).After(now.Add(-Window))
+}
+
+// DisplayName returns a provider's display name.
+func DisplayName(provider string) string {
+	switch provider {
+	case ProviderEtherscan:
+		return "Etherscan"
+	case ProviderWhatsOnChain:
+		return "WhatsOnChain"
+	default:
+		return provider
+	}
+}
+
+// rateLimitHeaders reads X-RateLimit-Limit and X-RateLimit-Remaining, or
+// their unprefixed RateLimit-* forms.
+func rateLimitHeaders(h http.Header) (limit, remaining int64, ok bool) {
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		l, lok := headerInt(h, prefix+"Limit")
+		r, rok := headerInt(h, prefix+"Remaining")
+		if lok && rok && l > 0 {
+			return l, r, true
+		}
+	}
+	return 0, 0, false
+}
+
+// headerInt parses the leading integer of a header such as "100" or
+// "100, 100;w=60".
+func headerInt(h http.Header, name string) (int64, bool) {
+	v := strings.TrimSpace(h.Get(name))
+	if end := strings.IndexFunc(v, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+		v = v[:end]
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	return n, err == nil
+}
+
+// id is the map key of a provider and key fingerprint.
+func id(provider, key string) string {
+	return provider + "/" + key
+}
+
+// sortUsage orders usage by provider, then key.
+func sortUsage(keys []*KeyUsage) {
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Provider != keys[j].Provider {
+			return keys[i].Provider < keys[j].Provider
+		}
+		return keys[i].Key < keys[j].Key
+	})
+}
+
+// Transport is an http.RoundTripper that counts requests against an API key.
+type Transport struct {
+	base     http.RoundTripper
+	tracker  *Tracker
+	provider string
+	key      string
+}
+
+// RoundTrip implements http.RoundTripper. A request that fails in transit is
+// still counted, since the provider may have received it.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	t.tracker.observe(t.provider, t.key, resp)
+	return resp, err
+}
+
+//nolint:gochecknoglobals // Process-wide tracker, set once at startup
+var active atomic.Pointer[Tracker]
+
+// Enable makes t the process-wide tracker used by Wrap and RecordLimited.
+func Enable(t *Tracker) {
+	active.Store(t)
+}
+
+// Disable turns off process-wide tracking.
+func Disable() {
+	active.Store(nil)
+}
+
+// Active returns the process-wide tracker, or nil when tracking is off.
+func Active() *Tracker {
+	return active.Load()
+}
+
+// Wrap returns rt counted by the process-wide tracker when tracking is
+// enabled, or rt itself otherwise.
+func Wrap(rt http.RoundTripper, provider, apiKey string) http.RoundTripper {
+	t := active.Load()
+	if t == nil {
+		return rt
+	}
+	return t.Wrap(rt, provider, apiKey)
+}
+
+// RecordLimited notes a rate-limit refusal with the process-wide tracker,
+// if tracking is enabled.
+func RecordLimited(provider, apiKey string) {
+	if t := active.Load(); t != nil {
+		t.RecordLimited(provider, apiKey)
+	}
+}
+
+// Save saves the process-wide tracker's counts, if tracking is enabled.
+func Save() error {
+	if t := active.Load(); t != nil {
+		return t.Save()
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package apiusage
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testClock is a settable Options.Now.
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time { return c.now }
+
+func newTestServer(t *testing.T, handler http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func get(t *testing.T, client *http.Client, url string) int {
+	t.Helper()
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, Anonymous, Fingerprint(""))
+	fp := Fingerprint("secret-key")
+	assert.Len(t, fp, 8)
+	assert.NotContains(t, fp, "secret")
+	assert.Equal(t, fp, Fingerprint("secret-key"))
+	assert.NotEqual(t, fp, Fingerprint("other-key"))
+}
+
+func TestTracker_WarnsNearQuota(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	clock := &testClock{now: time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)}
+	var warnings []string
+	tr, err := New(Options{
+		Quotas: map[string]int{ProviderEtherscan: 10},
+		Warn:   func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) },
+		Now:    clock.Now,
+	})
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: tr.Wrap(nil, ProviderEtherscan, "key")}
+	for range 7 {
+		get(t, client, srv.URL)
+	}
+	assert.Empty(t, warnings)
+
+	get(t, client, srv.URL)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "~80% of daily Etherscan quota used (8 of 10 requests")
+
+	// Warned once per process.
+	get(t, client, srv.URL)
+	assert.Len(t, warnings, 1)
+
+	usage := tr.Usage()
+	require.Len(t, usage, 1)
+	assert.Equal(t, int64(9), usage[0].Requests(clock.now))
+	assert.Equal(t, Fingerprint("key"), usage[0].Key)
+}
+
+func TestTracker_RateLimits(t *testing.T) {
+	t.Parallel()
+
+	remaining := 10
+	srv := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		remaining--
+		w.Header().Set("X-RateLimit-Limit", "10")
+		w.Header().Set("X-RateLimit-Remaining", fmt.Sprint(remaining))
+		if remaining < 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	})
+	clock := &testClock{now: time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)}
+	var warnings []string
+	tr, err := New(Options{
+		Warn: func(format string, args ...any) { warnings = append(warnings, fmt.Sprintf(format, args...)) },
+		Now:  clock.Now,
+	})
+	require.NoError(t, err)
+
+	client := &http.Client{Transport: tr.Wrap(nil, ProviderWhatsOnChain, "")}
+	for range 9 {
+		get(t, client, srv.URL)
+	}
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "~80% of WhatsOnChain rate limit used (2 of 10 requests left, key anonymous)")
+
+	tr.RecordLimited(ProviderWhatsOnChain, "")
+	u := tr.Usage()[0]
+	assert.Equal(t, int64(9), u.Requests(clock.now))
+	assert.Equal(t, int64(2), u.Limited(clock.now))
+	assert.Equal(t, int64(1), u.HeaderRemaining)
+	assert.Equal(t, clock.now, u.LastLimited)
+}
+
+func TestTracker_SaveMergesAndPrunes(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	path := filepath.Join(t.TempDir(), "cache", "api_usage.json")
+	start := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+
+	// Two commands run side by side, each counting its own requests.
+	clock := &testClock{now: start}
+	first, err := New(Options{Path: path, Now: clock.Now})
+	require.NoError(t, err)
+	second, err := New(Options{Path: path, Now: clock.Now})
+	require.NoError(t, err)
+
+	get(t, &http.Client{Transport: first.Wrap(nil, ProviderEtherscan, "key")}, srv.URL)
+	get(t, &http.Client{Transport: first.Wrap(nil, ProviderEtherscan, "key")}, srv.URL)
+	get(t, &http.Client{Transport: second.Wrap(nil, ProviderEtherscan, "key")}, srv.URL)
+	require.NoError(t, first.Save())
+	require.NoError(t, second.Save())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	keys, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, int64(3), keys[0].Requests(start))
+
+	// A day later the old hour has left the window and is dropped on save.
+	clock.now = start.Add(25 * time.Hour)
+	third, err := New(Options{Path: path, Now: clock.Now})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), third.Usage()[0].Requests(clock.now))
+	get(t, &http.Client{Transport: third.Wrap(nil, ProviderEtherscan, "key")}, srv.URL)
+	require.NoError(t, third.Save())
+
+	keys, err = Load(path)
+	require.NoError(t, err)
+	require.Len(t, keys[0].Buckets, 1)
+	assert.Equal(t, int64(1), keys[0].Buckets[0].Requests)
+}
+
+func TestNew_CorruptFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "api_usage.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+
+	tr, err := New(Options{Path: path})
+	require.Error(t, err)
+	require.NotNil(t, tr)
+
+	tr.RecordLimited(ProviderEtherscan, "key")
+	require.NoError(t, tr.Save())
+	keys, err := Load(path)
+	require.NoError(t, err)
+	assert.Len(t, keys, 1)
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+
+	h := http.Header{}
+	_, _, ok := rateLimitHeaders(h)
+	assert.False(t, ok)
+
+	h.Set("RateLimit-Limit", "100, 100;w=60")
+	h.Set("RateLimit-Remaining", "42")
+	limit, remaining, ok := rateLimitHeaders(h)
+	require.True(t, ok)
+	assert.Equal(t, int64(100), limit)
+	assert.Equal(t, int64(42), remaining)
+}
+
+//nolint:paralleltest // Uses the process-wide tracker
+func TestWrap_Disabled(t *testing.T) {
+	Disable()
+	rt := http.DefaultTransport
+	assert.Same(t, rt, Wrap(rt, ProviderEtherscan, "key"))
+	RecordLimited(ProviderEtherscan, "key")
+	require.NoError(t, Save())
+
+	tr, err := New(Options{})
+	require.NoError(t, err)
+	Enable(tr)
+	t.Cleanup(Disable)
+	assert.IsType(t, &Transport{}, Wrap(rt, ProviderEtherscan, "key"))
+	assert.Same(t, tr, Active())
+}
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"time"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 
 	"github.com/mrz1836/sigil/internal/fileutil"
 	"github.com/mrz1836/sigil/internal/sigilcrypto"
@@ -23,6 +26,26 @@ const (
 	BackupFilePermissions = 0o600
 )
 
+// backupSuffixRegex matches what follows "<wallet>-" in a backup file name:
+// a short content hash, or the timestamp of a legacy backup.
+var backupSuffixRegex = regexp.MustCompile(`^([0-9a-f]{16}|\d{4}-\d{2}-\d{2}-\d{6})\.sigil$`)
+
+// ChainEntry is one backup file of a wallet's manifest chain.
+type ChainEntry struct {
+	// Path is the path of the backup file.
+	Path string
+
+	// Backup is the backup read from Path, or nil if it could not be read.
+	Backup *Backup
+
+	// Err is why the backup failed verification, or nil.
+	Err error
+
+	// Gap reports that the previous backup the entry links to is not in
+	// the backup directory: it was moved, deleted, or is unreadable.
+	Gap bool
+}
+
 // Service provides backup operations.
 type Service struct {
 	backupDir string
@@ -83,8 +106,13 @@ func (s *Service) Create(walletName string, password []byte) (*Backup, string, e
 		chains = append(chains, string(chain))
 	}
 
-	// Create manifest
+	// Create manifest, linked to the wallet's latest backup
 	manifest := NewManifest(walletName, chains, addressCount)
+	chain, err := s.Chain(walletName)
+	if err != nil {
+		return nil, "", err
+	}
+	manifest.LinkTo(chainHead(chain))
 
 	// Create backup
 	backup := NewBackup(manifest, encryptedData)
@@ -112,7 +140,8 @@ func (s *Service) Verify(backupPath string) (*Manifest, error) {
 	return &backup.Manifest, nil
 }
 
-// VerifyWithDecryption verifies a backup and tests decryption.
+// VerifyWithDecryption verifies a backup, then decrypts it and checks that
+// its wallet data parses and matches the manifest, without restoring it.
 // The password should be zeroed by the caller after this call returns.
 func (s *Service) VerifyWithDecryption(backupPath string, password []byte) (*Manifest, error) {
 	backup, err := s.readBackup(backupPath)
@@ -125,10 +154,14 @@ func (s *Service) VerifyWithDecryption(backupPath string, password []byte) (*Man
 		return nil, validationErr
 	}
 
-	// Test decryption
-	_, err = sigilcrypto.Decrypt(backup.EncryptedData, string(password))
+	walletData, wlt, err := openBackup(backup, password)
 	if err != nil {
-		return nil, ErrDecryptionFailed
+		return nil, err
+	}
+	defer wallet.ZeroBytes(walletData.Seed)
+
+	if err := checkContents(&backup.Manifest, walletData, wlt); err != nil {
+		return nil, err
 	}
 
 	return &backup.Manifest, nil
@@ -148,33 +181,19 @@ func (s *Service) Restore(backupPath string, password []byte, newWalletName stri
 		return validationErr
 	}
 
-	// Decrypt data
-	decrypted, err := sigilcrypto.Decrypt(backup.EncryptedData, string(password))
+	walletData, wlt, err := openBackup(backup, password)
 	if err != nil {
-		return ErrDecryptionFailed
-	}
-	defer wallet.ZeroBytes(decrypted)
-
-	// Parse wallet data
-	var walletData WalletData
-	if err := json.Unmarshal(decrypted, &walletData); err != nil {
-		return fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+		return err
 	}
 	defer wallet.ZeroBytes(walletData.Seed)
 
-	// Parse wallet
-	var wlt wallet.Wallet
-	if err := json.Unmarshal(walletData.WalletJSON, &wlt); err != nil {
-		return fmt.Errorf("%w: %w", ErrInvalidFormat, err)
-	}
-
 	// Use new name if provided
 	if newWalletName != "" {
 		wlt.Name = newWalletName
 	}
 
 	// Save the restored wallet
-	if err := s.storage.Save(&wlt, walletData.Seed, password); err != nil {
+	if err := s.storage.Save(wlt, walletData.Seed, password); err != nil {
 		return fmt.Errorf("saving restored wallet: %w", err)
 	}
 
@@ -205,6 +224,124 @@ func (s *Service) List() ([]string, error) {
 	return backups, nil
 }
 
+// Chain returns the backups of a wallet in the backup directory in chain
+// order: legacy backups first, then by Sequence, and unreadable files last.
+// Files that cannot be read are attributed to the wallet by their name.
+//
+//nolint:gocognit // Reading, attributing, ordering, and linking the files
+func (s *Service) Chain(walletName string) ([]ChainEntry, error) {
+	names, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []ChainEntry
+	for _, name := range names {
+		path := s.BackupPath(name)
+		backup, readErr := s.readBackup(path)
+		switch {
+		case readErr != nil:
+			if suffix, ok := strings.CutPrefix(name, walletName+"-"); ok && backupSuffixRegex.MatchString(suffix) {
+				entries = append(entries, ChainEntry{Path: path, Err: readErr})
+			}
+		case backup.Manifest.WalletName == walletName:
+			entries = append(entries, ChainEntry{Path: path, Backup: backup, Err: backup.Validate()})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i].Backup, entries[j].Backup
+		if a == nil || b == nil {
+			return b == nil && a != nil
+		}
+		if a.Manifest.Sequence != b.Manifest.Sequence {
+			return a.Manifest.Sequence < b.Manifest.Sequence
+		}
+		return a.Manifest.CreatedAt.Before(b.Manifest.CreatedAt)
+	})
+
+	valid := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if e.Err == nil && e.Backup.Hash != "" {
+			valid[e.Backup.Hash] = true
+		}
+	}
+	for i := range entries {
+		if b := entries[i].Backup; b != nil && b.Manifest.Previous != "" {
+			entries[i].Gap = !valid[b.Manifest.Previous]
+		}
+	}
+	return entries, nil
+}
+
+// chainHead returns the latest intact backup of a chain to link the next
+// backup to, or nil to start a new chain.
+func chainHead(entries []ChainEntry) *Backup {
+	var head *Backup
+	for _, e := range entries {
+		if e.Err != nil || e.Backup.Version != BackupVersion {
+			continue
+		}
+		if head == nil || e.Backup.Manifest.Sequence > head.Manifest.Sequence {
+			head = e.Backup
+		}
+	}
+	return head
+}
+
+// openBackup decrypts a backup and parses its wallet data. The caller
+// should zero the returned seed.
+func openBackup(backup *Backup, password []byte) (*WalletData, *wallet.Wallet, error) {
+	decrypted, err := sigilcrypto.Decrypt(backup.EncryptedData, string(password))
+	if err != nil {
+		return nil, nil, ErrDecryptionFailed
+	}
+	defer wallet.ZeroBytes(decrypted)
+
+	var walletData WalletData
+	if err := json.Unmarshal(decrypted, &walletData); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	var wlt wallet.Wallet
+	if err := json.Unmarshal(walletData.WalletJSON, &wlt); err != nil {
+		wallet.ZeroBytes(walletData.Seed)
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	return &walletData, &wlt, nil
+}
+
+// checkContents checks that decrypted wallet data is complete and matches
+// the manifest it was backed up with.
+func checkContents(manifest *Manifest, walletData *WalletData, wlt *wallet.Wallet) error {
+	if len(walletData.Seed) == 0 {
+		return fmt.Errorf("%w: no seed", ErrContentMismatch)
+	}
+	if wlt.Name != manifest.WalletName {
+		return fmt.Errorf("%w: wallet name %q, manifest has %q", ErrContentMismatch, wlt.Name, manifest.WalletName)
+	}
+
+	chains := make([]string, 0, len(wlt.EnabledChains))
+	for _, c := range wlt.EnabledChains {
+		chains = append(chains, string(c))
+	}
+	if !slices.Equal(chains, manifest.Chains) {
+		return fmt.Errorf("%w: chains %v, manifest has %v", ErrContentMismatch, chains, manifest.Chains)
+	}
+
+	for c, addrs := range wlt.Addresses {
+		if manifest.AddressCount[string(c)] != len(addrs) {
+			return fmt.Errorf("%w: %d %s addresses, manifest has %d",
+				ErrContentMismatch, len(addrs), c, manifest.AddressCount[string(c)])
+		}
+	}
+	if len(wlt.Addresses) != len(manifest.AddressCount) {
+		return fmt.Errorf("%w: address chains differ from manifest", ErrContentMismatch)
+	}
+	return nil
+}
+
 // writeBackup writes a backup to the backup directory.
 //
 //nolint:funcorder // Keeping helper methods together
@@ -214,10 +351,8 @@ func (s *Service) writeBackup(backup *Backup) (string, error) {
 		return "", fmt.Errorf("creating backup directory: %w", err)
 	}
 
-	// Generate filename
-	timestamp := time.Now().Format("2006-01-02-150405")
-	filename := fmt.Sprintf("%s-%s%s", backup.Manifest.WalletName, timestamp, BackupExtension)
-	backupPath := filepath.Join(s.backupDir, filename)
+	// Name the file by its content hash
+	backupPath := filepath.Join(s.backupDir, backup.FileName())
 
 	// Serialize backup
 	data, err := json.MarshalIndent(backup, "", "  ")
@@ -204,6 +204,29 @@ func TestBackup_Validate(t *testing.T) {
 	})
 }
 
+func TestBackup_Validate_ContentHash(t *testing.T) {
+	t.Parallel()
+
+	manifest := backup.NewManifest("wallet", []string{"eth"}, map[string]int{"eth": 1})
+	b := backup.NewBackup(manifest, []byte("data"))
+	require.Len(t, b.Hash, 64)
+	assert.Equal(t, "wallet-"+b.Hash[:16]+".sigil", b.FileName())
+
+	t.Run("tampered manifest fails", func(t *testing.T) {
+		t.Parallel()
+		tampered := *b
+		tampered.Manifest.AddressCount = map[string]int{"eth": 2}
+		require.ErrorIs(t, tampered.Validate(), backup.ErrBackupCorrupted)
+	})
+
+	t.Run("legacy backup without hash passes", func(t *testing.T) {
+		t.Parallel()
+		legacy := *b
+		legacy.Version, legacy.Hash = backup.LegacyBackupVersion, ""
+		assert.NoError(t, legacy.Validate())
+	})
+}
+
 // --- backup.go Service tests ---
 
 func TestNewService(t *testing.T) {
@@ -371,6 +394,100 @@ func TestService_VerifyWithDecryption(t *testing.T) {
 	})
 }
 
+func TestService_VerifyWithDecryption_ContentMismatch(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	w, seed := testWallet(t)
+	svc := backup.NewService(tmpDir, &mockStorage{wallet: w, seed: seed})
+	password := []byte("test-password-123") // gitleaks:allow
+
+	b, _, err := svc.Create("testwallet", password)
+	require.NoError(t, err)
+
+	// A well-formed backup whose manifest does not describe its contents
+	manifest := b.Manifest
+	manifest.AddressCount = map[string]int{"eth": 5, "bsv": 1}
+	forged := backup.NewBackup(manifest, b.EncryptedData)
+	data, err := json.Marshal(forged)
+	require.NoError(t, err)
+	forgedPath := filepath.Join(tmpDir, "forged.sigil")
+	require.NoError(t, os.WriteFile(forgedPath, data, 0o600))
+
+	_, err = svc.Verify(forgedPath)
+	require.NoError(t, err, "the structure alone is intact")
+	_, err = svc.VerifyWithDecryption(forgedPath, password)
+	require.ErrorIs(t, err, backup.ErrContentMismatch)
+}
+
+func TestService_Chain(t *testing.T) {
+	t.Parallel()
+
+	tmpDir := t.TempDir()
+	w, seed := testWallet(t)
+	svc := backup.NewService(tmpDir, &mockStorage{wallet: w, seed: seed})
+	password := []byte("test-password-123") // gitleaks:allow
+
+	paths := make([]string, 3)
+	backups := make([]*backup.Backup, 3)
+	for i := range backups {
+		var err error
+		backups[i], paths[i], err = svc.Create("testwallet", password)
+		require.NoError(t, err)
+		assert.Equal(t, backups[i].FileName(), filepath.Base(paths[i]), "files are named by content hash")
+		assert.Equal(t, i+1, backups[i].Manifest.Sequence)
+	}
+	assert.Empty(t, backups[0].Manifest.Previous)
+	assert.Equal(t, backups[0].Hash, backups[1].Manifest.Previous)
+	assert.Equal(t, backups[1].Hash, backups[2].Manifest.Previous)
+
+	// Another wallet's backups are not part of the chain
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, "other-0123456789abcdef.sigil"), []byte("{}"), 0o600))
+
+	chain, err := svc.Chain("testwallet")
+	require.NoError(t, err)
+	require.Len(t, chain, 3)
+	for i, e := range chain {
+		require.NoError(t, e.Err)
+		assert.Equal(t, paths[i], e.Path)
+		assert.False(t, e.Gap)
+	}
+
+	// Bit-rot in the middle backup breaks its link from the last one
+	data, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	raw["manifest"].(map[string]any)["chains"] = []string{"eth"}
+	data, err = json.Marshal(raw)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(paths[1], data, 0o600))
+
+	chain, err = svc.Chain("testwallet")
+	require.NoError(t, err)
+	require.Len(t, chain, 3)
+	require.ErrorIs(t, chain[1].Err, backup.ErrBackupCorrupted)
+	assert.True(t, chain[2].Gap)
+
+	// An unreadable backup is still attributed by its name, and a deleted
+	// one leaves a gap
+	require.NoError(t, os.WriteFile(paths[1], []byte("garbage"), 0o600))
+	require.NoError(t, os.Remove(paths[0]))
+	chain, err = svc.Chain("testwallet")
+	require.NoError(t, err)
+	require.Len(t, chain, 2)
+	assert.Equal(t, paths[2], chain[0].Path)
+	assert.True(t, chain[0].Gap)
+	require.ErrorIs(t, chain[1].Err, backup.ErrInvalidFormat)
+	assert.Nil(t, chain[1].Backup)
+
+	// The next backup links to the latest intact one
+	next, _, err := svc.Create("testwallet", password)
+	require.NoError(t, err)
+	assert.Equal(t, 4, next.Manifest.Sequence)
+	assert.Equal(t, backups[2].Hash, next.Manifest.Previous)
+}
+
 func TestService_Restore(t *testing.T) {
 	t.Parallel()
 
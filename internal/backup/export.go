@@ -0,0 +1,154 @@
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/sigilcrypto"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+const (
+	// ExportFormat identifies a wallet export bundle.
+	ExportFormat = "sigil-wallet-export"
+
+	// ExportVersion is the current wallet export format version.
+	ExportVersion = 1
+
+	// ExportKDF names the passphrase key derivation of an export. The data is
+	// age encrypted to a scrypt recipient.
+	ExportKDF = "scrypt"
+)
+
+// ErrNotExport indicates the data is not a wallet export bundle.
+var ErrNotExport = errors.New("not a sigil wallet export")
+
+// Export is an encrypted, portable bundle of a wallet's seed and metadata.
+// Unlike a Backup, it is encrypted with a passphrase chosen for the export
+// rather than the wallet password, so the bundle can move between machines
+// without sharing the password of either wallet file.
+type Export struct {
+	// Format is always ExportFormat.
+	Format string `json:"format"`
+
+	// Version is the export format version.
+	Version int `json:"version"`
+
+	// WalletName is the name of the exported wallet.
+	WalletName string `json:"wallet_name"`
+
+	// CreatedAt is when the export was made.
+	CreatedAt time.Time `json:"created_at"`
+
+	// Chains lists the wallet's enabled chains.
+	Chains []string `json:"chains"`
+
+	// KDF is the passphrase key derivation, always ExportKDF.
+	KDF string `json:"kdf"`
+
+	// EncryptedData is the encrypted WalletData.
+	EncryptedData []byte `json:"encrypted_data"`
+
+	// Checksum is the SHA256 hash of EncryptedData.
+	Checksum string `json:"checksum"`
+}
+
+// NewExport encrypts the wallet and its seed with passphrase.
+// The seed and passphrase should be zeroed by the caller after this call returns.
+func NewExport(wlt *wallet.Wallet, seed, passphrase []byte) (*Export, error) {
+	if wlt == nil {
+		return nil, wallet.ErrNilWallet
+	}
+
+	walletJSON, err := json.Marshal(wlt)
+	if err != nil {
+		return nil, fmt.Errorf("serializing wallet: %w", err)
+	}
+	defer wallet.ZeroBytes(walletJSON)
+
+	dataJSON, err := json.Marshal(WalletData{Seed: seed, WalletJSON: walletJSON})
+	if err != nil {
+		return nil, fmt.Errorf("serializing export data: %w", err)
+	}
+	defer wallet.ZeroBytes(dataJSON)
+
+	encrypted, err := sigilcrypto.Encrypt(dataJSON, string(passphrase))
+	if err != nil {
+		return nil, fmt.Errorf("encrypting export: %w", err)
+	}
+
+	chains := make([]string, 0, len(wlt.EnabledChains))
+	for _, c := range wlt.EnabledChains {
+		chains = append(chains, string(c))
+	}
+
+	return &Export{
+		Format:        ExportFormat,
+		Version:       ExportVersion,
+		WalletName:    wlt.Name,
+		CreatedAt:     time.Now().UTC(),
+		Chains:        chains,
+		KDF:           ExportKDF,
+		EncryptedData: encrypted,
+		Checksum:      CalculateChecksum(encrypted),
+	}, nil
+}
+
+// ParseExport decodes and validates an export bundle.
+func ParseExport(data []byte) (*Export, error) {
+	var e Export
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Validate checks the export for consistency without decrypting it.
+func (e *Export) Validate() error {
+	if e.Format != ExportFormat {
+		return ErrNotExport
+	}
+	if e.Version != ExportVersion {
+		return fmt.Errorf("%w: unsupported export version %d", ErrInvalidFormat, e.Version)
+	}
+	if e.KDF != ExportKDF {
+		return fmt.Errorf("%w: unsupported key derivation %q", ErrInvalidFormat, e.KDF)
+	}
+	if e.WalletName == "" {
+		return fmt.Errorf("%w: missing wallet name", ErrInvalidFormat)
+	}
+	if len(e.EncryptedData) == 0 {
+		return fmt.Errorf("%w: missing encrypted data", ErrInvalidFormat)
+	}
+	return VerifyChecksum(e.EncryptedData, e.Checksum)
+}
+
+// Open decrypts the export with passphrase and returns the wallet and seed.
+// The caller must zero the returned seed when done.
+func (e *Export) Open(passphrase []byte) (*wallet.Wallet, []byte, error) {
+	decrypted, err := sigilcrypto.Decrypt(e.EncryptedData, string(passphrase))
+	if err != nil {
+		return nil, nil, ErrDecryptionFailed
+	}
+	defer wallet.ZeroBytes(decrypted)
+
+	var data WalletData
+	if err := json.Unmarshal(decrypted, &data); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+
+	var wlt wallet.Wallet
+	if err := json.Unmarshal(data.WalletJSON, &wlt); err != nil {
+		wallet.ZeroBytes(data.Seed)
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidFormat, err)
+	}
+	if len(data.Seed) == 0 {
+		return nil, nil, fmt.Errorf("%w: missing seed", ErrInvalidFormat)
+	}
+	return &wlt, data.Seed, nil
+}
@@ -0,0 +1,82 @@
+package backup_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/backup"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+func TestExport_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	w, seed := testWallet(t)
+	passphrase := []byte("correct horse battery staple")
+
+	e, err := backup.NewExport(w, seed, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, backup.ExportFormat, e.Format)
+	assert.Equal(t, backup.ExportVersion, e.Version)
+	assert.Equal(t, backup.ExportKDF, e.KDF)
+	assert.Equal(t, "testwallet", e.WalletName)
+	assert.ElementsMatch(t, []string{"eth", "bsv"}, e.Chains)
+	assert.NotContains(t, string(e.EncryptedData), string(seed), "the seed is not stored in plain text")
+
+	data, err := json.Marshal(e)
+	require.NoError(t, err)
+	parsed, err := backup.ParseExport(data)
+	require.NoError(t, err)
+
+	_, _, err = parsed.Open([]byte("wrong passphrase"))
+	require.ErrorIs(t, err, backup.ErrDecryptionFailed)
+
+	restored, restoredSeed, err := parsed.Open(passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, seed, restoredSeed)
+	assert.Equal(t, w.Name, restored.Name)
+	assert.Equal(t, w.Addresses, restored.Addresses)
+
+	_, err = backup.NewExport(nil, seed, passphrase)
+	require.ErrorIs(t, err, wallet.ErrNilWallet)
+}
+
+func TestParseExport_Invalid(t *testing.T) {
+	t.Parallel()
+
+	w, seed := testWallet(t)
+	e, err := backup.NewExport(w, seed, []byte("passphrase-123"))
+	require.NoError(t, err)
+
+	encode := func(mutate func(*backup.Export)) []byte {
+		c := *e
+		mutate(&c)
+		data, marshalErr := json.Marshal(c)
+		require.NoError(t, marshalErr)
+		return data
+	}
+
+	_, err = backup.ParseExport([]byte("not json"))
+	require.ErrorIs(t, err, backup.ErrInvalidFormat)
+
+	_, err = backup.ParseExport([]byte(`{"version":1,"manifest":{"wallet_name":"main"}}`))
+	require.ErrorIs(t, err, backup.ErrNotExport, "a backup file is not an export")
+
+	_, err = backup.ParseExport(encode(func(c *backup.Export) { c.Version = 2 }))
+	require.ErrorIs(t, err, backup.ErrInvalidFormat)
+
+	_, err = backup.ParseExport(encode(func(c *backup.Export) { c.KDF = "pbkdf2" }))
+	require.ErrorIs(t, err, backup.ErrInvalidFormat)
+
+	_, err = backup.ParseExport(encode(func(c *backup.Export) { c.WalletName = "" }))
+	require.ErrorIs(t, err, backup.ErrInvalidFormat)
+
+	_, err = backup.ParseExport(encode(func(c *backup.Export) {
+		c.EncryptedData = append([]byte{}, c.EncryptedData...)
+		c.EncryptedData[len(c.EncryptedData)-1] ^= 0xff
+	}))
+	require.ErrorIs(t, err, backup.ErrBackupCorrupted)
+}
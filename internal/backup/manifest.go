@@ -22,10 +22,25 @@ var (
 
 	// ErrInvalidFormat indicates the backup format is invalid.
 	ErrInvalidFormat = errors.New("invalid backup format")
+
+	// ErrContentMismatch indicates a backup decrypted, but its contents do
+	// not match its manifest.
+	ErrContentMismatch = errors.New("backup contents do not match manifest")
 )
 
-// BackupVersion is the current backup format version.
-const BackupVersion = 1
+const (
+	// BackupVersion is the current backup format version. Version 2 added
+	// the content hash and the manifest chain.
+	BackupVersion = 2
+
+	// LegacyBackupVersion is the first backup format version, still read
+	// and restored. Its backups have no content hash and no chain links.
+	LegacyBackupVersion = 1
+
+	// ShortHashLength is the number of hex digits of the content hash used
+	// to name a backup file.
+	ShortHashLength = 16
+)
 
 // Backup represents a complete wallet backup.
 type Backup struct {
@@ -40,6 +55,11 @@ type Backup struct {
 
 	// Checksum is the SHA256 hash of EncryptedData.
 	Checksum string `json:"checksum"`
+
+	// Hash is the content hash of the backup: the SHA256 hash of the
+	// manifest and Checksum. The backup file is named by it, and the next
+	// backup of the wallet links to it.
+	Hash string `json:"hash,omitempty"`
 }
 
 // Manifest contains metadata about the backup.
@@ -61,6 +81,14 @@ type Manifest struct {
 
 	// HostInfo contains optional host information.
 	HostInfo string `json:"host_info,omitempty"`
+
+	// Sequence is the position of the backup in the wallet's manifest
+	// chain, starting at 1.
+	Sequence int `json:"sequence,omitempty"`
+
+	// Previous is the content hash of the wallet's previous backup, or
+	// empty for the first backup of the chain.
+	Previous string `json:"previous,omitempty"`
 }
 
 // WalletData represents the decrypted wallet data within a backup.
@@ -98,19 +126,57 @@ func VerifyChecksum(data []byte, expected string) error {
 	return nil
 }
 
+// ContentHash computes the content hash of a backup from its manifest and
+// the checksum of its encrypted data, so it covers the whole backup.
+func ContentHash(manifest *Manifest, checksum string) (string, error) {
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return "", fmt.Errorf("serializing manifest: %w", err)
+	}
+	return CalculateChecksum(append(append(data, '\n'), checksum...)), nil
+}
+
 // NewBackup creates a new backup with the given manifest and encrypted data.
 func NewBackup(manifest Manifest, encryptedData []byte) *Backup {
-	return &Backup{
+	b := &Backup{
 		Version:       BackupVersion,
 		Manifest:      manifest,
 		EncryptedData: encryptedData,
 		Checksum:      CalculateChecksum(encryptedData),
 	}
+	// A manifest of strings, ints, and a time always serializes
+	b.Hash, _ = ContentHash(&b.Manifest, b.Checksum)
+	return b
+}
+
+// LinkTo makes the manifest follow prev in the wallet's manifest chain, or
+// start the chain when prev is nil. Call it before NewBackup, which hashes
+// the manifest.
+func (m *Manifest) LinkTo(prev *Backup) {
+	if prev == nil {
+		m.Sequence, m.Previous = 1, ""
+		return
+	}
+	m.Sequence, m.Previous = prev.Manifest.Sequence+1, prev.Hash
+}
+
+// ShortHash returns the prefix of the content hash that names the backup
+// file, or "" for a legacy backup.
+func (b *Backup) ShortHash() string {
+	if len(b.Hash) < ShortHashLength {
+		return b.Hash
+	}
+	return b.Hash[:ShortHashLength]
+}
+
+// FileName returns the content-addressed file name of the backup.
+func (b *Backup) FileName() string {
+	return fmt.Sprintf("%s-%s%s", b.Manifest.WalletName, b.ShortHash(), BackupExtension)
 }
 
 // Validate checks the backup for consistency.
 func (b *Backup) Validate() error {
-	if b.Version != BackupVersion {
+	if b.Version != BackupVersion && b.Version != LegacyBackupVersion {
 		return fmt.Errorf("%w: unsupported version %d", ErrInvalidFormat, b.Version)
 	}
 
@@ -122,5 +188,19 @@ func (b *Backup) Validate() error {
 		return fmt.Errorf("%w: no encrypted data", ErrInvalidFormat)
 	}
 
-	return VerifyChecksum(b.EncryptedData, b.Checksum)
+	if err := VerifyChecksum(b.EncryptedData, b.Checksum); err != nil {
+		return err
+	}
+	if b.Version == LegacyBackupVersion {
+		return nil
+	}
+
+	hash, err := ContentHash(&b.Manifest, b.Checksum)
+	if err != nil {
+		return err
+	}
+	if hash != b.Hash {
+		return fmt.Errorf("%w: manifest content hash is %s, expected %s", ErrBackupCorrupted, hash, b.Hash)
+	}
+	return nil
 }
@@ -2,6 +2,7 @@
 package cache
 
 import (
+	"sort"
 	"sync"
 	"time"
 
@@ -11,6 +12,29 @@ import (
 // DefaultStaleness is the default duration after which cache entries are considered stale.
 const DefaultStaleness = 5 * time.Minute
 
+const (
+	// DefaultMaxAge is how long an entry may go without an update before it
+	// is evicted.
+	DefaultMaxAge = 30 * 24 * time.Hour
+
+	// DefaultMaxEntries is the most entries kept before the least recently
+	// used are evicted.
+	DefaultMaxEntries = 10000
+)
+
+// Limits bound the size of a balance cache. A zero field disables that limit.
+type Limits struct {
+	// MaxAge evicts entries not updated within this duration.
+	MaxAge time.Duration
+	// MaxEntries caps the entry count, evicting the least recently used first.
+	MaxEntries int
+}
+
+// DefaultLimits returns the limits used when none are configured.
+func DefaultLimits() Limits {
+	return Limits{MaxAge: DefaultMaxAge, MaxEntries: DefaultMaxEntries}
+}
+
 // Cache defines the interface for balance caching operations.
 type Cache interface {
 	// Get retrieves a cached balance entry.
@@ -44,9 +68,13 @@ type Cache interface {
 // Compile-time interface check
 var _ Cache = (*BalanceCache)(nil)
 
+// CurrentVersion is the balance cache file format version this build writes.
+const CurrentVersion = 1
+
 // BalanceCache stores cached balance information.
 type BalanceCache struct {
 	mu      sync.RWMutex                 `json:"-"`
+	Version int                          `json:"version"`
 	Entries map[string]BalanceCacheEntry `json:"entries"`
 }
 
@@ -60,11 +88,23 @@ type BalanceCacheEntry struct {
 	Decimals    int       `json:"decimals"`
 	Token       string    `json:"token,omitempty"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	// AccessedAt is when the entry was last read or written, for LRU eviction.
+	AccessedAt time.Time `json:"accessed_at,omitempty"`
+}
+
+// lastUsed returns when the entry was last read or written. Entries saved
+// before AccessedAt was tracked fall back to UpdatedAt.
+func (e *BalanceCacheEntry) lastUsed() time.Time {
+	if e.AccessedAt.After(e.UpdatedAt) {
+		return e.AccessedAt
+	}
+	return e.UpdatedAt
 }
 
 // NewBalanceCache creates a new empty balance cache.
 func NewBalanceCache() *BalanceCache {
 	return &BalanceCache{
+		Version: CurrentVersion,
 		Entries: make(map[string]BalanceCacheEntry),
 	}
 }
@@ -81,17 +121,29 @@ func Key(chainID chain.ID, address, token string) string {
 	return string(chainID) + keySep + address
 }
 
-// Get retrieves a cached balance entry.
+// rekey rebuilds the map keys from the entries, for files written before
+// the keys used keySep.
+func (c *BalanceCache) rekey() {
+	entries := make(map[string]BalanceCacheEntry, len(c.Entries))
+	for _, entry := range c.Entries {
+		entries[Key(entry.Chain, entry.Address, entry.Token)] = entry
+	}
+	c.Entries = entries
+}
+
+// Get retrieves a cached balance entry and marks it as recently used.
 // Returns the entry, whether it exists, and its age.
 func (c *BalanceCache) Get(chainID chain.ID, address, token string) (*BalanceCacheEntry, bool, time.Duration) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
 	key := Key(chainID, address, token)
 	entry, exists := c.Entries[key]
 	if !exists {
 		return nil, false, 0
 	}
+	entry.AccessedAt = time.Now()
+	c.Entries[key] = entry
 
 	age := time.Since(entry.UpdatedAt)
 	return &entry, true, age
@@ -104,6 +156,7 @@ func (c *BalanceCache) Set(entry BalanceCacheEntry) {
 
 	key := Key(entry.Chain, entry.Address, entry.Token)
 	entry.UpdatedAt = time.Now()
+	entry.AccessedAt = entry.UpdatedAt
 	c.Entries[key] = entry
 }
 
@@ -177,3 +230,69 @@ func (c *BalanceCache) Prune(maxAge time.Duration) int {
 
 	return removed
 }
+
+// Evict removes entries older than limits.MaxAge, then the least recently
+// used entries beyond limits.MaxEntries. Returns the number removed.
+func (c *BalanceCache) Evict(limits Limits) int {
+	removed := 0
+	if limits.MaxAge > 0 {
+		removed = c.Prune(limits.MaxAge)
+	}
+	if limits.MaxEntries <= 0 {
+		return removed
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	excess := len(c.Entries) - limits.MaxEntries
+	if excess <= 0 {
+		return removed
+	}
+
+	keys := make([]string, 0, len(c.Entries))
+	for key := range c.Entries {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := c.Entries[keys[i]], c.Entries[keys[j]]
+		if at, bt := a.lastUsed(), b.lastUsed(); !at.Equal(bt) {
+			return at.Before(bt)
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys[:excess] {
+		delete(c.Entries, key)
+	}
+
+	return removed + excess
+}
+
+// Stats summarizes a balance cache.
+type Stats struct {
+	// Entries is the number of cached balances.
+	Entries int
+	// Oldest and Newest are the entries with the earliest and latest
+	// UpdatedAt, or nil when the cache is empty.
+	Oldest *BalanceCacheEntry
+	Newest *BalanceCacheEntry
+}
+
+// Stats returns the entry count and the oldest and newest entries.
+func (c *BalanceCache) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := Stats{Entries: len(c.Entries)}
+	for _, entry := range c.Entries {
+		if stats.Oldest == nil || entry.UpdatedAt.Before(stats.Oldest.UpdatedAt) {
+			oldest := entry
+			stats.Oldest = &oldest
+		}
+		if stats.Newest == nil || entry.UpdatedAt.After(stats.Newest.UpdatedAt) {
+			newest := entry
+			stats.Newest = &newest
+		}
+	}
+	return stats
+}
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"sync"
 	"time"
+
+	"github.com/mrz1836/sigil/internal/config"
 )
 
 const (
@@ -24,21 +26,50 @@ const (
 var ErrCorruptCache = errors.New("cache file is corrupted")
 
 // FileStorage implements cache persistence using the filesystem.
+// Entries beyond its limits are evicted on every load and save.
 type FileStorage struct {
-	mu   sync.RWMutex // Protects concurrent access to the cache file
-	path string
+	mu     sync.RWMutex // Protects concurrent access to the cache file
+	path   string
+	limits Limits
 }
 
-// NewFileStorage creates a new file-based cache storage.
+// NewFileStorage creates a new file-based cache storage with the default limits.
 func NewFileStorage(path string) *FileStorage {
-	return &FileStorage{path: path}
+	return &FileStorage{path: path, limits: DefaultLimits()}
+}
+
+// NewBalanceStorage returns the storage for the balance cache file under the
+// sigil home directory, bounded by the configured limits. Testnet balances
+// have a file of their own.
+func NewBalanceStorage(home string, cfg config.CacheConfig) *FileStorage {
+	name := "balances.json"
+	if cfg.Network == "test" {
+		name = "balances-test.json"
+	}
+	return NewFileStorage(filepath.Join(home, "cache", name)).WithLimits(LimitsFromConfig(cfg))
 }
 
-// Save writes the cache to the filesystem.
+// LimitsFromConfig converts the cache config section to eviction limits.
+func LimitsFromConfig(cfg config.CacheConfig) Limits {
+	return Limits{
+		MaxAge:     time.Duration(cfg.MaxAgeDays) * 24 * time.Hour,
+		MaxEntries: cfg.MaxEntries,
+	}
+}
+
+// WithLimits sets the eviction limits and returns the storage.
+func (s *FileStorage) WithLimits(limits Limits) *FileStorage {
+	s.limits = limits
+	return s
+}
+
+// Save evicts entries beyond the limits and writes the cache to the filesystem.
 func (s *FileStorage) Save(cache *BalanceCache) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	cache.Evict(s.limits)
+
 	// Ensure directory exists
 	dir := filepath.Dir(s.path)
 	if err := os.MkdirAll(dir, cacheDirPermissions); err != nil {
@@ -59,8 +90,8 @@ func (s *FileStorage) Save(cache *BalanceCache) error {
 	return nil
 }
 
-// Load reads the cache from the filesystem.
-// Returns an empty cache if the file doesn't exist.
+// Load reads the cache from the filesystem and evicts entries beyond the
+// limits. Returns an empty cache if the file doesn't exist.
 func (s *FileStorage) Load() (*BalanceCache, error) {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -90,6 +121,11 @@ func (s *FileStorage) Load() (*BalanceCache, error) {
 	if cache.Entries == nil {
 		cache.Entries = make(map[string]BalanceCacheEntry)
 	}
+	if cache.Version < CurrentVersion {
+		cache.rekey()
+		cache.Version = CurrentVersion
+	}
+	cache.Evict(s.limits)
 
 	return &cache, nil
 }
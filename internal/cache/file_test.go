@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
 )
 
 func TestFileStorage(t *testing.T) {
@@ -451,3 +452,112 @@ func TestFileStorage_Path(t *testing.T) {
 	storage := NewFileStorage(path)
 	assert.Equal(t, path, storage.Path())
 }
+
+func TestNewBalanceStorage_Network(t *testing.T) {
+	t.Parallel()
+
+	home := filepath.Join("tmp", "sigil")
+	assert.Equal(t, filepath.Join(home, "cache", "balances.json"), NewBalanceStorage(home, config.CacheConfig{}).Path())
+	assert.Equal(t, filepath.Join(home, "cache", "balances-test.json"), NewBalanceStorage(home, config.CacheConfig{Network: "test"}).Path())
+}
+
+func TestFileStorage_LoadOlderVersion(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "balances.json")
+	legacy := `{"entries": {"eth:0xabc": {"chain": "eth", "address": "0xabc", "balance": "1.5", "symbol": "ETH", "decimals": 18, "updated_at": "2026-01-01T00:00:00Z"}}}`
+	require.NoError(t, os.WriteFile(path, []byte(legacy), 0o600))
+
+	cache, err := NewFileStorage(path).WithLimits(Limits{}).Load()
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, cache.Version)
+	entry, ok, _ := cache.Get(chain.ETH, "0xabc", "")
+	require.True(t, ok, "entries of a version 0 file are re-keyed")
+	assert.Equal(t, "1.5", entry.Balance)
+}
+
+func TestBalanceCache_Evict(t *testing.T) {
+	t.Parallel()
+
+	t.Run("evicts entries older than max age", func(t *testing.T) {
+		t.Parallel()
+		cache := NewBalanceCache()
+		cache.Entries[Key(chain.BSV, "1Old", "")] = BalanceCacheEntry{Chain: chain.BSV, Address: "1Old", UpdatedAt: time.Now().Add(-48 * time.Hour)}
+		cache.Set(BalanceCacheEntry{Chain: chain.BSV, Address: "1New"})
+
+		assert.Equal(t, 1, cache.Evict(Limits{MaxAge: 24 * time.Hour}))
+		_, exists, _ := cache.Get(chain.BSV, "1Old", "")
+		assert.False(t, exists)
+		assert.Equal(t, 1, cache.Size())
+	})
+
+	t.Run("evicts least recently used beyond max entries", func(t *testing.T) {
+		t.Parallel()
+		cache := NewBalanceCache()
+		base := time.Now().Add(-time.Hour)
+		for i, addr := range []string{"1A", "1B", "1C"} {
+			cache.Entries[Key(chain.BSV, addr, "")] = BalanceCacheEntry{
+				Chain: chain.BSV, Address: addr, UpdatedAt: base.Add(time.Duration(i) * time.Minute),
+			}
+		}
+		// Reading the oldest entry makes it the most recently used.
+		_, exists, _ := cache.Get(chain.BSV, "1A", "")
+		require.True(t, exists)
+
+		assert.Equal(t, 1, cache.Evict(Limits{MaxEntries: 2}))
+		_, exists, _ = cache.Get(chain.BSV, "1B", "")
+		assert.False(t, exists)
+		_, exists, _ = cache.Get(chain.BSV, "1A", "")
+		assert.True(t, exists)
+	})
+
+	t.Run("zero limits keep everything", func(t *testing.T) {
+		t.Parallel()
+		cache := NewBalanceCache()
+		cache.Entries[Key(chain.BSV, "1Old", "")] = BalanceCacheEntry{Chain: chain.BSV, Address: "1Old", UpdatedAt: time.Now().AddDate(-1, 0, 0)}
+		assert.Zero(t, cache.Evict(Limits{}))
+		assert.Equal(t, 1, cache.Size())
+	})
+}
+
+func TestBalanceCache_Stats(t *testing.T) {
+	t.Parallel()
+
+	cache := NewBalanceCache()
+	assert.Equal(t, Stats{}, cache.Stats())
+
+	cache.Entries[Key(chain.BSV, "1Old", "")] = BalanceCacheEntry{Chain: chain.BSV, Address: "1Old", UpdatedAt: time.Now().Add(-time.Hour)}
+	cache.Set(BalanceCacheEntry{Chain: chain.ETH, Address: "0xNew"})
+
+	stats := cache.Stats()
+	assert.Equal(t, 2, stats.Entries)
+	require.NotNil(t, stats.Oldest)
+	require.NotNil(t, stats.Newest)
+	assert.Equal(t, "1Old", stats.Oldest.Address)
+	assert.Equal(t, "0xNew", stats.Newest.Address)
+}
+
+func TestFileStorage_Limits(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "balances.json")
+	cache := NewBalanceCache()
+	cache.Entries[Key(chain.BSV, "1Old", "")] = BalanceCacheEntry{Chain: chain.BSV, Address: "1Old", UpdatedAt: time.Now().Add(-2 * DefaultMaxAge)}
+	cache.Set(BalanceCacheEntry{Chain: chain.BSV, Address: "1New"})
+
+	// Without limits the stale entry survives a round trip.
+	unbounded := NewFileStorage(path).WithLimits(Limits{})
+	require.NoError(t, unbounded.Save(cache))
+	loaded, err := unbounded.Load()
+	require.NoError(t, err)
+	assert.Equal(t, 2, loaded.Size())
+
+	// The default limits evict it on load.
+	loaded, err = NewFileStorage(path).Load()
+	require.NoError(t, err)
+	assert.Equal(t, 1, loaded.Size())
+
+	// And on save.
+	require.NoError(t, NewFileStorage(path).WithLimits(Limits{MaxEntries: 1}).Save(cache))
+	assert.Equal(t, 1, cache.Size())
+}
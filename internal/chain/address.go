@@ -0,0 +1,129 @@
+package chain
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// AddressReason classifies why an address was rejected.
+type AddressReason string
+
+// Reasons an address can be rejected.
+const (
+	// AddressReasonFormat means the address is not in any format the chain
+	// uses: wrong length, characters, prefix, or version.
+	AddressReasonFormat AddressReason = "format"
+
+	// AddressReasonChecksum means the address is well formed but its
+	// checksum does not match, which usually points to a typo.
+	AddressReasonChecksum AddressReason = "checksum"
+
+	// AddressReasonNetwork means the address is valid on another network of
+	// the chain, such as a testnet address given for mainnet.
+	AddressReasonNetwork AddressReason = "network"
+)
+
+// AddressError reports an address rejected by ValidateAddress.
+//
+// It matches sigilerr.ErrInvalidChecksum for AddressReasonChecksum and
+// sigilerr.ErrInvalidAddress otherwise, so callers can keep checking those
+// with errors.Is.
+type AddressError struct {
+	Chain   ID
+	Network string
+	Address string
+	Reason  AddressReason
+	Err     error // chain-specific cause, may be nil
+}
+
+// NewAddressError returns an AddressError for address on the chain's network.
+func NewAddressError(id ID, network, address string, reason AddressReason, err error) *AddressError {
+	return &AddressError{Chain: id, Network: network, Address: address, Reason: reason, Err: err}
+}
+
+// Error implements error.
+func (e *AddressError) Error() string {
+	switch e.Reason {
+	case AddressReasonChecksum:
+		return fmt.Sprintf("%s address %s has a bad checksum", e.Chain, e.Address)
+	case AddressReasonNetwork:
+		return fmt.Sprintf("%s address %s is not a %s address", e.Chain, e.Address, e.Network)
+	default:
+		return fmt.Sprintf("%s address %s is not a valid address", e.Chain, e.Address)
+	}
+}
+
+// Suggestion tells the user how to fix the address.
+func (e *AddressError) Suggestion() string {
+	name := strings.ToUpper(string(e.Chain))
+	switch e.Reason {
+	case AddressReasonChecksum:
+		return fmt.Sprintf("%s has an invalid checksum and may contain a typo; double-check it with the recipient", e.Address)
+	case AddressReasonNetwork:
+		return fmt.Sprintf("%s is a %s address for another network; use a %s %s address", e.Address, name, e.Network, name)
+	default:
+		return fmt.Sprintf("invalid %s address: %s", name, e.Address)
+	}
+}
+
+// Unwrap returns the matching sigil error and the chain-specific cause.
+func (e *AddressError) Unwrap() []error {
+	sentinel := sigilerr.ErrInvalidAddress
+	if e.Reason == AddressReasonChecksum {
+		sentinel = sigilerr.ErrInvalidChecksum
+	}
+	if e.Err == nil {
+		return []error{sentinel}
+	}
+	return []error{sentinel, e.Err}
+}
+
+// AddressValidatorFunc validates address on network, one of the networks the
+// chain can be qualified with (Mainnet, Testnet, Sepolia). Rejections are
+// reported as *AddressError.
+type AddressValidatorFunc func(address, network string) error
+
+// addressValidators holds the validator each chain package registers.
+//
+//nolint:gochecknoglobals // Package-level registry filled by chain packages
+var addressValidators = struct {
+	mu sync.RWMutex
+	m  map[ID]AddressValidatorFunc
+}{m: make(map[ID]AddressValidatorFunc)}
+
+// RegisterAddressValidator sets the validator ValidateAddress uses for id.
+// Chain packages register theirs when they are imported.
+func RegisterAddressValidator(id ID, fn AddressValidatorFunc) {
+	addressValidators.mu.Lock()
+	defer addressValidators.mu.Unlock()
+	addressValidators.m[id] = fn
+}
+
+// ValidateAddress checks that address is a valid mainnet address for the
+// chain. See ValidateAddressOnNetwork.
+func ValidateAddress(id ID, address string) error {
+	return ValidateAddressOnNetwork(id, Mainnet, address)
+}
+
+// ValidateAddressOnNetwork checks that address is valid on a network of the
+// chain, given by any name ParseQualifiedChainID accepts for it ("testnet",
+// "test", "sepolia", ...). An invalid address returns an *AddressError
+// saying whether the format, the checksum, or the network is wrong. A chain
+// or network without a validator returns ErrUnsupportedChain.
+func ValidateAddressOnNetwork(id ID, network, address string) error {
+	canonical, ok := defaultRegistry.network(id, network)
+	if !ok {
+		return fmt.Errorf("%w: %s has no network %q", ErrUnsupportedChain, id, network)
+	}
+
+	addressValidators.mu.RLock()
+	fn, ok := addressValidators.m[id]
+	addressValidators.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("%w: no address validator for %s", ErrUnsupportedChain, id)
+	}
+	return fn(address, canonical)
+}
@@ -0,0 +1,75 @@
+package chain
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestValidateAddress(t *testing.T) {
+	// Not parallel: registers a validator in the package registry.
+	errCause := errors.New("cause")
+	var gotNetwork string
+	RegisterAddressValidator(LTC, func(address, network string) error {
+		gotNetwork = network
+		if address == "good" {
+			return nil
+		}
+		return NewAddressError(LTC, network, address, AddressReasonChecksum, errCause)
+	})
+	t.Cleanup(func() {
+		addressValidators.mu.Lock()
+		delete(addressValidators.m, LTC)
+		addressValidators.mu.Unlock()
+	})
+
+	require.NoError(t, ValidateAddress(LTC, "good"))
+	assert.Equal(t, Mainnet, gotNetwork)
+
+	require.NoError(t, ValidateAddressOnNetwork(LTC, "test", "good"))
+	assert.Equal(t, Testnet, gotNetwork, "network aliases are resolved")
+
+	err := ValidateAddressOnNetwork(LTC, "", "bad")
+	var addrErr *AddressError
+	require.ErrorAs(t, err, &addrErr)
+	assert.Equal(t, AddressReasonChecksum, addrErr.Reason)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidChecksum)
+	require.ErrorIs(t, err, errCause)
+	assert.Equal(t, "ltc address bad has a bad checksum", err.Error())
+
+	require.ErrorIs(t, ValidateAddressOnNetwork(LTC, Sepolia, "good"), ErrUnsupportedChain)
+	require.ErrorIs(t, ValidateAddress(ID("doge"), "good"), ErrUnsupportedChain)
+}
+
+func TestAddressError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		reason   AddressReason
+		sentinel error
+		message  string
+	}{
+		{AddressReasonFormat, sigilerr.ErrInvalidAddress, "bsv address xyz is not a valid address"},
+		{AddressReasonChecksum, sigilerr.ErrInvalidChecksum, "bsv address xyz has a bad checksum"},
+		{AddressReasonNetwork, sigilerr.ErrInvalidAddress, "bsv address xyz is not a testnet address"},
+	}
+	for _, tc := range tests {
+		err := NewAddressError(BSV, Testnet, "xyz", tc.reason, nil)
+		require.ErrorIs(t, err, tc.sentinel, tc.reason)
+		assert.Equal(t, tc.message, err.Error())
+	}
+}
+
+func TestAddressErrorSuggestion(t *testing.T) {
+	t.Parallel()
+
+	err := NewAddressError(BSV, Testnet, "1abc", AddressReasonNetwork, nil)
+	assert.Equal(t, "1abc is a BSV address for another network; use a testnet BSV address", err.Suggestion())
+
+	err = NewAddressError(ETH, Mainnet, "0xabc", AddressReasonFormat, nil)
+	assert.Equal(t, "invalid ETH address: 0xabc", err.Suggestion())
+}
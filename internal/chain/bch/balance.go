@@ -0,0 +1,192 @@
+package bch
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Balance represents a balance result with metadata.
+type Balance struct {
+	Address     string
+	Amount      *big.Int
+	Unconfirmed *big.Int // Unconfirmed balance delta in satoshis (can be negative)
+	Symbol      string
+	Decimals    int
+}
+
+// balanceResponse is the bch-api GET /electrumx/balance/:address response.
+type balanceResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	Balance struct {
+		Confirmed   int64 `json:"confirmed"`
+		Unconfirmed int64 `json:"unconfirmed"`
+	} `json:"balance"`
+}
+
+// utxosResponse is the bch-api GET /electrumx/utxos/:address response.
+type utxosResponse struct {
+	Success bool   `json:"success"`
+	Error   string `json:"error"`
+	UTXOs   []struct {
+		Height int64  `json:"height"` // 0 (or negative) while unconfirmed
+		TxHash string `json:"tx_hash"`
+		TxPos  uint32 `json:"tx_pos"`
+		Value  uint64 `json:"value"`
+	} `json:"utxos"`
+}
+
+// GetBalance retrieves the confirmed BCH balance for an address.
+func (c *Client) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	bal, err := c.GetNativeBalance(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return bal.Amount, nil
+}
+
+// GetNativeBalance retrieves the BCH balance including the mempool delta.
+func (c *Client) GetNativeBalance(ctx context.Context, address string) (*Balance, error) {
+	cashAddr, err := ToCashAddr(address, c.network)
+	if err != nil {
+		return nil, ErrInvalidAddress
+	}
+
+	body, err := c.get(ctx, "/electrumx/balance/"+url.PathEscape(cashAddr))
+	if err != nil {
+		return nil, err
+	}
+	var resp balanceResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing balance response: %w", err)
+	}
+	if !resp.Success {
+		return nil, sigilerr.WithDetails(ErrAPIError, map[string]string{"error": resp.Error})
+	}
+
+	bal := &Balance{
+		Address:  address,
+		Amount:   big.NewInt(resp.Balance.Confirmed),
+		Symbol:   "BCH",
+		Decimals: decimals,
+	}
+	if resp.Balance.Unconfirmed != 0 {
+		bal.Unconfirmed = big.NewInt(resp.Balance.Unconfirmed)
+	}
+	return bal, nil
+}
+
+// GetTokenBalance is not supported for BCH.
+func (c *Client) GetTokenBalance(_ context.Context, _, _ string) (*big.Int, error) {
+	return nil, sigilerr.ErrNotSupported
+}
+
+// ListUTXOs returns unspent transaction outputs for an address, including
+// unconfirmed ones (zero confirmations). UTXOs carry address as given so
+// callers can match them to wallet keys.
+func (c *Client) ListUTXOs(ctx context.Context, address string) ([]chain.UTXO, error) {
+	cashAddr, err := ToCashAddr(address, c.network)
+	if err != nil {
+		return nil, ErrInvalidAddress
+	}
+
+	body, err := c.get(ctx, "/electrumx/utxos/"+url.PathEscape(cashAddr))
+	if err != nil {
+		return nil, err
+	}
+	var resp utxosResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing utxo response: %w", err)
+	}
+	if !resp.Success {
+		return nil, sigilerr.WithDetails(ErrAPIError, map[string]string{"error": resp.Error})
+	}
+
+	var tip int64
+	for _, u := range resp.UTXOs {
+		if u.Height > 0 {
+			if tip, err = c.TipHeight(ctx); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	script, err := PayToAddrScript(address, c.network)
+	if err != nil {
+		return nil, err
+	}
+	scriptHex := hex.EncodeToString(script)
+
+	utxos := make([]chain.UTXO, 0, len(resp.UTXOs))
+	for _, u := range resp.UTXOs {
+		var confirmations uint32
+		if u.Height > 0 && tip >= u.Height {
+			confirmations = uint32(min(tip-u.Height+1, math.MaxUint32)) //nolint:gosec // G115: clamped to uint32 range
+		}
+		utxos = append(utxos, chain.UTXO{
+			TxID:          u.TxHash,
+			Vout:          u.TxPos,
+			Amount:        u.Value,
+			ScriptPubKey:  scriptHex,
+			Address:       address,
+			Confirmations: confirmations,
+		})
+	}
+	return utxos, nil
+}
+
+// TipHeight returns the height of the best block.
+func (c *Client) TipHeight(ctx context.Context) (int64, error) {
+	body, err := c.get(ctx, "/blockchain/getBlockCount")
+	if err != nil {
+		return 0, err
+	}
+	height, err := strconv.ParseInt(strings.TrimSpace(string(body)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing tip height: %w", err)
+	}
+	return height, nil
+}
+
+// Broadcast submits a signed raw transaction and returns its txid.
+func (c *Client) Broadcast(ctx context.Context, rawTx []byte) (string, error) {
+	payload, err := json.Marshal(map[string][]string{"hexes": {hex.EncodeToString(rawTx)}})
+	if err != nil {
+		return "", fmt.Errorf("encoding broadcast request: %w", err)
+	}
+	body, err := c.do(ctx, http.MethodPost, "/rawtransactions/sendRawTransaction", string(payload))
+	if err != nil {
+		return "", fmt.Errorf("broadcasting transaction: %w", err)
+	}
+
+	var txids []string
+	if err := json.Unmarshal(body, &txids); err != nil || len(txids) != 1 || !isValidTxID(txids[0]) {
+		return "", sigilerr.WithDetails(ErrAPIError, map[string]string{"body": truncateBody(string(body), 128)})
+	}
+	return txids[0], nil
+}
+
+// isValidTxID reports whether s is a 64-character hex transaction id.
+func isValidTxID(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,282 @@
+// Package bch provides a Bitcoin Cash chain client backed by the FullStack.cash
+// REST API (bch-api).
+package bch
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/faultinject"
+	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	"github.com/mrz1836/sigil/internal/reqsign"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	// decimals is the number of decimals for BCH (satoshis).
+	decimals = 8
+
+	// httpTimeout is the default HTTP request timeout.
+	httpTimeout = 30 * time.Second
+
+	// maxResponseBody is the maximum response body size to read (1 MB).
+	maxResponseBody = 1 << 20
+)
+
+// Network represents the Bitcoin Cash network.
+type Network string
+
+// Network constants, matching the wallet's stamped network names.
+const (
+	// NetworkMainnet is Bitcoin Cash mainnet.
+	NetworkMainnet Network = "main"
+	// NetworkTestnet is Bitcoin Cash testnet3.
+	NetworkTestnet Network = "test"
+)
+
+// API presets accepted by networks.bch.api, by network.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var apiPresets = map[string]map[Network]string{
+	"fullstack": {
+		NetworkMainnet: "https://api.fullstack.cash/v5",
+		NetworkTestnet: "https://testnet3.fullstack.cash/v5",
+	},
+}
+
+var (
+	// ErrInvalidAddress indicates the address format is invalid.
+	ErrInvalidAddress = &sigilerr.SigilError{
+		Code:     "BCH_INVALID_ADDRESS",
+		Message:  "invalid BCH address format",
+		ExitCode: sigilerr.ExitInput,
+	}
+
+	// ErrInvalidAmount indicates the amount format is invalid.
+	ErrInvalidAmount = &sigilerr.SigilError{
+		Code:     "BCH_INVALID_AMOUNT",
+		Message:  "invalid amount format",
+		ExitCode: sigilerr.ExitInput,
+	}
+
+	// ErrInsufficientFunds indicates insufficient funds for transaction.
+	ErrInsufficientFunds = &sigilerr.SigilError{
+		Code:     "BCH_INSUFFICIENT_FUNDS",
+		Message:  "insufficient funds for transaction",
+		ExitCode: sigilerr.ExitPermission,
+	}
+
+	// ErrUnknownAPI indicates networks.bch.api is neither a preset nor a URL.
+	ErrUnknownAPI = &sigilerr.SigilError{
+		Code:     "BCH_UNKNOWN_API",
+		Message:  "unknown BCH API (use fullstack or an http(s) URL)",
+		ExitCode: sigilerr.ExitInput,
+	}
+
+	// ErrAPIError indicates the BCH API returned an error response.
+	ErrAPIError = &sigilerr.SigilError{
+		Code:     "BCH_API_ERROR",
+		Message:  "BCH API returned an error",
+		ExitCode: sigilerr.ExitGeneral,
+	}
+)
+
+// Logger is the interface for client logging.
+type Logger interface {
+	Debug(format string, args ...any)
+	Error(format string, args ...any)
+}
+
+// ClientOptions configures the BCH client.
+type ClientOptions struct {
+	// API is a preset name ("fullstack") or a bch-api base URL.
+	// Empty means "fullstack".
+	API string
+
+	// Network specifies mainnet or testnet.
+	Network Network
+
+	// HTTPClient overrides the default HTTP client.
+	HTTPClient *http.Client
+
+	// Logger is an optional logger for diagnostic output.
+	Logger Logger
+}
+
+// Compile-time interface check
+var _ chain.UTXOChain = (*Client)(nil)
+
+// Client provides Bitcoin Cash blockchain operations.
+type Client struct {
+	baseURL    string
+	network    Network
+	httpClient *http.Client
+	logger     Logger
+}
+
+// NewClient creates a new BCH client.
+func NewClient(opts *ClientOptions) (*Client, error) {
+	c := &Client{
+		network: NetworkMainnet,
+		httpClient: &http.Client{
+			Timeout: httpTimeout,
+			Transport: reqlog.Wrap(faultinject.Wrap(reqsign.Wrap(&http.Transport{
+				TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+			})), "bch"),
+		},
+	}
+
+	var api string
+	if opts != nil {
+		api = opts.API
+		if opts.Network != "" {
+			c.network = opts.Network
+		}
+		if opts.HTTPClient != nil {
+			c.httpClient = opts.HTTPClient
+		}
+		c.logger = opts.Logger
+	}
+
+	baseURL, err := ResolveAPIURL(api, c.network)
+	if err != nil {
+		return nil, err
+	}
+	c.baseURL = baseURL
+	return c, nil
+}
+
+// ResolveAPIURL maps a networks.bch.api value to a bch-api base URL. Preset
+// names are resolved for the network; anything else must be an http(s) URL.
+func ResolveAPIURL(api string, network Network) (string, error) {
+	api = strings.TrimSpace(api)
+	if api == "" {
+		api = "fullstack"
+	}
+	if preset, ok := apiPresets[strings.ToLower(api)]; ok {
+		if network == NetworkTestnet {
+			return preset[NetworkTestnet], nil
+		}
+		return preset[NetworkMainnet], nil
+	}
+	if strings.HasPrefix(api, "https://") || strings.HasPrefix(api, "http://") {
+		return strings.TrimRight(api, "/"), nil
+	}
+	return "", sigilerr.WithDetails(ErrUnknownAPI, map[string]string{"api": api})
+}
+
+// ID returns the chain identifier.
+func (c *Client) ID() chain.ID {
+	return chain.BCH
+}
+
+// Network returns the network the client validates addresses for.
+func (c *Client) Network() Network {
+	return c.network
+}
+
+// ValidateAddress checks if an address is valid for BCH on this client's
+// network: a CashAddr (with or without its prefix) or a legacy P2PKH/P2SH
+// address.
+func (c *Client) ValidateAddress(address string) error {
+	if ValidateAddressForNetwork(address, c.network) != nil {
+		return ErrInvalidAddress
+	}
+	return nil
+}
+
+// FormatAmount converts a big.Int (satoshis) to a human-readable BCH string.
+func (c *Client) FormatAmount(amount *big.Int) string {
+	if amount == nil {
+		amount = big.NewInt(0)
+	}
+	str := fmt.Sprintf("%0*s", decimals+1, amount.String())
+	return str[:len(str)-decimals] + "." + str[len(str)-decimals:]
+}
+
+// ParseAmount converts a human-readable BCH string to big.Int (satoshis).
+func (c *Client) ParseAmount(amount string) (*big.Int, error) {
+	return chain.ParseDecimalAmount(amount, decimals, ErrInvalidAmount)
+}
+
+// get performs an HTTP GET against the API and returns the response body.
+func (c *Client) get(ctx context.Context, path string) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, path, "")
+}
+
+// do performs an HTTP request against the API. HTTP-level errors are
+// mapped to ErrAPIError with the status and a truncated body.
+func (c *Client) do(ctx context.Context, method, path, body string) ([]byte, error) {
+	start := time.Now()
+	result, err := c.doRequest(ctx, method, path, body)
+	metrics.Global.RecordRPCCall("bch", time.Since(start), err)
+	if err != nil {
+		c.logError("%s %s failed: %v", method, path, err)
+	}
+	return result, err
+}
+
+// doRequest performs the actual HTTP request.
+func (c *Client) doRequest(ctx context.Context, method, path, body string) ([]byte, error) {
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reader)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	httpReq.Header.Set("Accept", "application/json")
+	if body != "" {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", sigilerr.ErrNetworkError, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, sigilerr.WithDetails(ErrAPIError, map[string]string{
+			"status": strconv.Itoa(resp.StatusCode),
+			"body":   truncateBody(string(data), 512),
+		})
+	}
+	return data, nil
+}
+
+// debug logs a debug message if a logger is configured.
+func (c *Client) debug(format string, args ...any) {
+	if c.logger != nil {
+		c.logger.Debug("bch: "+format, args...)
+	}
+}
+
+// logError logs an error message if a logger is configured.
+func (c *Client) logError(format string, args ...any) {
+	if c.logger != nil {
+		c.logger.Error("bch: "+format, args...)
+	}
+}
+
+// truncateBody truncates a string to maxLen characters.
+func truncateBody(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	return s[:maxLen] + "..."
+}
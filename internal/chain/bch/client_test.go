@@ -0,0 +1,286 @@
+package bch
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+const (
+	// Addresses of the compressed public key for private key 1.
+	testMainnetLegacy   = "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"
+	testMainnetCashAddr = "bitcoincash:qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2h"
+	testTestnetLegacy   = "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r"
+	testTestnetCashAddr = "bchtest:qp63uahgrxged4z5jswyt5dn5v3lzsem6cq85x00dt"
+	testPubKeyHash      = "751e76e8199196d454941c45d1b3a323f1433bd6"
+
+	testMainnetP2SH         = "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"
+	testMainnetP2SHCashAddr = "bitcoincash:pz689gnx6z7cnsfhq6jpxtx0k9hhcwulev5cpumfk0"
+)
+
+// newTestClient returns a mainnet client for a bch-api test server.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&ClientOptions{API: server.URL})
+	require.NoError(t, err)
+	return client
+}
+
+func TestResolveAPIURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		api     string
+		network Network
+		want    string
+	}{
+		{"", NetworkMainnet, "https://api.fullstack.cash/v5"},
+		{"FullStack", NetworkTestnet, "https://testnet3.fullstack.cash/v5"},
+		{"https://bch-api.example/v5/", NetworkMainnet, "https://bch-api.example/v5"},
+	}
+	for _, tc := range tests {
+		got, err := ResolveAPIURL(tc.api, tc.network)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, tc.api)
+	}
+
+	_, err := ResolveAPIURL("electrum", NetworkMainnet)
+	require.ErrorIs(t, err, ErrUnknownAPI)
+}
+
+func TestPayToAddrScript(t *testing.T) {
+	t.Parallel()
+
+	p2pkh := "76a914" + testPubKeyHash + "88ac"
+	p2sh := "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"
+	tests := []struct {
+		name    string
+		address string
+		network Network
+		want    string
+	}{
+		{"mainnet legacy P2PKH", testMainnetLegacy, NetworkMainnet, p2pkh},
+		{"mainnet CashAddr P2PKH", testMainnetCashAddr, NetworkMainnet, p2pkh},
+		{"mainnet CashAddr without prefix", strings.TrimPrefix(testMainnetCashAddr, "bitcoincash:"), NetworkMainnet, p2pkh},
+		{"mainnet CashAddr upper case", strings.ToUpper(testMainnetCashAddr), NetworkMainnet, p2pkh},
+		{"testnet legacy P2PKH", testTestnetLegacy, NetworkTestnet, p2pkh},
+		{"testnet CashAddr P2PKH", testTestnetCashAddr, NetworkTestnet, p2pkh},
+		{"mainnet legacy P2SH", testMainnetP2SH, NetworkMainnet, p2sh},
+		{"mainnet CashAddr P2SH", testMainnetP2SHCashAddr, NetworkMainnet, p2sh},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			script, err := PayToAddrScript(tc.address, tc.network)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, hex.EncodeToString(script))
+		})
+	}
+}
+
+func TestValidateAddressForNetwork_Rejects(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, ValidateAddressForNetwork(testMainnetCashAddr, NetworkTestnet), "mainnet CashAddr on testnet")
+	require.Error(t, ValidateAddressForNetwork(testTestnetCashAddr, NetworkMainnet), "testnet CashAddr on mainnet")
+	require.Error(t, ValidateAddressForNetwork(testTestnetLegacy, NetworkMainnet), "testnet legacy on mainnet")
+	require.Error(t, ValidateAddressForNetwork("bitcoincash:qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2j", NetworkMainnet), "bad checksum")
+	require.Error(t, ValidateAddressForNetwork("bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", NetworkMainnet), "BTC segwit")
+	require.Error(t, ValidateAddressForNetwork("", NetworkMainnet))
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, client.ValidateAddress("not-an-address"), ErrInvalidAddress)
+}
+
+func TestToCashAddr(t *testing.T) {
+	t.Parallel()
+
+	got, err := ToCashAddr(testMainnetLegacy, NetworkMainnet)
+	require.NoError(t, err)
+	assert.Equal(t, testMainnetCashAddr, got)
+
+	got, err = ToCashAddr(testMainnetP2SH, NetworkMainnet)
+	require.NoError(t, err)
+	assert.Equal(t, testMainnetP2SHCashAddr, got)
+
+	got, err = ToCashAddr(testTestnetLegacy, NetworkTestnet)
+	require.NoError(t, err)
+	assert.Equal(t, testTestnetCashAddr, got)
+}
+
+func TestFormatAndParseAmount(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "0.00012345", client.FormatAmount(big.NewInt(12345)))
+
+	amount, err := client.ParseAmount("0.001")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100000), amount)
+
+	_, err = client.ParseAmount("abc")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestGetNativeBalance(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/electrumx/balance/"+testMainnetCashAddr, r.URL.Path)
+		_, _ = w.Write([]byte(`{"success":true,"balance":{"confirmed":100000,"unconfirmed":-20000}}`))
+	})
+
+	bal, err := client.GetNativeBalance(context.Background(), testMainnetLegacy)
+	require.NoError(t, err)
+	assert.Equal(t, testMainnetLegacy, bal.Address)
+	assert.Equal(t, big.NewInt(100000), bal.Amount)
+	assert.Equal(t, big.NewInt(-20000), bal.Unconfirmed)
+	assert.Equal(t, "BCH", bal.Symbol)
+	assert.Equal(t, 8, bal.Decimals)
+}
+
+func TestGetNativeBalance_APIError(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"success":false,"error":"rate limited"}`, http.StatusTooManyRequests)
+	})
+	_, err := client.GetNativeBalance(context.Background(), testMainnetLegacy)
+	require.ErrorIs(t, err, ErrAPIError)
+
+	client = newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"success":false,"error":"unknown address"}`))
+	})
+	_, err = client.GetNativeBalance(context.Background(), testMainnetLegacy)
+	require.ErrorIs(t, err, ErrAPIError, "success=false in a 200 response")
+}
+
+func TestListUTXOs(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/electrumx/utxos/" + testMainnetCashAddr:
+			_, _ = w.Write([]byte(`{"success":true,"utxos":[
+				{"height":100,"tx_hash":"` + strings.Repeat("aa", 32) + `","tx_pos":1,"value":5000},
+				{"height":0,"tx_hash":"` + strings.Repeat("bb", 32) + `","tx_pos":0,"value":700}
+			]}`))
+		case "/blockchain/getBlockCount":
+			_, _ = w.Write([]byte("105"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	utxos, err := client.ListUTXOs(context.Background(), testMainnetLegacy)
+	require.NoError(t, err)
+	require.Len(t, utxos, 2)
+	assert.Equal(t, uint32(6), utxos[0].Confirmations)
+	assert.Equal(t, uint32(1), utxos[0].Vout)
+	assert.Equal(t, uint64(5000), utxos[0].Amount)
+	assert.Equal(t, "76a914"+testPubKeyHash+"88ac", utxos[0].ScriptPubKey)
+	assert.Equal(t, uint32(0), utxos[1].Confirmations)
+	assert.Equal(t, testMainnetLegacy, utxos[1].Address, "UTXOs keep the queried address")
+}
+
+func TestBroadcast(t *testing.T) {
+	t.Parallel()
+
+	txid := strings.Repeat("cd", 32)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/rawtransactions/sendRawTransaction", r.URL.Path)
+		var req struct {
+			Hexes []string `json:"hexes"`
+		}
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &req))
+		assert.Equal(t, []string{"0102"}, req.Hexes)
+		_, _ = w.Write([]byte(`["` + txid + `"]`))
+	})
+
+	got, err := client.Broadcast(context.Background(), []byte{0x01, 0x02})
+	require.NoError(t, err)
+	assert.Equal(t, txid, got)
+}
+
+func TestBroadcast_Rejected(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"success":false,"error":"min relay fee not met"}`, http.StatusBadRequest)
+	})
+
+	_, err := client.Broadcast(context.Background(), []byte{0x01})
+	require.ErrorIs(t, err, ErrAPIError)
+}
+
+func TestGetFeeRate(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(DefaultFeeRate), client.GetFeeRate(context.Background()))
+
+	fee, err := client.EstimateFee(context.Background(), "", "", nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(int64(EstimateFeeForTx(1, 2, DefaultFeeRate))), fee) //nolint:gosec // small test value
+}
+
+func TestClientImplementsUTXOChain(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(&ClientOptions{Network: NetworkTestnet})
+	require.NoError(t, err)
+	var c chain.UTXOChain = client
+	assert.Equal(t, chain.BCH, c.ID())
+	assert.Equal(t, NetworkTestnet, client.Network())
+}
+
+func TestValidateChainAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		network string
+		address string
+		reason  chain.AddressReason // empty when valid
+	}{
+		{"CashAddr", chain.Mainnet, testMainnetCashAddr, ""},
+		{"legacy", chain.Mainnet, testMainnetLegacy, ""},
+		{"testnet CashAddr", chain.Testnet, testTestnetCashAddr, ""},
+		{"bad CashAddr checksum", chain.Mainnet, "bitcoincash:qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2j", chain.AddressReasonChecksum},
+		{"bad legacy checksum", chain.Mainnet, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMJ", chain.AddressReasonChecksum},
+		{"testnet on mainnet", chain.Mainnet, testTestnetCashAddr, chain.AddressReasonNetwork},
+		{"BTC segwit", chain.Mainnet, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", chain.AddressReasonFormat},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := chain.ValidateAddressOnNetwork(chain.BCH, tc.network, tc.address)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+			var addrErr *chain.AddressError
+			require.ErrorAs(t, err, &addrErr)
+			assert.Equal(t, tc.reason, addrErr.Reason)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package bch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+const (
+	// Serialized sizes of a transaction, in bytes.
+	txOverheadSize = 10  // version, input/output counts, locktime
+	p2pkhInputSize = 148 // outpoint, compressed-key scriptSig, sequence
+	maxOutputSize  = 34  // P2PKH, the largest standard output
+
+	// DefaultFeeRate is the fee rate in satoshis per kilobyte (1 sat/B).
+	// BCH blocks are rarely full, so the relay minimum confirms promptly.
+	DefaultFeeRate = 1000
+
+	// MinFeeRate is the minimum relay fee rate in satoshis per kilobyte.
+	MinFeeRate = 1000
+)
+
+// ErrSweepInsufficientFunds indicates there are not enough funds to cover the fee.
+var ErrSweepInsufficientFunds = errors.New("insufficient funds: fee exceeds total balance")
+
+// GetFeeRate returns the fee rate in satoshis per kilobyte. bch-api has no
+// fee estimation endpoint, so this is always DefaultFeeRate.
+func (c *Client) GetFeeRate(_ context.Context) uint64 {
+	return DefaultFeeRate
+}
+
+// EstimateFee estimates the fee for a one-input, two-output transaction at
+// the default fee rate.
+func (c *Client) EstimateFee(ctx context.Context, _, _ string, _ *big.Int) (*big.Int, error) {
+	return chain.AmountToBigInt(EstimateFeeForTx(1, 2, c.GetFeeRate(ctx))), nil
+}
+
+// EstimateTxSize estimates the size in bytes of a transaction spending
+// numInputs P2PKH inputs. Outputs are sized as the largest standard output so
+// the estimate never undershoots.
+func EstimateTxSize(numInputs, numOutputs int) uint64 {
+	return uint64(txOverheadSize + numInputs*p2pkhInputSize + numOutputs*maxOutputSize) //nolint:gosec // G115: counts are small and non-negative
+}
+
+// EstimateFeeForTx estimates the fee for a transaction with the given inputs
+// and outputs. The feeRate is in satoshis per kilobyte, rounded up.
+func EstimateFeeForTx(numInputs, numOutputs int, feeRate uint64) uint64 {
+	return (EstimateTxSize(numInputs, numOutputs)*feeRate + 999) / 1000
+}
+
+// CalculateSweepAmount returns the maximum amount that can be sent from
+// totalInputs across numInputs UTXOs with a single output and no change.
+func CalculateSweepAmount(totalInputs uint64, numInputs int, feeRate uint64) (uint64, error) {
+	fee := EstimateFeeForTx(numInputs, 1, max(feeRate, MinFeeRate))
+	if fee >= totalInputs {
+		return 0, fmt.Errorf("%w: total %d satoshis, fee %d satoshis",
+			ErrSweepInsufficientFunds, totalInputs, fee)
+	}
+
+	sendAmount := totalInputs - fee
+	if dustLimit := chain.BCH.DustLimit(); sendAmount < dustLimit {
+		return 0, fmt.Errorf("%w: remaining %d satoshis is below dust limit %d",
+			ErrSweepInsufficientFunds, sendAmount, dustLimit)
+	}
+	return sendAmount, nil
+}
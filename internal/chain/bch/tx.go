@@ -0,0 +1,469 @@
+package bch
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/wallet"
+	"github.com/mrz1836/sigil/internal/wallet/bitcoin"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	// txVersion is the transaction version (2 enables relative locktimes).
+	txVersion = 2
+
+	// sequenceFinal disables locktime for an input.
+	sequenceFinal = 0xffffffff
+
+	// sigHashAllForkID signs every input and output with the BCH replay
+	// protection flag (SIGHASH_ALL | SIGHASH_FORKID, fork id 0).
+	sigHashAllForkID = 0x41
+)
+
+var (
+	// ErrMissingKey is returned when no signing key matches an input's address.
+	ErrMissingKey = errors.New("no signing key for input address")
+
+	// ErrAmountOverflow is returned when input or output totals overflow uint64.
+	ErrAmountOverflow = errors.New("amount overflow")
+)
+
+// TxOutput is a transaction output paying Amount satoshis to Address.
+type TxOutput struct {
+	Address string
+	Amount  uint64
+}
+
+// SelectUTXOs chooses UTXOs, largest first, to fund amount plus the fee at
+// feeRate (satoshis per kilobyte) for a two-output transaction. Change below
+// the dust limit is left to the fee.
+func (c *Client) SelectUTXOs(utxos []chain.UTXO, amount, feeRate uint64) ([]chain.UTXO, uint64, error) {
+	sorted := slices.Clone(utxos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Amount > sorted[j].Amount
+	})
+
+	var selected []chain.UTXO
+	var total, target uint64
+	for _, utxo := range sorted {
+		selected = append(selected, utxo)
+		if total+utxo.Amount < total {
+			return nil, 0, fmt.Errorf("UTXO sum: %w", ErrAmountOverflow)
+		}
+		total += utxo.Amount
+
+		target = amount + EstimateFeeForTx(len(selected), 2, feeRate)
+		if target < amount {
+			return nil, 0, fmt.Errorf("target amount: %w", ErrAmountOverflow)
+		}
+		if total >= target {
+			change := total - target
+			if change < chain.BCH.DustLimit() {
+				change = 0
+			}
+			return selected, change, nil
+		}
+	}
+
+	if target == 0 {
+		target = amount + EstimateFeeForTx(1, 2, feeRate)
+	}
+	return nil, 0, fmt.Errorf("%w: need %d satoshis, have %d", ErrInsufficientFunds, target, total)
+}
+
+// Send implements the chain.Chain interface for BCH. Inputs come from
+// req.UTXOs when set, otherwise from the From address, and are signed with
+// the key for their address in req.PrivateKeys (or req.PrivateKey for From).
+//
+//nolint:gocognit,gocyclo // Transaction building involves multiple steps
+func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.TransactionResult, error) {
+	keys := req.PrivateKeys
+	if len(keys) == 0 && req.PrivateKey != nil {
+		keys = map[string][]byte{req.From: req.PrivateKey}
+	}
+	defer func() {
+		for _, key := range keys {
+			wallet.ZeroBytes(key)
+		}
+	}()
+
+	if err := c.ValidateAddress(req.To); err != nil {
+		return nil, fmt.Errorf("invalid to address: %w", err)
+	}
+	var amount uint64
+	if !req.SweepAll {
+		if req.Amount == nil {
+			return nil, sigilerr.ErrAmountRequired
+		}
+		if !req.Amount.IsUint64() {
+			return nil, ErrInvalidAmount
+		}
+		amount = req.Amount.Uint64()
+		if amount < chain.BCH.DustLimit() {
+			return nil, sigilerr.WithSuggestion(ErrInvalidAmount,
+				fmt.Sprintf("amount %d satoshis is below the dust limit of %d", amount, chain.BCH.DustLimit()))
+		}
+	}
+
+	utxos := req.UTXOs
+	if len(utxos) == 0 {
+		if err := c.ValidateAddress(req.From); err != nil {
+			return nil, fmt.Errorf("invalid from address: %w", err)
+		}
+		var err error
+		if utxos, err = c.ListUTXOs(ctx, req.From); err != nil {
+			return nil, fmt.Errorf("listing UTXOs: %w", err)
+		}
+	}
+	if len(utxos) == 0 {
+		return nil, ErrInsufficientFunds
+	}
+
+	feeRate := req.FeeRate
+	if feeRate == 0 {
+		feeRate = c.GetFeeRate(ctx)
+	}
+	feeRate = max(feeRate, MinFeeRate)
+
+	var selected []chain.UTXO
+	var change, total uint64
+	if req.SweepAll {
+		selected = utxos
+		for _, u := range utxos {
+			if total+u.Amount < total {
+				return nil, fmt.Errorf("calculating sweep total: %w", ErrAmountOverflow)
+			}
+			total += u.Amount
+		}
+		var err error
+		if amount, err = CalculateSweepAmount(total, len(utxos), feeRate); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if selected, change, err = c.SelectUTXOs(utxos, amount, feeRate); err != nil {
+			return nil, err
+		}
+	}
+
+	outputs := []TxOutput{{Address: req.To, Amount: amount}}
+	if change > 0 {
+		changeAddr := req.From
+		if req.ChangeAddress != "" {
+			changeAddr = req.ChangeAddress
+		}
+		outputs = append(outputs, TxOutput{Address: changeAddr, Amount: change})
+	}
+
+	if req.SweepAll {
+		claimed := chain.SweepTotals{Inputs: total, Outputs: amount, Fee: total - amount}
+		if err := verifySweep(utxos, outputs, req.To, feeRate, c.network, claimed); err != nil {
+			return nil, err
+		}
+	}
+
+	rawTx, err := BuildSignedTransaction(selected, outputs, keys, c.network)
+	if err != nil {
+		return nil, fmt.Errorf("building raw transaction: %w", err)
+	}
+	c.debug("send: raw tx built, %d bytes, %d inputs", len(rawTx), len(selected))
+
+	var inputTotal, outputTotal uint64
+	for _, u := range selected {
+		inputTotal += u.Amount
+	}
+	for _, out := range outputs {
+		outputTotal += out.Amount
+	}
+
+	if req.BeforeBroadcast != nil {
+		localTxID := TxID(rawTx)
+		signed := &chain.SignedTx{
+			Hash:    localTxID,
+			Raw:     rawTx,
+			Spent:   selected,
+			Created: createdUTXOs(localTxID, outputs),
+			Fee:     inputTotal - outputTotal,
+		}
+		if err = req.BeforeBroadcast(signed); err != nil {
+			return nil, err
+		}
+	}
+
+	txHash, err := c.Broadcast(ctx, rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &chain.TransactionResult{
+		Hash:    txHash,
+		From:    req.From,
+		To:      req.To,
+		Amount:  c.FormatAmount(chain.AmountToBigInt(amount)),
+		Fee:     c.FormatAmount(chain.AmountToBigInt(inputTotal - outputTotal)),
+		Status:  "pending",
+		Spent:   selected,
+		Created: createdUTXOs(txHash, outputs),
+	}, nil
+}
+
+// createdUTXOs returns the outputs of transaction txHash as the UTXOs it
+// creates.
+func createdUTXOs(txHash string, outputs []TxOutput) []chain.UTXO {
+	created := make([]chain.UTXO, len(outputs))
+	for i, out := range outputs {
+		created[i] = chain.UTXO{
+			TxID:    txHash,
+			Vout:    uint32(i), //nolint:gosec // output count is at most two
+			Amount:  out.Amount,
+			Address: out.Address,
+		}
+	}
+	return created
+}
+
+// BuildSignedTransaction builds a transaction spending P2PKH inputs and signs
+// each input with the key for its address in keys, using the BIP143-style
+// SIGHASH_FORKID digest BCH requires. Every output
+// must be at or above the dust limit and the inputs must cover the outputs.
+func BuildSignedTransaction(inputs []chain.UTXO, outputs []TxOutput, keys map[string][]byte, network Network) ([]byte, error) {
+	tx, prevScripts, err := assembleTransaction(inputs, outputs, network)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, in := range inputs {
+		key, ok := keys[in.Address]
+		if !ok || len(key) != 32 {
+			return nil, fmt.Errorf("%w: %s", ErrMissingKey, in.Address)
+		}
+		scriptSig, err := signInput(tx, i, prevScripts[i], in.Amount, key)
+		if err != nil {
+			return nil, fmt.Errorf("signing input %d: %w", i, err)
+		}
+		tx.inputs[i].script = scriptSig
+	}
+
+	return tx.serialize(), nil
+}
+
+// BuildUnsignedTransaction builds the transaction BuildSignedTransaction
+// would sign, with every input script empty.
+func BuildUnsignedTransaction(inputs []chain.UTXO, outputs []TxOutput, network Network) ([]byte, error) {
+	tx, _, err := assembleTransaction(inputs, outputs, network)
+	if err != nil {
+		return nil, err
+	}
+	return tx.serialize(), nil
+}
+
+// assembleTransaction validates the inputs and outputs of a transaction
+// and assembles it unsigned, returning the locking script of each input.
+func assembleTransaction(inputs []chain.UTXO, outputs []TxOutput, network Network) (*rawTx, [][]byte, error) {
+	if len(inputs) == 0 || len(outputs) == 0 {
+		return nil, nil, fmt.Errorf("%w: transaction needs inputs and outputs", ErrInsufficientFunds)
+	}
+
+	var inputTotal, outputTotal uint64
+	prevScripts := make([][]byte, len(inputs))
+	for i, in := range inputs {
+		hash, err := p2pkhHash(in.Address, network)
+		if err != nil {
+			return nil, nil, fmt.Errorf("input %d: %w", i, err)
+		}
+		prevScripts[i] = p2pkhScript(hash)
+		if in.ScriptPubKey != "" && in.ScriptPubKey != hex.EncodeToString(prevScripts[i]) {
+			return nil, nil, fmt.Errorf("input %d: script does not pay to %s", i, in.Address)
+		}
+		if inputTotal+in.Amount < inputTotal {
+			return nil, nil, ErrAmountOverflow
+		}
+		inputTotal += in.Amount
+	}
+
+	scripts := make([][]byte, len(outputs))
+	for i, out := range outputs {
+		script, err := PayToAddrScript(out.Address, network)
+		if err != nil {
+			return nil, nil, fmt.Errorf("output %d: %w", i, err)
+		}
+		if out.Amount < chain.BCH.DustLimit() {
+			return nil, nil, fmt.Errorf("output %d: %d satoshis is below the dust limit", i, out.Amount)
+		}
+		scripts[i] = script
+		if outputTotal+out.Amount < outputTotal {
+			return nil, nil, ErrAmountOverflow
+		}
+		outputTotal += out.Amount
+	}
+	if outputTotal > inputTotal {
+		return nil, nil, fmt.Errorf("%w: outputs %d exceed inputs %d satoshis", ErrInsufficientFunds, outputTotal, inputTotal)
+	}
+
+	tx := &rawTx{version: txVersion, inputs: make([]rawInput, len(inputs)), outputs: make([]rawOutput, len(outputs))}
+	for i, in := range inputs {
+		outpoint, err := hex.DecodeString(in.TxID)
+		if err != nil || len(outpoint) != 32 {
+			return nil, nil, fmt.Errorf("input %d: invalid txid %q", i, in.TxID)
+		}
+		slices.Reverse(outpoint)
+		tx.inputs[i] = rawInput{prevHash: outpoint, prevIndex: in.Vout, sequence: sequenceFinal}
+	}
+	for i, out := range outputs {
+		tx.outputs[i] = rawOutput{value: out.Amount, script: scripts[i]}
+	}
+	return tx, prevScripts, nil
+}
+
+// verifySweep checks the unsigned transaction of a sweep of utxos to "to"
+// before it is signed, recomputing its totals from the transaction itself
+// rather than trusting claimed.
+func verifySweep(utxos []chain.UTXO, outputs []TxOutput, to string, feeRate uint64, network Network, claimed chain.SweepTotals) error {
+	unsigned, err := BuildUnsignedTransaction(utxos, outputs, network)
+	if err != nil {
+		return fmt.Errorf("building unsigned transaction: %w", err)
+	}
+	recipient, err := PayToAddrScript(to, network)
+	if err != nil {
+		return fmt.Errorf("invalid to address: %w", err)
+	}
+	return chain.VerifySweep(unsigned, &chain.SweepCheck{
+		UTXOs:        utxos,
+		Recipients:   [][]byte{recipient},
+		FeeRate:      feeRate,
+		EstimatedFee: EstimateFeeForTx(len(utxos), 1, feeRate),
+		DustLimit:    chain.BCH.DustLimit(),
+	}, claimed)
+}
+
+// TxID returns the transaction id of a serialized transaction.
+func TxID(raw []byte) string {
+	hash := bitcoin.DoubleSHA256(raw)
+	slices.Reverse(hash)
+	return hex.EncodeToString(hash)
+}
+
+// signInput returns the scriptSig for input index, spending amount satoshis
+// locked by prevScript with key.
+func signInput(tx *rawTx, index int, prevScript []byte, amount uint64, key []byte) ([]byte, error) {
+	privKey := secp256k1.PrivKeyFromBytes(key)
+	defer privKey.Zero()
+	pubKey := privKey.PubKey().SerializeCompressed()
+	if !bytes.Equal(bitcoin.Hash160(pubKey), prevScript[3:23]) {
+		return nil, fmt.Errorf("%w: key does not match input address", ErrMissingKey)
+	}
+
+	sig := ecdsa.Sign(privKey, tx.sigHash(index, prevScript, amount, sigHashAllForkID))
+	sigBytes := append(sig.Serialize(), sigHashAllForkID)
+
+	script := make([]byte, 0, 2+len(sigBytes)+len(pubKey))
+	script = append(script, byte(len(sigBytes)))
+	script = append(script, sigBytes...)
+	script = append(script, byte(len(pubKey)))
+	return append(script, pubKey...), nil
+}
+
+// rawTx is a transaction being assembled.
+type rawTx struct {
+	version  uint32
+	inputs   []rawInput
+	outputs  []rawOutput
+	lockTime uint32
+}
+
+type rawInput struct {
+	prevHash  []byte // outpoint txid in internal byte order
+	prevIndex uint32
+	script    []byte
+	sequence  uint32
+}
+
+type rawOutput struct {
+	value  uint64
+	script []byte
+}
+
+// serialize encodes the transaction.
+func (tx *rawTx) serialize() []byte {
+	var buf []byte
+	buf = binary.LittleEndian.AppendUint32(buf, tx.version)
+
+	buf = appendVarInt(buf, uint64(len(tx.inputs)))
+	for _, in := range tx.inputs {
+		buf = append(buf, in.prevHash...)
+		buf = binary.LittleEndian.AppendUint32(buf, in.prevIndex)
+		buf = appendVarInt(buf, uint64(len(in.script)))
+		buf = append(buf, in.script...)
+		buf = binary.LittleEndian.AppendUint32(buf, in.sequence)
+	}
+
+	buf = appendVarInt(buf, uint64(len(tx.outputs)))
+	for _, out := range tx.outputs {
+		buf = appendOutput(buf, out)
+	}
+
+	return binary.LittleEndian.AppendUint32(buf, tx.lockTime)
+}
+
+// sigHash returns the BIP143 signature digest for input index, which spends
+// amount satoshis locked by scriptCode. BCH uses this digest for every input
+// once SIGHASH_FORKID is set; hashType carries the flags.
+func (tx *rawTx) sigHash(index int, scriptCode []byte, amount uint64, hashType uint32) []byte {
+	var prevouts, sequences, outputs []byte
+	for _, in := range tx.inputs {
+		prevouts = append(prevouts, in.prevHash...)
+		prevouts = binary.LittleEndian.AppendUint32(prevouts, in.prevIndex)
+		sequences = binary.LittleEndian.AppendUint32(sequences, in.sequence)
+	}
+	for _, out := range tx.outputs {
+		outputs = appendOutput(outputs, out)
+	}
+
+	in := tx.inputs[index]
+	var buf []byte
+	buf = binary.LittleEndian.AppendUint32(buf, tx.version)
+	buf = append(buf, bitcoin.DoubleSHA256(prevouts)...)
+	buf = append(buf, bitcoin.DoubleSHA256(sequences)...)
+	buf = append(buf, in.prevHash...)
+	buf = binary.LittleEndian.AppendUint32(buf, in.prevIndex)
+	buf = appendVarInt(buf, uint64(len(scriptCode)))
+	buf = append(buf, scriptCode...)
+	buf = binary.LittleEndian.AppendUint64(buf, amount)
+	buf = binary.LittleEndian.AppendUint32(buf, in.sequence)
+	buf = append(buf, bitcoin.DoubleSHA256(outputs)...)
+	buf = binary.LittleEndian.AppendUint32(buf, tx.lockTime)
+	buf = binary.LittleEndian.AppendUint32(buf, hashType)
+	return bitcoin.DoubleSHA256(buf)
+}
+
+// appendOutput appends a serialized transaction output.
+func appendOutput(buf []byte, out rawOutput) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, out.value)
+	buf = appendVarInt(buf, uint64(len(out.script)))
+	return append(buf, out.script...)
+}
+
+// appendVarInt appends a Bitcoin CompactSize integer.
+func appendVarInt(buf []byte, n uint64) []byte {
+	switch {
+	case n < 0xfd:
+		return append(buf, byte(n))
+	case n <= 0xffff:
+		return binary.LittleEndian.AppendUint16(append(buf, 0xfd), uint16(n))
+	case n <= 0xffffffff:
+		return binary.LittleEndian.AppendUint32(append(buf, 0xfe), uint32(n))
+	default:
+		return binary.LittleEndian.AppendUint64(append(buf, 0xff), n)
+	}
+}
@@ -0,0 +1,285 @@
+package bch
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// testKey returns private key 1, whose address is testMainnetLegacy.
+func testKey() []byte {
+	key := make([]byte, 32)
+	key[31] = 1
+	return key
+}
+
+// testUTXO returns a P2PKH UTXO of amount satoshis for testMainnetLegacy.
+func testUTXO(fill string, amount uint64) chain.UTXO {
+	return chain.UTXO{TxID: strings.Repeat(fill, 32), Vout: 0, Amount: amount, Address: testMainnetLegacy}
+}
+
+// verifyInputSignature checks the scriptSig of input index in a serialized
+// transaction against the SIGHASH_FORKID digest.
+func verifyInputSignature(t *testing.T, raw []byte, index int, prevScript []byte, amount uint64) {
+	t.Helper()
+	tx := parseRawTx(t, raw)
+
+	scriptSig := tx.inputs[index].script
+	sigLen := int(scriptSig[0])
+	require.Equal(t, byte(sigHashAllForkID), scriptSig[sigLen], "sighash type")
+	sig, err := ecdsa.ParseDERSignature(scriptSig[1:sigLen])
+	require.NoError(t, err)
+	pubKey, err := secp256k1.ParsePubKey(scriptSig[sigLen+2:])
+	require.NoError(t, err)
+
+	assert.True(t, sig.Verify(tx.sigHash(index, prevScript, amount, sigHashAllForkID), pubKey), "signature verifies")
+}
+
+// parseRawTx decodes a serialized transaction with small counts.
+func parseRawTx(t *testing.T, raw []byte) *rawTx {
+	t.Helper()
+	require.Equal(t, uint32(txVersion), binary.LittleEndian.Uint32(raw))
+	pos := 4
+	next := func(n int) []byte {
+		b := raw[pos : pos+n]
+		pos += n
+		return b
+	}
+
+	tx := &rawTx{version: txVersion}
+	for range int(next(1)[0]) {
+		in := rawInput{prevHash: next(32), prevIndex: binary.LittleEndian.Uint32(next(4))}
+		in.script = next(int(next(1)[0]))
+		in.sequence = binary.LittleEndian.Uint32(next(4))
+		tx.inputs = append(tx.inputs, in)
+	}
+	for range int(next(1)[0]) {
+		out := rawOutput{value: binary.LittleEndian.Uint64(next(8))}
+		out.script = next(int(next(1)[0]))
+		tx.outputs = append(tx.outputs, out)
+	}
+	require.Equal(t, uint32(0), binary.LittleEndian.Uint32(next(4)), "locktime")
+	require.Equal(t, len(raw), pos, "no trailing bytes")
+	return tx
+}
+
+func TestBuildSignedTransaction(t *testing.T) {
+	t.Parallel()
+
+	inputs := []chain.UTXO{testUTXO("aa", 60000), testUTXO("bb", 50000)}
+	outputs := []TxOutput{
+		{Address: testMainnetCashAddr, Amount: 100000},
+		{Address: testMainnetLegacy, Amount: 8000},
+	}
+	raw, err := BuildSignedTransaction(inputs, outputs, map[string][]byte{testMainnetLegacy: testKey()}, NetworkMainnet)
+	require.NoError(t, err)
+
+	tx := parseRawTx(t, raw)
+	require.Len(t, tx.inputs, 2)
+	require.Len(t, tx.outputs, 2)
+	assert.Equal(t, strings.Repeat("aa", 32), hex.EncodeToString(tx.inputs[0].prevHash))
+	assert.Equal(t, uint32(sequenceFinal), tx.inputs[0].sequence)
+	assert.Equal(t, uint64(100000), tx.outputs[0].value)
+	assert.Equal(t, "76a914"+testPubKeyHash+"88ac", hex.EncodeToString(tx.outputs[0].script))
+
+	prevScript, _ := hex.DecodeString("76a914" + testPubKeyHash + "88ac")
+	verifyInputSignature(t, raw, 0, prevScript, 60000)
+	verifyInputSignature(t, raw, 1, prevScript, 50000)
+
+	again, err := BuildSignedTransaction(inputs, outputs, map[string][]byte{testMainnetLegacy: testKey()}, NetworkMainnet)
+	require.NoError(t, err)
+	assert.Equal(t, TxID(raw), TxID(again), "RFC 6979 signing is deterministic")
+}
+
+// TestSigHash checks the digest against the BIP143 native P2WPKH example,
+// which BCH's SIGHASH_FORKID digest shares apart from the hash type.
+func TestSigHash(t *testing.T) {
+	t.Parallel()
+
+	tx := &rawTx{
+		version: 1,
+		inputs: []rawInput{
+			{
+				prevHash:  mustDecodeHex(t, "fff7f7881a8099afa6940d42d1e7f6362bec38171ea3edf433541db4e4ad969f"),
+				prevIndex: 0,
+				sequence:  0xffffffee,
+			},
+			{
+				prevHash:  mustDecodeHex(t, "ef51e1b804cc89d182d279655c3aa89e815b1b309fe287d9b2b55d57b90ec68a"),
+				prevIndex: 1,
+				sequence:  sequenceFinal,
+			},
+		},
+		outputs: []rawOutput{
+			{value: 112340000, script: mustDecodeHex(t, "76a9148280b37df378db99f66f85c95a783a76ac7a6d5988ac")},
+			{value: 223450000, script: mustDecodeHex(t, "76a9143bde42dbee7e4dbe6a21b2d50ce2f0167faa815988ac")},
+		},
+		lockTime: 17,
+	}
+	scriptCode := mustDecodeHex(t, "76a9141d0f172a0ecb48aee1be1f2687d2963ae33f71a188ac")
+
+	got := tx.sigHash(1, scriptCode, 600000000, 0x01)
+	assert.Equal(t, "c37af31116d1b27caf68aae9e3ac82f1477929014d5b917657d0eb49478cb670", hex.EncodeToString(got))
+}
+
+func TestBuildSignedTransaction_Rejects(t *testing.T) {
+	t.Parallel()
+
+	keys := map[string][]byte{testMainnetLegacy: testKey()}
+	pay := []TxOutput{{Address: testMainnetLegacy, Amount: 1000}}
+
+	_, err := BuildSignedTransaction([]chain.UTXO{testUTXO("aa", 500)}, pay, keys, NetworkMainnet)
+	require.ErrorIs(t, err, ErrInsufficientFunds, "outputs exceed inputs")
+
+	_, err = BuildSignedTransaction([]chain.UTXO{testUTXO("aa", 5000)}, pay, map[string][]byte{}, NetworkMainnet)
+	require.ErrorIs(t, err, ErrMissingKey)
+
+	wrongKey := testKey()
+	wrongKey[31] = 2
+	_, err = BuildSignedTransaction([]chain.UTXO{testUTXO("aa", 5000)}, pay, map[string][]byte{testMainnetLegacy: wrongKey}, NetworkMainnet)
+	require.ErrorIs(t, err, ErrMissingKey, "key must match the input address")
+
+	_, err = BuildSignedTransaction([]chain.UTXO{testUTXO("aa", 5000)}, []TxOutput{{Address: testMainnetLegacy, Amount: 100}}, keys, NetworkMainnet)
+	require.Error(t, err, "dust output")
+
+	p2shInput := testUTXO("aa", 5000)
+	p2shInput.Address = testMainnetP2SH
+	_, err = BuildSignedTransaction([]chain.UTXO{p2shInput}, pay, keys, NetworkMainnet)
+	require.ErrorIs(t, err, ErrInvalidAddress, "only P2PKH inputs are signed")
+}
+
+func TestSelectUTXOs(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+	utxos := []chain.UTXO{testUTXO("aa", 10000), testUTXO("bb", 90000), testUTXO("cc", 40000)}
+
+	selected, change, err := client.SelectUTXOs(utxos, 50000, 1000)
+	require.NoError(t, err)
+	require.Len(t, selected, 1)
+	assert.Equal(t, uint64(90000), selected[0].Amount, "largest first")
+	assert.Equal(t, 90000-50000-EstimateFeeForTx(1, 2, 1000), change)
+
+	_, _, err = client.SelectUTXOs(utxos, 200000, 1000)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCalculateSweepAmount(t *testing.T) {
+	t.Parallel()
+
+	amount, err := CalculateSweepAmount(100000, 2, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, 100000-EstimateFeeForTx(2, 1, 2000), amount)
+
+	_, err = CalculateSweepAmount(300, 1, 2000)
+	require.ErrorIs(t, err, ErrSweepInsufficientFunds)
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	var broadcast []byte
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/electrumx/utxos/" + testMainnetCashAddr:
+			_, _ = w.Write([]byte(`{"success":true,"utxos":[{"height":0,"tx_hash":"` + strings.Repeat("aa", 32) + `","tx_pos":0,"value":100000}]}`))
+		case "/rawtransactions/sendRawTransaction":
+			broadcast = decodeBroadcast(t, r)
+			_, _ = w.Write([]byte(`["` + TxID(broadcast) + `"]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	key := testKey()
+	result, err := client.Send(context.Background(), chain.SendRequest{
+		From:          testMainnetLegacy,
+		To:            testMainnetP2SHCashAddr,
+		Amount:        big.NewInt(30000),
+		PrivateKey:    key,
+		ChangeAddress: testMainnetLegacy,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, TxID(broadcast), result.Hash)
+	assert.Equal(t, "0.00030000", result.Amount)
+	assert.Equal(t, make([]byte, 32), key, "signing key is zeroed")
+
+	fee := EstimateFeeForTx(1, 2, DefaultFeeRate)
+	assert.Equal(t, client.FormatAmount(chain.AmountToBigInt(fee)), result.Fee)
+	require.Len(t, result.Spent, 1)
+	require.Len(t, result.Created, 2)
+	assert.Equal(t, 100000-30000-fee, result.Created[1].Amount)
+	assert.Equal(t, uint32(1), result.Created[1].Vout)
+}
+
+func TestSend_SweepAll(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`["` + TxID(decodeBroadcast(t, r)) + `"]`))
+	})
+
+	utxos := []chain.UTXO{testUTXO("aa", 40000), testUTXO("bb", 60000)}
+	utxos[1].Vout = 3
+	result, err := client.Send(context.Background(), chain.SendRequest{
+		To:          testMainnetCashAddr,
+		SweepAll:    true,
+		FeeRate:     1000,
+		UTXOs:       utxos,
+		PrivateKeys: map[string][]byte{testMainnetLegacy: testKey()},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Created, 1)
+	assert.Equal(t, 100000-EstimateFeeForTx(2, 1, 1000), result.Created[0].Amount)
+	assert.Len(t, result.Spent, 2)
+}
+
+func TestSend_Validation(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+
+	_, err = client.Send(context.Background(), chain.SendRequest{To: testTestnetLegacy, Amount: big.NewInt(1000)})
+	require.ErrorIs(t, err, ErrInvalidAddress)
+
+	_, err = client.Send(context.Background(), chain.SendRequest{
+		To:     testMainnetLegacy,
+		Amount: big.NewInt(100),
+		UTXOs:  []chain.UTXO{testUTXO("aa", 5000)},
+	})
+	require.ErrorIs(t, err, ErrInvalidAmount, "below dust")
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+// decodeBroadcast returns the raw transaction from a sendRawTransaction request.
+func decodeBroadcast(t *testing.T, r *http.Request) []byte {
+	t.Helper()
+	var req struct {
+		Hexes []string `json:"hexes"`
+	}
+	body, _ := io.ReadAll(r.Body)
+	require.NoError(t, json.Unmarshal(body, &req))
+	require.Len(t, req.Hexes, 1)
+	return mustDecodeHex(t, req.Hexes[0])
+}
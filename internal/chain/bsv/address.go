@@ -8,6 +8,7 @@ import (
 	"math/big"
 	"regexp"
 
+	"github.com/mrz1836/sigil/internal/chain"
 	sigilerrors "github.com/mrz1836/sigil/pkg/errors"
 )
 
@@ -45,11 +46,12 @@ var (
 	base58AlphabetMap = make(map[rune]int)
 )
 
-//nolint:gochecknoinits // Required for base58 alphabet map initialization
+//nolint:gochecknoinits // Required for base58 alphabet map initialization and validator registration
 func init() {
 	for i, c := range base58Alphabet {
 		base58AlphabetMap[c] = i
 	}
+	chain.RegisterAddressValidator(chain.BSV, validateChainAddress)
 }
 
 // IsValidAddress checks if a mainnet BSV address is valid (format only).
@@ -81,6 +83,29 @@ func ValidateBase58CheckAddressForNetwork(address string, network Network) error
 	return ValidateBase58CheckAddress(address)
 }
 
+// validateChainAddress is the chain.AddressValidatorFunc for BSV.
+func validateChainAddress(address, network string) error {
+	net, other := NetworkMainnet, NetworkTestnet
+	if network == chain.Testnet {
+		net, other = other, net
+	}
+
+	err := ValidateBase58CheckAddressForNetwork(address, net)
+	if err == nil {
+		return nil
+	}
+	otherErr := ValidateBase58CheckAddressForNetwork(address, other)
+
+	reason := chain.AddressReasonFormat
+	switch {
+	case otherErr == nil:
+		reason = chain.AddressReasonNetwork
+	case errors.Is(err, ErrInvalidChecksum), errors.Is(otherErr, ErrInvalidChecksum):
+		reason = chain.AddressReasonChecksum
+	}
+	return chain.NewAddressError(chain.BSV, network, address, reason, err)
+}
+
 // validateForVersions validates an address against a leading-character regex and an
 // allow-list of version bytes, verifying the full Base58Check checksum.
 func validateForVersions(address string, re *regexp.Regexp, allowed ...byte) error {
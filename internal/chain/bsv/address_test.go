@@ -5,6 +5,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
 )
 
 func TestValidateBase58CheckAddress(t *testing.T) {
@@ -189,3 +191,35 @@ func TestAddressVersions(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateChainAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		network string
+		address string
+		reason  chain.AddressReason // empty when valid
+	}{
+		{"mainnet", chain.Mainnet, mainnetP2PKH, ""},
+		{"testnet", chain.Testnet, testnetP2PKH, ""},
+		{"bad checksum", chain.Mainnet, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMJ", chain.AddressReasonChecksum},
+		{"testnet on mainnet", chain.Mainnet, testnetP2PKH, chain.AddressReasonNetwork},
+		{"mainnet on testnet", chain.Testnet, mainnetP2PKH, chain.AddressReasonNetwork},
+		{"ethereum address", chain.Mainnet, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", chain.AddressReasonFormat},
+		{"empty", chain.Mainnet, "", chain.AddressReasonFormat},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := chain.ValidateAddressOnNetwork(chain.BSV, tc.network, tc.address)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+			var addrErr *chain.AddressError
+			require.ErrorAs(t, err, &addrErr)
+			assert.Equal(t, tc.reason, addrErr.Reason)
+		})
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"regexp"
 	"strings"
@@ -96,6 +98,58 @@ func isAlreadyBroadcasted(responseText string) bool {
 		strings.Contains(lower, "txn-already-known")
 }
 
+// rejectionMarkers are substrings of broadcast errors where the network
+// rejected the transaction itself. Retrying through another provider cannot
+// succeed and only risks confusing the caller.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var rejectionMarkers = []string{
+	"fee too low",
+	"missing inputs",
+	"txn-mempool-conflict",
+	"double spend",
+	"bad-txns",
+	"mandatory-script-verify-flag-failed",
+	"non-mandatory-script-verify-flag",
+	"non-final",
+	"dust",
+	"scriptpubkey",
+}
+
+// isRejectedBroadcast reports whether a broadcast error is a definitive
+// rejection of the transaction rather than a transient provider failure.
+func isRejectedBroadcast(err error) bool {
+	lower := strings.ToLower(err.Error())
+	for _, marker := range rejectionMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUnreachable reports whether a broadcast error means the provider could
+// not be reached, rather than that it answered with a failure.
+func isUnreachable(err error) bool {
+	var netErr net.Error
+	return errors.Is(err, sigilerr.ErrNetworkError) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.As(err, &netErr)
+}
+
+// txKnown reports whether txid is already in the mempool or a block, according
+// to WhatsOnChain. Lookup failures count as unknown.
+func (c *Client) txKnown(ctx context.Context, txid string) bool {
+	if c.woc == nil {
+		return false
+	}
+	info, err := c.woc.GetTxByHash(ctx, txid)
+	if err != nil || info == nil {
+		return false
+	}
+	return strings.EqualFold(info.TxID, txid)
+}
+
 // GorillaPoolARCBroadcaster broadcasts via the GorillaPool ARC API.
 //
 // API: POST {BaseURL}/v1/tx
@@ -7,12 +7,17 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	whatsonchain "github.com/mrz1836/go-whatsonchain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // --- WhatsOnChain SDK Broadcaster Tests ---
@@ -381,6 +386,26 @@ func TestBroadcastFallback_AllFail(t *testing.T) {
 	_, err := client.BroadcastTransaction(ctx, []byte{0xde, 0xad})
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "all providers failed")
+	assert.NotErrorIs(t, err, sigilerr.ErrNetworkError, "providers that answered are not unreachable")
+}
+
+func TestBroadcastFallback_AllUnreachable(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockBroadcaster{name: "primary", err: fmt.Errorf("%w: dial tcp: connection refused", sigilerr.ErrNetworkError)}
+	secondary := &mockBroadcaster{name: "secondary", err: &url.Error{Op: "Post", URL: "https://example.com", Err: io.ErrUnexpectedEOF}}
+
+	client := &Client{
+		broadcasters: []Broadcaster{primary, secondary},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.BroadcastTransaction(ctx, []byte{0xde, 0xad})
+	require.ErrorIs(t, err, ErrBroadcastFailed)
+	require.ErrorIs(t, err, sigilerr.ErrNetworkError)
+	assert.Contains(t, err.Error(), "no provider reachable")
 }
 
 func TestBroadcastFallback_NoBroadcasters(t *testing.T) {
@@ -426,6 +451,97 @@ func TestBroadcastFallback_AlreadyKnownWithTxID(t *testing.T) {
 	assert.Equal(t, int64(0), secondary.called.Load()) // Not called.
 }
 
+func TestBroadcastFallback_RejectionStopsFallback(t *testing.T) {
+	t.Parallel()
+
+	primary := &mockBroadcaster{name: "primary", err: errTestMissingInputs}
+	secondary := &mockBroadcaster{name: "secondary", txid: "should_not_be_called"}
+
+	client := &Client{
+		broadcasters: []Broadcaster{primary, secondary},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	_, err := client.BroadcastTransaction(ctx, []byte{0xde, 0xad})
+	require.ErrorIs(t, err, ErrBroadcastFailed)
+	assert.Contains(t, err.Error(), "rejected by primary")
+	assert.Equal(t, int64(0), secondary.called.Load())
+}
+
+func TestBroadcastFallback_TxAlreadyOnNetwork(t *testing.T) {
+	t.Parallel()
+
+	rawTx := []byte{0xde, 0xad}
+	localTxID := chainhash.DoubleHashH(rawTx).String()
+
+	t.Run("found before fallback", func(t *testing.T) {
+		t.Parallel()
+
+		woc := &mockWOCClient{
+			txByHashFunc: func(_ context.Context, hash string) (*whatsonchain.TxInfo, error) {
+				return &whatsonchain.TxInfo{TxID: hash}, nil
+			},
+		}
+		primary := &mockBroadcaster{name: "primary", err: errTestConnRefused}
+		secondary := &mockBroadcaster{name: "secondary", txid: "should_not_be_called"}
+
+		client := &Client{woc: woc, broadcasters: []Broadcaster{primary, secondary}}
+
+		txid, err := client.BroadcastTransaction(context.Background(), rawTx)
+		require.NoError(t, err)
+		assert.Equal(t, localTxID, txid)
+		assert.Equal(t, int64(0), secondary.called.Load())
+	})
+
+	t.Run("found after all providers fail", func(t *testing.T) {
+		t.Parallel()
+
+		var lookups atomic.Int64
+		woc := &mockWOCClient{
+			txByHashFunc: func(_ context.Context, hash string) (*whatsonchain.TxInfo, error) {
+				if lookups.Add(1) == 1 {
+					return nil, errTestConnRefused
+				}
+				return &whatsonchain.TxInfo{TxID: hash}, nil
+			},
+		}
+		primary := &mockBroadcaster{name: "primary", err: ErrBroadcastFailed}
+		secondary := &mockBroadcaster{name: "secondary", err: ErrBroadcastFailed}
+
+		client := &Client{woc: woc, broadcasters: []Broadcaster{primary, secondary}}
+
+		txid, err := client.BroadcastTransaction(context.Background(), rawTx)
+		require.NoError(t, err)
+		assert.Equal(t, localTxID, txid)
+		assert.Equal(t, int64(1), secondary.called.Load())
+	})
+
+	t.Run("unknown tx falls through", func(t *testing.T) {
+		t.Parallel()
+
+		primary := &mockBroadcaster{name: "primary", err: errTestConnRefused}
+		secondary := &mockBroadcaster{name: "secondary", txid: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb"}
+
+		client := &Client{woc: &mockWOCClient{}, broadcasters: []Broadcaster{primary, secondary}}
+
+		txid, err := client.BroadcastTransaction(context.Background(), rawTx)
+		require.NoError(t, err)
+		assert.Equal(t, "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", txid)
+	})
+}
+
+func TestIsRejectedBroadcast(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, isRejectedBroadcast(errTestMissingInputs))
+	assert.True(t, isRejectedBroadcast(fmt.Errorf("%w: fee too low", ErrBroadcastFailed)))
+	assert.True(t, isRejectedBroadcast(fmt.Errorf("%w: 258: txn-mempool-conflict", ErrBroadcastFailed)))
+	assert.False(t, isRejectedBroadcast(errTestConnRefused))
+	assert.False(t, isRejectedBroadcast(fmt.Errorf("%w: HTTP 503", ErrBroadcastFailed)))
+}
+
 // --- Logger Tests ---
 
 // testLogger captures log messages for assertions.
@@ -0,0 +1,70 @@
+package bsv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	whatsonchain "github.com/mrz1836/go-whatsonchain"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// AddressTx is a transaction in the history of one of a set of addresses.
+type AddressTx struct {
+	TxID    string
+	Address string
+	// Height is the block the transaction was mined in, or zero or less
+	// while it is unconfirmed.
+	Height int64
+}
+
+// BulkAddressTransactions lists the transactions in the history of the
+// given addresses, batching requests by MaxBulkBatchSize. A transaction that
+// touches several of the addresses is listed once, under the first of them.
+func (b *BulkOperations) BulkAddressTransactions(ctx context.Context, addresses []string) ([]AddressTx, error) {
+	var txs []AddressTx
+	seen := make(map[string]bool)
+
+	for i := 0; i < len(addresses); i += MaxBulkBatchSize {
+		end := min(i+MaxBulkBatchSize, len(addresses))
+
+		history, err := b.fetchHistoryBatch(ctx, addresses[i:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range history {
+			if entry == nil {
+				continue
+			}
+			for _, record := range entry.History {
+				if record == nil || seen[record.TxHash] {
+					continue
+				}
+				seen[record.TxHash] = true
+				txs = append(txs, AddressTx{TxID: record.TxHash, Address: entry.Address, Height: record.Height})
+			}
+		}
+	}
+	return txs, nil
+}
+
+// fetchHistoryBatch fetches the history of a single batch of addresses.
+func (b *BulkOperations) fetchHistoryBatch(ctx context.Context, addresses []string) (whatsonchain.BulkAddressHistoryResponse, error) {
+	start := time.Now()
+
+	if err := b.limiter.Wait(ctx); err != nil {
+		return nil, fmt.Errorf("rate limiter: %w", err)
+	}
+
+	history, err := b.client.BulkAddressHistory(ctx, &whatsonchain.AddressList{Addresses: addresses})
+	if err != nil {
+		b.recordRequest(start, true)
+		b.logError("bulk history fetch failed for %d addresses: %v", len(addresses), err)
+		return nil, fmt.Errorf("%w: %w", sigilerr.ErrNetworkError, err)
+	}
+
+	b.recordRequest(start, false)
+	b.debug("bulk history fetch: %d addresses", len(addresses))
+	return history, nil
+}
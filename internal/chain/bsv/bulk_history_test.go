@@ -0,0 +1,50 @@
+package bsv
+
+import (
+	"context"
+	"testing"
+
+	whatsonchain "github.com/mrz1836/go-whatsonchain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBulkOperations_BulkAddressTransactions(t *testing.T) {
+	t.Run("lists each transaction once", func(t *testing.T) {
+		mock := &mockWOCClient{
+			bulkHistoryFunc: func(_ context.Context, list *whatsonchain.AddressList) (whatsonchain.BulkAddressHistoryResponse, error) {
+				assert.Len(t, list.Addresses, 2)
+				return whatsonchain.BulkAddressHistoryResponse{
+					{
+						Address: "addr1",
+						History: whatsonchain.AddressHistory{
+							&whatsonchain.HistoryRecord{TxHash: "tx1", Height: 800000},
+							&whatsonchain.HistoryRecord{TxHash: "tx2"},
+						},
+					},
+					{
+						Address: "addr2",
+						History: whatsonchain.AddressHistory{&whatsonchain.HistoryRecord{TxHash: "tx1", Height: 800000}},
+					},
+				}, nil
+			},
+		}
+
+		txs, err := NewBulkOperations(mock, nil).BulkAddressTransactions(context.Background(), []string{"addr1", "addr2"})
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+		assert.Equal(t, AddressTx{TxID: "tx1", Address: "addr1", Height: 800000}, txs[0])
+		assert.Equal(t, int64(0), txs[1].Height)
+	})
+
+	t.Run("returns batch errors", func(t *testing.T) {
+		mock := &mockWOCClient{
+			bulkHistoryFunc: func(_ context.Context, _ *whatsonchain.AddressList) (whatsonchain.BulkAddressHistoryResponse, error) {
+				return nil, errTestServerError
+			},
+		}
+
+		_, err := NewBulkOperations(mock, nil).BulkAddressTransactions(context.Background(), []string{"addr1"})
+		require.ErrorIs(t, err, errTestServerError)
+	})
+}
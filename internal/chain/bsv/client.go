@@ -4,16 +4,20 @@ package bsv
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 	"net/http"
 	"regexp"
-	"sort"
 	"time"
 
 	whatsonchain "github.com/mrz1836/go-whatsonchain"
 
+	"github.com/mrz1836/sigil/internal/apiusage"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/faultinject"
 	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	"github.com/mrz1836/sigil/internal/reqsign"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
@@ -75,6 +79,8 @@ type WOCClient interface {
 	AddressUnspentTransactions(ctx context.Context, address string) (whatsonchain.AddressHistory, error)
 	GetMinerFeesStats(ctx context.Context, from, to int64) ([]*whatsonchain.MinerFeeStats, error)
 	BroadcastTx(ctx context.Context, txHex string) (string, error)
+	GetTxByHash(ctx context.Context, hash string) (*whatsonchain.TxInfo, error)
+	GetExchangeRate(ctx context.Context) (*whatsonchain.ExchangeRate, error)
 
 	// Bulk operations (max 20 addresses per call)
 	BulkAddressConfirmedBalance(ctx context.Context, list *whatsonchain.AddressList) (whatsonchain.AddressBalances, error)
@@ -123,6 +129,12 @@ type ClientOptions struct {
 
 	// MinMiners is the minimum number of miners that must accept the fee (used by normal strategy).
 	MinMiners int
+
+	// FeeTable persists the last-known-good fee quote, used when the fee API is unreachable.
+	FeeTable *FeeTableStore
+
+	// CoinSelection selects the UTXO selection strategy (default largest-first).
+	CoinSelection CoinSelection
 }
 
 // Compile-time interface check
@@ -136,14 +148,18 @@ type Client struct {
 	broadcasters []Broadcaster
 	feeStrategy  FeeStrategy
 	minMiners    int
+	feeTable     *FeeTableStore
+	// coinSelection is the strategy SelectUTXOsForOutputs and Send use.
+	coinSelection CoinSelection
 }
 
 // NewClient creates a new BSV client.
 func NewClient(ctx context.Context, opts *ClientOptions) *Client {
 	c := &Client{
-		network:     NetworkMainnet,
-		feeStrategy: FeeStrategyNormal,
-		minMiners:   3,
+		network:       NetworkMainnet,
+		feeStrategy:   FeeStrategyNormal,
+		minMiners:     3,
+		coinSelection: DefaultCoinSelection,
 	}
 
 	if opts != nil {
@@ -166,8 +182,15 @@ func (c *Client) initializeWOCClient(ctx context.Context, opts *ClientOptions) {
 
 	var wocOpts []whatsonchain.ClientOption
 	wocOpts = append(wocOpts, whatsonchain.WithNetwork(mapNetwork(c.network)))
+	var apiKey string
 	if opts != nil && opts.APIKey != "" {
-		wocOpts = append(wocOpts, whatsonchain.WithAPIKey(opts.APIKey))
+		apiKey = opts.APIKey
+		wocOpts = append(wocOpts, whatsonchain.WithAPIKey(apiKey))
+	}
+	if faultinject.Enabled() || apiusage.Active() != nil || reqsign.Enabled() || reqlog.Enabled() {
+		transport := faultinject.Wrap(apiusage.Wrap(reqsign.Wrap(http.DefaultTransport), apiusage.ProviderWhatsOnChain, apiKey))
+		transport = reqlog.Wrap(transport, apiusage.ProviderWhatsOnChain)
+		wocOpts = append(wocOpts, whatsonchain.WithHTTPClient(newWOCHTTPClient(transport)))
 	}
 
 	wocClient, err := whatsonchain.NewClient(ctx, wocOpts...)
@@ -218,7 +241,7 @@ func (c *Client) initializeBroadcasters(opts *ClientOptions) {
 		&WOCSDKBroadcaster{woc: c.woc},
 		&GorillaPoolARCBroadcaster{
 			BaseURL:    GorillaPoolARCURL,
-			httpClient: &http.Client{Timeout: defaultTimeout},
+			httpClient: &http.Client{Timeout: defaultTimeout, Transport: reqlog.Wrap(faultinject.Wrap(reqsign.Wrap(nil)), "gorillapool-arc")},
 		},
 	}
 }
@@ -335,48 +358,44 @@ func (c *Client) doListUTXOs(ctx context.Context, address string) ([]UTXO, error
 	return utxos, nil
 }
 
-// SelectUTXOs chooses UTXOs to fund a transaction.
-//
-//nolint:gocognit // Overflow checks add necessary complexity for fund safety
-func (c *Client) SelectUTXOs(utxos []UTXO, amount, feeRate uint64) (selected []UTXO, change uint64, err error) {
-	if len(utxos) == 0 {
-		return nil, 0, ErrInsufficientFunds
+// CoinbaseStatus reports whether a transaction is a coinbase (block reward)
+// transaction and how many confirmations it has.
+func (c *Client) CoinbaseStatus(ctx context.Context, txid string) (bool, uint32, error) {
+	if !isValidTxID(txid) {
+		return false, 0, fmt.Errorf("%w: %s", ErrInvalidTxID, txid)
 	}
 
-	// Sort UTXOs by amount (largest first) for simple selection
-	sorted := make([]UTXO, len(utxos))
-	copy(sorted, utxos)
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].Amount > sorted[j].Amount
-	})
+	start := time.Now()
+	info, err := c.woc.GetTxByHash(ctx, txid)
+	metrics.Global.RecordRPCCall("bsv", time.Since(start), err)
+	if err != nil {
+		c.logError("fetching transaction %s: %v", txid, err)
+		return false, 0, fmt.Errorf("%w: %w", sigilerr.ErrNetworkError, err)
+	}
+	if info == nil {
+		return false, 0, sigilerr.WithDetails(sigilerr.ErrTransactionNotFound, map[string]string{"txid": txid})
+	}
 
-	var total uint64
-	var estimatedFee uint64
-	for _, utxo := range sorted {
-		selected = append(selected, utxo)
+	coinbase := len(info.Vin) == 1 && info.Vin[0].Coinbase != ""
+	confirmations := uint32(min(max(info.Confirmations, 0), math.MaxUint32)) //nolint:gosec // G115: clamped to uint32 range
+	return coinbase, confirmations, nil
+}
 
-		sum, addErr := checkedAdd(total, utxo.Amount)
-		if addErr != nil {
-			return nil, 0, fmt.Errorf("UTXO sum: %w", addErr)
-		}
-		total = sum
+// SelectUTXOs chooses UTXOs to fund a transaction.
+func (c *Client) SelectUTXOs(utxos []UTXO, amount, feeRate uint64) (selected []UTXO, change uint64, err error) {
+	return c.SelectUTXOsForOutputs(utxos, amount, feeRate, 1)
+}
 
-		estimatedFee = (EstimateTxSize(len(selected), 2)*feeRate + 999) / 1000
-		target, targetErr := checkedAdd(amount, estimatedFee)
-		if targetErr != nil {
-			return nil, 0, fmt.Errorf("target amount: %w", targetErr)
-		}
-		if total >= target {
-			change = total - target
-			if change < chain.BSV.DustLimit() {
-				change = 0
-			}
-			return selected, change, nil
-		}
+// SelectUTXOsForOutputs chooses UTXOs with the client's coin selection
+// strategy to fund a transaction paying amount in total to recipients
+// outputs. The fee estimate counts one output per recipient plus a change
+// output.
+func (c *Client) SelectUTXOsForOutputs(utxos []UTXO, amount, feeRate uint64, recipients int) (selected []UTXO, change uint64, err error) {
+	sel, err := c.SelectCoins(c.coinSelection, utxos, amount, feeRate, recipients)
+	if err != nil {
+		return nil, 0, err
 	}
-
-	target, _ := checkedAdd(amount, estimatedFee)
-	return nil, 0, fmt.Errorf("%w: need %d satoshis, have %d", ErrInsufficientFunds, target, total)
+	return sel.UTXOs, sel.Change, nil
 }
 
 // EstimateFee estimates the fee for a transaction.
@@ -448,6 +467,12 @@ func (c *Client) applyOptions(opts *ClientOptions) {
 	if opts.MinMiners > 0 {
 		c.minMiners = opts.MinMiners
 	}
+	if opts.FeeTable != nil {
+		c.feeTable = opts.FeeTable
+	}
+	if opts.CoinSelection != "" {
+		c.coinSelection = opts.CoinSelection
+	}
 }
 
 // debug logs a debug message if a logger is configured.
@@ -463,3 +488,18 @@ func (c *Client) logError(format string, args ...any) {
 		c.logger.Error(format, args...)
 	}
 }
+
+// newWOCHTTPClient builds the WhatsOnChain HTTP client used when fault
+// injection, API usage tracking, request signing, or request logging wraps
+// the transport. It
+// keeps the SDK's default retry and backoff settings so injected faults
+// exercise the same recovery path as real ones, and every retry is counted
+// against the quota.
+func newWOCHTTPClient(transport http.RoundTripper) whatsonchain.HTTPInterface {
+	base := &http.Client{
+		Transport: transport,
+		Timeout:   defaultTimeout,
+	}
+	backoff := whatsonchain.NewExponentialBackoff(2*time.Millisecond, 10*time.Millisecond, 2.0, 2*time.Millisecond)
+	return whatsonchain.NewRetryableHTTPClient(base, 2, backoff)
+}
@@ -2,6 +2,7 @@ package bsv
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
 	"time"
@@ -9,6 +10,8 @@ import (
 	whatsonchain "github.com/mrz1836/go-whatsonchain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // TestNewClient tests client creation.
@@ -592,6 +595,28 @@ func TestSelectUTXOs_LargeNumber(t *testing.T) {
 	assert.GreaterOrEqual(t, len(selected), 59)
 }
 
+// TestSelectUTXOsForOutputs tests that the fee estimate grows with the recipient count.
+func TestSelectUTXOsForOutputs(t *testing.T) {
+	t.Parallel()
+	client := NewClient(context.Background(), nil)
+
+	// Exactly enough for one recipient plus change, short for three recipients
+	utxos := makeUTXOs(10000 + EstimateTxSize(1, 2))
+
+	_, change, err := client.SelectUTXOsForOutputs(utxos, 10000, 1000, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), change)
+
+	_, _, err = client.SelectUTXOsForOutputs(utxos, 10000, 1000, 3)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+
+	utxos = makeUTXOs(10000 + EstimateTxSize(1, 4))
+	selected, change, err := client.SelectUTXOsForOutputs(utxos, 10000, 1000, 3)
+	require.NoError(t, err)
+	assert.Len(t, selected, 1)
+	assert.Equal(t, uint64(0), change)
+}
+
 // TestSelectUTXOs_SingleUTXOExact tests when single UTXO exactly matches.
 func TestSelectUTXOs_SingleUTXOExact(t *testing.T) {
 	t.Parallel()
@@ -657,3 +682,69 @@ func TestGetBalance_NetworkError(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "network")
 }
+
+func TestCoinbaseStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		info          *whatsonchain.TxInfo
+		coinbase      bool
+		confirmations uint32
+	}{
+		{
+			name:          "coinbase",
+			info:          &whatsonchain.TxInfo{Confirmations: 42, Vin: []whatsonchain.VinInfo{{Coinbase: "03a08601"}}},
+			coinbase:      true,
+			confirmations: 42,
+		},
+		{
+			name:          "regular transaction",
+			info:          &whatsonchain.TxInfo{Confirmations: 7, Vin: []whatsonchain.VinInfo{{TxID: testValidTxID}}},
+			confirmations: 7,
+		},
+		{
+			name: "unconfirmed",
+			info: &whatsonchain.TxInfo{Confirmations: -1, Vin: []whatsonchain.VinInfo{{TxID: testValidTxID}}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			mock := &mockWOCClient{
+				txByHashFunc: func(_ context.Context, _ string) (*whatsonchain.TxInfo, error) {
+					return tc.info, nil
+				},
+			}
+			client := NewClient(context.Background(), &ClientOptions{WOCClient: mock})
+
+			coinbase, confirmations, err := client.CoinbaseStatus(context.Background(), testValidTxID)
+			require.NoError(t, err)
+			assert.Equal(t, tc.coinbase, coinbase)
+			assert.Equal(t, tc.confirmations, confirmations)
+		})
+	}
+
+	t.Run("invalid txid", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{}})
+		_, _, err := client.CoinbaseStatus(context.Background(), "nope")
+		require.ErrorIs(t, err, ErrInvalidTxID)
+	})
+
+	t.Run("network error", func(t *testing.T) {
+		t.Parallel()
+
+		mock := &mockWOCClient{
+			txByHashFunc: func(_ context.Context, _ string) (*whatsonchain.TxInfo, error) {
+				return nil, errors.New("boom") //nolint:err113 // test error
+			},
+		}
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: mock})
+		_, _, err := client.CoinbaseStatus(context.Background(), testValidTxID)
+		require.ErrorIs(t, err, sigilerr.ErrNetworkError)
+	})
+}
@@ -0,0 +1,264 @@
+package bsv
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// CoinSelection names a strategy for choosing the UTXOs that fund a send.
+type CoinSelection string
+
+const (
+	// CoinSelectionLargestFirst spends the largest UTXOs first, using the
+	// fewest inputs.
+	CoinSelectionLargestFirst CoinSelection = "largest-first"
+	// CoinSelectionSmallestFirst spends the smallest UTXOs first,
+	// consolidating small outputs at the cost of a larger transaction.
+	CoinSelectionSmallestFirst CoinSelection = "smallest-first"
+	// CoinSelectionBranchAndBound searches for a set of UTXOs that pays the
+	// amount and fee with no change output, falling back to largest-first
+	// when there is none.
+	CoinSelectionBranchAndBound CoinSelection = "branch-and-bound"
+	// CoinSelectionManual spends every UTXO it is given, for coin control
+	// where the inputs were chosen by hand.
+	CoinSelectionManual CoinSelection = "manual"
+
+	// DefaultCoinSelection is the strategy used when none is configured.
+	DefaultCoinSelection = CoinSelectionLargestFirst
+
+	// bnbMaxTries bounds the branch-and-bound search.
+	bnbMaxTries = 100000
+)
+
+// ErrUnknownCoinSelection indicates an unrecognized coin selection strategy.
+var ErrUnknownCoinSelection = errors.New("unknown coin selection strategy")
+
+// CoinSelections returns the supported coin selection strategies.
+func CoinSelections() []CoinSelection {
+	return []CoinSelection{CoinSelectionLargestFirst, CoinSelectionSmallestFirst, CoinSelectionBranchAndBound, CoinSelectionManual}
+}
+
+// ParseCoinSelection parses a coin selection strategy name. An empty name is
+// the default strategy, and "bnb" is short for branch-and-bound.
+func ParseCoinSelection(name string) (CoinSelection, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "":
+		return DefaultCoinSelection, nil
+	case string(CoinSelectionLargestFirst):
+		return CoinSelectionLargestFirst, nil
+	case string(CoinSelectionSmallestFirst):
+		return CoinSelectionSmallestFirst, nil
+	case string(CoinSelectionBranchAndBound), "bnb":
+		return CoinSelectionBranchAndBound, nil
+	case string(CoinSelectionManual):
+		return CoinSelectionManual, nil
+	}
+	return "", fmt.Errorf("%w: %q (use largest-first, smallest-first, branch-and-bound, or manual)", ErrUnknownCoinSelection, name)
+}
+
+// Selection is the outcome of coin selection.
+type Selection struct {
+	// UTXOs are the inputs chosen to fund the send.
+	UTXOs []UTXO
+	// Change is the change output amount, zero when there is none.
+	Change uint64
+	// Strategy is the strategy that made the selection. A branch-and-bound
+	// request that found no changeless match reports largest-first.
+	Strategy CoinSelection
+}
+
+// Fee returns the fee the selection pays when sending amount.
+func (s *Selection) Fee(amount uint64) uint64 {
+	var total uint64
+	for _, u := range s.UTXOs {
+		total += u.Amount
+	}
+	return total - amount - s.Change
+}
+
+// SelectCoins chooses UTXOs with strategy to fund a transaction paying
+// amount in total to recipients outputs at feeRate sat/KB.
+func (c *Client) SelectCoins(strategy CoinSelection, utxos []UTXO, amount, feeRate uint64, recipients int) (*Selection, error) {
+	if len(utxos) == 0 {
+		return nil, ErrInsufficientFunds
+	}
+
+	sorted := make([]UTXO, len(utxos))
+	copy(sorted, utxos)
+
+	switch strategy {
+	case CoinSelectionManual:
+		change, err := spendAll(sorted, amount, feeRate, recipients)
+		if err != nil {
+			return nil, err
+		}
+		c.debug("coin selection: spending all %d given inputs", len(sorted))
+		return &Selection{UTXOs: sorted, Change: change, Strategy: CoinSelectionManual}, nil
+	case CoinSelectionBranchAndBound:
+		if selected := branchAndBound(sorted, amount, feeRate, recipients); selected != nil {
+			c.debug("coin selection: branch-and-bound found a changeless match with %d inputs", len(selected))
+			return &Selection{UTXOs: selected, Strategy: CoinSelectionBranchAndBound}, nil
+		}
+		c.debug("coin selection: no changeless match, falling back to largest-first")
+		strategy = CoinSelectionLargestFirst
+		fallthrough
+	case CoinSelectionLargestFirst, "":
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Amount > sorted[j].Amount })
+		strategy = CoinSelectionLargestFirst
+	case CoinSelectionSmallestFirst:
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Amount < sorted[j].Amount })
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCoinSelection, strategy)
+	}
+
+	selected, change, err := selectInOrder(sorted, amount, feeRate, recipients)
+	if err != nil {
+		return nil, err
+	}
+	return &Selection{UTXOs: selected, Change: change, Strategy: strategy}, nil
+}
+
+// selectInOrder takes UTXOs in the given order until they cover amount and
+// the fee for a transaction with a change output. Change below the dust
+// limit is left to the fee.
+//
+//nolint:gocognit // Overflow checks add necessary complexity for fund safety
+func selectInOrder(utxos []UTXO, amount, feeRate uint64, recipients int) (selected []UTXO, change uint64, err error) {
+	var total uint64
+	var estimatedFee uint64
+	for _, utxo := range utxos {
+		selected = append(selected, utxo)
+
+		sum, addErr := checkedAdd(total, utxo.Amount)
+		if addErr != nil {
+			return nil, 0, fmt.Errorf("UTXO sum: %w", addErr)
+		}
+		total = sum
+
+		estimatedFee = (EstimateTxSize(len(selected), recipients+1)*feeRate + 999) / 1000
+		target, targetErr := checkedAdd(amount, estimatedFee)
+		if targetErr != nil {
+			return nil, 0, fmt.Errorf("target amount: %w", targetErr)
+		}
+		if total >= target {
+			change = total - target
+			if change < chain.BSV.DustLimit() {
+				change = 0
+			}
+			return selected, change, nil
+		}
+	}
+
+	target, _ := checkedAdd(amount, estimatedFee)
+	return nil, 0, fmt.Errorf("%w: need %d satoshis, have %d", ErrInsufficientFunds, target, total)
+}
+
+// spendAll returns the change when every UTXO funds a transaction with a
+// change output. Change below the dust limit is left to the fee.
+func spendAll(utxos []UTXO, amount, feeRate uint64, recipients int) (uint64, error) {
+	var total uint64
+	for _, utxo := range utxos {
+		sum, err := checkedAdd(total, utxo.Amount)
+		if err != nil {
+			return 0, fmt.Errorf("UTXO sum: %w", err)
+		}
+		total = sum
+	}
+
+	estimatedFee := (EstimateTxSize(len(utxos), recipients+1)*feeRate + 999) / 1000
+	target, err := checkedAdd(amount, estimatedFee)
+	if err != nil {
+		return 0, fmt.Errorf("target amount: %w", err)
+	}
+	if total < target {
+		return 0, fmt.Errorf("%w: need %d satoshis, have %d", ErrInsufficientFunds, target, total)
+	}
+	change := total - target
+	if change < chain.BSV.DustLimit() {
+		change = 0
+	}
+	return change, nil
+}
+
+// branchAndBound searches for UTXOs that pay amount and the fee of a
+// transaction with no change output, overpaying the fee by less than a
+// change output would cost to create and later spend. Among the matches
+// found it returns the one that overpays least, or nil when there is none.
+//
+// Values are in thousandths of a satoshi, so a UTXO's value is its amount
+// less the fee for its own input at feeRate.
+func branchAndBound(utxos []UTXO, amount, feeRate uint64, recipients int) []UTXO {
+	const milli = 1000
+	// Keep every total well inside uint64, since values are scaled by 1000
+	const limit = math.MaxUint64 / milli / 2
+
+	inputCost := P2PKHInputSize * feeRate
+	var candidates []UTXO
+	var values []uint64
+	var available uint64
+	sort.SliceStable(utxos, func(i, j int) bool { return utxos[i].Amount > utxos[j].Amount })
+	for _, u := range utxos {
+		if u.Amount > limit || u.Amount*milli <= inputCost {
+			continue
+		}
+		available += u.Amount
+		if available > limit {
+			return nil
+		}
+		candidates = append(candidates, u)
+		values = append(values, u.Amount*milli-inputCost)
+	}
+	if amount > limit {
+		return nil
+	}
+
+	// The fee is rounded up to whole satoshis, hence the extra 999
+	target := amount*milli + EstimateTxSize(0, recipients)*feeRate + milli - 1
+	changeCost := (P2PKHOutputSize+P2PKHInputSize)*feeRate + chain.BSV.DustLimit()*milli
+	upper := target + changeCost
+
+	// remaining[i] is the value of candidates[i:], to prune branches that
+	// cannot reach the target
+	remaining := make([]uint64, len(values)+1)
+	for i := len(values) - 1; i >= 0; i-- {
+		remaining[i] = remaining[i+1] + values[i]
+	}
+
+	var best []int
+	bestExcess := uint64(math.MaxUint64)
+	tries := 0
+	var search func(i int, sum uint64, picked []int) bool
+	search = func(i int, sum uint64, picked []int) bool {
+		if tries++; tries > bnbMaxTries {
+			return true
+		}
+		if sum > upper {
+			return false
+		}
+		if sum >= target {
+			if excess := sum - target; excess < bestExcess {
+				best, bestExcess = append([]int(nil), picked...), excess
+			}
+			return bestExcess == 0
+		}
+		if i == len(values) || sum+remaining[i] < target {
+			return false
+		}
+		return search(i+1, sum+values[i], append(picked, i)) || search(i+1, sum, picked)
+	}
+	search(0, 0, nil)
+
+	if best == nil {
+		return nil
+	}
+	selected := make([]UTXO, len(best))
+	for j, i := range best {
+		selected[j] = candidates[i]
+	}
+	return selected
+}
@@ -0,0 +1,119 @@
+package bsv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCoinSelection(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		want CoinSelection
+	}{
+		{"", DefaultCoinSelection},
+		{"largest-first", CoinSelectionLargestFirst},
+		{" Smallest-First ", CoinSelectionSmallestFirst},
+		{"branch-and-bound", CoinSelectionBranchAndBound},
+		{"bnb", CoinSelectionBranchAndBound},
+		{"manual", CoinSelectionManual},
+	}
+	for _, tc := range tests {
+		got, err := ParseCoinSelection(tc.name)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.want, got, tc.name)
+	}
+
+	_, err := ParseCoinSelection("random")
+	require.ErrorIs(t, err, ErrUnknownCoinSelection)
+}
+
+// coinSelectionUTXOs returns 100000, 30000, and 20400 satoshi UTXOs. At
+// 1000 sat/KB the last two pay 50000 with no change and a 400 satoshi fee.
+func coinSelectionUTXOs() []UTXO {
+	return []UTXO{
+		{TxID: testTxID(1), Amount: 30000, Address: validAddress()},
+		{TxID: testTxID(2), Amount: 100000, Address: validAddress()},
+		{TxID: testTxID(3), Amount: 20400, Address: validAddress()},
+	}
+}
+
+func amounts(utxos []UTXO) []uint64 {
+	out := make([]uint64, len(utxos))
+	for i, u := range utxos {
+		out[i] = u.Amount
+	}
+	return out
+}
+
+func TestSelectCoins(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(context.Background(), nil)
+
+	t.Run("largest first", func(t *testing.T) {
+		t.Parallel()
+		sel, err := client.SelectCoins(CoinSelectionLargestFirst, coinSelectionUTXOs(), 50000, 1000, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{100000}, amounts(sel.UTXOs))
+		assert.Equal(t, uint64(49774), sel.Change) // fee for 1 input, 2 outputs: 226
+		assert.Equal(t, uint64(226), sel.Fee(50000))
+		assert.Equal(t, CoinSelectionLargestFirst, sel.Strategy)
+	})
+
+	t.Run("smallest first", func(t *testing.T) {
+		t.Parallel()
+		sel, err := client.SelectCoins(CoinSelectionSmallestFirst, coinSelectionUTXOs(), 40000, 1000, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{20400, 30000}, amounts(sel.UTXOs))
+		assert.Equal(t, uint64(10026), sel.Change)
+		assert.Equal(t, CoinSelectionSmallestFirst, sel.Strategy)
+	})
+
+	t.Run("branch and bound finds a changeless match", func(t *testing.T) {
+		t.Parallel()
+		sel, err := client.SelectCoins(CoinSelectionBranchAndBound, coinSelectionUTXOs(), 50000, 1000, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{30000, 20400}, amounts(sel.UTXOs))
+		assert.Zero(t, sel.Change)
+		assert.Equal(t, uint64(400), sel.Fee(50000), "covers the 340 byte transaction")
+		assert.Equal(t, CoinSelectionBranchAndBound, sel.Strategy)
+	})
+
+	t.Run("branch and bound falls back", func(t *testing.T) {
+		t.Parallel()
+		sel, err := client.SelectCoins(CoinSelectionBranchAndBound, coinSelectionUTXOs(), 60000, 1000, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{100000}, amounts(sel.UTXOs))
+		assert.Positive(t, sel.Change)
+		assert.Equal(t, CoinSelectionLargestFirst, sel.Strategy)
+	})
+
+	t.Run("manual spends every input", func(t *testing.T) {
+		t.Parallel()
+		sel, err := client.SelectCoins(CoinSelectionManual, coinSelectionUTXOs(), 10000, 1000, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []uint64{30000, 100000, 20400}, amounts(sel.UTXOs))
+		assert.Equal(t, uint64(139878), sel.Change) // fee for 3 inputs, 2 outputs: 522
+		assert.Equal(t, CoinSelectionManual, sel.Strategy)
+
+		_, err = client.SelectCoins(CoinSelectionManual, coinSelectionUTXOs(), 150000, 1000, 1)
+		require.ErrorIs(t, err, ErrInsufficientFunds)
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		t.Parallel()
+		_, err := client.SelectCoins(CoinSelectionBranchAndBound, coinSelectionUTXOs(), 200000, 1000, 1)
+		require.ErrorIs(t, err, ErrInsufficientFunds)
+	})
+
+	t.Run("unknown strategy", func(t *testing.T) {
+		t.Parallel()
+		_, err := client.SelectCoins("random", coinSelectionUTXOs(), 1000, 1000, 1)
+		require.ErrorIs(t, err, ErrUnknownCoinSelection)
+	})
+}
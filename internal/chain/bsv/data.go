@@ -0,0 +1,298 @@
+package bsv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// MaxDataCarrierSize is the maximum total payload, in bytes, accepted for a
+// single OP_FALSE OP_RETURN output. BSV itself has no practical limit, but
+// sigil only needs small payloads (hashes, short tags) and caps them to
+// avoid accidentally paying for megabytes of data.
+const MaxDataCarrierSize = 100 * 1024
+
+// ErrNoData indicates a data carrier output was requested without any payload.
+var ErrNoData = errors.New("data output has no payload")
+
+// DataScript builds an OP_FALSE OP_RETURN locking script carrying the given pushes.
+func DataScript(pushes ...[]byte) (*script.Script, error) {
+	if len(pushes) == 0 {
+		return nil, ErrNoData
+	}
+
+	s := &script.Script{}
+	if err := s.AppendOpcodes(script.OpFALSE, script.OpRETURN); err != nil {
+		return nil, fmt.Errorf("appending opcodes: %w", err)
+	}
+	if err := s.AppendPushDataArray(pushes); err != nil {
+		return nil, fmt.Errorf("appending data pushes: %w", err)
+	}
+
+	return s, nil
+}
+
+// DataOutputSize returns the serialized size in bytes of a data carrier output
+// holding the given pushes: 8 bytes of satoshis, the script length varint and
+// the script itself.
+func DataOutputSize(pushes [][]byte) uint64 {
+	s, err := DataScript(pushes...)
+	if err != nil {
+		return 0
+	}
+	scriptLen := uint64(len(*s))
+	return 8 + varIntSize(scriptLen) + scriptLen
+}
+
+// varIntSize returns the number of bytes used to encode n as a Bitcoin varint.
+func varIntSize(n uint64) uint64 {
+	switch {
+	case n < 0xfd:
+		return 1
+	case n <= 0xffff:
+		return 3
+	case n <= 0xffffffff:
+		return 5
+	default:
+		return 9
+	}
+}
+
+// AddDataOutput adds a zero-value OP_FALSE OP_RETURN output carrying the given pushes.
+func (b *TxBuilder) AddDataOutput(pushes ...[]byte) error {
+	if len(pushes) == 0 {
+		return ErrNoData
+	}
+
+	var total int
+	for _, p := range pushes {
+		total += len(p)
+	}
+	if total > MaxDataCarrierSize {
+		return sigilerr.WithDetails(sigilerr.ErrDataTooLarge, map[string]string{
+			"size":    fmt.Sprintf("%d", total),
+			"maximum": fmt.Sprintf("%d", MaxDataCarrierSize),
+		})
+	}
+
+	b.Outputs = append(b.Outputs, TxOutput{Data: pushes})
+	return nil
+}
+
+// ExtractDataPushes decodes a raw transaction hex and returns the pushes of
+// every data carrier output, in output order. Outputs that are not data
+// carriers are skipped.
+func ExtractDataPushes(rawTxHex string) ([][][]byte, error) {
+	tx, err := transaction.NewTransactionFromHex(rawTxHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decoding transaction: %w", sigilerr.ErrInvalidTransaction, err)
+	}
+
+	var outputs [][][]byte
+	for _, out := range tx.Outputs {
+		if out.LockingScript == nil || !out.LockingScript.IsData() {
+			continue
+		}
+		outputs = append(outputs, dataPushes(out.LockingScript))
+	}
+
+	return outputs, nil
+}
+
+// dataPushes returns the pushed data of an OP_RETURN script, skipping the
+// leading OP_FALSE OP_RETURN. Undecodable scripts yield no pushes.
+func dataPushes(s *script.Script) [][]byte {
+	chunks, err := script.DecodeScript(*s, script.DecodeOptionsParseOpReturn)
+	if err != nil {
+		return nil
+	}
+
+	var pushes [][]byte
+	for _, c := range chunks {
+		if c.Op == script.OpFALSE || c.Op == script.OpRETURN {
+			continue
+		}
+		pushes = append(pushes, c.Data)
+	}
+	return pushes
+}
+
+// TxData describes a confirmed or pending transaction and its data carrier payloads.
+type TxData struct {
+	TxID          string
+	BlockHeight   int64
+	BlockTime     time.Time // zero when the transaction is unconfirmed
+	Confirmations int64
+	DataOutputs   [][][]byte
+}
+
+// GetTxData fetches a transaction by ID and extracts its data carrier outputs.
+func (c *Client) GetTxData(ctx context.Context, txid string) (*TxData, error) {
+	if !isValidTxID(txid) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTxID, txid)
+	}
+
+	c.debug("fetching transaction %s", txid)
+	start := time.Now()
+	info, err := c.woc.GetTxByHash(ctx, txid)
+	metrics.Global.RecordRPCCall("bsv", time.Since(start), err)
+	if err != nil {
+		c.logError("fetching transaction %s: %v", txid, err)
+		return nil, fmt.Errorf("fetching transaction: %w", err)
+	}
+	if info == nil || info.Hex == "" {
+		return nil, sigilerr.WithDetails(sigilerr.ErrTransactionNotFound, map[string]string{"txid": txid})
+	}
+
+	outputs, err := ExtractDataPushes(info.Hex)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &TxData{
+		TxID:          txid,
+		BlockHeight:   info.BlockHeight,
+		Confirmations: info.Confirmations,
+		DataOutputs:   outputs,
+	}
+	if info.BlockTime > 0 {
+		data.BlockTime = time.Unix(info.BlockTime, 0).UTC()
+	}
+
+	return data, nil
+}
+
+// DataRequest describes a transaction that publishes data in an OP_RETURN output.
+type DataRequest struct {
+	// Data holds the pushes of the data carrier output.
+	Data [][]byte
+
+	// UTXOs are the candidate inputs used to pay the fee.
+	UTXOs []chain.UTXO
+
+	// PrivateKeys maps each UTXO address to its signing key.
+	// Keys are zeroed after signing.
+	PrivateKeys map[string][]byte
+
+	// ChangeAddress receives the remaining funds.
+	ChangeAddress string
+
+	// FeeRate in satoshis per kilobyte. Zero uses DefaultFeeRate.
+	FeeRate uint64
+}
+
+// SendData builds, signs and broadcasts a transaction carrying req.Data in a
+// zero-value data output. Inputs are selected largest-first until they cover
+// the fee; any remainder above dust is returned to the change address.
+func (c *Client) SendData(ctx context.Context, req DataRequest) (*chain.TransactionResult, error) {
+	if err := c.ValidateAddress(req.ChangeAddress); err != nil {
+		return nil, fmt.Errorf("invalid change address: %w", err)
+	}
+
+	feeRate := uint64(DefaultFeeRate)
+	if req.FeeRate > 0 {
+		feeRate = req.FeeRate
+	}
+
+	builder := NewTxBuilder()
+	builder.SetNetwork(c.network)
+	builder.SetFeeRate(feeRate)
+
+	if err := builder.AddDataOutput(req.Data...); err != nil {
+		return nil, err
+	}
+
+	selected, change, err := SelectDataUTXOs(convertChainUTXOs(req.UTXOs), DataOutputSize(req.Data), builder.FeeRate)
+	if err != nil {
+		return nil, err
+	}
+	for _, utxo := range selected {
+		if err = builder.AddInput(utxo); err != nil {
+			return nil, fmt.Errorf("adding input: %w", err)
+		}
+	}
+
+	if change >= chain.BSV.DustLimit() {
+		if err = builder.AddOutput(req.ChangeAddress, change); err != nil {
+			return nil, fmt.Errorf("adding change output: %w", err)
+		}
+	}
+
+	if err = builder.Validate(); err != nil {
+		return nil, fmt.Errorf("validating transaction: %w", err)
+	}
+
+	rawTx, err := BuildRawTransactionMultiKey(builder, req.PrivateKeys)
+	for addr := range req.PrivateKeys {
+		wallet.ZeroBytes(req.PrivateKeys[addr])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("building raw transaction: %w", err)
+	}
+	c.debug("send data: raw tx built, %d bytes", len(rawTx))
+
+	txHash, err := c.BroadcastTransaction(ctx, rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Safe: Validate already confirmed inputTotal >= outputTotal
+	inputTotal, _ := builder.TotalInputAmount()
+	outputTotal, _ := builder.TotalOutputAmount()
+
+	return &chain.TransactionResult{
+		Hash:   txHash,
+		To:     "OP_RETURN",
+		Amount: c.FormatAmount(chain.AmountToBigInt(0)),
+		Fee:    c.FormatAmount(chain.AmountToBigInt(inputTotal - outputTotal)),
+		Status: "pending",
+	}, nil
+}
+
+// SelectDataUTXOs picks inputs (largest first) to pay for a data output of
+// dataSize bytes plus a change output. The returned change is zero when the
+// remainder would be dust.
+func SelectDataUTXOs(utxos []UTXO, dataSize, feeRate uint64) (selected []UTXO, change uint64, err error) {
+	if len(utxos) == 0 {
+		return nil, 0, ErrInsufficientFunds
+	}
+
+	sorted := make([]UTXO, len(utxos))
+	copy(sorted, utxos)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Amount > sorted[j].Amount
+	})
+
+	var total, fee uint64
+	for _, utxo := range sorted {
+		selected = append(selected, utxo)
+
+		sum, addErr := checkedAdd(total, utxo.Amount)
+		if addErr != nil {
+			return nil, 0, fmt.Errorf("UTXO sum: %w", addErr)
+		}
+		total = sum
+
+		size := EstimateTxSize(len(selected), 1) + dataSize
+		fee = (size*feeRate + 999) / 1000
+		if total >= fee {
+			change = total - fee
+			if change < chain.BSV.DustLimit() {
+				change = 0
+			}
+			return selected, change, nil
+		}
+	}
+
+	return nil, 0, fmt.Errorf("%w: need %d satoshis, have %d", ErrInsufficientFunds, fee, total)
+}
@@ -0,0 +1,205 @@
+package bsv
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	whatsonchain "github.com/mrz1836/go-whatsonchain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// captureBroadcaster records the last broadcast raw transaction.
+type captureBroadcaster struct {
+	rawHex string
+	txid   string
+}
+
+func (b *captureBroadcaster) Name() string { return "capture" }
+
+func (b *captureBroadcaster) Broadcast(_ context.Context, rawTxHex string) (string, error) {
+	b.rawHex = rawTxHex
+	return b.txid, nil
+}
+
+func TestDataScript(t *testing.T) {
+	t.Parallel()
+
+	t.Run("starts with OP_FALSE OP_RETURN", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := DataScript([]byte("hello"), []byte{0x01, 0x02})
+		require.NoError(t, err)
+		assert.True(t, s.IsData())
+		assert.Equal(t, byte(0x00), (*s)[0])
+		assert.Equal(t, byte(0x6a), (*s)[1])
+	})
+
+	t.Run("no pushes", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := DataScript()
+		require.ErrorIs(t, err, ErrNoData)
+	})
+}
+
+func TestDataOutputSize(t *testing.T) {
+	t.Parallel()
+
+	// 8 (satoshis) + 1 (varint) + 2 (OP_FALSE OP_RETURN) + 1 (push op) + 32 (data)
+	assert.Equal(t, uint64(44), DataOutputSize([][]byte{make([]byte, 32)}))
+	assert.Equal(t, uint64(0), DataOutputSize(nil))
+}
+
+func TestTxBuilder_AddDataOutput(t *testing.T) {
+	t.Parallel()
+
+	t.Run("adds zero value output", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTxBuilder()
+		require.NoError(t, b.AddDataOutput([]byte("data")))
+		require.Len(t, b.Outputs, 1)
+		assert.Equal(t, uint64(0), b.Outputs[0].Amount)
+	})
+
+	t.Run("rejects empty payload", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTxBuilder()
+		require.ErrorIs(t, b.AddDataOutput(), ErrNoData)
+	})
+
+	t.Run("rejects oversized payload", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTxBuilder()
+		err := b.AddDataOutput(make([]byte, MaxDataCarrierSize+1))
+		require.ErrorIs(t, err, sigilerr.ErrDataTooLarge)
+	})
+
+	t.Run("size estimate includes payload", func(t *testing.T) {
+		t.Parallel()
+
+		b := NewTxBuilder()
+		require.NoError(t, b.AddInput(makeUTXO(testTxID(1), 10000)))
+		before := b.EstimateSize()
+		require.NoError(t, b.AddDataOutput(make([]byte, 1000)))
+		assert.Greater(t, b.EstimateSize()-before, uint64(1000))
+	})
+}
+
+func TestSendData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("builds data transaction with change", func(t *testing.T) {
+		t.Parallel()
+
+		kp := getTestKeyPair()
+		bc := &captureBroadcaster{txid: testValidTxID}
+		client := NewClient(context.Background(), &ClientOptions{
+			WOCClient:    &mockWOCClient{},
+			Broadcasters: []Broadcaster{bc},
+		})
+
+		payload := [][]byte{[]byte("sigil-stamp"), bytes.Repeat([]byte{0xab}, 32)}
+		result, err := client.SendData(context.Background(), DataRequest{
+			Data: payload,
+			UTXOs: []chain.UTXO{
+				{TxID: testTxID(1), Vout: 0, Amount: 5000, Address: kp.Address},
+			},
+			PrivateKeys:   map[string][]byte{kp.Address: kp.PrivateKey},
+			ChangeAddress: kp.Address,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, testValidTxID, result.Hash)
+
+		outputs, err := ExtractDataPushes(bc.rawHex)
+		require.NoError(t, err)
+		require.Len(t, outputs, 1)
+		assert.Equal(t, payload, outputs[0])
+	})
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		t.Parallel()
+
+		kp := getTestKeyPair()
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{}})
+
+		_, err := client.SendData(context.Background(), DataRequest{
+			Data:          [][]byte{[]byte("x")},
+			PrivateKeys:   map[string][]byte{kp.Address: kp.PrivateKey},
+			ChangeAddress: kp.Address,
+		})
+		require.ErrorIs(t, err, ErrInsufficientFunds)
+	})
+
+	t.Run("invalid change address", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{}})
+
+		_, err := client.SendData(context.Background(), DataRequest{
+			Data:          [][]byte{[]byte("x")},
+			ChangeAddress: "invalid",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "change address")
+	})
+}
+
+func TestGetTxData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid txid", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{}})
+		_, err := client.GetTxData(context.Background(), "nope")
+		require.ErrorIs(t, err, ErrInvalidTxID)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{}})
+		_, err := client.GetTxData(context.Background(), testValidTxID)
+		require.ErrorIs(t, err, sigilerr.ErrTransactionNotFound)
+	})
+
+	t.Run("extracts payload and block time", func(t *testing.T) {
+		t.Parallel()
+
+		kp := getTestKeyPair()
+		b := NewTxBuilder()
+		require.NoError(t, b.AddInput(UTXO{TxID: testTxID(1), Amount: 5000, Address: kp.Address}))
+		require.NoError(t, b.AddDataOutput([]byte("proof")))
+		raw, err := BuildRawTransaction(b, kp.PrivateKey)
+		require.NoError(t, err)
+
+		mock := &mockWOCClient{
+			txByHashFunc: func(_ context.Context, _ string) (*whatsonchain.TxInfo, error) {
+				return &whatsonchain.TxInfo{
+					Hex:           hex.EncodeToString(raw),
+					BlockHeight:   800000,
+					BlockTime:     1700000000,
+					Confirmations: 6,
+				}, nil
+			},
+		}
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: mock})
+
+		data, err := client.GetTxData(context.Background(), testValidTxID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), data.Confirmations)
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), data.BlockTime)
+		require.Len(t, data.DataOutputs, 1)
+		assert.Equal(t, [][]byte{[]byte("proof")}, data.DataOutputs[0])
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	whatsonchain "github.com/mrz1836/go-whatsonchain"
+
+	"github.com/mrz1836/sigil/internal/metrics"
 )
 
 const (
@@ -61,20 +63,24 @@ type FeeQuote struct {
 }
 
 // GetFeeQuote fetches the current fee quote from WhatsOnChain's miner fees API.
-// Falls back to the default fee rate on any error.
+// Live quotes are recorded in the fee table when one is configured. On any
+// error the last-known-good quote from the fee table is returned, or the
+// default fee rate if there is none; check FeeQuote.IsFallback to detect this.
 func (c *Client) GetFeeQuote(ctx context.Context) (*FeeQuote, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseFeeQuote)()
+
 	now := time.Now().Unix()
 	from := now - feeWindowSeconds
 
 	entries, err := c.woc.GetMinerFeesStats(ctx, from, now)
 	if err != nil {
-		c.logError("fee API request failed, using default rate: %v", err)
-		return defaultFeeQuote(), nil
+		c.logError("fee API request failed, using fallback rate: %v", err)
+		return c.fallbackFeeQuote(), nil
 	}
 
 	if len(entries) == 0 {
-		c.debug("fee API returned no entries, using default rate")
-		return defaultFeeQuote(), nil
+		c.debug("fee API returned no entries, using fallback rate")
+		return c.fallbackFeeQuote(), nil
 	}
 
 	rate := uint64(math.Ceil(selectFeeRate(entries, c.feeStrategy, c.minMiners)))
@@ -84,12 +90,33 @@ func (c *Client) GetFeeQuote(ctx context.Context) (*FeeQuote, error) {
 	}
 	c.debug("fee quote: %d sat/KB from %d miners (strategy=%s, min_miners=%d)", rate, len(entries), c.feeStrategy, c.minMiners)
 
-	return &FeeQuote{
+	quote := &FeeQuote{
 		StandardRate: rate,
 		DataRate:     rate,
-		Source:       "whatsonchain",
+		Source:       FeeSourceWhatsOnChain,
 		Timestamp:    time.Now(),
-	}, nil
+	}
+
+	if c.feeTable != nil {
+		if err := c.feeTable.Record(c.network, quote); err != nil {
+			c.logError("failed to record fee quote: %v", err)
+		}
+	}
+
+	return quote, nil
+}
+
+// fallbackFeeQuote returns the last-known-good quote for the client's network,
+// or the default quote when the fee table has no entry.
+func (c *Client) fallbackFeeQuote() *FeeQuote {
+	if c.feeTable != nil {
+		if quote, ok := c.feeTable.Lookup(c.network); ok {
+			quote.Source = FeeSourceCached
+			c.debug("using cached fee rate %d sat/KB from %s", quote.StandardRate, quote.Timestamp.Format(time.RFC3339))
+			return quote
+		}
+	}
+	return defaultFeeQuote()
 }
 
 // selectFeeRate picks a fee rate from miner entries based on the given strategy.
@@ -180,7 +207,7 @@ func defaultFeeQuote() *FeeQuote {
 	return &FeeQuote{
 		StandardRate: DefaultFeeRate,
 		DataRate:     DefaultFeeRate,
-		Source:       "default",
+		Source:       FeeSourceDefault,
 		Timestamp:    time.Now(),
 	}
 }
@@ -0,0 +1,163 @@
+package bsv
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// maxFeeAdjustments bounds how many times Send re-signs a transaction whose
+// signed size left its fee below the target rate.
+const maxFeeAdjustments = 3
+
+// ErrFeeBelowTarget indicates the fee of a signed transaction could not be
+// raised to the target rate.
+var ErrFeeBelowTarget = errors.New("signed transaction fee is below the target fee rate")
+
+// signWithFeeCheck signs the plan's transaction and checks the fee against
+// the real signed size, which can exceed EstimateTxSize for unusual scripts.
+// A fee below the target rate is raised from the change, or by adding a
+// spare input, and the transaction is signed again.
+func (c *Client) signWithFeeCheck(plan *sendPlan, sign func() ([]byte, error)) ([]byte, error) {
+	for attempt := 0; ; attempt++ {
+		rawTx, err := sign()
+		if err != nil {
+			return nil, err
+		}
+
+		shortfall, err := feeShortfall(plan.builder, len(rawTx))
+		if err != nil {
+			return nil, err
+		}
+		if shortfall == 0 {
+			return rawTx, nil
+		}
+		if attempt == maxFeeAdjustments {
+			return nil, fmt.Errorf("%w: still %d satoshis short after %d adjustments", ErrFeeBelowTarget, shortfall, attempt)
+		}
+
+		plan.signedSize = max(plan.signedSize, len(rawTx))
+		c.debug("send: signed size %d bytes leaves fee %d satoshis below %d sat/KB, adjusting", len(rawTx), shortfall, plan.builder.FeeRate)
+		if err := plan.coverShortfall(shortfall); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// feeShortfall returns how many satoshis the fee of a signed transaction of
+// rawSize bytes falls short of the builder's fee rate, or zero when it is
+// covered.
+func feeShortfall(builder *TxBuilder, rawSize int) (uint64, error) {
+	inputTotal, err := builder.TotalInputAmount()
+	if err != nil {
+		return 0, err
+	}
+	outputTotal, err := builder.TotalOutputAmount()
+	if err != nil {
+		return 0, err
+	}
+	fee := inputTotal - outputTotal
+
+	//nolint:gosec // Safe: a serialized transaction size is never negative
+	required := (uint64(rawSize)*builder.FeeRate + 999) / 1000
+	if fee >= required {
+		return 0, nil
+	}
+	return required - fee, nil
+}
+
+// coverShortfall raises the fee by shortfall. A sweep takes it from the
+// recipient, or from the largest recipient of a split sweep. Otherwise it comes out of the change, and the change output is
+// dropped when what is left would be dust. When the change cannot cover it,
+// the smallest spare UTXO that can is added as an input.
+func (p *sendPlan) coverShortfall(shortfall uint64) error {
+	b := p.builder
+	dust := chain.BSV.DustLimit()
+
+	if p.sweep {
+		out := &b.Outputs[0]
+		for i := range b.Outputs {
+			if b.Outputs[i].Amount > out.Amount {
+				out = &b.Outputs[i]
+			}
+		}
+		if out.Amount < shortfall+dust {
+			return fmt.Errorf("%w: sweep cannot cover %d more satoshis of fee", ErrInsufficientFunds, shortfall)
+		}
+		out.Amount -= shortfall
+		p.amount -= shortfall
+		return nil
+	}
+
+	if p.change >= 0 {
+		change := b.Outputs[p.change].Amount
+		if change >= shortfall+dust {
+			b.Outputs[p.change].Amount -= shortfall
+			return nil
+		}
+		// Dropping the change adds it all to the fee and shrinks the transaction
+		b.Outputs = append(b.Outputs[:p.change], b.Outputs[p.change+1:]...)
+		p.change = -1
+		if change >= shortfall {
+			return nil
+		}
+		shortfall -= change
+	}
+
+	return p.addSpareInput(shortfall)
+}
+
+// addSpareInput adds the smallest spare UTXO that covers shortfall plus the
+// fee for its own input and a change output, returning what is left over as
+// change.
+func (p *sendPlan) addSpareInput(shortfall uint64) error {
+	b := p.builder
+	extraFee := (uint64(P2PKHInputSize+P2PKHOutputSize)*b.FeeRate + 999) / 1000
+
+	sort.Slice(p.spare, func(i, j int) bool { return p.spare[i].Amount < p.spare[j].Amount })
+	for i, u := range p.spare {
+		if u.Amount < shortfall+extraFee {
+			continue
+		}
+		p.spare = append(p.spare[:i:i], p.spare[i+1:]...)
+		if err := b.AddInput(u); err != nil {
+			return fmt.Errorf("adding input: %w", err)
+		}
+		if change := u.Amount - shortfall - extraFee; change >= chain.BSV.DustLimit() {
+			if err := b.AddOutput(p.changeAddr, change); err != nil {
+				return fmt.Errorf("adding change output: %w", err)
+			}
+			p.change = len(b.Outputs) - 1
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: no spare UTXO covers %d more satoshis of fee", ErrInsufficientFunds, shortfall)
+}
+
+// unspentUTXOs returns the UTXOs in all that are not in selected.
+func unspentUTXOs(all, selected []UTXO) []UTXO {
+	used := make(map[string]bool, len(selected))
+	for _, u := range selected {
+		used[fmt.Sprintf("%s:%d", u.TxID, u.Vout)] = true
+	}
+	var spare []UTXO
+	for _, u := range all {
+		if !used[fmt.Sprintf("%s:%d", u.TxID, u.Vout)] {
+			spare = append(spare, u)
+		}
+	}
+	return spare
+}
+
+// keyedUTXOs returns the UTXOs whose address has a signing key.
+func keyedUTXOs(utxos []UTXO, keys map[string][]byte) []UTXO {
+	var keyed []UTXO
+	for _, u := range utxos {
+		if _, ok := keys[u.Address]; ok {
+			keyed = append(keyed, u)
+		}
+	}
+	return keyed
+}
@@ -0,0 +1,132 @@
+package bsv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPlan returns a plan spending one 100000-satoshi input to a
+// 50000-satoshi payment with the given change, at 1000 sat/KB.
+func newTestPlan(t *testing.T, change uint64, spare ...UTXO) *sendPlan {
+	t.Helper()
+
+	b := NewTxBuilder()
+	b.SetFeeRate(1000)
+	require.NoError(t, b.AddInput(UTXO{TxID: testTxID(1), Amount: 100000, Address: validAddress()}))
+	require.NoError(t, b.AddOutput(validAddress2(), 50000))
+	plan := &sendPlan{builder: b, amount: 50000, change: -1, changeAddr: validAddress(), spare: spare}
+	if change > 0 {
+		require.NoError(t, b.AddOutput(validAddress(), change))
+		plan.change = 1
+	}
+	return plan
+}
+
+// sizedSigner returns a signer whose transactions are bytesPerInput bytes
+// per input, standing in for inputs with large unlocking scripts.
+func sizedSigner(plan *sendPlan, bytesPerInput int) func() ([]byte, error) {
+	return func() ([]byte, error) {
+		return make([]byte, bytesPerInput*len(plan.builder.Inputs)), nil
+	}
+}
+
+func TestFeeShortfall(t *testing.T) {
+	t.Parallel()
+
+	plan := newTestPlan(t, 49700) // fee 300
+	short, err := feeShortfall(plan.builder, 300)
+	require.NoError(t, err)
+	assert.Zero(t, short)
+
+	short, err = feeShortfall(plan.builder, 450)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(150), short)
+}
+
+func TestSignWithFeeCheck(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(context.Background(), nil)
+
+	t.Run("estimate holds", func(t *testing.T) {
+		t.Parallel()
+		plan := newTestPlan(t, 49700)
+		raw, err := client.signWithFeeCheck(plan, sizedSigner(plan, 250))
+		require.NoError(t, err)
+		assert.Len(t, raw, 250)
+		assert.Equal(t, uint64(49700), plan.builder.Outputs[1].Amount)
+	})
+
+	t.Run("change pays the shortfall", func(t *testing.T) {
+		t.Parallel()
+		plan := newTestPlan(t, 49700)
+		_, err := client.signWithFeeCheck(plan, sizedSigner(plan, 400))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(49600), plan.builder.Outputs[1].Amount)
+		short, err := feeShortfall(plan.builder, 400)
+		require.NoError(t, err)
+		assert.Zero(t, short)
+	})
+
+	t.Run("dust change is dropped", func(t *testing.T) {
+		t.Parallel()
+		plan := newTestPlan(t, 150)
+		plan.builder.Outputs[0].Amount = 99650 // fee 200
+		_, err := client.signWithFeeCheck(plan, sizedSigner(plan, 350))
+		require.NoError(t, err)
+		assert.Len(t, plan.builder.Outputs, 1, "the change went to the fee")
+		assert.Equal(t, -1, plan.change)
+	})
+
+	t.Run("spare input is added", func(t *testing.T) {
+		t.Parallel()
+		spare := []UTXO{
+			{TxID: testTxID(2), Amount: 100, Address: validAddress()},
+			{TxID: testTxID(3), Amount: 20000, Address: validAddress()},
+			{TxID: testTxID(4), Amount: 90000, Address: validAddress()},
+		}
+		plan := newTestPlan(t, 0, spare...)
+		plan.builder.Outputs[0].Amount = 99800 // fee 200, no change
+		_, err := client.signWithFeeCheck(plan, sizedSigner(plan, 400))
+		require.NoError(t, err)
+		require.Len(t, plan.builder.Inputs, 2)
+		assert.Equal(t, testTxID(3), plan.builder.Inputs[1].TxID, "the smallest spare that covers the fee")
+		require.Len(t, plan.builder.Outputs, 2, "the rest of the spare returns as change")
+		short, err := feeShortfall(plan.builder, 800)
+		require.NoError(t, err)
+		assert.Zero(t, short)
+	})
+
+	t.Run("sweep pays from the recipient", func(t *testing.T) {
+		t.Parallel()
+		plan := newTestPlan(t, 0)
+		plan.sweep = true
+		plan.builder.Outputs[0].Amount = 99800
+		plan.amount = 99800
+		_, err := client.signWithFeeCheck(plan, sizedSigner(plan, 300))
+		require.NoError(t, err)
+		assert.Equal(t, uint64(99700), plan.builder.Outputs[0].Amount)
+		assert.Equal(t, uint64(99700), plan.amount)
+	})
+
+	t.Run("nothing covers the shortfall", func(t *testing.T) {
+		t.Parallel()
+		plan := newTestPlan(t, 0)
+		plan.builder.Outputs[0].Amount = 99800
+		_, err := client.signWithFeeCheck(plan, sizedSigner(plan, 400))
+		require.ErrorIs(t, err, ErrInsufficientFunds)
+	})
+}
+
+func TestUnspentAndKeyedUTXOs(t *testing.T) {
+	t.Parallel()
+
+	a := UTXO{TxID: testTxID(1), Vout: 0, Address: validAddress()}
+	b := UTXO{TxID: testTxID(1), Vout: 1, Address: validAddress2()}
+	spare := unspentUTXOs([]UTXO{a, b}, []UTXO{a})
+	assert.Equal(t, []UTXO{b}, spare)
+	assert.Empty(t, keyedUTXOs(spare, map[string][]byte{validAddress(): {1}}))
+}
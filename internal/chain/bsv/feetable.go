@@ -0,0 +1,150 @@
+package bsv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+// Fee quote sources reported in FeeQuote.Source.
+const (
+	// FeeSourceWhatsOnChain is a live quote from WhatsOnChain's miner fee stats.
+	FeeSourceWhatsOnChain = "whatsonchain"
+
+	// FeeSourceCached is the last-known-good quote from the local fee table,
+	// used when the fee API is unreachable.
+	FeeSourceCached = "cached"
+
+	// FeeSourceDefault is the hard-coded DefaultFeeRate, used when the fee API
+	// is unreachable and no fee table entry exists.
+	FeeSourceDefault = "default"
+)
+
+const (
+	// feeTableFilePermissions is the permission mode for the fee table file.
+	feeTableFilePermissions = 0o600
+
+	// feeTableDirPermissions is the permission mode for the fee table directory.
+	feeTableDirPermissions = 0o700
+)
+
+// ErrCorruptFeeTable indicates the fee table file is malformed JSON.
+var ErrCorruptFeeTable = errors.New("fee table file is corrupted")
+
+// IsFallback reports whether the quote did not come from a live fee provider.
+func (q *FeeQuote) IsFallback() bool {
+	return q.Source == FeeSourceCached || q.Source == FeeSourceDefault
+}
+
+// Age returns how long ago the quote was fetched from a live provider.
+// Returns zero when the fetch time is unknown.
+func (q *FeeQuote) Age() time.Duration {
+	if q.Timestamp.IsZero() {
+		return 0
+	}
+	return time.Since(q.Timestamp)
+}
+
+// FeeTable holds the last-known-good fee quote for each network.
+type FeeTable struct {
+	Quotes map[Network]FeeQuote `json:"quotes"`
+}
+
+// FeeTableStore persists the last-known-good fee table to disk so that sends
+// made while fee providers are unreachable fall back to a recent rate rather
+// than DefaultFeeRate.
+type FeeTableStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFeeTableStore creates a fee table store backed by the file at path.
+func NewFeeTableStore(path string) *FeeTableStore {
+	return &FeeTableStore{path: path}
+}
+
+// Path returns the fee table file path.
+func (s *FeeTableStore) Path() string {
+	return s.path
+}
+
+// Load reads the fee table. Returns an empty table if the file doesn't exist.
+func (s *FeeTableStore) Load() (*FeeTable, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// Lookup returns the last-known-good quote for a network.
+func (s *FeeTableStore) Lookup(network Network) (*FeeQuote, bool) {
+	table, err := s.Load()
+	if err != nil {
+		return nil, false
+	}
+
+	quote, ok := table.Quotes[network]
+	if !ok || quote.StandardRate == 0 {
+		return nil, false
+	}
+	return &quote, true
+}
+
+// Record stores a live quote as the last-known-good quote for a network.
+// Fallback quotes are ignored so a cached rate never refreshes its own age.
+func (s *FeeTableStore) Record(network Network, quote *FeeQuote) error {
+	if quote == nil || quote.IsFallback() {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.load()
+	if err != nil && !errors.Is(err, ErrCorruptFeeTable) {
+		return err
+	}
+	table.Quotes[network] = *quote
+
+	if err := os.MkdirAll(filepath.Dir(s.path), feeTableDirPermissions); err != nil {
+		return fmt.Errorf("creating fee table directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling fee table: %w", err)
+	}
+
+	if err := fileutil.WriteAtomic(s.path, data, feeTableFilePermissions); err != nil {
+		return fmt.Errorf("writing fee table: %w", err)
+	}
+	return nil
+}
+
+// load reads the fee table without locking. A corrupt file yields an empty
+// table together with ErrCorruptFeeTable.
+func (s *FeeTableStore) load() (*FeeTable, error) {
+	table := &FeeTable{Quotes: make(map[Network]FeeQuote)}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return table, nil
+	}
+	if err != nil {
+		return table, fmt.Errorf("reading fee table: %w", err)
+	}
+
+	if err := json.Unmarshal(data, table); err != nil {
+		return &FeeTable{Quotes: make(map[Network]FeeQuote)}, fmt.Errorf("%w: %w", ErrCorruptFeeTable, err)
+	}
+	if table.Quotes == nil {
+		table.Quotes = make(map[Network]FeeQuote)
+	}
+	return table, nil
+}
@@ -0,0 +1,143 @@
+package bsv
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	whatsonchain "github.com/mrz1836/go-whatsonchain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeTableStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file is empty", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewFeeTableStore(filepath.Join(t.TempDir(), "fees.json"))
+		table, err := s.Load()
+		require.NoError(t, err)
+		assert.Empty(t, table.Quotes)
+
+		_, ok := s.Lookup(NetworkMainnet)
+		assert.False(t, ok)
+	})
+
+	t.Run("record and lookup per network", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "cache", "fees.json")
+		s := NewFeeTableStore(path)
+		fetched := time.Now().Add(-time.Hour).UTC()
+
+		require.NoError(t, s.Record(NetworkMainnet, &FeeQuote{StandardRate: 120, DataRate: 120, Source: FeeSourceWhatsOnChain, Timestamp: fetched}))
+		require.NoError(t, s.Record(NetworkTestnet, &FeeQuote{StandardRate: 60, DataRate: 60, Source: FeeSourceWhatsOnChain, Timestamp: fetched}))
+
+		quote, ok := s.Lookup(NetworkMainnet)
+		require.True(t, ok)
+		assert.Equal(t, uint64(120), quote.StandardRate)
+		assert.True(t, quote.Timestamp.Equal(fetched))
+
+		quote, ok = s.Lookup(NetworkTestnet)
+		require.True(t, ok)
+		assert.Equal(t, uint64(60), quote.StandardRate)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("fallback quotes are not recorded", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewFeeTableStore(filepath.Join(t.TempDir(), "fees.json"))
+		require.NoError(t, s.Record(NetworkMainnet, defaultFeeQuote()))
+		require.NoError(t, s.Record(NetworkMainnet, &FeeQuote{StandardRate: 90, Source: FeeSourceCached}))
+
+		_, ok := s.Lookup(NetworkMainnet)
+		assert.False(t, ok)
+	})
+
+	t.Run("corrupt file is replaced on record", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "fees.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+		s := NewFeeTableStore(path)
+
+		_, err := s.Load()
+		require.ErrorIs(t, err, ErrCorruptFeeTable)
+
+		require.NoError(t, s.Record(NetworkMainnet, &FeeQuote{StandardRate: 100, Source: FeeSourceWhatsOnChain, Timestamp: time.Now()}))
+		quote, ok := s.Lookup(NetworkMainnet)
+		require.True(t, ok)
+		assert.Equal(t, uint64(100), quote.StandardRate)
+	})
+}
+
+func TestGetFeeQuote_FeeTable(t *testing.T) {
+	t.Parallel()
+
+	t.Run("live quote is recorded", func(t *testing.T) {
+		t.Parallel()
+
+		table := NewFeeTableStore(filepath.Join(t.TempDir(), "fees.json"))
+		mock := &mockWOCClient{
+			feeFunc: func(_ context.Context, _, _ int64) ([]*whatsonchain.MinerFeeStats, error) {
+				return []*whatsonchain.MinerFeeStats{{Miner: "MinerA", MinFeeRate: 300}}, nil
+			},
+		}
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: mock, FeeTable: table})
+
+		quote, err := client.GetFeeQuote(context.Background())
+		require.NoError(t, err)
+		assert.False(t, quote.IsFallback())
+
+		saved, ok := table.Lookup(NetworkMainnet)
+		require.True(t, ok)
+		assert.Equal(t, uint64(300), saved.StandardRate)
+	})
+
+	t.Run("unreachable API uses last-known-good quote", func(t *testing.T) {
+		t.Parallel()
+
+		table := NewFeeTableStore(filepath.Join(t.TempDir(), "fees.json"))
+		fetched := time.Now().Add(-3 * time.Hour)
+		require.NoError(t, table.Record(NetworkMainnet, &FeeQuote{StandardRate: 180, DataRate: 180, Source: FeeSourceWhatsOnChain, Timestamp: fetched}))
+
+		mock := &mockWOCClient{
+			feeFunc: func(_ context.Context, _, _ int64) ([]*whatsonchain.MinerFeeStats, error) {
+				return nil, errTestConnRefused
+			},
+		}
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: mock, FeeTable: table})
+
+		quote, err := client.GetFeeQuote(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, FeeSourceCached, quote.Source)
+		assert.True(t, quote.IsFallback())
+		assert.Equal(t, uint64(180), quote.StandardRate)
+		assert.InDelta(t, (3 * time.Hour).Seconds(), quote.Age().Seconds(), 60)
+	})
+
+	t.Run("unreachable API without table entry uses default", func(t *testing.T) {
+		t.Parallel()
+
+		table := NewFeeTableStore(filepath.Join(t.TempDir(), "fees.json"))
+		mock := &mockWOCClient{
+			feeFunc: func(_ context.Context, _, _ int64) ([]*whatsonchain.MinerFeeStats, error) {
+				return nil, errTestConnRefused
+			},
+		}
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: mock, FeeTable: table, Network: NetworkTestnet})
+
+		quote, err := client.GetFeeQuote(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, FeeSourceDefault, quote.Source)
+		assert.Equal(t, uint64(DefaultFeeRate), quote.StandardRate)
+	})
+}
@@ -0,0 +1,259 @@
+package bsv
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"slices"
+
+	"github.com/bsv-blockchain/go-sdk/util"
+)
+
+// BIP158 basic filter parameters: each item is coded with a P-bit remainder,
+// and the false positive rate is 1/M.
+const (
+	filterP = 19
+	filterM = 784931
+)
+
+// ErrMalformedFilter indicates a compact block filter could not be decoded.
+var ErrMalformedFilter = errors.New("malformed compact block filter")
+
+// BlockFilter is a BIP158 basic compact block filter: a Golomb-coded set of
+// the scripts a block's transactions create and spend. It answers whether a
+// block may touch a set of scripts without downloading the block, with no
+// false negatives and rare false positives.
+type BlockFilter struct {
+	n      uint64
+	k0, k1 uint64
+	data   []byte
+}
+
+// ParseBlockFilter decodes a serialized basic filter for the block with the
+// given hash (hex, as shown by explorers).
+func ParseBlockFilter(blockHash string, filter []byte) (*BlockFilter, error) {
+	k0, k1, err := filterKey(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	var n util.VarInt
+	read, err := n.ReadFrom(bytes.NewReader(filter))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedFilter, err)
+	}
+	return &BlockFilter{n: uint64(n), k0: k0, k1: k1, data: filter[read:]}, nil
+}
+
+// N returns the number of items in the filter.
+func (f *BlockFilter) N() uint64 {
+	return f.n
+}
+
+// MatchAny reports whether any of items may be in the filter.
+func (f *BlockFilter) MatchAny(items [][]byte) (bool, error) {
+	if f.n == 0 || len(items) == 0 {
+		return false, nil
+	}
+
+	modulus := f.n * filterM
+	targets := make([]uint64, len(items))
+	for i, item := range items {
+		targets[i] = hashToRange(f.k0, f.k1, item, modulus)
+	}
+	slices.Sort(targets)
+
+	r := bitReader{data: f.data}
+	var value uint64
+	ti := 0
+	for range f.n {
+		delta, err := r.golombRice()
+		if err != nil {
+			return false, err
+		}
+		value += delta
+		for ti < len(targets) && targets[ti] < value {
+			ti++
+		}
+		if ti == len(targets) {
+			return false, nil
+		}
+		if targets[ti] == value {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// BuildBlockFilter serializes a basic filter holding items for the block
+// with the given hash. Duplicate and empty items are dropped, as BIP158
+// requires.
+func BuildBlockFilter(blockHash string, items [][]byte) ([]byte, error) {
+	k0, k1, err := filterKey(blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	unique := make(map[string][]byte, len(items))
+	for _, item := range items {
+		if len(item) > 0 {
+			unique[string(item)] = item
+		}
+	}
+	n := uint64(len(unique))
+	modulus := n * filterM
+	values := make([]uint64, 0, n)
+	for _, item := range unique {
+		values = append(values, hashToRange(k0, k1, item, modulus))
+	}
+	slices.Sort(values)
+
+	var w bitWriter
+	var last uint64
+	for _, v := range values {
+		w.golombRice(v - last)
+		last = v
+	}
+	return append(util.VarInt(n).Bytes(), w.bytes()...), nil
+}
+
+// filterKey returns the SipHash key for a block: the first 16 bytes of its
+// hash in internal (little-endian) byte order.
+func filterKey(blockHash string) (uint64, uint64, error) {
+	b, err := hex.DecodeString(blockHash)
+	if err != nil || len(b) != 32 {
+		return 0, 0, fmt.Errorf("%w: invalid block hash %q", ErrMalformedFilter, blockHash)
+	}
+	slices.Reverse(b)
+	return binary.LittleEndian.Uint64(b[0:8]), binary.LittleEndian.Uint64(b[8:16]), nil
+}
+
+// hashToRange maps item uniformly into [0, modulus).
+func hashToRange(k0, k1 uint64, item []byte, modulus uint64) uint64 {
+	hi, _ := bits.Mul64(sipHash24(k0, k1, item), modulus)
+	return hi
+}
+
+// sipHash24 is SipHash-2-4 with the 128-bit key (k0, k1).
+func sipHash24(k0, k1 uint64, data []byte) uint64 {
+	v0 := k0 ^ 0x736f6d6570736575
+	v1 := k1 ^ 0x646f72616e646f6d
+	v2 := k0 ^ 0x6c7967656e657261
+	v3 := k1 ^ 0x7465646279746573
+
+	round := func() {
+		v0 += v1
+		v1 = bits.RotateLeft64(v1, 13)
+		v1 ^= v0
+		v0 = bits.RotateLeft64(v0, 32)
+		v2 += v3
+		v3 = bits.RotateLeft64(v3, 16)
+		v3 ^= v2
+		v0 += v3
+		v3 = bits.RotateLeft64(v3, 21)
+		v3 ^= v0
+		v2 += v1
+		v1 = bits.RotateLeft64(v1, 17)
+		v1 ^= v2
+		v2 = bits.RotateLeft64(v2, 32)
+	}
+
+	length := len(data)
+	for len(data) >= 8 {
+		m := binary.LittleEndian.Uint64(data)
+		v3 ^= m
+		round()
+		round()
+		v0 ^= m
+		data = data[8:]
+	}
+
+	last := uint64(length) << 56
+	for i, c := range data {
+		last |= uint64(c) << (8 * i)
+	}
+	v3 ^= last
+	round()
+	round()
+	v0 ^= last
+
+	v2 ^= 0xff
+	for range 4 {
+		round()
+	}
+	return v0 ^ v1 ^ v2 ^ v3
+}
+
+// bitReader reads a big-endian bit stream.
+type bitReader struct {
+	data []byte
+	pos  uint64
+}
+
+func (r *bitReader) bit() (uint64, error) {
+	i := r.pos / 8
+	if i >= uint64(len(r.data)) {
+		return 0, fmt.Errorf("%w: %w", ErrMalformedFilter, io.ErrUnexpectedEOF)
+	}
+	b := r.data[i] >> (7 - r.pos%8) & 1
+	r.pos++
+	return uint64(b), nil
+}
+
+// golombRice reads one Golomb-Rice coded value: the quotient in unary, then
+// a filterP-bit remainder.
+func (r *bitReader) golombRice() (uint64, error) {
+	var q uint64
+	for {
+		b, err := r.bit()
+		if err != nil {
+			return 0, err
+		}
+		if b == 0 {
+			break
+		}
+		q++
+	}
+	var rem uint64
+	for range filterP {
+		b, err := r.bit()
+		if err != nil {
+			return 0, err
+		}
+		rem = rem<<1 | b
+	}
+	return q<<filterP | rem, nil
+}
+
+// bitWriter writes a big-endian bit stream.
+type bitWriter struct {
+	data []byte
+	pos  uint64
+}
+
+func (w *bitWriter) bit(b uint64) {
+	if w.pos%8 == 0 {
+		w.data = append(w.data, 0)
+	}
+	if b != 0 {
+		w.data[len(w.data)-1] |= 1 << (7 - w.pos%8)
+	}
+	w.pos++
+}
+
+func (w *bitWriter) golombRice(v uint64) {
+	for q := v >> filterP; q > 0; q-- {
+		w.bit(1)
+	}
+	w.bit(0)
+	for i := filterP - 1; i >= 0; i-- {
+		w.bit(v >> uint(i) & 1)
+	}
+}
+
+func (w *bitWriter) bytes() []byte {
+	return w.data
+}
@@ -0,0 +1,92 @@
+package bsv
+
+import (
+	"encoding/binary"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSipHash24(t *testing.T) {
+	t.Parallel()
+
+	// Reference vectors from the SipHash paper: key 00..0f, message 00..n-1
+	k0 := binary.LittleEndian.Uint64([]byte{0, 1, 2, 3, 4, 5, 6, 7})
+	k1 := binary.LittleEndian.Uint64([]byte{8, 9, 10, 11, 12, 13, 14, 15})
+	msg := make([]byte, 15)
+	for i := range msg {
+		msg[i] = byte(i)
+	}
+
+	assert.Equal(t, uint64(0x726fdb47dd0e0e31), sipHash24(k0, k1, nil))
+	assert.Equal(t, uint64(0x93f5f5799a932462), sipHash24(k0, k1, msg[:8]))
+	assert.Equal(t, uint64(0xa129ca6149be45e5), sipHash24(k0, k1, msg))
+}
+
+func TestBlockFilter_MatchAny(t *testing.T) {
+	t.Parallel()
+
+	blockHash := "000000000000000002c3a3f3c4d8e0b43ad8d19ae28f4a1e8f2d7f41d1d7c1a0"
+	var items [][]byte
+	for i := range 200 {
+		items = append(items, fmt.Appendf(nil, "script-%d", i))
+	}
+
+	raw, err := BuildBlockFilter(blockHash, append(items, items[0], nil))
+	require.NoError(t, err)
+
+	filter, err := ParseBlockFilter(blockHash, raw)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(200), filter.N(), "duplicates and empty items are dropped")
+
+	for _, item := range []int{0, 57, 199} {
+		match, err := filter.MatchAny([][]byte{[]byte("absent"), items[item]})
+		require.NoError(t, err)
+		assert.True(t, match, "item %d", item)
+	}
+
+	match, err := filter.MatchAny([][]byte{[]byte("absent-1"), []byte("absent-2")})
+	require.NoError(t, err)
+	assert.False(t, match)
+
+	// The key comes from the block hash, so another block's filter differs.
+	other, err := ParseBlockFilter("00000000000000000a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f6071", raw)
+	require.NoError(t, err)
+	match, err = other.MatchAny(items[:1])
+	require.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestBlockFilter_Empty(t *testing.T) {
+	t.Parallel()
+
+	hash := "000000000000000002c3a3f3c4d8e0b43ad8d19ae28f4a1e8f2d7f41d1d7c1a0"
+	raw, err := BuildBlockFilter(hash, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0}, raw)
+
+	filter, err := ParseBlockFilter(hash, raw)
+	require.NoError(t, err)
+	match, err := filter.MatchAny([][]byte{[]byte("x")})
+	require.NoError(t, err)
+	assert.False(t, match)
+}
+
+func TestBlockFilter_Malformed(t *testing.T) {
+	t.Parallel()
+
+	hash := "000000000000000002c3a3f3c4d8e0b43ad8d19ae28f4a1e8f2d7f41d1d7c1a0"
+	_, err := ParseBlockFilter("abcd", []byte{0})
+	require.ErrorIs(t, err, ErrMalformedFilter)
+
+	_, err = ParseBlockFilter(hash, nil)
+	require.ErrorIs(t, err, ErrMalformedFilter)
+
+	// Claims 5 items but has no data
+	filter, err := ParseBlockFilter(hash, []byte{5})
+	require.NoError(t, err)
+	_, err = filter.MatchAny([][]byte{[]byte("x")})
+	require.ErrorIs(t, err, ErrMalformedFilter)
+}
@@ -12,6 +12,8 @@ type mockWOCClient struct {
 	utxoFunc                 func(ctx context.Context, address string) (whatsonchain.AddressHistory, error)
 	feeFunc                  func(ctx context.Context, from, to int64) ([]*whatsonchain.MinerFeeStats, error)
 	broadcastFunc            func(ctx context.Context, txHex string) (string, error)
+	txByHashFunc             func(ctx context.Context, hash string) (*whatsonchain.TxInfo, error)
+	exchangeRateFunc         func(ctx context.Context) (*whatsonchain.ExchangeRate, error)
 	bulkConfirmedFunc        func(ctx context.Context, list *whatsonchain.AddressList) (whatsonchain.AddressBalances, error)
 	bulkUnconfirmedFunc      func(ctx context.Context, list *whatsonchain.AddressList) (whatsonchain.AddressBalances, error)
 	bulkHistoryFunc          func(ctx context.Context, list *whatsonchain.AddressList) (whatsonchain.BulkAddressHistoryResponse, error)
@@ -48,6 +50,20 @@ func (m *mockWOCClient) BroadcastTx(ctx context.Context, txHex string) (string,
 	return "", nil
 }
 
+func (m *mockWOCClient) GetTxByHash(ctx context.Context, hash string) (*whatsonchain.TxInfo, error) {
+	if m.txByHashFunc != nil {
+		return m.txByHashFunc(ctx, hash)
+	}
+	return &whatsonchain.TxInfo{}, nil
+}
+
+func (m *mockWOCClient) GetExchangeRate(ctx context.Context) (*whatsonchain.ExchangeRate, error) {
+	if m.exchangeRateFunc != nil {
+		return m.exchangeRateFunc(ctx)
+	}
+	return &whatsonchain.ExchangeRate{}, nil
+}
+
 func (m *mockWOCClient) BulkAddressConfirmedBalance(ctx context.Context, list *whatsonchain.AddressList) (whatsonchain.AddressBalances, error) {
 	if m.bulkConfirmedFunc != nil {
 		return m.bulkConfirmedFunc(ctx, list)
@@ -0,0 +1,205 @@
+package bsv
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
+
+	"github.com/mrz1836/sigil/internal/reqlog"
+	"github.com/mrz1836/sigil/internal/reqsign"
+)
+
+// blockHeaderSize is the size of a serialized block header.
+const blockHeaderSize = 80
+
+var (
+	// ErrNodeRPCNotConfigured indicates no node RPC URL was given.
+	ErrNodeRPCNotConfigured = errors.New("BSV node RPC URL not configured")
+
+	// ErrNodeRPC indicates the node returned an error for a call.
+	ErrNodeRPC = errors.New("node RPC error")
+
+	// ErrMalformedBlock indicates a raw block could not be decoded.
+	ErrMalformedBlock = errors.New("malformed block")
+)
+
+// NodeOptions configures a NodeClient.
+type NodeOptions struct {
+	// URL is the node's JSON-RPC endpoint, e.g. http://127.0.0.1:8332.
+	URL string
+
+	// User and Password are the RPC credentials, if the node requires them.
+	User     string
+	Password string
+
+	// HTTPClient overrides the HTTP client (e.g., for testing).
+	HTTPClient *http.Client
+}
+
+// NodeClient reads blocks and compact block filters from a BSV node over
+// JSON-RPC. Unlike the WhatsOnChain client, it never sends the wallet's
+// addresses anywhere: filters are matched locally and only whole blocks are
+// requested.
+type NodeClient struct {
+	url        string
+	user       string
+	password   string
+	httpClient *http.Client
+}
+
+// NewNodeClient creates a client for the node at opts.URL.
+func NewNodeClient(opts NodeOptions) (*NodeClient, error) {
+	if opts.URL == "" {
+		return nil, ErrNodeRPCNotConfigured
+	}
+	u, err := url.Parse(opts.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: invalid node RPC URL %q", ErrNodeRPC, opts.URL)
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout, Transport: reqlog.Wrap(reqsign.Wrap(nil), "bsv-node")}
+	}
+	return &NodeClient{url: opts.URL, user: opts.User, password: opts.Password, httpClient: httpClient}, nil
+}
+
+// BlockCount returns the height of the node's best block.
+func (c *NodeClient) BlockCount(ctx context.Context) (int64, error) {
+	var height int64
+	err := c.call(ctx, "getblockcount", nil, &height)
+	return height, err
+}
+
+// BlockHash returns the hash of the block at height on the node's best chain.
+func (c *NodeClient) BlockHash(ctx context.Context, height int64) (string, error) {
+	var hash string
+	err := c.call(ctx, "getblockhash", []any{height}, &hash)
+	return hash, err
+}
+
+// BlockFilter returns the serialized BIP158 basic filter for a block. The
+// node must keep a block filter index.
+func (c *NodeClient) BlockFilter(ctx context.Context, blockHash string) ([]byte, error) {
+	var result struct {
+		Filter string `json:"filter"`
+	}
+	if err := c.call(ctx, "getblockfilter", []any{blockHash, "basic"}, &result); err != nil {
+		return nil, err
+	}
+	filter, err := hex.DecodeString(result.Filter)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedFilter, err)
+	}
+	return filter, nil
+}
+
+// Block returns the transactions of a block.
+func (c *NodeClient) Block(ctx context.Context, blockHash string) ([]*transaction.Transaction, error) {
+	var rawHex string
+	if err := c.call(ctx, "getblock", []any{blockHash, 0}, &rawHex); err != nil {
+		return nil, err
+	}
+	raw, err := hex.DecodeString(rawHex)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedBlock, err)
+	}
+	return ParseBlock(raw)
+}
+
+// ParseBlock decodes the transactions of a serialized block.
+func ParseBlock(raw []byte) ([]*transaction.Transaction, error) {
+	if len(raw) < blockHeaderSize {
+		return nil, fmt.Errorf("%w: %d bytes", ErrMalformedBlock, len(raw))
+	}
+	r := bytes.NewReader(raw[blockHeaderSize:])
+	var count util.VarInt
+	if _, err := count.ReadFrom(r); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedBlock, err)
+	}
+	// Every transaction takes at least 10 bytes, which bounds a bogus count
+	if uint64(count) > uint64(r.Len())/10+1 {
+		return nil, fmt.Errorf("%w: %d transactions in %d bytes", ErrMalformedBlock, count, r.Len())
+	}
+
+	txs := make([]*transaction.Transaction, 0, count)
+	for i := range uint64(count) {
+		tx := &transaction.Transaction{}
+		if _, err := tx.ReadFrom(r); err != nil {
+			return nil, fmt.Errorf("%w: transaction %d: %w", ErrMalformedBlock, i, err)
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// rpcRequest is a JSON-RPC 1.0 request as bitcoind-style nodes accept it.
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      string `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+// rpcResponse is a JSON-RPC response.
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// call invokes method on the node and decodes its result into out.
+func (c *NodeClient) call(ctx context.Context, method string, params []any, out any) error {
+	if params == nil {
+		params = []any{}
+	}
+	body, err := json.Marshal(rpcRequest{JSONRPC: "1.0", ID: "sigil", Method: method, Params: params})
+	if err != nil {
+		return fmt.Errorf("encoding %s request: %w", method, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("creating %s request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.user != "" || c.password != "" {
+		req.SetBasicAuth(c.user, c.password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: %w", method, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("%s: reading response: %w", method, err)
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return fmt.Errorf("%w: %s: HTTP %d (check the node RPC user and password)", ErrNodeRPC, method, resp.StatusCode)
+	}
+
+	// Nodes report call errors with HTTP 500 and a JSON error body
+	var rpcResp rpcResponse
+	if err := json.Unmarshal(data, &rpcResp); err != nil {
+		return fmt.Errorf("%w: %s: HTTP %d", ErrNodeRPC, method, resp.StatusCode)
+	}
+	if rpcResp.Error != nil {
+		return fmt.Errorf("%w: %s: %s (code %d)", ErrNodeRPC, method, rpcResp.Error.Message, rpcResp.Error.Code)
+	}
+	if err := json.Unmarshal(rpcResp.Result, out); err != nil {
+		return fmt.Errorf("%w: %s: decoding result: %w", ErrNodeRPC, method, err)
+	}
+	return nil
+}
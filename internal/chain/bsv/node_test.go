@@ -0,0 +1,133 @@
+package bsv
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBlock serializes txs after a zero header.
+func testBlock(txs ...*transaction.Transaction) []byte {
+	raw := make([]byte, blockHeaderSize)
+	raw = append(raw, util.VarInt(len(txs)).Bytes()...)
+	for _, tx := range txs {
+		raw = append(raw, tx.Bytes()...)
+	}
+	return raw
+}
+
+func testNodeTx(satoshis uint64) *transaction.Transaction {
+	tx := transaction.NewTransaction()
+	tx.AddInput(&transaction.TransactionInput{
+		SourceTXID:      &chainhash.Hash{1},
+		UnlockingScript: &script.Script{},
+		SequenceNumber:  0xffffffff,
+	})
+	lock := script.Script{0x6a}
+	tx.AddOutput(&transaction.TransactionOutput{Satoshis: satoshis, LockingScript: &lock})
+	return tx
+}
+
+func TestParseBlock(t *testing.T) {
+	t.Parallel()
+
+	a, b := testNodeTx(100), testNodeTx(200)
+	txs, err := ParseBlock(testBlock(a, b))
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	assert.Equal(t, a.TxID().String(), txs[0].TxID().String())
+	assert.Equal(t, uint64(200), txs[1].Outputs[0].Satoshis)
+
+	_, err = ParseBlock(make([]byte, 10))
+	require.ErrorIs(t, err, ErrMalformedBlock)
+
+	truncated := testBlock(a, b)
+	_, err = ParseBlock(truncated[:len(truncated)-5])
+	require.ErrorIs(t, err, ErrMalformedBlock)
+}
+
+func TestNodeClient(t *testing.T) {
+	t.Parallel()
+
+	hash := "000000000000000002c3a3f3c4d8e0b43ad8d19ae28f4a1e8f2d7f41d1d7c1a0"
+	filter, err := BuildBlockFilter(hash, [][]byte{{0x6a}})
+	require.NoError(t, err)
+	block := testBlock(testNodeTx(100))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		if !ok || user != "rpc" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req rpcRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var result any
+		switch req.Method {
+		case "getblockcount":
+			result = 850000
+		case "getblockhash":
+			result = hash
+		case "getblockfilter":
+			result = map[string]string{"filter": hex.EncodeToString(filter), "header": "00"}
+		case "getblock":
+			result = hex.EncodeToString(block)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"result": nil,
+				"error":  map[string]any{"code": -32601, "message": "Method not found"},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"result": result, "error": nil})
+	}))
+	t.Cleanup(srv.Close)
+
+	ctx := context.Background()
+	client, err := NewNodeClient(NodeOptions{URL: srv.URL, User: "rpc", Password: "secret"})
+	require.NoError(t, err)
+
+	height, err := client.BlockCount(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(850000), height)
+
+	got, err := client.BlockHash(ctx, height)
+	require.NoError(t, err)
+	assert.Equal(t, hash, got)
+
+	raw, err := client.BlockFilter(ctx, hash)
+	require.NoError(t, err)
+	assert.Equal(t, filter, raw)
+
+	txs, err := client.Block(ctx, hash)
+	require.NoError(t, err)
+	assert.Len(t, txs, 1)
+
+	err = client.call(ctx, "getblockheader", nil, new(any))
+	require.ErrorIs(t, err, ErrNodeRPC)
+	assert.Contains(t, err.Error(), "Method not found")
+
+	bad, err := NewNodeClient(NodeOptions{URL: srv.URL, User: "rpc", Password: "wrong"})
+	require.NoError(t, err)
+	_, err = bad.BlockCount(ctx)
+	require.ErrorIs(t, err, ErrNodeRPC)
+
+	_, err = NewNodeClient(NodeOptions{})
+	require.ErrorIs(t, err, ErrNodeRPCNotConfigured)
+	_, err = NewNodeClient(NodeOptions{URL: "ftp://node"})
+	require.ErrorIs(t, err, ErrNodeRPC)
+}
@@ -0,0 +1,75 @@
+package bsv
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// BuildUnsigned selects the inputs and outputs for req as Send does and
+// returns them in the offline signing format, without signing. Keys in req
+// are ignored.
+func (c *Client) BuildUnsigned(ctx context.Context, req chain.SendRequest) (*chain.UnsignedBSV, error) {
+	plan, err := c.prepareSend(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	builder := plan.builder
+
+	u := &chain.UnsignedBSV{
+		FeeRate: builder.FeeRate,
+		Inputs:  make([]chain.OfflineInput, len(builder.Inputs)),
+		Outputs: make([]chain.OfflineOutput, len(builder.Outputs)),
+	}
+	for i, in := range builder.Inputs {
+		u.Inputs[i] = chain.OfflineInput{
+			TxID:         in.TxID,
+			Vout:         in.Vout,
+			Amount:       in.Amount,
+			ScriptPubKey: in.ScriptPubKey,
+			Address:      in.Address,
+		}
+	}
+	for i, out := range builder.Outputs {
+		u.Outputs[i] = chain.OfflineOutput{Address: out.Address, Amount: out.Amount}
+	}
+	return u, nil
+}
+
+// SignUnsigned signs an offline transaction for network. keys maps each input
+// address to its private key. It needs no network access and returns the
+// serialized signed transaction and its txid.
+func SignUnsigned(u *chain.UnsignedBSV, network Network, keys map[string][]byte) ([]byte, string, error) {
+	builder := NewTxBuilder()
+	builder.SetNetwork(network)
+	builder.SetFeeRate(u.FeeRate)
+
+	for _, in := range u.Inputs {
+		if err := builder.AddInput(UTXO{
+			TxID:         in.TxID,
+			Vout:         in.Vout,
+			Amount:       in.Amount,
+			ScriptPubKey: in.ScriptPubKey,
+			Address:      in.Address,
+		}); err != nil {
+			return nil, "", fmt.Errorf("adding input: %w", err)
+		}
+	}
+	for i, out := range u.Outputs {
+		if err := builder.AddOutput(out.Address, out.Amount); err != nil {
+			return nil, "", fmt.Errorf("adding output %d: %w", i, err)
+		}
+	}
+	if err := builder.Validate(); err != nil {
+		return nil, "", fmt.Errorf("validating transaction: %w", err)
+	}
+
+	raw, err := BuildRawTransactionMultiKey(builder, keys)
+	if err != nil {
+		return nil, "", err
+	}
+	return raw, chainhash.DoubleHashH(raw).String(), nil
+}
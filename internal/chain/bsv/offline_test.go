@@ -0,0 +1,125 @@
+package bsv
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestOfflineBuildAndSign(t *testing.T) {
+	t.Parallel()
+
+	kp := getTestKeyPair()
+	client := NewClient(context.Background(), nil)
+
+	u, err := client.BuildUnsigned(context.Background(), chain.SendRequest{
+		From:   kp.Address,
+		To:     validAddress2(),
+		Amount: big.NewInt(30000),
+		UTXOs: []chain.UTXO{
+			{TxID: testTxID(1), Vout: 0, Amount: 20000, Address: kp.Address},
+			{TxID: testTxID(2), Vout: 1, Amount: 20000, Address: kp.Address},
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, u.Inputs, 2)
+	require.Len(t, u.Outputs, 2)
+	assert.Equal(t, validAddress2(), u.Outputs[0].Address)
+	assert.Equal(t, uint64(30000), u.Outputs[0].Amount)
+	assert.Equal(t, kp.Address, u.Outputs[1].Address, "change returns to the sender")
+
+	t.Run("signs without network access", func(t *testing.T) {
+		t.Parallel()
+		raw, txid, err := SignUnsigned(u, NetworkMainnet, map[string][]byte{kp.Address: kp.PrivateKey})
+		require.NoError(t, err)
+
+		tx, err := transaction.NewTransactionFromBytes(raw)
+		require.NoError(t, err)
+		assert.Equal(t, tx.TxID().String(), txid)
+		assert.Len(t, tx.Inputs, 2)
+		assert.Equal(t, uint64(30000), tx.Outputs[0].Satoshis)
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		t.Parallel()
+		other := getTestKeyPair2()
+		_, _, err := SignUnsigned(u, NetworkMainnet, map[string][]byte{other.Address: other.PrivateKey})
+		require.ErrorIs(t, err, ErrSigningFailed)
+	})
+
+	t.Run("wrong network", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := SignUnsigned(u, NetworkTestnet, map[string][]byte{kp.Address: kp.PrivateKey})
+		require.Error(t, err)
+	})
+}
+
+func TestBuildUnsigned_Payments(t *testing.T) {
+	t.Parallel()
+
+	kp := getTestKeyPair()
+	other := getTestKeyPair2()
+	client := NewClient(context.Background(), nil)
+	req := chain.SendRequest{
+		From:   kp.Address,
+		To:     validAddress2(),
+		Amount: big.NewInt(10000),
+		Payments: []chain.Payment{
+			{To: other.Address, Amount: big.NewInt(20000)},
+			{To: kp.Address, Amount: big.NewInt(5000)},
+		},
+		UTXOs: []chain.UTXO{
+			{TxID: testTxID(1), Vout: 0, Amount: 50000, Address: kp.Address},
+		},
+	}
+
+	u, err := client.BuildUnsigned(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, u.Outputs, 4, "three payments and change")
+	assert.Equal(t, chain.OfflineOutput{Address: validAddress2(), Amount: 10000}, u.Outputs[0])
+	assert.Equal(t, chain.OfflineOutput{Address: other.Address, Amount: 20000}, u.Outputs[1])
+	assert.Equal(t, chain.OfflineOutput{Address: kp.Address, Amount: 5000}, u.Outputs[2])
+	fee := EstimateFeeForTx(1, 4, DefaultFeeRate)
+	assert.Equal(t, 50000-35000-fee, u.Outputs[3].Amount)
+
+	t.Run("sweep", func(t *testing.T) {
+		t.Parallel()
+		sweep := req
+		sweep.SweepAll = true
+		_, err := client.BuildUnsigned(context.Background(), sweep)
+		require.ErrorIs(t, err, ErrSweepPayments, "the payments leave most of the balance unpaid")
+	})
+
+	t.Run("split sweep", func(t *testing.T) {
+		t.Parallel()
+		amounts, _, err := SplitSweep(50000, 1, DefaultFeeRate, []SweepShare{
+			{To: validAddress2(), BasisPoints: 7000},
+			{To: other.Address, BasisPoints: 3000},
+		})
+		require.NoError(t, err)
+
+		split := req
+		split.SweepAll = true
+		split.Amount = new(big.Int).SetUint64(amounts[0])
+		split.Payments = []chain.Payment{{To: other.Address, Amount: new(big.Int).SetUint64(amounts[1])}}
+		u, err := client.BuildUnsigned(context.Background(), split)
+		require.NoError(t, err)
+		require.Len(t, u.Outputs, 2, "no change output")
+		assert.Equal(t, amounts[0], u.Outputs[0].Amount)
+		assert.Equal(t, amounts[1], u.Outputs[1].Amount)
+	})
+
+	t.Run("bad payment address", func(t *testing.T) {
+		t.Parallel()
+		bad := req
+		bad.Payments = []chain.Payment{{To: "not-an-address", Amount: big.NewInt(1000)}}
+		_, err := client.BuildUnsigned(context.Background(), bad)
+		require.ErrorIs(t, err, ErrInvalidAddress)
+	})
+}
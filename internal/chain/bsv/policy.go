@@ -0,0 +1,96 @@
+package bsv
+
+import (
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// MaxStandardTxSize is the largest transaction, in bytes, that miners relay
+// under default policy (SV Node maxtxsizepolicy).
+const MaxStandardTxSize = 10 * 1000 * 1000
+
+// ErrPolicyViolation indicates a built transaction would be rejected by
+// standard miner policy and was not broadcast.
+var ErrPolicyViolation = &sigilerr.SigilError{
+	Code:     "BSV_POLICY_VIOLATION",
+	Message:  "transaction violates miner policy",
+	ExitCode: sigilerr.ExitInput,
+}
+
+// CheckPolicy validates a signed transaction against common miner relay
+// policy: total size, data carrier size, dust outputs, and standard output
+// scripts. It runs before broadcast so violations surface as actionable
+// errors instead of an opaque rejection from the broadcast provider.
+func CheckPolicy(tx *transaction.Transaction) error {
+	size := len(tx.Bytes())
+	if size > MaxStandardTxSize {
+		return policyViolation(
+			map[string]string{"rule": "max_tx_size", "size": fmt.Sprintf("%d", size), "maximum": fmt.Sprintf("%d", MaxStandardTxSize)},
+			fmt.Sprintf("transaction is %d bytes, above the %d byte relay limit; spend fewer UTXOs per transaction (consolidate first)", size, MaxStandardTxSize),
+		)
+	}
+
+	dustLimit := chain.BSV.DustLimit()
+	var dataSize int
+	for i, out := range tx.Outputs {
+		ls := out.LockingScript
+		switch {
+		case ls == nil || len(*ls) == 0:
+			return policyViolation(
+				map[string]string{"rule": "nonstandard_script", "output": fmt.Sprintf("%d", i)},
+				fmt.Sprintf("output %d has an empty locking script", i),
+			)
+
+		case ls.IsData():
+			if out.Satoshis > 0 {
+				return policyViolation(
+					map[string]string{"rule": "data_output_value", "output": fmt.Sprintf("%d", i)},
+					fmt.Sprintf("data output %d carries %d satoshis, which would be burned; data outputs must be zero-value", i, out.Satoshis),
+				)
+			}
+			dataSize += dataPayloadSize(ls)
+
+		case ls.IsP2PKH() || ls.IsP2PK() || ls.IsMultiSigOut():
+			if out.Satoshis < dustLimit {
+				return policyViolation(
+					map[string]string{"rule": "dust", "output": fmt.Sprintf("%d", i), "amount": fmt.Sprintf("%d", out.Satoshis)},
+					fmt.Sprintf("output %d pays %d satoshis, below the %d satoshi dust limit", i, out.Satoshis, dustLimit),
+				)
+			}
+
+		default:
+			return policyViolation(
+				map[string]string{"rule": "nonstandard_script", "output": fmt.Sprintf("%d", i)},
+				fmt.Sprintf("output %d uses a non-standard locking script; only P2PKH, P2PK, bare multisig and OP_RETURN outputs are relayed", i),
+			)
+		}
+	}
+
+	if dataSize > MaxDataCarrierSize {
+		return policyViolation(
+			map[string]string{"rule": "max_data_carrier_size", "size": fmt.Sprintf("%d", dataSize), "maximum": fmt.Sprintf("%d", MaxDataCarrierSize)},
+			fmt.Sprintf("OP_RETURN payload is %d bytes, above the %d byte limit; publish less data or split it across transactions", dataSize, MaxDataCarrierSize),
+		)
+	}
+
+	return nil
+}
+
+// policyViolation builds an ErrPolicyViolation with details and a suggestion.
+func policyViolation(details map[string]string, suggestion string) error {
+	return sigilerr.WithSuggestion(sigilerr.WithDetails(ErrPolicyViolation, details), suggestion)
+}
+
+// dataPayloadSize returns the total number of pushed bytes in a data carrier script.
+func dataPayloadSize(s *script.Script) int {
+	var total int
+	for _, push := range dataPushes(s) {
+		total += len(push)
+	}
+	return total
+}
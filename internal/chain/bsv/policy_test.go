@@ -0,0 +1,129 @@
+package bsv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func policyTestP2PKH(t *testing.T) *script.Script {
+	t.Helper()
+
+	addr, err := script.NewAddressFromString("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa")
+	require.NoError(t, err)
+	ls, err := p2pkh.Lock(addr)
+	require.NoError(t, err)
+	return ls
+}
+
+func policyTestData(t *testing.T, payload []byte) *script.Script {
+	t.Helper()
+
+	ls, err := DataScript(payload)
+	require.NoError(t, err)
+	return ls
+}
+
+func TestCheckPolicy(t *testing.T) {
+	t.Parallel()
+
+	p2sh := script.Script(append([]byte{script.OpHASH160, script.OpDATA20}, append(bytes.Repeat([]byte{0x01}, 20), script.OpEQUAL)...))
+
+	tests := []struct {
+		name     string
+		outputs  func(t *testing.T) []*transaction.TransactionOutput
+		wantRule string
+	}{
+		{
+			name: "standard payment and data",
+			outputs: func(t *testing.T) []*transaction.TransactionOutput {
+				return []*transaction.TransactionOutput{
+					{Satoshis: 1000, LockingScript: policyTestP2PKH(t)},
+					{Satoshis: 0, LockingScript: policyTestData(t, []byte("hello"))},
+				}
+			},
+		},
+		{
+			name: "dust output",
+			outputs: func(t *testing.T) []*transaction.TransactionOutput {
+				return []*transaction.TransactionOutput{{Satoshis: 0, LockingScript: policyTestP2PKH(t)}}
+			},
+			wantRule: "dust",
+		},
+		{
+			name: "data output with value",
+			outputs: func(t *testing.T) []*transaction.TransactionOutput {
+				return []*transaction.TransactionOutput{{Satoshis: 5, LockingScript: policyTestData(t, []byte("x"))}}
+			},
+			wantRule: "data_output_value",
+		},
+		{
+			name: "oversized data carrier",
+			outputs: func(t *testing.T) []*transaction.TransactionOutput {
+				return []*transaction.TransactionOutput{
+					{Satoshis: 0, LockingScript: policyTestData(t, make([]byte, MaxDataCarrierSize))},
+					{Satoshis: 0, LockingScript: policyTestData(t, []byte("x"))},
+				}
+			},
+			wantRule: "max_data_carrier_size",
+		},
+		{
+			name: "p2sh is non-standard",
+			outputs: func(_ *testing.T) []*transaction.TransactionOutput {
+				return []*transaction.TransactionOutput{{Satoshis: 1000, LockingScript: &p2sh}}
+			},
+			wantRule: "nonstandard_script",
+		},
+		{
+			name: "empty locking script",
+			outputs: func(_ *testing.T) []*transaction.TransactionOutput {
+				return []*transaction.TransactionOutput{{Satoshis: 1000, LockingScript: &script.Script{}}}
+			},
+			wantRule: "nonstandard_script",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tx := transaction.NewTransaction()
+			for _, out := range tc.outputs(t) {
+				tx.AddOutput(out)
+			}
+
+			err := CheckPolicy(tx)
+			if tc.wantRule == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrPolicyViolation)
+			assert.Contains(t, err.Error(), "rule: "+tc.wantRule)
+
+			var se *sigilerr.SigilError
+			require.ErrorAs(t, err, &se)
+			assert.NotEmpty(t, se.Suggestion)
+		})
+	}
+}
+
+func TestCheckPolicy_MaxTxSize(t *testing.T) {
+	t.Parallel()
+
+	tx := transaction.NewTransaction()
+	tx.AddOutput(&transaction.TransactionOutput{
+		Satoshis:      0,
+		LockingScript: policyTestData(t, make([]byte, MaxStandardTxSize)),
+	})
+
+	err := CheckPolicy(tx)
+	require.ErrorIs(t, err, ErrPolicyViolation)
+	assert.Contains(t, err.Error(), "rule: max_tx_size")
+}
@@ -0,0 +1,48 @@
+package bsv
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/metrics"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// ErrInvalidExchangeRate indicates the provider returned an unusable rate.
+var ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+
+// ExchangeRate is the BSV spot price reported by WhatsOnChain.
+type ExchangeRate struct {
+	Currency string    // Fiat currency code, e.g. "USD"
+	Rate     float64   // Fiat units per 1 BSV
+	Time     time.Time // When the provider captured the rate
+}
+
+// GetExchangeRate fetches the current BSV exchange rate from WhatsOnChain.
+func (c *Client) GetExchangeRate(ctx context.Context) (*ExchangeRate, error) {
+	c.debug("fetching exchange rate")
+	start := time.Now()
+	resp, err := c.woc.GetExchangeRate(ctx)
+	metrics.Global.RecordRPCCall("bsv", time.Since(start), err)
+	if err != nil {
+		c.logError("exchange rate fetch failed: %v", err)
+		return nil, fmt.Errorf("%w: %w", sigilerr.ErrNetworkError, err)
+	}
+	if resp == nil || resp.Rate <= 0 {
+		return nil, ErrInvalidExchangeRate
+	}
+
+	rate := &ExchangeRate{
+		Currency: resp.Currency,
+		Rate:     resp.Rate,
+	}
+	if rate.Currency == "" {
+		rate.Currency = "USD"
+	}
+	if resp.Time > 0 {
+		rate.Time = time.Unix(resp.Time, 0).UTC()
+	}
+	return rate, nil
+}
@@ -0,0 +1,70 @@
+package bsv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	whatsonchain "github.com/mrz1836/go-whatsonchain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestGetExchangeRate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("returns rate and capture time", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{
+			exchangeRateFunc: func(_ context.Context) (*whatsonchain.ExchangeRate, error) {
+				return &whatsonchain.ExchangeRate{Currency: "USD", Rate: 52.31, Time: 1760000000}, nil
+			},
+		}})
+
+		rate, err := client.GetExchangeRate(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "USD", rate.Currency)
+		assert.InDelta(t, 52.31, rate.Rate, 0.0001)
+		assert.Equal(t, time.Unix(1760000000, 0).UTC(), rate.Time)
+	})
+
+	t.Run("defaults currency to USD", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{
+			exchangeRateFunc: func(_ context.Context) (*whatsonchain.ExchangeRate, error) {
+				return &whatsonchain.ExchangeRate{Rate: 40}, nil
+			},
+		}})
+
+		rate, err := client.GetExchangeRate(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "USD", rate.Currency)
+		assert.True(t, rate.Time.IsZero())
+	})
+
+	t.Run("zero rate is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{}})
+
+		_, err := client.GetExchangeRate(context.Background())
+		require.ErrorIs(t, err, ErrInvalidExchangeRate)
+	})
+
+	t.Run("provider error is a network error", func(t *testing.T) {
+		t.Parallel()
+
+		client := NewClient(context.Background(), &ClientOptions{WOCClient: &mockWOCClient{
+			exchangeRateFunc: func(_ context.Context) (*whatsonchain.ExchangeRate, error) {
+				return nil, errTestConnRefused
+			},
+		}})
+
+		_, err := client.GetExchangeRate(context.Background())
+		require.ErrorIs(t, err, sigilerr.ErrNetworkError)
+	})
+}
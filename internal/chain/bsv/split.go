@@ -0,0 +1,104 @@
+package bsv
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// FullShare is 100% in basis points, the unit of SweepShare.BasisPoints.
+const FullShare = 10000
+
+// ErrInvalidSplit indicates the shares of a split sweep do not divide the
+// swept balance.
+var ErrInvalidSplit = errors.New("invalid split sweep")
+
+// SweepShare is one recipient's part of a split sweep: either a fixed
+// amount or a percentage of what is left after the fee and the fixed
+// amounts.
+type SweepShare struct {
+	To string
+	// Fixed is the amount paid in satoshis; zero for a percentage share.
+	Fixed uint64
+	// BasisPoints is the percentage share in hundredths of a percent;
+	// zero for a fixed share.
+	BasisPoints uint64
+}
+
+// SplitSweep divides totalInputs, less the fee of a transaction spending
+// numInputs UTXOs to one output per share, among shares. The fixed amounts
+// are paid first and the percentage shares, which must total 100%, divide
+// the rest. Percentages are rounded down and the satoshis left over go to
+// the last percentage share, so the outputs spend every input.
+//
+// It returns the amount of each share, in order, and the fee.
+//
+//nolint:gocognit // Each share is checked before and after the division
+func SplitSweep(totalInputs uint64, numInputs int, feeRate uint64, shares []SweepShare) ([]uint64, uint64, error) {
+	var percent uint64
+	last := -1
+	for i, s := range shares {
+		switch {
+		case s.Fixed > 0 && s.BasisPoints > 0:
+			return nil, 0, fmt.Errorf("%w: %s has both a fixed amount and a percentage", ErrInvalidSplit, s.To)
+		case s.Fixed == 0 && s.BasisPoints == 0:
+			return nil, 0, fmt.Errorf("%w: %s has a zero share", ErrInvalidSplit, s.To)
+		case s.BasisPoints > 0:
+			percent += s.BasisPoints
+			last = i
+		}
+	}
+	if percent != FullShare {
+		return nil, 0, fmt.Errorf("%w: percentages total %s%%, not 100%%", ErrInvalidSplit, FormatBasisPoints(percent))
+	}
+
+	fee := EstimateFeeForTx(numInputs, len(shares), ValidateFeeRate(feeRate))
+	if fee >= totalInputs {
+		return nil, 0, fmt.Errorf("%w: total %d satoshis, fee %d satoshis",
+			ErrSweepInsufficientFunds, totalInputs, fee)
+	}
+
+	amounts := make([]uint64, len(shares))
+	rest := totalInputs - fee
+	for i, s := range shares {
+		if s.Fixed == 0 {
+			continue
+		}
+		if s.Fixed > rest {
+			return nil, 0, fmt.Errorf("%w: fixed amounts exceed the %d satoshis left after the %d satoshi fee",
+				ErrSweepInsufficientFunds, totalInputs-fee, fee)
+		}
+		amounts[i] = s.Fixed
+		rest -= s.Fixed
+	}
+
+	pool := rest
+	for i, s := range shares {
+		if s.BasisPoints == 0 {
+			continue
+		}
+		// pool*BasisPoints can exceed 64 bits; the high word stays below FullShare
+		hi, lo := bits.Mul64(pool, s.BasisPoints)
+		amounts[i], _ = bits.Div64(hi, lo, FullShare)
+		rest -= amounts[i]
+	}
+	amounts[last] += rest
+
+	dustLimit := chain.BSV.DustLimit()
+	for i, amount := range amounts {
+		if amount < dustLimit {
+			return nil, 0, fmt.Errorf("%w: %s would receive %d satoshis, below the dust limit %d",
+				ErrInvalidSplit, shares[i].To, amount, dustLimit)
+		}
+	}
+	return amounts, fee, nil
+}
+
+// FormatBasisPoints formats a share in basis points as a percentage
+// without the sign, e.g. 3333 as "33.33".
+func FormatBasisPoints(bp uint64) string {
+	return strconv.FormatFloat(float64(bp)/100, 'f', -1, 64)
+}
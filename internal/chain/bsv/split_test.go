@@ -0,0 +1,52 @@
+package bsv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitSweep(t *testing.T) {
+	t.Parallel()
+
+	fee := EstimateFeeForTx(2, 3, DefaultFeeRate)
+	total := 100000 + fee
+
+	amounts, gotFee, err := SplitSweep(total, 2, DefaultFeeRate, []SweepShare{
+		{To: "1A", Fixed: 10000},
+		{To: "1B", BasisPoints: 3333},
+		{To: "1C", BasisPoints: 6667},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, fee, gotFee, "the fee covers one output per recipient")
+	assert.Equal(t, []uint64{10000, 29997, 60003}, amounts, "the rounding remainder goes to the last percentage")
+	assert.Equal(t, total, amounts[0]+amounts[1]+amounts[2]+gotFee)
+
+	rejections := []struct {
+		name   string
+		total  uint64
+		shares []SweepShare
+		want   error
+	}{
+		{"percentages under 100", total, []SweepShare{{To: "1A", BasisPoints: 7000}, {To: "1B", BasisPoints: 2000}}, ErrInvalidSplit},
+		{"fixed only", total, []SweepShare{{To: "1A", Fixed: 1000}, {To: "1B", Fixed: 2000}}, ErrInvalidSplit},
+		{"zero share", total, []SweepShare{{To: "1A", BasisPoints: FullShare}, {To: "1B"}}, ErrInvalidSplit},
+		{"dust share", fee + 5000, []SweepShare{{To: "1A", BasisPoints: 1}, {To: "1B", BasisPoints: 9999}}, ErrInvalidSplit},
+		{"fixed over balance", total, []SweepShare{{To: "1A", Fixed: total}, {To: "1B", BasisPoints: FullShare}}, ErrSweepInsufficientFunds},
+		{"fee over balance", 10, []SweepShare{{To: "1A", BasisPoints: FullShare}}, ErrSweepInsufficientFunds},
+	}
+	for _, tc := range rejections {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := SplitSweep(tc.total, 2, DefaultFeeRate, tc.shares)
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestFormatBasisPoints(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "33.33", FormatBasisPoints(3333))
+	assert.Equal(t, "70", FormatBasisPoints(7000))
+}
@@ -0,0 +1,115 @@
+package bsv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	whatsonchain "github.com/mrz1836/go-whatsonchain"
+
+	"github.com/mrz1836/sigil/internal/metrics"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// TxStatus describes the confirmation state and fee of a transaction.
+type TxStatus struct {
+	TxID          string
+	Confirmations int64     // zero while the transaction is in the mempool
+	BlockHeight   int64     // zero while the transaction is in the mempool
+	BlockTime     time.Time // zero while the transaction is in the mempool
+	Fee           uint64    // satoshis; zero for coinbase transactions
+	Size          int       // bytes
+}
+
+// GetTxStatus fetches a transaction by ID and reports its confirmations and
+// the fee it paid. WhatsOnChain does not return input values, so the fee is
+// computed by fetching each parent transaction.
+func (c *Client) GetTxStatus(ctx context.Context, txid string) (*TxStatus, error) {
+	tx, info, err := c.fetchTx(ctx, txid)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TxStatus{
+		TxID:          txid,
+		Confirmations: max(info.Confirmations, 0),
+		BlockHeight:   info.BlockHeight,
+		Size:          len(tx.Bytes()),
+	}
+	if status.Confirmations > 0 && info.BlockTime > 0 {
+		status.BlockTime = time.Unix(info.BlockTime, 0).UTC()
+	}
+
+	if tx.IsCoinbase() {
+		return status, nil
+	}
+
+	fee, err := c.txFee(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	status.Fee = fee
+
+	return status, nil
+}
+
+// txFee returns the inputs of tx minus its outputs, reading input values from
+// the parent transactions.
+func (c *Client) txFee(ctx context.Context, tx *transaction.Transaction) (uint64, error) {
+	parents := make(map[string]*transaction.Transaction)
+	var in uint64
+	for _, input := range tx.Inputs {
+		parentID := input.SourceTXID.String()
+		parent, ok := parents[parentID]
+		if !ok {
+			var err error
+			if parent, _, err = c.fetchTx(ctx, parentID); err != nil {
+				return 0, fmt.Errorf("fetching input transaction: %w", err)
+			}
+			parents[parentID] = parent
+		}
+
+		if int(input.SourceTxOutIndex) >= len(parent.Outputs) {
+			return 0, fmt.Errorf("%w: input spends missing output %s:%d",
+				sigilerr.ErrInvalidTransaction, parentID, input.SourceTxOutIndex)
+		}
+		in += parent.Outputs[input.SourceTxOutIndex].Satoshis
+	}
+
+	var out uint64
+	for _, output := range tx.Outputs {
+		out += output.Satoshis
+	}
+	if out > in {
+		return 0, fmt.Errorf("%w: outputs exceed inputs", sigilerr.ErrInvalidTransaction)
+	}
+
+	return in - out, nil
+}
+
+// fetchTx fetches and decodes a transaction by ID.
+func (c *Client) fetchTx(ctx context.Context, txid string) (*transaction.Transaction, *whatsonchain.TxInfo, error) {
+	if !isValidTxID(txid) {
+		return nil, nil, fmt.Errorf("%w: %s", ErrInvalidTxID, txid)
+	}
+
+	c.debug("fetching transaction %s", txid)
+	start := time.Now()
+	info, err := c.woc.GetTxByHash(ctx, txid)
+	metrics.Global.RecordRPCCall("bsv", time.Since(start), err)
+	if err != nil {
+		c.logError("fetching transaction %s: %v", txid, err)
+		return nil, nil, fmt.Errorf("%w: %w", sigilerr.ErrNetworkError, err)
+	}
+	if info == nil || info.Hex == "" {
+		return nil, nil, sigilerr.WithDetails(sigilerr.ErrTransactionNotFound, map[string]string{"txid": txid})
+	}
+
+	tx, err := transaction.NewTransactionFromHex(info.Hex)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: decoding transaction: %w", sigilerr.ErrInvalidTransaction, err)
+	}
+
+	return tx, info, nil
+}
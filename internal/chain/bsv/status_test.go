@@ -0,0 +1,110 @@
+package bsv
+
+import (
+	"context"
+	"encoding/hex"
+	"testing"
+	"time"
+
+	whatsonchain "github.com/mrz1836/go-whatsonchain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestGetTxStatus(t *testing.T) {
+	t.Parallel()
+
+	kp := getTestKeyPair()
+	buildTx := func(t *testing.T, input UTXO, amounts ...uint64) string {
+		t.Helper()
+		b := NewTxBuilder()
+		require.NoError(t, b.AddInput(input))
+		for _, amount := range amounts {
+			require.NoError(t, b.AddOutput(kp.Address, amount))
+		}
+		raw, err := BuildRawTransaction(b, kp.PrivateKey)
+		require.NoError(t, err)
+		return hex.EncodeToString(raw)
+	}
+
+	parentHex := buildTx(t, UTXO{TxID: testTxID(9), Amount: 20000, Address: kp.Address}, 1000, 9000)
+	childHex := buildTx(t, UTXO{TxID: testTxID(1), Vout: 1, Amount: 9000, Address: kp.Address}, 8500)
+	newClient := func(child *whatsonchain.TxInfo) *Client {
+		mock := &mockWOCClient{
+			txByHashFunc: func(_ context.Context, hash string) (*whatsonchain.TxInfo, error) {
+				switch hash {
+				case testValidTxID:
+					return child, nil
+				case testTxID(1):
+					return &whatsonchain.TxInfo{Hex: parentHex, Confirmations: 10}, nil
+				default:
+					return nil, nil
+				}
+			},
+		}
+		return NewClient(context.Background(), &ClientOptions{WOCClient: mock})
+	}
+
+	t.Run("confirmed", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(&whatsonchain.TxInfo{
+			Hex:           childHex,
+			BlockHeight:   800000,
+			BlockTime:     1700000000,
+			Confirmations: 3,
+		})
+		status, err := client.GetTxStatus(context.Background(), testValidTxID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), status.Confirmations)
+		assert.Equal(t, int64(800000), status.BlockHeight)
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), status.BlockTime)
+		assert.Equal(t, uint64(500), status.Fee)
+		assert.Equal(t, len(childHex)/2, status.Size)
+	})
+
+	t.Run("mempool", func(t *testing.T) {
+		t.Parallel()
+
+		client := newClient(&whatsonchain.TxInfo{Hex: childHex, Confirmations: -1})
+		status, err := client.GetTxStatus(context.Background(), testValidTxID)
+		require.NoError(t, err)
+		assert.Zero(t, status.Confirmations)
+		assert.True(t, status.BlockTime.IsZero())
+		assert.Equal(t, uint64(500), status.Fee)
+	})
+
+	t.Run("missing parent output", func(t *testing.T) {
+		t.Parallel()
+
+		bad := buildTx(t, UTXO{TxID: testTxID(1), Vout: 5, Amount: 9000, Address: kp.Address}, 8500)
+		client := newClient(&whatsonchain.TxInfo{Hex: bad, Confirmations: 1})
+		_, err := client.GetTxStatus(context.Background(), testValidTxID)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidTransaction)
+	})
+
+	t.Run("missing parent", func(t *testing.T) {
+		t.Parallel()
+
+		orphan := buildTx(t, UTXO{TxID: testTxID(2), Amount: 9000, Address: kp.Address}, 8500)
+		client := newClient(&whatsonchain.TxInfo{Hex: orphan, Confirmations: 1})
+		_, err := client.GetTxStatus(context.Background(), testValidTxID)
+		require.ErrorIs(t, err, sigilerr.ErrTransactionNotFound)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newClient(nil).GetTxStatus(context.Background(), testValidTxID)
+		require.ErrorIs(t, err, sigilerr.ErrTransactionNotFound)
+	})
+
+	t.Run("invalid txid", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := newClient(nil).GetTxStatus(context.Background(), "nope")
+		require.ErrorIs(t, err, ErrInvalidTxID)
+	})
+}
@@ -0,0 +1,72 @@
+package bsv
+
+import (
+	"fmt"
+
+	"github.com/bsv-blockchain/go-sdk/transaction"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// verifySweep checks the unsigned transaction of a sweep plan against what
+// req asked for, independently of the builder's own totals. Send runs it
+// before every signing, including after a fee adjustment.
+func verifySweep(plan *sendPlan, req chain.SendRequest) error {
+	unsigned, err := unsignedTransaction(plan.builder)
+	if err != nil {
+		return err
+	}
+
+	check := &chain.SweepCheck{
+		UTXOs:      make([]chain.UTXO, len(plan.swept)),
+		FeeRate:    plan.builder.FeeRate,
+		SignedSize: uint64(plan.signedSize), //nolint:gosec // G115: a transaction size is never negative
+		DustLimit:  chain.BSV.DustLimit(),
+	}
+	for i, u := range plan.swept {
+		check.UTXOs[i] = chain.UTXO{TxID: u.TxID, Vout: u.Vout, Amount: u.Amount}
+	}
+	recipients := []string{req.To}
+	if len(req.Payments) > 0 {
+		check.Amounts = []uint64{req.Amount.Uint64()}
+		for _, p := range req.Payments {
+			recipients = append(recipients, p.To)
+			check.Amounts = append(check.Amounts, p.Amount.Uint64())
+		}
+	}
+	for _, addr := range recipients {
+		lock, lockErr := getLockingScript(UTXO{Address: addr})
+		if lockErr != nil {
+			return fmt.Errorf("recipient %s: %w", addr, lockErr)
+		}
+		check.Recipients = append(check.Recipients, lock.Bytes())
+	}
+	check.EstimatedFee = EstimateFeeForTx(len(check.UTXOs), len(recipients), check.FeeRate)
+
+	inputTotal, err := plan.builder.TotalInputAmount()
+	if err != nil {
+		return err
+	}
+	outputTotal, err := plan.builder.TotalOutputAmount()
+	if err != nil {
+		return err
+	}
+	claimed := chain.SweepTotals{Inputs: inputTotal, Outputs: plan.amount}
+	if outputTotal <= inputTotal {
+		claimed.Fee = inputTotal - outputTotal
+	}
+	return chain.VerifySweep(unsigned, check, claimed)
+}
+
+// unsignedTransaction serializes the builder's transaction with empty
+// unlocking scripts.
+func unsignedTransaction(builder *TxBuilder) ([]byte, error) {
+	tx := transaction.NewTransaction()
+	if err := addInputsToTx(tx, builder.Inputs, nil); err != nil {
+		return nil, err
+	}
+	if err := addOutputsToTx(tx, builder.Outputs); err != nil {
+		return nil, err
+	}
+	return tx.Bytes(), nil
+}
@@ -0,0 +1,71 @@
+package bsv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestVerifySweep(t *testing.T) {
+	t.Parallel()
+
+	client := NewClient(context.Background(), nil)
+	req := chain.SendRequest{
+		From:     validAddress(),
+		To:       validAddress2(),
+		SweepAll: true,
+		FeeRate:  1000,
+		UTXOs: []chain.UTXO{
+			{TxID: testTxID(1), Amount: 60000, Address: validAddress()},
+			{TxID: testTxID(2), Vout: 1, Amount: 40000, Address: validAddress()},
+		},
+	}
+	newPlan := func(t *testing.T) *sendPlan {
+		t.Helper()
+		plan, err := client.prepareSend(context.Background(), req)
+		require.NoError(t, err)
+		return plan
+	}
+
+	require.NoError(t, verifySweep(newPlan(t), req))
+
+	t.Run("fee raised after signing", func(t *testing.T) {
+		t.Parallel()
+		// Unusual unlocking scripts can sign larger than any P2PKH input
+		plan := newPlan(t)
+		shortfall, err := feeShortfall(plan.builder, 600)
+		require.NoError(t, err)
+		require.NoError(t, plan.coverShortfall(shortfall))
+		require.ErrorIs(t, verifySweep(plan, req), chain.ErrSweepMismatch)
+
+		plan.signedSize = 600
+		require.NoError(t, verifySweep(plan, req))
+	})
+
+	tamper := []struct {
+		name string
+		fn   func(*sendPlan)
+	}{
+		{"input dropped", func(p *sendPlan) { p.builder.Inputs = p.builder.Inputs[:1] }},
+		{"input spent twice", func(p *sendPlan) { p.builder.Inputs[1] = p.builder.Inputs[0] }},
+		{"input mispriced", func(p *sendPlan) { p.builder.Inputs[0].Amount += 5000 }},
+		{"fee overstated", func(p *sendPlan) { p.builder.Outputs[0].Amount -= 5000 }},
+		{"amount misreported", func(p *sendPlan) { p.amount++ }},
+		{"change output", func(p *sendPlan) {
+			p.builder.Outputs[0].Amount -= 1000
+			p.builder.Outputs = append(p.builder.Outputs, TxOutput{Address: validAddress(), Amount: 1000})
+		}},
+		{"wrong recipient", func(p *sendPlan) { p.builder.Outputs[0].Address = validAddress() }},
+	}
+	for _, tc := range tamper {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			plan := newPlan(t)
+			tc.fn(plan)
+			require.ErrorIs(t, verifySweep(plan, req), chain.ErrSweepMismatch)
+		})
+	}
+}
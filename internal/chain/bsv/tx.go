@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"slices"
 
 	"github.com/bsv-blockchain/go-sdk/chainhash"
 	ec "github.com/bsv-blockchain/go-sdk/primitives/ec"
@@ -14,6 +15,7 @@ import (
 	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
 
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/metrics"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
@@ -45,6 +47,10 @@ var (
 
 	// ErrMissingLockingScript indicates a UTXO is missing its locking script.
 	ErrMissingLockingScript = errors.New("UTXO missing locking script")
+
+	// ErrSweepPayments indicates the recipients of a split sweep are not paid
+	// its inputs less the fee.
+	ErrSweepPayments = errors.New("split sweep payments do not spend the swept balance")
 )
 
 // checkedAdd returns a + b, or an error if the result overflows uint64.
@@ -59,6 +65,9 @@ func checkedAdd(a, b uint64) (uint64, error) {
 type TxOutput struct {
 	Address string
 	Amount  uint64
+	// Data holds the pushes of an OP_FALSE OP_RETURN data carrier output.
+	// When set, Address is ignored and Amount is always zero.
+	Data [][]byte
 }
 
 // TxBuilder builds BSV transactions.
@@ -136,11 +145,25 @@ func (b *TxBuilder) TotalOutputAmount() (uint64, error) {
 	return total, nil
 }
 
+// EstimateSize estimates the serialized transaction size in bytes.
+// P2PKH outputs use the standard size estimate; data carrier outputs are
+// measured exactly since their size depends on the payload.
+func (b *TxBuilder) EstimateSize() uint64 {
+	size := EstimateTxSize(len(b.Inputs), 0)
+	for _, output := range b.Outputs {
+		if len(output.Data) > 0 {
+			size += DataOutputSize(output.Data)
+		} else {
+			size += P2PKHOutputSize
+		}
+	}
+	return size
+}
+
 // CalculateFee calculates the fee based on transaction size.
 // The feeRate is in satoshis per kilobyte, rounded up.
 func (b *TxBuilder) CalculateFee(feeRate uint64) uint64 {
-	size := EstimateTxSize(len(b.Inputs), len(b.Outputs))
-	return (size*feeRate + 999) / 1000
+	return (b.EstimateSize()*feeRate + 999) / 1000
 }
 
 // Validate checks that the transaction is valid.
@@ -184,35 +207,140 @@ func (b *TxBuilder) SetFeeRate(rate uint64) {
 }
 
 // Send implements the chain.Chain interface for BSV.
+func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.TransactionResult, error) {
+	plan, err := c.prepareSend(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	builder := plan.builder
+	if len(req.PrivateKeys) > 0 {
+		// Only inputs we hold keys for can be added to raise the fee
+		plan.spare = keyedUTXOs(plan.spare, req.PrivateKeys)
+	}
+
+	// Build and sign raw transaction (multi-key when PrivateKeys is provided),
+	// re-signing when the signed size leaves the fee below the target rate
+	rawTx, err := c.signWithFeeCheck(plan, func() ([]byte, error) {
+		if plan.sweep {
+			if err := verifySweep(plan, req); err != nil {
+				return nil, err
+			}
+		}
+		if len(req.PrivateKeys) > 0 {
+			return BuildRawTransactionMultiKey(builder, req.PrivateKeys)
+		}
+		return BuildRawTransaction(builder, req.PrivateKey)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building raw transaction: %w", err)
+	}
+	c.debug("send: raw tx built, %d bytes", len(rawTx))
+
+	// Zero private keys after use
+	if req.PrivateKey != nil {
+		wallet.ZeroBytes(req.PrivateKey)
+	}
+	for addr := range req.PrivateKeys {
+		wallet.ZeroBytes(req.PrivateKeys[addr])
+	}
+
+	// Calculate fee (safe: Validate already confirmed inputTotal >= outputTotal)
+	inputTotal, _ := builder.TotalInputAmount()
+	outputTotal, _ := builder.TotalOutputAmount()
+	fee := inputTotal - outputTotal
+
+	if req.BeforeBroadcast != nil {
+		localTxID := chainhash.DoubleHashH(rawTx).String()
+		spent, created := builderOutpoints(builder, localTxID)
+		if err = req.BeforeBroadcast(&chain.SignedTx{Hash: localTxID, Raw: rawTx, Spent: spent, Created: created, Fee: fee}); err != nil {
+			return nil, err
+		}
+	}
+
+	// Broadcast transaction
+	txHash, err := c.BroadcastTransaction(ctx, rawTx)
+	if err != nil {
+		return nil, err
+	}
+
+	spent, created := builderOutpoints(builder, txHash)
+	return &chain.TransactionResult{
+		Hash:    txHash,
+		From:    req.From,
+		To:      req.To,
+		Amount:  c.FormatAmount(chain.AmountToBigInt(plan.amount)),
+		Fee:     c.FormatAmount(chain.AmountToBigInt(fee)),
+		Status:  "pending",
+		Spent:   spent,
+		Created: created,
+
+		CoinSelection: string(plan.coinSelection),
+	}, nil
+}
+
+// sendPlan is a validated, unsigned send and what is needed to adjust its
+// fee after signing.
+type sendPlan struct {
+	builder *TxBuilder
+	// amount is the total paid to req.To and req.Payments.
+	amount uint64
+	// sweep marks a send of every UTXO with no change, to req.To or split
+	// among req.To and req.Payments.
+	sweep bool
+	// change is the index of the change output, or -1 when there is none.
+	change     int
+	changeAddr string
+	// spare are the available UTXOs the transaction does not spend.
+	spare []UTXO
+	// coinSelection is the strategy that chose the inputs, empty for a sweep.
+	coinSelection CoinSelection
+	// swept are the UTXOs a sweep was asked to spend, kept apart from the
+	// builder's inputs so verifySweep can check them.
+	swept []UTXO
+	// signedSize is the largest signed size whose fee shortfall was covered.
+	signedSize int
+}
+
+// prepareSend validates req, selects the UTXOs that fund it, and returns the
+// plan of the validated, unsigned transaction.
 //
 //nolint:gocognit,gocyclo // Transaction building involves multiple steps
-func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.TransactionResult, error) {
+func (c *Client) prepareSend(ctx context.Context, req chain.SendRequest) (*sendPlan, error) {
+	var amount uint64
+	var err error
+
 	// Validate addresses against this client's network. From is required unless
 	// pre-fetched UTXOs are provided. Network-scoped validation prevents sending
 	// to (or from) an address that belongs to the other network.
 	if len(req.UTXOs) == 0 {
-		if err := c.ValidateAddress(req.From); err != nil {
+		if err = c.ValidateAddress(req.From); err != nil {
 			return nil, fmt.Errorf("invalid from address: %w", err)
 		}
 	} else if req.From != "" {
-		if err := c.ValidateAddress(req.From); err != nil {
+		if err = c.ValidateAddress(req.From); err != nil {
 			return nil, fmt.Errorf("invalid from address: %w", err)
 		}
 	}
-	if err := c.ValidateAddress(req.To); err != nil {
+	if err = c.ValidateAddress(req.To); err != nil {
 		return nil, fmt.Errorf("invalid to address: %w", err)
 	}
-
-	// Validate amount for non-sweep requests before any network calls
-	if !req.SweepAll && req.Amount == nil {
+	for i, p := range req.Payments {
+		if err = c.ValidateAddress(p.To); err != nil {
+			return nil, fmt.Errorf("invalid payment %d address: %w", i+1, err)
+		}
+		if p.Amount == nil {
+			return nil, sigilerr.ErrAmountRequired
+		}
+	}
+	// Validate amount for non-sweep and split sweep requests before any
+	// network calls
+	split := req.SweepAll && len(req.Payments) > 0
+	if (!req.SweepAll || split) && req.Amount == nil {
 		return nil, sigilerr.ErrAmountRequired
 	}
 
 	// Get UTXOs: use pre-fetched multi-address UTXOs or fetch for single address
-	var (
-		utxos []UTXO
-		err   error
-	)
+	var utxos []UTXO
 	if len(req.UTXOs) > 0 {
 		utxos = convertChainUTXOs(req.UTXOs)
 		c.debug("send: using %d pre-fetched UTXOs", len(utxos))
@@ -232,8 +360,8 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 	}
 
 	var selected []UTXO
-	var amount uint64
 	var change uint64
+	var strategy CoinSelection
 
 	//nolint:nestif // Sweep vs normal send have distinct UTXO selection paths
 	if req.SweepAll {
@@ -252,19 +380,33 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 			totalInputs = sum
 		}
 
-		sweepAmount, sweepErr := CalculateSweepAmount(totalInputs, len(utxos), feeRate)
-		if sweepErr != nil {
-			return nil, sweepErr
+		if split {
+			// Split sweep: the caller divided the balance (see SplitSweep)
+			if amount, err = checkSplitSweep(req, totalInputs, len(utxos), feeRate); err != nil {
+				return nil, err
+			}
+		} else {
+			sweepAmount, sweepErr := CalculateSweepAmount(totalInputs, len(utxos), feeRate)
+			if sweepErr != nil {
+				return nil, sweepErr
+			}
+			amount = sweepAmount
 		}
-		amount = sweepAmount
 	} else {
-		// Normal send: select UTXOs to cover amount + fee
+		// Normal send: select UTXOs to cover every payment + fee
 		amount = req.Amount.Uint64()
+		for _, p := range req.Payments {
+			if amount, err = checkedAdd(amount, p.Amount.Uint64()); err != nil {
+				return nil, fmt.Errorf("payment total: %w", err)
+			}
+		}
 
-		selected, change, err = c.SelectUTXOs(utxos, amount, feeRate)
-		if err != nil {
-			return nil, err
+		sel, selErr := c.SelectCoins(c.coinSelection, utxos, amount, feeRate, 1+len(req.Payments))
+		if selErr != nil {
+			return nil, selErr
 		}
+		selected, change, strategy = sel.UTXOs, sel.Change, sel.Strategy
+		c.debug("send: %s coin selection chose %d of %d UTXOs", strategy, len(selected), len(utxos))
 	}
 
 	// Build transaction
@@ -279,26 +421,44 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 		}
 	}
 
-	// Add recipient output
-	err = builder.AddOutput(req.To, amount)
+	// Add recipient outputs (a sweep pays its whole amount to req.To)
+	toAmount := amount
+	if !req.SweepAll || split {
+		toAmount = req.Amount.Uint64()
+	}
+	err = builder.AddOutput(req.To, toAmount)
 	if err != nil {
 		return nil, fmt.Errorf("adding recipient output: %w", err)
 	}
+	for i, p := range req.Payments {
+		if err = builder.AddOutput(p.To, p.Amount.Uint64()); err != nil {
+			return nil, fmt.Errorf("adding payment %d output: %w", i+1, err)
+		}
+	}
+
+	plan := &sendPlan{
+		builder:       builder,
+		amount:        amount,
+		sweep:         req.SweepAll,
+		change:        -1,
+		changeAddr:    req.From,
+		spare:         unspentUTXOs(utxos, selected),
+		coinSelection: strategy,
+	}
+	if req.ChangeAddress != "" {
+		plan.changeAddr = req.ChangeAddress
+	}
+	if req.SweepAll {
+		plan.swept = slices.Clone(utxos)
+	}
 
 	// Add change output if above dust (skipped for sweep since there is no change)
-	//nolint:nestif // Change output logic only applies to non-sweep transactions
-	if !req.SweepAll {
-		dustLimit := chain.BSV.DustLimit()
-		if change >= dustLimit {
-			changeAddr := req.From
-			if req.ChangeAddress != "" {
-				changeAddr = req.ChangeAddress
-			}
-			err = builder.AddOutput(changeAddr, change)
-			if err != nil {
-				return nil, fmt.Errorf("adding change output: %w", err)
-			}
+	if !req.SweepAll && change >= chain.BSV.DustLimit() {
+		err = builder.AddOutput(plan.changeAddr, change)
+		if err != nil {
+			return nil, fmt.Errorf("adding change output: %w", err)
 		}
+		plan.change = len(builder.Outputs) - 1
 	}
 
 	// Validate transaction
@@ -307,45 +467,35 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 		return nil, fmt.Errorf("validating transaction: %w", err)
 	}
 
-	// Build and sign raw transaction (multi-key when PrivateKeys is provided)
-	var rawTx []byte
-	if len(req.PrivateKeys) > 0 {
-		rawTx, err = BuildRawTransactionMultiKey(builder, req.PrivateKeys)
-	} else {
-		rawTx, err = BuildRawTransaction(builder, req.PrivateKey)
-	}
-	if err != nil {
-		return nil, fmt.Errorf("building raw transaction: %w", err)
-	}
-	c.debug("send: raw tx built, %d bytes", len(rawTx))
+	return plan, nil
+}
 
-	// Zero private keys after use
-	if req.PrivateKey != nil {
-		wallet.ZeroBytes(req.PrivateKey)
-	}
-	for addr := range req.PrivateKeys {
-		wallet.ZeroBytes(req.PrivateKeys[addr])
+// builderOutpoints returns the inputs a built transaction spends and the P2PKH
+// outputs it creates, the latter addressed by txHash and their vout.
+func builderOutpoints(builder *TxBuilder, txHash string) (spent, created []chain.UTXO) {
+	spent = make([]chain.UTXO, len(builder.Inputs))
+	for i, u := range builder.Inputs {
+		spent[i] = chain.UTXO{
+			TxID:          u.TxID,
+			Vout:          u.Vout,
+			Amount:        u.Amount,
+			ScriptPubKey:  u.ScriptPubKey,
+			Address:       u.Address,
+			Confirmations: u.Confirmations,
+		}
 	}
-
-	// Broadcast transaction
-	txHash, err := c.BroadcastTransaction(ctx, rawTx)
-	if err != nil {
-		return nil, err
+	for i, out := range builder.Outputs {
+		if len(out.Data) > 0 {
+			continue
+		}
+		created = append(created, chain.UTXO{
+			TxID:    txHash,
+			Vout:    uint32(i), //nolint:gosec // output count is bounded by transaction size
+			Amount:  out.Amount,
+			Address: out.Address,
+		})
 	}
-
-	// Calculate fee (safe: Validate already confirmed inputTotal >= outputTotal)
-	inputTotal, _ := builder.TotalInputAmount()
-	outputTotal, _ := builder.TotalOutputAmount()
-	fee := inputTotal - outputTotal
-
-	return &chain.TransactionResult{
-		Hash:   txHash,
-		From:   req.From,
-		To:     req.To,
-		Amount: c.FormatAmount(chain.AmountToBigInt(amount)),
-		Fee:    c.FormatAmount(chain.AmountToBigInt(fee)),
-		Status: "pending",
-	}, nil
+	return spent, created
 }
 
 // BuildRawTransaction builds and signs a raw BSV transaction using go-sdk.
@@ -358,6 +508,8 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 // - P2PKH unlocking script generation with SIGHASH_ALL|SIGHASH_FORKID signing
 // - Proper BSV transaction serialization
 func BuildRawTransaction(builder *TxBuilder, privateKey []byte) ([]byte, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseSign)()
+
 	if err := validateBuildInputs(builder, privateKey); err != nil {
 		return nil, err
 	}
@@ -385,6 +537,11 @@ func BuildRawTransaction(builder *TxBuilder, privateKey []byte) ([]byte, error)
 		return nil, err
 	}
 
+	// Reject anything miners would refuse to relay before it reaches a broadcaster
+	if err := CheckPolicy(tx); err != nil {
+		return nil, err
+	}
+
 	return tx.Bytes(), nil
 }
 
@@ -393,6 +550,8 @@ func BuildRawTransaction(builder *TxBuilder, privateKey []byte) ([]byte, error)
 // The keyMap maps each address to its 32-byte private key.
 // Each input's UTXO.Address is looked up in keyMap to find its signing key.
 func BuildRawTransactionMultiKey(builder *TxBuilder, keyMap map[string][]byte) ([]byte, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseSign)()
+
 	if err := validateMultiKeyInputs(builder, keyMap); err != nil {
 		return nil, err
 	}
@@ -417,6 +576,11 @@ func BuildRawTransactionMultiKey(builder *TxBuilder, keyMap map[string][]byte) (
 		return nil, err
 	}
 
+	// Reject anything miners would refuse to relay before it reaches a broadcaster
+	if err := CheckPolicy(tx); err != nil {
+		return nil, err
+	}
+
 	return tx.Bytes(), nil
 }
 
@@ -550,6 +714,17 @@ func addInputsToTx(tx *transaction.Transaction, utxos []UTXO, unlocker *p2pkh.P2
 // addOutputsToTx adds all outputs to the transaction.
 func addOutputsToTx(tx *transaction.Transaction, outputs []TxOutput) error {
 	for i, output := range outputs {
+		if len(output.Data) > 0 {
+			lockingScript, err := DataScript(output.Data...)
+			if err != nil {
+				return fmt.Errorf("adding data output %d: %w", i, err)
+			}
+			tx.AddOutput(&transaction.TransactionOutput{
+				Satoshis:      0,
+				LockingScript: lockingScript,
+			})
+			continue
+		}
 		if err := tx.PayToAddress(output.Address, output.Amount); err != nil {
 			return fmt.Errorf("adding output %d: %w", i, err)
 		}
@@ -592,11 +767,32 @@ func getLockingScript(utxo UTXO) (*script.Script, error) {
 
 // BroadcastTransaction broadcasts a raw transaction to the network.
 // It tries each configured broadcaster in order, returning on first success.
+// Transient failures (network errors, 5xx responses) fall through to the next
+// provider; a rejection of the transaction itself stops immediately, since
+// every provider would reject it the same way.
+//
+// Broadcast is at-most-once: before each fallback attempt, and once more
+// before reporting failure, the locally computed txid is looked up on the
+// network. If an earlier attempt actually reached the mempool despite the
+// error, that txid is returned instead of broadcasting again.
+//
+// When no provider could be reached at all, the error also wraps
+// sigilerr.ErrNetworkError, so callers can tell a network partition from a
+// provider that answered with a failure.
 func (c *Client) BroadcastTransaction(ctx context.Context, rawTx []byte) (string, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseBroadcast)()
+
 	txHex := hex.EncodeToString(rawTx)
+	localTxID := chainhash.DoubleHashH(rawTx).String()
 
 	var lastErr error
-	for _, b := range c.broadcasters {
+	unreachable := true
+	for i, b := range c.broadcasters {
+		if i > 0 && c.txKnown(ctx, localTxID) {
+			c.debug("transaction %s already on network, skipping %s", localTxID, b.Name())
+			return localTxID, nil
+		}
+
 		c.debug("broadcasting via %s", b.Name())
 		txid, err := b.Broadcast(ctx, txHex)
 		if err == nil {
@@ -605,15 +801,51 @@ func (c *Client) BroadcastTransaction(ctx context.Context, rawTx []byte) (string
 		}
 		c.logError("broadcast failed via %s: %v", b.Name(), err)
 		lastErr = err
+		unreachable = unreachable && isUnreachable(err)
+
+		if isRejectedBroadcast(err) {
+			return "", fmt.Errorf("%w: rejected by %s: %w", ErrBroadcastFailed, b.Name(), err)
+		}
 	}
 
 	if lastErr != nil {
+		if c.txKnown(ctx, localTxID) {
+			c.debug("transaction %s found on network after broadcast errors", localTxID)
+			return localTxID, nil
+		}
 		c.logError("all broadcast providers failed, last error: %v", lastErr)
+		if unreachable {
+			return "", fmt.Errorf("%w: %w: no provider reachable: %w", ErrBroadcastFailed, sigilerr.ErrNetworkError, lastErr)
+		}
 		return "", fmt.Errorf("%w: all providers failed: %w", ErrBroadcastFailed, lastErr)
 	}
 	return "", fmt.Errorf("%w: no broadcast providers configured", ErrBroadcastFailed)
 }
 
+// checkSplitSweep returns the total a split sweep pays, after checking
+// that its recipients are paid totalInputs less the fee of the transaction.
+// What is left over must be below the dust limit; it adds to the fee.
+func checkSplitSweep(req chain.SendRequest, totalInputs uint64, numInputs int, feeRate uint64) (uint64, error) {
+	paid := req.Amount.Uint64()
+	for _, p := range req.Payments {
+		sum, err := checkedAdd(paid, p.Amount.Uint64())
+		if err != nil {
+			return 0, fmt.Errorf("payment total: %w", err)
+		}
+		paid = sum
+	}
+
+	fee := EstimateFeeForTx(numInputs, 1+len(req.Payments), ValidateFeeRate(feeRate))
+	if paid > totalInputs || totalInputs-paid < fee {
+		return 0, fmt.Errorf("%w: %d satoshis paid from %d with a %d satoshi fee",
+			ErrSweepPayments, paid, totalInputs, fee)
+	}
+	if left := totalInputs - paid - fee; left >= chain.BSV.DustLimit() {
+		return 0, fmt.Errorf("%w: %d satoshis would be left unpaid", ErrSweepPayments, left)
+	}
+	return paid, nil
+}
+
 // ErrSweepInsufficientFunds indicates there are not enough funds to cover the fee.
 var ErrSweepInsufficientFunds = errors.New("insufficient funds: fee exceeds total balance")
 
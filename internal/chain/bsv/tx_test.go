@@ -670,3 +670,29 @@ func TestZeroBytes_32Bytes(t *testing.T) {
 		assert.Equal(t, byte(0), b, "byte at position %d should be zero", i)
 	}
 }
+
+func TestBuilderOutpoints(t *testing.T) {
+	t.Parallel()
+
+	builder := NewTxBuilder()
+	require.NoError(t, builder.AddInput(UTXO{
+		TxID:    "a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0c1d2e3f4a5b6c7d8e9f0a1b2",
+		Vout:    3,
+		Amount:  100000,
+		Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+	}))
+	require.NoError(t, builder.AddDataOutput([]byte("hello")))
+	require.NoError(t, builder.AddOutput("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", 60000))
+	require.NoError(t, builder.AddOutput("1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", 39000))
+
+	spent, created := builderOutpoints(builder, "feed")
+
+	require.Len(t, spent, 1)
+	assert.Equal(t, uint32(3), spent[0].Vout)
+	assert.Equal(t, uint64(100000), spent[0].Amount)
+
+	// The data carrier output is skipped but keeps its vout slot.
+	require.Len(t, created, 2)
+	assert.Equal(t, chain.UTXO{TxID: "feed", Vout: 1, Amount: 60000, Address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"}, created[0])
+	assert.Equal(t, chain.UTXO{TxID: "feed", Vout: 2, Amount: 39000, Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"}, created[1])
+}
@@ -0,0 +1,146 @@
+package btc
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/wallet/bitcoin"
+)
+
+// Script opcodes used by standard output scripts.
+const (
+	opDup         = 0x76
+	opHash160     = 0xa9
+	opEqual       = 0x87
+	opEqualVerify = 0x88
+	opCheckSig    = 0xac
+	op1           = 0x51
+)
+
+// hash160Length is the size of a public key or script hash.
+const hash160Length = 20
+
+//nolint:gochecknoinits // Registers the BTC validator behind chain.ValidateAddress
+func init() {
+	chain.RegisterAddressValidator(chain.BTC, validateChainAddress)
+}
+
+// Base58Check version bytes per network.
+const (
+	mainnetP2PKH byte = 0x00
+	mainnetP2SH  byte = 0x05
+	testnetP2PKH byte = 0x6f
+	testnetP2SH  byte = 0xc4
+)
+
+// segwitHRP returns the bech32 human-readable part for a network.
+func segwitHRP(network Network) string {
+	if network == NetworkTestnet {
+		return "tb"
+	}
+	return "bc"
+}
+
+// ValidateAddressForNetwork checks that address is a P2PKH, P2SH, or segwit
+// address for the network, including its checksum.
+func ValidateAddressForNetwork(address string, network Network) error {
+	_, err := PayToAddrScript(address, network)
+	return err
+}
+
+// validateChainAddress is the chain.AddressValidatorFunc for BTC.
+func validateChainAddress(address, network string) error {
+	net, other := NetworkMainnet, NetworkTestnet
+	if network == chain.Testnet {
+		net, other = other, net
+	}
+
+	err := ValidateAddressForNetwork(address, net)
+	if err == nil {
+		return nil
+	}
+
+	reason := chain.AddressReasonFormat
+	switch {
+	case ValidateAddressForNetwork(address, other) == nil:
+		reason = chain.AddressReasonNetwork
+	case hasBadChecksum(address, net):
+		reason = chain.AddressReasonChecksum
+	}
+	return chain.NewAddressError(chain.BTC, network, address, reason, err)
+}
+
+// hasBadChecksum reports whether address has the shape of an address on
+// network but a checksum that does not match, as a typo leaves it.
+func hasBadChecksum(address string, network Network) bool {
+	if _, _, err := bitcoin.SegwitDecode(segwitHRP(network), address); errors.Is(err, bitcoin.ErrBadChecksum) {
+		return true
+	}
+	decoded, err := bitcoin.Base58Decode(address)
+	if err != nil || len(decoded) != 1+hash160Length+4 {
+		return false
+	}
+	_, err = bitcoin.Base58CheckDecode(address)
+	return errors.Is(err, bitcoin.ErrBadChecksum)
+}
+
+// PayToAddrScript returns the output script paying to address on network.
+func PayToAddrScript(address string, network Network) ([]byte, error) {
+	if address == "" {
+		return nil, ErrInvalidAddress
+	}
+
+	if version, program, err := bitcoin.SegwitDecode(segwitHRP(network), address); err == nil {
+		opVersion := byte(0)
+		if version > 0 {
+			opVersion = op1 + version - 1
+		}
+		script := make([]byte, 0, 2+len(program))
+		script = append(script, opVersion, byte(len(program)))
+		return append(script, program...), nil
+	}
+
+	payload, err := bitcoin.Base58CheckDecode(address)
+	if err != nil || len(payload) != 1+hash160Length {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAddress, address)
+	}
+	p2pkh, p2sh := mainnetP2PKH, mainnetP2SH
+	if network == NetworkTestnet {
+		p2pkh, p2sh = testnetP2PKH, testnetP2SH
+	}
+
+	hash := payload[1:]
+	switch payload[0] {
+	case p2pkh:
+		return p2pkhScript(hash), nil
+	case p2sh:
+		script := make([]byte, 0, 23)
+		script = append(script, opHash160, hash160Length)
+		script = append(script, hash...)
+		return append(script, opEqual), nil
+	default:
+		return nil, fmt.Errorf("%w: %s is not a %s network address", ErrInvalidAddress, address, network)
+	}
+}
+
+// p2pkhScript returns the P2PKH output script for a public key hash.
+func p2pkhScript(pubKeyHash []byte) []byte {
+	script := make([]byte, 0, 25)
+	script = append(script, opDup, opHash160, hash160Length)
+	script = append(script, pubKeyHash...)
+	return append(script, opEqualVerify, opCheckSig)
+}
+
+// p2pkhHash returns the public key hash of a P2PKH address, or an error for
+// any other address type. Only P2PKH inputs can be signed.
+func p2pkhHash(address string, network Network) ([]byte, error) {
+	script, err := PayToAddrScript(address, network)
+	if err != nil {
+		return nil, err
+	}
+	if len(script) != 25 || script[0] != opDup {
+		return nil, fmt.Errorf("%w: %s is not a P2PKH address", ErrInvalidAddress, address)
+	}
+	return script[3:23], nil
+}
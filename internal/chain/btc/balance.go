@@ -0,0 +1,178 @@
+package btc
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Balance represents a balance result with metadata.
+type Balance struct {
+	Address     string
+	Amount      *big.Int
+	Unconfirmed *big.Int // Unconfirmed balance delta in satoshis (can be negative)
+	Symbol      string
+	Decimals    int
+}
+
+// addressStats is the funded/spent summary in an Esplora address response.
+type addressStats struct {
+	FundedTxoSum int64 `json:"funded_txo_sum"`
+	SpentTxoSum  int64 `json:"spent_txo_sum"`
+}
+
+// addressResponse is the Esplora GET /address/:address response.
+type addressResponse struct {
+	ChainStats   addressStats `json:"chain_stats"`
+	MempoolStats addressStats `json:"mempool_stats"`
+}
+
+// utxoResponse is one entry of the Esplora GET /address/:address/utxo response.
+type utxoResponse struct {
+	TxID   string `json:"txid"`
+	Vout   uint32 `json:"vout"`
+	Value  uint64 `json:"value"`
+	Status struct {
+		Confirmed   bool  `json:"confirmed"`
+		BlockHeight int64 `json:"block_height"`
+	} `json:"status"`
+}
+
+// GetBalance retrieves the confirmed BTC balance for an address.
+func (c *Client) GetBalance(ctx context.Context, address string) (*big.Int, error) {
+	bal, err := c.GetNativeBalance(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	return bal.Amount, nil
+}
+
+// GetNativeBalance retrieves the BTC balance including the mempool delta.
+func (c *Client) GetNativeBalance(ctx context.Context, address string) (*Balance, error) {
+	if err := c.ValidateAddress(address); err != nil {
+		return nil, err
+	}
+
+	body, err := c.get(ctx, "/address/"+address)
+	if err != nil {
+		return nil, err
+	}
+	var resp addressResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing address response: %w", err)
+	}
+
+	bal := &Balance{
+		Address:  address,
+		Amount:   big.NewInt(resp.ChainStats.FundedTxoSum - resp.ChainStats.SpentTxoSum),
+		Symbol:   "BTC",
+		Decimals: decimals,
+	}
+	if delta := resp.MempoolStats.FundedTxoSum - resp.MempoolStats.SpentTxoSum; delta != 0 {
+		bal.Unconfirmed = big.NewInt(delta)
+	}
+	return bal, nil
+}
+
+// GetTokenBalance is not supported for BTC.
+func (c *Client) GetTokenBalance(_ context.Context, _, _ string) (*big.Int, error) {
+	return nil, sigilerr.ErrNotSupported
+}
+
+// ListUTXOs returns unspent transaction outputs for an address, including
+// unconfirmed ones (zero confirmations).
+func (c *Client) ListUTXOs(ctx context.Context, address string) ([]chain.UTXO, error) {
+	if err := c.ValidateAddress(address); err != nil {
+		return nil, err
+	}
+
+	body, err := c.get(ctx, "/address/"+address+"/utxo")
+	if err != nil {
+		return nil, err
+	}
+	var resp []utxoResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing utxo response: %w", err)
+	}
+
+	var tip int64
+	for _, u := range resp {
+		if u.Status.Confirmed {
+			if tip, err = c.TipHeight(ctx); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	script, err := PayToAddrScript(address, c.network)
+	if err != nil {
+		return nil, err
+	}
+	scriptHex := hex.EncodeToString(script)
+
+	utxos := make([]chain.UTXO, 0, len(resp))
+	for _, u := range resp {
+		var confirmations uint32
+		if u.Status.Confirmed && tip >= u.Status.BlockHeight {
+			confirmations = uint32(min(tip-u.Status.BlockHeight+1, math.MaxUint32)) //nolint:gosec // G115: clamped to uint32 range
+		}
+		utxos = append(utxos, chain.UTXO{
+			TxID:          u.TxID,
+			Vout:          u.Vout,
+			Amount:        u.Value,
+			ScriptPubKey:  scriptHex,
+			Address:       address,
+			Confirmations: confirmations,
+		})
+	}
+	return utxos, nil
+}
+
+// TipHeight returns the height of the best block.
+func (c *Client) TipHeight(ctx context.Context) (int64, error) {
+	body, err := c.get(ctx, "/blocks/tip/height")
+	if err != nil {
+		return 0, err
+	}
+	height, err := strconv.ParseInt(strings.TrimSpace(string(body)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing tip height: %w", err)
+	}
+	return height, nil
+}
+
+// Broadcast submits a signed raw transaction and returns its txid.
+func (c *Client) Broadcast(ctx context.Context, rawTx []byte) (string, error) {
+	body, err := c.do(ctx, http.MethodPost, "/tx", hex.EncodeToString(rawTx))
+	if err != nil {
+		return "", fmt.Errorf("broadcasting transaction: %w", err)
+	}
+	txid := strings.TrimSpace(string(body))
+	if !isValidTxID(txid) {
+		return "", sigilerr.WithDetails(ErrAPIError, map[string]string{"body": truncateBody(txid, 128)})
+	}
+	return txid, nil
+}
+
+// isValidTxID reports whether s is a 64-character hex transaction id.
+func isValidTxID(s string) bool {
+	if len(s) != 64 {
+		return false
+	}
+	for _, r := range s {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') && (r < 'A' || r > 'F') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,281 @@
+package btc
+
+import (
+	"context"
+	"encoding/hex"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+const (
+	// Addresses of the compressed public key for private key 1.
+	testMainnetP2PKH = "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"
+	testTestnetP2PKH = "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r"
+	testPubKeyHash   = "751e76e8199196d454941c45d1b3a323f1433bd6"
+
+	testMainnetP2SH   = "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"
+	testMainnetP2WPKH = "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+	testMainnetP2TR   = "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0"
+)
+
+// newTestClient returns a mainnet client for an Esplora test server.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(&ClientOptions{API: server.URL})
+	require.NoError(t, err)
+	return client
+}
+
+func TestResolveAPIURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		api     string
+		network Network
+		want    string
+	}{
+		{"", NetworkMainnet, "https://mempool.space/api"},
+		{"mempool", NetworkTestnet, "https://mempool.space/testnet/api"},
+		{"Blockstream", NetworkMainnet, "https://blockstream.info/api"},
+		{"https://esplora.example/api/", NetworkMainnet, "https://esplora.example/api"},
+	}
+	for _, tc := range tests {
+		got, err := ResolveAPIURL(tc.api, tc.network)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got, tc.api)
+	}
+
+	_, err := ResolveAPIURL("electrum", NetworkMainnet)
+	require.ErrorIs(t, err, ErrUnknownAPI)
+}
+
+func TestPayToAddrScript(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		address string
+		network Network
+		want    string
+	}{
+		{"mainnet P2PKH", testMainnetP2PKH, NetworkMainnet, "76a914" + testPubKeyHash + "88ac"},
+		{"testnet P2PKH", testTestnetP2PKH, NetworkTestnet, "76a914" + testPubKeyHash + "88ac"},
+		{"mainnet P2SH", testMainnetP2SH, NetworkMainnet, "a914b472a266d0bd89c13706a4132ccfb16f7c3b9fcb87"},
+		{"mainnet P2WPKH", testMainnetP2WPKH, NetworkMainnet, "0014" + testPubKeyHash},
+		{"mainnet P2TR", testMainnetP2TR, NetworkMainnet, "5120" + "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			script, err := PayToAddrScript(tc.address, tc.network)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, hex.EncodeToString(script))
+		})
+	}
+}
+
+func TestValidateAddressForNetwork_Rejects(t *testing.T) {
+	t.Parallel()
+
+	require.Error(t, ValidateAddressForNetwork(testMainnetP2PKH, NetworkTestnet), "mainnet address on testnet")
+	require.Error(t, ValidateAddressForNetwork(testTestnetP2PKH, NetworkMainnet), "testnet address on mainnet")
+	require.Error(t, ValidateAddressForNetwork(testMainnetP2WPKH, NetworkTestnet), "bc1 address on testnet")
+	require.Error(t, ValidateAddressForNetwork("1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMJ", NetworkMainnet), "bad checksum")
+	require.Error(t, ValidateAddressForNetwork("", NetworkMainnet))
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, client.ValidateAddress("not-an-address"), ErrInvalidAddress)
+}
+
+func TestFormatAndParseAmount(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "0.00012345", client.FormatAmount(big.NewInt(12345)))
+
+	amount, err := client.ParseAmount("0.001")
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100000), amount)
+
+	_, err = client.ParseAmount("abc")
+	require.ErrorIs(t, err, ErrInvalidAmount)
+}
+
+func TestGetNativeBalance(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/address/"+testMainnetP2PKH, r.URL.Path)
+		_, _ = w.Write([]byte(`{"chain_stats":{"funded_txo_sum":150000,"spent_txo_sum":50000},"mempool_stats":{"funded_txo_sum":0,"spent_txo_sum":20000}}`))
+	})
+
+	bal, err := client.GetNativeBalance(context.Background(), testMainnetP2PKH)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(100000), bal.Amount)
+	assert.Equal(t, big.NewInt(-20000), bal.Unconfirmed)
+	assert.Equal(t, "BTC", bal.Symbol)
+	assert.Equal(t, 8, bal.Decimals)
+}
+
+func TestGetNativeBalance_APIError(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	})
+
+	_, err := client.GetNativeBalance(context.Background(), testMainnetP2PKH)
+	require.ErrorIs(t, err, ErrAPIError)
+}
+
+func TestListUTXOs(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/address/" + testMainnetP2PKH + "/utxo":
+			_, _ = w.Write([]byte(`[
+				{"txid":"` + strings.Repeat("aa", 32) + `","vout":1,"value":5000,"status":{"confirmed":true,"block_height":100}},
+				{"txid":"` + strings.Repeat("bb", 32) + `","vout":0,"value":700,"status":{"confirmed":false}}
+			]`))
+		case "/blocks/tip/height":
+			_, _ = w.Write([]byte("105"))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	utxos, err := client.ListUTXOs(context.Background(), testMainnetP2PKH)
+	require.NoError(t, err)
+	require.Len(t, utxos, 2)
+	assert.Equal(t, uint32(6), utxos[0].Confirmations)
+	assert.Equal(t, uint64(5000), utxos[0].Amount)
+	assert.Equal(t, "76a914"+testPubKeyHash+"88ac", utxos[0].ScriptPubKey)
+	assert.Equal(t, uint32(0), utxos[1].Confirmations)
+	assert.Equal(t, testMainnetP2PKH, utxos[1].Address)
+}
+
+func TestGetFeeEstimates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mempool recommended", func(t *testing.T) {
+		t.Parallel()
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/v1/fees/recommended", r.URL.Path)
+			_, _ = w.Write([]byte(`{"fastestFee":20,"halfHourFee":12,"hourFee":8,"economyFee":4,"minimumFee":1}`))
+		})
+
+		fees, err := client.GetFeeEstimates(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, FeeEstimates{Fastest: 20, HalfHour: 12, Hour: 8, Economy: 4, Minimum: 1}, *fees)
+		assert.Equal(t, uint64(12000), client.GetFeeRate(context.Background()))
+	})
+
+	t.Run("esplora fee-estimates", func(t *testing.T) {
+		t.Parallel()
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/fee-estimates" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write([]byte(`{"1":15.2,"3":10.1,"6":7,"144":1.5}`))
+		})
+
+		fees, err := client.GetFeeEstimates(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, FeeEstimates{Fastest: 16, HalfHour: 11, Hour: 7, Economy: 2, Minimum: 1}, *fees)
+	})
+
+	t.Run("unreachable falls back to default", func(t *testing.T) {
+		t.Parallel()
+		client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			http.NotFound(w, r)
+		})
+		assert.Equal(t, uint64(DefaultFeeRate), client.GetFeeRate(context.Background()))
+	})
+}
+
+func TestBroadcast(t *testing.T) {
+	t.Parallel()
+
+	txid := strings.Repeat("cd", 32)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/tx", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, "0102", string(body))
+		_, _ = w.Write([]byte(txid))
+	})
+
+	got, err := client.Broadcast(context.Background(), []byte{0x01, 0x02})
+	require.NoError(t, err)
+	assert.Equal(t, txid, got)
+}
+
+func TestBroadcast_Rejected(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "sendrawtransaction RPC error: min relay fee not met", http.StatusBadRequest)
+	})
+
+	_, err := client.Broadcast(context.Background(), []byte{0x01})
+	require.ErrorIs(t, err, ErrAPIError)
+}
+
+func TestClientImplementsUTXOChain(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(&ClientOptions{Network: NetworkTestnet})
+	require.NoError(t, err)
+	var c chain.UTXOChain = client
+	assert.Equal(t, chain.BTC, c.ID())
+	assert.Equal(t, NetworkTestnet, client.Network())
+}
+
+func TestValidateChainAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		network string
+		address string
+		reason  chain.AddressReason // empty when valid
+	}{
+		{"P2PKH", chain.Mainnet, testMainnetP2PKH, ""},
+		{"P2WPKH", chain.Mainnet, testMainnetP2WPKH, ""},
+		{"testnet P2PKH", chain.Testnet, testTestnetP2PKH, ""},
+		{"bad Base58Check checksum", chain.Mainnet, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMJ", chain.AddressReasonChecksum},
+		{"bad bech32 checksum", chain.Mainnet, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5", chain.AddressReasonChecksum},
+		{"testnet on mainnet", chain.Mainnet, testTestnetP2PKH, chain.AddressReasonNetwork},
+		{"segwit on testnet", chain.Testnet, testMainnetP2WPKH, chain.AddressReasonNetwork},
+		{"garbage", chain.Mainnet, "hello", chain.AddressReasonFormat},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := chain.ValidateAddressOnNetwork(chain.BTC, tc.network, tc.address)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+			var addrErr *chain.AddressError
+			require.ErrorAs(t, err, &addrErr)
+			assert.Equal(t, tc.reason, addrErr.Reason)
+		})
+	}
+}
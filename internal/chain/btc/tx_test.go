@@ -0,0 +1,311 @@
+package btc
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/wallet/bitcoin"
+)
+
+// testKey returns private key 1, whose address is testMainnetP2PKH.
+func testKey() []byte {
+	key := make([]byte, 32)
+	key[31] = 1
+	return key
+}
+
+// testUTXO returns a P2PKH UTXO of amount satoshis for testMainnetP2PKH.
+func testUTXO(fill string, amount uint64) chain.UTXO {
+	return chain.UTXO{TxID: strings.Repeat(fill, 32), Vout: 0, Amount: amount, Address: testMainnetP2PKH}
+}
+
+// verifyInputSignature checks the scriptSig of input index in a serialized
+// transaction against the legacy SIGHASH_ALL preimage.
+func verifyInputSignature(t *testing.T, raw []byte, index int, prevScript []byte) {
+	t.Helper()
+	tx := parseRawTx(t, raw)
+
+	scriptSig := tx.inputs[index].script
+	sigLen := int(scriptSig[0])
+	require.Equal(t, byte(sigHashAll), scriptSig[sigLen], "sighash type")
+	sig, err := ecdsa.ParseDERSignature(scriptSig[1:sigLen])
+	require.NoError(t, err)
+	pubKey, err := secp256k1.ParsePubKey(scriptSig[sigLen+2:])
+	require.NoError(t, err)
+
+	preimage := binary.LittleEndian.AppendUint32(tx.serialize(index, prevScript), sigHashAll)
+	assert.True(t, sig.Verify(bitcoin.DoubleSHA256(preimage), pubKey), "signature verifies")
+}
+
+// parseRawTx decodes a serialized legacy transaction with small counts.
+func parseRawTx(t *testing.T, raw []byte) *rawTx {
+	t.Helper()
+	require.Equal(t, uint32(txVersion), binary.LittleEndian.Uint32(raw))
+	pos := 4
+	next := func(n int) []byte {
+		b := raw[pos : pos+n]
+		pos += n
+		return b
+	}
+
+	tx := &rawTx{}
+	for range int(next(1)[0]) {
+		in := rawInput{prevHash: next(32), prevIndex: binary.LittleEndian.Uint32(next(4))}
+		in.script = next(int(next(1)[0]))
+		in.sequence = binary.LittleEndian.Uint32(next(4))
+		tx.inputs = append(tx.inputs, in)
+	}
+	for range int(next(1)[0]) {
+		out := rawOutput{value: binary.LittleEndian.Uint64(next(8))}
+		out.script = next(int(next(1)[0]))
+		tx.outputs = append(tx.outputs, out)
+	}
+	require.Equal(t, uint32(0), binary.LittleEndian.Uint32(next(4)), "locktime")
+	require.Equal(t, len(raw), pos, "no trailing bytes")
+	return tx
+}
+
+func TestBuildSignedTransaction(t *testing.T) {
+	t.Parallel()
+
+	inputs := []chain.UTXO{testUTXO("aa", 60000), testUTXO("bb", 50000)}
+	outputs := []TxOutput{
+		{Address: testMainnetP2WPKH, Amount: 100000},
+		{Address: testMainnetP2PKH, Amount: 8000},
+	}
+	raw, err := BuildSignedTransaction(inputs, outputs, map[string][]byte{testMainnetP2PKH: testKey()}, NetworkMainnet)
+	require.NoError(t, err)
+
+	tx := parseRawTx(t, raw)
+	require.Len(t, tx.inputs, 2)
+	require.Len(t, tx.outputs, 2)
+	assert.Equal(t, strings.Repeat("aa", 32), hex.EncodeToString(tx.inputs[0].prevHash))
+	assert.Equal(t, uint32(sequenceFinal), tx.inputs[0].sequence)
+	assert.Equal(t, uint64(100000), tx.outputs[0].value)
+	assert.Equal(t, "0014"+testPubKeyHash, hex.EncodeToString(tx.outputs[0].script))
+
+	prevScript, _ := hex.DecodeString("76a914" + testPubKeyHash + "88ac")
+	verifyInputSignature(t, raw, 0, prevScript)
+	verifyInputSignature(t, raw, 1, prevScript)
+
+	again, err := BuildSignedTransaction(inputs, outputs, map[string][]byte{testMainnetP2PKH: testKey()}, NetworkMainnet)
+	require.NoError(t, err)
+	assert.Equal(t, TxID(raw), TxID(again), "RFC 6979 signing is deterministic")
+}
+
+func TestBuildSignedTransaction_Rejects(t *testing.T) {
+	t.Parallel()
+
+	keys := map[string][]byte{testMainnetP2PKH: testKey()}
+	pay := []TxOutput{{Address: testMainnetP2PKH, Amount: 1000}}
+
+	_, err := BuildSignedTransaction([]chain.UTXO{testUTXO("aa", 500)}, pay, keys, NetworkMainnet)
+	require.ErrorIs(t, err, ErrInsufficientFunds, "outputs exceed inputs")
+
+	_, err = BuildSignedTransaction([]chain.UTXO{testUTXO("aa", 5000)}, pay, map[string][]byte{}, NetworkMainnet)
+	require.ErrorIs(t, err, ErrMissingKey)
+
+	wrongKey := testKey()
+	wrongKey[31] = 2
+	_, err = BuildSignedTransaction([]chain.UTXO{testUTXO("aa", 5000)}, pay, map[string][]byte{testMainnetP2PKH: wrongKey}, NetworkMainnet)
+	require.ErrorIs(t, err, ErrMissingKey, "key must match the input address")
+
+	_, err = BuildSignedTransaction([]chain.UTXO{testUTXO("aa", 5000)}, []TxOutput{{Address: testMainnetP2PKH, Amount: 100}}, keys, NetworkMainnet)
+	require.Error(t, err, "dust output")
+
+	segwitInput := testUTXO("aa", 5000)
+	segwitInput.Address = testMainnetP2WPKH
+	_, err = BuildSignedTransaction([]chain.UTXO{segwitInput}, pay, keys, NetworkMainnet)
+	require.ErrorIs(t, err, ErrInvalidAddress, "only P2PKH inputs are signed")
+}
+
+func TestSelectUTXOs(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+	utxos := []chain.UTXO{testUTXO("aa", 10000), testUTXO("bb", 90000), testUTXO("cc", 40000)}
+
+	selected, change, err := client.SelectUTXOs(utxos, 50000, 1000)
+	require.NoError(t, err)
+	require.Len(t, selected, 1)
+	assert.Equal(t, uint64(90000), selected[0].Amount, "largest first")
+	assert.Equal(t, 90000-50000-EstimateFeeForTx(1, 2, 1000), change)
+
+	_, _, err = client.SelectUTXOs(utxos, 200000, 1000)
+	require.ErrorIs(t, err, ErrInsufficientFunds)
+}
+
+func TestCalculateSweepAmount(t *testing.T) {
+	t.Parallel()
+
+	amount, err := CalculateSweepAmount(100000, 2, 2000)
+	require.NoError(t, err)
+	assert.Equal(t, 100000-EstimateFeeForTx(2, 1, 2000), amount)
+
+	_, err = CalculateSweepAmount(300, 1, 2000)
+	require.ErrorIs(t, err, ErrSweepInsufficientFunds)
+}
+
+func TestSend(t *testing.T) {
+	t.Parallel()
+
+	var broadcast []byte
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/address/" + testMainnetP2PKH + "/utxo":
+			_, _ = w.Write([]byte(`[{"txid":"` + strings.Repeat("aa", 32) + `","vout":0,"value":100000,"status":{"confirmed":false}}]`))
+		case "/v1/fees/recommended":
+			_, _ = w.Write([]byte(`{"fastestFee":3,"halfHourFee":2,"hourFee":1,"economyFee":1,"minimumFee":1}`))
+		case "/tx":
+			body, _ := io.ReadAll(r.Body)
+			broadcast, _ = hex.DecodeString(string(body))
+			_, _ = w.Write([]byte(TxID(broadcast)))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	key := testKey()
+	result, err := client.Send(context.Background(), chain.SendRequest{
+		From:          testMainnetP2PKH,
+		To:            testMainnetP2SH,
+		Amount:        big.NewInt(30000),
+		PrivateKey:    key,
+		ChangeAddress: testMainnetP2PKH,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, TxID(broadcast), result.Hash)
+	assert.Equal(t, "0.00030000", result.Amount)
+	assert.Equal(t, make([]byte, 32), key, "signing key is zeroed")
+
+	fee := EstimateFeeForTx(1, 2, 2000)
+	assert.Equal(t, client.FormatAmount(chain.AmountToBigInt(fee)), result.Fee)
+	require.Len(t, result.Spent, 1)
+	require.Len(t, result.Created, 2)
+	assert.Equal(t, 100000-30000-fee, result.Created[1].Amount)
+	assert.Equal(t, uint32(1), result.Created[1].Vout)
+}
+
+func TestSend_SweepAll(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(TxID(mustDecodeHex(t, string(body)))))
+	})
+
+	utxos := []chain.UTXO{testUTXO("aa", 40000), testUTXO("bb", 60000)}
+	utxos[1].Vout = 3
+	result, err := client.Send(context.Background(), chain.SendRequest{
+		To:          testMainnetP2WPKH,
+		SweepAll:    true,
+		FeeRate:     1000,
+		UTXOs:       utxos,
+		PrivateKeys: map[string][]byte{testMainnetP2PKH: testKey()},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Created, 1)
+	assert.Equal(t, 100000-EstimateFeeForTx(2, 1, 1000), result.Created[0].Amount)
+	assert.Len(t, result.Spent, 2)
+}
+
+func TestSend_BeforeBroadcast(t *testing.T) {
+	t.Parallel()
+
+	var broadcasts atomic.Int32
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		broadcasts.Add(1)
+		body, _ := io.ReadAll(r.Body)
+		_, _ = w.Write([]byte(TxID(mustDecodeHex(t, string(body)))))
+	})
+
+	send := func(hook func(*chain.SignedTx) error) (*chain.TransactionResult, error) {
+		return client.Send(context.Background(), chain.SendRequest{
+			To:              testMainnetP2WPKH,
+			SweepAll:        true,
+			FeeRate:         1000,
+			UTXOs:           []chain.UTXO{testUTXO("aa", 40000)},
+			PrivateKeys:     map[string][]byte{testMainnetP2PKH: testKey()},
+			BeforeBroadcast: hook,
+		})
+	}
+
+	var signed *chain.SignedTx
+	result, err := send(func(tx *chain.SignedTx) error {
+		signed = tx
+		return nil
+	})
+	require.NoError(t, err)
+	require.NotNil(t, signed)
+	assert.Equal(t, result.Hash, signed.Hash)
+	assert.Equal(t, EstimateFeeForTx(1, 1, 1000), signed.Fee)
+	require.Len(t, signed.Spent, 1)
+	assert.Equal(t, result.Created, signed.Created)
+
+	hookErr := errors.New("disk full")
+	_, err = send(func(*chain.SignedTx) error { return hookErr })
+	require.ErrorIs(t, err, hookErr)
+	assert.Equal(t, int32(1), broadcasts.Load(), "a failing hook stops the broadcast")
+}
+
+func TestSend_Validation(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewClient(nil)
+	require.NoError(t, err)
+
+	_, err = client.Send(context.Background(), chain.SendRequest{To: testTestnetP2PKH, Amount: big.NewInt(1000)})
+	require.ErrorIs(t, err, ErrInvalidAddress)
+
+	_, err = client.Send(context.Background(), chain.SendRequest{
+		To:     testMainnetP2PKH,
+		Amount: big.NewInt(100),
+		UTXOs:  []chain.UTXO{testUTXO("aa", 5000)},
+	})
+	require.ErrorIs(t, err, ErrInvalidAmount, "below dust")
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return b
+}
+
+func TestVerifySweep(t *testing.T) {
+	t.Parallel()
+
+	utxos := []chain.UTXO{testUTXO("aa", 40000), testUTXO("bb", 60000)}
+	fee := EstimateFeeForTx(2, 1, 1000)
+	outputs := []TxOutput{{Address: testMainnetP2WPKH, Amount: 100000 - fee}}
+	claimed := chain.SweepTotals{Inputs: 100000, Outputs: 100000 - fee, Fee: fee}
+	require.NoError(t, verifySweep(utxos, outputs, testMainnetP2WPKH, 1000, NetworkMainnet, claimed))
+
+	unsigned, err := BuildUnsignedTransaction(utxos, outputs, NetworkMainnet)
+	require.NoError(t, err)
+	for _, in := range parseRawTx(t, unsigned).inputs {
+		assert.Empty(t, in.script)
+	}
+
+	// A sweep that pays someone else, or disagrees with the amount it
+	// claims, is refused before signing
+	err = verifySweep(utxos, []TxOutput{{Address: testMainnetP2PKH, Amount: 100000 - fee}}, testMainnetP2WPKH, 1000, NetworkMainnet, claimed)
+	require.ErrorIs(t, err, chain.ErrSweepMismatch)
+	err = verifySweep(utxos, outputs, testMainnetP2WPKH, 1000, NetworkMainnet, chain.SweepTotals{Inputs: 100000, Outputs: 100000 - fee + 1, Fee: fee - 1})
+	require.ErrorIs(t, err, chain.ErrSweepMismatch)
+}
@@ -139,12 +139,6 @@ func (id ID) DustLimit() uint64 {
 	}
 }
 
-// ParseChainID parses a string into a ChainID.
-func ParseChainID(s string) (ID, bool) {
-	id := ID(s)
-	return id, id.IsValid()
-}
-
 // Identifier provides chain identification.
 type Identifier interface {
 	// ID returns the chain identifier.
@@ -236,27 +230,65 @@ type SendRequest struct {
 	Token         string   // ERC-20 token address (ETH only, empty for native)
 	GasLimit      uint64   // Optional gas limit override (ETH only)
 	GasPrice      *big.Int // Optional gas price override (ETH only)
+	Nonce         *uint64  // Optional nonce override (ETH only)
 	FeeRate       uint64   // Optional fee rate override (satoshis per kilobyte)
 	ChangeAddress string   // Optional change address (BSV only, defaults to From)
 	SweepAll      bool     // When true, send maximum amount minus fees (no change output)
 
+	// Payments are further recipients paid by the same transaction, after
+	// To and Amount (BSV only). With SweepAll they split the sweep: Amount
+	// and the payments must add up to every input less the fee.
+	Payments []Payment
+
 	// Multi-address fields (BSV only). When UTXOs is non-nil, Client.Send
 	// uses these pre-fetched UTXOs instead of fetching for a single address.
 	UTXOs       []UTXO            // Pre-fetched UTXOs from multiple addresses
 	PrivateKeys map[string][]byte // Address → private key map for per-input signing
+
+	// BeforeBroadcast, when set, is called with the signed transaction just
+	// before it is broadcast. An error aborts the send without broadcasting.
+	BeforeBroadcast func(tx *SignedTx) error
+}
+
+// Payment is an additional recipient of a multi-recipient send.
+type Payment struct {
+	To     string   // Recipient address
+	Amount *big.Int // Value in smallest units
+}
+
+// SignedTx is a signed transaction that has not been broadcast yet.
+type SignedTx struct {
+	Hash    string // Transaction id
+	Raw     []byte // Serialized transaction
+	Spent   []UTXO // Inputs the transaction spends (UTXO chains only)
+	Created []UTXO // Outputs the transaction creates, in output order (UTXO chains only)
+	Fee     uint64 // Fee in satoshis (UTXO chains only)
+	Nonce   uint64 // Account nonce (ETH only)
 }
 
 // TransactionResult contains the outcome of a broadcast transaction.
 type TransactionResult struct {
-	Hash     string `json:"hash"`                // Transaction hash
-	From     string `json:"from"`                // Sender address
-	To       string `json:"to"`                  // Recipient address
-	Amount   string `json:"amount"`              // Transferred amount (human-readable)
-	Token    string `json:"token,omitempty"`     // Token symbol if applicable
-	Fee      string `json:"fee"`                 // Fee paid (human-readable)
-	GasUsed  uint64 `json:"gas_used"`            // ETH-specific gas consumption
-	GasPrice string `json:"gas_price,omitempty"` // ETH-specific gas price
-	Status   string `json:"status"`              // "pending" after broadcast
+	Hash   string `json:"hash"`            // Transaction hash
+	From   string `json:"from"`            // Sender address
+	To     string `json:"to"`              // Recipient address
+	Amount string `json:"amount"`          // Transferred amount (human-readable)
+	Token  string `json:"token,omitempty"` // Token symbol if applicable
+	// Delivered is the token amount the recipient received when it may differ
+	// from Amount (fee-on-transfer or rebasing tokens).
+	Delivered string `json:"delivered,omitempty"`
+	Fee       string `json:"fee"`                 // Fee paid (human-readable)
+	GasUsed   uint64 `json:"gas_used"`            // ETH-specific gas consumption
+	GasPrice  string `json:"gas_price,omitempty"` // ETH-specific gas price
+	Status    string `json:"status"`              // "pending" after broadcast
+
+	// CoinSelection is the strategy that chose the inputs of a UTXO-based
+	// send, empty for sweeps and account chains.
+	CoinSelection string `json:"coin_selection,omitempty"`
+
+	// Spent and Created list the outputs consumed and produced by a UTXO-based
+	// transaction, with Created in vout order. Both are empty for account chains.
+	Spent   []UTXO `json:"-"`
+	Created []UTXO `json:"-"`
 }
 
 // UTXO represents an unspent transaction output.
@@ -139,7 +139,15 @@ func TestParseChainID(t *testing.T) {
 		{"ltc", "ltc", LTC, true},
 		{"invalid", "foo", ID("foo"), false},
 		{"empty", "", ID(""), false},
-		{"uppercase", "ETH", ID("ETH"), false},
+		{"uppercase", "ETH", ETH, true},
+		{"padded", " bsv ", BSV, true},
+		{"alias", "ethereum", ETH, true},
+		{"alias mixed case", "Bitcoin-SV", BSV, true},
+		{"network alias", "bsv-main", BSV, true},
+		{"mainnet qualified", "eth:mainnet", ETH, true},
+		{"main qualified alias", "bitcoin-sv:main", BSV, true},
+		{"testnet qualified", "eth:sepolia", ID("eth:sepolia"), false},
+		{"unknown network", "bsv:regtest", ID("bsv:regtest"), false},
 	}
 
 	for _, tt := range tests {
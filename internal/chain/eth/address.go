@@ -6,9 +6,15 @@ import (
 
 	"golang.org/x/crypto/sha3"
 
+	"github.com/mrz1836/sigil/internal/chain"
 	sigilerrors "github.com/mrz1836/sigil/pkg/errors"
 )
 
+//nolint:gochecknoinits // Registers the ETH validator behind chain.ValidateAddress
+func init() {
+	chain.RegisterAddressValidator(chain.ETH, validateChainAddress)
+}
+
 // IsValidAddress checks if the address is a valid Ethereum address format.
 // This validates the format (40 hex chars with 0x prefix) but does not validate checksum.
 func IsValidAddress(address string) bool {
@@ -89,6 +95,43 @@ func ValidateChecksumAddress(address string) error {
 	return nil
 }
 
+// RequireChecksumAddress validates that an Ethereum address is written in its
+// EIP-55 checksummed form. Unlike ValidateChecksumAddress, all-lowercase and
+// all-uppercase addresses are rejected, since they carry no checksum and a
+// typo in them cannot be detected.
+func RequireChecksumAddress(address string) error {
+	if !IsValidAddress(address) {
+		return sigilerrors.WithDetails(sigilerrors.ErrInvalidAddress, map[string]string{
+			"address": address,
+		})
+	}
+
+	expected := ToChecksumAddress(address)
+	if address != expected {
+		return sigilerrors.WithDetails(sigilerrors.ErrInvalidChecksum, map[string]string{
+			"expected": expected,
+			"actual":   address,
+		})
+	}
+
+	return nil
+}
+
+// NormalizeRecipient validates a destination address and returns it in EIP-55
+// form. By default the input must already be checksummed; allowNonChecksum
+// additionally accepts single-case addresses. A mixed-case address with a
+// wrong checksum is always rejected.
+func NormalizeRecipient(address string, allowNonChecksum bool) (string, error) {
+	check := RequireChecksumAddress
+	if allowNonChecksum {
+		check = ValidateChecksumAddress
+	}
+	if err := check(address); err != nil {
+		return "", err
+	}
+	return ToChecksumAddress(address), nil
+}
+
 // NormalizeAddress validates and converts an address to EIP-55 checksum format.
 // Returns an error if the address is invalid.
 func NormalizeAddress(address string) (string, error) {
@@ -100,6 +143,18 @@ func NormalizeAddress(address string) (string, error) {
 	return ToChecksumAddress(address), nil
 }
 
+// validateChainAddress is the chain.AddressValidatorFunc for ETH. Addresses
+// are the same on every network, and single-case addresses carry no checksum.
+func validateChainAddress(address, network string) error {
+	if !IsValidAddress(address) {
+		return chain.NewAddressError(chain.ETH, network, address, chain.AddressReasonFormat, ErrInvalidAddress)
+	}
+	if err := ValidateChecksumAddress(address); err != nil {
+		return chain.NewAddressError(chain.ETH, network, address, chain.AddressReasonChecksum, err)
+	}
+	return nil
+}
+
 // isHexChar returns true if c is a valid hexadecimal character.
 func isHexChar(c rune) bool {
 	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
@@ -5,6 +5,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerrors "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // Test vectors from EIP-55: https://eips.ethereum.org/EIPS/eip-55
@@ -195,6 +198,54 @@ func TestValidateChecksumAddress_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "checksum")
 }
 
+func TestRequireChecksumAddress(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		address string
+		wantErr error
+	}{
+		{"valid checksum", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", nil},
+		{"all lowercase", "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", sigilerrors.ErrInvalidChecksum},
+		{"all uppercase", "0x5AAEB6053F3E94C9B9A09F33669435E7EF1BEAED", sigilerrors.ErrInvalidChecksum},
+		{"wrong case", "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", sigilerrors.ErrInvalidChecksum},
+		{"digits only", "0x1234567890123456789012345678901234567890", nil},
+		{"malformed", "0x1234", sigilerrors.ErrInvalidAddress},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := RequireChecksumAddress(tc.address)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeRecipient(t *testing.T) {
+	t.Parallel()
+
+	const checksummed = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+
+	got, err := NormalizeRecipient(checksummed, false)
+	require.NoError(t, err)
+	assert.Equal(t, checksummed, got)
+
+	_, err = NormalizeRecipient("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", false)
+	require.ErrorIs(t, err, sigilerrors.ErrInvalidChecksum)
+
+	got, err = NormalizeRecipient("0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", true)
+	require.NoError(t, err)
+	assert.Equal(t, checksummed, got)
+
+	_, err = NormalizeRecipient("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", true)
+	require.ErrorIs(t, err, sigilerrors.ErrInvalidChecksum)
+}
+
 func TestIsValidAddress(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -285,3 +336,33 @@ func TestZeroAddress(t *testing.T) {
 	err := ValidateChecksumAddress(zeroAddr)
 	assert.NoError(t, err)
 }
+
+func TestValidateChainAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		network string
+		address string
+		reason  chain.AddressReason // empty when valid
+	}{
+		{"checksummed", chain.Mainnet, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", ""},
+		{"lowercase", chain.Sepolia, "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", ""},
+		{"bad checksum", chain.Mainnet, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", chain.AddressReasonChecksum},
+		{"too short", chain.Mainnet, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeA", chain.AddressReasonFormat},
+		{"bitcoin address", chain.Mainnet, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH", chain.AddressReasonFormat},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := chain.ValidateAddressOnNetwork(chain.ETH, tc.network, tc.address)
+			if tc.reason == "" {
+				require.NoError(t, err)
+				return
+			}
+			var addrErr *chain.AddressError
+			require.ErrorAs(t, err, &addrErr)
+			assert.Equal(t, tc.reason, addrErr.Reason)
+		})
+	}
+}
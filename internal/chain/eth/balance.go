@@ -41,9 +41,15 @@ func (c *Client) GetNativeBalance(ctx context.Context, address string) (*Balance
 	return bal, nil
 }
 
-// GetUSDCBalance retrieves the USDC balance.
+// GetUSDCBalance retrieves the USDC balance on the active network (see UseChain).
 func (c *Client) GetUSDCBalance(ctx context.Context, address string) (*Balance, error) {
-	amount, err := c.GetTokenBalance(ctx, address, USDCMainnet)
+	usdc, _ := LookupKnownToken("USDC")
+	return c.GetERC20Balance(ctx, address, usdc)
+}
+
+// GetERC20Balance retrieves the balance of an ERC-20 token.
+func (c *Client) GetERC20Balance(ctx context.Context, address string, token *TokenMetadata) (*Balance, error) {
+	amount, err := c.GetTokenBalance(ctx, address, token.Address)
 	if err != nil {
 		return nil, err
 	}
@@ -51,9 +57,9 @@ func (c *Client) GetUSDCBalance(ctx context.Context, address string) (*Balance,
 	return &Balance{
 		Address:  address,
 		Amount:   amount,
-		Symbol:   "USDC",
-		Decimals: USDCDecimals,
-		Token:    USDCMainnet,
+		Symbol:   token.Symbol,
+		Decimals: token.Decimals,
+		Token:    token.Address,
 	}, nil
 }
 
@@ -22,6 +22,9 @@ const (
 	// USDCMainnet is the USDC contract address on Ethereum mainnet.
 	USDCMainnet = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
 
+	// USDCSepolia is Circle's USDC contract address on the Sepolia testnet.
+	USDCSepolia = "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238"
+
 	// USDCDecimals is the number of decimals for USDC.
 	USDCDecimals = 6
 
@@ -87,6 +90,15 @@ type ClientOptions struct {
 	BroadcastFallback Broadcaster
 	// GasPriceOracle is an optional external gas price oracle (e.g. Etherscan gas tracker).
 	GasPriceOracle GasPriceOracle
+	// GasMarginPercent is the safety margin added to eth_estimateGas results.
+	// Zero keeps the default of 20%.
+	GasMarginPercent int
+	// NonceStore persists the nonces of broadcast transactions so that rapid
+	// sends from separate processes do not reuse a nonce.
+	NonceStore *NonceStore
+	// GasTable persists the last-known-good gas prices, used when every RPC
+	// fails to return a gas price.
+	GasTable *GasTableStore
 }
 
 // Compile-time interface checks
@@ -104,9 +116,12 @@ type Client struct {
 	fallbackRPCs      []string
 	broadcastFallback Broadcaster
 	gasPriceOracle    GasPriceOracle
+	gasMargin         float64
 	mu                sync.Mutex
 	initErr           error
 	nonceManager      *NonceManager
+	nonceStore        *NonceStore
+	gasTable          *GasTableStore
 }
 
 // NewClient creates a new ETH client.
@@ -138,6 +153,15 @@ func NewClient(rpcURL string, opts *ClientOptions) (*Client, error) {
 	if opts.GasPriceOracle != nil {
 		c.gasPriceOracle = opts.GasPriceOracle
 	}
+	if opts.GasMarginPercent > 0 {
+		c.gasMargin = 1 + float64(opts.GasMarginPercent)/100
+	}
+	if opts.NonceStore != nil {
+		c.nonceStore = opts.NonceStore
+	}
+	if opts.GasTable != nil {
+		c.gasTable = opts.GasTable
+	}
 
 	return c, nil
 }
@@ -241,8 +265,7 @@ func (c *Client) EstimateFee(ctx context.Context, from, to string, amount *big.I
 		gasLimit = defaultGasLimit
 	} else {
 		// Apply safety buffer to the estimate
-		buffered := multiplyBigInt(new(big.Int).SetUint64(gasLimit), gasEstimateBuffer)
-		gasLimit = buffered.Uint64()
+		gasLimit = c.bufferGas(gasLimit)
 	}
 
 	// Fee = gasPrice * gasLimit
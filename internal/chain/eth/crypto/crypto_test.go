@@ -108,6 +108,34 @@ func TestSign(t *testing.T) {
 	assert.True(t, sig[64] == 0 || sig[64] == 1)
 }
 
+func TestRecoverAddress(t *testing.T) {
+	t.Parallel()
+
+	privKey, err := hex.DecodeString("4c0883a69102937d6231471b5dbb6204fe5129617082792ae468d01a3f362318")
+	require.NoError(t, err)
+	hash := Keccak256([]byte("hello"))
+
+	sig, err := Sign(hash, privKey)
+	require.NoError(t, err)
+
+	addr, err := RecoverAddress(hash, sig)
+	require.NoError(t, err)
+	assert.Equal(t, "2c7536e3605d9c16a7a3d7b1898e529396a65c23", hex.EncodeToString(addr))
+
+	// The other recovery ID yields a different key
+	flipped := append([]byte(nil), sig...)
+	flipped[64] ^= 1
+	other, err := RecoverAddress(hash, flipped)
+	if err == nil {
+		assert.NotEqual(t, addr, other)
+	}
+
+	_, err = RecoverAddress(hash, sig[:64])
+	require.ErrorIs(t, err, ErrInvalidSignature)
+	_, err = RecoverAddress([]byte{1}, sig)
+	require.ErrorIs(t, err, ErrInvalidHashLength)
+}
+
 func TestSign_InvalidHash(t *testing.T) {
 	t.Parallel()
 
@@ -114,3 +114,25 @@ func DeriveAddress(privateKey []byte) ([]byte, error) {
 	}
 	return PublicKeyToAddress(pubKey)
 }
+
+// RecoverAddress returns the address whose key made the 65-byte [R || S || V]
+// signature of hash, where V is the recovery ID (0 or 1).
+func RecoverAddress(hash, sig []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, ErrInvalidHashLength
+	}
+	if len(sig) != 65 || sig[64] > 1 {
+		return nil, ErrInvalidSignature
+	}
+
+	// RecoverCompact takes the Bitcoin [V || R || S] format with V = 27 + recovery ID
+	compact := make([]byte, 65)
+	compact[0] = 27 + sig[64]
+	copy(compact[1:], sig[:64])
+
+	pubKey, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+	return PublicKeyToAddress(pubKey.SerializeUncompressed())
+}
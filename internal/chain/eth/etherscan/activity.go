@@ -0,0 +1,276 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/metrics"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// ErrInvalidActivity indicates an account activity response could not be parsed.
+var ErrInvalidActivity = &sigilerr.SigilError{
+	Code:     "ETHERSCAN_INVALID_ACTIVITY",
+	Message:  "invalid account activity in Etherscan response",
+	ExitCode: sigilerr.ExitGeneral,
+}
+
+// AddressActivity summarizes the on-chain history of an ETH address.
+type AddressActivity struct {
+	Address string
+	// Nonce is the number of transactions sent from the address.
+	Nonce uint64
+	// FirstSeen and LastSeen are the timestamps of the oldest and newest
+	// normal transactions touching the address, sent or received.
+	// Both are zero when the address has no transactions.
+	FirstSeen time.Time
+	LastSeen  time.Time
+	// LastBlock is the block of the newest transaction, or zero when the
+	// address has no transactions.
+	LastBlock uint64
+}
+
+// HasActivity reports whether the address has ever sent or received a transaction.
+func (a *AddressActivity) HasActivity() bool {
+	return a.Nonce > 0 || !a.FirstSeen.IsZero()
+}
+
+// txListResponse is the Etherscan response for the account txlist action.
+// Unlike apiResponse, the Result field is an array on success.
+type txListResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// txListEntry holds the fields of a txlist entry that sigil uses.
+type txListEntry struct {
+	BlockNumber string `json:"blockNumber"`
+	TimeStamp   string `json:"timeStamp"`
+	Hash        string `json:"hash"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Value       string `json:"value"`
+	GasUsed     string `json:"gasUsed"`
+	GasPrice    string `json:"gasPrice"`
+	IsError     string `json:"isError"`
+}
+
+// edgeTx is the parsed block and time of a txlist entry.
+type edgeTx struct {
+	block uint64
+	time  time.Time
+}
+
+// GetAddressActivity retrieves the nonce and first/last transaction timestamps
+// for an address. A full sync issues three requests: the nonce via the proxy
+// module and the oldest and newest entries of the account's transaction list.
+//
+// With a sync state store, later calls only ask for transactions after the
+// recorded block; an address with nothing new costs a single request, since
+// its nonce can only change by sending a transaction.
+func (c *Client) GetAddressActivity(ctx context.Context, address string) (*AddressActivity, error) {
+	if c.syncState == nil {
+		return c.fullActivity(ctx, address)
+	}
+
+	var (
+		activity *AddressActivity
+		err      error
+	)
+	if cursor, ok := c.syncState.Lookup(c.chainID, address); ok {
+		activity, err = c.deltaActivity(ctx, address, cursor)
+	} else {
+		activity, err = c.fullActivity(ctx, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// A lost cursor only costs a full sync next time, so write errors are dropped
+	_ = c.syncState.Record(c.chainID, address, &SyncCursor{
+		LastBlock: activity.LastBlock,
+		Nonce:     activity.Nonce,
+		FirstSeen: activity.FirstSeen,
+		LastSeen:  activity.LastSeen,
+		SyncedAt:  time.Now().UTC(),
+	})
+	return activity, nil
+}
+
+// fullActivity fetches the nonce and both ends of the transaction list.
+func (c *Client) fullActivity(ctx context.Context, address string) (*AddressActivity, error) {
+	nonce, err := c.GetTransactionCount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := c.edgeTx(ctx, address, "asc", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := &AddressActivity{Address: address, Nonce: nonce}
+	if first == nil {
+		return activity, nil
+	}
+	activity.FirstSeen = first.time
+
+	last, err := c.edgeTx(ctx, address, "desc", 0)
+	if err != nil {
+		return nil, err
+	}
+	if last != nil {
+		activity.LastSeen, activity.LastBlock = last.time, last.block
+	}
+	return activity, nil
+}
+
+// deltaActivity updates a cursor with transactions after its last block.
+func (c *Client) deltaActivity(ctx context.Context, address string, cursor *SyncCursor) (*AddressActivity, error) {
+	startBlock := cursor.LastBlock
+	if startBlock > 0 {
+		startBlock++
+	}
+
+	newest, err := c.edgeTx(ctx, address, "desc", startBlock)
+	if err != nil {
+		return nil, err
+	}
+	if newest == nil {
+		return cursor.activity(address), nil
+	}
+
+	nonce, err := c.GetTransactionCount(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	activity := cursor.activity(address)
+	activity.Nonce = nonce
+	activity.LastSeen, activity.LastBlock = newest.time, newest.block
+	if activity.FirstSeen.IsZero() {
+		// First transactions ever: the oldest one is in the new range
+		first, firstErr := c.edgeTx(ctx, address, "asc", startBlock)
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		if first != nil {
+			activity.FirstSeen = first.time
+		}
+	}
+	return activity, nil
+}
+
+// GetTransactionCount returns the number of transactions sent from an address
+// (its nonce at the latest block) via the Etherscan proxy API.
+func (c *Client) GetTransactionCount(ctx context.Context, address string) (uint64, error) {
+	start := time.Now()
+
+	params := url.Values{
+		"module":  {"proxy"},
+		"action":  {"eth_getTransactionCount"},
+		"address": {address},
+		"tag":     {"latest"},
+	}
+
+	body, err := c.get(ctx, params)
+	metrics.Global.RecordRPCCall("eth", time.Since(start), err)
+	if err != nil {
+		return 0, err
+	}
+
+	var proxyResp proxyResponse
+	if err := json.Unmarshal(body, &proxyResp); err != nil {
+		return 0, fmt.Errorf("parsing proxy response: %w", err)
+	}
+	if proxyResp.Error != nil {
+		return 0, sigilerr.WithDetails(ErrAPIError, map[string]string{
+			"code":    fmt.Sprintf("%d", proxyResp.Error.Code),
+			"message": proxyResp.Error.Message,
+		})
+	}
+
+	count, ok := new(big.Int).SetString(strings.TrimPrefix(proxyResp.Result, "0x"), 16)
+	if !ok || !count.IsUint64() {
+		return 0, sigilerr.WithDetails(ErrInvalidActivity, map[string]string{
+			"result": truncateBody(proxyResp.Result, 64),
+		})
+	}
+	return count.Uint64(), nil
+}
+
+// edgeTx returns the oldest (sort "asc") or newest (sort "desc") normal
+// transaction for an address at or after startBlock, or nil if there is none.
+func (c *Client) edgeTx(ctx context.Context, address, sort string, startBlock uint64) (*edgeTx, error) {
+	entries, err := c.txList(ctx, address, sort, startBlock, 1)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	secs, err := strconv.ParseInt(entries[0].TimeStamp, 10, 64)
+	if err != nil {
+		return nil, sigilerr.WithDetails(ErrInvalidActivity, map[string]string{
+			"timeStamp": entries[0].TimeStamp,
+		})
+	}
+	// A missing block number leaves the cursor at zero, which only means
+	// the next sync starts from the beginning.
+	block, _ := strconv.ParseUint(entries[0].BlockNumber, 10, 64)
+	return &edgeTx{block: block, time: time.Unix(secs, 0).UTC()}, nil
+}
+
+// txList fetches up to limit normal transactions for an address at or after
+// startBlock in the given sort order. An address without history yields none.
+func (c *Client) txList(ctx context.Context, address, sort string, startBlock uint64, limit int) ([]txListEntry, error) {
+	start := time.Now()
+
+	params := url.Values{
+		"module":     {"account"},
+		"action":     {"txlist"},
+		"address":    {address},
+		"startblock": {strconv.FormatUint(startBlock, 10)},
+		"endblock":   {"99999999"},
+		"page":       {"1"},
+		"offset":     {strconv.Itoa(limit)},
+		"sort":       {sort},
+	}
+
+	body, err := c.get(ctx, params)
+	metrics.Global.RecordRPCCall("eth", time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp txListResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+
+	var entries []txListEntry
+	if resp.Status != "1" {
+		// An address without history is reported as status "0" with an empty list.
+		if resp.Message == "No transactions found" {
+			return nil, nil
+		}
+		var result string
+		_ = json.Unmarshal(resp.Result, &result)
+		if result == "Max rate limit reached" {
+			return nil, c.rateLimited()
+		}
+		return nil, sigilerr.WithDetails(ErrAPIError, map[string]string{
+			"message": resp.Message,
+			"result":  truncateBody(result, 256),
+		})
+	}
+	if err := json.Unmarshal(resp.Result, &entries); err != nil {
+		return nil, fmt.Errorf("parsing transaction list: %w", err)
+	}
+	return entries, nil
+}
@@ -0,0 +1,145 @@
+package etherscan
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testActivityAddress = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+
+// newActivityServer serves canned proxy and txlist responses keyed by action and sort order.
+func newActivityServer(t *testing.T, nonceBody, ascBody, descBody string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, testActivityAddress, q.Get("address"))
+		w.Header().Set("Content-Type", "application/json")
+
+		switch q.Get("action") {
+		case "eth_getTransactionCount":
+			assert.Equal(t, "proxy", q.Get("module"))
+			_, _ = w.Write([]byte(nonceBody))
+		case "txlist":
+			assert.Equal(t, "1", q.Get("offset"))
+			if q.Get("sort") == "asc" {
+				_, _ = w.Write([]byte(ascBody))
+			} else {
+				_, _ = w.Write([]byte(descBody))
+			}
+		default:
+			t.Errorf("unexpected action %q", q.Get("action"))
+		}
+	}))
+}
+
+func TestGetAddressActivity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("address with history", func(t *testing.T) {
+		t.Parallel()
+		server := newActivityServer(t,
+			`{"jsonrpc":"2.0","id":1,"result":"0x2a"}`,
+			`{"status":"1","message":"OK","result":[{"timeStamp":"1700000000"}]}`,
+			`{"status":"1","message":"OK","result":[{"timeStamp":"1710000000"}]}`,
+		)
+		defer server.Close()
+
+		client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+		require.NoError(t, err)
+
+		activity, err := client.GetAddressActivity(context.Background(), testActivityAddress)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(42), activity.Nonce)
+		assert.Equal(t, time.Unix(1700000000, 0).UTC(), activity.FirstSeen)
+		assert.Equal(t, time.Unix(1710000000, 0).UTC(), activity.LastSeen)
+		assert.True(t, activity.HasActivity())
+	})
+
+	t.Run("receive-only address has zero nonce but is active", func(t *testing.T) {
+		t.Parallel()
+		server := newActivityServer(t,
+			`{"jsonrpc":"2.0","id":1,"result":"0x0"}`,
+			`{"status":"1","message":"OK","result":[{"timeStamp":"1700000000"}]}`,
+			`{"status":"1","message":"OK","result":[{"timeStamp":"1700000000"}]}`,
+		)
+		defer server.Close()
+
+		client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+		require.NoError(t, err)
+
+		activity, err := client.GetAddressActivity(context.Background(), testActivityAddress)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(0), activity.Nonce)
+		assert.True(t, activity.HasActivity())
+	})
+
+	t.Run("fresh address", func(t *testing.T) {
+		t.Parallel()
+		server := newActivityServer(t,
+			`{"jsonrpc":"2.0","id":1,"result":"0x0"}`,
+			`{"status":"0","message":"No transactions found","result":[]}`,
+			`{"status":"0","message":"No transactions found","result":[]}`,
+		)
+		defer server.Close()
+
+		client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+		require.NoError(t, err)
+
+		activity, err := client.GetAddressActivity(context.Background(), testActivityAddress)
+		require.NoError(t, err)
+		assert.True(t, activity.FirstSeen.IsZero())
+		assert.True(t, activity.LastSeen.IsZero())
+		assert.False(t, activity.HasActivity())
+	})
+
+	t.Run("proxy error", func(t *testing.T) {
+		t.Parallel()
+		server := newActivityServer(t,
+			`{"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"invalid address"}}`,
+			"", "",
+		)
+		defer server.Close()
+
+		client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+		require.NoError(t, err)
+
+		_, err = client.GetAddressActivity(context.Background(), testActivityAddress)
+		require.ErrorIs(t, err, ErrAPIError)
+		assert.Contains(t, err.Error(), "invalid address")
+	})
+
+	t.Run("txlist rate limited", func(t *testing.T) {
+		t.Parallel()
+		server := newActivityServer(t,
+			`{"jsonrpc":"2.0","id":1,"result":"0x1"}`,
+			`{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`,
+			"",
+		)
+		defer server.Close()
+
+		client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+		require.NoError(t, err)
+
+		_, err = client.GetAddressActivity(context.Background(), testActivityAddress)
+		require.ErrorIs(t, err, ErrRateLimited)
+	})
+
+	t.Run("malformed nonce", func(t *testing.T) {
+		t.Parallel()
+		server := newActivityServer(t, `{"jsonrpc":"2.0","id":1,"result":"0xzz"}`, "", "")
+		defer server.Close()
+
+		client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+		require.NoError(t, err)
+
+		_, err = client.GetAddressActivity(context.Background(), testActivityAddress)
+		require.ErrorIs(t, err, ErrInvalidActivity)
+	})
+}
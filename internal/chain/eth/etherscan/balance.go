@@ -81,16 +81,23 @@ func (c *Client) GetTokenBalance(ctx context.Context, address, tokenAddress stri
 	}, nil
 }
 
-// GetUSDCBalance retrieves the USDC balance for an address.
+// GetUSDCBalance retrieves the USDC balance for an address on the active
+// network (see eth.UseChain).
 func (c *Client) GetUSDCBalance(ctx context.Context, address string) (*eth.Balance, error) {
-	balance, err := c.GetTokenBalance(ctx, address, eth.USDCMainnet)
+	usdc, _ := eth.LookupKnownToken("USDC")
+	return c.GetERC20Balance(ctx, address, usdc)
+}
+
+// GetERC20Balance retrieves the balance of an ERC-20 token for an address.
+func (c *Client) GetERC20Balance(ctx context.Context, address string, token *eth.TokenMetadata) (*eth.Balance, error) {
+	balance, err := c.GetTokenBalance(ctx, address, token.Address)
 	if err != nil {
 		return nil, err
 	}
 
-	balance.Symbol = "USDC"
-	balance.Decimals = eth.USDCDecimals
-	balance.Token = eth.USDCMainnet
+	balance.Symbol = token.Symbol
+	balance.Decimals = token.Decimals
+	balance.Token = token.Address
 
 	return balance, nil
 }
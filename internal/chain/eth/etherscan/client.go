@@ -11,7 +11,11 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/mrz1836/sigil/internal/apiusage"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/faultinject"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	"github.com/mrz1836/sigil/internal/reqsign"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
@@ -67,6 +71,7 @@ type Client struct {
 	chainID     string
 	httpClient  *http.Client
 	rateLimiter *chain.RateLimiter
+	syncState   *SyncStateStore
 }
 
 // ClientOptions configures the Etherscan client.
@@ -77,6 +82,9 @@ type ClientOptions struct {
 	HTTPClient *http.Client
 	// ChainID overrides the default chain ID (default "1" for Ethereum mainnet).
 	ChainID string
+	// SyncState persists per-address history cursors so that repeated
+	// activity lookups only fetch new transactions.
+	SyncState *SyncStateStore
 }
 
 // NewClient creates a new Etherscan API client.
@@ -91,9 +99,9 @@ func NewClient(apiKey string, opts *ClientOptions) (*Client, error) {
 		chainID: DefaultChainID,
 		httpClient: &http.Client{
 			Timeout: httpTimeout,
-			Transport: &http.Transport{
+			Transport: reqlog.Wrap(faultinject.Wrap(apiusage.Wrap(reqsign.Wrap(&http.Transport{
 				TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12},
-			},
+			}), apiusage.ProviderEtherscan, apiKey)), apiusage.ProviderEtherscan),
 		},
 		rateLimiter: chain.NewRateLimiter(5, 5), // 5 req/s, burst of 5 (Etherscan free tier)
 	}
@@ -108,6 +116,7 @@ func NewClient(apiKey string, opts *ClientOptions) (*Client, error) {
 		if opts.ChainID != "" {
 			c.chainID = opts.ChainID
 		}
+		c.syncState = opts.SyncState
 	}
 
 	return c, nil
@@ -115,9 +124,38 @@ func NewClient(apiKey string, opts *ClientOptions) (*Client, error) {
 
 // doRequest performs an HTTP GET request to the Etherscan API and returns the result string.
 func (c *Client) doRequest(ctx context.Context, params url.Values) (string, error) {
+	body, err := c.get(ctx, params)
+	if err != nil {
+		return "", err
+	}
+
+	var apiResp apiResponse
+	if err := json.Unmarshal(body, &apiResp); err != nil {
+		return "", fmt.Errorf("parsing response: %w", err)
+	}
+
+	if apiResp.Status != "1" {
+		// Etherscan returns status "0" for errors.
+		if apiResp.Result == "Max rate limit reached" {
+			return "", c.rateLimited()
+		}
+		return "", sigilerr.WithDetails(ErrAPIError, map[string]string{
+			"message": apiResp.Message,
+			"result":  truncateBody(apiResp.Result, 256),
+		})
+	}
+
+	return apiResp.Result, nil
+}
+
+// get performs a rate-limited HTTP GET request to the Etherscan API and
+// returns the raw response body. HTTP-level errors are mapped to sentinel
+// errors; parsing the body is left to the caller since its shape varies by
+// module.
+func (c *Client) get(ctx context.Context, params url.Values) ([]byte, error) {
 	// Rate limit
 	if err := c.rateLimiter.Wait(ctx, "etherscan"); err != nil {
-		return "", fmt.Errorf("rate limiter: %w", err)
+		return nil, fmt.Errorf("rate limiter: %w", err)
 	}
 
 	// Etherscan v2 API requires chainid on every request
@@ -127,7 +165,7 @@ func (c *Client) doRequest(ctx context.Context, params url.Values) (string, erro
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
 	if err != nil {
-		return "", fmt.Errorf("creating request: %w", err)
+		return nil, fmt.Errorf("creating request: %w", err)
 	}
 
 	// Send API key in header rather than URL query parameters to avoid
@@ -136,46 +174,37 @@ func (c *Client) doRequest(ctx context.Context, params url.Values) (string, erro
 
 	resp, err := c.httpClient.Do(httpReq)
 	if err != nil {
-		return "", fmt.Errorf("sending request: %w", err)
+		return nil, fmt.Errorf("sending request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
 	if err != nil {
-		return "", fmt.Errorf("reading response: %w", err)
+		return nil, fmt.Errorf("reading response: %w", err)
 	}
 
 	// Handle HTTP-level rate limiting
 	if resp.StatusCode == http.StatusTooManyRequests {
-		return "", sigilerr.WithDetails(ErrRateLimited, map[string]string{
+		return nil, sigilerr.WithDetails(ErrRateLimited, map[string]string{
 			"status": fmt.Sprintf("%d", resp.StatusCode),
 		})
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", sigilerr.WithDetails(ErrAPIError, map[string]string{
+		return nil, sigilerr.WithDetails(ErrAPIError, map[string]string{
 			"status": fmt.Sprintf("%d", resp.StatusCode),
 			"body":   truncateBody(string(body), 512),
 		})
 	}
 
-	var apiResp apiResponse
-	if err := json.Unmarshal(body, &apiResp); err != nil {
-		return "", fmt.Errorf("parsing response: %w", err)
-	}
-
-	if apiResp.Status != "1" {
-		// Etherscan returns status "0" for errors.
-		if apiResp.Result == "Max rate limit reached" {
-			return "", ErrRateLimited
-		}
-		return "", sigilerr.WithDetails(ErrAPIError, map[string]string{
-			"message": apiResp.Message,
-			"result":  truncateBody(apiResp.Result, 256),
-		})
-	}
+	return body, nil
+}
 
-	return apiResp.Result, nil
+// rateLimited records a rate-limit refusal that Etherscan reported in the
+// response body, which the transport cannot see, and returns ErrRateLimited.
+func (c *Client) rateLimited() error {
+	apiusage.RecordLimited(apiusage.ProviderEtherscan, c.apiKey)
+	return ErrRateLimited
 }
 
 // truncateBody truncates a string to maxLen characters.
@@ -90,6 +90,9 @@ func (c *Client) GetGasOracle(ctx context.Context) (*GasOracleResult, error) {
 	}
 
 	if err := validateGasOracleStatus(&apiResp); err != nil {
+		if errors.Is(err, ErrRateLimited) {
+			return nil, c.rateLimited()
+		}
 		return nil, err
 	}
 
@@ -0,0 +1,78 @@
+package etherscan
+
+import (
+	"context"
+	"math/big"
+	"strconv"
+	"time"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// MaxTransactions is the most transactions GetTransactions returns, the
+// largest page Etherscan serves for the txlist action.
+const MaxTransactions = 1000
+
+// Transaction is a normal (native ETH) transaction touching an address.
+type Transaction struct {
+	Hash        string
+	From        string
+	To          string
+	Value       *big.Int // wei
+	Fee         *big.Int // gas used × gas price, in wei
+	BlockNumber uint64
+	Time        time.Time
+	// Failed is set when the transaction was mined but reverted.
+	Failed bool
+}
+
+// GetTransactions returns the newest normal transactions sent or received by
+// an address, newest first, at most MaxTransactions of them.
+func (c *Client) GetTransactions(ctx context.Context, address string) ([]Transaction, error) {
+	entries, err := c.txList(ctx, address, "desc", 0, MaxTransactions)
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]Transaction, 0, len(entries))
+	for i := range entries {
+		tx, err := parseTransaction(&entries[i])
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return txs, nil
+}
+
+// parseTransaction converts a txlist entry to a Transaction.
+func parseTransaction(e *txListEntry) (Transaction, error) {
+	secs, err := strconv.ParseInt(e.TimeStamp, 10, 64)
+	if err != nil || e.Hash == "" {
+		return Transaction{}, sigilerr.WithDetails(ErrInvalidActivity, map[string]string{
+			"hash":      e.Hash,
+			"timeStamp": e.TimeStamp,
+		})
+	}
+	block, _ := strconv.ParseUint(e.BlockNumber, 10, 64)
+
+	return Transaction{
+		Hash:        e.Hash,
+		From:        e.From,
+		To:          e.To,
+		Value:       parseWei(e.Value),
+		Fee:         new(big.Int).Mul(parseWei(e.GasUsed), parseWei(e.GasPrice)),
+		BlockNumber: block,
+		Time:        time.Unix(secs, 0).UTC(),
+		Failed:      e.IsError == "1",
+	}, nil
+}
+
+// parseWei parses a base-10 integer, treating malformed values as zero.
+func parseWei(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		return new(big.Int)
+	}
+	return n
+}
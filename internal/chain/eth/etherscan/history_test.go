@@ -0,0 +1,61 @@
+package etherscan
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransactions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses transactions", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			q := r.URL.Query()
+			assert.Equal(t, "txlist", q.Get("action"))
+			assert.Equal(t, "desc", q.Get("sort"))
+			assert.Equal(t, "1000", q.Get("offset"))
+			_, _ = w.Write([]byte(`{"status":"1","message":"OK","result":[
+				{"blockNumber":"200","timeStamp":"1710000000","hash":"0xbb","from":"0xaaa","to":"0xbbb","value":"1000","gasUsed":"21000","gasPrice":"2","isError":"1"},
+				{"blockNumber":"100","timeStamp":"1700000000","hash":"0xaa","from":"0xccc","to":"0xaaa","value":"5","gasUsed":"21000","gasPrice":"1","isError":"0"}
+			]}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+		require.NoError(t, err)
+
+		txs, err := client.GetTransactions(context.Background(), testActivityAddress)
+		require.NoError(t, err)
+		require.Len(t, txs, 2)
+		assert.Equal(t, "0xbb", txs[0].Hash)
+		assert.Equal(t, big.NewInt(1000), txs[0].Value)
+		assert.Equal(t, big.NewInt(42000), txs[0].Fee)
+		assert.Equal(t, uint64(200), txs[0].BlockNumber)
+		assert.Equal(t, time.Unix(1710000000, 0).UTC(), txs[0].Time)
+		assert.True(t, txs[0].Failed)
+		assert.False(t, txs[1].Failed)
+	})
+
+	t.Run("address without history", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			_, _ = w.Write([]byte(`{"status":"0","message":"No transactions found","result":[]}`))
+		}))
+		defer server.Close()
+
+		client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+		require.NoError(t, err)
+
+		txs, err := client.GetTransactions(context.Background(), testActivityAddress)
+		require.NoError(t, err)
+		assert.Empty(t, txs)
+	})
+}
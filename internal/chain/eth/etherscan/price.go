@@ -0,0 +1,89 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/metrics"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// ErrInvalidPrice indicates the ethprice response could not be parsed.
+var ErrInvalidPrice = &sigilerr.SigilError{
+	Code:     "ETHERSCAN_INVALID_PRICE",
+	Message:  "invalid ETH price in Etherscan response",
+	ExitCode: sigilerr.ExitGeneral,
+}
+
+// ethPriceResponse is the Etherscan response for the stats ethprice action.
+type ethPriceResponse struct {
+	Status  string          `json:"status"`
+	Message string          `json:"message"`
+	Result  json.RawMessage `json:"result"`
+}
+
+// ethPriceResult holds the fields of the ethprice result that sigil uses.
+type ethPriceResult struct {
+	ETHUSD          string `json:"ethusd"`
+	ETHUSDTimestamp string `json:"ethusd_timestamp"`
+}
+
+// ETHPrice is the ETH spot price reported by Etherscan.
+type ETHPrice struct {
+	USD  float64   // US dollars per 1 ETH
+	Time time.Time // When Etherscan captured the price
+}
+
+// GetETHPrice fetches the last ETH/USD price from the Etherscan stats API.
+func (c *Client) GetETHPrice(ctx context.Context) (*ETHPrice, error) {
+	start := time.Now()
+
+	params := url.Values{
+		"module": {"stats"},
+		"action": {"ethprice"},
+	}
+
+	body, err := c.get(ctx, params)
+	metrics.Global.RecordRPCCall("eth", time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp ethPriceResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("parsing response: %w", err)
+	}
+	if resp.Status != "1" {
+		var result string
+		_ = json.Unmarshal(resp.Result, &result)
+		if result == "Max rate limit reached" {
+			return nil, c.rateLimited()
+		}
+		return nil, sigilerr.WithDetails(ErrAPIError, map[string]string{
+			"message": resp.Message,
+			"result":  truncateBody(result, 256),
+		})
+	}
+
+	var result ethPriceResult
+	if err := json.Unmarshal(resp.Result, &result); err != nil {
+		return nil, fmt.Errorf("parsing ETH price: %w", err)
+	}
+
+	usd, err := strconv.ParseFloat(result.ETHUSD, 64)
+	if err != nil || usd <= 0 {
+		return nil, sigilerr.WithDetails(ErrInvalidPrice, map[string]string{
+			"ethusd": result.ETHUSD,
+		})
+	}
+
+	price := &ETHPrice{USD: usd}
+	if secs, err := strconv.ParseInt(result.ETHUSDTimestamp, 10, 64); err == nil && secs > 0 {
+		price.Time = time.Unix(secs, 0).UTC()
+	}
+	return price, nil
+}
@@ -0,0 +1,83 @@
+package etherscan
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetETHPrice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		body     string
+		wantUSD  float64
+		wantTime time.Time
+		wantErr  error
+	}{
+		{
+			name:     "price with timestamp",
+			body:     `{"status":"1","message":"OK","result":{"ethbtc":"0.0321","ethbtc_timestamp":"1760000000","ethusd":"3456.78","ethusd_timestamp":"1760000010"}}`,
+			wantUSD:  3456.78,
+			wantTime: time.Unix(1760000010, 0).UTC(),
+		},
+		{
+			name:    "price without timestamp",
+			body:    `{"status":"1","message":"OK","result":{"ethusd":"2000"}}`,
+			wantUSD: 2000,
+		},
+		{
+			name:    "unparseable price",
+			body:    `{"status":"1","message":"OK","result":{"ethusd":"n/a","ethusd_timestamp":"1760000010"}}`,
+			wantErr: ErrInvalidPrice,
+		},
+		{
+			name:    "zero price",
+			body:    `{"status":"1","message":"OK","result":{"ethusd":"0"}}`,
+			wantErr: ErrInvalidPrice,
+		},
+		{
+			name:    "rate limited",
+			body:    `{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`,
+			wantErr: ErrRateLimited,
+		},
+		{
+			name:    "api error",
+			body:    `{"status":"0","message":"NOTOK","result":"Invalid API Key"}`,
+			wantErr: ErrAPIError,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "stats", r.URL.Query().Get("module"))
+				assert.Equal(t, "ethprice", r.URL.Query().Get("action"))
+				assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL})
+			require.NoError(t, err)
+
+			price, err := client.GetETHPrice(context.Background())
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.InDelta(t, tc.wantUSD, price.USD, 0.0001)
+			assert.Equal(t, tc.wantTime, price.Time)
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package etherscan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// syncStateFilePermissions is the permission mode for the sync state file.
+	syncStateFilePermissions = 0o600
+
+	// syncStateDirPermissions is the permission mode for the sync state directory.
+	syncStateDirPermissions = 0o700
+)
+
+// ErrCorruptSyncState indicates the sync state file is malformed JSON.
+var ErrCorruptSyncState = errors.New("etherscan sync state file is corrupted")
+
+// SyncCursor records how far an address's transaction history has been
+// processed, so the next sync only asks Etherscan for newer blocks.
+type SyncCursor struct {
+	// LastBlock is the block of the newest transaction seen, or zero when
+	// the address had no transactions.
+	LastBlock uint64    `json:"last_block"`
+	Nonce     uint64    `json:"nonce"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	SyncedAt  time.Time `json:"synced_at"`
+}
+
+// activity rebuilds the address activity the cursor was recorded from.
+func (c *SyncCursor) activity(address string) *AddressActivity {
+	return &AddressActivity{
+		Address:   address,
+		Nonce:     c.Nonce,
+		FirstSeen: c.FirstSeen,
+		LastSeen:  c.LastSeen,
+		LastBlock: c.LastBlock,
+	}
+}
+
+// SyncState holds the sync cursor of each address, keyed by chain ID and
+// lowercase address.
+type SyncState struct {
+	Cursors map[string]SyncCursor `json:"cursors"`
+}
+
+// SyncStateStore persists per-address sync cursors to disk. Losing the file
+// only costs one full sync per address.
+type SyncStateStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewSyncStateStore creates a sync state store backed by the file at path.
+func NewSyncStateStore(path string) *SyncStateStore {
+	return &SyncStateStore{path: path}
+}
+
+// Path returns the sync state file path.
+func (s *SyncStateStore) Path() string {
+	return s.path
+}
+
+// Load reads the sync state. Returns an empty state if the file doesn't exist.
+func (s *SyncStateStore) Load() (*SyncState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// Lookup returns the cursor for an address on a chain.
+func (s *SyncStateStore) Lookup(chainID, address string) (*SyncCursor, bool) {
+	state, err := s.Load()
+	if err != nil {
+		return nil, false
+	}
+
+	cursor, ok := state.Cursors[syncKey(chainID, address)]
+	if !ok {
+		return nil, false
+	}
+	return &cursor, true
+}
+
+// Record stores the cursor for an address on a chain.
+func (s *SyncStateStore) Record(chainID, address string, cursor *SyncCursor) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state, err := s.load()
+	if err != nil && !errors.Is(err, ErrCorruptSyncState) {
+		return err
+	}
+	state.Cursors[syncKey(chainID, address)] = *cursor
+
+	if err := os.MkdirAll(filepath.Dir(s.path), syncStateDirPermissions); err != nil {
+		return fmt.Errorf("creating sync state directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling sync state: %w", err)
+	}
+
+	if err := fileutil.WriteAtomic(s.path, data, syncStateFilePermissions); err != nil {
+		return fmt.Errorf("writing sync state: %w", err)
+	}
+	return nil
+}
+
+// load reads the sync state without locking. A corrupt file yields an empty
+// state together with ErrCorruptSyncState.
+func (s *SyncStateStore) load() (*SyncState, error) {
+	state := &SyncState{Cursors: make(map[string]SyncCursor)}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("reading sync state: %w", err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return &SyncState{Cursors: make(map[string]SyncCursor)}, fmt.Errorf("%w: %w", ErrCorruptSyncState, err)
+	}
+	if state.Cursors == nil {
+		state.Cursors = make(map[string]SyncCursor)
+	}
+	return state, nil
+}
+
+// syncKey builds the cursor map key. Addresses are lowercased so checksummed
+// and plain forms share a cursor.
+func syncKey(chainID, address string) string {
+	return chainID + ":" + strings.ToLower(address)
+}
@@ -0,0 +1,237 @@
+package etherscan
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncStateStore_RecordLookup(t *testing.T) {
+	t.Parallel()
+
+	store := NewSyncStateStore(filepath.Join(t.TempDir(), "cache", "eth_sync.json"))
+
+	_, ok := store.Lookup("1", testActivityAddress)
+	assert.False(t, ok)
+
+	cursor := &SyncCursor{LastBlock: 19000000, Nonce: 3, FirstSeen: time.Unix(1700000000, 0).UTC(), LastSeen: time.Unix(1710000000, 0).UTC()}
+	require.NoError(t, store.Record("1", testActivityAddress, cursor))
+
+	got, ok := store.Lookup("1", strings.ToLower(testActivityAddress))
+	require.True(t, ok, "lookups ignore address case")
+	assert.Equal(t, *cursor, *got)
+
+	_, ok = store.Lookup("11155111", testActivityAddress)
+	assert.False(t, ok, "cursors are per chain")
+
+	info, err := os.Stat(store.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestSyncStateStore_Corrupt(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "eth_sync.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+	store := NewSyncStateStore(path)
+
+	_, err := store.Load()
+	require.ErrorIs(t, err, ErrCorruptSyncState)
+	_, ok := store.Lookup("1", testActivityAddress)
+	assert.False(t, ok)
+
+	// Recording replaces the corrupt file
+	require.NoError(t, store.Record("1", testActivityAddress, &SyncCursor{LastBlock: 5}))
+	got, ok := store.Lookup("1", testActivityAddress)
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), got.LastBlock)
+}
+
+// historyTx is a transaction served by historyServer.
+type historyTx struct {
+	block uint64
+	time  int64
+}
+
+// historyServer serves txlist and nonce responses from an in-memory history,
+// honoring startblock and sort, and counts requests by action.
+type historyServer struct {
+	mu    sync.Mutex
+	txs   []historyTx // ascending by block
+	nonce uint64
+	calls map[string]int
+	start []string
+}
+
+func newHistoryServer(t *testing.T, h *historyServer) *httptest.Server {
+	t.Helper()
+
+	h.calls = make(map[string]int)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		q := r.URL.Query()
+		h.calls[q.Get("action")]++
+		w.Header().Set("Content-Type", "application/json")
+
+		if q.Get("action") == "eth_getTransactionCount" {
+			_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":1,"result":"0x%x"}`, h.nonce)
+			return
+		}
+
+		h.start = append(h.start, q.Get("startblock"))
+		startBlock, _ := strconv.ParseUint(q.Get("startblock"), 10, 64)
+		var match []historyTx
+		for _, tx := range h.txs {
+			if tx.block >= startBlock {
+				match = append(match, tx)
+			}
+		}
+		if len(match) == 0 {
+			_, _ = w.Write([]byte(`{"status":"0","message":"No transactions found","result":[]}`))
+			return
+		}
+		pick := match[0]
+		if q.Get("sort") == "desc" {
+			pick = match[len(match)-1]
+		}
+		entry, _ := json.Marshal([]txListEntry{{BlockNumber: strconv.FormatUint(pick.block, 10), TimeStamp: strconv.FormatInt(pick.time, 10)}})
+		_, _ = fmt.Fprintf(w, `{"status":"1","message":"OK","result":%s}`, entry)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func (h *historyServer) add(tx historyTx, sent bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.txs = append(h.txs, tx)
+	if sent {
+		h.nonce++
+	}
+}
+
+func (h *historyServer) reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.calls = make(map[string]int)
+	h.start = nil
+}
+
+func TestGetAddressActivity_DeltaSync(t *testing.T) {
+	t.Parallel()
+
+	h := &historyServer{txs: []historyTx{{block: 100, time: 1700000000}, {block: 200, time: 1710000000}}, nonce: 1}
+	server := newHistoryServer(t, h)
+	store := NewSyncStateStore(filepath.Join(t.TempDir(), "eth_sync.json"))
+
+	client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL, SyncState: store})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	// First sync is a full sync and records the cursor
+	activity, err := client.GetAddressActivity(ctx, testActivityAddress)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), activity.Nonce)
+	assert.Equal(t, uint64(200), activity.LastBlock)
+	assert.Equal(t, 1, h.calls["eth_getTransactionCount"])
+	assert.Equal(t, 2, h.calls["txlist"])
+
+	cursor, ok := store.Lookup(DefaultChainID, testActivityAddress)
+	require.True(t, ok)
+	assert.Equal(t, uint64(200), cursor.LastBlock)
+
+	// Nothing new: one txlist request from the next block, no nonce lookup
+	h.reset()
+	activity, err = client.GetAddressActivity(ctx, testActivityAddress)
+	require.NoError(t, err)
+	assert.Equal(t, 0, h.calls["eth_getTransactionCount"])
+	assert.Equal(t, 1, h.calls["txlist"])
+	assert.Equal(t, []string{"201"}, h.start)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), activity.FirstSeen)
+	assert.Equal(t, time.Unix(1710000000, 0).UTC(), activity.LastSeen)
+	assert.Equal(t, uint64(1), activity.Nonce)
+
+	// A new outgoing transaction moves the cursor and refreshes the nonce
+	h.add(historyTx{block: 300, time: 1720000000}, true)
+	h.reset()
+	activity, err = client.GetAddressActivity(ctx, testActivityAddress)
+	require.NoError(t, err)
+	assert.Equal(t, 1, h.calls["eth_getTransactionCount"])
+	assert.Equal(t, 1, h.calls["txlist"])
+	assert.Equal(t, uint64(2), activity.Nonce)
+	assert.Equal(t, uint64(300), activity.LastBlock)
+	assert.Equal(t, time.Unix(1700000000, 0).UTC(), activity.FirstSeen, "first seen is kept from the cursor")
+	assert.Equal(t, time.Unix(1720000000, 0).UTC(), activity.LastSeen)
+}
+
+func TestGetAddressActivity_DeltaSyncFreshAddress(t *testing.T) {
+	t.Parallel()
+
+	h := &historyServer{}
+	server := newHistoryServer(t, h)
+	store := NewSyncStateStore(filepath.Join(t.TempDir(), "eth_sync.json"))
+
+	client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL, SyncState: store})
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	activity, err := client.GetAddressActivity(ctx, testActivityAddress)
+	require.NoError(t, err)
+	assert.False(t, activity.HasActivity())
+
+	// Still unused: a single request
+	h.reset()
+	_, err = client.GetAddressActivity(ctx, testActivityAddress)
+	require.NoError(t, err)
+	assert.Equal(t, 0, h.calls["eth_getTransactionCount"])
+	assert.Equal(t, 1, h.calls["txlist"])
+	assert.Equal(t, []string{"0"}, h.start)
+
+	// First deposit arrives: first and last seen are both filled in
+	h.add(historyTx{block: 500, time: 1730000000}, false)
+	h.reset()
+	activity, err = client.GetAddressActivity(ctx, testActivityAddress)
+	require.NoError(t, err)
+	assert.True(t, activity.HasActivity())
+	assert.Equal(t, time.Unix(1730000000, 0).UTC(), activity.FirstSeen)
+	assert.Equal(t, time.Unix(1730000000, 0).UTC(), activity.LastSeen)
+	assert.Equal(t, uint64(0), activity.Nonce)
+}
+
+func TestGetAddressActivity_DeltaSyncErrorKeepsCursor(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"status":"0","message":"NOTOK","result":"Max rate limit reached"}`))
+	}))
+	defer server.Close()
+
+	store := NewSyncStateStore(filepath.Join(t.TempDir(), "eth_sync.json"))
+	require.NoError(t, store.Record(DefaultChainID, testActivityAddress, &SyncCursor{LastBlock: 42, Nonce: 7}))
+
+	client, err := NewClient("test-key", &ClientOptions{BaseURL: server.URL, SyncState: store})
+	require.NoError(t, err)
+
+	_, err = client.GetAddressActivity(context.Background(), testActivityAddress)
+	require.ErrorIs(t, err, ErrRateLimited)
+
+	cursor, ok := store.Lookup(DefaultChainID, testActivityAddress)
+	require.True(t, ok)
+	assert.Equal(t, uint64(42), cursor.LastBlock)
+	assert.Equal(t, uint64(7), cursor.Nonce)
+}
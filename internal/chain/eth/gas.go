@@ -7,8 +7,10 @@ import (
 	"math/big"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	"github.com/mrz1836/sigil/internal/metrics"
 	sigilerrors "github.com/mrz1836/sigil/pkg/errors"
 )
 
@@ -44,7 +46,8 @@ const (
 	slowMultiplier = 0.8
 	// fastMultiplier increases gas price by 20% for fast transactions.
 	fastMultiplier = 1.2
-	// gasEstimateBuffer adds a 20% safety margin to eth_estimateGas results.
+	// gasEstimateBuffer adds the default 20% safety margin to eth_estimateGas
+	// results; ClientOptions.GasMarginPercent overrides it.
 	gasEstimateBuffer = 1.2
 )
 
@@ -74,13 +77,22 @@ type GasEstimate struct {
 
 // GasPrices contains gas prices for different speeds.
 type GasPrices struct {
-	Slow   *big.Int
-	Medium *big.Int
-	Fast   *big.Int
+	Slow   *big.Int `json:"slow"`
+	Medium *big.Int `json:"medium"`
+	Fast   *big.Int `json:"fast"`
+
+	// Source names where the prices came from (GasSourceOracle, GasSourceRPC
+	// or GasSourceCached).
+	Source string `json:"source,omitempty"`
+
+	// Timestamp is when the prices were fetched from a live source.
+	Timestamp time.Time `json:"timestamp,omitempty"`
 }
 
 // GetGasPrices fetches current gas prices for all speed levels.
 // Uses a layered strategy: Etherscan gas oracle → multi-RPC median → minimum floor.
+// Live prices are recorded in the gas table, whose last-known-good entry is
+// returned when every RPC fails.
 func (c *Client) GetGasPrices(ctx context.Context) (*GasPrices, error) {
 	if err := c.connect(ctx); err != nil {
 		return nil, err
@@ -90,9 +102,10 @@ func (c *Client) GetGasPrices(ctx context.Context) (*GasPrices, error) {
 	if c.gasPriceOracle != nil {
 		slow, medium, fast, err := c.gasPriceOracle.GetGasPrices(ctx)
 		if err == nil {
-			return c.applyGasPriceFloor(&GasPrices{
+			return c.recordGasPrices(c.applyGasPriceFloor(&GasPrices{
 				Slow: slow, Medium: medium, Fast: fast,
-			}), nil
+				Source: GasSourceOracle, Timestamp: time.Now().UTC(),
+			})), nil
 		}
 		// Oracle failed — fall through to multi-RPC
 	}
@@ -100,6 +113,12 @@ func (c *Client) GetGasPrices(ctx context.Context) (*GasPrices, error) {
 	// Strategy 2: Multi-RPC median (query all configured RPCs, take median)
 	medianPrice, err := c.getMultiRPCMedianGasPrice(ctx)
 	if err != nil {
+		if c.gasTable != nil {
+			if cached, ok := c.gasTable.Lookup(c.chainID); ok {
+				cached.Source = GasSourceCached
+				return cached, nil
+			}
+		}
 		return nil, err
 	}
 
@@ -108,11 +127,22 @@ func (c *Client) GetGasPrices(ctx context.Context) (*GasPrices, error) {
 	fastPrice := multiplyBigInt(medianPrice, fastMultiplier)
 
 	// Strategy 3: Apply minimum floor as safety net
-	return c.applyGasPriceFloor(&GasPrices{
-		Slow:   slowPrice,
-		Medium: new(big.Int).Set(medianPrice),
-		Fast:   fastPrice,
-	}), nil
+	return c.recordGasPrices(c.applyGasPriceFloor(&GasPrices{
+		Slow:      slowPrice,
+		Medium:    new(big.Int).Set(medianPrice),
+		Fast:      fastPrice,
+		Source:    GasSourceRPC,
+		Timestamp: time.Now().UTC(),
+	})), nil
+}
+
+// recordGasPrices stores live prices in the gas table, if one is configured.
+// A failed write only loses the fallback, so it is ignored.
+func (c *Client) recordGasPrices(prices *GasPrices) *GasPrices {
+	if c.gasTable != nil {
+		_ = c.gasTable.Record(c.chainID, prices)
+	}
+	return prices
 }
 
 // getMultiRPCMedianGasPrice queries eth_gasPrice from the primary and all fallback RPCs
@@ -234,6 +264,8 @@ func (c *Client) GetGasPrice(ctx context.Context, speed GasSpeed) (*big.Int, err
 // EstimateGasForETHTransfer estimates gas for a native ETH transfer using eth_estimateGas.
 // Falls back to the standard 21000 gas limit if the RPC call fails.
 func (c *Client) EstimateGasForETHTransfer(ctx context.Context, from, to string, value *big.Int, speed GasSpeed) (*GasEstimate, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseFeeQuote)()
+
 	gasPrice, err := c.GetGasPrice(ctx, speed)
 	if err != nil {
 		return nil, err
@@ -262,6 +294,8 @@ func (c *Client) EstimateGasForETHTransfer(ctx context.Context, from, to string,
 // EstimateGasForERC20Transfer estimates gas for an ERC-20 token transfer using eth_estimateGas.
 // Falls back to the default 65000 gas limit if the RPC call fails.
 func (c *Client) EstimateGasForERC20Transfer(ctx context.Context, from, tokenContract string, data []byte, speed GasSpeed) (*GasEstimate, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseFeeQuote)()
+
 	gasPrice, err := c.GetGasPrice(ctx, speed)
 	if err != nil {
 		return nil, err
@@ -333,8 +367,16 @@ func (c *Client) estimateGasWithClient(ctx context.Context, msg rpc.CallMsg) (ui
 		return 0, fmt.Errorf("eth_estimateGas: %w", err)
 	}
 
-	buffered := multiplyBigInt(new(big.Int).SetUint64(gasLimit), gasEstimateBuffer)
-	return buffered.Uint64(), nil
+	return c.bufferGas(gasLimit), nil
+}
+
+// bufferGas adds the client's safety margin to a gas estimate.
+func (c *Client) bufferGas(gasLimit uint64) uint64 {
+	margin := c.gasMargin
+	if margin == 0 {
+		margin = gasEstimateBuffer
+	}
+	return multiplyBigInt(new(big.Int).SetUint64(gasLimit), margin).Uint64()
 }
 
 // FormatGasPrice formats a gas price in wei to a human-readable Gwei string.
@@ -366,6 +408,8 @@ func multiplyBigInt(n *big.Int, multiplier float64) *big.Int {
 // GetNonce gets the next nonce for an address.
 // Uses a local nonce manager to prevent nonce collisions during rapid sends.
 // The local nonce is compared with the RPC pending nonce; the higher value is used.
+// With a nonce store, nonces broadcast by earlier processes that the node does
+// not report yet are also skipped.
 func (c *Client) GetNonce(ctx context.Context, address string) (uint64, error) {
 	if err := c.connect(ctx); err != nil {
 		return 0, err
@@ -385,6 +429,15 @@ func (c *Client) GetNonce(ctx context.Context, address string) (uint64, error) {
 		return 0, fmt.Errorf("getting nonce: %w", err)
 	}
 
+	// Skip nonces recorded by earlier sends that the node has not seen yet.
+	// The store only refines the RPC nonce, so a store that cannot be read
+	// does not block the send.
+	if c.nonceStore != nil {
+		if next, storeErr := c.nonceStore.Reconcile(c.chainID, address, rpcNonce, time.Now()); storeErr == nil {
+			rpcNonce = next
+		}
+	}
+
 	// Use the nonce manager to get the next nonce, taking into account
 	// locally-tracked nonces from recent sends that may not yet be visible
 	// to the RPC node's mempool.
@@ -2,11 +2,13 @@ package eth
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -680,6 +682,36 @@ func TestGetNonce(t *testing.T) {
 		assert.Equal(t, uint64(5), nonce)
 	})
 
+	t.Run("skips nonces in the nonce store", func(t *testing.T) {
+		t.Parallel()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+			result := "0x1"
+			if req["method"] == "eth_getTransactionCount" {
+				result = "0x5"
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req["id"], "result": result}))
+		}))
+		defer server.Close()
+
+		address := "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+		store := NewNonceStore(filepath.Join(t.TempDir(), "nonces.json"))
+		require.NoError(t, store.Record(big.NewInt(1), address, PendingNonce{Nonce: 5, SentAt: time.Now()}))
+		require.NoError(t, store.Record(big.NewInt(1), address, PendingNonce{Nonce: 6, SentAt: time.Now()}))
+
+		client, err := NewClient(server.URL, &ClientOptions{NonceStore: store})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+
+		nonce, err := client.GetNonce(ctx, address)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), nonce, "a nonce broadcast by an earlier run is not reused")
+	})
+
 	t.Run("returns error for invalid address", func(t *testing.T) {
 		t.Parallel()
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1438,3 +1470,62 @@ func TestApplyGasPriceFloor(t *testing.T) {
 		assert.Equal(t, big.NewInt(2_000_000_000), result.Fast)   // unchanged (above floor)
 	})
 }
+
+func TestEstimateGasForERC20Transfer_CalldataAndMargin(t *testing.T) {
+	t.Parallel()
+
+	testFrom := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	testData, err := BuildERC20TransferData("0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", big.NewInt(123456789))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		margin   int
+		expected uint64
+	}{
+		{"default margin", 0, 66000},
+		{"custom margin", 50, 82500},
+		{"fee-on-transfer headroom", 100, 110000},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var call map[string]any
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+				resp := map[string]any{"jsonrpc": "2.0", "id": req["id"]}
+				switch req["method"].(string) {
+				case rpcMethodChainID:
+					resp["result"] = "0x1"
+				case rpcMethodGasPrice:
+					resp["result"] = "0x4a817c800"
+				case "eth_estimateGas":
+					call = req["params"].([]any)[0].(map[string]any)
+					resp["result"] = "0xd6d8" // 55000
+				}
+				assert.NoError(t, json.NewEncoder(w).Encode(resp))
+			}))
+			defer server.Close()
+
+			client, err := NewClient(server.URL, &ClientOptions{GasMarginPercent: tc.margin})
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			estimate, err := client.EstimateGasForERC20Transfer(ctx, testFrom, USDCMainnet, testData, GasSpeedMedium)
+			require.NoError(t, err)
+			// Floating-point multiplication may round down by 1
+			assert.InDelta(t, tc.expected, estimate.GasLimit, 1)
+
+			require.NotNil(t, call, "eth_estimateGas was called")
+			assert.Equal(t, testFrom, call["from"])
+			assert.Equal(t, USDCMainnet, call["to"])
+			assert.Equal(t, "0x"+hex.EncodeToString(testData), call["data"])
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+// Gas price sources reported in GasPrices.Source.
+const (
+	// GasSourceOracle is a live price from the external gas oracle.
+	GasSourceOracle = "oracle"
+
+	// GasSourceRPC is a live median of eth_gasPrice across the configured RPCs.
+	GasSourceRPC = "rpc"
+
+	// GasSourceCached is the last-known-good price from the local gas table,
+	// used when every RPC is unreachable.
+	GasSourceCached = "cached"
+)
+
+const (
+	// gasTableFilePermissions is the permission mode for the gas table file.
+	gasTableFilePermissions = 0o600
+
+	// gasTableDirPermissions is the permission mode for the gas table directory.
+	gasTableDirPermissions = 0o700
+)
+
+// ErrCorruptGasTable indicates the gas table file is malformed JSON.
+var ErrCorruptGasTable = errors.New("gas table file is corrupted")
+
+// GasTable holds the last-known-good gas prices for each chain ID.
+type GasTable struct {
+	Prices map[string]GasPrices `json:"prices"`
+}
+
+// GasTableStore persists the last-known-good gas prices to disk so that gas
+// lookups made while RPCs are unreachable fall back to a recent price.
+type GasTableStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewGasTableStore creates a gas table store backed by the file at path.
+func NewGasTableStore(path string) *GasTableStore {
+	return &GasTableStore{path: path}
+}
+
+// Path returns the gas table file path.
+func (s *GasTableStore) Path() string {
+	return s.path
+}
+
+// Load reads the gas table. Returns an empty table if the file doesn't exist.
+func (s *GasTableStore) Load() (*GasTable, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// Lookup returns the last-known-good prices for a chain ID.
+func (s *GasTableStore) Lookup(chainID *big.Int) (*GasPrices, bool) {
+	table, err := s.Load()
+	if err != nil {
+		return nil, false
+	}
+
+	prices, ok := table.Prices[gasTableKey(chainID)]
+	if !ok || prices.Slow == nil || prices.Medium == nil || prices.Fast == nil || prices.Medium.Sign() <= 0 {
+		return nil, false
+	}
+	return &prices, true
+}
+
+// Record stores live prices as the last-known-good prices for a chain ID.
+// Cached prices are ignored so a fallback never refreshes its own age.
+func (s *GasTableStore) Record(chainID *big.Int, prices *GasPrices) error {
+	if prices == nil || prices.Source == GasSourceCached {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.load()
+	if err != nil && !errors.Is(err, ErrCorruptGasTable) {
+		return err
+	}
+	table.Prices[gasTableKey(chainID)] = *prices
+
+	if err := os.MkdirAll(filepath.Dir(s.path), gasTableDirPermissions); err != nil {
+		return fmt.Errorf("creating gas table directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling gas table: %w", err)
+	}
+
+	if err := fileutil.WriteAtomic(s.path, data, gasTableFilePermissions); err != nil {
+		return fmt.Errorf("writing gas table: %w", err)
+	}
+	return nil
+}
+
+// load reads the gas table without locking. A corrupt file yields an empty
+// table together with ErrCorruptGasTable.
+func (s *GasTableStore) load() (*GasTable, error) {
+	table := &GasTable{Prices: make(map[string]GasPrices)}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return table, nil
+	}
+	if err != nil {
+		return table, fmt.Errorf("reading gas table: %w", err)
+	}
+
+	if err := json.Unmarshal(data, table); err != nil {
+		return &GasTable{Prices: make(map[string]GasPrices)}, fmt.Errorf("%w: %w", ErrCorruptGasTable, err)
+	}
+	if table.Prices == nil {
+		table.Prices = make(map[string]GasPrices)
+	}
+	return table, nil
+}
+
+// gasTableKey keys the gas table by decimal chain ID; "0" when unknown.
+func gasTableKey(chainID *big.Int) string {
+	if chainID == nil {
+		return "0"
+	}
+	return chainID.String()
+}
@@ -0,0 +1,118 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGasTableStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file is empty", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewGasTableStore(filepath.Join(t.TempDir(), "gas.json"))
+		table, err := s.Load()
+		require.NoError(t, err)
+		assert.Empty(t, table.Prices)
+
+		_, ok := s.Lookup(big.NewInt(1))
+		assert.False(t, ok)
+	})
+
+	t.Run("record and lookup per chain", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "cache", "gas.json")
+		s := NewGasTableStore(path)
+		fetched := time.Now().Add(-time.Hour).UTC()
+
+		require.NoError(t, s.Record(big.NewInt(1), &GasPrices{
+			Slow: big.NewInt(8), Medium: big.NewInt(10), Fast: big.NewInt(12),
+			Source: GasSourceOracle, Timestamp: fetched,
+		}))
+		require.NoError(t, s.Record(big.NewInt(11155111), &GasPrices{
+			Slow: big.NewInt(1), Medium: big.NewInt(2), Fast: big.NewInt(3), Source: GasSourceRPC,
+		}))
+
+		prices, ok := s.Lookup(big.NewInt(1))
+		require.True(t, ok)
+		assert.Equal(t, big.NewInt(10), prices.Medium)
+		assert.Equal(t, GasSourceOracle, prices.Source)
+		assert.True(t, prices.Timestamp.Equal(fetched))
+
+		prices, ok = s.Lookup(big.NewInt(11155111))
+		require.True(t, ok)
+		assert.Equal(t, big.NewInt(2), prices.Medium)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("cached prices are not recorded", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewGasTableStore(filepath.Join(t.TempDir(), "gas.json"))
+		require.NoError(t, s.Record(big.NewInt(1), &GasPrices{
+			Slow: big.NewInt(1), Medium: big.NewInt(1), Fast: big.NewInt(1), Source: GasSourceCached,
+		}))
+
+		_, ok := s.Lookup(big.NewInt(1))
+		assert.False(t, ok)
+	})
+
+	t.Run("corrupt file is replaced on record", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "gas.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+		s := NewGasTableStore(path)
+
+		_, err := s.Load()
+		require.ErrorIs(t, err, ErrCorruptGasTable)
+
+		require.NoError(t, s.Record(big.NewInt(1), &GasPrices{
+			Slow: big.NewInt(5), Medium: big.NewInt(5), Fast: big.NewInt(5), Source: GasSourceRPC,
+		}))
+		_, ok := s.Lookup(big.NewInt(1))
+		assert.True(t, ok)
+	})
+}
+
+func TestGetGasPrices_GasTable(t *testing.T) {
+	t.Parallel()
+
+	table := NewGasTableStore(filepath.Join(t.TempDir(), "gas.json"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// A live price is recorded
+	primary := newTestRPCServer(t, "0x1", "0x4a817c800") // 20 Gwei
+	defer primary.Close()
+	client, err := NewClient(primary.URL, &ClientOptions{GasTable: table})
+	require.NoError(t, err)
+
+	prices, err := client.GetGasPrices(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, GasSourceRPC, prices.Source)
+
+	// Every RPC failing falls back to the recorded price
+	failing := newTestRPCServerError(t)
+	defer failing.Close()
+	client, err = NewClient(failing.URL, &ClientOptions{GasTable: table})
+	require.NoError(t, err)
+
+	prices, err = client.GetGasPrices(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, GasSourceCached, prices.Source)
+	assert.Equal(t, big.NewInt(20_000_000_000), prices.Medium)
+	assert.False(t, prices.Timestamp.IsZero())
+}
@@ -41,6 +41,18 @@ func (nm *NonceManager) Next(address string, rpcNonce uint64) uint64 {
 	return nonce
 }
 
+// Release returns nonce to the pool when the transaction reserved with it
+// was never broadcast, so the next send does not leave a gap. It does nothing
+// once a later nonce has been reserved.
+func (nm *NonceManager) Release(address string, nonce uint64) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	if next, exists := nm.nonces[address]; exists && next == nonce+1 {
+		nm.nonces[address] = nonce
+	}
+}
+
 // Reset clears the local nonce tracking for an address.
 // Useful after errors or when nonce state is known to be stale.
 func (nm *NonceManager) Reset(address string) {
@@ -165,6 +165,22 @@ func TestNonceManager_Reset(t *testing.T) {
 	assert.Equal(t, uint64(6), nonce)
 }
 
+// TestNonceManager_Release verifies an unused reservation is handed out again.
+func TestNonceManager_Release(t *testing.T) {
+	t.Parallel()
+	nm := NewNonceManager()
+	addr := testAddress
+
+	nonce := nm.Next(addr, 3)
+	nm.Release(addr, nonce)
+	assert.Equal(t, uint64(3), nm.Next(addr, 3), "released nonce is reused")
+
+	first := nm.Next(addr, 3)
+	second := nm.Next(addr, 3)
+	nm.Release(addr, first)
+	assert.Equal(t, second+1, nm.Next(addr, 3), "an earlier nonce is not released once a later one is reserved")
+}
+
 // TestNonceManager_ResetDoesNotAffectOtherAddresses verifies reset isolation.
 func TestNonceManager_ResetDoesNotAffectOtherAddresses(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,195 @@
+package eth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// nonceStoreFilePermissions is the permission mode for the nonce store file.
+	nonceStoreFilePermissions = 0o600
+
+	// nonceStoreDirPermissions is the permission mode for the nonce store directory.
+	nonceStoreDirPermissions = 0o700
+
+	// PendingNonceTTL is how long a broadcast nonce the node does not report
+	// is still treated as in use. Past it the transaction is assumed dropped,
+	// so its nonce is reused rather than leaving a gap that stalls every later
+	// transaction from the address.
+	PendingNonceTTL = 10 * time.Minute
+)
+
+// ErrCorruptNonceStore indicates the nonce store file is malformed JSON.
+var ErrCorruptNonceStore = errors.New("nonce store file is corrupted")
+
+// PendingNonce is a nonce used by a broadcast transaction that may not be
+// mined yet.
+type PendingNonce struct {
+	Nonce  uint64    `json:"nonce"`
+	Hash   string    `json:"hash"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// NonceTable holds the pending nonces of each sender, keyed by chain ID and
+// lower-cased address ("1:0xabc...").
+type NonceTable struct {
+	Pending map[string][]PendingNonce `json:"pending"`
+}
+
+// NonceStore persists the nonces of broadcast transactions to disk so that a
+// send made right after another, even from a new process, does not reuse a
+// nonce the RPC node has not reported yet.
+type NonceStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewNonceStore creates a nonce store backed by the file at path.
+func NewNonceStore(path string) *NonceStore {
+	return &NonceStore{path: path}
+}
+
+// Path returns the nonce store file path.
+func (s *NonceStore) Path() string {
+	return s.path
+}
+
+// Pending returns the recorded pending nonces of address on the chain,
+// lowest first.
+func (s *NonceStore) Pending(chainID *big.Int, address string) ([]PendingNonce, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	return table.Pending[nonceKey(chainID, address)], nil
+}
+
+// Record stores the nonce of a broadcast transaction, replacing any entry
+// with the same nonce.
+func (s *NonceStore) Record(chainID *big.Int, address string, pending PendingNonce) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.load()
+	if err != nil && !errors.Is(err, ErrCorruptNonceStore) {
+		return err
+	}
+
+	key := nonceKey(chainID, address)
+	entries := make([]PendingNonce, 0, len(table.Pending[key])+1)
+	inserted := false
+	for _, p := range table.Pending[key] {
+		if p.Nonce == pending.Nonce {
+			continue
+		}
+		if !inserted && p.Nonce > pending.Nonce {
+			entries = append(entries, pending)
+			inserted = true
+		}
+		entries = append(entries, p)
+	}
+	if !inserted {
+		entries = append(entries, pending)
+	}
+	table.Pending[key] = entries
+
+	return s.save(table)
+}
+
+// Reconcile drops the pending nonces of address that the node already counts
+// (below confirmed, its eth_getTransactionCount) or that are older than
+// PendingNonceTTL, and returns the next nonce to use: one past the highest
+// remaining pending nonce, or confirmed when none remain.
+func (s *NonceStore) Reconcile(chainID *big.Int, address string, confirmed uint64, now time.Time) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.load()
+	if err != nil && !errors.Is(err, ErrCorruptNonceStore) {
+		return confirmed, err
+	}
+
+	key := nonceKey(chainID, address)
+	next := confirmed
+	var kept []PendingNonce
+	for _, p := range table.Pending[key] {
+		if p.Nonce < confirmed || now.Sub(p.SentAt) > PendingNonceTTL {
+			continue
+		}
+		kept = append(kept, p)
+		if p.Nonce+1 > next {
+			next = p.Nonce + 1
+		}
+	}
+
+	if err == nil && len(kept) == len(table.Pending[key]) {
+		return next, nil
+	}
+	if len(kept) == 0 {
+		delete(table.Pending, key)
+	} else {
+		table.Pending[key] = kept
+	}
+	return next, s.save(table)
+}
+
+// load reads the nonce table without locking. A corrupt file yields an empty
+// table together with ErrCorruptNonceStore.
+func (s *NonceStore) load() (*NonceTable, error) {
+	table := &NonceTable{Pending: make(map[string][]PendingNonce)}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return table, nil
+	}
+	if err != nil {
+		return table, fmt.Errorf("reading nonce store: %w", err)
+	}
+
+	if err := json.Unmarshal(data, table); err != nil {
+		return &NonceTable{Pending: make(map[string][]PendingNonce)}, fmt.Errorf("%w: %w", ErrCorruptNonceStore, err)
+	}
+	if table.Pending == nil {
+		table.Pending = make(map[string][]PendingNonce)
+	}
+	return table, nil
+}
+
+// save writes the nonce table without locking.
+func (s *NonceStore) save(table *NonceTable) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), nonceStoreDirPermissions); err != nil {
+		return fmt.Errorf("creating nonce store directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling nonce store: %w", err)
+	}
+
+	if err := fileutil.WriteAtomic(s.path, data, nonceStoreFilePermissions); err != nil {
+		return fmt.Errorf("writing nonce store: %w", err)
+	}
+	return nil
+}
+
+// nonceKey identifies an address on a chain. Addresses are compared without
+// their EIP-55 checksum casing.
+func nonceKey(chainID *big.Int, address string) string {
+	id := "0"
+	if chainID != nil {
+		id = chainID.String()
+	}
+	return id + ":" + strings.ToLower(address)
+}
@@ -0,0 +1,106 @@
+package eth
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonceStore(t *testing.T) {
+	t.Parallel()
+
+	mainnet := big.NewInt(1)
+	sepolia := big.NewInt(11155111)
+	now := time.Now()
+
+	t.Run("missing file is empty", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewNonceStore(filepath.Join(t.TempDir(), "nonces.json"))
+		pending, err := s.Pending(mainnet, testAddress)
+		require.NoError(t, err)
+		assert.Empty(t, pending)
+
+		next, err := s.Reconcile(mainnet, testAddress, 4, now)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(4), next)
+	})
+
+	t.Run("record per chain and address", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "cache", "nonces.json")
+		s := NewNonceStore(path)
+		require.NoError(t, s.Record(mainnet, testAddress, PendingNonce{Nonce: 6, Hash: "0x6", SentAt: now}))
+		require.NoError(t, s.Record(mainnet, testAddress, PendingNonce{Nonce: 5, Hash: "0x5", SentAt: now}))
+		require.NoError(t, s.Record(mainnet, testAddress, PendingNonce{Nonce: 6, Hash: "0x6b", SentAt: now}))
+		require.NoError(t, s.Record(sepolia, testAddress, PendingNonce{Nonce: 1, Hash: "0x1", SentAt: now}))
+
+		pending, err := s.Pending(mainnet, strings.ToLower(testAddress))
+		require.NoError(t, err)
+		require.Len(t, pending, 2)
+		assert.Equal(t, uint64(5), pending[0].Nonce)
+		assert.Equal(t, "0x6b", pending[1].Hash, "a replacement overwrites the entry for its nonce")
+
+		pending, err = s.Pending(sepolia, testAddress)
+		require.NoError(t, err)
+		require.Len(t, pending, 1)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("reconcile skips pending nonces the node has not seen", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewNonceStore(filepath.Join(t.TempDir(), "nonces.json"))
+		require.NoError(t, s.Record(mainnet, testAddress, PendingNonce{Nonce: 5, SentAt: now}))
+		require.NoError(t, s.Record(mainnet, testAddress, PendingNonce{Nonce: 6, SentAt: now}))
+
+		next, err := s.Reconcile(mainnet, testAddress, 5, now)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(7), next)
+
+		next, err = s.Reconcile(mainnet, testAddress, 9, now)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(9), next, "the node count wins once it is ahead")
+
+		pending, err := s.Pending(mainnet, testAddress)
+		require.NoError(t, err)
+		assert.Empty(t, pending, "nonces the node counts are pruned")
+	})
+
+	t.Run("reconcile drops expired nonces", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewNonceStore(filepath.Join(t.TempDir(), "nonces.json"))
+		require.NoError(t, s.Record(mainnet, testAddress, PendingNonce{Nonce: 5, SentAt: now.Add(-PendingNonceTTL - time.Minute)}))
+
+		next, err := s.Reconcile(mainnet, testAddress, 5, now)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(5), next, "a dropped transaction's nonce is reused")
+	})
+
+	t.Run("corrupt file is replaced", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "nonces.json")
+		require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+		s := NewNonceStore(path)
+
+		_, err := s.Pending(mainnet, testAddress)
+		require.ErrorIs(t, err, ErrCorruptNonceStore)
+
+		require.NoError(t, s.Record(mainnet, testAddress, PendingNonce{Nonce: 2, SentAt: now}))
+		next, err := s.Reconcile(mainnet, testAddress, 0, now)
+		require.NoError(t, err)
+		assert.Equal(t, uint64(3), next)
+	})
+}
@@ -0,0 +1,134 @@
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	ethcrypto "github.com/mrz1836/sigil/internal/chain/eth/crypto"
+	"github.com/mrz1836/sigil/internal/chain/eth/rlp"
+	ethtypes "github.com/mrz1836/sigil/internal/chain/eth/types"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// ErrSignerMismatch indicates the signing key does not control the sender of
+// an offline transaction.
+var ErrSignerMismatch = &sigilerr.SigilError{
+	Code:     "ETH_SIGNER_MISMATCH",
+	Message:  "signing key does not match the sender address",
+	ExitCode: sigilerr.ExitInput,
+}
+
+// BuildUnsigned resolves the nonce and chain ID of params over RPC and returns
+// the transaction in the offline signing format. Gas must already be set.
+func (c *Client) BuildUnsigned(ctx context.Context, params *TxParams) (*chain.UnsignedETH, error) {
+	if _, err := c.BuildTransaction(ctx, params); err != nil {
+		return nil, err
+	}
+	return &chain.UnsignedETH{
+		ChainID:  params.ChainID.String(),
+		Nonce:    params.Nonce,
+		To:       params.To,
+		Value:    params.Value.String(),
+		GasLimit: params.GasLimit,
+		GasPrice: params.GasPrice.String(),
+		Data:     hex.EncodeToString(params.Data),
+	}, nil
+}
+
+// SignUnsigned signs an offline transaction sent from from. It needs no
+// network access and returns the serialized signed transaction and its hash.
+func SignUnsigned(u *chain.UnsignedETH, from string, privateKey []byte) ([]byte, string, error) {
+	signer, err := DeriveAddress(privateKey)
+	if err != nil {
+		return nil, "", err
+	}
+	if !strings.EqualFold(signer, from) {
+		return nil, "", sigilerr.WithDetails(ErrSignerMismatch, map[string]string{
+			"from":   from,
+			"signer": signer,
+		})
+	}
+
+	tx, chainID, err := legacyTxFromUnsigned(u)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := SignTransaction(tx, privateKey, chainID); err != nil {
+		return nil, "", err
+	}
+	return tx.RawBytes(), tx.HashHex(), nil
+}
+
+// SignUnsignedWith signs an offline transaction sent from from with a key
+// held elsewhere, such as on a hardware wallet. sign is given the RLP payload
+// EIP-155 signs and returns the R and S of its signature. The recovery ID is
+// found by recovering from, so a signature by any other key fails with
+// ErrSignerMismatch.
+func SignUnsignedWith(u *chain.UnsignedETH, from string, sign func(payload []byte) (r, s []byte, err error)) ([]byte, string, error) {
+	tx, chainID, err := legacyTxFromUnsigned(u)
+	if err != nil {
+		return nil, "", err
+	}
+	payload := rlp.EncodeTransactionForSigning(tx.Nonce, tx.GasPrice, tx.GasLimit, tx.To, tx.Value, tx.Data, chainID)
+	r, s, err := sign(payload)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(r) != 32 || len(s) != 32 {
+		return nil, "", ethcrypto.ErrInvalidSignature
+	}
+
+	hash := ethcrypto.Keccak256(payload)
+	sig := make([]byte, 65)
+	copy(sig, r)
+	copy(sig[32:], s)
+	var signer string
+	for recID := byte(0); recID <= 1; recID++ {
+		sig[64] = recID
+		addr, recErr := ethcrypto.RecoverAddress(hash, sig)
+		if recErr != nil {
+			continue
+		}
+		signer = "0x" + hex.EncodeToString(addr)
+		if !strings.EqualFold(signer, from) {
+			continue
+		}
+		tx.R = new(big.Int).SetBytes(r)
+		tx.S = new(big.Int).SetBytes(s)
+		tx.V = new(big.Int).Add(new(big.Int).Mul(chainID, big.NewInt(2)), big.NewInt(35+int64(recID)))
+		return tx.RawBytes(), tx.HashHex(), nil
+	}
+	return nil, "", sigilerr.WithDetails(ErrSignerMismatch, map[string]string{
+		"from":   from,
+		"signer": signer,
+	})
+}
+
+// legacyTxFromUnsigned rebuilds the unsigned transaction u describes.
+func legacyTxFromUnsigned(u *chain.UnsignedETH) (*ethtypes.LegacyTx, *big.Int, error) {
+	chainID, ok := new(big.Int).SetString(u.ChainID, 10)
+	if !ok {
+		return nil, nil, sigilerr.ErrInvalidChainID
+	}
+	value, ok := new(big.Int).SetString(u.Value, 10)
+	if !ok {
+		return nil, nil, ErrInvalidAmount
+	}
+	gasPrice, ok := new(big.Int).SetString(u.GasPrice, 10)
+	if !ok {
+		return nil, nil, sigilerr.ErrInvalidGasPrice
+	}
+	data, err := hex.DecodeString(strings.TrimPrefix(u.Data, "0x"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decoding call data: %w", err)
+	}
+	to, err := ethcrypto.HexToAddress(u.To)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid to address: %w", err)
+	}
+	return ethtypes.NewLegacyTx(u.Nonce, to.Bytes(), value, u.GasLimit, gasPrice, data), chainID, nil
+}
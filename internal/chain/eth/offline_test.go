@@ -0,0 +1,136 @@
+package eth
+
+import (
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	ethcrypto "github.com/mrz1836/sigil/internal/chain/eth/crypto"
+	ethtypes "github.com/mrz1836/sigil/internal/chain/eth/types"
+)
+
+func TestSignUnsigned(t *testing.T) {
+	t.Parallel()
+
+	privateKey := []byte{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+		0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	}
+	from, err := DeriveAddress(privateKey)
+	require.NoError(t, err)
+
+	to := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	data, err := BuildERC20TransferData(to, big.NewInt(1000000))
+	require.NoError(t, err)
+
+	u := &chain.UnsignedETH{
+		ChainID:  "1",
+		Nonce:    3,
+		To:       to,
+		Value:    "0",
+		GasLimit: 65000,
+		GasPrice: "2000000000",
+		Data:     hex.EncodeToString(data),
+	}
+
+	t.Run("matches an online signature", func(t *testing.T) {
+		t.Parallel()
+		raw, hash, err := SignUnsigned(u, from, privateKey)
+		require.NoError(t, err)
+
+		want, chainID, err := legacyTxFromUnsigned(u)
+		require.NoError(t, err)
+		want, err = SignTransaction(want, privateKey, chainID)
+		require.NoError(t, err)
+
+		assert.Equal(t, want.RawBytes(), raw)
+		assert.Equal(t, want.HashHex(), hash)
+	})
+
+	t.Run("wrong sender", func(t *testing.T) {
+		t.Parallel()
+		_, _, err := SignUnsigned(u, to, privateKey)
+		require.ErrorIs(t, err, ErrSignerMismatch)
+	})
+
+	t.Run("bad fields", func(t *testing.T) {
+		t.Parallel()
+		bad := *u
+		bad.GasPrice = "fast"
+		_, _, err := SignUnsigned(&bad, from, privateKey)
+		require.Error(t, err)
+	})
+}
+
+func TestSignUnsignedWith(t *testing.T) {
+	t.Parallel()
+
+	privateKey := []byte{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+		0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	}
+	from, err := DeriveAddress(privateKey)
+	require.NoError(t, err)
+
+	u := &chain.UnsignedETH{
+		ChainID:  "11155111",
+		Nonce:    4,
+		To:       "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		Value:    "1000",
+		GasLimit: 21000,
+		GasPrice: "1000000000",
+	}
+
+	// An external signer that holds privateKey
+	sign := func(payload []byte) ([]byte, []byte, error) {
+		sig, signErr := ethcrypto.Sign(ethcrypto.Keccak256(payload), privateKey)
+		if signErr != nil {
+			return nil, nil, signErr
+		}
+		return sig[:32], sig[32:64], nil
+	}
+
+	raw, hash, err := SignUnsignedWith(u, from, sign)
+	require.NoError(t, err)
+	wantRaw, wantHash, err := SignUnsigned(u, from, privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, wantRaw, raw)
+	assert.Equal(t, wantHash, hash)
+
+	_, _, err = SignUnsignedWith(u, u.To, sign)
+	require.ErrorIs(t, err, ErrSignerMismatch)
+
+	_, _, err = SignUnsignedWith(u, from, func([]byte) ([]byte, []byte, error) {
+		return []byte{1}, []byte{2}, nil
+	})
+	require.Error(t, err)
+}
+
+func TestLegacyTxFromUnsigned(t *testing.T) {
+	t.Parallel()
+
+	u := &chain.UnsignedETH{
+		ChainID:  "11155111",
+		Nonce:    9,
+		To:       "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		Value:    "1000",
+		GasLimit: 21000,
+		GasPrice: "1000000000",
+	}
+	tx, chainID, err := legacyTxFromUnsigned(u)
+	require.NoError(t, err)
+	assert.Equal(t, int64(11155111), chainID.Int64())
+
+	to, err := hex.DecodeString("5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed")
+	require.NoError(t, err)
+	want := ethtypes.NewLegacyTx(9, to, big.NewInt(1000), 21000, big.NewInt(1000000000), []byte{})
+	assert.Equal(t, want.SigningHash(chainID), tx.SigningHash(chainID))
+}
@@ -0,0 +1,110 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+)
+
+// transferEventTopic is keccak256("Transfer(address,address,uint256)"), the
+// first topic of every ERC-20 Transfer log.
+const transferEventTopic = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+
+// DefaultReceiptPollInterval is how often WaitForReceipt polls the node.
+const DefaultReceiptPollInterval = 3 * time.Second
+
+// GetTransactionReceipt returns the receipt of a mined transaction, or
+// rpc.ErrReceiptNotFound while it is still pending.
+func (c *Client) GetTransactionReceipt(ctx context.Context, txHash string) (*rpc.Receipt, error) {
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	receipt, err := c.rpcClient.GetTransactionReceipt(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting receipt: %w", err)
+	}
+	return receipt, nil
+}
+
+// BlockNumber returns the number of the most recent block.
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	if err := c.connect(ctx); err != nil {
+		return 0, err
+	}
+
+	height, err := c.rpcClient.BlockNumber(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("getting block number: %w", err)
+	}
+	return height, nil
+}
+
+// WaitForReceipt polls until the transaction is mined or ctx is done. A zero
+// interval uses DefaultReceiptPollInterval.
+func (c *Client) WaitForReceipt(ctx context.Context, txHash string, interval time.Duration) (*rpc.Receipt, error) {
+	if interval <= 0 {
+		interval = DefaultReceiptPollInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		receipt, err := c.GetTransactionReceipt(ctx, txHash)
+		if err == nil {
+			return receipt, nil
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if !errors.Is(err, rpc.ErrReceiptNotFound) {
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliveredAmount sums the Transfer events the token contract emitted to
+// recipient in the receipt. The second result is false when the receipt holds
+// no such event, e.g. for a reverted transfer.
+func DeliveredAmount(receipt *rpc.Receipt, tokenAddress, recipient string) (*big.Int, bool) {
+	total := new(big.Int)
+	found := false
+
+	for _, l := range receipt.Logs {
+		if !strings.EqualFold(l.Address, tokenAddress) || len(l.Topics) != 3 {
+			continue
+		}
+		if !strings.EqualFold(l.Topics[0], transferEventTopic) || !topicIsAddress(l.Topics[2], recipient) {
+			continue
+		}
+		if len(l.Data) != 32 {
+			continue
+		}
+		total.Add(total, new(big.Int).SetBytes(l.Data))
+		found = true
+	}
+
+	return total, found
+}
+
+// topicIsAddress reports whether an indexed 32-byte topic encodes address.
+func topicIsAddress(topic, address string) bool {
+	topic = strings.TrimPrefix(strings.ToLower(topic), "0x")
+	address = strings.TrimPrefix(strings.ToLower(address), "0x")
+	if len(topic) != 64 || len(address) != 40 {
+		return false
+	}
+	return topic[24:] == address && strings.Trim(topic[:24], "0") == ""
+}
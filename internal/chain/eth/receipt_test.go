@@ -0,0 +1,129 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+)
+
+const (
+	testReceiptToken     = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+	testReceiptRecipient = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+)
+
+// addressTopic encodes an address as an indexed event topic.
+func addressTopic(address string) string {
+	return "0x000000000000000000000000" + address[2:]
+}
+
+func transferLog(token, to string, amount int64) rpc.Log {
+	return rpc.Log{
+		Address: token,
+		Topics:  []string{transferEventTopic, addressTopic("0x1111111111111111111111111111111111111111"), addressTopic(to)},
+		Data:    new(big.Int).SetInt64(amount).FillBytes(make([]byte, 32)),
+	}
+}
+
+func TestDeliveredAmount(t *testing.T) {
+	t.Parallel()
+
+	other := "0x2222222222222222222222222222222222222222"
+
+	tests := []struct {
+		name      string
+		logs      []rpc.Log
+		want      int64
+		wantFound bool
+	}{
+		{
+			name:      "fee deducted",
+			logs:      []rpc.Log{transferLog(testReceiptToken, testReceiptRecipient, 98), transferLog(testReceiptToken, other, 2)},
+			want:      98,
+			wantFound: true,
+		},
+		{
+			name:      "case-insensitive match",
+			logs:      []rpc.Log{transferLog(testReceiptToken, "0x742d35cc6634c0532925a3b844bc454e4438f44e", 50)},
+			want:      50,
+			wantFound: true,
+		},
+		{
+			name:      "split transfers summed",
+			logs:      []rpc.Log{transferLog(testReceiptToken, testReceiptRecipient, 40), transferLog(testReceiptToken, testReceiptRecipient, 55)},
+			want:      95,
+			wantFound: true,
+		},
+		{
+			name: "other contract ignored",
+			logs: []rpc.Log{transferLog(other, testReceiptRecipient, 100)},
+		},
+		{
+			name: "no logs",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, found := DeliveredAmount(&rpc.Receipt{Logs: tc.logs}, testReceiptToken, testReceiptRecipient)
+			assert.Equal(t, tc.wantFound, found)
+			assert.Equal(t, big.NewInt(tc.want), got)
+		})
+	}
+}
+
+func TestWaitForReceipt(t *testing.T) {
+	t.Parallel()
+
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any    `json:"id"`
+			Method string `json:"method"`
+		}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			return
+		}
+
+		var result any
+		switch req.Method {
+		case "eth_chainId":
+			result = "0x1"
+		case "eth_getTransactionReceipt":
+			if polls.Add(1) >= 3 {
+				result = map[string]any{"transactionHash": "0xabc", "blockNumber": "0x1", "status": "0x1"}
+			}
+		default:
+			t.Errorf("unexpected method: %s", req.Method)
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req.ID, "result": result}))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, nil)
+	require.NoError(t, err)
+	defer client.Close()
+
+	receipt, err := client.WaitForReceipt(context.Background(), "0xabc", time.Millisecond)
+	require.NoError(t, err)
+	assert.True(t, receipt.Success)
+	assert.Equal(t, int32(3), polls.Load())
+
+	polls.Store(-1000)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	receipt, err = client.WaitForReceipt(ctx, "0xabc", time.Millisecond)
+	require.Error(t, err, "gives up when the context ends")
+	assert.Nil(t, receipt)
+}
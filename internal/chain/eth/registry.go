@@ -0,0 +1,85 @@
+package eth
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// Chain IDs of the Ethereum networks sigil knows.
+const (
+	// MainnetChainID is the chain ID of Ethereum mainnet.
+	MainnetChainID = 1
+
+	// SepoliaChainID is the chain ID of the Sepolia testnet.
+	SepoliaChainID = 11155111
+)
+
+// knownTokens are the ERC-20 tokens sigil recognizes without any
+// configuration, by the chain ID of the network they are deployed on.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var knownTokens = map[int64][]TokenMetadata{
+	MainnetChainID: {
+		{Address: USDCMainnet, Symbol: "USDC", Decimals: USDCDecimals},
+		{Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Symbol: "USDT", Decimals: 6},
+		{Address: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Symbol: "DAI", Decimals: 18},
+		{Address: "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599", Symbol: "WBTC", Decimals: 8},
+		{Address: "0x514910771AF9Ca656af840dff83E8264EcF986CA", Symbol: "LINK", Decimals: 18},
+	},
+	SepoliaChainID: {
+		{Address: USDCSepolia, Symbol: "USDC", Decimals: USDCDecimals},
+	},
+}
+
+// activeChain is the chain ID whose built-in tokens are used; zero means
+// mainnet.
+//
+//nolint:gochecknoglobals // Process-wide network, set when a command picks its wallet
+var activeChain atomic.Int64
+
+// UseChain selects the network whose built-in tokens KnownTokens and
+// LookupKnownToken return, so a symbol such as USDC resolves to the
+// contract on that network. Unknown chains have no built-in tokens.
+func UseChain(chainID int64) {
+	activeChain.Store(chainID)
+}
+
+// ActiveChain returns the chain ID selected with UseChain, mainnet by
+// default.
+func ActiveChain() int64 {
+	if id := activeChain.Load(); id != 0 {
+		return id
+	}
+	return MainnetChainID
+}
+
+// KnownTokens returns the built-in ERC-20 tokens of the active network.
+func KnownTokens() []TokenMetadata {
+	known := knownTokens[ActiveChain()]
+	tokens := make([]TokenMetadata, len(known))
+	copy(tokens, known)
+	return tokens
+}
+
+// KnownTokenSymbols returns the symbols of the built-in tokens of the
+// active network.
+func KnownTokenSymbols() []string {
+	known := knownTokens[ActiveChain()]
+	symbols := make([]string, len(known))
+	for i, t := range known {
+		symbols[i] = t.Symbol
+	}
+	return symbols
+}
+
+// LookupKnownToken finds a built-in token of the active network by symbol
+// (case-insensitive) or contract address.
+func LookupKnownToken(symbolOrAddress string) (*TokenMetadata, bool) {
+	for _, t := range knownTokens[ActiveChain()] {
+		if strings.EqualFold(t.Symbol, symbolOrAddress) || strings.EqualFold(t.Address, symbolOrAddress) {
+			token := t
+			return &token, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,78 @@
+package eth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKnownTokens(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"USDC", "USDT", "DAI", "WBTC", "LINK"}, KnownTokenSymbols())
+	for _, token := range KnownTokens() {
+		assert.True(t, IsValidAddress(token.Address), token.Symbol)
+	}
+
+	tokens := KnownTokens()
+	tokens[0].Symbol = "CHANGED"
+	assert.Equal(t, "USDC", KnownTokens()[0].Symbol, "callers must not modify the built-in list")
+}
+
+func TestLookupKnownToken(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		input        string
+		wantSymbol   string
+		wantDecimals int
+		wantFound    bool
+	}{
+		{name: "symbol", input: "WBTC", wantSymbol: "WBTC", wantDecimals: 8, wantFound: true},
+		{name: "lowercase symbol", input: "dai", wantSymbol: "DAI", wantDecimals: 18, wantFound: true},
+		{name: "address any case", input: strings.ToLower(USDCMainnet), wantSymbol: "USDC", wantDecimals: USDCDecimals, wantFound: true},
+		{name: "unknown symbol", input: "UNI"},
+		{name: "empty", input: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			token, ok := LookupKnownToken(tc.input)
+			assert.Equal(t, tc.wantFound, ok)
+			if !tc.wantFound {
+				assert.Nil(t, token)
+				return
+			}
+			require.NotNil(t, token)
+			assert.Equal(t, tc.wantSymbol, token.Symbol)
+			assert.Equal(t, tc.wantDecimals, token.Decimals)
+		})
+	}
+}
+
+//nolint:paralleltest // UseChain changes process-wide state
+func TestUseChain(t *testing.T) {
+	t.Cleanup(func() { UseChain(0) })
+	assert.Equal(t, int64(MainnetChainID), ActiveChain())
+
+	UseChain(SepoliaChainID)
+	assert.Equal(t, int64(SepoliaChainID), ActiveChain())
+	token, ok := LookupKnownToken("usdc")
+	require.True(t, ok)
+	assert.Equal(t, USDCSepolia, token.Address)
+	_, ok = LookupKnownToken(USDCMainnet)
+	assert.False(t, ok, "mainnet contracts are not built in on Sepolia")
+	assert.Equal(t, []string{"USDC"}, KnownTokenSymbols())
+
+	UseChain(5)
+	assert.Empty(t, KnownTokens())
+
+	UseChain(MainnetChainID)
+	token, ok = LookupKnownToken("USDC")
+	require.True(t, ok)
+	assert.Equal(t, USDCMainnet, token.Address)
+}
@@ -0,0 +1,112 @@
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// replacementBumpPercent is how much more gas price, in percent, nodes
+// require of a transaction that replaces a pending one with the same nonce.
+const replacementBumpPercent = 10
+
+// ErrNotReplaceable indicates a transaction can no longer be replaced.
+var ErrNotReplaceable = &sigilerr.SigilError{
+	Code:     "ETH_TX_NOT_REPLACEABLE",
+	Message:  "transaction cannot be replaced",
+	ExitCode: sigilerr.ExitInput,
+}
+
+// Replacement is an unsigned transaction that re-uses the nonce of a
+// pending one, so whichever is mined first drops the other.
+type Replacement struct {
+	Original *rpc.Transaction
+	Unsigned *chain.UnsignedETH
+	GasPrice *big.Int
+}
+
+// MaxFee returns the most the replacement can pay in fees, in wei.
+func (r *Replacement) MaxFee() *big.Int {
+	return new(big.Int).Mul(r.GasPrice, new(big.Int).SetUint64(r.Unsigned.GasLimit))
+}
+
+// GetTransaction returns a mined or pending transaction, or
+// rpc.ErrTransactionNotFound when the node does not know it.
+func (c *Client) GetTransaction(ctx context.Context, txHash string) (*rpc.Transaction, error) {
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, err := c.rpcClient.GetTransactionByHash(ctx, txHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// BuildReplacement builds a transaction that replaces the pending
+// transaction txHash at the speed's gas price. A speed-up resends the same
+// transaction; a cancel sends 0 ETH from the sender to itself instead.
+func (c *Client) BuildReplacement(ctx context.Context, txHash string, cancel bool, speed GasSpeed) (*Replacement, error) {
+	orig, err := c.GetTransaction(ctx, txHash)
+	if errors.Is(err, rpc.ErrTransactionNotFound) {
+		return nil, sigilerr.WithSuggestion(ErrNotReplaceable,
+			fmt.Sprintf("the node does not know transaction %s; it may have been dropped from the mempool", txHash))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !orig.Pending {
+		return nil, sigilerr.WithSuggestion(ErrNotReplaceable,
+			fmt.Sprintf("transaction %s was already mined in block %d", txHash, orig.BlockNumber))
+	}
+	if !cancel && orig.To == "" {
+		return nil, sigilerr.WithSuggestion(ErrNotReplaceable,
+			"a contract creation cannot be sped up; cancel it instead")
+	}
+
+	networkPrice, err := c.GetGasPrice(ctx, speed)
+	if err != nil {
+		return nil, fmt.Errorf("getting gas price: %w", err)
+	}
+	chainID, err := c.GetChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting chain ID: %w", err)
+	}
+
+	gasPrice := ReplacementGasPrice(orig.GasPrice, networkPrice)
+	u := &chain.UnsignedETH{
+		ChainID:  chainID.String(),
+		Nonce:    orig.Nonce,
+		To:       orig.To,
+		Value:    orig.Value.String(),
+		GasLimit: orig.Gas,
+		GasPrice: gasPrice.String(),
+		Data:     hex.EncodeToString(orig.Input),
+	}
+	if cancel {
+		u.To, u.Value, u.GasLimit, u.Data = orig.From, "0", defaultGasLimit, ""
+	}
+	return &Replacement{Original: orig, Unsigned: u, GasPrice: gasPrice}, nil
+}
+
+// ReplacementGasPrice returns the gas price of a replacement for a pending
+// transaction priced at original: the network price, or the least nodes
+// accept as a replacement, whichever is higher.
+func ReplacementGasPrice(original, network *big.Int) *big.Int {
+	// original * 1.1, rounded up
+	minimum := new(big.Int).Mul(original, big.NewInt(100+replacementBumpPercent))
+	minimum.Add(minimum, big.NewInt(99))
+	minimum.Div(minimum, big.NewInt(100))
+
+	if network != nil && network.Cmp(minimum) > 0 {
+		return new(big.Int).Set(network)
+	}
+	return minimum
+}
@@ -0,0 +1,137 @@
+package eth
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newReplaceServer serves a chain with a 1 Gwei gas price where hash is the
+// transaction tx, or unknown when tx is nil.
+func newReplaceServer(t *testing.T, tx map[string]any) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		var result any
+		switch req["method"] {
+		case rpcMethodChainID:
+			result = "0x5"
+		case rpcMethodGasPrice:
+			result = "0x3b9aca00"
+		case "eth_getTransactionByHash":
+			if tx != nil {
+				result = tx
+			}
+		default:
+			t.Errorf("unexpected method: %v", req["method"])
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{"jsonrpc": "2.0", "id": req["id"], "result": result}))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+// pendingTx returns a pending token transfer with the given gas price.
+func pendingTx(gasPrice string) map[string]any {
+	return map[string]any{
+		"hash":        "0xabc",
+		"from":        "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"to":          "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"nonce":       "0x7",
+		"value":       "0x0",
+		"gas":         "0xea60",
+		"gasPrice":    gasPrice,
+		"input":       "0xa9059cbb",
+		"blockNumber": nil,
+	}
+}
+
+func TestBuildReplacement(t *testing.T) {
+	t.Parallel()
+
+	t.Run("speed up", func(t *testing.T) {
+		t.Parallel()
+		client, err := NewClient(newReplaceServer(t, pendingTx("0x77359400")).URL, nil) // 2 Gwei
+		require.NoError(t, err)
+		defer client.Close()
+
+		repl, err := client.BuildReplacement(context.Background(), "0xabc", false, GasSpeedMedium)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(2_200_000_000), repl.GasPrice, "10% over the original beats the network price")
+		assert.Equal(t, "5", repl.Unsigned.ChainID)
+		assert.Equal(t, uint64(7), repl.Unsigned.Nonce)
+		assert.Equal(t, "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", repl.Unsigned.To)
+		assert.Equal(t, "0", repl.Unsigned.Value)
+		assert.Equal(t, uint64(60000), repl.Unsigned.GasLimit)
+		assert.Equal(t, "a9059cbb", repl.Unsigned.Data)
+		assert.Equal(t, big.NewInt(132_000_000_000_000), repl.MaxFee())
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		t.Parallel()
+		client, err := NewClient(newReplaceServer(t, pendingTx("0x1dcd6500")).URL, nil) // 0.5 Gwei
+		require.NoError(t, err)
+		defer client.Close()
+
+		repl, err := client.BuildReplacement(context.Background(), "0xabc", true, GasSpeedMedium)
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(1_000_000_000), repl.GasPrice, "the network price beats 10% over the original")
+		assert.Equal(t, uint64(7), repl.Unsigned.Nonce)
+		assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", repl.Unsigned.To)
+		assert.Equal(t, "0", repl.Unsigned.Value)
+		assert.Equal(t, uint64(defaultGasLimit), repl.Unsigned.GasLimit)
+		assert.Empty(t, repl.Unsigned.Data)
+	})
+
+	t.Run("mined", func(t *testing.T) {
+		t.Parallel()
+		tx := pendingTx("0x77359400")
+		tx["blockNumber"] = "0x10"
+		client, err := NewClient(newReplaceServer(t, tx).URL, nil)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.BuildReplacement(context.Background(), "0xabc", false, GasSpeedMedium)
+		require.ErrorIs(t, err, ErrNotReplaceable)
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, err, &se)
+		assert.Contains(t, se.Suggestion, "already mined in block 16")
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+		client, err := NewClient(newReplaceServer(t, nil).URL, nil)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.BuildReplacement(context.Background(), "0xabc", true, GasSpeedMedium)
+		require.ErrorIs(t, err, ErrNotReplaceable)
+	})
+}
+
+func TestReplacementGasPrice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		original, network, want int64
+	}{
+		{original: 100, network: 0, want: 110},
+		{original: 101, network: 105, want: 112},
+		{original: 100, network: 500, want: 500},
+	}
+	for _, tc := range tests {
+		got := ReplacementGasPrice(big.NewInt(tc.original), big.NewInt(tc.network))
+		assert.Equal(t, big.NewInt(tc.want), got, "original %d, network %d", tc.original, tc.network)
+	}
+}
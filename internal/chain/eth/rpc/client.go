@@ -17,7 +17,10 @@ import (
 	"time"
 
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/faultinject"
 	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	"github.com/mrz1836/sigil/internal/reqsign"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
@@ -72,6 +75,20 @@ var (
 		Message:  "invalid hex number",
 		ExitCode: sigilerr.ExitInput,
 	}
+
+	// ErrReceiptNotFound indicates the transaction has not been mined yet.
+	ErrReceiptNotFound = &sigilerr.SigilError{
+		Code:     "RPC_RECEIPT_NOT_FOUND",
+		Message:  "transaction receipt not found",
+		ExitCode: sigilerr.ExitNotFound,
+	}
+
+	// ErrTransactionNotFound indicates the node does not know the transaction.
+	ErrTransactionNotFound = &sigilerr.SigilError{
+		Code:     "RPC_TX_NOT_FOUND",
+		Message:  "transaction not found",
+		ExitCode: sigilerr.ExitNotFound,
+	}
 )
 
 // Client is a minimal Ethereum JSON-RPC client.
@@ -119,7 +136,7 @@ func NewClientWithOptions(url string, opts *ClientOptions) *Client {
 	return &Client{
 		url: url,
 		httpClient: &http.Client{
-			Transport: transport,
+			Transport: reqlog.Wrap(faultinject.Wrap(reqsign.Wrap(transport)), "eth-rpc"),
 			Timeout:   45 * time.Second,
 		},
 		rateLimiter: chain.DefaultRateLimiter(),
@@ -218,6 +235,26 @@ func (c *Client) GetTransactionCount(ctx context.Context, address, block string)
 	return n.Uint64(), nil
 }
 
+// BlockNumber returns the number of the most recent block.
+func (c *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	result, err := c.Call(ctx, "eth_blockNumber")
+	if err != nil {
+		return 0, err
+	}
+
+	var hexVal string
+	if unmarshalErr := json.Unmarshal(result, &hexVal); unmarshalErr != nil {
+		return 0, fmt.Errorf("parsing block number: %w", unmarshalErr)
+	}
+
+	n, err := parseHexBigInt(hexVal)
+	if err != nil {
+		return 0, err
+	}
+
+	return n.Uint64(), nil
+}
+
 // GasPrice returns the current gas price in wei.
 func (c *Client) GasPrice(ctx context.Context) (*big.Int, error) {
 	result, err := c.Call(ctx, "eth_gasPrice")
@@ -327,6 +364,173 @@ func (c *Client) SendRawTransaction(ctx context.Context, signedTx []byte) (strin
 	return txHash, nil
 }
 
+// Log is an event log emitted by a transaction.
+type Log struct {
+	Address string
+	Topics  []string
+	Data    []byte
+}
+
+// Receipt is the subset of a transaction receipt sigil uses.
+type Receipt struct {
+	TxHash            string
+	BlockNumber       uint64
+	Success           bool
+	GasUsed           uint64
+	EffectiveGasPrice *big.Int
+	Logs              []Log
+}
+
+// receiptJSON is the wire format of eth_getTransactionReceipt.
+type receiptJSON struct {
+	TransactionHash   string `json:"transactionHash"`
+	BlockNumber       string `json:"blockNumber"`
+	Status            string `json:"status"`
+	GasUsed           string `json:"gasUsed"`
+	EffectiveGasPrice string `json:"effectiveGasPrice"`
+	Logs              []struct {
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	} `json:"logs"`
+}
+
+// GetTransactionReceipt returns the receipt of a mined transaction.
+// Returns ErrReceiptNotFound while the transaction is still pending.
+func (c *Client) GetTransactionReceipt(ctx context.Context, txHash string) (*Receipt, error) {
+	result, err := c.Call(ctx, "eth_getTransactionReceipt", txHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 || string(result) == "null" {
+		return nil, ErrReceiptNotFound
+	}
+
+	var raw receiptJSON
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("parsing receipt: %w", err)
+	}
+
+	block, err := parseHexBigInt(raw.BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("parsing receipt block number: %w", err)
+	}
+	gasUsed, err := parseHexBigInt(raw.GasUsed)
+	if err != nil {
+		return nil, fmt.Errorf("parsing receipt gas used: %w", err)
+	}
+	gasPrice, err := parseHexBigInt(raw.EffectiveGasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("parsing receipt gas price: %w", err)
+	}
+
+	receipt := &Receipt{
+		TxHash:            raw.TransactionHash,
+		BlockNumber:       block.Uint64(),
+		Success:           raw.Status == "0x1",
+		GasUsed:           gasUsed.Uint64(),
+		EffectiveGasPrice: gasPrice,
+		Logs:              make([]Log, 0, len(raw.Logs)),
+	}
+	for _, l := range raw.Logs {
+		data, err := parseHexBytes(l.Data)
+		if err != nil {
+			return nil, fmt.Errorf("parsing receipt log data: %w", err)
+		}
+		receipt.Logs = append(receipt.Logs, Log{Address: l.Address, Topics: l.Topics, Data: data})
+	}
+
+	return receipt, nil
+}
+
+// Transaction is the subset of a transaction sigil uses to replace it.
+type Transaction struct {
+	Hash     string
+	From     string
+	To       string // empty for contract creation
+	Nonce    uint64
+	Value    *big.Int
+	Gas      uint64
+	GasPrice *big.Int
+	Input    []byte
+	// Pending is true until the transaction is mined in BlockNumber.
+	Pending     bool
+	BlockNumber uint64
+}
+
+// transactionJSON is the wire format of eth_getTransactionByHash.
+type transactionJSON struct {
+	Hash        string  `json:"hash"`
+	From        string  `json:"from"`
+	To          *string `json:"to"`
+	Nonce       string  `json:"nonce"`
+	Value       string  `json:"value"`
+	Gas         string  `json:"gas"`
+	GasPrice    string  `json:"gasPrice"`
+	Input       string  `json:"input"`
+	BlockNumber *string `json:"blockNumber"`
+}
+
+// GetTransactionByHash returns a mined or pending transaction.
+// Returns ErrTransactionNotFound when the node does not know it.
+func (c *Client) GetTransactionByHash(ctx context.Context, txHash string) (*Transaction, error) {
+	result, err := c.Call(ctx, "eth_getTransactionByHash", txHash)
+	if err != nil {
+		return nil, err
+	}
+	if len(result) == 0 || string(result) == "null" {
+		return nil, ErrTransactionNotFound
+	}
+
+	var raw transactionJSON
+	if err := json.Unmarshal(result, &raw); err != nil {
+		return nil, fmt.Errorf("parsing transaction: %w", err)
+	}
+
+	nonce, err := parseHexBigInt(raw.Nonce)
+	if err != nil {
+		return nil, fmt.Errorf("parsing transaction nonce: %w", err)
+	}
+	value, err := parseHexBigInt(raw.Value)
+	if err != nil {
+		return nil, fmt.Errorf("parsing transaction value: %w", err)
+	}
+	gas, err := parseHexBigInt(raw.Gas)
+	if err != nil {
+		return nil, fmt.Errorf("parsing transaction gas: %w", err)
+	}
+	gasPrice, err := parseHexBigInt(raw.GasPrice)
+	if err != nil {
+		return nil, fmt.Errorf("parsing transaction gas price: %w", err)
+	}
+	input, err := parseHexBytes(raw.Input)
+	if err != nil {
+		return nil, fmt.Errorf("parsing transaction input: %w", err)
+	}
+
+	tx := &Transaction{
+		Hash:     raw.Hash,
+		From:     raw.From,
+		Nonce:    nonce.Uint64(),
+		Value:    value,
+		Gas:      gas.Uint64(),
+		GasPrice: gasPrice,
+		Input:    input,
+		Pending:  raw.BlockNumber == nil,
+	}
+	if raw.To != nil {
+		tx.To = *raw.To
+	}
+	if raw.BlockNumber != nil {
+		block, err := parseHexBigInt(*raw.BlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("parsing transaction block number: %w", err)
+		}
+		tx.BlockNumber = block.Uint64()
+	}
+	return tx, nil
+}
+
 // parseHexBigInt parses a hex string (with or without 0x prefix) to big.Int.
 func parseHexBigInt(s string) (*big.Int, error) {
 	s = strings.TrimPrefix(s, "0x")
@@ -353,7 +557,7 @@ func parseHexBytes(s string) ([]byte, error) {
 
 // Close closes the client and releases idle connections.
 func (c *Client) Close() {
-	if t, ok := c.httpClient.Transport.(*http.Transport); ok {
+	if t, ok := c.httpClient.Transport.(interface{ CloseIdleConnections() }); ok {
 		t.CloseIdleConnections()
 	}
 }
@@ -100,6 +100,34 @@ func TestGetTransactionCount(t *testing.T) {
 	assert.Equal(t, uint64(10), nonce)
 }
 
+func TestBlockNumber(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		err := json.NewDecoder(r.Body).Decode(&req)
+		assert.NoError(t, err)
+		assert.Equal(t, "eth_blockNumber", req["method"])
+
+		resp := map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req["id"],
+			"result":  "0x12d687", // 1234567
+		}
+		err = json.NewEncoder(w).Encode(resp)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	height, err := client.BlockNumber(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1234567), height)
+}
+
 func TestGasPrice(t *testing.T) {
 	t.Parallel()
 
@@ -245,3 +273,128 @@ func TestCallMsgMarshalJSON(t *testing.T) {
 	assert.Equal(t, "0xde0b6b3a7640000", result["value"])
 	assert.Equal(t, "0x70a08231", result["data"])
 }
+
+func TestGetTransactionReceipt(t *testing.T) {
+	t.Parallel()
+
+	receipt := map[string]any{
+		"transactionHash":   "0xabc",
+		"blockNumber":       "0x10",
+		"status":            "0x1",
+		"gasUsed":           "0x5208",
+		"effectiveGasPrice": "0x3b9aca00",
+		"logs": []map[string]any{{
+			"address": "0xtoken",
+			"topics":  []string{"0x01", "0x02"},
+			"data":    "0x64",
+		}},
+	}
+
+	tests := []struct {
+		name    string
+		result  any
+		wantErr error
+	}{
+		{name: "mined", result: receipt},
+		{name: "pending", result: nil, wantErr: ErrReceiptNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "eth_getTransactionReceipt", req["method"])
+				assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+					"jsonrpc": "2.0",
+					"id":      req["id"],
+					"result":  tc.result,
+				}))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			got, err := client.GetTransactionReceipt(context.Background(), "0xabc")
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.Success)
+			assert.Equal(t, uint64(16), got.BlockNumber)
+			assert.Equal(t, uint64(21000), got.GasUsed)
+			assert.Equal(t, big.NewInt(1_000_000_000), got.EffectiveGasPrice)
+			require.Len(t, got.Logs, 1)
+			assert.Equal(t, []byte{0x64}, got.Logs[0].Data)
+		})
+	}
+}
+
+func TestGetTransactionByHash(t *testing.T) {
+	t.Parallel()
+
+	pending := map[string]any{
+		"hash":        "0xabc",
+		"from":        "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed",
+		"to":          "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359",
+		"nonce":       "0x7",
+		"value":       "0xde0b6b3a7640000",
+		"gas":         "0x5208",
+		"gasPrice":    "0x3b9aca00",
+		"input":       "0x",
+		"blockNumber": nil,
+	}
+	mined := map[string]any{}
+	for k, v := range pending {
+		mined[k] = v
+	}
+	mined["blockNumber"] = "0x10"
+
+	tests := []struct {
+		name        string
+		result      any
+		wantErr     error
+		wantPending bool
+		wantBlock   uint64
+	}{
+		{name: "pending", result: pending, wantPending: true},
+		{name: "mined", result: mined, wantBlock: 16},
+		{name: "unknown", result: nil, wantErr: ErrTransactionNotFound},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var req map[string]any
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+				assert.Equal(t, "eth_getTransactionByHash", req["method"])
+				assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+					"jsonrpc": "2.0",
+					"id":      req["id"],
+					"result":  tc.result,
+				}))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL)
+			got, err := client.GetTransactionByHash(context.Background(), "0xabc")
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantPending, got.Pending)
+			assert.Equal(t, tc.wantBlock, got.BlockNumber)
+			assert.Equal(t, uint64(7), got.Nonce)
+			assert.Equal(t, uint64(21000), got.Gas)
+			assert.Equal(t, big.NewInt(1_000_000_000), got.GasPrice)
+			assert.Equal(t, "1000000000000000000", got.Value.String())
+			assert.Equal(t, "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359", got.To)
+			assert.Empty(t, got.Input)
+		})
+	}
+}
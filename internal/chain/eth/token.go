@@ -0,0 +1,138 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"unicode"
+
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	// maxTokenDecimals is the largest decimals value an ERC-20 token can report (uint8).
+	maxTokenDecimals = 255
+
+	// maxTokenSymbolLen bounds symbols read from untrusted contracts.
+	maxTokenSymbolLen = 32
+)
+
+// ErrInvalidTokenMetadata indicates a contract did not return usable ERC-20 metadata.
+var ErrInvalidTokenMetadata = &sigilerr.SigilError{
+	Code:     "ETH_INVALID_TOKEN_METADATA",
+	Message:  "contract did not return valid ERC-20 metadata",
+	ExitCode: sigilerr.ExitInput,
+}
+
+// TokenMetadata describes an ERC-20 token as reported by its contract.
+type TokenMetadata struct {
+	Address  string
+	Symbol   string
+	Decimals int
+
+	// FeeOnTransfer and Rebasing come from the token registry, not the
+	// contract; either means the delivered amount may differ from the sent one.
+	FeeOnTransfer bool
+	Rebasing      bool
+}
+
+// AmountMayDiffer reports whether the recipient may receive a different
+// amount than was sent.
+func (m *TokenMetadata) AmountMayDiffer() bool {
+	return m.FeeOnTransfer || m.Rebasing
+}
+
+// GetTokenMetadata reads decimals() and symbol() from an ERC-20 contract.
+// Both the standard string return and the legacy bytes32 return (e.g. MKR)
+// are accepted for symbol().
+func (c *Client) GetTokenMetadata(ctx context.Context, tokenAddress string) (*TokenMetadata, error) {
+	if !addressRegex.MatchString(tokenAddress) {
+		return nil, ErrInvalidTokenAddress
+	}
+
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+
+	// decimals() selector: keccak256("decimals()")[0:4] = 0x313ce567
+	raw, err := c.rpcClient.EthCall(ctx, rpc.CallMsg{To: tokenAddress, Data: []byte{0x31, 0x3c, 0xe5, 0x67}}, "latest")
+	if err != nil {
+		return nil, fmt.Errorf("calling decimals: %w", err)
+	}
+	tokenDecimals, err := decodeTokenDecimals(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// symbol() selector: keccak256("symbol()")[0:4] = 0x95d89b41
+	raw, err = c.rpcClient.EthCall(ctx, rpc.CallMsg{To: tokenAddress, Data: []byte{0x95, 0xd8, 0x9b, 0x41}}, "latest")
+	if err != nil {
+		return nil, fmt.Errorf("calling symbol: %w", err)
+	}
+	symbol, err := decodeTokenSymbol(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenMetadata{
+		Address:  tokenAddress,
+		Symbol:   symbol,
+		Decimals: tokenDecimals,
+	}, nil
+}
+
+// decodeTokenDecimals parses a uint256 decimals() return value.
+func decodeTokenDecimals(raw []byte) (int, error) {
+	if len(raw) < 32 {
+		return 0, sigilerr.WithDetails(ErrInvalidTokenMetadata, map[string]string{
+			"field": "decimals",
+		})
+	}
+
+	value := new(big.Int).SetBytes(raw[:32])
+	if !value.IsInt64() || value.Int64() > maxTokenDecimals {
+		return 0, sigilerr.WithDetails(ErrInvalidTokenMetadata, map[string]string{
+			"field": "decimals",
+			"value": value.String(),
+		})
+	}
+
+	return int(value.Int64()), nil
+}
+
+// decodeTokenSymbol parses a symbol() return value encoded either as an ABI
+// dynamic string or as a right-padded bytes32.
+func decodeTokenSymbol(raw []byte) (string, error) {
+	var symbol []byte
+
+	switch {
+	case len(raw) >= 64 && new(big.Int).SetBytes(raw[:32]).Cmp(big.NewInt(32)) == 0:
+		length := new(big.Int).SetBytes(raw[32:64])
+		if !length.IsInt64() || length.Int64() > int64(len(raw)-64) {
+			return "", sigilerr.WithDetails(ErrInvalidTokenMetadata, map[string]string{
+				"field": "symbol",
+			})
+		}
+		symbol = raw[64 : 64+length.Int64()]
+	case len(raw) == 32:
+		symbol = bytes.TrimRight(raw, "\x00")
+	default:
+		return "", sigilerr.WithDetails(ErrInvalidTokenMetadata, map[string]string{
+			"field": "symbol",
+		})
+	}
+
+	s := strings.TrimSpace(string(symbol))
+	if s == "" || len(s) > maxTokenSymbolLen || strings.IndexFunc(s, func(r rune) bool {
+		return !unicode.IsPrint(r) || unicode.IsSpace(r)
+	}) >= 0 {
+		return "", sigilerr.WithDetails(ErrInvalidTokenMetadata, map[string]string{
+			"field": "symbol",
+		})
+	}
+
+	return s, nil
+}
@@ -0,0 +1,146 @@
+package eth
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// abiWord left-pads n into a 32-byte ABI word.
+func abiWord(n byte) []byte {
+	w := make([]byte, 32)
+	w[31] = n
+	return w
+}
+
+// abiString encodes s as an ABI dynamic string return value.
+func abiString(s string) []byte {
+	out := append(abiWord(32), abiWord(byte(len(s)))...)
+	padded := make([]byte, (len(s)+31)/32*32)
+	copy(padded, s)
+	return append(out, padded...)
+}
+
+// bytes32 right-pads s into a fixed 32-byte value.
+func bytes32(s string) []byte {
+	w := make([]byte, 32)
+	copy(w, s)
+	return w
+}
+
+// newTokenServer serves eth_call for decimals() and symbol() with fixed results.
+func newTokenServer(t *testing.T, decimalsRet, symbolRet []byte) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			return
+		}
+
+		var result string
+		switch req.Method {
+		case "eth_chainId":
+			result = "0x1"
+		case "eth_call":
+			var msg struct {
+				Data string `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(req.Params[0], &msg))
+			switch {
+			case strings.HasPrefix(msg.Data, "0x313ce567"):
+				result = "0x" + hex.EncodeToString(decimalsRet)
+			case strings.HasPrefix(msg.Data, "0x95d89b41"):
+				result = "0x" + hex.EncodeToString(symbolRet)
+			default:
+				t.Errorf("unexpected call data: %s", msg.Data)
+			}
+		default:
+			t.Errorf("unexpected method: %s", req.Method)
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		}))
+	}))
+}
+
+func TestGetTokenMetadata(t *testing.T) {
+	t.Parallel()
+
+	const token = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+
+	tests := []struct {
+		name         string
+		decimalsRet  []byte
+		symbolRet    []byte
+		wantSymbol   string
+		wantDecimals int
+		wantErr      bool
+	}{
+		{name: "string symbol", decimalsRet: abiWord(18), symbolRet: abiString("DAI"), wantSymbol: "DAI", wantDecimals: 18},
+		{name: "bytes32 symbol", decimalsRet: abiWord(18), symbolRet: bytes32("MKR"), wantSymbol: "MKR", wantDecimals: 18},
+		{name: "zero decimals", decimalsRet: abiWord(0), symbolRet: abiString("NFT"), wantSymbol: "NFT", wantDecimals: 0},
+		{name: "empty decimals", decimalsRet: nil, symbolRet: abiString("X"), wantErr: true},
+		{name: "empty symbol", decimalsRet: abiWord(6), symbolRet: bytes32(""), wantErr: true},
+		{name: "symbol with whitespace", decimalsRet: abiWord(6), symbolRet: abiString("US DC"), wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			server := newTokenServer(t, tc.decimalsRet, tc.symbolRet)
+			defer server.Close()
+
+			client, err := NewClient(server.URL, nil)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+
+			meta, err := client.GetTokenMetadata(ctx, token)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidTokenMetadata)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, token, meta.Address)
+			assert.Equal(t, tc.wantSymbol, meta.Symbol)
+			assert.Equal(t, tc.wantDecimals, meta.Decimals)
+		})
+	}
+
+	t.Run("invalid token address", func(t *testing.T) {
+		t.Parallel()
+		client, err := NewClient("http://localhost:8545", nil)
+		require.NoError(t, err)
+
+		_, err = client.GetTokenMetadata(context.Background(), "0x123")
+		require.ErrorIs(t, err, ErrInvalidTokenAddress)
+	})
+}
+
+func TestDecodeTokenDecimals_TooLarge(t *testing.T) {
+	t.Parallel()
+
+	_, err := decodeTokenDecimals(abiWord(0)[:31])
+	require.ErrorIs(t, err, ErrInvalidTokenMetadata)
+
+	tooLarge := abiWord(0)
+	tooLarge[30] = 1 // 256
+	_, err = decodeTokenDecimals(tooLarge)
+	require.ErrorIs(t, err, ErrInvalidTokenMetadata)
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"golang.org/x/crypto/sha3"
 
@@ -12,6 +13,7 @@ import (
 	ethcrypto "github.com/mrz1836/sigil/internal/chain/eth/crypto"
 	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
 	ethtypes "github.com/mrz1836/sigil/internal/chain/eth/types"
+	"github.com/mrz1836/sigil/internal/metrics"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerrors "github.com/mrz1836/sigil/pkg/errors"
 )
@@ -180,12 +182,18 @@ func SignTransaction(tx *ethtypes.LegacyTx, privateKey []byte, chainID *big.Int)
 // It tries the primary RPC first, then the broadcast fallback (e.g. Etherscan),
 // then fallback RPCs. All errors are collected for diagnostics.
 func (c *Client) BroadcastTransaction(ctx context.Context, tx *ethtypes.LegacyTx) (string, error) {
+	return c.BroadcastRaw(ctx, tx.RawBytes())
+}
+
+// BroadcastRaw sends a serialized signed transaction to the network, with the
+// same failover as BroadcastTransaction.
+func (c *Client) BroadcastRaw(ctx context.Context, rawTx []byte) (string, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseBroadcast)()
+
 	if err := c.connect(ctx); err != nil {
 		return "", err
 	}
 
-	rawTx := tx.RawBytes()
-
 	// Collect all errors for diagnostics
 	var errs []error
 
@@ -269,19 +277,24 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 	// Determine if this is an ERC-20 or native transfer
 	var params *TxParams
 	var tokenSymbol string
+	amount := c.FormatAmount(req.Amount)
 
 	if req.Token != "" {
-		// ERC-20 transfer
+		// ERC-20 transfer, reported in the token's symbol and decimals
+		meta, metaErr := c.GetTokenMetadata(ctx, req.Token)
+		if metaErr != nil {
+			return nil, fmt.Errorf("reading token metadata: %w", metaErr)
+		}
 		var paramErr error
 		params, paramErr = NewERC20TransferParams(req.From, req.To, req.Token, req.Amount)
 		if paramErr != nil {
 			return nil, fmt.Errorf("building ERC-20 params: %w", paramErr)
 		}
-		tokenSymbol = "USDC" // Assume USDC for now, can be extended
+		tokenSymbol = meta.Symbol
+		amount = chain.FormatDecimalAmount(req.Amount, meta.Decimals)
 	} else {
 		// Native ETH transfer
 		params = NewETHTransferParams(req.From, req.To, req.Amount)
-		tokenSymbol = ""
 	}
 
 	// Get gas estimate
@@ -311,6 +324,13 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 		params.GasPrice = req.GasPrice
 	}
 
+	// Override nonce if specified (replaces or fills a gap at that nonce)
+	if req.Nonce != nil {
+		params.Nonce = *req.Nonce
+		params.NonceSet = true
+	}
+	reserved := !params.NonceSet
+
 	feeTotal := new(big.Int).Mul(params.GasPrice, new(big.Int).SetUint64(params.GasLimit))
 
 	// Build transaction
@@ -320,23 +340,36 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 	}
 
 	// Sign transaction (this zeros the private key)
+	endSign := metrics.Global.StartPhase(metrics.PhaseSign)
 	signedTx, err := SignTransaction(tx, req.PrivateKey, c.chainID)
+	endSign()
 	if err != nil {
+		c.releaseNonce(params, reserved)
 		return nil, fmt.Errorf("signing transaction: %w", err)
 	}
 
+	if req.BeforeBroadcast != nil {
+		signed := &chain.SignedTx{Hash: signedTx.HashHex(), Raw: signedTx.RawBytes(), Nonce: params.Nonce}
+		if err = req.BeforeBroadcast(signed); err != nil {
+			c.releaseNonce(params, reserved)
+			return nil, err
+		}
+	}
+
 	// Broadcast transaction
 	txHash, err := c.BroadcastTransaction(ctx, signedTx)
 	if err != nil {
+		c.releaseNonce(params, reserved)
 		return nil, err
 	}
+	c.recordNonce(params, txHash)
 
 	// Build result
 	result := &chain.TransactionResult{
 		Hash:     txHash,
 		From:     req.From,
 		To:       req.To,
-		Amount:   c.FormatAmount(req.Amount),
+		Amount:   amount,
 		Token:    tokenSymbol,
 		Fee:      c.FormatAmount(feeTotal),
 		GasUsed:  params.GasLimit,
@@ -347,6 +380,27 @@ func (c *Client) Send(ctx context.Context, req chain.SendRequest) (*chain.Transa
 	return result, nil
 }
 
+// releaseNonce frees the nonce reserved for params when its transaction was
+// not broadcast. Explicit nonces were never reserved.
+func (c *Client) releaseNonce(params *TxParams, reserved bool) {
+	if reserved {
+		c.nonceManager.Release(params.From, params.Nonce)
+	}
+}
+
+// recordNonce persists the nonce of a broadcast transaction so later sends
+// skip it until the node reports it. A failed write only loses that hint.
+func (c *Client) recordNonce(params *TxParams, hash string) {
+	if c.nonceStore == nil {
+		return
+	}
+	_ = c.nonceStore.Record(params.ChainID, params.From, PendingNonce{
+		Nonce:  params.Nonce,
+		Hash:   hash,
+		SentAt: time.Now(),
+	})
+}
+
 // DeriveAddress derives an Ethereum address from a private key.
 func DeriveAddress(privateKey []byte) (string, error) {
 	addrBytes, err := ethcrypto.DeriveAddress(privateKey)
@@ -1,12 +1,16 @@
 package eth
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -575,7 +579,8 @@ func newSignedTestTx(t *testing.T) *ethtypes.LegacyTx {
 
 // newFullRPCServer creates a test JSON-RPC server that handles all methods
 // needed by Send(): eth_chainId, eth_gasPrice, eth_estimateGas,
-// eth_getTransactionCount, and eth_sendRawTransaction.
+// eth_getTransactionCount, and eth_sendRawTransaction. eth_call answers
+// decimals() and symbol() as USDC.
 func newFullRPCServer(t *testing.T, gasPrice, txHash string) *httptest.Server {
 	t.Helper()
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -604,6 +609,14 @@ func newFullRPCServer(t *testing.T, gasPrice, txHash string) *httptest.Server {
 		case "eth_sendRawTransaction":
 			resp := map[string]any{"jsonrpc": "2.0", "id": id, "result": txHash}
 			_ = json.NewEncoder(w).Encode(resp)
+		case "eth_call":
+			result := "0x" + hex.EncodeToString(abiString("USDC"))
+			params, _ := req["params"].([]any)
+			if msg, _ := params[0].(map[string]any); strings.HasPrefix(msg["data"].(string), "0x313ce567") {
+				result = "0x" + hex.EncodeToString(abiWord(6))
+			}
+			resp := map[string]any{"jsonrpc": "2.0", "id": id, "result": result}
+			_ = json.NewEncoder(w).Encode(resp)
 		default:
 			resp := map[string]any{
 				"jsonrpc": "2.0",
@@ -698,6 +711,59 @@ func TestSend_NilGasPriceUsesRPCPrice(t *testing.T) {
 	assert.Contains(t, result.GasPrice, "20")
 }
 
+func TestSend_BeforeBroadcast(t *testing.T) {
+	t.Parallel()
+
+	expectedHash := "0xdeadbeef1234567890abcdef1234567890abcdef1234567890abcdef12345678"
+	srv := newFullRPCServer(t, "0x4a817c800", expectedHash)
+	defer srv.Close()
+
+	client, err := NewClient(srv.URL, &ClientOptions{ChainID: big.NewInt(1)})
+	require.NoError(t, err)
+	defer client.Close()
+
+	key := []byte{
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08,
+		0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f, 0x10,
+		0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18,
+		0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f, 0x20,
+	}
+	from, err := DeriveAddress(key)
+	require.NoError(t, err)
+
+	send := func(hook func(*chain.SignedTx) error) (*chain.TransactionResult, error) {
+		return client.Send(context.Background(), chain.SendRequest{
+			From:            from,
+			To:              "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+			Amount:          big.NewInt(1000000000000000000),
+			PrivateKey:      bytes.Clone(key),
+			BeforeBroadcast: hook,
+		})
+	}
+
+	hookErr := errors.New("dry run")
+	var aborted *chain.SignedTx
+	_, err = send(func(tx *chain.SignedTx) error {
+		aborted = tx
+		return hookErr
+	})
+	require.ErrorIs(t, err, hookErr)
+	require.NotNil(t, aborted)
+	assert.NotEmpty(t, aborted.Raw)
+	assert.True(t, strings.HasPrefix(aborted.Hash, "0x"))
+
+	// The aborted send released its nonce
+	var signed *chain.SignedTx
+	result, err := send(func(tx *chain.SignedTx) error {
+		signed = tx
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expectedHash, result.Hash)
+	require.NotNil(t, signed)
+	assert.Equal(t, aborted.Nonce, signed.Nonce)
+}
+
 func TestSend_ERC20GasPriceOverride(t *testing.T) {
 	t.Parallel()
 
@@ -741,8 +807,9 @@ func TestSend_ERC20GasPriceOverride(t *testing.T) {
 	assert.Contains(t, result.GasPrice, "25")
 	assert.NotContains(t, result.GasPrice, "50")
 
-	// Token should be identified as USDC
+	// The token is reported with the symbol and decimals of its contract
 	assert.Equal(t, "USDC", result.Token)
+	assert.Equal(t, "1.0", result.Amount)
 }
 
 func TestBroadcastTransaction_PrimarySuccess(t *testing.T) {
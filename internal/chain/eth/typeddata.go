@@ -0,0 +1,435 @@
+package eth
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/mrz1836/sigil/internal/chain/eth/crypto"
+)
+
+// ErrInvalidTypedData indicates EIP-712 typed data that cannot be hashed:
+// malformed JSON, an unknown type, or a value that does not fit its type.
+var ErrInvalidTypedData = errors.New("invalid typed data")
+
+// domainType is the EIP-712 type name of the signing domain.
+const domainType = "EIP712Domain"
+
+// TypedDataField is one member of an EIP-712 struct type.
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedData is an EIP-712 typed data document, in the JSON form taken by
+// eth_signTypedData_v4.
+type TypedData struct {
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      map[string]any              `json:"domain"`
+	Message     map[string]any              `json:"message"`
+}
+
+// domainFields are the EIP712Domain members in their canonical order, used
+// when a document leaves the domain type out.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var domainFields = []TypedDataField{
+	{Name: "name", Type: "string"},
+	{Name: "version", Type: "string"},
+	{Name: "chainId", Type: "uint256"},
+	{Name: "verifyingContract", Type: "address"},
+	{Name: "salt", Type: "bytes32"},
+}
+
+// ParseTypedData decodes and checks an EIP-712 JSON document. Numbers are
+// kept exact, so uint256 values survive decoding. When the document has no
+// EIP712Domain type, one is built from the domain's members.
+func ParseTypedData(data []byte) (*TypedData, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var td TypedData
+	if err := dec.Decode(&td); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTypedData, err)
+	}
+	if td.Types == nil {
+		return nil, fmt.Errorf("%w: missing types", ErrInvalidTypedData)
+	}
+	if _, ok := td.Types[domainType]; !ok {
+		fields, err := inferDomainType(td.Domain)
+		if err != nil {
+			return nil, err
+		}
+		td.Types[domainType] = fields
+	}
+	if err := td.validate(); err != nil {
+		return nil, err
+	}
+	return &td, nil
+}
+
+// inferDomainType returns the EIP712Domain fields present in domain.
+func inferDomainType(domain map[string]any) ([]TypedDataField, error) {
+	fields := make([]TypedDataField, 0, len(domainFields))
+	for _, f := range domainFields {
+		if _, ok := domain[f.Name]; ok {
+			fields = append(fields, f)
+		}
+	}
+	if len(fields) != len(domain) {
+		return nil, fmt.Errorf("%w: domain has members outside EIP712Domain; declare the type", ErrInvalidTypedData)
+	}
+	return fields, nil
+}
+
+// validate checks that the primary type exists and that every field has a
+// known type and a unique name.
+func (td *TypedData) validate() error {
+	if td.PrimaryType == "" || td.PrimaryType == domainType {
+		return fmt.Errorf("%w: primaryType must name a message type", ErrInvalidTypedData)
+	}
+	if _, ok := td.Types[td.PrimaryType]; !ok {
+		return fmt.Errorf("%w: primaryType %q is not defined in types", ErrInvalidTypedData, td.PrimaryType)
+	}
+	for name, fields := range td.Types {
+		seen := make(map[string]bool, len(fields))
+		for _, f := range fields {
+			if f.Name == "" || seen[f.Name] {
+				return fmt.Errorf("%w: type %s has an empty or repeated field name %q", ErrInvalidTypedData, name, f.Name)
+			}
+			seen[f.Name] = true
+			base := baseType(f.Type)
+			if _, ok := td.Types[base]; !ok && !isAtomicType(base) {
+				return fmt.Errorf("%w: field %s.%s has unknown type %q", ErrInvalidTypedData, name, f.Name, f.Type)
+			}
+		}
+	}
+	return nil
+}
+
+// Hash returns the EIP-712 signing digest:
+// keccak256(0x19 0x01 || domainSeparator || hashStruct(message)).
+func (td *TypedData) Hash() ([]byte, error) {
+	domain, err := td.DomainSeparator()
+	if err != nil {
+		return nil, err
+	}
+	message, err := td.HashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, err
+	}
+	return ethcrypto.Keccak256([]byte{0x19, 0x01}, domain, message), nil
+}
+
+// DomainSeparator returns hashStruct of the domain.
+func (td *TypedData) DomainSeparator() ([]byte, error) {
+	return td.HashStruct(domainType, td.Domain)
+}
+
+// HashStruct returns keccak256(typeHash || encodeData) of a value of the
+// named struct type.
+func (td *TypedData) HashStruct(typeName string, data map[string]any) ([]byte, error) {
+	fields, ok := td.Types[typeName]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidTypedData, typeName)
+	}
+	for key := range data {
+		if !slices.ContainsFunc(fields, func(f TypedDataField) bool { return f.Name == key }) {
+			return nil, fmt.Errorf("%w: %s has no field %q", ErrInvalidTypedData, typeName, key)
+		}
+	}
+
+	enc := make([]byte, 0, 32*(len(fields)+1))
+	enc = append(enc, td.TypeHash(typeName)...)
+	for _, f := range fields {
+		v, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s.%s is missing", ErrInvalidTypedData, typeName, f.Name)
+		}
+		word, err := td.encodeValue(f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s.%s: %w", typeName, f.Name, err)
+		}
+		enc = append(enc, word...)
+	}
+	return ethcrypto.Keccak256(enc), nil
+}
+
+// TypeHash returns keccak256 of EncodeType.
+func (td *TypedData) TypeHash(typeName string) []byte {
+	return ethcrypto.Keccak256([]byte(td.EncodeType(typeName)))
+}
+
+// EncodeType returns the EIP-712 type string: the named type followed by
+// every struct type it references, sorted by name, e.g.
+// "Mail(Person from,Person to,string contents)Person(string name,address wallet)".
+func (td *TypedData) EncodeType(typeName string) string {
+	deps := map[string]bool{}
+	td.collectDeps(typeName, deps)
+	delete(deps, typeName)
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	for _, name := range append([]string{typeName}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, f := range td.Types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.Type)
+			b.WriteByte(' ')
+			b.WriteString(f.Name)
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
+
+// collectDeps adds typeName and the struct types it references to deps.
+func (td *TypedData) collectDeps(typeName string, deps map[string]bool) {
+	if deps[typeName] {
+		return
+	}
+	fields, ok := td.Types[typeName]
+	if !ok {
+		return
+	}
+	deps[typeName] = true
+	for _, f := range fields {
+		td.collectDeps(baseType(f.Type), deps)
+	}
+}
+
+// encodeValue returns the 32-byte encoding of v as typ.
+func (td *TypedData) encodeValue(typ string, v any) ([]byte, error) {
+	if inner, isArray := splitArrayType(typ); isArray {
+		return td.encodeArray(typ, inner, v)
+	}
+	if _, ok := td.Types[typ]; ok {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected an object of type %s", ErrInvalidTypedData, typ)
+		}
+		return td.HashStruct(typ, m)
+	}
+	return encodeAtomic(typ, v)
+}
+
+// encodeArray hashes the concatenated encodings of an array's elements.
+func (td *TypedData) encodeArray(typ, inner string, v any) ([]byte, error) {
+	items, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected an array for %s", ErrInvalidTypedData, typ)
+	}
+	if n := typ[strings.LastIndexByte(typ, '[')+1 : len(typ)-1]; n != "" {
+		if size, err := strconv.Atoi(n); err != nil || size != len(items) {
+			return nil, fmt.Errorf("%w: %s needs %s elements, got %d", ErrInvalidTypedData, typ, n, len(items))
+		}
+	}
+	enc := make([]byte, 0, 32*len(items))
+	for i, item := range items {
+		word, err := td.encodeValue(inner, item)
+		if err != nil {
+			return nil, fmt.Errorf("[%d]: %w", i, err)
+		}
+		enc = append(enc, word...)
+	}
+	return ethcrypto.Keccak256(enc), nil
+}
+
+// splitArrayType strips the last array suffix from typ, so "uint256[][2]"
+// gives "uint256[]".
+func splitArrayType(typ string) (string, bool) {
+	if !strings.HasSuffix(typ, "]") {
+		return typ, false
+	}
+	i := strings.LastIndexByte(typ, '[')
+	if i <= 0 {
+		return typ, false
+	}
+	return typ[:i], true
+}
+
+// baseType strips every array suffix from typ.
+func baseType(typ string) string {
+	for {
+		inner, isArray := splitArrayType(typ)
+		if !isArray {
+			return typ
+		}
+		typ = inner
+	}
+}
+
+// isAtomicType reports whether typ is a Solidity type EIP-712 encodes
+// directly.
+func isAtomicType(typ string) bool {
+	switch typ {
+	case "address", "bool", "string", "bytes":
+		return true
+	}
+	if n, ok := strings.CutPrefix(typ, "bytes"); ok {
+		size, err := strconv.Atoi(n)
+		return err == nil && size >= 1 && size <= 32 && n == strconv.Itoa(size)
+	}
+	_, ok := intBits(typ)
+	return ok
+}
+
+// intBits returns the size of a uintN or intN type.
+func intBits(typ string) (int, bool) {
+	n, ok := strings.CutPrefix(typ, "uint")
+	if !ok {
+		n, ok = strings.CutPrefix(typ, "int")
+	}
+	if !ok {
+		return 0, false
+	}
+	bits, err := strconv.Atoi(n)
+	if err != nil || bits < 8 || bits > 256 || bits%8 != 0 || n != strconv.Itoa(bits) {
+		return 0, false
+	}
+	return bits, true
+}
+
+// encodeAtomic encodes a value of an atomic type as one 32-byte word.
+// Dynamic types, string and bytes, are replaced by their hash.
+func encodeAtomic(typ string, v any) ([]byte, error) {
+	switch typ {
+	case "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected a string", ErrInvalidTypedData)
+		}
+		return ethcrypto.Keccak256([]byte(s)), nil
+
+	case "bytes":
+		b, err := typedHexBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		return ethcrypto.Keccak256(b), nil
+
+	case "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: expected true or false", ErrInvalidTypedData)
+		}
+		word := make([]byte, 32)
+		if b {
+			word[31] = 1
+		}
+		return word, nil
+
+	case "address":
+		s, ok := v.(string)
+		if !ok || !IsValidAddress(s) {
+			return nil, fmt.Errorf("%w: expected a 0x-prefixed 20-byte address", ErrInvalidTypedData)
+		}
+		addr, err := hex.DecodeString(s[2:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidTypedData, err)
+		}
+		return ethcrypto.LeftPadBytes(addr, 32), nil
+	}
+
+	if n, ok := strings.CutPrefix(typ, "bytes"); ok {
+		size, _ := strconv.Atoi(n)
+		b, err := typedHexBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > size {
+			return nil, fmt.Errorf("%w: %d bytes do not fit %s", ErrInvalidTypedData, len(b), typ)
+		}
+		word := make([]byte, 32)
+		copy(word, b)
+		return word, nil
+	}
+
+	return encodeInt(typ, v)
+}
+
+// encodeInt encodes a uintN or intN value, given as a JSON number or a
+// decimal or 0x-hex string, as a big-endian two's complement word.
+func encodeInt(typ string, v any) ([]byte, error) {
+	bits, ok := intBits(typ)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown type %q", ErrInvalidTypedData, typ)
+	}
+
+	var s string
+	switch val := v.(type) {
+	case json.Number:
+		s = val.String()
+	case string:
+		s = strings.TrimSpace(val)
+	default:
+		return nil, fmt.Errorf("%w: expected an integer for %s", ErrInvalidTypedData, typ)
+	}
+	n, ok := parseTypedInt(s)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q is not an integer", ErrInvalidTypedData, s)
+	}
+
+	limit := new(big.Int).Lsh(big.NewInt(1), uint(bits)) //nolint:gosec // bits is 8..256
+	if strings.HasPrefix(typ, "int") {
+		limit.Rsh(limit, 1)
+		if n.Cmp(limit) >= 0 || n.Cmp(new(big.Int).Neg(limit)) < 0 {
+			return nil, fmt.Errorf("%w: %s does not fit %s", ErrInvalidTypedData, s, typ)
+		}
+	} else if n.Sign() < 0 || n.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("%w: %s does not fit %s", ErrInvalidTypedData, s, typ)
+	}
+
+	if n.Sign() < 0 {
+		n.Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+	return n.FillBytes(make([]byte, 32)), nil
+}
+
+// parseTypedInt parses a decimal or 0x-prefixed hex integer, optionally
+// negative.
+func parseTypedInt(s string) (*big.Int, bool) {
+	neg := strings.HasPrefix(s, "-")
+	s = strings.TrimPrefix(s, "-")
+	base := 10
+	if h, ok := strings.CutPrefix(strings.ToLower(s), "0x"); ok {
+		s, base = h, 16
+	}
+	if s == "" || strings.HasPrefix(s, "+") {
+		return nil, false
+	}
+	n, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return nil, false
+	}
+	if neg {
+		n.Neg(n)
+	}
+	return n, true
+}
+
+// typedHexBytes decodes a 0x-prefixed hex string.
+func typedHexBytes(v any) ([]byte, error) {
+	s, ok := v.(string)
+	if !ok || !strings.HasPrefix(s, "0x") {
+		return nil, fmt.Errorf("%w: expected 0x-prefixed hex bytes", ErrInvalidTypedData)
+	}
+	b, err := hex.DecodeString(s[2:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTypedData, err)
+	}
+	return b, nil
+}
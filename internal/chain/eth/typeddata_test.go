@@ -0,0 +1,199 @@
+package eth
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mailTypedData is the example from the EIP-712 specification; the expected
+// hashes below are the ones the specification's reference code prints.
+const mailTypedData = `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [
+      {"name": "name", "type": "string"},
+      {"name": "wallet", "type": "address"}
+    ],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person"},
+      {"name": "contents", "type": "string"}
+    ]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`
+
+func hex0x(b []byte) string {
+	return "0x" + hex.EncodeToString(b)
+}
+
+func TestTypedData_Mail(t *testing.T) {
+	t.Parallel()
+
+	td, err := ParseTypedData([]byte(mailTypedData))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Mail(Person from,Person to,string contents)Person(string name,address wallet)", td.EncodeType("Mail"))
+	assert.Equal(t, "0xa0cedeb2dc280ba39b857546d74f5549c3a1d7bdc2dd96bf881f76108e23dac2", hex0x(td.TypeHash("Mail")))
+
+	domain, err := td.DomainSeparator()
+	require.NoError(t, err)
+	assert.Equal(t, "0xf2cee375fa42b42143804025fc449deafd50cc031ca257e0b194a650a912090f", hex0x(domain))
+
+	message, err := td.HashStruct("Mail", td.Message)
+	require.NoError(t, err)
+	assert.Equal(t, "0xc52c0ee5d84264471806290a3f2c4cecfc5490626bf912d01f240d7a274b371e", hex0x(message))
+
+	digest, err := td.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex0x(digest))
+}
+
+func TestTypedData_Arrays(t *testing.T) {
+	t.Parallel()
+
+	// The eth_signTypedData_v4 array example from MetaMask's eth-sig-util
+	doc := `{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Group": [{"name": "name", "type": "string"}, {"name": "members", "type": "Person[]"}],
+    "Mail": [
+      {"name": "from", "type": "Person"},
+      {"name": "to", "type": "Person[]"},
+      {"name": "contents", "type": "string"}
+    ],
+    "Person": [{"name": "name", "type": "string"}, {"name": "wallets", "type": "address[]"}]
+  },
+  "primaryType": "Mail",
+  "domain": {
+    "name": "Ether Mail",
+    "version": "1",
+    "chainId": 1,
+    "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"
+  },
+  "message": {
+    "from": {
+      "name": "Cow",
+      "wallets": ["0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", "0xDeaDbeefdEAdbeefdEadbEEFdeadbeEFdEaDbeeF"]
+    },
+    "to": [{
+      "name": "Bob",
+      "wallets": [
+        "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+        "0xB0BdaBea57B0BDABeA57b0bdABEA57b0BDabEa57",
+        "0xB0B0b0b0b0b0B000000000000000000000000000"
+      ]
+    }],
+    "contents": "Hello, Bob!"
+  }
+}`
+	td, err := ParseTypedData([]byte(doc))
+	require.NoError(t, err)
+	assert.Equal(t, "Mail(Person from,Person[] to,string contents)Person(string name,address[] wallets)", td.EncodeType("Mail"))
+	assert.Equal(t, "Group(string name,Person[] members)Person(string name,address[] wallets)", td.EncodeType("Group"))
+
+	digest, err := td.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, "0xa85c2e2b118698e88db68a8105b794a8cc7cec074e89ef991cb4f5f533819cc2", hex0x(digest))
+}
+
+func TestTypedData_InferredDomainType(t *testing.T) {
+	t.Parallel()
+
+	// Without EIP712Domain in types, the type is built from the domain in
+	// canonical order, as ethers does
+	doc := strings.Replace(mailTypedData, `"EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],`, "", 1)
+	td, err := ParseTypedData([]byte(doc))
+	require.NoError(t, err)
+	digest, err := td.Hash()
+	require.NoError(t, err)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex0x(digest))
+}
+
+func TestTypedData_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		old  string
+		new  string
+	}{
+		{"not json", mailTypedData, "{"},
+		{"unknown primary type", `"primaryType": "Mail"`, `"primaryType": "Letter"`},
+		{"unknown field type", `"type": "Person"}`, `"type": "Human"}`},
+		{"missing field", `"contents": "Hello, Bob!"`, `"body": "Hello, Bob!"`},
+		{"bad address", `"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"`, `"0xbBbB"`},
+		{"number for string", `"contents": "Hello, Bob!"`, `"contents": 5`},
+		{"fraction", `"chainId": 1,`, `"chainId": 1.5,`},
+		{"negative uint", `"chainId": 1,`, `"chainId": -1,`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			td, err := ParseTypedData([]byte(strings.Replace(mailTypedData, tc.old, tc.new, 1)))
+			if err == nil {
+				_, err = td.Hash()
+			}
+			require.ErrorIs(t, err, ErrInvalidTypedData)
+		})
+	}
+}
+
+func TestEncodeAtomic(t *testing.T) {
+	t.Parallel()
+
+	word := func(s string) string { return "0x" + strings.Repeat("0", 64-len(s)) + s }
+
+	tests := []struct {
+		typ   string
+		value any
+		want  string
+	}{
+		{"uint8", "255", word("ff")},
+		{"uint256", "0x10", word("10")},
+		{"int8", "-1", "0x" + strings.Repeat("f", 64)},
+		{"int256", "-2", "0x" + strings.Repeat("f", 63) + "e"},
+		{"bool", true, word("1")},
+		{"bytes4", "0xdeadbeef", "0xdeadbeef" + strings.Repeat("0", 56)},
+	}
+	for _, tc := range tests {
+		got, err := encodeAtomic(tc.typ, tc.value)
+		require.NoError(t, err, tc.typ)
+		assert.Equal(t, tc.want, hex0x(got), tc.typ)
+	}
+
+	for typ, value := range map[string]any{"uint8": "256", "int8": "128", "bytes2": "0xdeadbeef", "uint7": "1"} {
+		_, err := encodeAtomic(typ, value)
+		require.ErrorIs(t, err, ErrInvalidTypedData, typ)
+	}
+}
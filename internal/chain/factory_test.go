@@ -129,8 +129,8 @@ func TestDefaultFactory_NewChain(t *testing.T) {
 		}
 	})
 
-	t.Run("future chain BTC returns ErrUnsupportedChain", func(t *testing.T) {
-		_, err := factory.NewChain(context.Background(), BTC, "http://localhost")
+	t.Run("future chain LTC returns ErrUnsupportedChain", func(t *testing.T) {
+		_, err := factory.NewChain(context.Background(), LTC, "http://localhost")
 		if !errors.Is(err, ErrUnsupportedChain) {
 			t.Errorf("NewChain() error = %v, want %v", err, ErrUnsupportedChain)
 		}
@@ -145,8 +145,9 @@ func TestIsSupportedChain(t *testing.T) {
 	}{
 		{"ETH", ETH, true},
 		{"BSV", BSV, true},
-		{"BTC", BTC, false},
-		{"BCH", BCH, false},
+		{"BTC", BTC, true},
+		{"BCH", BCH, true},
+		{"LTC", LTC, false},
 		{"unknown", ID("unknown"), false},
 		{"empty", ID(""), false},
 	}
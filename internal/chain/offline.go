@@ -0,0 +1,265 @@
+package chain
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// OfflineTxVersion is the format version of UnsignedTx and SignedRawTx.
+// Decoding rejects files written by a newer version.
+const OfflineTxVersion = 1
+
+// ErrInvalidOfflineTx indicates an unsigned or signed transaction file is
+// malformed, inconsistent, or from an unsupported format version.
+var ErrInvalidOfflineTx = &sigilerr.SigilError{
+	Code:     "INVALID_OFFLINE_TX",
+	Message:  "invalid offline transaction",
+	ExitCode: sigilerr.ExitInput,
+}
+
+// UnsignedTx is a transaction built on an online machine so it can be signed
+// on an offline one. It carries everything the signer needs without network
+// access: the ETH nonce, gas, and chain ID, or the BSV inputs and outputs.
+// Amounts are decimal strings in the chain's smallest unit.
+type UnsignedTx struct {
+	Version   int       `json:"version"`
+	Chain     ID        `json:"chain"`
+	Network   string    `json:"network,omitempty"` // Network ("main" or "test"); ETH test is Sepolia
+	Wallet    string    `json:"wallet,omitempty"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Amount    string    `json:"amount"`
+	Fee       string    `json:"fee"`
+	Token     string    `json:"token,omitempty"` // ERC-20 contract address
+	Symbol    string    `json:"symbol"`
+	Decimals  int       `json:"decimals"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ETH *UnsignedETH `json:"eth,omitempty"`
+	BSV *UnsignedBSV `json:"bsv,omitempty"`
+}
+
+// UnsignedETH holds the fields of an unsigned legacy ETH transaction.
+type UnsignedETH struct {
+	ChainID  string `json:"chain_id"`
+	Nonce    uint64 `json:"nonce"`
+	To       string `json:"to"` // token contract for ERC-20 transfers
+	Value    string `json:"value"`
+	GasLimit uint64 `json:"gas_limit"`
+	GasPrice string `json:"gas_price"`
+	Data     string `json:"data,omitempty"` // hex-encoded call data
+}
+
+// UnsignedBSV holds the inputs and outputs of an unsigned BSV transaction.
+type UnsignedBSV struct {
+	FeeRate uint64          `json:"fee_rate"` // satoshis per kilobyte
+	Inputs  []OfflineInput  `json:"inputs"`
+	Outputs []OfflineOutput `json:"outputs"`
+}
+
+// OfflineInput is an output an unsigned BSV transaction spends. Address
+// selects the wallet key that signs it.
+type OfflineInput struct {
+	TxID         string `json:"txid"`
+	Vout         uint32 `json:"vout"`
+	Amount       uint64 `json:"amount"`
+	ScriptPubKey string `json:"script_pub_key,omitempty"`
+	Address      string `json:"address"`
+}
+
+// OfflineOutput is an output an unsigned BSV transaction creates.
+type OfflineOutput struct {
+	Address string `json:"address"`
+	Amount  uint64 `json:"amount"`
+}
+
+// SignedRawTx is a signed transaction ready to broadcast from any machine,
+// with the summary of the UnsignedTx it was signed from.
+type SignedRawTx struct {
+	Version  int    `json:"version"`
+	Chain    ID     `json:"chain"`
+	Network  string `json:"network,omitempty"`
+	Hash     string `json:"hash"`
+	Hex      string `json:"hex"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Amount   string `json:"amount"`
+	Fee      string `json:"fee"`
+	Token    string `json:"token,omitempty"`
+	Symbol   string `json:"symbol"`
+	Decimals int    `json:"decimals"`
+}
+
+// DecodeUnsignedTx parses and validates an UnsignedTx.
+func DecodeUnsignedTx(data []byte) (*UnsignedTx, error) {
+	var tx UnsignedTx
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOfflineTx, err)
+	}
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// DecodeSignedRawTx parses and validates a SignedRawTx.
+func DecodeSignedRawTx(data []byte) (*SignedRawTx, error) {
+	var tx SignedRawTx
+	if err := json.Unmarshal(data, &tx); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidOfflineTx, err)
+	}
+	if err := tx.Validate(); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// Validate checks that the transaction is complete and self-consistent.
+func (tx *UnsignedTx) Validate() error {
+	if err := checkOfflineHeader(tx.Version, tx.Chain); err != nil {
+		return err
+	}
+	if tx.From == "" || tx.To == "" {
+		return invalidOfflineTx("from and to are required")
+	}
+	amount, err := parseUnits("amount", tx.Amount)
+	if err != nil {
+		return err
+	}
+	fee, err := parseUnits("fee", tx.Fee)
+	if err != nil {
+		return err
+	}
+
+	switch tx.Chain {
+	case ETH:
+		if tx.BSV != nil || tx.ETH == nil {
+			return invalidOfflineTx("eth transaction must carry only eth fields")
+		}
+		return tx.ETH.validate()
+	case BSV:
+		if tx.ETH != nil || tx.BSV == nil {
+			return invalidOfflineTx("bsv transaction must carry only bsv fields")
+		}
+		return tx.BSV.validate(amount, fee)
+	default:
+		return invalidOfflineTx(fmt.Sprintf("unsupported chain %q", tx.Chain))
+	}
+}
+
+// validate checks the ETH fields are present and numeric.
+func (e *UnsignedETH) validate() error {
+	if e.To == "" {
+		return invalidOfflineTx("eth.to is required")
+	}
+	if e.GasLimit == 0 {
+		return invalidOfflineTx("eth.gas_limit must be positive")
+	}
+	if _, err := parseUnits("eth.chain_id", e.ChainID); err != nil {
+		return err
+	}
+	if _, err := parseUnits("eth.value", e.Value); err != nil {
+		return err
+	}
+	if _, err := parseUnits("eth.gas_price", e.GasPrice); err != nil {
+		return err
+	}
+	if _, err := hex.DecodeString(strings.TrimPrefix(e.Data, "0x")); err != nil {
+		return invalidOfflineTx("eth.data is not hex")
+	}
+	return nil
+}
+
+// validate checks the inputs cover the outputs and that the summary amount
+// and fee match them.
+func (b *UnsignedBSV) validate(amount, fee *big.Int) error {
+	if len(b.Inputs) == 0 {
+		return invalidOfflineTx("bsv transaction has no inputs")
+	}
+	if len(b.Outputs) == 0 {
+		return invalidOfflineTx("bsv transaction has no outputs")
+	}
+
+	in := new(big.Int)
+	for i, input := range b.Inputs {
+		if input.TxID == "" || input.Address == "" {
+			return invalidOfflineTx(fmt.Sprintf("bsv input %d needs a txid and address", i))
+		}
+		in.Add(in, AmountToBigInt(input.Amount))
+	}
+	out := new(big.Int)
+	for i, output := range b.Outputs {
+		if output.Address == "" {
+			return invalidOfflineTx(fmt.Sprintf("bsv output %d has no address", i))
+		}
+		out.Add(out, AmountToBigInt(output.Amount))
+	}
+
+	if in.Cmp(out) < 0 {
+		return invalidOfflineTx("bsv outputs exceed inputs")
+	}
+	if new(big.Int).Sub(in, out).Cmp(fee) != 0 {
+		return invalidOfflineTx("bsv fee does not match inputs minus outputs")
+	}
+	if AmountToBigInt(b.Outputs[0].Amount).Cmp(amount) != 0 {
+		return invalidOfflineTx("bsv amount does not match the first output")
+	}
+	return nil
+}
+
+// Validate checks that the signed transaction is complete.
+func (tx *SignedRawTx) Validate() error {
+	if err := checkOfflineHeader(tx.Version, tx.Chain); err != nil {
+		return err
+	}
+	if tx.Chain != ETH && tx.Chain != BSV {
+		return invalidOfflineTx(fmt.Sprintf("unsupported chain %q", tx.Chain))
+	}
+	if tx.Hash == "" {
+		return invalidOfflineTx("hash is required")
+	}
+	if _, err := tx.Raw(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Raw returns the serialized signed transaction.
+func (tx *SignedRawTx) Raw() ([]byte, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(tx.Hex, "0x"))
+	if err != nil || len(raw) == 0 {
+		return nil, invalidOfflineTx("hex is not a serialized transaction")
+	}
+	return raw, nil
+}
+
+// checkOfflineHeader validates the version and chain shared by both formats.
+func checkOfflineHeader(version int, id ID) error {
+	if version < 1 || version > OfflineTxVersion {
+		return invalidOfflineTx(fmt.Sprintf("unsupported version %d (this sigil reads version %d)", version, OfflineTxVersion))
+	}
+	if id == "" {
+		return invalidOfflineTx("chain is required")
+	}
+	return nil
+}
+
+// parseUnits parses a non-negative decimal integer field.
+func parseUnits(field, value string) (*big.Int, error) {
+	n, ok := new(big.Int).SetString(value, 10)
+	if !ok || n.Sign() < 0 {
+		return nil, invalidOfflineTx(fmt.Sprintf("%s must be a non-negative integer, got %q", field, value))
+	}
+	return n, nil
+}
+
+// invalidOfflineTx wraps ErrInvalidOfflineTx with a reason.
+func invalidOfflineTx(reason string) error {
+	return fmt.Errorf("%w: %s", ErrInvalidOfflineTx, reason)
+}
@@ -0,0 +1,141 @@
+package chain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func validUnsignedBSV() *UnsignedTx {
+	return &UnsignedTx{
+		Version:   OfflineTxVersion,
+		Chain:     BSV,
+		Network:   "main",
+		From:      "1From",
+		To:        "1To",
+		Amount:    "5000",
+		Fee:       "100",
+		Symbol:    "BSV",
+		Decimals:  8,
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		BSV: &UnsignedBSV{
+			FeeRate: 100,
+			Inputs:  []OfflineInput{{TxID: "aa", Vout: 1, Amount: 8000, Address: "1From"}},
+			Outputs: []OfflineOutput{{Address: "1To", Amount: 5000}, {Address: "1From", Amount: 2900}},
+		},
+	}
+}
+
+func validUnsignedETH() *UnsignedTx {
+	return &UnsignedTx{
+		Version:  OfflineTxVersion,
+		Chain:    ETH,
+		From:     "0xFrom",
+		To:       "0xTo",
+		Amount:   "1000000",
+		Fee:      "21000000000000",
+		Token:    "0xToken",
+		Symbol:   "USDC",
+		Decimals: 6,
+		ETH: &UnsignedETH{
+			ChainID:  "1",
+			Nonce:    7,
+			To:       "0xToken",
+			Value:    "0",
+			GasLimit: 65000,
+			GasPrice: "1000000000",
+			Data:     "a9059cbb",
+		},
+	}
+}
+
+func TestUnsignedTx_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, tx := range []*UnsignedTx{validUnsignedBSV(), validUnsignedETH()} {
+		data, err := json.Marshal(tx)
+		require.NoError(t, err)
+
+		got, err := DecodeUnsignedTx(data)
+		require.NoError(t, err)
+		assert.Equal(t, tx, got)
+	}
+}
+
+func TestUnsignedTx_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		mutate func(tx *UnsignedTx)
+	}{
+		{name: "future version", mutate: func(tx *UnsignedTx) { tx.Version = OfflineTxVersion + 1 }},
+		{name: "no version", mutate: func(tx *UnsignedTx) { tx.Version = 0 }},
+		{name: "unknown chain", mutate: func(tx *UnsignedTx) { tx.Chain = BTC }},
+		{name: "missing recipient", mutate: func(tx *UnsignedTx) { tx.To = "" }},
+		{name: "negative amount", mutate: func(tx *UnsignedTx) { tx.Amount = "-1" }},
+		{name: "decimal fee", mutate: func(tx *UnsignedTx) { tx.Fee = "0.1" }},
+		{name: "eth fields on bsv", mutate: func(tx *UnsignedTx) { tx.ETH = validUnsignedETH().ETH }},
+		{name: "no inputs", mutate: func(tx *UnsignedTx) { tx.BSV.Inputs = nil }},
+		{name: "no outputs", mutate: func(tx *UnsignedTx) { tx.BSV.Outputs = nil }},
+		{name: "input without address", mutate: func(tx *UnsignedTx) { tx.BSV.Inputs[0].Address = "" }},
+		{name: "outputs exceed inputs", mutate: func(tx *UnsignedTx) { tx.BSV.Outputs[1].Amount = 9000 }},
+		{name: "fee mismatch", mutate: func(tx *UnsignedTx) { tx.Fee = "1" }},
+		{name: "amount mismatch", mutate: func(tx *UnsignedTx) { tx.Amount = "4999" }},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tx := validUnsignedBSV()
+			tc.mutate(tx)
+			require.ErrorIs(t, tx.Validate(), ErrInvalidOfflineTx)
+		})
+	}
+
+	t.Run("eth", func(t *testing.T) {
+		t.Parallel()
+		require.NoError(t, validUnsignedETH().Validate())
+
+		for _, mutate := range []func(e *UnsignedETH){
+			func(e *UnsignedETH) { e.GasLimit = 0 },
+			func(e *UnsignedETH) { e.GasPrice = "" },
+			func(e *UnsignedETH) { e.ChainID = "mainnet" },
+			func(e *UnsignedETH) { e.Data = "zz" },
+			func(e *UnsignedETH) { e.To = "" },
+		} {
+			tx := validUnsignedETH()
+			mutate(tx.ETH)
+			require.ErrorIs(t, tx.Validate(), ErrInvalidOfflineTx)
+		}
+	})
+}
+
+func TestDecodeSignedRawTx(t *testing.T) {
+	t.Parallel()
+
+	signed := &SignedRawTx{
+		Version: OfflineTxVersion, Chain: ETH, Hash: "0xabc", Hex: "0xf86b07",
+		From: "0xFrom", To: "0xTo", Amount: "1", Fee: "2", Symbol: "ETH", Decimals: 18,
+	}
+	data, err := json.Marshal(signed)
+	require.NoError(t, err)
+
+	got, err := DecodeSignedRawTx(data)
+	require.NoError(t, err)
+	raw, err := got.Raw()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0xf8, 0x6b, 0x07}, raw)
+
+	_, err = DecodeSignedRawTx([]byte(`{"version":1,"chain":"bsv","hash":"aa","hex":"xyz"}`))
+	require.ErrorIs(t, err, ErrInvalidOfflineTx)
+
+	_, err = DecodeSignedRawTx([]byte(`{"version":1,"chain":"bsv","hex":"00"}`))
+	require.ErrorIs(t, err, ErrInvalidOfflineTx)
+
+	_, err = DecodeSignedRawTx([]byte(`not json`))
+	require.ErrorIs(t, err, ErrInvalidOfflineTx)
+}
@@ -0,0 +1,186 @@
+package chain
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Network names used in network-qualified chain IDs such as "eth:sepolia".
+const (
+	Mainnet = "mainnet"
+	Testnet = "testnet"
+	Sepolia = "sepolia"
+)
+
+// networkSeparator separates a chain from its network in a qualified ID.
+const networkSeparator = ":"
+
+// ErrInvalidAlias indicates a chain alias could not be registered.
+var ErrInvalidAlias = &sigilerr.SigilError{
+	Code:     "INVALID_CHAIN_ALIAS",
+	Message:  "invalid chain alias",
+	ExitCode: sigilerr.ExitInput,
+}
+
+// registry maps human-friendly names to chain IDs and lists the networks each
+// chain can be qualified with.
+type registry struct {
+	mu       sync.RWMutex
+	aliases  map[string]ID
+	networks map[ID]map[string]string // chain -> network alias -> network
+}
+
+// defaultRegistry holds the built-in aliases and networks.
+//
+//nolint:gochecknoglobals // Package-level registry shared by ParseChainID callers
+var defaultRegistry = newRegistry()
+
+func newRegistry() *registry {
+	r := &registry{
+		aliases:  make(map[string]ID),
+		networks: make(map[ID]map[string]string),
+	}
+
+	for _, id := range AllChains() {
+		r.aliases[string(id)] = id
+		r.networks[id] = map[string]string{
+			Mainnet: Mainnet,
+			"main":  Mainnet,
+			Testnet: Testnet,
+			"test":  Testnet,
+		}
+	}
+	delete(r.networks[ETH], Testnet)
+	delete(r.networks[ETH], "test")
+	r.networks[ETH][Sepolia] = Sepolia
+
+	builtIn := map[ID][]string{
+		ETH: {"ethereum", "ether", "eth-main", "eth-mainnet"},
+		BSV: {"bitcoin-sv", "bitcoinsv", "bsv-main", "bsv-mainnet"},
+		BTC: {"bitcoin", "btc-main", "btc-mainnet"},
+		BCH: {"bitcoin-cash", "bitcoincash", "bch-main", "bch-mainnet"},
+		LTC: {"litecoin", "ltc-main", "ltc-mainnet"},
+	}
+	for id, names := range builtIn {
+		for _, name := range names {
+			r.aliases[name] = id
+		}
+	}
+
+	return r
+}
+
+// normalizeChainName lower-cases and trims a user-supplied chain name.
+func normalizeChainName(s string) string {
+	return strings.ToLower(strings.TrimSpace(s))
+}
+
+// parse resolves a chain name, alias, or network-qualified ID.
+func (r *registry) parse(s string) (ID, string, bool) {
+	name, network, qualified := strings.Cut(normalizeChainName(s), networkSeparator)
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	id, ok := r.aliases[strings.TrimSpace(name)]
+	if !ok {
+		return "", "", false
+	}
+	if !qualified {
+		return id, Mainnet, true
+	}
+
+	network, ok = r.networks[id][strings.TrimSpace(network)]
+	if !ok {
+		return "", "", false
+	}
+	return id, network, true
+}
+
+// network resolves a network name of id, treating "" as Mainnet.
+func (r *registry) network(id ID, name string) (string, bool) {
+	name = normalizeChainName(name)
+	if name == "" {
+		return Mainnet, id.IsValid()
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	network, ok := r.networks[id][name]
+	return network, ok
+}
+
+// register adds alias as another name for id.
+func (r *registry) register(alias string, id ID) error {
+	name := normalizeChainName(alias)
+	switch {
+	case !id.IsValid():
+		return fmt.Errorf("%w: unknown chain %q", ErrInvalidAlias, id)
+	case name == "" || strings.Contains(name, networkSeparator):
+		return fmt.Errorf("%w: %q", ErrInvalidAlias, alias)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if existing, ok := r.aliases[name]; ok && existing != id {
+		return fmt.Errorf("%w: %q already names %s", ErrInvalidAlias, alias, existing)
+	}
+	r.aliases[name] = id
+	return nil
+}
+
+// aliasesFor returns the sorted aliases of id, excluding the ID itself.
+func (r *registry) aliasesFor(id ID) []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var names []string
+	for name, target := range r.aliases {
+		if target == id && name != string(id) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ParseChainID parses a chain name into an ID. Names are case-insensitive and
+// may be an alias ("ethereum", "bitcoin-sv", "bsv-main") or qualified with the
+// mainnet ("eth:mainnet"). A name qualified with another network is rejected,
+// since the ID alone would send it to mainnet; use ParseQualifiedChainID where
+// the network is honored.
+func ParseChainID(s string) (ID, bool) {
+	id, network, ok := defaultRegistry.parse(s)
+	if !ok || network != Mainnet {
+		return ID(s), false
+	}
+	return id, true
+}
+
+// ParseQualifiedChainID parses a chain name that may carry a network, such as
+// "eth:sepolia" or "bsv:test". Unqualified names and aliases are on Mainnet.
+func ParseQualifiedChainID(s string) (ID, string, bool) {
+	id, network, ok := defaultRegistry.parse(s)
+	if !ok {
+		return ID(s), "", false
+	}
+	return id, network, true
+}
+
+// RegisterAlias adds alias as another name ParseChainID accepts for id.
+// Aliases are case-insensitive and cannot contain ":" or rename a chain
+// another alias already names.
+func RegisterAlias(alias string, id ID) error {
+	return defaultRegistry.register(alias, id)
+}
+
+// Aliases returns the alternative names ParseChainID accepts for id, sorted.
+func Aliases(id ID) []string {
+	return defaultRegistry.aliasesFor(id)
+}
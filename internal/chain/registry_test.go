@@ -0,0 +1,81 @@
+package chain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseQualifiedChainID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input       string
+		wantID      ID
+		wantNetwork string
+		wantOK      bool
+	}{
+		{input: "eth", wantID: ETH, wantNetwork: Mainnet, wantOK: true},
+		{input: "ethereum", wantID: ETH, wantNetwork: Mainnet, wantOK: true},
+		{input: "eth:sepolia", wantID: ETH, wantNetwork: Sepolia, wantOK: true},
+		{input: "ETH : Mainnet", wantID: ETH, wantNetwork: Mainnet, wantOK: true},
+		{input: "bsv:test", wantID: BSV, wantNetwork: Testnet, wantOK: true},
+		{input: "bitcoin-sv:testnet", wantID: BSV, wantNetwork: Testnet, wantOK: true},
+		{input: "btc:main", wantID: BTC, wantNetwork: Mainnet, wantOK: true},
+		{input: "eth:testnet", wantID: ID("eth:testnet")},
+		{input: "bsv:", wantID: ID("bsv:")},
+		{input: ":mainnet", wantID: ID(":mainnet")},
+		{input: "doge", wantID: ID("doge")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			id, network, ok := ParseQualifiedChainID(tc.input)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.Equal(t, tc.wantID, id)
+			assert.Equal(t, tc.wantNetwork, network)
+		})
+	}
+}
+
+func TestRegistryRegister(t *testing.T) {
+	t.Parallel()
+
+	r := newRegistry()
+	require.NoError(t, r.register("Ethereum-Classic-Not", ETH))
+	id, _, ok := r.parse("ethereum-classic-not")
+	require.True(t, ok)
+	assert.Equal(t, ETH, id)
+
+	require.NoError(t, r.register("ethereum", ETH), "re-registering the same target is allowed")
+	require.ErrorIs(t, r.register("ethereum", BSV), ErrInvalidAlias)
+	require.ErrorIs(t, r.register("bsv", ETH), ErrInvalidAlias)
+	require.ErrorIs(t, r.register("bsv:main", BSV), ErrInvalidAlias)
+	require.ErrorIs(t, r.register(" ", BSV), ErrInvalidAlias)
+	require.ErrorIs(t, r.register("doge", ID("doge")), ErrInvalidAlias)
+
+	_, _, ok = defaultRegistry.parse("ethereum-classic-not")
+	assert.False(t, ok, "a private registry does not change ParseChainID")
+}
+
+func TestRegisterAlias(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, RegisterAlias("sigil-test-bsv", BSV))
+	id, ok := ParseChainID("SIGIL-TEST-BSV")
+	require.True(t, ok)
+	assert.Equal(t, BSV, id)
+	assert.Contains(t, Aliases(BSV), "sigil-test-bsv")
+}
+
+func TestAliases(t *testing.T) {
+	t.Parallel()
+
+	aliases := Aliases(ETH)
+	assert.Contains(t, aliases, "ethereum")
+	assert.NotContains(t, aliases, "eth")
+	assert.IsIncreasing(t, aliases)
+	assert.Empty(t, Aliases(ID("doge")))
+}
@@ -0,0 +1,267 @@
+package chain
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// maxP2PKHScriptSigSize is the largest scriptSig that spends a P2PKH output
+// with a compressed key: a push of a 72-byte DER signature plus the sighash
+// byte, and a push of the 33-byte key.
+const maxP2PKHScriptSigSize = 1 + 73 + 1 + 33
+
+// ErrSweepMismatch indicates the unsigned transaction of a sweep does not
+// spend or pay what the sweep should. It is raised before signing, so
+// nothing has been sent.
+var ErrSweepMismatch = &sigilerr.SigilError{
+	Code:     "SWEEP_MISMATCH",
+	Message:  "sweep verification failed",
+	ExitCode: sigilerr.ExitGeneral,
+}
+
+// errMalformedTx indicates a serialized transaction could not be parsed.
+var errMalformedTx = errors.New("malformed transaction")
+
+// SweepCheck is what a sweep should do, taken from the request rather than
+// from the transaction builder, so VerifySweep can catch coin-selection and
+// fee arithmetic bugs in the builder.
+type SweepCheck struct {
+	// UTXOs are the UTXOs the sweep must spend, every one of them.
+	UTXOs []UTXO
+	// Recipients are the locking scripts of the outputs, in order. A sweep
+	// has no change output.
+	Recipients [][]byte
+	// Amounts, when set, are the requested amounts of a split sweep's
+	// outputs. An output may pay less, when the fee had to be raised, but
+	// never more.
+	Amounts []uint64
+	// FeeRate is the target fee rate in satoshis per kilobyte.
+	FeeRate uint64
+	// EstimatedFee is the fee the chain's size estimate gives the sweep.
+	EstimatedFee uint64
+	// SignedSize is the size of an earlier signing of the transaction whose
+	// fee was then raised to cover it, or zero.
+	SignedSize uint64
+	// DustLimit is the chain's dust limit. A split sweep may leave less than
+	// it to the fee.
+	DustLimit uint64
+}
+
+// SweepTotals are the input, output, and fee totals of a sweep in satoshis.
+type SweepTotals struct {
+	Inputs  uint64
+	Outputs uint64
+	Fee     uint64
+}
+
+// VerifySweep checks a sweep's unsigned legacy transaction against check
+// and the totals the builder claims for it. It recomputes the totals from
+// the transaction itself: the inputs are priced from check.UTXOs, and the
+// fee must cover the unsigned size at check.FeeRate without exceeding what
+// the largest possible signed transaction, or the estimate, would need.
+func VerifySweep(unsigned []byte, check *SweepCheck, claimed SweepTotals) error {
+	tx, err := parseLegacyTx(unsigned)
+	if err != nil {
+		return sweepMismatch("parsing unsigned transaction: %v", err)
+	}
+
+	amounts := make(map[string]uint64, len(check.UTXOs))
+	for _, u := range check.UTXOs {
+		amounts[fmt.Sprintf("%s:%d", u.TxID, u.Vout)] = u.Amount
+	}
+	if len(tx.inputs) != len(amounts) {
+		return sweepMismatch("transaction spends %d inputs, sweep has %d UTXOs", len(tx.inputs), len(amounts))
+	}
+
+	var totals SweepTotals
+	spent := make(map[string]bool, len(tx.inputs))
+	for i, in := range tx.inputs {
+		if len(in.script) > 0 {
+			return sweepMismatch("input %d is already signed", i)
+		}
+		outpoint := fmt.Sprintf("%s:%d", in.txid, in.vout)
+		amount, ok := amounts[outpoint]
+		if !ok {
+			return sweepMismatch("input %d spends %s, which is not a swept UTXO", i, outpoint)
+		}
+		if spent[outpoint] {
+			return sweepMismatch("input %d spends %s twice", i, outpoint)
+		}
+		spent[outpoint] = true
+		if totals.Inputs, err = addAmounts(totals.Inputs, amount); err != nil {
+			return err
+		}
+	}
+
+	if check.Amounts != nil && len(check.Amounts) != len(check.Recipients) {
+		return sweepMismatch("sweep has %d amounts for %d recipients", len(check.Amounts), len(check.Recipients))
+	}
+	if len(tx.outputs) != len(check.Recipients) {
+		return sweepMismatch("transaction has %d outputs, sweep pays %d recipients", len(tx.outputs), len(check.Recipients))
+	}
+	for i, out := range tx.outputs {
+		if !bytes.Equal(out.script, check.Recipients[i]) {
+			return sweepMismatch("output %d does not pay recipient %d", i, i+1)
+		}
+		if out.value < check.DustLimit {
+			return sweepMismatch("output %d pays %d satoshis, below the dust limit", i, out.value)
+		}
+		if check.Amounts != nil && out.value > check.Amounts[i] {
+			return sweepMismatch("output %d pays %d satoshis, more than the %d requested", i, out.value, check.Amounts[i])
+		}
+		if totals.Outputs, err = addAmounts(totals.Outputs, out.value); err != nil {
+			return err
+		}
+	}
+	if totals.Outputs > totals.Inputs {
+		return sweepMismatch("outputs %d exceed inputs %d satoshis", totals.Outputs, totals.Inputs)
+	}
+	totals.Fee = totals.Inputs - totals.Outputs
+
+	size := uint64(len(unsigned))
+	if minFee := feeAtRate(size, check.FeeRate); totals.Fee < minFee {
+		return sweepMismatch("fee %d satoshis is below %d for the unsigned size at %d sat/KB", totals.Fee, minFee, check.FeeRate)
+	}
+	maxSigned := size + uint64(len(tx.inputs))*maxP2PKHScriptSigSize
+	maxSigned = max(maxSigned, check.SignedSize)
+	maxFee := max(feeAtRate(maxSigned, check.FeeRate), check.EstimatedFee) + check.DustLimit
+	if totals.Fee > maxFee {
+		return sweepMismatch("fee %d satoshis exceeds %d, the most the sweep can need at %d sat/KB", totals.Fee, maxFee, check.FeeRate)
+	}
+
+	if totals != claimed {
+		return sweepMismatch("transaction spends %d, pays %d, fee %d satoshis; builder expected %d, %d, fee %d",
+			totals.Inputs, totals.Outputs, totals.Fee, claimed.Inputs, claimed.Outputs, claimed.Fee)
+	}
+	return nil
+}
+
+func sweepMismatch(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrSweepMismatch, fmt.Sprintf(format, args...))
+}
+
+func addAmounts(a, b uint64) (uint64, error) {
+	if a > math.MaxUint64-b {
+		return 0, sweepMismatch("amount total overflows")
+	}
+	return a + b, nil
+}
+
+// feeAtRate returns the fee for size bytes at rate satoshis per kilobyte,
+// rounded up.
+func feeAtRate(size, rate uint64) uint64 {
+	return (size*rate + 999) / 1000
+}
+
+// legacyTx is a parsed legacy (non-segwit) transaction.
+type legacyTx struct {
+	inputs  []legacyInput
+	outputs []legacyOutput
+}
+
+type legacyInput struct {
+	txid   string // display (reversed) byte order
+	vout   uint32
+	script []byte
+}
+
+type legacyOutput struct {
+	value  uint64
+	script []byte
+}
+
+// parseLegacyTx parses a serialized legacy transaction. The whole of raw
+// must be the transaction.
+func parseLegacyTx(raw []byte) (*legacyTx, error) {
+	r := &txReader{buf: raw}
+	r.skip(4) // version
+	numInputs := r.varInt()
+	if numInputs == 0 && r.err == nil {
+		return nil, fmt.Errorf("%w: no inputs", errMalformedTx)
+	}
+	tx := &legacyTx{}
+	for i := uint64(0); i < numInputs && r.err == nil; i++ {
+		hash := slices.Clone(r.bytes(32))
+		slices.Reverse(hash)
+		in := legacyInput{txid: hex.EncodeToString(hash), vout: r.uint32()}
+		in.script = r.bytes(r.varInt())
+		r.skip(4) // sequence
+		tx.inputs = append(tx.inputs, in)
+	}
+	numOutputs := r.varInt()
+	for i := uint64(0); i < numOutputs && r.err == nil; i++ {
+		out := legacyOutput{value: r.uint64()}
+		out.script = r.bytes(r.varInt())
+		tx.outputs = append(tx.outputs, out)
+	}
+	r.skip(4) // locktime
+	if r.err != nil {
+		return nil, r.err
+	}
+	if len(r.buf) > 0 {
+		return nil, fmt.Errorf("%w: %d trailing bytes", errMalformedTx, len(r.buf))
+	}
+	return tx, nil
+}
+
+// txReader reads a serialized transaction, recording the first error.
+type txReader struct {
+	buf []byte
+	err error
+}
+
+func (r *txReader) bytes(n uint64) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > uint64(len(r.buf)) {
+		r.err = fmt.Errorf("%w: truncated", errMalformedTx)
+		return nil
+	}
+	b := r.buf[:n]
+	r.buf = r.buf[n:]
+	return b
+}
+
+func (r *txReader) skip(n uint64) { r.bytes(n) }
+
+func (r *txReader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *txReader) uint64() uint64 {
+	if b := r.bytes(8); b != nil {
+		return binary.LittleEndian.Uint64(b)
+	}
+	return 0
+}
+
+func (r *txReader) varInt() uint64 {
+	b := r.bytes(1)
+	if b == nil {
+		return 0
+	}
+	switch b[0] {
+	case 0xfd:
+		if v := r.bytes(2); v != nil {
+			return uint64(binary.LittleEndian.Uint16(v))
+		}
+	case 0xfe:
+		return uint64(r.uint32())
+	case 0xff:
+		return r.uint64()
+	default:
+		return uint64(b[0])
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package chain
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSweepTx serializes a legacy transaction spending utxos with empty
+// scripts and paying values to scripts.
+func testSweepTx(t *testing.T, utxos []UTXO, scripts [][]byte, values []uint64) []byte {
+	t.Helper()
+	buf := binary.LittleEndian.AppendUint32(nil, 1)
+	buf = append(buf, byte(len(utxos)))
+	for _, u := range utxos {
+		hash, err := hex.DecodeString(u.TxID)
+		require.NoError(t, err)
+		slices.Reverse(hash)
+		buf = append(buf, hash...)
+		buf = binary.LittleEndian.AppendUint32(buf, u.Vout)
+		buf = append(buf, 0)
+		buf = binary.LittleEndian.AppendUint32(buf, 0xffffffff)
+	}
+	buf = append(buf, byte(len(scripts)))
+	for i, script := range scripts {
+		buf = binary.LittleEndian.AppendUint64(buf, values[i])
+		buf = append(buf, byte(len(script)))
+		buf = append(buf, script...)
+	}
+	return binary.LittleEndian.AppendUint32(buf, 0)
+}
+
+func TestVerifySweep(t *testing.T) {
+	t.Parallel()
+
+	utxos := []UTXO{
+		{TxID: strings.Repeat("aa", 32), Vout: 0, Amount: 40000},
+		{TxID: strings.Repeat("bb", 32), Vout: 3, Amount: 60000},
+	}
+	recipient := append([]byte{0x76, 0xa9, 0x14}, make([]byte, 20)...)
+	recipient = append(recipient, 0x88, 0xac)
+	other := slices.Clone(recipient)
+	other[3] = 1
+
+	// 2 inputs, 1 output: 10 + 2*148 + 34 = 340 bytes estimated at 1000 sat/KB
+	const fee = 340
+	check := &SweepCheck{UTXOs: utxos, Recipients: [][]byte{recipient}, FeeRate: 1000, EstimatedFee: fee, DustLimit: 1}
+	claimed := SweepTotals{Inputs: 100000, Outputs: 100000 - fee, Fee: fee}
+	valid := testSweepTx(t, utxos, check.Recipients, []uint64{100000 - fee})
+	require.NoError(t, VerifySweep(valid, check, claimed))
+
+	tests := []struct {
+		name    string
+		raw     []byte
+		claimed SweepTotals
+	}{
+		{"utxo left unspent", testSweepTx(t, utxos[:1], check.Recipients, []uint64{40000 - fee}), SweepTotals{40000, 40000 - fee, fee}},
+		{"unknown input", testSweepTx(t, []UTXO{utxos[0], {TxID: utxos[1].TxID, Vout: 4}}, check.Recipients, []uint64{100000 - fee}), claimed},
+		{"input spent twice", testSweepTx(t, []UTXO{utxos[0], utxos[0]}, check.Recipients, []uint64{100000 - fee}), claimed},
+		{"change output", testSweepTx(t, utxos, [][]byte{recipient, other}, []uint64{90000, 10000 - fee}), claimed},
+		{"wrong recipient", testSweepTx(t, utxos, [][]byte{other}, []uint64{100000 - fee}), claimed},
+		{"outputs exceed inputs", testSweepTx(t, utxos, check.Recipients, []uint64{100001}), claimed},
+		{"fee below rate", testSweepTx(t, utxos, check.Recipients, []uint64{100000 - 10}), SweepTotals{100000, 100000 - 10, 10}},
+		{"fee above what signing needs", testSweepTx(t, utxos, check.Recipients, []uint64{90000}), SweepTotals{100000, 90000, 10000}},
+		{"builder totals disagree", valid, SweepTotals{Inputs: 100000, Outputs: 100000 - fee + 1, Fee: fee - 1}},
+		{"truncated", valid[:len(valid)-1], claimed},
+		{"trailing bytes", append(slices.Clone(valid), 0), claimed},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			require.ErrorIs(t, VerifySweep(tc.raw, check, tc.claimed), ErrSweepMismatch)
+		})
+	}
+
+	t.Run("signed input", func(t *testing.T) {
+		t.Parallel()
+		signed := slices.Clone(valid)
+		signed[4+1+32+4] = 1 // first input's script length
+		signed = slices.Insert(signed, 4+1+32+4+1, 0x51)
+		require.ErrorIs(t, VerifySweep(signed, check, claimed), ErrSweepMismatch)
+	})
+
+	t.Run("split sweep amounts", func(t *testing.T) {
+		t.Parallel()
+		split := *check
+		split.Recipients = [][]byte{recipient, other}
+		split.Amounts = []uint64{50000, 49626}
+		split.EstimatedFee = 374
+		raw := testSweepTx(t, utxos, split.Recipients, []uint64{50000, 49626})
+		require.NoError(t, VerifySweep(raw, &split, SweepTotals{100000, 99626, 374}))
+
+		raw = testSweepTx(t, utxos, split.Recipients, []uint64{50100, 49526})
+		err := VerifySweep(raw, &split, SweepTotals{100000, 99626, 374})
+		require.ErrorIs(t, err, ErrSweepMismatch)
+		assert.Contains(t, err.Error(), "more than the 50000 requested")
+	})
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// accountFlagUsage is the help text of the --account flags.
+const accountFlagUsage = "BIP44 account index of the wallet to use"
+
+// selectAccount returns wlt as seen from BIP44 account n, for commands that
+// only read its addresses. They cannot derive addresses, so an account the
+// wallet has no addresses in is an error pointing at receive, which can.
+func selectAccount(wlt *wallet.Wallet, n uint32) (*wallet.Wallet, error) {
+	if !slices.Contains(wlt.AccountIndexes(), n) {
+		if _, err := wlt.Account(n); err != nil {
+			return nil, err
+		}
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrNotFound,
+			fmt.Sprintf("wallet '%s' has no addresses in account %d; derive one with: sigil receive --wallet %s --account %d",
+				wlt.Name, n, wlt.Name, n))
+	}
+	return wlt.Account(n)
+}
+
+// checkAgentXpubAccount refuses a non-default account in SIGIL_AGENT_XPUB
+// mode, whose xpub only derives the addresses of the account it was
+// exported from.
+func checkAgentXpubAccount(cc *CommandContext, wlt *wallet.Wallet, n uint32) error {
+	if cc.AgentXpub == "" || n == wlt.AccountIndex() {
+		return nil
+	}
+	return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+		fmt.Sprintf("SIGIL_AGENT_XPUB only covers account %d; --account %d needs the wallet password", wlt.AccountIndex(), n))
+}
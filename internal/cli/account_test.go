@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:paralleltest // Mutates package-level flags and prompt functions
+func TestRunReceive_Account(t *testing.T) {
+	home, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	t.Cleanup(func() { receiveWallet, receiveChain, receiveAccount = "", "bsv", 0 })
+
+	walletsDir := filepath.Join(home, "wallets")
+	createTestWallet(t, walletsDir, "main")
+	withMockPrompts(t, []byte("password"), true)
+
+	// Reading commands cannot derive the first address of a new account
+	storage := wallet.NewFileStorage(walletsDir)
+	wlt, err := storage.LoadMetadata("main")
+	require.NoError(t, err)
+	_, err = selectAccount(wlt, 1)
+	require.ErrorIs(t, err, sigilerr.ErrNotFound)
+
+	// Receiving into the account derives it
+	receiveWallet, receiveChain, receiveAccount = "main", "eth", 1
+	cmd, buf := newBackupListTestCmd(home, output.FormatText)
+	require.NoError(t, runReceive(cmd, nil))
+	assert.Contains(t, buf.String(), "m/44'/60'/1'/0/0")
+
+	wlt, err = storage.LoadMetadata("main")
+	require.NoError(t, err)
+	assert.Len(t, wlt.Addresses[wallet.ChainETH], 1, "the default account is unchanged")
+	acct, err := selectAccount(wlt, 1)
+	require.NoError(t, err)
+	require.Len(t, acct.Addresses[wallet.ChainETH], 1)
+	assert.Equal(t, uint32(1), acct.Addresses[wallet.ChainETH][0].Account)
+	assert.NotEqual(t, wlt.Addresses[wallet.ChainETH][0].Address, acct.Addresses[wallet.ChainETH][0].Address)
+}
@@ -15,10 +15,14 @@ import (
 
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
 	"github.com/mrz1836/sigil/internal/output"
 	"github.com/mrz1836/sigil/internal/service/address"
 	"github.com/mrz1836/sigil/internal/service/balance"
 	"github.com/mrz1836/sigil/internal/service/discovery"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/txjournal"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
@@ -30,28 +34,41 @@ var (
 	addressesWallet string
 	// addressesChain is the blockchain filter.
 	addressesChain string
+	// addressesAccount is the BIP44 account whose addresses are shown.
+	addressesAccount uint32
 	// addressesType is the address type filter (receive, change, all).
 	addressesType string
 	// addressesUsed filters to show only used addresses.
 	addressesUsed bool
 	// addressesUnused filters to show only unused addresses.
 	addressesUnused bool
+	// addressesTags filters the list to addresses matching every tag.
+	addressesTags []string
+	// addressesDetail adds each address's sends from the transaction journal.
+	addressesDetail bool
 	// addressesRefresh forces a fresh fetch, ignoring the cache.
 	addressesRefresh bool
 	// addressesRefreshAddresses is a list of specific addresses to refresh.
 	addressesRefreshAddresses []string
+	// addressesRefreshAll refreshes every wallet instead of a single one.
+	addressesRefreshAll bool
+	// addressesRefreshMaxAge skips addresses whose cached balance is newer than this.
+	addressesRefreshMaxAge time.Duration
 )
 
 // addressesCmd is the parent command for address operations.
 //
 //nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
 var addressesCmd = &cobra.Command{
-	Use:   "addresses",
-	Short: "Manage and view addresses",
+	Use:     "addresses",
+	Aliases: []string{"addr"},
+	Short:   "Manage and view addresses",
 	Long: `View, filter, and manage wallet addresses.
 
-List addresses with balances, set labels, and refresh data from the network.
-Supports filtering by chain, address type (receive/change), and usage status.`,
+List addresses with balances, set labels and tags, and refresh data from the
+network. Supports filtering by chain, address type (receive/change), usage
+status, and tag.
+ETH addresses are always displayed in EIP-55 checksummed form.`,
 }
 
 // addressesListCmd lists all addresses in a wallet.
@@ -63,15 +80,38 @@ var addressesListCmd = &cobra.Command{
 	Long: `List all addresses in a wallet with their status and balance.
 
 Balances are fetched live from the network with cache fallback.
-Use --refresh to bypass the cache and force a fresh fetch.`,
+Use --refresh to bypass the cache and force a fresh fetch.
+
+Use --detail to add each address's spending history from the wallet's
+transaction journal: how many transactions it sent, the total spent
+(amounts plus fees, with tokens listed separately), how many of those sends
+were in the last 24 hours and 7 days, and when it last sent. An address
+that is sending more often than expected, such as one an agent is draining,
+stands out at a glance. Reverted transactions are not counted, and only
+transactions sent or backfilled into the journal are included.
+
+Use --account to list the addresses of another BIP44 account of the wallet.
+
+When the newest receive address of a chain is used, new receive addresses
+are derived and saved until derivation.address_gap (default 20) unused ones
+follow it. Set derivation.address_gap to 0 to turn this off.`,
 	Example: `  # List all BSV addresses
   sigil addresses list --wallet main --chain bsv
 
   # List only unused receiving addresses
   sigil addresses list --wallet main --chain bsv --type receive --unused
 
+  # List addresses tagged for any customer and for invoices
+  sigil addresses list --wallet main --tag customer --tag purpose:invoices
+
   # Force fresh balance fetch
-  sigil addresses list --wallet main --refresh`,
+  sigil addresses list --wallet main --refresh
+
+  # Show sends, total spent, and recent send counts per address
+  sigil addresses list --wallet main --detail
+
+  # List the addresses of BIP44 account 1
+  sigil addresses list --wallet main --account 1`,
 	RunE: runAddressesList,
 }
 
@@ -103,7 +143,16 @@ For BSV addresses: re-scans UTXOs via WhatsOnChain and updates the balance cache
 For ETH addresses: fetches fresh balances via the configured provider and updates the balance cache.
 
 By default, refreshes all addresses. Use --address to target specific addresses.
-Use --chain to filter by blockchain.`,
+Activity on the newest receive address derives new ones up to the gap limit,
+as with 'addresses list', and refreshes them too.
+Use --chain to filter by blockchain, and --account to refresh another BIP44
+account of the wallet.
+
+Use --all-wallets to refresh every wallet in one run, for example from a
+nightly cron job that keeps the balance cache warm. Wallets are read without
+their password, progress is reduced to one summary line per wallet, and the
+command exits non-zero only when every wallet fails. Use --max-age to skip
+addresses whose cached balance is newer than the given duration.`,
 	Example: `  # Refresh all addresses
   sigil addresses refresh --wallet main
 
@@ -114,40 +163,71 @@ Use --chain to filter by blockchain.`,
   sigil addresses refresh --wallet main --address 1ABC... --address 1XYZ...
 
   # JSON output
-  sigil addresses refresh --wallet main -o json`,
+  sigil addresses refresh --wallet main -o json
+
+  # Nightly cron: refresh every wallet, skipping addresses refreshed in the last 6 hours
+  sigil addresses refresh --all-wallets --max-age 6h`,
 	RunE: runAddressesRefresh,
 }
 
+// addressesChecksumCmd converts an ETH address to its EIP-55 checksummed form.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var addressesChecksumCmd = &cobra.Command{
+	Use:   "checksum <address>",
+	Short: "Convert an ETH address to its EIP-55 checksummed form",
+	Long: `Convert an Ethereum address to its EIP-55 checksummed (mixed-case) form.
+
+'sigil tx send' rejects all-lowercase ETH recipients unless --no-checksum is
+passed; use this command to get the correctly cased address instead. An input
+that is already mixed-case but has a wrong checksum is reported as invalid,
+since it most likely contains a typo.`,
+	Example: `  sigil addr checksum 0x742d35cc6634c0532925a3b844bc454e4438f44e
+  sigil addr checksum 0x742d35cc6634c0532925a3b844bc454e4438f44e -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAddressesChecksum,
+}
+
 //nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
 func init() {
 	addressesCmd.GroupID = "wallet"
 	rootCmd.AddCommand(addressesCmd)
 	addressesCmd.AddCommand(addressesListCmd)
 	addressesCmd.AddCommand(addressesLabelCmd)
+	addressesCmd.AddCommand(addressesChecksumCmd)
 
 	// List command flags
-	addressesListCmd.Flags().StringVarP(&addressesWallet, "wallet", "w", "", "wallet name (required)")
+	addressesListCmd.Flags().StringVarP(&addressesWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
 	addressesListCmd.Flags().StringVarP(&addressesChain, "chain", "c", "", "filter by chain (eth, bsv)")
+	addressesListCmd.Flags().Uint32Var(&addressesAccount, "account", 0, accountFlagUsage)
 	addressesListCmd.Flags().StringVarP(&addressesType, "type", "t", "all", "filter: receive, change, all")
 	addressesListCmd.Flags().BoolVar(&addressesUsed, "used", false, "show only used addresses")
 	addressesListCmd.Flags().BoolVar(&addressesUnused, "unused", false, "show only unused addresses")
+	addressesListCmd.Flags().StringArrayVar(&addressesTags, "tag", nil, "show only addresses with this tag or a tag under it (repeatable, all must match)")
 	addressesListCmd.Flags().BoolVar(&addressesRefresh, "refresh", false, "force fresh fetch, ignore cache")
-	_ = addressesListCmd.MarkFlagRequired("wallet")
+	addressesListCmd.Flags().BoolVar(&addressesDetail, "detail", false, "show sends, total spent, and send velocity per address from the transaction journal")
 
 	// Label command flags
-	addressesLabelCmd.Flags().StringVarP(&addressesWallet, "wallet", "w", "", "wallet name (required)")
-	_ = addressesLabelCmd.MarkFlagRequired("wallet")
+	addressesLabelCmd.Flags().StringVarP(&addressesWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
 
 	// Refresh command
 	addressesCmd.AddCommand(addressesRefreshCmd)
-	addressesRefreshCmd.Flags().StringVarP(&addressesWallet, "wallet", "w", "", "wallet name (required)")
+	addressesRefreshCmd.Flags().StringVarP(&addressesWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
 	addressesRefreshCmd.Flags().StringVarP(&addressesChain, "chain", "c", "", "filter by chain (eth, bsv)")
+	addressesRefreshCmd.Flags().Uint32Var(&addressesAccount, "account", 0, accountFlagUsage)
 	addressesRefreshCmd.Flags().StringArrayVar(&addressesRefreshAddresses, "address", nil, "specific address(es) to refresh (optional, repeatable)")
-	_ = addressesRefreshCmd.MarkFlagRequired("wallet")
+	addressesRefreshCmd.Flags().BoolVar(&addressesRefreshAll, "all-wallets", false, "refresh every wallet and print a consolidated summary")
+	addressesRefreshCmd.Flags().DurationVar(&addressesRefreshMaxAge, "max-age", 0, "skip addresses refreshed more recently than this (e.g. 6h)")
+	addressesRefreshCmd.MarkFlagsMutuallyExclusive("all-wallets", "wallet")
+	addressesRefreshCmd.MarkFlagsMutuallyExclusive("all-wallets", "address")
+	addressesRefreshCmd.MarkFlagsMutuallyExclusive("all-wallets", "account")
 }
 
 //nolint:gocognit,gocyclo // CLI flow involves multiple validation, collection, and fetch steps
 func runAddressesList(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &addressesWallet); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd)
 
 	// Validate type filter
@@ -167,15 +247,23 @@ func runAddressesList(cmd *cobra.Command, _ []string) error {
 		)
 	}
 
+	tagFilters, err := normalizeTagArgs(addressesTags)
+	if err != nil {
+		return err
+	}
+
 	// Load wallet
 	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
-	wlt, seed, err := loadWalletWithSession(addressesWallet, storage, cmd)
+	wlt, seed, err := loadWalletForRead(addressesWallet, storage, cmd)
 	if err != nil {
 		return err
 	}
 	defer wallet.ZeroBytes(seed)
+	if wlt, err = selectAccount(wlt, addressesAccount); err != nil {
+		return err
+	}
 
-	// Load UTXO store (for address metadata: labels and HasActivity)
+	// Load UTXO store (for address metadata: labels, tags, and HasActivity)
 	utxoStorePath := filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", addressesWallet)
 	store := utxostore.New(utxoStorePath)
 	if loadErr := store.Load(); loadErr != nil {
@@ -183,19 +271,15 @@ func runAddressesList(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Load or create balance cache
-	cachePath := filepath.Join(cmdCtx.Cfg.GetHome(), "cache", "balances.json")
-	cacheStorage := cache.NewFileStorage(cachePath)
+	cacheStorage := cache.NewBalanceStorage(cmdCtx.Cfg.GetHome(), cmdCtx.Cfg.GetCache())
 	balanceCache := loadOrCreateBalanceCache(cacheStorage, addressesRefresh, cmd, cmdCtx.Log)
 
 	// Parse chain filter
 	var chainFilter chain.ID
 	if addressesChain != "" {
-		parsed, ok := chain.ParseChainID(addressesChain)
-		if !ok || !parsed.IsMVP() {
-			return sigilerr.WithSuggestion(
-				sigilerr.ErrInvalidInput,
-				fmt.Sprintf("invalid chain: %s (use eth or bsv)", addressesChain),
-			)
+		parsed, err := parseEnabledChain(cmdCtx.Cfg, addressesChain)
+		if err != nil {
+			return err
 		}
 		chainFilter = parsed
 	}
@@ -219,9 +303,16 @@ func runAddressesList(cmd *cobra.Command, _ []string) error {
 		TypeFilter:  typeFilter,
 	})
 
+	// Tags are local metadata, so filter before fetching balances
+	allAddresses = address.FilterTags(allAddresses, tagFilters)
+
 	// Fetch live balances concurrently
 	fetchAddressBalances(cmd, allAddresses, balanceCache, cmdCtx.Cfg)
 
+	// ETH addresses carry nonce and first/last activity, which also marks
+	// addresses that were used but have since been emptied
+	fetchETHActivity(cmd, allAddresses, cmdCtx)
+
 	// Enrich "Used" status from fetched balance data
 	for i := range allAddresses {
 		if !allAddresses[i].HasActivity {
@@ -229,6 +320,30 @@ func runAddressesList(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
+	// Activity on the newest receive address extends the list to the gap limit
+	listedUsed := make(map[string]bool, len(allAddresses))
+	for _, a := range allAddresses {
+		if a.HasActivity {
+			listedUsed[string(a.ChainID)+":"+a.Address] = true
+		}
+	}
+	storeUsed := storedAddressUsed(store)
+	gapChains := wlt.EnabledChains
+	if chainFilter != "" {
+		gapChains = []chain.ID{chainFilter}
+	}
+	newTargets := extendAddressGap(cmd, storage, store, wlt, seed, gapChains, func(id chain.ID, addr string) bool {
+		return listedUsed[string(id)+":"+addr] || storeUsed(id, addr)
+	})
+	if typeFilter != address.Change {
+		added := address.FilterTags(targetAddressInfos(store, wlt, newTargets), tagFilters)
+		fetchAddressBalances(cmd, added, balanceCache, cmdCtx.Cfg)
+		for i := range added {
+			added[i].HasActivity = isNonZeroBalance(added[i].Balance) || isNonZeroBalance(added[i].Unconfirmed)
+		}
+		allAddresses = append(allAddresses, added...)
+	}
+
 	// Apply --used/--unused filter after balance enrichment
 	allAddresses = address.FilterUsage(allAddresses, addressesUsed, addressesUnused)
 
@@ -250,6 +365,13 @@ func runAddressesList(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
+	if addressesDetail {
+		journal := loadTxJournal(cmdCtx, addressesWallet)
+		if err := annotateAddressSpending(allAddresses, journal, time.Now()); err != nil && cmdCtx.Log != nil {
+			cmdCtx.Log.Error("failed to load transaction journal: %v", err)
+		}
+	}
+
 	// Display results
 	if cmdCtx.Fmt.Format() == output.FormatJSON {
 		displayAddressesJSON(cmd, allAddresses)
@@ -268,16 +390,34 @@ type refreshTarget struct {
 
 //nolint:gocognit,gocyclo // CLI flow involves validation, chain-specific refresh, and display steps
 func runAddressesRefresh(cmd *cobra.Command, _ []string) error {
+	if addressesRefreshMaxAge < 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--max-age must not be negative")
+	}
+	if addressesRefreshAll {
+		return runAddressesRefreshAll(cmd)
+	}
+	if err := resolveWalletName(cmd, &addressesWallet); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd)
 	w := cmd.OutOrStdout()
 
 	// Load wallet
 	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
-	wlt, seed, err := loadWalletWithSession(addressesWallet, storage, cmd)
+	wlt, seed, err := loadWalletForRead(addressesWallet, storage, cmd)
 	if err != nil {
 		return err
 	}
 	defer wallet.ZeroBytes(seed)
+	if wlt, err = selectAccount(wlt, addressesAccount); err != nil {
+		return err
+	}
+
+	lock, err := lockWallet(cmd, storage, addressesWallet, "addresses refresh")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
 
 	// Load UTXO store
 	utxoStorePath := filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", addressesWallet)
@@ -286,24 +426,15 @@ func runAddressesRefresh(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("loading UTXO store: %w", loadErr)
 	}
 
-	// Create fresh balance cache (refresh always bypasses existing cache)
-	cachePath := filepath.Join(cmdCtx.Cfg.GetHome(), "cache", "balances.json")
-	cacheStorage := cache.NewFileStorage(cachePath)
-	balanceCache := loadOrCreateBalanceCache(cacheStorage, true, cmd, cmdCtx.Log)
+	// Create fresh balance cache (refresh always bypasses existing cache),
+	// unless --max-age needs the existing entries to decide what to skip
+	cacheStorage := cache.NewBalanceStorage(cmdCtx.Cfg.GetHome(), cmdCtx.Cfg.GetCache())
+	balanceCache := loadOrCreateBalanceCache(cacheStorage, addressesRefreshMaxAge == 0, cmd, cmdCtx.Log)
 
 	// Determine which chains to refresh
-	var chains []chain.ID
-	if addressesChain != "" {
-		chainID, ok := chain.ParseChainID(addressesChain)
-		if !ok || !chainID.IsMVP() {
-			return sigilerr.WithSuggestion(
-				sigilerr.ErrInvalidInput,
-				fmt.Sprintf("invalid chain: %s (use eth or bsv)", addressesChain),
-			)
-		}
-		chains = []chain.ID{chainID}
-	} else {
-		chains = wlt.EnabledChains
+	chains, err := refreshChains(wlt, addressesChain)
+	if err != nil {
+		return err
 	}
 
 	// Build target list
@@ -312,7 +443,12 @@ func runAddressesRefresh(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	targets, skipped := filterRecentTargets(targets, balanceCache, addressesRefreshMaxAge)
 	if len(targets) == 0 {
+		if skipped > 0 {
+			out(w, "All %d address(es) were refreshed within the last %s.\n", skipped, addressesRefreshMaxAge)
+			return nil
+		}
 		out(w, "No addresses found to refresh.\n")
 		return nil
 	}
@@ -323,7 +459,23 @@ func runAddressesRefresh(cmd *cobra.Command, _ []string) error {
 	out(w, "Refreshing %d address(es) for wallet '%s'...\n", len(targets), addressesWallet)
 
 	// Refresh addresses by chain
-	refreshErrors := refreshTargetAddresses(ctx, w, cmdCtx, store, targets, balanceCache)
+	refreshErrors, refreshed := refreshTargetAddresses(ctx, w, cmdCtx, store, targets, balanceCache)
+
+	// Activity on the newest receive address extends the wallet to the gap
+	// limit; the new addresses are refreshed too, as funds may already be there
+	if len(addressesRefreshAddresses) == 0 && refreshed == len(targets) {
+		storeUsed := storedAddressUsed(store)
+		newTargets := extendAddressGap(cmd, storage, store, wlt, seed, chains, func(id chain.ID, addr string) bool {
+			entry, ok, _ := balanceCache.Get(id, addr, "")
+			return storeUsed(id, addr) || (ok && (isNonZeroBalance(entry.Balance) || isNonZeroBalance(entry.Unconfirmed)))
+		})
+		if len(newTargets) > 0 {
+			gapErrors, gapRefreshed := refreshTargetAddresses(ctx, w, cmdCtx, store, newTargets, balanceCache)
+			refreshErrors = append(refreshErrors, gapErrors...)
+			refreshed += gapRefreshed
+			targets = append(targets, newTargets...)
+		}
+	}
 
 	// Save balance cache
 	if saveErr := cacheStorage.Save(balanceCache); saveErr != nil {
@@ -386,10 +538,16 @@ func runAddressesRefresh(cmd *cobra.Command, _ []string) error {
 	if cmdCtx.Fmt.Format() == output.FormatJSON {
 		displayAddressesRefreshJSON(cmd, allAddresses, errorCount)
 	} else {
-		out(w, "Refreshed %d address(es)", len(targets))
+		out(w, "Refreshed %d address(es)", refreshed)
+		if refreshed < len(targets) {
+			out(w, " of %d (canceled)", len(targets))
+		}
 		if errorCount > 0 {
 			out(w, " (%d error(s))", errorCount)
 		}
+		if skipped > 0 {
+			out(w, ", skipped %d refreshed within the last %s", skipped, addressesRefreshMaxAge)
+		}
 		outln(w)
 		displayAddressesText(cmd, allAddresses)
 		for _, re := range refreshErrors {
@@ -400,6 +558,38 @@ func runAddressesRefresh(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// refreshChains returns the chains to refresh: the --chain filter when set,
+// otherwise the wallet's enabled chains.
+func refreshChains(wlt *wallet.Wallet, chainFilter string) ([]chain.ID, error) {
+	if chainFilter == "" {
+		return wlt.EnabledChains, nil
+	}
+	chainID, ok := chain.ParseChainID(chainFilter)
+	if !ok || !chainID.IsMVP() {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid chain: %s (use eth or bsv)", chainFilter),
+		)
+	}
+	return []chain.ID{chainID}, nil
+}
+
+// filterRecentTargets drops targets whose cached balance is newer than maxAge
+// and returns the remaining targets with the number skipped. A zero maxAge
+// keeps every target.
+func filterRecentTargets(targets []refreshTarget, balanceCache *cache.BalanceCache, maxAge time.Duration) ([]refreshTarget, int) {
+	if maxAge <= 0 {
+		return targets, 0
+	}
+	kept := make([]refreshTarget, 0, len(targets))
+	for _, t := range targets {
+		if balanceCache.IsStaleWithDuration(t.chainID, t.address, "", maxAge) {
+			kept = append(kept, t)
+		}
+	}
+	return kept, len(targets) - len(kept)
+}
+
 // refreshError records a failed refresh for a specific address.
 type refreshError struct {
 	address string
@@ -460,9 +650,11 @@ func findInAddresses(addresses []wallet.Address, target string) bool {
 	return false
 }
 
-// refreshTargetAddresses performs the actual refresh for all targets.
+// refreshTargetAddresses performs the actual refresh for all targets. It
+// returns the per-address errors and how many targets were refreshed, which
+// is fewer than len(targets) when the command is canceled.
 // Returns any errors encountered during refresh.
-func refreshTargetAddresses(ctx context.Context, w io.Writer, cmdCtx *CommandContext, store *utxostore.Store, targets []refreshTarget, balanceCache *cache.BalanceCache) []refreshError {
+func refreshTargetAddresses(ctx context.Context, w io.Writer, cmdCtx *CommandContext, store *utxostore.Store, targets []refreshTarget, balanceCache *cache.BalanceCache) ([]refreshError, int) {
 	targetsByChain := groupTargetsByChain(targets)
 
 	// Create discovery service with balance service
@@ -470,7 +662,11 @@ func refreshTargetAddresses(ctx context.Context, w io.Writer, cmdCtx *CommandCon
 
 	// Refresh each chain's addresses
 	errs := make([]refreshError, 0, len(targets))
+	refreshed := 0
 	for chainID, chainTargets := range targetsByChain {
+		if isCanceled(ctx.Err()) {
+			break
+		}
 		addresses := extractAddresses(chainTargets)
 		displayRefreshProgress(w, addresses, chainID)
 
@@ -480,10 +676,15 @@ func refreshTargetAddresses(ctx context.Context, w io.Writer, cmdCtx *CommandCon
 			Timeout:   30 * time.Second,
 		})
 
+		for _, result := range results {
+			if !isCanceled(result.Error) {
+				refreshed++
+			}
+		}
 		errs = append(errs, convertRefreshResults(results)...)
 	}
 
-	return errs
+	return errs, refreshed
 }
 
 // groupTargetsByChain groups refresh targets by chain ID, excluding unsupported chains.
@@ -532,10 +733,11 @@ func displayRefreshProgress(w io.Writer, addresses []string, chainID chain.ID) {
 }
 
 // convertRefreshResults converts discovery results to refreshError format.
+// An address the refresh did not reach because it was canceled is not an error.
 func convertRefreshResults(results []discovery.RefreshResult) []refreshError {
 	var errs []refreshError
 	for _, result := range results {
-		if !result.Success {
+		if !result.Success && !isCanceled(result.Error) {
 			errs = append(errs, refreshError{address: result.Address, err: result.Error})
 		}
 	}
@@ -574,7 +776,6 @@ func fetchAddressBalances(cmd *cobra.Command, addresses []address.AddressInfo, b
 	}
 
 	const perAddressTimeout = 30 * time.Second
-	const maxConcurrent = 8
 
 	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
 	defer cancel()
@@ -602,18 +803,16 @@ func fetchAddressBalances(cmd *cobra.Command, addresses []address.AddressInfo, b
 	results := make(map[string]*fetchResult)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
-	sem := make(chan struct{}, maxConcurrent)
+	limiter := newBalanceLimiter(cfg)
 
 	for _, task := range tasks {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			select {
-			case sem <- struct{}{}:
-			case <-ctx.Done():
+			permit, err := limiter.Acquire(ctx)
+			if err != nil {
 				return
 			}
-			defer func() { <-sem }()
 
 			balanceSvc := balance.NewService(&balance.Config{
 				ConfigProvider: cfg,
@@ -623,12 +822,16 @@ func fetchAddressBalances(cmd *cobra.Command, addresses []address.AddressInfo, b
 			})
 
 			addrCtx, addrCancel := context.WithTimeout(ctx, perAddressTimeout)
-			result, _ := balanceSvc.FetchBalance(addrCtx, &balance.FetchRequest{
+			result, err := balanceSvc.FetchBalance(addrCtx, &balance.FetchRequest{
 				ChainID:      task.chainID,
 				Address:      task.address,
 				ForceRefresh: false,
 			})
 			addrCancel()
+			if err == nil && result != nil {
+				err = result.Error
+			}
+			limiter.Release(permit, err)
 
 			mu.Lock()
 			defer mu.Unlock()
@@ -653,6 +856,135 @@ func fetchAddressBalances(cmd *cobra.Command, addresses []address.AddressInfo, b
 	}
 }
 
+// fetchETHActivity enriches ETH addresses with their nonce and first/last
+// activity from Etherscan. Without an Etherscan API key, or on lookup errors,
+// addresses keep their balance-derived status.
+func fetchETHActivity(cmd *cobra.Command, addresses []address.AddressInfo, cmdCtx *CommandContext) {
+	hasETH := false
+	for _, addr := range addresses {
+		if addr.ChainID == chain.ETH {
+			hasETH = true
+			break
+		}
+	}
+	if !hasETH {
+		return
+	}
+
+	// The sync state lets repeated lookups ask only for blocks not yet seen
+	opts := etherscanOptions(cmdCtx.Cfg)
+	opts.SyncState = etherscan.NewSyncStateStore(filepath.Join(cmdCtx.Cfg.GetHome(), "cache", "eth_sync.json"))
+	client, err := etherscan.NewClient(cmdCtx.Cfg.GetETHEtherscanAPIKey(), opts)
+	if err != nil {
+		if cmdCtx.Log != nil {
+			cmdCtx.Log.Debug("skipping eth activity lookup: %v", err)
+		}
+		return
+	}
+
+	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
+	defer cancel()
+
+	if err := address.EnrichActivity(ctx, addresses, client); err != nil && cmdCtx.Log != nil {
+		cmdCtx.Log.Error("eth activity lookup failed: %v", err)
+	}
+}
+
+// formatActivity returns a one-line summary of an address's on-chain activity,
+// or "" when no activity data was fetched.
+func formatActivity(addr *address.AddressInfo) string {
+	if addr.Nonce == nil {
+		return ""
+	}
+	if addr.FirstActivity.IsZero() {
+		return fmt.Sprintf("nonce %d, no transactions", *addr.Nonce)
+	}
+	return fmt.Sprintf("nonce %d, first %s, last %s", *addr.Nonce,
+		addr.FirstActivity.Format(time.DateOnly), addr.LastActivity.Format(time.DateOnly))
+}
+
+// annotateAddressSpending fills in each address's sends from the journal,
+// counting recent sends relative to now.
+func annotateAddressSpending(addresses []address.AddressInfo, journal *txjournal.Journal, now time.Time) error {
+	entries, err := journal.Entries()
+	if err != nil {
+		return err
+	}
+	for i := range addresses {
+		addresses[i].Spending = txjournal.SpendingFrom(entries, addresses[i].ChainID, addresses[i].Address, now)
+	}
+	return nil
+}
+
+// formatSpending returns a one-line summary of the sends from an address,
+// or "" when it has none or its history was not requested.
+func formatSpending(addr *address.AddressInfo) string {
+	s := addr.Spending
+	if s == nil || s.Sends == 0 {
+		return ""
+	}
+
+	sends := "sends"
+	if s.Sends == 1 {
+		sends = "send"
+	}
+	spent := []string{chain.FormatDecimalAmount(s.Native, nativeDecimals(addr.ChainID)) + " " + strings.ToUpper(string(addr.ChainID))}
+	for _, t := range s.Tokens {
+		spent = append(spent, chain.FormatDecimalAmount(t.Amount, t.Decimals)+" "+t.Symbol)
+	}
+	return fmt.Sprintf("%d %s (%d in 24h, %d in 7d), spent %s, last %s",
+		s.Sends, sends, s.LastDay, s.LastWeek, strings.Join(spent, " + "), s.LastSent.Local().Format("2006-01-02 15:04"))
+}
+
+// addressSpendingJSON is the spending history of an address in JSON output.
+type addressSpendingJSON struct {
+	Sends    int                     `json:"sends"`
+	Spent    string                  `json:"spent"`
+	Tokens   []addressTokenSpendJSON `json:"tokens,omitempty"`
+	Sends24h int                     `json:"sends_24h"`
+	Sends7d  int                     `json:"sends_7d"`
+	LastSent string                  `json:"last_sent,omitempty"`
+}
+
+// addressTokenSpendJSON is the total of one token sent from an address.
+type addressTokenSpendJSON struct {
+	Token  string `json:"token"`
+	Symbol string `json:"symbol"`
+	Amount string `json:"amount"`
+}
+
+// newAddressSpendingJSON converts an address's spending history for JSON
+// output, or returns nil when it was not requested.
+func newAddressSpendingJSON(addr *address.AddressInfo) *addressSpendingJSON {
+	s := addr.Spending
+	if s == nil {
+		return nil
+	}
+	j := &addressSpendingJSON{
+		Sends:    s.Sends,
+		Spent:    chain.FormatDecimalAmount(s.Native, nativeDecimals(addr.ChainID)),
+		Sends24h: s.LastDay,
+		Sends7d:  s.LastWeek,
+		LastSent: formatActivityTime(s.LastSent),
+	}
+	for _, t := range s.Tokens {
+		j.Tokens = append(j.Tokens, addressTokenSpendJSON{
+			Token:  t.Token,
+			Symbol: t.Symbol,
+			Amount: chain.FormatDecimalAmount(t.Amount, t.Decimals),
+		})
+	}
+	return j
+}
+
+// formatActivityTime formats an activity timestamp for JSON output, or "" if unset.
+func formatActivityTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
 // isNonZeroBalance returns true if the balance string represents a non-zero amount.
 func isNonZeroBalance(s string) bool {
 	if s == "" {
@@ -713,8 +1045,17 @@ func displayAddressesTextNarrow(w io.Writer, addresses []address.AddressInfo) {
 		}
 
 		out(w, "  %-7s  %5d  %-42s  %-14s  %15s  %s\n",
-			addr.Type.String(), addr.Index, truncateAddressDisplay(addr.Address),
-			formatLabel(addr.Label), formatBalanceDisplay(addr.Balance), formatStatus(addr.HasActivity))
+			addr.Type.String(), addr.Index, truncateAddressDisplay(displayAddress(addr.ChainID, addr.Address)),
+			formatLabel(addr.Label), formatBalanceDisplay(addr.Balance), formatAddressStatus(&addr))
+		if activity := formatActivity(&addr); activity != "" {
+			out(w, "                   %s\n", activity)
+		}
+		if spending := formatSpending(&addr); spending != "" {
+			out(w, "                   sent: %s\n", spending)
+		}
+		if len(addr.Tags) > 0 {
+			out(w, "                   tags: %s\n", strings.Join(addr.Tags, ", "))
+		}
 	}
 }
 
@@ -734,10 +1075,29 @@ func displayAddressesTextWide(w io.Writer, addresses []address.AddressInfo) {
 		}
 
 		out(w, "  %-7s  %5d  %-42s  %-14s  %15s  %15s  %s\n",
-			addr.Type.String(), addr.Index, truncateAddressDisplay(addr.Address),
+			addr.Type.String(), addr.Index, truncateAddressDisplay(displayAddress(addr.ChainID, addr.Address)),
 			formatLabel(addr.Label), formatBalanceDisplay(addr.Balance),
-			formatBalanceDisplay(addr.Unconfirmed), formatStatus(addr.HasActivity))
+			formatBalanceDisplay(addr.Unconfirmed), formatAddressStatus(&addr))
+		if activity := formatActivity(&addr); activity != "" {
+			out(w, "                   %s\n", activity)
+		}
+		if spending := formatSpending(&addr); spending != "" {
+			out(w, "                   sent: %s\n", spending)
+		}
+		if len(addr.Tags) > 0 {
+			out(w, "                   tags: %s\n", strings.Join(addr.Tags, ", "))
+		}
+	}
+}
+
+// displayAddress returns an address in the form it should be shown to users.
+// ETH addresses are rendered with their EIP-55 checksum; other chains are
+// returned unchanged.
+func displayAddress(chainID chain.ID, addr string) string {
+	if chainID == chain.ETH {
+		return eth.ToChecksumAddress(addr)
 	}
+	return addr
 }
 
 // truncateAddressDisplay shortens an address for table display.
@@ -775,17 +1135,33 @@ func formatStatus(used bool) string {
 	return "unused"
 }
 
+// formatAddressStatus returns the display status of an address: "pending"
+// for an unused address handed out by receive, otherwise formatStatus.
+func formatAddressStatus(addr *address.AddressInfo) string {
+	if addr.Pending && !addr.HasActivity {
+		return "pending"
+	}
+	return formatStatus(addr.HasActivity)
+}
+
 func displayAddressesJSON(cmd *cobra.Command, addresses []address.AddressInfo) {
 	type addressJSON struct {
-		Chain       string `json:"chain"`
-		Type        string `json:"type"`
-		Index       uint32 `json:"index"`
-		Address     string `json:"address"`
-		Path        string `json:"path"`
-		Label       string `json:"label"`
-		Balance     string `json:"balance"`
-		Unconfirmed string `json:"unconfirmed,omitempty"`
-		Used        bool   `json:"used"`
+		Chain         string   `json:"chain"`
+		Type          string   `json:"type"`
+		Index         uint32   `json:"index"`
+		Address       string   `json:"address"`
+		Path          string   `json:"path"`
+		Label         string   `json:"label"`
+		Tags          []string `json:"tags,omitempty"`
+		Balance       string   `json:"balance"`
+		Unconfirmed   string   `json:"unconfirmed,omitempty"`
+		Used          bool     `json:"used"`
+		Pending       bool     `json:"pending_receive,omitempty"`
+		Nonce         *uint64  `json:"nonce,omitempty"`
+		FirstActivity string   `json:"first_activity,omitempty"`
+		LastActivity  string   `json:"last_activity,omitempty"`
+
+		Spending *addressSpendingJSON `json:"spending,omitempty"`
 	}
 	type responseJSON struct {
 		Addresses []addressJSON `json:"addresses"`
@@ -794,15 +1170,21 @@ func displayAddressesJSON(cmd *cobra.Command, addresses []address.AddressInfo) {
 	resp := responseJSON{Addresses: make([]addressJSON, 0, len(addresses))}
 	for _, addr := range addresses {
 		resp.Addresses = append(resp.Addresses, addressJSON{
-			Chain:       string(addr.ChainID),
-			Type:        addr.Type.String(),
-			Index:       addr.Index,
-			Address:     addr.Address,
-			Path:        addr.Path,
-			Label:       addr.Label,
-			Balance:     addr.Balance,
-			Unconfirmed: addr.Unconfirmed,
-			Used:        addr.HasActivity,
+			Chain:         string(addr.ChainID),
+			Type:          addr.Type.String(),
+			Index:         addr.Index,
+			Address:       displayAddress(addr.ChainID, addr.Address),
+			Path:          addr.Path,
+			Label:         addr.Label,
+			Tags:          addr.Tags,
+			Balance:       addr.Balance,
+			Unconfirmed:   addr.Unconfirmed,
+			Used:          addr.HasActivity,
+			Pending:       addr.Pending && !addr.HasActivity,
+			Nonce:         addr.Nonce,
+			FirstActivity: formatActivityTime(addr.FirstActivity),
+			LastActivity:  formatActivityTime(addr.LastActivity),
+			Spending:      newAddressSpendingJSON(&addr),
 		})
 	}
 
@@ -837,7 +1219,7 @@ func displayAddressesRefreshJSON(cmd *cobra.Command, addresses []address.Address
 			Chain:       string(addr.ChainID),
 			Type:        addr.Type.String(),
 			Index:       addr.Index,
-			Address:     addr.Address,
+			Address:     displayAddress(addr.ChainID, addr.Address),
 			Path:        addr.Path,
 			Label:       addr.Label,
 			Balance:     addr.Balance,
@@ -858,6 +1240,9 @@ func formatSatoshis(sats uint64) string {
 }
 
 func runAddressesLabel(cmd *cobra.Command, args []string) error {
+	if err := resolveWalletName(cmd, &addressesWallet); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd)
 	address := args[0]
 	label := args[1]
@@ -900,3 +1285,33 @@ func runAddressesLabel(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// addressChecksumResult is the output of the addresses checksum command.
+type addressChecksumResult struct {
+	Input       string `json:"input"`
+	Checksum    string `json:"checksum"`
+	Checksummed bool   `json:"checksummed"`
+}
+
+func runAddressesChecksum(cmd *cobra.Command, args []string) error {
+	cmdCtx := GetCmdContext(cmd)
+	input := strings.TrimSpace(args[0])
+
+	checksummed, err := transaction.NormalizeETHRecipient(input, true)
+	if err != nil {
+		return err
+	}
+
+	res := addressChecksumResult{
+		Input:       input,
+		Checksum:    checksummed,
+		Checksummed: input == checksummed,
+	}
+
+	w := cmd.OutOrStdout()
+	if cmdCtx.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, res)
+	}
+	outln(w, res.Checksum)
+	return nil
+}
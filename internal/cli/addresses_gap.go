@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/service/address"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// extendAddressGap derives new receive addresses on each chain whose newest
+// receive address has activity, up to the derivation.address_gap limit, and
+// saves them to the wallet metadata and the UTXO store. used reports the
+// activity of an address. Chains the wallet cannot derive on, such as those
+// of a watch-only wallet imported without an xpub, are skipped, and so is a
+// wallet file written by a newer sigil, which cannot be saved.
+//
+// It returns the new addresses as refresh targets. A failure to derive or
+// save is logged rather than returned, so it never fails the listing that
+// triggered it.
+func extendAddressGap(cmd *cobra.Command, storage *wallet.FileStorage, store *utxostore.Store,
+	wlt *wallet.Wallet, seed []byte, chains []chain.ID, used func(chain.ID, string) bool,
+) []refreshTarget {
+	cmdCtx := GetCmdContext(cmd)
+	gap := cmdCtx.Cfg.GetAddressGap()
+	if gap == 0 || wlt.IsNewerVersion() {
+		return nil
+	}
+
+	// SIGIL_AGENT_XPUB only derives the wallet's default account, which
+	// AccountIndexes lists first
+	agentXpub := cmdCtx.AgentXpub
+	if wlt.AccountIndex() != wlt.AccountIndexes()[0] {
+		agentXpub = ""
+	}
+
+	addressService := address.NewService(address.NewMetadataAdapter(store))
+	var targets []refreshTarget
+	for _, chainID := range chains {
+		xpub := agentXpub
+		if xpub == "" {
+			xpub = wlt.Xpubs[chainID]
+		}
+		if seed == nil && xpub == "" {
+			continue
+		}
+
+		derived, err := addressService.ExtendGap(&address.GapRequest{
+			Wallet:   wlt,
+			Seed:     seed,
+			ChainID:  chainID,
+			Xpub:     xpub,
+			GapLimit: gap,
+			Used:     func(addr string) bool { return used(chainID, addr) },
+		})
+		if err != nil {
+			logGapError(cmdCtx, "deriving %s receive addresses: %v", chainID, err)
+		}
+		for _, addr := range derived {
+			store.AddAddress(&utxostore.AddressMetadata{
+				Address:        addr.Address,
+				ChainID:        chainID,
+				DerivationPath: addr.Path,
+				Index:          addr.Index,
+			})
+			targets = append(targets, refreshTarget{address: addr.Address, chainID: chainID})
+		}
+		if len(derived) > 0 {
+			out(cmd.ErrOrStderr(), "Derived %d new %s receive address(es): the last one has activity (gap limit %d)\n",
+				len(derived), chainID, gap)
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	if err := storage.UpdateMetadata(wlt); err != nil {
+		logGapError(cmdCtx, "persisting wallet metadata: %v", err)
+		return nil
+	}
+	if err := store.Save(); err != nil {
+		logGapError(cmdCtx, "saving UTXO store: %v", err)
+	}
+	return targets
+}
+
+// logGapError logs a failure to extend the address gap.
+func logGapError(cmdCtx *CommandContext, format string, args ...any) {
+	if cmdCtx.Log != nil {
+		cmdCtx.Log.Error("address gap extension: "+format, args...)
+	}
+}
+
+// storedAddressUsed reports whether the UTXO store has seen activity on an
+// address.
+func storedAddressUsed(store *utxostore.Store) func(chain.ID, string) bool {
+	return func(chainID chain.ID, addr string) bool {
+		meta := store.GetAddress(chainID, addr)
+		return meta != nil && meta.HasActivity
+	}
+}
+
+// targetAddressInfos returns the addresses of wlt that are among targets.
+func targetAddressInfos(store *utxostore.Store, wlt *wallet.Wallet, targets []refreshTarget) []address.AddressInfo {
+	if len(targets) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(targets))
+	for _, t := range targets {
+		set[fmt.Sprintf("%s:%s", t.chainID, t.address)] = true
+	}
+
+	var infos []address.AddressInfo
+	for _, info := range address.NewService(address.NewMetadataAdapter(store)).Collect(&address.CollectionRequest{
+		Wallet:     wlt,
+		TypeFilter: address.Receive,
+	}) {
+		if set[fmt.Sprintf("%s:%s", info.ChainID, info.Address)] {
+			infos = append(infos, info)
+		}
+	}
+	return infos
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+func TestExtendAddressGap(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	walletsDir := filepath.Join(home, "wallets")
+	createTestWallet(t, walletsDir, "gap")
+	storage := wallet.NewFileStorage(walletsDir)
+	wlt, seed, err := storage.Load("gap", []byte("password"))
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+
+	store := utxostore.New(filepath.Join(walletsDir, "gap"))
+	tail := wlt.Addresses[chain.ETH][0]
+	store.AddAddress(&utxostore.AddressMetadata{Address: tail.Address, ChainID: chain.ETH, HasActivity: true})
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{
+		Cfg: &mockConfigProvider{home: home, addressGap: 3},
+		Fmt: &mockFormatProvider{format: output.FormatText},
+	})
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	targets := extendAddressGap(cmd, storage, store, wlt, seed, []chain.ID{chain.ETH}, storedAddressUsed(store))
+	require.Len(t, targets, 3)
+	assert.Contains(t, stderr.String(), "Derived 3 new eth receive address(es)")
+
+	// The new addresses are saved to the wallet metadata and the UTXO store
+	loaded, err := storage.LoadMetadata("gap")
+	require.NoError(t, err)
+	require.Len(t, loaded.Addresses[chain.ETH], 4)
+	assert.Equal(t, "m/44'/60'/0'/0/3", loaded.Addresses[chain.ETH][3].Path)
+	reloaded := utxostore.New(filepath.Join(walletsDir, "gap"))
+	require.NoError(t, reloaded.Load())
+	assert.NotNil(t, reloaded.GetAddress(chain.ETH, targets[2].address))
+
+	infos := targetAddressInfos(store, wlt, targets)
+	require.Len(t, infos, 3)
+	assert.Equal(t, uint32(1), infos[0].Index)
+
+	// The tail is unused again, and a wallet without key material is skipped
+	assert.Empty(t, extendAddressGap(cmd, storage, store, wlt, seed, []chain.ID{chain.ETH}, storedAddressUsed(store)))
+	allUsed := func(chain.ID, string) bool { return true }
+	assert.Empty(t, extendAddressGap(cmd, storage, store, loaded, nil, []chain.ID{chain.ETH}, allUsed))
+}
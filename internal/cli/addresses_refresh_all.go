@@ -0,0 +1,224 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// walletRefreshSummary is the outcome of refreshing one wallet with --all-wallets.
+type walletRefreshSummary struct {
+	Wallet    string `json:"wallet"`
+	Refreshed int    `json:"refreshed"`
+	Skipped   int    `json:"skipped"`
+	Errors    int    `json:"errors"`
+	Error     string `json:"error,omitempty"`
+
+	addressErrors []refreshError
+}
+
+// failed reports whether nothing useful happened for the wallet: it could not
+// be loaded, or every address it tried to refresh failed.
+func (s *walletRefreshSummary) failed() bool {
+	return s.Error != "" || (s.Refreshed > 0 && s.Errors == s.Refreshed)
+}
+
+// runAddressesRefreshAll refreshes every wallet under the sigil home and
+// prints a consolidated summary. It returns an error only when every wallet
+// failed, so a cron job is alerted to outages but not to a single bad address.
+func runAddressesRefreshAll(cmd *cobra.Command) error {
+	cmdCtx := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+
+	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	names, err := storage.List()
+	if err != nil {
+		return fmt.Errorf("listing wallets: %w", err)
+	}
+
+	jsonOutput := cmdCtx.Fmt.Format() == output.FormatJSON
+	if len(names) == 0 {
+		if jsonOutput {
+			displayRefreshAllJSON(w, nil)
+		} else {
+			outln(w, "No wallets found to refresh.")
+		}
+		return nil
+	}
+
+	// One balance cache is shared by all wallets and saved once at the end.
+	// It is loaded rather than recreated so entries for addresses skipped by
+	// --max-age, or belonging to wallets that fail, are kept.
+	cacheStorage := cache.NewBalanceStorage(cmdCtx.Cfg.GetHome(), cmdCtx.Cfg.GetCache())
+	balanceCache := loadOrCreateBalanceCache(cacheStorage, false, cmd, cmdCtx.Log)
+
+	if !jsonOutput {
+		out(w, "Refreshing %d wallet(s)...\n", len(names))
+	}
+
+	summaries := make([]*walletRefreshSummary, 0, len(names))
+	for _, name := range names {
+		if commandCanceled(cmd) {
+			if !jsonOutput {
+				out(w, "  Canceled: %d wallet(s) not refreshed\n", len(names)-len(summaries))
+			}
+			break
+		}
+		summary := refreshWallet(cmd, cmdCtx, storage, name, balanceCache, addressesChain, addressesRefreshMaxAge)
+		summaries = append(summaries, summary)
+		if !jsonOutput {
+			displayWalletRefreshLine(w, summary)
+		}
+	}
+
+	if saveErr := cacheStorage.Save(balanceCache); saveErr != nil {
+		if cmdCtx.Log != nil {
+			cmdCtx.Log.Error("failed to save balance cache: %v", saveErr)
+		}
+	}
+
+	if jsonOutput {
+		displayRefreshAllJSON(w, summaries)
+	} else {
+		displayRefreshAllText(w, summaries)
+	}
+
+	for _, s := range summaries {
+		if !s.failed() {
+			return nil
+		}
+	}
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrNetworkError,
+		fmt.Sprintf("refresh failed for all %d wallet(s)", len(summaries)),
+	)
+}
+
+// refreshWallet refreshes the addresses of a single wallet on chainFilter
+// (all chains when empty), skipping those refreshed within maxAge. Wallets are
+// read from their metadata, so no password or session is needed.
+func refreshWallet(cmd *cobra.Command, cmdCtx *CommandContext, storage *wallet.FileStorage, name string, balanceCache *cache.BalanceCache, chainFilter string, maxAge time.Duration) *walletRefreshSummary {
+	summary := &walletRefreshSummary{Wallet: name}
+
+	wlt, err := storage.LoadMetadata(name)
+	if err != nil {
+		summary.Error = fmt.Sprintf("loading wallet: %v", err)
+		return summary
+	}
+
+	// Clients and the shared cache serve the command's network
+	if network := effectiveBSVNetwork(wlt, cmdCtx.Cfg); network != cmdCtx.Cfg.GetBSVNetwork() {
+		summary.Error = fmt.Sprintf("%snet wallet; refresh it with --network %s", network, network)
+		return summary
+	}
+
+	chains, err := refreshChains(wlt, chainFilter)
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+	targets, err := buildRefreshTargets(wlt, chains, nil)
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+	targets, summary.Skipped = filterRecentTargets(targets, balanceCache, maxAge)
+	if len(targets) == 0 {
+		return summary
+	}
+
+	lock, err := lockWallet(cmd, storage, name, "addresses refresh")
+	if err != nil {
+		summary.Error = err.Error()
+		return summary
+	}
+	defer func() { _ = lock.Release() }()
+
+	store := utxostore.New(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", name))
+	if loadErr := store.Load(); loadErr != nil {
+		summary.Error = fmt.Sprintf("loading UTXO store: %v", loadErr)
+		return summary
+	}
+
+	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
+	defer cancel()
+
+	// Per-address progress is dropped; the summary line replaces it
+	summary.addressErrors, summary.Refreshed = refreshTargetAddresses(ctx, io.Discard, cmdCtx, store, targets, balanceCache)
+	summary.Errors = len(summary.addressErrors)
+	return summary
+}
+
+// displayWalletRefreshLine prints the one-line outcome for a wallet.
+func displayWalletRefreshLine(w io.Writer, s *walletRefreshSummary) {
+	if s.Error != "" {
+		out(w, "  %s: failed: %s\n", s.Wallet, s.Error)
+		return
+	}
+	out(w, "  %s: %d refreshed, %d skipped, %d error(s)\n", s.Wallet, s.Refreshed, s.Skipped, s.Errors)
+}
+
+// displayRefreshAllText prints the consolidated totals and any address errors.
+func displayRefreshAllText(w io.Writer, summaries []*walletRefreshSummary) {
+	var refreshed, skipped, errs, failed int
+	for _, s := range summaries {
+		refreshed += s.Refreshed
+		skipped += s.Skipped
+		errs += s.Errors
+		if s.failed() {
+			failed++
+		}
+	}
+
+	outln(w)
+	out(w, "Refreshed %d address(es) across %d wallet(s)", refreshed, len(summaries))
+	if skipped > 0 {
+		out(w, ", %d skipped", skipped)
+	}
+	if errs > 0 {
+		out(w, ", %d error(s)", errs)
+	}
+	if failed > 0 {
+		out(w, ", %d wallet(s) failed", failed)
+	}
+	outln(w)
+
+	for _, s := range summaries {
+		for _, re := range s.addressErrors {
+			out(w, "  Error refreshing %s [%s]: %s\n", truncateAddressDisplay(re.address), s.Wallet, re.err)
+		}
+	}
+}
+
+// displayRefreshAllJSON writes the per-wallet summaries and totals as JSON.
+func displayRefreshAllJSON(w io.Writer, summaries []*walletRefreshSummary) {
+	type responseJSON struct {
+		Wallets       []*walletRefreshSummary `json:"wallets"`
+		Refreshed     int                     `json:"refreshed"`
+		Skipped       int                     `json:"skipped"`
+		Errors        int                     `json:"errors"`
+		FailedWallets int                     `json:"failed_wallets"`
+	}
+
+	resp := responseJSON{Wallets: make([]*walletRefreshSummary, 0, len(summaries))}
+	for _, s := range summaries {
+		resp.Wallets = append(resp.Wallets, s)
+		resp.Refreshed += s.Refreshed
+		resp.Skipped += s.Skipped
+		resp.Errors += s.Errors
+		if s.failed() {
+			resp.FailedWallets++
+		}
+	}
+
+	_ = writeJSON(w, resp)
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestFilterRecentTargets(t *testing.T) {
+	t.Parallel()
+
+	balanceCache := cache.NewBalanceCache()
+	balanceCache.Set(cache.BalanceCacheEntry{Chain: chain.BSV, Address: "1Fresh", Balance: "0.1"})
+	balanceCache.Entries[cache.Key(chain.BSV, "1Old", "")] = cache.BalanceCacheEntry{
+		Chain: chain.BSV, Address: "1Old", Balance: "0.2", UpdatedAt: time.Now().Add(-2 * time.Hour),
+	}
+
+	targets := []refreshTarget{
+		{address: "1Fresh", chainID: chain.BSV},
+		{address: "1Old", chainID: chain.BSV},
+		{address: "1Never", chainID: chain.BSV},
+	}
+
+	kept, skipped := filterRecentTargets(targets, balanceCache, time.Hour)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, []refreshTarget{targets[1], targets[2]}, kept)
+
+	kept, skipped = filterRecentTargets(targets, balanceCache, 0)
+	assert.Equal(t, 0, skipped)
+	assert.Equal(t, targets, kept)
+}
+
+func TestWalletRefreshSummary_Failed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		summary walletRefreshSummary
+		failed  bool
+	}{
+		{"all refreshed", walletRefreshSummary{Refreshed: 3}, false},
+		{"some errors", walletRefreshSummary{Refreshed: 3, Errors: 2}, false},
+		{"all errors", walletRefreshSummary{Refreshed: 3, Errors: 3}, true},
+		{"all skipped", walletRefreshSummary{Skipped: 4}, false},
+		{"load error", walletRefreshSummary{Error: "loading wallet: boom"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.failed, tc.summary.failed())
+		})
+	}
+}
+
+func TestDisplayRefreshAllText(t *testing.T) {
+	t.Parallel()
+
+	summaries := []*walletRefreshSummary{
+		{Wallet: "main", Refreshed: 4, Skipped: 2, Errors: 1, addressErrors: []refreshError{
+			{address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", err: errors.New("timeout")}, //nolint:err113 // test error
+		}},
+		{Wallet: "broken", Error: "loading wallet: bad file"},
+	}
+
+	var buf bytes.Buffer
+	for _, s := range summaries {
+		displayWalletRefreshLine(&buf, s)
+	}
+	displayRefreshAllText(&buf, summaries)
+
+	result := buf.String()
+	assert.Contains(t, result, "main: 4 refreshed, 2 skipped, 1 error(s)")
+	assert.Contains(t, result, "broken: failed: loading wallet: bad file")
+	assert.Contains(t, result, "Refreshed 4 address(es) across 2 wallet(s), 2 skipped, 1 error(s), 1 wallet(s) failed")
+	assert.Contains(t, result, "[main]: timeout")
+}
+
+func TestDisplayRefreshAllJSON(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displayRefreshAllJSON(&buf, []*walletRefreshSummary{
+		{Wallet: "main", Refreshed: 4, Skipped: 2, Errors: 1},
+		{Wallet: "broken", Error: "loading wallet: bad file"},
+	})
+
+	var resp struct {
+		Wallets []struct {
+			Wallet string `json:"wallet"`
+			Error  string `json:"error"`
+		} `json:"wallets"`
+		Refreshed     int `json:"refreshed"`
+		Skipped       int `json:"skipped"`
+		Errors        int `json:"errors"`
+		FailedWallets int `json:"failed_wallets"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+	require.Len(t, resp.Wallets, 2)
+	assert.Equal(t, "broken", resp.Wallets[1].Wallet)
+	assert.Equal(t, 4, resp.Refreshed)
+	assert.Equal(t, 2, resp.Skipped)
+	assert.Equal(t, 1, resp.Errors)
+	assert.Equal(t, 1, resp.FailedWallets)
+
+	buf.Reset()
+	displayRefreshAllJSON(&buf, nil)
+	assert.Contains(t, buf.String(), `"wallets": []`)
+}
+
+// newRefreshAllTestCmd creates a command for runAddressesRefreshAll and sets
+// the --max-age flag variable for the duration of the test.
+func newRefreshAllTestCmd(t *testing.T, home string, format output.Format, maxAge time.Duration) (*cobra.Command, *bytes.Buffer) {
+	t.Helper()
+
+	origMaxAge, origChain := addressesRefreshMaxAge, addressesChain
+	t.Cleanup(func() {
+		addressesRefreshMaxAge, addressesChain = origMaxAge, origChain
+	})
+	addressesRefreshMaxAge, addressesChain = maxAge, ""
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{
+		Cfg: &mockConfigProvider{home: home},
+		Fmt: &mockFormatProvider{format: format},
+	})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	return cmd, &buf
+}
+
+// warmBalanceCache writes a fresh cache entry for every address of a wallet.
+func warmBalanceCache(t *testing.T, home, name string) {
+	t.Helper()
+
+	wlt, err := wallet.NewFileStorage(filepath.Join(home, "wallets")).LoadMetadata(name)
+	require.NoError(t, err)
+
+	balanceCache := cache.NewBalanceCache()
+	targets, err := buildRefreshTargets(wlt, wlt.EnabledChains, nil)
+	require.NoError(t, err)
+	for _, target := range targets {
+		balanceCache.Set(cache.BalanceCacheEntry{Chain: target.chainID, Address: target.address, Balance: "0"})
+	}
+	require.NoError(t, cache.NewFileStorage(filepath.Join(home, "cache", "balances.json")).Save(balanceCache))
+}
+
+func TestRunAddressesRefreshAll_NoWallets(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	cmd, buf := newRefreshAllTestCmd(t, tmpDir, output.FormatText, 0)
+	require.NoError(t, runAddressesRefreshAll(cmd))
+	assert.Contains(t, buf.String(), "No wallets found to refresh.")
+}
+
+func TestRunAddressesRefreshAll_PartialFailure(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	walletsDir := filepath.Join(tmpDir, "wallets")
+	createTestWallet(t, walletsDir, "good")
+	warmBalanceCache(t, tmpDir, "good")
+	require.NoError(t, os.WriteFile(filepath.Join(walletsDir, "broken.wallet"), []byte("{not json"), 0o600))
+
+	// Every address of "good" was just refreshed, so nothing hits the network
+	cmd, buf := newRefreshAllTestCmd(t, tmpDir, output.FormatText, time.Hour)
+	require.NoError(t, runAddressesRefreshAll(cmd))
+
+	result := buf.String()
+	assert.Contains(t, result, "Refreshing 2 wallet(s)...")
+	assert.Contains(t, result, "good: 0 refreshed, 1 skipped, 0 error(s)")
+	assert.Contains(t, result, "broken: failed: loading wallet")
+	assert.Contains(t, result, "1 wallet(s) failed")
+}
+
+func TestRunAddressesRefreshAll_TotalFailure(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	walletsDir := filepath.Join(tmpDir, "wallets")
+	require.NoError(t, os.WriteFile(filepath.Join(walletsDir, "broken.wallet"), []byte("{not json"), 0o600))
+
+	cmd, buf := newRefreshAllTestCmd(t, tmpDir, output.FormatJSON, 0)
+	err := runAddressesRefreshAll(cmd)
+	require.ErrorIs(t, err, sigilerr.ErrNetworkError)
+	assert.Contains(t, buf.String(), `"failed_wallets": 1`)
+}
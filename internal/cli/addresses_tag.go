@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// addressesTagCmd adds tags to an address.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var addressesTagCmd = &cobra.Command{
+	Use:   "tag <address> <tag>...",
+	Short: "Add tags to an address",
+	Long: `Add one or more tags to an address.
+
+Tags are hierarchical: segments are separated by ':' so related tags group
+together, as in customer:acme or purpose:invoices. A filter on a namespace
+matches every tag under it, so --tag customer selects customer:acme and
+customer:globex. Tags are lower-cased and may contain letters, digits, '-',
+'_' and '.'. An address can carry any number of tags alongside its label.`,
+	Example: `  # Tag an address with a customer and a purpose
+  sigil addresses tag 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa customer:acme purpose:invoices --wallet main
+
+  # List addresses for any customer
+  sigil addresses list --wallet main --tag customer`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runAddressesTag,
+}
+
+// addressesUntagCmd removes tags from an address.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var addressesUntagCmd = &cobra.Command{
+	Use:   "untag <address> <tag>...",
+	Short: "Remove tags from an address",
+	Long: `Remove one or more tags from an address.
+
+Only the exact tags given are removed; untagging customer does not remove
+customer:acme.`,
+	Example: `  sigil addresses untag 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa purpose:invoices --wallet main`,
+	Args:    cobra.MinimumNArgs(2),
+	RunE:    runAddressesUntag,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	addressesCmd.AddCommand(addressesTagCmd)
+	addressesCmd.AddCommand(addressesUntagCmd)
+
+	addressesTagCmd.Flags().StringVarP(&addressesWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
+	addressesUntagCmd.Flags().StringVarP(&addressesWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
+}
+
+// addressTagsResult is the output of the addresses tag and untag commands.
+type addressTagsResult struct {
+	Address string   `json:"address"`
+	Chain   string   `json:"chain"`
+	Tags    []string `json:"tags"`
+}
+
+func runAddressesTag(cmd *cobra.Command, args []string) error {
+	return updateAddressTags(cmd, args[0], args[1:], true)
+}
+
+func runAddressesUntag(cmd *cobra.Command, args []string) error {
+	return updateAddressTags(cmd, args[0], args[1:], false)
+}
+
+// updateAddressTags adds or removes tags on an address in the wallet's UTXO
+// store and prints the address's resulting tags.
+func updateAddressTags(cmd *cobra.Command, addr string, rawTags []string, add bool) error {
+	if err := resolveWalletName(cmd, &addressesWallet); err != nil {
+		return err
+	}
+	cmdCtx := GetCmdContext(cmd)
+
+	tags, err := normalizeTagArgs(rawTags)
+	if err != nil {
+		return err
+	}
+
+	// Load UTXO store
+	utxoStorePath := filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", addressesWallet)
+	store := utxostore.New(utxoStorePath)
+	if loadErr := store.Load(); loadErr != nil {
+		return fmt.Errorf("loading UTXO store: %w", loadErr)
+	}
+
+	chainID, stored, found := findStoredAddress(store, addr)
+	if !found {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("address not found in wallet: %s", addr),
+		)
+	}
+
+	if add {
+		err = store.AddAddressTags(chainID, stored, tags...)
+	} else {
+		_, err = store.RemoveAddressTags(chainID, stored, tags...)
+	}
+	if err != nil {
+		return fmt.Errorf("updating address tags: %w", err)
+	}
+
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("saving UTXO store: %w", err)
+	}
+
+	res := addressTagsResult{
+		Address: displayAddress(chainID, stored),
+		Chain:   string(chainID),
+		Tags:    store.GetAddress(chainID, stored).Tags,
+	}
+	if res.Tags == nil {
+		res.Tags = []string{}
+	}
+
+	w := cmd.OutOrStdout()
+	if cmdCtx.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, res)
+	}
+	if len(res.Tags) == 0 {
+		out(w, "Address %s has no tags\n", res.Address)
+	} else {
+		out(w, "Tags for address %s: %s\n", res.Address, strings.Join(res.Tags, ", "))
+	}
+	return nil
+}
+
+// normalizeTagArgs normalizes tags given on the command line.
+func normalizeTagArgs(rawTags []string) ([]string, error) {
+	tags := make([]string, 0, len(rawTags))
+	for _, raw := range rawTags {
+		tag, err := utxostore.NormalizeTag(raw)
+		if err != nil {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("%v; use letters, digits, '-', '_' and '.', with ':' between namespaces (e.g. customer:acme)", err),
+			)
+		}
+		tags = append(tags, tag)
+	}
+	return tags, nil
+}
+
+// findStoredAddress finds an address in the UTXO store and returns its chain
+// and stored form. ETH addresses match regardless of checksum case.
+func findStoredAddress(store *utxostore.Store, addr string) (chain.ID, string, bool) {
+	for _, chainID := range []chain.ID{chain.BSV, chain.ETH} {
+		if store.GetAddress(chainID, addr) != nil {
+			return chainID, addr, true
+		}
+	}
+	for _, meta := range store.GetAddresses(chain.ETH) {
+		if strings.EqualFold(meta.Address, addr) {
+			return chain.ETH, meta.Address, true
+		}
+	}
+	return "", "", false
+}
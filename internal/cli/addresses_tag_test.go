@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newTagTestCmd creates a wallet UTXO store holding the given addresses and
+// returns a command whose context points at it.
+func newTagTestCmd(t *testing.T, format output.Format, addrs ...*utxostore.AddressMetadata) (*cobra.Command, *bytes.Buffer, string) {
+	t.Helper()
+	testHome := t.TempDir()
+	walletDir := filepath.Join(testHome, "wallets", "tagwallet")
+	require.NoError(t, os.MkdirAll(walletDir, 0o750))
+
+	store := utxostore.New(walletDir)
+	for _, addr := range addrs {
+		store.AddAddress(addr)
+	}
+	require.NoError(t, store.Save())
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{
+		Cfg: &mockConfigProvider{home: testHome},
+		Fmt: &mockFormatProvider{format: format},
+	})
+	addressesWallet = "tagwallet"
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	return cmd, &buf, walletDir
+}
+
+func TestRunAddressesTag(t *testing.T) {
+	// Tests modify the global addressesWallet variable so cannot be parallel at top level.
+	origWallet := addressesWallet
+	defer func() { addressesWallet = origWallet }()
+
+	t.Run("add and remove tags", func(t *testing.T) {
+		cmd, buf, walletDir := newTagTestCmd(t, output.FormatText,
+			&utxostore.AddressMetadata{Address: "1TagAddr", ChainID: chain.BSV, Label: "Invoices"})
+
+		require.NoError(t, runAddressesTag(cmd, []string{"1TagAddr", "Customer:Acme", "purpose:invoices"}))
+		assert.Contains(t, buf.String(), "customer:acme, purpose:invoices")
+
+		buf.Reset()
+		require.NoError(t, runAddressesUntag(cmd, []string{"1TagAddr", "purpose:invoices"}))
+		assert.Contains(t, buf.String(), "Tags for address 1TagAddr: customer:acme")
+
+		store := utxostore.New(walletDir)
+		require.NoError(t, store.Load())
+		meta := store.GetAddress(chain.BSV, "1TagAddr")
+		require.NotNil(t, meta)
+		assert.Equal(t, []string{"customer:acme"}, meta.Tags)
+		assert.Equal(t, "Invoices", meta.Label)
+	})
+
+	t.Run("eth address in any case", func(t *testing.T) {
+		stored := "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+		cmd, buf, _ := newTagTestCmd(t, output.FormatJSON,
+			&utxostore.AddressMetadata{Address: stored, ChainID: chain.ETH})
+
+		require.NoError(t, runAddressesTag(cmd, []string{"0x742d35cc6634c0532925a3b844bc454e4438f44e", "vendor"}))
+
+		var res addressTagsResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		assert.Equal(t, "eth", res.Chain)
+		assert.Equal(t, stored, res.Address)
+		assert.Equal(t, []string{"vendor"}, res.Tags)
+	})
+
+	t.Run("untag last tag", func(t *testing.T) {
+		cmd, buf, _ := newTagTestCmd(t, output.FormatJSON,
+			&utxostore.AddressMetadata{Address: "1TagAddr", ChainID: chain.BSV, Tags: []string{"vendor"}})
+
+		require.NoError(t, runAddressesUntag(cmd, []string{"1TagAddr", "vendor"}))
+		assert.JSONEq(t, `{"address":"1TagAddr","chain":"bsv","tags":[]}`, buf.String())
+	})
+
+	t.Run("invalid tag", func(t *testing.T) {
+		cmd, _, _ := newTagTestCmd(t, output.FormatText,
+			&utxostore.AddressMetadata{Address: "1TagAddr", ChainID: chain.BSV})
+
+		err := runAddressesTag(cmd, []string{"1TagAddr", "customer:"})
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	})
+
+	t.Run("address not found", func(t *testing.T) {
+		cmd, _, _ := newTagTestCmd(t, output.FormatText)
+
+		err := runAddressesTag(cmd, []string{"1Missing", "vendor"})
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, err, &se)
+		assert.Contains(t, se.Suggestion, "1Missing")
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -15,7 +16,9 @@ import (
 
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
 	"github.com/mrz1836/sigil/internal/service/address"
+	"github.com/mrz1836/sigil/internal/txjournal"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
@@ -350,6 +353,37 @@ func TestDisplayAddressesJSON(t *testing.T) {
 				assert.Contains(t, output, `"unconfirmed": "0.5"`)
 			},
 		},
+		{
+			name: "eth address with activity",
+			addresses: []address.AddressInfo{
+				{
+					Type:          address.Receive,
+					Address:       "0x742d35cc6634c0532925a3b844bc454e4438f44e",
+					Balance:       "0",
+					HasActivity:   true,
+					ChainID:       chain.ETH,
+					Nonce:         func() *uint64 { n := uint64(0); return &n }(),
+					FirstActivity: time.Unix(1700000000, 0),
+					LastActivity:  time.Unix(1710000000, 0),
+				},
+			},
+			validate: func(t *testing.T, output string) {
+				assert.Contains(t, output, `"nonce": 0`)
+				assert.Contains(t, output, `"first_activity": "2023-11-14T22:13:20Z"`)
+				assert.Contains(t, output, `"last_activity": "2024-03-09T16:00:00Z"`)
+				assert.Contains(t, output, `"used": true`)
+			},
+		},
+		{
+			name: "activity omitted when not fetched",
+			addresses: []address.AddressInfo{
+				{Type: address.Receive, Address: "1TestAddress", ChainID: chain.BSV},
+			},
+			validate: func(t *testing.T, output string) {
+				assert.NotContains(t, output, `"nonce"`)
+				assert.NotContains(t, output, `"first_activity"`)
+			},
+		},
 		{
 			name: "multiple addresses",
 			addresses: []address.AddressInfo{
@@ -398,7 +432,7 @@ func TestDisplayAddressesJSONStructure(t *testing.T) {
 		{
 			Type:        address.Receive,
 			Index:       5,
-			Address:     "0x742d35Cc6634C0532925a3b844Bc9e7595f8b2E0",
+			Address:     "0x742d35cc6634c0532925a3b844bc454e4438f44e",
 			Path:        "m/44'/60'/0'/0/5",
 			Label:       "Main",
 			Balance:     "1.0",
@@ -436,7 +470,7 @@ func TestDisplayAddressesJSONStructure(t *testing.T) {
 	assert.Equal(t, "eth", addr.Chain)
 	assert.Equal(t, "receive", addr.Type)
 	assert.Equal(t, 5, addr.Index)
-	assert.Equal(t, "0x742d35Cc6634C0532925a3b844Bc9e7595f8b2E0", addr.Address)
+	assert.Equal(t, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", addr.Address, "ETH addresses are rendered checksummed")
 	assert.Equal(t, "m/44'/60'/0'/0/5", addr.Path)
 	assert.Equal(t, "Main", addr.Label)
 	assert.Equal(t, "1.0", addr.Balance)
@@ -811,6 +845,10 @@ func TestFormatHelpers(t *testing.T) {
 	// formatStatus
 	assert.Equal(t, "used", formatStatus(true))
 	assert.Equal(t, "unused", formatStatus(false))
+
+	// formatAddressStatus
+	assert.Equal(t, "pending", formatAddressStatus(&address.AddressInfo{Pending: true}))
+	assert.Equal(t, "used", formatAddressStatus(&address.AddressInfo{Pending: true, HasActivity: true}))
 }
 
 // mockLogWriter implements LogWriter for testing.
@@ -1157,9 +1195,9 @@ func TestAddressesRefreshCmd_FlagRegistration(t *testing.T) {
 	addressFlag := addressesRefreshCmd.Flags().Lookup("address")
 	require.NotNil(t, addressFlag, "address flag should exist")
 
-	// Verify wallet is required
+	// Wallet is optional; it falls back to config default_wallet
 	err := addressesRefreshCmd.ValidateRequiredFlags()
-	require.Error(t, err, "wallet flag should be required")
+	require.NoError(t, err, "wallet flag should not be required")
 }
 
 func TestBuildRefreshTargets(t *testing.T) {
@@ -1254,3 +1292,151 @@ func TestFindInAddresses(t *testing.T) {
 	assert.False(t, findInAddresses(nil, "1Addr1"))
 	assert.False(t, findInAddresses([]wallet.Address{}, "1Addr1"))
 }
+
+func TestDisplayAddress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		chainID chain.ID
+		addr    string
+		want    string
+	}{
+		{"eth lowercase", chain.ETH, "0x742d35cc6634c0532925a3b844bc454e4438f44e", "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"},
+		{"eth already checksummed", chain.ETH, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"},
+		{"eth invalid unchanged", chain.ETH, "0xETHAddress", "0xETHAddress"},
+		{"bsv unchanged", chain.BSV, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, displayAddress(tc.chainID, tc.addr))
+		})
+	}
+}
+
+func TestFormatActivity(t *testing.T) {
+	t.Parallel()
+
+	nonce := uint64(7)
+	zero := uint64(0)
+
+	assert.Empty(t, formatActivity(&address.AddressInfo{}))
+	assert.Equal(t, "nonce 0, no transactions", formatActivity(&address.AddressInfo{Nonce: &zero}))
+	assert.Equal(t, "nonce 7, first 2023-11-14, last 2024-03-09", formatActivity(&address.AddressInfo{
+		Nonce:         &nonce,
+		FirstActivity: time.Unix(1700000000, 0).UTC(),
+		LastActivity:  time.Unix(1710000000, 0).UTC(),
+	}))
+}
+
+func TestAnnotateAddressSpending(t *testing.T) {
+	t.Parallel()
+
+	const ethFrom = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	now := time.Now()
+	journal := txjournal.New(t.TempDir())
+	require.NoError(t, journal.Record(txjournal.Entry{
+		Hash: "0x01", Chain: chain.ETH, From: ethFrom, Amount: "1000000000000000000", Fee: "21000000000000",
+		Direction: txjournal.DirectionSent, CreatedAt: now.Add(-time.Hour),
+	}))
+	require.NoError(t, journal.Record(txjournal.Entry{
+		Hash: "0x02", Chain: chain.ETH, From: ethFrom, Amount: "2500000", Token: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+		Symbol: "USDC", Decimals: 6, Fee: "65000000000000", Direction: txjournal.DirectionSent, CreatedAt: now.Add(-72 * time.Hour),
+	}))
+
+	addresses := []address.AddressInfo{
+		{ChainID: chain.ETH, Address: strings.ToLower(ethFrom)},
+		{ChainID: chain.BSV, Address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+	}
+	require.NoError(t, annotateAddressSpending(addresses, journal, now))
+	require.NotNil(t, addresses[1].Spending)
+	assert.Empty(t, formatSpending(&addresses[1]), "addresses without sends show no history line")
+
+	text := formatSpending(&addresses[0])
+	assert.Contains(t, text, "2 sends (1 in 24h, 2 in 7d)")
+	assert.Contains(t, text, "spent 1.000086 ETH + 2.5 USDC")
+
+	buf := new(bytes.Buffer)
+	cmd := &cobra.Command{}
+	cmd.SetOut(buf)
+	displayAddressesJSON(cmd, addresses)
+
+	var result struct {
+		Addresses []struct {
+			Spending *struct {
+				Sends    int    `json:"sends"`
+				Spent    string `json:"spent"`
+				Sends24h int    `json:"sends_24h"`
+				Sends7d  int    `json:"sends_7d"`
+				Tokens   []struct {
+					Symbol string `json:"symbol"`
+					Amount string `json:"amount"`
+				} `json:"tokens"`
+			} `json:"spending"`
+		} `json:"addresses"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Len(t, result.Addresses, 2)
+	spending := result.Addresses[0].Spending
+	require.NotNil(t, spending)
+	assert.Equal(t, 2, spending.Sends)
+	assert.Equal(t, "1.000086", spending.Spent)
+	assert.Equal(t, 1, spending.Sends24h)
+	assert.Equal(t, 2, spending.Sends7d)
+	require.Len(t, spending.Tokens, 1)
+	assert.Equal(t, "USDC", spending.Tokens[0].Symbol)
+	assert.Equal(t, "2.5", spending.Tokens[0].Amount)
+	require.NotNil(t, result.Addresses[1].Spending)
+	assert.Zero(t, result.Addresses[1].Spending.Sends)
+}
+
+func TestRunAddressesChecksum(t *testing.T) {
+	t.Parallel()
+
+	newCmd := func(format output.Format) (*cobra.Command, *bytes.Buffer) {
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.Background())
+		SetCmdContext(cmd, &CommandContext{Fmt: &mockFormatProvider{format: format}})
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		return cmd, &buf
+	}
+
+	t.Run("lowercase converted", func(t *testing.T) {
+		t.Parallel()
+
+		cmd, buf := newCmd(output.FormatText)
+		require.NoError(t, runAddressesChecksum(cmd, []string{"0x742d35cc6634c0532925a3b844bc454e4438f44e"}))
+		assert.Equal(t, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e\n", buf.String())
+	})
+
+	t.Run("json output", func(t *testing.T) {
+		t.Parallel()
+
+		cmd, buf := newCmd(output.FormatJSON)
+		require.NoError(t, runAddressesChecksum(cmd, []string{"0x742d35Cc6634C0532925a3b844Bc454e4438f44e"}))
+
+		var res addressChecksumResult
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+		assert.Equal(t, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", res.Checksum)
+		assert.True(t, res.Checksummed)
+	})
+
+	t.Run("bad mixed case rejected", func(t *testing.T) {
+		t.Parallel()
+
+		cmd, _ := newCmd(output.FormatText)
+		err := runAddressesChecksum(cmd, []string{"0x742d35cc6634C0532925a3b844Bc454e4438f44e"})
+		require.ErrorIs(t, err, sigilerr.ErrInvalidChecksum)
+	})
+
+	t.Run("invalid address", func(t *testing.T) {
+		t.Parallel()
+
+		cmd, _ := newCmd(output.FormatText)
+		err := runAddressesChecksum(cmd, []string{"0x1234"})
+		require.ErrorIs(t, err, sigilerr.ErrInvalidAddress)
+	})
+}
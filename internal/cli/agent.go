@@ -13,7 +13,11 @@ import (
 
 	"github.com/mrz1836/sigil/internal/agent"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/sigilcrypto"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
@@ -26,19 +30,31 @@ var (
 
 //nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
 var (
-	agentWallet      string
-	agentChains      string
-	agentMaxPerTx    string
-	agentMaxDaily    string
-	agentMaxPerTxETH string
-	agentMaxDailyETH string
-	agentAllowedAddr string
-	agentExpires     string
-	agentLabel       string
-	agentID          string
-	agentRevokeAll   bool
+	agentWallet       string
+	agentChains       string
+	agentMaxPerTx     string
+	agentMaxDaily     string
+	agentMaxPerTxETH  string
+	agentMaxDailyETH  string
+	agentAllowedAddr  string
+	agentAllowedAsset string
+	agentExpires      string
+	agentLabel        string
+	agentID           string
+	agentRevokeAll    bool
+	agentEncryptTo    string
+	agentHost         string
 )
 
+// hostFingerprintFn returns this machine's fingerprint. Tests replace it.
+//
+//nolint:gochecknoglobals // Test seam for the machine ID lookup
+var hostFingerprintFn = agent.HostFingerprint
+
+// localHostFingerprint is the --host-fingerprint value that binds an agent
+// to the machine running agent create.
+const localHostFingerprint = "local"
+
 // agentCmd is the parent command for agent operations.
 //
 //nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
@@ -56,8 +72,11 @@ without any secrets.
 
 Security model:
   - Token encrypts the wallet seed in a separate agent file
+  - Optional agent store passphrase provisions agents without the wallet password
   - Spending policy enforced per-transaction and per-day
   - Tokens can be revoked instantly
+  - Every send is recorded in a per-agent audit log ('agent audit',
+    'agent report')
   - xpub mode has zero spending capability
 
 Environment variables for agents:
@@ -72,20 +91,40 @@ var agentCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new agent token with spending policy",
 	Long: `Create a new agent token for programmatic wallet access. You will be
-prompted for the wallet password once. A random token is generated and
-displayed — store it securely, it will not be shown again.
+prompted for the wallet password once, or for the agent store passphrase if
+the wallet has an agent store (see agent store init). A random token is
+generated and displayed — store it securely, it will not be shown again.
 
 The token is used by setting SIGIL_AGENT_TOKEN in the agent's
 environment. The agent then uses normal sigil commands (tx send,
 balance show, receive) without password prompts.
 
-Spending limits are enforced per-transaction and per-day. Multiple
-agents can be created for the same wallet with different policies.
+Spending limits are enforced per transaction and over a rolling 24-hour
+window: a send is refused when it and the agent's sends in the 24 hours
+before it would exceed the daily limit. Multiple agents can be created for
+the same wallet with different policies.
+
+Use --allowed-assets to limit which assets the agent may send: "bsv" or
+"eth" for the native coin, or an ERC-20 token symbol or contract address.
+Token symbols are resolved to their contract address when the agent is
+created. Without it, the agent may send any asset on its chains.
 
 Amount format: use 'sat' suffix for satoshis (e.g., 50000sat),
 decimal BSV (e.g., 0.0005), or 0 for unlimited.
 ETH limits can be set explicitly with --max-per-tx-eth and
---max-daily-eth, or left at 0 for unlimited.`,
+--max-daily-eth, or left at 0 for unlimited.
+
+Use --encrypt-to to deliver the token encrypted for the machine that
+will run the agent, so it never appears in plaintext in the terminal
+or CI logs. Pass an age public key (age1...) or a GPG key ID,
+fingerprint, or email (optionally prefixed with gpg:). GPG recipients
+must be in the local keyring.
+
+Use --host-fingerprint to bind the agent to the machine that will run it.
+Sends with the token from any other machine are rejected, so a leaked token
+is useless elsewhere. Run sigil agent fingerprint on the agent host and pass
+its output, or pass "local" to bind to this machine. The binding is covered
+by the policy HMAC.`,
 	Example: `  # BSV-only agent with spending limits
   sigil agent create --wallet main --chains bsv --max-per-tx 50000sat --max-daily 500000sat --expires 30d --label "payment-bot"
 
@@ -95,11 +134,35 @@ ETH limits can be set explicitly with --max-per-tx-eth and
   # Agent restricted to specific addresses
   sigil agent create --wallet main --chains bsv --max-per-tx 100000sat --max-daily 1000000sat --allowed-addrs "1ABC...,1DEF..." --expires 90d --label "payroll"
 
+  # Agent that may move USDC but never native ETH
+  sigil agent create --wallet main --chains eth --allowed-assets usdc --expires 30d --label "usdc-payouts"
+
   # Unlimited (use with caution)
-  sigil agent create --wallet main --chains bsv,eth --expires 1d --label "test-bot"`,
+  sigil agent create --wallet main --chains bsv,eth --expires 1d --label "test-bot"
+
+  # Deliver the token encrypted to the agent host's age key
+  sigil agent create --wallet main --chains bsv --expires 30d --label "ci-bot" --encrypt-to age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p
+
+  # Agent usable only from the server with this fingerprint
+  sigil agent create --wallet main --chains bsv --expires 30d --label "pay-server" --host-fingerprint 3f9a0c1d2e4b5a6978c0d1e2f3a4b5c6`,
 	RunE: runAgentCreate,
 }
 
+// agentFingerprintCmd prints this machine's host fingerprint.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentFingerprintCmd = &cobra.Command{
+	Use:   "fingerprint",
+	Short: "Show this machine's host fingerprint for agent binding",
+	Long: `Print the host fingerprint of this machine, a hash of its operating-system
+machine ID. Run it on the server that will use an agent token and pass the
+output to agent create --host-fingerprint. The machine ID itself is not shown.`,
+	Example: `  sigil agent fingerprint
+  sigil agent fingerprint -o json`,
+	Args: cobra.NoArgs,
+	RunE: runAgentFingerprint,
+}
+
 // agentListCmd lists agents for a wallet.
 //
 //nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
@@ -122,8 +185,9 @@ var agentInfoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Show detailed agent token information",
 	Long: `Show full details for a specific agent token including policy,
-daily spending status, xpub for read-only access, and creation
-metadata. Does not require the wallet password.`,
+spending in the last 24 hours with the remaining daily allowance and when
+more frees up, xpub for read-only access, and creation metadata. Does not
+require the wallet password.`,
 	Example: `  sigil agent info --wallet main --id agt_7f3a2b
   sigil agent info --wallet main --id agt_7f3a2b -o json`,
 	RunE: runAgentInfo,
@@ -155,38 +219,38 @@ func init() {
 	agentCmd.AddCommand(agentListCmd)
 	agentCmd.AddCommand(agentInfoCmd)
 	agentCmd.AddCommand(agentRevokeCmd)
+	agentCmd.AddCommand(agentFingerprintCmd)
 
 	// Create flags
-	agentCreateCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (required)")
+	agentCreateCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
 	agentCreateCmd.Flags().StringVar(&agentChains, "chains", "", "comma-separated chain list: bsv, eth (required)")
 	agentCreateCmd.Flags().StringVar(&agentMaxPerTx, "max-per-tx", "0", "max BSV per transaction (e.g., 50000sat or 0.0005)")
 	agentCreateCmd.Flags().StringVar(&agentMaxDaily, "max-daily", "0", "max daily BSV spend (e.g., 500000sat or 0.005)")
 	agentCreateCmd.Flags().StringVar(&agentMaxPerTxETH, "max-per-tx-eth", "0", "max ETH per transaction (e.g., 0.001)")
 	agentCreateCmd.Flags().StringVar(&agentMaxDailyETH, "max-daily-eth", "0", "max daily ETH spend (e.g., 0.01)")
 	agentCreateCmd.Flags().StringVar(&agentAllowedAddr, "allowed-addrs", "", "comma-separated address allowlist (empty=any)")
+	agentCreateCmd.Flags().StringVar(&agentAllowedAsset, "allowed-assets", "", "comma-separated asset allowlist: bsv, eth, or ERC-20 symbol/contract (empty=any)")
 	agentCreateCmd.Flags().StringVar(&agentExpires, "expires", "", "token lifetime: e.g., 1d, 7d, 30d, 90d, 365d (required)")
 	agentCreateCmd.Flags().StringVar(&agentLabel, "label", "", "human-readable label for this agent (required)")
+	agentCreateCmd.Flags().StringVar(&agentEncryptTo, "encrypt-to", "", "encrypt the token to an age public key or GPG recipient instead of printing it")
+	agentCreateCmd.Flags().StringVar(&agentHost, "host-fingerprint", "", "only allow sends from the host with this fingerprint, or \"local\" for this machine")
 
-	_ = agentCreateCmd.MarkFlagRequired("wallet")
 	_ = agentCreateCmd.MarkFlagRequired("chains")
 	_ = agentCreateCmd.MarkFlagRequired("expires")
 	_ = agentCreateCmd.MarkFlagRequired("label")
 
 	// List flags
-	agentListCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (required)")
-	_ = agentListCmd.MarkFlagRequired("wallet")
+	agentListCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
 
 	// Info flags
-	agentInfoCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (required)")
+	agentInfoCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
 	agentInfoCmd.Flags().StringVar(&agentID, "id", "", "agent ID (required, e.g., agt_7f3a2b)")
-	_ = agentInfoCmd.MarkFlagRequired("wallet")
 	_ = agentInfoCmd.MarkFlagRequired("id")
 
 	// Revoke flags
-	agentRevokeCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (required)")
+	agentRevokeCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
 	agentRevokeCmd.Flags().StringVar(&agentID, "id", "", "agent ID to revoke")
 	agentRevokeCmd.Flags().BoolVar(&agentRevokeAll, "all", false, "revoke all agents for this wallet")
-	_ = agentRevokeCmd.MarkFlagRequired("wallet")
 
 	// Declarative flag constraints for revoke
 	agentRevokeCmd.MarkFlagsOneRequired("id", "all")
@@ -195,6 +259,9 @@ func init() {
 
 //nolint:gocognit,gocyclo // Agent creation involves multiple validation and setup steps
 func runAgentCreate(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
 	cc := GetCmdContext(cmd)
 	w := cmd.OutOrStdout()
 
@@ -238,21 +305,41 @@ func runAgentCreate(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
-	// Load wallet to get seed
-	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
-	password, promptErr := promptPasswordFn("Enter wallet password: ")
-	if promptErr != nil {
-		return promptErr
+	// Parse allowed assets
+	allowedAssets, err := parseAssetList(agentAllowedAsset, chains, cc.Cfg.GetETHTokens())
+	if err != nil {
+		return err
 	}
-	defer wallet.ZeroBytes(password)
 
-	agentWlt, seed, loadErr := storage.Load(agentWallet, password)
+	hostFingerprint, err := resolveHostFingerprint(agentHost)
+	if err != nil {
+		return err
+	}
+
+	// Resolve the delivery recipient before prompting, so a bad key fails fast
+	var recipient *sigilcrypto.Recipient
+	if agentEncryptTo != "" {
+		recipient, err = sigilcrypto.ParseRecipient(agentEncryptTo)
+		if err != nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+				fmt.Sprintf("invalid --encrypt-to: %s (use an age1... public key or a GPG key ID/email)", err))
+		}
+	}
+
+	// Get the seed from the agent store, or by unlocking the wallet
+	agentWlt, seed, loadErr := unlockAgentSeed(cc, agentWallet)
 	if loadErr != nil {
 		return loadErr
 	}
 	defer wallet.ZeroBytes(seed)
 	agentNet := agentWlt.Net()
 
+	for _, addr := range allowedAddrs {
+		if err := validateAllowedAddr(addr, chains, agentNet); err != nil {
+			return err
+		}
+	}
+
 	// Generate token
 	token, err := agent.GenerateToken()
 	if err != nil {
@@ -267,11 +354,13 @@ func runAgentCreate(cmd *cobra.Command, _ []string) error {
 		WalletName: agentWallet,
 		Chains:     chains,
 		Policy: agent.Policy{
-			MaxPerTxSat:  maxPerTxSat,
-			MaxPerTxWei:  maxPerTxWei,
-			MaxDailySat:  maxDailySat,
-			MaxDailyWei:  maxDailyWei,
-			AllowedAddrs: allowedAddrs,
+			MaxPerTxSat:     maxPerTxSat,
+			MaxPerTxWei:     maxPerTxWei,
+			MaxDailySat:     maxDailySat,
+			MaxDailyWei:     maxDailyWei,
+			AllowedAddrs:    allowedAddrs,
+			AllowedAssets:   allowedAssets,
+			HostFingerprint: hostFingerprint,
 		},
 		CreatedAt: now,
 		ExpiresAt: now.Add(expiry),
@@ -291,6 +380,19 @@ func runAgentCreate(cmd *cobra.Command, _ []string) error {
 		cred.Xpubs[ch] = xpub
 	}
 
+	// Encrypt the token before storing the credential: if encryption fails,
+	// no agent is created whose token nobody ever received.
+	var encryptedToken string
+	if recipient != nil {
+		ctx, cancel := contextWithTimeout(cmd, 30*time.Second)
+		ciphertext, encErr := recipient.Encrypt(ctx, []byte(token))
+		cancel()
+		if encErr != nil {
+			return fmt.Errorf("encrypting agent token: %w", encErr)
+		}
+		encryptedToken = string(ciphertext)
+	}
+
 	// Store credential
 	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
 	if err := agentStore.CreateCredential(cred, token, seed); err != nil {
@@ -299,17 +401,24 @@ func runAgentCreate(cmd *cobra.Command, _ []string) error {
 
 	// Output
 	if cc.Fmt.Format() == output.FormatJSON {
-		return writeJSON(w, map[string]any{
+		resp := map[string]any{
 			"id":         cred.ID,
 			"label":      cred.Label,
 			"wallet":     cred.WalletName,
 			"chains":     cred.Chains,
 			"created_at": cred.CreatedAt.Format(time.RFC3339),
 			"expires_at": cred.ExpiresAt.Format(time.RFC3339),
-			"token":      token,
 			"xpubs":      cred.Xpubs,
 			"policy":     cred.Policy,
-		})
+		}
+		if recipient != nil {
+			resp["encrypted_token"] = encryptedToken
+			resp["encryption"] = recipient.Scheme
+			resp["encrypted_to"] = recipient.Key
+		} else {
+			resp["token"] = token
+		}
+		return writeJSON(w, resp)
 	}
 
 	outln(w)
@@ -332,10 +441,28 @@ func runAgentCreate(cmd *cobra.Command, _ []string) error {
 	if len(allowedAddrs) > 0 {
 		out(w, "  Allowed:      %s\n", strings.Join(allowedAddrs, ", "))
 	}
+	if len(allowedAssets) > 0 {
+		out(w, "  Assets:       %s\n", formatAssetList(allowedAssets, cc.Cfg.GetETHTokens()))
+	}
+	if hostFingerprint != "" {
+		out(w, "  Host:         %s\n", hostFingerprint)
+	}
 	out(w, "  Expires:      %s\n", cred.ExpiresAt.Format("2006-01-02 15:04"))
 	outln(w)
-	outln(w, "Token (store securely, shown once):")
-	out(w, "  SIGIL_AGENT_TOKEN=%s\n", token)
+	if recipient != nil {
+		out(w, "Token (encrypted to %s %s, shown once):\n", recipient.Scheme, recipient.Key)
+		out(w, "%s", encryptedToken)
+		outln(w)
+		outln(w, "Decrypt on the agent host and export it as SIGIL_AGENT_TOKEN:")
+		if recipient.Scheme == sigilcrypto.SchemeGPG {
+			outln(w, "  export SIGIL_AGENT_TOKEN=$(gpg -d token.asc)")
+		} else {
+			outln(w, "  export SIGIL_AGENT_TOKEN=$(age -d -i key.txt token.age)")
+		}
+	} else {
+		outln(w, "Token (store securely, shown once):")
+		out(w, "  SIGIL_AGENT_TOKEN=%s\n", token)
+	}
 
 	// Display xpubs for read-only mode
 	hasXpub := false
@@ -369,6 +496,9 @@ func runAgentCreate(cmd *cobra.Command, _ []string) error {
 
 //nolint:gocognit // Agent list display with format branching requires conditional logic
 func runAgentList(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
 	cc := GetCmdContext(cmd)
 	w := cmd.OutOrStdout()
 
@@ -394,10 +524,15 @@ func runAgentList(cmd *cobra.Command, _ []string) error {
 				DailyRemainSat uint64   `json:"daily_remaining_sat"`
 				MaxPerTxWei    string   `json:"max_per_tx_wei"`
 				MaxDailyWei    string   `json:"max_daily_wei"`
+				DailySpentWei  string   `json:"daily_spent_wei,omitempty"`
+				DailyRemainWei string   `json:"daily_remaining_wei,omitempty"`
 				AllowedAddrs   []string `json:"allowed_addrs"`
+				AllowedAssets  []string `json:"allowed_assets"`
+				Host           string   `json:"host_fingerprint,omitempty"`
 			} `json:"policy"`
 		}
 
+		now := time.Now()
 		result := make([]agentJSON, 0, len(agents))
 		for _, a := range agents {
 			aj := agentJSON{
@@ -417,13 +552,26 @@ func runAgentList(cmd *cobra.Command, _ []string) error {
 			if aj.Policy.AllowedAddrs == nil {
 				aj.Policy.AllowedAddrs = []string{}
 			}
-
-			// Load daily counter (best effort, needs no token for list display)
-			counterPath := agentStore.CounterPath(agentWallet, a.ID)
-			spentSat, _ := agent.GetDailySpent(counterPath, "")
-			aj.Policy.DailySpentSat = spentSat
-			if a.Policy.MaxDailySat > spentSat {
-				aj.Policy.DailyRemainSat = a.Policy.MaxDailySat - spentSat
+			aj.Policy.AllowedAssets = a.Policy.AllowedAssets
+			if aj.Policy.AllowedAssets == nil {
+				aj.Policy.AllowedAssets = []string{}
+			}
+			aj.Policy.Host = a.Policy.HostFingerprint
+
+			// Read the spend ledger unverified: the owner has no agent token
+			spent := agent.PeekDailySpent(agentStore.CounterPath(agentWallet, a.ID), now)
+			for _, al := range spent.Allowances(&a.Policy) {
+				if al.Unit == agent.LimitUnitWei {
+					aj.Policy.DailySpentWei = al.Spent.String()
+					if al.Remaining != nil {
+						aj.Policy.DailyRemainWei = al.Remaining.String()
+					}
+					continue
+				}
+				aj.Policy.DailySpentSat = al.Spent.Uint64()
+				if al.Remaining != nil {
+					aj.Policy.DailyRemainSat = al.Remaining.Uint64()
+				}
 			}
 
 			result = append(result, aj)
@@ -454,6 +602,9 @@ func runAgentList(cmd *cobra.Command, _ []string) error {
 
 //nolint:gocognit,gocyclo // Agent info display requires multiple conditional branches
 func runAgentInfo(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
 	cc := GetCmdContext(cmd)
 	w := cmd.OutOrStdout()
 
@@ -479,17 +630,40 @@ func runAgentInfo(cmd *cobra.Command, _ []string) error {
 		)
 	}
 
+	// Read the spend ledger unverified: the owner has no agent token
+	spent := agent.PeekDailySpent(agentStore.CounterPath(agentWallet, found.ID), time.Now())
+	allowances := spent.Allowances(&found.Policy)
+
 	if cc.Fmt.Format() == output.FormatJSON {
+		daily := make([]map[string]any, 0, len(allowances))
+		for _, al := range allowances {
+			entry := map[string]any{
+				"unit":         al.Unit,
+				"max_daily":    formatLimit(al.MaxDaily),
+				"spent":        al.Spent.String(),
+				"remaining":    nil, // unlimited
+				"next_release": nil,
+			}
+			if al.Remaining != nil {
+				entry["remaining"] = al.Remaining.String()
+			}
+			if !al.NextRelease.IsZero() {
+				entry["next_release"] = al.NextRelease.UTC().Format(time.RFC3339)
+			}
+			daily = append(daily, entry)
+		}
 		return writeJSON(w, map[string]any{
-			"id":         found.ID,
-			"label":      found.Label,
-			"wallet":     found.WalletName,
-			"chains":     found.Chains,
-			"created_at": found.CreatedAt.Format(time.RFC3339),
-			"expires_at": found.ExpiresAt.Format(time.RFC3339),
-			"expired":    found.IsExpired(),
-			"policy":     found.Policy,
-			"xpubs":      found.Xpubs,
+			"id":            found.ID,
+			"label":         found.Label,
+			"wallet":        found.WalletName,
+			"chains":        found.Chains,
+			"created_at":    found.CreatedAt.Format(time.RFC3339),
+			"expires_at":    found.ExpiresAt.Format(time.RFC3339),
+			"expired":       found.IsExpired(),
+			"policy":        found.Policy,
+			"xpubs":         found.Xpubs,
+			"daily":         daily,
+			"ledger_intact": !spent.Tampered,
 		})
 	}
 
@@ -536,6 +710,42 @@ func runAgentInfo(cmd *cobra.Command, _ []string) error {
 	} else {
 		outln(w, "    Allowed addresses: any")
 	}
+	if len(found.Policy.AllowedAssets) > 0 {
+		out(w, "    Allowed assets:    %s\n", formatAssetList(found.Policy.AllowedAssets, cc.Cfg.GetETHTokens()))
+	} else {
+		outln(w, "    Allowed assets:    any")
+	}
+	if found.Policy.HostFingerprint != "" {
+		out(w, "    Bound to host:     %s\n", found.Policy.HostFingerprint)
+	} else {
+		outln(w, "    Bound to host:     any")
+	}
+	if len(found.Policy.Rules) > 0 {
+		outln(w, "    Rules:")
+		for i := range found.Policy.Rules {
+			out(w, "      - %s\n", formatAgentRule(&found.Policy.Rules[i]))
+		}
+	}
+
+	outln(w)
+	outln(w, "  Spending in the last 24 hours:")
+	if spent.Tampered {
+		outln(w, "    WARNING: the spend ledger failed its integrity check; sends against a daily limit are denied")
+	}
+	if len(allowances) == 0 {
+		outln(w, "    none")
+	}
+	for _, al := range allowances {
+		remaining := "no daily limit"
+		if al.Remaining != nil {
+			remaining = fmt.Sprintf("%s %s remaining", al.Remaining, al.Unit)
+		}
+		line := fmt.Sprintf("    %-4s %s %s spent, %s", al.Unit+":", al.Spent, al.Unit, remaining)
+		if !al.NextRelease.IsZero() {
+			line += ", more from " + al.NextRelease.Local().Format("2006-01-02 15:04")
+		}
+		outln(w, line)
+	}
 
 	if len(found.Xpubs) > 0 {
 		outln(w)
@@ -552,7 +762,74 @@ func runAgentInfo(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// runAgentFingerprint prints this machine's host fingerprint.
+func runAgentFingerprint(cmd *cobra.Command, _ []string) error {
+	fingerprint, err := hostFingerprintFn()
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("cannot fingerprint this host: %s", err))
+	}
+
+	w := cmd.OutOrStdout()
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]string{"fingerprint": fingerprint})
+	}
+	outln(w, fingerprint)
+	return nil
+}
+
+// resolveHostFingerprint parses a --host-fingerprint value. "local" is the
+// fingerprint of this machine, and "" leaves the agent unbound.
+func resolveHostFingerprint(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "":
+		return "", nil
+	case strings.EqualFold(value, localHostFingerprint):
+		fingerprint, err := hostFingerprintFn()
+		if err != nil {
+			return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+				fmt.Sprintf("cannot fingerprint this host: %s; pass the fingerprint from sigil agent fingerprint instead", err))
+		}
+		return fingerprint, nil
+	}
+
+	fingerprint, err := agent.ParseFingerprint(value)
+	if err != nil {
+		return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid --host-fingerprint: %s (run sigil agent fingerprint on the agent host)", err))
+	}
+	return fingerprint, nil
+}
+
+// enforceAgentHost rejects a send with a host-bound agent token from a
+// machine other than the one it is bound to, or whose fingerprint cannot be
+// determined.
+func enforceAgentHost(cred *agent.Credential) error {
+	if cred.Policy.HostFingerprint == "" {
+		return nil
+	}
+
+	fingerprint, err := hostFingerprintFn()
+	if err != nil {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAgentHostDenied,
+			fmt.Sprintf("agent '%s' is bound to a host, but this host has no fingerprint: %s", cred.ID, err),
+		)
+	}
+	if err := agent.CheckHost(cred, fingerprint); err != nil {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAgentHostDenied,
+			fmt.Sprintf("%s, not this one (%s); use the token from the bound host or create a new agent", err, fingerprint),
+		)
+	}
+	return nil
+}
+
 func runAgentRevoke(cmd *cobra.Command, _ []string) error { //nolint:gocognit // complexity from error handling paths
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
 	cc := GetCmdContext(cmd)
 	w := cmd.OutOrStdout()
 
@@ -611,12 +888,37 @@ func runAgentRevoke(cmd *cobra.Command, _ []string) error { //nolint:gocognit //
 	return nil
 }
 
+// validateAllowedAddr checks that an --allowed-addrs entry is a valid address
+// on one of the agent's chains. ETH addresses are the same on every network,
+// so only BSV is checked against the wallet's network.
+func validateAllowedAddr(addr string, chains []chain.ID, network wallet.Network) error {
+	var rejected error
+	for _, ch := range chains {
+		net := network.String()
+		if ch == chain.ETH {
+			net = chain.Mainnet
+		}
+		err := chain.ValidateAddressOnNetwork(ch, net, addr)
+		if err == nil {
+			return nil
+		}
+
+		// Report the most specific reason: a bad checksum or wrong network
+		// says more than "not an address of this chain".
+		var addrErr *chain.AddressError
+		if rejected == nil || (errors.As(err, &addrErr) && addrErr.Reason != chain.AddressReasonFormat) {
+			rejected = err
+		}
+	}
+	return transaction.InvalidAddressError(rejected)
+}
+
 // parseChainList parses a comma-separated list of chain IDs.
 func parseChainList(s string) ([]chain.ID, error) {
 	parts := strings.Split(s, ",")
 	var chains []chain.ID
 	for _, p := range parts {
-		p = strings.TrimSpace(strings.ToLower(p))
+		p = strings.TrimSpace(p)
 		if p == "" {
 			continue
 		}
@@ -638,6 +940,81 @@ func parseChainList(s string) ([]chain.ID, error) {
 	return chains, nil
 }
 
+// parseAssetList parses a comma-separated asset allowlist. Chain IDs stand
+// for the native coin and must be among the agent's chains; token symbols are
+// resolved to their contract address so a look-alike contract cannot match.
+func parseAssetList(s string, chains []chain.ID, tokens []config.TokenConfig) ([]string, error) {
+	hasChain := func(id chain.ID) bool {
+		for _, ch := range chains {
+			if ch == id {
+				return true
+			}
+		}
+		return false
+	}
+
+	var assets []string
+	for _, p := range strings.Split(s, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		var asset string
+		if id, ok := chain.ParseChainID(p); ok && id.IsMVP() {
+			if !hasChain(id) {
+				return nil, sigilerr.WithSuggestion(
+					sigilerr.ErrInvalidInput,
+					fmt.Sprintf("allowed asset %s is not on the agent's chains (%s)", p, formatChainList(chains)),
+				)
+			}
+			asset = string(id)
+		} else {
+			if !hasChain(chain.ETH) {
+				return nil, sigilerr.WithSuggestion(
+					sigilerr.ErrInvalidInput,
+					fmt.Sprintf("token %s requires the eth chain (add eth to --chains)", p),
+				)
+			}
+			if meta, ok := transaction.LookupToken(tokens, p); ok {
+				asset = meta.Address
+			} else if eth.IsValidAddress(p) {
+				asset = p
+			} else {
+				return nil, sigilerr.WithSuggestion(
+					sigilerr.ErrInvalidInput,
+					fmt.Sprintf("unknown asset: %s (use bsv, eth, a configured token symbol, or a contract address)", p),
+				)
+			}
+		}
+
+		duplicate := false
+		for _, a := range assets {
+			duplicate = duplicate || strings.EqualFold(a, asset)
+		}
+		if !duplicate {
+			assets = append(assets, asset)
+		}
+	}
+	return assets, nil
+}
+
+// formatAssetList formats an asset allowlist for display, showing the symbol
+// of known token contracts.
+func formatAssetList(assets []string, tokens []config.TokenConfig) string {
+	parts := make([]string, len(assets))
+	for i, a := range assets {
+		parts[i] = a
+		if !eth.IsValidAddress(a) {
+			continue
+		}
+		if meta, ok := transaction.LookupToken(tokens, a); ok {
+			parts[i] = fmt.Sprintf("%s (%s)", meta.Symbol, a)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // formatChainList formats chain IDs as a comma-separated string.
 func formatChainList(chains []chain.ID) string {
 	parts := make([]string, len(chains))
@@ -0,0 +1,237 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Sources of audited agent actions.
+const (
+	agentAuditSourceCLI   = "cli"
+	agentAuditSourceServe = "serve"
+)
+
+// agentAuditActionSend is the audited action of a send.
+const agentAuditActionSend = "tx send"
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var agentAuditLimit int
+
+// agentAuditCmd shows an agent's audit log.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentAuditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Show the audit log of an agent token",
+	Long: `Show every send an agent attempted, oldest first, with the time, where it
+came from (the CLI or sigil serve), the chain, recipient, and amount, and the
+decision: allowed, with the transaction hash, denied by the agent's policy,
+or failed after it was allowed.
+
+The log is append-only, and each entry carries a hash of the one before it,
+so an entry that was edited or removed is reported. The log is kept when the
+agent is revoked. Does not require the wallet password.`,
+	Example: `  sigil agent audit --wallet main --id agt_7f3a2b
+  sigil agent audit --wallet main --id agt_7f3a2b --limit 20
+  sigil agent audit --wallet main --id agt_7f3a2b -o json`,
+	Args: cobra.NoArgs,
+	RunE: runAgentAudit,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	agentCmd.AddCommand(agentAuditCmd)
+
+	agentAuditCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+	agentAuditCmd.Flags().StringVar(&agentID, "id", "", "agent ID (required, e.g., agt_7f3a2b)")
+	agentAuditCmd.Flags().IntVar(&agentAuditLimit, "limit", 0, "show only the most recent entries (0 shows all)")
+	_ = agentAuditCmd.MarkFlagRequired("id")
+}
+
+func runAgentAudit(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
+	if agentAuditLimit < 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--limit must be zero or more")
+	}
+	cc := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	entries, readErr := agentStore.ReadAudit(agentWallet, agentID)
+	if readErr != nil && !errors.Is(readErr, agent.ErrAuditTampered) {
+		return readErr
+	}
+	if len(entries) == 0 && readErr == nil {
+		agents, err := agentStore.List(agentWallet)
+		if err != nil {
+			return err
+		}
+		if findAgent(agents, agentID) == nil {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrNotFound,
+				fmt.Sprintf("agent '%s' not found for wallet '%s'. List agents with: sigil agent list --wallet %s",
+					agentID, agentWallet, agentWallet),
+			)
+		}
+	}
+
+	total := len(entries)
+	if agentAuditLimit > 0 && total > agentAuditLimit {
+		entries = entries[total-agentAuditLimit:]
+	}
+
+	if cc.Fmt.Format() == output.FormatJSON {
+		result := map[string]any{
+			"id":      agentID,
+			"wallet":  agentWallet,
+			"total":   total,
+			"intact":  readErr == nil,
+			"entries": entries,
+		}
+		if entries == nil {
+			result["entries"] = []agent.AuditEntry{}
+		}
+		if readErr != nil {
+			result["error"] = readErr.Error()
+		}
+		return writeJSON(w, result)
+	}
+
+	if readErr != nil {
+		out(cmd.ErrOrStderr(), "WARNING: %v\n", readErr)
+		outln(cmd.ErrOrStderr(), "Only the entries before the break are shown.")
+	}
+	if len(entries) == 0 {
+		out(w, "No audit entries for agent '%s'.\n", agentID)
+		return nil
+	}
+
+	out(w, "Audit log for agent '%s' (wallet '%s'):\n", agentID, agentWallet)
+	outln(w)
+	for _, e := range entries {
+		detail := e.TxHash
+		if e.Decision != agent.DecisionAllowed {
+			detail = e.Reason
+		}
+		amount := e.Amount
+		if e.Token != "" {
+			amount += " " + e.Token
+		}
+		out(w, "  %s  %-7s  %-5s  %-4s  %-14s  %s  %s\n",
+			e.Time.Local().Format("2006-01-02 15:04:05"), e.Decision, e.Source, e.Chain, amount, e.To, detail)
+	}
+	if len(entries) < total {
+		outln(w)
+		out(w, "Showing the last %d of %d entries.\n", len(entries), total)
+	}
+	return nil
+}
+
+// findAgent returns the agent with id from agents, or nil.
+func findAgent(agents []*agent.Credential, id string) *agent.Credential {
+	for _, a := range agents {
+		if a.ID == id {
+			return a
+		}
+	}
+	return nil
+}
+
+// recordAgentAudit appends the outcome of an agent's action to its audit
+// log: allowed when err is nil, denied when the agent's policy refused it,
+// and failed otherwise. The action has already happened or been refused, so
+// a write failure is logged rather than returned.
+func recordAgentAudit(cc *CommandContext, walletName, agentID string, entry *agent.AuditEntry, err error) {
+	if cc.AgentStore == nil {
+		return
+	}
+	entry.Decision = agent.DecisionAllowed
+	if err != nil {
+		entry.Decision = agent.DecisionFailed
+		if isAgentDenial(err) {
+			entry.Decision = agent.DecisionDenied
+		}
+		detail := output.NewErrorDetail(err)
+		entry.Reason = detail.Message
+		if detail.Suggestion != "" {
+			entry.Reason += ": " + detail.Suggestion
+		}
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if writeErr := cc.AgentStore.AppendAudit(walletName, agentID, entry); writeErr != nil && cc.Log != nil {
+		cc.Log.Error("failed to record agent audit entry: %v", writeErr)
+	}
+}
+
+// recordAgentSends records each broadcast transaction of results in the
+// active agent's audit log. Failed transactions of a batch have no hash and
+// are left to the caller's error.
+func recordAgentSends(cc *CommandContext, source string, results ...*transaction.SendResult) {
+	if cc.AgentCred == nil {
+		return
+	}
+	for _, r := range results {
+		if r == nil || r.Hash == "" {
+			continue
+		}
+		recordAgentAudit(cc, cc.AgentCred.WalletName, cc.AgentCred.ID, &agent.AuditEntry{
+			Action: agentAuditActionSend,
+			Source: source,
+			Chain:  r.ChainID,
+			To:     r.To,
+			Amount: r.Amount,
+			Token:  r.Token,
+			Fee:    r.Fee,
+			TxHash: r.Hash,
+		}, nil)
+	}
+}
+
+// agentDenials are the errors of a refusal by an agent's policy.
+//
+//nolint:gochecknoglobals // Read-only list of sentinel errors
+var agentDenials = []error{
+	sigilerr.ErrAgentPolicyViolation,
+	sigilerr.ErrAgentDailyLimit,
+	sigilerr.ErrAgentChainDenied,
+	sigilerr.ErrAgentAddrDenied,
+	sigilerr.ErrAgentAssetDenied,
+	sigilerr.ErrAgentHostDenied,
+	sigilerr.ErrAgentXpubWriteDenied,
+}
+
+// isAgentDenial reports whether err is a refusal by an agent's policy.
+func isAgentDenial(err error) bool {
+	for _, denial := range agentDenials {
+		if errors.Is(err, denial) {
+			return true
+		}
+	}
+	return false
+}
+
+// agentSendEntry returns the audit entry of a send that did not go through.
+func agentSendEntry(source string, chainID chain.ID, to, amount, token string) *agent.AuditEntry {
+	return &agent.AuditEntry{
+		Action: agentAuditActionSend,
+		Source: source,
+		Chain:  chainID,
+		To:     to,
+		Amount: amount,
+		Token:  token,
+	}
+}
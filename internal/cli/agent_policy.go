@@ -0,0 +1,461 @@
+package cli
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	walletservice "github.com/mrz1836/sigil/internal/service/wallet"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	agentRuleChain    string
+	agentRuleToken    string
+	agentRuleTo       string
+	agentRuleMaxPerTx string
+	agentRuleMaxDaily string
+	agentRuleID       string
+)
+
+// agentPolicyCmd is the parent command for agent policy rules.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentPolicyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Manage per-asset policy rules of an agent token",
+	Long: `Manage the policy rules of an agent token. A rule scopes what the agent
+may send of one asset: to which addresses, and how much per transaction and
+over a rolling 24-hour window, in the asset's own units. For example:
+
+  - BSV only to three addresses
+  - at most 100 USDC per day to one address
+
+A send of an asset that has rules must be allowed by at least one of them:
+a rule allows the addresses given with --to, or any address when it has
+none. Every rule that allows the destination also limits the send. Assets
+without rules are limited by the agent's flat limits alone, which still
+apply on top of the rules.
+
+Changing the rules re-signs the policy HMAC, so add and remove ask for the
+agent's token and for the wallet password (or the agent store passphrase).
+They cannot be run in agent mode.`,
+}
+
+// agentPolicyListCmd lists the rules of an agent.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentPolicyListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List the policy rules of an agent token",
+	Long: `List the policy rules of an agent token with what each daily-limited rule
+counted in the last 24 hours. Does not require the wallet password.`,
+	Example: `  sigil agent policy list --wallet main --id agt_7f3a2b
+  sigil agent policy list --wallet main --id agt_7f3a2b -o json`,
+	Args: cobra.NoArgs,
+	RunE: runAgentPolicyList,
+}
+
+// agentPolicyAddCmd adds a rule to an agent.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentPolicyAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a policy rule to an agent token",
+	Long: `Add a rule for one asset to an agent's policy: the native coin of --chain,
+or the ERC-20 token given with --token (a configured symbol or contract).
+--to limits the rule to destination addresses; --max-per-tx and --max-daily
+are amounts of the asset (e.g., 100 for 100 USDC, 0.5 or 50000000sat for
+BSV). The agent's token and the wallet password (or agent store passphrase)
+are prompted for.`,
+	Example: `  # BSV only to these three addresses
+  sigil agent policy add --wallet main --id agt_7f3a2b --chain bsv --to "1ABC...,1DEF...,1GHI..."
+
+  # At most 100 USDC per day to one address
+  sigil agent policy add --wallet main --id agt_7f3a2b --chain eth --token usdc --to 0x742d... --max-daily 100`,
+	Args: cobra.NoArgs,
+	RunE: runAgentPolicyAdd,
+}
+
+// agentPolicyRemoveCmd removes a rule from an agent.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentPolicyRemoveCmd = &cobra.Command{
+	Use:     "remove",
+	Aliases: []string{"rm"},
+	Short:   "Remove a policy rule from an agent token",
+	Long: `Remove a rule from an agent's policy by its ID (see agent policy list).
+The agent's token and the wallet password (or agent store passphrase) are
+prompted for.`,
+	Example: `  sigil agent policy remove --wallet main --id agt_7f3a2b --rule r2`,
+	Args:    cobra.NoArgs,
+	RunE:    runAgentPolicyRemove,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	agentCmd.AddCommand(agentPolicyCmd)
+	agentPolicyCmd.AddCommand(agentPolicyListCmd)
+	agentPolicyCmd.AddCommand(agentPolicyAddCmd)
+	agentPolicyCmd.AddCommand(agentPolicyRemoveCmd)
+
+	for _, c := range []*cobra.Command{agentPolicyListCmd, agentPolicyAddCmd, agentPolicyRemoveCmd} {
+		c.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+		c.Flags().StringVar(&agentID, "id", "", "agent ID (required, e.g., agt_7f3a2b)")
+		_ = c.MarkFlagRequired("id")
+	}
+
+	agentPolicyAddCmd.Flags().StringVar(&agentRuleChain, "chain", "", "chain of the asset: bsv, eth (required)")
+	agentPolicyAddCmd.Flags().StringVar(&agentRuleToken, "token", "", "ERC-20 token symbol or contract (default: the chain's native coin)")
+	agentPolicyAddCmd.Flags().StringVar(&agentRuleTo, "to", "", "comma-separated destination addresses (empty=any)")
+	agentPolicyAddCmd.Flags().StringVar(&agentRuleMaxPerTx, "max-per-tx", "0", "max amount of the asset per transaction (0=unlimited)")
+	agentPolicyAddCmd.Flags().StringVar(&agentRuleMaxDaily, "max-daily", "0", "max amount of the asset per 24 hours (0=unlimited)")
+	_ = agentPolicyAddCmd.MarkFlagRequired("chain")
+
+	agentPolicyRemoveCmd.Flags().StringVar(&agentRuleID, "rule", "", "rule ID to remove (required, e.g., r1)")
+	_ = agentPolicyRemoveCmd.MarkFlagRequired("rule")
+}
+
+func runAgentPolicyList(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	cred, err := findAgentCredential(agentStore)
+	if err != nil {
+		return err
+	}
+
+	// Read the spend ledger unverified: the owner has no agent token
+	spends, tampered := agent.PeekSpends(agentStore.CounterPath(agentWallet, cred.ID))
+	now := time.Now()
+
+	if cc.Fmt.Format() == output.FormatJSON {
+		type ruleJSON struct {
+			agent.Rule
+
+			DailySpent *string `json:"daily_spent"`
+		}
+		rules := make([]ruleJSON, 0, len(cred.Policy.Rules))
+		for i := range cred.Policy.Rules {
+			r := ruleJSON{Rule: cred.Policy.Rules[i]}
+			if r.MaxDailyBig() != nil && !tampered {
+				spent := r.SpentIn(spends, now).String()
+				r.DailySpent = &spent
+			}
+			rules = append(rules, r)
+		}
+		return writeJSON(w, map[string]any{
+			"id":            cred.ID,
+			"wallet":        agentWallet,
+			"rules":         rules,
+			"ledger_intact": !tampered,
+		})
+	}
+
+	if len(cred.Policy.Rules) == 0 {
+		out(w, "No policy rules for agent '%s'.\n", cred.ID)
+		out(w, "Add one with: sigil agent policy add --wallet %s --id %s --chain bsv --to <address>\n", agentWallet, cred.ID)
+		return nil
+	}
+
+	out(w, "Policy rules for agent '%s' (wallet '%s'):\n", cred.ID, agentWallet)
+	if tampered {
+		outln(w, "  WARNING: the spend ledger failed to read; sends limited by a daily rule are denied")
+	}
+	for i := range cred.Policy.Rules {
+		r := &cred.Policy.Rules[i]
+		var spent *big.Int
+		if r.MaxDailyBig() != nil && !tampered {
+			spent = r.SpentIn(spends, now)
+		}
+		outln(w)
+		displayAgentRule(w, r, spent)
+	}
+	outln(w)
+	return nil
+}
+
+func runAgentPolicyAdd(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
+	if err := refuseAgentPolicyInAgentMode(); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	cred, err := findAgentCredential(agentStore)
+	if err != nil {
+		return err
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(agentWallet)
+	if err != nil {
+		return err
+	}
+	rule, err := parseAgentRule(cc, cred, wlt.Net())
+	if err != nil {
+		return err
+	}
+
+	policy := cred.Policy
+	policy.Rules = append([]agent.Rule(nil), cred.Policy.Rules...)
+	rule.ID = policy.AddRule(*rule)
+	if err := updateAgentPolicy(cc, agentStore, cred, &policy); err != nil {
+		return err
+	}
+
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]any{
+			"id":     cred.ID,
+			"wallet": agentWallet,
+			"added":  rule,
+		})
+	}
+	out(w, "Rule %s added to agent '%s':\n", rule.ID, cred.ID)
+	displayAgentRule(w, rule, nil)
+	return nil
+}
+
+func runAgentPolicyRemove(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
+	if err := refuseAgentPolicyInAgentMode(); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	cred, err := findAgentCredential(agentStore)
+	if err != nil {
+		return err
+	}
+
+	policy := cred.Policy
+	policy.Rules = append([]agent.Rule(nil), cred.Policy.Rules...)
+	if err := policy.RemoveRule(agentRuleID); err != nil {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrNotFound,
+			fmt.Sprintf("rule '%s' not found for agent '%s'. List rules with: sigil agent policy list --wallet %s --id %s",
+				agentRuleID, cred.ID, agentWallet, cred.ID),
+		)
+	}
+	if err := updateAgentPolicy(cc, agentStore, cred, &policy); err != nil {
+		return err
+	}
+
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]any{
+			"id":      cred.ID,
+			"wallet":  agentWallet,
+			"removed": agentRuleID,
+		})
+	}
+	out(w, "Rule %s removed from agent '%s'.\n", agentRuleID, cred.ID)
+	return nil
+}
+
+// findAgentCredential returns the credential of agentID for agentWallet,
+// read without its token.
+func findAgentCredential(agentStore *agent.FileStore) (*agent.Credential, error) {
+	agents, err := agentStore.List(agentWallet)
+	if err != nil {
+		return nil, err
+	}
+	cred := findAgent(agents, agentID)
+	if cred == nil {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrNotFound,
+			fmt.Sprintf("agent '%s' not found for wallet '%s'. List agents with: sigil agent list --wallet %s",
+				agentID, agentWallet, agentWallet),
+		)
+	}
+	return cred, nil
+}
+
+// refuseAgentPolicyInAgentMode rejects a policy change from an environment
+// holding an agent token: an agent must not widen its own policy.
+func refuseAgentPolicyInAgentMode() error {
+	if walletservice.CheckAgentToken() == "" {
+		return nil
+	}
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrAgentPolicyViolation,
+		"changing an agent's policy needs the wallet owner; it cannot be done in agent mode (unset SIGIL_AGENT_TOKEN)",
+	)
+}
+
+// updateAgentPolicy replaces the policy of cred after checking both the
+// agent's token, which re-signs the policy, and the wallet owner's secret:
+// the token alone is what the agent holds, and must not be enough to change
+// its own limits.
+func updateAgentPolicy(cc *CommandContext, agentStore *agent.FileStore, cred *agent.Credential, policy *agent.Policy) error {
+	token, err := promptPasswordFn(fmt.Sprintf("Enter agent token for %s: ", cred.ID))
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(token)
+
+	agentSeed, _, err := agentStore.Load(agentWallet, cred.ID, string(token))
+	if err != nil {
+		if errors.Is(err, agent.ErrAgentExpired) {
+			return sigilerr.WithSuggestion(sigilerr.ErrAgentTokenExpired,
+				fmt.Sprintf("agent '%s' has expired; create a new agent instead", cred.ID))
+		}
+		return sigilerr.WithSuggestion(sigilerr.ErrAgentTokenInvalid,
+			fmt.Sprintf("the token does not unlock agent '%s': %s", cred.ID, err))
+	}
+	defer wallet.ZeroBytes(agentSeed)
+
+	_, ownerSeed, err := unlockAgentSeed(cc, agentWallet)
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(ownerSeed)
+	if subtle.ConstantTimeCompare(agentSeed, ownerSeed) != 1 {
+		return sigilerr.WithSuggestion(sigilerr.ErrAuthentication,
+			fmt.Sprintf("agent '%s' was not created from wallet '%s'", cred.ID, agentWallet))
+	}
+
+	if err := agentStore.UpdatePolicy(agentWallet, cred.ID, string(token), policy); err != nil {
+		return fmt.Errorf("updating agent policy: %w", err)
+	}
+	return nil
+}
+
+// parseAgentRule builds a rule from the agent policy add flags. The asset
+// must be on one of the agent's chains and allowed by its asset allowlist.
+func parseAgentRule(cc *CommandContext, cred *agent.Credential, network wallet.Network) (*agent.Rule, error) {
+	chainID, ok := chain.ParseChainID(strings.TrimSpace(agentRuleChain))
+	if !ok || !chainID.IsMVP() {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid --chain: %s (supported: bsv, eth)", agentRuleChain))
+	}
+	if !cred.HasChain(chainID) {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("agent '%s' is not authorized for chain %s (allowed: %s)", cred.ID, chainID, formatChainList(cred.Chains)))
+	}
+
+	rule := &agent.Rule{Chain: chainID, Symbol: strings.ToUpper(string(chainID)), Decimals: nativeDecimals(chainID)}
+	if token := strings.TrimSpace(agentRuleToken); token != "" {
+		if chainID != chain.ETH {
+			return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--token is only supported with --chain eth")
+		}
+		meta, found := transaction.LookupToken(cc.Cfg.GetETHTokens(), token)
+		if !found {
+			return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+				fmt.Sprintf("unknown token: %s (use a configured token symbol or contract address)", token))
+		}
+		rule.Token, rule.Symbol, rule.Decimals = meta.Address, meta.Symbol, meta.Decimals
+	}
+	if !cred.Policy.AllowsAsset(chainID, rule.Token) {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("%s is not among the assets agent '%s' may send, so the rule would never apply", rule.Symbol, cred.ID))
+	}
+
+	for _, addr := range strings.Split(agentRuleTo, ",") {
+		addr = strings.TrimSpace(addr)
+		if addr == "" {
+			continue
+		}
+		if err := validateAllowedAddr(addr, []chain.ID{chainID}, network); err != nil {
+			return nil, err
+		}
+		rule.Addresses = append(rule.Addresses, addr)
+	}
+
+	var err error
+	if rule.MaxPerTx, err = parseRuleAmount(agentRuleMaxPerTx, rule); err != nil {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("invalid --max-per-tx: %s", err))
+	}
+	if rule.MaxDaily, err = parseRuleAmount(agentRuleMaxDaily, rule); err != nil {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("invalid --max-daily: %s", err))
+	}
+	return rule, nil
+}
+
+// parseRuleAmount parses a rule limit in units of the rule's asset into its
+// smallest unit, or "" for unlimited. Satoshi chains also accept a 'sat'
+// suffix.
+func parseRuleAmount(s string, rule *agent.Rule) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "0" {
+		return "", nil
+	}
+	if rule.Chain != chain.ETH {
+		sat, err := parseSatAmount(s)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprint(sat), nil
+	}
+	amount, err := parseDecimalAmount(s, rule.Decimals)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errInvalidAmount, s)
+	}
+	if amount.Sign() == 0 {
+		return "", nil
+	}
+	return amount.String(), nil
+}
+
+// displayAgentRule writes one rule as text, with what it counted in the last
+// 24 hours unless spent is nil.
+func displayAgentRule(w io.Writer, rule *agent.Rule, spent *big.Int) {
+	asset := rule.Symbol + " on " + string(rule.Chain)
+	if rule.Token != "" {
+		asset += " (" + rule.Token + ")"
+	}
+	out(w, "  %s  %s\n", rule.ID, asset)
+	if len(rule.Addresses) > 0 {
+		out(w, "      To:         %s\n", strings.Join(rule.Addresses, ", "))
+	} else {
+		outln(w, "      To:         any address")
+	}
+	out(w, "      Per-tx:     %s\n", formatRuleLimit(rule, rule.MaxPerTxBig()))
+	out(w, "      Daily:      %s\n", formatRuleLimit(rule, rule.MaxDailyBig()))
+	if spent != nil {
+		out(w, "      Last 24h:   %s\n", formatRuleLimit(rule, spent))
+	}
+}
+
+// formatAgentRule formats a rule on one line.
+func formatAgentRule(rule *agent.Rule) string {
+	to := "any address"
+	if len(rule.Addresses) > 0 {
+		to = strings.Join(rule.Addresses, ", ")
+	}
+	return fmt.Sprintf("%s: %s to %s, per-tx %s, daily %s", rule.ID, rule.Symbol, to,
+		formatRuleLimit(rule, rule.MaxPerTxBig()), formatRuleLimit(rule, rule.MaxDailyBig()))
+}
+
+// formatRuleLimit formats an amount of a rule's asset, or "unlimited".
+func formatRuleLimit(rule *agent.Rule, amount *big.Int) string {
+	if amount == nil {
+		return "unlimited"
+	}
+	return rule.Format(amount)
+}
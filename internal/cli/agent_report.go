@@ -0,0 +1,423 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/txjournal"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var agentReportSince string
+
+// agentReportCmd summarizes an agent's activity for review.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize the activity of an agent token for review",
+	Long: `Summarize everything an agent token did over a period, for periodic review
+by the wallet owner: every transaction it sent (from the wallet's transaction
+journal), totals per asset, its largest send and busiest day against its
+policy limits, the sends its policy denied (from its audit log), and
+anomalies worth a closer look:
+
+  burst            5 or more sends within 10 minutes
+  new_destination  the first send from the wallet to an address
+
+Output is text, JSON (-o json), or CSV (-o csv). CSV has one row per
+transaction or denial, for a spreadsheet; totals, limits, and anomalies are
+in the text and JSON reports, and anomalies are flagged on their rows.
+
+Works for revoked agents too, without the limits. Does not require the
+wallet password.`,
+	Example: `  sigil agent report --wallet main --id agt_7f3a2b
+  sigil agent report --wallet main --id agt_7f3a2b --since 7d -o json
+  sigil agent report --wallet main --id agt_7f3a2b --since 90d -o csv > agent.csv`,
+	Args: cobra.NoArgs,
+	RunE: runAgentReport,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	agentCmd.AddCommand(agentReportCmd)
+
+	agentReportCmd.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+	agentReportCmd.Flags().StringVar(&agentID, "id", "", "agent ID (required, e.g., agt_7f3a2b)")
+	agentReportCmd.Flags().StringVar(&agentReportSince, "since", "30d", "period to report, back from now (e.g., 24h, 7d, 30d)")
+	_ = agentReportCmd.MarkFlagRequired("id")
+}
+
+func runAgentReport(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
+	period, err := parseDuration(agentReportSince)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+	cc := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	agents, err := agentStore.List(agentWallet)
+	if err != nil {
+		return err
+	}
+	cred := findAgent(agents, agentID)
+
+	audit, auditErr := agentStore.ReadAudit(agentWallet, agentID)
+	if auditErr != nil && !errors.Is(auditErr, agent.ErrAuditTampered) {
+		return auditErr
+	}
+	journal, err := loadTxJournal(cc, agentWallet).Entries()
+	if err != nil {
+		return fmt.Errorf("loading transaction journal: %w", err)
+	}
+	if cred == nil && len(audit) == 0 && !journalHasAgent(journal, agentID) {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrNotFound,
+			fmt.Sprintf("agent '%s' not found for wallet '%s'. List agents with: sigil agent list --wallet %s",
+				agentID, agentWallet, agentWallet),
+		)
+	}
+
+	now := time.Now().UTC()
+	report := agent.BuildReport(agentID, cred, journal, audit, now.Add(-period), now)
+
+	if auditErr != nil {
+		out(cmd.ErrOrStderr(), "WARNING: %v\n", auditErr)
+		outln(cmd.ErrOrStderr(), "Only the denials before the break are counted.")
+	}
+
+	switch cc.Fmt.Format() {
+	case output.FormatJSON:
+		return writeJSON(w, agentReportJSON(cred, report, auditErr))
+	case output.FormatCSV:
+		return writeAgentReportCSV(w, report)
+	default:
+		outputAgentReport(w, report)
+		return nil
+	}
+}
+
+// journalHasAgent reports whether any journal entry was sent by agent id.
+func journalHasAgent(entries []txjournal.Entry, id string) bool {
+	for i := range entries {
+		if entries[i].Agent == id {
+			return true
+		}
+	}
+	return false
+}
+
+// agentReportTx is a transaction in the JSON report.
+type agentReportTx struct {
+	Time           string   `json:"time"`
+	Chain          chain.ID `json:"chain"`
+	To             string   `json:"to"`
+	Amount         string   `json:"amount"`
+	Symbol         string   `json:"symbol"`
+	Fee            string   `json:"fee"`
+	Status         string   `json:"status"`
+	Hash           string   `json:"hash"`
+	NewDestination bool     `json:"new_destination"`
+	Burst          bool     `json:"burst"`
+}
+
+// agentReportTotal is an asset total in the JSON report.
+type agentReportTotal struct {
+	Chain  chain.ID `json:"chain"`
+	Symbol string   `json:"symbol"`
+	Token  string   `json:"token,omitempty"`
+	Sends  int      `json:"sends"`
+	Amount string   `json:"amount"`
+	Fees   string   `json:"fees,omitempty"`
+}
+
+// agentReportLimit is a limit comparison in the JSON report, in sat or wei.
+type agentReportLimit struct {
+	Unit      string `json:"unit"`
+	MaxPerTx  string `json:"max_per_tx"`
+	LargestTx string `json:"largest_tx"`
+	MaxDaily  string `json:"max_daily"`
+	PeakDaily string `json:"peak_daily"`
+	PeakAt    string `json:"peak_at,omitempty"`
+}
+
+// agentReportDenial is a denied send in the JSON report.
+type agentReportDenial struct {
+	Time   string   `json:"time"`
+	Source string   `json:"source,omitempty"`
+	Chain  chain.ID `json:"chain,omitempty"`
+	To     string   `json:"to,omitempty"`
+	Amount string   `json:"amount,omitempty"`
+	Token  string   `json:"token,omitempty"`
+	Reason string   `json:"reason"`
+}
+
+// agentReportAnomaly is an anomaly in the JSON report.
+type agentReportAnomaly struct {
+	Kind   string   `json:"kind"`
+	Time   string   `json:"time"`
+	Chain  chain.ID `json:"chain,omitempty"`
+	To     string   `json:"to,omitempty"`
+	Count  int      `json:"count,omitempty"`
+	Detail string   `json:"detail"`
+}
+
+// agentReportJSON returns the JSON form of a report.
+func agentReportJSON(cred *agent.Credential, r *agent.Report, auditErr error) map[string]any {
+	txs := make([]agentReportTx, 0, len(r.Transactions))
+	for i := range r.Transactions {
+		tx := &r.Transactions[i]
+		txs = append(txs, agentReportTx{
+			Time:           tx.CreatedAt.UTC().Format(time.RFC3339),
+			Chain:          tx.Chain,
+			To:             tx.To,
+			Amount:         formatJournalUnits(tx.Amount, tx.Decimals),
+			Symbol:         tx.Symbol,
+			Fee:            formatJournalUnits(tx.Fee, nativeDecimals(tx.Chain)),
+			Status:         tx.Status,
+			Hash:           tx.Hash,
+			NewDestination: tx.NewDestination,
+			Burst:          tx.Burst,
+		})
+	}
+
+	totals := make([]agentReportTotal, 0, len(r.Totals))
+	for _, t := range r.Totals {
+		total := agentReportTotal{
+			Chain:  t.Chain,
+			Symbol: t.Symbol,
+			Token:  t.Token,
+			Sends:  t.Sends,
+			Amount: chain.FormatDecimalAmount(t.Amount, t.Decimals),
+		}
+		if t.Token == "" {
+			total.Fees = chain.FormatDecimalAmount(t.Fees, t.Decimals)
+		}
+		totals = append(totals, total)
+	}
+
+	limits := make([]agentReportLimit, 0, len(r.Limits))
+	for _, l := range r.Limits {
+		limits = append(limits, agentReportLimit{
+			Unit:      l.Unit,
+			MaxPerTx:  formatLimit(l.MaxPerTx),
+			LargestTx: l.LargestTx.String(),
+			MaxDaily:  formatLimit(l.MaxDaily),
+			PeakDaily: l.PeakDaily.String(),
+		})
+		if !l.PeakAt.IsZero() {
+			limits[len(limits)-1].PeakAt = l.PeakAt.UTC().Format(time.RFC3339)
+		}
+	}
+
+	denials := make([]agentReportDenial, 0, len(r.Denials))
+	for _, d := range r.Denials {
+		denials = append(denials, agentReportDenial{
+			Time:   d.Time.UTC().Format(time.RFC3339),
+			Source: d.Source,
+			Chain:  d.Chain,
+			To:     d.To,
+			Amount: d.Amount,
+			Token:  d.Token,
+			Reason: d.Reason,
+		})
+	}
+
+	anomalies := make([]agentReportAnomaly, 0, len(r.Anomalies))
+	for _, a := range r.Anomalies {
+		anomalies = append(anomalies, agentReportAnomaly{
+			Kind:   a.Kind,
+			Time:   a.Time.UTC().Format(time.RFC3339),
+			Chain:  a.Chain,
+			To:     a.To,
+			Count:  a.Count,
+			Detail: a.Detail,
+		})
+	}
+
+	result := map[string]any{
+		"id":           agentID,
+		"wallet":       agentWallet,
+		"revoked":      cred == nil,
+		"since":        r.Since.Format(time.RFC3339),
+		"until":        r.Until.Format(time.RFC3339),
+		"transactions": txs,
+		"totals":       totals,
+		"limits":       limits,
+		"denied":       len(denials),
+		"denials":      denials,
+		"failed":       r.Failed,
+		"anomalies":    anomalies,
+		"audit_intact": auditErr == nil,
+	}
+	if cred != nil {
+		result["label"] = cred.Label
+	}
+	if auditErr != nil {
+		result["audit_error"] = auditErr.Error()
+	}
+	return result
+}
+
+// agentReportCSVHeader is the header row of the CSV report.
+//
+//nolint:gochecknoglobals // Read-only column list
+var agentReportCSVHeader = []string{"time", "record", "chain", "to", "amount", "asset", "fee", "status", "tx_hash", "flags", "reason"}
+
+// writeAgentReportCSV writes the report's transactions and denials as CSV,
+// oldest first. Anomalous transactions list their kinds in flags.
+func writeAgentReportCSV(w io.Writer, r *agent.Report) error {
+	type row struct {
+		at     time.Time
+		fields []string
+	}
+	rows := make([]row, 0, len(r.Transactions)+len(r.Denials))
+	for i := range r.Transactions {
+		tx := &r.Transactions[i]
+		var flags []string
+		if tx.NewDestination {
+			flags = append(flags, agent.AnomalyNewDestination)
+		}
+		if tx.Burst {
+			flags = append(flags, agent.AnomalyBurst)
+		}
+		rows = append(rows, row{tx.CreatedAt, []string{
+			tx.CreatedAt.UTC().Format(time.RFC3339), "tx", string(tx.Chain), tx.To,
+			formatJournalUnits(tx.Amount, tx.Decimals), tx.Symbol,
+			formatJournalUnits(tx.Fee, nativeDecimals(tx.Chain)),
+			tx.Status, tx.Hash, strings.Join(flags, ";"), "",
+		}})
+	}
+	for _, d := range r.Denials {
+		asset := d.Token
+		if asset == "" {
+			asset = strings.ToUpper(string(d.Chain))
+		}
+		rows = append(rows, row{d.Time, []string{
+			d.Time.UTC().Format(time.RFC3339), agent.DecisionDenied, string(d.Chain), d.To,
+			d.Amount, asset, "", "", "", "", d.Reason,
+		}})
+	}
+	// Both lists are oldest first; merge them
+	sort.SliceStable(rows, func(a, b int) bool { return rows[a].at.Before(rows[b].at) })
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(agentReportCSVHeader); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if err := cw.Write(r.fields); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// outputAgentReport prints the report as text.
+//
+//nolint:gocognit // Report sections each need their own formatting
+func outputAgentReport(w io.Writer, r *agent.Report) {
+	out(w, "Activity report for agent '%s' (wallet '%s')\n", agentID, agentWallet)
+	out(w, "Period: %s to %s\n", r.Since.Local().Format("2006-01-02 15:04"), r.Until.Local().Format("2006-01-02 15:04"))
+
+	outln(w)
+	if len(r.Transactions) == 0 {
+		outln(w, "Transactions: none")
+	} else {
+		out(w, "Transactions: %d\n", len(r.Transactions))
+		out(w, "  %-16s %-5s %-10s %-24s %-36s %s\n", "TIME", "CHAIN", "STATUS", "AMOUNT", "TO", "HASH")
+		for i := range r.Transactions {
+			tx := &r.Transactions[i]
+			amount := formatJournalUnits(tx.Amount, tx.Decimals) + " " + tx.Symbol
+			line := fmt.Sprintf("  %-16s %-5s %-10s %-24s %-36s %s",
+				tx.CreatedAt.Local().Format("2006-01-02 15:04"), tx.Chain, tx.Status, amount, tx.To, tx.Hash)
+			if tx.NewDestination {
+				line += "  [new destination]"
+			}
+			if tx.Burst {
+				line += "  [burst]"
+			}
+			outln(w, line)
+		}
+	}
+
+	if len(r.Totals) > 0 {
+		outln(w)
+		outln(w, "Totals:")
+		for _, t := range r.Totals {
+			line := fmt.Sprintf("  %-5s %-6s %4d sends  %s", t.Chain, t.Symbol, t.Sends, chain.FormatDecimalAmount(t.Amount, t.Decimals))
+			if t.Token == "" {
+				line += "  (fees " + chain.FormatDecimalAmount(t.Fees, t.Decimals) + ")"
+			}
+			outln(w, line)
+		}
+	}
+
+	if len(r.Limits) > 0 {
+		outln(w)
+		outln(w, "Limits:")
+		for _, l := range r.Limits {
+			out(w, "  Largest send: %s %s of %s per transaction\n", l.LargestTx, l.Unit, formatLimitText(l.MaxPerTx))
+			peak := l.PeakDaily.String() + " " + l.Unit
+			if !l.PeakAt.IsZero() {
+				peak += " ending " + l.PeakAt.Local().Format("2006-01-02 15:04")
+			}
+			out(w, "  Busiest 24h:  %s of %s daily\n", peak, formatLimitText(l.MaxDaily))
+		}
+	}
+
+	outln(w)
+	out(w, "Denied: %d   Failed: %d\n", len(r.Denials), r.Failed)
+	for _, d := range r.Denials {
+		out(w, "  %s  %-5s  %-4s  %-14s  %s  %s\n",
+			d.Time.Local().Format("2006-01-02 15:04"), d.Source, d.Chain, d.Amount, d.To, d.Reason)
+	}
+
+	outln(w)
+	if len(r.Anomalies) == 0 {
+		outln(w, "Anomalies: none")
+		return
+	}
+	out(w, "Anomalies: %d\n", len(r.Anomalies))
+	for _, a := range r.Anomalies {
+		detail := a.Detail
+		if a.To != "" {
+			detail = string(a.Chain) + " " + a.To + ": " + detail
+		}
+		out(w, "  %s  %-15s  %s\n", a.Time.Local().Format("2006-01-02 15:04"), a.Kind, detail)
+	}
+}
+
+// formatLimit formats a limit for JSON, "0" when unlimited like the policy.
+func formatLimit(limit *big.Int) string {
+	if limit == nil {
+		return "0"
+	}
+	return limit.String()
+}
+
+// formatLimitText formats a limit for text output.
+func formatLimitText(limit *big.Int) string {
+	if limit == nil {
+		return "unlimited"
+	}
+	return limit.String()
+}
@@ -0,0 +1,252 @@
+package cli
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var agentStoreWeakPasswordOK bool
+
+// agentStoreCmd is the parent command for the agent store vault.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentStoreCmd = &cobra.Command{
+	Use:   "store",
+	Short: "Manage the agent store passphrase for a wallet",
+	Long: `Manage a wallet's agent store: a copy of the wallet seed encrypted
+with its own passphrase, separate from the wallet password.
+
+Once the store is initialized, agent create asks for the agent store
+passphrase instead of the wallet password, so agents can be provisioned and
+rotated without unlocking the wallet itself. The seed is encrypted with a
+random master key, and only the master key is encrypted with the passphrase.
+
+In non-interactive use the passphrase is read from
+SIGIL_AGENT_STORE_PASSPHRASE_FILE or SIGIL_SECRETS_DIR.`,
+}
+
+// agentStoreInitCmd initializes the agent store vault.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentStoreInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Create the agent store with its own passphrase",
+	Long: `Create the agent store for a wallet. You are prompted for the wallet
+password once, then for a new agent store passphrase, which must differ from
+the wallet password. Running init again replaces the store.`,
+	Example: `  sigil agent store init --wallet main`,
+	Args:    cobra.NoArgs,
+	RunE:    runAgentStoreInit,
+}
+
+// agentStorePasswdCmd changes the agent store passphrase.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentStorePasswdCmd = &cobra.Command{
+	Use:   "passwd",
+	Short: "Change the agent store passphrase",
+	Long: `Change the agent store passphrase. Only the master key is re-encrypted;
+the wallet password is not needed.`,
+	Example: `  sigil agent store passwd --wallet main`,
+	Args:    cobra.NoArgs,
+	RunE:    runAgentStorePasswd,
+}
+
+// agentStoreRemoveCmd deletes the agent store vault.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var agentStoreRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Delete the agent store",
+	Long: `Delete the agent store for a wallet. Existing agent tokens keep working;
+creating new agents asks for the wallet password again.`,
+	Example: `  sigil agent store remove --wallet main`,
+	Args:    cobra.NoArgs,
+	RunE:    runAgentStoreRemove,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	agentCmd.AddCommand(agentStoreCmd)
+	agentStoreCmd.AddCommand(agentStoreInitCmd)
+	agentStoreCmd.AddCommand(agentStorePasswdCmd)
+	agentStoreCmd.AddCommand(agentStoreRemoveCmd)
+
+	for _, c := range []*cobra.Command{agentStoreInitCmd, agentStorePasswdCmd, agentStoreRemoveCmd} {
+		c.Flags().StringVar(&agentWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+	}
+	agentStoreInitCmd.Flags().BoolVar(&agentStoreWeakPasswordOK, "weak-password-ok", false, "warn instead of failing when the passphrase is weak")
+	agentStorePasswdCmd.Flags().BoolVar(&agentStoreWeakPasswordOK, "weak-password-ok", false, "warn instead of failing when the passphrase is weak")
+}
+
+func runAgentStoreInit(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	password, err := promptWalletPassword("Enter wallet password: ")
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(password)
+
+	wlt, secret, err := storage.Load(agentWallet, password)
+	if err != nil {
+		return err
+	}
+	seed, err := walletSeed(wlt, secret)
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(seed)
+
+	outln(os.Stderr, "Choose the agent store passphrase (separate from the wallet password).")
+	passphrase, err := promptNewPasswordFn(newPasswordPolicy(cmd, agentStoreWeakPasswordOK))
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(passphrase)
+
+	if subtle.ConstantTimeCompare(passphrase, password) == 1 {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"the agent store passphrase must differ from the wallet password",
+		)
+	}
+
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	if err := agentStore.InitVault(agentWallet, seed, string(passphrase)); err != nil {
+		return fmt.Errorf("creating agent store: %w", err)
+	}
+
+	return agentStoreResult(cmd, "initialized", "Agent store initialized for wallet '%s'. agent create now asks for the agent store passphrase.\n")
+}
+
+func runAgentStorePasswd(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	if !agentStore.HasVault(agentWallet) {
+		return agentStoreNotFound()
+	}
+
+	current, err := promptAgentStorePassphrase("Enter current agent store passphrase: ")
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(current)
+
+	passphrase, err := promptNewPasswordFn(newPasswordPolicy(cmd, agentStoreWeakPasswordOK))
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(passphrase)
+
+	if err := agentStore.ChangeVaultPassphrase(agentWallet, string(current), string(passphrase)); err != nil {
+		return agentStoreError(err)
+	}
+
+	return agentStoreResult(cmd, "passphrase_changed", "Agent store passphrase changed for wallet '%s'.\n")
+}
+
+func runAgentStoreRemove(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &agentWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	if err := agentStore.DeleteVault(agentWallet); err != nil {
+		return agentStoreError(err)
+	}
+
+	return agentStoreResult(cmd, "removed", "Agent store removed for wallet '%s'. agent create asks for the wallet password again.\n")
+}
+
+// unlockAgentSeed returns the wallet seed for provisioning an agent, from
+// the agent store when the wallet has one, otherwise by unlocking the wallet.
+// The caller MUST zero the returned seed when done.
+func unlockAgentSeed(cc *CommandContext, walletName string) (*wallet.Wallet, []byte, error) {
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	agentStore := agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+
+	if !agentStore.HasVault(walletName) {
+		password, err := promptWalletPassword("Enter wallet password: ")
+		if err != nil {
+			return nil, nil, err
+		}
+		defer wallet.ZeroBytes(password)
+
+		wlt, secret, err := storage.Load(walletName, password)
+		if err != nil {
+			return nil, nil, err
+		}
+		seed, err := walletSeed(wlt, secret)
+		if err != nil {
+			return nil, nil, err
+		}
+		return wlt, seed, nil
+	}
+
+	wlt, err := storage.LoadMetadata(walletName)
+	if err != nil {
+		return nil, nil, err
+	}
+	passphrase, err := promptAgentStorePassphrase("Enter agent store passphrase: ")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer wallet.ZeroBytes(passphrase)
+
+	seed, err := agentStore.UnlockVault(walletName, string(passphrase))
+	if err != nil {
+		return nil, nil, agentStoreError(err)
+	}
+	return wlt, seed, nil
+}
+
+// agentStoreNotFound is the error for a wallet without an agent store.
+func agentStoreNotFound() error {
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrNotFound,
+		fmt.Sprintf("no agent store for wallet %q; create one with: sigil agent store init --wallet %s", agentWallet, agentWallet),
+	)
+}
+
+// agentStoreError maps agent store errors to user-facing errors.
+func agentStoreError(err error) error {
+	switch {
+	case errors.Is(err, agent.ErrVaultNotFound):
+		return agentStoreNotFound()
+	case errors.Is(err, agent.ErrVaultBadPassphrase):
+		return sigilerr.WithSuggestion(sigilerr.ErrAuthentication, "wrong agent store passphrase")
+	default:
+		return fmt.Errorf("agent store: %w", err)
+	}
+}
+
+// agentStoreResult reports the outcome of an agent store command.
+func agentStoreResult(cmd *cobra.Command, status, textFormat string) error {
+	w := cmd.OutOrStdout()
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]string{"wallet": agentWallet, "status": status})
+	}
+	out(w, textFormat, agentWallet)
+	return nil
+}
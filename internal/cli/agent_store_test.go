@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// withAgentStorePrompts makes password prompts return password and new
+// password prompts return passphrase.
+func withAgentStorePrompts(t *testing.T, password, passphrase string) {
+	t.Helper()
+	withMockPrompts(t, []byte(password), true)
+	promptNewPasswordFn = func(_ passwordPolicy) ([]byte, error) {
+		return []byte(passphrase), nil
+	}
+}
+
+func TestAgentStore_InitAndCreate(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	defer cleanup()
+	createTestWalletForAgent(t, tmpDir)
+	agentsDir := filepath.Join(tmpDir, "agents")
+
+	// Initialize the store with the wallet password and a new passphrase
+	withAgentStorePrompts(t, "testpass123", "store-pass-456")
+	initCmd := agentStoreInitCmd
+	initCmd.SetContext(context.Background())
+	SetCmdContext(initCmd, cmdCtx)
+	agentWallet = "test-wallet"
+	var buf bytes.Buffer
+	initCmd.SetOut(&buf)
+	require.NoError(t, runAgentStoreInit(initCmd, nil))
+	assert.Contains(t, buf.String(), "Agent store initialized")
+	assert.True(t, agent.NewFileStore(agentsDir).HasVault("test-wallet"))
+
+	// agent create now takes the store passphrase; the wallet password fails
+	withMockPrompts(t, []byte("testpass123"), true)
+	createCmd := agentCreateCmd
+	createCmd.SetContext(context.Background())
+	SetCmdContext(createCmd, cmdCtx)
+	require.NoError(t, createCmd.Flags().Set("wallet", "test-wallet"))
+	require.NoError(t, createCmd.Flags().Set("chains", "bsv"))
+	require.NoError(t, createCmd.Flags().Set("expires", "1d"))
+	require.NoError(t, createCmd.Flags().Set("label", "store-agent"))
+	buf.Reset()
+	createCmd.SetOut(&buf)
+	err := createCmd.RunE(createCmd, nil)
+	require.ErrorIs(t, err, sigilerr.ErrAuthentication)
+
+	withMockPrompts(t, []byte("store-pass-456"), true)
+	require.NoError(t, createCmd.RunE(createCmd, nil))
+	assert.Contains(t, buf.String(), "SIGIL_AGENT_TOKEN=")
+
+	agents, err := agent.NewFileStore(agentsDir).List("test-wallet")
+	require.NoError(t, err)
+	assert.Len(t, agents, 1)
+}
+
+func TestAgentStore_InitRejectsWalletPassword(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	defer cleanup()
+	createTestWalletForAgent(t, tmpDir)
+
+	withAgentStorePrompts(t, "testpass123", "testpass123")
+	cmd := agentStoreInitCmd
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+	agentWallet = "test-wallet"
+
+	err := runAgentStoreInit(cmd, nil)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	assert.False(t, agent.NewFileStore(filepath.Join(tmpDir, "agents")).HasVault("test-wallet"))
+}
+
+func TestAgentStore_PasswdAndRemove(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	defer cleanup()
+	store := agent.NewFileStore(filepath.Join(tmpDir, "agents"))
+	require.NoError(t, store.InitVault("test-wallet", []byte("seed"), "old-pass"))
+
+	cmd := agentStorePasswdCmd
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	agentWallet = "test-wallet"
+
+	withAgentStorePrompts(t, "wrong-pass", "new-pass")
+	require.ErrorIs(t, runAgentStorePasswd(cmd, nil), sigilerr.ErrAuthentication)
+
+	withAgentStorePrompts(t, "old-pass", "new-pass")
+	require.NoError(t, runAgentStorePasswd(cmd, nil))
+	seed, err := store.UnlockVault("test-wallet", "new-pass")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("seed"), seed)
+
+	require.NoError(t, runAgentStoreRemove(cmd, nil))
+	assert.False(t, store.HasVault("test-wallet"))
+	require.ErrorIs(t, runAgentStoreRemove(cmd, nil), sigilerr.ErrNotFound)
+	require.ErrorIs(t, runAgentStorePasswd(cmd, nil), sigilerr.ErrNotFound)
+}
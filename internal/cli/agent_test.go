@@ -4,20 +4,29 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"io"
+	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/mrz1836/sigil/internal/agent"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
 	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/txjournal"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // resetAgentFlags resets all package-level agent flag variables to their defaults.
@@ -29,10 +38,20 @@ func resetAgentFlags() {
 	agentMaxPerTxETH = "0"
 	agentMaxDailyETH = "0"
 	agentAllowedAddr = ""
+	agentAllowedAsset = ""
 	agentExpires = ""
 	agentLabel = ""
 	agentID = ""
 	agentRevokeAll = false
+	agentEncryptTo = ""
+	agentAuditLimit = 0
+	agentReportSince = "30d"
+	agentRuleChain = ""
+	agentRuleToken = ""
+	agentRuleTo = ""
+	agentRuleMaxPerTx = "0"
+	agentRuleMaxDaily = "0"
+	agentRuleID = ""
 }
 
 // setupAgentTest creates a test environment for agent commands.
@@ -122,6 +141,17 @@ func TestParseChainList(t *testing.T) {
 			want:    []chain.ID{chain.BSV, chain.ETH},
 			wantErr: false,
 		},
+		{
+			name:    "aliases",
+			input:   "bitcoin-sv, ethereum",
+			want:    []chain.ID{chain.BSV, chain.ETH},
+			wantErr: false,
+		},
+		{
+			name:    "testnet qualified",
+			input:   "eth:sepolia",
+			wantErr: true,
+		},
 		{
 			name:    "uppercase",
 			input:   "BSV,ETH",
@@ -168,6 +198,226 @@ func TestParseChainList(t *testing.T) {
 	}
 }
 
+// TestParseAssetList tests asset allowlist parsing.
+func TestParseAssetList(t *testing.T) {
+	t.Parallel()
+
+	const usdt = "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+	tokens := []config.TokenConfig{{Symbol: "USDT", Address: usdt, Decimals: 6}}
+	both := []chain.ID{chain.BSV, chain.ETH}
+
+	tests := []struct {
+		name    string
+		input   string
+		chains  []chain.ID
+		want    []string
+		wantErr bool
+	}{
+		{name: "empty", input: "", chains: both, want: nil},
+		{name: "native coins", input: "BSV, eth", chains: both, want: []string{"bsv", "eth"}},
+		{name: "built-in symbol", input: "usdc", chains: both, want: []string{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"}},
+		{name: "configured symbol", input: "usdt", chains: both, want: []string{usdt}},
+		{name: "contract address", input: "0x6B175474E89094C44Da98b954EedeAC495271d0F", chains: both, want: []string{"0x6B175474E89094C44Da98b954EedeAC495271d0F"}},
+		{name: "duplicates collapse", input: "usdc,USDC,0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", chains: both, want: []string{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"}},
+		{name: "native coin off agent chains", input: "eth", chains: []chain.ID{chain.BSV}, wantErr: true},
+		{name: "token without eth", input: "usdc", chains: []chain.ID{chain.BSV}, wantErr: true},
+		{name: "unknown symbol", input: "doge", chains: both, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseAssetList(tt.input, tt.chains, tokens)
+			if tt.wantErr {
+				require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// TestFormatAssetList tests asset allowlist display.
+func TestFormatAssetList(t *testing.T) {
+	t.Parallel()
+
+	got := formatAssetList([]string{"bsv", "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"}, nil)
+	assert.Equal(t, "bsv, USDC (0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48), 0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984", got)
+}
+
+// TestEnforceAgentAsset tests the send-path asset check.
+func TestEnforceAgentAsset(t *testing.T) {
+	t.Parallel()
+
+	cred := &agent.Credential{
+		ID:     "agt_test",
+		Chains: []chain.ID{chain.ETH},
+		Policy: agent.Policy{AllowedAssets: []string{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"}},
+	}
+
+	require.NoError(t, enforceAgentAsset(cred, chain.ETH, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"))
+
+	err := enforceAgentAsset(cred, chain.ETH, "")
+	require.ErrorIs(t, err, sigilerr.ErrAgentAssetDenied)
+	assert.Contains(t, err.Error(), "asset not in agent allowlist")
+}
+
+// TestEnforceAgentSpend tests the send-path limit checks.
+func TestEnforceAgentSpend(t *testing.T) {
+	t.Parallel()
+
+	const allowed, other = "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+	counterPath := filepath.Join(t.TempDir(), "agt_test.counter")
+	token := "sigil_agt_test"
+	cred := &agent.Credential{
+		ID:     "agt_test",
+		Chains: []chain.ID{chain.BSV},
+		Policy: agent.Policy{MaxPerTxSat: 50000, MaxDailySat: 100000, AllowedAddrs: []string{allowed, other}},
+	}
+	send := func(amount string, payments ...transaction.Payment) *transaction.SendRequest {
+		return &transaction.SendRequest{ChainID: chain.BSV, To: allowed, AmountStr: amount, Payments: payments}
+	}
+
+	require.NoError(t, enforceAgentSpend(cred, counterPath, token, send("0.0004"), 8))
+	require.ErrorIs(t, enforceAgentSpend(cred, counterPath, token, send("0.0006"), 8), sigilerr.ErrAgentPolicyViolation)
+	require.ErrorIs(t, enforceAgentSpend(cred, counterPath, token, send("all"), 8), sigilerr.ErrAgentPolicyViolation)
+	require.ErrorIs(t, enforceAgentSpend(cred, counterPath, token, send("lots"), 8), sigilerr.ErrInvalidInput)
+
+	// One transaction pays both recipients, so their total is one send
+	err := enforceAgentSpend(cred, counterPath, token, send("0.0003", transaction.Payment{To: other, AmountStr: "0.0003"}), 8)
+	require.ErrorIs(t, err, sigilerr.ErrAgentPolicyViolation)
+	err = enforceAgentSpend(cred, counterPath, token, send("0.0001", transaction.Payment{To: "1Unknown", AmountStr: "0.0001"}), 8)
+	require.ErrorIs(t, err, sigilerr.ErrAgentPolicyViolation)
+
+	// The rolling window counts earlier sends
+	require.NoError(t, agent.RecordSpend(counterPath, token, chain.BSV, big.NewInt(80000)))
+	err = enforceAgentSpend(cred, counterPath, token, send("0.0003"), 8)
+	require.ErrorIs(t, err, sigilerr.ErrAgentDailyLimit)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "remaining: 20000 sat")
+	require.NoError(t, enforceAgentSpend(cred, counterPath, token, send("0.0002"), 8))
+}
+
+// TestEnforceAgentSpend_Rules tests the send-path checks of policy rules.
+func TestEnforceAgentSpend_Rules(t *testing.T) {
+	t.Parallel()
+
+	const payee, other = "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+	counterPath := filepath.Join(t.TempDir(), "agt_rules.counter")
+	token := "sigil_agt_rules"
+	cred := &agent.Credential{
+		ID:     "agt_rules",
+		Chains: []chain.ID{chain.BSV},
+		Policy: agent.Policy{Rules: []agent.Rule{
+			{ID: "r1", Chain: chain.BSV, Symbol: "BSV", Decimals: 8, Addresses: []string{payee}, MaxDaily: "50000"},
+		}},
+	}
+	send := func(to, amount string) *transaction.SendRequest {
+		return &transaction.SendRequest{ChainID: chain.BSV, To: to, AmountStr: amount}
+	}
+
+	require.NoError(t, enforceAgentSpend(cred, counterPath, token, send(payee, "0.0004"), 8))
+	require.ErrorIs(t, enforceAgentSpend(cred, counterPath, token, send(other, "0.0001"), 8), sigilerr.ErrAgentPolicyViolation)
+	require.ErrorIs(t, enforceAgentSpend(cred, counterPath, token, send(payee, "all"), 8), sigilerr.ErrAgentPolicyViolation)
+
+	require.NoError(t, agent.RecordAssetSpend(counterPath, token, agent.Spend{Chain: chain.BSV, To: payee, Amount: "40000"}))
+	err := enforceAgentSpend(cred, counterPath, token, send(payee, "0.0002"), 8)
+	require.ErrorIs(t, err, sigilerr.ErrAgentDailyLimit)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "remaining: 0.0001 BSV")
+}
+
+// TestEnforceAgentSpend_Token tests that token sends are held only to their
+// token's rules, not the ETH limits in wei.
+func TestEnforceAgentSpend_Token(t *testing.T) {
+	t.Parallel()
+
+	const payee = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	const usdc = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	counterPath := filepath.Join(t.TempDir(), "agt_token.counter")
+	token := "sigil_agt_token"
+	cred := &agent.Credential{
+		ID:     "agt_token",
+		Chains: []chain.ID{chain.ETH},
+		Policy: agent.Policy{MaxPerTxWei: "1000", MaxDailyWei: "1000", AllowedAddrs: []string{payee}},
+	}
+	send := func(to, amount string) *transaction.SendRequest {
+		return &transaction.SendRequest{
+			ChainID: chain.ETH, To: to, AmountStr: amount, Token: usdc,
+			TokenMeta: &eth.TokenMetadata{Address: usdc, Symbol: "USDC", Decimals: 6},
+		}
+	}
+
+	// 50 USDC is far more base units than the wei limits allow
+	require.NoError(t, enforceAgentSpend(cred, counterPath, token, send(payee, "50"), 6))
+	require.NoError(t, enforceAgentSpend(cred, counterPath, token, send(payee, "all"), 6))
+	require.ErrorIs(t, enforceAgentSpend(cred, counterPath, token, send("0x0000000000000000000000000000000000000001", "1"), 6),
+		sigilerr.ErrAgentPolicyViolation)
+
+	// Token spends leave the wei allowance untouched
+	require.NoError(t, agent.RecordAssetSpend(counterPath, token, agent.Spend{Chain: chain.ETH, Token: usdc, To: payee, Amount: "50000000"}))
+	coin := &transaction.SendRequest{ChainID: chain.ETH, To: payee, AmountStr: "0.000000000000001"}
+	require.NoError(t, enforceAgentSpend(cred, counterPath, token, coin, 18))
+
+	// A rule on the token limits it in its own units
+	cred.Policy.Rules = []agent.Rule{
+		{ID: "r1", Chain: chain.ETH, Token: usdc, Symbol: "USDC", Decimals: 6, MaxDaily: "60000000"},
+	}
+	err := enforceAgentSpend(cred, counterPath, token, send(payee, "20"), 6)
+	require.ErrorIs(t, err, sigilerr.ErrAgentDailyLimit)
+	require.ErrorIs(t, enforceAgentSpend(cred, counterPath, token, send(payee, "all"), 6), sigilerr.ErrAgentPolicyViolation)
+}
+
+// TestResolveHostFingerprint tests --host-fingerprint parsing.
+func TestResolveHostFingerprint(t *testing.T) {
+	local := agent.FingerprintMachineID("this-machine")
+	orig := hostFingerprintFn
+	hostFingerprintFn = func() (string, error) { return local, nil }
+	t.Cleanup(func() { hostFingerprintFn = orig })
+
+	got, err := resolveHostFingerprint("")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	got, err = resolveHostFingerprint("LOCAL")
+	require.NoError(t, err)
+	assert.Equal(t, local, got)
+
+	remote := agent.FingerprintMachineID("server")
+	got, err = resolveHostFingerprint(strings.ToUpper(remote))
+	require.NoError(t, err)
+	assert.Equal(t, remote, got)
+
+	_, err = resolveHostFingerprint("server-01")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+// TestEnforceAgentHost tests the send-path host binding check.
+func TestEnforceAgentHost(t *testing.T) {
+	server := agent.FingerprintMachineID("server")
+	orig := hostFingerprintFn
+	t.Cleanup(func() { hostFingerprintFn = orig })
+
+	cred := &agent.Credential{ID: "agt_test", Policy: agent.Policy{HostFingerprint: server}}
+
+	hostFingerprintFn = func() (string, error) { return server, nil }
+	require.NoError(t, enforceAgentHost(cred))
+
+	hostFingerprintFn = func() (string, error) { return agent.FingerprintMachineID("laptop"), nil }
+	err := enforceAgentHost(cred)
+	require.ErrorIs(t, err, sigilerr.ErrAgentHostDenied)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "bound to host "+server)
+
+	hostFingerprintFn = func() (string, error) { return "", agent.ErrNoMachineID }
+	require.ErrorIs(t, enforceAgentHost(cred), sigilerr.ErrAgentHostDenied)
+	require.NoError(t, enforceAgentHost(&agent.Credential{ID: "agt_any"}), "unbound agents need no fingerprint")
+}
+
 // TestFormatChainList tests chain list formatting.
 func TestFormatChainList(t *testing.T) {
 	t.Parallel()
@@ -428,7 +678,7 @@ func TestParseWeiAmount(t *testing.T) {
 
 // TestAgentCreate_Success tests successful agent creation.
 func TestAgentCreate_Success(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	// Create test wallet
@@ -468,7 +718,7 @@ func TestAgentCreate_Success(t *testing.T) {
 
 // TestAgentCreate_JSONOutput tests agent creation with JSON output.
 func TestAgentCreate_JSONOutput(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	// Use JSON format
@@ -514,6 +764,81 @@ func TestAgentCreate_JSONOutput(t *testing.T) {
 	assert.Equal(t, "test-wallet", result["wallet"])
 }
 
+// TestAgentCreate_EncryptTo tests delivering the token encrypted to an age recipient.
+func TestAgentCreate_EncryptTo(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatJSON}
+
+	createTestWalletForAgent(t, tmpDir)
+	withMockPrompts(t, []byte("testpass123"), true)
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	cmd := agentCreateCmd
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+
+	require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
+	require.NoError(t, cmd.Flags().Set("chains", "bsv"))
+	require.NoError(t, cmd.Flags().Set("expires", "7d"))
+	require.NoError(t, cmd.Flags().Set("label", "encrypted"))
+	require.NoError(t, cmd.Flags().Set("encrypt-to", identity.Recipient().String()))
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.NotContains(t, result, "token", "plaintext token must not be emitted")
+	assert.Equal(t, "age", result["encryption"])
+
+	armored, ok := result["encrypted_token"].(string)
+	require.True(t, ok)
+	r, err := age.Decrypt(armor.NewReader(strings.NewReader(armored)), identity)
+	require.NoError(t, err)
+	token, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.Equal(t, result["id"], agent.TokenID(string(token)), "decrypted token matches the created agent")
+}
+
+// TestAgentCreate_EncryptToInvalid tests that a bad recipient fails before any agent is created.
+func TestAgentCreate_EncryptToInvalid(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+	withMockPrompts(t, []byte("testpass123"), true)
+
+	cmd := agentCreateCmd
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+
+	require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
+	require.NoError(t, cmd.Flags().Set("chains", "bsv"))
+	require.NoError(t, cmd.Flags().Set("expires", "7d"))
+	require.NoError(t, cmd.Flags().Set("label", "bad-recipient"))
+	require.NoError(t, cmd.Flags().Set("encrypt-to", "age1notavalidkey"))
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	err := cmd.RunE(cmd, []string{})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "--encrypt-to")
+
+	agents, listErr := cmdCtx.AgentStore.List("test-wallet")
+	require.NoError(t, listErr)
+	assert.Empty(t, agents)
+}
+
 // TestAgentCreate_MissingWallet tests error when wallet doesn't exist.
 func TestAgentCreate_MissingWallet(t *testing.T) {
 	_, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
@@ -545,7 +870,7 @@ func TestAgentCreate_MissingWallet(t *testing.T) {
 
 // TestAgentCreate_InvalidChains tests error with invalid chain.
 func TestAgentCreate_InvalidChains(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -570,7 +895,7 @@ func TestAgentCreate_InvalidChains(t *testing.T) {
 
 // TestAgentCreate_InvalidDuration tests error with bad expiry format.
 func TestAgentCreate_InvalidDuration(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -595,7 +920,7 @@ func TestAgentCreate_InvalidDuration(t *testing.T) {
 
 // TestAgentCreate_InvalidAmount tests error with bad spending limit.
 func TestAgentCreate_InvalidAmount(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -621,7 +946,7 @@ func TestAgentCreate_InvalidAmount(t *testing.T) {
 
 // TestAgentCreate_MultipleChains tests agent with multiple chains.
 func TestAgentCreate_MultipleChains(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -650,7 +975,7 @@ func TestAgentCreate_MultipleChains(t *testing.T) {
 
 // TestAgentCreate_AllowedAddresses tests agent with address allowlist.
 func TestAgentCreate_AllowedAddresses(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -662,7 +987,7 @@ func TestAgentCreate_AllowedAddresses(t *testing.T) {
 
 	require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
 	require.NoError(t, cmd.Flags().Set("chains", "bsv"))
-	require.NoError(t, cmd.Flags().Set("allowed-addrs", "1ABC,1DEF"))
+	require.NoError(t, cmd.Flags().Set("allowed-addrs", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa,1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"))
 	require.NoError(t, cmd.Flags().Set("expires", "30d"))
 	require.NoError(t, cmd.Flags().Set("label", "restricted"))
 
@@ -674,13 +999,34 @@ func TestAgentCreate_AllowedAddresses(t *testing.T) {
 	require.NoError(t, err)
 
 	output := buf.String()
-	assert.Contains(t, output, "1ABC")
-	assert.Contains(t, output, "1DEF")
+	assert.Contains(t, output, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa")
+	assert.Contains(t, output, "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH")
+}
+
+func TestValidateAllowedAddr(t *testing.T) {
+	t.Parallel()
+
+	chains := []chain.ID{chain.BSV, chain.ETH}
+	require.NoError(t, validateAllowedAddr("1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", chains, wallet.Mainnet))
+	require.NoError(t, validateAllowedAddr("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", chains, wallet.Testnet))
+	require.NoError(t, validateAllowedAddr("mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r", chains, wallet.Testnet))
+
+	err := validateAllowedAddr("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", chains, wallet.Mainnet)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidChecksum, "the ETH checksum is reported, not the BSV format")
+
+	err = validateAllowedAddr("mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r", chains, wallet.Mainnet)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidAddress)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "for another network")
+
+	require.ErrorIs(t, validateAllowedAddr("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", []chain.ID{chain.BSV}, wallet.Mainnet),
+		sigilerr.ErrInvalidAddress)
 }
 
 // TestAgentList_MultipleAgents tests listing multiple agents.
 func TestAgentList_MultipleAgents(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -730,7 +1076,7 @@ func TestAgentList_MultipleAgents(t *testing.T) {
 
 // TestAgentList_NoAgents tests listing when no agents exist.
 func TestAgentList_NoAgents(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -753,7 +1099,7 @@ func TestAgentList_NoAgents(t *testing.T) {
 
 // TestAgentList_JSONOutput tests agent list with JSON output.
 func TestAgentList_JSONOutput(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatJSON}
@@ -806,7 +1152,7 @@ func TestAgentList_JSONOutput(t *testing.T) {
 
 // TestAgentInfo_Success tests showing agent info.
 func TestAgentInfo_Success(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -855,7 +1201,7 @@ func TestAgentInfo_Success(t *testing.T) {
 
 // TestAgentInfo_NotFound tests error when agent doesn't exist.
 func TestAgentInfo_NotFound(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -877,7 +1223,7 @@ func TestAgentInfo_NotFound(t *testing.T) {
 
 // TestAgentInfo_JSONOutput tests agent info with JSON output.
 func TestAgentInfo_JSONOutput(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatJSON}
@@ -928,9 +1274,79 @@ func TestAgentInfo_JSONOutput(t *testing.T) {
 	assert.Equal(t, "json-info", result["label"])
 }
 
+// TestAgentInfo_DailyAllowance tests the rolling daily allowance in agent info.
+func TestAgentInfo_DailyAllowance(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+
+	storage := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets"))
+	_, seed, err := storage.Load("test-wallet", []byte("testpass123"))
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+
+	token, err := agent.GenerateToken()
+	require.NoError(t, err)
+	cred := &agent.Credential{
+		ID:         agent.TokenID(token),
+		Label:      "limited",
+		WalletName: "test-wallet",
+		Chains:     []chain.ID{chain.BSV},
+		Policy:     agent.Policy{MaxDailySat: 100000},
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(30 * 24 * time.Hour),
+	}
+	require.NoError(t, cmdCtx.AgentStore.CreateCredential(cred, token, seed))
+	counterPath := cmdCtx.AgentStore.CounterPath("test-wallet", cred.ID)
+	require.NoError(t, agent.RecordSpend(counterPath, token, chain.BSV, big.NewInt(30000)))
+
+	run := func() string {
+		cmd := agentInfoCmd
+		cmd.SetContext(context.Background())
+		SetCmdContext(cmd, cmdCtx)
+		require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
+		require.NoError(t, cmd.Flags().Set("id", cred.ID))
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		require.NoError(t, cmd.RunE(cmd, []string{}))
+		return buf.String()
+	}
+
+	text := run()
+	assert.Contains(t, text, "30000 sat spent, 70000 sat remaining, more from ")
+
+	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatJSON}
+	var result struct {
+		Daily []struct {
+			Unit        string  `json:"unit"`
+			Spent       string  `json:"spent"`
+			Remaining   *string `json:"remaining"`
+			NextRelease *string `json:"next_release"`
+		} `json:"daily"`
+		LedgerIntact bool `json:"ledger_intact"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(run()), &result))
+	require.Len(t, result.Daily, 1)
+	assert.Equal(t, "sat", result.Daily[0].Unit)
+	assert.Equal(t, "30000", result.Daily[0].Spent)
+	require.NotNil(t, result.Daily[0].Remaining)
+	assert.Equal(t, "70000", *result.Daily[0].Remaining)
+	assert.NotNil(t, result.Daily[0].NextRelease)
+	assert.True(t, result.LedgerIntact)
+
+	// A tampered ledger leaves nothing to spend
+	require.NoError(t, os.WriteFile(counterPath, []byte("garbage"), 0o600))
+	require.NoError(t, json.Unmarshal([]byte(run()), &result))
+	assert.False(t, result.LedgerIntact)
+	require.Len(t, result.Daily, 1)
+	assert.Equal(t, "0", *result.Daily[0].Remaining)
+}
+
 // TestAgentRevoke_SingleAgent tests revoking a specific agent.
 func TestAgentRevoke_SingleAgent(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -982,7 +1398,7 @@ func TestAgentRevoke_SingleAgent(t *testing.T) {
 
 // TestAgentRevoke_AllAgents tests revoking all agents.
 func TestAgentRevoke_AllAgents(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -1036,7 +1452,7 @@ func TestAgentRevoke_AllAgents(t *testing.T) {
 
 // TestAgentRevoke_NotFound tests error when agent doesn't exist.
 func TestAgentRevoke_NotFound(t *testing.T) {
-	tmpDir, cmdCtx, cleanup := setupAgentTest(t) //nolint:govet // test helper returns
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
 	defer cleanup()
 
 	createTestWalletForAgent(t, tmpDir)
@@ -1054,3 +1470,271 @@ func TestAgentRevoke_NotFound(t *testing.T) {
 	err := cmd.RunE(cmd, []string{})
 	require.Error(t, err)
 }
+
+// TestAgentAudit shows an agent's audit log as text and JSON.
+func TestAgentAudit(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+
+	cc := &CommandContext{AgentStore: cmdCtx.AgentStore, AgentCred: &agent.Credential{ID: "agt_audited", WalletName: "test-wallet"}}
+	recordAgentSends(cc, agentAuditSourceCLI, &transaction.SendResult{
+		ChainID: chain.BSV, To: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Amount: "0.0001", Hash: "abc123",
+	})
+	recordAgentAudit(cc, "test-wallet", "agt_audited",
+		agentSendEntry(agentAuditSourceServe, chain.BSV, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "5", ""),
+		sigilerr.WithSuggestion(sigilerr.ErrAgentPolicyViolation, "amount exceeds per-transaction limit"))
+
+	cmd := agentAuditCmd
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+	require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
+	require.NoError(t, cmd.Flags().Set("id", "agt_audited"))
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Contains(t, buf.String(), "allowed")
+	assert.Contains(t, buf.String(), "abc123")
+	assert.Contains(t, buf.String(), "denied")
+	assert.Contains(t, buf.String(), "amount exceeds per-transaction limit")
+
+	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatJSON}
+	agentAuditLimit = 1
+	buf.Reset()
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+
+	var result struct {
+		Total   int                `json:"total"`
+		Intact  bool               `json:"intact"`
+		Entries []agent.AuditEntry `json:"entries"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, 2, result.Total)
+	assert.True(t, result.Intact)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, agent.DecisionDenied, result.Entries[0].Decision)
+	assert.Equal(t, agentAuditSourceServe, result.Entries[0].Source)
+}
+
+// TestAgentAudit_NotFound tests error when the agent has no log and doesn't exist.
+func TestAgentAudit_NotFound(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+
+	cmd := agentAuditCmd
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+	require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
+	require.NoError(t, cmd.Flags().Set("id", "agt_nonexistent"))
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	err := cmd.RunE(cmd, []string{})
+	require.ErrorIs(t, err, sigilerr.ErrNotFound)
+}
+
+// TestAgentReport reports an agent's journal sends and denials as JSON and CSV.
+func TestAgentReport(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+
+	journal := txjournal.New(filepath.Join(tmpDir, "wallets", "test-wallet"))
+	require.NoError(t, journal.Record(txjournal.Entry{
+		Hash: "abc123", Chain: chain.BSV, To: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Amount: "10000", Fee: "25",
+		Symbol: "BSV", Decimals: 8, Direction: txjournal.DirectionSent, Agent: "agt_reported", CreatedAt: time.Now().Add(-time.Hour),
+	}))
+	require.NoError(t, journal.Record(txjournal.Entry{
+		Hash: "old", Chain: chain.BSV, To: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Amount: "1", Fee: "1",
+		Symbol: "BSV", Decimals: 8, Direction: txjournal.DirectionSent, Agent: "agt_reported", CreatedAt: time.Now().Add(-60 * 24 * time.Hour),
+	}))
+	require.NoError(t, cmdCtx.AgentStore.AppendAudit("test-wallet", "agt_reported", &agent.AuditEntry{
+		Action: agentAuditActionSend, Source: agentAuditSourceServe, Chain: chain.BSV, To: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+		Amount: "5", Decision: agent.DecisionDenied, Reason: "over limit",
+	}))
+
+	cmd := agentReportCmd
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+	require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
+	require.NoError(t, cmd.Flags().Set("id", "agt_reported"))
+	require.NoError(t, cmd.Flags().Set("since", "30d"))
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatJSON}
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	var result struct {
+		Revoked      bool `json:"revoked"`
+		Transactions []struct {
+			Hash           string `json:"hash"`
+			Amount         string `json:"amount"`
+			NewDestination bool   `json:"new_destination"`
+		} `json:"transactions"`
+		Totals []struct {
+			Amount string `json:"amount"`
+			Fees   string `json:"fees"`
+		} `json:"totals"`
+		Denied int `json:"denied"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.True(t, result.Revoked)
+	require.Len(t, result.Transactions, 1, "sends before --since are left out")
+	assert.Equal(t, "abc123", result.Transactions[0].Hash)
+	assert.Equal(t, "0.0001", result.Transactions[0].Amount)
+	assert.False(t, result.Transactions[0].NewDestination, "the earlier send made the destination known")
+	require.Len(t, result.Totals, 1)
+	assert.Equal(t, "0.00000025", result.Totals[0].Fees)
+	assert.Equal(t, 1, result.Denied)
+
+	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatCSV}
+	buf.Reset()
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "time,record,chain,to,amount,asset,fee,status,tx_hash,flags,reason", lines[0])
+	assert.Contains(t, lines[1], ",tx,bsv,1BoatSLRHtKNngkdXEeobR76b53LETtpyT,0.0001,BSV,0.00000025,pending,abc123,,")
+	assert.Contains(t, lines[2], ",denied,bsv,1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa,5,BSV,,,,,over limit")
+
+	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatText}
+	buf.Reset()
+	require.NoError(t, cmd.RunE(cmd, []string{}))
+	assert.Contains(t, buf.String(), "Transactions: 1")
+	assert.Contains(t, buf.String(), "Denied: 1")
+}
+
+// TestAgentReport_NotFound tests error when nothing is known of the agent.
+func TestAgentReport_NotFound(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+
+	cmd := agentReportCmd
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+	require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
+	require.NoError(t, cmd.Flags().Set("id", "agt_nonexistent"))
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+
+	require.ErrorIs(t, cmd.RunE(cmd, []string{}), sigilerr.ErrNotFound)
+}
+
+// TestAgentPolicy_AddListRemove tests managing an agent's policy rules.
+func TestAgentPolicy_AddListRemove(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+
+	storage := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets"))
+	_, seed, err := storage.Load("test-wallet", []byte("testpass123"))
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+
+	token, err := agent.GenerateToken()
+	require.NoError(t, err)
+	cred := &agent.Credential{
+		ID:         agent.TokenID(token),
+		Label:      "ruled",
+		WalletName: "test-wallet",
+		Chains:     []chain.ID{chain.BSV},
+		CreatedAt:  time.Now(),
+		ExpiresAt:  time.Now().Add(30 * 24 * time.Hour),
+	}
+	require.NoError(t, cmdCtx.AgentStore.CreateCredential(cred, token, seed))
+
+	// The agent token and then the wallet password are prompted for
+	agentToken := token
+	withMockPrompts(t, []byte("testpass123"), true)
+	promptPasswordFn = func(prompt string) ([]byte, error) {
+		if strings.Contains(prompt, "agent token") {
+			return []byte(agentToken), nil
+		}
+		return []byte("testpass123"), nil
+	}
+
+	run := func(cmd *cobra.Command, flags map[string]string) (string, error) {
+		cmd.SetContext(context.Background())
+		SetCmdContext(cmd, cmdCtx)
+		require.NoError(t, cmd.Flags().Set("wallet", "test-wallet"))
+		require.NoError(t, cmd.Flags().Set("id", cred.ID))
+		for name, value := range flags {
+			require.NoError(t, cmd.Flags().Set(name, value))
+		}
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		cmd.SetErr(&buf)
+		err := cmd.RunE(cmd, []string{})
+		return buf.String(), err
+	}
+
+	text, err := run(agentPolicyAddCmd, map[string]string{
+		"chain": "bsv", "to": "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", "max-daily": "0.001",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, text, "Rule r1 added")
+	assert.Contains(t, text, "0.001 BSV")
+
+	agentSeed, loaded, err := cmdCtx.AgentStore.Load("test-wallet", cred.ID, token)
+	require.NoError(t, err, "the policy is re-signed with the token")
+	wallet.ZeroBytes(agentSeed)
+	require.Len(t, loaded.Policy.Rules, 1)
+	assert.Equal(t, "100000", loaded.Policy.Rules[0].MaxDaily)
+
+	// Invalid destinations and chains the agent lacks are refused
+	_, err = run(agentPolicyAddCmd, map[string]string{"chain": "bsv", "to": "not-an-address", "max-daily": "0"})
+	require.Error(t, err)
+	_, err = run(agentPolicyAddCmd, map[string]string{"chain": "eth", "to": ""})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	// A wrong token cannot re-sign the policy
+	agentToken = "sigil_agt_wrong"
+	_, err = run(agentPolicyAddCmd, map[string]string{"chain": "bsv", "to": "", "max-daily": "0.002"})
+	require.ErrorIs(t, err, sigilerr.ErrAgentTokenInvalid)
+	agentToken = token
+
+	require.NoError(t, agent.RecordAssetSpend(cmdCtx.AgentStore.CounterPath("test-wallet", cred.ID), token,
+		agent.Spend{Chain: chain.BSV, To: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Amount: "25000"}))
+	text, err = run(agentPolicyListCmd, nil)
+	require.NoError(t, err)
+	assert.Contains(t, text, "r1  BSV on bsv")
+	assert.Contains(t, text, "Last 24h:   0.00025 BSV")
+
+	cmdCtx.Fmt = &mockFormatProvider{format: output.FormatJSON}
+	text, err = run(agentPolicyListCmd, nil)
+	require.NoError(t, err)
+	var list struct {
+		Rules []struct {
+			ID         string  `json:"id"`
+			MaxDaily   string  `json:"max_daily"`
+			DailySpent *string `json:"daily_spent"`
+		} `json:"rules"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(text), &list))
+	require.Len(t, list.Rules, 1)
+	require.NotNil(t, list.Rules[0].DailySpent)
+	assert.Equal(t, "25000", *list.Rules[0].DailySpent)
+
+	_, err = run(agentPolicyRemoveCmd, map[string]string{"rule": "r7"})
+	require.ErrorIs(t, err, sigilerr.ErrNotFound)
+	_, err = run(agentPolicyRemoveCmd, map[string]string{"rule": "r1"})
+	require.NoError(t, err)
+	agentSeed, loaded, err = cmdCtx.AgentStore.Load("test-wallet", cred.ID, token)
+	require.NoError(t, err)
+	wallet.ZeroBytes(agentSeed)
+	assert.Empty(t, loaded.Policy.Rules)
+}
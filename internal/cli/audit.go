@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// auditWallet is the wallet name to audit.
+	auditWallet string
+)
+
+// auditCmd is the parent command for wallet integrity checks.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Check wallet integrity",
+	Long:  `Verify that wallet files are consistent with the seed they were created from.`,
+}
+
+// auditAddressesCmd re-derives stored addresses and compares them with the wallet file.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var auditAddressesCmd = &cobra.Command{
+	Use:   "addresses",
+	Short: "Re-derive stored addresses and compare them with the wallet file",
+	Long: `Re-derive every stored receive and change address from the seed and check
+that its address, public key, derivation path, and index match the wallet
+metadata.
+
+A mismatch means the wallet file was corrupted or tampered with. Do not
+receive funds to a mismatching address; restore the wallet from its
+mnemonic instead. The command exits with a non-zero status when any
+mismatch is found.`,
+	Example: `  sigil audit addresses --wallet main
+  sigil audit addresses --wallet main -o json`,
+	RunE: runAuditAddresses,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	auditCmd.GroupID = "security"
+	rootCmd.AddCommand(auditCmd)
+	auditCmd.AddCommand(auditAddressesCmd)
+
+	auditAddressesCmd.Flags().StringVar(&auditWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+}
+
+func runAuditAddresses(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &auditWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	wlt, seed, err := loadWalletWithSession(auditWallet, storage, cmd)
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(seed)
+
+	report, err := wlt.AuditAddresses(seed)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		_ = writeJSON(w, report)
+	} else {
+		displayAuditReportText(w, report)
+	}
+
+	if !report.OK() {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrWalletIntegrity,
+			fmt.Sprintf("%d mismatch(es) in wallet '%s'; restore it from the mnemonic with: sigil wallet restore",
+				len(report.Mismatches), auditWallet),
+		)
+	}
+	return nil
+}
+
+// displayAuditReportText shows an address audit report in text format.
+func displayAuditReportText(w io.Writer, report *wallet.AuditReport) {
+	if report.OK() {
+		out(w, "Wallet '%s': all %d stored addresses match the seed.\n", report.Wallet, report.Checked)
+		return
+	}
+
+	out(w, "Wallet '%s': %d stored addresses checked, %d mismatch(es) found.\n\n",
+		report.Wallet, report.Checked, len(report.Mismatches))
+
+	for _, m := range report.Mismatches {
+		kind := "receive"
+		if m.IsChange {
+			kind = "change"
+		}
+		out(w, "  %s %s %s (index %d)\n", m.Chain, kind, m.Path, m.Index)
+		out(w, "    %-10s stored:   %s\n", m.Field, m.Stored)
+		out(w, "    %-10s expected: %s\n", "", m.Expected)
+	}
+}
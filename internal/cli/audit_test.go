@@ -0,0 +1,46 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+func TestDisplayAuditReportText(t *testing.T) {
+	t.Parallel()
+
+	t.Run("clean", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		displayAuditReportText(&buf, &wallet.AuditReport{Wallet: "main", Checked: 12, Mismatches: []wallet.AddressMismatch{}})
+		assert.Contains(t, buf.String(), "all 12 stored addresses match")
+	})
+
+	t.Run("mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		displayAuditReportText(&buf, &wallet.AuditReport{
+			Wallet:  "main",
+			Checked: 4,
+			Mismatches: []wallet.AddressMismatch{{
+				Chain:    wallet.ChainBSV,
+				Path:     "m/44'/236'/0'/1/0",
+				IsChange: true,
+				Field:    wallet.AuditFieldAddress,
+				Stored:   "1Tampered",
+				Expected: "1Expected",
+			}},
+		})
+
+		got := buf.String()
+		assert.Contains(t, got, "1 mismatch(es)")
+		assert.Contains(t, got, "bsv change m/44'/236'/0'/1/0")
+		assert.Contains(t, got, "1Tampered")
+		assert.Contains(t, got, "1Expected")
+	})
+}
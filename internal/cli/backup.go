@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"path/filepath"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -31,7 +34,9 @@ var backupCmd = &cobra.Command{
 	Long: `Create, verify, and restore encrypted wallet backups.
 
 Backups are encrypted with your wallet password and stored in ~/.sigil/backups/.
-Each backup includes the wallet seed and all metadata.`,
+Each backup includes the wallet seed and all metadata. Backup files are named
+by their content hash, and each links to the wallet's previous backup, so a
+wallet's backups form a chain that backup verify can check.`,
 }
 
 // backupCreateCmd creates a backup.
@@ -42,8 +47,10 @@ var backupCreateCmd = &cobra.Command{
 	Short: "Create a wallet backup",
 	Long: `Create an encrypted backup of a wallet.
 
-The backup file will be created in ~/.sigil/backups/ with a timestamped name.
-The backup includes the wallet seed and all metadata, encrypted with your password.`,
+The backup file will be created in ~/.sigil/backups/ as <wallet>-<hash>.sigil,
+named by the start of its content hash. The backup includes the wallet seed
+and all metadata, encrypted with your password, and links to the wallet's
+latest intact backup.`,
 	Example: `  sigil backup create --wallet main`,
 	RunE:    runBackupCreate,
 }
@@ -53,13 +60,20 @@ The backup includes the wallet seed and all metadata, encrypted with your passwo
 //nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
 var backupVerifyCmd = &cobra.Command{
 	Use:   "verify",
-	Short: "Verify a backup file",
-	Long: `Verify the integrity of a backup file.
-
-This checks the backup structure and SHA256 checksum. Optionally tests
-decryption by providing your wallet password.`,
-	Example: `  sigil backup verify --input ~/.sigil/backups/main-2024-01-15.sigil`,
-	RunE:    runBackupVerify,
+	Short: "Verify backup files",
+	Long: `Verify that backups are intact, to catch silent bit-rot of backup media.
+
+With --input, verifies one backup file. Otherwise verifies every backup of
+--wallet in ~/.sigil/backups/ and the links of its manifest chain.
+
+This checks the backup structure, the SHA256 checksum, and the content hash.
+Given the password, it also decrypts each backup and checks that the wallet
+data parses and matches the manifest, without restoring anything. Exits with
+a non-zero status when a backup is corrupted.`,
+	Example: `  sigil backup verify --input ~/.sigil/backups/main-3f9a1c0d2b4e6f81.sigil
+  sigil backup verify --wallet main
+  sigil backup verify --wallet main -o json`,
+	RunE: runBackupVerify,
 }
 
 // backupRestoreCmd restores a wallet from backup.
@@ -72,7 +86,7 @@ var backupRestoreCmd = &cobra.Command{
 
 You will need the password used when creating the backup.
 Optionally specify a new name for the restored wallet.`,
-	Example: `  sigil backup restore --input ~/.sigil/backups/main-2024-01-15.sigil
+	Example: `  sigil backup restore --input ~/.sigil/backups/main-3f9a1c0d2b4e6f81.sigil
   sigil backup restore --input backup.sigil --name restored_wallet`,
 	RunE: runBackupRestore,
 }
@@ -98,11 +112,11 @@ func init() {
 	backupCmd.AddCommand(backupRestoreCmd)
 	backupCmd.AddCommand(backupListCmd)
 
-	backupCreateCmd.Flags().StringVar(&backupWallet, "wallet", "", "wallet name (required)")
-	_ = backupCreateCmd.MarkFlagRequired("wallet")
+	backupCreateCmd.Flags().StringVar(&backupWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
 
-	backupVerifyCmd.Flags().StringVar(&backupInput, "input", "", "path to backup file (required)")
-	_ = backupVerifyCmd.MarkFlagRequired("input")
+	backupVerifyCmd.Flags().StringVar(&backupInput, "input", "", "path to one backup file to verify")
+	backupVerifyCmd.Flags().StringVar(&backupWallet, "wallet", "", "verify every backup of this wallet (defaults to config default_wallet)")
+	backupVerifyCmd.MarkFlagsMutuallyExclusive("input", "wallet")
 
 	backupRestoreCmd.Flags().StringVar(&backupInput, "input", "", "path to backup file (required)")
 	backupRestoreCmd.Flags().StringVar(&restoreName, "name", "", "new name for restored wallet (optional)")
@@ -110,6 +124,9 @@ func init() {
 }
 
 func runBackupCreate(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &backupWallet); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd)
 
 	// Get backup service
@@ -130,7 +147,7 @@ func runBackupCreate(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Prompt for password
-	password, err := promptPasswordFn("Enter wallet password: ")
+	password, err := promptWalletPassword("Enter wallet password: ")
 	if err != nil {
 		return err
 	}
@@ -150,6 +167,12 @@ func runBackupCreate(cmd *cobra.Command, _ []string) error {
 	out(w, "  Wallet:   %s\n", bak.Manifest.WalletName)
 	out(w, "  Chains:   %v\n", bak.Manifest.Chains)
 	out(w, "  Checksum: %s\n", bak.Checksum[:16]+"...")
+	out(w, "  Hash:     %s\n", bak.Hash)
+	if bak.Manifest.Previous != "" {
+		out(w, "  Chain:    #%d, after %s\n", bak.Manifest.Sequence, shortBackupHash(bak.Manifest.Previous))
+	} else {
+		out(w, "  Chain:    #%d, first backup of the wallet\n", bak.Manifest.Sequence)
+	}
 	outln(w)
 	outln(w, "Store this backup file securely. You will need your wallet password to restore it.")
 
@@ -157,6 +180,17 @@ func runBackupCreate(cmd *cobra.Command, _ []string) error {
 }
 
 func runBackupVerify(cmd *cobra.Command, _ []string) error {
+	if backupInput != "" {
+		return runBackupVerifyFile(cmd)
+	}
+	if err := resolveWalletName(cmd, &backupWallet); err != nil {
+		return err
+	}
+	return runBackupVerifyChain(cmd)
+}
+
+// runBackupVerifyFile verifies the single backup file given with --input.
+func runBackupVerifyFile(cmd *cobra.Command) error {
 	cmdCtx := GetCmdContext(cmd)
 
 	// Get backup service
@@ -170,30 +204,48 @@ func runBackupVerify(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("verifying backup: %w", err)
 	}
 
-	// Ask if user wants to test decryption
 	w := cmd.OutOrStdout()
-	outln(w, "Backup structure verified successfully!")
-	outln(w)
-	out(w, "  Wallet:  %s\n", manifest.WalletName)
-	out(w, "  Created: %s\n", manifest.CreatedAt.Format("2006-01-02 15:04:05"))
-	out(w, "  Chains:  %v\n", manifest.Chains)
-	outln(w)
+	jsonOut := cmdCtx.Fmt.Format() == output.FormatJSON
+	if !jsonOut {
+		outln(w, "Backup structure verified successfully!")
+		outln(w)
+		out(w, "  Wallet:  %s\n", manifest.WalletName)
+		out(w, "  Created: %s\n", manifest.CreatedAt.Format("2006-01-02 15:04:05"))
+		out(w, "  Chains:  %v\n", manifest.Chains)
+		if manifest.Sequence > 0 {
+			out(w, "  Chain:   #%d\n", manifest.Sequence)
+		}
+		outln(w)
+
+		// Ask if user wants to test decryption
+		outln(w, "To test decryption, enter your password (or press Enter to skip):")
+	}
 
-	outln(w, "To test decryption, enter your password (or press Enter to skip):")
 	password, err := promptPasswordFn("Password: ")
 	if err != nil {
 		return err
 	}
 	defer wallet.ZeroBytes(password)
 
-	if len(password) > 0 {
-		_, err = svc.VerifyWithDecryption(backupInput, password)
-		if err != nil {
-			return sigilerr.WithSuggestion(
-				sigilerr.ErrAuthentication,
-				"decryption test failed - wrong password or corrupted backup",
-			)
+	decrypted := len(password) > 0
+	if decrypted {
+		if _, err = svc.VerifyWithDecryption(backupInput, password); err != nil {
+			return backupDecryptionError(err)
 		}
+	}
+
+	if jsonOut {
+		return writeJSON(w, map[string]any{
+			"file":       backupInput,
+			"wallet":     manifest.WalletName,
+			"created_at": manifest.CreatedAt,
+			"chains":     manifest.Chains,
+			"sequence":   manifest.Sequence,
+			"previous":   manifest.Previous,
+			"decrypted":  decrypted,
+		})
+	}
+	if decrypted {
 		outln(w)
 		outln(w, "Decryption verified successfully!")
 	}
@@ -201,6 +253,180 @@ func runBackupVerify(cmd *cobra.Command, _ []string) error {
 	return nil
 }
 
+// backupVerifyEntry is the verification result of one backup of a chain.
+// Status is "ok", "corrupted", or "wrong_password".
+type backupVerifyEntry struct {
+	File      string     `json:"file"`
+	Sequence  int        `json:"sequence,omitempty"`
+	Hash      string     `json:"hash,omitempty"`
+	Previous  string     `json:"previous,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	Status    string     `json:"status"`
+	Decrypted bool       `json:"decrypted"`
+	Gap       bool       `json:"gap"`
+	Error     string     `json:"error,omitempty"`
+}
+
+// Statuses of a verified backup.
+const (
+	backupStatusOK            = "ok"
+	backupStatusCorrupted     = "corrupted"
+	backupStatusWrongPassword = "wrong_password"
+)
+
+// runBackupVerifyChain verifies every backup of --wallet and its manifest chain.
+func runBackupVerifyChain(cmd *cobra.Command) error {
+	cmdCtx := GetCmdContext(cmd)
+
+	walletStorage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	backupDir := filepath.Join(cmdCtx.Cfg.GetHome(), "backups")
+	svc := backup.NewService(backupDir, walletStorage)
+
+	chain, err := svc.Chain(backupWallet)
+	if err != nil {
+		return fmt.Errorf("reading backups: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	jsonOut := cmdCtx.Fmt.Format() == output.FormatJSON
+	if len(chain) == 0 {
+		if jsonOut {
+			return writeJSON(w, map[string]any{"wallet": backupWallet, "backups": []backupVerifyEntry{}})
+		}
+		out(w, "No backups of wallet '%s' found in %s.\n", backupWallet, backupDir)
+		out(w, "Create one with: sigil backup create --wallet %s\n", backupWallet)
+		return nil
+	}
+
+	if !jsonOut {
+		out(w, "To test decryption of the %d backup(s), enter the wallet password (or press Enter to skip):\n", len(chain))
+	}
+	password, err := promptPasswordFn("Password: ")
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(password)
+
+	entries := make([]backupVerifyEntry, 0, len(chain))
+	corrupted := 0
+	for i := range chain {
+		entry := verifyChainEntry(svc, &chain[i], password)
+		if entry.Status == backupStatusCorrupted {
+			corrupted++
+		}
+		entries = append(entries, entry)
+	}
+
+	if jsonOut {
+		_ = writeJSON(w, map[string]any{"wallet": backupWallet, "backups": entries})
+	} else {
+		displayBackupChain(w, backupWallet, entries)
+	}
+
+	if corrupted > 0 {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrBackupCorrupted,
+			fmt.Sprintf("%d of %d backup(s) of wallet '%s' failed verification; replace them with: sigil backup create --wallet %s",
+				corrupted, len(entries), backupWallet, backupWallet),
+		)
+	}
+	return nil
+}
+
+// verifyChainEntry verifies one backup of a chain, decrypting it when a
+// password is given.
+func verifyChainEntry(svc *backup.Service, e *backup.ChainEntry, password []byte) backupVerifyEntry {
+	entry := backupVerifyEntry{File: filepath.Base(e.Path), Status: backupStatusOK, Gap: e.Gap}
+	if b := e.Backup; b != nil {
+		entry.Sequence, entry.Hash, entry.Previous = b.Manifest.Sequence, b.Hash, b.Manifest.Previous
+		createdAt := b.Manifest.CreatedAt
+		entry.CreatedAt = &createdAt
+	}
+	if e.Err != nil {
+		entry.Status, entry.Error = backupStatusCorrupted, e.Err.Error()
+		return entry
+	}
+	if len(password) == 0 {
+		return entry
+	}
+
+	_, err := svc.VerifyWithDecryption(e.Path, password)
+	switch {
+	case err == nil:
+		entry.Decrypted = true
+	case errors.Is(err, backup.ErrDecryptionFailed):
+		// The checksum passed, so the data is intact: the backup was made
+		// under another password
+		entry.Status, entry.Error = backupStatusWrongPassword, err.Error()
+	default:
+		entry.Status, entry.Error = backupStatusCorrupted, err.Error()
+	}
+	return entry
+}
+
+// displayBackupChain shows the verification results of a wallet's backups.
+func displayBackupChain(w io.Writer, walletName string, entries []backupVerifyEntry) {
+	outln(w)
+	out(w, "Backups of wallet '%s':\n", walletName)
+	counts := make(map[string]int)
+	for _, e := range entries {
+		counts[e.Status]++
+		seq := "-"
+		switch {
+		case e.Sequence > 0:
+			seq = fmt.Sprintf("#%d", e.Sequence)
+		case e.CreatedAt != nil:
+			seq = "legacy"
+		}
+		created := ""
+		if e.CreatedAt != nil {
+			created = e.CreatedAt.Local().Format("2006-01-02 15:04")
+		}
+
+		status := "ok"
+		switch {
+		case e.Status == backupStatusCorrupted:
+			status = "CORRUPTED: " + e.Error
+		case e.Status == backupStatusWrongPassword:
+			status = "intact, not decrypted (made under another password)"
+		case e.Decrypted:
+			status = "ok, decrypted"
+		}
+		out(w, "  %-7s %-36s %-16s %s\n", seq, e.File, created, status)
+		if e.Gap {
+			out(w, "          previous backup %s not found (moved, deleted, or corrupted)\n", shortBackupHash(e.Previous))
+		}
+	}
+	outln(w)
+	out(w, "%d backup(s) checked: %d ok, %d corrupted", len(entries), counts[backupStatusOK], counts[backupStatusCorrupted])
+	if n := counts[backupStatusWrongPassword]; n > 0 {
+		out(w, ", %d not decrypted", n)
+	}
+	outln(w, ".")
+}
+
+// backupDecryptionError maps a failed decryption test to a CLI error.
+func backupDecryptionError(err error) error {
+	if errors.Is(err, backup.ErrDecryptionFailed) {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAuthentication,
+			"decryption test failed - wrong password or corrupted backup",
+		)
+	}
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrBackupCorrupted,
+		fmt.Sprintf("backup decrypts, but its contents are damaged: %s", err),
+	)
+}
+
+// shortBackupHash shortens a content hash for display.
+func shortBackupHash(hash string) string {
+	if len(hash) > backup.ShortHashLength {
+		return hash[:backup.ShortHashLength]
+	}
+	return hash
+}
+
 func runBackupRestore(cmd *cobra.Command, _ []string) error {
 	cmdCtx := GetCmdContext(cmd)
 
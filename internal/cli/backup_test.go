@@ -3,6 +3,7 @@ package cli
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"testing"
@@ -14,6 +15,7 @@ import (
 	"github.com/mrz1836/sigil/internal/backup"
 	"github.com/mrz1836/sigil/internal/output"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // newBackupListTestCmd creates a cobra.Command with CommandContext for runBackupList testing.
@@ -500,6 +502,68 @@ func TestRunBackupVerify_WithDecryption(t *testing.T) {
 	assert.Contains(t, result, "Decryption verified successfully")
 }
 
+func TestRunBackupVerify_Chain(t *testing.T) {
+	tmpDir, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	withMockPrompts(t, []byte("chainpass123"), true)
+
+	walletsDir := filepath.Join(tmpDir, "wallets")
+	storage := wallet.NewFileStorage(walletsDir)
+	w, err := wallet.NewWallet("chained", []wallet.ChainID{wallet.ChainBSV})
+	require.NoError(t, err)
+	mnemonic, err := wallet.GenerateMnemonic(12)
+	require.NoError(t, err)
+	seed, err := wallet.MnemonicToSeed(mnemonic, "")
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+	require.NoError(t, w.DeriveAddresses(seed, 1))
+	require.NoError(t, storage.Save(w, seed, []byte("chainpass123")))
+
+	svc := backup.NewService(filepath.Join(tmpDir, "backups"), storage)
+	paths := make([]string, 3)
+	for i := range paths {
+		_, paths[i], err = svc.Create("chained", []byte("chainpass123"))
+		require.NoError(t, err)
+	}
+
+	origWallet, origInput := backupWallet, backupInput
+	defer func() { backupWallet, backupInput = origWallet, origInput }()
+	backupWallet, backupInput = "chained", ""
+
+	cmd, buf := newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runBackupVerify(cmd, nil))
+	assert.Contains(t, buf.String(), "#3")
+	assert.Contains(t, buf.String(), "3 backup(s) checked: 3 ok, 0 corrupted.")
+
+	// Flip one byte of the middle backup's encrypted data
+	data, err := os.ReadFile(paths[1])
+	require.NoError(t, err)
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(data, &raw))
+	enc := []byte(raw["encrypted_data"].(string))
+	enc[40] ^= 0x01
+	raw["encrypted_data"] = string(enc)
+	data, err = json.Marshal(raw)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(paths[1], data, 0o600))
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatJSON)
+	err = runBackupVerify(cmd, nil)
+	require.ErrorIs(t, err, sigilerr.ErrBackupCorrupted)
+
+	var result struct {
+		Wallet  string              `json:"wallet"`
+		Backups []backupVerifyEntry `json:"backups"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	require.Len(t, result.Backups, 3)
+	assert.Equal(t, backupStatusOK, result.Backups[0].Status)
+	assert.True(t, result.Backups[0].Decrypted)
+	assert.Equal(t, backupStatusCorrupted, result.Backups[1].Status)
+	assert.Equal(t, filepath.Base(paths[1]), result.Backups[1].File)
+	assert.True(t, result.Backups[2].Gap, "the last backup links to the corrupted one")
+}
+
 func TestRunBackupRestore_HappyPath(t *testing.T) {
 	tmpDir, testCleanup := setupTestEnv(t)
 	defer testCleanup()
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -16,6 +17,7 @@ import (
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
 	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/price"
 	"github.com/mrz1836/sigil/internal/service/balance"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
@@ -38,6 +40,8 @@ var (
 	balanceWalletName string
 	// balanceChainFilter filters by chain (eth, bsv).
 	balanceChainFilter string
+	// balanceAccount is the BIP44 account whose balances are shown.
+	balanceAccount uint32
 	// balanceRefresh forces a fresh fetch, ignoring the cache.
 	balanceRefresh bool
 	// balanceCachedOnly shows cached data only, skipping network calls.
@@ -46,6 +50,12 @@ var (
 	balanceAsync bool
 	// balanceValidate validates cached UTXOs are still unspent (BSV only).
 	balanceValidate bool
+	// balanceTags filters balances to addresses matching every tag.
+	balanceTags []string
+	// balanceEnvelopes totals balances by envelope.
+	balanceEnvelopes bool
+	// balanceFiat is the currency to show approximate values in.
+	balanceFiat string
 )
 
 // balanceCmd is the parent command for balance operations.
@@ -77,12 +87,30 @@ Balances are cached and refreshed intelligently:
 
 Use --cached for instant display without network calls.
 Use --async for instant display with background refresh.
-Use --refresh to force fresh network fetch.`,
+Use --refresh to force fresh network fetch.
+
+Addresses tagged with 'sigil addresses tag' are totaled by tag under the
+table, with each namespace (customer) summing the tags under it
+(customer:acme, customer:globex). Use --tag to show only tagged addresses.
+
+Use --envelopes to total BSV, BTC, and BCH balances by budget envelope (see
+'sigil envelope'), with the addresses in no envelope totaled as unassigned.
+
+Use --account to show the balances of another BIP44 account of the wallet.
+
+Use --fiat usd or --fiat eur (or set price.fiat in the config) to add the
+approximate value of each coin balance and a total. Prices come from the
+price API (CoinGecko by default) and are cached in ~/.sigil/cache/prices.json
+for price.cache_minutes; --cached and --async use cached prices only.`,
 	Example: `  sigil balance show --wallet main
   sigil balance show --wallet main --cached       # instant, cache only
   sigil balance show --wallet main --async        # instant + background refresh
   sigil balance show --wallet main --refresh      # force fresh fetch
   sigil balance show --wallet main --chain eth    # filter by chain
+  sigil balance show --wallet main --tag customer # tagged addresses only
+  sigil balance show --wallet main --envelopes    # totals by envelope
+  sigil balance show --wallet main --account 1    # BIP44 account 1
+  sigil balance show --wallet main --fiat eur     # with values in EUR
   sigil balance show --wallet main -o json`,
 	RunE: runBalanceShow,
 }
@@ -98,14 +126,50 @@ type BalanceResult struct {
 	Decimals    int    `json:"decimals"`
 	Stale       bool   `json:"stale,omitempty"`
 	CacheAge    string `json:"cache_age,omitempty"`
+	Source      string `json:"source,omitempty"`
+	// Immature is the part of Balance held in coinbase outputs that cannot be
+	// spent yet, from the wallet's scanned UTXOs.
+	Immature string `json:"immature,omitempty"`
+	// PendingOutgoing is what unconfirmed sends from this address will take
+	// from Balance, from the wallet's transaction journal.
+	PendingOutgoing string `json:"pending_outgoing,omitempty"`
+	PendingTxs      int    `json:"pending_txs,omitempty"`
+	// Tags are the address's tags from 'sigil addresses tag'.
+	Tags []string `json:"tags,omitempty"`
+	// Envelope is the address's envelope from 'sigil envelope assign'.
+	Envelope string `json:"envelope,omitempty"`
+	// FiatValue is the approximate value of Balance in the currency of
+	// BalanceShowResponse.Fiat. Token balances are not valued.
+	FiatValue string `json:"fiat_value,omitempty"`
+}
+
+// BalanceFiat totals the fiat values of a balance show response.
+type BalanceFiat struct {
+	Currency string `json:"currency"`
+	// Total is the sum of the fiat values of the listed balances.
+	Total string     `json:"total"`
+	Rates []FiatRate `json:"rates"`
+}
+
+// FiatRate is the price a chain's coin was valued at.
+type FiatRate struct {
+	Chain  string    `json:"chain"`
+	Rate   float64   `json:"rate"`
+	Time   time.Time `json:"time"`
+	Source string    `json:"source"`
 }
 
 // BalanceShowResponse is the full response for balance show command.
 type BalanceShowResponse struct {
 	Wallet    string          `json:"wallet"`
+	Account   uint32          `json:"account,omitempty"`
 	Balances  []BalanceResult `json:"balances"`
-	Timestamp string          `json:"timestamp"`
-	Warning   string          `json:"warning,omitempty"`
+	TagTotals []TagTotal      `json:"tag_totals,omitempty"`
+	// EnvelopeTotals is set by --envelopes.
+	EnvelopeTotals []EnvelopeTotal `json:"envelope_totals,omitempty"`
+	Fiat           *BalanceFiat    `json:"fiat,omitempty"`
+	Timestamp      string          `json:"timestamp"`
+	Warning        string          `json:"warning,omitempty"`
 }
 
 //nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
@@ -114,18 +178,23 @@ func init() {
 	rootCmd.AddCommand(balanceCmd)
 	balanceCmd.AddCommand(balanceShowCmd)
 
-	balanceShowCmd.Flags().StringVar(&balanceWalletName, "wallet", "", "wallet name (required)")
+	balanceShowCmd.Flags().StringVar(&balanceWalletName, "wallet", "", "wallet name (defaults to config default_wallet)")
 	balanceShowCmd.Flags().StringVar(&balanceChainFilter, "chain", "", "filter by chain (eth, bsv)")
+	balanceShowCmd.Flags().Uint32Var(&balanceAccount, "account", 0, accountFlagUsage)
 	balanceShowCmd.Flags().BoolVar(&balanceRefresh, "refresh", false, "force fresh fetch, ignore cache")
 	balanceShowCmd.Flags().BoolVar(&balanceCachedOnly, "cached", false, "show cached data only, skip network")
 	balanceShowCmd.Flags().BoolVar(&balanceAsync, "async", false, "show cached data immediately, refresh in background")
 	balanceShowCmd.Flags().BoolVar(&balanceValidate, "validate", false, "validate cached UTXOs are still unspent (BSV only)")
-
-	_ = balanceShowCmd.MarkFlagRequired("wallet")
+	balanceShowCmd.Flags().StringArrayVar(&balanceTags, "tag", nil, "show only addresses with this tag or a tag under it (repeatable, all must match)")
+	balanceShowCmd.Flags().BoolVar(&balanceEnvelopes, "envelopes", false, "total balances by envelope (BSV, BTC, BCH)")
+	balanceShowCmd.Flags().StringVar(&balanceFiat, "fiat", "", fiatFlagUsage)
 }
 
 //nolint:gocognit,gocyclo,nestif // Complex business logic for balance display with multiple modes (async, cached, normal)
 func runBalanceShow(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &balanceWalletName); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd)
 
 	// Validate mutually exclusive flags
@@ -138,18 +207,30 @@ func runBalanceShow(cmd *cobra.Command, _ []string) error {
 	if balanceRefresh && balanceCachedOnly {
 		return ErrRefreshAndCached
 	}
+	tagFilters, err := normalizeTagArgs(balanceTags)
+	if err != nil {
+		return err
+	}
+	fiatCurrency, err := resolveFiatCurrency(cmdCtx.Cfg, balanceFiat)
+	if err != nil {
+		return err
+	}
 
 	// 1. Load wallet
 	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
-	w, seed, err := loadWalletWithSession(balanceWalletName, storage, cmd)
+	w, seed, err := loadWalletForRead(balanceWalletName, storage, cmd)
 	if err != nil {
 		return err
 	}
 	defer wallet.ZeroBytes(seed)
+	if w, err = selectAccount(w, balanceAccount); err != nil {
+		return err
+	}
 
 	// 2. Initialize service dependencies
 	utxoStore := loadUTXOStore(cmdCtx, balanceWalletName)
 	balanceCache := loadBalanceCache(cmdCtx, cmd.ErrOrStderr())
+	journal := loadTxJournal(cmdCtx, balanceWalletName)
 
 	// The wallet's stamped network governs its balance queries (per-wallet model).
 	warnNetworkConflict(cmd, w)
@@ -162,6 +243,9 @@ func runBalanceShow(cmd *cobra.Command, _ []string) error {
 	})
 
 	// 3. Build address list
+	if id, ok := chain.ParseChainID(balanceChainFilter); ok {
+		balanceChainFilter = string(id)
+	}
 	addresses := buildAddressList(w, balanceChainFilter)
 
 	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
@@ -182,6 +266,12 @@ func runBalanceShow(cmd *cobra.Command, _ []string) error {
 		// Show cached data (even if incomplete)
 		if len(batchResult.Results) > 0 {
 			response := convertToBalanceResponse(balanceWalletName, batchResult)
+			response.Account = balanceAccount
+			annotateImmatureBalances(response.Balances, utxoStore)
+			annotatePendingBalances(response.Balances, journal)
+			applyBalanceTags(&response, utxoStore, tagFilters)
+			applyBalanceEnvelopes(&response, utxoStore, balanceEnvelopes)
+			applyBalanceFiat(ctx, cmd, cmdCtx, &response, fiatCurrency, true)
 
 			// Add async refresh indicator
 			if response.Warning == "" {
@@ -225,7 +315,7 @@ func runBalanceShow(cmd *cobra.Command, _ []string) error {
 		batchResult, err = balanceService.FetchBalances(ctx, &balance.FetchBatchRequest{
 			Addresses:        addresses,
 			ForceRefresh:     balanceRefresh,
-			MaxConcurrent:    8,
+			Limiter:          newBalanceLimiter(cmdCtx.Cfg),
 			Timeout:          30 * time.Second,
 			ProgressCallback: progressCallback,
 		})
@@ -235,6 +325,9 @@ func runBalanceShow(cmd *cobra.Command, _ []string) error {
 
 		// Save cache after network fetch
 		saveBalanceCache(cmdCtx, balanceCache)
+
+		// Settle sends that confirmed since the last run
+		reconcileTxJournal(ctx, cmdCtx, journal, effectiveBSVNetwork(w, cmdCtx.Cfg))
 	}
 
 	// Add blank line after progress for better output separation
@@ -254,9 +347,63 @@ func runBalanceShow(cmd *cobra.Command, _ []string) error {
 
 	// 5. Convert and output results
 	response := convertToBalanceResponse(balanceWalletName, batchResult)
+	response.Account = balanceAccount
+	annotateImmatureBalances(response.Balances, utxoStore)
+	annotatePendingBalances(response.Balances, journal)
+	applyBalanceTags(&response, utxoStore, tagFilters)
+	applyBalanceEnvelopes(&response, utxoStore, balanceEnvelopes)
+	applyBalanceFiat(ctx, cmd, cmdCtx, &response, fiatCurrency, balanceCachedOnly)
 	return outputBalanceResponse(cmd, cmdCtx, response)
 }
 
+// applyBalanceFiat values each coin balance in currency and totals them.
+// Nothing is added when currency is empty or no price is available. With
+// offline set, only cached prices are used.
+func applyBalanceFiat(ctx context.Context, cmd *cobra.Command, cmdCtx *CommandContext, response *BalanceShowResponse, currency string, offline bool) {
+	if currency == "" {
+		return
+	}
+	var chains []chain.ID
+	seen := make(map[chain.ID]bool)
+	for _, bal := range response.Balances {
+		id := chain.ID(bal.Chain)
+		if bal.Token == "" && !seen[id] {
+			seen[id] = true
+			chains = append(chains, id)
+		}
+	}
+	if len(chains) == 0 {
+		return
+	}
+
+	quotes := fetchFiatQuotes(ctx, cmdCtx, cmd.ErrOrStderr(), chains, currency, offline)
+	if len(quotes) == 0 {
+		return
+	}
+	fiat := &BalanceFiat{Currency: currency, Rates: make([]FiatRate, 0, len(quotes))}
+	for _, id := range chains {
+		if q, ok := quotes[id]; ok {
+			fiat.Rates = append(fiat.Rates, FiatRate{Chain: string(id), Rate: q.Rate, Time: q.Time, Source: q.Source})
+		}
+	}
+	var values []string
+	for i := range response.Balances {
+		bal := &response.Balances[i]
+		q, ok := quotes[chain.ID(bal.Chain)]
+		if !ok || bal.Token != "" {
+			continue
+		}
+		value, err := fiatValue(q, bal.Balance, bal.Decimals)
+		if err != nil {
+			continue
+		}
+		bal.FiatValue = value
+		values = append(values, value)
+	}
+	fiat.Total = sumFiatValues(values)
+	response.Fiat = fiat
+}
+
 // createBalanceProgressCallback creates a progress callback for balance fetching.
 // Only shows progress in text mode (not JSON). Writes to stderr to avoid
 // interfering with stdout output.
@@ -317,8 +464,7 @@ func loadBalanceCache(cmdCtx *CommandContext, errWriter io.Writer) *cache.Balanc
 		return cache.NewBalanceCache()
 	}
 
-	cachePath := filepath.Join(cmdCtx.Cfg.GetHome(), "cache", "balances.json")
-	cacheStorage := cache.NewFileStorage(cachePath)
+	cacheStorage := cache.NewBalanceStorage(cmdCtx.Cfg.GetHome(), cmdCtx.Cfg.GetCache())
 	balanceCache, err := cacheStorage.Load()
 	if err != nil {
 		handleCacheLoadError(cmdCtx, errWriter, err)
@@ -359,8 +505,7 @@ func buildAddressList(w *wallet.Wallet, chainFilter string) []balance.AddressInp
 
 // saveBalanceCache saves the balance cache to storage, logging errors.
 func saveBalanceCache(cmdCtx *CommandContext, balanceCache *cache.BalanceCache) {
-	cachePath := filepath.Join(cmdCtx.Cfg.GetHome(), "cache", "balances.json")
-	cacheStorage := cache.NewFileStorage(cachePath)
+	cacheStorage := cache.NewBalanceStorage(cmdCtx.Cfg.GetHome(), cmdCtx.Cfg.GetCache())
 	if err := cacheStorage.Save(balanceCache); err != nil && cmdCtx.Log != nil {
 		cmdCtx.Log.Error("failed to save balance cache: %v", err)
 	}
@@ -378,13 +523,14 @@ func convertToBalanceResponse(walletName string, batchResult *balance.FetchBatch
 		for _, bal := range result.Balances {
 			cliResult := BalanceResult{
 				Chain:       string(bal.Chain),
-				Address:     bal.Address,
+				Address:     displayAddress(bal.Chain, bal.Address),
 				Balance:     bal.Balance,
 				Unconfirmed: bal.Unconfirmed,
 				Symbol:      bal.Symbol,
 				Token:       bal.Token,
 				Decimals:    bal.Decimals,
 				Stale:       bal.Stale,
+				Source:      bal.Source,
 			}
 			if bal.Stale {
 				cliResult.CacheAge = formatCacheAge(bal.UpdatedAt)
@@ -420,6 +566,46 @@ func sortBalanceResults(balances []BalanceResult) {
 	})
 }
 
+// newBalanceLimiter bounds parallel balance fetches by the concurrency
+// settings: adaptive by default, or a fixed pool.
+func newBalanceLimiter(cfg ConfigProvider) *balance.Limiter {
+	c := cfg.GetConcurrency()
+	return balance.NewLimiter(balance.LimiterConfig{Adaptive: c.Adaptive, Initial: c.Initial, Max: c.Max})
+}
+
+// annotateImmatureBalances fills in the immature coinbase amount of each BSV
+// balance from the wallet's UTXO store.
+func annotateImmatureBalances(balances []BalanceResult, store *utxostore.Store) {
+	if store == nil {
+		return
+	}
+	for i := range balances {
+		bal := &balances[i]
+		if bal.Chain != string(chain.BSV) || bal.Token != "" {
+			continue
+		}
+		if immature := store.GetImmatureBalance(chain.BSV, bal.Address); immature > 0 {
+			bal.Immature = chain.FormatDecimalAmount(new(big.Int).SetUint64(immature), 8)
+		}
+	}
+}
+
+// outputImmatureBalances lists balances that include immature coinbase outputs.
+func outputImmatureBalances(w io.Writer, balances []BalanceResult) {
+	header := false
+	for _, bal := range balances {
+		if bal.Immature == "" {
+			continue
+		}
+		if !header {
+			outln(w)
+			out(w, "Immature (coinbase outputs, spendable after %d confirmations):\n", utxostore.CoinbaseMaturity)
+			header = true
+		}
+		out(w, "  %-4s %-42s %s %s\n", strings.ToUpper(bal.Chain), truncateAddress(bal.Address), bal.Immature, bal.Symbol)
+	}
+}
+
 // outputBalanceResponse outputs the balance response in the requested format.
 func outputBalanceResponse(cmd *cobra.Command, cmdCtx *CommandContext, response BalanceShowResponse) error {
 	if cmdCtx.Fmt.Format() == output.FormatJSON {
@@ -428,10 +614,33 @@ func outputBalanceResponse(cmd *cobra.Command, cmdCtx *CommandContext, response
 		}
 	} else {
 		outputBalanceText(cmd.OutOrStdout(), response)
+		outputImmatureBalances(cmd.OutOrStdout(), response.Balances)
+		outputPendingBalances(cmd.OutOrStdout(), response.Balances)
+		outputTagTotals(cmd.OutOrStdout(), response.TagTotals)
+		outputEnvelopeTotals(cmd.OutOrStdout(), response.EnvelopeTotals)
+		if cmdCtx.Cfg.IsVerbose() {
+			outputBalanceSources(cmd.OutOrStdout(), response.Balances)
+		}
 	}
 	return nil
 }
 
+// outputBalanceSources lists the source that served each balance (verbose mode).
+func outputBalanceSources(w io.Writer, balances []BalanceResult) {
+	if len(balances) == 0 {
+		return
+	}
+	outln(w)
+	outln(w, "Sources:")
+	for _, bal := range balances {
+		source := bal.Source
+		if source == "" {
+			source = "unknown"
+		}
+		out(w, "  %-4s %-42s %-6s %s\n", strings.ToUpper(bal.Chain), truncateAddress(bal.Address), bal.Symbol, source)
+	}
+}
+
 // formatCacheAge formats the age of a cache entry for display.
 func formatCacheAge(t time.Time) string {
 	age := time.Since(t)
@@ -481,7 +690,11 @@ func hasUnconfirmedData(balances []BalanceResult) bool {
 
 // outputBalanceText outputs balances in text table format.
 func outputBalanceText(w io.Writer, response BalanceShowResponse) {
-	outln(w, fmt.Sprintf("Balances for wallet: %s", response.Wallet))
+	if response.Account > 0 {
+		outln(w, fmt.Sprintf("Balances for wallet: %s (account %d)", response.Wallet, response.Account))
+	} else {
+		outln(w, fmt.Sprintf("Balances for wallet: %s", response.Wallet))
+	}
 	outln(w)
 
 	if response.Warning != "" {
@@ -495,11 +708,15 @@ func outputBalanceText(w io.Writer, response BalanceShowResponse) {
 	}
 
 	showUnconfirmed := hasUnconfirmedData(response.Balances)
+	var fiatCurrency string
+	if response.Fiat != nil {
+		fiatCurrency = response.Fiat.Currency
+	}
 
 	if showUnconfirmed {
-		outputBalanceTableWide(w, response.Balances)
+		outputBalanceTableWide(w, response.Balances, fiatCurrency)
 	} else {
-		outputBalanceTableNarrow(w, response.Balances)
+		outputBalanceTableNarrow(w, response.Balances, fiatCurrency)
 	}
 
 	// Show staleness legend if any data is stale
@@ -510,6 +727,7 @@ func outputBalanceText(w io.Writer, response BalanceShowResponse) {
 			break
 		}
 	}
+	outputBalanceFiat(w, response.Fiat)
 }
 
 // balanceColumnWidth returns the max display width needed for balance values,
@@ -543,16 +761,56 @@ func unconfirmedColumnWidth(balances []BalanceResult, minWidth int) int {
 	return w
 }
 
-// outputBalanceTableNarrow renders the 4-column table (no unconfirmed data).
-func outputBalanceTableNarrow(w io.Writer, balances []BalanceResult) {
+// fiatColumnWidth returns the width of the fiat value column of the balance
+// tables, or 0 when no fiat currency is shown.
+func fiatColumnWidth(balances []BalanceResult, currency string) int {
+	if currency == "" {
+		return 0
+	}
+	fw := len(currency + " value")
+	for _, bal := range balances {
+		if len(bal.FiatValue) > fw {
+			fw = len(bal.FiatValue)
+		}
+	}
+	return fw
+}
+
+// fiatBorder ends a table border line, adding the fiat column segment when
+// the column is shown.
+func fiatBorder(fw int, joint, end string) string {
+	if fw == 0 {
+		return end
+	}
+	return joint + strings.Repeat("─", fw+2) + end
+}
+
+// fiatCell renders a fiat column cell, or nothing when the column is hidden.
+func fiatCell(fw int, value string, leftAlign bool) string {
+	switch {
+	case fw == 0:
+		return ""
+	case leftAlign:
+		return fmt.Sprintf(" %-*s │", fw, value)
+	case value == "":
+		return fmt.Sprintf(" %*s │", fw, "-")
+	default:
+		return fmt.Sprintf(" %*s │", fw, value)
+	}
+}
+
+// outputBalanceTableNarrow renders the 4-column table (no unconfirmed data),
+// plus a fiat value column when fiatCurrency is set.
+func outputBalanceTableNarrow(w io.Writer, balances []BalanceResult, fiatCurrency string) {
 	bw := balanceColumnWidth(balances, len("Balance"))
+	fw := fiatColumnWidth(balances, fiatCurrency)
 	balSep := strings.Repeat("─", bw+2)
 	balHdr := fmt.Sprintf(" %-*s ", bw, "Balance")
-	rowFmt := fmt.Sprintf("│ %%-6s │ %%-42s │ %%%ds │ %%-6s │\n", bw)
+	rowFmt := fmt.Sprintf("│ %%-6s │ %%-42s │ %%%ds │ %%-6s │%%s\n", bw)
 
-	outln(w, "┌────────┬────────────────────────────────────────────┬"+balSep+"┬────────┐")
-	outln(w, "│ Chain  │ Address                                    │"+balHdr+"│ Symbol │")
-	outln(w, "├────────┼────────────────────────────────────────────┼"+balSep+"┼────────┤")
+	outln(w, "┌────────┬────────────────────────────────────────────┬"+balSep+"┬────────"+fiatBorder(fw, "┬", "┐"))
+	outln(w, "│ Chain  │ Address                                    │"+balHdr+"│ Symbol │"+fiatCell(fw, fiatCurrency+" value", true))
+	outln(w, "├────────┼────────────────────────────────────────────┼"+balSep+"┼────────"+fiatBorder(fw, "┼", "┤"))
 
 	for _, bal := range balances {
 		addr := truncateAddress(bal.Address)
@@ -567,25 +825,28 @@ func outputBalanceTableNarrow(w io.Writer, balances []BalanceResult) {
 			addr,
 			balanceStr,
 			bal.Symbol,
+			fiatCell(fw, bal.FiatValue, false),
 		)
 	}
 
-	outln(w, "└────────┴────────────────────────────────────────────┴"+balSep+"┴────────┘")
+	outln(w, "└────────┴────────────────────────────────────────────┴"+balSep+"┴────────"+fiatBorder(fw, "┴", "┘"))
 }
 
-// outputBalanceTableWide renders the 5-column table with unconfirmed data.
-func outputBalanceTableWide(w io.Writer, balances []BalanceResult) {
+// outputBalanceTableWide renders the 5-column table with unconfirmed data,
+// plus a fiat value column when fiatCurrency is set.
+func outputBalanceTableWide(w io.Writer, balances []BalanceResult, fiatCurrency string) {
 	bw := balanceColumnWidth(balances, len("Confirmed"))
 	uw := unconfirmedColumnWidth(balances, len("Unconfirmed"))
+	fw := fiatColumnWidth(balances, fiatCurrency)
 	balSep := strings.Repeat("─", bw+2)
 	uncSep := strings.Repeat("─", uw+2)
 	balHdr := fmt.Sprintf(" %-*s ", bw, "Confirmed")
 	uncHdr := fmt.Sprintf(" %-*s ", uw, "Unconfirmed")
-	rowFmt := fmt.Sprintf("│ %%-6s │ %%-42s │ %%%ds │ %%%ds │ %%-6s │\n", bw, uw)
+	rowFmt := fmt.Sprintf("│ %%-6s │ %%-42s │ %%%ds │ %%%ds │ %%-6s │%%s\n", bw, uw)
 
-	outln(w, "┌────────┬────────────────────────────────────────────┬"+balSep+"┬"+uncSep+"┬────────┐")
-	outln(w, "│ Chain  │ Address                                    │"+balHdr+"│"+uncHdr+"│ Symbol │")
-	outln(w, "├────────┼────────────────────────────────────────────┼"+balSep+"┼"+uncSep+"┼────────┤")
+	outln(w, "┌────────┬────────────────────────────────────────────┬"+balSep+"┬"+uncSep+"┬────────"+fiatBorder(fw, "┬", "┐"))
+	outln(w, "│ Chain  │ Address                                    │"+balHdr+"│"+uncHdr+"│ Symbol │"+fiatCell(fw, fiatCurrency+" value", true))
+	outln(w, "├────────┼────────────────────────────────────────────┼"+balSep+"┼"+uncSep+"┼────────"+fiatBorder(fw, "┼", "┤"))
 
 	for _, bal := range balances {
 		addr := truncateAddress(bal.Address)
@@ -606,10 +867,24 @@ func outputBalanceTableWide(w io.Writer, balances []BalanceResult) {
 			balanceStr,
 			unconfStr,
 			bal.Symbol,
+			fiatCell(fw, bal.FiatValue, false),
 		)
 	}
 
-	outln(w, "└────────┴────────────────────────────────────────────┴"+balSep+"┴"+uncSep+"┴────────┘")
+	outln(w, "└────────┴────────────────────────────────────────────┴"+balSep+"┴"+uncSep+"┴────────"+fiatBorder(fw, "┴", "┘"))
+}
+
+// outputBalanceFiat writes the total fiat value and the prices used.
+func outputBalanceFiat(w io.Writer, fiat *BalanceFiat) {
+	if fiat == nil {
+		return
+	}
+	outln(w)
+	out(w, "Total value: ≈ %s %s\n", fiat.Total, fiat.Currency)
+	for _, rate := range fiat.Rates {
+		q := &price.Quote{Chain: chain.ID(rate.Chain), Currency: fiat.Currency, Rate: rate.Rate, Time: rate.Time, Source: rate.Source}
+		out(w, "  %s\n", describeFiatQuote(q))
+	}
 }
 
 // truncateAddress shortens an address for table display.
@@ -674,10 +949,10 @@ func refreshBalancesAsync(
 
 	// Fetch fresh balances using smart refresh policy
 	_, err := service.FetchBalances(bgCtx, &balance.FetchBatchRequest{
-		Addresses:     addresses,
-		ForceRefresh:  false, // Use smart refresh policy
-		MaxConcurrent: 8,
-		Timeout:       30 * time.Second,
+		Addresses:    addresses,
+		ForceRefresh: false, // Use smart refresh policy
+		Limiter:      newBalanceLimiter(cmdCtx.Cfg),
+		Timeout:      30 * time.Second,
 	})
 
 	if err != nil && cmdCtx.Log != nil {
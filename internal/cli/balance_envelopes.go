@@ -0,0 +1,115 @@
+package cli
+
+import (
+	"io"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/utxostore"
+)
+
+// EnvelopeTotal is the balance of the addresses of one chain assigned to an
+// envelope. An empty Envelope totals the chain's unassigned addresses.
+type EnvelopeTotal struct {
+	Envelope  string `json:"envelope"`
+	Chain     string `json:"chain"`
+	Symbol    string `json:"symbol"`
+	Balance   string `json:"balance"`
+	Addresses int    `json:"addresses"`
+}
+
+// applyBalanceEnvelopes annotates the response's balances with their
+// address envelopes and, when totals is set, totals them by envelope.
+func applyBalanceEnvelopes(response *BalanceShowResponse, store *utxostore.Store, totals bool) {
+	annotateBalanceEnvelopes(response.Balances, store)
+	if totals {
+		response.EnvelopeTotals = rollupBalanceEnvelopes(response.Balances)
+	}
+}
+
+// annotateBalanceEnvelopes fills in each balance's address envelope from the
+// wallet's UTXO store.
+func annotateBalanceEnvelopes(balances []BalanceResult, store *utxostore.Store) {
+	if store == nil {
+		return
+	}
+	for i := range balances {
+		bal := &balances[i]
+		if bal.Token == "" {
+			bal.Envelope = store.AddressEnvelope(chain.ID(bal.Chain), bal.Address)
+		}
+	}
+}
+
+// rollupBalanceEnvelopes totals balances by envelope. The unassigned
+// addresses of a chain are totaled too, as long as the chain has an
+// envelope, so each chain's totals add up to its balance. Balances that
+// cannot be parsed are left out.
+func rollupBalanceEnvelopes(balances []BalanceResult) []EnvelopeTotal {
+	type totalKey struct {
+		envelope, chain string
+	}
+	totals := make(map[totalKey]*EnvelopeTotal)
+	sums := make(map[totalKey]*big.Int)
+	withEnvelopes := make(map[string]bool)
+
+	for _, bal := range balances {
+		if bal.Token != "" {
+			continue
+		}
+		amount, err := parseDecimalAmount(bal.Balance, bal.Decimals)
+		if err != nil {
+			continue
+		}
+		if bal.Envelope != "" {
+			withEnvelopes[bal.Chain] = true
+		}
+
+		key := totalKey{envelope: bal.Envelope, chain: bal.Chain}
+		total, ok := totals[key]
+		if !ok {
+			total = &EnvelopeTotal{Envelope: bal.Envelope, Chain: bal.Chain, Symbol: bal.Symbol}
+			totals[key] = total
+			sums[key] = new(big.Int)
+		}
+		sums[key].Add(sums[key], amount)
+		total.Addresses++
+		total.Balance = chain.FormatDecimalAmount(sums[key], bal.Decimals)
+	}
+
+	result := make([]EnvelopeTotal, 0, len(totals))
+	for _, total := range totals {
+		if withEnvelopes[total.Chain] {
+			result = append(result, *total)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Chain != result[j].Chain {
+			return result[i].Chain < result[j].Chain
+		}
+		// Unassigned sorts last
+		if (result[i].Envelope == "") != (result[j].Envelope == "") {
+			return result[j].Envelope == ""
+		}
+		return result[i].Envelope < result[j].Envelope
+	})
+	return result
+}
+
+// outputEnvelopeTotals lists balance totals by envelope.
+func outputEnvelopeTotals(w io.Writer, totals []EnvelopeTotal) {
+	if len(totals) == 0 {
+		return
+	}
+	outln(w)
+	outln(w, "By envelope:")
+	for _, total := range totals {
+		name := total.Envelope
+		if name == "" {
+			name = "(unassigned)"
+		}
+		out(w, "  %-20s %-4s %s %s (%d address(es))\n", name, strings.ToUpper(total.Chain), total.Balance, total.Symbol, total.Addresses)
+	}
+}
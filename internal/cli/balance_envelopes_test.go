@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/utxostore"
+)
+
+func TestApplyBalanceEnvelopes(t *testing.T) {
+	t.Parallel()
+
+	store := utxostore.New(t.TempDir())
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1A", Envelope: "rent"})
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1B", Envelope: "rent"})
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1C", Envelope: "savings"})
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1D"})
+
+	newResponse := func() BalanceShowResponse {
+		return BalanceShowResponse{Balances: []BalanceResult{
+			{Chain: "bsv", Address: "1A", Balance: "0.5", Symbol: "BSV", Decimals: 8},
+			{Chain: "bsv", Address: "1B", Balance: "0.25", Symbol: "BSV", Decimals: 8},
+			{Chain: "bsv", Address: "1C", Balance: "3", Symbol: "BSV", Decimals: 8},
+			{Chain: "bsv", Address: "1D", Balance: "1", Symbol: "BSV", Decimals: 8},
+			{Chain: "eth", Address: "0xabc", Balance: "2", Symbol: "ETH", Decimals: 18},
+		}}
+	}
+
+	t.Run("totals", func(t *testing.T) {
+		t.Parallel()
+
+		response := newResponse()
+		applyBalanceEnvelopes(&response, store, true)
+		assert.Equal(t, "rent", response.Balances[0].Envelope)
+		assert.Empty(t, response.Balances[3].Envelope)
+		assert.Equal(t, []EnvelopeTotal{
+			{Envelope: "rent", Chain: "bsv", Symbol: "BSV", Balance: "0.75", Addresses: 2},
+			{Envelope: "savings", Chain: "bsv", Symbol: "BSV", Balance: "3.0", Addresses: 1},
+			{Envelope: "", Chain: "bsv", Symbol: "BSV", Balance: "1.0", Addresses: 1},
+		}, response.EnvelopeTotals, "chains without envelopes are left out")
+
+		var buf bytes.Buffer
+		outputEnvelopeTotals(&buf, response.EnvelopeTotals)
+		assert.Contains(t, buf.String(), "By envelope:")
+		assert.Contains(t, buf.String(), "rent                 BSV  0.75 BSV (2 address(es))")
+		assert.Contains(t, buf.String(), "(unassigned)         BSV  1.0 BSV (1 address(es))")
+	})
+
+	t.Run("annotated without totals", func(t *testing.T) {
+		t.Parallel()
+
+		response := newResponse()
+		applyBalanceEnvelopes(&response, store, false)
+		assert.Equal(t, "savings", response.Balances[2].Envelope)
+		assert.Nil(t, response.EnvelopeTotals)
+
+		var buf bytes.Buffer
+		outputEnvelopeTotals(&buf, response.EnvelopeTotals)
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("no store", func(t *testing.T) {
+		t.Parallel()
+
+		response := newResponse()
+		applyBalanceEnvelopes(&response, nil, true)
+		require.Empty(t, response.EnvelopeTotals)
+	})
+}
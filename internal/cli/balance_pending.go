@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	"github.com/mrz1836/sigil/internal/txjournal"
+)
+
+// ethStatusChecker reports ETH transaction status from receipts. A mined
+// transaction stays pending until it has target confirmations.
+type ethStatusChecker struct {
+	client ethStatusReader
+	target uint64
+}
+
+// TxStatus implements txjournal.StatusChecker. Reverted transactions are
+// reported as soon as they are mined.
+func (c *ethStatusChecker) TxStatus(ctx context.Context, hash string) (string, uint64, error) {
+	receipt, err := c.client.GetTransactionReceipt(ctx, hash)
+	if errors.Is(err, rpc.ErrReceiptNotFound) {
+		return txjournal.StatusPending, 0, nil
+	}
+	if err != nil {
+		return "", 0, err
+	}
+	if !receipt.Success {
+		return txjournal.StatusReverted, receipt.BlockNumber, nil
+	}
+	if c.target > 1 {
+		tip, err := c.client.BlockNumber(ctx)
+		if err != nil {
+			return "", 0, err
+		}
+		if tip < receipt.BlockNumber || tip-receipt.BlockNumber+1 < c.target {
+			return txjournal.StatusPending, 0, nil
+		}
+	}
+	return txjournal.StatusConfirmed, receipt.BlockNumber, nil
+}
+
+// bsvConfirmationReader reports how many confirmations a BSV transaction has.
+type bsvConfirmationReader interface {
+	CoinbaseStatus(ctx context.Context, txid string) (bool, uint32, error)
+}
+
+// bsvStatusChecker reports BSV transaction status from its confirmations,
+// pending until it has target of them.
+type bsvStatusChecker struct {
+	client bsvConfirmationReader
+	target uint64
+}
+
+// TxStatus implements txjournal.StatusChecker. WhatsOnChain does not return
+// the block height here, so confirmed entries carry no block number.
+func (c *bsvStatusChecker) TxStatus(ctx context.Context, hash string) (string, uint64, error) {
+	_, confirmations, err := c.client.CoinbaseStatus(ctx, hash)
+	if err != nil {
+		return "", 0, err
+	}
+	if confirmations == 0 || uint64(confirmations) < c.target {
+		return txjournal.StatusPending, 0, nil
+	}
+	return txjournal.StatusConfirmed, 0, nil
+}
+
+// loadTxJournal opens the wallet's transaction journal.
+func loadTxJournal(cmdCtx *CommandContext, walletName string) *txjournal.Journal {
+	return txjournal.New(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", walletName))
+}
+
+// reconcileTxJournal marks pending journal entries that have since reached
+// the chain's confirmation target or reverted, so they stop counting as
+// pending outgoing. Only chains with pending entries are queried. Failures
+// are logged and leave entries pending.
+func reconcileTxJournal(ctx context.Context, cmdCtx *CommandContext, journal *txjournal.Journal, bsvNetwork string) {
+	entries, err := journal.Entries()
+	if err != nil {
+		if cmdCtx.Log != nil {
+			cmdCtx.Log.Error("failed to load transaction journal: %v", err)
+		}
+		return
+	}
+
+	pending := make(map[chain.ID]bool)
+	for _, e := range entries {
+		if e.Pending() {
+			pending[e.Chain] = true
+		}
+	}
+
+	targets := cmdCtx.Cfg.GetConfirmations()
+	checkers := make(map[chain.ID]txjournal.StatusChecker)
+	if pending[chain.ETH] {
+		if client, clientErr := eth.NewClient(cmdCtx.Cfg.GetETHRPC(), nil); clientErr == nil {
+			defer client.Close()
+			checkers[chain.ETH] = &ethStatusChecker{client: client, target: targets.Target(string(chain.ETH))}
+		}
+	}
+	if pending[chain.BSV] {
+		client := bsv.NewClient(ctx, &bsv.ClientOptions{
+			APIKey:  cmdCtx.Cfg.GetBSVAPIKey(),
+			Network: bsvClientNetwork(bsvNetwork),
+		})
+		checkers[chain.BSV] = &bsvStatusChecker{client: client, target: targets.Target(string(chain.BSV))}
+	}
+	if len(checkers) == 0 {
+		return
+	}
+
+	if _, err := journal.Reconcile(ctx, checkers); err != nil && cmdCtx.Log != nil {
+		cmdCtx.Log.Error("failed to reconcile transaction journal: %v", err)
+	}
+}
+
+// annotatePendingBalances fills in what pending journal entries will take
+// from each balance once they confirm.
+func annotatePendingBalances(balances []BalanceResult, journal *txjournal.Journal) {
+	if journal == nil {
+		return
+	}
+	entries, err := journal.Entries()
+	if err != nil || len(entries) == 0 {
+		return
+	}
+
+	for i := range balances {
+		bal := &balances[i]
+		reserved, count := txjournal.Reserved(entries, chain.ID(bal.Chain), bal.Address, bal.Token)
+		if count == 0 {
+			continue
+		}
+		bal.PendingOutgoing = chain.FormatDecimalAmount(reserved, bal.Decimals)
+		bal.PendingTxs = count
+	}
+}
+
+// outputPendingBalances lists balances with sends that have not confirmed yet.
+func outputPendingBalances(w io.Writer, balances []BalanceResult) {
+	header := false
+	for _, bal := range balances {
+		if bal.PendingOutgoing == "" {
+			continue
+		}
+		if !header {
+			outln(w)
+			outln(w, "Pending outgoing (sent, not yet confirmed; the balance above may still include it):")
+			header = true
+		}
+		out(w, "  %-4s %-42s %s %s (%d tx)\n", strings.ToUpper(bal.Chain), truncateAddress(bal.Address), bal.PendingOutgoing, bal.Symbol, bal.PendingTxs)
+	}
+}
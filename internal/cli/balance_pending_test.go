@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	"github.com/mrz1836/sigil/internal/txjournal"
+)
+
+// fakeConfirmationReader returns a fixed confirmation count.
+type fakeConfirmationReader struct {
+	confirmations uint32
+	err           error
+}
+
+func (f *fakeConfirmationReader) CoinbaseStatus(_ context.Context, _ string) (bool, uint32, error) {
+	return false, f.confirmations, f.err
+}
+
+func TestEthStatusChecker(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		reader     *fakeReceiptReader
+		target     uint64
+		wantStatus string
+		wantErr    bool
+	}{
+		{name: "pending", reader: &fakeReceiptReader{err: rpc.ErrReceiptNotFound}, wantStatus: txjournal.StatusPending},
+		{name: "confirmed", reader: &fakeReceiptReader{receipt: &rpc.Receipt{Success: true, BlockNumber: 7}}, wantStatus: txjournal.StatusConfirmed},
+		{name: "below target", reader: &fakeReceiptReader{receipt: &rpc.Receipt{Success: true, BlockNumber: 7}, tip: 9}, target: 6, wantStatus: txjournal.StatusPending},
+		{name: "at target", reader: &fakeReceiptReader{receipt: &rpc.Receipt{Success: true, BlockNumber: 7}, tip: 12}, target: 6, wantStatus: txjournal.StatusConfirmed},
+		{name: "reverted", reader: &fakeReceiptReader{receipt: &rpc.Receipt{BlockNumber: 7}}, wantStatus: txjournal.StatusReverted},
+		{name: "lookup failure", reader: &fakeReceiptReader{err: errors.New("offline")}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			status, _, err := (&ethStatusChecker{client: tc.reader, target: tc.target}).TxStatus(context.Background(), testTxHash)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, status)
+		})
+	}
+}
+
+func TestBSVStatusChecker(t *testing.T) {
+	t.Parallel()
+
+	status, _, err := (&bsvStatusChecker{client: &fakeConfirmationReader{}}).TxStatus(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, txjournal.StatusPending, status)
+
+	status, _, err = (&bsvStatusChecker{client: &fakeConfirmationReader{confirmations: 2}}).TxStatus(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, txjournal.StatusConfirmed, status)
+
+	status, _, err = (&bsvStatusChecker{client: &fakeConfirmationReader{confirmations: 2}, target: 6}).TxStatus(context.Background(), "abc")
+	require.NoError(t, err)
+	assert.Equal(t, txjournal.StatusPending, status)
+
+	_, _, err = (&bsvStatusChecker{client: &fakeConfirmationReader{err: errors.New("offline")}}).TxStatus(context.Background(), "abc")
+	require.Error(t, err)
+}
+
+func TestAnnotatePendingBalances(t *testing.T) {
+	t.Parallel()
+
+	const from = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	journal := txjournal.New(t.TempDir())
+	require.NoError(t, journal.Record(txjournal.Entry{
+		Hash: "0x01", Chain: chain.ETH, From: from, Amount: "500000000000000000", Fee: "21000000000000",
+	}))
+	require.NoError(t, journal.Record(txjournal.Entry{
+		Hash: "0x02", Chain: chain.ETH, From: from, Amount: "1", Status: txjournal.StatusConfirmed,
+	}))
+
+	balances := []BalanceResult{
+		{Chain: "eth", Address: from, Balance: "1.0", Symbol: "ETH", Decimals: 18},
+		{Chain: "eth", Address: from, Balance: "10.0", Symbol: "USDC", Token: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", Decimals: 6},
+	}
+	annotatePendingBalances(balances, journal)
+
+	assert.Equal(t, "0.500021", balances[0].PendingOutgoing)
+	assert.Equal(t, 1, balances[0].PendingTxs)
+	assert.Empty(t, balances[1].PendingOutgoing)
+
+	var buf bytes.Buffer
+	outputPendingBalances(&buf, balances)
+	assert.Contains(t, buf.String(), "Pending outgoing")
+	assert.Contains(t, buf.String(), "0.500021 ETH (1 tx)")
+	assert.NotContains(t, buf.String(), "USDC")
+
+	buf.Reset()
+	outputPendingBalances(&buf, balances[1:])
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"io"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/service/address"
+	"github.com/mrz1836/sigil/internal/utxostore"
+)
+
+// TagTotal is the balance of every address carrying a tag or a tag under it,
+// for one chain and asset.
+type TagTotal struct {
+	Tag       string `json:"tag"`
+	Chain     string `json:"chain"`
+	Symbol    string `json:"symbol"`
+	Token     string `json:"token,omitempty"`
+	Balance   string `json:"balance"`
+	Addresses int    `json:"addresses"`
+}
+
+// applyBalanceTags annotates the response's balances with their address
+// tags, keeps those matching every tag filter, and totals them by tag.
+func applyBalanceTags(response *BalanceShowResponse, store *utxostore.Store, filters []string) {
+	annotateBalanceTags(response.Balances, store)
+	response.Balances = filterBalancesByTags(response.Balances, filters)
+	response.TagTotals = rollupBalanceTags(response.Balances)
+}
+
+// annotateBalanceTags fills in each balance's address tags from the wallet's
+// UTXO store. ETH addresses are matched regardless of checksum case.
+func annotateBalanceTags(balances []BalanceResult, store *utxostore.Store) {
+	if store == nil {
+		return
+	}
+	tags := make(map[string][]string)
+	for _, chainID := range []chain.ID{chain.BSV, chain.ETH} {
+		for _, meta := range store.GetAddresses(chainID) {
+			if len(meta.Tags) > 0 {
+				tags[balanceTagKey(chainID, meta.Address)] = meta.Tags
+			}
+		}
+	}
+	for i := range balances {
+		bal := &balances[i]
+		bal.Tags = slices.Clone(tags[balanceTagKey(chain.ID(bal.Chain), bal.Address)])
+	}
+}
+
+// balanceTagKey is the lookup key for an address's tags.
+func balanceTagKey(chainID chain.ID, addr string) string {
+	if chainID == chain.ETH {
+		addr = strings.ToLower(addr)
+	}
+	return string(chainID) + ":" + addr
+}
+
+// filterBalancesByTags keeps balances whose address matches every tag filter.
+func filterBalancesByTags(balances []BalanceResult, filters []string) []BalanceResult {
+	if len(filters) == 0 {
+		return balances
+	}
+	filtered := make([]BalanceResult, 0, len(balances))
+	for _, bal := range balances {
+		if address.HasTags(bal.Tags, filters) {
+			filtered = append(filtered, bal)
+		}
+	}
+	return filtered
+}
+
+// rollupBalanceTags totals balances by tag. Each address counts toward its
+// tags and every namespace above them, once per namespace, so an address
+// tagged customer:acme:eu and customer:acme:us adds to customer once.
+// Balances that cannot be parsed are left out.
+func rollupBalanceTags(balances []BalanceResult) []TagTotal {
+	type totalKey struct {
+		tag, chain, token string
+	}
+	totals := make(map[totalKey]*TagTotal)
+	sums := make(map[totalKey]*big.Int)
+
+	for _, bal := range balances {
+		if len(bal.Tags) == 0 {
+			continue
+		}
+		amount, err := parseDecimalAmount(bal.Balance, bal.Decimals)
+		if err != nil {
+			continue
+		}
+
+		seen := make(map[string]bool)
+		for _, tag := range bal.Tags {
+			for _, prefix := range utxostore.TagPrefixes(tag) {
+				if seen[prefix] {
+					continue
+				}
+				seen[prefix] = true
+
+				key := totalKey{tag: prefix, chain: bal.Chain, token: bal.Token}
+				total, ok := totals[key]
+				if !ok {
+					total = &TagTotal{Tag: prefix, Chain: bal.Chain, Symbol: bal.Symbol, Token: bal.Token}
+					totals[key] = total
+					sums[key] = new(big.Int)
+				}
+				sums[key].Add(sums[key], amount)
+				total.Addresses++
+				total.Balance = chain.FormatDecimalAmount(sums[key], bal.Decimals)
+			}
+		}
+	}
+
+	result := make([]TagTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, *total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Tag != result[j].Tag {
+			return result[i].Tag < result[j].Tag
+		}
+		if result[i].Chain != result[j].Chain {
+			return result[i].Chain < result[j].Chain
+		}
+		return result[i].Token < result[j].Token
+	})
+	return result
+}
+
+// outputTagTotals lists balance totals by tag, indenting nested namespaces.
+func outputTagTotals(w io.Writer, totals []TagTotal) {
+	if len(totals) == 0 {
+		return
+	}
+	outln(w)
+	outln(w, "By tag:")
+	for _, total := range totals {
+		depth := strings.Count(total.Tag, utxostore.TagSeparator)
+		name := strings.Repeat("  ", depth) + total.Tag
+		out(w, "  %-30s %-4s %s %s (%d address(es))\n", name, strings.ToUpper(total.Chain), total.Balance, total.Symbol, total.Addresses)
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/utxostore"
+)
+
+func TestApplyBalanceTags(t *testing.T) {
+	t.Parallel()
+
+	store := utxostore.New(t.TempDir())
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1A", Tags: []string{"customer:acme:eu", "customer:acme:us"}})
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1B", Tags: []string{"customer:globex"}})
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.ETH, Address: "0xAbC", Tags: []string{"customer:acme"}})
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1C"})
+
+	newResponse := func() BalanceShowResponse {
+		return BalanceShowResponse{Balances: []BalanceResult{
+			{Chain: "bsv", Address: "1A", Balance: "0.5", Symbol: "BSV", Decimals: 8},
+			{Chain: "bsv", Address: "1B", Balance: "0.25", Symbol: "BSV", Decimals: 8},
+			{Chain: "bsv", Address: "1C", Balance: "1", Symbol: "BSV", Decimals: 8},
+			{Chain: "eth", Address: "0xabc", Balance: "2", Symbol: "ETH", Decimals: 18},
+			{Chain: "eth", Address: "0xabc", Balance: "10", Symbol: "USDC", Token: "0xa0b8", Decimals: 6},
+		}}
+	}
+
+	t.Run("rollups", func(t *testing.T) {
+		t.Parallel()
+
+		response := newResponse()
+		applyBalanceTags(&response, store, nil)
+		require.Len(t, response.Balances, 5)
+		assert.Equal(t, []string{"customer:acme:eu", "customer:acme:us"}, response.Balances[0].Tags)
+		assert.Equal(t, []string{"customer:acme"}, response.Balances[3].Tags, "eth address matched case-insensitively")
+		assert.Empty(t, response.Balances[2].Tags)
+
+		totals := make(map[string]string)
+		for _, total := range response.TagTotals {
+			totals[total.Tag+"/"+total.Chain+"/"+total.Symbol] = total.Balance
+		}
+		assert.Equal(t, map[string]string{
+			"customer/bsv/BSV":         "0.75",
+			"customer/eth/ETH":         "2.0",
+			"customer/eth/USDC":        "10.0",
+			"customer:acme/bsv/BSV":    "0.5",
+			"customer:acme/eth/ETH":    "2.0",
+			"customer:acme/eth/USDC":   "10.0",
+			"customer:acme:eu/bsv/BSV": "0.5",
+			"customer:acme:us/bsv/BSV": "0.5",
+			"customer:globex/bsv/BSV":  "0.25",
+		}, totals)
+		assert.Equal(t, "customer", response.TagTotals[0].Tag)
+		assert.Equal(t, 2, response.TagTotals[0].Addresses, "an address counts once per namespace")
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		t.Parallel()
+
+		response := newResponse()
+		applyBalanceTags(&response, store, []string{"customer:acme"})
+		require.Len(t, response.Balances, 3)
+		for _, bal := range response.Balances {
+			assert.NotEqual(t, "1B", bal.Address)
+		}
+	})
+
+	t.Run("no store", func(t *testing.T) {
+		t.Parallel()
+
+		response := newResponse()
+		applyBalanceTags(&response, nil, nil)
+		assert.Len(t, response.Balances, 5)
+		assert.Empty(t, response.TagTotals)
+	})
+}
+
+func TestOutputTagTotals(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	outputTagTotals(&buf, []TagTotal{
+		{Tag: "customer", Chain: "bsv", Symbol: "BSV", Balance: "0.75", Addresses: 2},
+		{Tag: "customer:acme", Chain: "bsv", Symbol: "BSV", Balance: "0.5", Addresses: 1},
+	})
+	assert.Contains(t, buf.String(), "By tag:")
+	assert.Contains(t, buf.String(), "  customer ")
+	assert.Contains(t, buf.String(), "    customer:acme")
+	assert.Contains(t, buf.String(), "0.75 BSV (2 address(es))")
+
+	buf.Reset()
+	outputTagTotals(&buf, nil)
+	assert.Empty(t, buf.String())
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/output"
 	"github.com/mrz1836/sigil/internal/service/balance"
+	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
 )
 
@@ -30,6 +31,8 @@ var (
 // mockConfigProvider implements ConfigProvider for testing.
 type mockConfigProvider struct {
 	home               string
+	defaultWallet      string
+	addressGap         int
 	ethRPC             string
 	fallbackRPCs       []string
 	ethProvider        string
@@ -38,17 +41,37 @@ type mockConfigProvider struct {
 	bsvNetwork         string
 	bsvBroadcast       string
 	bsvFeeStrategy     string
+	bsvNode            config.BSVNodeConfig
+	bsvUTXOSync        string
+	bsvUTXOArchiveDays int
 	bsvMinMiners       int
+	btcAPI             string
+	btcEnabled         bool
+	bchAPI             string
+	bchEnabled         bool
 	logLevel           string
 	logFile            string
 	outputFormat       string
 	verbose            bool
 	security           config.SecurityConfig
+	hooks              config.HooksConfig
+	quotas             config.QuotaConfig
+	price              config.PriceConfig
+	outbox             config.OutboxConfig
+	confirmations      config.ConfirmationsConfig
+	concurrency        config.ConcurrencyConfig
+	ethTokens          []config.TokenConfig
+	balanceSources     map[string][]string
+	walletTemplates    map[string]config.WalletTemplateConfig
 }
 
 func (m *mockConfigProvider) GetHome() string              { return m.home }
+func (m *mockConfigProvider) GetDefaultWallet() string     { return m.defaultWallet }
+func (m *mockConfigProvider) GetAddressGap() int           { return m.addressGap }
 func (m *mockConfigProvider) GetETHRPC() string            { return m.ethRPC }
 func (m *mockConfigProvider) GetETHFallbackRPCs() []string { return m.fallbackRPCs }
+func (m *mockConfigProvider) GetETHChainID() int           { return 1 }
+func (m *mockConfigProvider) UseNetwork(string)            {}
 func (m *mockConfigProvider) GetBSVAPIKey() string         { return m.bsvAPIKey }
 func (m *mockConfigProvider) GetBSVNetwork() string {
 	if m.bsvNetwork == "" {
@@ -57,11 +80,30 @@ func (m *mockConfigProvider) GetBSVNetwork() string {
 	return m.bsvNetwork
 }
 func (m *mockConfigProvider) GetBSVBroadcast() string            { return m.bsvBroadcast }
+func (m *mockConfigProvider) GetBTCAPI() string                  { return m.btcAPI }
+func (m *mockConfigProvider) IsBTCEnabled() bool                 { return m.btcEnabled }
+func (m *mockConfigProvider) GetBCHAPI() string                  { return m.bchAPI }
+func (m *mockConfigProvider) IsBCHEnabled() bool                 { return m.bchEnabled }
 func (m *mockConfigProvider) GetLoggingLevel() string            { return m.logLevel }
 func (m *mockConfigProvider) GetLoggingFile() string             { return m.logFile }
 func (m *mockConfigProvider) GetOutputFormat() string            { return m.outputFormat }
 func (m *mockConfigProvider) IsVerbose() bool                    { return m.verbose }
 func (m *mockConfigProvider) GetSecurity() config.SecurityConfig { return m.security }
+func (m *mockConfigProvider) GetETHTokens() []config.TokenConfig { return m.ethTokens }
+func (m *mockConfigProvider) GetETHGasMarginPercent() int        { return 0 }
+func (m *mockConfigProvider) GetCache() config.CacheConfig       { return config.CacheConfig{} }
+func (m *mockConfigProvider) GetHooks() config.HooksConfig       { return m.hooks }
+func (m *mockConfigProvider) GetQuotas() config.QuotaConfig      { return m.quotas }
+func (m *mockConfigProvider) GetPrice() config.PriceConfig       { return m.price }
+func (m *mockConfigProvider) GetOutbox() config.OutboxConfig     { return m.outbox }
+func (m *mockConfigProvider) GetConfirmations() config.ConfirmationsConfig {
+	return m.confirmations
+}
+func (m *mockConfigProvider) GetConcurrency() config.ConcurrencyConfig { return m.concurrency }
+
+func (m *mockConfigProvider) GetWalletTemplates() map[string]config.WalletTemplateConfig {
+	return m.walletTemplates
+}
 
 func (m *mockConfigProvider) GetETHProvider() string {
 	if m.ethProvider == "" {
@@ -74,6 +116,24 @@ func (m *mockConfigProvider) GetETHEtherscanAPIKey() string {
 	return m.ethEtherscanAPIKey
 }
 
+func (m *mockConfigProvider) GetBalanceSources(chainName string) []string {
+	if sources, ok := m.balanceSources[chainName]; ok {
+		return sources
+	}
+	return (&config.Config{}).GetBalanceSources(chainName)
+}
+
+func (m *mockConfigProvider) GetBSVNode() config.BSVNodeConfig { return m.bsvNode }
+
+func (m *mockConfigProvider) GetBSVUTXOSync() string {
+	if m.bsvUTXOSync == "" {
+		return "api"
+	}
+	return m.bsvUTXOSync
+}
+
+func (m *mockConfigProvider) GetBSVUTXOArchiveDays() int { return m.bsvUTXOArchiveDays }
+
 func (m *mockConfigProvider) GetBSVFeeStrategy() string {
 	if m.bsvFeeStrategy == "" {
 		return "normal"
@@ -81,6 +141,10 @@ func (m *mockConfigProvider) GetBSVFeeStrategy() string {
 	return m.bsvFeeStrategy
 }
 
+func (m *mockConfigProvider) GetBSVCoinSelection() string {
+	return "largest-first"
+}
+
 func (m *mockConfigProvider) GetBSVMinMiners() int {
 	if m.bsvMinMiners == 0 {
 		return 3
@@ -158,6 +222,42 @@ func TestOutputBalanceText(t *testing.T) {
 	assert.Contains(t, output, "0.1")
 }
 
+func TestAnnotateImmatureBalances(t *testing.T) {
+	t.Parallel()
+
+	store := utxostore.New(t.TempDir())
+	store.AddUTXO(&utxostore.StoredUTXO{
+		ChainID:       chain.BSV,
+		TxID:          "coinbase11111111111111111111111111111111111111111111111111111111111",
+		Amount:        625000000,
+		Address:       "1MinerAddress",
+		Confirmations: 12,
+		Coinbase:      true,
+	})
+
+	balances := []BalanceResult{
+		{Chain: "bsv", Address: "1MinerAddress", Balance: "6.25", Symbol: "BSV"},
+		{Chain: "bsv", Address: "1OtherAddress", Balance: "0.1", Symbol: "BSV"},
+		{Chain: "eth", Address: "1MinerAddress", Balance: "1", Symbol: "ETH"},
+	}
+	annotateImmatureBalances(balances, store)
+	annotateImmatureBalances(balances, nil)
+
+	assert.Equal(t, "6.25", balances[0].Immature)
+	assert.Empty(t, balances[1].Immature)
+	assert.Empty(t, balances[2].Immature)
+
+	var buf bytes.Buffer
+	outputImmatureBalances(&buf, balances)
+	assert.Contains(t, buf.String(), "Immature (coinbase outputs, spendable after 100 confirmations):")
+	assert.Contains(t, buf.String(), "1MinerAddress")
+	assert.NotContains(t, buf.String(), "1OtherAddress")
+
+	buf.Reset()
+	outputImmatureBalances(&buf, balances[1:])
+	assert.Empty(t, buf.String())
+}
+
 func TestOutputBalanceJSON(t *testing.T) {
 	response := BalanceShowResponse{
 		Wallet:    "test",
@@ -798,3 +898,24 @@ func TestSaveBalanceCache(t *testing.T) {
 func (m *mockLogger) Close() error {
 	return nil
 }
+
+func TestOutputBalanceSources(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	outputBalanceSources(&buf, []BalanceResult{
+		{Chain: "eth", Address: "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", Symbol: "ETH", Source: "rpc"},
+		{Chain: "bsv", Address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", Symbol: "BSV", Source: "cache"},
+		{Chain: "bsv", Address: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", Symbol: "BSV"},
+	})
+
+	output := buf.String()
+	assert.Contains(t, output, "Sources:")
+	assert.Contains(t, output, "rpc")
+	assert.Contains(t, output, "cache")
+	assert.Contains(t, output, "unknown")
+
+	buf.Reset()
+	outputBalanceSources(&buf, nil)
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,182 @@
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ErrBuildInfoUnavailable is returned when the binary carries no module build information.
+var ErrBuildInfoUnavailable = errors.New("build information not embedded in binary")
+
+// buildInfoHashVersion prefixes the reproducibility hash input so the format
+// can change without colliding with earlier hashes.
+const buildInfoHashVersion = "sigil-build-info/v1"
+
+// Build information sources, replaceable in tests.
+//
+//nolint:gochecknoglobals // Required for test injection
+var (
+	readBuildInfoFn  = debug.ReadBuildInfo
+	executablePathFn = os.Executable
+)
+
+// buildInfoCmd prints build provenance for attestation checks.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var buildInfoCmd = &cobra.Command{
+	Use:     "build-info",
+	GroupID: "config",
+	Short:   "Print build provenance as JSON",
+	Long: `Print the version, commit, build date, Go version, module checksums, and
+build settings embedded in this binary, as JSON.
+
+reproducibility_hash is a SHA-256 over everything that determines the build
+output except the build date: version, commit, Go version, every module path,
+version and checksum, and the build settings other than -ldflags (which
+carries the date). Two builds of the same commit with the same toolchain and
+settings have the same hash, so compare it with the value from CI.
+
+binary_sha256 is the SHA-256 of the running executable file.`,
+	Example: `  sigil build-info
+  sigil build-info | jq -r .reproducibility_hash`,
+	RunE: runBuildInfo,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	rootCmd.AddCommand(buildInfoCmd)
+}
+
+// buildInfoModule is one module compiled into the binary.
+type buildInfoModule struct {
+	Path    string           `json:"path"`
+	Version string           `json:"version"`
+	Sum     string           `json:"sum,omitempty"`
+	Replace *buildInfoModule `json:"replace,omitempty"`
+}
+
+// buildInfoReport is the build-info JSON output.
+type buildInfoReport struct {
+	Version             string            `json:"version"`
+	Commit              string            `json:"commit"`
+	Date                string            `json:"date"`
+	GoVersion           string            `json:"go_version"`
+	Path                string            `json:"path"`
+	Main                buildInfoModule   `json:"main"`
+	Deps                []buildInfoModule `json:"deps"`
+	Settings            map[string]string `json:"settings"`
+	ReproducibilityHash string            `json:"reproducibility_hash"`
+	BinarySHA256        string            `json:"binary_sha256,omitempty"`
+}
+
+func runBuildInfo(cmd *cobra.Command, _ []string) error {
+	bi, ok := readBuildInfoFn()
+	if !ok {
+		return ErrBuildInfoUnavailable
+	}
+
+	report := newBuildInfoReport(buildInfo, bi)
+	if sum, err := executableSHA256(); err == nil {
+		report.BinarySHA256 = sum
+	}
+
+	return writeJSON(cmd.OutOrStdout(), report)
+}
+
+// newBuildInfoReport combines the ldflags build info with the module
+// information embedded by the Go toolchain.
+func newBuildInfoReport(info BuildInfo, bi *debug.BuildInfo) *buildInfoReport {
+	version, commit, date := resolvedBuildInfo(info)
+	report := &buildInfoReport{
+		Version:   version,
+		Commit:    commit,
+		Date:      date,
+		GoVersion: bi.GoVersion,
+		Path:      bi.Path,
+		Main:      newBuildInfoModule(&bi.Main),
+		Deps:      make([]buildInfoModule, 0, len(bi.Deps)),
+		Settings:  make(map[string]string, len(bi.Settings)),
+	}
+	for _, dep := range bi.Deps {
+		report.Deps = append(report.Deps, newBuildInfoModule(dep))
+	}
+	sort.Slice(report.Deps, func(i, j int) bool { return report.Deps[i].Path < report.Deps[j].Path })
+	for _, s := range bi.Settings {
+		report.Settings[s.Key] = s.Value
+	}
+
+	report.ReproducibilityHash = reproducibilityHash(report)
+	return report
+}
+
+func newBuildInfoModule(m *debug.Module) buildInfoModule {
+	mod := buildInfoModule{Path: m.Path, Version: m.Version, Sum: m.Sum}
+	if m.Replace != nil {
+		replace := newBuildInfoModule(m.Replace)
+		mod.Replace = &replace
+	}
+	return mod
+}
+
+// reproducibilityHash hashes a canonical, line-based rendering of the build
+// inputs. The build date and -ldflags are left out because they differ
+// between otherwise identical rebuilds.
+func reproducibilityHash(r *buildInfoReport) string {
+	var b strings.Builder
+	b.WriteString(buildInfoHashVersion + "\n")
+	fmt.Fprintf(&b, "version %s\ncommit %s\ngo %s\npath %s\n", r.Version, r.Commit, r.GoVersion, r.Path)
+	writeModuleLine(&b, "main", r.Main)
+	for _, dep := range r.Deps {
+		writeModuleLine(&b, "dep", dep)
+	}
+
+	keys := make([]string, 0, len(r.Settings))
+	for k := range r.Settings {
+		if k != "-ldflags" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fmt.Fprintf(&b, "setting %s=%s\n", k, r.Settings[k])
+	}
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+func writeModuleLine(b *strings.Builder, kind string, m buildInfoModule) {
+	fmt.Fprintf(b, "%s %s %s %s", kind, m.Path, m.Version, m.Sum)
+	if m.Replace != nil {
+		fmt.Fprintf(b, " => %s %s %s", m.Replace.Path, m.Replace.Version, m.Replace.Sum)
+	}
+	b.WriteString("\n")
+}
+
+// executableSHA256 hashes the running binary.
+func executableSHA256() (string, error) {
+	path, err := executablePathFn()
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Open(path) //nolint:gosec // G304: path is the running executable
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
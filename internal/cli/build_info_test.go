@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testDebugBuildInfo() *debug.BuildInfo {
+	return &debug.BuildInfo{
+		GoVersion: "go1.25.6",
+		Path:      "github.com/mrz1836/sigil/cmd/sigil",
+		Main:      debug.Module{Path: "github.com/mrz1836/sigil", Version: "v1.2.3", Sum: "h1:main"},
+		Deps: []*debug.Module{
+			{Path: "golang.org/x/term", Version: "v0.1.0", Sum: "h1:term"},
+			{Path: "github.com/spf13/cobra", Version: "v1.8.0", Sum: "h1:cobra"},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "-trimpath", Value: "true"},
+			{Key: "-ldflags", Value: "-X main.buildDate=2026-01-01"},
+			{Key: "vcs.revision", Value: "abc123"},
+		},
+	}
+}
+
+func TestNewBuildInfoReport(t *testing.T) {
+	t.Parallel()
+
+	report := newBuildInfoReport(BuildInfo{Version: "1.2.3", Commit: "abc123", Date: "2026-01-01"}, testDebugBuildInfo())
+
+	assert.Equal(t, "1.2.3", report.Version)
+	assert.Equal(t, "go1.25.6", report.GoVersion)
+	assert.Equal(t, "h1:main", report.Main.Sum)
+	require.Len(t, report.Deps, 2)
+	assert.Equal(t, "github.com/spf13/cobra", report.Deps[0].Path, "deps are sorted by path")
+	assert.Equal(t, "true", report.Settings["-trimpath"])
+	assert.Len(t, report.ReproducibilityHash, 64)
+}
+
+func TestReproducibilityHash(t *testing.T) {
+	t.Parallel()
+
+	info := BuildInfo{Version: "1.2.3", Commit: "abc123", Date: "2026-01-01"}
+	base := newBuildInfoReport(info, testDebugBuildInfo()).ReproducibilityHash
+
+	t.Run("ignores build date and ldflags", func(t *testing.T) {
+		t.Parallel()
+		bi := testDebugBuildInfo()
+		bi.Settings[1].Value = "-X main.buildDate=2026-02-02"
+		other := newBuildInfoReport(BuildInfo{Version: "1.2.3", Commit: "abc123", Date: "2026-02-02"}, bi)
+		assert.Equal(t, base, other.ReproducibilityHash)
+	})
+
+	t.Run("ignores dependency order", func(t *testing.T) {
+		t.Parallel()
+		bi := testDebugBuildInfo()
+		bi.Deps[0], bi.Deps[1] = bi.Deps[1], bi.Deps[0]
+		assert.Equal(t, base, newBuildInfoReport(info, bi).ReproducibilityHash)
+	})
+
+	t.Run("changes with a dependency checksum", func(t *testing.T) {
+		t.Parallel()
+		bi := testDebugBuildInfo()
+		bi.Deps[0].Sum = "h1:tampered"
+		assert.NotEqual(t, base, newBuildInfoReport(info, bi).ReproducibilityHash)
+	})
+
+	t.Run("changes with a replace directive", func(t *testing.T) {
+		t.Parallel()
+		bi := testDebugBuildInfo()
+		bi.Deps[0].Replace = &debug.Module{Path: "example.com/fork/term", Version: "v0.1.1"}
+		assert.NotEqual(t, base, newBuildInfoReport(info, bi).ReproducibilityHash)
+	})
+
+	t.Run("changes with the commit", func(t *testing.T) {
+		t.Parallel()
+		assert.NotEqual(t, base, newBuildInfoReport(BuildInfo{Version: "1.2.3", Commit: "def456"}, testDebugBuildInfo()).ReproducibilityHash)
+	})
+}
+
+func TestRunBuildInfo(t *testing.T) {
+	origRead, origExe := readBuildInfoFn, executablePathFn
+	t.Cleanup(func() { readBuildInfoFn, executablePathFn = origRead, origExe })
+
+	exe := filepath.Join(t.TempDir(), "sigil")
+	require.NoError(t, os.WriteFile(exe, []byte("binary"), 0o600))
+	readBuildInfoFn = func() (*debug.BuildInfo, bool) { return testDebugBuildInfo(), true }
+	executablePathFn = func() (string, error) { return exe, nil }
+
+	cmd, buf := newConfigTestCmd()
+	require.NoError(t, runBuildInfo(cmd, nil))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, "go1.25.6", got["go_version"])
+	assert.Equal(t, "9a3a45d01531a20e89ac6ae10b0b0beb0492acd7216a368aa062d1a5fecaf9cd", got["binary_sha256"])
+	assert.NotEmpty(t, got["reproducibility_hash"])
+	assert.Contains(t, got, "deps")
+}
+
+func TestRunBuildInfo_Unavailable(t *testing.T) {
+	orig := readBuildInfoFn
+	t.Cleanup(func() { readBuildInfoFn = orig })
+	readBuildInfoFn = func() (*debug.BuildInfo, bool) { return nil, false }
+
+	cmd, _ := newConfigTestCmd()
+	require.ErrorIs(t, runBuildInfo(cmd, nil), ErrBuildInfoUnavailable)
+}
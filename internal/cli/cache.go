@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/output"
+)
+
+// cacheCmd is the parent command for balance cache operations.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Inspect the balance cache",
+	Long: `Inspect the balance cache in ~/.sigil/cache/balances.json.
+
+The cache is bounded by the cache section of config.yaml. Each time it is
+loaded or saved, balances not updated in cache.max_age_days days (default 30)
+are evicted, then the least recently used balances beyond cache.max_entries
+(default 10000). Setting either to 0 disables that limit.`,
+}
+
+// cacheStatsCmd reports the size of the balance cache.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show balance cache size and age",
+	Long: `Show the number of cached balances, the file size, the oldest and newest
+entries, and how many entries the configured limits would evict on the next
+load.`,
+	Example: `  sigil cache stats
+  sigil cache stats -o json`,
+	Args: cobra.NoArgs,
+	RunE: runCacheStats,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	cacheCmd.GroupID = "config"
+	rootCmd.AddCommand(cacheCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+}
+
+// cacheStatsEntry identifies a cached balance in cache stats output.
+type cacheStatsEntry struct {
+	Chain     string    `json:"chain"`
+	Address   string    `json:"address"`
+	Symbol    string    `json:"symbol"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// cacheStatsResponse is the cache stats output.
+type cacheStatsResponse struct {
+	Path       string           `json:"path"`
+	SizeBytes  int64            `json:"size_bytes"`
+	Entries    int              `json:"entries"`
+	MaxEntries int              `json:"max_entries"`
+	MaxAgeDays int              `json:"max_age_days"`
+	Evictable  int              `json:"evictable"`
+	Oldest     *cacheStatsEntry `json:"oldest,omitempty"`
+	Newest     *cacheStatsEntry `json:"newest,omitempty"`
+}
+
+func runCacheStats(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+	cfg := cc.Cfg.GetCache()
+
+	limits := cache.LimitsFromConfig(cfg)
+
+	// Load without limits so the stats describe the file as it is.
+	storage := cache.NewBalanceStorage(cc.Cfg.GetHome(), cfg).WithLimits(cache.Limits{})
+	balanceCache, err := storage.Load()
+	if err != nil {
+		return fmt.Errorf("loading balance cache: %w", err)
+	}
+
+	resp, err := buildCacheStats(storage.Path(), balanceCache, limits)
+	if err != nil {
+		return err
+	}
+	resp.MaxEntries, resp.MaxAgeDays = cfg.MaxEntries, cfg.MaxAgeDays
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, resp)
+	}
+	outputCacheStats(w, resp)
+	return nil
+}
+
+// buildCacheStats summarizes a loaded cache and counts the entries limits
+// would evict. The cache is modified; callers must not save it.
+func buildCacheStats(path string, balanceCache *cache.BalanceCache, limits cache.Limits) (*cacheStatsResponse, error) {
+	resp := &cacheStatsResponse{Path: path}
+	info, err := os.Stat(path)
+	switch {
+	case err == nil:
+		resp.SizeBytes = info.Size()
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, fmt.Errorf("reading balance cache: %w", err)
+	}
+
+	stats := balanceCache.Stats()
+	resp.Entries = stats.Entries
+	resp.Oldest = newCacheStatsEntry(stats.Oldest)
+	resp.Newest = newCacheStatsEntry(stats.Newest)
+	resp.Evictable = balanceCache.Evict(limits)
+	return resp, nil
+}
+
+func newCacheStatsEntry(entry *cache.BalanceCacheEntry) *cacheStatsEntry {
+	if entry == nil {
+		return nil
+	}
+	return &cacheStatsEntry{
+		Chain:     string(entry.Chain),
+		Address:   entry.Address,
+		Symbol:    entry.Symbol,
+		UpdatedAt: entry.UpdatedAt,
+	}
+}
+
+// outputCacheStats shows cache stats in text format.
+func outputCacheStats(w io.Writer, resp *cacheStatsResponse) {
+	out(w, "Balance cache: %s\n", resp.Path)
+	out(w, "  Entries:  %d (limit %s)\n", resp.Entries, formatCacheLimit(resp.MaxEntries, ""))
+	out(w, "  Size:     %d bytes\n", resp.SizeBytes)
+	out(w, "  Max age:  %s\n", formatCacheLimit(resp.MaxAgeDays, " days"))
+	if resp.Oldest != nil {
+		out(w, "  Oldest:   %s %s %s, updated %s\n", strings.ToUpper(resp.Oldest.Chain), resp.Oldest.Symbol,
+			resp.Oldest.Address, formatCacheAge(resp.Oldest.UpdatedAt))
+		out(w, "  Newest:   %s %s %s, updated %s\n", strings.ToUpper(resp.Newest.Chain), resp.Newest.Symbol,
+			resp.Newest.Address, formatCacheAge(resp.Newest.UpdatedAt))
+	}
+	if resp.Evictable > 0 {
+		out(w, "  Evicting: %d on the next load (over the limits)\n", resp.Evictable)
+	}
+}
+
+// formatCacheLimit renders a limit, where zero means none.
+func formatCacheLimit(n int, unit string) string {
+	if n <= 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%d%s", n, unit)
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestBuildCacheStats(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+		resp, err := buildCacheStats(filepath.Join(t.TempDir(), "balances.json"), cache.NewBalanceCache(), cache.DefaultLimits())
+		require.NoError(t, err)
+		assert.Zero(t, resp.Entries)
+		assert.Zero(t, resp.SizeBytes)
+		assert.Nil(t, resp.Oldest)
+	})
+
+	t.Run("counts entries over the limits", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "balances.json")
+		bc := cache.NewBalanceCache()
+		bc.Entries[cache.Key(chain.BSV, "1Old", "")] = cache.BalanceCacheEntry{
+			Chain: chain.BSV, Address: "1Old", Symbol: "BSV", UpdatedAt: time.Now().Add(-72 * time.Hour),
+		}
+		bc.Set(cache.BalanceCacheEntry{Chain: chain.ETH, Address: "0xNew", Symbol: "ETH"})
+		require.NoError(t, cache.NewFileStorage(path).WithLimits(cache.Limits{}).Save(bc))
+
+		resp, err := buildCacheStats(path, bc, cache.Limits{MaxAge: 48 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 2, resp.Entries)
+		assert.Positive(t, resp.SizeBytes)
+		assert.Equal(t, 1, resp.Evictable)
+		require.NotNil(t, resp.Oldest)
+		assert.Equal(t, "1Old", resp.Oldest.Address)
+		assert.Equal(t, "0xNew", resp.Newest.Address)
+
+		var buf bytes.Buffer
+		resp.MaxAgeDays = 2
+		outputCacheStats(&buf, resp)
+		assert.Contains(t, buf.String(), "Entries:  2 (limit none)")
+		assert.Contains(t, buf.String(), "Max age:  2 days")
+		assert.Contains(t, buf.String(), "BSV BSV 1Old, updated 3d ago")
+		assert.Contains(t, buf.String(), "Evicting: 1 on the next load")
+	})
+}
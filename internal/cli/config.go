@@ -1,9 +1,11 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -21,8 +23,13 @@ var configCmd = &cobra.Command{
 	Short: "Manage configuration",
 	Long: `View and modify Sigil configuration settings.
 
-Configuration is stored in ~/.sigil/config.yaml and controls network endpoints,
-output format, logging, and session behavior. Use dot notation for nested paths.`,
+Configuration is stored in ~/.sigil/config.yaml (or config.toml) and controls
+network endpoints, output format, logging, and session behavior. Use dot
+notation for nested paths.
+
+Every setting can also be given as a SIGIL_* environment variable named after
+its path, e.g. networks.eth.rpc is SIGIL_NETWORKS_ETH_RPC. Set SIGIL_CONFIG to
+a file path to load that file, or to "env" to ignore config files entirely.`,
 }
 
 // configInitCmd initializes the configuration.
@@ -60,9 +67,12 @@ var configGetCmd = &cobra.Command{
 	Short: "Get a configuration value",
 	Long: `Get a specific configuration value by its path.
 
-The path uses dot notation to navigate the configuration tree.`,
+The path uses dot notation to navigate the configuration tree. Every key
+listed by "config list" can be read. The short forms eth.rpc, bsv.api_key,
+bsv.network, output.format and security.session_ttl are also accepted.`,
 	Example: `  sigil config get networks.eth.rpc
   sigil config get output.default_format
+  sigil config get security.session_ttl
   sigil config get logging.level`,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigGet,
@@ -76,17 +86,49 @@ var configSetCmd = &cobra.Command{
 	Short: "Set a configuration value",
 	Long: `Set a specific configuration value by its path.
 
-The path uses dot notation to navigate the configuration tree.
-The configuration file will be updated immediately.`,
+The path uses dot notation to navigate the configuration tree, with the same
+short forms as "config get". The value is checked against the config schema
+before the configuration file is updated. Lists are comma-separated.`,
 	Example: `  sigil config set networks.eth.rpc https://mainnet.infura.io/v3/YOUR_KEY
   sigil config set output.default_format json
+  sigil config set security.session_ttl 30
+  sigil config set networks.eth.fallback_rpcs https://rpc.ankr.com/eth,https://1rpc.io/eth
   sigil config set logging.level debug`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
 
+// configPrintCmd prints the effective configuration.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var configPrintCmd = &cobra.Command{
+	Use:   "print",
+	Short: "Print the effective configuration",
+	Long: `Print the configuration in effect after the config file, environment
+variables, and flags are applied, as YAML, TOML, or environment variables.
+
+--format env writes one SIGIL_* variable per setting, for an env file or a
+container definition. API keys are redacted unless --show-secrets is given.`,
+	Example: `  sigil config print
+  sigil config print --format toml > ~/.sigil/config.toml
+  sigil config print --format env --show-secrets > sigil.env`,
+	RunE: runConfigPrint,
+}
+
 //nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
-var configForce bool
+var (
+	configForce bool
+	// configPrintFormat is the config print output format (yaml, toml, env).
+	configPrintFormat string
+	// configPrintShowSecrets includes API keys in config print output.
+	configPrintShowSecrets bool
+)
+
+// configPrintFormatEnv selects SIGIL_* environment variable output.
+const configPrintFormatEnv = "env"
+
+// redactedValue replaces secrets in config print output.
+const redactedValue = "<redacted>"
 
 //nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
 func init() {
@@ -96,12 +138,15 @@ func init() {
 	configCmd.AddCommand(configShowCmd)
 	configCmd.AddCommand(configGetCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configPrintCmd)
 
 	configInitCmd.Flags().BoolVar(&configForce, "force", false, "overwrite existing configuration")
+	configPrintCmd.Flags().StringVar(&configPrintFormat, "format", config.FormatYAML, "output format: yaml, toml, env")
+	configPrintCmd.Flags().BoolVar(&configPrintShowSecrets, "show-secrets", false, "include API keys instead of redacting them")
 }
 
 func runConfigInit(cmd *cobra.Command, _ []string) error {
-	configPath := config.Path(cfg.Home)
+	configPath := config.FindPath(cfg.Home)
 
 	// Check if config already exists
 	if _, err := os.Stat(configPath); err == nil && !configForce {
@@ -151,7 +196,7 @@ func runConfigShow(cmd *cobra.Command, _ []string) error {
 }
 
 func runConfigGet(cmd *cobra.Command, args []string) error {
-	path := args[0]
+	path := config.ResolveKey(args[0])
 
 	value, err := getConfigValue(cfg, path)
 	if err != nil {
@@ -168,7 +213,7 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 }
 
 func runConfigSet(cmd *cobra.Command, args []string) error {
-	path := args[0]
+	path := config.ResolveKey(args[0])
 	value := args[1]
 
 	// Validate the path exists
@@ -180,31 +225,98 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 	}
 
 	// Load current config from file
-	configPath := config.Path(cfg.Home)
+	configPath := config.FindPath(cfg.Home)
 	currentCfg, err := config.Load(configPath)
 	if err != nil {
 		// If file doesn't exist, start with defaults
 		currentCfg = config.Defaults()
 	}
 
-	// Update the value
+	// Update the value and check it against the schema
 	if err := setConfigValue(currentCfg, path, value); err != nil {
 		return fmt.Errorf("setting config value: %w", err)
 	}
+	if err := config.ValidateKey(currentCfg, path); err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidValue, err.Error())
+	}
 
 	// Save updated config
 	if err := config.Save(currentCfg, configPath); err != nil {
+		if errors.Is(err, config.ErrEnvOnly) {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("configuration comes from the environment; set %s=%s instead", config.EnvName(path), value),
+			)
+		}
 		return fmt.Errorf("saving config: %w", err)
 	}
 
 	w := cmd.OutOrStdout()
 	out(w, "Set %s = %s\n", path, value)
+	if env := config.EnvName(path); hasEnv(env) {
+		out(w, "Note: %s is set and overrides this value\n", env)
+	}
 
 	return nil
 }
 
+func runConfigPrint(cmd *cobra.Command, _ []string) error {
+	printCfg := *cfg
+	if !configPrintShowSecrets {
+		printCfg = *config.Redact(cfg, redactedValue)
+	}
+
+	w := cmd.OutOrStdout()
+	switch configPrintFormat {
+	case configPrintFormatEnv:
+		vars, err := config.EnvVars(&printCfg)
+		if err != nil {
+			return fmt.Errorf("formatting config: %w", err)
+		}
+		for _, v := range vars {
+			out(w, "%s=%s\n", v.Name, shellQuote(v.Value))
+		}
+		return nil
+	case config.FormatYAML, config.FormatTOML:
+		data, err := config.Marshal(&printCfg, configPrintFormat)
+		if err != nil {
+			return fmt.Errorf("formatting config: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	default:
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidFormat,
+			fmt.Sprintf("unknown format %q (use yaml, toml, or env)", configPrintFormat),
+		)
+	}
+}
+
+// shellQuote single-quotes a value for a POSIX shell or env file when it
+// contains anything beyond plain word characters.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_-./:@,+") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
 // getConfigValue retrieves a value from the config using dot notation.
+// Settings without a dedicated getter are read through the config schema.
 func getConfigValue(c *config.Config, path string) (string, error) {
+	path = config.ResolveKey(path)
+	value, err := getKnownConfigValue(c, path)
+	if !errors.Is(err, sigilerr.ErrUnknownConfigKey) {
+		return value, err
+	}
+	if value, schemaErr := config.GetValue(c, path); schemaErr == nil {
+		return value, nil
+	}
+	return "", err
+}
+
+// getKnownConfigValue retrieves the values that have dedicated getters.
+func getKnownConfigValue(c *config.Config, path string) (string, error) {
 	parts := strings.Split(path, ".")
 
 	switch len(parts) {
@@ -212,6 +324,8 @@ func getConfigValue(c *config.Config, path string) (string, error) {
 		switch parts[0] {
 		case "home":
 			return c.Home, nil
+		case "default_wallet":
+			return c.DefaultWallet, nil
 		default:
 			return "", sigilerr.WithDetails(
 				sigilerr.ErrUnknownConfigKey,
@@ -296,6 +410,10 @@ func getNetworkValue(c *config.Config, network, key string) (string, error) {
 			return c.Networks.BSV.APIKey, nil
 		case "network":
 			return c.GetBSVNetwork(), nil
+		case "utxo_sync":
+			return c.GetBSVUTXOSync(), nil
+		case "utxo_archive_days":
+			return strconv.Itoa(c.GetBSVUTXOArchiveDays()), nil
 		default:
 			return "", sigilerr.WithDetails(
 				sigilerr.ErrUnknownConfigKey,
@@ -310,8 +428,25 @@ func getNetworkValue(c *config.Config, network, key string) (string, error) {
 	}
 }
 
-// setConfigValue sets a value in the config using dot notation.
+// setConfigValue sets a value in the config using dot notation. Settings
+// without a dedicated setter are parsed and validated by the config schema.
 func setConfigValue(c *config.Config, path, value string) error {
+	path = config.ResolveKey(path)
+	err := setKnownConfigValue(c, path, value)
+	if !errors.Is(err, sigilerr.ErrUnknownConfigKey) {
+		return err
+	}
+	if schemaErr := config.SetValue(c, path, value); !errors.Is(schemaErr, config.ErrUnknownKey) {
+		if schemaErr != nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidValue, schemaErr.Error())
+		}
+		return nil
+	}
+	return err
+}
+
+// setKnownConfigValue sets the values that have dedicated setters.
+func setKnownConfigValue(c *config.Config, path, value string) error {
 	parts := strings.Split(path, ".")
 
 	switch len(parts) {
@@ -320,6 +455,9 @@ func setConfigValue(c *config.Config, path, value string) error {
 		case "home":
 			c.Home = value
 			return nil
+		case "default_wallet":
+			c.DefaultWallet = value
+			return nil
 		default:
 			return sigilerr.WithDetails(
 				sigilerr.ErrUnknownConfigKey,
@@ -440,6 +578,25 @@ func setNetworkValue(c *config.Config, network, key, value string) error {
 			}
 			c.Networks.BSV.Network = n
 			return nil
+		case "utxo_sync":
+			if value != "api" && value != utxoSyncFilters {
+				return sigilerr.WithDetails(
+					sigilerr.ErrInvalidValue,
+					map[string]string{"key": "networks.bsv.utxo_sync", "value": value, "valid": "api or filters"},
+				)
+			}
+			c.Networks.BSV.UTXOSync = value
+			return nil
+		case "utxo_archive_days":
+			days, err := strconv.Atoi(value)
+			if err != nil || days < 0 {
+				return sigilerr.WithDetails(
+					sigilerr.ErrInvalidValue,
+					map[string]string{"key": "networks.bsv.utxo_archive_days", "value": value, "valid": "an integer >= 0"},
+				)
+			}
+			c.Networks.BSV.UTXOArchiveDays = days
+			return nil
 		default:
 			return sigilerr.WithDetails(
 				sigilerr.ErrUnknownConfigKey,
@@ -462,6 +619,11 @@ func displayConfigText(w interface {
 	outln(w, "Configuration:")
 	outln(w)
 	out(w, "  Home: %s\n", c.Home)
+	defaultWallet := c.DefaultWallet
+	if defaultWallet == "" {
+		defaultWallet = "(not set)"
+	}
+	out(w, "  Default wallet: %s\n", defaultWallet)
 	outln(w)
 	outln(w, "  Output:")
 	out(w, "    default_format: %s\n", c.Output.DefaultFormat)
@@ -471,6 +633,7 @@ func displayConfigText(w interface {
 	outln(w, "  Logging:")
 	out(w, "    level: %s\n", c.Logging.Level)
 	out(w, "    file: %s\n", c.Logging.File)
+	out(w, "    format: %s\n", c.Logging.Format)
 	outln(w)
 	outln(w, "  Networks:")
 	outln(w, "    ETH:")
@@ -507,16 +670,18 @@ func displayConfigJSON(w interface {
 		APIKey  string `json:"api_key,omitempty"`
 	}
 	type configJSON struct {
-		Version int    `json:"version"`
-		Home    string `json:"home"`
-		Output  struct {
+		Version       int    `json:"version"`
+		Home          string `json:"home"`
+		DefaultWallet string `json:"default_wallet,omitempty"`
+		Output        struct {
 			DefaultFormat string `json:"default_format"`
 			Color         string `json:"color"`
 			Verbose       bool   `json:"verbose"`
 		} `json:"output"`
 		Logging struct {
-			Level string `json:"level"`
-			File  string `json:"file"`
+			Level  string `json:"level"`
+			File   string `json:"file"`
+			Format string `json:"format"`
 		} `json:"logging"`
 		Networks struct {
 			ETH networkJSON `json:"eth"`
@@ -534,14 +699,16 @@ func displayConfigJSON(w interface {
 	}
 
 	outCfg := configJSON{
-		Version: c.Version,
-		Home:    c.Home,
+		Version:       c.Version,
+		Home:          c.Home,
+		DefaultWallet: c.DefaultWallet,
 	}
 	outCfg.Output.DefaultFormat = c.Output.DefaultFormat
 	outCfg.Output.Color = c.Output.Color
 	outCfg.Output.Verbose = c.Output.Verbose
 	outCfg.Logging.Level = c.Logging.Level
 	outCfg.Logging.File = c.Logging.File
+	outCfg.Logging.Format = c.Logging.Format
 	outCfg.Networks.ETH = networkJSON{RPC: c.Networks.ETH.RPC}
 	outCfg.Networks.BSV = networkJSON{Network: c.GetBSVNetwork(), APIKey: maskedKey}
 
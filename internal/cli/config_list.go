@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+)
+
+// Sources of a setting reported by config list.
+const (
+	configSourceDefault  = "default"
+	configSourceFile     = "file"
+	configSourceEnv      = "env"
+	configSourceOverride = "override"
+)
+
+// configListShowSecrets includes API keys in config list output.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var configListShowSecrets bool
+
+// configListCmd lists every setting with its effective value and source.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List every setting with its effective value and source",
+	Long: `List every configuration key with the value in effect and where it comes
+from:
+
+  default   the built-in default
+  file      the config file
+  env       the key's SIGIL_* environment variable
+  override  a shorter environment variable (e.g. SIGIL_ETH_RPC) or a flag
+
+API keys are redacted unless --show-secrets is given.`,
+	Example: `  sigil config list
+  sigil config list -o json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigList,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	configCmd.AddCommand(configListCmd)
+	configListCmd.Flags().BoolVar(&configListShowSecrets, "show-secrets", false, "include API keys instead of redacting them")
+}
+
+// configSetting is one row of config list.
+type configSetting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+	Env    string `json:"env"`
+}
+
+func runConfigList(cmd *cobra.Command, _ []string) error {
+	settings, err := listConfigSettings(cfg, loadConfigFile(cfg.Home), configListShowSecrets)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if formatter.Format() == output.FormatJSON {
+		return writeJSON(w, settings)
+	}
+	displayConfigSettings(w, settings)
+	return nil
+}
+
+// loadConfigFile reads the config file alone, without environment
+// overrides. Defaults stand in when there is no file.
+func loadConfigFile(home string) *config.Config {
+	if !config.EnvOnly() {
+		if fileCfg, err := config.Load(config.FindPath(home)); err == nil {
+			return fileCfg
+		}
+	}
+	fileCfg := config.Defaults()
+	fileCfg.Home = home
+	return fileCfg
+}
+
+// listConfigSettings compares the effective config with the file and the
+// defaults to find the source of each setting.
+func listConfigSettings(effective, file *config.Config, showSecrets bool) ([]configSetting, error) {
+	defaults := config.Defaults()
+	defaults.Home = file.Home
+
+	keys := config.Keys()
+	settings := make([]configSetting, 0, len(keys))
+	for _, key := range keys {
+		value, err := config.GetValue(effective, key)
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", key, err)
+		}
+		fileValue, _ := config.GetValue(file, key)
+		defaultValue, _ := config.GetValue(defaults, key)
+
+		source := configSourceDefault
+		switch {
+		case hasEnv(config.EnvName(key)):
+			source = configSourceEnv
+		case value != fileValue:
+			source = configSourceOverride
+		case fileValue != defaultValue:
+			source = configSourceFile
+		}
+
+		if !showSecrets && value != "" && config.IsSecret(key) {
+			value = redactedValue
+		}
+		settings = append(settings, configSetting{Key: key, Value: value, Source: source, Env: config.EnvName(key)})
+	}
+	return settings, nil
+}
+
+// hasEnv reports whether an environment variable is set.
+func hasEnv(name string) bool {
+	_, ok := os.LookupEnv(name)
+	return ok
+}
+
+// displayConfigSettings prints one aligned line per setting.
+func displayConfigSettings(w io.Writer, settings []configSetting) {
+	keyWidth, valueWidth := len("KEY"), len("VALUE")
+	for _, s := range settings {
+		keyWidth = max(keyWidth, len(s.Key))
+		valueWidth = max(valueWidth, len(s.Value))
+	}
+	valueWidth = min(valueWidth, 60)
+
+	out(w, "%-*s  %-*s  %s\n", keyWidth, "KEY", valueWidth, "VALUE", "SOURCE")
+	for _, s := range settings {
+		value := s.Value
+		if len(value) > valueWidth {
+			value = value[:valueWidth-3] + "..."
+		}
+		out(w, "%-*s  %-*s  %s\n", keyWidth, s.Key, valueWidth, value, s.Source)
+	}
+}
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+)
+
+func TestListConfigSettings(t *testing.T) {
+	t.Setenv("SIGIL_FEES_BSV_MIN_MINERS", "5")
+
+	file := config.Defaults()
+	file.Home = "/test/home"
+	file.Logging.Level = "debug"
+	file.Networks.BSV.APIKey = "woc-secret"
+
+	effective := *file
+	effective.Fees.BSVMinMiners = 5
+	effective.Output.DefaultFormat = "json" // e.g. from -o json
+
+	settings, err := listConfigSettings(&effective, file, false)
+	require.NoError(t, err)
+
+	byKey := make(map[string]configSetting, len(settings))
+	for _, s := range settings {
+		byKey[s.Key] = s
+	}
+	assert.Equal(t, configSourceDefault, byKey["output.color"].Source)
+	assert.Equal(t, configSourceFile, byKey["logging.level"].Source)
+	assert.Equal(t, configSourceEnv, byKey["fees.bsv_min_miners"].Source)
+	assert.Equal(t, "5", byKey["fees.bsv_min_miners"].Value)
+	assert.Equal(t, configSourceOverride, byKey["output.default_format"].Source)
+	assert.Equal(t, redactedValue, byKey["networks.bsv.api_key"].Value)
+	assert.Equal(t, "SIGIL_NETWORKS_BSV_API_KEY", byKey["networks.bsv.api_key"].Env)
+
+	var buf bytes.Buffer
+	displayConfigSettings(&buf, settings)
+	assert.Contains(t, buf.String(), "KEY")
+	assert.Regexp(t, `logging\.level +debug +file\n`, buf.String())
+}
+
+func TestRunConfigList_JSON(t *testing.T) {
+	_, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	formatter = output.NewFormatter(output.FormatJSON, nil)
+
+	cmd, buf := newConfigTestCmd()
+	require.NoError(t, runConfigList(cmd, nil))
+
+	var settings []configSetting
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &settings))
+	assert.Len(t, settings, len(config.Keys()))
+}
+
+func TestRunConfigSet_SchemaKey(t *testing.T) {
+	tmpDir, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+
+	cmd0, _ := newConfigTestCmd()
+	require.NoError(t, runConfigInit(cmd0, nil))
+
+	cmd, buf := newConfigTestCmd()
+	require.NoError(t, runConfigSet(cmd, []string{"security.session_ttl", "45"}))
+	assert.Contains(t, buf.String(), "Set security.session_ttl_minutes = 45")
+
+	cmd, _ = newConfigTestCmd()
+	require.Error(t, runConfigSet(cmd, []string{"fees.bsv_fee_strategy", "fastest"}))
+	cmd, _ = newConfigTestCmd()
+	require.Error(t, runConfigSet(cmd, []string{"eth.rpc", "http://eth.example.com"}), "plaintext RPC is rejected")
+
+	updated, err := config.Load(config.Path(tmpDir))
+	require.NoError(t, err)
+	assert.Equal(t, 45, updated.Security.SessionTTLMinutes)
+	assert.Equal(t, "normal", updated.Fees.BSVFeeStrategy)
+}
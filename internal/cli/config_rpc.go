@@ -0,0 +1,287 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// rpcMaxBlocksBehind is how far an endpoint may trail the highest block seen
+// by the others and still count as healthy.
+const rpcMaxBlocksBehind = 5
+
+// Sources of an endpoint tested by config rpc-test.
+const (
+	rpcSourcePrimary  = "rpc"
+	rpcSourceFallback = "fallback"
+	rpcSourceProfile  = "profile"
+)
+
+// configRPCTestTimeout bounds each endpoint test.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var configRPCTestTimeout time.Duration
+
+// configRPCTestCmd pings every configured ETH RPC endpoint.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var configRPCTestCmd = &cobra.Command{
+	Use:   "rpc-test",
+	Short: "Check the health of every configured ETH RPC endpoint",
+	Long: `Ping every configured Ethereum RPC endpoint: networks.eth.rpc, the
+fallback RPCs and each named profile in networks.eth.rpc_profiles.
+
+Each endpoint is asked for its chain ID and latest block. The latency is that
+of the first request, connection setup included, as a sigil command sees it.
+An endpoint is healthy when it answers, serves networks.eth.chain_id, and is
+no more than 5 blocks behind the highest block reported by the others.
+
+The endpoint in use is marked active; select another with --rpc-profile or
+networks.eth.rpc_profile. API keys in URLs are not shown. The command exits
+with an error if no endpoint is healthy.`,
+	Example: `  sigil config rpc-test
+  sigil config rpc-test --timeout 5s
+  sigil config rpc-test -o json`,
+	Args: cobra.NoArgs,
+	RunE: runConfigRPCTest,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	configCmd.AddCommand(configRPCTestCmd)
+	configRPCTestCmd.Flags().DurationVar(&configRPCTestTimeout, "timeout", 10*time.Second, "time allowed for each endpoint")
+}
+
+// rpcEndpoint is a configured ETH RPC URL to test.
+type rpcEndpoint struct {
+	name   string
+	source string
+	url    string
+}
+
+// rpcTestResult is the outcome of testing one endpoint.
+type rpcTestResult struct {
+	Name         string `json:"name"`
+	Source       string `json:"source"`
+	Endpoint     string `json:"endpoint"`
+	Active       bool   `json:"active"`
+	Healthy      bool   `json:"healthy"`
+	LatencyMS    int64  `json:"latency_ms"`
+	ChainID      uint64 `json:"chain_id,omitempty"`
+	BlockHeight  uint64 `json:"block_height,omitempty"`
+	BlocksBehind uint64 `json:"blocks_behind,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+func runConfigRPCTest(cmd *cobra.Command, _ []string) error {
+	endpoints := configuredRPCEndpoints(cfg)
+	if len(endpoints) == 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrConfigInvalid,
+			"no ETH RPC endpoints configured; set networks.eth.rpc or networks.eth.rpc_profiles")
+	}
+
+	results := testRPCEndpoints(cmd, endpoints, configRPCTestTimeout)
+	healthy := judgeRPCResults(results, uint64(cfg.GetETHChainID()))
+
+	w := cmd.OutOrStdout()
+	if formatter.Format() == output.FormatJSON {
+		if err := writeJSON(w, results); err != nil {
+			return err
+		}
+	} else {
+		displayRPCTestResults(w, results)
+	}
+
+	if healthy == 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrNetworkError,
+			fmt.Sprintf("none of the %d ETH RPC endpoint(s) is healthy", len(results)))
+	}
+	return nil
+}
+
+// configuredRPCEndpoints lists the primary RPC, the fallbacks and the
+// profiles, in that order. Profiles are sorted by name.
+func configuredRPCEndpoints(c *config.Config) []rpcEndpoint {
+	var endpoints []rpcEndpoint
+	if rpcURL := c.GetETHRPC(); rpcURL != "" {
+		endpoints = append(endpoints, rpcEndpoint{name: rpcSourcePrimary, source: rpcSourcePrimary, url: rpcURL})
+	}
+	for i, rpcURL := range c.GetETHFallbackRPCs() {
+		endpoints = append(endpoints, rpcEndpoint{
+			name:   rpcSourceFallback + "-" + strconv.Itoa(i+1),
+			source: rpcSourceFallback,
+			url:    rpcURL,
+		})
+	}
+	for _, name := range c.RPCProfileNames() {
+		endpoints = append(endpoints, rpcEndpoint{name: name, source: rpcSourceProfile, url: c.Networks.ETH.RPCProfiles[name]})
+	}
+	return endpoints
+}
+
+// testRPCEndpoints queries every endpoint concurrently, each within timeout.
+// Results are in the order of endpoints; health is judged separately.
+func testRPCEndpoints(cmd *cobra.Command, endpoints []rpcEndpoint, timeout time.Duration) []rpcTestResult {
+	active := cfg.GetETHRPC()
+	results := make([]rpcTestResult, len(endpoints))
+	var wg sync.WaitGroup
+	for i, ep := range endpoints {
+		results[i] = rpcTestResult{
+			Name:     ep.name,
+			Source:   ep.source,
+			Endpoint: displayRPCEndpoint(ep.url),
+			Active:   ep.url == active,
+		}
+		wg.Add(1)
+		go func(r *rpcTestResult, rpcURL string) {
+			defer wg.Done()
+			ctx, cancel := contextWithTimeout(cmd, timeout)
+			defer cancel()
+			pingRPCEndpoint(ctx, r, rpcURL)
+		}(&results[i], ep.url)
+	}
+	wg.Wait()
+	return results
+}
+
+// pingRPCEndpoint fills r with the chain ID, latest block and latency of an
+// endpoint, or the error that stopped it.
+func pingRPCEndpoint(ctx context.Context, r *rpcTestResult, rpcURL string) {
+	if err := config.ValidateRPCURL(rpcURL); err != nil {
+		r.Error = err.Error()
+		return
+	}
+	client := rpc.NewClientWithOptions(rpcURL, nil)
+	defer client.Close()
+
+	start := time.Now()
+	chainID, err := client.ChainID(ctx)
+	r.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		r.Error = reqlog.ErrorText(err)
+		return
+	}
+	if !chainID.IsUint64() {
+		r.Error = fmt.Sprintf("invalid chain ID %s", chainID)
+		return
+	}
+	r.ChainID = chainID.Uint64()
+
+	height, err := client.BlockNumber(ctx)
+	if err != nil {
+		r.Error = reqlog.ErrorText(err)
+		return
+	}
+	r.BlockHeight = height
+}
+
+// judgeRPCResults marks the healthy endpoints and returns how many there
+// are. Lag is measured against the highest block among the endpoints that
+// serve the expected chain.
+func judgeRPCResults(results []rpcTestResult, chainID uint64) int {
+	var best uint64
+	for i := range results {
+		r := &results[i]
+		if r.Error == "" && r.ChainID != chainID {
+			r.Error = fmt.Sprintf("wrong chain ID %d (expected %d)", r.ChainID, chainID)
+		}
+		if r.Error == "" {
+			best = max(best, r.BlockHeight)
+		}
+	}
+
+	healthy := 0
+	for i := range results {
+		r := &results[i]
+		if r.Error != "" {
+			continue
+		}
+		r.BlocksBehind = best - r.BlockHeight
+		r.Healthy = r.BlocksBehind <= rpcMaxBlocksBehind
+		if r.Healthy {
+			healthy++
+		}
+	}
+	return healthy
+}
+
+// displayRPCEndpoint returns an RPC URL without the API keys it may carry.
+func displayRPCEndpoint(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "(invalid URL)"
+	}
+	return reqlog.Endpoint(u)
+}
+
+// displayRPCTestResults prints the results as a table.
+func displayRPCTestResults(w io.Writer, results []rpcTestResult) {
+	nameWidth, endpointWidth := len("NAME"), len("ENDPOINT")
+	for _, r := range results {
+		nameWidth = max(nameWidth, len(r.Name)+2) // Room for the active marker
+		endpointWidth = max(endpointWidth, len(r.Endpoint))
+	}
+	endpointWidth = min(endpointWidth, 50)
+
+	healthy := 0
+	out(w, "%-*s  %-*s  %-9s  %8s  %8s  %10s  %s\n",
+		nameWidth, "NAME", endpointWidth, "ENDPOINT", "STATUS", "LATENCY", "CHAIN ID", "BLOCK", "NOTE")
+	for _, r := range results {
+		name := r.Name
+		if r.Active {
+			name += " *"
+		}
+		endpoint := r.Endpoint
+		if len(endpoint) > endpointWidth {
+			endpoint = endpoint[:endpointWidth-3] + "..."
+		}
+		status, chainID, block, note := "unhealthy", "-", "-", r.Error
+		if r.Healthy {
+			status = "healthy"
+			healthy++
+		}
+		if r.Error == "" {
+			chainID = strconv.FormatUint(r.ChainID, 10)
+			block = strconv.FormatUint(r.BlockHeight, 10)
+			if r.BlocksBehind > 0 {
+				note = fmt.Sprintf("%d blocks behind", r.BlocksBehind)
+			}
+		}
+		out(w, "%-*s  %-*s  %-9s  %6dms  %8s  %10s  %s\n",
+			nameWidth, name, endpointWidth, endpoint, status, r.LatencyMS, chainID, block, note)
+	}
+	out(w, "\n%d of %d endpoint(s) healthy (* = in use)\n", healthy, len(results))
+}
+
+// applyRPCProfile selects the ETH RPC profile. --rpc-profile always wins and
+// must name a configured profile. The default profile of the config yields
+// to an RPC URL set in the environment, and a missing one is only warned
+// about, so a stale setting does not break every command.
+func applyRPCProfile(c *config.Config, flag string, w io.Writer) error {
+	if flag != "" {
+		if err := c.UseRPCProfile(flag); err != nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("--rpc-profile: %v", err))
+		}
+		return nil
+	}
+	name := c.Networks.ETH.RPCProfile
+	if name == "" || config.ETHRPCFromEnv() {
+		return nil
+	}
+	if err := c.UseRPCProfile(name); err != nil {
+		fmt.Fprintf(w, "Warning: networks.eth.rpc_profile: %v; using networks.eth.rpc\n", err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newRPCTestServer serves eth_chainId and eth_blockNumber with fixed answers.
+func newRPCTestServer(t *testing.T, chainID, block uint64) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     uint64 `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		result := fmt.Sprintf("0x%x", block)
+		if req.Method == "eth_chainId" {
+			result = fmt.Sprintf("0x%x", chainID)
+		}
+		_, _ = fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%d,"result":%q}`, req.ID, result)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestRunConfigRPCTest(t *testing.T) {
+	_, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+
+	good := newRPCTestServer(t, 1, 20_000_000)
+	lagging := newRPCTestServer(t, 1, 19_999_900)
+	sepolia := newRPCTestServer(t, 11155111, 7_000_000)
+	down := newRPCTestServer(t, 1, 0)
+	down.Close()
+
+	cfg.Networks.ETH.RPC = good.URL
+	cfg.Networks.ETH.FallbackRPCs = []string{lagging.URL}
+	cfg.Networks.ETH.RPCProfiles = map[string]string{
+		"sepolia": sepolia.URL,
+		"down":    down.URL,
+		"plain":   "http://eth.example.com",
+	}
+
+	cmd, buf := newConfigTestCmd()
+	require.NoError(t, runConfigRPCTest(cmd, nil))
+	text := buf.String()
+	assert.Contains(t, text, "rpc *")
+	assert.Contains(t, text, "100 blocks behind")
+	assert.Contains(t, text, "wrong chain ID 11155111 (expected 1)")
+	assert.Contains(t, text, "1 of 5 endpoint(s) healthy")
+
+	formatter = output.NewFormatter(output.FormatJSON, &bytes.Buffer{})
+	cmd, buf = newConfigTestCmd()
+	require.NoError(t, runConfigRPCTest(cmd, nil))
+	var results []rpcTestResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &results))
+	require.Len(t, results, 5)
+
+	byName := map[string]rpcTestResult{}
+	for _, r := range results {
+		byName[r.Name] = r
+	}
+	assert.True(t, byName["rpc"].Healthy)
+	assert.True(t, byName["rpc"].Active)
+	assert.Equal(t, uint64(20_000_000), byName["rpc"].BlockHeight)
+	assert.Equal(t, uint64(1), byName["rpc"].ChainID)
+	assert.Equal(t, rpcSourceFallback, byName["fallback-1"].Source)
+	assert.False(t, byName["fallback-1"].Healthy)
+	assert.Equal(t, uint64(100), byName["fallback-1"].BlocksBehind)
+	assert.False(t, byName["sepolia"].Healthy)
+	assert.NotEmpty(t, byName["down"].Error)
+	assert.Contains(t, byName["plain"].Error, "HTTPS")
+
+	// Nothing healthy
+	cfg.Networks.ETH.RPC = down.URL
+	cfg.Networks.ETH.FallbackRPCs = nil
+	cfg.Networks.ETH.RPCProfiles = nil
+	cmd, _ = newConfigTestCmd()
+	require.ErrorIs(t, runConfigRPCTest(cmd, nil), sigilerr.ErrNetworkError)
+
+	cfg.Networks.ETH.RPC = ""
+	cmd, _ = newConfigTestCmd()
+	require.ErrorIs(t, runConfigRPCTest(cmd, nil), sigilerr.ErrConfigInvalid)
+}
+
+func TestDisplayRPCEndpoint(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://mainnet.infura.io/v3/*", displayRPCEndpoint("https://mainnet.infura.io/v3/0123456789abcdef0123456789abcdef"))
+	assert.Equal(t, "(invalid URL)", displayRPCEndpoint("://nope"))
+}
+
+//nolint:paralleltest // Sets environment variables
+func TestApplyRPCProfile(t *testing.T) {
+	t.Setenv(config.EnvETHRPC, "")
+	t.Setenv("SIGIL_NETWORKS_ETH_RPC", "")
+
+	newCfg := func() *config.Config {
+		c := config.Defaults()
+		c.Networks.ETH.RPC = "https://default.example"
+		c.Networks.ETH.RPCProfiles = map[string]string{
+			"alchemy": "https://alchemy.example",
+			"infura":  "https://infura.example",
+		}
+		return c
+	}
+	var warn bytes.Buffer
+
+	// No profile selected
+	c := newCfg()
+	require.NoError(t, applyRPCProfile(c, "", &warn))
+	assert.Equal(t, "https://default.example", c.GetETHRPC())
+
+	// Config default
+	c = newCfg()
+	c.Networks.ETH.RPCProfile = "alchemy"
+	require.NoError(t, applyRPCProfile(c, "", &warn))
+	assert.Equal(t, "https://alchemy.example", c.GetETHRPC())
+
+	// Flag wins over the config default
+	c = newCfg()
+	c.Networks.ETH.RPCProfile = "alchemy"
+	require.NoError(t, applyRPCProfile(c, "infura", &warn))
+	assert.Equal(t, "https://infura.example", c.GetETHRPC())
+
+	// Unknown flag profile is an error
+	err := applyRPCProfile(newCfg(), "quicknode", &warn)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	// Unknown config default only warns
+	c = newCfg()
+	c.Networks.ETH.RPCProfile = "quicknode"
+	require.NoError(t, applyRPCProfile(c, "", &warn))
+	assert.Equal(t, "https://default.example", c.GetETHRPC())
+	assert.Contains(t, warn.String(), "use alchemy, infura")
+
+	// An RPC URL in the environment wins over the config default
+	t.Setenv(config.EnvETHRPC, "https://env.example")
+	c = newCfg()
+	c.Networks.ETH.RPCProfile = "alchemy"
+	require.NoError(t, applyRPCProfile(c, "", &warn))
+	assert.Equal(t, "https://default.example", c.GetETHRPC())
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 func TestGetConfigValue(t *testing.T) {
@@ -24,6 +25,7 @@ func TestGetConfigValue(t *testing.T) {
 	testCfg.Logging.File = "/var/log/sigil.log"
 	testCfg.Networks.ETH.RPC = "https://eth.example.com"
 	testCfg.Networks.BSV.APIKey = "test-api-key"
+	testCfg.DefaultWallet = "main"
 
 	tests := []struct {
 		name    string
@@ -33,6 +35,7 @@ func TestGetConfigValue(t *testing.T) {
 	}{
 		// Single-part paths
 		{name: "home", path: "home", want: "/test/home"},
+		{name: "default_wallet", path: "default_wallet", want: "main"},
 		{name: "unknown single key", path: "unknown", wantErr: true},
 
 		// Output section
@@ -192,6 +195,14 @@ func TestSetConfigValue(t *testing.T) {
 				assert.Equal(t, "/new/home", c.Home)
 			},
 		},
+		{
+			name:  "set default_wallet",
+			path:  "default_wallet",
+			value: "savings",
+			verify: func(t *testing.T, c *config.Config) {
+				assert.Equal(t, "savings", c.DefaultWallet)
+			},
+		},
 		{name: "set unknown single key", path: "unknown", value: "val", wantErr: true},
 
 		// Output section
@@ -850,3 +861,95 @@ func TestRunConfigSet_NoConfigFile(t *testing.T) {
 	require.Error(t, err)
 	assert.Empty(t, buf.String())
 }
+
+func TestRunConfigPrint(t *testing.T) {
+	_, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+
+	cfg.Networks.BSV.APIKey = "bsv-secret"
+	defer func() {
+		configPrintFormat = config.FormatYAML
+		configPrintShowSecrets = false
+	}()
+
+	tests := []struct {
+		name        string
+		format      string
+		showSecrets bool
+		contains    []string
+		excludes    []string
+	}{
+		{
+			name:     "yaml redacts secrets",
+			format:   config.FormatYAML,
+			contains: []string{"networks:", "api_key: <redacted>"},
+			excludes: []string{"bsv-secret"},
+		},
+		{
+			name:     "toml",
+			format:   config.FormatTOML,
+			contains: []string{"[networks.bsv]", `api_key = "<redacted>"`},
+			excludes: []string{"bsv-secret"},
+		},
+		{
+			name:        "env with secrets",
+			format:      "env",
+			showSecrets: true,
+			contains:    []string{"SIGIL_NETWORKS_BSV_API_KEY=bsv-secret\n", "SIGIL_LOGGING_LEVEL="},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			configPrintFormat = tc.format
+			configPrintShowSecrets = tc.showSecrets
+
+			cmd, buf := newConfigTestCmd()
+			require.NoError(t, runConfigPrint(cmd, nil))
+			for _, s := range tc.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+			for _, s := range tc.excludes {
+				assert.NotContains(t, buf.String(), s)
+			}
+		})
+	}
+
+	assert.Equal(t, "bsv-secret", cfg.Networks.BSV.APIKey, "printing must not modify the loaded config")
+}
+
+func TestRunConfigPrint_InvalidFormat(t *testing.T) {
+	_, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+
+	configPrintFormat = "xml"
+	defer func() { configPrintFormat = config.FormatYAML }()
+
+	cmd, _ := newConfigTestCmd()
+	require.ErrorIs(t, runConfigPrint(cmd, nil), sigilerr.ErrInvalidFormat)
+}
+
+func TestRunConfigSet_EnvOnly(t *testing.T) {
+	_, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv(config.EnvConfig, config.EnvOnlyMode)
+
+	cmd, _ := newConfigTestCmd()
+	err := runConfigSet(cmd, []string{"logging.level", "debug"})
+	require.Error(t, err)
+
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "SIGIL_LOGGING_LEVEL=debug")
+}
+
+func TestShellQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "https://a.example,https://b.example", shellQuote("https://a.example,https://b.example"))
+	assert.Equal(t, "''", shellQuote(""))
+	assert.Equal(t, "'[{symbol: USDC}]'", shellQuote("[{symbol: USDC}]"))
+	assert.Equal(t, `'it'\''s'`, shellQuote("it's"))
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// configValidateCmd checks a config file against the config schema.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file]",
+	Short: "Check the configuration against the schema",
+	Long: `Check a config file for unknown keys and values the schema does not allow,
+such as an unknown output format, a plaintext RPC URL or a negative limit.
+
+Without a file, the active config file is checked together with the SIGIL_*
+environment variables in effect. Every problem is listed, and the command
+exits with an error if there are any.`,
+	Example: `  sigil config validate
+  sigil config validate ~/team/config.yaml
+  sigil config validate -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigValidate,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+// configValidateResult is the JSON output of config validate.
+type configValidateResult struct {
+	Path     string   `json:"path,omitempty"`
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	result := configValidateResult{Problems: []string{}}
+
+	switch {
+	case len(args) == 1:
+		result.Path = args[0]
+	case !config.EnvOnly():
+		result.Path = config.FindPath(cfg.Home)
+	}
+
+	if result.Path != "" {
+		err := config.ValidateFile(result.Path)
+		switch {
+		case os.IsNotExist(err) && len(args) == 1:
+			return sigilerr.WithSuggestion(sigilerr.ErrConfigNotFound, fmt.Sprintf("no config file at %s", result.Path))
+		case os.IsNotExist(err):
+			result.Path = "" // Defaults only; the environment is still checked
+		default:
+			result.Problems = append(result.Problems, errorMessages(err)...)
+		}
+	}
+	if len(args) == 0 {
+		result.Problems = append(result.Problems, cfg.Warnings...)
+		result.Problems = append(result.Problems, errorMessages(config.Validate(cfg))...)
+		result.Problems = dedupeStrings(result.Problems)
+	}
+	result.Valid = len(result.Problems) == 0
+
+	w := cmd.OutOrStdout()
+	if formatter.Format() == output.FormatJSON {
+		if err := writeJSON(w, result); err != nil {
+			return err
+		}
+	} else {
+		displayConfigValidateText(w, result)
+	}
+
+	if !result.Valid {
+		return sigilerr.WithSuggestion(sigilerr.ErrConfigInvalid,
+			fmt.Sprintf("%d configuration problem(s) found", len(result.Problems)))
+	}
+	return nil
+}
+
+// displayConfigValidateText prints the outcome of config validate.
+func displayConfigValidateText(w io.Writer, result configValidateResult) {
+	source := result.Path
+	if source == "" {
+		source = "defaults and environment"
+	}
+	if result.Valid {
+		out(w, "Configuration is valid (%s)\n", source)
+		return
+	}
+	out(w, "Configuration has %d problem(s) (%s):\n", len(result.Problems), source)
+	for _, p := range result.Problems {
+		out(w, "  - %s\n", p)
+	}
+}
+
+// errorMessages splits an error joined with errors.Join, whose message has
+// one line per joined error, into those lines.
+func errorMessages(err error) []string {
+	if err == nil {
+		return nil
+	}
+	return strings.Split(err.Error(), "\n")
+}
+
+// dedupeStrings removes repeated strings, keeping the first of each.
+func dedupeStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0]
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			unique = append(unique, v)
+		}
+	}
+	return unique
+}
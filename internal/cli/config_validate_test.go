@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestRunConfigValidate(t *testing.T) {
+	tmpDir, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+
+	// No file: defaults are valid
+	cmd, buf := newConfigTestCmd()
+	require.NoError(t, runConfigValidate(cmd, nil))
+	assert.Contains(t, buf.String(), "Configuration is valid (defaults and environment)")
+
+	path := filepath.Join(tmpDir, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("output:\n  default_format: yaml\nlogging:\n  levle: debug\n"), 0o600))
+	cmd, buf = newConfigTestCmd()
+	err := runConfigValidate(cmd, nil)
+	require.ErrorIs(t, err, sigilerr.ErrConfigInvalid)
+	assert.Contains(t, buf.String(), "field levle not found")
+	assert.Contains(t, buf.String(), "Configuration has 2 problem(s)")
+
+	require.NoError(t, os.WriteFile(path, []byte("output:\n  default_format: yaml\n"), 0o600))
+	cmd, buf = newConfigTestCmd()
+	require.ErrorIs(t, runConfigValidate(cmd, []string{path}), sigilerr.ErrConfigInvalid)
+	assert.Contains(t, buf.String(), `output.default_format: "yaml" (use text, json, auto)`)
+
+	cmd, _ = newConfigTestCmd()
+	require.ErrorIs(t, runConfigValidate(cmd, []string{filepath.Join(tmpDir, "missing.yaml")}), sigilerr.ErrConfigNotFound)
+}
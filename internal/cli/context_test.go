@@ -62,6 +62,7 @@ func (m *mockFactory) NewChain(_ context.Context, _ chain.ID, _ string) (chain.C
 // mockSessionManager implements session.Manager for testing.
 type mockSessionManager struct {
 	available bool
+	sessions  []*session.Session
 }
 
 func (m *mockSessionManager) Available() bool                                        { return m.available }
@@ -72,7 +73,7 @@ func (m *mockSessionManager) GetSession(_ string) ([]byte, *session.Session, err
 func (m *mockSessionManager) HasValidSession(_ string) bool             { return false }
 func (m *mockSessionManager) EndSession(_ string) error                 { return nil }
 func (m *mockSessionManager) EndAllSessions() int                       { return 0 }
-func (m *mockSessionManager) ListSessions() ([]*session.Session, error) { return nil, nil }
+func (m *mockSessionManager) ListSessions() ([]*session.Session, error) { return m.sessions, nil }
 
 func TestNewCommandContext(t *testing.T) {
 	tests := []struct {
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/mrz1836/sigil/internal/cosign"
+	"github.com/mrz1836/sigil/internal/hooks"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newCosignApprover returns the policy service approver configured under
+// security.cosign, or nil when co-signing is disabled. A broken cosign
+// configuration is an error rather than a silent bypass.
+func newCosignApprover(cc *CommandContext) (transaction.Approver, error) {
+	client, err := cosign.New(cc.Cfg.GetSecurity().Cosign, nil)
+	if err != nil {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrConfigInvalid,
+			"fix security.cosign in config.yaml: "+err.Error(),
+		)
+	}
+	if client == nil {
+		return nil, nil //nolint:nilnil // nil approver means co-signing is off
+	}
+	return client, nil
+}
+
+// approverChain asks each approver in turn. The first rejection stops the
+// chain, so later approvers never see a transaction an earlier one refused.
+type approverChain []transaction.Approver
+
+// Approve implements transaction.Approver.
+func (c approverChain) Approve(ctx context.Context, summary *cosign.Summary) error {
+	for _, approver := range c {
+		if err := approver.Approve(ctx, summary); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newSendApprover returns the approvers every send must pass: the pre_send
+// hook, then the co-sign policy service. It returns nil when neither is
+// configured. The local hook runs first so a rejection it can decide on its
+// own never reaches the policy service.
+func newSendApprover(cc *CommandContext) (transaction.Approver, error) {
+	var approvers approverChain
+	if runner := hooks.New(cc.Cfg.GetHooks()); runner.HasPreSend() {
+		approvers = append(approvers, runner)
+	}
+	cosigner, err := newCosignApprover(cc)
+	if err != nil {
+		return nil, err
+	}
+	if cosigner != nil {
+		approvers = append(approvers, cosigner)
+	}
+
+	switch len(approvers) {
+	case 0:
+		return nil, nil //nolint:nilnil // nil approver means no approval is needed
+	case 1:
+		return approvers[0], nil
+	}
+	return approvers, nil
+}
+
+// newTransactionService returns the command's transaction service with the
+// pre_send hook and co-sign approvers applied. An injected service gets the
+// approvers too, so it cannot bypass the policy.
+func newTransactionService(cc *CommandContext, storage transaction.StorageProvider) (*transaction.Service, error) {
+	approver, err := newSendApprover(cc)
+	if err != nil {
+		return nil, err
+	}
+	if cc.TransactionService != nil {
+		return cc.TransactionService.WithApprover(approver), nil
+	}
+	return transaction.NewService(&transaction.Config{
+		Config:   cc.Cfg,
+		Storage:  storage,
+		Logger:   cc.Log,
+		Approver: approver,
+	}), nil
+}
@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/cosign"
+	"github.com/mrz1836/sigil/internal/hooks"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestNewCosignApprover(t *testing.T) {
+	t.Parallel()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	newCC := func(cfg config.CosignConfig) *CommandContext {
+		return &CommandContext{Cfg: &mockConfigProvider{security: config.SecurityConfig{Cosign: cfg}}}
+	}
+
+	approver, err := newCosignApprover(newCC(config.CosignConfig{}))
+	require.NoError(t, err)
+	assert.Nil(t, approver, "co-signing is off without a URL")
+
+	approver, err = newCosignApprover(newCC(config.CosignConfig{URL: "https://policy.example.com", PublicKey: hex.EncodeToString(pub)}))
+	require.NoError(t, err)
+	assert.NotNil(t, approver)
+
+	_, err = newCosignApprover(newCC(config.CosignConfig{URL: "https://policy.example.com"}))
+	require.ErrorIs(t, err, sigilerr.ErrConfigInvalid)
+
+	_, err = newCosignApprover(newCC(config.CosignConfig{URL: "http://policy.example.com", PublicKey: hex.EncodeToString(pub)}))
+	require.ErrorIs(t, err, sigilerr.ErrConfigInvalid)
+}
+
+func TestNewSendApprover(t *testing.T) {
+	t.Parallel()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	cosignCfg := config.SecurityConfig{Cosign: config.CosignConfig{URL: "https://policy.example.com", PublicKey: hex.EncodeToString(pub)}}
+	hooksCfg := config.HooksConfig{PreSend: "/usr/local/bin/check-send"}
+
+	approver, err := newSendApprover(&CommandContext{Cfg: &mockConfigProvider{}})
+	require.NoError(t, err)
+	assert.Nil(t, approver)
+
+	approver, err = newSendApprover(&CommandContext{Cfg: &mockConfigProvider{hooks: config.HooksConfig{PostSend: "/bin/true"}}})
+	require.NoError(t, err)
+	assert.Nil(t, approver, "a post_send hook alone approves nothing")
+
+	approver, err = newSendApprover(&CommandContext{Cfg: &mockConfigProvider{hooks: hooksCfg}})
+	require.NoError(t, err)
+	assert.IsType(t, &hooks.Runner{}, approver)
+
+	approver, err = newSendApprover(&CommandContext{Cfg: &mockConfigProvider{hooks: hooksCfg, security: cosignCfg}})
+	require.NoError(t, err)
+	approvers, ok := approver.(approverChain)
+	require.True(t, ok)
+	require.Len(t, approvers, 2)
+	assert.IsType(t, &hooks.Runner{}, approvers[0], "the local hook runs before the policy service")
+}
+
+// stubApprover returns err and counts its calls.
+type stubApprover struct {
+	err   error
+	calls int
+}
+
+func (s *stubApprover) Approve(context.Context, *cosign.Summary) error {
+	s.calls++
+	return s.err
+}
+
+func TestApproverChain(t *testing.T) {
+	t.Parallel()
+
+	first, second := &stubApprover{}, &stubApprover{}
+	require.NoError(t, approverChain{first, second}.Approve(context.Background(), &cosign.Summary{}))
+	assert.Equal(t, 1, first.calls)
+	assert.Equal(t, 1, second.calls)
+
+	first.err = sigilerr.ErrHookRejected
+	err := approverChain{first, second}.Approve(context.Background(), &cosign.Summary{})
+	require.ErrorIs(t, err, sigilerr.ErrHookRejected)
+	assert.Equal(t, 1, second.calls, "a rejection stops the chain")
+}
@@ -0,0 +1,298 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// envelopeWallet is the wallet whose envelopes are managed.
+	envelopeWallet string
+)
+
+// envelopeCmd is the parent command for budget envelopes.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var envelopeCmd = &cobra.Command{
+	Use:   "envelope",
+	Short: "Partition a wallet's balance into budget envelopes",
+	Long: `Envelopes split a wallet's balance into named budgets, such as rent or
+savings, without creating separate wallets.
+
+An envelope is a set of BSV, BTC, or BCH addresses of the wallet; the funds
+those addresses hold are the envelope's balance. 'tx send --envelope rent'
+spends only from the rent envelope's addresses, and the change address of
+such a send joins the envelope, so the rest of the wallet is never touched.
+'balance show --envelopes' totals balances by envelope.`,
+}
+
+// envelopeAssignCmd assigns addresses to an envelope.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var envelopeAssignCmd = &cobra.Command{
+	Use:   "assign <envelope> <address>...",
+	Short: "Assign addresses to an envelope",
+	Long: `Assign one or more BSV, BTC, or BCH addresses of the wallet to an envelope,
+creating the envelope if it has no addresses yet. An address belongs to at
+most one envelope; assigning it again moves it.
+
+Envelope names are lower-cased and may contain letters, digits, '-', '_'
+and '.'.`,
+	Example: `  sigil envelope assign rent 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --wallet main
+  sigil tx send --wallet main --envelope rent --to 1BoatSLRHtKNngkdXEeobR76b53LETtpyT --amount 0.01 --chain bsv`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: runEnvelopeAssign,
+}
+
+// envelopeUnassignCmd removes addresses from their envelope.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var envelopeUnassignCmd = &cobra.Command{
+	Use:     "unassign <address>...",
+	Short:   "Remove addresses from their envelope",
+	Long:    `Remove one or more addresses from their envelope. Their funds return to the unassigned part of the wallet.`,
+	Example: `  sigil envelope unassign 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --wallet main`,
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    runEnvelopeUnassign,
+}
+
+// envelopeListCmd lists envelopes with their addresses and balances.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var envelopeListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List envelopes with their addresses and balances",
+	Long: `List the wallet's envelopes per chain with the number of addresses and the
+balance of their stored UTXOs. Run 'utxo refresh' first to bring the store up
+to date, or use 'balance show --envelopes' for live balances.`,
+	Example: `  sigil envelope list --wallet main
+  sigil envelope list --wallet main -o json`,
+	Args: cobra.NoArgs,
+	RunE: runEnvelopeList,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	envelopeCmd.GroupID = "wallet"
+	rootCmd.AddCommand(envelopeCmd)
+	envelopeCmd.AddCommand(envelopeAssignCmd)
+	envelopeCmd.AddCommand(envelopeUnassignCmd)
+	envelopeCmd.AddCommand(envelopeListCmd)
+
+	for _, cmd := range []*cobra.Command{envelopeAssignCmd, envelopeUnassignCmd, envelopeListCmd} {
+		cmd.Flags().StringVar(&envelopeWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+	}
+}
+
+// envelopeAssignment is one address in the output of the assign and
+// unassign commands.
+type envelopeAssignment struct {
+	Address  string `json:"address"`
+	Chain    string `json:"chain"`
+	Envelope string `json:"envelope"`
+	Previous string `json:"previous,omitempty"`
+	Changed  bool   `json:"changed"`
+}
+
+func runEnvelopeAssign(cmd *cobra.Command, args []string) error {
+	envelope, err := normalizeEnvelopeArg(args[0])
+	if err != nil {
+		return err
+	}
+	return setAddressEnvelopes(cmd, args[1:], envelope)
+}
+
+func runEnvelopeUnassign(cmd *cobra.Command, args []string) error {
+	return setAddressEnvelopes(cmd, args, "")
+}
+
+// normalizeEnvelopeArg normalizes an envelope name given on the command line.
+func normalizeEnvelopeArg(raw string) (string, error) {
+	envelope, err := utxostore.NormalizeEnvelope(raw)
+	if err != nil {
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("%v; use letters, digits, '-', '_' and '.' (e.g. rent)", err),
+		)
+	}
+	return envelope, nil
+}
+
+// setAddressEnvelopes assigns addresses of the wallet to envelope in its
+// UTXO store, or removes them from their envelope when envelope is empty.
+// Addresses the store does not know are registered first. Nothing is saved
+// unless every address belongs to the wallet.
+func setAddressEnvelopes(cmd *cobra.Command, addrs []string, envelope string) error {
+	if err := resolveWalletName(cmd, &envelopeWallet); err != nil {
+		return err
+	}
+	cmdCtx := GetCmdContext(cmd)
+
+	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(envelopeWallet)
+	if err != nil {
+		return err
+	}
+
+	lock, err := lockWallet(cmd, storage, envelopeWallet, "envelope assign")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	store := utxostore.New(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", envelopeWallet))
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("loading UTXO store: %w", err)
+	}
+
+	results := make([]envelopeAssignment, 0, len(addrs))
+	changed := false
+	for _, addr := range addrs {
+		chainID, found := registerOwnedAddress(store, wlt, addr)
+		if !found {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("address %s is not a BSV, BTC, or BCH address of wallet '%s'", addr, envelopeWallet),
+			)
+		}
+		previous := store.AddressEnvelope(chainID, addr)
+		addrChanged, setErr := store.SetAddressEnvelope(chainID, addr, envelope)
+		if setErr != nil {
+			return fmt.Errorf("updating address envelope: %w", setErr)
+		}
+		changed = changed || addrChanged
+		results = append(results, envelopeAssignment{
+			Address:  addr,
+			Chain:    string(chainID),
+			Envelope: envelope,
+			Previous: previous,
+			Changed:  addrChanged,
+		})
+	}
+	if changed {
+		if err := store.Save(); err != nil {
+			return fmt.Errorf("saving UTXO store: %w", err)
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	if cmdCtx.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, results)
+	}
+	for _, r := range results {
+		switch {
+		case !r.Changed && r.Envelope == "":
+			out(w, "Address %s is not in an envelope\n", r.Address)
+		case !r.Changed:
+			out(w, "Address %s is already in envelope '%s'\n", r.Address, r.Envelope)
+		case r.Envelope == "":
+			out(w, "Address %s removed from envelope '%s'\n", r.Address, r.Previous)
+		case r.Previous != "":
+			out(w, "Address %s moved from envelope '%s' to '%s'\n", r.Address, r.Previous, r.Envelope)
+		default:
+			out(w, "Address %s assigned to envelope '%s'\n", r.Address, r.Envelope)
+		}
+	}
+	return nil
+}
+
+// envelopeSummary is an envelope's addresses and stored balance on one chain.
+type envelopeSummary struct {
+	Envelope  string   `json:"envelope"`
+	Chain     string   `json:"chain"`
+	Addresses []string `json:"addresses"`
+	Balance   uint64   `json:"balance"`
+}
+
+func runEnvelopeList(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &envelopeWallet); err != nil {
+		return err
+	}
+	cmdCtx := GetCmdContext(cmd)
+
+	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	exists, err := storage.Exists(envelopeWallet)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sigilerr.WithSuggestion(
+			wallet.ErrWalletNotFound,
+			fmt.Sprintf("wallet '%s' not found. List wallets with: sigil wallet list", envelopeWallet),
+		)
+	}
+
+	store := utxostore.New(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", envelopeWallet))
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("loading UTXO store: %w", err)
+	}
+	summaries := summarizeEnvelopes(store)
+
+	w := cmd.OutOrStdout()
+	if cmdCtx.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, struct {
+			Wallet    string            `json:"wallet"`
+			Envelopes []envelopeSummary `json:"envelopes"`
+		}{envelopeWallet, summaries})
+	}
+
+	if len(summaries) == 0 {
+		out(w, "Wallet '%s' has no envelopes. Create one with: sigil envelope assign <envelope> <address>\n", envelopeWallet)
+		return nil
+	}
+	out(w, "Envelopes of wallet '%s':\n\n", envelopeWallet)
+	for _, s := range summaries {
+		out(w, "  %-20s %-4s %3d address(es) %13d sats\n", s.Envelope, s.Chain, len(s.Addresses), s.Balance)
+	}
+	return nil
+}
+
+// summarizeEnvelopes returns every envelope of the store per chain, sorted
+// by envelope and chain.
+func summarizeEnvelopes(store *utxostore.Store) []envelopeSummary {
+	summaries := make([]envelopeSummary, 0)
+	for _, envelope := range store.Envelopes() {
+		for _, chainID := range receiveOnlyChains {
+			addrs := store.EnvelopeAddresses(chainID, envelope)
+			if len(addrs) == 0 {
+				continue
+			}
+			summaries = append(summaries, envelopeSummary{
+				Envelope:  envelope,
+				Chain:     string(chainID),
+				Addresses: addrs,
+				Balance:   store.GetEnvelopeBalance(chainID, envelope),
+			})
+		}
+	}
+	return summaries
+}
+
+// restrictToEnvelope keeps the addresses of a send that are assigned to
+// envelope in the wallet's UTXO store. It fails when none are, since the
+// send would have nothing to spend.
+func restrictToEnvelope(store *utxostore.Store, chainID chain.ID, addresses []wallet.Address, envelope, walletName string) ([]wallet.Address, error) {
+	restricted := make([]wallet.Address, 0, len(addresses))
+	for _, addr := range addresses {
+		if store.AddressEnvelope(chainID, addr.Address) == envelope {
+			restricted = append(restricted, addr)
+		}
+	}
+	if len(restricted) == 0 {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("envelope '%s' has no %s addresses in wallet '%s'. Assign some with: sigil envelope assign %s <address> --wallet %s",
+				envelope, chainID, walletName, envelope, walletName),
+		)
+	}
+	return restricted, nil
+}
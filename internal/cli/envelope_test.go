@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestRunEnvelopeAssign(t *testing.T) {
+	tmpDir, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	t.Cleanup(func() { envelopeWallet = "" })
+
+	createTestWalletForAgent(t, tmpDir)
+	wlt, err := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets")).LoadMetadata("test-wallet")
+	require.NoError(t, err)
+	addr := wlt.Addresses[chain.BSV][0].Address
+
+	// Assigning registers the address, which was never refreshed
+	envelopeWallet = "test-wallet"
+	cmd, buf := newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runEnvelopeAssign(cmd, []string{"Rent", addr}))
+	assert.Contains(t, buf.String(), "assigned to envelope 'rent'")
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runEnvelopeAssign(cmd, []string{"rent", addr}))
+	assert.Contains(t, buf.String(), "already in envelope 'rent'")
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatJSON)
+	require.NoError(t, runEnvelopeAssign(cmd, []string{"savings", addr}))
+	var moved []envelopeAssignment
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &moved))
+	assert.Equal(t, []envelopeAssignment{{Address: addr, Chain: "bsv", Envelope: "savings", Previous: "rent", Changed: true}}, moved)
+
+	store := utxostore.New(filepath.Join(tmpDir, "wallets", "test-wallet"))
+	require.NoError(t, store.Load())
+	assert.Equal(t, "savings", store.AddressEnvelope(chain.BSV, addr))
+	assert.Equal(t, wlt.Addresses[chain.BSV][0].Path, store.GetAddress(chain.BSV, addr).DerivationPath)
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatJSON)
+	require.NoError(t, runEnvelopeList(cmd, nil))
+	var list struct {
+		Envelopes []envelopeSummary `json:"envelopes"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &list))
+	assert.Equal(t, []envelopeSummary{{Envelope: "savings", Chain: "bsv", Addresses: []string{addr}}}, list.Envelopes)
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runEnvelopeUnassign(cmd, []string{addr}))
+	assert.Contains(t, buf.String(), "removed from envelope 'savings'")
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runEnvelopeList(cmd, nil))
+	assert.Contains(t, buf.String(), "has no envelopes")
+
+	// Bad names, ETH addresses, and strangers are refused
+	cmd, _ = newBackupListTestCmd(tmpDir, output.FormatText)
+	require.ErrorIs(t, runEnvelopeAssign(cmd, []string{"bills:rent", addr}), sigilerr.ErrInvalidInput)
+	cmd, _ = newBackupListTestCmd(tmpDir, output.FormatText)
+	require.ErrorIs(t, runEnvelopeAssign(cmd, []string{"rent", wlt.Addresses[chain.ETH][0].Address}), sigilerr.ErrInvalidInput)
+}
+
+func TestRestrictToEnvelope(t *testing.T) {
+	t.Parallel()
+
+	store := utxostore.New(t.TempDir())
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1A", Envelope: "rent"})
+	store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: "1B"})
+	addresses := []wallet.Address{{Address: "1A"}, {Address: "1B"}, {Address: "1C"}}
+
+	restricted, err := restrictToEnvelope(store, chain.BSV, addresses, "rent", "main")
+	require.NoError(t, err)
+	assert.Equal(t, []wallet.Address{{Address: "1A"}}, restricted)
+
+	_, err = restrictToEnvelope(store, chain.BSV, addresses, "savings", "main")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
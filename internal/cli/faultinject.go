@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/faultinject"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// enableFaultInjection turns on provider fault injection for the hidden
+// --fault-injection flag. The flag is refused unless the environment opts in,
+// so a stray flag in a script cannot make real sends fail. A warning is
+// written to w whenever injection is active.
+func enableFaultInjection(spec string, w io.Writer) error {
+	if spec == "" {
+		return nil
+	}
+	if os.Getenv(config.EnvAllowFaultInjection) != "1" {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("--fault-injection is a testing mode and requires %s=1", config.EnvAllowFaultInjection),
+		)
+	}
+
+	fiCfg, err := faultinject.ParseSpec(spec)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+	faultinject.Enable(fiCfg)
+
+	kinds := fiCfg.Kinds
+	if len(kinds) == 0 {
+		kinds = faultinject.AllKinds()
+	}
+	names := make([]string, len(kinds))
+	for i, k := range kinds {
+		names[i] = string(k)
+	}
+	out(w, "Warning: fault injection enabled (rate %.2f, kinds %s); provider requests will fail on purpose\n",
+		fiCfg.Rate, strings.Join(names, ", "))
+	return nil
+}
+
+// writeFaultInjectionSummary reports how many provider requests were faulted.
+// It writes nothing when fault injection is off.
+func writeFaultInjectionSummary(w io.Writer) {
+	stats, ok := faultinject.CurrentStats()
+	if !ok {
+		return
+	}
+
+	kinds := make([]string, 0, len(stats.Injected))
+	for k, n := range stats.Injected {
+		kinds = append(kinds, fmt.Sprintf("%d %s", n, k))
+	}
+	sort.Strings(kinds)
+
+	out(w, "Fault injection: %d of %d provider requests faulted", stats.Total(), stats.Requests)
+	if len(kinds) > 0 {
+		out(w, " (%s)", strings.Join(kinds, ", "))
+	}
+	outln(w)
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/faultinject"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestEnableFaultInjection(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv() or process-wide fault injection
+	t.Cleanup(faultinject.Disable)
+
+	t.Run("empty spec is a no-op", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, enableFaultInjection("", &buf))
+		assert.False(t, faultinject.Enabled())
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("requires env opt-in", func(t *testing.T) {
+		t.Setenv(config.EnvAllowFaultInjection, "")
+		err := enableFaultInjection("rate=0.5", &bytes.Buffer{})
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, err, &se)
+		assert.Contains(t, se.Suggestion, config.EnvAllowFaultInjection+"=1")
+		assert.False(t, faultinject.Enabled())
+	})
+
+	t.Run("invalid spec", func(t *testing.T) {
+		t.Setenv(config.EnvAllowFaultInjection, "1")
+		err := enableFaultInjection("kinds=dns", &bytes.Buffer{})
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+		assert.False(t, faultinject.Enabled())
+	})
+
+	t.Run("enabled with warning and summary", func(t *testing.T) {
+		t.Setenv(config.EnvAllowFaultInjection, "1")
+		var buf bytes.Buffer
+		require.NoError(t, enableFaultInjection("rate=0.3,kinds=429", &buf))
+		assert.True(t, faultinject.Enabled())
+		assert.Contains(t, buf.String(), "fault injection enabled (rate 0.30, kinds 429)")
+
+		buf.Reset()
+		writeFaultInjectionSummary(&buf)
+		assert.Equal(t, "Fault injection: 0 of 0 provider requests faulted\n", buf.String())
+
+		faultinject.Disable()
+		buf.Reset()
+		writeFaultInjectionSummary(&buf)
+		assert.Empty(t, buf.String())
+	})
+}
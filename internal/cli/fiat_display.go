@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/price"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// fiatFlagUsage is the --fiat flag description shared by balance show and tx send.
+const fiatFlagUsage = "show approximate values in this fiat currency (usd, eur; none to turn off price.fiat)"
+
+// errUnpricedAmount indicates an amount a fiat value cannot be computed for.
+var errUnpricedAmount = errors.New("amount cannot be priced")
+
+// fiatQuoteSource fetches the display prices of several coins in one currency.
+type fiatQuoteSource interface {
+	Quotes(ctx context.Context, chains []chain.ID, currency string, offline bool) (map[chain.ID]*price.Quote, error)
+}
+
+// newFiatQuoteSourceFn creates the display price source (replaceable in tests).
+//
+//nolint:gochecknoglobals // Allows tests to stub price lookups
+var newFiatQuoteSourceFn = newFiatQuoteSource
+
+// newFiatQuoteSource builds a price source for the configured price API,
+// backed by the price cache in the sigil home directory.
+func newFiatQuoteSource(cc *CommandContext) (fiatQuoteSource, error) {
+	cfg := cc.Cfg.GetPrice()
+	api, err := price.NewCoinGecko(&price.CoinGeckoOptions{API: cfg.API, APIKey: cfg.APIKey})
+	if err != nil {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrConfigInvalid,
+			fmt.Sprintf("price.api: %v", err))
+	}
+	cache := price.NewQuoteCache(price.QuoteCachePath(cc.Cfg.GetHome()))
+	return price.NewCachedQuotes(api, cache, time.Duration(cfg.CacheMinutes)*time.Minute), nil
+}
+
+// resolveFiatCurrency returns the currency to show fiat values in: the
+// --fiat flag, else price.fiat from the config. Empty means no fiat values.
+func resolveFiatCurrency(cfg ConfigProvider, flag string) (string, error) {
+	value := strings.TrimSpace(flag)
+	if value == "" {
+		value = strings.TrimSpace(cfg.GetPrice().Fiat)
+	}
+	if value == "" || strings.EqualFold(value, "none") {
+		return "", nil
+	}
+	currency, err := price.ParseCurrency(value)
+	if err != nil {
+		return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+	return currency, nil
+}
+
+// fetchFiatQuotes returns display prices for chains in currency. Prices are
+// only decoration, so a failure is reported as a warning on w and whatever
+// quotes are available are returned. With offline set, only cached prices
+// are used.
+func fetchFiatQuotes(ctx context.Context, cc *CommandContext, w io.Writer, chains []chain.ID, currency string, offline bool) map[chain.ID]*price.Quote {
+	source, err := newFiatQuoteSourceFn(cc)
+	if err != nil {
+		out(w, "Warning: fiat values unavailable: %v\n", err)
+		return nil
+	}
+	quotes, err := source.Quotes(ctx, chains, currency, offline)
+	if err != nil {
+		if cc.Log != nil {
+			cc.Log.Error("fetching %s prices: %v", currency, err)
+		}
+		out(w, "Warning: some fiat values unavailable: %v\n", err)
+	}
+	return quotes
+}
+
+// fiatValue returns the fiat value of a decimal coin amount, rounded to cents.
+func fiatValue(q *price.Quote, amount string, decimals int) (string, error) {
+	units, err := chain.ParseDecimalAmount(amount, decimals, errUnpricedAmount)
+	if err != nil {
+		return "", err
+	}
+	return q.Value(units, decimals), nil
+}
+
+// sumFiatValues adds decimal fiat values, rounding the total to cents.
+func sumFiatValues(values []string) string {
+	total := new(big.Rat)
+	for _, v := range values {
+		if r, ok := new(big.Rat).SetString(v); ok {
+			total.Add(total, r)
+		}
+	}
+	return total.FloatString(2)
+}
@@ -0,0 +1,203 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/price"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// stubFiatQuoteSource returns fixed rates and records the last request.
+type stubFiatQuoteSource struct {
+	rates   map[chain.ID]float64
+	err     error
+	offline bool
+}
+
+func (s *stubFiatQuoteSource) Quotes(_ context.Context, chains []chain.ID, currency string, offline bool) (map[chain.ID]*price.Quote, error) {
+	s.offline = offline
+	quotes := make(map[chain.ID]*price.Quote)
+	for _, id := range chains {
+		if rate, ok := s.rates[id]; ok {
+			q := testFiatQuote(rate)
+			q.Chain, q.Currency, q.Source = id, currency, price.SourceCoinGecko
+			quotes[id] = q
+		}
+	}
+	return quotes, s.err
+}
+
+// withFiatQuoteSource replaces the display price source for one test.
+func withFiatQuoteSource(t *testing.T, source fiatQuoteSource) {
+	t.Helper()
+	orig := newFiatQuoteSourceFn
+	newFiatQuoteSourceFn = func(*CommandContext) (fiatQuoteSource, error) { return source, nil }
+	t.Cleanup(func() { newFiatQuoteSourceFn = orig })
+}
+
+func TestResolveFiatCurrency(t *testing.T) {
+	t.Parallel()
+
+	cfg := &mockConfigProvider{price: config.PriceConfig{Fiat: "eur"}}
+	tests := []struct {
+		flag string
+		cfg  ConfigProvider
+		want string
+	}{
+		{"", &mockConfigProvider{}, ""},
+		{"usd", &mockConfigProvider{}, price.CurrencyUSD},
+		{"", cfg, price.CurrencyEUR},
+		{"USD", cfg, price.CurrencyUSD},
+		{"none", cfg, ""},
+	}
+	for _, tc := range tests {
+		got, err := resolveFiatCurrency(tc.cfg, tc.flag)
+		require.NoError(t, err, tc.flag)
+		assert.Equal(t, tc.want, got, tc.flag)
+	}
+
+	_, err := resolveFiatCurrency(cfg, "gbp")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestNewFiatQuoteSource_BadAPI(t *testing.T) {
+	t.Parallel()
+
+	cc := &CommandContext{Cfg: &mockConfigProvider{home: t.TempDir(), price: config.PriceConfig{API: "kraken"}}}
+	_, err := newFiatQuoteSource(cc)
+	require.ErrorIs(t, err, sigilerr.ErrConfigInvalid)
+}
+
+//nolint:paralleltest // Replaces the package-level price source
+func TestApplyBalanceFiat(t *testing.T) {
+	source := &stubFiatQuoteSource{rates: map[chain.ID]float64{chain.BSV: 48.25, chain.ETH: 3000}}
+	withFiatQuoteSource(t, source)
+
+	cmd := &cobra.Command{}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+	cc := &CommandContext{Cfg: &mockConfigProvider{home: t.TempDir()}}
+
+	response := BalanceShowResponse{
+		Wallet: "main",
+		Balances: []BalanceResult{
+			{Chain: "bsv", Address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", Balance: "2", Symbol: "BSV", Decimals: 8},
+			{Chain: "eth", Address: "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", Balance: "0.5", Symbol: "ETH", Decimals: 18},
+			{Chain: "eth", Address: "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", Balance: "100", Symbol: "USDC", Token: "0xa0b8", Decimals: 6},
+			{Chain: "btc", Address: "bc1qexample", Balance: "1", Symbol: "BTC", Decimals: 8},
+		},
+	}
+	applyBalanceFiat(context.Background(), cmd, cc, &response, price.CurrencyUSD, true)
+
+	assert.True(t, source.offline)
+	assert.Empty(t, stderr.String())
+	assert.Equal(t, "96.50", response.Balances[0].FiatValue)
+	assert.Equal(t, "1500.00", response.Balances[1].FiatValue)
+	assert.Empty(t, response.Balances[2].FiatValue, "tokens are not valued")
+	assert.Empty(t, response.Balances[3].FiatValue, "no BTC price")
+	require.NotNil(t, response.Fiat)
+	assert.Equal(t, "1596.50", response.Fiat.Total)
+	require.Len(t, response.Fiat.Rates, 2)
+	assert.Equal(t, "bsv", response.Fiat.Rates[0].Chain)
+
+	var buf bytes.Buffer
+	outputBalanceText(&buf, response)
+	text := buf.String()
+	assert.Contains(t, text, "USD value")
+	assert.Contains(t, text, "│     96.50 │")
+	assert.Contains(t, text, "│         - │")
+	assert.Contains(t, text, "Total value: ≈ 1596.50 USD")
+	assert.Contains(t, text, "1 BSV = 48.25 USD (as of 2026-01-02 03:04:05 UTC, coingecko)")
+
+	buf.Reset()
+	require.NoError(t, outputBalanceJSON(&buf, response))
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	fiat, ok := decoded["fiat"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "1596.50", fiat["total"])
+	balances, ok := decoded["balances"].([]any)
+	require.True(t, ok)
+	first, ok := balances[0].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "96.50", first["fiat_value"])
+}
+
+//nolint:paralleltest // Replaces the package-level price source
+func TestApplyBalanceFiat_Unavailable(t *testing.T) {
+	withFiatQuoteSource(t, &stubFiatQuoteSource{err: price.ErrPriceAPI})
+
+	cmd := &cobra.Command{}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+	cc := &CommandContext{Cfg: &mockConfigProvider{home: t.TempDir()}}
+
+	response := BalanceShowResponse{Balances: []BalanceResult{{Chain: "bsv", Balance: "1", Symbol: "BSV", Decimals: 8}}}
+	applyBalanceFiat(context.Background(), cmd, cc, &response, price.CurrencyEUR, false)
+
+	assert.Nil(t, response.Fiat, "balances are still shown without prices")
+	assert.Contains(t, stderr.String(), "Warning: some fiat values unavailable")
+
+	applyBalanceFiat(context.Background(), cmd, cc, &response, "", false)
+	assert.Nil(t, response.Fiat)
+}
+
+func TestTxFiatValue(t *testing.T) {
+	t.Parallel()
+
+	q := testFiatQuote(40)
+	v := txFiatValue(q, "1.25", nil)
+	require.NotNil(t, v)
+	assert.Equal(t, "50.00", v.Amount)
+
+	var buf bytes.Buffer
+	displayFiatValueText(&buf, v, 7)
+	assert.Equal(t,
+		"  Value:  ≈ 50.00 USD\n  Price:  1 BSV = 40.00 USD (as of 2026-01-02 03:04:05 UTC, whatsonchain)\n",
+		buf.String())
+
+	usd := &transaction.FiatConversion{Amount: "50.00", Currency: price.CurrencyUSD, Quote: q}
+	assert.Nil(t, txFiatValue(q, "1.25", usd), "a USD send already shows its USD value")
+	assert.Nil(t, txFiatValue(nil, "1.25", nil))
+	assert.Nil(t, txFiatValue(q, "all", nil))
+}
+
+//nolint:paralleltest // Sets the package-level txFiatQuote
+func TestDisplayTxResultJSON_FiatValue(t *testing.T) {
+	eur := testFiatQuote(2500)
+	eur.Chain, eur.Currency = chain.ETH, price.CurrencyEUR
+	txFiatQuote = eur
+	t.Cleanup(func() { txFiatQuote = nil })
+
+	var buf bytes.Buffer
+	displayTxResultJSON(&buf, &chain.TransactionResult{Hash: "0xabc", Amount: "0.1"}, "", nil, nil)
+	var payload struct {
+		FiatValue *fiatConversionJSON `json:"fiat_value"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &payload))
+	require.NotNil(t, payload.FiatValue)
+	assert.Equal(t, "250.00", payload.FiatValue.Amount)
+	assert.Equal(t, price.CurrencyEUR, payload.FiatValue.Currency)
+
+	cmd := &cobra.Command{}
+	cmd.SetOut(&buf)
+	txFiatQuote = testFiatQuote(50)
+	buf.Reset()
+	displayBSVTxDetailsEnhanced(cmd, &bsvConfirmationDetails{
+		Chain:           chain.BSV,
+		To:              "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+		AmountSats:      10_000_000,
+		SourceAddresses: []string{"1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2"},
+	})
+	assert.Contains(t, buf.String(), "  Value:     ≈ 5.00 USD\n")
+}
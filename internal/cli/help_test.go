@@ -123,6 +123,7 @@ func TestParentCommandsShowSubcommandsInHelp(t *testing.T) {
 		{"session", sessionCmd},
 		{"backup", backupCmd},
 		{"config", configCmd},
+		{"profile", profileCmd},
 	}
 
 	for _, pc := range parentCmds {
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/mrz1836/sigil/internal/hooks"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+)
+
+// runPostSendHook passes each broadcast transaction to the post_send hook.
+// The transactions are already broadcast, so a failing hook only warns. The
+// hook gets its own timeout rather than what is left of the send's.
+func runPostSendHook(ctx context.Context, cc *CommandContext, sent ...*hooks.Sent) {
+	runner := hooks.New(cc.Cfg.GetHooks())
+	if !runner.HasPostSend() {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	for _, s := range sent {
+		if err := runner.PostSend(ctx, s); err != nil {
+			output.Warnf("post_send hook for %s: %v", s.Hash, err)
+		}
+	}
+}
+
+// sentFromResults describes send results for the post_send hook. Failed
+// transactions of a batch have no hash and are skipped.
+func sentFromResults(walletName string, results ...*transaction.SendResult) []*hooks.Sent {
+	sent := make([]*hooks.Sent, 0, len(results))
+	for _, r := range results {
+		if r == nil || r.Hash == "" {
+			continue
+		}
+		sent = append(sent, &hooks.Sent{
+			Chain:  string(r.ChainID),
+			Wallet: walletName,
+			Hash:   r.Hash,
+			From:   r.From,
+			To:     r.To,
+			Amount: r.Amount,
+			Token:  r.Token,
+			Fee:    r.Fee,
+			Status: r.Status,
+		})
+	}
+	return sent
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+)
+
+func TestSentFromResults(t *testing.T) {
+	t.Parallel()
+
+	sent := sentFromResults("main",
+		&transaction.SendResult{Hash: "0xaaa", ChainID: chain.ETH, From: "0x1", To: "0x2", Amount: "0.5", Token: "USDC", Fee: "0.0001", Status: "pending"},
+		&transaction.SendResult{ChainID: chain.ETH, To: "0x3"},
+		nil,
+	)
+	require.Len(t, sent, 1, "results without a hash were not broadcast")
+	assert.Equal(t, "eth", sent[0].Chain)
+	assert.Equal(t, "main", sent[0].Wallet)
+	assert.Equal(t, "0xaaa", sent[0].Hash)
+	assert.Equal(t, "USDC", sent[0].Token)
+}
+
+func TestRunPostSendHook(t *testing.T) {
+	t.Parallel()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts need a POSIX shell")
+	}
+
+	dir := t.TempDir()
+	log := filepath.Join(dir, "sent.log")
+	hook := filepath.Join(dir, "notify")
+	require.NoError(t, os.WriteFile(hook, []byte("#!/bin/sh\ncat >> \""+log+"\"\necho >> \""+log+"\"\n"), 0o700)) //nolint:gosec // The test hook must be executable
+
+	cc := &CommandContext{Cfg: &mockConfigProvider{hooks: config.HooksConfig{PostSend: hook}}}
+	results := []*transaction.SendResult{
+		{Hash: "aaa", ChainID: chain.BSV},
+		{Hash: "bbb", ChainID: chain.BSV},
+	}
+
+	// A canceled send context does not stop the hook.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	runPostSendHook(ctx, cc, sentFromResults("main", results...)...)
+
+	raw, err := os.ReadFile(log) //nolint:gosec // Test file in t.TempDir
+	require.NoError(t, err)
+	assert.Contains(t, string(raw), `"hash":"aaa"`)
+	assert.Contains(t, string(raw), `"hash":"bbb"`)
+	assert.Contains(t, string(raw), `"event":"post_send"`)
+}
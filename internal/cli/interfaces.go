@@ -18,33 +18,81 @@ type ConfigProvider interface {
 	// GetHome returns the sigil home directory path.
 	GetHome() string
 
+	// GetDefaultWallet returns the wallet used when --wallet is omitted.
+	GetDefaultWallet() string
+
+	// GetAddressGap returns the number of unused receive addresses kept after
+	// the last used one, or 0 when address lists are never extended.
+	GetAddressGap() int
+
 	// GetETHRPC returns the Ethereum RPC URL.
 	GetETHRPC() string
 
 	// GetETHFallbackRPCs returns the fallback Ethereum RPC URLs.
 	GetETHFallbackRPCs() []string
 
+	// GetETHChainID returns the chain ID of the ETH network in use.
+	GetETHChainID() int
+
 	// GetETHProvider returns the ETH balance provider ("rpc" or "etherscan").
 	GetETHProvider() string
 
 	// GetETHEtherscanAPIKey returns the Etherscan API key.
 	GetETHEtherscanAPIKey() string
 
+	// GetBalanceSources returns the ordered balance sources for a chain.
+	GetBalanceSources(chainName string) []string
+
+	// GetETHTokens returns the ERC-20 tokens listed in the configuration.
+	GetETHTokens() []config.TokenConfig
+
+	// GetETHGasMarginPercent returns the percentage added to ETH gas estimates.
+	GetETHGasMarginPercent() int
+
 	// GetBSVAPIKey returns the BSV API key.
 	GetBSVAPIKey() string
 
 	// GetBSVNetwork returns the configured BSV network ("main" or "test").
 	GetBSVNetwork() string
 
+	// UseNetwork switches the ETH settings and the balance cache to a
+	// network ("main" or "test").
+	UseNetwork(network string)
+
 	// GetBSVBroadcast returns the BSV broadcast provider or custom URL.
 	GetBSVBroadcast() string
 
+	// GetBSVNode returns the BSV node JSON-RPC endpoint.
+	GetBSVNode() config.BSVNodeConfig
+
+	// GetBSVUTXOSync returns how utxo refresh finds UTXOs ("api" or "filters").
+	GetBSVUTXOSync() string
+
+	// GetBSVUTXOArchiveDays returns the age in days after which utxo refresh
+	// archives spent UTXOs, or 0 when it does not archive.
+	GetBSVUTXOArchiveDays() int
+
 	// GetBSVFeeStrategy returns the BSV fee strategy (economy, normal, priority).
 	GetBSVFeeStrategy() string
 
 	// GetBSVMinMiners returns the minimum number of miners for the normal fee strategy.
 	GetBSVMinMiners() int
 
+	// GetBSVCoinSelection returns the BSV coin selection strategy.
+	GetBSVCoinSelection() string
+
+	// GetBTCAPI returns the BTC Esplora API preset or base URL.
+	GetBTCAPI() string
+
+	// IsBTCEnabled reports whether BTC is enabled.
+	IsBTCEnabled() bool
+
+	// GetBCHAPI returns the BCH API preset or base URL.
+	GetBCHAPI() string
+
+	// IsBCHEnabled reports whether BCH is enabled.
+	IsBCHEnabled() bool
+
 	// GetLoggingLevel returns the configured logging level.
 	GetLoggingLevel() string
 
@@ -59,6 +107,28 @@ type ConfigProvider interface {
 
 	// GetSecurity returns the security configuration.
 	GetSecurity() config.SecurityConfig
+
+	// GetCache returns the balance cache limits.
+	GetCache() config.CacheConfig
+
+	// GetHooks returns the pre_send and post_send hook configuration.
+	GetHooks() config.HooksConfig
+
+	// GetQuotas returns the daily API quotas used for usage warnings.
+	GetQuotas() config.QuotaConfig
+
+	// GetPrice returns the price API used for fiat values.
+	GetPrice() config.PriceConfig
+
+	// GetOutbox returns the broadcast outbox settings.
+	GetOutbox() config.OutboxConfig
+	// GetConfirmations returns the per-chain confirmation targets.
+	GetConfirmations() config.ConfirmationsConfig
+	// GetConcurrency returns the balance fetch concurrency settings.
+	GetConcurrency() config.ConcurrencyConfig
+
+	// GetWalletTemplates returns the wallet templates defined in config.
+	GetWalletTemplates() map[string]config.WalletTemplateConfig
 }
 
 // LogWriter provides logging capabilities.
@@ -0,0 +1,215 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/migrate"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// migrateCmd is the parent command for data file migrations.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade data files to the current format",
+	Long: `Upgrade sigil's data files to the format this version writes.
+
+Wallet files, UTXO stores, balance caches, and agent credentials and vaults
+each record a schema version. When a file is older than this sigil writes, the
+migrations for its kind upgrade it one version at a time. Files written by a
+newer sigil are never rewritten.
+
+Migrations run automatically before the first command after an upgrade of
+sigil. The original of every migrated file is first copied to
+~/.sigil/backups/migrate-<time>/, at the same path it has under ~/.sigil.`,
+}
+
+// migrateStatusCmd reports the schema version of every data file.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the schema version of every data file",
+	Long: `List every data file with its schema version, the version this sigil
+writes, and the migrations it still needs. Files that cannot be read and
+files written by a newer sigil are flagged.`,
+	Example: `  sigil migrate status
+  sigil migrate status -o json`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateStatus,
+}
+
+// migrateRunCmd upgrades every out-of-date data file.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var migrateRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Upgrade out-of-date data files",
+	Long: `Back up and upgrade every data file older than the format this sigil
+writes. Files that cannot be read are left as they are and reported; the
+command then exits with an error.`,
+	Example: `  sigil migrate run
+  sigil migrate run -o json`,
+	Args: cobra.NoArgs,
+	RunE: runMigrateRun,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	migrateCmd.GroupID = "config"
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+	migrateCmd.AddCommand(migrateRunCmd)
+}
+
+func runMigrateStatus(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+	statuses, err := migrate.Status(cc.Cfg.GetHome())
+	if err != nil {
+		return err
+	}
+	if statuses == nil {
+		statuses = []migrate.FileStatus{}
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		type fileStatus struct {
+			migrate.FileStatus
+
+			State string `json:"state"`
+		}
+		resp := make([]fileStatus, len(statuses))
+		for i, s := range statuses {
+			resp[i] = fileStatus{FileStatus: s, State: s.State()}
+		}
+		return writeJSON(w, resp)
+	}
+	displayMigrateStatus(w, statuses)
+	return nil
+}
+
+// displayMigrateStatus prints the statuses as a table.
+func displayMigrateStatus(w io.Writer, statuses []migrate.FileStatus) {
+	if len(statuses) == 0 {
+		outln(w, "No data files found.")
+		return
+	}
+
+	pathWidth := len("FILE")
+	for _, s := range statuses {
+		pathWidth = max(pathWidth, len(s.Path))
+	}
+
+	pending := 0
+	out(w, "%-11s  %-*s  %7s  %-7s  %s\n", "STORE", pathWidth, "FILE", "VERSION", "STATE", "NOTE")
+	for _, s := range statuses {
+		state, note := s.State(), ""
+		switch state {
+		case migrate.StatePending:
+			pending++
+			note = strings.Join(s.Pending, "; ")
+		case migrate.StateNewer:
+			note = "written by a newer sigil; upgrade with 'sigil upgrade'"
+		case migrate.StateError:
+			note = s.Error
+		}
+		out(w, "%-11s  %-*s  %3d/%-3d  %-7s  %s\n", s.Store, pathWidth, s.Path, s.Version, s.Current, state, note)
+	}
+
+	if pending > 0 {
+		out(w, "\n%d file(s) need migration. Run: sigil migrate run\n", pending)
+	}
+}
+
+func runMigrateRun(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+	result, err := migrate.Run(cc.Cfg.GetHome(), time.Now())
+	if err != nil {
+		return fmt.Errorf("migrating data files: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		if result.Migrated == nil {
+			result.Migrated = []migrate.FileStatus{}
+		}
+		if err := writeJSON(w, result); err != nil {
+			return err
+		}
+	} else {
+		displayMigrateResult(w, result)
+	}
+
+	if len(result.Failed) > 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("%d data file(s) could not be migrated; see 'sigil migrate status'", len(result.Failed)))
+	}
+	return nil
+}
+
+// displayMigrateResult prints the files migrated and those that failed.
+func displayMigrateResult(w io.Writer, result *migrate.Result) {
+	if len(result.Migrated) == 0 && len(result.Failed) == 0 {
+		outln(w, "All data files are up to date.")
+		return
+	}
+	for _, s := range result.Migrated {
+		out(w, "Migrated %s (%s) from version %d to %d\n", s.Path, s.Store, s.Version, s.Current)
+	}
+	for _, s := range result.Failed {
+		out(w, "Failed   %s (%s): %s\n", s.Path, s.Store, s.Error)
+	}
+	if result.BackupDir != "" {
+		out(w, "\nOriginals backed up to %s\n", result.BackupDir)
+	}
+}
+
+// autoMigrate upgrades out-of-date data files before a command runs, once
+// after each upgrade of sigil. Failures are only warned about: the stores
+// still refuse what they cannot read, and migrate status explains why.
+// The migrate command itself and hidden commands are skipped.
+func autoMigrate(cmd *cobra.Command, home string) {
+	if isMigrateCommand(cmd) || strings.HasPrefix(cmd.Name(), "__") {
+		return
+	}
+	if _, err := os.Stat(filepath.Join(home, "wallets")); err != nil {
+		// No wallets yet, so nothing worth migrating
+		return
+	}
+	if !migrate.Due(home) {
+		return
+	}
+
+	w := cmd.ErrOrStderr()
+	result, err := migrate.Run(home, time.Now())
+	if err != nil {
+		out(w, "Warning: migrating data files: %v; run: sigil migrate status\n", err)
+		return
+	}
+	if n := len(result.Migrated); n > 0 {
+		out(w, "Migrated %d data file(s) to the current format; originals backed up to %s\n", n, result.BackupDir)
+	}
+	if n := len(result.Failed); n > 0 {
+		out(w, "Warning: %d data file(s) could not be migrated; run: sigil migrate status\n", n)
+	}
+}
+
+// isMigrateCommand reports whether cmd is migrate or one of its subcommands.
+func isMigrateCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == migrateCmd {
+			return true
+		}
+	}
+	return false
+}
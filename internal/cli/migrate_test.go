@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/migrate"
+)
+
+func TestAutoMigrate(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	walletPath := filepath.Join(home, "wallets", "main.wallet")
+	require.NoError(t, os.MkdirAll(filepath.Dir(walletPath), 0o700))
+	require.NoError(t, os.WriteFile(walletPath,
+		[]byte(`{"wallet": {"name": "main", "addresses": {"bsv": []}}, "encrypted_seed": "AAEC"}`), 0o600))
+
+	// The migrate command never migrates on its own
+	var stderr bytes.Buffer
+	autoMigrate(migrateStatusCmd, home)
+	assert.True(t, migrate.Due(home))
+
+	cmd := &cobra.Command{Use: "list"}
+	cmd.SetErr(&stderr)
+	autoMigrate(cmd, home)
+	assert.Contains(t, stderr.String(), "Migrated 1 data file(s) to the current format; originals backed up to "+
+		filepath.Join(home, "backups", "migrate-"))
+	assert.False(t, migrate.Due(home))
+
+	_, err := os.Stat(filepath.Join(home, "schema.json"))
+	require.NoError(t, err)
+
+	// Once migrated, later commands do nothing
+	stderr.Reset()
+	autoMigrate(cmd, home)
+	assert.Empty(t, stderr.String())
+
+	// A home without wallets is left alone
+	empty := t.TempDir()
+	autoMigrate(cmd, empty)
+	_, err = os.Stat(filepath.Join(empty, "schema.json"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestDisplayMigrateStatus(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displayMigrateStatus(&buf, nil)
+	assert.Equal(t, "No data files found.\n", buf.String())
+
+	buf.Reset()
+	displayMigrateStatus(&buf, []migrate.FileStatus{
+		{Store: "wallet", Path: "wallets/main.wallet", Version: 0, Current: 1, Pending: []string{"record the file version"}},
+		{Store: "wallet", Path: "wallets/new.wallet", Version: 2, Current: 1},
+		{Store: "cache", Path: "cache/balances.json", Version: 1, Current: 1},
+	})
+	text := buf.String()
+	assert.Contains(t, text, "wallets/main.wallet    0/1    pending  record the file version")
+	assert.Contains(t, text, "newer    written by a newer sigil")
+	assert.Contains(t, text, "current")
+	assert.Contains(t, text, "1 file(s) need migration. Run: sigil migrate run")
+}
+
+func TestDisplayMigrateResult(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displayMigrateResult(&buf, &migrate.Result{})
+	assert.Equal(t, "All data files are up to date.\n", buf.String())
+
+	buf.Reset()
+	displayMigrateResult(&buf, &migrate.Result{
+		Migrated:  []migrate.FileStatus{{Store: "cache", Path: "cache/balances.json", Version: 0, Current: 1}},
+		Failed:    []migrate.FileStatus{{Store: "wallet", Path: "wallets/bad.wallet", Error: "parsing file: boom"}},
+		BackupDir: "/home/u/.sigil/backups/migrate-20260102-030405",
+	})
+	text := buf.String()
+	assert.Contains(t, text, "Migrated cache/balances.json (cache) from version 0 to 1")
+	assert.Contains(t, text, "Failed   wallets/bad.wallet (wallet): parsing file: boom")
+	assert.Contains(t, text, "Originals backed up to /home/u/.sigil/backups/migrate-20260102-030405")
+}
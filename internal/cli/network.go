@@ -3,11 +3,19 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/explorer"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // effectiveBSVNetwork resolves the network for a wallet-scoped operation.
@@ -49,37 +57,160 @@ func walletNetwork(network string) wallet.Network {
 	return wallet.NetworkFromString(network)
 }
 
-// normalizeNetworkString normalizes a raw network string to "main"/"test",
-// defaulting unknown/empty values to "main".
-func normalizeNetworkString(s string) string {
-	if s == "test" || s == "testnet" {
+// useNetwork points the config and the ETH token registry at a network,
+// "main" or "test". On testnet ETH runs on Sepolia.
+func useNetwork(c ConfigProvider, network string) {
+	c.UseNetwork(network)
+	eth.UseChain(int64(c.GetETHChainID()))
+}
+
+// useWalletNetwork switches the command to the stamped network of a wallet.
+// It must run before any client or cache for the wallet is created; wallets
+// loaded through loadWallet are switched to automatically.
+func useWalletNetwork(cmd *cobra.Command, w *wallet.Wallet) {
+	if cc := GetCmdContext(cmd); cc != nil && cc.Cfg != nil {
+		useNetwork(cc.Cfg, effectiveBSVNetwork(w, cc.Cfg))
+	}
+}
+
+// applyWalletNetwork is useWalletNetwork for a wallet read from its metadata,
+// warning when --network asked for the other network.
+func applyWalletNetwork(cmd *cobra.Command, w *wallet.Wallet) {
+	warnNetworkConflict(cmd, w)
+	useWalletNetwork(cmd, w)
+}
+
+// ethNetwork returns "test" when ETH runs on Sepolia, "main" otherwise.
+func ethNetwork(cfg ConfigProvider) string {
+	if cfg != nil && cfg.GetETHChainID() == config.ETHSepoliaChainID {
 		return "test"
 	}
 	return "main"
 }
 
-// bsvExplorerTxLinks returns explorer URLs for a BSV transaction. On testnet it
-// returns WhatsOnChain test (primary) and bananablocks test (secondary); on
-// mainnet it returns the single WhatsOnChain link.
-func bsvExplorerTxLinks(network, txid string) []string {
-	if network == "test" {
-		return []string{
-			"https://test.whatsonchain.com/tx/" + txid,
-			"https://test.bananablocks.com/tx/" + txid,
+// etherscanOptions returns Etherscan client options for the ETH network in use.
+func etherscanOptions(cfg ConfigProvider) *etherscan.ClientOptions {
+	return &etherscan.ClientOptions{ChainID: strconv.Itoa(cfg.GetETHChainID())}
+}
+
+// normalizeNetworkString normalizes a raw network string to "main"/"test",
+// defaulting unknown/empty values to "main".
+func normalizeNetworkString(s string) string {
+	network, _ := config.NormalizeBSVNetwork(s)
+	return network
+}
+
+// explorers are the block explorers linked to in command output, set up from
+// the config by initGlobals.
+//
+//nolint:gochecknoglobals // Shared by the output of every command, like cfg
+var explorers *explorer.Registry
+
+// newExplorerRegistry returns the built-in explorers with the overrides in
+// the explorers section of the config. Invalid entries are skipped; config
+// validate reports them.
+func newExplorerRegistry(c *config.Config) *explorer.Registry {
+	r := explorer.New()
+	if c == nil {
+		return r
+	}
+	for key, e := range c.GetExplorers() {
+		chainName, network, err := config.ParseExplorerKey(key)
+		if err != nil {
+			continue
 		}
+		r.Set(chain.ID(chainName), network, explorer.Explorer{TxURL: e.Tx, AddressURL: e.Address})
 	}
-	return []string{"https://whatsonchain.com/tx/" + txid}
+	return r
 }
 
-// bsvExplorerAddressLinks returns explorer URLs for a BSV address (see bsvExplorerTxLinks).
-func bsvExplorerAddressLinks(network, address string) []string {
-	if network == "test" {
-		return []string{
-			"https://test.whatsonchain.com/address/" + address,
-			"https://test.bananablocks.com/address/" + address,
+// explorerRegistry returns the explorers set up by initGlobals, or the
+// built-in ones before then.
+func explorerRegistry() *explorer.Registry {
+	if explorers == nil {
+		return explorer.New()
+	}
+	return explorers
+}
+
+// explorerTitle names the explorer of a chain and network for a heading such
+// as "View on Etherscan", or returns "block explorer" when there are several
+// or the configured one has no name.
+func explorerTitle(chainID chain.ID, network string) string {
+	list := explorerRegistry().Explorers(chainID, network)
+	if len(list) == 1 && list[0].Name != "" {
+		return list[0].Name
+	}
+	return "block explorer"
+}
+
+// explorerTxLinks returns the explorer URLs of a transaction, primary first.
+// BSV testnet has two built-in explorers; other chains have one.
+func explorerTxLinks(chainID chain.ID, network, txid string) []string {
+	return explorerRegistry().TxLinks(chainID, network, txid)
+}
+
+// explorerTxLink returns the primary explorer URL of a transaction.
+func explorerTxLink(chainID chain.ID, network, txid string) string {
+	return explorerRegistry().TxLink(chainID, network, txid)
+}
+
+// explorerAddressLinks returns the explorer URLs of an address, primary first.
+func explorerAddressLinks(chainID chain.ID, network, address string) []string {
+	return explorerRegistry().AddressLinks(chainID, network, address)
+}
+
+// explorerAddressLink returns the primary explorer URL of an address.
+func explorerAddressLink(chainID chain.ID, network, address string) string {
+	return explorerRegistry().AddressLink(chainID, network, address)
+}
+
+// enabledChains returns the chains commands accept: ETH and BSV, plus BTC
+// and BCH when networks.btc.enabled and networks.bch.enabled are set.
+func enabledChains(cfg ConfigProvider) []chain.ID {
+	chains := []chain.ID{chain.ETH, chain.BSV}
+	if cfg != nil && cfg.IsBTCEnabled() {
+		chains = append(chains, chain.BTC)
+	}
+	if cfg != nil && cfg.IsBCHEnabled() {
+		chains = append(chains, chain.BCH)
+	}
+	return chains
+}
+
+// walletChainsForCmd returns the chains a new wallet is created with.
+func walletChainsForCmd(cmd *cobra.Command) []wallet.ChainID {
+	if cc := GetCmdContext(cmd); cc != nil {
+		return enabledChains(cc.Cfg)
+	}
+	return enabledChains(nil)
+}
+
+// parseEnabledChain parses a --chain value, rejecting chains that are not
+// enabled in the configuration.
+func parseEnabledChain(cfg ConfigProvider, name string) (chain.ID, error) {
+	chains := enabledChains(cfg)
+	if id, ok := chain.ParseChainID(name); ok {
+		for _, enabled := range chains {
+			if id == enabled {
+				return id, nil
+			}
 		}
 	}
-	return []string{"https://whatsonchain.com/address/" + address}
+
+	names := make([]string, len(chains))
+	for i, id := range chains {
+		names[i] = string(id)
+	}
+	list := strings.Join(names[:len(names)-1], ", ")
+	if len(names) > 2 {
+		list += ","
+	}
+	suggestion := fmt.Sprintf("invalid chain: %s (use %s or %s)", name, list, names[len(names)-1])
+	if id, ok := chain.ParseChainID(name); ok && (id == chain.BTC || id == chain.BCH) {
+		suggestion += fmt.Sprintf("; set networks.%s.enabled to use %s", id, id)
+	}
+	return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, suggestion)
 }
 
 // warnNetworkConflict prints a fail-closed warning when a --network/--testnet flag
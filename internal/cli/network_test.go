@@ -1,11 +1,16 @@
 package cli
 
 import (
+	"context"
 	"testing"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/wallet"
 )
 
@@ -41,22 +46,71 @@ func TestBsvClientAndWalletNetworkMapping(t *testing.T) {
 	assert.Equal(t, wallet.Mainnet, walletNetwork("main"))
 }
 
-func TestBSVExplorerLinks(t *testing.T) {
+func TestExplorerLinks(t *testing.T) {
 	t.Parallel()
 
 	// Mainnet: single WhatsOnChain link.
-	mainTx := bsvExplorerTxLinks("main", "deadbeef")
+	mainTx := explorerTxLinks(chain.BSV, "main", "deadbeef")
 	assert.Equal(t, []string{"https://whatsonchain.com/tx/deadbeef"}, mainTx)
 
 	// Testnet: WhatsOnChain test (primary) + bananablocks test (secondary).
-	testTx := bsvExplorerTxLinks("test", "deadbeef")
+	testTx := explorerTxLinks(chain.BSV, "test", "deadbeef")
 	assert.Equal(t, []string{
 		"https://test.whatsonchain.com/tx/deadbeef",
 		"https://test.bananablocks.com/tx/deadbeef",
 	}, testTx)
 
-	testAddr := bsvExplorerAddressLinks("test", "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r")
+	testAddr := explorerAddressLinks(chain.BSV, "test", "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r")
 	assert.Len(t, testAddr, 2)
 	assert.Contains(t, testAddr[0], "test.whatsonchain.com/address/")
 	assert.Contains(t, testAddr[1], "test.bananablocks.com/address/")
+
+	assert.Equal(t, "https://etherscan.io/tx/0xabc", explorerTxLink(chain.ETH, "main", "0xabc"))
+	assert.Equal(t, "https://sepolia.etherscan.io/tx/0xabc", explorerTxLink(chain.ETH, "test", "0xabc"))
+	assert.Equal(t, "https://sepolia.etherscan.io/address/0xdef", explorerAddressLink(chain.ETH, "test", "0xdef"))
+	assert.Empty(t, explorerTxLink(chain.LTC, "main", "aa"), "no explorer for the chain")
+
+	assert.Equal(t, "Etherscan", explorerTitle(chain.ETH, "main"))
+	assert.Equal(t, "block explorer", explorerTitle(chain.BSV, "test"), "several explorers")
+}
+
+func TestNewExplorerRegistry(t *testing.T) {
+	t.Parallel()
+
+	c := config.Defaults()
+	c.Explorers = map[string]config.ExplorerConfig{
+		"bsv-test":  {Tx: "http://explorer.lan/tx/{txid}"},
+		"eth-main":  {Address: "https://eth.example/a/{address}"},
+		"doge-main": {Tx: "https://doge.example/{txid}"},
+	}
+	r := newExplorerRegistry(c)
+
+	assert.Equal(t, []string{"http://explorer.lan/tx/aa"}, r.TxLinks(chain.BSV, "test", "aa"), "an override replaces every built-in explorer")
+	assert.Equal(t, "https://test.whatsonchain.com/address/mx", r.AddressLink(chain.BSV, "test", "mx"), "unset URLs keep the built-in one")
+	assert.Equal(t, "https://eth.example/a/0xdef", r.AddressLink(chain.ETH, "main", "0xdef"))
+	assert.Equal(t, "https://etherscan.io/tx/0xabc", r.TxLink(chain.ETH, "main", "0xabc"))
+	assert.Equal(t, "https://whatsonchain.com/tx/aa", r.TxLink(chain.BSV, "main", "aa"), "other networks are unchanged")
+}
+
+//nolint:paralleltest // Switches the process-wide ETH token registry
+func TestUseWalletNetwork(t *testing.T) {
+	defer eth.UseChain(eth.MainnetChainID)
+
+	c := config.Defaults()
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{Cfg: c})
+
+	useWalletNetwork(cmd, &wallet.Wallet{Name: "t", Network: "test"})
+	assert.Equal(t, "test", ethNetwork(c))
+	assert.Equal(t, "11155111", etherscanOptions(c).ChainID)
+	usdc, ok := eth.LookupKnownToken("USDC")
+	assert.True(t, ok)
+	assert.Equal(t, eth.USDCSepolia, usdc.Address)
+
+	useWalletNetwork(cmd, &wallet.Wallet{Name: "m", Network: "main"})
+	assert.Equal(t, "main", ethNetwork(c))
+	assert.Equal(t, "1", etherscanOptions(c).ChainID)
+	usdc, _ = eth.LookupKnownToken("USDC")
+	assert.Equal(t, eth.USDCMainnet, usdc.Address)
 }
@@ -0,0 +1,356 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/txintent"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// intentActionExpired is the outbox flush action for a queued send that
+// expired before it could be broadcast.
+const intentActionExpired = "expired"
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// outboxWallet limits the outbox commands to one wallet.
+	outboxWallet string
+)
+
+// outboxCmd is the parent command for the broadcast outbox.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var outboxCmd = &cobra.Command{
+	Use:   "outbox",
+	Short: "Manage sends queued while the network was unreachable",
+	Long: `Manage the broadcast outbox of BSV, BTC, and BCH sends.
+
+A send made with --queue, or with outbox.enabled in the config, is queued
+instead of failed when no broadcast provider can be reached. The signed
+transaction is kept in ~/.sigil/wallets/<name>/intents.json and its inputs
+stay reserved, so later sends will not spend them.
+
+'outbox flush' broadcasts the queued sends once the network is back, and
+'sigil serve' flushes its wallet's outbox every
+outbox.flush_interval_seconds. A queued send that has not reached the
+network by outbox.expiry_hours is released and its inputs can be spent
+again.`,
+}
+
+// outboxListCmd lists the queued sends.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var outboxListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List queued sends",
+	Long: `List the sends waiting in the outbox, with when they expire, how often
+their broadcast was tried, and why the last try failed.
+
+Without --wallet, every wallet with queued sends is listed.`,
+	Example: `  sigil outbox list
+  sigil outbox list --wallet main -o json`,
+	Args: cobra.NoArgs,
+	RunE: runOutboxList,
+}
+
+// outboxFlushCmd broadcasts the queued sends.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var outboxFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Broadcast queued sends",
+	Long: `Broadcast the sends waiting in the outbox.
+
+Each queued send is first checked against the network:
+
+  broadcast    An input is spent, so the transaction made it. The send is
+               recorded in tx history and leaves the outbox.
+  unbroadcast  The signed transaction is broadcast again. Once accepted it
+               is recorded in tx history; if it fails, it stays queued.
+  expired      A send past its expiry that never reached the network is
+               released, and its inputs can be spent again.
+
+Without --wallet, every wallet with queued sends is flushed.`,
+	Example: `  sigil outbox flush
+  sigil outbox flush --wallet main`,
+	Args: cobra.NoArgs,
+	RunE: runOutboxFlush,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	outboxCmd.GroupID = "wallet"
+	rootCmd.AddCommand(outboxCmd)
+	outboxCmd.AddCommand(outboxListCmd)
+	outboxCmd.AddCommand(outboxFlushCmd)
+
+	for _, c := range []*cobra.Command{outboxListCmd, outboxFlushCmd} {
+		c.Flags().StringVarP(&outboxWallet, "wallet", "w", "", "only this wallet (default every wallet with queued sends)")
+	}
+}
+
+// outboxItem is a queued send, and in flush output what became of it.
+type outboxItem struct {
+	Wallet    string    `json:"wallet"`
+	Hash      string    `json:"hash"`
+	Chain     string    `json:"chain"`
+	To        string    `json:"to"`
+	Amount    string    `json:"amount"`
+	Fee       string    `json:"fee"`
+	QueuedAt  time.Time `json:"queued_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	Action    string    `json:"action,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// newOutboxItem describes a queued intent of walletName.
+func newOutboxItem(walletName string, intent *txintent.Intent) outboxItem {
+	return outboxItem{
+		Wallet:    walletName,
+		Hash:      intent.Hash,
+		Chain:     string(intent.Chain),
+		To:        intent.To,
+		Amount:    formatIntentUnits(intent.Amount),
+		Fee:       formatIntentUnits(intent.Fee),
+		QueuedAt:  intent.QueuedAt,
+		ExpiresAt: intent.ExpiresAt,
+		Attempts:  intent.Attempts,
+		LastError: intent.LastError,
+	}
+}
+
+func runOutboxList(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+	walletsDir := filepath.Join(cc.Cfg.GetHome(), "wallets")
+	names, err := outboxWallets(walletsDir, outboxWallet)
+	if err != nil {
+		return err
+	}
+
+	items := make([]outboxItem, 0)
+	for _, name := range names {
+		intents, err := queuedIntents(txintent.New(filepath.Join(walletsDir, name)))
+		if err != nil {
+			return err
+		}
+		for i := range intents {
+			items = append(items, newOutboxItem(name, &intents[i]))
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, items)
+	}
+	outputOutbox(w, items, false)
+	return nil
+}
+
+func runOutboxFlush(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+	walletsDir := filepath.Join(cc.Cfg.GetHome(), "wallets")
+	names, err := outboxWallets(walletsDir, outboxWallet)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := contextWithTimeout(cmd, 2*time.Minute)
+	defer cancel()
+
+	storage := wallet.NewFileStorage(walletsDir)
+	svc := transaction.NewService(&transaction.Config{Config: cc.Cfg, Logger: cc.Log})
+	items := make([]outboxItem, 0)
+	for _, name := range names {
+		flushed, err := flushWalletOutbox(ctx, cmd, storage, svc, walletsDir, name)
+		if err != nil {
+			return err
+		}
+		items = append(items, flushed...)
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, items)
+	}
+	outputOutbox(w, items, true)
+	return nil
+}
+
+// flushWalletOutbox flushes one wallet's outbox under its wallet lock, so a
+// send running alongside cannot change the UTXO store underneath it.
+func flushWalletOutbox(ctx context.Context, cmd *cobra.Command, storage *wallet.FileStorage, resolver intentResolver, walletsDir, name string) ([]outboxItem, error) {
+	lock, err := lockWallet(cmd, storage, name, "outbox flush")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Release() }()
+
+	log := txintent.New(filepath.Join(walletsDir, name))
+	intents, err := queuedIntents(log)
+	if err != nil {
+		return nil, err
+	}
+	return flushOutbox(ctx, resolver, log, name, intents, time.Now()), nil
+}
+
+// outboxWallets returns the wallets to work on: name when given, otherwise
+// every wallet under walletsDir with queued sends, sorted.
+func outboxWallets(walletsDir, name string) ([]string, error) {
+	if name != "" {
+		return []string{name}, nil
+	}
+	counts, err := txintent.Outbox(walletsDir)
+	if err != nil {
+		return nil, fmt.Errorf("reading outboxes: %w", err)
+	}
+	names := make([]string, 0, len(counts))
+	for n := range counts {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// queuedIntents returns the queued intents of a wallet's intent log.
+func queuedIntents(log *txintent.Log) ([]txintent.Intent, error) {
+	intents, err := log.Intents()
+	if err != nil {
+		return nil, fmt.Errorf("loading send intents: %w", err)
+	}
+	queued := intents[:0]
+	for _, intent := range intents {
+		if intent.Queued() {
+			queued = append(queued, intent)
+		}
+	}
+	return queued, nil
+}
+
+// flushOutbox checks each queued intent against the network. Broadcast ones
+// are finalized, expired ones released, and the rest broadcast again. A send
+// that fails stays queued, with the attempt and its error written to log.
+func flushOutbox(ctx context.Context, resolver intentResolver, log *txintent.Log, walletName string, intents []txintent.Intent, now time.Time) []outboxItem {
+	items := make([]outboxItem, 0, len(intents))
+	for i := range intents {
+		intent := &intents[i]
+		item := newOutboxItem(walletName, intent)
+
+		state, err := resolver.CheckIntent(ctx, intent)
+		switch {
+		case err != nil:
+			// Unknown state: never release, even past expiry
+		case state == transaction.IntentBroadcast:
+			item.Action = intentActionRecorded
+			err = resolver.FinalizeIntent(walletName, intent)
+		case intent.Expired(now):
+			item.Action = intentActionExpired
+			err = resolver.ReleaseIntent(walletName, intent.Hash)
+		default:
+			item.Action = intentActionRebroadcast
+			intent.Attempts++
+			_, err = resolver.RebroadcastIntent(ctx, walletName, intent)
+		}
+		if err != nil {
+			item.Action, item.Error = intentActionFailed, err.Error()
+			intent.LastError = err.Error()
+			// Keep the attempt on record; the intent itself is unchanged
+			_ = log.Record(*intent)
+			item.Attempts, item.LastError = intent.Attempts, intent.LastError
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// outputOutbox shows queued sends in text format, with the flush outcome of
+// each when flushed is set.
+func outputOutbox(w io.Writer, items []outboxItem, flushed bool) {
+	if len(items) == 0 {
+		outln(w, "No queued sends")
+		return
+	}
+
+	queued := 0
+	for _, item := range items {
+		out(w, "  Wallet:  %s\n", item.Wallet)
+		out(w, "  Hash:    %s\n", item.Hash)
+		out(w, "  Send:    %s %s to %s (fee %s)\n", item.Amount, strings.ToUpper(item.Chain), item.To, item.Fee)
+		out(w, "  Queued:  %s, expires %s\n",
+			item.QueuedAt.Local().Format(time.DateTime), item.ExpiresAt.Local().Format(time.DateTime))
+		if !flushed {
+			queued++
+			if item.LastError != "" {
+				out(w, "  Tries:   %d, last failed: %s\n", item.Attempts, item.LastError)
+			}
+			outln(w)
+			continue
+		}
+		switch item.Action {
+		case intentActionRecorded:
+			outln(w, "  Result:  reached the network; recorded in tx history")
+		case intentActionRebroadcast:
+			outln(w, "  Result:  broadcast; recorded in tx history")
+		case intentActionExpired:
+			outln(w, "  Result:  expired; released, its inputs can be spent again")
+		default:
+			queued++
+			out(w, "  Result:  still queued after %d tries: %s\n", item.Attempts, item.Error)
+		}
+		outln(w)
+	}
+
+	if queued > 0 {
+		out(w, "%d send(s) queued. Broadcast them with: sigil outbox flush\n", queued)
+	}
+}
+
+// displayQueuedResult shows a send that was queued in the outbox because no
+// broadcast provider could be reached.
+func displayQueuedResult(cmd *cobra.Command, walletName string, result *transaction.SendResult) {
+	w := cmd.OutOrStdout()
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		_ = writeJSON(w, struct {
+			Hash        string    `json:"hash"`
+			Chain       string    `json:"chain"`
+			From        string    `json:"from"`
+			To          string    `json:"to"`
+			Amount      string    `json:"amount"`
+			Fee         string    `json:"fee"`
+			Status      string    `json:"status"`
+			QueuedUntil time.Time `json:"queued_until"`
+		}{
+			Hash:        result.Hash,
+			Chain:       string(result.ChainID),
+			From:        result.From,
+			To:          result.To,
+			Amount:      result.Amount,
+			Fee:         result.Fee,
+			Status:      result.Status,
+			QueuedUntil: result.QueuedUntil,
+		})
+		return
+	}
+
+	symbol := strings.ToUpper(string(result.ChainID))
+	outln(w, "\nNo broadcast provider was reachable; the transaction is queued.")
+	outln(w)
+	out(w, "  Hash:   %s\n", result.Hash)
+	out(w, "  Status: %s until %s\n", result.Status, result.QueuedUntil.Local().Format(time.DateTime))
+	out(w, "  Amount: %s %s\n", result.Amount, symbol)
+	out(w, "  Fee:    %s %s\n", result.Fee, symbol)
+	outln(w)
+	outln(w, "Its inputs stay reserved. Broadcast it once the network is back with:")
+	out(w, "  sigil outbox flush --wallet %s\n", walletName)
+}
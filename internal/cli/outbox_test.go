@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/txintent"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+var errTestUnreachable = errors.New("dial tcp: connection refused")
+
+func TestFlushOutbox(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	queued := func(hash string, expiresIn time.Duration) txintent.Intent {
+		return txintent.Intent{
+			Hash: hash, Chain: chain.BSV, To: "1To", Amount: "5000", Fee: "20",
+			QueuedAt: now.Add(-time.Hour), ExpiresAt: now.Add(expiresIn), Attempts: 1,
+		}
+	}
+	intents := []txintent.Intent{
+		queued("aa", time.Hour),  // reached the network after all
+		queued("bb", time.Hour),  // still waiting
+		queued("cc", -time.Hour), // expired
+	}
+	states := map[string]transaction.IntentState{
+		"aa": transaction.IntentBroadcast,
+		"bb": transaction.IntentUnbroadcast,
+		"cc": transaction.IntentUnbroadcast,
+	}
+
+	t.Run("network back", func(t *testing.T) {
+		t.Parallel()
+		log := txintent.New(t.TempDir())
+		resolver := &fakeIntentResolver{states: states}
+		items := flushOutbox(context.Background(), resolver, log, "main", append([]txintent.Intent(nil), intents...), now)
+		require.Len(t, items, 3)
+		assert.Equal(t, []string{intentActionRecorded, intentActionRebroadcast, intentActionExpired},
+			[]string{items[0].Action, items[1].Action, items[2].Action})
+		assert.Equal(t, []string{"finalize:aa", "rebroadcast:bb", "release:cc"}, resolver.resolved)
+		assert.Equal(t, "main", items[0].Wallet)
+		assert.Equal(t, "0.00005", items[0].Amount)
+	})
+
+	t.Run("still unreachable", func(t *testing.T) {
+		t.Parallel()
+		log := txintent.New(t.TempDir())
+		require.NoError(t, log.Record(intents[1]))
+		resolver := &fakeIntentResolver{states: states, rebroadcastErr: errTestUnreachable}
+		items := flushOutbox(context.Background(), resolver, log, "main", []txintent.Intent{intents[1]}, now)
+		require.Len(t, items, 1)
+		assert.Equal(t, intentActionFailed, items[0].Action)
+		assert.Equal(t, 2, items[0].Attempts)
+
+		// The attempt is kept on record and the send stays queued
+		kept, err := log.Get("bb")
+		require.NoError(t, err)
+		assert.True(t, kept.Queued())
+		assert.Equal(t, 2, kept.Attempts)
+		assert.Equal(t, errTestUnreachable.Error(), kept.LastError)
+	})
+
+	t.Run("expired but unchecked is kept", func(t *testing.T) {
+		t.Parallel()
+		log := txintent.New(t.TempDir())
+		resolver := &fakeIntentResolver{states: states, checkErr: errTestUnreachable}
+		items := flushOutbox(context.Background(), resolver, log, "main", []txintent.Intent{intents[2]}, now)
+		require.Len(t, items, 1)
+		assert.Equal(t, intentActionFailed, items[0].Action)
+		assert.Empty(t, resolver.resolved, "a send whose state is unknown must not be released")
+	})
+}
+
+func TestOutputOutbox(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	outputOutbox(&buf, nil, false)
+	assert.Contains(t, buf.String(), "No queued sends")
+
+	buf.Reset()
+	item := outboxItem{Wallet: "main", Hash: "aa", Chain: "bsv", To: "1To", Amount: "0.00005", Fee: "0.0000002",
+		Attempts: 2, LastError: "connection refused"}
+	outputOutbox(&buf, []outboxItem{item}, false)
+	assert.Contains(t, buf.String(), "0.00005 BSV to 1To")
+	assert.Contains(t, buf.String(), "last failed: connection refused")
+	assert.Contains(t, buf.String(), "1 send(s) queued")
+
+	buf.Reset()
+	item.Action = intentActionExpired
+	outputOutbox(&buf, []outboxItem{item}, true)
+	assert.Contains(t, buf.String(), "expired; released")
+	assert.NotContains(t, buf.String(), "queued.")
+}
+
+func TestOutboxWallets(t *testing.T) {
+	t.Parallel()
+
+	walletsDir := t.TempDir()
+	for _, name := range []string{"savings", "main"} {
+		require.NoError(t, txintent.New(filepath.Join(walletsDir, name)).Record(txintent.Intent{
+			Hash: "aa", Chain: chain.BSV, QueuedAt: time.Now(),
+		}))
+	}
+	require.NoError(t, txintent.New(filepath.Join(walletsDir, "other")).Record(txintent.Intent{Hash: "bb", Chain: chain.BSV}))
+
+	names, err := outboxWallets(walletsDir, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"main", "savings"}, names)
+
+	names, err = outboxWallets(walletsDir, "other")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"other"}, names)
+}
+
+//nolint:paralleltest // Mutates the txQueue flag variable
+func TestResolveTxQueue(t *testing.T) {
+	defer func() { txQueue = false }()
+	enabled := &mockConfigProvider{outbox: config.OutboxConfig{Enabled: true, ExpiryHours: 6}}
+	disabled := &mockConfigProvider{outbox: config.OutboxConfig{ExpiryHours: 6}}
+
+	txQueue = false
+	expiry, err := resolveTxQueue(enabled, chain.BSV, false)
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, expiry)
+
+	// --queue=false overrides the config
+	expiry, err = resolveTxQueue(enabled, chain.BSV, true)
+	require.NoError(t, err)
+	assert.Zero(t, expiry)
+
+	txQueue = true
+	expiry, err = resolveTxQueue(disabled, chain.BTC, true)
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, expiry)
+
+	// ETH sends are never queued, and asking for it is an error
+	expiry, err = resolveTxQueue(enabled, chain.ETH, false)
+	require.NoError(t, err)
+	assert.Zero(t, expiry)
+	_, err = resolveTxQueue(disabled, chain.ETH, true)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	_, err = resolveTxQueue(&mockConfigProvider{}, chain.BSV, true)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/pwstrength"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// passwordPolicy is the strength policy applied to new wallet passwords.
+type passwordPolicy struct {
+	// minEntropy is the minimum estimated strength in bits (0 disables the check).
+	minEntropy float64
+	// breachList is the path of an optional breached password list.
+	breachList string
+	// allowWeak downgrades a failed check to a warning (--weak-password-ok).
+	allowWeak bool
+}
+
+// newPasswordPolicy builds the password policy from the security config and
+// the command's --weak-password-ok flag.
+func newPasswordPolicy(cmd *cobra.Command, allowWeak bool) passwordPolicy {
+	policy := passwordPolicy{allowWeak: allowWeak}
+
+	cc := GetCmdContext(cmd)
+	if cc == nil || cc.Cfg == nil {
+		return policy
+	}
+	sec := cc.Cfg.GetSecurity()
+	policy.minEntropy = sec.MinPasswordEntropy
+	policy.breachList = sec.BreachedPasswordList
+
+	if strings.HasPrefix(policy.breachList, "~/") {
+		if userHome, err := os.UserHomeDir(); err == nil {
+			policy.breachList = filepath.Join(userHome, policy.breachList[2:])
+		}
+	}
+	return policy
+}
+
+// checkPasswordPolicy refuses a password that is weaker than the policy allows
+// or appears in the breached password list. With allowWeak the problem is
+// written to w as a warning instead.
+func checkPasswordPolicy(password []byte, policy passwordPolicy, w io.Writer) error {
+	var problems []string
+
+	if policy.minEntropy > 0 {
+		est := pwstrength.Evaluate(password)
+		if est.Bits < policy.minEntropy {
+			problem := fmt.Sprintf("password is too weak (estimated %.0f bits, minimum %.0f)", est.Bits, policy.minEntropy)
+			if est.Warning != "" {
+				problem += ": " + est.Warning
+			}
+			problems = append(problems, problem)
+		}
+	}
+
+	if policy.breachList != "" {
+		breached, err := pwstrength.Breached(policy.breachList, password)
+		switch {
+		case err != nil:
+			out(w, "Warning: could not check breached password list: %v\n", err)
+		case breached:
+			problems = append(problems, "password appears in the breached password list")
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	if policy.allowWeak {
+		for _, p := range problems {
+			out(w, "Warning: %s\n", p)
+		}
+		return nil
+	}
+
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrInvalidInput,
+		strings.Join(problems, "; ")+". Use a longer passphrase, or pass --weak-password-ok to use it anyway",
+	)
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1" //nolint:gosec // breached password lists are SHA-1
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestCheckPasswordPolicy(t *testing.T) {
+	t.Parallel()
+
+	sum := sha1.Sum([]byte("zq8vnr2wlkmx7#")) //nolint:gosec // test fixture
+	breachList := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(breachList, []byte(strings.ToUpper(hex.EncodeToString(sum[:]))+":3\n"), 0o600))
+
+	tests := []struct {
+		name        string
+		password    string
+		policy      passwordPolicy
+		wantErr     bool
+		wantWarning string
+	}{
+		{
+			name:     "strong password",
+			password: "correct horse battery staple",
+			policy:   passwordPolicy{minEntropy: 40},
+		},
+		{
+			name:     "weak password refused",
+			password: "Password123!",
+			policy:   passwordPolicy{minEntropy: 40},
+			wantErr:  true,
+		},
+		{
+			name:        "weak password allowed with warning",
+			password:    "Password123!",
+			policy:      passwordPolicy{minEntropy: 40, allowWeak: true},
+			wantWarning: "Warning: password is too weak",
+		},
+		{
+			name:     "strength check disabled",
+			password: "Password123!",
+			policy:   passwordPolicy{},
+		},
+		{
+			name:     "breached password refused",
+			password: "zq8vnr2wlkmx7#",
+			policy:   passwordPolicy{minEntropy: 40, breachList: breachList},
+			wantErr:  true,
+		},
+		{
+			name:        "unreadable breach list warns",
+			password:    "correct horse battery staple",
+			policy:      passwordPolicy{breachList: filepath.Join(t.TempDir(), "missing.txt")},
+			wantWarning: "could not check breached password list",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			err := checkPasswordPolicy([]byte(tt.password), tt.policy, &buf)
+			if tt.wantErr {
+				require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+				var se *sigilerr.SigilError
+				require.ErrorAs(t, err, &se)
+				assert.Contains(t, se.Suggestion, "--weak-password-ok")
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantWarning == "" {
+				assert.Empty(t, buf.String())
+			} else {
+				assert.Contains(t, buf.String(), tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestNewPasswordPolicy(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{
+		Cfg: &mockConfigProvider{security: config.SecurityConfig{
+			MinPasswordEntropy:   50,
+			BreachedPasswordList: "/data/pwned.txt",
+		}},
+	})
+
+	policy := newPasswordPolicy(cmd, true)
+	assert.InDelta(t, 50.0, policy.minEntropy, 0)
+	assert.Equal(t, "/data/pwned.txt", policy.breachList)
+	assert.True(t, policy.allowWeak)
+
+	bare := &cobra.Command{}
+	assert.Equal(t, passwordPolicy{}, newPasswordPolicy(bare, false))
+}
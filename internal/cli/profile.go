@@ -0,0 +1,289 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/profile"
+	"github.com/mrz1836/sigil/internal/sigilcrypto"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// profileName names an exported profile.
+	profileName string
+	// profileEncryptTo is the age or GPG recipient an export is encrypted to.
+	profileEncryptTo string
+	// profileOutput is the export file; empty writes to stdout.
+	profileOutput string
+	// profileSigningKey is the ed25519 key file exports are signed with.
+	profileSigningKey string
+	// profileSigner is the hex public key an import must be signed by.
+	profileSigner string
+	// profileIdentity is the age identity file an import is decrypted with.
+	profileIdentity string
+	// profileConfirm skips the import review prompt.
+	profileConfirm bool
+)
+
+// profileCmd is the parent command for shared configuration profiles.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Share configuration profiles across a team",
+	Long: `Export and import signed, encrypted configuration profiles.
+
+A profile carries the settings a team wants every member to use: network
+endpoints and token lists, fee settings, the co-signing service and
+confirmation threshold, and wallet templates. API keys, wallets, and seeds
+are never included.
+
+Every profile is signed with the exporter's ed25519 key and encrypted to
+one recipient. Importers give the signer's public key, obtained out of band,
+and nothing is applied unless the signature verifies against it.`,
+}
+
+// profileExportCmd exports the shareable configuration as a signed bundle.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var profileExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export a signed, encrypted configuration profile",
+	Long: `Export the shareable parts of the current configuration as a profile,
+signed with your profile signing key and encrypted to a recipient.
+
+The signing key is read from --signing-key, by default
+~/.sigil/profile_signing.key. It is created on first export; share the
+public key it prints with your team so they can verify your profiles.
+
+--encrypt-to takes an age public key (age1...) or a GPG key ID, fingerprint,
+or email. Export once per recipient.`,
+	Example: `  # Export for a teammate's age key
+  sigil profile export --name acme-ops --encrypt-to age1ql3z7hjy54pw3hyww5ayyfg7zqgvc7w3j2elw8zmrj2kg5sfn9aqmcac8p --output acme-ops.profile
+
+  # Export for a GPG recipient
+  sigil profile export --name acme-ops --encrypt-to ops@example.com > acme-ops.profile`,
+	RunE: runProfileExport,
+}
+
+// profileImportCmd verifies and applies a profile.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var profileImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Verify and apply a configuration profile",
+	Long: `Decrypt a profile, verify it was signed by the expected key, and write
+its settings into the configuration file.
+
+--signer is the exporter's public key, printed by profile export. Confirm it
+with the exporter over a channel you trust; a profile signed by any other key
+is rejected.
+
+age profiles are decrypted with --identity, by default encryption.identity_file
+(~/.sigil/identity.age). GPG profiles are decrypted by gpg with your keyring.
+
+Imported settings replace networks, fees, security.cosign, and
+security.require_confirm_above. Wallet templates are merged by name. Your API
+keys and all other settings are kept. Use - to read the profile from stdin.`,
+	Example: `  # Import a profile from a teammate
+  sigil profile import acme-ops.profile --signer 3b6a27bcceb6a42d62a3a8d02a6f0d73653215771de243a63ac048a18b59da29
+
+  # Decrypt with a specific age identity and skip the review
+  sigil profile import acme-ops.profile --signer 3b6a27bc... --identity ~/keys/me.age --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileImport,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	profileCmd.GroupID = "config"
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileExportCmd)
+	profileCmd.AddCommand(profileImportCmd)
+
+	profileExportCmd.Flags().StringVar(&profileName, "name", "", "profile name shown to importers (required)")
+	profileExportCmd.Flags().StringVar(&profileEncryptTo, "encrypt-to", "", "age public key or GPG recipient to encrypt to (required)")
+	profileExportCmd.Flags().StringVar(&profileOutput, "output", "", "write the profile to this file instead of stdout")
+	profileExportCmd.Flags().StringVar(&profileSigningKey, "signing-key", "", "ed25519 signing key file (default ~/.sigil/profile_signing.key)")
+	if err := profileExportCmd.MarkFlagRequired("name"); err != nil {
+		panic(err)
+	}
+	if err := profileExportCmd.MarkFlagRequired("encrypt-to"); err != nil {
+		panic(err)
+	}
+
+	profileImportCmd.Flags().StringVar(&profileSigner, "signer", "", "hex ed25519 public key the profile must be signed by (required)")
+	profileImportCmd.Flags().StringVar(&profileIdentity, "identity", "", "age identity file (default encryption.identity_file)")
+	profileImportCmd.Flags().BoolVar(&profileConfirm, "yes", false, "skip the review prompt")
+	if err := profileImportCmd.MarkFlagRequired("signer"); err != nil {
+		panic(err)
+	}
+}
+
+func runProfileExport(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+
+	// Resolve the recipient first, so a bad key fails before a signing key is created
+	recipient, err := sigilcrypto.ParseRecipient(profileEncryptTo)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid --encrypt-to: %s (use an age1... public key or a GPG key ID/email)", err))
+	}
+
+	keyPath := profileSigningKey
+	if keyPath == "" {
+		keyPath = profile.KeyPath(cc.Cfg.GetHome())
+	}
+	key, created, err := profile.LoadOrCreateKey(expandHomePath(keyPath))
+	if err != nil {
+		return err
+	}
+
+	bundle, err := profile.Sign(profileName, profile.FromConfig(cfg), key, time.Now())
+	if err != nil {
+		return err
+	}
+	var plaintext strings.Builder
+	if err := writeJSON(&plaintext, bundle); err != nil {
+		return fmt.Errorf("encoding profile: %w", err)
+	}
+	ciphertext, err := recipient.Encrypt(cmd.Context(), []byte(plaintext.String()))
+	if err != nil {
+		return fmt.Errorf("encrypting profile: %w", err)
+	}
+
+	if profileOutput == "" {
+		if _, err := cmd.OutOrStdout().Write(ciphertext); err != nil {
+			return fmt.Errorf("writing profile: %w", err)
+		}
+	} else if err := os.WriteFile(profileOutput, ciphertext, 0o600); err != nil {
+		return fmt.Errorf("writing %s: %w", profileOutput, err)
+	}
+
+	// Status goes to stderr so stdout can be redirected to a file
+	w := cmd.ErrOrStderr()
+	if created {
+		out(w, "Created profile signing key %s\n", keyPath)
+	}
+	out(w, "Exported profile '%s' encrypted to %s\n", profileName, recipient.Key)
+	out(w, "Signer: %s\n", bundle.Signer)
+	outln(w, "Share the signer key with importers over a trusted channel; they pass it as --signer.")
+	return nil
+}
+
+func runProfileImport(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+	if _, err := profile.ParsePublicKey(profileSigner); err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("invalid --signer: %s", err))
+	}
+
+	ciphertext, err := readOfflineInput(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	identity := profileIdentity
+	if identity == "" {
+		identity = cfg.Encryption.IdentityFile
+	}
+	plaintext, err := sigilcrypto.DecryptFromRecipient(cmd.Context(), ciphertext, expandHomePath(identity))
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("decrypting profile: %s (use --identity to choose the age identity file)", err))
+	}
+
+	bundle, err := profile.Parse(plaintext)
+	if err != nil {
+		return err
+	}
+	settings, err := bundle.Verify(profileSigner)
+	if errors.Is(err, profile.ErrUntrustedSigner) {
+		return sigilerr.WithSuggestion(err,
+			fmt.Sprintf("the profile is signed by %s; confirm that key with the exporter before trusting it", bundle.Signer))
+	}
+	if err != nil {
+		return err
+	}
+
+	if !profileConfirm {
+		displayProfileSummary(cmd.ErrOrStderr(), bundle, settings)
+		if !promptConfirmFn() {
+			outln(cmd.OutOrStdout(), "Import canceled.")
+			return nil
+		}
+	}
+
+	configPath := config.FindPath(cc.Cfg.GetHome())
+	current, err := config.Load(configPath)
+	if err != nil {
+		current = config.Defaults()
+	}
+	settings.Apply(current)
+	if err := config.Save(current, configPath); err != nil {
+		if errors.Is(err, config.ErrEnvOnly) {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+				"configuration comes from the environment (SIGIL_CONFIG=env); there is no file to import into")
+		}
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(cmd.OutOrStdout(), struct {
+			Name       string    `json:"name"`
+			Signer     string    `json:"signer"`
+			CreatedAt  time.Time `json:"created_at"`
+			ConfigPath string    `json:"config_path"`
+		}{bundle.Name, bundle.Signer, bundle.CreatedAt, configPath})
+	}
+	out(cmd.OutOrStdout(), "Imported profile '%s' into %s\n", bundle.Name, configPath)
+	return nil
+}
+
+// displayProfileSummary shows where a verified profile came from and the
+// settings it will apply.
+func displayProfileSummary(w io.Writer, b *profile.Bundle, s *profile.Settings) {
+	out(w, "\nImport profile '%s'\n", b.Name)
+	out(w, "  Signed by: %s (verified)\n", b.Signer)
+	out(w, "  Created:   %s\n", b.CreatedAt.Local().Format("2006-01-02 15:04"))
+	out(w, "  ETH RPC:   %s\n", profileValue(s.Networks.ETH.RPC))
+	out(w, "  Tokens:    %d\n", len(s.Networks.ETH.Tokens))
+	out(w, "  BSV:       %s network, fee strategy %s\n", profileValue(s.Networks.BSV.Network), profileValue(s.Fees.BSVFeeStrategy))
+	out(w, "  Cosign:    %s\n", profileValue(s.Cosign.URL))
+	if len(s.WalletTemplates) > 0 {
+		names := make([]string, 0, len(s.WalletTemplates))
+		for name := range s.WalletTemplates {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		out(w, "  Templates: %s\n", strings.Join(names, ", "))
+	}
+}
+
+// profileValue returns v, or "-" when it is empty.
+func profileValue(v string) string {
+	if v == "" {
+		return "-"
+	}
+	return v
+}
+
+// expandHomePath replaces a leading ~/ with the user's home directory.
+func expandHomePath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if userHome, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(userHome, path[2:])
+		}
+	}
+	return path
+}
@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"filippo.io/age"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/profile"
+)
+
+func newProfileTestCmd() (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{Cfg: cfg, Fmt: &mockFormatProvider{format: output.FormatText}})
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	return cmd, &stdout, &stderr
+}
+
+func TestProfileExportImport(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+	t.Cleanup(func() {
+		profileName, profileEncryptTo, profileOutput, profileSigningKey = "", "", "", ""
+		profileSigner, profileIdentity, profileConfirm = "", "", false
+	})
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	identityFile := filepath.Join(tmpDir, "identity.age")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0o600))
+
+	cfg.Networks.ETH.RPC = "https://rpc.team.example"
+	cfg.Networks.ETH.EtherscanAPIKey = "exporter-secret"
+	cfg.Fees.BSVFeeStrategy = "economy"
+
+	bundlePath := filepath.Join(tmpDir, "team.profile")
+	profileName = "team"
+	profileEncryptTo = identity.Recipient().String()
+	profileOutput = bundlePath
+
+	cmd, _, stderr := newProfileTestCmd()
+	require.NoError(t, runProfileExport(cmd, nil))
+	assert.Contains(t, stderr.String(), "Created profile signing key")
+
+	data, err := os.ReadFile(bundlePath) //nolint:gosec // test file
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "rpc.team.example", "the profile is encrypted")
+
+	key, created, err := profile.LoadOrCreateKey(profile.KeyPath(tmpDir))
+	require.NoError(t, err)
+	require.False(t, created, "export saved its signing key")
+	signer := hex.EncodeToString(key.Public().(ed25519.PublicKey))
+	assert.Contains(t, stderr.String(), "Signer: "+signer)
+
+	// Import into a fresh configuration
+	cfg = config.Defaults()
+	cfg.Home = tmpDir
+	profileIdentity = identityFile
+	profileConfirm = true
+
+	t.Run("untrusted signer", func(t *testing.T) {
+		profileSigner = strings.Repeat("ab", 32)
+		cmd, _, _ := newProfileTestCmd()
+		err := runProfileImport(cmd, []string{bundlePath})
+		require.ErrorIs(t, err, profile.ErrUntrustedSigner)
+		_, statErr := os.Stat(config.Path(tmpDir))
+		assert.True(t, os.IsNotExist(statErr), "nothing is written for an untrusted profile")
+	})
+
+	t.Run("applies the profile", func(t *testing.T) {
+		profileSigner = signer
+		cmd, stdout, _ := newProfileTestCmd()
+		require.NoError(t, runProfileImport(cmd, []string{bundlePath}))
+		assert.Contains(t, stdout.String(), "Imported profile 'team'")
+
+		imported, err := config.Load(config.Path(tmpDir))
+		require.NoError(t, err)
+		assert.Equal(t, "https://rpc.team.example", imported.Networks.ETH.RPC)
+		assert.Equal(t, "economy", imported.Fees.BSVFeeStrategy)
+		assert.Empty(t, imported.Networks.ETH.EtherscanAPIKey, "API keys are not shared")
+	})
+}
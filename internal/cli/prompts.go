@@ -1,12 +1,14 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
 	"os"
 	"strings"
 
 	"golang.org/x/term"
 
+	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
@@ -19,7 +21,10 @@ var (
 	promptNewPasswordFn = promptNewPassword
 	promptPassphraseFn  = promptPassphrase
 	promptConfirmFn     = promptConfirmation
+	promptConfirmCodeFn = promptConfirmCode
 	promptSeedFn        = promptSeedMaterial
+	promptEntropyFn     = promptExtraEntropy
+	promptCoinPickFn    = promptCoinPick
 )
 
 // promptPassword prompts for a password with hidden input.
@@ -37,9 +42,51 @@ func promptPassword(prompt string) ([]byte, error) {
 	return password, nil
 }
 
-// promptNewPassword prompts for a new password with confirmation.
+// stdinIsTerminalFn reports whether stdin is a terminal (replaceable in tests).
+//
+//nolint:gochecknoglobals // Required for test injection
+var stdinIsTerminalFn = func() bool { return term.IsTerminal(int(os.Stdin.Fd())) }
+
+// promptWalletPassword prompts for the wallet password. When stdin is not a
+// terminal, a mounted secret file (SIGIL_WALLET_PASSWORD_FILE or
+// SIGIL_SECRETS_DIR) is used instead, with a notice on stderr. Interactive
+// sessions always prompt, so a leftover secret mount never unlocks a wallet
+// silently for someone at the keyboard.
+// The caller is responsible for zeroing the returned bytes after use.
+func promptWalletPassword(prompt string) ([]byte, error) {
+	return promptSecret(prompt, config.EnvWalletPassword, "wallet password")
+}
+
+// promptAgentStorePassphrase prompts for the agent store passphrase, read
+// from SIGIL_AGENT_STORE_PASSPHRASE_FILE or SIGIL_SECRETS_DIR when stdin is
+// not a terminal, like promptWalletPassword.
 // The caller is responsible for zeroing the returned bytes after use.
-func promptNewPassword() ([]byte, error) {
+func promptAgentStorePassphrase(prompt string) ([]byte, error) {
+	return promptSecret(prompt, config.EnvAgentStorePassphrase, "agent store passphrase")
+}
+
+// promptSecret prompts for a secret, or reads the named secret file when
+// stdin is not a terminal. label names the secret in the stderr notice.
+func promptSecret(prompt, name, label string) ([]byte, error) {
+	if stdinIsTerminalFn() {
+		return promptPasswordFn(prompt)
+	}
+
+	secret, err := config.LookupSecretFile(name)
+	if err != nil {
+		return nil, sigilerr.WithSuggestion(err, "check the secret file mount and its permissions")
+	}
+	if secret != "" {
+		outln(os.Stderr, "Using "+label+" from mounted secret file")
+		return []byte(secret), nil
+	}
+	return promptPasswordFn(prompt)
+}
+
+// promptNewPassword prompts for a new password with confirmation, refusing
+// passwords that fail the strength policy.
+// The caller is responsible for zeroing the returned bytes after use.
+func promptNewPassword(policy passwordPolicy) ([]byte, error) {
 	password, err := promptPassword("Enter encryption password: ")
 	if err != nil {
 		return nil, err
@@ -53,6 +100,11 @@ func promptNewPassword() ([]byte, error) {
 		)
 	}
 
+	if err = checkPasswordPolicy(password, policy, os.Stderr); err != nil {
+		wallet.ZeroBytes(password)
+		return nil, err
+	}
+
 	confirm, err := promptPassword("Confirm password: ")
 	if err != nil {
 		wallet.ZeroBytes(password)
@@ -107,6 +159,23 @@ func promptPassphrase() (string, error) {
 	return result, nil
 }
 
+// promptExtraEntropy prompts for user-provided entropy, such as dice rolls or
+// random typing, with hidden input.
+// The caller is responsible for zeroing the returned bytes after use.
+func promptExtraEntropy() ([]byte, error) {
+	outln(os.Stderr, "\nExtra entropy (dice rolls or random typing, mixed with system randomness):")
+	outln(os.Stderr, "It adds to the system randomness and never replaces it. You do not need to remember it.")
+
+	extra, err := promptPassword("Enter extra entropy: ")
+	if err != nil {
+		return nil, err
+	}
+	if len(extra) == 0 {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "no extra entropy entered")
+	}
+	return extra, nil
+}
+
 // promptConfirmation asks user to confirm addresses are correct.
 func promptConfirmation() bool {
 	out(os.Stderr, "\nDo these addresses match your expected addresses? [y/N]: ")
@@ -121,6 +190,35 @@ func promptConfirmation() bool {
 	return response == "y" || response == "yes"
 }
 
+// stdinReader is created once so input it reads ahead of one prompt is still
+// there for the next, instead of being dropped with a per-call reader.
+//
+//nolint:gochecknoglobals // One buffered reader for the process's stdin
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// promptConfirmCode reads the transaction confirmation code typed by the user.
+// The whole line is read so codes typed with a space still compare equal.
+func promptConfirmCode() (string, error) {
+	out(os.Stderr, "Type the confirmation code to send: ")
+
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("reading confirmation code: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// promptCoinPick reads one line of input picker commands.
+func promptCoinPick() (string, error) {
+	out(os.Stderr, "Toggle inputs (e.g. 1 3-5), a = all, n = none, Enter = accept, q = cancel: ")
+
+	line, err := stdinReader.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("reading input selection: %w", err)
+	}
+	return line, nil
+}
+
 // promptSeedMaterial prompts for seed material interactively.
 func promptSeedMaterial() (string, error) {
 	outln(os.Stderr, "Enter your seed material (mnemonic phrase, WIF, or hex key):")
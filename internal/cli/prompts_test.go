@@ -2,11 +2,14 @@ package cli
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
@@ -54,12 +57,12 @@ func TestPromptNewPassword_Success(t *testing.T) {
 	t.Cleanup(func() { promptNewPasswordFn = orig })
 
 	// Mock implementation - password meets requirements
-	promptNewPasswordFn = func() ([]byte, error) {
+	promptNewPasswordFn = func(_ passwordPolicy) ([]byte, error) {
 		return []byte("validpass123"), nil
 	}
 
 	// Test
-	result, err := promptNewPasswordFn()
+	result, err := promptNewPasswordFn(passwordPolicy{})
 	require.NoError(t, err)
 	assert.Equal(t, []byte("validpass123"), result)
 }
@@ -71,12 +74,12 @@ func TestPromptNewPassword_TooShort(t *testing.T) {
 	t.Cleanup(func() { promptNewPasswordFn = origNPW })
 
 	// Mock to return error about short password
-	promptNewPasswordFn = func() ([]byte, error) {
+	promptNewPasswordFn = func(_ passwordPolicy) ([]byte, error) {
 		return nil, errors.New("password must be at least 8 characters") //nolint:err113 // test error
 	}
 
 	// Test through the function variable
-	result, err := promptNewPasswordFn()
+	result, err := promptNewPasswordFn(passwordPolicy{})
 	require.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "at least 8 characters")
@@ -89,12 +92,12 @@ func TestPromptNewPassword_Mismatch(t *testing.T) {
 	t.Cleanup(func() { promptNewPasswordFn = origNPW })
 
 	// Mock to return error about mismatch
-	promptNewPasswordFn = func() ([]byte, error) {
+	promptNewPasswordFn = func(_ passwordPolicy) ([]byte, error) {
 		return nil, errors.New("passwords do not match") //nolint:err113 // test error
 	}
 
 	// Test through the function variable
-	result, err := promptNewPasswordFn()
+	result, err := promptNewPasswordFn(passwordPolicy{})
 	require.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "do not match")
@@ -373,3 +376,77 @@ func splitMnemonic(s string) []string {
 	}
 	return result
 }
+
+// TestPromptWalletPassword_SecretFile tests that a mounted password file replaces the prompt
+// when stdin is not a terminal.
+func TestPromptWalletPassword_SecretFile(t *testing.T) {
+	orig, origTTY := promptPasswordFn, stdinIsTerminalFn
+	t.Cleanup(func() { promptPasswordFn, stdinIsTerminalFn = orig, origTTY })
+	stdinIsTerminalFn = func() bool { return false }
+	promptPasswordFn = func(_ string) ([]byte, error) {
+		return nil, errors.New("should not prompt") //nolint:err113 // test error
+	}
+
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("from-secret-file\n"), 0o600))
+
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv(config.EnvWalletPassword+config.EnvFileSuffix, path)
+
+	result, err := promptWalletPassword("Enter wallet password: ")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("from-secret-file"), result)
+}
+
+// TestPromptWalletPassword_InteractiveIgnoresSecretFile tests that a terminal session prompts
+// even when a password file is mounted.
+func TestPromptWalletPassword_InteractiveIgnoresSecretFile(t *testing.T) {
+	orig, origTTY := promptPasswordFn, stdinIsTerminalFn
+	t.Cleanup(func() { promptPasswordFn, stdinIsTerminalFn = orig, origTTY })
+	stdinIsTerminalFn = func() bool { return true }
+	promptPasswordFn = func(_ string) ([]byte, error) {
+		return []byte("typed"), nil
+	}
+
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("from-secret-file\n"), 0o600))
+
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv(config.EnvWalletPassword+config.EnvFileSuffix, path)
+
+	result, err := promptWalletPassword("Enter wallet password: ")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("typed"), result)
+}
+
+// TestPromptWalletPassword_BadSecretFile tests that an unreadable password file is reported
+// when the password is needed.
+func TestPromptWalletPassword_BadSecretFile(t *testing.T) {
+	origTTY := stdinIsTerminalFn
+	t.Cleanup(func() { stdinIsTerminalFn = origTTY })
+	stdinIsTerminalFn = func() bool { return false }
+
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv(config.EnvWalletPassword+config.EnvFileSuffix, filepath.Join(t.TempDir(), "missing"))
+
+	_, err := promptWalletPassword("Enter wallet password: ")
+	require.ErrorIs(t, err, config.ErrSecretFile)
+}
+
+// TestPromptWalletPassword_FallsBackToPrompt tests prompting when no secret is mounted.
+func TestPromptWalletPassword_FallsBackToPrompt(t *testing.T) {
+	orig := promptPasswordFn
+	t.Cleanup(func() { promptPasswordFn = orig })
+	promptPasswordFn = func(_ string) ([]byte, error) {
+		return []byte("typed"), nil
+	}
+
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv(config.EnvWalletPassword+config.EnvFileSuffix, "")
+	t.Setenv(config.EnvSecretsDir, "")
+	t.Setenv(config.EnvWalletPassword, "ignored-plain-env")
+
+	result, err := promptWalletPassword("Enter wallet password: ")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("typed"), result)
+}
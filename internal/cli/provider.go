@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/apiusage"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	"github.com/mrz1836/sigil/internal/reqsign"
+)
+
+// providerCmd is the parent command for API provider operations.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var providerCmd = &cobra.Command{
+	Use:   "provider",
+	Short: "Inspect API provider usage",
+	Long: `Inspect how sigil uses the quota-limited APIs it talks to.
+
+Every request to Etherscan and WhatsOnChain is counted per API key in
+~/.sigil/cache/api_usage.json. Keys are identified by a fingerprint, never by
+the key itself. When a key passes quotas.warn_percent (default 80) of its daily
+quota, or the provider's rate-limit headers show it nearly spent, sigil warns
+once per command before requests start failing.`,
+}
+
+// providerUsageCmd shows the request counters per API key.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var providerUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Show API requests made in the last 24 hours",
+	Long: `Show the requests made to each API key in the last 24 hours, the share of
+its daily quota they use, and how many the provider refused for rate.
+
+Quotas come from the quotas section of config.yaml. Etherscan defaults to
+100000 requests a day; WhatsOnChain has no daily quota by default, since its
+free tier is limited per second. The counts are sigil's own estimate: requests
+made with the same key by other programs are not included.`,
+	Example: `  sigil provider usage
+  sigil provider usage -o json`,
+	Args: cobra.NoArgs,
+	RunE: runProviderUsage,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	providerCmd.GroupID = "config"
+	rootCmd.AddCommand(providerCmd)
+	providerCmd.AddCommand(providerUsageCmd)
+}
+
+// providerUsageItem is one API key in provider usage output.
+type providerUsageItem struct {
+	Provider    string    `json:"provider"`
+	Key         string    `json:"key"`
+	Requests24h int64     `json:"requests_24h"`
+	DailyQuota  int       `json:"daily_quota,omitempty"`
+	Percent     int64     `json:"percent,omitempty"`
+	Limited24h  int64     `json:"rate_limited_24h"`
+	LastRequest time.Time `json:"last_request"`
+	LastLimited time.Time `json:"last_rate_limited,omitzero"`
+	// HeaderLimit and HeaderRemaining are the last rate-limit headers seen.
+	HeaderLimit     int64 `json:"header_limit,omitempty"`
+	HeaderRemaining int64 `json:"header_remaining,omitempty"`
+}
+
+func runProviderUsage(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+	keys, err := apiusage.Load(apiusage.Path(cc.Cfg.GetHome()))
+	if err != nil {
+		return fmt.Errorf("loading API usage: %w", err)
+	}
+	items := buildProviderUsage(keys, apiQuotas(cc.Cfg.GetQuotas()), time.Now())
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, items)
+	}
+	outputProviderUsage(w, items)
+	return nil
+}
+
+// buildProviderUsage summarizes each key's usage in the 24 hours before now.
+func buildProviderUsage(keys []*apiusage.KeyUsage, quotas map[string]int, now time.Time) []providerUsageItem {
+	items := make([]providerUsageItem, 0, len(keys))
+	for _, u := range keys {
+		item := providerUsageItem{
+			Provider:    u.Provider,
+			Key:         u.Key,
+			Requests24h: u.Requests(now),
+			DailyQuota:  quotas[u.Provider],
+			Limited24h:  u.Limited(now),
+			LastRequest: u.LastRequest,
+			LastLimited: u.LastLimited,
+		}
+		if item.DailyQuota > 0 {
+			item.Percent = item.Requests24h * 100 / int64(item.DailyQuota)
+		}
+		if !u.HeaderAt.IsZero() {
+			item.HeaderLimit, item.HeaderRemaining = u.HeaderLimit, u.HeaderRemaining
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// outputProviderUsage shows API usage as a table.
+func outputProviderUsage(w io.Writer, items []providerUsageItem) {
+	if len(items) == 0 {
+		outln(w, "No API requests recorded.")
+		return
+	}
+	out(w, "  %-12s  %-9s  %8s  %8s  %5s  %7s  %s\n", "PROVIDER", "KEY", "24H", "QUOTA", "USED", "LIMITED", "LAST REQUEST")
+	for _, item := range items {
+		quota, used := "-", "-"
+		if item.DailyQuota > 0 {
+			quota, used = fmt.Sprintf("%d", item.DailyQuota), fmt.Sprintf("%d%%", item.Percent)
+		}
+		out(w, "  %-12s  %-9s  %8d  %8s  %5s  %7d  %s\n", item.Provider, item.Key, item.Requests24h,
+			quota, used, item.Limited24h, formatCacheAge(item.LastRequest))
+		if item.HeaderLimit > 0 {
+			out(w, "  %-12s  %-9s  rate-limit headers: %d of %d left\n", "", "", item.HeaderRemaining, item.HeaderLimit)
+		}
+	}
+}
+
+// apiQuotas maps the quota config to apiusage providers.
+func apiQuotas(q config.QuotaConfig) map[string]int {
+	return map[string]int{
+		apiusage.ProviderEtherscan:    q.EtherscanDaily,
+		apiusage.ProviderWhatsOnChain: q.WhatsOnChainDaily,
+	}
+}
+
+// enableAPIUsage starts counting provider requests for quota warnings. It
+// must run before any chain client is constructed. A corrupt usage file is
+// reported and replaced on save.
+func enableAPIUsage(c *config.Config, w io.Writer) {
+	quotas := c.GetQuotas()
+	tracker, err := apiusage.New(apiusage.Options{
+		Path:        apiusage.Path(c.GetHome()),
+		Quotas:      apiQuotas(quotas),
+		WarnPercent: quotas.WarnPercent,
+		Warn:        output.Warnf,
+	})
+	if err != nil {
+		out(w, "Warning: %v; API usage counters restart\n", err)
+	}
+	apiusage.Enable(tracker)
+}
+
+// enableRequestSigning applies the http section of the config to provider
+// requests: the User-Agent and, with a signing key, HMAC signature headers.
+// Like enableAPIUsage it must run before any chain client is constructed. A
+// half-configured signing key is reported and leaves requests unsigned.
+func enableRequestSigning(c *config.Config, w io.Writer) {
+	httpCfg := c.GetHTTP()
+	err := reqsign.Enable(&reqsign.Config{
+		UserAgent: strings.TrimSpace(httpCfg.UserAgent),
+		KeyID:     strings.TrimSpace(httpCfg.Signing.KeyID),
+		Secret:    []byte(httpCfg.Signing.Secret),
+		Hosts:     httpCfg.Signing.Hosts,
+	})
+	if err != nil {
+		out(w, "Warning: http.signing: %v; provider requests are not signed\n", err)
+		_ = reqsign.Enable(&reqsign.Config{UserAgent: strings.TrimSpace(httpCfg.UserAgent)})
+	}
+}
+
+// enableRequestLogging logs every provider request and cache lookup as a
+// structured event when the log level is debug, as it is with --verbose.
+// Like enableRequestSigning it must run before any chain client is
+// constructed.
+func enableRequestLogging(l *config.Logger) {
+	if l.Level() < config.LogLevelDebug {
+		reqlog.Disable()
+		return
+	}
+	reqlog.Enable(l.Structured())
+}
+
+// saveAPIUsage saves the provider request counts of this command.
+func saveAPIUsage(w io.Writer) {
+	if err := apiusage.Save(); err != nil {
+		out(w, "Warning: failed to save API usage: %v\n", err)
+	}
+}
@@ -0,0 +1,144 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/apiusage"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	"github.com/mrz1836/sigil/internal/reqsign"
+)
+
+func TestBuildProviderUsage(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 30, 0, 0, time.UTC)
+	keys := []*apiusage.KeyUsage{
+		{
+			Provider: apiusage.ProviderEtherscan,
+			Key:      "1a2b3c4d",
+			Buckets: []apiusage.Bucket{
+				{Hour: now.Add(-30 * time.Hour).Truncate(time.Hour), Requests: 500},
+				{Hour: now.Truncate(time.Hour), Requests: 850, Limited: 3},
+			},
+			LastRequest: now.Add(-time.Minute),
+		},
+		{
+			Provider:        apiusage.ProviderWhatsOnChain,
+			Key:             apiusage.Anonymous,
+			Buckets:         []apiusage.Bucket{{Hour: now.Truncate(time.Hour), Requests: 12}},
+			LastRequest:     now.Add(-time.Minute),
+			HeaderLimit:     3,
+			HeaderRemaining: 1,
+			HeaderAt:        now,
+		},
+	}
+
+	items := buildProviderUsage(keys, apiQuotas(config.QuotaConfig{EtherscanDaily: 1000}), now)
+	require.Len(t, items, 2)
+	assert.Equal(t, int64(850), items[0].Requests24h, "requests older than 24h are left out")
+	assert.Equal(t, int64(85), items[0].Percent)
+	assert.Equal(t, int64(3), items[0].Limited24h)
+	assert.Zero(t, items[1].DailyQuota)
+	assert.Equal(t, int64(3), items[1].HeaderLimit)
+
+	var buf bytes.Buffer
+	outputProviderUsage(&buf, items)
+	assert.Contains(t, buf.String(), "etherscan     1a2b3c4d        850      1000    85%        3")
+	assert.Contains(t, buf.String(), "whatsonchain  anonymous        12         -      -        0")
+	assert.Contains(t, buf.String(), "rate-limit headers: 1 of 3 left")
+
+	buf.Reset()
+	outputProviderUsage(&buf, nil)
+	assert.Equal(t, "No API requests recorded.\n", buf.String())
+}
+
+func TestRunProviderUsage(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	tracker, err := apiusage.New(apiusage.Options{Path: apiusage.Path(home)})
+	require.NoError(t, err)
+	tracker.RecordLimited(apiusage.ProviderEtherscan, "key")
+	require.NoError(t, tracker.Save())
+
+	cmd, buf := newBackupListTestCmd(home, output.FormatJSON)
+	require.NoError(t, runProviderUsage(cmd, nil))
+
+	var items []providerUsageItem
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+	require.Len(t, items, 1)
+	assert.Equal(t, apiusage.ProviderEtherscan, items[0].Provider)
+	assert.Equal(t, apiusage.Fingerprint("key"), items[0].Key)
+	assert.Equal(t, int64(1), items[0].Limited24h)
+}
+
+//nolint:paralleltest // Request signing is process-wide state
+func TestEnableRequestSigning(t *testing.T) {
+	t.Cleanup(reqsign.Disable)
+
+	t.Run("nothing configured", func(t *testing.T) {
+		var buf bytes.Buffer
+		enableRequestSigning(config.Defaults(), &buf)
+		assert.False(t, reqsign.Enabled())
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("user agent only", func(t *testing.T) {
+		cfg := config.Defaults()
+		cfg.HTTP.UserAgent = " sigil/ops-1 "
+		var buf bytes.Buffer
+		enableRequestSigning(cfg, &buf)
+		assert.True(t, reqsign.Enabled())
+		assert.Empty(t, buf.String())
+	})
+
+	t.Run("key without secret warns and keeps the user agent", func(t *testing.T) {
+		cfg := config.Defaults()
+		cfg.HTTP.UserAgent = "sigil/ops-1"
+		cfg.HTTP.Signing.KeyID = "ops-1"
+		var buf bytes.Buffer
+		enableRequestSigning(cfg, &buf)
+		assert.True(t, reqsign.Enabled())
+		assert.Contains(t, buf.String(), "provider requests are not signed")
+	})
+}
+
+//nolint:paralleltest // Request logging is process-wide state
+func TestEnableRequestLogging(t *testing.T) {
+	t.Cleanup(reqlog.Disable)
+	logFile := filepath.Join(t.TempDir(), "sigil.log")
+
+	quiet, err := config.NewLogger(config.LogLevelError, logFile)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = quiet.Close() })
+	enableRequestLogging(quiet)
+	assert.False(t, reqlog.Enabled(), "only verbose logging includes requests")
+
+	verboseLogger, err := config.NewStructuredLogger(config.LogLevelDebug, logFile)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = verboseLogger.Close() })
+	enableRequestLogging(verboseLogger)
+	require.True(t, reqlog.Enabled())
+
+	reqlog.Cache(context.Background(), "prices", "bsv/USD", true)
+	data, err := os.ReadFile(logFile) //nolint:gosec // Test file under t.TempDir
+	require.NoError(t, err)
+	var event map[string]any
+	require.NoError(t, json.Unmarshal(bytes.TrimSpace(data), &event))
+	assert.Equal(t, reqlog.MsgCache, event["msg"])
+	assert.Equal(t, reqlog.CacheHit, event["result"])
+
+	enableRequestLogging(config.NullLogger())
+	assert.False(t, reqlog.Enabled())
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -26,10 +27,14 @@ var (
 	receiveWallet string
 	// receiveChain is the blockchain to show address for.
 	receiveChain string
+	// receiveAccount is the BIP44 account to receive into.
+	receiveAccount uint32
 	// receiveNew forces generation of a new address.
 	receiveNew bool
 	// receiveLabel sets a label for the address.
 	receiveLabel string
+	// receiveAmount is the amount to request in the payment URI.
+	receiveAmount string
 	// receiveQR displays a QR code for the address.
 	receiveQR bool
 	// receiveCheck checks for received funds at the address.
@@ -46,18 +51,34 @@ var (
 var receiveCmd = &cobra.Command{
 	Use:   "receive",
 	Short: "Show a receiving address",
-	Long: `Display a receiving address for your wallet.
+	Long: `Display a fresh receiving address for your wallet.
 
-By default, shows the first unused address. Use --new to force generation
-of a new address even if the current one hasn't been used yet.`,
+Each call hands out the first unused address that is not already awaiting
+a payment, deriving a new one when none is left, and marks it
+pending-receive until funds arrive. Use --new to always derive a new
+address.
+
+With --amount or --label, the address is also given as a payment request:
+a BIP21 URI for BSV, BTC, and BCH, or an EIP-681 URI for ETH. Text output
+shows it as a QR code when writing to a terminal; JSON output includes the
+URI.
+
+Use --account to receive into another BIP44 account of the wallet. The first
+receive into a new account derives its first address.`,
 	Example: `  # Show next unused BSV receiving address
   sigil receive --wallet main --chain bsv
 
   # Generate a new address with a label
   sigil receive --wallet main --chain bsv --new --label "Payment from Alice"
 
-  # Show address with QR code for mobile wallet scanning
-  sigil receive --wallet main --chain bsv --qr
+  # Request 0.05 BSV with a BIP21 payment URI
+  sigil receive --wallet main --chain bsv --amount 0.05 --label "Invoice 42"
+
+  # Receive into BIP44 account 1
+  sigil receive --wallet main --chain bsv --account 1
+
+  # Show the address without a QR code
+  sigil receive --wallet main --chain bsv --qr=false
 
   # Check if funds have arrived at your receive address
   sigil receive --wallet main --chain bsv --check
@@ -75,24 +96,28 @@ func init() {
 	receiveCmd.GroupID = "wallet"
 	rootCmd.AddCommand(receiveCmd)
 
-	receiveCmd.Flags().StringVarP(&receiveWallet, "wallet", "w", "", "wallet name (required)")
+	receiveCmd.Flags().StringVarP(&receiveWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
 	receiveCmd.Flags().StringVarP(&receiveChain, "chain", "c", "bsv", "blockchain: eth, bsv")
+	receiveCmd.Flags().Uint32Var(&receiveAccount, "account", 0, accountFlagUsage)
 	receiveCmd.Flags().BoolVar(&receiveNew, "new", false, "force generation of a new address")
-	receiveCmd.Flags().StringVarP(&receiveLabel, "label", "l", "", "label for the address")
-	receiveCmd.Flags().BoolVar(&receiveQR, "qr", false, "display QR code for the address")
+	receiveCmd.Flags().StringVarP(&receiveLabel, "label", "l", "", "label for the address, also the payee label of the payment URI")
+	receiveCmd.Flags().StringVar(&receiveAmount, "amount", "", "amount to request in a payment URI (BIP21 or EIP-681)")
+	receiveCmd.Flags().BoolVar(&receiveQR, "qr", true, "display a QR code in text output on a terminal")
 	receiveCmd.Flags().BoolVar(&receiveCheck, "check", false, "check for received funds and refresh local UTXO state")
 	receiveCmd.Flags().StringVar(&receiveAddress, "address", "", "specific address to check (use with --check)")
 	receiveCmd.Flags().BoolVar(&receiveAll, "all", false, "check all receiving addresses (use with --check)")
 
-	_ = receiveCmd.MarkFlagRequired("wallet")
-
 	// Declarative flag constraints (Cobra generates clear error messages)
 	receiveCmd.MarkFlagsMutuallyExclusive("check", "new")
+	receiveCmd.MarkFlagsMutuallyExclusive("check", "amount")
 	receiveCmd.MarkFlagsMutuallyExclusive("address", "all")
 }
 
 //nolint:gocognit,gocyclo // CLI flow involves multiple validation and setup steps
 func runReceive(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &receiveWallet); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd)
 
 	// Validate flag combinations
@@ -112,21 +137,24 @@ func runReceive(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Validate chain
-	chainID, ok := chain.ParseChainID(receiveChain)
-	if !ok || !chainID.IsMVP() {
-		return sigilerr.WithSuggestion(
-			sigilerr.ErrInvalidInput,
-			fmt.Sprintf("invalid chain: %s (use eth or bsv)", receiveChain),
-		)
+	chainID, err := parseEnabledChain(cmdCtx.Cfg, receiveChain)
+	if err != nil {
+		return err
 	}
 
 	// Load wallet
 	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
-	wlt, seed, err := loadWalletWithSession(receiveWallet, storage, cmd)
+	wlt, seed, err := loadWalletForRead(receiveWallet, storage, cmd)
 	if err != nil {
 		return err
 	}
 	defer wallet.ZeroBytes(seed)
+	if err = checkAgentXpubAccount(cmdCtx, wlt, receiveAccount); err != nil {
+		return err
+	}
+	if wlt, err = wlt.Account(receiveAccount); err != nil {
+		return err
+	}
 
 	// Load UTXO store to check address activity
 	utxoStorePath := filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", receiveWallet)
@@ -138,6 +166,12 @@ func runReceive(cmd *cobra.Command, _ []string) error {
 	// Create address service
 	addressService := address.NewService(address.NewMetadataAdapter(store))
 
+	// A watch-only wallet derives new addresses from the xpub it was imported from
+	xpub := cmdCtx.AgentXpub
+	if xpub == "" {
+		xpub = wlt.Xpubs[chainID]
+	}
+
 	// Multi-chain check: when --check --all is used without explicit --chain,
 	// check all enabled MVP chains (BSV + ETH).
 	if receiveCheck && receiveAll && !cmd.Flags().Changed("chain") {
@@ -155,17 +189,19 @@ func runReceive(cmd *cobra.Command, _ []string) error {
 			Wallet:  wlt,
 			Seed:    seed,
 			ChainID: chainID,
-			Xpub:    cmdCtx.AgentXpub,
+			Xpub:    xpub,
 		})
 		if err != nil {
 			return fmt.Errorf("deriving new address: %w", err)
 		}
 		isNew = true
 	} else {
-		// Find first unused address
+		// Find the first unused address; a new receive skips those already
+		// handed out, while --check looks at the oldest of them
 		addr = addressService.FindUnused(&address.FindRequest{
-			Wallet:  wlt,
-			ChainID: chainID,
+			Wallet:      wlt,
+			ChainID:     chainID,
+			SkipPending: !receiveCheck,
 		})
 		if addr == nil {
 			// All addresses are used, derive a new one
@@ -173,7 +209,7 @@ func runReceive(cmd *cobra.Command, _ []string) error {
 				Wallet:  wlt,
 				Seed:    seed,
 				ChainID: chainID,
-				Xpub:    cmdCtx.AgentXpub,
+				Xpub:    xpub,
 			})
 			if err != nil {
 				return fmt.Errorf("deriving new address: %w", err)
@@ -182,45 +218,20 @@ func runReceive(cmd *cobra.Command, _ []string) error {
 		}
 	}
 
+	// Build the payment request before saving, so a bad --amount changes nothing
+	uri, err := paymentURI(chainID, addr.Address, receiveAmount, receiveLabel, effectiveBSVNetwork(wlt, cmdCtx.Cfg))
+	if err != nil {
+		return err
+	}
+
 	if isNew {
 		if err := storage.UpdateMetadata(wlt); err != nil {
 			return fmt.Errorf("persisting wallet metadata: %w", err)
 		}
 	}
 
-	// Register the address in UTXO store if new
-	//nolint:nestif // UTXO store operations require conditional nesting
-	if isNew {
-		store.AddAddress(&utxostore.AddressMetadata{
-			Address:        addr.Address,
-			ChainID:        chainID,
-			DerivationPath: addr.Path,
-			Index:          addr.Index,
-			Label:          receiveLabel,
-			IsChange:       false,
-		})
-		if err := store.Save(); err != nil {
-			return fmt.Errorf("saving UTXO store: %w", err)
-		}
-	} else if receiveLabel != "" {
-		// Update label on existing address, registering it in the store first if needed
-		if store.GetAddress(chainID, addr.Address) == nil {
-			store.AddAddress(&utxostore.AddressMetadata{
-				Address:        addr.Address,
-				ChainID:        chainID,
-				DerivationPath: addr.Path,
-				Index:          addr.Index,
-				Label:          receiveLabel,
-				IsChange:       false,
-			})
-		} else {
-			if err := store.SetAddressLabel(chainID, addr.Address, receiveLabel); err != nil {
-				return fmt.Errorf("setting address label: %w", err)
-			}
-		}
-		if err := store.Save(); err != nil {
-			return fmt.Errorf("saving UTXO store: %w", err)
-		}
+	if err := registerReceiveAddress(store, addr, chainID, isNew, time.Now()); err != nil {
+		return err
 	}
 
 	// Get label from store if not setting a new one
@@ -237,17 +248,58 @@ func runReceive(cmd *cobra.Command, _ []string) error {
 	}
 
 	// Display result
+	network := effectiveBSVNetwork(wlt, cmdCtx.Cfg)
 	if cmdCtx.Fmt.Format() == output.FormatJSON {
-		displayReceiveJSON(cmd, addr, chainID, label, isNew)
+		displayReceiveJSON(cmd, addr, chainID, label, isNew, network, uri)
 	} else {
-		displayReceiveText(cmd, addr, chainID, label, isNew, effectiveBSVNetwork(wlt, cmdCtx.Cfg))
+		displayReceiveText(cmd, addr, chainID, label, isNew, network, uri)
+	}
+
+	return nil
+}
+
+// registerReceiveAddress saves addr to the UTXO store with any --label.
+// Unless --check is set, it also marks addr pending-receive at now, so the
+// next receive hands out a fresh address.
+func registerReceiveAddress(store *utxostore.Store, addr *wallet.Address, chainID chain.ID, isNew bool, now time.Time) error {
+	changed := false
+	if store.GetAddress(chainID, addr.Address) == nil {
+		if isNew || receiveLabel != "" || !receiveCheck {
+			store.AddAddress(&utxostore.AddressMetadata{
+				Address:        addr.Address,
+				ChainID:        chainID,
+				DerivationPath: addr.Path,
+				Index:          addr.Index,
+				Label:          receiveLabel,
+				IsChange:       false,
+			})
+			changed = true
+		}
+	} else if receiveLabel != "" {
+		if err := store.SetAddressLabel(chainID, addr.Address, receiveLabel); err != nil {
+			return fmt.Errorf("setting address label: %w", err)
+		}
+		changed = true
 	}
 
+	if !receiveCheck {
+		if err := store.MarkPendingReceive(chainID, addr.Address, now); err != nil {
+			return fmt.Errorf("marking address pending-receive: %w", err)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("saving UTXO store: %w", err)
+	}
 	return nil
 }
 
 // displayReceiveText shows the receiving address in text format.
-func displayReceiveText(cmd *cobra.Command, addr *wallet.Address, chainID chain.ID, label string, isNew bool, bsvNetwork string) {
+func displayReceiveText(cmd *cobra.Command, addr *wallet.Address, chainID chain.ID, label string, isNew bool, bsvNetwork, uri string) {
 	w := cmd.OutOrStdout()
 
 	outln(w)
@@ -264,29 +316,37 @@ func displayReceiveText(cmd *cobra.Command, addr *wallet.Address, chainID chain.
 	if label != "" {
 		out(w, "  Label:   %s\n", label)
 	}
+	if uri != "" {
+		out(w, "  URI:     %s\n", uri)
+	}
 	outln(w)
 
 	// Render QR code if requested and output is a terminal
 	if receiveQR && output.CanRenderQR(w) {
+		qrData := formatQRData(addr.Address)
+		if uri != "" {
+			qrData = uri
+		}
 		cfg := output.DefaultQRConfig()
-		_ = output.RenderQR(w, formatQRData(addr.Address), cfg)
+		_ = output.RenderQR(w, qrData, cfg)
 		outln(w)
-		outln(w, "  Scan with a mobile wallet to send BSV")
+		out(w, "  Scan with a mobile wallet to send %s\n", strings.ToUpper(string(chainID)))
 		outln(w)
 	}
 
-	// Show explorer link based on chain
-	switch chainID {
-	case chain.BSV:
-		outln(w, "View on block explorer:")
-		for _, link := range bsvExplorerAddressLinks(bsvNetwork, addr.Address) {
-			out(w, "  %s\n", link)
-		}
-	case chain.ETH:
-		outln(w, "View on Etherscan:")
-		out(w, "  https://etherscan.io/address/%s\n", addr.Address)
-	case chain.BTC, chain.BCH, chain.LTC:
-		// Future chains - no explorer link yet
+	displayExplorerAddressLinks(w, chainID, bsvNetwork, addr.Address)
+}
+
+// displayExplorerAddressLinks prints the explorer links of an address, if
+// the chain has an explorer.
+func displayExplorerAddressLinks(w io.Writer, chainID chain.ID, network, address string) {
+	links := explorerAddressLinks(chainID, network, address)
+	if len(links) == 0 {
+		return
+	}
+	out(w, "View on %s:\n", explorerTitle(chainID, network))
+	for _, link := range links {
+		out(w, "  %s\n", link)
 	}
 }
 
@@ -297,21 +357,26 @@ func formatQRData(address string) string {
 }
 
 // displayReceiveJSON shows the receiving address in JSON format.
-func displayReceiveJSON(cmd *cobra.Command, addr *wallet.Address, chainID chain.ID, label string, isNew bool) {
+// The URI is included when --amount or --label built one.
+func displayReceiveJSON(cmd *cobra.Command, addr *wallet.Address, chainID chain.ID, label string, isNew bool, network, uri string) {
 	payload := struct {
-		Chain   string `json:"chain"`
-		Address string `json:"address"`
-		Path    string `json:"path"`
-		Index   uint32 `json:"index"`
-		Label   string `json:"label,omitempty"`
-		IsNew   bool   `json:"is_new"`
+		Chain       string `json:"chain"`
+		Address     string `json:"address"`
+		Path        string `json:"path"`
+		Index       uint32 `json:"index"`
+		Label       string `json:"label,omitempty"`
+		URI         string `json:"uri,omitempty"`
+		ExplorerURL string `json:"explorer_url,omitempty"`
+		IsNew       bool   `json:"is_new"`
 	}{
-		Chain:   string(chainID),
-		Address: addr.Address,
-		Path:    addr.Path,
-		Index:   addr.Index,
-		Label:   label,
-		IsNew:   isNew,
+		Chain:       string(chainID),
+		Address:     addr.Address,
+		Path:        addr.Path,
+		Index:       addr.Index,
+		Label:       label,
+		URI:         uri,
+		ExplorerURL: explorerAddressLink(chainID, network, addr.Address),
+		IsNew:       isNew,
 	}
 
 	_ = writeJSON(cmd.OutOrStdout(), payload)
@@ -417,7 +482,7 @@ func runReceiveCheckSingle(ctx context.Context, w io.Writer, cmdCtx *CommandCont
 	}
 
 	if cmdCtx.Fmt.Format() == output.FormatJSON {
-		displayReceiveCheckJSON(w, addr, chainID, result.Label, result.Balance, storeUTXOs)
+		displayReceiveCheckJSON(w, addr, chainID, result.Label, result.Balance, storeUTXOs, bsvNetwork)
 	} else {
 		displayReceiveCheckText(w, addr, chainID, result.Label, result.Balance, storeUTXOs, bsvNetwork)
 	}
@@ -550,7 +615,7 @@ func runReceiveCheckAllETH(ctx context.Context, w io.Writer, cmdCtx *CommandCont
 		)
 	}
 
-	client, err := etherscan.NewClient(apiKey, nil)
+	client, err := etherscan.NewClient(apiKey, etherscanOptions(cmdCtx.Cfg))
 	if err != nil {
 		return fmt.Errorf("creating Etherscan client: %w", err)
 	}
@@ -589,7 +654,7 @@ func runReceiveCheckSingleETH(ctx context.Context, w io.Writer, cmdCtx *CommandC
 		)
 	}
 
-	client, err := etherscan.NewClient(apiKey, nil)
+	client, err := etherscan.NewClient(apiKey, etherscanOptions(cmdCtx.Cfg))
 	if err != nil {
 		return fmt.Errorf("creating Etherscan client: %w", err)
 	}
@@ -609,8 +674,7 @@ func runReceiveCheckSingleETH(ctx context.Context, w io.Writer, cmdCtx *CommandC
 	outln(w)
 	out(w, "  Balance: %s ETH\n", chain.FormatDecimalAmount(balance.Amount, balance.Decimals))
 	outln(w)
-	outln(w, "View on Etherscan:")
-	out(w, "  https://etherscan.io/address/%s\n", addr.Address)
+	displayExplorerAddressLinks(w, chain.ETH, ethNetwork(cmdCtx.Cfg), addr.Address)
 
 	return nil
 }
@@ -716,22 +780,11 @@ func displayReceiveCheckText(w io.Writer, addr *wallet.Address, chainID chain.ID
 	}
 	outln(w)
 
-	switch chainID {
-	case chain.BSV:
-		outln(w, "View on block explorer:")
-		for _, link := range bsvExplorerAddressLinks(bsvNetwork, addr.Address) {
-			out(w, "  %s\n", link)
-		}
-	case chain.ETH:
-		outln(w, "View on Etherscan:")
-		out(w, "  https://etherscan.io/address/%s\n", addr.Address)
-	case chain.BTC, chain.BCH, chain.LTC:
-		// Future chains
-	}
+	displayExplorerAddressLinks(w, chainID, bsvNetwork, addr.Address)
 }
 
 // displayReceiveCheckJSON shows the check result for a single address in JSON format.
-func displayReceiveCheckJSON(w io.Writer, addr *wallet.Address, chainID chain.ID, label string, balance uint64, utxos []*utxostore.StoredUTXO) {
+func displayReceiveCheckJSON(w io.Writer, addr *wallet.Address, chainID chain.ID, label string, balance uint64, utxos []*utxostore.StoredUTXO, network string) {
 	type utxoJSON struct {
 		TxID          string `json:"txid"`
 		Vout          uint32 `json:"vout"`
@@ -750,27 +803,29 @@ func displayReceiveCheckJSON(w io.Writer, addr *wallet.Address, chainID chain.ID
 	}
 
 	payload := struct {
-		Chain      string     `json:"chain"`
-		Address    string     `json:"address"`
-		Path       string     `json:"path"`
-		Index      uint32     `json:"index"`
-		Label      string     `json:"label,omitempty"`
-		HasFunds   bool       `json:"has_funds"`
-		Balance    uint64     `json:"balance"`
-		BalanceBSV float64    `json:"balance_bsv"`
-		UTXOCount  int        `json:"utxo_count"`
-		UTXOs      []utxoJSON `json:"utxos"`
+		Chain       string     `json:"chain"`
+		Address     string     `json:"address"`
+		Path        string     `json:"path"`
+		Index       uint32     `json:"index"`
+		Label       string     `json:"label,omitempty"`
+		HasFunds    bool       `json:"has_funds"`
+		Balance     uint64     `json:"balance"`
+		BalanceBSV  float64    `json:"balance_bsv"`
+		UTXOCount   int        `json:"utxo_count"`
+		UTXOs       []utxoJSON `json:"utxos"`
+		ExplorerURL string     `json:"explorer_url,omitempty"`
 	}{
-		Chain:      string(chainID),
-		Address:    addr.Address,
-		Path:       addr.Path,
-		Index:      addr.Index,
-		Label:      label,
-		HasFunds:   len(utxos) > 0,
-		Balance:    balance,
-		BalanceBSV: float64(balance) / 1e8,
-		UTXOCount:  len(utxos),
-		UTXOs:      utxoList,
+		Chain:       string(chainID),
+		Address:     addr.Address,
+		Path:        addr.Path,
+		Index:       addr.Index,
+		Label:       label,
+		HasFunds:    len(utxos) > 0,
+		Balance:     balance,
+		BalanceBSV:  float64(balance) / 1e8,
+		UTXOCount:   len(utxos),
+		UTXOs:       utxoList,
+		ExplorerURL: explorerAddressLink(chainID, network, addr.Address),
 	}
 
 	_ = writeJSON(w, payload)
@@ -9,7 +9,7 @@ import (
 func TestReceiveQRFlagRegistered(t *testing.T) {
 	flag := receiveCmd.Flags().Lookup("qr")
 	assert.NotNil(t, flag, "--qr flag should be registered")
-	assert.Equal(t, "false", flag.DefValue, "default value should be false")
+	assert.Equal(t, "true", flag.DefValue, "text output shows a QR code by default")
 	assert.Equal(t, "bool", flag.Value.Type(), "flag type should be bool")
 }
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -232,7 +233,7 @@ func TestDisplayReceiveText(t *testing.T) {
 			var buf bytes.Buffer
 			cmd.SetOut(&buf)
 
-			displayReceiveText(cmd, tc.addr, tc.chainID, tc.label, tc.isNew, "main")
+			displayReceiveText(cmd, tc.addr, tc.chainID, tc.label, tc.isNew, "main", "")
 
 			result := buf.String()
 			for _, s := range tc.contains {
@@ -255,7 +256,7 @@ func TestDisplayReceiveText_WithNonEmptyLabel(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	displayReceiveText(cmd, addr, chain.BSV, "MyLabel", false, "main")
+	displayReceiveText(cmd, addr, chain.BSV, "MyLabel", false, "main", "")
 
 	result := buf.String()
 	assert.Contains(t, result, "Label:   MyLabel")
@@ -274,7 +275,7 @@ func TestDisplayReceiveText_NoLabelExcluded(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	displayReceiveText(cmd, addr, chain.BSV, "", false, "main")
+	displayReceiveText(cmd, addr, chain.BSV, "", false, "main", "")
 
 	result := buf.String()
 	assert.NotContains(t, result, "Label:")
@@ -352,7 +353,7 @@ func TestDisplayReceiveJSON(t *testing.T) {
 			var buf bytes.Buffer
 			cmd.SetOut(&buf)
 
-			displayReceiveJSON(cmd, tc.addr, tc.chainID, tc.label, tc.isNew)
+			displayReceiveJSON(cmd, tc.addr, tc.chainID, tc.label, tc.isNew, "main", "")
 
 			var parsed map[string]any
 			require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
@@ -384,10 +385,11 @@ func TestDisplayReceiveJSON_NoLabel(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	displayReceiveJSON(cmd, addr, chain.BSV, "", false)
+	displayReceiveJSON(cmd, addr, chain.BSV, "", false, "main", "")
 
 	result := buf.String()
 	assert.NotContains(t, result, `"label"`)
+	assert.Contains(t, result, `"explorer_url": "https://whatsonchain.com/address/1TestAddress"`)
 }
 
 func TestDisplayReceiveJSON_Escaping(t *testing.T) {
@@ -403,7 +405,7 @@ func TestDisplayReceiveJSON_Escaping(t *testing.T) {
 	var buf bytes.Buffer
 	cmd.SetOut(&buf)
 
-	displayReceiveJSON(cmd, addr, chain.BSV, "line1\nline2 \"quoted\" \u2713", true)
+	displayReceiveJSON(cmd, addr, chain.BSV, "line1\nline2 \"quoted\" \u2713", true, "main", "")
 
 	var parsed map[string]any
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
@@ -552,7 +554,7 @@ func TestDisplayReceiveCheckJSON(t *testing.T) {
 			t.Parallel()
 
 			var buf bytes.Buffer
-			displayReceiveCheckJSON(&buf, tc.addr, tc.chainID, tc.label, tc.balance, tc.utxos)
+			displayReceiveCheckJSON(&buf, tc.addr, tc.chainID, tc.label, tc.balance, tc.utxos, "main")
 
 			var parsed map[string]any
 			require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
@@ -589,7 +591,7 @@ func TestDisplayReceiveCheckJSON_EmptyUTXOsNotNull(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	displayReceiveCheckJSON(&buf, addr, chain.BSV, "", 0, []*utxostore.StoredUTXO{})
+	displayReceiveCheckJSON(&buf, addr, chain.BSV, "", 0, []*utxostore.StoredUTXO{}, "main")
 
 	result := buf.String()
 	// Verify utxos is [] not null
@@ -1153,3 +1155,54 @@ func TestReceiveLabelStoredWithNewAddress(t *testing.T) {
 	assert.Equal(t, "new addr label", meta.Label)
 	assert.False(t, meta.IsChange)
 }
+
+//nolint:paralleltest // Modifies the global receive flags
+func TestRegisterReceiveAddress(t *testing.T) {
+	origLabel, origCheck := receiveLabel, receiveCheck
+	defer func() { receiveLabel, receiveCheck = origLabel, origCheck }()
+
+	addr := &wallet.Address{Index: 0, Address: "1HandedOut", Path: "m/44'/236'/0'/0/0"}
+	dir := t.TempDir()
+	store := utxostore.New(dir)
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// --check registers nothing for an existing, unregistered address
+	receiveLabel, receiveCheck = "", true
+	require.NoError(t, registerReceiveAddress(store, addr, chain.BSV, false, now))
+	assert.Nil(t, store.GetAddress(chain.BSV, addr.Address))
+
+	// A receive registers the address and marks it pending
+	receiveLabel, receiveCheck = "Invoice 42", false
+	require.NoError(t, registerReceiveAddress(store, addr, chain.BSV, false, now))
+
+	reloaded := utxostore.New(dir)
+	require.NoError(t, reloaded.Load())
+	meta := reloaded.GetAddress(chain.BSV, addr.Address)
+	require.NotNil(t, meta)
+	assert.Equal(t, "Invoice 42", meta.Label)
+	assert.True(t, meta.AwaitingReceive())
+
+	// The next receive skips the pending address
+	wlt := &wallet.Wallet{Addresses: map[chain.ID][]wallet.Address{chain.BSV: {*addr}}}
+	service := address.NewService(address.NewMetadataAdapter(reloaded))
+	assert.Nil(t, service.FindUnused(&address.FindRequest{Wallet: wlt, ChainID: chain.BSV, SkipPending: true}))
+}
+
+func TestDisplayReceiveURI(t *testing.T) {
+	t.Parallel()
+
+	addr := &wallet.Address{Index: 0, Address: "1TestAddress", Path: "m/44'/236'/0'/0/0"}
+	uri := "bitcoin:1TestAddress?amount=0.05"
+
+	cmd := &cobra.Command{}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	displayReceiveText(cmd, addr, chain.BSV, "", false, "main", uri)
+	assert.Contains(t, buf.String(), "URI:     "+uri)
+
+	buf.Reset()
+	displayReceiveJSON(cmd, addr, chain.BSV, "", false, "main", uri)
+	var payload map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &payload))
+	assert.Equal(t, uri, payload["uri"])
+}
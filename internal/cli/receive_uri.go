@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bch"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// paymentURI returns a payment request for address: a BIP21 URI for the
+// UTXO chains and an EIP-681 URI for ETH. amount is in whole coins and is
+// normalized; label names the payee and is left out for ETH, which has no
+// such parameter. It returns "" when there is nothing to add to the plain
+// address.
+func paymentURI(chainID chain.ID, address, amount, label, network string) (string, error) {
+	if chainID == chain.ETH {
+		label = ""
+	}
+	amount = SanitizeAmount(amount)
+	if amount == "" && label == "" {
+		return "", nil
+	}
+
+	decimals := 8
+	if chainID == chain.ETH {
+		decimals = 18
+	}
+	var units string
+	if amount != "" {
+		value, err := parseDecimalAmount(amount, decimals)
+		if err != nil {
+			return "", err
+		}
+		if value.Sign() == 0 {
+			return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidAmount, "the requested amount must be greater than zero")
+		}
+		// EIP-681 takes the value in wei; BIP21 takes whole coins
+		units = value.String()
+		if chainID != chain.ETH {
+			units = chain.FormatDecimalAmount(value, decimals)
+		}
+	}
+
+	switch chainID {
+	case chain.ETH:
+		return "ethereum:" + address + "?value=" + units, nil
+	case chain.BSV, chain.BTC:
+		return bip21URI("bitcoin:"+address, units, label), nil
+	case chain.BCH:
+		bchNetwork := bch.NetworkMainnet
+		if network == "test" {
+			bchNetwork = bch.NetworkTestnet
+		}
+		cashAddr, err := bch.ToCashAddr(address, bchNetwork)
+		if err != nil {
+			return "", fmt.Errorf("converting %s to CashAddr: %w", address, err)
+		}
+		return bip21URI(cashAddr, units, label), nil
+	case chain.LTC:
+		return bip21URI("litecoin:"+address, units, label), nil
+	default:
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("payment requests are not supported on %s", chainID),
+		)
+	}
+}
+
+// bip21URI appends the amount and label query parameters of BIP21 to base.
+// Spaces are encoded as %20, since not every wallet decodes "+".
+func bip21URI(base, amount, label string) string {
+	var params []string
+	if amount != "" {
+		params = append(params, "amount="+amount)
+	}
+	if label != "" {
+		params = append(params, "label="+strings.ReplaceAll(url.QueryEscape(label), "+", "%20"))
+	}
+	if len(params) == 0 {
+		return base
+	}
+	return base + "?" + strings.Join(params, "&")
+}
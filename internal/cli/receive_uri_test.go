@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestPaymentURI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		chainID chain.ID
+		address string
+		amount  string
+		label   string
+		want    string
+	}{
+		{name: "plain address", chainID: chain.BSV, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		{
+			name: "BSV amount and label", chainID: chain.BSV, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+			amount: "0.050", label: "Invoice 42 & co",
+			want: "bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa?amount=0.05&label=Invoice%2042%20%26%20co",
+		},
+		{
+			name: "BTC label only", chainID: chain.BTC, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa",
+			label: "Alice", want: "bitcoin:1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa?label=Alice",
+		},
+		{
+			name: "BCH uses CashAddr", chainID: chain.BCH, address: "1BpEi6DfDAUFd7GtittLSdBeYJvcoaVggu",
+			amount: "1", want: "bitcoincash:qpm2qsznhks23z7629mms6s4cwef74vcwvy22gdx6a?amount=1.0",
+		},
+		{
+			name: "ETH value in wei", chainID: chain.ETH, address: "0x742d35Cc6634C0532925a3b844Bc454e4438f44e",
+			amount: "1.5", label: "ignored",
+			want: "ethereum:0x742d35Cc6634C0532925a3b844Bc454e4438f44e?value=1500000000000000000",
+		},
+		{name: "ETH label only", chainID: chain.ETH, address: "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", label: "Alice"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			uri, err := paymentURI(tc.chainID, tc.address, tc.amount, tc.label, "main")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, uri)
+		})
+	}
+}
+
+func TestPaymentURI_InvalidAmount(t *testing.T) {
+	t.Parallel()
+
+	_, err := paymentURI(chain.BSV, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "abc", "", "main")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidAmount)
+
+	_, err = paymentURI(chain.BSV, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "0", "", "main")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidAmount)
+
+	_, err = paymentURI(chain.BSV, "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "0.000000001", "", "main")
+	require.Error(t, err, "more decimals than the chain supports")
+}
@@ -12,16 +12,21 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/mrz1836/sigil/internal/agent"
 	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/metrics"
 	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	walletservice "github.com/mrz1836/sigil/internal/service/wallet"
 	"github.com/mrz1836/sigil/internal/session"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
@@ -64,8 +69,14 @@ var (
 	homeDir      string
 	outputFormat string
 	verbose      bool
+	logFormat    string // --log-format: "text" or "json"
 	networkFlag  string // --network: "main" or "test"
 	testnetFlag  bool   // --testnet: shortcut for --network test
+	rpcProfile   string // --rpc-profile: named ETH RPC profile
+	timingsFlag  bool   // --timings: print phase breakdown on exit
+
+	// faultInjectionFlag is the hidden --fault-injection spec (testing only)
+	faultInjectionFlag string
 
 	// Global state initialized in PersistentPreRunE
 	cfg       *config.Config
@@ -90,7 +101,12 @@ transactions across Ethereum (ETH/USDC) and Bitcoin SV (BSV) networks.`,
 	SilenceUsage:  true,
 	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
-		return initGlobals(cmd)
+		if err := initGlobals(cmd); err != nil {
+			return err
+		}
+		autoMigrate(cmd, cfg.Home)
+		warnPendingIntents(cmd, cfg.Home)
+		return nil
 	},
 	PersistentPostRun: func(_ *cobra.Command, _ []string) {
 		cleanup()
@@ -101,7 +117,15 @@ transactions across Ethereum (ETH/USDC) and Bitcoin SV (BSV) networks.`,
 func Execute(info BuildInfo) error {
 	buildInfo = info
 	rootCmd.Version = formatVersion(info)
-	err := rootCmd.Execute()
+	start := time.Now()
+	ctx, sd := notifyShutdown(context.Background())
+	err := sd.result(rootCmd.ExecuteContext(ctx))
+	sd.stop()
+	if timingsFlag {
+		writeTimings(os.Stderr, timingsFormat(), metrics.Global, time.Since(start))
+	}
+	writeFaultInjectionSummary(os.Stderr)
+	saveAPIUsage(os.Stderr)
 	if err != nil {
 		formatErr(err)
 		return err
@@ -129,6 +153,11 @@ func ExitCode(err error) int {
 //
 //nolint:gocognit,gocyclo // Initialization logic requires multiple conditional branches
 func initGlobals(cmd *cobra.Command) error {
+	// Fault injection must be on before any chain client is constructed
+	if err := enableFaultInjection(faultInjectionFlag, os.Stderr); err != nil {
+		return err
+	}
+
 	// Determine home directory
 	home := homeDir
 	if home == "" {
@@ -138,11 +167,13 @@ func initGlobals(cmd *cobra.Command) error {
 		home = config.DefaultHome()
 	}
 
-	// Load or create config
-	configPath := config.Path(home)
+	// Load or create config; SIGIL_CONFIG=env reads no file at all
+	configPath := config.FindPath(home)
 	var err error
-	cfg, err = config.Load(configPath)
-	if err != nil {
+	if config.EnvOnly() {
+		cfg = config.Defaults()
+		cfg.Home = home
+	} else if cfg, err = config.Load(configPath); err != nil {
 		if os.IsNotExist(err) {
 			// Expected case: no config file yet, use defaults
 			cfg = config.Defaults()
@@ -169,6 +200,9 @@ func initGlobals(cmd *cobra.Command) error {
 	if outputFormat != "" && outputFormat != "auto" {
 		cfg.Output.DefaultFormat = outputFormat
 	}
+	if logFormat != "" {
+		cfg.Logging.Format = strings.ToLower(logFormat)
+	}
 
 	// BSV network: --network wins; --testnet is a shortcut when --network is unset.
 	// Precedence overall: flag > env (applied above) > config file > default.
@@ -184,6 +218,15 @@ func initGlobals(cmd *cobra.Command) error {
 		}
 	}
 
+	// ETH RPC profile: --rpc-profile > SIGIL_ETH_RPC > networks.eth.rpc_profile
+	if err := applyRPCProfile(cfg, rpcProfile, os.Stderr); err != nil {
+		return err
+	}
+
+	// On testnet ETH runs on Sepolia; a loaded wallet switches to its own network
+	useNetwork(cfg, cfg.GetBSVNetwork())
+	explorers = newExplorerRegistry(cfg)
+
 	// Expand tilde in Home path if present
 	if strings.HasPrefix(cfg.Home, "~/") {
 		if userHome, homeErr := os.UserHomeDir(); homeErr == nil {
@@ -191,6 +234,10 @@ func initGlobals(cmd *cobra.Command) error {
 		}
 	}
 
+	// API usage tracking, like fault injection, wraps clients as they are built
+	enableAPIUsage(cfg, os.Stderr)
+	enableRequestSigning(cfg, os.Stderr)
+
 	// Initialize logger
 	logLevel := config.ParseLogLevel(cfg.Logging.Level)
 	logger, err = config.NewLogger(logLevel, cfg.Logging.File)
@@ -198,6 +245,8 @@ func initGlobals(cmd *cobra.Command) error {
 		// Use null logger if we can't create the file
 		logger = config.NullLogger()
 	}
+	logger.SetJSONOutput(cfg.Logging.Format == "json")
+	enableRequestLogging(logger)
 
 	// Initialize formatter
 	explicitFormat := output.ParseFormat(cfg.Output.DefaultFormat)
@@ -216,8 +265,8 @@ func initGlobals(cmd *cobra.Command) error {
 	agentsPath := filepath.Join(cfg.Home, "agents")
 	cmdCtx.AgentStore = agent.NewFileStore(agentsPath)
 
-	// Auto-JSON output when SIGIL_AGENT_TOKEN is set (agents need machine-readable output)
-	if os.Getenv(config.EnvAgentToken) != "" && outputFormat == "" {
+	// Auto-JSON output when an agent token is set (agents need machine-readable output)
+	if walletservice.CheckAgentToken() != "" && outputFormat == "" {
 		detectedFormat = output.FormatJSON
 		formatter = output.NewFormatter(detectedFormat, os.Stdout)
 		cmdCtx.Fmt = formatter
@@ -232,6 +281,7 @@ func initGlobals(cmd *cobra.Command) error {
 
 // cleanup releases resources.
 func cleanup() {
+	reqlog.Disable()
 	if logger != nil {
 		if closeErr := logger.Close(); closeErr != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to close logger: %v\n", closeErr)
@@ -276,8 +326,13 @@ func init() {
 	versionCmd.GroupID = "config"
 	rootCmd.AddCommand(versionCmd)
 	rootCmd.PersistentFlags().StringVar(&homeDir, "home", "", "sigil data directory (default: ~/.sigil)")
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "auto", "output format: text, json, auto")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "auto", "output format: text, json, auto, or csv where supported")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose output")
+	rootCmd.PersistentFlags().StringVar(&logFormat, "log-format", "", "log file format: text or json (default: config value)")
 	rootCmd.PersistentFlags().StringVar(&networkFlag, "network", "", "BSV network: main or test (default: config value)")
 	rootCmd.PersistentFlags().BoolVar(&testnetFlag, "testnet", false, "shortcut for --network test")
+	rootCmd.PersistentFlags().StringVar(&rpcProfile, "rpc-profile", "", "named ETH RPC profile from networks.eth.rpc_profiles (default: config value)")
+	rootCmd.PersistentFlags().BoolVar(&timingsFlag, "timings", false, "print a per-phase timing breakdown to stderr when the command finishes")
+	rootCmd.PersistentFlags().StringVar(&faultInjectionFlag, "fault-injection", "", "inject provider faults, e.g. rate=0.3,kinds=timeout+429+malformed (testing only)")
+	_ = rootCmd.PersistentFlags().MarkHidden("fault-injection")
 }
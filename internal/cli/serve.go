@@ -0,0 +1,773 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/listen"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/server"
+	"github.com/mrz1836/sigil/internal/service/balance"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/txintent"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	serveWallet        string
+	serveAddr          string
+	serveSocket        string
+	serveAllowCIDRs    []string
+	serveTLSCert       string
+	serveTLSKey        string
+	serveTLSSelfSigned bool
+	serveTLSClientCA   string
+	serveRateLimit     int
+)
+
+// serveShutdownTimeout bounds how long in-flight requests may take to finish
+// once the server is stopped.
+const serveShutdownTimeout = 30 * time.Second
+
+// serveCmd runs the wallet API daemon.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a wallet over a local HTTP API",
+	Long: `Run a long-lived server exposing a wallet's balances, addresses, and sends
+over HTTP, so other tools can integrate without shelling out to sigil.
+
+Clients authenticate with an agent token from 'sigil agent create', sent as
+"Authorization: Bearer <token>". Each request runs with that agent's
+chains, assets, host binding, and spending limits: per-transaction and daily
+limits and the address allowlist are checked before every send, and spends
+count toward the agent's daily total like 'tx send' in agent mode. Every
+send, allowed or refused, is recorded in the agent's audit log ('sigil
+agent audit'). Each agent may make --rate-limit requests a minute, and each
+client address 10 failed authentications a minute; further requests get
+HTTP 429 with a Retry-After header.
+
+The API is served as REST routes and as JSON-RPC 2.0:
+
+  GET  /v1/health                  No token needed
+  GET  /v1/balance?chain=bsv       Balances of the agent's chains
+  GET  /v1/addresses?chain=bsv     Addresses of the agent's chains
+  POST /v1/tx/build                Build an unsigned ETH or BSV transaction
+  POST /v1/tx/send                 Sign and broadcast a transaction
+  POST /v1/rpc                     JSON-RPC methods: health, balance,
+                                   addresses, tx.build, tx.send
+
+Send and build bodies take chain, to, amount, and optionally token, gas,
+and max_fee_rate. Errors use the same JSON as '-o json' on the command line.
+
+With outbox.enabled in the config, a BSV, BTC, or BCH send that cannot reach
+any broadcast provider is queued rather than failed: its status is "queued"
+and queued_until says when it expires. The server flushes the wallet's
+outbox every outbox.flush_interval_seconds (see 'sigil outbox').
+
+The server listens on 127.0.0.1:7420 by default, or on a unix socket with
+--socket (created mode 0600). Binding beyond loopback requires TLS and
+--allow-cidr. Sends at or above security.require_confirm_above are refused,
+since nobody is present to type their confirmation code. Stop the server
+with Ctrl+C or SIGTERM; in-flight requests are allowed to finish.`,
+	Example: `  sigil serve --wallet main
+  sigil serve --wallet main --socket ~/.sigil/serve.sock
+  sigil serve --wallet main --addr 0.0.0.0:7420 --tls-self-signed --allow-cidr 10.0.0.0/8
+  curl -H "Authorization: Bearer $SIGIL_AGENT_TOKEN" http://127.0.0.1:7420/v1/balance?chain=bsv`,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	serveCmd.GroupID = "utility"
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().StringVar(&serveWallet, "wallet", "", "wallet to serve (defaults to config default_wallet)")
+	serveCmd.Flags().StringVar(&serveAddr, "addr", listen.DefaultAddr, "host:port to listen on")
+	serveCmd.Flags().StringVar(&serveSocket, "socket", "", "listen on a unix socket at this path instead of TCP")
+	serveCmd.Flags().StringSliceVar(&serveAllowCIDRs, "allow-cidr", nil, "client networks allowed to connect, as CIDRs or addresses (repeatable)")
+	serveCmd.Flags().StringVar(&serveTLSCert, "tls-cert", "", "PEM certificate to serve TLS with")
+	serveCmd.Flags().StringVar(&serveTLSKey, "tls-key", "", "PEM private key of --tls-cert")
+	serveCmd.Flags().BoolVar(&serveTLSSelfSigned, "tls-self-signed", false, "serve TLS with a self-signed certificate kept under <home>/serve")
+	serveCmd.Flags().StringVar(&serveTLSClientCA, "tls-client-ca", "", "PEM CA bundle; clients must present a certificate it signed")
+	serveCmd.Flags().IntVar(&serveRateLimit, "rate-limit", 60, "requests a minute allowed per agent (0 disables)")
+
+	serveCmd.MarkFlagsRequiredTogether("tls-cert", "tls-key")
+	serveCmd.MarkFlagsMutuallyExclusive("tls-cert", "tls-self-signed")
+	for _, tcpFlag := range []string{"addr", "allow-cidr", "tls-cert", "tls-self-signed", "tls-client-ca"} {
+		serveCmd.MarkFlagsMutuallyExclusive("socket", tcpFlag)
+	}
+}
+
+func runServe(cmd *cobra.Command, _ []string) error {
+	if serveRateLimit < 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--rate-limit must be zero or more")
+	}
+	if err := resolveWalletName(cmd, &serveWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// The wallet's stamped network governs every request
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(serveWallet)
+	if err != nil {
+		return err
+	}
+	applyWalletNetwork(cmd, wlt)
+
+	if cc.AgentStore == nil {
+		cc.AgentStore = agent.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "agents"))
+	}
+	v, _, _ := resolvedBuildInfo(buildInfo)
+	backend := &serveBackend{cmd: cmd, cc: cc, wallet: serveWallet, storage: storage}
+	cfg := &server.Config{
+		Wallet:    serveWallet,
+		Version:   v,
+		Auth:      &serveAuth{store: cc.AgentStore, wallet: serveWallet},
+		Backend:   backend,
+		RateLimit: serveRateLimit,
+	}
+	if cc.Log != nil {
+		cfg.Logger = cc.Log
+	}
+
+	ln, url, err := serveListener(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	if interval := cc.Cfg.GetOutbox().FlushIntervalSeconds; interval > 0 {
+		go backend.flushOutboxEvery(ctx, time.Duration(interval)*time.Second)
+	}
+
+	httpServer := &http.Server{
+		Handler:           server.New(cfg).Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		if err := writeJSON(w, map[string]string{"wallet": serveWallet, "url": url}); err != nil {
+			_ = ln.Close()
+			return err
+		}
+	} else {
+		out(w, "Serving wallet '%s' on %s\n", serveWallet, url)
+		outln(w, "Press Ctrl+C to stop.")
+	}
+
+	if err := httpServer.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("serving API: %w", err)
+	}
+	return nil
+}
+
+// serveListener opens the unix socket or TCP listener of the serve flags and
+// returns it with the URL clients reach it at.
+func serveListener(ctx context.Context, cc *CommandContext) (net.Listener, string, error) {
+	if serveSocket != "" {
+		ln, err := listenUnixSocket(ctx, serveSocket)
+		if err != nil {
+			return nil, "", err
+		}
+		return ln, "unix://" + serveSocket, nil
+	}
+
+	opts := listen.Options{Addr: serveAddr, AllowedCIDRs: serveAllowCIDRs}
+	if serveTLSCert != "" || serveTLSSelfSigned || serveTLSClientCA != "" {
+		opts.TLS = &listen.TLSOptions{
+			CertFile:     serveTLSCert,
+			KeyFile:      serveTLSKey,
+			ClientCAFile: serveTLSClientCA,
+		}
+		if serveTLSCert == "" {
+			opts.TLS.SelfSignedDir = filepath.Join(cc.Cfg.GetHome(), "serve")
+		}
+	}
+	ln, err := listen.Listen(ctx, opts)
+	if err != nil {
+		return nil, "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("cannot listen: %v; binding beyond loopback needs --tls-cert/--tls-key or --tls-self-signed, and --allow-cidr", err),
+		)
+	}
+	scheme := "http"
+	if opts.TLS != nil {
+		scheme = "https"
+	}
+	return ln, scheme + "://" + ln.Addr().String(), nil
+}
+
+// listenUnixSocket listens on a unix socket only its owner can connect to.
+// A stale socket left by a server that did not shut down cleanly is
+// replaced; a live one, or any other file, is not.
+func listenUnixSocket(ctx context.Context, path string) (net.Listener, error) {
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("%s exists and is not a socket; choose another --socket path", path),
+			)
+		}
+		var d net.Dialer
+		if conn, dialErr := d.DialContext(ctx, "unix", path); dialErr == nil {
+			_ = conn.Close()
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("another server is listening on %s", path),
+			)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("removing stale socket: %w", err)
+		}
+	}
+
+	var lc net.ListenConfig
+	ln, err := lc.Listen(ctx, "unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listening on %s: %w", path, err)
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("restricting socket permissions: %w", err)
+	}
+	return ln, nil
+}
+
+// serveAuth authenticates API clients with the wallet's agent tokens.
+type serveAuth struct {
+	store  *agent.FileStore
+	wallet string
+}
+
+// Authenticate implements server.Authenticator. It applies the same checks
+// as SIGIL_AGENT_TOKEN on the command line: the token must match an agent of
+// the wallet that has not expired and, if bound to a host, this host.
+func (a *serveAuth) Authenticate(_ context.Context, token string) (*server.Session, error) {
+	seed, cred, err := a.store.LoadByToken(a.wallet, token)
+	if err != nil {
+		if expired := a.expiredAgent(token); expired != nil {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrAgentTokenExpired,
+				fmt.Sprintf("agent '%s' expired at %s. Create a new agent with: sigil agent create --wallet %s",
+					expired.ID, expired.ExpiresAt.Format(time.RFC3339), a.wallet),
+			)
+		}
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrAgentTokenInvalid,
+			fmt.Sprintf("agent token does not match any agent for wallet '%s'. "+
+				"Create one with: sigil agent create --wallet %s", a.wallet, a.wallet),
+		)
+	}
+	if err := enforceAgentHost(cred); err != nil {
+		wallet.ZeroBytes(seed)
+		return nil, err
+	}
+	return &server.Session{Credential: cred, Token: token, Seed: seed}, nil
+}
+
+// expiredAgent returns the expired agent a token belongs to, or nil. The
+// store refuses to decrypt an expired agent, so it is found by the ID the
+// token derives.
+func (a *serveAuth) expiredAgent(token string) *agent.Credential {
+	agents, err := a.store.List(a.wallet)
+	if err != nil {
+		return nil
+	}
+	id := agent.TokenID(token)
+	for _, cred := range agents {
+		if cred.ID == id && cred.IsExpired() {
+			return cred
+		}
+	}
+	return nil
+}
+
+// serveBackend performs API requests against the served wallet. Requests
+// run one at a time, so concurrent sends cannot pick the same inputs and
+// balance refreshes do not race on the cache file.
+type serveBackend struct {
+	cmd     *cobra.Command
+	cc      *CommandContext
+	wallet  string
+	storage *wallet.FileStorage
+
+	mu sync.Mutex
+}
+
+// serveAddress is an address in the addresses response.
+type serveAddress struct {
+	Chain    string `json:"chain"`
+	Address  string `json:"address"`
+	Path     string `json:"path"`
+	Index    uint32 `json:"index"`
+	IsChange bool   `json:"is_change"`
+}
+
+// serveSendResult is the response to a send.
+type serveSendResult struct {
+	Hash        string `json:"hash"`
+	Chain       string `json:"chain"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Amount      string `json:"amount"`
+	Fee         string `json:"fee"`
+	Token       string `json:"token,omitempty"`
+	Status      string `json:"status"`
+	ExplorerURL string `json:"explorer_url,omitempty"`
+	// QueuedUntil is when a queued send expires from the outbox.
+	QueuedUntil *time.Time `json:"queued_until,omitempty"`
+}
+
+// sessionChains returns the chains a request covers: the requested one, or
+// every enabled chain the agent may use.
+func (b *serveBackend) sessionChains(s *server.Session, name string) ([]chain.ID, error) {
+	if name != "" {
+		chainID, err := parseEnabledChain(b.cc.Cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		if err := authorizeAgentChain(s.Credential, chainID); err != nil {
+			return nil, err
+		}
+		return []chain.ID{chainID}, nil
+	}
+	var chains []chain.ID
+	for _, chainID := range enabledChains(b.cc.Cfg) {
+		if s.Credential.HasChain(chainID) {
+			chains = append(chains, chainID)
+		}
+	}
+	return chains, nil
+}
+
+// authorizeAgentChain rejects a chain the agent is not authorized for.
+func authorizeAgentChain(cred *agent.Credential, chainID chain.ID) error {
+	if cred.HasChain(chainID) {
+		return nil
+	}
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrAgentChainDenied,
+		fmt.Sprintf("agent '%s' is not authorized for chain %s (allowed: %v)", cred.ID, chainID, cred.Chains),
+	)
+}
+
+// Balances implements server.Backend with the balances 'balance show' reports.
+func (b *serveBackend) Balances(ctx context.Context, s *server.Session, p *server.ChainParams) (any, error) {
+	chains, err := b.sessionChains(s, p.Chain)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wlt, err := b.storage.LoadMetadata(b.wallet)
+	if err != nil {
+		return nil, err
+	}
+	var addresses []balance.AddressInput
+	for _, chainID := range chains {
+		addresses = append(addresses, buildAddressList(wlt, string(chainID))...)
+	}
+
+	utxoStore := loadUTXOStore(b.cc, b.wallet)
+	balanceCache := loadBalanceCache(b.cc, io.Discard)
+	balanceService := balance.NewService(&balance.Config{
+		ConfigProvider: b.cc.Cfg,
+		CacheProvider:  balance.NewCacheAdapter(balanceCache),
+		Metadata:       balance.NewMetadataAdapter(utxoStore),
+		Network:        effectiveBSVNetwork(wlt, b.cc.Cfg),
+	})
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	batchResult, err := balanceService.FetchBalances(ctx, &balance.FetchBatchRequest{
+		Addresses: addresses,
+		Limiter:   newBalanceLimiter(b.cc.Cfg),
+		Timeout:   30 * time.Second,
+	})
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return nil, err
+	}
+	saveBalanceCache(b.cc, balanceCache)
+
+	response := convertToBalanceResponse(b.wallet, batchResult)
+	annotateImmatureBalances(response.Balances, utxoStore)
+	applyBalanceEnvelopes(&response, utxoStore, false)
+	return response, nil
+}
+
+// Addresses implements server.Backend with the wallet's receive and change
+// addresses.
+func (b *serveBackend) Addresses(_ context.Context, s *server.Session, p *server.ChainParams) (any, error) {
+	chains, err := b.sessionChains(s, p.Chain)
+	if err != nil {
+		return nil, err
+	}
+	wlt, err := b.storage.LoadMetadata(b.wallet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]serveAddress, 0)
+	for _, chainID := range chains {
+		addrs := slices.Concat(wlt.Addresses[chainID], wlt.ChangeAddresses[chainID])
+		for _, addr := range addrs {
+			result = append(result, serveAddress{
+				Chain:    string(chainID),
+				Address:  addr.Address,
+				Path:     addr.Path,
+				Index:    addr.Index,
+				IsChange: addr.IsChange,
+			})
+		}
+	}
+	return result, nil
+}
+
+// serveToken resolves the token of a build or send. Only tokens in the
+// configuration are accepted: an unknown contract cannot be reviewed
+// without a terminal.
+func serveToken(cfg ConfigProvider, chainID chain.ID, token string) (*eth.TokenMetadata, error) {
+	if token == "" {
+		return nil, nil //nolint:nilnil // no token means the native coin
+	}
+	if chainID != chain.ETH {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "token is only supported for chain eth")
+	}
+	meta, ok := transaction.LookupToken(cfg.GetETHTokens(), token)
+	if !ok {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("unknown token: %s (add it to networks.eth.tokens in config.yaml first)", token),
+		)
+	}
+	return meta, nil
+}
+
+// Build implements server.Backend with an unsigned transaction, as written
+// by 'tx build'. The session's agent must be allowed the chain and asset,
+// as for Send.
+func (b *serveBackend) Build(ctx context.Context, s *server.Session, p *server.SendParams) (any, error) {
+	cred := s.Credential
+	chainID, err := parseEnabledChain(b.cc.Cfg, p.Chain)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeAgentChain(cred, chainID); err != nil {
+		return nil, err
+	}
+	meta, err := serveToken(b.cc.Cfg, chainID, p.Token)
+	if err != nil {
+		return nil, err
+	}
+	var tokenAddress string
+	if meta != nil {
+		tokenAddress = meta.Address
+	}
+	if err := enforceAgentAsset(cred, chainID, tokenAddress); err != nil {
+		return nil, err
+	}
+	if err := checkOfflineChain(chainID); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wlt, err := b.storage.LoadMetadata(b.wallet)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+	return buildOfflineTx(ctx, b.cmd, wlt, chainID, offlineBuild{
+		to:         p.To,
+		amount:     p.Amount,
+		gasSpeed:   serveGasSpeed(p.Gas),
+		token:      meta,
+		maxFeeRate: p.MaxFeeRate,
+	})
+}
+
+// serveGasSpeed defaults an empty gas speed to medium, like tx send --gas.
+func serveGasSpeed(gas string) string {
+	if gas == "" {
+		return "medium"
+	}
+	return gas
+}
+
+// Send implements server.Backend by signing and broadcasting a transaction
+// within the session agent's policy, and records the outcome in the agent's
+// audit log.
+func (b *serveBackend) Send(ctx context.Context, s *server.Session, p *server.SendParams) (any, error) {
+	result, err := b.send(ctx, s, p)
+	entry := agentSendEntry(agentAuditSourceServe, chain.ID(p.Chain), p.To, p.Amount, p.Token)
+	if sent, ok := result.(*serveSendResult); ok {
+		entry.Chain, entry.To, entry.Amount, entry.Fee, entry.TxHash = chain.ID(sent.Chain), sent.To, sent.Amount, sent.Fee, sent.Hash
+	}
+	recordAgentAudit(b.cc, b.wallet, s.Credential.ID, entry, err)
+	return result, err
+}
+
+// send sends a transaction for the session's agent within its policy.
+//
+//nolint:gocognit,gocyclo // Each agent policy check is a separate guard
+func (b *serveBackend) send(ctx context.Context, s *server.Session, p *server.SendParams) (any, error) {
+	cc := b.cc
+	cred := s.Credential
+
+	chainID, err := parseEnabledChain(cc.Cfg, p.Chain)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorizeAgentChain(cred, chainID); err != nil {
+		return nil, err
+	}
+	meta, err := serveToken(cc.Cfg, chainID, p.Token)
+	if err != nil {
+		return nil, err
+	}
+	var tokenAddress string
+	decimals := 8
+	if chainID == chain.ETH {
+		decimals = 18
+	}
+	if meta != nil {
+		tokenAddress, decimals = meta.Address, meta.Decimals
+	}
+	if err := enforceAgentAsset(cred, chainID, tokenAddress); err != nil {
+		return nil, err
+	}
+
+	to := p.To
+	if chainID == chain.ETH {
+		if to, err = transaction.NormalizeETHRecipient(to, false); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check the agent's limits before touching the wallet
+	counterPath := cc.AgentStore.CounterPath(b.wallet, cred.ID)
+	amount := new(big.Int)
+	if isAmountAll(p.Amount) {
+		if agentHasSpendLimit(cred, chainID, tokenAddress) {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrAgentPolicyViolation,
+				fmt.Sprintf("agent '%s' has spending limits on %s, so it cannot send 'all'; send an exact amount", cred.ID, chainID),
+			)
+		}
+	} else if amount, err = parseDecimalAmount(p.Amount, decimals); err != nil {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("invalid amount: %s", p.Amount))
+	}
+	// A token send is held only to its token's rules, checked with the send
+	if tokenAddress != "" {
+		if err := agent.ValidateTransaction(cred, chainID, to, new(big.Int)); err != nil {
+			return nil, sigilerr.WithSuggestion(sigilerr.ErrAgentPolicyViolation, err.Error())
+		}
+	} else if err := transaction.EnforceAgentPolicy(cred, counterPath, s.Token, chainID, to, amount); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wlt, err := b.storage.LoadMetadata(b.wallet)
+	if err != nil {
+		return nil, err
+	}
+	addresses := wlt.Addresses[chainID]
+	if len(addresses) == 0 {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("wallet '%s' has no addresses for chain %s", b.wallet, chainID),
+		)
+	}
+
+	req := &transaction.SendRequest{
+		ChainID:          chainID,
+		To:               to,
+		AmountStr:        p.Amount,
+		Wallet:           b.wallet,
+		FromAddress:      addresses[0].Address,
+		Token:            p.Token,
+		TokenMeta:        meta,
+		GasSpeed:         serveGasSpeed(p.Gas),
+		Addresses:        addresses,
+		Network:          effectiveBSVNetwork(wlt, cc.Cfg),
+		MaxFeeRate:       p.MaxFeeRate,
+		Confirm:          true,
+		Seed:             s.Seed,
+		AgentCredID:      cred.ID,
+		AgentToken:       s.Token,
+		AgentCounterPath: counterPath,
+	}
+	if outbox := cc.Cfg.GetOutbox(); outbox.Enabled && chainID != chain.ETH {
+		req.QueueExpiry = time.Duration(outbox.ExpiryHours) * time.Hour
+	}
+	if chainID == chain.BSV {
+		coinSelection, csErr := resolveCoinSelection(cc.Cfg)
+		if csErr != nil {
+			return nil, csErr
+		}
+		req.CoinSelection = string(coinSelection)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
+	defer cancel()
+
+	// Nobody is present to type a confirmation code
+	confirm := newSendConfirmParams(chainID, req)
+	if chainID == chain.ETH {
+		confirm = newETHConfirmParams(req, req.GasSpeed)
+	}
+	if reason := sendConfirmCodeReason(ctx, cc, confirm); reason != "" {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("the server cannot send this because %s; send it with sigil tx send at a terminal", reason),
+		)
+	}
+
+	// Hold the wallet lock against sigil commands running alongside
+	lock, err := lockWallet(b.cmd, b.storage, b.wallet, "serve tx send")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = lock.Release() }()
+
+	// Check again under the lock: a send that finished since the first check
+	// may have used up the rolling allowance
+	if err := enforceAgentSpend(cred, counterPath, s.Token, req, decimals); err != nil {
+		return nil, err
+	}
+
+	txService, err := newTransactionService(cc, b.storage)
+	if err != nil {
+		return nil, err
+	}
+	result, err := txService.Send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if result.Queued() {
+		return &serveSendResult{
+			Hash:        result.Hash,
+			Chain:       string(chainID),
+			From:        result.From,
+			To:          result.To,
+			Amount:      result.Amount,
+			Fee:         result.Fee,
+			Status:      result.Status,
+			QueuedUntil: &result.QueuedUntil,
+		}, nil
+	}
+	runPostSendHook(ctx, cc, sentFromResults(b.wallet, result)...)
+
+	network := req.Network
+	if chainID == chain.ETH {
+		network = ethNetwork(cc.Cfg)
+	}
+	return &serveSendResult{
+		Hash:        result.Hash,
+		Chain:       string(chainID),
+		From:        result.From,
+		To:          result.To,
+		Amount:      result.Amount,
+		Fee:         result.Fee,
+		Token:       result.Token,
+		Status:      result.Status,
+		ExplorerURL: explorerTxLink(chainID, network, result.Hash),
+	}, nil
+}
+
+// flushOutboxEvery flushes the wallet's outbox every interval until ctx is
+// done. Each flush runs between requests and under the wallet lock.
+func (b *serveBackend) flushOutboxEvery(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			b.flushOutbox(ctx)
+		}
+	}
+}
+
+// flushOutbox broadcasts the wallet's queued sends, logging the outcome.
+func (b *serveBackend) flushOutbox(ctx context.Context) {
+	walletsDir := filepath.Join(b.cc.Cfg.GetHome(), "wallets")
+	if counts, err := txintent.Outbox(walletsDir); err != nil || counts[b.wallet] == 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	svc := transaction.NewService(&transaction.Config{Config: b.cc.Cfg, Logger: b.cc.Log})
+	items, err := flushWalletOutbox(ctx, b.cmd, b.storage, svc, walletsDir, b.wallet)
+	if err != nil {
+		logTxError(b.cc, "outbox flush: %v", err)
+		return
+	}
+	for _, item := range items {
+		if item.Action == intentActionFailed {
+			logTxError(b.cc, "outbox flush: %s still queued: %s", item.Hash, item.Error)
+		} else if b.cc.Log != nil {
+			b.cc.Log.Debug("outbox flush: %s %s", item.Hash, item.Action)
+		}
+	}
+}
+
+// agentHasSpendLimit reports whether the agent has a per-transaction or
+// daily limit on a chain, or a policy rule limiting the asset sent. contract
+// is the ERC-20 contract, or "" for the native coin.
+func agentHasSpendLimit(cred *agent.Credential, chainID chain.ID, contract string) bool {
+	policy := &cred.Policy
+	if policy.HasRuleLimit(chainID, contract) {
+		return true
+	}
+	if contract != "" {
+		return false // Token sends are held only to their token's rules
+	}
+	if chainID == chain.ETH {
+		return policy.MaxPerTxWeiBig() != nil || policy.MaxDailyWeiBig() != nil
+	}
+	return policy.MaxPerTxSat > 0 || policy.MaxDailySat > 0
+}
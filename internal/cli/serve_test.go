@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/server"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// createServeAgent stores an agent for test-wallet and returns its token.
+func createServeAgent(t *testing.T, tmpDir string, cmdCtx *CommandContext, cred *agent.Credential) string {
+	t.Helper()
+
+	storage := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets"))
+	_, seed, err := storage.Load("test-wallet", []byte("testpass123"))
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+
+	token, err := agent.GenerateToken()
+	require.NoError(t, err)
+	cred.ID = agent.TokenID(token)
+	cred.WalletName = "test-wallet"
+	cred.CreatedAt = time.Now()
+	if cred.ExpiresAt.IsZero() {
+		cred.ExpiresAt = time.Now().Add(time.Hour)
+	}
+	require.NoError(t, cmdCtx.AgentStore.CreateCredential(cred, token, seed))
+	return token
+}
+
+func newServeTestBackend(tmpDir string, cmdCtx *CommandContext) *serveBackend {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, cmdCtx)
+	return &serveBackend{
+		cmd:     cmd,
+		cc:      cmdCtx,
+		wallet:  "test-wallet",
+		storage: wallet.NewFileStorage(filepath.Join(tmpDir, "wallets")),
+	}
+}
+
+func TestServeAuth_Authenticate(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+	createTestWalletForAgent(t, tmpDir)
+
+	token := createServeAgent(t, tmpDir, cmdCtx, &agent.Credential{Label: "bot", Chains: []chain.ID{chain.BSV}})
+	expired := createServeAgent(t, tmpDir, cmdCtx, &agent.Credential{
+		Label:     "old",
+		Chains:    []chain.ID{chain.BSV},
+		ExpiresAt: time.Now().Add(-time.Minute),
+	})
+	auth := &serveAuth{store: cmdCtx.AgentStore, wallet: "test-wallet"}
+
+	sess, err := auth.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "bot", sess.Credential.Label)
+	assert.NotEmpty(t, sess.Seed)
+	sess.Close()
+	assert.Nil(t, sess.Seed)
+
+	_, err = auth.Authenticate(context.Background(), "sigil_agt_unknown")
+	require.ErrorIs(t, err, sigilerr.ErrAgentTokenInvalid)
+
+	_, err = auth.Authenticate(context.Background(), expired)
+	require.ErrorIs(t, err, sigilerr.ErrAgentTokenExpired)
+}
+
+func TestServeBackend_Addresses(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+	createTestWalletForAgent(t, tmpDir)
+	backend := newServeTestBackend(tmpDir, cmdCtx)
+	sess := &server.Session{Credential: &agent.Credential{ID: "agt_1", Chains: []chain.ID{chain.BSV}}}
+
+	result, err := backend.Addresses(context.Background(), sess, &server.ChainParams{})
+	require.NoError(t, err)
+	addrs, ok := result.([]serveAddress)
+	require.True(t, ok)
+	require.Len(t, addrs, 1)
+	assert.Equal(t, "bsv", addrs[0].Chain)
+	assert.NotEmpty(t, addrs[0].Address)
+
+	// A chain outside the agent's is refused, not silently empty
+	_, err = backend.Addresses(context.Background(), sess, &server.ChainParams{Chain: "eth"})
+	require.ErrorIs(t, err, sigilerr.ErrAgentChainDenied)
+}
+
+func TestServeBackend_SendPolicy(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+	createTestWalletForAgent(t, tmpDir)
+	backend := newServeTestBackend(tmpDir, cmdCtx)
+
+	cred := &agent.Credential{
+		ID:     "agt_limited",
+		Chains: []chain.ID{chain.BSV},
+		Policy: agent.Policy{MaxPerTxSat: 10000, AllowedAddrs: []string{"1BoatSLRHtKNngkdXEeobR76b53LETtpyT"}},
+	}
+	sess := &server.Session{Credential: cred, Token: "sigil_agt_test"}
+
+	tests := []struct {
+		name   string
+		params server.SendParams
+		want   error
+	}{
+		{"chain not authorized", server.SendParams{Chain: "eth", To: "0x0", Amount: "1"}, sigilerr.ErrAgentChainDenied},
+		{"token on bsv", server.SendParams{Chain: "bsv", To: "1x", Amount: "1", Token: "USDC"}, sigilerr.ErrInvalidInput},
+		{"sweep with limits", server.SendParams{Chain: "bsv", To: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Amount: "all"}, sigilerr.ErrAgentPolicyViolation},
+		{"over per-tx limit", server.SendParams{Chain: "bsv", To: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Amount: "0.001"}, sigilerr.ErrAgentPolicyViolation},
+		{"recipient not allowed", server.SendParams{Chain: "bsv", To: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", Amount: "0.00001"}, sigilerr.ErrAgentPolicyViolation},
+		{"bad amount", server.SendParams{Chain: "bsv", To: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Amount: "lots"}, sigilerr.ErrInvalidInput},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := backend.Send(context.Background(), sess, &tc.params)
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+
+	// Every refused send is in the agent's audit log
+	entries, err := cmdCtx.AgentStore.ReadAudit("test-wallet", "agt_limited")
+	require.NoError(t, err)
+	require.Len(t, entries, len(tests))
+	assert.Equal(t, agent.DecisionDenied, entries[0].Decision)
+	assert.Equal(t, agentAuditSourceServe, entries[0].Source)
+	assert.Equal(t, agent.DecisionFailed, entries[1].Decision)
+}
+
+func TestServeBackend_BuildPolicy(t *testing.T) {
+	tmpDir, cmdCtx, cleanup := setupAgentTest(t)
+	defer cleanup()
+	createTestWalletForAgent(t, tmpDir)
+	backend := newServeTestBackend(tmpDir, cmdCtx)
+
+	// Builds are held to the chain and asset checks of sends
+	cred := &agent.Credential{
+		ID:     "agt_build",
+		Chains: []chain.ID{chain.BSV},
+		Policy: agent.Policy{AllowedAssets: []string{"0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"}},
+	}
+	sess := &server.Session{Credential: cred, Token: "sigil_agt_test"}
+
+	tests := []struct {
+		name   string
+		params server.SendParams
+		want   error
+	}{
+		{"chain not enabled", server.SendParams{Chain: "doge", To: "1x", Amount: "1"}, sigilerr.ErrInvalidInput},
+		{"chain not authorized", server.SendParams{Chain: "eth", To: "0x0", Amount: "1"}, sigilerr.ErrAgentChainDenied},
+		{"asset not allowed", server.SendParams{Chain: "bsv", To: "1BoatSLRHtKNngkdXEeobR76b53LETtpyT", Amount: "0.0001"}, sigilerr.ErrAgentAssetDenied},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := backend.Build(context.Background(), sess, &tc.params)
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+}
+
+func TestAgentHasSpendLimit(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, agentHasSpendLimit(&agent.Credential{}, chain.BSV, ""))
+	assert.True(t, agentHasSpendLimit(&agent.Credential{Policy: agent.Policy{MaxDailySat: 1}}, chain.BSV, ""))
+	assert.False(t, agentHasSpendLimit(&agent.Credential{Policy: agent.Policy{MaxDailySat: 1}}, chain.ETH, ""))
+	assert.True(t, agentHasSpendLimit(&agent.Credential{Policy: agent.Policy{MaxPerTxWei: "1000"}}, chain.ETH, ""))
+
+	const usdc = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+	ruled := &agent.Credential{Policy: agent.Policy{Rules: []agent.Rule{{ID: "r1", Chain: chain.ETH, Token: usdc, MaxDaily: "100"}}}}
+	assert.True(t, agentHasSpendLimit(ruled, chain.ETH, usdc))
+	assert.False(t, agentHasSpendLimit(ruled, chain.ETH, ""))
+}
+
+func TestListenUnixSocket(t *testing.T) {
+	t.Parallel()
+	// Socket paths are length-limited, so avoid the long test temp dir
+	dir, err := os.MkdirTemp("", "sigil-serve")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	path := filepath.Join(dir, "serve.sock")
+
+	ln, err := listenUnixSocket(context.Background(), path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// A live socket is not replaced
+	_, err = listenUnixSocket(context.Background(), path)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	require.NoError(t, ln.Close())
+
+	// A stale socket is
+	stale, err := (&net.ListenConfig{}).Listen(context.Background(), "unix", path)
+	require.NoError(t, err)
+	stale.(*net.UnixListener).SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+	ln, err = listenUnixSocket(context.Background(), path)
+	require.NoError(t, err)
+	require.NoError(t, ln.Close())
+
+	// Any other file is not
+	other := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(other, nil, 0o600))
+	_, err = listenUnixSocket(context.Background(), other)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// shutdown cancels the command context on the first SIGINT or SIGTERM.
+// Long-running commands watch the context, save what they completed, and
+// print a summary before returning. Handling is released after the first
+// signal, so a second one terminates sigil at once.
+type shutdown struct {
+	cancel context.CancelFunc
+	sigs   chan os.Signal
+	done   chan struct{}
+
+	mu  sync.Mutex
+	sig os.Signal
+}
+
+// notifyShutdown starts listening for SIGINT and SIGTERM and returns the
+// context they cancel.
+func notifyShutdown(parent context.Context) (context.Context, *shutdown) {
+	ctx, cancel := context.WithCancel(parent)
+	s := &shutdown{
+		cancel: cancel,
+		sigs:   make(chan os.Signal, 1),
+		done:   make(chan struct{}),
+	}
+	signal.Notify(s.sigs, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-s.sigs:
+			s.interrupt(sig)
+		case <-s.done:
+		}
+	}()
+	return ctx, s
+}
+
+// interrupt records sig and cancels the context.
+func (s *shutdown) interrupt(sig os.Signal) {
+	signal.Stop(s.sigs)
+
+	s.mu.Lock()
+	if s.sig == nil {
+		s.sig = sig
+	}
+	s.mu.Unlock()
+
+	s.cancel()
+}
+
+// stop releases the signals and the context.
+func (s *shutdown) stop() {
+	signal.Stop(s.sigs)
+	close(s.done)
+	s.cancel()
+}
+
+// result maps the command's outcome to ErrCanceled once a signal arrived, so
+// an interrupted run exits with ExitCanceled even if the command returned
+// cleanly after printing its partial summary.
+func (s *shutdown) result(err error) error {
+	s.mu.Lock()
+	sig := s.sig
+	s.mu.Unlock()
+
+	if sig == nil {
+		return err
+	}
+	canceled := sigilerr.WithDetails(sigilerr.ErrCanceled, map[string]string{"signal": sig.String()})
+	return sigilerr.WithSuggestion(canceled, "completed work was saved; run the command again to continue")
+}
+
+// commandCanceled reports whether cmd's context was canceled by a signal.
+func commandCanceled(cmd *cobra.Command) bool {
+	ctx := cmd.Context()
+	return ctx != nil && isCanceled(ctx.Err())
+}
+
+// isCanceled reports whether err comes from a canceled context, as opposed
+// to a timeout.
+func isCanceled(err error) bool {
+	return errors.Is(err, context.Canceled)
+}
@@ -0,0 +1,64 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+var errShutdownTest = errors.New("boom")
+
+func TestShutdown(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no signal keeps the outcome", func(t *testing.T) {
+		t.Parallel()
+		ctx, sd := notifyShutdown(context.Background())
+		defer sd.stop()
+
+		require.NoError(t, ctx.Err())
+		require.NoError(t, sd.result(nil))
+		require.ErrorIs(t, sd.result(errShutdownTest), errShutdownTest)
+	})
+
+	t.Run("signal cancels and maps to canceled", func(t *testing.T) {
+		t.Parallel()
+		ctx, sd := notifyShutdown(context.Background())
+		defer sd.stop()
+
+		sd.interrupt(os.Interrupt)
+		<-ctx.Done()
+		require.ErrorIs(t, ctx.Err(), context.Canceled)
+
+		for _, err := range []error{nil, fmt.Errorf("scanning wallet: %w", context.Canceled)} {
+			got := sd.result(err)
+			require.ErrorIs(t, got, sigilerr.ErrCanceled)
+			assert.Equal(t, sigilerr.ExitCanceled, sigilerr.ExitCode(got))
+			assert.Contains(t, got.Error(), "interrupt")
+		}
+	})
+}
+
+func TestCommandCanceled(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{}
+	assert.False(t, commandCanceled(cmd), "no context")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd.SetContext(ctx)
+	assert.False(t, commandCanceled(cmd))
+
+	cancel()
+	assert.True(t, commandCanceled(cmd))
+
+	assert.False(t, isCanceled(context.DeadlineExceeded), "a timeout is not a cancellation")
+}
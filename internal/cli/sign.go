@@ -0,0 +1,409 @@
+package cli
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/msgsign"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// signWallet is the wallet holding the signing key.
+	signWallet string
+	// signChain is the chain whose first address signs when --address is not given.
+	signChain string
+	// signAddress is the wallet address whose key signs.
+	signAddress string
+	// signFile signs or verifies the contents of a file instead of a message argument.
+	signFile string
+)
+
+// signCmd signs a message with a wallet address key.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var signCmd = &cobra.Command{
+	Use:   "sign [message]",
+	Short: "Sign a message with an address key to prove ownership",
+	Long: `Sign a message with the private key of one of the wallet's addresses.
+The signature proves that whoever holds the wallet controls the address,
+without moving any funds.
+
+BSV, BTC, and BCH addresses sign in the Bitcoin Signed Message format and
+give a base64 signature. ETH addresses sign with EIP-191 personal_sign and
+give a 0x-prefixed hex signature. Both are accepted by other wallets and by
+'sigil verify'.
+
+Without --address, the wallet's first receiving address on --chain signs.
+The message is signed exactly as given, so quote it; use --file to sign the
+exact bytes of a file. For EIP-712 typed data, use 'sigil sign typed-data'.`,
+	Example: `  # Sign with the first BSV address
+  sigil sign "I control this address - 2026-10-16" --wallet main
+
+  # Sign with a specific address
+  sigil sign "Proof for Acme audit" --wallet main --address 1ABC...
+
+  # Sign with the ETH address
+  sigil sign "hello" --wallet main --chain eth
+
+  # Sign the contents of a file
+  sigil sign --file statement.txt --wallet main -o json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runSign,
+}
+
+// verifyCmd verifies a signed message.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var verifyCmd = &cobra.Command{
+	Use:   "verify <address> <signature> [message]",
+	Short: "Verify a signed message against an address",
+	Long: `Check that a message was signed by the key of an address. No wallet is
+needed, and nothing is sent over the network.
+
+An 0x address is verified as an EIP-191 personal_sign signature. Any other
+address must be a P2PKH address, in Base58Check or BCH CashAddr form, and
+is verified as a Bitcoin Signed Message. The message must match the signed
+one byte for byte; use --file for a signed file.
+
+The command exits with an error when the signature does not match.`,
+	Example: `  sigil verify 1ABC... "H+2x...=" "I control this address - 2026-10-16"
+  sigil verify 0x742d... 0x5f1c...1b "hello"
+  sigil verify 1ABC... "H+2x...=" --file statement.txt -o json`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runVerify,
+}
+
+// signTypedDataCmd signs EIP-712 typed data with an ETH address key.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var signTypedDataCmd = &cobra.Command{
+	Use:   "typed-data",
+	Short: "Sign EIP-712 typed data with the ETH address key",
+	Long: `Sign an EIP-712 typed data document with the private key of one of the
+wallet's ETH addresses, as eth_signTypedData_v4 does. Dapps use typed data
+for off-chain orders, permits, and logins.
+
+The file holds the JSON document with types, primaryType, domain, and
+message. When types has no EIP712Domain entry, it is built from the domain's
+members. The output includes the EIP-712 digest that was signed, and the
+signature is a 0x-prefixed 65-byte hex value with V of 27 or 28.
+
+Signing typed data can authorize transfers, for example an ERC-20 permit.
+Check the domain and message before signing a document you did not write.`,
+	Example: `  # Sign an order with the first ETH address
+  sigil sign typed-data --file order.json --wallet main
+
+  # Sign with a specific ETH address
+  sigil sign typed-data --file permit.json --wallet main --address 0x742d... -o json`,
+	Args: cobra.NoArgs,
+	RunE: runSignTypedData,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	signCmd.GroupID = "wallet"
+	rootCmd.AddCommand(signCmd)
+	signCmd.AddCommand(signTypedDataCmd)
+	verifyCmd.GroupID = "utility"
+	rootCmd.AddCommand(verifyCmd)
+
+	signCmd.Flags().StringVarP(&signWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
+	signCmd.Flags().StringVarP(&signChain, "chain", "c", "bsv", "chain of the signing address when --address is not given: bsv, btc, bch, eth")
+	signCmd.Flags().StringVar(&signAddress, "address", "", "wallet address whose key signs (default: first receiving address on --chain)")
+	signCmd.Flags().StringVar(&signFile, "file", "", "sign the contents of this file instead of a message argument")
+	verifyCmd.Flags().StringVar(&signFile, "file", "", "verify the contents of this file instead of a message argument")
+
+	signTypedDataCmd.Flags().StringVarP(&signWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
+	signTypedDataCmd.Flags().StringVar(&signAddress, "address", "", "wallet ETH address whose key signs (default: first receiving address)")
+	signTypedDataCmd.Flags().StringVar(&signFile, "file", "", "EIP-712 typed data JSON file to sign (required)")
+	_ = signTypedDataCmd.MarkFlagRequired("file")
+}
+
+// signChains are the chains whose address keys can sign messages.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var signChains = []chain.ID{chain.BSV, chain.BTC, chain.BCH, chain.ETH}
+
+// signResult is the output of the sign and verify commands.
+type signResult struct {
+	Address   string `json:"address"`
+	Chain     string `json:"chain,omitempty"`
+	Scheme    string `json:"scheme"`
+	Message   string `json:"message,omitempty"`
+	File      string `json:"file,omitempty"`
+	Signature string `json:"signature"`
+	Verified  bool   `json:"verified"`
+
+	// Typed data only
+	PrimaryType string `json:"primary_type,omitempty"`
+	Digest      string `json:"digest,omitempty"`
+}
+
+func runSign(cmd *cobra.Command, args []string) error {
+	message, err := readSignMessage(args)
+	if err != nil {
+		return err
+	}
+	chainID, owned, key, err := loadSigningKey(cmd, signChains, signChain)
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(key)
+
+	var signature string
+	if chainID == chain.ETH {
+		signature, err = msgsign.SignEthereum(key, message)
+	} else {
+		signature, err = msgsign.SignBitcoin(key, message)
+	}
+	if err != nil {
+		return fmt.Errorf("signing message: %w", err)
+	}
+
+	// Never hand out a signature that does not verify against the address,
+	// such as one from an address derived under another path
+	scheme, err := msgsign.Verify(owned.Address, signature, message)
+	if err != nil {
+		return fmt.Errorf("checking signature for %s: %w", owned.Address, err)
+	}
+
+	res := newSignResult(owned.Address, scheme, signature, message)
+	res.Chain = string(chainID)
+	res.Verified = true
+	return displaySignResult(cmd, res, true)
+}
+
+func runSignTypedData(cmd *cobra.Command, _ []string) error {
+	data, err := readSignMessage(nil)
+	if err != nil {
+		return err
+	}
+	td, err := eth.ParseTypedData(data)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("%s: %v", signFile, err))
+	}
+	digest, err := td.Hash()
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("%s: %v", signFile, err))
+	}
+
+	_, owned, key, err := loadSigningKey(cmd, []chain.ID{chain.ETH}, string(chain.ETH))
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(key)
+
+	signature, err := msgsign.SignTypedData(key, td)
+	if err != nil {
+		return fmt.Errorf("signing typed data: %w", err)
+	}
+	if err := msgsign.VerifyTypedData(owned.Address, signature, td); err != nil {
+		return fmt.Errorf("checking signature for %s: %w", owned.Address, err)
+	}
+
+	res := newSignResult(owned.Address, msgsign.SchemeTypedData, signature, data)
+	res.Chain = string(chain.ETH)
+	res.PrimaryType = td.PrimaryType
+	res.Digest = "0x" + hex.EncodeToString(digest)
+	res.Verified = true
+	return displaySignResult(cmd, res, true)
+}
+
+// loadSigningKey unlocks the wallet named by --wallet and derives the private
+// key of the signing address, chosen among chains by resolveSigningAddress.
+// The caller must zero the key.
+func loadSigningKey(cmd *cobra.Command, chains []chain.ID, chainName string) (chain.ID, *wallet.Address, []byte, error) {
+	if err := resolveWalletName(cmd, &signWallet); err != nil {
+		return "", nil, nil, err
+	}
+	cc := GetCmdContext(cmd)
+
+	if cc.AgentXpub != "" {
+		return "", nil, nil, sigilerr.WithSuggestion(
+			sigilerr.ErrAgentXpubWriteDenied,
+			"SIGIL_AGENT_XPUB provides read-only access. Use SIGIL_AGENT_TOKEN to sign messages",
+		)
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	wlt, seed, err := loadWalletWithSession(signWallet, storage, cmd)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	defer wallet.ZeroBytes(seed)
+
+	chainID, owned, err := resolveSigningAddress(wlt, chains, chainName)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if cc.AgentCred != nil && !cc.AgentCred.HasChain(chainID) {
+		return "", nil, nil, sigilerr.WithSuggestion(
+			sigilerr.ErrAgentChainDenied,
+			fmt.Sprintf("agent '%s' is not authorized for chain %s", cc.AgentCred.ID, chainID),
+		)
+	}
+
+	change := wallet.ExternalChain
+	if owned.IsChange {
+		change = wallet.InternalChain
+	}
+	key, err := wallet.DerivePrivateKeyWithChange(seed, chainID, owned.Account, change, owned.Index)
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("deriving key for %s: %w", owned.Address, err)
+	}
+	return chainID, owned, key, nil
+}
+
+// resolveSigningAddress returns the wallet address named by --address, which
+// must be on one of chains, or the first receiving address on chainName.
+func resolveSigningAddress(wlt *wallet.Wallet, chains []chain.ID, chainName string) (chain.ID, *wallet.Address, error) {
+	names := make([]string, len(chains))
+	for i, id := range chains {
+		names[i] = string(id)
+	}
+
+	if signAddress != "" {
+		for _, chainID := range chains {
+			if owned := wlt.OwnedAddress(chainID, signAddress); owned != nil {
+				return chainID, owned, nil
+			}
+		}
+		return "", nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("address %s is not a %s address of wallet '%s'", signAddress, strings.Join(names, "/"), signWallet),
+		)
+	}
+
+	chainID, ok := chain.ParseChainID(chainName)
+	if !ok || !slices.Contains(chains, chainID) {
+		return "", nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid chain: %s (use %s)", chainName, strings.Join(names, ", ")),
+		)
+	}
+	addrs := wlt.Addresses[chainID]
+	if len(addrs) == 0 {
+		return "", nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("wallet '%s' has no %s addresses", signWallet, chainID),
+		)
+	}
+	return chainID, &addrs[0], nil
+}
+
+func runVerify(cmd *cobra.Command, args []string) error {
+	address, signature := args[0], args[1]
+	message, err := readSignMessage(args[2:])
+	if err != nil {
+		return err
+	}
+
+	scheme, verr := msgsign.Verify(address, signature, message)
+	switch {
+	case errors.Is(verr, msgsign.ErrUnsupportedAddress):
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidAddress, verr.Error())
+	case errors.Is(verr, msgsign.ErrInvalidSignature):
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidFormat, verr.Error())
+	case verr != nil && !errors.Is(verr, msgsign.ErrSignatureMismatch):
+		return verr
+	}
+
+	res := newSignResult(address, scheme, signature, message)
+	res.Verified = verr == nil
+	if err := displaySignResult(cmd, res, false); err != nil {
+		return err
+	}
+	if !res.Verified {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("the signature was not made by %s for this message", address),
+		)
+	}
+	return nil
+}
+
+// readSignMessage returns the message argument, or the contents of --file.
+// Exactly one of the two must be given.
+func readSignMessage(args []string) ([]byte, error) {
+	switch {
+	case signFile != "" && len(args) > 0:
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "give either a message or --file, not both")
+	case signFile != "":
+		data, err := os.ReadFile(signFile) //nolint:gosec // path is supplied by the user on purpose
+		if err != nil {
+			return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("reading %s: %v", signFile, err))
+		}
+		return data, nil
+	case len(args) == 0:
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "give the message to sign as an argument, or --file")
+	default:
+		return []byte(args[0]), nil
+	}
+}
+
+// newSignResult describes a signature over message, naming the file instead
+// of repeating its contents when --file was used.
+func newSignResult(address, scheme, signature string, message []byte) *signResult {
+	res := &signResult{Address: address, Scheme: scheme, Signature: signature}
+	if signFile != "" {
+		res.File = filepath.Base(signFile)
+	} else {
+		res.Message = string(message)
+	}
+	return res
+}
+
+// displaySignResult renders a sign or verify result.
+func displaySignResult(cmd *cobra.Command, res *signResult, signed bool) error {
+	w := cmd.OutOrStdout()
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, res)
+	}
+
+	switch {
+	case signed:
+		outln(w, "Message signed")
+	case res.Verified:
+		outln(w, "Signature verified")
+	default:
+		outln(w, "Signature does NOT match")
+	}
+	outln(w)
+	displaySignFields(w, res)
+	return nil
+}
+
+// displaySignFields prints the fields of a sign or verify result.
+func displaySignFields(w io.Writer, res *signResult) {
+	out(w, "  Address:   %s\n", res.Address)
+	if res.Chain != "" {
+		out(w, "  Chain:     %s\n", res.Chain)
+	}
+	out(w, "  Scheme:    %s\n", res.Scheme)
+	if res.PrimaryType != "" {
+		out(w, "  Type:      %s\n", res.PrimaryType)
+	}
+	if res.File != "" {
+		out(w, "  File:      %s\n", res.File)
+	} else {
+		out(w, "  Message:   %s\n", res.Message)
+	}
+	if res.Digest != "" {
+		out(w, "  Digest:    %s\n", res.Digest)
+	}
+	out(w, "  Signature: %s\n", res.Signature)
+}
@@ -0,0 +1,164 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/msgsign"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func resetSignFlags() {
+	signWallet, signChain, signAddress, signFile = "", "bsv", "", ""
+}
+
+//nolint:paralleltest // Mutates package-level flag variables
+func TestRunSign(t *testing.T) {
+	tmpDir, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	t.Cleanup(resetSignFlags)
+
+	createTestWalletForAgent(t, tmpDir)
+	withMockPrompts(t, []byte("testpass123"), true)
+	wlt, err := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets")).LoadMetadata("test-wallet")
+	require.NoError(t, err)
+
+	for _, chainID := range []chain.ID{chain.BSV, chain.ETH} {
+		t.Run(string(chainID), func(t *testing.T) {
+			resetSignFlags()
+			signWallet, signChain = "test-wallet", string(chainID)
+
+			cmd, buf := newBackupListTestCmd(tmpDir, output.FormatJSON)
+			require.NoError(t, runSign(cmd, []string{"I control this address"}))
+
+			var res signResult
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+			assert.Equal(t, wlt.Addresses[chainID][0].Address, res.Address)
+			assert.Equal(t, string(chainID), res.Chain)
+			assert.True(t, res.Verified)
+
+			scheme, err := msgsign.Verify(res.Address, res.Signature, []byte("I control this address"))
+			require.NoError(t, err)
+			assert.Equal(t, res.Scheme, scheme)
+		})
+	}
+
+	t.Run("address not in wallet", func(t *testing.T) {
+		resetSignFlags()
+		signWallet, signAddress = "test-wallet", "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+		cmd, _ := newBackupListTestCmd(tmpDir, output.FormatText)
+		require.ErrorIs(t, runSign(cmd, []string{"hello"}), sigilerr.ErrInvalidInput)
+	})
+
+	t.Run("xpub agent cannot sign", func(t *testing.T) {
+		resetSignFlags()
+		signWallet = "test-wallet"
+		cmd, _ := newBackupListTestCmd(tmpDir, output.FormatText)
+		GetCmdContext(cmd).AgentXpub = "xpub-test"
+		require.ErrorIs(t, runSign(cmd, []string{"hello"}), sigilerr.ErrAgentXpubWriteDenied)
+	})
+}
+
+//nolint:paralleltest // Mutates package-level flag variables
+func TestRunSignTypedData(t *testing.T) {
+	tmpDir, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	t.Cleanup(resetSignFlags)
+
+	createTestWalletForAgent(t, tmpDir)
+	withMockPrompts(t, []byte("testpass123"), true)
+	wlt, err := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets")).LoadMetadata("test-wallet")
+	require.NoError(t, err)
+
+	doc := []byte(`{
+  "types": {
+    "Person": [{"name": "name", "type": "string"}, {"name": "wallet", "type": "address"}],
+    "Mail": [{"name": "from", "type": "Person"}, {"name": "to", "type": "Person"}, {"name": "contents", "type": "string"}]
+  },
+  "primaryType": "Mail",
+  "domain": {"name": "Ether Mail", "version": "1", "chainId": 1, "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"},
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`)
+	path := filepath.Join(tmpDir, "mail.json")
+	require.NoError(t, os.WriteFile(path, doc, 0o600))
+
+	resetSignFlags()
+	signWallet, signFile = "test-wallet", path
+	cmd, buf := newBackupListTestCmd(tmpDir, output.FormatJSON)
+	require.NoError(t, runSignTypedData(cmd, nil))
+
+	var res signResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.Equal(t, wlt.Addresses[chain.ETH][0].Address, res.Address)
+	assert.Equal(t, msgsign.SchemeTypedData, res.Scheme)
+	assert.Equal(t, "Mail", res.PrimaryType)
+	assert.Equal(t, "mail.json", res.File)
+	assert.Equal(t, "0xbe609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", res.Digest)
+	assert.True(t, res.Verified)
+
+	td, err := eth.ParseTypedData(doc)
+	require.NoError(t, err)
+	require.NoError(t, msgsign.VerifyTypedData(res.Address, res.Signature, td))
+
+	t.Run("BSV address cannot sign typed data", func(t *testing.T) {
+		signAddress = wlt.Addresses[chain.BSV][0].Address
+		defer func() { signAddress = "" }()
+		cmd, _ := newBackupListTestCmd(tmpDir, output.FormatText)
+		require.ErrorIs(t, runSignTypedData(cmd, nil), sigilerr.ErrInvalidInput)
+	})
+
+	t.Run("invalid document", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"types": {}, "primaryType": "Mail"}`), 0o600))
+		cmd, _ := newBackupListTestCmd(tmpDir, output.FormatText)
+		require.ErrorIs(t, runSignTypedData(cmd, nil), sigilerr.ErrInvalidInput)
+	})
+}
+
+//nolint:paralleltest // Mutates package-level flag variables
+func TestRunVerify(t *testing.T) {
+	t.Cleanup(resetSignFlags)
+	resetSignFlags()
+
+	const (
+		address   = "1KeiT9opiiEyqBjazSix8muc1JuFNNWEKe"
+		signature = "INBC2+Ok+GtbeAfvgYDaBkYPXh/nVNDfxAkXvar5+OQ6ZeMmxFA9ov5zpNBXeI0zVsKPuVj9bbZNnuVkzk6MLCc="
+		message   = "I own this address"
+	)
+
+	cmd, buf := newBackupListTestCmd(t.TempDir(), output.FormatText)
+	require.NoError(t, runVerify(cmd, []string{address, signature, message}))
+	assert.Contains(t, buf.String(), "Signature verified")
+	assert.Contains(t, buf.String(), msgsign.SchemeBitcoin)
+
+	cmd, buf = newBackupListTestCmd(t.TempDir(), output.FormatJSON)
+	require.ErrorIs(t, runVerify(cmd, []string{address, signature, "tampered"}), sigilerr.ErrInvalidInput)
+	var res signResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.False(t, res.Verified)
+
+	// --file verifies the file's bytes
+	signFile = filepath.Join(t.TempDir(), "statement.txt")
+	require.NoError(t, os.WriteFile(signFile, []byte(message), 0o600))
+	cmd, buf = newBackupListTestCmd(t.TempDir(), output.FormatText)
+	require.NoError(t, runVerify(cmd, []string{address, signature}))
+	assert.Contains(t, buf.String(), "File:      statement.txt")
+	require.ErrorIs(t, runVerify(cmd, []string{address, signature, message}), sigilerr.ErrInvalidInput)
+	resetSignFlags()
+
+	cmd, _ = newBackupListTestCmd(t.TempDir(), output.FormatText)
+	require.ErrorIs(t, runVerify(cmd, []string{address, "garbage", message}), sigilerr.ErrInvalidFormat)
+	require.ErrorIs(t, runVerify(cmd, []string{"3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", signature, message}), sigilerr.ErrInvalidAddress)
+}
@@ -0,0 +1,350 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/hooks"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/stamp"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// stampWallet is the wallet that pays for the stamp transaction.
+	stampWallet string
+	// stampConfirm skips the confirmation prompt.
+	stampConfirm bool
+)
+
+// stampCmd timestamps a file on the BSV blockchain.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var stampCmd = &cobra.Command{
+	Use:   "stamp <file>",
+	Short: "Timestamp a file on the BSV blockchain",
+	Long: `Hash a file with SHA-256 and embed the digest in a BSV OP_RETURN output.
+
+The transaction ID is a timestamp proof: anyone holding the same file can later
+show it existed no later than the block that mined the transaction. The file
+itself never leaves your machine; only its hash is published.
+
+Proofs are recorded locally in stamps.json under the sigil home directory.
+Use 'sigil stamp verify' to check a file against a transaction.`,
+	Example: `  # Timestamp a document
+  sigil stamp contract.pdf --wallet main
+
+  # Verify it later
+  sigil stamp verify contract.pdf <txid>`,
+	Args: cobra.ExactArgs(1),
+	RunE: runStamp,
+}
+
+// stampVerifyCmd verifies a file against a stamp transaction.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var stampVerifyCmd = &cobra.Command{
+	Use:   "verify <file> [txid]",
+	Short: "Verify a file against a stamp transaction",
+	Long: `Hash a file and check that the given BSV transaction carries its digest
+in a sigil stamp OP_RETURN output. Reports the block time when the
+transaction is confirmed.
+
+When the transaction ID is omitted, it is looked up in the local stamps.json
+by the file's hash, using the most recent stamp recorded on the current network.`,
+	Example: `  sigil stamp verify contract.pdf 3a1f...e9
+  sigil stamp verify contract.pdf 3a1f...e9 -o json
+
+  # Use the transaction recorded when the file was stamped
+  sigil stamp verify contract.pdf`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runStampVerify,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	stampCmd.GroupID = "wallet"
+	rootCmd.AddCommand(stampCmd)
+	stampCmd.AddCommand(stampVerifyCmd)
+
+	stampCmd.Flags().StringVar(&stampWallet, "wallet", "", "wallet that pays the fee (defaults to config default_wallet)")
+	stampCmd.Flags().BoolVar(&stampConfirm, "yes", false, "skip confirmation prompt")
+
+}
+
+// stampResult is the outcome of a stamp or verify operation.
+type stampResult struct {
+	File          string `json:"file"`
+	SHA256        string `json:"sha256"`
+	TxID          string `json:"txid"`
+	Fee           string `json:"fee,omitempty"`
+	Status        string `json:"status,omitempty"`
+	Verified      bool   `json:"verified"`
+	Confirmations int64  `json:"confirmations"`
+	BlockHeight   int64  `json:"block_height,omitempty"`
+	BlockTime     string `json:"block_time,omitempty"`
+}
+
+func runStamp(cmd *cobra.Command, args []string) error {
+	if err := resolveWalletName(cmd, &stampWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
+	defer cancel()
+
+	path := args[0]
+	digest, err := stamp.HashFile(path)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	wlt, seed, err := loadWalletWithSession(stampWallet, storage, cmd)
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(seed)
+	keyCache := wallet.NewKeyCache(seed)
+	defer keyCache.Zero()
+
+	if cc.AgentXpub != "" {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAgentXpubWriteDenied,
+			"SIGIL_AGENT_XPUB provides read-only access. Use SIGIL_AGENT_TOKEN for spending operations",
+		)
+	}
+	if cc.AgentCred != nil {
+		if !cc.AgentCred.HasChain(chain.BSV) {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrAgentChainDenied,
+				fmt.Sprintf("agent '%s' is not authorized for chain %s", cc.AgentCred.ID, chain.BSV),
+			)
+		}
+		if err := enforceAgentAsset(cc.AgentCred, chain.BSV, ""); err != nil {
+			return err
+		}
+		if err := enforceAgentHost(cc.AgentCred); err != nil {
+			return err
+		}
+		stampConfirm = true
+	}
+
+	addresses := wlt.Addresses[wallet.ChainBSV]
+	if len(addresses) == 0 {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("wallet '%s' has no BSV addresses", stampWallet),
+		)
+	}
+
+	warnNetworkConflict(cmd, wlt)
+	network := effectiveBSVNetwork(wlt, cc.Cfg)
+	sha := hex.EncodeToString(digest)
+
+	if !stampConfirm {
+		w := cmd.ErrOrStderr()
+		out(w, "\nStamp %s\n", filepath.Base(path))
+		out(w, "  SHA-256: %s\n", sha)
+		out(w, "  Network: BSV %s\n", network)
+		out(w, "  Wallet:  %s\n", stampWallet)
+		if !promptConfirmFn() {
+			outln(cmd.OutOrStdout(), "Stamp canceled.")
+			return nil
+		}
+	}
+
+	lock, err := lockWallet(cmd, storage, stampWallet, "stamp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	txService, err := newTransactionService(cc, storage)
+	if err != nil {
+		return err
+	}
+
+	result, err := txService.SendData(ctx, &transaction.DataRequest{
+		Wallet:    stampWallet,
+		Data:      stamp.Payload(digest),
+		Addresses: addresses,
+		Network:   network,
+		Seed:      seed,
+		Keys:      keyCache,
+	})
+	if err != nil {
+		return err
+	}
+
+	proofs := stamp.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "stamps.json"))
+	if err := proofs.Add(stamp.NewProof(path, digest, result.Hash, stampWallet, network)); err != nil {
+		// The transaction is already broadcast; losing the local record is not fatal.
+		output.Warnf("failed to record stamp proof: %v", err)
+	}
+
+	displayStampResult(cmd, &stampResult{
+		File:   filepath.Base(path),
+		SHA256: sha,
+		TxID:   result.Hash,
+		Fee:    result.Fee,
+		Status: result.Status,
+	}, network)
+	runPostSendHook(ctx, cc, &hooks.Sent{
+		Chain:  string(chain.BSV),
+		Wallet: stampWallet,
+		Hash:   result.Hash,
+		Fee:    result.Fee,
+		Status: result.Status,
+	})
+	return nil
+}
+
+func runStampVerify(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+	ctx, cancel := contextWithTimeout(cmd, 30*time.Second)
+	defer cancel()
+
+	path := args[0]
+	digest, err := stamp.HashFile(path)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+
+	network := bsvNetworkForCmd(cmd)
+	var txid string
+	if len(args) > 1 {
+		txid = args[1]
+	} else {
+		proofs := stamp.NewFileStore(filepath.Join(cc.Cfg.GetHome(), "stamps.json"))
+		if txid, err = lookupStampTxID(proofs, hex.EncodeToString(digest), network); err != nil {
+			return err
+		}
+	}
+
+	client := bsv.NewClient(ctx, &bsv.ClientOptions{
+		APIKey:  cc.Cfg.GetBSVAPIKey(),
+		Network: bsvClientNetwork(network),
+		Logger:  cc.Log,
+	})
+
+	data, err := client.GetTxData(ctx, txid)
+	if err != nil {
+		return err
+	}
+
+	res := &stampResult{
+		File:          filepath.Base(path),
+		SHA256:        hex.EncodeToString(digest),
+		TxID:          txid,
+		Verified:      stamp.Matches(data.DataOutputs, digest),
+		Confirmations: data.Confirmations,
+		BlockHeight:   data.BlockHeight,
+	}
+	if !data.BlockTime.IsZero() {
+		res.BlockTime = data.BlockTime.Format(time.RFC3339)
+	}
+
+	displayStampResult(cmd, res, network)
+
+	if !res.Verified {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidTransaction,
+			fmt.Sprintf("transaction %s does not contain a stamp for this file", txid),
+		)
+	}
+	return nil
+}
+
+// lookupStampTxID returns the transaction of the most recent locally recorded
+// stamp for a file hash on the given network.
+func lookupStampTxID(proofs *stamp.FileStore, sha, network string) (string, error) {
+	matches, err := proofs.FindByHash(sha)
+	if err != nil {
+		return "", err
+	}
+
+	var latest *stamp.Proof
+	for i := range matches {
+		if matches[i].Network != network {
+			continue
+		}
+		if latest == nil || matches[i].CreatedAt.After(latest.CreatedAt) {
+			latest = &matches[i]
+		}
+	}
+	if latest == nil {
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrNotFound,
+			"no local stamp recorded for this file; pass the transaction ID: sigil stamp verify <file> <txid>",
+		)
+	}
+	return latest.TxID, nil
+}
+
+// displayStampResult renders a stamp or verify result.
+func displayStampResult(cmd *cobra.Command, res *stampResult, network string) {
+	cc := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+
+	if cc.Fmt.Format() == output.FormatJSON {
+		_ = writeJSON(w, res)
+		return
+	}
+
+	if res.Status != "" {
+		displayStampText(w, res, network)
+		return
+	}
+	displayStampVerifyText(w, res, network)
+}
+
+// displayStampText shows a freshly broadcast stamp.
+func displayStampText(w io.Writer, res *stampResult, network string) {
+	outln(w, "\nFile stamped successfully!")
+	outln(w)
+	out(w, "  File:    %s\n", res.File)
+	out(w, "  SHA-256: %s\n", res.SHA256)
+	out(w, "  TxID:    %s\n", res.TxID)
+	out(w, "  Fee:     %s BSV\n", res.Fee)
+	outln(w)
+	outln(w, "Keep the transaction ID with the file; it is your timestamp proof.")
+	for _, link := range explorerTxLinks(chain.BSV, network, res.TxID) {
+		out(w, "  %s\n", link)
+	}
+}
+
+// displayStampVerifyText shows the outcome of a verification.
+func displayStampVerifyText(w io.Writer, res *stampResult, network string) {
+	if res.Verified {
+		outln(w, "\nStamp verified.")
+	} else {
+		outln(w, "\nStamp NOT verified.")
+	}
+	outln(w)
+	out(w, "  File:    %s\n", res.File)
+	out(w, "  SHA-256: %s\n", res.SHA256)
+	out(w, "  TxID:    %s\n", res.TxID)
+	if res.BlockTime != "" {
+		out(w, "  Block:   %d (%s)\n", res.BlockHeight, res.BlockTime)
+		out(w, "  Confirmations: %d\n", res.Confirmations)
+	} else {
+		outln(w, "  Block:   unconfirmed")
+	}
+	if res.Verified {
+		outln(w)
+		for _, link := range explorerTxLinks(chain.BSV, network, res.TxID) {
+			out(w, "  %s\n", link)
+		}
+	}
+}
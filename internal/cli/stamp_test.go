@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/stamp"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestDisplayStampText(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displayStampText(&buf, &stampResult{
+		File:   "doc.pdf",
+		SHA256: "ab12",
+		TxID:   "deadbeef",
+		Fee:    "0.00000050",
+		Status: "pending",
+	}, "main")
+
+	got := buf.String()
+	assert.Contains(t, got, "File stamped successfully")
+	assert.Contains(t, got, "doc.pdf")
+	assert.Contains(t, got, "ab12")
+	assert.Contains(t, got, "https://whatsonchain.com/tx/deadbeef")
+}
+
+func TestDisplayStampVerifyText(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		res      *stampResult
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "verified and confirmed",
+			res: &stampResult{
+				File: "doc.pdf", SHA256: "ab12", TxID: "deadbeef", Verified: true,
+				BlockHeight: 800000, BlockTime: "2023-11-14T22:13:20Z", Confirmations: 6,
+			},
+			contains: []string{"Stamp verified.", "800000", "2023-11-14T22:13:20Z", "whatsonchain.com/tx/deadbeef"},
+		},
+		{
+			name:     "unconfirmed",
+			res:      &stampResult{File: "doc.pdf", SHA256: "ab12", TxID: "deadbeef", Verified: true},
+			contains: []string{"Stamp verified.", "unconfirmed"},
+		},
+		{
+			name:     "mismatch",
+			res:      &stampResult{File: "doc.pdf", SHA256: "ab12", TxID: "deadbeef"},
+			contains: []string{"Stamp NOT verified."},
+			excludes: []string{"whatsonchain.com"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			displayStampVerifyText(&buf, tc.res, "main")
+
+			for _, s := range tc.contains {
+				assert.Contains(t, buf.String(), s)
+			}
+			for _, s := range tc.excludes {
+				assert.NotContains(t, buf.String(), s)
+			}
+		})
+	}
+}
+
+func TestLookupStampTxID(t *testing.T) {
+	t.Parallel()
+
+	proofs := stamp.NewFileStore(filepath.Join(t.TempDir(), "stamps.json"))
+	older := stamp.Proof{File: "doc.pdf", SHA256: "ab12", TxID: "older", Network: "main", CreatedAt: time.Unix(1000, 0)}
+	newer := stamp.Proof{File: "doc.pdf", SHA256: "ab12", TxID: "newer", Network: "main", CreatedAt: time.Unix(2000, 0)}
+	testnet := stamp.Proof{File: "doc.pdf", SHA256: "ab12", TxID: "testnet", Network: "test", CreatedAt: time.Unix(3000, 0)}
+	other := stamp.Proof{File: "other.pdf", SHA256: "cd34", TxID: "other", Network: "main", CreatedAt: time.Unix(4000, 0)}
+	for _, p := range []stamp.Proof{newer, older, testnet, other} {
+		require.NoError(t, proofs.Add(p))
+	}
+
+	txid, err := lookupStampTxID(proofs, "ab12", "main")
+	require.NoError(t, err)
+	assert.Equal(t, "newer", txid)
+
+	txid, err = lookupStampTxID(proofs, "ab12", "test")
+	require.NoError(t, err)
+	assert.Equal(t, "testnet", txid)
+
+	_, err = lookupStampTxID(proofs, "ef56", "main")
+	require.ErrorIs(t, err, sigilerr.ErrNotFound)
+}
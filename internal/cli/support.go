@@ -0,0 +1,346 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/apiusage"
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/fileutil"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/support"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Support bundle sections, named for --exclude.
+const (
+	supportSectionVersion   = "version"
+	supportSectionConfig    = "config"
+	supportSectionLogs      = "logs"
+	supportSectionCache     = "cache"
+	supportSectionUTXOStore = "utxostore"
+	supportSectionProviders = "providers"
+)
+
+// supportSections lists the bundle sections in archive order.
+//
+//nolint:gochecknoglobals // Fixed list of section names
+var supportSections = []string{
+	supportSectionVersion, supportSectionConfig, supportSectionLogs,
+	supportSectionCache, supportSectionUTXOStore, supportSectionProviders,
+}
+
+const (
+	// supportPreviewLines caps each file shown in the review.
+	supportPreviewLines = 40
+
+	// supportDefaultLogLines is how much of the log file is bundled by default.
+	supportDefaultLogLines = 1000
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// supportOutput is the archive path; empty names it after the bundle.
+	supportOutput string
+	// supportExclude lists sections to leave out.
+	supportExclude []string
+	// supportLogLines is the number of log lines bundled.
+	supportLogLines int
+	// supportConfirm skips the review prompt.
+	supportConfirm bool
+)
+
+// supportCmd is the parent command for support diagnostics.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var supportCmd = &cobra.Command{
+	Use:   "support",
+	Short: "Collect diagnostics for bug reports",
+	Long: `Collect diagnostics to attach to a bug report.
+
+Nothing is sent anywhere; the bundle is written to a local file for you to
+attach yourself.`,
+}
+
+// supportBundleCmd writes a sanitized diagnostic archive.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var supportBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Write a sanitized diagnostic archive",
+	Long: `Collect sanitized diagnostics into one .tar.gz archive to attach to a bug
+report. The archive holds:
+
+  version.json     sigil version, commit, Go version, OS and architecture
+  config.yaml      the configuration with API keys and passwords redacted
+  logs/sigil.log   the last --log-lines lines of the log file
+  cache.json       balance cache size and age
+  utxostore.json   per-wallet UTXO and address counts
+  providers.json   API requests and rate-limit refusals per key, last 24h
+  manifest.json    the files included and any section that was skipped
+
+Secrets are removed before anything is shown: configured API keys and
+passwords are replaced with <redacted> wherever they appear, URLs are cut to
+their scheme and host, since provider URLs often carry keys in the path, and
+your home directory is shown as ~. Seeds, keys, addresses, and transaction
+IDs are never collected.
+
+Every file is shown for review before the archive is written. Leave
+sections out with --exclude.`,
+	Example: `  sigil support bundle
+  sigil support bundle --output ~/Desktop/sigil-support.tar.gz
+  sigil support bundle --exclude logs --exclude utxostore --yes`,
+	Args: cobra.NoArgs,
+	RunE: runSupportBundle,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	supportCmd.GroupID = "config"
+	rootCmd.AddCommand(supportCmd)
+	supportCmd.AddCommand(supportBundleCmd)
+
+	supportBundleCmd.Flags().StringVar(&supportOutput, "output", "", "archive path (default ./sigil-support-<time>.tar.gz)")
+	supportBundleCmd.Flags().StringArrayVar(&supportExclude, "exclude", nil,
+		"leave out a section: "+strings.Join(supportSections, ", ")+" (repeatable)")
+	supportBundleCmd.Flags().IntVar(&supportLogLines, "log-lines", supportDefaultLogLines, "number of log lines to include")
+	supportBundleCmd.Flags().BoolVar(&supportConfirm, "yes", false, "skip the review prompt")
+}
+
+// supportManifest is manifest.json.
+type supportManifest struct {
+	CreatedAt time.Time         `json:"created_at"`
+	Files     []string          `json:"files"`
+	Skipped   map[string]string `json:"skipped,omitempty"`
+}
+
+// supportWalletStats is one wallet in utxostore.json.
+type supportWalletStats struct {
+	Wallet string          `json:"wallet"`
+	Store  utxostore.Stats `json:"store"`
+}
+
+func runSupportBundle(cmd *cobra.Command, _ []string) error {
+	for _, s := range supportExclude {
+		if !slices.Contains(supportSections, s) {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+				fmt.Sprintf("unknown section %q for --exclude (use %s)", s, strings.Join(supportSections, ", ")))
+		}
+	}
+	if supportLogLines < 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--log-lines must be 0 or more")
+	}
+
+	cc := GetCmdContext(cmd)
+	bundle, err := collectSupportBundle(cfg, cc.Cfg.GetQuotas(), time.Now())
+	if err != nil {
+		return err
+	}
+
+	path := supportOutput
+	if path == "" {
+		path = bundle.Name + ".tar.gz"
+	}
+	path = expandHomePath(path)
+
+	if !supportConfirm {
+		w := cmd.ErrOrStderr()
+		displaySupportReview(w, bundle, path)
+		if !promptConfirmFn() {
+			outln(cmd.OutOrStdout(), "Support bundle canceled.")
+			return nil
+		}
+	}
+
+	data, err := bundle.Archive()
+	if err != nil {
+		return err
+	}
+	if err := fileutil.WriteAtomic(path, data, 0o600); err != nil {
+		return fmt.Errorf("writing support bundle: %w", err)
+	}
+
+	if cc.Fmt.Format() == output.FormatJSON {
+		files := make([]string, 0, len(bundle.Files))
+		for _, f := range bundle.Files {
+			files = append(files, f.Name)
+		}
+		return writeJSON(cmd.OutOrStdout(), struct {
+			Path  string   `json:"path"`
+			Size  int      `json:"size"`
+			Files []string `json:"files"`
+		}{path, len(data), files})
+	}
+	out(cmd.OutOrStdout(), "Support bundle written to %s (%d bytes, %d files)\n", path, len(data), len(bundle.Files))
+	outln(cmd.OutOrStdout(), "Review it before attaching it to a bug report.")
+	return nil
+}
+
+// collectSupportBundle gathers every section not excluded. A section that
+// cannot be collected is listed as skipped in manifest.json rather than
+// failing the bundle, since the bundle is most needed when something is
+// broken.
+func collectSupportBundle(c *config.Config, quotas config.QuotaConfig, now time.Time) (*support.Bundle, error) {
+	bundle := support.New(now)
+	sanitizer := newSupportSanitizer(c)
+	manifest := supportManifest{CreatedAt: now, Skipped: map[string]string{}}
+
+	for _, section := range supportSections {
+		if slices.Contains(supportExclude, section) {
+			manifest.Skipped[section] = "excluded"
+			continue
+		}
+		name, data, err := collectSupportSection(section, c, quotas, now)
+		if err != nil {
+			manifest.Skipped[section] = string(sanitizer.Sanitize([]byte(err.Error())))
+			continue
+		}
+		bundle.Add(name, sanitizer.Sanitize(data))
+		manifest.Files = append(manifest.Files, name)
+	}
+
+	if err := bundle.AddJSON("manifest.json", manifest); err != nil {
+		return nil, err
+	}
+	return bundle, nil
+}
+
+// errNoLogFile is reported when logging has no file to bundle.
+var errNoLogFile = errors.New("no log file")
+
+// collectSupportSection returns the file name and contents of one section.
+func collectSupportSection(section string, c *config.Config, quotas config.QuotaConfig, now time.Time) (string, []byte, error) {
+	switch section {
+	case supportSectionVersion:
+		v, commit, date := resolvedBuildInfo(buildInfo)
+		data, err := supportJSON(map[string]string{
+			"version":    v,
+			"commit":     commit,
+			"date":       date,
+			"go_version": runtime.Version(),
+			"os":         runtime.GOOS,
+			"arch":       runtime.GOARCH,
+		})
+		return "version.json", data, err
+
+	case supportSectionConfig:
+		data, err := config.Marshal(config.Redact(c, redactedValue), config.FormatYAML)
+		return "config.yaml", data, err
+
+	case supportSectionLogs:
+		if c.Logging.File == "" || supportLogLines == 0 {
+			return "", nil, errNoLogFile
+		}
+		data, err := support.Tail(expandHomePath(c.Logging.File), supportLogLines)
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil, fmt.Errorf("%w at %s", errNoLogFile, c.Logging.File)
+		}
+		return "logs/sigil.log", data, err
+
+	case supportSectionCache:
+		storage := cache.NewBalanceStorage(c.Home, c.Cache).WithLimits(cache.Limits{})
+		balanceCache, err := storage.Load()
+		if err != nil {
+			return "", nil, fmt.Errorf("loading balance cache: %w", err)
+		}
+		stats, err := buildCacheStats(storage.Path(), balanceCache, cache.LimitsFromConfig(c.Cache))
+		if err != nil {
+			return "", nil, err
+		}
+		stats.MaxEntries, stats.MaxAgeDays = c.Cache.MaxEntries, c.Cache.MaxAgeDays
+		// Name no addresses: the oldest and newest entries are left out
+		stats.Oldest, stats.Newest = nil, nil
+		data, err := supportJSON(stats)
+		return "cache.json", data, err
+
+	case supportSectionUTXOStore:
+		stats, err := collectUTXOStoreStats(c.Home)
+		if err != nil {
+			return "", nil, err
+		}
+		data, err := supportJSON(stats)
+		return "utxostore.json", data, err
+
+	case supportSectionProviders:
+		keys, err := apiusage.Load(apiusage.Path(c.Home))
+		if err != nil {
+			return "", nil, fmt.Errorf("loading API usage: %w", err)
+		}
+		data, err := supportJSON(buildProviderUsage(keys, apiQuotas(quotas), now))
+		return "providers.json", data, err
+	}
+	return "", nil, fmt.Errorf("%w: unknown section %q", sigilerr.ErrInvalidInput, section)
+}
+
+// collectUTXOStoreStats summarizes the UTXO store of every wallet.
+func collectUTXOStoreStats(home string) ([]supportWalletStats, error) {
+	walletsDir := filepath.Join(home, "wallets")
+	names, err := wallet.NewFileStorage(walletsDir).List()
+	if err != nil {
+		return nil, err
+	}
+	stats := make([]supportWalletStats, 0, len(names))
+	for _, name := range names {
+		store := utxostore.New(filepath.Join(walletsDir, name))
+		if err := store.Load(); err != nil {
+			return nil, fmt.Errorf("loading UTXO store of wallet '%s': %w", name, err)
+		}
+		stats = append(stats, supportWalletStats{Wallet: name, Store: store.Stats()})
+	}
+	return stats, nil
+}
+
+// newSupportSanitizer removes the configured secrets and the user's home
+// directory from bundled text.
+func newSupportSanitizer(c *config.Config) *support.Sanitizer {
+	s := support.NewSanitizer(redactedValue)
+	for _, secret := range config.Secrets(c) {
+		s.Secret(secret)
+	}
+	if userHome, err := os.UserHomeDir(); err == nil && userHome != "/" {
+		s.Replace(userHome, "~")
+	}
+	return s
+}
+
+// supportJSON encodes a bundle file as indented JSON.
+func supportJSON(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := writeJSON(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// displaySupportReview shows every bundled file, each cut to
+// supportPreviewLines lines.
+func displaySupportReview(w io.Writer, bundle *support.Bundle, path string) {
+	out(w, "\nSupport bundle %s, to be written to %s\n", bundle.Name, path)
+	for _, f := range bundle.Files {
+		out(w, "\n== %s (%d bytes) ==\n", f.Name, len(f.Data))
+		lines := strings.Split(strings.TrimRight(string(f.Data), "\n"), "\n")
+		shown := lines
+		if len(lines) > supportPreviewLines {
+			shown = lines[:supportPreviewLines]
+		}
+		for _, line := range shown {
+			outln(w, line)
+		}
+		if hidden := len(lines) - len(shown); hidden > 0 {
+			out(w, "... %d more lines\n", hidden)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// readSupportArchive returns the files of a support bundle by name, without
+// the bundle directory.
+func readSupportArchive(t *testing.T, path string) map[string]string {
+	t.Helper()
+	data, err := os.ReadFile(path) //nolint:gosec // test file path
+	require.NoError(t, err)
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	files := map[string]string{}
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return files
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		_, name, _ := strings.Cut(hdr.Name, "/")
+		files[name] = string(content)
+	}
+}
+
+//nolint:paralleltest // Mutates package-level config, flags and prompt functions
+func TestRunSupportBundle(t *testing.T) {
+	home, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	t.Cleanup(func() {
+		supportOutput, supportExclude, supportLogLines, supportConfirm = "", nil, supportDefaultLogLines, false
+	})
+
+	cfg.Networks.ETH.EtherscanAPIKey = "ETHERSCANKEY123"
+	cfg.Networks.ETH.RPC = "https://mainnet.infura.io/v3/infura-project-key"
+	cfg.Logging.File = filepath.Join(home, "sigil.log")
+	require.NoError(t, os.WriteFile(cfg.Logging.File, []byte(
+		"old line\nusing key ETHERSCANKEY123\ncalling https://mainnet.infura.io/v3/infura-project-key\n"), 0o600))
+	createTestWallet(t, filepath.Join(home, "wallets"), "main")
+
+	withMockPrompts(t, []byte("password"), true)
+	supportOutput = filepath.Join(home, "bundle.tar.gz")
+	supportLogLines = 2
+	cmd, buf := newBackupListTestCmd(home, output.FormatText)
+	var review bytes.Buffer
+	cmd.SetErr(&review)
+	require.NoError(t, runSupportBundle(cmd, nil))
+	assert.Contains(t, buf.String(), "Support bundle written to "+supportOutput)
+	assert.Contains(t, review.String(), "== config.yaml (")
+
+	files := readSupportArchive(t, supportOutput)
+	for _, name := range []string{"version.json", "config.yaml", "logs/sigil.log", "cache.json", "utxostore.json", "providers.json", "manifest.json"} {
+		assert.Contains(t, files, name)
+	}
+	for name, content := range files {
+		assert.NotContains(t, content, "ETHERSCANKEY123", name)
+		assert.NotContains(t, content, "infura-project-key", name)
+	}
+	assert.Contains(t, files["config.yaml"], "etherscan_api_key: <redacted>")
+	assert.Equal(t, "using key <redacted>\ncalling https://mainnet.infura.io/<redacted>\n", files["logs/sigil.log"])
+	assert.Contains(t, files["utxostore.json"], `"wallet": "main"`)
+
+	// Excluded sections are listed as skipped
+	supportExclude = []string{supportSectionLogs}
+	supportConfirm = true
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	require.NoError(t, runSupportBundle(cmd, nil))
+	files = readSupportArchive(t, supportOutput)
+	assert.NotContains(t, files, "logs/sigil.log")
+	assert.Contains(t, files["manifest.json"], `"logs": "excluded"`)
+
+	supportExclude = []string{"wallets"}
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	require.ErrorIs(t, runSupportBundle(cmd, nil), sigilerr.ErrInvalidInput)
+}
+
+//nolint:paralleltest // Mutates package-level config, flags and prompt functions
+func TestRunSupportBundle_Canceled(t *testing.T) {
+	home, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	t.Cleanup(func() { supportOutput = "" })
+
+	withMockPrompts(t, []byte("password"), false)
+	supportOutput = filepath.Join(home, "bundle.tar.gz")
+	cmd, buf := newBackupListTestCmd(home, output.FormatText)
+	cmd.SetErr(io.Discard)
+	require.NoError(t, runSupportBundle(cmd, nil))
+	assert.Contains(t, buf.String(), "Support bundle canceled.")
+	assert.NoFileExists(t, supportOutput)
+}
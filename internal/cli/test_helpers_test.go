@@ -22,7 +22,7 @@ func withMockPrompts(t *testing.T, password []byte, confirm bool) {
 		copy(cp, password)
 		return cp, nil
 	}
-	promptNewPasswordFn = func() ([]byte, error) {
+	promptNewPasswordFn = func(_ passwordPolicy) ([]byte, error) {
 		cp := make([]byte, len(password))
 		copy(cp, password)
 		return cp, nil
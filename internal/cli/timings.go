@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"io"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/output"
+)
+
+// timingsFormat returns the output format for the --timings report. It follows
+// the command's format once globals are initialized, and falls back to text.
+func timingsFormat() output.Format {
+	if formatter != nil {
+		return formatter.Format()
+	}
+	return output.FormatText
+}
+
+// writeTimings prints the phase breakdown recorded in m, followed by total
+// wall time and provider call statistics. It writes to stderr in practice so
+// the report never mixes with command output on stdout.
+func writeTimings(w io.Writer, format output.Format, m *metrics.Metrics, total time.Duration) {
+	phases := m.Phases()
+	snap := m.Snapshot()
+
+	if format == output.FormatJSON {
+		type phaseJSON struct {
+			Name  string `json:"name"`
+			MS    int64  `json:"ms"`
+			Count int    `json:"count"`
+		}
+		type timingsJSON struct {
+			Phases    []phaseJSON `json:"phases"`
+			TotalMS   int64       `json:"total_ms"`
+			RPCCalls  int64       `json:"rpc_calls"`
+			RPCErrors int64       `json:"rpc_errors"`
+			RPCAvgMS  float64     `json:"rpc_avg_ms"`
+		}
+
+		resp := timingsJSON{
+			Phases:    make([]phaseJSON, 0, len(phases)),
+			TotalMS:   total.Milliseconds(),
+			RPCCalls:  snap.RPCCallsTotal,
+			RPCErrors: snap.RPCErrorsTotal,
+			RPCAvgMS:  m.RPCLatencyAvgMs(),
+		}
+		for _, p := range phases {
+			resp.Phases = append(resp.Phases, phaseJSON{Name: p.Name, MS: p.Duration.Milliseconds(), Count: p.Count})
+		}
+		_ = writeJSON(w, map[string]timingsJSON{"timings": resp})
+		return
+	}
+
+	outln(w)
+	outln(w, "Timings:")
+	for _, p := range phases {
+		if p.Count > 1 {
+			out(w, "  %-12s %8d ms  (%d calls)\n", p.Name, p.Duration.Milliseconds(), p.Count)
+		} else {
+			out(w, "  %-12s %8d ms\n", p.Name, p.Duration.Milliseconds())
+		}
+	}
+	out(w, "  %-12s %8d ms\n", "total", total.Milliseconds())
+	if snap.RPCCallsTotal > 0 {
+		out(w, "  Provider calls: %d (avg %.0f ms, %d failed)\n", snap.RPCCallsTotal, m.RPCLatencyAvgMs(), snap.RPCErrorsTotal)
+	}
+}
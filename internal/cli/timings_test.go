@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/output"
+)
+
+func TestWriteTimings(t *testing.T) {
+	t.Parallel()
+
+	newMetrics := func() *metrics.Metrics {
+		m := &metrics.Metrics{}
+		m.RecordPhase(metrics.PhaseWalletLoad, 12*time.Millisecond)
+		m.RecordPhase(metrics.PhaseFeeQuote, 30*time.Millisecond)
+		m.RecordPhase(metrics.PhaseFeeQuote, 10*time.Millisecond)
+		m.RecordPhase(metrics.PhaseBroadcast, 250*time.Millisecond)
+		m.RecordRPCCall("bsv", 100*time.Millisecond, nil)
+		m.RecordRPCCall("bsv", 300*time.Millisecond, nil)
+		return m
+	}
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		writeTimings(&buf, output.FormatText, newMetrics(), 400*time.Millisecond)
+
+		got := buf.String()
+		assert.Contains(t, got, "Timings:")
+		assert.Contains(t, got, "wallet load        12 ms")
+		assert.Contains(t, got, "fee quote          40 ms  (2 calls)")
+		assert.Contains(t, got, "broadcast         250 ms")
+		assert.Contains(t, got, "total             400 ms")
+		assert.Contains(t, got, "Provider calls: 2 (avg 200 ms, 0 failed)")
+		assert.Less(t, bytes.Index(buf.Bytes(), []byte("wallet load")), bytes.Index(buf.Bytes(), []byte("broadcast")),
+			"phases keep the order they ran in")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		writeTimings(&buf, output.FormatJSON, newMetrics(), 400*time.Millisecond)
+
+		var resp struct {
+			Timings struct {
+				Phases []struct {
+					Name  string `json:"name"`
+					MS    int64  `json:"ms"`
+					Count int    `json:"count"`
+				} `json:"phases"`
+				TotalMS  int64 `json:"total_ms"`
+				RPCCalls int64 `json:"rpc_calls"`
+			} `json:"timings"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+		require.Len(t, resp.Timings.Phases, 3)
+		assert.Equal(t, metrics.PhaseFeeQuote, resp.Timings.Phases[1].Name)
+		assert.Equal(t, int64(40), resp.Timings.Phases[1].MS)
+		assert.Equal(t, 2, resp.Timings.Phases[1].Count)
+		assert.Equal(t, int64(400), resp.Timings.TotalMS)
+		assert.Equal(t, int64(2), resp.Timings.RPCCalls)
+	})
+
+	t.Run("no phases or calls", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		writeTimings(&buf, output.FormatText, &metrics.Metrics{}, 5*time.Millisecond)
+		assert.Contains(t, buf.String(), "total")
+		assert.NotContains(t, buf.String(), "Provider calls")
+	})
+}
@@ -0,0 +1,352 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/tokens"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// tokensCmd is the parent command for the ERC-20 token registry.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var tokensCmd = &cobra.Command{
+	Use:   "tokens",
+	Short: "Manage ERC-20 tokens",
+	Long: `Manage the ERC-20 tokens sigil can send and show in balances.
+
+USDC, USDT, DAI, WBTC, and LINK are built in. Any other token is added to
+networks.eth.tokens in config.yaml with its contract address, symbol, and
+decimals. Tokens listed in config.yaml are tracked: their balances are always
+shown. Untracked built-in tokens appear in balances only when held.
+
+A configured token cannot reuse the symbol or contract address of a built-in
+token.`,
+}
+
+// tokensListCmd lists the registry.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var tokensListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List built-in and configured tokens",
+	Long:  `List the built-in ERC-20 tokens and those added in config.yaml.`,
+	Example: `  sigil tokens list
+  sigil tokens list -o json`,
+	Args: cobra.NoArgs,
+	RunE: runTokensList,
+}
+
+// tokensAddCmd adds a token to config.yaml.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var tokensAddCmd = &cobra.Command{
+	Use:   "add <address|symbol>",
+	Short: "Add or track an ERC-20 token",
+	Long: `Add an ERC-20 token to config.yaml so it can be sent with --token and is
+shown in balances.
+
+Pass a contract address. Symbol and decimals are read from the contract unless
+given with --symbol and --decimals. Passing a built-in symbol or address tracks
+that token, so its balance is always shown.
+
+Mark tokens that take a fee on transfer or rebase with --fee-on-transfer or
+--rebasing; sends of those tokens report the amount actually delivered.`,
+	Example: `  # Read symbol and decimals from the contract
+  sigil tokens add 0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984
+
+  # Supply them yourself
+  sigil tokens add 0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984 --symbol UNI --decimals 18
+
+  # Always show the DAI balance
+  sigil tokens add DAI`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTokensAdd,
+}
+
+// tokensRemoveCmd removes a token from config.yaml.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var tokensRemoveCmd = &cobra.Command{
+	Use:   "remove <symbol|address>",
+	Short: "Remove a token from config.yaml",
+	Long: `Remove a token from config.yaml.
+
+Removing a built-in token stops tracking it; it remains available and is still
+shown in balances when held.`,
+	Example: `  sigil tokens remove UNI
+  sigil tokens remove 0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTokensRemove,
+}
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// tokensAddSymbol overrides the symbol read from the contract.
+	tokensAddSymbol string
+	// tokensAddDecimals overrides the decimals read from the contract.
+	tokensAddDecimals int
+	// tokensAddFeeOnTransfer marks the token as taking a fee on transfer.
+	tokensAddFeeOnTransfer bool
+	// tokensAddRebasing marks the token as rebasing.
+	tokensAddRebasing bool
+)
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	tokensCmd.GroupID = "config"
+	rootCmd.AddCommand(tokensCmd)
+	tokensCmd.AddCommand(tokensListCmd)
+	tokensCmd.AddCommand(tokensAddCmd)
+	tokensCmd.AddCommand(tokensRemoveCmd)
+
+	tokensAddCmd.Flags().StringVar(&tokensAddSymbol, "symbol", "", "token symbol (read from the contract if omitted)")
+	tokensAddCmd.Flags().IntVar(&tokensAddDecimals, "decimals", 0, "token decimals (read from the contract if omitted)")
+	tokensAddCmd.Flags().BoolVar(&tokensAddFeeOnTransfer, "fee-on-transfer", false, "the token deducts a fee from transferred amounts")
+	tokensAddCmd.Flags().BoolVar(&tokensAddRebasing, "rebasing", false, "the token's balances change without transfers")
+}
+
+// tokenItem is a registry entry in command output.
+type tokenItem struct {
+	Symbol        string `json:"symbol"`
+	Address       string `json:"address"`
+	Decimals      int    `json:"decimals"`
+	Source        string `json:"source"`
+	Tracked       bool   `json:"tracked"`
+	FeeOnTransfer bool   `json:"fee_on_transfer,omitempty"`
+	Rebasing      bool   `json:"rebasing,omitempty"`
+}
+
+func newTokenItem(token *tokens.Token) tokenItem {
+	return tokenItem{
+		Symbol:        token.Symbol,
+		Address:       token.Address,
+		Decimals:      token.Decimals,
+		Source:        string(token.Source),
+		Tracked:       token.Tracked,
+		FeeOnTransfer: token.FeeOnTransfer,
+		Rebasing:      token.Rebasing,
+	}
+}
+
+func runTokensList(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+	all := tokens.All(cc.Cfg.GetETHTokens())
+	items := make([]tokenItem, 0, len(all))
+	for i := range all {
+		items = append(items, newTokenItem(&all[i]))
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, items)
+	}
+	outputTokensList(w, items)
+	return nil
+}
+
+// outputTokensList shows the registry as a table.
+func outputTokensList(w io.Writer, items []tokenItem) {
+	out(w, "  %-8s  %-42s  %8s  %-8s  %s\n", "SYMBOL", "ADDRESS", "DECIMALS", "SOURCE", "TRACKED")
+	for _, item := range items {
+		tracked := "no"
+		if item.Tracked {
+			tracked = "yes"
+		}
+		out(w, "  %-8s  %-42s  %8d  %-8s  %s\n", item.Symbol, item.Address, item.Decimals, item.Source, tracked)
+	}
+}
+
+func runTokensAdd(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+	configured := cc.Cfg.GetETHTokens()
+
+	token, err := tokenToAdd(cmd, configured, args[0], tokenAddOptions{
+		symbol:        tokensAddSymbol,
+		decimals:      tokensAddDecimals,
+		symbolSet:     cmd.Flags().Changed("symbol"),
+		decimalsSet:   cmd.Flags().Changed("decimals"),
+		feeOnTransfer: tokensAddFeeOnTransfer,
+		rebasing:      tokensAddRebasing,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := updateConfigTokens(cc.Cfg.GetHome(), ethNetwork(cc.Cfg), func(c *config.Config) { c.AddETHToken(token) }); err != nil {
+		return err
+	}
+
+	item := tokenItem{
+		Symbol:        token.Symbol,
+		Address:       token.Address,
+		Decimals:      token.Decimals,
+		Source:        string(tokenSource(token.Address)),
+		Tracked:       true,
+		FeeOnTransfer: token.FeeOnTransfer,
+		Rebasing:      token.Rebasing,
+	}
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, item)
+	}
+	out(w, "Added %s (%s, %d decimals)\n", item.Symbol, item.Address, item.Decimals)
+	return nil
+}
+
+// tokenAddOptions are the tokens add flag values.
+type tokenAddOptions struct {
+	symbol        string
+	decimals      int
+	symbolSet     bool
+	decimalsSet   bool
+	feeOnTransfer bool
+	rebasing      bool
+}
+
+// tokenToAdd builds the config entry for tokens add. A built-in token keeps
+// its own symbol and decimals; any other token needs a contract address and
+// has missing details read from the chain.
+func tokenToAdd(cmd *cobra.Command, configured []config.TokenConfig, arg string, opts tokenAddOptions) (config.TokenConfig, error) {
+	token := config.TokenConfig{
+		Symbol:        opts.symbol,
+		Decimals:      opts.decimals,
+		FeeOnTransfer: opts.feeOnTransfer,
+		Rebasing:      opts.rebasing,
+	}
+
+	if known, ok := eth.LookupKnownToken(arg); ok {
+		if (opts.symbolSet && !strings.EqualFold(opts.symbol, known.Symbol)) ||
+			(opts.decimalsSet && opts.decimals != known.Decimals) {
+			return token, sigilerr.WithSuggestion(
+				tokens.ErrBuiltIn,
+				fmt.Sprintf("%s is built in with %d decimals; omit --symbol and --decimals", known.Symbol, known.Decimals),
+			)
+		}
+		token.Address, token.Symbol, token.Decimals = known.Address, known.Symbol, known.Decimals
+		return token, nil
+	}
+
+	if !eth.IsValidAddress(arg) {
+		return token, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("%s is not a built-in token or a contract address (0x...)", arg),
+		)
+	}
+	token.Address = arg
+
+	if !opts.symbolSet || !opts.decimalsSet {
+		meta, err := readTokenMetadata(cmd, arg)
+		if err != nil {
+			return token, err
+		}
+		if !opts.symbolSet {
+			token.Symbol = meta.Symbol
+		}
+		if !opts.decimalsSet {
+			token.Decimals = meta.Decimals
+		}
+	}
+
+	if err := tokens.Validate(configured, token); err != nil {
+		return token, sigilerr.WithSuggestion(err, "pick another symbol with --symbol, or remove the existing token first")
+	}
+	return token, nil
+}
+
+// readTokenMetadata reads a contract's symbol and decimals from the ETH RPC.
+func readTokenMetadata(cmd *cobra.Command, address string) (*eth.TokenMetadata, error) {
+	cc := GetCmdContext(cmd)
+	client, err := eth.NewClient(cc.Cfg.GetETHRPC(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating ETH client for token lookup: %w", err)
+	}
+	defer client.Close()
+
+	ctx, cancel := contextWithTimeout(cmd, 30*time.Second)
+	defer cancel()
+	meta, err := client.GetTokenMetadata(ctx, address)
+	if err != nil {
+		return nil, sigilerr.WithSuggestion(
+			fmt.Errorf("reading token metadata for %s: %w", address, err),
+			"pass --symbol and --decimals to add the token without reading the contract",
+		)
+	}
+	return meta, nil
+}
+
+// tokenSource reports whether a contract address is built in.
+func tokenSource(address string) tokens.Source {
+	if _, ok := eth.LookupKnownToken(address); ok {
+		return tokens.SourceBuiltIn
+	}
+	return tokens.SourceConfig
+}
+
+func runTokensRemove(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+	token, ok := config.FindETHToken(cc.Cfg.GetETHTokens(), args[0])
+	if !ok {
+		if known, builtIn := eth.LookupKnownToken(args[0]); builtIn {
+			return sigilerr.WithSuggestion(
+				tokens.ErrBuiltIn,
+				fmt.Sprintf("%s is built in and not tracked in config.yaml; there is nothing to remove", known.Symbol),
+			)
+		}
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrNotFound,
+			fmt.Sprintf("token %s is not in config.yaml. List tokens with: sigil tokens list", args[0]),
+		)
+	}
+
+	if err := updateConfigTokens(cc.Cfg.GetHome(), ethNetwork(cc.Cfg), func(c *config.Config) { c.RemoveETHToken(token.Address) }); err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]string{"removed": token.Symbol, "address": token.Address})
+	}
+	out(w, "Removed %s (%s)\n", token.Symbol, token.Address)
+	return nil
+}
+
+// updateConfigTokens applies update to the config file and saves it. On
+// testnet update edits the Sepolia token list instead.
+func updateConfigTokens(home, network string, update func(c *config.Config)) error {
+	configPath := config.FindPath(home)
+	currentCfg, err := config.Load(configPath)
+	if err != nil {
+		// If file doesn't exist, start with defaults
+		currentCfg = config.Defaults()
+		currentCfg.Home = home
+	}
+	if network == "test" {
+		eth := &currentCfg.Networks.ETH
+		eth.Tokens, eth.Sepolia.Tokens = eth.Sepolia.Tokens, eth.Tokens
+		update(currentCfg)
+		eth.Tokens, eth.Sepolia.Tokens = eth.Sepolia.Tokens, eth.Tokens
+	} else {
+		update(currentCfg)
+	}
+
+	if err := config.Save(currentCfg, configPath); err != nil {
+		if errors.Is(err, config.ErrEnvOnly) {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("configuration comes from the environment; set %s instead", config.EnvName("networks.eth.tokens")),
+			)
+		}
+		return fmt.Errorf("saving config: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/tokens"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestTokenToAdd(t *testing.T) {
+	t.Parallel()
+
+	t.Run("built-in symbol tracks the built-in token", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTokenTestCmd(t.TempDir(), "", nil)
+
+		token, err := tokenToAdd(cmd, nil, "dai", tokenAddOptions{rebasing: true})
+		require.NoError(t, err)
+		assert.Equal(t, "DAI", token.Symbol)
+		assert.Equal(t, "0x6B175474E89094C44Da98b954EedeAC495271d0F", token.Address)
+		assert.Equal(t, 18, token.Decimals)
+		assert.True(t, token.Rebasing)
+	})
+
+	t.Run("built-in with different decimals", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTokenTestCmd(t.TempDir(), "", nil)
+
+		_, err := tokenToAdd(cmd, nil, "USDT", tokenAddOptions{decimals: 18, decimalsSet: true})
+		require.ErrorIs(t, err, tokens.ErrBuiltIn)
+	})
+
+	t.Run("explicit details skip the contract read", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTokenTestCmd(t.TempDir(), "", nil)
+
+		token, err := tokenToAdd(cmd, nil, testTokenContract, tokenAddOptions{
+			symbol: "UNI", decimals: 18, symbolSet: true, decimalsSet: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "UNI", token.Symbol)
+		assert.Equal(t, testTokenContract, token.Address)
+	})
+
+	t.Run("details read from the contract", func(t *testing.T) {
+		t.Parallel()
+		server := newTokenRPCServer(t)
+		defer server.Close()
+		cmd, _ := newTokenTestCmd(t.TempDir(), server.URL, nil)
+
+		token, err := tokenToAdd(cmd, nil, testTokenContract, tokenAddOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "UNI", token.Symbol)
+		assert.Equal(t, 18, token.Decimals)
+	})
+
+	t.Run("built-in symbol on another contract", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTokenTestCmd(t.TempDir(), "", nil)
+
+		_, err := tokenToAdd(cmd, nil, testTokenContract, tokenAddOptions{
+			symbol: "USDC", decimals: 6, symbolSet: true, decimalsSet: true,
+		})
+		require.ErrorIs(t, err, tokens.ErrSymbolInUse)
+	})
+
+	t.Run("not an address", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTokenTestCmd(t.TempDir(), "", nil)
+
+		_, err := tokenToAdd(cmd, nil, "UNI", tokenAddOptions{})
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	})
+}
+
+func TestRunTokensRemove(t *testing.T) {
+	t.Parallel()
+
+	uni := config.TokenConfig{Symbol: "UNI", Address: testTokenContract, Decimals: 18}
+	newCmd := func(home string) (*cobra.Command, *bytes.Buffer) {
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.Background())
+		SetCmdContext(cmd, &CommandContext{
+			Cfg: &mockConfigProvider{home: home, ethTokens: []config.TokenConfig{uni}},
+			Fmt: &mockFormatProvider{format: output.FormatText},
+		})
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		return cmd, &buf
+	}
+
+	t.Run("configured token", func(t *testing.T) {
+		t.Parallel()
+		home := t.TempDir()
+		require.NoError(t, updateConfigTokens(home, "main", func(c *config.Config) { c.AddETHToken(uni) }))
+		cmd, buf := newCmd(home)
+
+		require.NoError(t, runTokensRemove(cmd, []string{"uni"}))
+		assert.Contains(t, buf.String(), "Removed UNI")
+
+		saved, err := config.Load(config.Path(home))
+		require.NoError(t, err)
+		_, ok := config.FindETHToken(saved.GetETHTokens(), "UNI")
+		assert.False(t, ok)
+		_, ok = config.FindETHToken(saved.GetETHTokens(), "USDC")
+		assert.True(t, ok, "other tokens are kept")
+	})
+
+	t.Run("testnet edits the sepolia list", func(t *testing.T) {
+		t.Parallel()
+		home := t.TempDir()
+		require.NoError(t, updateConfigTokens(home, "test", func(c *config.Config) { c.AddETHToken(uni) }))
+
+		saved, err := config.Load(config.Path(home))
+		require.NoError(t, err)
+		_, ok := config.FindETHToken(saved.Networks.ETH.Sepolia.Tokens, "UNI")
+		assert.True(t, ok)
+		_, ok = config.FindETHToken(saved.GetETHTokens(), "UNI")
+		assert.False(t, ok, "mainnet tokens are unchanged")
+	})
+
+	t.Run("untracked built-in", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newCmd(t.TempDir())
+		require.ErrorIs(t, runTokensRemove(cmd, []string{"LINK"}), tokens.ErrBuiltIn)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newCmd(t.TempDir())
+		require.ErrorIs(t, runTokensRemove(cmd, []string{"FOO"}), sigilerr.ErrNotFound)
+	})
+}
+
+func TestOutputTokensList(t *testing.T) {
+	t.Parallel()
+
+	all := tokens.All([]config.TokenConfig{{Symbol: "UNI", Address: testTokenContract, Decimals: 18}})
+	items := make([]tokenItem, 0, len(all))
+	for i := range all {
+		items = append(items, newTokenItem(&all[i]))
+	}
+
+	var buf bytes.Buffer
+	outputTokensList(&buf, items)
+	assert.Contains(t, buf.String(), "SYMBOL")
+	assert.Regexp(t, `USDC\s+0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48\s+6\s+built-in\s+no`, buf.String())
+	assert.Regexp(t, `UNI\s+`+testTokenContract+`\s+18\s+config\s+yes`, buf.String())
+}
@@ -6,17 +6,22 @@ import (
 	"fmt"
 	"io"
 	"math/big"
+	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/mrz1836/sigil/internal/agent"
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
 	"github.com/mrz1836/sigil/internal/chain/eth"
 	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/price"
 	"github.com/mrz1836/sigil/internal/service/transaction"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
@@ -27,32 +32,98 @@ import (
 var (
 	// txWallet is the wallet name for transactions.
 	txWallet string
+	// txAccount is the BIP44 account of the wallet that sends.
+	txAccount uint32
 	// txTo is the recipient address.
 	txTo string
 	// txAmount is the amount to send.
 	txAmount string
+	// txToList and txAmountList hold every --to and --amount, paired in order.
+	txToList     []string
+	txAmountList []string
+	// txPaymentsFile lists further recipients as "address,amount" lines.
+	txPaymentsFile string
+	// txPayments are the recipients after txTo in a multi-recipient send.
+	txPayments []transaction.Payment
+	// txSplit are the recipients of a split sweep with their shares.
+	txSplit []transaction.Payment
 	// txChain is the blockchain to use.
 	txChain string
-	// txToken is the ERC-20 token to transfer (e.g., "USDC").
+	// txToken is the ERC-20 token to transfer, by symbol or contract address (e.g., "USDC").
 	txToken string
+	// txSaveToken adds an unknown --token contract to the config token list.
+	txSaveToken bool
 	// txGasSpeed is the gas speed preference (slow/medium/fast).
 	txGasSpeed string
 	// txConfirm skips confirmation prompt if false.
 	txConfirm bool
+	// txOverrideConfirmLimit lets --yes and agent mode skip review of a send
+	// at or above security.require_confirm_above.
+	txOverrideConfirmLimit bool
 	// txValidate enables UTXO validation before sweep transactions.
 	txValidate bool
+	// txNoChecksum accepts a single-case (non-checksummed) ETH recipient.
+	txNoChecksum bool
+	// txMaxFeeRate caps the BSV fee rate in sat/KB (0 = no cap).
+	txMaxFeeRate uint64
+	// txCoinSelection is the BSV UTXO selection strategy; empty uses the config.
+	txCoinSelection string
+	// txUTXOs are "txid:vout" outpoints that restrict BSV input selection.
+	txUTXOs []string
+	// txEnvelope restricts the send to the addresses of a budget envelope.
+	txEnvelope string
+	// txInteractiveCoins lets the user pick the BSV inputs at the confirmation prompt.
+	txInteractiveCoins bool
+	// txMaxSlippage is the allowed exchange rate move, in percent, for fiat amounts.
+	txMaxSlippage float64
+	// txFiat is the currency to show the approximate value of the send in.
+	txFiat string
+	// txFiatQuote is the price the send is valued at, nil when none is shown.
+	txFiatQuote *price.Quote
+	// txNonce overrides the ETH nonce when --nonce is given.
+	txNonce uint64
+	// txSigner selects what signs the transaction: seed or hardware.
+	txSigner string
+	// txDevice is the ID of the hardware wallet to sign with.
+	txDevice string
+	// txQueue queues the signed transaction in the outbox when no broadcast
+	// provider is reachable.
+	txQueue bool
+	// txQueueExpiry is how long an unreachable send stays queued, zero when
+	// it is not queued.
+	txQueueExpiry time.Duration
+	// txDryRun builds and signs the transaction and shows it without
+	// broadcasting.
+	txDryRun bool
 )
 
-// bsvConfirmationDetails holds computed details for BSV transaction confirmation.
+// bsvConfirmationDetails holds computed details for BSV (and BTC) transaction confirmation.
 type bsvConfirmationDetails struct {
+	Chain           chain.ID // BSV or BTC
 	To              string
 	AmountSats      uint64         // Actual satoshi amount (computed for sweep)
 	IsSweep         bool           // Whether this is a sweep-all transaction
 	EstimatedFee    uint64         // Estimated fee in satoshis
 	FeeRate         uint64         // Fee rate in sat/KB
+	FeeOrigin       string         // Where the fee rate came from (live, cached, default)
+	CoinSelection   string         // Strategy that chose the inputs (BSV only, empty for sweeps)
 	TotalUTXOs      int            // Total number of UTXOs being spent
 	AddressUTXOs    map[string]int // Address -> UTXO count
 	SourceAddresses []string       // Ordered list of addresses with UTXOs
+
+	// Inputs are the UTXOs being spent, and Candidates every spendable UTXO
+	// of the wallet they were chosen from (BSV only).
+	Inputs     []bsv.UTXO
+	Candidates []bsv.UTXO
+
+	// Recipients lists every payment of a multi-recipient send, To first;
+	// AmountSats is then their total. Nil for a single recipient.
+	Recipients []transaction.Payment
+	// Shares holds the share of each recipient of a split sweep as given,
+	// e.g. "70%"; nil otherwise.
+	Shares []string
+
+	Fiat *transaction.FiatConversion // Set when the amount was entered in fiat
 }
 
 // txCmd is the parent command for transaction operations.
@@ -78,21 +149,100 @@ var txSendCmd = &cobra.Command{
 For Ethereum transactions, you can send native ETH or ERC-20 tokens like USDC.
 For BSV transactions, only native BSV is supported.
 
-Use --amount all to send the entire balance (fees are deducted automatically).`,
+Use --amount all to send the entire balance (fees are deducted automatically).
+
+To pay several recipients, repeat --to and --amount in pairs or list them in
+--payments-file, one "address,amount" per line. BSV pays every recipient from
+a single transaction. ETH sends one transaction per recipient, with
+sequential nonces, in a single run.
+
+To split a BSV sweep, give --amount all once and a share with each recipient:
+--to address=70% --to address=30%. A share is a percentage or a fixed BSV
+amount; fixed amounts are paid first and the percentages, which must total
+100%, divide the rest. The fee is computed once for the single transaction,
+and the split is checked before confirmation.
+
+Amounts for native ETH and BSV may be given in US dollars (e.g. 50usd or $50).
+They are converted at the current exchange rate, which is shown with its
+timestamp before confirming. The rate is checked again just before broadcast
+and the send is aborted if it moved more than --max-slippage percent.
+
+With --signer hardware, an ETH send is signed by the Ethereum app of a
+Ledger connected over USB (Linux) instead of with the seed file, so the
+wallet is never unlocked. The device shows the derivation path and address
+of the key it signs with for confirmation, and must derive the wallet's own
+address. Use --device to choose between several connected devices (see
+"sigil wallet devices").
+
+Use --account to send from another BIP44 account of the wallet. Only that
+account's addresses are spent from, and BSV change goes to a new change
+address of the same account.
+
+Use --envelope to spend only from the addresses of a budget envelope (see
+"sigil envelope"). The change address of a BSV send joins the envelope.
+
+With --queue, or outbox.enabled in the config, a BSV, BTC, or BCH send that
+cannot reach any broadcast provider is not failed: the signed transaction is
+queued in the wallet's outbox and its status is reported as "queued". Its
+inputs stay reserved while "sigil outbox flush" (or a running "sigil serve")
+retries the broadcast, until it expires after outbox.expiry_hours.
+
+With --dry-run, the send is validated, its inputs or gas are chosen, and the
+transaction is built and signed exactly as it would be sent, but it is shown
+instead of broadcast: its inputs, outputs with the change marked, fee, size,
+and raw hex (or nonce and gas for ETH). Nothing is prompted for or recorded,
+and the wallet's change address is not used up.`,
 	Example: `  # Send ETH
-  sigil tx send --wallet main --to 0x742d35Cc6634C0532925a3b844Bc9e7595f8b2E0 --amount 0.1 --chain eth
+  sigil tx send --wallet main --to 0x742d35Cc6634C0532925a3b844Bc454e4438f44e --amount 0.1 --chain eth
 
   # Send all ETH
-  sigil tx send --wallet main --to 0x742d35Cc6634C0532925a3b844Bc9e7595f8b2E0 --amount all --chain eth
+  sigil tx send --wallet main --to 0x742d35Cc6634C0532925a3b844Bc454e4438f44e --amount all --chain eth
 
   # Send USDC
-  sigil tx send --wallet main --to 0x742d35Cc6634C0532925a3b844Bc9e7595f8b2E0 --amount 100 --chain eth --token USDC
+  sigil tx send --wallet main --to 0x742d35Cc6634C0532925a3b844Bc454e4438f44e --amount 100 --chain eth --token USDC
 
   # Send BSV
   sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount 0.001 --chain bsv
 
   # Send all BSV
-  sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount all --chain bsv`,
+  sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount all --chain bsv
+
+  # Send $50 worth of BSV
+  sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount 50usd --chain bsv
+
+  # Pay two recipients in one BSV transaction
+  sigil tx send --wallet main --chain bsv \
+    --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount 0.001 \
+    --to 1BoatSLRHtKNngkdXEeobR76b53LETtpyT --amount 0.002
+
+  # Sweep all BSV, 70% to one address and 30% to another
+  sigil tx send --wallet main --chain bsv --amount all \
+    --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa=70% \
+    --to 1BoatSLRHtKNngkdXEeobR76b53LETtpyT=30%
+
+  # Pay everyone listed in a file
+  sigil tx send --wallet main --chain bsv --payments-file payroll.csv
+
+  # Replace the pending ETH transaction with nonce 42
+  sigil tx send --wallet main --to 0x742d35Cc6634C0532925a3b844Bc454e4438f44e --amount 0.1 --chain eth --nonce 42 --gas fast
+
+  # Choose the BSV inputs at the confirmation prompt
+  sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount 0.001 --chain bsv --interactive-coins
+
+  # Pay rent from the rent envelope only
+  sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount 0.01 --chain bsv --envelope rent
+
+  # Send BSV from BIP44 account 1
+  sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount 0.001 --chain bsv --account 1
+
+  # Preview the signed transaction without broadcasting it
+  sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount 0.001 --chain bsv --dry-run
+
+  # Queue the transaction if the network is unreachable
+  sigil tx send --wallet main --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount 0.001 --chain bsv --queue
+
+  # Sign on a connected hardware wallet
+  sigil tx send --wallet ledger --to 0x742d35Cc6634C0532925a3b844Bc454e4438f44e --amount 0.1 --chain eth --signer hardware`,
 	RunE: runTxSend,
 }
 
@@ -102,33 +252,91 @@ func init() {
 	rootCmd.AddCommand(txCmd)
 	txCmd.AddCommand(txSendCmd)
 
-	txSendCmd.Flags().StringVar(&txWallet, "wallet", "", "wallet name (required)")
-	txSendCmd.Flags().StringVar(&txTo, "to", "", "recipient address (required)")
-	txSendCmd.Flags().StringVar(&txAmount, "amount", "", "amount to send, or 'all' for entire balance (required)")
+	txSendCmd.Flags().StringVar(&txWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+	txSendCmd.Flags().Uint32Var(&txAccount, "account", 0, accountFlagUsage)
+	txSendCmd.Flags().StringArrayVar(&txToList, "to", nil, "recipient address (repeat with --amount to pay several recipients, or address=share to split --amount all)")
+	txSendCmd.Flags().StringArrayVar(&txAmountList, "amount", nil, "amount to send, a USD value like 50usd, or 'all' for entire balance")
+	txSendCmd.Flags().StringVar(&txPaymentsFile, "payments-file", "", "file of \"address,amount\" lines to pay, or - for stdin (BSV and ETH only)")
 	txSendCmd.Flags().StringVar(&txChain, "chain", "eth", "blockchain: eth, bsv")
-	txSendCmd.Flags().StringVar(&txToken, "token", "", "ERC-20 token symbol (e.g., USDC) - ETH only")
+	txSendCmd.Flags().StringVar(&txToken, "token", "", "ERC-20 token symbol or contract address (e.g., USDC, 0x...) - ETH only")
+	txSendCmd.Flags().BoolVar(&txSaveToken, "save-token", false, "save an unknown --token contract to the config token list (ETH only)")
 	txSendCmd.Flags().StringVar(&txGasSpeed, "gas", "medium", "gas speed: slow, medium, fast")
 	txSendCmd.Flags().BoolVar(&txConfirm, "yes", false, "skip confirmation prompt")
+	txSendCmd.Flags().BoolVar(&txOverrideConfirmLimit, "override-confirm-limit", false, "allow --yes or agent mode to skip review at or above security.require_confirm_above")
 	txSendCmd.Flags().BoolVar(&txValidate, "validate", false, "validate UTXOs before sweep (BSV only)")
-
-	_ = txSendCmd.MarkFlagRequired("wallet")
-	_ = txSendCmd.MarkFlagRequired("to")
-	_ = txSendCmd.MarkFlagRequired("amount")
+	txSendCmd.Flags().BoolVar(&txNoChecksum, "no-checksum", false, "accept an all-lowercase ETH recipient address (ETH only)")
+	txSendCmd.Flags().Uint64Var(&txMaxFeeRate, "max-fee-rate", 0, "refuse to send above this fee rate in sat/KB, 0 = no limit (BSV only)")
+	txSendCmd.Flags().StringVar(&txCoinSelection, "coin-selection", "", "UTXO selection: largest-first, smallest-first, branch-and-bound, manual (default fees.bsv_coin_selection, BSV only)")
+	txSendCmd.Flags().StringArrayVar(&txUTXOs, "utxo", nil, "spend only this txid:vout outpoint, repeatable (BSV only)")
+	txSendCmd.Flags().StringVar(&txEnvelope, "envelope", "", "spend only from the addresses of this envelope (BSV, BTC, BCH)")
+	txSendCmd.Flags().BoolVar(&txInteractiveCoins, "interactive-coins", false, "choose the inputs from a list at the confirmation prompt (BSV only)")
+	txSendCmd.Flags().Uint64Var(&txNonce, "nonce", 0, "use this nonce instead of the next free one, e.g. to replace a pending transaction (ETH only)")
+	txSendCmd.Flags().Float64Var(&txMaxSlippage, "max-slippage", defaultMaxSlippage, "abort a USD-denominated send if the exchange rate moves more than this percent before broadcast")
+	txSendCmd.Flags().StringVar(&txFiat, "fiat", "", fiatFlagUsage)
+	txSendCmd.Flags().StringVar(&txSigner, "signer", string(wallet.SignerSeed), "what signs the transaction: seed, hardware (ETH only)")
+	txSendCmd.Flags().StringVar(&txDevice, "device", "", "ID of the hardware wallet to sign with when several are connected")
+	txSendCmd.Flags().BoolVar(&txQueue, "queue", false, "queue the signed transaction in the outbox if no broadcast provider is reachable (BSV, BTC, BCH; default outbox.enabled)")
+	txSendCmd.Flags().BoolVar(&txDryRun, "dry-run", false, "build and sign the transaction and show it without broadcasting")
 }
 
 //nolint:gocyclo,gocognit // CLI flow involves validation and routing
-func runTxSend(cmd *cobra.Command, _ []string) error {
+func runTxSend(cmd *cobra.Command, _ []string) (err error) {
+	if err = resolveWalletName(cmd, &txWallet); err != nil {
+		return err
+	}
 	cc := GetCmdContext(cmd)
+	if cc.AgentCred != nil {
+		// Sends that went out are recorded as they are broadcast
+		defer func() {
+			if err != nil {
+				entry := agentSendEntry(agentAuditSourceCLI, chain.ID(txChain), txTo, txAmount, txToken)
+				recordAgentAudit(cc, cc.AgentCred.WalletName, cc.AgentCred.ID, entry, err)
+			}
+		}()
+	}
 	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
 	defer cancel()
 
 	// Validate chain
-	chainID, ok := chain.ParseChainID(txChain)
-	if !ok || !chainID.IsMVP() {
-		return sigilerr.WithSuggestion(
-			sigilerr.ErrInvalidInput,
-			fmt.Sprintf("invalid chain: %s (use eth or bsv)", txChain),
-		)
+	chainID, err := parseEnabledChain(cc.Cfg, txChain)
+	if err != nil {
+		return err
+	}
+
+	// The wallet's stamped network governs this send, token lookups included
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	if meta, metaErr := storage.LoadMetadata(txWallet); metaErr == nil {
+		applyWalletNetwork(cmd, meta)
+	}
+
+	// Collect the recipients from --to/--amount pairs and --payments-file
+	if err := resolveTxPayments(cmd, chainID); err != nil {
+		return err
+	}
+
+	// Check --utxo outpoints and --envelope before unlocking the wallet
+	if err := resolveTxUTXOs(chainID); err != nil {
+		return err
+	}
+	if err := resolveTxEnvelope(chainID); err != nil {
+		return err
+	}
+	if err := checkInteractiveCoins(chainID, txConfirm || cc.AgentCred != nil); err != nil {
+		return err
+	}
+	nonce, err := resolveTxNonce(chainID, cmd.Flags().Changed("nonce"))
+	if err != nil {
+		return err
+	}
+	if txQueueExpiry, err = resolveTxQueue(cc.Cfg, chainID, cmd.Flags().Changed("queue")); err != nil {
+		return err
+	}
+	signer, err := resolveTxSigner(cc, chainID)
+	if err != nil {
+		return err
+	}
+	if err := checkTxDryRun(signer); err != nil {
+		return err
 	}
 
 	// Token validation
@@ -139,13 +347,51 @@ func runTxSend(cmd *cobra.Command, _ []string) error {
 		)
 	}
 
+	// ETH recipients must be EIP-55 checksummed; check before unlocking the wallet
+	if chainID == chain.ETH {
+		to, err := transaction.NormalizeETHRecipient(txTo, txNoChecksum)
+		if err != nil {
+			return err
+		}
+		txTo = to
+	}
+
+	// Resolve the token before unlocking the wallet so an unknown contract
+	// can be reviewed first
+	var tokenMeta *eth.TokenMetadata
+	if txToken != "" {
+		meta, accepted, err := resolveTxToken(ctx, cmd, txToken, txConfirm || cc.AgentCred != nil, txSaveToken)
+		if err != nil {
+			return err
+		}
+		if !accepted {
+			outln(cmd.OutOrStdout(), "Transaction canceled.")
+			return nil
+		}
+		tokenMeta = meta
+	}
+
+	// A hardware wallet signs without the seed file
+	if signer == wallet.SignerHardware {
+		return runTxSendHardware(ctx, cmd, chainID, tokenMeta, nonce)
+	}
+
+	// Convert a USD amount to coin at the current rate before unlocking the wallet
+	fiat, err := resolveTxFiatAmount(ctx, cmd, chainID)
+	if err != nil {
+		return err
+	}
+	if txFiatQuote, err = resolveTxFiatQuote(ctx, cmd, chainID); err != nil {
+		return err
+	}
+
 	// Load wallet and get private key (using session if available)
-	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
 	wlt, seed, err := loadWalletWithSession(txWallet, storage, cmd)
 	if err != nil {
 		return err
 	}
 	defer wallet.ZeroBytes(seed)
+	applyWalletSettings(cmd, wlt)
 
 	// xpub read-only mode: deny spending operations
 	if cc.AgentXpub != "" {
@@ -155,7 +401,10 @@ func runTxSend(cmd *cobra.Command, _ []string) error {
 		)
 	}
 
-	// Get the addresses for this chain
+	// Get the addresses of the sending account for this chain
+	if wlt, err = selectAccount(wlt, txAccount); err != nil {
+		return err
+	}
 	addresses, ok := wlt.Addresses[chainID]
 	if !ok || len(addresses) == 0 {
 		return sigilerr.WithSuggestion(
@@ -163,6 +412,15 @@ func runTxSend(cmd *cobra.Command, _ []string) error {
 			fmt.Sprintf("wallet '%s' has no addresses for chain %s", txWallet, chainID),
 		)
 	}
+	if txEnvelope != "" {
+		store := utxostore.New(filepath.Join(cc.Cfg.GetHome(), "wallets", txWallet))
+		if err := store.Load(); err != nil {
+			return fmt.Errorf("loading UTXO store: %w", err)
+		}
+		if addresses, err = restrictToEnvelope(store, chainID, addresses, txEnvelope, txWallet); err != nil {
+			return err
+		}
+	}
 
 	// Agent mode: enforce chain authorization
 	if cc.AgentCred != nil {
@@ -173,6 +431,16 @@ func runTxSend(cmd *cobra.Command, _ []string) error {
 					cc.AgentCred.ID, chainID, cc.AgentCred.Chains),
 			)
 		}
+		var tokenAddress string
+		if tokenMeta != nil {
+			tokenAddress = tokenMeta.Address
+		}
+		if err := enforceAgentAsset(cc.AgentCred, chainID, tokenAddress); err != nil {
+			return err
+		}
+		if err := enforceAgentHost(cc.AgentCred); err != nil {
+			return err
+		}
 	}
 
 	// Agent mode: skip confirmation prompt (non-interactive)
@@ -180,43 +448,191 @@ func runTxSend(cmd *cobra.Command, _ []string) error {
 		txConfirm = true
 	}
 
+	// Hold the wallet lock until the send has recorded its spent UTXOs, so a
+	// concurrent send cannot select the same inputs
+	lock, err := lockWallet(cmd, storage, txWallet, "tx send")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
 	// Execute transaction via service
-	return runTxSendWithService(ctx, cmd, chainID, wlt, addresses, seed, storage)
+	return runTxSendWithService(ctx, cmd, chainID, wlt, addresses, seed, storage, tokenMeta, fiat, nonce)
+}
+
+// enforceAgentAsset rejects a send of an asset outside the agent's allowlist.
+// token is the ERC-20 contract address, or "" for the native coin.
+func enforceAgentAsset(cred *agent.Credential, chainID chain.ID, token string) error {
+	if err := agent.CheckAsset(cred, chainID, token); err != nil {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAgentAssetDenied,
+			fmt.Sprintf("agent '%s': %s", cred.ID, err),
+		)
+	}
+	return nil
+}
+
+// enforceAgentSpend checks every recipient of req against the agent's
+// address allowlist and per-transaction limit, the total against its
+// rolling 24-hour limit, and the recipients against the policy rules of the
+// asset sent. Token amounts are not in the chain's limit units, so a token
+// send is held only to the policy rules of its token. A sweep's amount is
+// only known once it is built, so an agent with spending limits on the
+// chain or asset cannot sweep. decimals are those of the asset sent.
+//
+//nolint:gocognit // Flat limits and policy rules are checked in turn
+func enforceAgentSpend(cred *agent.Credential, counterPath, token string, req *transaction.SendRequest, decimals int) error {
+	payments := req.AllPayments()
+	if len(req.Split) > 0 {
+		payments = req.Split
+	}
+	var contract string
+	if req.TokenMeta != nil {
+		contract = req.TokenMeta.Address
+	}
+	sweep := req.SweepAll() || len(req.Split) > 0
+	if sweep && agentHasSpendLimit(cred, req.ChainID, contract) {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAgentPolicyViolation,
+			fmt.Sprintf("agent '%s' has spending limits on %s, so it cannot send 'all'; send an exact amount", cred.ID, req.ChainID),
+		)
+	}
+
+	total := new(big.Int)
+	transfers := make([]agent.Transfer, 0, len(payments))
+	for _, p := range payments {
+		amount := new(big.Int)
+		if !sweep {
+			var err error
+			if amount, err = parseDecimalAmount(p.AmountStr, decimals); err != nil {
+				return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("invalid amount: %s", p.AmountStr))
+			}
+		}
+		if err := agent.ValidateTransaction(cred, req.ChainID, p.To, nativeAmount(amount, contract)); err != nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrAgentPolicyViolation, err.Error())
+		}
+		total.Add(total, amount)
+		transfers = append(transfers, agent.Transfer{To: p.To, Amount: amount})
+	}
+
+	// ETH sends one transaction per recipient; the other chains pay every
+	// recipient in one
+	if req.ChainID != chain.ETH && len(payments) > 1 {
+		if err := agent.ValidateTransaction(cred, req.ChainID, payments[0].To, total); err != nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrAgentPolicyViolation, err.Error())
+		}
+	}
+
+	if contract == "" {
+		if err := agent.CheckDailyLimit(counterPath, token, cred, req.ChainID, total); err != nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrAgentDailyLimit, err.Error())
+		}
+	}
+
+	if err := agent.CheckRules(counterPath, token, cred, req.ChainID, contract, transfers); err != nil {
+		if errors.Is(err, agent.ErrDailyLimitExceed) {
+			return sigilerr.WithSuggestion(sigilerr.ErrAgentDailyLimit, err.Error())
+		}
+		return sigilerr.WithSuggestion(sigilerr.ErrAgentPolicyViolation, err.Error())
+	}
+	return nil
+}
+
+// nativeAmount returns the native coin moved by a send of amount of the
+// asset contract: amount itself for the native coin, and nothing for an
+// ERC-20 token, which the chain's limits do not cover.
+func nativeAmount(amount *big.Int, contract string) *big.Int {
+	if contract != "" {
+		return new(big.Int)
+	}
+	return amount
+}
+
+// resolveTxFiatAmount converts a fiat --amount into the chain's coin and
+// replaces txAmount with the result. It returns nil for coin amounts.
+func resolveTxFiatAmount(ctx context.Context, cmd *cobra.Command, chainID chain.ID) (*transaction.FiatConversion, error) {
+	fiatAmount, isFiat, err := price.ParseFiatAmount(txAmount)
+	if !isFiat {
+		return nil, nil //nolint:nilnil // A coin amount has no fiat conversion
+	}
+	if err != nil {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid USD amount: %s (e.g. 50usd or $12.50)", txAmount),
+		)
+	}
+	if txToken != "" {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"USD amounts are only supported for native ETH and BSV, not --token transfers",
+		)
+	}
+	if txMaxSlippage < 0 {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid --max-slippage: %g (must be zero or more)", txMaxSlippage),
+		)
+	}
+
+	fiat, coinAmount, err := convertFiatAmount(ctx, newPriceQuoterFn(ctx, GetCmdContext(cmd)), chainID, fiatAmount)
+	if err != nil {
+		return nil, err
+	}
+	txAmount = coinAmount
+	return fiat, nil
 }
 
 // runTxSendWithService executes a transaction using the transaction service.
-func runTxSendWithService(ctx context.Context, cmd *cobra.Command, chainID chain.ID, wlt *wallet.Wallet, addresses []wallet.Address, seed []byte, storage *wallet.FileStorage) error {
+func runTxSendWithService(ctx context.Context, cmd *cobra.Command, chainID chain.ID, wlt *wallet.Wallet, addresses []wallet.Address, seed []byte, storage *wallet.FileStorage, tokenMeta *eth.TokenMetadata, fiat *transaction.FiatConversion, nonce *uint64) error {
 	cc := GetCmdContext(cmd)
 
 	// The wallet's stamped network governs this send (per-wallet model).
-	warnNetworkConflict(cmd, wlt)
 	bsvNetwork := effectiveBSVNetwork(wlt, cc.Cfg)
 
+	// Signing keys derived during this run are cached and wiped before returning.
+	keyCache := wallet.NewKeyCache(seed)
+	defer keyCache.Zero()
+
 	// Create transaction service
-	txService := cc.TransactionService
-	if txService == nil {
-		// Initialize on-demand if not set
-		txService = transaction.NewService(&transaction.Config{
-			Config:  cc.Cfg,
-			Storage: storage,
-			Logger:  cc.Log,
-		})
+	txService, err := newTransactionService(cc, storage)
+	if err != nil {
+		return err
+	}
+
+	var coinSelection bsv.CoinSelection
+	if chainID == chain.BSV {
+		if coinSelection, err = resolveCoinSelection(cc.Cfg); err != nil {
+			return err
+		}
 	}
 
 	// Build send request
 	req := &transaction.SendRequest{
-		ChainID:       chainID,
-		To:            txTo,
-		AmountStr:     txAmount,
-		Wallet:        txWallet,
-		FromAddress:   addresses[0].Address,
-		Token:         txToken,
-		GasSpeed:      txGasSpeed,
-		Addresses:     addresses, // For BSV multi-address
-		Network:       bsvNetwork,
-		Confirm:       txConfirm,
-		Seed:          seed,
-		ValidateUTXOs: txValidate, // Enable UTXO validation if requested
+		ChainID:          chainID,
+		To:               txTo,
+		AmountStr:        txAmount,
+		Payments:         txPayments,
+		Split:            txSplit,
+		Wallet:           txWallet,
+		Account:          txAccount,
+		FromAddress:      addresses[0].Address,
+		Token:            txToken,
+		TokenMeta:        tokenMeta,
+		GasSpeed:         txGasSpeed,
+		Addresses:        addresses, // For BSV multi-address
+		AllowNonChecksum: txNoChecksum,
+		Network:          bsvNetwork,
+		MaxFeeRate:       txMaxFeeRate,
+		CoinSelection:    string(coinSelection),
+		Outpoints:        txUTXOs,
+		Fiat:             fiat,
+		Nonce:            nonce,
+		QueueExpiry:      txQueueExpiry,
+		DryRun:           txDryRun,
+		Confirm:          txConfirm,
+		Seed:             seed,
+		Keys:             keyCache,
+		ValidateUTXOs:    txValidate, // Enable UTXO validation if requested
 	}
 
 	// Set agent fields if in agent mode
@@ -224,10 +640,28 @@ func runTxSendWithService(ctx context.Context, cmd *cobra.Command, chainID chain
 		req.AgentCredID = cc.AgentCred.ID
 		req.AgentToken = cc.AgentToken
 		req.AgentCounterPath = cc.AgentCounterPath
+
+		// The wallet lock is held, so no other send can spend the allowance
+		// between this check and the send's record of it
+		decimals := nativeDecimals(chainID)
+		if tokenMeta != nil {
+			decimals = tokenMeta.Decimals
+		}
+		if err := enforceAgentSpend(cc.AgentCred, cc.AgentCounterPath, cc.AgentToken, req, decimals); err != nil {
+			return err
+		}
 	}
 
 	// Display transaction details and prompt for confirmation (unless --yes flag or agent mode)
-	if !txConfirm {
+	switch {
+	case txDryRun:
+		// Nothing is broadcast, so there is nothing to confirm
+	case txConfirm:
+		if err := checkUnreviewedSend(ctx, cmd, newSendConfirmParams(chainID, req), txOverrideConfirmLimit); err != nil {
+			return err
+		}
+	default:
+		displayInternalTransferBanner(cmd.OutOrStdout(), wlt, req)
 		confirmed, err := promptTransactionConfirmation(ctx, cmd, chainID, req, addresses)
 		if err != nil {
 			return err
@@ -238,23 +672,74 @@ func runTxSendWithService(ctx context.Context, cmd *cobra.Command, chainID chain
 		}
 	}
 
+	// Re-quote a fiat amount so time spent at the prompt cannot send at a stale rate
+	if fiat != nil {
+		if err := checkFiatSlippage(ctx, newPriceQuoterFn(ctx, cc), fiat, txMaxSlippage); err != nil {
+			return err
+		}
+	}
+
+	// Send a multi-recipient batch or split sweep
+	if len(req.Payments) > 0 || len(req.Split) > 0 {
+		results, err := txService.SendBatch(ctx, req)
+		if chainID == chain.BSV && (txConfirm || txDryRun) && len(results) > 0 {
+			warnBSVFeeFallback(results[0].FeeSource, results[0].FeeRate, results[0].FeeAge)
+		}
+		if txDryRun {
+			displayDryRunResults(cmd, results)
+			return err
+		}
+		recordAgentSends(cc, agentAuditSourceCLI, results...)
+		if len(results) == 1 && results[0].Queued() {
+			displayQueuedResult(cmd, txWallet, results[0])
+			return err
+		}
+		displayBatchResults(cmd, req, results, bsvNetwork)
+		assignChangeToEnvelope(cc, storage, chainID, results...)
+		runPostSendHook(ctx, cc, sentFromResults(txWallet, results...)...)
+		return err
+	}
+
 	// Send transaction
 	result, err := txService.Send(ctx, req)
 	if err != nil {
 		return err
 	}
+	if result.DryRun() {
+		if chainID == chain.BSV {
+			warnBSVFeeFallback(result.FeeSource, result.FeeRate, result.FeeAge)
+		}
+		displayDryRunResults(cmd, []*transaction.SendResult{result})
+		return nil
+	}
+	recordAgentSends(cc, agentAuditSourceCLI, result)
+	if result.Queued() {
+		// Nothing was broadcast: the outbox runs the post-send steps
+		displayQueuedResult(cmd, txWallet, result)
+		return nil
+	}
+	assignChangeToEnvelope(cc, storage, chainID, result)
 
 	// Display result
 	switch chainID {
 	case chain.BSV:
-		displayBSVTxResult(cmd, convertToBSVTransactionResult(result), bsvNetwork)
+		if txConfirm {
+			// No confirmation screen was shown, so surface a fallback fee rate here.
+			warnBSVFeeFallback(result.FeeSource, result.FeeRate, result.FeeAge)
+		}
+		displayBSVTxResult(cmd, convertToBSVTransactionResult(result), bsvNetwork, result.Changes, fiat)
+	case chain.BTC:
+		displayBTCTxResult(cmd, convertToBSVTransactionResult(result), bsvNetwork, result.Changes, fiat)
+	case chain.BCH:
+		displayBCHTxResult(cmd, convertToBSVTransactionResult(result), bsvNetwork, result.Changes, fiat)
 	case chain.ETH:
-		displayTxResult(cmd, convertToETHTransactionResult(result))
-	case chain.BTC, chain.BCH, chain.LTC:
-		// BTC, BCH, and LTC are not yet supported for transactions
-		displayTxResult(cmd, convertToETHTransactionResult(result))
+		displayTxResult(cmd, convertToETHTransactionResult(result), result.Changes, fiat)
+	case chain.LTC:
+		// LTC is not yet supported for transactions
+		displayTxResult(cmd, convertToETHTransactionResult(result), result.Changes, fiat)
 	}
 
+	runPostSendHook(ctx, cc, sentFromResults(txWallet, result)...)
 	return nil
 }
 
@@ -263,11 +748,18 @@ func runTxSendWithService(ctx context.Context, cmd *cobra.Command, chainID chain
 func promptTransactionConfirmation(ctx context.Context, cmd *cobra.Command, chainID chain.ID, req *transaction.SendRequest, addresses []wallet.Address) (bool, error) {
 	switch chainID {
 	case chain.ETH:
+		if len(req.Payments) > 0 {
+			return promptETHBatchConfirmation(ctx, cmd, req)
+		}
 		return promptETHConfirmation(ctx, cmd, req)
 	case chain.BSV:
 		return promptBSVConfirmation(ctx, cmd, req, addresses)
-	case chain.BTC, chain.BCH, chain.LTC:
-		// BTC, BCH, and LTC are not yet supported for transactions
+	case chain.BTC:
+		return promptBTCConfirmation(ctx, cmd, req, addresses)
+	case chain.BCH:
+		return promptBCHConfirmation(ctx, cmd, req, addresses)
+	case chain.LTC:
+		// LTC is not yet supported for transactions
 		return false, sigilerr.WithSuggestion(
 			sigilerr.ErrInvalidInput,
 			fmt.Sprintf("chain %s is not yet supported for transactions", chainID),
@@ -280,29 +772,86 @@ func promptTransactionConfirmation(ctx context.Context, cmd *cobra.Command, chai
 	}
 }
 
+// tokenBalanceReader reads ERC-20 balances for the fee preview.
+type tokenBalanceReader interface {
+	GetTokenBalance(ctx context.Context, address, tokenAddress string) (*big.Int, error)
+}
+
+// previewTokenAmount returns the token amount the send will transfer, in
+// base units: the wallet's token balance for a sweep, otherwise the parsed
+// --amount.
+func previewTokenAmount(ctx context.Context, client tokenBalanceReader, req *transaction.SendRequest) (*big.Int, error) {
+	if req.SweepAll() {
+		balance, err := client.GetTokenBalance(ctx, req.FromAddress, req.TokenMeta.Address)
+		if err != nil {
+			return nil, fmt.Errorf("getting token balance for fee preview: %w", err)
+		}
+		return balance, nil
+	}
+
+	amount, err := parseDecimalAmount(req.AmountStr, req.TokenMeta.Decimals)
+	if err != nil {
+		return nil, fmt.Errorf("parsing amount for fee preview: %w", err)
+	}
+	return amount, nil
+}
+
 // promptETHConfirmation handles ETH transaction confirmation prompt.
 func promptETHConfirmation(ctx context.Context, cmd *cobra.Command, req *transaction.SendRequest) (bool, error) {
+	estimate, tokenSymbol, err := estimateETHConfirmFee(ctx, cmd, req)
+	if err != nil {
+		return false, err
+	}
+
+	// Format display amount
+	displayAmount := txAmount
+	if req.SweepAll() {
+		displayAmount = txAmount + " (sweep all)"
+	}
+
+	// Display transaction details
+	displayTxDetails(cmd, req.FromAddress, req.To, displayAmount, tokenSymbol, estimate, req.Fiat)
+	displayTokenAmountWarning(cmd.OutOrStdout(), req.TokenMeta)
+	displayNonceOverride(cmd.OutOrStdout(), req.Nonce)
+
+	// Prompt for confirmation
+	return confirmSend(ctx, cmd, newETHConfirmParams(req, txGasSpeed))
+}
+
+// estimateETHConfirmFee estimates the gas for the review screen of an ETH
+// send to req.To and returns the token symbol, empty for native ETH.
+func estimateETHConfirmFee(ctx context.Context, cmd *cobra.Command, req *transaction.SendRequest) (*eth.GasEstimate, string, error) {
 	cc := GetCmdContext(cmd)
 
 	// Estimate ETH gas fees for display
-	ethClient, err := eth.NewClient(cc.Cfg.GetETHRPC(), nil)
+	ethClient, err := eth.NewClient(cc.Cfg.GetETHRPC(), &eth.ClientOptions{
+		GasMarginPercent: cc.Cfg.GetETHGasMarginPercent(),
+		GasTable:         eth.NewGasTableStore(transaction.GasTablePath(cc.Cfg.GetHome())),
+	})
 	if err != nil {
-		return false, fmt.Errorf("creating ETH client for fee estimation: %w", err)
+		return nil, "", fmt.Errorf("creating ETH client for fee estimation: %w", err)
 	}
 	defer ethClient.Close()
 
 	speed, err := eth.ParseGasSpeed(txGasSpeed)
 	if err != nil {
-		return false, fmt.Errorf("parsing gas speed: %w", err)
+		return nil, "", fmt.Errorf("parsing gas speed: %w", err)
 	}
 
 	var estimate *eth.GasEstimate
-	if txToken != "" {
-		// Build ERC-20 call data for gas estimation (amount doesn't affect gas)
-		tokenAddress, _, _ := resolveToken(txToken)
-		previewData, dataErr := eth.BuildERC20TransferData(req.To, big.NewInt(1))
+	var tokenSymbol string
+	if req.TokenMeta != nil {
+		// Estimate with the real transfer calldata: fee-on-transfer and proxy
+		// tokens can need more gas depending on the amount moved
+		tokenAddress := req.TokenMeta.Address
+		tokenSymbol = req.TokenMeta.Symbol
+		previewAmount, amountErr := previewTokenAmount(ctx, ethClient, req)
+		if amountErr != nil {
+			return nil, "", amountErr
+		}
+		previewData, dataErr := eth.BuildERC20TransferData(req.To, previewAmount)
 		if dataErr != nil {
-			return false, fmt.Errorf("building ERC-20 data for fee preview: %w", dataErr)
+			return nil, "", fmt.Errorf("building ERC-20 data for fee preview: %w", dataErr)
 		}
 		estimate, err = ethClient.EstimateGasForERC20Transfer(ctx, req.FromAddress, tokenAddress, previewData, speed)
 	} else {
@@ -310,20 +859,33 @@ func promptETHConfirmation(ctx context.Context, cmd *cobra.Command, req *transac
 		estimate, err = ethClient.EstimateGasForETHTransfer(ctx, req.FromAddress, req.To, big.NewInt(1), speed)
 	}
 	if err != nil {
-		return false, fmt.Errorf("estimating fees for confirmation: %w", err)
+		return nil, "", fmt.Errorf("estimating fees for confirmation: %w", err)
 	}
+	return estimate, tokenSymbol, nil
+}
 
-	// Format display amount
-	displayAmount := txAmount
-	if req.SweepAll() {
-		displayAmount = txAmount + " (sweep all)"
+// newETHConfirmParams collects the parameters an ETH confirmation code covers.
+func newETHConfirmParams(req *transaction.SendRequest, gasSpeed string) *txConfirmParams {
+	p := &txConfirmParams{
+		Chain:    chain.ETH,
+		From:     []string{req.FromAddress},
+		To:       req.To,
+		Decimals: 18,
+		Fee:      strings.ToLower(gasSpeed),
+		Fiat:     req.Fiat,
 	}
-
-	// Display transaction details
-	displayTxDetails(cmd, req.FromAddress, req.To, displayAmount, txToken, estimate)
-
-	// Prompt for confirmation
-	return promptConfirmFn(), nil
+	if req.TokenMeta != nil {
+		p.Token = req.TokenMeta.Address
+		p.TokenSymbol = req.TokenMeta.Symbol
+		p.Decimals = req.TokenMeta.Decimals
+	}
+	if !req.SweepAll() {
+		if amount, err := parseDecimalAmount(req.AmountStr, p.Decimals); err == nil {
+			p.Amount = amount
+		}
+	}
+	setConfirmPayments(p, req)
+	return p
 }
 
 // promptBSVConfirmation handles BSV transaction confirmation prompt.
@@ -334,11 +896,55 @@ func promptBSVConfirmation(ctx context.Context, cmd *cobra.Command, req *transac
 		return false, err
 	}
 
+	// Let the user change the inputs before reviewing the send
+	if txInteractiveCoins {
+		picked, pickErr := pickBSVCoins(ctx, os.Stderr, req, details)
+		if pickErr != nil || !picked {
+			return false, pickErr
+		}
+	}
+
 	// Display transaction details with actual computed values
 	displayBSVTxDetailsEnhanced(cmd, details)
 
 	// Prompt for confirmation
-	return promptConfirmFn(), nil
+	return confirmSend(ctx, cmd, newBSVConfirmParams(details))
+}
+
+// newBSVConfirmParams collects the parameters a BSV or BTC confirmation code covers.
+func newBSVConfirmParams(details *bsvConfirmationDetails) *txConfirmParams {
+	return &txConfirmParams{
+		Chain:    details.Chain,
+		From:     details.SourceAddresses,
+		To:       details.To,
+		Amount:   new(big.Int).SetUint64(details.AmountSats),
+		Decimals: 8,
+		Fee:      strconv.FormatUint(details.FeeRate, 10),
+		Fiat:     details.Fiat,
+		Payments: confirmPayments(details.Recipients, 8),
+	}
+}
+
+// newSendConfirmParams collects the parameters of a send that skips the
+// review screen. BSV inputs and fee rate are only known once UTXOs are
+// fetched, so only the value matters here.
+func newSendConfirmParams(chainID chain.ID, req *transaction.SendRequest) *txConfirmParams {
+	if chainID == chain.ETH {
+		return newETHConfirmParams(req, txGasSpeed)
+	}
+	p := &txConfirmParams{
+		Chain:    chainID,
+		To:       req.To,
+		Decimals: 8,
+		Fiat:     req.Fiat,
+	}
+	if !req.SweepAll() {
+		if amount, err := parseDecimalAmount(req.AmountStr, p.Decimals); err == nil {
+			p.Amount = amount
+		}
+	}
+	setConfirmPayments(p, req)
+	return p
 }
 
 // prepareBSVConfirmation fetches UTXOs and calculates actual amounts for confirmation display.
@@ -360,6 +966,7 @@ func prepareBSVConfirmation(
 		Logger:      cc.Log,
 		FeeStrategy: bsv.FeeStrategy(cc.Cfg.GetBSVFeeStrategy()),
 		MinMiners:   cc.Cfg.GetBSVMinMiners(),
+		FeeTable:    bsv.NewFeeTableStore(transaction.FeeTablePath(cc.Cfg.GetHome())),
 	}
 	bsvClient := bsv.NewClient(ctx, opts)
 
@@ -367,8 +974,12 @@ func prepareBSVConfirmation(
 	feeQuote, err := bsvClient.GetFeeQuote(ctx)
 	if err != nil {
 		// Use default if fee quote fails (same as service does)
-		feeQuote = &bsv.FeeQuote{StandardRate: bsv.DefaultFeeRate}
+		feeQuote = &bsv.FeeQuote{StandardRate: bsv.DefaultFeeRate, Source: bsv.FeeSourceDefault}
+	}
+	if capErr := transaction.CheckMaxFeeRate(feeQuote, req.MaxFeeRate); capErr != nil {
+		return nil, capErr
 	}
+	warnBSVFeeFallback(feeQuote.Source, feeQuote.StandardRate, feeQuote.Age())
 
 	// Load UTXO store for spent filtering
 	walletPath := filepath.Join(cc.Cfg.GetHome(), "wallets", req.Wallet)
@@ -390,6 +1001,19 @@ func prepareBSVConfirmation(
 	if utxoStore != nil {
 		allUTXOs = transaction.FilterSpentBSVUTXOs(allUTXOs, utxoStore)
 	}
+	allUTXOs = transaction.FilterReservedUTXOs(cc.Log, walletPath, chain.BSV, allUTXOs)
+	candidates := toBSVUTXOs(allUTXOs)
+
+	// Manual coin control: spend only the chosen outpoints
+	if len(req.Outpoints) > 0 {
+		var spentStore transaction.UTXOProvider
+		if utxoStore != nil {
+			spentStore = utxoStore
+		}
+		if allUTXOs, err = transaction.RestrictToOutpoints(allUTXOs, req.Outpoints, spentStore); err != nil {
+			return nil, err
+		}
+	}
 
 	// Group UTXOs by address
 	addressUTXOs := make(map[string]int)
@@ -402,11 +1026,18 @@ func prepareBSVConfirmation(
 	}
 
 	details := &bsvConfirmationDetails{
+		Chain:           chain.BSV,
 		To:              req.To,
 		FeeRate:         feeQuote.StandardRate,
+		FeeOrigin:       transaction.DescribeFeeQuote(feeQuote),
 		IsSweep:         req.SweepAll(),
 		AddressUTXOs:    addressUTXOs,
 		SourceAddresses: sourceAddresses,
+		Fiat:            req.Fiat,
+		Candidates:      candidates,
+	}
+	if len(req.Payments) > 0 {
+		details.Recipients = req.AllPayments()
 	}
 
 	//nolint:nestif // Sweep flow with validation has nested conditional checks
@@ -424,23 +1055,36 @@ func prepareBSVConfirmation(
 			totalInputs += u.Amount
 		}
 
-		// Calculate sweep amount (total - fees)
-		sweepAmount, err := bsv.CalculateSweepAmount(totalInputs, len(allUTXOs), feeQuote.StandardRate)
-		if err != nil {
-			return nil, err
-		}
+		if len(req.Split) > 0 {
+			// Split sweep: divide the balance now, so a bad split fails before confirmation
+			paid, _, fee, err := transaction.SplitSweep(req.Split, totalInputs, len(allUTXOs), feeQuote.StandardRate)
+			if err != nil {
+				return nil, err
+			}
+			details.Recipients = paid
+			details.Shares = make([]string, len(req.Split))
+			for i, p := range req.Split {
+				details.Shares[i] = p.AmountStr
+			}
+			details.AmountSats = totalInputs - fee
+			details.EstimatedFee = fee
+		} else {
+			// Calculate sweep amount (total - fees)
+			sweepAmount, err := bsv.CalculateSweepAmount(totalInputs, len(allUTXOs), feeQuote.StandardRate)
+			if err != nil {
+				return nil, err
+			}
 
-		details.AmountSats = sweepAmount
-		details.EstimatedFee = totalInputs - sweepAmount
+			details.AmountSats = sweepAmount
+			details.EstimatedFee = totalInputs - sweepAmount
+		}
 		details.TotalUTXOs = len(allUTXOs)
+		details.Inputs = toBSVUTXOs(allUTXOs)
 	} else {
-		// Normal send: parse amount and estimate fee
-		amount, err := bsvClient.ParseAmount(req.AmountStr)
+		// Normal send: total every payment and estimate fee
+		amount, err := transaction.PaymentsTotal(bsvClient.ParseAmount, req.AllPayments())
 		if err != nil {
-			return nil, sigilerr.WithSuggestion(
-				sigilerr.ErrInvalidInput,
-				fmt.Sprintf("invalid amount: %s", req.AmountStr),
-			)
+			return nil, err
 		}
 
 		if len(allUTXOs) == 0 {
@@ -451,68 +1095,85 @@ func prepareBSVConfirmation(
 		}
 
 		// Convert to bsv.UTXO for selection
-		bsvUTXOs := make([]bsv.UTXO, len(allUTXOs))
-		for i, u := range allUTXOs {
-			bsvUTXOs[i] = bsv.UTXO{
-				TxID:          u.TxID,
-				Vout:          u.Vout,
-				Amount:        u.Amount,
-				ScriptPubKey:  u.ScriptPubKey,
-				Address:       u.Address,
-				Confirmations: u.Confirmations,
-			}
-		}
+		bsvUTXOs := toBSVUTXOs(allUTXOs)
 
 		// Select UTXOs needed for this transaction
-		selected, _, err := bsvClient.SelectUTXOs(bsvUTXOs, amount.Uint64(), feeQuote.StandardRate)
+		recipients := 1 + len(req.Payments)
+		sel, err := bsvClient.SelectCoins(bsv.CoinSelection(req.CoinSelection), bsvUTXOs, amount.Uint64(), feeQuote.StandardRate, recipients)
 		if err != nil {
-			return nil, err
-		}
-
-		// Update source addresses to only include those with selected UTXOs
-		selectedAddresses := make(map[string]int)
-		var selectedSourceAddrs []string
-		for _, u := range selected {
-			if _, exists := selectedAddresses[u.Address]; !exists {
-				selectedSourceAddrs = append(selectedSourceAddrs, u.Address)
+			if len(req.Outpoints) > 0 && errors.Is(err, bsv.ErrInsufficientFunds) {
+				return nil, transaction.InsufficientOutpoints(err, len(bsvUTXOs))
 			}
-			selectedAddresses[u.Address]++
+			return nil, err
 		}
 
 		details.AmountSats = amount.Uint64()
-		details.EstimatedFee = bsv.EstimateFeeForTx(len(selected), 2, feeQuote.StandardRate)
-		details.TotalUTXOs = len(selected)
-		details.AddressUTXOs = selectedAddresses
-		details.SourceAddresses = selectedSourceAddrs
+		details.EstimatedFee = sel.Fee(amount.Uint64())
+		details.CoinSelection = string(sel.Strategy)
+		// Source addresses only include those with selected UTXOs
+		details.setInputs(sel.UTXOs)
 	}
 
 	return details, nil
 }
 
+// setInputs records inputs as the UTXOs being spent, with their source
+// addresses in order.
+func (d *bsvConfirmationDetails) setInputs(inputs []bsv.UTXO) {
+	d.Inputs = inputs
+	d.TotalUTXOs = len(inputs)
+	d.AddressUTXOs = make(map[string]int)
+	d.SourceAddresses = nil
+	for _, u := range inputs {
+		if _, exists := d.AddressUTXOs[u.Address]; !exists {
+			d.SourceAddresses = append(d.SourceAddresses, u.Address)
+		}
+		d.AddressUTXOs[u.Address]++
+	}
+}
+
+// toBSVUTXOs converts chain UTXOs to bsv.UTXO for coin selection.
+func toBSVUTXOs(utxos []chain.UTXO) []bsv.UTXO {
+	bsvUTXOs := make([]bsv.UTXO, len(utxos))
+	for i, u := range utxos {
+		bsvUTXOs[i] = bsv.UTXO{
+			TxID:          u.TxID,
+			Vout:          u.Vout,
+			Amount:        u.Amount,
+			ScriptPubKey:  u.ScriptPubKey,
+			Address:       u.Address,
+			Confirmations: u.Confirmations,
+		}
+	}
+	return bsvUTXOs
+}
+
 // convertToETHTransactionResult converts service result to chain.TransactionResult for display.
 func convertToETHTransactionResult(result *transaction.SendResult) *chain.TransactionResult {
 	return &chain.TransactionResult{
-		Hash:     result.Hash,
-		From:     result.From,
-		To:       result.To,
-		Amount:   result.Amount,
-		Fee:      result.Fee,
-		Token:    result.Token,
-		Status:   result.Status,
-		GasUsed:  result.GasUsed,
-		GasPrice: result.GasPrice,
+		Hash:      result.Hash,
+		From:      eth.ToChecksumAddress(result.From),
+		To:        eth.ToChecksumAddress(result.To),
+		Amount:    result.Amount,
+		Delivered: result.Delivered,
+		Fee:       result.Fee,
+		Token:     result.Token,
+		Status:    result.Status,
+		GasUsed:   result.GasUsed,
+		GasPrice:  result.GasPrice,
 	}
 }
 
 // convertToBSVTransactionResult converts service result to chain.TransactionResult for display.
 func convertToBSVTransactionResult(result *transaction.SendResult) *chain.TransactionResult {
 	return &chain.TransactionResult{
-		Hash:   result.Hash,
-		From:   result.From,
-		To:     result.To,
-		Amount: result.Amount,
-		Fee:    result.Fee,
-		Status: result.Status,
+		Hash:          result.Hash,
+		From:          result.From,
+		To:            result.To,
+		Amount:        result.Amount,
+		Fee:           result.Fee,
+		Status:        result.Status,
+		CoinSelection: result.CoinSelection,
 	}
 }
 
@@ -551,9 +1212,10 @@ func displayBSVTxDetails(cmd *cobra.Command, from, to, amount string, fee, feeRa
 	outln(w, "═══════════════════════════════════════════════════════════════")
 }
 
-// displayBSVTxDetailsEnhanced shows BSV transaction details with computed values.
+// displayBSVTxDetailsEnhanced shows BSV or BTC transaction details with computed values.
 func displayBSVTxDetailsEnhanced(cmd *cobra.Command, details *bsvConfirmationDetails) {
 	w := cmd.OutOrStdout()
+	symbol := getChainSymbol(details.Chain)
 	outln(w)
 	outln(w, "═══════════════════════════════════════════════════════════════")
 	outln(w, "                    TRANSACTION DETAILS")
@@ -573,14 +1235,31 @@ func displayBSVTxDetailsEnhanced(cmd *cobra.Command, details *bsvConfirmationDet
 		}
 	}
 
-	out(w, "  To:        %s\n", details.To)
+	if len(details.Recipients) > 0 {
+		out(w, "  To:        %d recipients:\n", len(details.Recipients))
+		for i, p := range details.Recipients {
+			if i < len(details.Shares) {
+				out(w, "             • %s  %s %s (%s)\n", p.To, p.AmountStr, symbol, details.Shares[i])
+				continue
+			}
+			out(w, "             • %s  %s %s\n", p.To, p.AmountStr, symbol)
+		}
+	} else {
+		out(w, "  To:        %s\n", details.To)
+	}
 
 	// Amount with sweep indicator
-	if details.IsSweep {
-		out(w, "  Amount:    %s sats (sweep all) BSV\n", formatSatsWithCommas(details.AmountSats))
-	} else {
-		out(w, "  Amount:    %s sats BSV\n", formatSatsWithCommas(details.AmountSats))
+	switch {
+	case len(details.Shares) > 0:
+		out(w, "  Amount:    %s sats (split sweep) %s\n", formatSatsWithCommas(details.AmountSats), symbol)
+	case details.IsSweep:
+		out(w, "  Amount:    %s sats (sweep all) %s\n", formatSatsWithCommas(details.AmountSats), symbol)
+	default:
+		out(w, "  Amount:    %s sats %s\n", formatSatsWithCommas(details.AmountSats), symbol)
 	}
+	displayFiatConversionText(w, details.Fiat, 10)
+	amount := chain.FormatDecimalAmount(new(big.Int).SetUint64(details.AmountSats), 8)
+	displayFiatValueText(w, txFiatValue(txFiatQuote, amount, details.Fiat), 10)
 
 	// UTXO count
 	if len(details.SourceAddresses) > 1 {
@@ -590,42 +1269,253 @@ func displayBSVTxDetailsEnhanced(cmd *cobra.Command, details *bsvConfirmationDet
 		// For single address, use "UTXOs:" label
 		out(w, "  UTXOs:     %d\n", details.TotalUTXOs)
 	}
+	if details.CoinSelection != "" {
+		out(w, "  Selection: %s\n", details.CoinSelection)
+	}
 
 	// Fee details
-	out(w, "  Fee Rate:  %d sat/KB\n", details.FeeRate)
+	if details.FeeOrigin != "" {
+		out(w, "  Fee Rate:  %d sat/KB (%s)\n", details.FeeRate, details.FeeOrigin)
+	} else {
+		out(w, "  Fee Rate:  %d sat/KB\n", details.FeeRate)
+	}
 	out(w, "  Est. Fee:  %s satoshis\n", formatSatsWithCommas(details.EstimatedFee))
 
 	outln(w)
 	outln(w, "═══════════════════════════════════════════════════════════════")
 }
 
+// resolveTxUTXOs checks the --utxo outpoints and normalizes txUTXOs in
+// place. Coin control is only supported for BSV.
+func resolveTxUTXOs(chainID chain.ID) error {
+	if len(txUTXOs) == 0 {
+		return nil
+	}
+	if chainID != chain.BSV {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--utxo is only supported for BSV chain",
+		)
+	}
+	for i, op := range txUTXOs {
+		key, err := transaction.ParseOutpoint(op)
+		if err != nil {
+			return err
+		}
+		txUTXOs[i] = key
+	}
+	return nil
+}
+
+// resolveTxEnvelope checks and normalizes --envelope in place. Envelopes
+// hold addresses of the UTXO chains only.
+func resolveTxEnvelope(chainID chain.ID) error {
+	if txEnvelope == "" {
+		return nil
+	}
+	if !slices.Contains(receiveOnlyChains, chainID) {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--envelope is only supported for BSV, BTC, and BCH",
+		)
+	}
+	envelope, err := normalizeEnvelopeArg(txEnvelope)
+	if err != nil {
+		return err
+	}
+	txEnvelope = envelope
+	return nil
+}
+
+// assignChangeToEnvelope adds the change address of each sent transaction
+// to --envelope, so the change stays in the envelope it was spent from.
+// Failures are logged; the send already succeeded.
+func assignChangeToEnvelope(cc *CommandContext, storage *wallet.FileStorage, chainID chain.ID, results ...*transaction.SendResult) {
+	if txEnvelope == "" {
+		return
+	}
+	var changeAddrs []string
+	for _, r := range results {
+		if r != nil && r.Changes != nil && r.Changes.ChangeAddress != "" {
+			changeAddrs = append(changeAddrs, r.Changes.ChangeAddress)
+		}
+	}
+	if len(changeAddrs) == 0 {
+		return
+	}
+
+	logErr := func(format string, args ...any) {
+		if cc.Log != nil {
+			cc.Log.Error(format, args...)
+		}
+	}
+	wlt, err := storage.LoadMetadata(txWallet)
+	if err == nil {
+		wlt, err = selectAccount(wlt, txAccount)
+	}
+	if err != nil {
+		logErr("envelope: loading wallet for change address: %v", err)
+		return
+	}
+	store := utxostore.New(filepath.Join(cc.Cfg.GetHome(), "wallets", txWallet))
+	if err := store.Load(); err != nil {
+		logErr("envelope: loading utxo store: %v", err)
+		return
+	}
+	for _, addr := range changeAddrs {
+		if _, found := registerOwnedAddress(store, wlt, addr); !found {
+			logErr("envelope: change address %s not found in wallet", addr)
+			continue
+		}
+		if _, err := store.SetAddressEnvelope(chainID, addr, txEnvelope); err != nil {
+			logErr("envelope: assigning change address %s: %v", addr, err)
+		}
+	}
+	if err := store.Save(); err != nil {
+		logErr("envelope: saving utxo store: %v", err)
+	}
+}
+
+// resolveTxQueue returns how long a send that cannot reach any broadcast
+// provider is queued in the outbox, or zero when it fails instead. --queue,
+// when set, overrides outbox.enabled; ETH sends cannot be queued.
+func resolveTxQueue(cfg ConfigProvider, chainID chain.ID, set bool) (time.Duration, error) {
+	settings := cfg.GetOutbox()
+	queue := settings.Enabled
+	if set {
+		queue = txQueue
+	}
+	if !queue {
+		return 0, nil
+	}
+	if chainID == chain.ETH {
+		if !set {
+			return 0, nil
+		}
+		return 0, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--queue is only supported for BSV, BTC, and BCH; an ETH send holds a nonce and cannot wait in the outbox",
+		)
+	}
+	if settings.ExpiryHours <= 0 {
+		return 0, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"queueing sends needs outbox.expiry_hours of at least 1; set it with: sigil config set outbox.expiry_hours 24",
+		)
+	}
+	return time.Duration(settings.ExpiryHours) * time.Hour, nil
+}
+
+// resolveTxNonce returns the --nonce override, or nil when set is false.
+// Only a single ETH transaction can take an explicit nonce.
+func resolveTxNonce(chainID chain.ID, set bool) (*uint64, error) {
+	if !set {
+		return nil, nil //nolint:nilnil // No override is not an error
+	}
+	if chainID != chain.ETH {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--nonce is only supported for ETH chain",
+		)
+	}
+	if len(txPayments) > 0 {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--nonce applies to a single transaction; send to one recipient",
+		)
+	}
+	nonce := txNonce
+	return &nonce, nil
+}
+
+// displayNonceOverride notes that the send uses an explicit nonce, which
+// replaces any pending transaction with that nonce.
+func displayNonceOverride(w io.Writer, nonce *uint64) {
+	if nonce == nil {
+		return
+	}
+	out(w, "\nUsing nonce %d from --nonce. A pending transaction with this nonce is\n", *nonce)
+	outln(w, "replaced if this one pays at least 10% more gas; otherwise the node rejects it.")
+}
+
+// displayInternalTransferBanner shows a banner above the confirmation
+// screen when every recipient of req is an address of wlt.
+func displayInternalTransferBanner(w io.Writer, wlt *wallet.Wallet, req *transaction.SendRequest) {
+	if !transaction.IsInternalTransfer(wlt, req) {
+		return
+	}
+	outln(w, "=== INTERNAL TRANSFER ===")
+	out(w, "Every recipient is an address of wallet '%s'. Only the fee leaves the\n", wlt.Name)
+	outln(w, "wallet; the journal records this send as internal, not as an expense.")
+	outln(w)
+}
+
+// resolveCoinSelection returns the --coin-selection strategy, falling back
+// to fees.bsv_coin_selection.
+func resolveCoinSelection(cfg ConfigProvider) (bsv.CoinSelection, error) {
+	name := txCoinSelection
+	if name == "" {
+		name = cfg.GetBSVCoinSelection()
+	}
+	strategy, err := bsv.ParseCoinSelection(name)
+	if err != nil {
+		return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+	return strategy, nil
+}
+
+// warnBSVFeeFallback warns when a BSV fee rate did not come from a live fee
+// provider, so the user knows the fee may be out of date.
+func warnBSVFeeFallback(source string, rate uint64, age time.Duration) {
+	switch source {
+	case bsv.FeeSourceCached:
+		output.Warnf("fee providers unreachable; using last-known-good fee rate %d sat/KB from %s ago (use --max-fee-rate to cap it)",
+			rate, age.Round(time.Minute))
+	case bsv.FeeSourceDefault:
+		output.Warnf("fee providers unreachable and no saved fee table; using default fee rate %d sat/KB (use --max-fee-rate to cap it)", rate)
+	}
+}
+
 // displayBSVTxResult shows the BSV transaction result.
-func displayBSVTxResult(cmd *cobra.Command, result *chain.TransactionResult, network string) {
+func displayBSVTxResult(cmd *cobra.Command, result *chain.TransactionResult, network string, changes *transaction.SendChanges, fiat *transaction.FiatConversion) {
 	cc := GetCmdContext(cmd)
 	w := cmd.OutOrStdout()
 	format := cc.Fmt.Format()
 
 	if format == output.FormatJSON {
-		displayBSVTxResultJSON(w, result)
-	} else {
-		displayBSVTxResultText(w, result, network)
+		displayBSVTxResultJSON(w, result, explorerTxLink(chain.BSV, network, result.Hash), changes, fiat)
+		return
 	}
+
+	// The coin selection strategy is only shown with --verbose
+	if result.CoinSelection != "" && !cc.Cfg.IsVerbose() {
+		shown := *result
+		shown.CoinSelection = ""
+		result = &shown
+	}
+	displayBSVTxResultText(w, result, network, changes, fiat)
 }
 
 // displayBSVTxResultText shows BSV transaction result in text format.
 func displayBSVTxResultText(w interface {
 	Write(p []byte) (n int, err error)
-}, result *chain.TransactionResult, network string,
+}, result *chain.TransactionResult, network string, changes *transaction.SendChanges, fiat *transaction.FiatConversion,
 ) {
 	outln(w, "\nTransaction broadcast successfully!")
 	outln(w)
 	out(w, "  Hash:   %s\n", result.Hash)
 	out(w, "  Status: %s\n", result.Status)
 	out(w, "  Amount: %s BSV\n", result.Amount)
+	displayFiatConversionText(w, fiat, 7)
+	displayFiatValueText(w, txFiatValue(txFiatQuote, result.Amount, fiat), 7)
 	out(w, "  Fee:    %s BSV\n", result.Fee)
+	if result.CoinSelection != "" {
+		out(w, "  Coins:  %s selection\n", result.CoinSelection)
+	}
+	displaySendChangesText(w, changes)
 	outln(w)
 	outln(w, "Track your transaction:")
-	for _, link := range bsvExplorerTxLinks(network, result.Hash) {
+	for _, link := range explorerTxLinks(chain.BSV, network, result.Hash) {
 		out(w, "  %s\n", link)
 	}
 }
@@ -633,38 +1523,46 @@ func displayBSVTxResultText(w interface {
 // displayBSVTxResultJSON shows BSV transaction result in JSON format.
 func displayBSVTxResultJSON(w interface {
 	Write(p []byte) (n int, err error)
-}, result *chain.TransactionResult,
+}, result *chain.TransactionResult, explorerURL string, changes *transaction.SendChanges, fiat *transaction.FiatConversion,
 ) {
 	payload := struct {
-		Hash   string `json:"hash"`
-		From   string `json:"from"`
-		To     string `json:"to"`
-		Amount string `json:"amount"`
-		Fee    string `json:"fee"`
-		Status string `json:"status"`
+		Hash          string              `json:"hash"`
+		From          string              `json:"from"`
+		To            string              `json:"to"`
+		Amount        string              `json:"amount"`
+		Fiat          *fiatConversionJSON `json:"fiat,omitempty"`
+		FiatValue     *fiatConversionJSON `json:"fiat_value,omitempty"`
+		Fee           string              `json:"fee"`
+		Status        string              `json:"status"`
+		CoinSelection string              `json:"coin_selection,omitempty"`
+		ExplorerURL   string              `json:"explorer_url,omitempty"`
+		Changes       *sendChangesJSON    `json:"changes,omitempty"`
 	}{
-		Hash:   result.Hash,
-		From:   result.From,
-		To:     result.To,
-		Amount: result.Amount,
-		Fee:    result.Fee,
-		Status: result.Status,
+		Hash:          result.Hash,
+		From:          result.From,
+		To:            result.To,
+		Amount:        result.Amount,
+		Fiat:          newFiatConversionJSON(fiat),
+		FiatValue:     newFiatConversionJSON(txFiatValue(txFiatQuote, result.Amount, fiat)),
+		Fee:           result.Fee,
+		Status:        result.Status,
+		CoinSelection: result.CoinSelection,
+		ExplorerURL:   explorerURL,
+		Changes:       newSendChangesJSON(changes),
 	}
 
 	_ = writeJSON(w, payload)
 }
 
-// resolveToken resolves a token symbol to its contract address and decimals.
+// resolveToken resolves a built-in token symbol to its contract address and decimals.
 func resolveToken(symbol string) (address string, decimals int, err error) {
-	switch strings.ToUpper(symbol) {
-	case "USDC":
-		return eth.USDCMainnet, eth.USDCDecimals, nil
-	default:
-		return "", 0, sigilerr.WithSuggestion(
-			sigilerr.ErrInvalidInput,
-			fmt.Sprintf("unsupported token: %s (only USDC is supported)", symbol),
-		)
+	if token, ok := eth.LookupKnownToken(symbol); ok {
+		return token.Address, token.Decimals, nil
 	}
+	return "", 0, sigilerr.WithSuggestion(
+		sigilerr.ErrInvalidInput,
+		fmt.Sprintf("unsupported token: %s (built-in tokens are %s; see sigil tokens list)", symbol, strings.Join(eth.KnownTokenSymbols(), ", ")),
+	)
 }
 
 // amountAll is the special value for sending the entire balance.
@@ -718,7 +1616,7 @@ func parseDecimalAmount(amount string, decimals int) (*big.Int, error) {
 }
 
 // displayTxDetails shows transaction details before confirmation.
-func displayTxDetails(cmd *cobra.Command, from, to, amount, token string, estimate *eth.GasEstimate) {
+func displayTxDetails(cmd *cobra.Command, from, to, amount, token string, estimate *eth.GasEstimate, fiat *transaction.FiatConversion) {
 	w := cmd.OutOrStdout()
 	outln(w)
 	outln(w, "═══════════════════════════════════════════════════════════════")
@@ -726,14 +1624,16 @@ func displayTxDetails(cmd *cobra.Command, from, to, amount, token string, estima
 	outln(w, "═══════════════════════════════════════════════════════════════")
 	outln(w)
 
-	out(w, "  From:      %s\n", from)
-	out(w, "  To:        %s\n", to)
+	out(w, "  From:      %s\n", eth.ToChecksumAddress(from))
+	out(w, "  To:        %s\n", eth.ToChecksumAddress(to))
 
 	if token != "" {
 		out(w, "  Amount:    %s %s\n", amount, token)
 	} else {
 		out(w, "  Amount:    %s ETH\n", amount)
+		displayFiatValueText(w, txFiatValue(txFiatQuote, amount, fiat), 10)
 	}
+	displayFiatConversionText(w, fiat, 10)
 
 	out(w, "  Gas Limit: %d\n", estimate.GasLimit)
 	out(w, "  Gas Price: %s\n", eth.FormatGasPrice(estimate.GasPrice))
@@ -743,21 +1643,38 @@ func displayTxDetails(cmd *cobra.Command, from, to, amount, token string, estima
 	outln(w, "═══════════════════════════════════════════════════════════════")
 }
 
+// displayTokenAmountWarning warns that a fee-on-transfer or rebasing token
+// may deliver a different amount than is sent.
+func displayTokenAmountWarning(w io.Writer, token *eth.TokenMetadata) {
+	if token == nil || !token.AmountMayDiffer() {
+		return
+	}
+
+	kind := "rebasing"
+	if token.FeeOnTransfer {
+		kind = "fee-on-transfer"
+	}
+	out(w, "  WARNING: %s is a %s token; the recipient may receive a different\n", token.Symbol, kind)
+	outln(w, "  amount than shown. The delivered amount is read from the receipt.")
+	outln(w)
+}
+
 // displayTxResult shows the transaction result.
-func displayTxResult(cmd *cobra.Command, result *chain.TransactionResult) {
+func displayTxResult(cmd *cobra.Command, result *chain.TransactionResult, changes *transaction.SendChanges, fiat *transaction.FiatConversion) {
 	cc := GetCmdContext(cmd)
 	w := cmd.OutOrStdout()
 	format := cc.Fmt.Format()
 
 	if format == output.FormatJSON {
-		displayTxResultJSON(w, result)
+		displayTxResultJSON(w, result, explorerTxLink(chain.ETH, ethNetwork(cc.Cfg), result.Hash), changes, fiat)
 	} else {
-		displayTxResultText(w, result)
+		displayTxResultText(w, ethNetwork(cc.Cfg), result, changes, fiat)
 	}
 }
 
-// displayTxResultText shows transaction result in text format.
-func displayTxResultText(w io.Writer, result *chain.TransactionResult) {
+// displayTxResultText shows transaction result in text format, with an
+// explorer link for the network the transaction was sent on.
+func displayTxResultText(w io.Writer, network string, result *chain.TransactionResult, changes *transaction.SendChanges, fiat *transaction.FiatConversion) {
 	outln(w, "\nTransaction broadcast successfully!")
 	outln(w)
 	out(w, "  Hash:   %s\n", result.Hash)
@@ -768,35 +1685,54 @@ func displayTxResultText(w io.Writer, result *chain.TransactionResult) {
 	} else {
 		out(w, "  Amount: %s ETH\n", result.Amount)
 	}
+	if result.Delivered != "" {
+		out(w, "  Received: %s %s\n", result.Delivered, result.Token)
+	}
+	displayFiatConversionText(w, fiat, 7)
+	displayFiatValueText(w, txFiatValue(txFiatQuote, result.Amount, fiat), 7)
 
-	out(w, "  Fee:    %s\n", result.Fee)
+	out(w, "  Fee:    %s (estimated)\n", result.Fee)
+	displaySendChangesText(w, changes)
+	outln(w)
+	out(w, "Track your transaction on %s:\n", explorerTitle(chain.ETH, network))
+	out(w, "  %s\n", explorerTxLink(chain.ETH, network, result.Hash))
 	outln(w)
-	outln(w, "Track your transaction on Etherscan:")
-	out(w, "  https://etherscan.io/tx/%s\n", result.Hash)
+	outln(w, "The final fee is known once the transaction is mined:")
+	out(w, "  sigil tx status %s --wait\n", result.Hash)
 }
 
 // displayTxResultJSON shows transaction result in JSON format.
-func displayTxResultJSON(w io.Writer, result *chain.TransactionResult) {
+func displayTxResultJSON(w io.Writer, result *chain.TransactionResult, explorerURL string, changes *transaction.SendChanges, fiat *transaction.FiatConversion) {
 	payload := struct {
-		Hash     string `json:"hash"`
-		From     string `json:"from"`
-		To       string `json:"to"`
-		Amount   string `json:"amount"`
-		Token    string `json:"token,omitempty"`
-		Fee      string `json:"fee"`
-		GasUsed  uint64 `json:"gas_used"`
-		GasPrice string `json:"gas_price"`
-		Status   string `json:"status"`
+		Hash        string              `json:"hash"`
+		From        string              `json:"from"`
+		To          string              `json:"to"`
+		Amount      string              `json:"amount"`
+		Token       string              `json:"token,omitempty"`
+		Delivered   string              `json:"delivered,omitempty"`
+		Fiat        *fiatConversionJSON `json:"fiat,omitempty"`
+		FiatValue   *fiatConversionJSON `json:"fiat_value,omitempty"`
+		Fee         string              `json:"fee"`
+		GasUsed     uint64              `json:"gas_used"`
+		GasPrice    string              `json:"gas_price"`
+		Status      string              `json:"status"`
+		ExplorerURL string              `json:"explorer_url,omitempty"`
+		Changes     *sendChangesJSON    `json:"changes,omitempty"`
 	}{
-		Hash:     result.Hash,
-		From:     result.From,
-		To:       result.To,
-		Amount:   result.Amount,
-		Token:    result.Token,
-		Fee:      result.Fee,
-		GasUsed:  result.GasUsed,
-		GasPrice: result.GasPrice,
-		Status:   result.Status,
+		Hash:        result.Hash,
+		From:        result.From,
+		To:          result.To,
+		Amount:      result.Amount,
+		Token:       result.Token,
+		Delivered:   result.Delivered,
+		Fiat:        newFiatConversionJSON(fiat),
+		FiatValue:   newFiatConversionJSON(txFiatValue(txFiatQuote, result.Amount, fiat)),
+		Fee:         result.Fee,
+		GasUsed:     result.GasUsed,
+		GasPrice:    result.GasPrice,
+		Status:      result.Status,
+		ExplorerURL: explorerURL,
+		Changes:     newSendChangesJSON(changes),
 	}
 
 	_ = writeJSON(w, payload)
@@ -808,8 +1744,7 @@ func displayTxResultJSON(w io.Writer, result *chain.TransactionResult) {
 // deleted, forcing the next balance query to fetch from the network.
 // Errors are logged but never returned — cache invalidation is best-effort.
 func invalidateBalanceCache(cc *CommandContext, chainID chain.ID, address, token, expectedBalance string) {
-	cachePath := filepath.Join(cc.Cfg.GetHome(), "cache", "balances.json")
-	cacheStorage := cache.NewFileStorage(cachePath)
+	cacheStorage := cache.NewBalanceStorage(cc.Cfg.GetHome(), cc.Cfg.GetCache())
 
 	balanceCache, err := cacheStorage.Load()
 	if err != nil {
@@ -866,10 +1801,10 @@ var errAddressNotInWallet = errors.New("address not found in wallet")
 // deriveKeysForUTXOs derives private keys for each unique address that appears in the UTXO set.
 // Returns a map of address → private key. The caller must zero all keys after use.
 func deriveKeysForUTXOs(utxos []chain.UTXO, addresses []wallet.Address, seed []byte) (map[string][]byte, error) {
-	// Build address → index lookup
-	addrIndex := make(map[string]uint32, len(addresses))
+	// Build address lookup
+	byAddr := make(map[string]wallet.Address, len(addresses))
 	for _, addr := range addresses {
-		addrIndex[addr.Address] = addr.Index
+		byAddr[addr.Address] = addr
 	}
 
 	// Collect unique addresses from UTXOs
@@ -878,7 +1813,7 @@ func deriveKeysForUTXOs(utxos []chain.UTXO, addresses []wallet.Address, seed []b
 	// Derive private key for each unique address
 	keys := make(map[string][]byte, len(needed))
 	for addr := range needed {
-		key, err := deriveKeyForAddress(addr, addrIndex, seed)
+		key, err := deriveKeyForAddress(addr, byAddr, seed)
 		if err != nil {
 			zeroKeyMap(keys)
 			return nil, err
@@ -889,15 +1824,16 @@ func deriveKeysForUTXOs(utxos []chain.UTXO, addresses []wallet.Address, seed []b
 	return keys, nil
 }
 
-// deriveKeyForAddress derives a private key for a single address using the index lookup.
-func deriveKeyForAddress(addr string, addrIndex map[string]uint32, seed []byte) ([]byte, error) {
-	index, ok := addrIndex[addr]
+// deriveKeyForAddress derives a private key for a single address using the
+// address lookup, under the BIP44 account the address was derived in.
+func deriveKeyForAddress(addr string, byAddr map[string]wallet.Address, seed []byte) ([]byte, error) {
+	stored, ok := byAddr[addr]
 	if !ok {
 		return nil, fmt.Errorf("%w: %s", errAddressNotInWallet, addr)
 	}
-	privKey, err := wallet.DerivePrivateKeyForChain(seed, wallet.ChainBSV, index)
+	privKey, err := wallet.DerivePrivateKey(seed, wallet.ChainBSV, stored.Account, stored.Index)
 	if err != nil {
-		return nil, fmt.Errorf("deriving key for address %s (index %d): %w", addr, index, err)
+		return nil, fmt.Errorf("deriving key for address %s (index %d): %w", addr, stored.Index, err)
 	}
 	return privKey, nil
 }
@@ -0,0 +1,302 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/price"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// resolveTxPayments pairs the --to and --amount flags in order, appends the
+// recipients in --payments-file, and sets txTo and txAmount to the first
+// recipient and txPayments to the rest. A --to of the form address=share
+// makes the send a split sweep (see resolveTxSplit).
+//
+//nolint:gocognit // Each recipient source and batch restriction is checked in turn
+func resolveTxPayments(cmd *cobra.Command, chainID chain.ID) error {
+	for _, to := range txToList {
+		if strings.Contains(to, "=") {
+			return resolveTxSplit(chainID)
+		}
+	}
+
+	if len(txToList) != len(txAmountList) {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("--to and --amount must be given in pairs (got %d --to and %d --amount)", len(txToList), len(txAmountList)),
+		)
+	}
+
+	payments := make([]transaction.Payment, 0, len(txToList))
+	for i := range txToList {
+		payments = append(payments, transaction.Payment{To: strings.TrimSpace(txToList[i]), AmountStr: txAmountList[i]})
+	}
+	if txPaymentsFile != "" {
+		data, err := readOfflineInput(cmd, txPaymentsFile)
+		if err != nil {
+			return err
+		}
+		filePayments, err := parsePaymentsFile(data)
+		if err != nil {
+			return err
+		}
+		payments = append(payments, filePayments...)
+	}
+	if len(payments) == 0 {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--to and --amount are required (or list recipients with --payments-file)",
+		)
+	}
+
+	txTo, txAmount = payments[0].To, payments[0].AmountStr
+	txPayments, txSplit = payments[1:], nil
+	if len(txPayments) == 0 {
+		return nil
+	}
+
+	if chainID != chain.BSV && chainID != chain.ETH {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("multi-recipient sends are only supported for BSV and ETH, not %s", chainID),
+		)
+	}
+	for _, p := range payments {
+		if isAmountAll(p.AmountStr) {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				"'all' cannot be used in a multi-recipient send; give each recipient an amount",
+			)
+		}
+		if _, isFiat, _ := price.ParseFiatAmount(p.AmountStr); isFiat {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				"USD amounts are not supported in a multi-recipient send; give each amount in coin",
+			)
+		}
+	}
+
+	// ETH recipients must be EIP-55 checksummed; txTo is checked by the caller
+	if chainID == chain.ETH {
+		for i := range txPayments {
+			to, err := transaction.NormalizeETHRecipient(txPayments[i].To, txNoChecksum)
+			if err != nil {
+				return err
+			}
+			txPayments[i].To = to
+		}
+	}
+	return nil
+}
+
+// resolveTxSplit reads a split sweep: a single --amount all and a
+// --to address=share for every recipient. It sets txTo to the first
+// recipient, txAmount to "all", and txSplit to every recipient with its
+// share.
+func resolveTxSplit(chainID chain.ID) error {
+	var reason string
+	switch {
+	case chainID != chain.BSV:
+		reason = fmt.Sprintf("split sweeps (--to address=share) are only supported for BSV, not %s", chainID)
+	case len(txAmountList) != 1 || !isAmountAll(txAmountList[0]):
+		reason = "a split sweep takes a single --amount all; give each recipient its share with --to address=share"
+	case txPaymentsFile != "":
+		reason = "--payments-file cannot be used in a split sweep; give each recipient with --to address=share"
+	case len(txToList) < 2:
+		reason = "a split sweep needs at least two --to address=share recipients; use --to address --amount all to sweep to one"
+	}
+	if reason != "" {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, reason)
+	}
+
+	split := make([]transaction.Payment, 0, len(txToList))
+	for _, to := range txToList {
+		addr, share, _ := strings.Cut(to, "=")
+		addr, share = strings.TrimSpace(addr), strings.TrimSpace(share)
+		if addr == "" || share == "" {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("invalid --to %q: every recipient of a split sweep needs a share, like --to address=50%%", to),
+			)
+		}
+		split = append(split, transaction.Payment{To: addr, AmountStr: share})
+	}
+	if _, err := transaction.ParseSweepShares(split); err != nil {
+		return err
+	}
+
+	txTo, txAmount, txPayments, txSplit = split[0].To, txAmountList[0], nil, split
+	return nil
+}
+
+// parsePaymentsFile reads "address,amount" lines. Blank lines and lines
+// starting with # are skipped.
+func parsePaymentsFile(data []byte) ([]transaction.Payment, error) {
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comment = '#'
+	r.FieldsPerRecord = 2
+	r.TrimLeadingSpace = true
+
+	var payments []transaction.Payment
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("invalid payments file: %v (expected \"address,amount\" lines)", err),
+			)
+		}
+		payments = append(payments, transaction.Payment{
+			To:        strings.TrimSpace(record[0]),
+			AmountStr: strings.TrimSpace(record[1]),
+		})
+	}
+	if len(payments) == 0 {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "payments file lists no recipients")
+	}
+	return payments, nil
+}
+
+// promptETHBatchConfirmation shows every recipient of an ETH batch with the
+// fee estimate for all of its transactions and asks the user to confirm.
+func promptETHBatchConfirmation(ctx context.Context, cmd *cobra.Command, req *transaction.SendRequest) (bool, error) {
+	estimate, tokenSymbol, err := estimateETHConfirmFee(ctx, cmd, req)
+	if err != nil {
+		return false, err
+	}
+	if tokenSymbol == "" {
+		tokenSymbol = "ETH"
+	}
+
+	payments := req.AllPayments()
+	w := cmd.OutOrStdout()
+	outln(w)
+	outln(w, "═══════════════════════════════════════════════════════════════")
+	outln(w, "                    TRANSACTION DETAILS")
+	outln(w, "═══════════════════════════════════════════════════════════════")
+	outln(w)
+	out(w, "  From:      %s\n", eth.ToChecksumAddress(req.FromAddress))
+	out(w, "  To:        %d recipients, one transaction each:\n", len(payments))
+	for _, p := range payments {
+		out(w, "             • %s  %s %s\n", eth.ToChecksumAddress(p.To), p.AmountStr, tokenSymbol)
+	}
+	out(w, "  Gas Limit: %d per transaction\n", estimate.GasLimit)
+	out(w, "  Gas Price: %s\n", eth.FormatGasPrice(estimate.GasPrice))
+	totalFee := new(big.Int).Mul(estimate.Total, big.NewInt(int64(len(payments))))
+	out(w, "  Est. Fee:  %s ETH\n", chain.FormatDecimalAmount(totalFee, 18))
+	outln(w)
+	outln(w, "═══════════════════════════════════════════════════════════════")
+	displayTokenAmountWarning(w, req.TokenMeta)
+
+	return confirmSend(ctx, cmd, newETHConfirmParams(req, txGasSpeed))
+}
+
+// batchPaymentJSON is one recipient in the JSON output of a batch send.
+type batchPaymentJSON struct {
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+}
+
+// batchTxJSON is one transaction in the JSON output of a batch send.
+type batchTxJSON struct {
+	Hash     string             `json:"hash"`
+	Status   string             `json:"status"`
+	Fee      string             `json:"fee"`
+	Payments []batchPaymentJSON `json:"payments"`
+	Changes  *sendChangesJSON   `json:"changes,omitempty"`
+}
+
+// batchTransactions pairs each result of a batch send with the recipients it
+// paid: a BSV batch is one transaction paying everyone, an ETH batch is one
+// transaction per recipient. A split sweep reports what each recipient got.
+func batchTransactions(req *transaction.SendRequest, results []*transaction.SendResult) []batchTxJSON {
+	txs := make([]batchTxJSON, 0, len(results))
+	for _, r := range results {
+		tx := batchTxJSON{Hash: r.Hash, Status: r.Status, Fee: r.Fee, Changes: newSendChangesJSON(r.Changes)}
+		switch {
+		case len(r.Payments) > 0:
+			for _, p := range r.Payments {
+				tx.Payments = append(tx.Payments, batchPaymentJSON{To: p.To, Amount: p.AmountStr})
+			}
+		case req.ChainID == chain.BSV:
+			for _, p := range req.AllPayments() {
+				tx.Payments = append(tx.Payments, batchPaymentJSON{To: p.To, Amount: p.AmountStr})
+			}
+		default:
+			tx.Payments = []batchPaymentJSON{{To: r.To, Amount: r.Amount}}
+		}
+		txs = append(txs, tx)
+	}
+	return txs
+}
+
+// displayBatchResults shows the transactions a multi-recipient send
+// broadcast. After a partial ETH batch only the sent transactions are shown.
+func displayBatchResults(cmd *cobra.Command, req *transaction.SendRequest, results []*transaction.SendResult, network string) {
+	if len(results) == 0 {
+		return
+	}
+	w := cmd.OutOrStdout()
+	txs := batchTransactions(req, results)
+
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		_ = writeJSON(w, struct {
+			Chain        chain.ID      `json:"chain"`
+			From         string        `json:"from"`
+			Transactions []batchTxJSON `json:"transactions"`
+		}{Chain: req.ChainID, From: results[0].From, Transactions: txs})
+		return
+	}
+
+	symbol := strings.ToUpper(string(req.ChainID))
+	if results[0].Token != "" {
+		symbol = results[0].Token
+	}
+	var paid int
+	for _, tx := range txs {
+		paid += len(tx.Payments)
+	}
+	recipients := len(req.AllPayments())
+	if len(req.Split) > 0 {
+		recipients = len(req.Split)
+	}
+	out(w, "\n%d of %d payments broadcast successfully!\n", paid, recipients)
+	for i, tx := range txs {
+		outln(w)
+		out(w, "  Hash:   %s\n", tx.Hash)
+		out(w, "  Status: %s\n", tx.Status)
+		for _, p := range tx.Payments {
+			out(w, "  Paid:   %s %s to %s\n", p.Amount, symbol, p.To)
+		}
+		if req.ChainID == chain.ETH {
+			out(w, "  Fee:    %s (estimated)\n", tx.Fee)
+		} else {
+			out(w, "  Fee:    %s BSV\n", tx.Fee)
+		}
+		displaySendChangesText(w, results[i].Changes)
+	}
+
+	outln(w)
+	outln(w, "Track your transactions:")
+	for _, r := range results {
+		for _, link := range explorerTxLinks(req.ChainID, network, r.Hash) {
+			out(w, "  %s\n", link)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	batchETHAddr1 = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	batchETHAddr2 = "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+)
+
+//nolint:tparallel // Subtests replace package-level flag variables
+func TestResolveTxPayments(t *testing.T) {
+	origTo, origAmount, origFile, origNoChecksum := txToList, txAmountList, txPaymentsFile, txNoChecksum
+	t.Cleanup(func() {
+		txToList, txAmountList, txPaymentsFile, txNoChecksum = origTo, origAmount, origFile, origNoChecksum
+		txTo, txAmount, txPayments, txSplit = "", "", nil, nil
+	})
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	set := func(to, amounts []string, file string) {
+		txToList, txAmountList, txPaymentsFile, txNoChecksum = to, amounts, file, false
+		txTo, txAmount, txPayments, txSplit = "", "", nil, nil
+	}
+
+	t.Run("single recipient", func(t *testing.T) {
+		set([]string{"1A"}, []string{"0.1"}, "")
+		require.NoError(t, resolveTxPayments(cmd, chain.BTC))
+		assert.Equal(t, "1A", txTo)
+		assert.Equal(t, "0.1", txAmount)
+		assert.Empty(t, txPayments)
+	})
+
+	t.Run("flag pairs and stdin file", func(t *testing.T) {
+		set([]string{"1A", "1B"}, []string{"0.1", "0.2"}, "-")
+		cmd.SetIn(strings.NewReader("# payroll\n1C, 0.3\n\n1D,0.4\n"))
+		require.NoError(t, resolveTxPayments(cmd, chain.BSV))
+		assert.Equal(t, "1A", txTo)
+		assert.Equal(t, []transaction.Payment{
+			{To: "1B", AmountStr: "0.2"},
+			{To: "1C", AmountStr: "0.3"},
+			{To: "1D", AmountStr: "0.4"},
+		}, txPayments)
+	})
+
+	t.Run("eth recipients are checksummed", func(t *testing.T) {
+		set([]string{batchETHAddr1, strings.ToLower(batchETHAddr2)}, []string{"0.1", "0.2"}, "")
+		err := resolveTxPayments(cmd, chain.ETH)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidChecksum)
+
+		set([]string{batchETHAddr1, strings.ToLower(batchETHAddr2)}, []string{"0.1", "0.2"}, "")
+		txNoChecksum = true
+		require.NoError(t, resolveTxPayments(cmd, chain.ETH))
+		assert.Equal(t, batchETHAddr2, txPayments[0].To)
+	})
+
+	t.Run("split sweep", func(t *testing.T) {
+		set([]string{"1A=70%", " 1B = 0.001 "}, []string{"all"}, "")
+		require.NoError(t, resolveTxPayments(cmd, chain.BSV))
+		assert.Equal(t, "1A", txTo)
+		assert.Equal(t, "all", txAmount)
+		assert.Empty(t, txPayments)
+		assert.Equal(t, []transaction.Payment{{To: "1A", AmountStr: "70%"}, {To: "1B", AmountStr: "0.001"}}, txSplit)
+	})
+
+	rejections := []struct {
+		name    string
+		to      []string
+		amounts []string
+		chainID chain.ID
+	}{
+		{"unpaired flags", []string{"1A", "1B"}, []string{"0.1"}, chain.BSV},
+		{"no recipients", nil, nil, chain.BSV},
+		{"sweep", []string{"1A", "1B"}, []string{"all", "0.1"}, chain.BSV},
+		{"fiat", []string{"1A", "1B"}, []string{"0.1", "50usd"}, chain.BSV},
+		{"unsupported chain", []string{"1A", "1B"}, []string{"0.1", "0.2"}, chain.BTC},
+		{"split on eth", []string{"0xA=50%", "0xB=50%"}, []string{"all"}, chain.ETH},
+		{"split without sweep", []string{"1A=50%", "1B=50%"}, []string{"0.1"}, chain.BSV},
+		{"split to one recipient", []string{"1A=100%"}, []string{"all"}, chain.BSV},
+		{"split share missing", []string{"1A=50%", "1B"}, []string{"all"}, chain.BSV},
+		{"split share invalid", []string{"1A=50%", "1B=half"}, []string{"all"}, chain.BSV},
+	}
+	for _, tc := range rejections {
+		t.Run(tc.name, func(t *testing.T) {
+			set(tc.to, tc.amounts, "")
+			err := resolveTxPayments(cmd, tc.chainID)
+			require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+		})
+	}
+}
+
+func TestParsePaymentsFile(t *testing.T) {
+	t.Parallel()
+
+	payments, err := parsePaymentsFile([]byte("1A,0.1\n# comment\n1B,  0.2  \n"))
+	require.NoError(t, err)
+	assert.Equal(t, []transaction.Payment{{To: "1A", AmountStr: "0.1"}, {To: "1B", AmountStr: "0.2"}}, payments)
+
+	_, err = parsePaymentsFile([]byte("1A,0.1,extra\n"))
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	_, err = parsePaymentsFile([]byte("# nothing here\n"))
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestSetConfirmPayments(t *testing.T) {
+	t.Parallel()
+
+	req := &transaction.SendRequest{
+		ChainID: chain.BSV, To: "1A", AmountStr: "0.1",
+		Payments: []transaction.Payment{{To: "1B", AmountStr: "0.25"}},
+	}
+	p := newSendConfirmParams(chain.BSV, req)
+	assert.Equal(t, big.NewInt(35000000), p.Amount, "the amount covers every recipient")
+	require.Len(t, p.Payments, 2)
+	assert.Equal(t, "1B", p.Payments[1].To)
+	assert.Contains(t, p.canonical(), "pay 1B 25000000\n")
+
+	single := newSendConfirmParams(chain.BSV, &transaction.SendRequest{To: "1A", AmountStr: "0.1"})
+	assert.Nil(t, single.Payments)
+	assert.NotContains(t, single.canonical(), "pay ")
+}
+
+func TestDisplayBatchResults(t *testing.T) {
+	t.Parallel()
+
+	bsvReq := &transaction.SendRequest{
+		ChainID: chain.BSV, To: "1A", AmountStr: "0.1",
+		Payments: []transaction.Payment{{To: "1B", AmountStr: "0.2"}},
+	}
+	bsvResults := []*transaction.SendResult{{Hash: "bsvhash", From: "1From", Fee: "0.00000100", Status: "pending"}}
+
+	t.Run("bsv text", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayBatchResults(cmd, bsvReq, bsvResults, "main")
+		assert.Contains(t, buf.String(), "2 of 2 payments broadcast successfully!")
+		assert.Contains(t, buf.String(), "Paid:   0.2 BSV to 1B")
+		assert.Contains(t, buf.String(), "whatsonchain.com/tx/bsvhash")
+	})
+
+	t.Run("partial eth json", func(t *testing.T) {
+		t.Parallel()
+		ethReq := &transaction.SendRequest{
+			ChainID: chain.ETH, To: batchETHAddr1, AmountStr: "0.1",
+			Payments: []transaction.Payment{{To: batchETHAddr2, AmountStr: "0.2"}},
+		}
+		results := []*transaction.SendResult{{Hash: "0xabc", From: batchETHAddr1, To: batchETHAddr1, Amount: "0.1", Status: "pending"}}
+
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatJSON)
+		cmd.SetOut(&buf)
+		displayBatchResults(cmd, ethReq, results, "main")
+		assert.Contains(t, buf.String(), `"hash": "0xabc"`)
+		assert.Contains(t, buf.String(), `"to": "`+batchETHAddr1+`"`)
+		assert.NotContains(t, buf.String(), batchETHAddr2)
+	})
+
+	t.Run("split sweep text", func(t *testing.T) {
+		t.Parallel()
+		splitReq := &transaction.SendRequest{
+			ChainID: chain.BSV, To: "1A", AmountStr: "all",
+			Split: []transaction.Payment{{To: "1A", AmountStr: "70%"}, {To: "1B", AmountStr: "30%"}},
+		}
+		results := []*transaction.SendResult{{
+			Hash: "splithash", Fee: "0.00000100", Status: "pending",
+			Payments: []transaction.Payment{{To: "1A", AmountStr: "0.007"}, {To: "1B", AmountStr: "0.003"}},
+		}}
+
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayBatchResults(cmd, splitReq, results, "main")
+		assert.Contains(t, buf.String(), "2 of 2 payments broadcast successfully!")
+		assert.Contains(t, buf.String(), "Paid:   0.007 BSV to 1A")
+		assert.Contains(t, buf.String(), "Paid:   0.003 BSV to 1B")
+	})
+}
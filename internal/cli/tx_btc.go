@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/btc"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// promptBTCConfirmation handles the BTC transaction confirmation prompt.
+func promptBTCConfirmation(ctx context.Context, cmd *cobra.Command, req *transaction.SendRequest, addresses []wallet.Address) (bool, error) {
+	details, err := prepareBTCConfirmation(ctx, cmd, req, addresses)
+	if err != nil {
+		return false, err
+	}
+
+	displayBSVTxDetailsEnhanced(cmd, details)
+	return confirmSend(ctx, cmd, newBSVConfirmParams(details))
+}
+
+// prepareBTCConfirmation fetches UTXOs and the fee rate so the review screen
+// shows the amount, inputs, and fee the send will use.
+func prepareBTCConfirmation(ctx context.Context, cmd *cobra.Command, req *transaction.SendRequest, addresses []wallet.Address) (*bsvConfirmationDetails, error) {
+	cc := GetCmdContext(cmd)
+
+	client, err := transaction.NewBTCClient(cc.Cfg, req.Network, cc.Log)
+	if err != nil {
+		return nil, err
+	}
+	feeRate := client.GetFeeRate(ctx)
+	if capErr := transaction.CheckBTCFeeRate(feeRate, req.MaxFeeRate); capErr != nil {
+		return nil, capErr
+	}
+
+	allUTXOs, err := transaction.AggregateBTCUTXOs(ctx, client, addresses)
+	if err != nil {
+		return nil, fmt.Errorf("fetching UTXOs for confirmation: %w", err)
+	}
+	walletPath := filepath.Join(cc.Cfg.GetHome(), "wallets", req.Wallet)
+	store := utxostore.New(walletPath)
+	if loadErr := store.Load(); loadErr == nil {
+		allUTXOs = transaction.FilterSpentBTCUTXOs(allUTXOs, store)
+	} else if cc.Log != nil {
+		cc.Log.Error("failed to load utxo store for confirmation: %v", loadErr)
+	}
+	allUTXOs = transaction.FilterReservedUTXOs(cc.Log, walletPath, chain.BTC, allUTXOs)
+	if len(allUTXOs) == 0 {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInsufficientFunds, "no UTXOs found for transaction")
+	}
+
+	details := &bsvConfirmationDetails{
+		Chain:   chain.BTC,
+		To:      req.To,
+		FeeRate: feeRate,
+		IsSweep: req.SweepAll(),
+		Fiat:    req.Fiat,
+	}
+
+	spend := allUTXOs
+	if details.IsSweep {
+		var totalInputs uint64
+		for _, u := range allUTXOs {
+			totalInputs += u.Amount
+		}
+		sweepAmount, sweepErr := btc.CalculateSweepAmount(totalInputs, len(allUTXOs), feeRate)
+		if sweepErr != nil {
+			return nil, sweepErr
+		}
+		details.AmountSats = sweepAmount
+		details.EstimatedFee = totalInputs - sweepAmount
+	} else {
+		amount, parseErr := client.ParseAmount(req.AmountStr)
+		if parseErr != nil {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("invalid amount: %s", req.AmountStr),
+			)
+		}
+		selected, _, selErr := client.SelectUTXOs(allUTXOs, amount.Uint64(), feeRate)
+		if selErr != nil {
+			return nil, selErr
+		}
+		spend = selected
+		details.AmountSats = amount.Uint64()
+		details.EstimatedFee = btc.EstimateFeeForTx(len(selected), 2, feeRate)
+	}
+
+	details.TotalUTXOs = len(spend)
+	details.AddressUTXOs = make(map[string]int)
+	for _, u := range spend {
+		if details.AddressUTXOs[u.Address] == 0 {
+			details.SourceAddresses = append(details.SourceAddresses, u.Address)
+		}
+		details.AddressUTXOs[u.Address]++
+	}
+	return details, nil
+}
+
+// displayBTCTxResult shows the BTC transaction result.
+func displayBTCTxResult(cmd *cobra.Command, result *chain.TransactionResult, network string, changes *transaction.SendChanges, fiat *transaction.FiatConversion) {
+	w := cmd.OutOrStdout()
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		displayBSVTxResultJSON(w, result, explorerTxLink(chain.BTC, network, result.Hash), changes, fiat)
+		return
+	}
+
+	outln(w, "\nTransaction broadcast successfully!")
+	outln(w)
+	out(w, "  Hash:   %s\n", result.Hash)
+	out(w, "  Status: %s\n", result.Status)
+	out(w, "  Amount: %s BTC\n", result.Amount)
+	displayFiatConversionText(w, fiat, 7)
+	displayFiatValueText(w, txFiatValue(txFiatQuote, result.Amount, fiat), 7)
+	out(w, "  Fee:    %s BTC\n", result.Fee)
+	displaySendChangesText(w, changes)
+	outln(w)
+	outln(w, "Track your transaction:")
+	out(w, "  %s\n", explorerTxLink(chain.BTC, network, result.Hash))
+}
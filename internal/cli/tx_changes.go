@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"io"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+)
+
+// balanceChangeJSON is one address's balance movement in the `changes` block.
+type balanceChangeJSON struct {
+	Address  string `json:"address"`
+	Symbol   string `json:"symbol"`
+	Previous string `json:"previous"`
+	Expected string `json:"expected"`
+}
+
+// outpointJSON is a UTXO consumed or created by a send.
+type outpointJSON struct {
+	TxID    string `json:"txid"`
+	Vout    uint32 `json:"vout"`
+	Amount  uint64 `json:"amount"`
+	Address string `json:"address"`
+}
+
+// cacheEntryJSON identifies a balance cache entry touched by a send.
+type cacheEntryJSON struct {
+	Chain   string `json:"chain"`
+	Address string `json:"address"`
+	Token   string `json:"token,omitempty"`
+}
+
+// sendChangesJSON is the `changes` block of a send result.
+type sendChangesJSON struct {
+	Balances         []balanceChangeJSON `json:"balances"`
+	UTXOsConsumed    []outpointJSON      `json:"utxos_consumed,omitempty"`
+	UTXOsCreated     []outpointJSON      `json:"utxos_created,omitempty"`
+	ChangeAddress    string              `json:"change_address,omitempty"`
+	CacheInvalidated []cacheEntryJSON    `json:"cache_invalidated"`
+}
+
+// newSendChangesJSON converts a service change summary for JSON output.
+// Returns nil when there is nothing to report.
+func newSendChangesJSON(changes *transaction.SendChanges) *sendChangesJSON {
+	if changes == nil {
+		return nil
+	}
+
+	out := &sendChangesJSON{
+		Balances:         make([]balanceChangeJSON, 0, len(changes.Balances)),
+		UTXOsConsumed:    outpointsJSON(changes.UTXOsConsumed),
+		UTXOsCreated:     outpointsJSON(changes.UTXOsCreated),
+		ChangeAddress:    changes.ChangeAddress,
+		CacheInvalidated: make([]cacheEntryJSON, 0, len(changes.CacheInvalidated)),
+	}
+	for _, b := range changes.Balances {
+		out.Balances = append(out.Balances, balanceChangeJSON(b))
+	}
+	for _, c := range changes.CacheInvalidated {
+		out.CacheInvalidated = append(out.CacheInvalidated, cacheEntryJSON{
+			Chain:   string(c.Chain),
+			Address: c.Address,
+			Token:   c.Token,
+		})
+	}
+	return out
+}
+
+// outpointsJSON converts UTXOs to their JSON form.
+func outpointsJSON(utxos []chain.UTXO) []outpointJSON {
+	if len(utxos) == 0 {
+		return nil
+	}
+	out := make([]outpointJSON, len(utxos))
+	for i, u := range utxos {
+		out[i] = outpointJSON{TxID: u.TxID, Vout: u.Vout, Amount: u.Amount, Address: u.Address}
+	}
+	return out
+}
+
+// displaySendChangesText prints the "what changed" summary after a send.
+func displaySendChangesText(w io.Writer, changes *transaction.SendChanges) {
+	if changes == nil {
+		return
+	}
+
+	outln(w)
+	outln(w, "What changed:")
+	if len(changes.Balances) > 0 {
+		outln(w, "  Balances (expected after confirmation):")
+		for _, b := range changes.Balances {
+			out(w, "    %s  %s → %s %s\n", b.Address, b.Previous, b.Expected, b.Symbol)
+		}
+	}
+	if len(changes.UTXOsConsumed) > 0 {
+		out(w, "  UTXOs consumed: %d\n", len(changes.UTXOsConsumed))
+		for _, u := range changes.UTXOsConsumed {
+			out(w, "    %s:%d  %d sat  %s\n", u.TxID, u.Vout, u.Amount, u.Address)
+		}
+	}
+	if len(changes.UTXOsCreated) > 0 {
+		out(w, "  UTXOs created: %d\n", len(changes.UTXOsCreated))
+		for _, u := range changes.UTXOsCreated {
+			out(w, "    %s:%d  %d sat  %s\n", u.TxID, u.Vout, u.Amount, u.Address)
+		}
+	}
+	if changes.ChangeAddress != "" {
+		out(w, "  Change address: %s\n", changes.ChangeAddress)
+	}
+	if n := len(changes.CacheInvalidated); n > 0 {
+		noun := "entries"
+		if n == 1 {
+			noun = "entry"
+		}
+		out(w, "  Balance cache: %d %s refreshed\n", n, noun)
+	}
+}
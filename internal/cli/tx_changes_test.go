@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+)
+
+func testSendChanges() *transaction.SendChanges {
+	return &transaction.SendChanges{
+		Balances: []transaction.BalanceChange{
+			{Address: "1ADDR", Symbol: "BSV", Previous: "0.00008000", Expected: "0.00000000"},
+			{Address: "1CHANGE", Symbol: "BSV", Previous: "0.00000000", Expected: "0.00001900"},
+		},
+		UTXOsConsumed: []chain.UTXO{{TxID: "aaaa", Vout: 0, Amount: 8000, Address: "1ADDR"}},
+		UTXOsCreated: []chain.UTXO{
+			{TxID: "bbbb", Vout: 0, Amount: 6000, Address: "1EXTERNAL"},
+			{TxID: "bbbb", Vout: 1, Amount: 1900, Address: "1CHANGE"},
+		},
+		ChangeAddress:    "1CHANGE",
+		CacheInvalidated: []transaction.CacheEntryRef{{Chain: chain.BSV, Address: "1ADDR"}},
+	}
+}
+
+func TestDisplaySendChangesText(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displaySendChangesText(&buf, testSendChanges())
+	text := buf.String()
+
+	assert.Contains(t, text, "What changed:")
+	assert.Contains(t, text, "1ADDR  0.00008000 → 0.00000000 BSV")
+	assert.Contains(t, text, "UTXOs consumed: 1")
+	assert.Contains(t, text, "aaaa:0  8000 sat  1ADDR")
+	assert.Contains(t, text, "UTXOs created: 2")
+	assert.Contains(t, text, "Change address: 1CHANGE")
+	assert.Contains(t, text, "Balance cache: 1 entry refreshed")
+}
+
+func TestDisplaySendChangesText_Nil(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displaySendChangesText(&buf, nil)
+	assert.Empty(t, buf.String())
+}
+
+func TestDisplayBSVTxResultJSON_Changes(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displayBSVTxResultJSON(&buf, &chain.TransactionResult{Hash: "bbbb", Status: "pending"}, "", testSendChanges(), nil)
+
+	var parsed struct {
+		Hash    string `json:"hash"`
+		Changes struct {
+			Balances []struct {
+				Address  string `json:"address"`
+				Previous string `json:"previous"`
+				Expected string `json:"expected"`
+			} `json:"balances"`
+			UTXOsConsumed []struct {
+				TxID   string `json:"txid"`
+				Amount uint64 `json:"amount"`
+			} `json:"utxos_consumed"`
+			UTXOsCreated     []json.RawMessage `json:"utxos_created"`
+			ChangeAddress    string            `json:"change_address"`
+			CacheInvalidated []struct {
+				Chain   string `json:"chain"`
+				Address string `json:"address"`
+			} `json:"cache_invalidated"`
+		} `json:"changes"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+
+	assert.Equal(t, "bbbb", parsed.Hash)
+	require.Len(t, parsed.Changes.Balances, 2)
+	assert.Equal(t, "0.00001900", parsed.Changes.Balances[1].Expected)
+	require.Len(t, parsed.Changes.UTXOsConsumed, 1)
+	assert.Equal(t, uint64(8000), parsed.Changes.UTXOsConsumed[0].Amount)
+	assert.Len(t, parsed.Changes.UTXOsCreated, 2)
+	assert.Equal(t, "1CHANGE", parsed.Changes.ChangeAddress)
+	require.Len(t, parsed.Changes.CacheInvalidated, 1)
+	assert.Equal(t, "bsv", parsed.Changes.CacheInvalidated[0].Chain)
+}
+
+func TestDisplayTxResultJSON_NoChanges(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displayTxResultJSON(&buf, &chain.TransactionResult{Hash: "0xabc"}, "", nil, nil)
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	assert.NotContains(t, parsed, "changes")
+}
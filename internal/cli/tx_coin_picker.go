@@ -0,0 +1,255 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/txintent"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// errInvalidCoinPick indicates an input number outside the picker's list.
+var errInvalidCoinPick = errors.New("invalid input number")
+
+// coinPick is the state of the interactive input picker: the candidate
+// UTXOs and which of them are chosen.
+type coinPick struct {
+	candidates []bsv.UTXO
+	picked     []bool
+}
+
+// coinPickTotals are the amounts a choice of inputs gives the send.
+type coinPickTotals struct {
+	inputs uint64 // total of the chosen inputs
+	amount uint64 // amount sent, reduced by the fee for a sweep
+	fee    uint64
+	change uint64
+}
+
+// newCoinPick returns a picker over candidates with the inputs already
+// chosen by coin selection marked.
+func newCoinPick(candidates, inputs []bsv.UTXO) *coinPick {
+	chosen := make(map[string]bool, len(inputs))
+	for _, u := range inputs {
+		chosen[txintent.OutpointKey(u.TxID, u.Vout)] = true
+	}
+	p := &coinPick{candidates: candidates, picked: make([]bool, len(candidates))}
+	for i, u := range candidates {
+		p.picked[i] = chosen[txintent.OutpointKey(u.TxID, u.Vout)]
+	}
+	return p
+}
+
+// toggle flips the inputs named by spec, a list of 1-based input numbers and
+// ranges such as "1 3-5,7". Nothing changes when any part is invalid.
+func (p *coinPick) toggle(spec string) error {
+	var flips []int
+	for _, part := range strings.FieldsFunc(spec, func(r rune) bool { return r == ' ' || r == ',' }) {
+		first, last, isRange := strings.Cut(part, "-")
+		lo, err := p.inputNumber(first)
+		if err != nil {
+			return err
+		}
+		hi := lo
+		if isRange {
+			if hi, err = p.inputNumber(last); err != nil {
+				return err
+			}
+		}
+		if hi < lo {
+			return fmt.Errorf("%w: %q", errInvalidCoinPick, part)
+		}
+		for i := lo; i <= hi; i++ {
+			flips = append(flips, i-1)
+		}
+	}
+	for _, i := range flips {
+		p.picked[i] = !p.picked[i]
+	}
+	return nil
+}
+
+// inputNumber parses a 1-based input number.
+func (p *coinPick) inputNumber(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > len(p.candidates) {
+		return 0, fmt.Errorf("%w: %q (use 1 to %d)", errInvalidCoinPick, s, len(p.candidates))
+	}
+	return n, nil
+}
+
+// setAll chooses every input, or none.
+func (p *coinPick) setAll(picked bool) {
+	for i := range p.picked {
+		p.picked[i] = picked
+	}
+}
+
+// chosen returns the chosen inputs in list order.
+func (p *coinPick) chosen() []bsv.UTXO {
+	var inputs []bsv.UTXO
+	for i, u := range p.candidates {
+		if p.picked[i] {
+			inputs = append(inputs, u)
+		}
+	}
+	return inputs
+}
+
+// totals computes what the chosen inputs give a send of details at its fee
+// rate. A sweep sends them all less the fee; any other send spends every
+// chosen input, as the manual coin selection strategy does.
+func (p *coinPick) totals(selector *bsv.Client, details *bsvConfirmationDetails, recipients int) (*coinPickTotals, error) {
+	inputs := p.chosen()
+	if len(inputs) == 0 {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInsufficientFunds, "no inputs chosen")
+	}
+	t := &coinPickTotals{}
+	for _, u := range inputs {
+		t.inputs += u.Amount
+	}
+
+	if details.IsSweep {
+		amount, err := bsv.CalculateSweepAmount(t.inputs, len(inputs), details.FeeRate)
+		if err != nil {
+			return nil, err
+		}
+		t.amount, t.fee = amount, t.inputs-amount
+		return t, nil
+	}
+
+	sel, err := selector.SelectCoins(bsv.CoinSelectionManual, inputs, details.AmountSats, details.FeeRate, recipients)
+	if err != nil {
+		return nil, err
+	}
+	t.amount, t.fee, t.change = details.AmountSats, sel.Fee(details.AmountSats), sel.Change
+	return t, nil
+}
+
+// checkInteractiveCoins rejects --interactive-coins where there is no
+// confirmation prompt to pick the inputs at.
+func checkInteractiveCoins(chainID chain.ID, unattended bool) error {
+	if !txInteractiveCoins {
+		return nil
+	}
+	if chainID != chain.BSV {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--interactive-coins is only supported for BSV chain",
+		)
+	}
+	if unattended {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--interactive-coins needs the confirmation prompt; it cannot be used with --yes or in agent mode (use --utxo instead)",
+		)
+	}
+	if len(txSplit) > 0 {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--interactive-coins cannot be used in a split sweep; choose its inputs with --utxo",
+		)
+	}
+	return nil
+}
+
+// pickBSVCoins lets the user choose the inputs of a BSV send from the
+// wallet's spendable UTXOs, showing the fee and change of each choice. On
+// accept, req spends exactly the chosen outpoints and details describes
+// them. It returns false when the user cancels.
+func pickBSVCoins(ctx context.Context, w io.Writer, req *transaction.SendRequest, details *bsvConfirmationDetails) (bool, error) {
+	pick := newCoinPick(details.Candidates, details.Inputs)
+	// Selection is local, so a client without network options will do
+	selector := bsv.NewClient(ctx, nil)
+	recipients := 1 + len(req.Payments)
+
+	for {
+		displayCoinPick(w, pick, details.FeeRate)
+		totals, totalsErr := pick.totals(selector, details, recipients)
+		displayCoinPickTotals(w, totals, totalsErr, details.IsSweep)
+
+		line, err := promptCoinPickFn()
+		if err != nil {
+			return false, err
+		}
+		switch choice := strings.ToLower(strings.TrimSpace(line)); choice {
+		case "":
+			if totalsErr != nil {
+				outln(w, "  These inputs cannot fund the send. Change the selection, or q to cancel.")
+				continue
+			}
+			applyCoinPick(req, details, pick.chosen(), totals)
+			return true, nil
+		case "q":
+			return false, nil
+		case "a":
+			pick.setAll(true)
+		case "n":
+			pick.setAll(false)
+		default:
+			if err := pick.toggle(choice); err != nil {
+				out(w, "  %s\n", err)
+			}
+		}
+	}
+}
+
+// applyCoinPick makes req spend exactly the chosen inputs and updates the
+// confirmation details to match.
+func applyCoinPick(req *transaction.SendRequest, details *bsvConfirmationDetails, inputs []bsv.UTXO, totals *coinPickTotals) {
+	outpoints := make([]string, len(inputs))
+	for i, u := range inputs {
+		outpoints[i] = txintent.OutpointKey(u.TxID, u.Vout)
+	}
+	req.Outpoints = outpoints
+	if !details.IsSweep {
+		req.CoinSelection = string(bsv.CoinSelectionManual)
+		details.CoinSelection = req.CoinSelection
+	}
+	details.AmountSats = totals.amount
+	details.EstimatedFee = totals.fee
+	details.setInputs(inputs)
+}
+
+// displayCoinPick lists the candidate inputs, marking the chosen ones.
+func displayCoinPick(w io.Writer, pick *coinPick, feeRate uint64) {
+	outln(w)
+	out(w, "Choose inputs (fee rate %d sat/KB):\n", feeRate)
+	for i, u := range pick.candidates {
+		mark := " "
+		if pick.picked[i] {
+			mark = "x"
+		}
+		out(w, "  [%s] %3d  %s  %s  %s sats  %d conf\n",
+			mark, i+1, txintent.OutpointKey(u.TxID, u.Vout), u.Address, formatSatsWithCommas(u.Amount), u.Confirmations)
+	}
+}
+
+// displayCoinPickTotals shows the fee and change of the current choice, or
+// why it cannot fund the send.
+func displayCoinPickTotals(w io.Writer, t *coinPickTotals, err error, sweep bool) {
+	outln(w)
+	if err != nil {
+		var sigilErr *sigilerr.SigilError
+		if errors.As(err, &sigilErr) && sigilErr.Suggestion != "" {
+			out(w, "  Cannot send: %s\n", sigilErr.Suggestion)
+		} else {
+			out(w, "  Cannot send: %s\n", err)
+		}
+		return
+	}
+	if sweep {
+		out(w, "  Inputs: %s sats  Fee: %s sats  Sweep amount: %s sats\n",
+			formatSatsWithCommas(t.inputs), formatSatsWithCommas(t.fee), formatSatsWithCommas(t.amount))
+		return
+	}
+	out(w, "  Inputs: %s sats  Fee: %s sats  Change: %s sats\n",
+		formatSatsWithCommas(t.inputs), formatSatsWithCommas(t.fee), formatSatsWithCommas(t.change))
+}
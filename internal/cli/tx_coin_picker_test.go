@@ -0,0 +1,139 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// coinPickCandidates returns 30000, 100000, and 20400 satoshi UTXOs. At
+// 1000 sat/KB the first and last pay 50000 with a 374 satoshi fee and 26
+// satoshis of change.
+func coinPickCandidates() []bsv.UTXO {
+	addr := "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+	return []bsv.UTXO{
+		{TxID: strings.Repeat("a1", 32), Vout: 0, Amount: 30000, Address: addr},
+		{TxID: strings.Repeat("b2", 32), Vout: 1, Amount: 100000, Address: addr},
+		{TxID: strings.Repeat("c3", 32), Vout: 2, Amount: 20400, Address: addr},
+	}
+}
+
+// stubCoinPick answers the picker prompt with lines, in order.
+func stubCoinPick(t *testing.T, lines ...string) {
+	t.Helper()
+	orig := promptCoinPickFn
+	t.Cleanup(func() { promptCoinPickFn = orig })
+	promptCoinPickFn = func() (string, error) {
+		require.NotEmpty(t, lines, "picker asked for more input")
+		line := lines[0]
+		lines = lines[1:]
+		return line, nil
+	}
+}
+
+func TestCoinPickToggle(t *testing.T) {
+	t.Parallel()
+
+	candidates := coinPickCandidates()
+	pick := newCoinPick(candidates, candidates[1:2])
+	assert.Equal(t, []bool{false, true, false}, pick.picked)
+
+	require.NoError(t, pick.toggle("1-2,3"))
+	assert.Equal(t, []bool{true, false, true}, pick.picked)
+
+	for _, spec := range []string{"0", "4", "2-1", "x", "1-"} {
+		require.ErrorIs(t, pick.toggle(spec), errInvalidCoinPick, spec)
+	}
+	require.ErrorIs(t, pick.toggle("2 9"), errInvalidCoinPick)
+	assert.Equal(t, []bool{true, false, true}, pick.picked, "an invalid spec changes nothing")
+
+	pick.setAll(false)
+	assert.Empty(t, pick.chosen())
+}
+
+func TestCoinPickTotals(t *testing.T) {
+	t.Parallel()
+
+	selector := bsv.NewClient(context.Background(), nil)
+	pick := newCoinPick(coinPickCandidates(), nil)
+	require.NoError(t, pick.toggle("1 3"))
+
+	t.Run("send", func(t *testing.T) {
+		t.Parallel()
+		totals, err := pick.totals(selector, &bsvConfirmationDetails{AmountSats: 50000, FeeRate: 1000}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &coinPickTotals{inputs: 50400, amount: 50000, fee: 374, change: 26}, totals)
+	})
+
+	t.Run("sweep", func(t *testing.T) {
+		t.Parallel()
+		totals, err := pick.totals(selector, &bsvConfirmationDetails{IsSweep: true, FeeRate: 1000}, 1)
+		require.NoError(t, err)
+		assert.Equal(t, &coinPickTotals{inputs: 50400, amount: 50060, fee: 340}, totals)
+	})
+
+	t.Run("short", func(t *testing.T) {
+		t.Parallel()
+		_, err := pick.totals(selector, &bsvConfirmationDetails{AmountSats: 60000, FeeRate: 1000}, 1)
+		require.ErrorIs(t, err, bsv.ErrInsufficientFunds)
+	})
+}
+
+func TestPickBSVCoins(t *testing.T) {
+	newDetails := func() *bsvConfirmationDetails {
+		candidates := coinPickCandidates()
+		details := &bsvConfirmationDetails{Chain: chain.BSV, AmountSats: 50000, FeeRate: 1000, Candidates: candidates}
+		details.setInputs(candidates[1:2])
+		return details
+	}
+
+	t.Run("accept", func(t *testing.T) {
+		stubCoinPick(t, "n\n", "\n", "9\n", "1 3\n", "\n")
+		req := &transaction.SendRequest{}
+		details := newDetails()
+		var buf bytes.Buffer
+		picked, err := pickBSVCoins(context.Background(), &buf, req, details)
+		require.NoError(t, err)
+		require.True(t, picked)
+
+		assert.Equal(t, []string{strings.Repeat("a1", 32) + ":0", strings.Repeat("c3", 32) + ":2"}, req.Outpoints)
+		assert.Equal(t, string(bsv.CoinSelectionManual), req.CoinSelection)
+		assert.Equal(t, 2, details.TotalUTXOs)
+		assert.Equal(t, uint64(374), details.EstimatedFee)
+		assert.Equal(t, string(bsv.CoinSelectionManual), details.CoinSelection)
+
+		assert.Contains(t, buf.String(), "Cannot send: no inputs chosen")
+		assert.Contains(t, buf.String(), "cannot fund the send")
+		assert.Contains(t, buf.String(), `invalid input number: "9"`)
+		assert.Contains(t, buf.String(), "Inputs: 50,400 sats  Fee: 374 sats  Change: 26 sats")
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		stubCoinPick(t, "q\n")
+		req := &transaction.SendRequest{}
+		picked, err := pickBSVCoins(context.Background(), &bytes.Buffer{}, req, newDetails())
+		require.NoError(t, err)
+		assert.False(t, picked)
+		assert.Empty(t, req.Outpoints)
+	})
+}
+
+func TestCheckInteractiveCoins(t *testing.T) {
+	t.Cleanup(func() { txInteractiveCoins = false })
+
+	require.NoError(t, checkInteractiveCoins(chain.ETH, true), "off by default")
+
+	txInteractiveCoins = true
+	require.NoError(t, checkInteractiveCoins(chain.BSV, false))
+	require.ErrorIs(t, checkInteractiveCoins(chain.ETH, false), sigilerr.ErrInvalidInput)
+	require.ErrorIs(t, checkInteractiveCoins(chain.BSV, true), sigilerr.ErrInvalidInput)
+}
@@ -0,0 +1,273 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/price"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	// confirmCodeVersion prefixes the confirmation code hash input so the
+	// canonical form can change without reusing codes.
+	confirmCodeVersion = "sigil-confirm/v1"
+
+	// confirmCodeAlphabet is Crockford base32, which has no I, L, O or U, so
+	// a code read off the screen cannot be mistyped as a look-alike.
+	confirmCodeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	// confirmCodeLength is the number of base32 characters (40 bits) in a code.
+	confirmCodeLength = 8
+)
+
+// stablecoinSymbols are tokens valued at one US dollar each for the typed
+// confirmation threshold.
+//
+//nolint:gochecknoglobals // Read-only set of USD stablecoin symbols
+var stablecoinSymbols = map[string]bool{"USDC": true, "USDT": true, "DAI": true}
+
+// txConfirmParams are the transaction parameters shown on the review screen.
+type txConfirmParams struct {
+	Chain chain.ID
+	From  []string
+	To    string
+	// Amount is in the smallest unit. It is nil for an ETH sweep, whose
+	// amount is only known when the transaction is built.
+	Amount      *big.Int
+	Decimals    int
+	Token       string // ERC-20 contract address, empty for the native coin
+	TokenSymbol string
+	Fee         string // Gas speed (ETH) or fee rate in sat/KB (BSV)
+	Fiat        *transaction.FiatConversion
+	// Payments lists every recipient of a multi-recipient send, To first;
+	// Amount is then their total. Nil for a single recipient.
+	Payments []confirmPayment
+}
+
+// confirmPayment is one recipient of a multi-recipient send. Amount is in
+// the smallest unit.
+type confirmPayment struct {
+	To     string
+	Amount *big.Int
+}
+
+// canonical renders the parameters one per line. Addresses are sorted and,
+// for ETH, lower-cased so the same transaction always has the same code.
+func (p *txConfirmParams) canonical() string {
+	normalize := func(addr string) string {
+		if p.Chain == chain.ETH {
+			return strings.ToLower(addr)
+		}
+		return addr
+	}
+
+	from := make([]string, 0, len(p.From))
+	for _, addr := range p.From {
+		from = append(from, normalize(addr))
+	}
+	sort.Strings(from)
+
+	amount := "all"
+	if p.Amount != nil {
+		amount = p.Amount.String()
+	}
+
+	var b strings.Builder
+	b.WriteString(confirmCodeVersion + "\n")
+	fmt.Fprintf(&b, "chain %s\n", p.Chain)
+	for _, addr := range from {
+		fmt.Fprintf(&b, "from %s\n", addr)
+	}
+	fmt.Fprintf(&b, "to %s\namount %s\ntoken %s\nfee %s\n", normalize(p.To), amount, strings.ToLower(p.Token), p.Fee)
+	for _, pay := range p.Payments {
+		fmt.Fprintf(&b, "pay %s %s\n", normalize(pay.To), pay.Amount)
+	}
+	return b.String()
+}
+
+// confirmPayments converts the recipients of a multi-recipient send to the
+// smallest unit. It returns nil for a single recipient or an amount that
+// does not parse, which the send itself rejects.
+func confirmPayments(payments []transaction.Payment, decimals int) []confirmPayment {
+	if len(payments) < 2 {
+		return nil
+	}
+	parsed := make([]confirmPayment, len(payments))
+	for i, pay := range payments {
+		amount, err := parseDecimalAmount(pay.AmountStr, decimals)
+		if err != nil {
+			return nil
+		}
+		parsed[i] = confirmPayment{To: pay.To, Amount: amount}
+	}
+	return parsed
+}
+
+// setConfirmPayments fills in the recipients of a multi-recipient request
+// and sets Amount to their total.
+func setConfirmPayments(p *txConfirmParams, req *transaction.SendRequest) {
+	if len(req.Payments) == 0 {
+		return
+	}
+	p.Payments = confirmPayments(req.AllPayments(), p.Decimals)
+	if p.Payments == nil {
+		return
+	}
+	total := new(big.Int)
+	for _, pay := range p.Payments {
+		total.Add(total, pay.Amount)
+	}
+	p.Amount = total
+}
+
+// confirmationCode derives the short code shown on the review screen from a
+// hash of the canonical transaction parameters, formatted as XXXX-XXXX.
+// A display that has been tampered with (for example by escape sequences in
+// an address label) cannot show the code for the real transaction.
+func confirmationCode(p *txConfirmParams) string {
+	sum := sha256.Sum256([]byte(p.canonical()))
+	bits := binary.BigEndian.Uint64(sum[:8]) >> (64 - 5*confirmCodeLength)
+
+	code := make([]byte, confirmCodeLength)
+	for i := confirmCodeLength - 1; i >= 0; i-- {
+		code[i] = confirmCodeAlphabet[bits&0x1f]
+		bits >>= 5
+	}
+	return string(code[:4]) + "-" + string(code[4:])
+}
+
+// normalizeConfirmCode upper-cases a typed code, drops separators, and maps
+// the letters Crockford base32 leaves out to the digits they resemble.
+func normalizeConfirmCode(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		switch r {
+		case '-', ' ', '\t', '\r', '\n':
+			continue
+		case 'O':
+			r = '0'
+		case 'I', 'L':
+			r = '1'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// sendValueUSD estimates the US dollar value of a send. It reports false
+// when the value cannot be determined.
+func sendValueUSD(ctx context.Context, quoter priceQuoter, p *txConfirmParams) (float64, bool) {
+	if p.Fiat != nil && p.Fiat.Currency == price.CurrencyUSD {
+		var usd float64
+		if _, err := fmt.Sscan(p.Fiat.Amount, &usd); err == nil {
+			return usd, true
+		}
+	}
+	if p.Amount == nil {
+		return 0, false
+	}
+
+	coins, _ := new(big.Rat).SetFrac(p.Amount, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(p.Decimals)), nil)).Float64()
+	if p.Token != "" {
+		if stablecoinSymbols[strings.ToUpper(p.TokenSymbol)] {
+			return coins, true
+		}
+		return 0, false
+	}
+
+	if quoter == nil {
+		return 0, false
+	}
+	quote, err := quoter.Quote(ctx, p.Chain, price.CurrencyUSD)
+	if err != nil || quote.Rate <= 0 {
+		return 0, false
+	}
+	return coins * quote.Rate, true
+}
+
+// confirmCodeReason explains why the code must be typed, or returns "" when a
+// plain yes/no confirmation is enough. Sends whose value cannot be priced are
+// treated as over the limit.
+func confirmCodeReason(ctx context.Context, quoter priceQuoter, p *txConfirmParams, limit float64) string {
+	if limit <= 0 {
+		return ""
+	}
+	usd, ok := sendValueUSD(ctx, quoter, p)
+	if !ok {
+		return fmt.Sprintf("its USD value could not be determined and require_confirm_above is %s USD", formatFiatRate(limit))
+	}
+	if usd < limit {
+		return ""
+	}
+	return fmt.Sprintf("it is worth about %s USD, at or above require_confirm_above (%s USD)", formatFiatRate(usd), formatFiatRate(limit))
+}
+
+// sendConfirmCodeReason prices the send against security.require_confirm_above
+// and returns why its code must be typed, or "" when it is below the limit.
+func sendConfirmCodeReason(ctx context.Context, cc *CommandContext, p *txConfirmParams) string {
+	var quoter priceQuoter
+	limit := cc.Cfg.GetSecurity().RequireConfirmAbove
+	if limit > 0 && p.Fiat == nil && p.Amount != nil && p.Token == "" {
+		quoter = newPriceQuoterFn(ctx, cc)
+	}
+	return confirmCodeReason(ctx, quoter, p, limit)
+}
+
+// checkUnreviewedSend refuses to let --yes or agent mode skip review of a
+// send at or above security.require_confirm_above, unless override is set.
+func checkUnreviewedSend(ctx context.Context, cmd *cobra.Command, p *txConfirmParams, override bool) error {
+	if override {
+		return nil
+	}
+	reason := sendConfirmCodeReason(ctx, GetCmdContext(cmd), p)
+	if reason == "" {
+		return nil
+	}
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrInvalidInput,
+		fmt.Sprintf("--yes cannot skip review of this send because %s; run it interactively to type the confirmation code, or add --override-confirm-limit", reason),
+	)
+}
+
+// confirmSend shows the confirmation code under the transaction details and
+// asks the user to approve the send. Sends at or above
+// security.require_confirm_above must be approved by typing the code, which
+// needs an interactive terminal.
+func confirmSend(ctx context.Context, cmd *cobra.Command, p *txConfirmParams) (bool, error) {
+	code := confirmationCode(p)
+	out(os.Stderr, "  Confirmation code: %s\n", code)
+
+	reason := sendConfirmCodeReason(ctx, GetCmdContext(cmd), p)
+	if reason == "" {
+		return promptConfirmFn(), nil
+	}
+
+	if !stdinIsTerminalFn() {
+		return false, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("this send needs its confirmation code typed at a terminal because %s; run it interactively, or pass --yes with --override-confirm-limit to skip review", reason),
+		)
+	}
+
+	out(os.Stderr, "\nThis send needs its confirmation code typed because %s.\n", reason)
+	typed, err := promptConfirmCodeFn()
+	if err != nil {
+		return false, err
+	}
+	if normalizeConfirmCode(typed) != normalizeConfirmCode(code) {
+		outln(os.Stderr, "Confirmation code does not match.")
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,316 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/price"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func testConfirmParams() *txConfirmParams {
+	return &txConfirmParams{
+		Chain:    chain.ETH,
+		From:     []string{"0x742d35Cc6634C0532925a3b844Bc454e4438f44e"},
+		To:       "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		Amount:   big.NewInt(100000000000000000),
+		Decimals: 18,
+		Fee:      "medium",
+	}
+}
+
+func TestConfirmationCode(t *testing.T) {
+	t.Parallel()
+
+	base := confirmationCode(testConfirmParams())
+
+	t.Run("format", func(t *testing.T) {
+		t.Parallel()
+
+		require.Len(t, base, confirmCodeLength+1)
+		assert.Equal(t, byte('-'), base[4])
+		for _, r := range strings.ReplaceAll(base, "-", "") {
+			assert.Contains(t, confirmCodeAlphabet, string(r))
+		}
+	})
+
+	t.Run("deterministic", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, base, confirmationCode(testConfirmParams()))
+	})
+
+	t.Run("ignores eth address case and source order", func(t *testing.T) {
+		t.Parallel()
+
+		p := testConfirmParams()
+		p.To = strings.ToLower(p.To)
+		p.From = []string{strings.ToLower(p.From[0])}
+		assert.Equal(t, base, confirmationCode(p))
+
+		a := &txConfirmParams{Chain: chain.BSV, From: []string{"1A", "1B"}, To: "1C", Amount: big.NewInt(5), Fee: "100"}
+		b := &txConfirmParams{Chain: chain.BSV, From: []string{"1B", "1A"}, To: "1C", Amount: big.NewInt(5), Fee: "100"}
+		assert.Equal(t, confirmationCode(a), confirmationCode(b))
+	})
+
+	changes := []struct {
+		name   string
+		modify func(p *txConfirmParams)
+	}{
+		{"recipient", func(p *txConfirmParams) { p.To = "0x0000000000000000000000000000000000000001" }},
+		{"amount", func(p *txConfirmParams) { p.Amount = big.NewInt(100000000000000001) }},
+		{"sweep", func(p *txConfirmParams) { p.Amount = nil }},
+		{"source", func(p *txConfirmParams) { p.From = append(p.From, "0x0000000000000000000000000000000000000002") }},
+		{"token", func(p *txConfirmParams) { p.Token = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48" }},
+		{"fee", func(p *txConfirmParams) { p.Fee = "fast" }},
+		{"chain", func(p *txConfirmParams) { p.Chain = chain.BSV }},
+		{"payments", func(p *txConfirmParams) { p.Payments = []confirmPayment{{To: p.To, Amount: p.Amount}} }},
+	}
+	for _, tc := range changes {
+		t.Run("changes with "+tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := testConfirmParams()
+			tc.modify(p)
+			assert.NotEqual(t, base, confirmationCode(p))
+		})
+	}
+}
+
+func TestNormalizeConfirmCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"7KQ2-M9XD", "7KQ2M9XD"},
+		{"7kq2m9xd", "7KQ2M9XD"},
+		{" 7KQ2 M9XD\n", "7KQ2M9XD"},
+		{"O1IL-0000", "01110000"},
+		{"", ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, normalizeConfirmCode(tc.input))
+		})
+	}
+}
+
+func TestSendValueUSD(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		params *txConfirmParams
+		quoter priceQuoter
+		want   float64
+		wantOK bool
+	}{
+		{
+			name:   "fiat amount",
+			params: &txConfirmParams{Chain: chain.BSV, Amount: big.NewInt(1), Fiat: &transaction.FiatConversion{Amount: "1250.50", Currency: price.CurrencyUSD}},
+			want:   1250.50,
+			wantOK: true,
+		},
+		{
+			name:   "native at quoted rate",
+			params: &txConfirmParams{Chain: chain.BSV, Amount: big.NewInt(250000000), Decimals: 8},
+			quoter: &stubPriceQuoter{quotes: []*price.Quote{testFiatQuote(40)}},
+			want:   100,
+			wantOK: true,
+		},
+		{
+			name:   "stablecoin at face value",
+			params: &txConfirmParams{Chain: chain.ETH, Amount: big.NewInt(2500000000), Decimals: 6, Token: "0xa0b8", TokenSymbol: "usdc"},
+			want:   2500,
+			wantOK: true,
+		},
+		{
+			name:   "unknown token",
+			params: &txConfirmParams{Chain: chain.ETH, Amount: big.NewInt(1), Decimals: 18, Token: "0x6b17", TokenSymbol: "MKR"},
+		},
+		{
+			name:   "eth sweep",
+			params: &txConfirmParams{Chain: chain.ETH, Decimals: 18},
+			quoter: &stubPriceQuoter{quotes: []*price.Quote{testFiatQuote(4000)}},
+		},
+		{
+			name:   "quote error",
+			params: &txConfirmParams{Chain: chain.ETH, Amount: big.NewInt(1), Decimals: 18},
+			quoter: &stubPriceQuoter{err: price.ErrUnsupportedChain},
+		},
+		{
+			name:   "no quoter",
+			params: &txConfirmParams{Chain: chain.BSV, Amount: big.NewInt(1), Decimals: 8},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := sendValueUSD(ctx, tc.quoter, tc.params)
+			assert.Equal(t, tc.wantOK, ok)
+			assert.InDelta(t, tc.want, got, 1e-9)
+		})
+	}
+}
+
+func TestConfirmCodeReason(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	quoter := &stubPriceQuoter{quotes: []*price.Quote{testFiatQuote(40)}}
+	oneBSV := &txConfirmParams{Chain: chain.BSV, Amount: big.NewInt(100000000), Decimals: 8}
+
+	assert.Empty(t, confirmCodeReason(ctx, quoter, oneBSV, 0), "zero limit disables typed codes")
+	assert.Empty(t, confirmCodeReason(ctx, quoter, oneBSV, 50), "below limit")
+	assert.Contains(t, confirmCodeReason(ctx, quoter, oneBSV, 40), "worth about 40.00 USD")
+	assert.Contains(t, confirmCodeReason(ctx, nil, oneBSV, 40), "could not be determined")
+}
+
+// newConfirmSendCmd returns a command whose config has the given typed
+// confirmation limit.
+func newConfirmSendCmd(limit float64) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	SetCmdContext(cmd, &CommandContext{
+		Cfg: &mockConfigProvider{security: config.SecurityConfig{RequireConfirmAbove: limit}},
+	})
+	return cmd, &buf
+}
+
+// withConfirmCodePrompt stubs the terminal check and typed code prompt.
+func withConfirmCodePrompt(t *testing.T, isTerminal bool, typed string, err error) {
+	t.Helper()
+	origTerminal := stdinIsTerminalFn
+	origCode := promptConfirmCodeFn
+	t.Cleanup(func() {
+		stdinIsTerminalFn = origTerminal
+		promptConfirmCodeFn = origCode
+	})
+	stdinIsTerminalFn = func() bool { return isTerminal }
+	promptConfirmCodeFn = func() (string, error) { return typed, err }
+}
+
+func TestConfirmSend(t *testing.T) { //nolint:tparallel // Subtests replace package-level prompt functions
+	ctx := context.Background()
+	params := &txConfirmParams{
+		Chain:  chain.BSV,
+		From:   []string{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		To:     "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+		Amount: big.NewInt(100000000),
+		Fee:    "100",
+		Fiat:   &transaction.FiatConversion{Amount: "5000.00", Currency: price.CurrencyUSD},
+	}
+	code := confirmationCode(params)
+
+	t.Run("below limit uses yes/no prompt", func(t *testing.T) {
+		withMockPrompts(t, nil, true)
+		withConfirmCodePrompt(t, true, "", errors.New("should not prompt for code")) //nolint:err113 // test sentinel
+
+		cmd, buf := newConfirmSendCmd(0)
+		ok, err := confirmSend(ctx, cmd, params)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.NotContains(t, buf.String(), code, "code is printed to stderr with the prompts")
+	})
+
+	t.Run("above limit accepts typed code", func(t *testing.T) {
+		withMockPrompts(t, nil, false)
+		withConfirmCodePrompt(t, true, strings.ToLower(strings.ReplaceAll(code, "-", " ")), nil)
+
+		cmd, _ := newConfirmSendCmd(1000)
+		ok, err := confirmSend(ctx, cmd, params)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("above limit rejects wrong code", func(t *testing.T) {
+		withMockPrompts(t, nil, true)
+		withConfirmCodePrompt(t, true, "y", nil)
+
+		cmd, _ := newConfirmSendCmd(1000)
+		ok, err := confirmSend(ctx, cmd, params)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("above limit needs a terminal", func(t *testing.T) {
+		withMockPrompts(t, nil, true)
+		withConfirmCodePrompt(t, false, code, nil)
+
+		cmd, _ := newConfirmSendCmd(1000)
+		ok, err := confirmSend(ctx, cmd, params)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+		assert.False(t, ok)
+
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, err, &se)
+		assert.Contains(t, se.Suggestion, "--override-confirm-limit")
+	})
+}
+
+func TestCheckUnreviewedSend(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	params := &txConfirmParams{
+		Chain:  chain.BSV,
+		Amount: big.NewInt(100000000),
+		Fiat:   &transaction.FiatConversion{Amount: "5000.00", Currency: price.CurrencyUSD},
+	}
+
+	t.Run("below limit", func(t *testing.T) {
+		t.Parallel()
+
+		cmd, _ := newConfirmSendCmd(10000)
+		require.NoError(t, checkUnreviewedSend(ctx, cmd, params, false))
+	})
+
+	t.Run("above limit refuses --yes", func(t *testing.T) {
+		t.Parallel()
+
+		cmd, _ := newConfirmSendCmd(1000)
+		err := checkUnreviewedSend(ctx, cmd, params, false)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, err, &se)
+		assert.Contains(t, se.Suggestion, "worth about 5000.00 USD")
+		assert.Contains(t, se.Suggestion, "--override-confirm-limit")
+	})
+
+	t.Run("unpriced send refuses --yes", func(t *testing.T) {
+		t.Parallel()
+
+		cmd, _ := newConfirmSendCmd(1000)
+		sweep := &txConfirmParams{Chain: chain.ETH, Decimals: 18}
+		require.ErrorIs(t, checkUnreviewedSend(ctx, cmd, sweep, false), sigilerr.ErrInvalidInput)
+	})
+
+	t.Run("override", func(t *testing.T) {
+		t.Parallel()
+
+		cmd, _ := newConfirmSendCmd(1000)
+		require.NoError(t, checkUnreviewedSend(ctx, cmd, params, true))
+	})
+}
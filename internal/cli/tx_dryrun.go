@@ -0,0 +1,183 @@
+package cli
+
+import (
+	"encoding/hex"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// checkTxDryRun rejects --dry-run with a signer or flag it cannot preview.
+func checkTxDryRun(signer wallet.SignerKind) error {
+	if !txDryRun {
+		return nil
+	}
+	if signer == wallet.SignerHardware {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--dry-run cannot be used with --signer hardware; preview the unsigned transaction with \"sigil tx build\"",
+		)
+	}
+	if txInteractiveCoins {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--interactive-coins needs the confirmation prompt, which --dry-run skips; choose the inputs with --utxo",
+		)
+	}
+	return nil
+}
+
+// dryRunTxJSON is one transaction in the JSON output of a dry run.
+type dryRunTxJSON struct {
+	Chain         chain.ID       `json:"chain"`
+	Hash          string         `json:"hash"`
+	Status        string         `json:"status"`
+	From          string         `json:"from"`
+	To            string         `json:"to"`
+	Amount        string         `json:"amount"`
+	Token         string         `json:"token,omitempty"`
+	Fee           string         `json:"fee"`
+	FeeRate       uint64         `json:"fee_rate,omitempty"`
+	CoinSelection string         `json:"coin_selection,omitempty"`
+	Size          int            `json:"size"`
+	Inputs        []outpointJSON `json:"inputs,omitempty"`
+	Outputs       []outpointJSON `json:"outputs,omitempty"`
+	ChangeAddress string         `json:"change_address,omitempty"`
+	Nonce         *uint64        `json:"nonce,omitempty"`
+	GasLimit      uint64         `json:"gas_limit,omitempty"`
+	GasPrice      string         `json:"gas_price,omitempty"`
+	RawHex        string         `json:"raw_hex"`
+}
+
+// newDryRunTxJSON converts the result of a dry run for JSON output.
+func newDryRunTxJSON(result *transaction.SendResult) dryRunTxJSON {
+	tx := dryRunTxJSON{
+		Chain:         result.ChainID,
+		Hash:          result.Hash,
+		Status:        result.Status,
+		From:          result.From,
+		To:            result.To,
+		Amount:        result.Amount,
+		Token:         result.Token,
+		Fee:           result.Fee,
+		FeeRate:       result.FeeRate,
+		CoinSelection: result.CoinSelection,
+	}
+	if p := result.Preview; p != nil {
+		tx.Size = len(p.Raw)
+		tx.Inputs = outpointsJSON(p.Inputs)
+		tx.Outputs = outpointsJSON(p.Outputs)
+		tx.ChangeAddress = changeOutputAddress(p)
+		tx.RawHex = hex.EncodeToString(p.Raw)
+		if result.ChainID == chain.ETH {
+			nonce := p.Nonce
+			tx.Nonce = &nonce
+			tx.GasLimit = result.GasUsed
+			tx.GasPrice = result.GasPrice
+		}
+	}
+	return tx
+}
+
+// changeOutputAddress returns the change address of a previewed transaction,
+// or "" when none of its outputs pays it.
+func changeOutputAddress(p *transaction.SendPreview) string {
+	for _, u := range p.Outputs {
+		if p.ChangeAddress != "" && u.Address == p.ChangeAddress {
+			return p.ChangeAddress
+		}
+	}
+	return ""
+}
+
+// displayDryRunResults shows the transactions a dry run built and signed
+// without broadcasting them: one for a single send or a BSV batch, one per
+// recipient for an ETH batch.
+func displayDryRunResults(cmd *cobra.Command, results []*transaction.SendResult) {
+	if len(results) == 0 {
+		return
+	}
+	w := cmd.OutOrStdout()
+
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		if len(results) == 1 {
+			_ = writeJSON(w, newDryRunTxJSON(results[0]))
+			return
+		}
+		txs := make([]dryRunTxJSON, len(results))
+		for i, r := range results {
+			txs[i] = newDryRunTxJSON(r)
+		}
+		_ = writeJSON(w, struct {
+			Chain        chain.ID       `json:"chain"`
+			From         string         `json:"from"`
+			Transactions []dryRunTxJSON `json:"transactions"`
+		}{Chain: results[0].ChainID, From: results[0].From, Transactions: txs})
+		return
+	}
+
+	if len(results) == 1 {
+		outln(w, "\nDry run: the transaction was built and signed but not broadcast.")
+	} else {
+		out(w, "\nDry run: %d transactions were built and signed but not broadcast.\n", len(results))
+	}
+	for _, r := range results {
+		displayDryRunText(w, r)
+	}
+	outln(w)
+	outln(w, "Run the same command without --dry-run to send it.")
+}
+
+// displayDryRunText prints the preview of one dry-run transaction.
+func displayDryRunText(w io.Writer, result *transaction.SendResult) {
+	symbol := strings.ToUpper(string(result.ChainID))
+	amountSymbol := symbol
+	if result.Token != "" {
+		amountSymbol = result.Token
+	}
+	p := result.Preview
+
+	outln(w)
+	out(w, "  Hash:      %s\n", result.Hash)
+	out(w, "  From:      %s\n", result.From)
+	out(w, "  To:        %s\n", result.To)
+	out(w, "  Amount:    %s %s\n", result.Amount, amountSymbol)
+	if result.FeeRate > 0 {
+		out(w, "  Fee:       %s %s (%d sat/KB)\n", result.Fee, symbol, result.FeeRate)
+	} else {
+		out(w, "  Fee:       %s %s (estimated)\n", result.Fee, symbol)
+	}
+	if result.ChainID == chain.ETH {
+		out(w, "  Nonce:     %d\n", p.Nonce)
+		out(w, "  Gas Limit: %d\n", result.GasUsed)
+		out(w, "  Gas Price: %s\n", result.GasPrice)
+	}
+	out(w, "  Size:      %d bytes\n", len(p.Raw))
+
+	if len(p.Inputs) > 0 {
+		out(w, "  Inputs:    %d\n", len(p.Inputs))
+		for _, u := range p.Inputs {
+			out(w, "    %s:%d  %s sat  %s\n", u.TxID, u.Vout, formatSatsWithCommas(u.Amount), u.Address)
+		}
+	}
+	if len(p.Outputs) > 0 {
+		out(w, "  Outputs:   %d\n", len(p.Outputs))
+		change := changeOutputAddress(p)
+		for _, u := range p.Outputs {
+			suffix := ""
+			if change != "" && u.Address == change {
+				suffix = "  (change)"
+			}
+			out(w, "    #%d  %s sat  %s%s\n", u.Vout, formatSatsWithCommas(u.Amount), u.Address, suffix)
+		}
+	}
+	outln(w, "  Raw:")
+	out(w, "    %s\n", hex.EncodeToString(p.Raw))
+}
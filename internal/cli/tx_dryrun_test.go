@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:paralleltest // Mutates package-level tx flags
+func TestCheckTxDryRun(t *testing.T) {
+	t.Cleanup(func() { txDryRun, txInteractiveCoins = false, false })
+
+	txDryRun = false
+	require.NoError(t, checkTxDryRun(wallet.SignerHardware))
+
+	txDryRun = true
+	require.NoError(t, checkTxDryRun(wallet.SignerSeed))
+	require.ErrorIs(t, checkTxDryRun(wallet.SignerHardware), sigilerr.ErrInvalidInput)
+
+	txInteractiveCoins = true
+	require.ErrorIs(t, checkTxDryRun(wallet.SignerSeed), sigilerr.ErrInvalidInput)
+}
+
+func TestDisplayDryRunResults(t *testing.T) {
+	t.Parallel()
+
+	bsvResult := &transaction.SendResult{
+		Hash: "dryhash", From: "1From", To: "1To", Amount: "0.001", Fee: "0.00000023",
+		Status: transaction.StatusDryRun, ChainID: chain.BSV, FeeRate: 100,
+		Preview: &transaction.SendPreview{
+			Raw:    []byte{0x01, 0x00, 0xab},
+			Inputs: []chain.UTXO{{TxID: "aa", Vout: 1, Amount: 150000, Address: "1From"}},
+			Outputs: []chain.UTXO{
+				{TxID: "dryhash", Vout: 0, Amount: 100000, Address: "1To"},
+				{TxID: "dryhash", Vout: 1, Amount: 49977, Address: "1Change"},
+			},
+			ChangeAddress: "1Change",
+		},
+	}
+
+	t.Run("bsv text", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayDryRunResults(cmd, []*transaction.SendResult{bsvResult})
+		assert.Contains(t, buf.String(), "built and signed but not broadcast")
+		assert.Contains(t, buf.String(), "Fee:       0.00000023 BSV (100 sat/KB)")
+		assert.Contains(t, buf.String(), "aa:1  150,000 sat  1From")
+		assert.Contains(t, buf.String(), "#1  49,977 sat  1Change  (change)")
+		assert.NotContains(t, buf.String(), "1To  (change)")
+		assert.Contains(t, buf.String(), "0100ab")
+		assert.NotContains(t, buf.String(), "Nonce")
+	})
+
+	t.Run("bsv json", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatJSON)
+		cmd.SetOut(&buf)
+		displayDryRunResults(cmd, []*transaction.SendResult{bsvResult})
+
+		var got dryRunTxJSON
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		assert.Equal(t, transaction.StatusDryRun, got.Status)
+		assert.Equal(t, "0100ab", got.RawHex)
+		assert.Equal(t, 3, got.Size)
+		assert.Len(t, got.Inputs, 1)
+		assert.Len(t, got.Outputs, 2)
+		assert.Equal(t, "1Change", got.ChangeAddress)
+		assert.Nil(t, got.Nonce)
+	})
+
+	t.Run("eth batch json", func(t *testing.T) {
+		t.Parallel()
+		results := []*transaction.SendResult{
+			{Hash: "0x1", Status: transaction.StatusDryRun, ChainID: chain.ETH, GasUsed: 21000, GasPrice: "20.00 Gwei",
+				Preview: &transaction.SendPreview{Raw: []byte{0xf8}, Nonce: 0}},
+			{Hash: "0x2", Status: transaction.StatusDryRun, ChainID: chain.ETH, GasUsed: 21000, GasPrice: "20.00 Gwei",
+				Preview: &transaction.SendPreview{Raw: []byte{0xf8}, Nonce: 1}},
+		}
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatJSON)
+		cmd.SetOut(&buf)
+		displayDryRunResults(cmd, results)
+
+		var got struct {
+			Transactions []dryRunTxJSON `json:"transactions"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Len(t, got.Transactions, 2)
+		require.NotNil(t, got.Transactions[0].Nonce, "nonce 0 is still shown")
+		assert.Equal(t, uint64(0), *got.Transactions[0].Nonce)
+		assert.Equal(t, uint64(1), *got.Transactions[1].Nonce)
+		assert.Equal(t, uint64(21000), got.Transactions[1].GasLimit)
+	})
+}
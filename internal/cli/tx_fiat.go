@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/price"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	// defaultMaxSlippage is the default --max-slippage, in percent.
+	defaultMaxSlippage = 1.0
+
+	// maxFiatQuoteAge rejects provider rates that were already this old when fetched.
+	maxFiatQuoteAge = 15 * time.Minute
+)
+
+// priceQuoter fetches exchange rates for fiat-denominated sends.
+type priceQuoter interface {
+	Quote(ctx context.Context, chainID chain.ID, currency string) (*price.Quote, error)
+}
+
+// newPriceQuoterFn creates the exchange rate provider (replaceable in tests).
+//
+//nolint:gochecknoglobals // Allows tests to stub exchange rate lookups
+var newPriceQuoterFn = newPriceQuoter
+
+// newPriceQuoter builds a provider backed by WhatsOnChain for BSV and, when an
+// API key is configured, Etherscan for ETH. Rates always come from mainnet.
+func newPriceQuoter(ctx context.Context, cc *CommandContext) priceQuoter {
+	bsvClient := bsv.NewClient(ctx, &bsv.ClientOptions{
+		APIKey: cc.Cfg.GetBSVAPIKey(),
+		Logger: cc.Log,
+	})
+
+	var ethSource price.ETHSource
+	if apiKey := cc.Cfg.GetETHEtherscanAPIKey(); apiKey != "" {
+		if client, err := etherscan.NewClient(apiKey, nil); err == nil {
+			ethSource = client
+		}
+	}
+	return price.NewProvider(bsvClient, ethSource)
+}
+
+// nativeDecimals returns the number of decimal places of a chain's native coin.
+func nativeDecimals(chainID chain.ID) int {
+	if chainID == chain.ETH {
+		return 18
+	}
+	return 8
+}
+
+// convertFiatAmount quotes the current exchange rate and converts a fiat
+// amount into the chain's native coin, rounding down to the smallest unit.
+func convertFiatAmount(ctx context.Context, quoter priceQuoter, chainID chain.ID, fiat *price.FiatAmount) (*transaction.FiatConversion, string, error) {
+	quote, err := quoter.Quote(ctx, chainID, fiat.Currency)
+	if err != nil {
+		if chainID == chain.ETH && errors.Is(err, price.ErrUnsupportedChain) {
+			return nil, "", sigilerr.WithSuggestion(
+				etherscan.ErrAPIKeyRequired,
+				"Set ETHERSCAN_API_KEY to send ETH amounts in USD",
+			)
+		}
+		return nil, "", fmt.Errorf("fetching %s exchange rate: %w", chainID, err)
+	}
+
+	if age := quote.Age(quote.FetchedAt); age > maxFiatQuoteAge {
+		return nil, "", sigilerr.WithSuggestion(
+			sigilerr.WithDetails(sigilerr.ErrPriceSlippage, map[string]string{
+				"source":   quote.Source,
+				"rate_age": age.Round(time.Second).String(),
+			}),
+			fmt.Sprintf("the %s exchange rate from %s is %s old; retry later or send a coin amount instead",
+				chainID, quote.Source, age.Round(time.Minute)),
+		)
+	}
+
+	decimals := nativeDecimals(chainID)
+	units, err := quote.CoinAmount(fiat, decimals)
+	if err != nil {
+		return nil, "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+
+	conv := &transaction.FiatConversion{
+		Amount:   fiat.String(),
+		Currency: fiat.Currency,
+		Quote:    quote,
+	}
+	return conv, chain.FormatDecimalAmount(units, decimals), nil
+}
+
+// checkFiatSlippage re-quotes the exchange rate just before broadcasting and
+// refuses to send if it moved more than maxSlippage percent since the amount
+// was converted, so a stale rate never decides how much is sent.
+func checkFiatSlippage(ctx context.Context, quoter priceQuoter, fiat *transaction.FiatConversion, maxSlippage float64) error {
+	current, err := quoter.Quote(ctx, fiat.Quote.Chain, fiat.Currency)
+	if err != nil {
+		return fmt.Errorf("re-checking %s exchange rate: %w", fiat.Quote.Chain, err)
+	}
+
+	moved := price.Slippage(fiat.Quote, current)
+	if moved <= maxSlippage {
+		return nil
+	}
+	return sigilerr.WithSuggestion(
+		sigilerr.WithDetails(sigilerr.ErrPriceSlippage, map[string]string{
+			"quoted_rate":  formatFiatRate(fiat.Quote.Rate),
+			"current_rate": formatFiatRate(current.Rate),
+			"moved":        fmt.Sprintf("%.2f%%", moved),
+		}),
+		fmt.Sprintf("the %s rate moved %.2f%% since it was quoted (limit %.2f%%); rerun to send at the current rate or raise --max-slippage",
+			fiat.Quote.Chain, moved, maxSlippage),
+	)
+}
+
+// formatFiatRate formats a fiat-per-coin rate with cent precision.
+func formatFiatRate(rate float64) string {
+	return fmt.Sprintf("%.2f", rate)
+}
+
+// describeFiatQuote renders an exchange rate line such as
+// "1 BSV = 52.31 USD (as of 2026-01-02 03:04:05 UTC, whatsonchain)".
+func describeFiatQuote(q *price.Quote) string {
+	return fmt.Sprintf("1 %s = %s %s (as of %s, %s)",
+		strings.ToUpper(q.Chain.String()), formatFiatRate(q.Rate), q.Currency,
+		q.Time.UTC().Format("2006-01-02 15:04:05 MST"), q.Source)
+}
+
+// displayFiatConversionText writes the fiat value and rate lines shared by the
+// confirmation screens and send results. The label column width matches the caller.
+func displayFiatConversionText(w io.Writer, fiat *transaction.FiatConversion, labelWidth int) {
+	if fiat == nil {
+		return
+	}
+	out(w, "  %-*s %s %s\n", labelWidth, "Value:", fiat.Amount, fiat.Currency)
+	out(w, "  %-*s %s\n", labelWidth, "Price:", describeFiatQuote(fiat.Quote))
+}
+
+// fiatConversionJSON is the JSON form of a fiat conversion in send results.
+type fiatConversionJSON struct {
+	Amount     string    `json:"amount"`
+	Currency   string    `json:"currency"`
+	Rate       float64   `json:"rate"`
+	RateTime   time.Time `json:"rate_time"`
+	RateSource string    `json:"rate_source"`
+}
+
+// newFiatConversionJSON converts a fiat conversion for JSON output, or nil.
+func newFiatConversionJSON(fiat *transaction.FiatConversion) *fiatConversionJSON {
+	if fiat == nil || fiat.Quote == nil {
+		return nil
+	}
+	return &fiatConversionJSON{
+		Amount:     fiat.Amount,
+		Currency:   fiat.Currency,
+		Rate:       fiat.Quote.Rate,
+		RateTime:   fiat.Quote.Time,
+		RateSource: fiat.Quote.Source,
+	}
+}
+
+// resolveTxFiatQuote fetches the price that the approximate value of a send
+// is shown at, in the --fiat or price.fiat currency. It returns nil when no
+// currency is set, for token transfers, and when no price is available.
+func resolveTxFiatQuote(ctx context.Context, cmd *cobra.Command, chainID chain.ID) (*price.Quote, error) {
+	cc := GetCmdContext(cmd)
+	currency, err := resolveFiatCurrency(cc.Cfg, txFiat)
+	if err != nil || currency == "" || txToken != "" {
+		return nil, err
+	}
+	quotes := fetchFiatQuotes(ctx, cc, cmd.ErrOrStderr(), []chain.ID{chainID}, currency, false)
+	return quotes[chainID], nil
+}
+
+// txFiatValue values a send amount for the confirmation screen and result.
+// It returns nil without a quote, and for fiat-denominated sends already
+// shown in the same currency.
+func txFiatValue(q *price.Quote, amount string, denominated *transaction.FiatConversion) *transaction.FiatConversion {
+	if q == nil || (denominated != nil && denominated.Currency == q.Currency) {
+		return nil
+	}
+	value, err := fiatValue(q, amount, nativeDecimals(q.Chain))
+	if err != nil {
+		return nil
+	}
+	return &transaction.FiatConversion{Amount: value, Currency: q.Currency, Quote: q}
+}
+
+// displayFiatValueText writes the approximate value and rate lines of a send
+// in a display currency. The label column width matches the caller.
+func displayFiatValueText(w io.Writer, v *transaction.FiatConversion, labelWidth int) {
+	if v == nil {
+		return
+	}
+	out(w, "  %-*s ≈ %s %s\n", labelWidth, "Value:", v.Amount, v.Currency)
+	out(w, "  %-*s %s\n", labelWidth, "Price:", describeFiatQuote(v.Quote))
+}
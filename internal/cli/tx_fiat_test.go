@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/price"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// stubPriceQuoter returns queued quotes in order, repeating the last one.
+type stubPriceQuoter struct {
+	quotes []*price.Quote
+	err    error
+	calls  int
+}
+
+func (s *stubPriceQuoter) Quote(_ context.Context, chainID chain.ID, _ string) (*price.Quote, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	q := s.quotes[min(s.calls, len(s.quotes)-1)]
+	s.calls++
+	cp := *q
+	cp.Chain = chainID
+	return &cp, nil
+}
+
+func testFiatQuote(rate float64) *price.Quote {
+	captured := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &price.Quote{
+		Chain:     chain.BSV,
+		Currency:  price.CurrencyUSD,
+		Rate:      rate,
+		Time:      captured,
+		FetchedAt: captured.Add(30 * time.Second),
+		Source:    "whatsonchain",
+	}
+}
+
+func TestConvertFiatAmount(t *testing.T) {
+	t.Parallel()
+
+	fiat, ok, err := price.ParseFiatAmount("50usd")
+	require.True(t, ok)
+	require.NoError(t, err)
+
+	t.Run("converts bsv at quoted rate", func(t *testing.T) {
+		t.Parallel()
+
+		conv, amount, err := convertFiatAmount(context.Background(), &stubPriceQuoter{quotes: []*price.Quote{testFiatQuote(40)}}, chain.BSV, fiat)
+		require.NoError(t, err)
+		assert.Equal(t, "1.25", amount)
+		assert.Equal(t, "50.00", conv.Amount)
+		assert.Equal(t, price.CurrencyUSD, conv.Currency)
+		assert.InDelta(t, 40.0, conv.Quote.Rate, 1e-9)
+	})
+
+	t.Run("converts eth to wei precision", func(t *testing.T) {
+		t.Parallel()
+
+		_, amount, err := convertFiatAmount(context.Background(), &stubPriceQuoter{quotes: []*price.Quote{testFiatQuote(3000)}}, chain.ETH, fiat)
+		require.NoError(t, err)
+		assert.Equal(t, "0.016666666666666666", amount)
+	})
+
+	t.Run("eth without etherscan key", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := convertFiatAmount(context.Background(), &stubPriceQuoter{err: price.ErrUnsupportedChain}, chain.ETH, fiat)
+		require.ErrorIs(t, err, etherscan.ErrAPIKeyRequired)
+	})
+
+	t.Run("stale provider rate is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		stale := testFiatQuote(40)
+		stale.FetchedAt = stale.Time.Add(time.Hour)
+		_, _, err := convertFiatAmount(context.Background(), &stubPriceQuoter{quotes: []*price.Quote{stale}}, chain.BSV, fiat)
+		require.ErrorIs(t, err, sigilerr.ErrPriceSlippage)
+	})
+}
+
+func TestCheckFiatSlippage(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		currentRate float64
+		maxSlippage float64
+		wantErr     bool
+	}{
+		{name: "unchanged", currentRate: 40, maxSlippage: 1},
+		{name: "within limit", currentRate: 40.3, maxSlippage: 1},
+		{name: "drop beyond limit", currentRate: 39, maxSlippage: 1, wantErr: true},
+		{name: "rise beyond limit", currentRate: 41, maxSlippage: 2, wantErr: true},
+		{name: "zero tolerance", currentRate: 40.01, maxSlippage: 0, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fiat := &transaction.FiatConversion{Amount: "50.00", Currency: price.CurrencyUSD, Quote: testFiatQuote(40)}
+			quoter := &stubPriceQuoter{quotes: []*price.Quote{testFiatQuote(tc.currentRate)}}
+
+			err := checkFiatSlippage(context.Background(), quoter, fiat, tc.maxSlippage)
+			if tc.wantErr {
+				require.ErrorIs(t, err, sigilerr.ErrPriceSlippage)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestDisplayFiatConversion(t *testing.T) {
+	t.Parallel()
+
+	fiat := &transaction.FiatConversion{Amount: "50.00", Currency: price.CurrencyUSD, Quote: testFiatQuote(52.31)}
+
+	var buf bytes.Buffer
+	displayFiatConversionText(&buf, fiat, 7)
+	assert.Equal(t,
+		"  Value:  50.00 USD\n  Price:  1 BSV = 52.31 USD (as of 2026-01-02 03:04:05 UTC, whatsonchain)\n",
+		buf.String())
+
+	buf.Reset()
+	displayFiatConversionText(&buf, nil, 7)
+	assert.Empty(t, buf.String())
+
+	payload := newFiatConversionJSON(fiat)
+	require.NotNil(t, payload)
+	assert.Equal(t, "50.00", payload.Amount)
+	assert.Equal(t, "whatsonchain", payload.RateSource)
+	assert.Nil(t, newFiatConversionJSON(nil))
+}
+
+func TestDisplayBSVTxResultJSON_Fiat(t *testing.T) {
+	t.Parallel()
+
+	fiat := &transaction.FiatConversion{Amount: "50.00", Currency: price.CurrencyUSD, Quote: testFiatQuote(40)}
+
+	var buf bytes.Buffer
+	displayBSVTxResultJSON(&buf, &chain.TransactionResult{Hash: "bbbb", Amount: "1.25"}, "", nil, fiat)
+	assert.Contains(t, buf.String(), `"fiat"`)
+	assert.Contains(t, buf.String(), `"rate_time": "2026-01-02T03:04:05Z"`)
+}
@@ -0,0 +1,231 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/cosign"
+	"github.com/mrz1836/sigil/internal/hooks"
+	"github.com/mrz1836/sigil/internal/price"
+	"github.com/mrz1836/sigil/internal/wallet"
+	_ "github.com/mrz1836/sigil/internal/wallet/ledger" // registers the Ledger transport
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// openHardwareSignerFn opens the hardware wallet with the given ID, or the
+// only connected one for "". Tests replace it with a fake device.
+//
+//nolint:gochecknoglobals // Test seam for hardware wallet access
+var openHardwareSignerFn = wallet.OpenHardware
+
+// resolveTxSigner parses --signer and checks that the send can use it. A
+// hardware wallet signs single-recipient ETH sends made interactively.
+func resolveTxSigner(cc *CommandContext, chainID chain.ID) (wallet.SignerKind, error) {
+	signer, err := wallet.ParseSignerKind(txSigner)
+	if err != nil {
+		return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+	if signer != wallet.SignerHardware {
+		if txDevice != "" {
+			return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--device requires --signer hardware")
+		}
+		return signer, nil
+	}
+
+	var reason string
+	_, isFiat, _ := price.ParseFiatAmount(txAmount)
+	switch {
+	case chainID != chain.ETH:
+		reason = fmt.Sprintf("--signer hardware supports ETH, not %s; sign other chains from a file made by: sigil tx build",
+			strings.ToUpper(string(chainID)))
+	case cc.AgentCred != nil || cc.AgentXpub != "":
+		reason = "--signer hardware needs a person to confirm on the device; agents cannot use it"
+	case len(txPayments) > 0 || len(txSplit) > 0:
+		reason = "--signer hardware sends to a single recipient; drop the extra --to/--amount pairs or --payments-file"
+	case len(txUTXOs) > 0 || txInteractiveCoins || txEnvelope != "":
+		reason = "--signer hardware cannot be combined with --utxo, --interactive-coins, or --envelope"
+	case isFiat:
+		reason = "--signer hardware needs a coin amount, not a USD amount"
+	case txAccount != 0:
+		reason = "--signer hardware signs for the wallet's default account; drop --account"
+	default:
+		return signer, nil
+	}
+	return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, reason)
+}
+
+// runTxSendHardware builds the send from the wallet's addresses, has a
+// hardware wallet sign it, and broadcasts it. The wallet is never unlocked.
+func runTxSendHardware(ctx context.Context, cmd *cobra.Command, chainID chain.ID, token *eth.TokenMetadata, nonce *uint64) error {
+	cc := GetCmdContext(cmd)
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(txWallet)
+	if err != nil {
+		return fmt.Errorf("loading wallet: %w", err)
+	}
+	applyWalletSettings(cmd, wlt)
+
+	p := offlineBuild{
+		to:         txTo,
+		amount:     txAmount,
+		gasSpeed:   txGasSpeed,
+		noChecksum: txNoChecksum,
+		token:      token,
+		maxFeeRate: txMaxFeeRate,
+	}
+	tx, err := buildOfflineTx(ctx, cmd, wlt, chainID, p)
+	if err != nil {
+		return err
+	}
+	if nonce != nil {
+		tx.ETH.Nonce = *nonce
+	}
+
+	paths, err := wlt.SigningPaths(tx)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("%v; a hardware wallet can only sign for addresses with a derivation path, "+
+				"such as those of a wallet imported with: sigil wallet import-watch --xpub", err))
+	}
+
+	if !txConfirm {
+		w := cmd.ErrOrStderr()
+		out(w, "\nSign transaction on a hardware wallet for wallet '%s'\n", txWallet)
+		displayOfflineSummary(w, tx)
+		if !promptConfirmFn() {
+			outln(cmd.OutOrStdout(), "Transaction canceled.")
+			return nil
+		}
+	}
+
+	if err := approveOfflineTx(ctx, cc, storage, tx); err != nil {
+		return err
+	}
+
+	signer, err := openHardwareSignerFn(ctx, txDevice)
+	if err != nil {
+		return hardwareError(err)
+	}
+	defer func() { _ = signer.Close() }()
+
+	if err := confirmHardwarePaths(ctx, cmd, signer, chainID, paths); err != nil {
+		return err
+	}
+
+	outln(cmd.ErrOrStderr(), "Review and approve the transaction on the device...")
+	signed, err := signer.Sign(ctx, &wallet.HardwareSignRequest{Tx: tx, Paths: paths})
+	if err != nil {
+		return fmt.Errorf("signing on %s: %w", signer.Device(), err)
+	}
+
+	hash, err := broadcastSignedTx(ctx, cmd, signed)
+	if err != nil {
+		return err
+	}
+	if err := displayBroadcastResult(cmd, signed, hash); err != nil {
+		return err
+	}
+	sent := &hooks.Sent{
+		Chain:  string(tx.Chain),
+		Wallet: txWallet,
+		Hash:   hash,
+		From:   tx.From,
+		To:     tx.To,
+		Amount: formatUnits(tx.Amount, tx.Decimals),
+		Fee:    formatUnits(tx.Fee, offlineFeeDecimals(tx.Chain)),
+		Status: "pending",
+	}
+	if tx.Token != "" {
+		sent.Token = tx.Symbol
+	}
+	runPostSendHook(ctx, cc, sent)
+	return nil
+}
+
+// approveOfflineTx puts a transaction built for an external signer through
+// the same pre_send hook and co-sign approval as a send signed by sigil.
+func approveOfflineTx(ctx context.Context, cc *CommandContext, storage *wallet.FileStorage, tx *chain.UnsignedTx) error {
+	txService, err := newTransactionService(cc, storage)
+	if err != nil {
+		return err
+	}
+	summary := &cosign.Summary{
+		Chain:   string(tx.Chain),
+		Wallet:  tx.Wallet,
+		From:    tx.From,
+		To:      tx.To,
+		Amount:  tx.Amount,
+		Display: formatUnits(tx.Amount, tx.Decimals),
+		Token:   tx.Token,
+		Fee:     tx.Fee,
+	}
+	if tx.BSV != nil {
+		summary.FeeRate = tx.BSV.FeeRate
+		for _, in := range tx.BSV.Inputs {
+			summary.Inputs = append(summary.Inputs, cosign.Input{TxID: in.TxID, Vout: in.Vout, Amount: in.Amount})
+		}
+	}
+	return txService.Approve(ctx, summary)
+}
+
+// confirmHardwarePaths has the device show the path and address of every key
+// it will sign with, and checks each address is the wallet's. A mismatch
+// means the device holds a different seed than the wallet was created from.
+func confirmHardwarePaths(ctx context.Context, cmd *cobra.Command, signer wallet.HardwareSigner, chainID chain.ID, paths map[string]string) error {
+	addresses := make([]string, 0, len(paths))
+	for addr := range paths {
+		addresses = append(addresses, addr)
+	}
+	sort.Strings(addresses)
+
+	w := cmd.ErrOrStderr()
+	for _, addr := range addresses {
+		path := paths[addr]
+		out(w, "Confirm on %s: %s -> %s\n", signer.Device(), path, addr)
+		got, err := signer.Address(ctx, chainID, path, true)
+		if err != nil {
+			return fmt.Errorf("confirming %s on %s: %w", path, signer.Device(), err)
+		}
+		if !strings.EqualFold(got, addr) {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrWalletIntegrity,
+				fmt.Sprintf("the device derives %s at %s but the wallet has %s; connect the device that holds this wallet's seed",
+					got, path, addr),
+			)
+		}
+	}
+	return nil
+}
+
+// hardwareError adds a suggestion to a hardware wallet discovery error.
+func hardwareError(err error) error {
+	switch {
+	case errors.Is(err, wallet.ErrNoHardwareTransport):
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrHardwareUnavailable,
+			"hardware wallets are reached over USB on Linux only; on this platform sign with the device's own "+
+				"software from a file made by: sigil tx build",
+		)
+	case errors.Is(err, wallet.ErrHardwareDeviceAmbiguous):
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrHardwareUnavailable,
+			fmt.Sprintf("%v; choose one with --device (list them with: sigil wallet devices)", err),
+		)
+	case errors.Is(err, wallet.ErrNoHardwareDevice):
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrHardwareUnavailable,
+			fmt.Sprintf("%v; connect and unlock the Ledger and open its Ethereum app", err),
+		)
+	default:
+		return sigilerr.WithSuggestion(sigilerr.ErrHardwareUnavailable, err.Error())
+	}
+}
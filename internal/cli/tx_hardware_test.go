@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// fakeHardwareSigner is a HardwareSigner that derives addresses from a map.
+type fakeHardwareSigner struct {
+	addresses map[string]string
+	shown     []string
+}
+
+func (f *fakeHardwareSigner) Device() wallet.HardwareDevice {
+	return wallet.HardwareDevice{Transport: "test", ID: "1", Vendor: "ledger", Model: "Nano X"}
+}
+
+func (f *fakeHardwareSigner) Address(_ context.Context, _ chain.ID, path string, display bool) (string, error) {
+	if display {
+		f.shown = append(f.shown, path)
+	}
+	return f.addresses[path], nil
+}
+
+func (f *fakeHardwareSigner) Sign(context.Context, *wallet.HardwareSignRequest) (*chain.SignedRawTx, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeHardwareSigner) Close() error { return nil }
+
+//nolint:paralleltest // Mutates package-level tx flags
+func TestResolveTxSigner(t *testing.T) {
+	t.Cleanup(func() {
+		txSigner, txDevice, txAmount, txPayments = "seed", "", "", nil
+	})
+	cc := &CommandContext{}
+
+	txSigner, txDevice, txAmount = "seed", "", "0.1"
+	signer, err := resolveTxSigner(cc, chain.ETH)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.SignerSeed, signer)
+
+	txDevice = "abc"
+	_, err = resolveTxSigner(cc, chain.ETH)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	txSigner = "hardware"
+	signer, err = resolveTxSigner(cc, chain.ETH)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.SignerHardware, signer)
+
+	_, err = resolveTxSigner(cc, chain.BSV)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	txSigner = "usb"
+	_, err = resolveTxSigner(cc, chain.ETH)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	txSigner, txDevice = "hardware", ""
+	_, err = resolveTxSigner(cc, chain.BTC)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	_, err = resolveTxSigner(&CommandContext{AgentXpub: "xpub"}, chain.ETH)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	txAmount = "50usd"
+	_, err = resolveTxSigner(cc, chain.ETH)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "USD")
+}
+
+func TestConfirmHardwarePaths(t *testing.T) {
+	t.Parallel()
+
+	paths := map[string]string{"1bbb": "m/44'/236'/0'/1/0", "1aaa": "m/44'/236'/0'/0/0"}
+	cmd := newTestCmdWithContext(output.FormatText)
+	var errBuf bytes.Buffer
+	cmd.SetErr(&errBuf)
+
+	signer := &fakeHardwareSigner{addresses: map[string]string{
+		"m/44'/236'/0'/0/0": "1aaa",
+		"m/44'/236'/0'/1/0": "1bbb",
+	}}
+	require.NoError(t, confirmHardwarePaths(context.Background(), cmd, signer, chain.BSV, paths))
+	assert.Equal(t, []string{"m/44'/236'/0'/0/0", "m/44'/236'/0'/1/0"}, signer.shown)
+	assert.Contains(t, errBuf.String(), "Confirm on ledger Nano X (1): m/44'/236'/0'/0/0 -> 1aaa")
+
+	// A device holding another seed derives different addresses.
+	other := &fakeHardwareSigner{addresses: map[string]string{"m/44'/236'/0'/0/0": "1zzz"}}
+	err := confirmHardwarePaths(context.Background(), cmd, other, chain.BSV, paths)
+	require.ErrorIs(t, err, sigilerr.ErrWalletIntegrity)
+}
+
+func TestHardwareError(t *testing.T) {
+	t.Parallel()
+
+	for _, err := range []error{
+		wallet.ErrNoHardwareTransport,
+		wallet.ErrNoHardwareDevice,
+		wallet.ErrHardwareDeviceAmbiguous,
+		errors.New("usb busy"),
+	} {
+		got := hardwareError(err)
+		require.ErrorIs(t, got, sigilerr.ErrHardwareUnavailable)
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, got, &se)
+		assert.NotEmpty(t, se.Suggestion)
+	}
+}
+
+//nolint:paralleltest // Replaces discoverHardwareFn
+func TestRunWalletDevices(t *testing.T) {
+	orig := discoverHardwareFn
+	t.Cleanup(func() { discoverHardwareFn = orig })
+
+	discoverHardwareFn = func(context.Context) ([]wallet.HardwareDevice, error) {
+		return []wallet.HardwareDevice{{Transport: "ledger-hid", ID: "0001:0007:00", Vendor: "ledger", Model: "Nano X"}}, nil
+	}
+	cmd := newTestCmdWithContext(output.FormatText)
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	require.NoError(t, runWalletDevices(cmd, nil))
+	assert.Contains(t, buf.String(), "0001:0007:00")
+
+	cmd = newTestCmdWithContext(output.FormatJSON)
+	buf.Reset()
+	cmd.SetOut(&buf)
+	require.NoError(t, runWalletDevices(cmd, nil))
+	assert.Contains(t, buf.String(), `"vendor": "ledger"`)
+
+	discoverHardwareFn = func(context.Context) ([]wallet.HardwareDevice, error) {
+		return nil, wallet.ErrNoHardwareTransport
+	}
+	err := runWalletDevices(newTestCmdWithContext(output.FormatText), nil)
+	require.ErrorIs(t, err, sigilerr.ErrHardwareUnavailable)
+}
@@ -0,0 +1,358 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/txjournal"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// txHistoryWallet is the wallet whose history is shown.
+	txHistoryWallet string
+	// txHistoryChain limits the history to one chain.
+	txHistoryChain string
+	// txHistoryBackfill imports past transactions from block explorers.
+	txHistoryBackfill bool
+	// txHistoryLimit caps the number of entries shown.
+	txHistoryLimit int
+)
+
+// txHistoryCmd lists the transactions in a wallet's journal.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var txHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List a wallet's transactions",
+	Long: `List the transactions recorded in a wallet's journal, newest first.
+
+Every transaction sigil broadcasts is recorded in
+~/.sigil/wallets/<name>/txhistory.json with its hash, amount, fee, recipient,
+time, and status. Pending entries are checked against the chain before they
+are listed.
+
+Use --backfill to import transactions sigil did not send, or that predate the
+journal: ETH history comes from Etherscan (the newest 1000 transactions per
+address) and BSV history from WhatsOnChain. BSV backfill records the
+transaction ID and block only. Sends already in the journal are kept as
+recorded.
+
+Sends whose recipients are all addresses of the same wallet, such as
+consolidations, are listed with direction "internal". They are neither
+income nor expense: only their fee leaves the wallet.`,
+	Example: `  # Show recent transactions
+  sigil tx history --wallet main
+
+  # Import ETH history from Etherscan, then show it
+  sigil tx history --wallet main --chain eth --backfill`,
+	Args: cobra.NoArgs,
+	RunE: runTxHistory,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	txCmd.AddCommand(txHistoryCmd)
+
+	txHistoryCmd.Flags().StringVarP(&txHistoryWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
+	txHistoryCmd.Flags().StringVarP(&txHistoryChain, "chain", "c", "", "only show transactions on this chain: eth, bsv")
+	txHistoryCmd.Flags().BoolVar(&txHistoryBackfill, "backfill", false, "import past transactions from Etherscan and WhatsOnChain")
+	txHistoryCmd.Flags().IntVar(&txHistoryLimit, "limit", 50, "maximum number of transactions to show (0 for all)")
+}
+
+// txHistoryItem is a journal entry as shown by tx history, with amounts
+// formatted in whole units.
+type txHistoryItem struct {
+	Hash        string     `json:"hash"`
+	Chain       string     `json:"chain"`
+	Direction   string     `json:"direction,omitempty"`
+	From        string     `json:"from,omitempty"`
+	To          string     `json:"to,omitempty"`
+	Amount      string     `json:"amount,omitempty"`
+	Symbol      string     `json:"symbol"`
+	Token       string     `json:"token,omitempty"`
+	Fee         string     `json:"fee,omitempty"`
+	Status      string     `json:"status"`
+	BlockNumber uint64     `json:"block_number,omitempty"`
+	Time        *time.Time `json:"time,omitempty"`
+	Backfilled  bool       `json:"backfilled,omitempty"`
+}
+
+func runTxHistory(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &txHistoryWallet); err != nil {
+		return err
+	}
+	cmdCtx := GetCmdContext(cmd)
+
+	var chainFilter chain.ID
+	if txHistoryChain != "" {
+		parsed, ok := chain.ParseChainID(txHistoryChain)
+		if !ok || !parsed.IsMVP() {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("invalid chain: %s (use eth or bsv)", txHistoryChain),
+			)
+		}
+		chainFilter = parsed
+	}
+	if txHistoryLimit < 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--limit cannot be negative")
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(txHistoryWallet)
+	if err != nil {
+		return fmt.Errorf("loading wallet: %w", err)
+	}
+	applyWalletNetwork(cmd, wlt)
+
+	ctx, cancel := contextWithTimeout(cmd, 2*time.Minute)
+	defer cancel()
+
+	journal := loadTxJournal(cmdCtx, txHistoryWallet)
+	if txHistoryBackfill {
+		added, backfillErr := backfillTxJournal(ctx, cmdCtx, journal, wlt, chainFilter)
+		if backfillErr != nil {
+			return backfillErr
+		}
+		out(cmd.ErrOrStderr(), "Backfilled %d transaction(s)\n", added)
+	}
+	reconcileTxJournal(ctx, cmdCtx, journal, effectiveBSVNetwork(wlt, cmdCtx.Cfg))
+
+	entries, err := journal.Entries()
+	if err != nil {
+		return fmt.Errorf("loading transaction journal: %w", err)
+	}
+	items := txHistoryItems(entries, chainFilter, txHistoryLimit)
+
+	w := cmd.OutOrStdout()
+	if cmdCtx.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, items)
+	}
+	outputTxHistory(w, txHistoryWallet, items)
+	return nil
+}
+
+// backfillTxJournal imports the on-chain history of the wallet's addresses
+// into the journal and returns how many transactions were new.
+func backfillTxJournal(ctx context.Context, cmdCtx *CommandContext, journal *txjournal.Journal, wlt *wallet.Wallet, chainFilter chain.ID) (int, error) {
+	var entries []txjournal.Entry
+
+	if chainFilter == "" || chainFilter == chain.ETH {
+		if addrs := walletAddressStrings(wlt, chain.ETH); len(addrs) > 0 {
+			client, err := etherscan.NewClient(cmdCtx.Cfg.GetETHEtherscanAPIKey(), etherscanOptions(cmdCtx.Cfg))
+			if err != nil {
+				return 0, fmt.Errorf("creating Etherscan client: %w", err)
+			}
+			for _, addr := range addrs {
+				txs, err := client.GetTransactions(ctx, addr)
+				if err != nil {
+					return 0, fmt.Errorf("fetching ETH history for %s: %w", addr, err)
+				}
+				entries = append(entries, ethHistoryEntries(txs, addrs)...)
+			}
+		}
+	}
+
+	if chainFilter == "" || chainFilter == chain.BSV {
+		if addrs := walletAddressStrings(wlt, chain.BSV); len(addrs) > 0 {
+			client := bsv.NewClient(ctx, &bsv.ClientOptions{
+				APIKey:  cmdCtx.Cfg.GetBSVAPIKey(),
+				Network: bsvClientNetwork(effectiveBSVNetwork(wlt, cmdCtx.Cfg)),
+			})
+			txs, err := bsv.NewBulkOperations(client.GetWOCClient(), nil).BulkAddressTransactions(ctx, addrs)
+			if err != nil {
+				return 0, fmt.Errorf("fetching BSV history: %w", err)
+			}
+			entries = append(entries, bsvHistoryEntries(txs)...)
+		}
+	}
+
+	added, err := journal.Merge(entries)
+	if err != nil {
+		return 0, fmt.Errorf("saving transaction journal: %w", err)
+	}
+	return added, nil
+}
+
+// walletAddressStrings returns the receive and change addresses of a chain.
+func walletAddressStrings(wlt *wallet.Wallet, chainID chain.ID) []string {
+	all := wlt.GetAllAddresses(chainID)
+	addrs := make([]string, 0, len(all))
+	for _, addr := range all {
+		addrs = append(addrs, addr.Address)
+	}
+	return addrs
+}
+
+// ethHistoryEntries converts Etherscan transactions to journal entries. A
+// transaction is a send when it came from one of the wallet's addresses,
+// and internal when it also went to one.
+func ethHistoryEntries(txs []etherscan.Transaction, walletAddrs []string) []txjournal.Entry {
+	owned := func(addr string) bool {
+		for _, a := range walletAddrs {
+			if strings.EqualFold(a, addr) {
+				return true
+			}
+		}
+		return false
+	}
+
+	entries := make([]txjournal.Entry, 0, len(txs))
+	for _, tx := range txs {
+		direction := txjournal.DirectionReceived
+		if owned(tx.From) {
+			direction = txjournal.DirectionSent
+		}
+		status := txjournal.StatusConfirmed
+		if tx.Failed {
+			status = txjournal.StatusReverted
+		}
+
+		entries = append(entries, txjournal.Entry{
+			Hash:        tx.Hash,
+			Chain:       chain.ETH,
+			From:        tx.From,
+			To:          tx.To,
+			Amount:      tx.Value.String(),
+			Symbol:      "ETH",
+			Decimals:    nativeDecimals(chain.ETH),
+			Fee:         tx.Fee.String(),
+			Status:      status,
+			Direction:   direction,
+			Internal:    direction == txjournal.DirectionSent && owned(tx.To),
+			Backfilled:  true,
+			BlockNumber: tx.BlockNumber,
+			CreatedAt:   tx.Time,
+			ConfirmedAt: tx.Time,
+		})
+	}
+	return entries
+}
+
+// bsvHistoryEntries converts WhatsOnChain address history to journal
+// entries. The history carries no amounts or times, so only the
+// transaction ID, block, and status are known.
+func bsvHistoryEntries(txs []bsv.AddressTx) []txjournal.Entry {
+	entries := make([]txjournal.Entry, 0, len(txs))
+	for _, tx := range txs {
+		entry := txjournal.Entry{
+			Hash:       tx.TxID,
+			Chain:      chain.BSV,
+			Symbol:     "BSV",
+			Decimals:   nativeDecimals(chain.BSV),
+			Status:     txjournal.StatusPending,
+			Backfilled: true,
+		}
+		if tx.Height > 0 {
+			entry.Status = txjournal.StatusConfirmed
+			entry.BlockNumber = uint64(tx.Height)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// txHistoryItems formats journal entries for display, keeping those on
+// chainFilter (all chains when empty), at most limit of them (0 for all).
+func txHistoryItems(entries []txjournal.Entry, chainFilter chain.ID, limit int) []txHistoryItem {
+	items := make([]txHistoryItem, 0, len(entries))
+	for i := range entries {
+		e := &entries[i]
+		if chainFilter != "" && e.Chain != chainFilter {
+			continue
+		}
+		if limit > 0 && len(items) == limit {
+			break
+		}
+
+		direction := e.Direction
+		switch {
+		case e.Internal:
+			direction = txjournal.DirectionInternal
+		case direction == "" && e.Sent():
+			direction = txjournal.DirectionSent
+		}
+		item := txHistoryItem{
+			Hash:        e.Hash,
+			Chain:       string(e.Chain),
+			Direction:   direction,
+			From:        e.From,
+			To:          e.To,
+			Amount:      formatJournalUnits(e.Amount, e.Decimals),
+			Symbol:      e.Symbol,
+			Token:       e.Token,
+			Fee:         formatJournalUnits(e.Fee, nativeDecimals(e.Chain)),
+			Status:      e.Status,
+			BlockNumber: e.BlockNumber,
+			Backfilled:  e.Backfilled,
+		}
+		if !e.CreatedAt.IsZero() {
+			created := e.CreatedAt
+			item.Time = &created
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// formatJournalUnits formats a smallest-unit amount from the journal, or
+// returns "" when it is missing or malformed.
+func formatJournalUnits(units string, decimals int) string {
+	n, ok := new(big.Int).SetString(units, 10)
+	if !ok {
+		return ""
+	}
+	return chain.FormatDecimalAmount(n, decimals)
+}
+
+// outputTxHistory prints the history as one line per transaction.
+func outputTxHistory(w io.Writer, walletName string, items []txHistoryItem) {
+	if len(items) == 0 {
+		out(w, "No transactions recorded for wallet '%s'\n", walletName)
+		out(w, "Use --backfill to import past transactions from Etherscan and WhatsOnChain.\n")
+		return
+	}
+
+	out(w, "Transactions for wallet '%s':\n\n", walletName)
+	out(w, "  %-16s %-5s %-9s %-10s %-24s %-12s %s\n", "TIME", "CHAIN", "DIRECTION", "STATUS", "AMOUNT", "COUNTERPARTY", "HASH")
+	for _, item := range items {
+		when := "-"
+		if item.Time != nil {
+			when = item.Time.Local().Format("2006-01-02 15:04")
+		}
+		direction := item.Direction
+		if direction == "" {
+			direction = "-"
+		}
+		amount := "-"
+		if item.Amount != "" {
+			amount = item.Amount + " " + item.Symbol
+		}
+		counterparty := item.To
+		if item.Direction == txjournal.DirectionReceived {
+			counterparty = item.From
+		}
+		if counterparty == "" {
+			counterparty = "-"
+		}
+		out(w, "  %-16s %-5s %-9s %-10s %-24s %-12s %s\n",
+			when, strings.ToUpper(item.Chain), direction, item.Status, amount, truncateAddress(counterparty), item.Hash)
+		if item.Fee != "" && (item.Direction == txjournal.DirectionSent || item.Direction == txjournal.DirectionInternal) {
+			out(w, "  %-16s %-5s fee %s %s\n", "", "", item.Fee, strings.ToUpper(item.Chain))
+		}
+	}
+}
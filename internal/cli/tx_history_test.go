@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/txjournal"
+)
+
+func TestEthHistoryEntries(t *testing.T) {
+	t.Parallel()
+
+	wallet := "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	at := time.Unix(1700000000, 0).UTC()
+	entries := ethHistoryEntries([]etherscan.Transaction{
+		{Hash: "0x01", From: "0x742d35cc6634c0532925a3b844bc454e4438f44e", To: "0xbeef", Value: big.NewInt(10), Fee: big.NewInt(2), BlockNumber: 5, Time: at},
+		{Hash: "0x02", From: "0xbeef", To: wallet, Value: big.NewInt(7), Fee: big.NewInt(1), Failed: true},
+		{Hash: "0x03", From: wallet, To: "0x742D35CC6634C0532925A3B844BC454E4438F44E", Value: big.NewInt(3), Fee: big.NewInt(1)},
+	}, []string{wallet})
+
+	require.Len(t, entries, 3)
+	assert.Equal(t, txjournal.DirectionSent, entries[0].Direction)
+	assert.Equal(t, txjournal.StatusConfirmed, entries[0].Status)
+	assert.Equal(t, "10", entries[0].Amount)
+	assert.Equal(t, "2", entries[0].Fee)
+	assert.Equal(t, at, entries[0].CreatedAt)
+	assert.True(t, entries[0].Backfilled)
+	assert.Equal(t, txjournal.DirectionReceived, entries[1].Direction)
+	assert.Equal(t, txjournal.StatusReverted, entries[1].Status)
+	assert.False(t, entries[0].Internal)
+	assert.False(t, entries[1].Internal)
+	assert.True(t, entries[2].Internal, "a send to the wallet's own address is internal")
+}
+
+func TestBsvHistoryEntries(t *testing.T) {
+	t.Parallel()
+
+	entries := bsvHistoryEntries([]bsv.AddressTx{
+		{TxID: "aa", Address: "1A", Height: 800000},
+		{TxID: "bb", Address: "1A", Height: -1},
+	})
+
+	require.Len(t, entries, 2)
+	assert.Equal(t, txjournal.StatusConfirmed, entries[0].Status)
+	assert.Equal(t, uint64(800000), entries[0].BlockNumber)
+	assert.Equal(t, txjournal.StatusPending, entries[1].Status)
+	assert.Empty(t, entries[1].Amount)
+}
+
+func TestTxHistoryItems(t *testing.T) {
+	t.Parallel()
+
+	entries := []txjournal.Entry{
+		{Hash: "0x01", Chain: chain.ETH, Amount: "1500000000000000000", Symbol: "ETH", Decimals: 18, Fee: "21000000000000", Status: txjournal.StatusConfirmed},
+		{Hash: "aa", Chain: chain.BSV, Symbol: "BSV", Decimals: 8, Status: txjournal.StatusConfirmed, Backfilled: true},
+		{Hash: "bb", Chain: chain.BSV, Amount: "5000", Symbol: "BSV", Decimals: 8, Fee: "12", Status: txjournal.StatusPending, Direction: txjournal.DirectionSent},
+		{Hash: "cc", Chain: chain.BSV, Amount: "9000", Symbol: "BSV", Decimals: 8, Fee: "20", Status: txjournal.StatusConfirmed, Direction: txjournal.DirectionSent, Internal: true},
+	}
+
+	items := txHistoryItems(entries, "", 0)
+	require.Len(t, items, 4)
+	assert.Equal(t, "1.5", items[0].Amount)
+	assert.Equal(t, "0.000021", items[0].Fee)
+	assert.Equal(t, txjournal.DirectionSent, items[0].Direction, "entries without a direction are sends")
+	assert.Empty(t, items[1].Direction, "backfilled entries keep an unknown direction")
+	assert.Empty(t, items[1].Amount)
+	assert.Nil(t, items[1].Time)
+	assert.Equal(t, txjournal.DirectionInternal, items[3].Direction, "internal sends are neither income nor expense")
+	assert.Equal(t, "0.0000002", items[3].Fee)
+
+	items = txHistoryItems(entries, chain.BSV, 1)
+	require.Len(t, items, 1)
+	assert.Equal(t, "aa", items[0].Hash)
+}
+
+func TestRunTxHistory(t *testing.T) {
+	// Tests modify the global tx history flags so cannot be parallel.
+	origWallet, origChain, origBackfill, origLimit := txHistoryWallet, txHistoryChain, txHistoryBackfill, txHistoryLimit
+	defer func() {
+		txHistoryWallet, txHistoryChain, txHistoryBackfill, txHistoryLimit = origWallet, origChain, origBackfill, origLimit
+	}()
+
+	home := t.TempDir()
+	walletsDir := filepath.Join(home, "wallets")
+	createTestWallet(t, walletsDir, "histwallet")
+
+	journal := txjournal.New(filepath.Join(walletsDir, "histwallet"))
+	require.NoError(t, journal.Record(txjournal.Entry{
+		Hash: "0xabc", Chain: chain.ETH, To: "0xbeef", Amount: "1000000000000000000",
+		Symbol: "ETH", Decimals: 18, Fee: "21000000000000", Status: txjournal.StatusConfirmed,
+	}))
+
+	newCmd := func(format output.Format) (*cobra.Command, *bytes.Buffer) {
+		cmd := &cobra.Command{}
+		cmd.SetContext(context.Background())
+		SetCmdContext(cmd, &CommandContext{
+			Cfg: &mockConfigProvider{home: home},
+			Fmt: &mockFormatProvider{format: format},
+		})
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		return cmd, &buf
+	}
+	txHistoryWallet, txHistoryChain, txHistoryBackfill, txHistoryLimit = "histwallet", "", false, 50
+
+	t.Run("text", func(t *testing.T) {
+		cmd, buf := newCmd(output.FormatText)
+		require.NoError(t, runTxHistory(cmd, nil))
+		assert.Contains(t, buf.String(), "Transactions for wallet 'histwallet'")
+		assert.Contains(t, buf.String(), "1 ETH")
+		assert.Contains(t, buf.String(), "0xabc")
+		assert.Contains(t, buf.String(), "fee 0.000021 ETH")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		cmd, buf := newCmd(output.FormatJSON)
+		require.NoError(t, runTxHistory(cmd, nil))
+		var items []txHistoryItem
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &items))
+		require.Len(t, items, 1)
+		assert.Equal(t, "1.0", items[0].Amount)
+		assert.Equal(t, txjournal.StatusConfirmed, items[0].Status)
+	})
+
+	t.Run("chain filter", func(t *testing.T) {
+		txHistoryChain = "bsv"
+		defer func() { txHistoryChain = "" }()
+		cmd, buf := newCmd(output.FormatText)
+		require.NoError(t, runTxHistory(cmd, nil))
+		assert.Contains(t, buf.String(), "No transactions recorded")
+	})
+
+	t.Run("invalid chain", func(t *testing.T) {
+		txHistoryChain = "doge"
+		defer func() { txHistoryChain = "" }()
+		cmd, _ := newCmd(output.FormatText)
+		require.Error(t, runTxHistory(cmd, nil))
+	})
+}
@@ -0,0 +1,771 @@
+package cli
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// txBuildWallet is the wallet whose addresses fund the transaction.
+	txBuildWallet string
+	// txBuildTo is the recipient address.
+	txBuildTo string
+	// txBuildAmount is the amount to send, or "all".
+	txBuildAmount string
+	// txBuildChain is the blockchain to build for.
+	txBuildChain string
+	// txBuildToken is the ERC-20 token to transfer (ETH only).
+	txBuildToken string
+	// txBuildGasSpeed is the gas speed preference (ETH only).
+	txBuildGasSpeed string
+	// txBuildNoChecksum accepts a single-case ETH recipient.
+	txBuildNoChecksum bool
+	// txBuildMaxFeeRate caps the BSV fee rate in sat/KB (0 = no cap).
+	txBuildMaxFeeRate uint64
+	// txBuildOutput is the file the unsigned transaction is written to.
+	txBuildOutput string
+
+	// txSignWallet is the wallet holding the signing keys.
+	txSignWallet string
+	// txSignOutput is the file the signed transaction is written to.
+	txSignOutput string
+	// txSignConfirm skips the review prompt.
+	txSignConfirm bool
+
+	// txBroadcastChain is the chain of a raw hex transaction.
+	txBroadcastChain string
+)
+
+// offlineStdio is the file argument that means stdin.
+const offlineStdio = "-"
+
+// rawTxHexRegex matches a hex-encoded transaction given on the command line.
+var rawTxHexRegex = regexp.MustCompile(`^(0x)?[0-9a-fA-F]+$`)
+
+// txBuildCmd builds an unsigned transaction for offline signing.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var txBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build an unsigned transaction for offline signing",
+	Long: `Build an unsigned transaction on an online machine without unlocking the
+wallet. The result is a JSON file to carry to an air-gapped machine, sign
+there with "sigil tx sign", and broadcast from any machine with
+"sigil tx broadcast".
+
+Only the wallet's public addresses are used. For ETH the nonce, gas limit,
+gas price, and chain ID are fixed at build time, so sign and broadcast the
+transaction before sending another from the same address. For BSV the
+inputs are selected from the wallet's unspent outputs and change returns to
+the first receive address.
+
+Supports native ETH, ERC-20 tokens, and BSV. Use --amount all to send the
+entire balance.`,
+	Example: `  # Build an ETH transfer
+  sigil tx build --wallet cold --to 0x742d35Cc6634C0532925a3b844Bc454e4438f44e --amount 0.5 --chain eth --output send.json
+
+  # Build a BSV sweep
+  sigil tx build --wallet cold --to 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --amount all --chain bsv --output sweep.json`,
+	Args: cobra.NoArgs,
+	RunE: runTxBuild,
+}
+
+// txSignCmd signs an unsigned transaction built by tx build.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var txSignCmd = &cobra.Command{
+	Use:   "sign <file>",
+	Short: "Sign a transaction built by tx build",
+	Long: `Sign an unsigned transaction file produced by "sigil tx build". Signing
+needs no network access, so it can run on an air-gapped machine where the
+wallet seed lives.
+
+The transaction is shown for review before the wallet is unlocked. The
+signing wallet must own the sender address (ETH) or every input address
+(BSV), and a BSV transaction must be for the wallet's network. Use - to read
+the file from stdin.`,
+	Example: `  sigil tx sign send.json --wallet cold --output signed.json
+  cat send.json | sigil tx sign - --wallet cold > signed.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTxSign,
+}
+
+// txBroadcastCmd broadcasts a signed transaction.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var txBroadcastCmd = &cobra.Command{
+	Use:   "broadcast <file|hex>",
+	Short: "Broadcast a signed transaction",
+	Long: `Broadcast a transaction signed by "sigil tx sign". No wallet is needed.
+
+The argument is the signed transaction file, - to read it from stdin, or the
+raw signed transaction hex. Raw hex carries no chain, so --chain is required
+with it. BSV transactions are sent to the network recorded in the file, or
+the configured BSV network for raw hex.
+
+After a BSV broadcast, run "sigil utxo refresh" for the wallet on the
+machine that built the transaction so its spent outputs are not selected
+again.`,
+	Example: `  sigil tx broadcast signed.json
+  sigil tx broadcast 0100000001... --chain bsv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTxBroadcast,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	txCmd.AddCommand(txBuildCmd)
+	txCmd.AddCommand(txSignCmd)
+	txCmd.AddCommand(txBroadcastCmd)
+
+	txBuildCmd.Flags().StringVar(&txBuildWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+	txBuildCmd.Flags().StringVar(&txBuildTo, "to", "", "recipient address (required)")
+	txBuildCmd.Flags().StringVar(&txBuildAmount, "amount", "", "amount to send, or 'all' for entire balance (required)")
+	txBuildCmd.Flags().StringVar(&txBuildChain, "chain", "eth", "blockchain: eth, bsv")
+	txBuildCmd.Flags().StringVar(&txBuildToken, "token", "", "ERC-20 token symbol or contract address (e.g., USDC, 0x...) - ETH only")
+	txBuildCmd.Flags().StringVar(&txBuildGasSpeed, "gas", "medium", "gas speed: slow, medium, fast")
+	txBuildCmd.Flags().BoolVar(&txBuildNoChecksum, "no-checksum", false, "accept an all-lowercase ETH recipient address (ETH only)")
+	txBuildCmd.Flags().Uint64Var(&txBuildMaxFeeRate, "max-fee-rate", 0, "refuse to build above this fee rate in sat/KB, 0 = no limit (BSV only)")
+	txBuildCmd.Flags().StringVar(&txBuildOutput, "output", "", "write the unsigned transaction to this file instead of stdout")
+	_ = txBuildCmd.MarkFlagRequired("to")
+	_ = txBuildCmd.MarkFlagRequired("amount")
+
+	txSignCmd.Flags().StringVar(&txSignWallet, "wallet", "", "signing wallet (defaults to the wallet named in the file, then config default_wallet)")
+	txSignCmd.Flags().StringVar(&txSignOutput, "output", "", "write the signed transaction to this file instead of stdout")
+	txSignCmd.Flags().BoolVar(&txSignConfirm, "yes", false, "skip the review prompt")
+
+	txBroadcastCmd.Flags().StringVar(&txBroadcastChain, "chain", "", "blockchain of a raw hex transaction: eth, bsv")
+}
+
+func runTxBuild(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &txBuildWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
+	defer cancel()
+
+	chainID, err := parseOfflineChain(cc.Cfg, txBuildChain)
+	if err != nil {
+		return err
+	}
+	if txBuildToken != "" && chainID != chain.ETH {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--token flag is only supported for ETH chain")
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(txBuildWallet)
+	if err != nil {
+		return fmt.Errorf("loading wallet: %w", err)
+	}
+	applyWalletNetwork(cmd, wlt)
+	p := offlineBuild{
+		to:         txBuildTo,
+		amount:     txBuildAmount,
+		gasSpeed:   txBuildGasSpeed,
+		noChecksum: txBuildNoChecksum,
+		maxFeeRate: txBuildMaxFeeRate,
+	}
+	if txBuildToken != "" {
+		meta, accepted, tokenErr := resolveTxToken(ctx, cmd, txBuildToken, false, false)
+		if tokenErr != nil {
+			return tokenErr
+		}
+		if !accepted {
+			outln(cmd.OutOrStdout(), "Transaction canceled.")
+			return nil
+		}
+		p.token = meta
+	}
+
+	tx, err := buildOfflineTx(ctx, cmd, wlt, chainID, p)
+	if err != nil {
+		return err
+	}
+	if err := writeOfflineTx(cmd, txBuildOutput, tx); err != nil {
+		return err
+	}
+	if txBuildOutput != "" {
+		w := cmd.OutOrStdout()
+		out(w, "Unsigned transaction written to %s\n\n", txBuildOutput)
+		displayOfflineSummary(w, tx)
+		out(w, "\nSign it offline with: sigil tx sign %s\n", txBuildOutput)
+	}
+	return nil
+}
+
+// parseOfflineChain resolves an enabled chain that supports offline signing.
+func parseOfflineChain(cfg ConfigProvider, name string) (chain.ID, error) {
+	chainID, err := parseEnabledChain(cfg, name)
+	if err != nil {
+		return "", err
+	}
+	if err := checkOfflineChain(chainID); err != nil {
+		return "", err
+	}
+	return chainID, nil
+}
+
+// checkOfflineChain rejects chains unsigned transactions cannot be built
+// for: all but ETH and BSV.
+func checkOfflineChain(chainID chain.ID) error {
+	if chainID != chain.ETH && chainID != chain.BSV {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("offline signing does not support chain %s (use eth or bsv)", chainID),
+		)
+	}
+	return nil
+}
+
+// offlineBuild holds what an unsigned transaction sends: the recipient,
+// amount, and fee settings of tx build, or of tx send with a hardware signer.
+type offlineBuild struct {
+	to         string
+	amount     string
+	gasSpeed   string
+	noChecksum bool
+	token      *eth.TokenMetadata // resolved --token, nil for native ETH
+	maxFeeRate uint64
+}
+
+// buildOfflineTx builds an unsigned transaction from the wallet's first
+// address for chainID (ETH) or its addresses' UTXOs (BSV).
+func buildOfflineTx(ctx context.Context, cmd *cobra.Command, wlt *wallet.Wallet, chainID chain.ID, p offlineBuild) (*chain.UnsignedTx, error) {
+	addresses := wlt.Addresses[chainID]
+	if len(addresses) == 0 {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("wallet '%s' has no addresses for chain %s", wlt.Name, chainID),
+		)
+	}
+
+	tx := &chain.UnsignedTx{
+		Version:   chain.OfflineTxVersion,
+		Chain:     chainID,
+		Wallet:    wlt.Name,
+		From:      addresses[0].Address,
+		Network:   effectiveBSVNetwork(wlt, GetCmdContext(cmd).Cfg),
+		CreatedAt: time.Now().UTC(),
+	}
+	var buildErr error
+	if chainID == chain.ETH {
+		buildErr = buildOfflineETH(ctx, cmd, tx, p)
+	} else {
+		buildErr = buildOfflineBSV(ctx, cmd, tx, addresses, p)
+	}
+	if buildErr != nil {
+		return nil, buildErr
+	}
+	if err := tx.Validate(); err != nil {
+		return nil, fmt.Errorf("building unsigned transaction: %w", err)
+	}
+	return tx, nil
+}
+
+// buildOfflineETH fills in tx for an ETH or ERC-20 transfer.
+//
+//nolint:gocognit // Native vs token and sweep vs amount have distinct gas paths
+func buildOfflineETH(ctx context.Context, cmd *cobra.Command, tx *chain.UnsignedTx, p offlineBuild) error {
+	cc := GetCmdContext(cmd)
+
+	to, err := transaction.NormalizeETHRecipient(p.to, p.noChecksum)
+	if err != nil {
+		return err
+	}
+	speed, err := eth.ParseGasSpeed(p.gasSpeed)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+
+	tx.To, tx.Symbol, tx.Decimals = to, "ETH", 18
+	token := p.token
+	if token != nil {
+		tx.Token, tx.Symbol, tx.Decimals = token.Address, token.Symbol, token.Decimals
+	}
+
+	client, err := newOfflineETHClient(cc)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	sweep := isAmountAll(p.amount)
+	var amount *big.Int
+	if !sweep {
+		if amount, err = parseDecimalAmount(p.amount, tx.Decimals); err != nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("invalid amount: %s", p.amount))
+		}
+	}
+
+	var params *eth.TxParams
+	var estimate *eth.GasEstimate
+	if token != nil {
+		if sweep {
+			if amount, err = client.GetTokenBalance(ctx, tx.From, token.Address); err != nil {
+				return fmt.Errorf("getting token balance: %w", err)
+			}
+		}
+		if params, err = eth.NewERC20TransferParams(tx.From, to, token.Address, amount); err != nil {
+			return fmt.Errorf("building ERC-20 params: %w", err)
+		}
+		if estimate, err = client.EstimateGasForERC20Transfer(ctx, tx.From, token.Address, params.Data, speed); err != nil {
+			return fmt.Errorf("estimating gas: %w", err)
+		}
+	} else {
+		value := amount
+		if sweep {
+			if value, err = client.GetBalance(ctx, tx.From); err != nil {
+				return fmt.Errorf("getting ETH balance: %w", err)
+			}
+		}
+		if estimate, err = client.EstimateGasForETHTransfer(ctx, tx.From, to, value, speed); err != nil {
+			return fmt.Errorf("estimating gas: %w", err)
+		}
+		if sweep {
+			amount = new(big.Int).Sub(value, estimate.Total)
+		}
+		params = eth.NewETHTransferParams(tx.From, to, amount)
+	}
+	if amount.Sign() <= 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInsufficientFunds, fmt.Sprintf("nothing to send from %s", tx.From))
+	}
+
+	var tokenAddress string
+	if token != nil {
+		tokenAddress = token.Address
+	}
+	if err := transaction.ValidateETHBalance(ctx, client, tx.From, amount, estimate.Total, tokenAddress); err != nil {
+		return err
+	}
+
+	params.GasLimit, params.GasPrice = estimate.GasLimit, estimate.GasPrice
+	if tx.ETH, err = client.BuildUnsigned(ctx, params); err != nil {
+		return fmt.Errorf("building transaction: %w", err)
+	}
+	tx.Amount = amount.String()
+	tx.Fee = estimate.Total.String()
+	return nil
+}
+
+// buildOfflineBSV fills in tx with the inputs and outputs of a BSV send
+// funded by addresses.
+func buildOfflineBSV(ctx context.Context, cmd *cobra.Command, tx *chain.UnsignedTx, addresses []wallet.Address, p offlineBuild) error {
+	cc := GetCmdContext(cmd)
+
+	if err := chain.ValidateAddressOnNetwork(chain.BSV, tx.Network, p.to); err != nil {
+		return transaction.InvalidAddressError(err)
+	}
+	tx.To, tx.Symbol, tx.Decimals = p.to, "BSV", 8
+
+	client := bsv.NewClient(ctx, &bsv.ClientOptions{
+		APIKey:      cc.Cfg.GetBSVAPIKey(),
+		Network:     bsvClientNetwork(tx.Network),
+		Logger:      cc.Log,
+		FeeStrategy: bsv.FeeStrategy(cc.Cfg.GetBSVFeeStrategy()),
+		MinMiners:   cc.Cfg.GetBSVMinMiners(),
+		FeeTable:    bsv.NewFeeTableStore(transaction.FeeTablePath(cc.Cfg.GetHome())),
+	})
+
+	feeQuote, err := client.GetFeeQuote(ctx)
+	if err != nil {
+		feeQuote = &bsv.FeeQuote{StandardRate: bsv.DefaultFeeRate, Source: bsv.FeeSourceDefault}
+	}
+	if capErr := transaction.CheckMaxFeeRate(feeQuote, p.maxFeeRate); capErr != nil {
+		return capErr
+	}
+	warnBSVFeeFallback(feeQuote.Source, feeQuote.StandardRate, feeQuote.Age())
+
+	utxos, err := transaction.AggregateBSVUTXOs(ctx, client, addresses)
+	if err != nil {
+		return fmt.Errorf("listing UTXOs: %w", err)
+	}
+	walletPath := filepath.Join(cc.Cfg.GetHome(), "wallets", tx.Wallet)
+	store := utxostore.New(walletPath)
+	if loadErr := store.Load(); loadErr == nil {
+		utxos = transaction.FilterSpentBSVUTXOs(utxos, store)
+	}
+	utxos = transaction.FilterReservedUTXOs(cc.Log, walletPath, chain.BSV, utxos)
+	if len(utxos) == 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInsufficientFunds, "no UTXOs found across any wallet address")
+	}
+
+	req := chain.SendRequest{
+		From:     tx.From,
+		To:       tx.To,
+		UTXOs:    utxos,
+		FeeRate:  feeQuote.StandardRate,
+		SweepAll: isAmountAll(p.amount),
+	}
+	if !req.SweepAll {
+		if req.Amount, err = client.ParseAmount(p.amount); err != nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("invalid amount: %s", p.amount))
+		}
+	}
+
+	if tx.BSV, err = client.BuildUnsigned(ctx, req); err != nil {
+		return err
+	}
+	var inTotal, outTotal uint64
+	for _, input := range tx.BSV.Inputs {
+		inTotal += input.Amount
+	}
+	for _, output := range tx.BSV.Outputs {
+		outTotal += output.Amount
+	}
+	tx.Amount = strconv.FormatUint(tx.BSV.Outputs[0].Amount, 10)
+	tx.Fee = strconv.FormatUint(inTotal-outTotal, 10)
+	return nil
+}
+
+// newOfflineETHClient creates an ETH client with the configured broadcast
+// failover and the gas table.
+func newOfflineETHClient(cc *CommandContext) (*eth.Client, error) {
+	opts := &eth.ClientOptions{
+		FallbackRPCs:     cc.Cfg.GetETHFallbackRPCs(),
+		GasMarginPercent: cc.Cfg.GetETHGasMarginPercent(),
+		GasTable:         eth.NewGasTableStore(transaction.GasTablePath(cc.Cfg.GetHome())),
+	}
+	if apiKey := cc.Cfg.GetETHEtherscanAPIKey(); apiKey != "" {
+		if esClient, esErr := etherscan.NewClient(apiKey, etherscanOptions(cc.Cfg)); esErr == nil {
+			opts.BroadcastFallback = esClient
+			opts.GasPriceOracle = etherscan.NewGasPriceAdapter(esClient)
+		}
+	}
+	client, err := eth.NewClient(cc.Cfg.GetETHRPC(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating ETH client: %w", err)
+	}
+	return client, nil
+}
+
+func runTxSign(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+
+	data, err := readOfflineInput(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	tx, err := chain.DecodeUnsignedTx(data)
+	if err != nil {
+		return err
+	}
+
+	if cc.AgentXpub != "" || cc.AgentCred != nil {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAgentPolicyViolation,
+			"offline signing needs the wallet password; agents can send with: sigil tx send",
+		)
+	}
+
+	if txSignWallet == "" {
+		txSignWallet = tx.Wallet
+	}
+	if err := resolveWalletName(cmd, &txSignWallet); err != nil {
+		return err
+	}
+
+	if !txSignConfirm {
+		w := cmd.ErrOrStderr()
+		out(w, "\nSign transaction with wallet '%s'\n", txSignWallet)
+		displayOfflineSummary(w, tx)
+		if !promptConfirmFn() {
+			outln(cmd.OutOrStdout(), "Signing canceled.")
+			return nil
+		}
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	wlt, seed, err := loadWalletWithSession(txSignWallet, storage, cmd)
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(seed)
+
+	signed, err := signOfflineTx(tx, wlt, seed, effectiveBSVNetwork(wlt, cc.Cfg))
+	if err != nil {
+		return err
+	}
+
+	if err := writeOfflineTx(cmd, txSignOutput, signed); err != nil {
+		return err
+	}
+	if txSignOutput != "" {
+		w := cmd.OutOrStdout()
+		out(w, "Signed transaction written to %s\n", txSignOutput)
+		out(w, "  Hash: %s\n", signed.Hash)
+		out(w, "\nBroadcast it from an online machine with: sigil tx broadcast %s\n", txSignOutput)
+	}
+	return nil
+}
+
+// signOfflineTx signs tx with the keys of wlt. network is the wallet's BSV
+// network, which a BSV transaction must match.
+func signOfflineTx(tx *chain.UnsignedTx, wlt *wallet.Wallet, seed []byte, network string) (*chain.SignedRawTx, error) {
+	addresses := wlt.Addresses[tx.Chain]
+	if len(addresses) == 0 {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("wallet '%s' has no addresses for chain %s", wlt.Name, tx.Chain),
+		)
+	}
+
+	var raw []byte
+	var hash string
+	switch tx.Chain {
+	case chain.ETH:
+		key, err := wallet.DerivePrivateKeyForChain(seed, wallet.ChainETH, 0)
+		if err != nil {
+			return nil, fmt.Errorf("deriving private key: %w", err)
+		}
+		defer wallet.ZeroBytes(key)
+		if raw, hash, err = eth.SignUnsigned(tx.ETH, tx.From, key); err != nil {
+			return nil, sigilerr.WithSuggestion(err, fmt.Sprintf("sign with the wallet that owns %s", tx.From))
+		}
+	case chain.BSV:
+		if tx.Network != network {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("transaction is for BSV %s but wallet '%s' is on %s", tx.Network, wlt.Name, network),
+			)
+		}
+		keys, err := transaction.DeriveKeysForUTXOs(offlineInputUTXOs(tx.BSV.Inputs), addresses, seed)
+		if err != nil {
+			return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+				fmt.Sprintf("%v; sign with the wallet that built the transaction", err))
+		}
+		defer transaction.ZeroKeyMap(keys)
+		if raw, hash, err = bsv.SignUnsigned(tx.BSV, bsvClientNetwork(network), keys); err != nil {
+			return nil, fmt.Errorf("signing transaction: %w", err)
+		}
+	default:
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("cannot sign %s transactions", tx.Chain))
+	}
+
+	return &chain.SignedRawTx{
+		Version:  chain.OfflineTxVersion,
+		Chain:    tx.Chain,
+		Network:  tx.Network,
+		Hash:     hash,
+		Hex:      hex.EncodeToString(raw),
+		From:     tx.From,
+		To:       tx.To,
+		Amount:   tx.Amount,
+		Fee:      tx.Fee,
+		Token:    tx.Token,
+		Symbol:   tx.Symbol,
+		Decimals: tx.Decimals,
+	}, nil
+}
+
+// offlineInputUTXOs converts the inputs of an unsigned BSV transaction.
+func offlineInputUTXOs(inputs []chain.OfflineInput) []chain.UTXO {
+	utxos := make([]chain.UTXO, len(inputs))
+	for i, in := range inputs {
+		utxos[i] = chain.UTXO{
+			TxID:         in.TxID,
+			Vout:         in.Vout,
+			Amount:       in.Amount,
+			ScriptPubKey: in.ScriptPubKey,
+			Address:      in.Address,
+		}
+	}
+	return utxos
+}
+
+func runTxBroadcast(cmd *cobra.Command, args []string) error {
+	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
+	defer cancel()
+
+	signed, err := readBroadcastInput(cmd, args[0])
+	if err != nil {
+		return err
+	}
+	hash, err := broadcastSignedTx(ctx, cmd, signed)
+	if err != nil {
+		return err
+	}
+	return displayBroadcastResult(cmd, signed, hash)
+}
+
+// broadcastSignedTx broadcasts signed and returns its hash. A transaction
+// without a network goes to the configured network.
+func broadcastSignedTx(ctx context.Context, cmd *cobra.Command, signed *chain.SignedRawTx) (string, error) {
+	cc := GetCmdContext(cmd)
+	raw, err := signed.Raw()
+	if err != nil {
+		return "", err
+	}
+
+	switch signed.Chain {
+	case chain.ETH:
+		if signed.Network != "" {
+			useNetwork(cc.Cfg, signed.Network)
+		}
+		client, clientErr := newOfflineETHClient(cc)
+		if clientErr != nil {
+			return "", clientErr
+		}
+		defer client.Close()
+		return client.BroadcastRaw(ctx, raw)
+	case chain.BSV:
+		if signed.Network == "" {
+			signed.Network = bsvNetworkForCmd(cmd)
+		}
+		client := bsv.NewClient(ctx, &bsv.ClientOptions{
+			APIKey:  cc.Cfg.GetBSVAPIKey(),
+			Network: bsvClientNetwork(signed.Network),
+			Logger:  cc.Log,
+		})
+		return client.BroadcastTransaction(ctx, raw)
+	default:
+		return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("cannot broadcast %s transactions", signed.Chain))
+	}
+}
+
+// displayBroadcastResult shows a broadcast transaction in the configured
+// format.
+func displayBroadcastResult(cmd *cobra.Command, signed *chain.SignedRawTx, hash string) error {
+	w := cmd.OutOrStdout()
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]string{
+			"hash":   hash,
+			"chain":  string(signed.Chain),
+			"status": "pending",
+		})
+	}
+	out(w, "Transaction broadcast\n")
+	out(w, "  Chain: %s\n", strings.ToUpper(string(signed.Chain)))
+	out(w, "  Hash:  %s\n", hash)
+	out(w, "\nCheck it with: sigil tx status %s --chain %s\n", hash, signed.Chain)
+	return nil
+}
+
+// readBroadcastInput reads a signed transaction file, or wraps raw hex given
+// with --chain.
+func readBroadcastInput(cmd *cobra.Command, arg string) (*chain.SignedRawTx, error) {
+	if arg != offlineStdio && rawTxHexRegex.MatchString(arg) {
+		if _, statErr := os.Stat(arg); statErr != nil {
+			return rawHexBroadcast(arg)
+		}
+	}
+
+	data, err := readOfflineInput(cmd, arg)
+	if err != nil {
+		return nil, err
+	}
+	return chain.DecodeSignedRawTx(data)
+}
+
+// rawHexBroadcast wraps a raw signed transaction for the chain in --chain.
+func rawHexBroadcast(rawHex string) (*chain.SignedRawTx, error) {
+	if txBroadcastChain == "" {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--chain is required to broadcast raw hex (eth or bsv)")
+	}
+	chainID, ok := chain.ParseChainID(txBroadcastChain)
+	if !ok || (chainID != chain.ETH && chainID != chain.BSV) {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("tx broadcast does not support chain %s (use eth or bsv)", txBroadcastChain),
+		)
+	}
+	return &chain.SignedRawTx{Version: chain.OfflineTxVersion, Chain: chainID, Hex: rawHex}, nil
+}
+
+// readOfflineInput reads a transaction file, or stdin for "-".
+func readOfflineInput(cmd *cobra.Command, path string) ([]byte, error) {
+	var data []byte
+	var err error
+	if path == offlineStdio {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(path) //nolint:gosec // Path is supplied by the user
+	}
+	if err != nil {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("reading %s: %v", path, err))
+	}
+	return data, nil
+}
+
+// writeOfflineTx writes v as JSON to path, or to stdout when path is empty.
+// Files are created owner-only.
+func writeOfflineTx(cmd *cobra.Command, path string, v any) error {
+	if path == "" {
+		return writeJSON(cmd.OutOrStdout(), v)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600) //nolint:gosec // Path is supplied by the user
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	if err := writeJSON(f, v); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// displayOfflineSummary prints what an unsigned transaction does.
+func displayOfflineSummary(w io.Writer, tx *chain.UnsignedTx) {
+	feeDecimals := offlineFeeDecimals(tx.Chain)
+	feeSymbol := "ETH"
+	if tx.Chain == chain.BSV {
+		feeSymbol = "BSV"
+	}
+
+	network := tx.Network
+	if tx.ETH != nil {
+		network = "chain ID " + tx.ETH.ChainID
+	}
+	out(w, "  Chain:   %s (%s)\n", strings.ToUpper(string(tx.Chain)), network)
+	out(w, "  From:    %s\n", tx.From)
+	out(w, "  To:      %s\n", tx.To)
+	out(w, "  Amount:  %s %s\n", formatUnits(tx.Amount, tx.Decimals), tx.Symbol)
+	out(w, "  Fee:     %s %s\n", formatUnits(tx.Fee, feeDecimals), feeSymbol)
+	if tx.ETH != nil {
+		out(w, "  Nonce:   %d\n", tx.ETH.Nonce)
+	}
+	if tx.BSV != nil {
+		out(w, "  Inputs:  %d\n", len(tx.BSV.Inputs))
+		out(w, "  Outputs: %d\n", len(tx.BSV.Outputs))
+	}
+}
+
+// offlineFeeDecimals returns the decimals of the fee of an offline
+// transaction, which is always paid in the chain's native coin.
+func offlineFeeDecimals(chainID chain.ID) int {
+	if chainID == chain.BSV {
+		return 8
+	}
+	return 18
+}
+
+// formatUnits formats a decimal string of smallest units for display.
+func formatUnits(units string, decimals int) string {
+	n, ok := new(big.Int).SetString(units, 10)
+	if !ok {
+		return units
+	}
+	return chain.FormatDecimalAmount(n, decimals)
+}
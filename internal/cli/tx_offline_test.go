@@ -0,0 +1,176 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newOfflineTestWallet returns a wallet with two addresses per chain and its seed.
+func newOfflineTestWallet(t *testing.T) (*wallet.Wallet, []byte) {
+	t.Helper()
+
+	mnemonic, err := wallet.GenerateMnemonic(12)
+	require.NoError(t, err)
+	seed, err := wallet.MnemonicToSeed(mnemonic, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { wallet.ZeroBytes(seed) })
+
+	w, err := wallet.NewWallet("cold", []wallet.ChainID{wallet.ChainETH, wallet.ChainBSV})
+	require.NoError(t, err)
+	require.NoError(t, w.DeriveAddresses(seed, 2))
+	return w, seed
+}
+
+func TestSignOfflineTx_BSV(t *testing.T) {
+	t.Parallel()
+
+	w, seed := newOfflineTestWallet(t)
+	addrs := w.Addresses[wallet.ChainBSV]
+
+	unsigned := func() *chain.UnsignedTx {
+		return &chain.UnsignedTx{
+			Version: chain.OfflineTxVersion, Chain: chain.BSV, Network: "main",
+			From: addrs[0].Address, To: addrs[1].Address,
+			Amount: "30000", Fee: "1000", Symbol: "BSV", Decimals: 8,
+			BSV: &chain.UnsignedBSV{
+				FeeRate: 250,
+				Inputs: []chain.OfflineInput{
+					{TxID: strings.Repeat("a", 64), Vout: 0, Amount: 20000, Address: addrs[0].Address},
+					{TxID: strings.Repeat("b", 64), Vout: 1, Amount: 20000, Address: addrs[1].Address},
+				},
+				Outputs: []chain.OfflineOutput{
+					{Address: addrs[1].Address, Amount: 30000},
+					{Address: addrs[0].Address, Amount: 9000},
+				},
+			},
+		}
+	}
+
+	t.Run("signs every input", func(t *testing.T) {
+		t.Parallel()
+		tx := unsigned()
+		require.NoError(t, tx.Validate())
+
+		signed, err := signOfflineTx(tx, w, seed, "main")
+		require.NoError(t, err)
+		require.NoError(t, signed.Validate())
+		assert.Len(t, signed.Hash, 64)
+		assert.Equal(t, tx.Amount, signed.Amount)
+		assert.Equal(t, "main", signed.Network)
+	})
+
+	t.Run("network mismatch", func(t *testing.T) {
+		t.Parallel()
+		_, err := signOfflineTx(unsigned(), w, seed, "test")
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	})
+
+	t.Run("input from another wallet", func(t *testing.T) {
+		t.Parallel()
+		other, _ := newOfflineTestWallet(t)
+		tx := unsigned()
+		tx.BSV.Inputs[0].Address = other.Addresses[wallet.ChainBSV][0].Address
+
+		_, err := signOfflineTx(tx, w, seed, "main")
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	})
+}
+
+func TestSignOfflineTx_ETH(t *testing.T) {
+	t.Parallel()
+
+	w, seed := newOfflineTestWallet(t)
+	addrs := w.Addresses[wallet.ChainETH]
+
+	tx := &chain.UnsignedTx{
+		Version: chain.OfflineTxVersion, Chain: chain.ETH,
+		From: addrs[0].Address, To: addrs[1].Address,
+		Amount: "1000", Fee: "21000000000000", Symbol: "ETH", Decimals: 18,
+		ETH: &chain.UnsignedETH{
+			ChainID: "1", Nonce: 4, To: addrs[1].Address, Value: "1000",
+			GasLimit: 21000, GasPrice: "1000000000",
+		},
+	}
+
+	signed, err := signOfflineTx(tx, w, seed, "main")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(signed.Hash, "0x"))
+	raw, err := signed.Raw()
+	require.NoError(t, err)
+	assert.NotEmpty(t, raw)
+
+	tx.From = addrs[1].Address
+	_, err = signOfflineTx(tx, w, seed, "main")
+	require.ErrorIs(t, err, eth.ErrSignerMismatch)
+}
+
+func TestReadBroadcastInput(t *testing.T) {
+	origChain := txBroadcastChain
+	t.Cleanup(func() { txBroadcastChain = origChain })
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	txBroadcastChain = ""
+	_, err := readBroadcastInput(cmd, "0100000001")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput, "raw hex needs --chain")
+
+	txBroadcastChain = "bsv"
+	signed, err := readBroadcastInput(cmd, "0100000001")
+	require.NoError(t, err)
+	assert.Equal(t, chain.BSV, signed.Chain)
+	raw, err := signed.Raw()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x01, 0x00, 0x00, 0x00, 0x01}, raw)
+
+	path := filepath.Join(t.TempDir(), "signed.json")
+	want := &chain.SignedRawTx{Version: chain.OfflineTxVersion, Chain: chain.ETH, Hash: "0xab", Hex: "0xf86b"}
+	require.NoError(t, writeOfflineTx(cmd, path, want))
+	got, err := readBroadcastInput(cmd, path)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+
+	cmd.SetIn(strings.NewReader(`{"version":1,"chain":"eth","hash":"0xab","hex":"0xf86b"}`))
+	got, err = readBroadcastInput(cmd, "-")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestWriteOfflineTx_Stdout(t *testing.T) {
+	t.Parallel()
+
+	cmd := &cobra.Command{}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	tx := &chain.UnsignedTx{Version: chain.OfflineTxVersion, Chain: chain.ETH}
+	require.NoError(t, writeOfflineTx(cmd, "", tx))
+	assert.Contains(t, buf.String(), `"chain": "eth"`)
+}
+
+func TestDisplayOfflineSummary(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displayOfflineSummary(&buf, &chain.UnsignedTx{
+		Chain: chain.ETH, From: "0xfrom", To: "0xto",
+		Amount: "1500000", Fee: "21000000000000", Symbol: "USDC", Decimals: 6,
+		ETH: &chain.UnsignedETH{ChainID: "1", Nonce: 12},
+	})
+	assert.Contains(t, buf.String(), "1.5 USDC")
+	assert.Contains(t, buf.String(), "0.000021 ETH")
+	assert.Contains(t, buf.String(), "chain ID 1")
+	assert.Contains(t, buf.String(), "Nonce:   12")
+}
@@ -0,0 +1,264 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"math/big"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/txintent"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Actions tx recover reports for a send intent.
+const (
+	intentActionRecorded    = "recorded"
+	intentActionRebroadcast = "rebroadcast"
+	intentActionReleased    = "released"
+	intentActionPending     = "pending"
+	intentActionFailed      = "failed"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// txRecoverWallet is the wallet whose send intents are resolved.
+	txRecoverWallet string
+	// txRecoverHash limits recovery to one intent.
+	txRecoverHash string
+	// txRecoverRebroadcast resubmits transactions that never reached the network.
+	txRecoverRebroadcast bool
+	// txRecoverRelease drops intents whose transaction never reached the network.
+	txRecoverRelease bool
+)
+
+// txRecoverCmd resolves send intents left behind by an interrupted send.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var txRecoverCmd = &cobra.Command{
+	Use:   "recover",
+	Short: "Resolve sends interrupted before they finished",
+	Long: `Resolve BSV, BTC, and BCH sends that were interrupted mid-broadcast.
+
+Before broadcasting, tx send records the signed transaction and the inputs it
+spends in ~/.sigil/wallets/<name>/intents.json, and removes the entry once the
+send is recorded. An entry that is still there means sigil stopped, or lost
+its connection, before it knew whether the transaction reached the network.
+Its inputs are reserved: later sends will not select them.
+
+tx recover checks each entry against the network:
+
+  broadcast    An input is spent, so the transaction made it. The inputs are
+               marked spent, the send is added to tx history, and the entry
+               is removed.
+  unbroadcast  Every input is still unspent. Use --rebroadcast to submit the
+               signed transaction again, or --release to discard it and make
+               the inputs spendable.
+
+Sends queued in the outbox because the network was unreachable are left to
+"sigil outbox flush", unless one is named with --hash.`,
+	Example: `  # Check interrupted sends
+  sigil tx recover --wallet main
+
+  # Submit the signed transactions again
+  sigil tx recover --wallet main --rebroadcast
+
+  # Discard one and free its inputs
+  sigil tx recover --wallet main --hash 5c50...a1f3 --release`,
+	Args: cobra.NoArgs,
+	RunE: runTxRecover,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	txCmd.AddCommand(txRecoverCmd)
+
+	txRecoverCmd.Flags().StringVarP(&txRecoverWallet, "wallet", "w", "", "wallet name (defaults to config default_wallet)")
+	txRecoverCmd.Flags().StringVar(&txRecoverHash, "hash", "", "only resolve the send with this transaction hash")
+	txRecoverCmd.Flags().BoolVar(&txRecoverRebroadcast, "rebroadcast", false, "resubmit transactions that never reached the network")
+	txRecoverCmd.Flags().BoolVar(&txRecoverRelease, "release", false, "discard transactions that never reached the network and free their inputs")
+	txRecoverCmd.MarkFlagsMutuallyExclusive("rebroadcast", "release")
+}
+
+// intentResolver checks and resolves send intents.
+type intentResolver interface {
+	CheckIntent(ctx context.Context, intent *txintent.Intent) (transaction.IntentState, error)
+	RebroadcastIntent(ctx context.Context, walletName string, intent *txintent.Intent) (string, error)
+	FinalizeIntent(walletName string, intent *txintent.Intent) error
+	ReleaseIntent(walletName, hash string) error
+}
+
+// txRecoverItem is the outcome of resolving one send intent.
+type txRecoverItem struct {
+	Hash      string    `json:"hash"`
+	Chain     string    `json:"chain"`
+	To        string    `json:"to"`
+	Amount    string    `json:"amount"`
+	Fee       string    `json:"fee"`
+	CreatedAt time.Time `json:"created_at"`
+	State     string    `json:"state,omitempty"`
+	Action    string    `json:"action"`
+	Error     string    `json:"error,omitempty"`
+}
+
+func runTxRecover(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &txRecoverWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+
+	intents, err := txintent.New(filepath.Join(cc.Cfg.GetHome(), "wallets", txRecoverWallet)).Intents()
+	if err != nil {
+		return fmt.Errorf("loading send intents: %w", err)
+	}
+	if txRecoverHash == "" {
+		intents = slices.DeleteFunc(intents, func(intent txintent.Intent) bool { return intent.Queued() })
+	} else {
+		intents = filterIntentsByHash(intents, txRecoverHash)
+		if len(intents) == 0 {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrNotFound,
+				fmt.Sprintf("no interrupted send with hash %s in wallet %s", txRecoverHash, txRecoverWallet),
+			)
+		}
+	}
+
+	ctx, cancel := contextWithTimeout(cmd, 2*time.Minute)
+	defer cancel()
+
+	svc := transaction.NewService(&transaction.Config{Config: cc.Cfg, Logger: cc.Log})
+	items := resolveIntents(ctx, svc, txRecoverWallet, intents, txRecoverRebroadcast, txRecoverRelease)
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, items)
+	}
+	outputTxRecover(w, txRecoverWallet, items)
+	return nil
+}
+
+// filterIntentsByHash returns the intents whose hash matches, ignoring case.
+func filterIntentsByHash(intents []txintent.Intent, hash string) []txintent.Intent {
+	var matched []txintent.Intent
+	for _, intent := range intents {
+		if strings.EqualFold(intent.Hash, hash) {
+			matched = append(matched, intent)
+		}
+	}
+	return matched
+}
+
+// resolveIntents checks each intent against the network. Broadcast intents
+// are always finalized; unbroadcast ones are rebroadcast or released when
+// asked and otherwise left pending. Failures are reported per intent.
+func resolveIntents(ctx context.Context, resolver intentResolver, walletName string, intents []txintent.Intent, rebroadcast, release bool) []txRecoverItem {
+	items := make([]txRecoverItem, 0, len(intents))
+	for i := range intents {
+		intent := &intents[i]
+		item := txRecoverItem{
+			Hash:      intent.Hash,
+			Chain:     string(intent.Chain),
+			To:        intent.To,
+			Amount:    formatIntentUnits(intent.Amount),
+			Fee:       formatIntentUnits(intent.Fee),
+			CreatedAt: intent.CreatedAt,
+		}
+
+		state, err := resolver.CheckIntent(ctx, intent)
+		item.State = string(state)
+		switch {
+		case err != nil:
+			item.Action, item.Error = intentActionFailed, err.Error()
+		case state == transaction.IntentBroadcast:
+			item.Action = intentActionRecorded
+			err = resolver.FinalizeIntent(walletName, intent)
+		case rebroadcast:
+			item.Action = intentActionRebroadcast
+			_, err = resolver.RebroadcastIntent(ctx, walletName, intent)
+		case release:
+			item.Action = intentActionReleased
+			err = resolver.ReleaseIntent(walletName, intent.Hash)
+		default:
+			item.Action = intentActionPending
+		}
+		if err != nil {
+			item.Action, item.Error = intentActionFailed, err.Error()
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// formatIntentUnits formats a satoshi amount from an intent in whole coins.
+func formatIntentUnits(sats string) string {
+	n, ok := new(big.Int).SetString(sats, 10)
+	if !ok {
+		return sats
+	}
+	return chain.FormatDecimalAmount(n, 8)
+}
+
+// outputTxRecover shows the recovery outcome in text format.
+func outputTxRecover(w io.Writer, walletName string, items []txRecoverItem) {
+	if len(items) == 0 {
+		out(w, "No interrupted sends in wallet %s\n", walletName)
+		return
+	}
+
+	pending := 0
+	for _, item := range items {
+		out(w, "  Hash:    %s\n", item.Hash)
+		out(w, "  Send:    %s %s to %s (fee %s)\n", item.Amount, strings.ToUpper(item.Chain), item.To, item.Fee)
+		out(w, "  Started: %s\n", item.CreatedAt.Local().Format(time.DateTime))
+		switch item.Action {
+		case intentActionRecorded:
+			outln(w, "  Result:  reached the network; recorded in tx history")
+		case intentActionRebroadcast:
+			outln(w, "  Result:  rebroadcast; recorded in tx history")
+		case intentActionReleased:
+			outln(w, "  Result:  released; its inputs can be spent again")
+		case intentActionPending:
+			pending++
+			outln(w, "  Result:  not on the network; inputs still reserved")
+		default:
+			out(w, "  Result:  could not resolve: %s\n", item.Error)
+		}
+		outln(w)
+	}
+
+	if pending > 0 {
+		out(w, "%d send(s) never reached the network. Run again with --rebroadcast to submit\n", pending)
+		outln(w, "them, or with --release to discard them and free their inputs.")
+	}
+}
+
+// warnPendingIntents prints a notice to stderr for each wallet with sends
+// left unresolved by an interrupted tx send, or waiting in the outbox. tx
+// recover and the outbox commands are skipped.
+func warnPendingIntents(cmd *cobra.Command, home string) {
+	if cmd == txRecoverCmd || cmd.Parent() == outboxCmd || strings.HasPrefix(cmd.Name(), "__") {
+		return
+	}
+	walletsDir := filepath.Join(home, "wallets")
+	if counts, err := txintent.Pending(walletsDir); err == nil {
+		for _, name := range slices.Sorted(maps.Keys(counts)) {
+			out(cmd.ErrOrStderr(), "Warning: wallet %s has %d interrupted send(s); run: sigil tx recover --wallet %s\n",
+				name, counts[name], name)
+		}
+	}
+	if counts, err := txintent.Outbox(walletsDir); err == nil {
+		for _, name := range slices.Sorted(maps.Keys(counts)) {
+			out(cmd.ErrOrStderr(), "Notice: wallet %s has %d queued send(s); run: sigil outbox flush --wallet %s\n",
+				name, counts[name], name)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/txintent"
+)
+
+// fakeIntentResolver reports a fixed state per hash and records what it resolved.
+type fakeIntentResolver struct {
+	states         map[string]transaction.IntentState
+	checkErr       error
+	rebroadcastErr error
+	resolved       []string
+}
+
+func (f *fakeIntentResolver) CheckIntent(_ context.Context, intent *txintent.Intent) (transaction.IntentState, error) {
+	return f.states[intent.Hash], f.checkErr
+}
+
+func (f *fakeIntentResolver) RebroadcastIntent(_ context.Context, _ string, intent *txintent.Intent) (string, error) {
+	f.resolved = append(f.resolved, "rebroadcast:"+intent.Hash)
+	return intent.Hash, f.rebroadcastErr
+}
+
+func (f *fakeIntentResolver) FinalizeIntent(_ string, intent *txintent.Intent) error {
+	f.resolved = append(f.resolved, "finalize:"+intent.Hash)
+	return nil
+}
+
+func (f *fakeIntentResolver) ReleaseIntent(_, hash string) error {
+	f.resolved = append(f.resolved, "release:"+hash)
+	return nil
+}
+
+func TestResolveIntents(t *testing.T) {
+	t.Parallel()
+
+	intents := []txintent.Intent{
+		{Hash: "aa", Chain: chain.BSV, Amount: "150000000", Fee: "20"},
+		{Hash: "bb", Chain: chain.BTC, Amount: "5000", Fee: "300"},
+	}
+	states := map[string]transaction.IntentState{
+		"aa": transaction.IntentBroadcast,
+		"bb": transaction.IntentUnbroadcast,
+	}
+
+	tests := []struct {
+		name         string
+		rebroadcast  bool
+		release      bool
+		wantActions  []string
+		wantResolved []string
+	}{
+		{name: "check only", wantActions: []string{intentActionRecorded, intentActionPending}, wantResolved: []string{"finalize:aa"}},
+		{name: "rebroadcast", rebroadcast: true, wantActions: []string{intentActionRecorded, intentActionRebroadcast}, wantResolved: []string{"finalize:aa", "rebroadcast:bb"}},
+		{name: "release", release: true, wantActions: []string{intentActionRecorded, intentActionReleased}, wantResolved: []string{"finalize:aa", "release:bb"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			resolver := &fakeIntentResolver{states: states}
+			items := resolveIntents(context.Background(), resolver, "main", intents, tc.rebroadcast, tc.release)
+			require.Len(t, items, 2)
+			assert.Equal(t, tc.wantActions, []string{items[0].Action, items[1].Action})
+			assert.Equal(t, tc.wantResolved, resolver.resolved)
+			assert.Equal(t, "1.5", items[0].Amount)
+		})
+	}
+
+	t.Run("check failure leaves intent untouched", func(t *testing.T) {
+		t.Parallel()
+		resolver := &fakeIntentResolver{checkErr: errors.New("api down")}
+		items := resolveIntents(context.Background(), resolver, "main", intents[:1], false, true)
+		require.Len(t, items, 1)
+		assert.Equal(t, intentActionFailed, items[0].Action)
+		assert.Equal(t, "api down", items[0].Error)
+		assert.Empty(t, resolver.resolved)
+	})
+}
+
+func TestOutputTxRecover(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	outputTxRecover(&buf, "main", nil)
+	assert.Contains(t, buf.String(), "No interrupted sends in wallet main")
+
+	buf.Reset()
+	outputTxRecover(&buf, "main", []txRecoverItem{
+		{Hash: "aa", Chain: "bsv", To: "1To", Amount: "0.00005000", Fee: "0.00000020", Action: intentActionPending},
+	})
+	assert.Contains(t, buf.String(), "0.00005000 BSV to 1To")
+	assert.Contains(t, buf.String(), "--rebroadcast")
+}
+
+func TestWarnPendingIntents(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	require.NoError(t, txintent.New(filepath.Join(home, "wallets", "main")).Record(txintent.Intent{Hash: "aa", Chain: chain.BSV}))
+
+	var stderr bytes.Buffer
+	cmd := &cobra.Command{Use: "balance"}
+	cmd.SetErr(&stderr)
+	warnPendingIntents(cmd, home)
+	assert.Contains(t, stderr.String(), "wallet main has 1 interrupted send(s); run: sigil tx recover --wallet main")
+	assert.NotContains(t, stderr.String(), "queued")
+
+	require.NoError(t, txintent.New(filepath.Join(home, "wallets", "main")).Record(txintent.Intent{
+		Hash: "bb", Chain: chain.BSV, QueuedAt: time.Now(),
+	}))
+	stderr.Reset()
+	warnPendingIntents(cmd, home)
+	assert.Contains(t, stderr.String(), "wallet main has 1 interrupted send(s)")
+	assert.Contains(t, stderr.String(), "wallet main has 1 queued send(s); run: sigil outbox flush --wallet main")
+
+	stderr.Reset()
+	warnPendingIntents(cmd, t.TempDir())
+	assert.Empty(t, stderr.String())
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// txReplaceWallet is the wallet that sent the transaction being replaced.
+	txReplaceWallet string
+	// txReplaceGasSpeed is the gas speed the replacement is priced at.
+	txReplaceGasSpeed string
+	// txReplaceConfirm skips the review prompt.
+	txReplaceConfirm bool
+)
+
+// txSpeedupCmd re-sends a stuck ETH transaction at a higher gas price.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var txSpeedupCmd = &cobra.Command{
+	Use:   "speedup <hash>",
+	Short: "Re-send a stuck ETH transaction with a higher gas price",
+	Long: `Speed up a pending ETH transaction by signing it again with the same nonce
+and a higher gas price. The recipient, value, data, and gas limit are
+unchanged. Whichever of the two is mined first drops the other, so the
+payment is made only once.
+
+The new gas price is the current --gas price, raised when needed to 10% above
+the original, the least nodes accept for a replacement. The replacement is
+shown for review before the wallet is unlocked.`,
+	Example: `  sigil tx speedup 0x5c50...a1f3
+  sigil tx speedup 0x5c50...a1f3 --gas medium --wallet main`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTxReplace(cmd, args[0], false)
+	},
+}
+
+// txCancelCmd replaces a stuck ETH transaction with an empty self-send.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var txCancelCmd = &cobra.Command{
+	Use:   "cancel <hash>",
+	Short: "Cancel a stuck ETH transaction",
+	Long: `Cancel a pending ETH transaction by sending 0 ETH from its sender to itself
+with the same nonce and a higher gas price. If the cancellation is mined
+first, the original is dropped and only the cancellation's fee is paid. If
+the original is mined first, the cancellation is dropped instead.
+
+The gas price is chosen as for tx speedup, and the cancellation is shown for
+review before the wallet is unlocked.`,
+	Example: `  sigil tx cancel 0x5c50...a1f3
+  sigil tx cancel 0x5c50...a1f3 --yes -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTxReplace(cmd, args[0], true)
+	},
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	txCmd.AddCommand(txSpeedupCmd)
+	txCmd.AddCommand(txCancelCmd)
+
+	for _, cmd := range []*cobra.Command{txSpeedupCmd, txCancelCmd} {
+		cmd.Flags().StringVar(&txReplaceWallet, "wallet", "", "wallet that sent the transaction (defaults to config default_wallet)")
+		cmd.Flags().StringVar(&txReplaceGasSpeed, "gas", "fast", "gas speed to price the replacement at: slow, medium, fast")
+		cmd.Flags().BoolVar(&txReplaceConfirm, "yes", false, "skip confirmation prompt")
+	}
+}
+
+// runTxReplace replaces the pending ETH transaction hash, with an empty
+// self-send when cancel is set or a re-priced copy otherwise.
+func runTxReplace(cmd *cobra.Command, hash string, cancel bool) error {
+	cc := GetCmdContext(cmd)
+
+	hash = strings.TrimSpace(hash)
+	if err := validateTxStatusHash(chain.ETH, hash); err != nil {
+		return err
+	}
+	if cc.AgentXpub != "" || cc.AgentCred != nil {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAgentPolicyViolation,
+			"replacing a transaction needs the wallet password; run it interactively",
+		)
+	}
+	if err := resolveWalletName(cmd, &txReplaceWallet); err != nil {
+		return err
+	}
+	speed, err := eth.ParseGasSpeed(txReplaceGasSpeed)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+
+	ctx, cancelCtx := contextWithTimeout(cmd, 60*time.Second)
+	defer cancelCtx()
+
+	client, err := newOfflineETHClient(cc)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	repl, err := client.BuildReplacement(ctx, hash, cancel, speed)
+	if err != nil {
+		return err
+	}
+
+	if !txReplaceConfirm {
+		displayReplacement(cmd.ErrOrStderr(), repl, cancel)
+		if !promptConfirmFn() {
+			outln(cmd.OutOrStdout(), "Replacement canceled.")
+			return nil
+		}
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	_, seed, err := loadWalletWithSession(txReplaceWallet, storage, cmd)
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(seed)
+
+	key, err := wallet.DerivePrivateKeyForChain(seed, wallet.ChainETH, 0)
+	if err != nil {
+		return fmt.Errorf("deriving private key: %w", err)
+	}
+	defer wallet.ZeroBytes(key)
+
+	raw, _, err := eth.SignUnsigned(repl.Unsigned, repl.Original.From, key)
+	if err != nil {
+		return sigilerr.WithSuggestion(err, fmt.Sprintf("replace it with the wallet that owns %s", repl.Original.From))
+	}
+	newHash, err := client.BroadcastRaw(ctx, raw)
+	if err != nil {
+		return err
+	}
+
+	displayReplacementResult(cmd, repl, cancel, newHash)
+	return nil
+}
+
+// replaceAction names the replacement for display.
+func replaceAction(cancel bool) string {
+	if cancel {
+		return "cancel"
+	}
+	return "speedup"
+}
+
+// displayReplacement shows a replacement for review.
+func displayReplacement(w io.Writer, repl *eth.Replacement, cancel bool) {
+	orig := repl.Original
+	outln(w)
+	if cancel {
+		outln(w, "Cancel transaction")
+	} else {
+		outln(w, "Speed up transaction")
+	}
+	out(w, "  Replaces:  %s\n", orig.Hash)
+	out(w, "  From:      %s\n", orig.From)
+	out(w, "  Nonce:     %d\n", orig.Nonce)
+	if cancel {
+		out(w, "  Sends:     0 ETH to itself\n")
+	} else {
+		out(w, "  To:        %s\n", orig.To)
+		out(w, "  Value:     %s ETH\n", chain.FormatDecimalAmount(orig.Value, 18))
+	}
+	out(w, "  Gas Price: %s (was %s)\n", eth.FormatGasPrice(repl.GasPrice), eth.FormatGasPrice(orig.GasPrice))
+	out(w, "  Max Fee:   %s ETH\n", chain.FormatDecimalAmount(repl.MaxFee(), 18))
+}
+
+// displayReplacementResult shows the broadcast replacement in the
+// configured format.
+func displayReplacementResult(cmd *cobra.Command, repl *eth.Replacement, cancel bool, hash string) {
+	w := cmd.OutOrStdout()
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		displayReplacementResultJSON(w, repl, cancel, hash)
+	} else {
+		displayReplacementResultText(w, repl, cancel, hash)
+	}
+}
+
+// displayReplacementResultJSON shows the broadcast replacement in JSON format.
+func displayReplacementResultJSON(w io.Writer, repl *eth.Replacement, cancel bool, hash string) {
+	value, _ := new(big.Int).SetString(repl.Unsigned.Value, 10)
+	_ = writeJSON(w, struct {
+		Action   string `json:"action"`
+		Hash     string `json:"hash"`
+		Replaces string `json:"replaces"`
+		From     string `json:"from"`
+		To       string `json:"to"`
+		Nonce    uint64 `json:"nonce"`
+		Value    string `json:"value"`
+		GasLimit uint64 `json:"gas_limit"`
+		GasPrice string `json:"gas_price"`
+		MaxFee   string `json:"max_fee"`
+		Status   string `json:"status"`
+	}{
+		Action:   replaceAction(cancel),
+		Hash:     hash,
+		Replaces: repl.Original.Hash,
+		From:     repl.Original.From,
+		To:       repl.Unsigned.To,
+		Nonce:    repl.Unsigned.Nonce,
+		Value:    chain.FormatDecimalAmount(value, 18),
+		GasLimit: repl.Unsigned.GasLimit,
+		GasPrice: repl.GasPrice.String(),
+		MaxFee:   chain.FormatDecimalAmount(repl.MaxFee(), 18),
+		Status:   txStatusPending,
+	})
+}
+
+// displayReplacementResultText shows the broadcast replacement in text format.
+func displayReplacementResultText(w io.Writer, repl *eth.Replacement, cancel bool, hash string) {
+	if cancel {
+		outln(w, "\nCancellation broadcast successfully!")
+	} else {
+		outln(w, "\nReplacement broadcast successfully!")
+	}
+	outln(w)
+	out(w, "  Hash:      %s\n", hash)
+	out(w, "  Replaces:  %s\n", repl.Original.Hash)
+	out(w, "  Nonce:     %d\n", repl.Unsigned.Nonce)
+	out(w, "  Gas Price: %s\n", eth.FormatGasPrice(repl.GasPrice))
+	out(w, "  Max Fee:   %s ETH\n", chain.FormatDecimalAmount(repl.MaxFee(), 18))
+	outln(w)
+	outln(w, "Only one of the two transactions will be mined. Check which with:")
+	out(w, "  sigil tx status %s --wait\n", hash)
+}
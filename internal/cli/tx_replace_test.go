@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// testReplacement returns a cancellation of a 1 ETH payment sent at 2 Gwei.
+func testReplacement() *eth.Replacement {
+	from := "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	return &eth.Replacement{
+		Original: &rpc.Transaction{
+			Hash:     testTxHash,
+			From:     from,
+			To:       "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+			Nonce:    7,
+			Value:    big.NewInt(1_000_000_000_000_000_000),
+			Gas:      21000,
+			GasPrice: big.NewInt(2_000_000_000),
+			Pending:  true,
+		},
+		Unsigned: &chain.UnsignedETH{ChainID: "1", Nonce: 7, To: from, Value: "0", GasLimit: 21000, GasPrice: "2200000000"},
+		GasPrice: big.NewInt(2_200_000_000),
+	}
+}
+
+func TestDisplayReplacement(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cancel review", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		displayReplacement(&buf, testReplacement(), true)
+		assert.Contains(t, buf.String(), "Cancel transaction")
+		assert.Contains(t, buf.String(), "Nonce:     7")
+		assert.Contains(t, buf.String(), "Sends:     0 ETH to itself")
+		assert.Contains(t, buf.String(), "Gas Price: 2.20 Gwei (was 2.00 Gwei)")
+		assert.Contains(t, buf.String(), "Max Fee:   0.0000462 ETH")
+	})
+
+	t.Run("speedup review", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		displayReplacement(&buf, testReplacement(), false)
+		assert.Contains(t, buf.String(), "Speed up transaction")
+		assert.Contains(t, buf.String(), "To:        0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359")
+		assert.Contains(t, buf.String(), "Value:     1.0 ETH")
+	})
+
+	t.Run("text result", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayReplacementResult(cmd, testReplacement(), true, "0xnew")
+		assert.Contains(t, buf.String(), "Cancellation broadcast successfully!")
+		assert.Contains(t, buf.String(), "Replaces:  "+testTxHash)
+		assert.Contains(t, buf.String(), "sigil tx status 0xnew --wait")
+	})
+
+	t.Run("json result", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatJSON)
+		cmd.SetOut(&buf)
+		displayReplacementResult(cmd, testReplacement(), true, "0xnew")
+		assert.Contains(t, buf.String(), `"action": "cancel"`)
+		assert.Contains(t, buf.String(), `"hash": "0xnew"`)
+		assert.Contains(t, buf.String(), `"replaces": "`+testTxHash+`"`)
+		assert.Contains(t, buf.String(), `"nonce": 7`)
+		assert.Contains(t, buf.String(), `"value": "0.0"`)
+		assert.Contains(t, buf.String(), `"gas_price": "2200000000"`)
+		assert.Contains(t, buf.String(), `"max_fee": "0.0000462"`)
+		assert.Contains(t, buf.String(), `"status": "pending"`)
+	})
+}
+
+func TestRunTxReplace_Rejects(t *testing.T) {
+	t.Parallel()
+
+	cmd := newTestCmdWithContext(output.FormatText)
+	err := runTxReplace(cmd, "0x"+strings.Repeat("z", 64), false)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	cmd = newTestCmdWithContext(output.FormatText)
+	GetCmdContext(cmd).AgentCred = &agent.Credential{ID: "bot"}
+	err = runTxReplace(cmd, testTxHash, true)
+	require.ErrorIs(t, err, sigilerr.ErrAgentPolicyViolation)
+}
@@ -0,0 +1,467 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Transaction status values reported by tx status.
+const (
+	txStatusPending   = "pending"
+	txStatusConfirmed = "confirmed"
+	txStatusReverted  = "reverted"
+)
+
+// bsvStatusPollInterval is how often --wait checks a BSV transaction.
+const bsvStatusPollInterval = 10 * time.Second
+
+// ethStatusPollInterval is how often --wait checks the ETH chain tip while a
+// mined transaction gathers confirmations.
+const ethStatusPollInterval = 5 * time.Second
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// txStatusChain is the blockchain the transaction was sent on.
+	txStatusChain string
+	// txStatusWait polls until the transaction is mined.
+	txStatusWait bool
+	// txStatusTimeout bounds how long --wait polls.
+	txStatusTimeout time.Duration
+	// txStatusConfirmations overrides the chain's confirmation target.
+	txStatusConfirmations int
+)
+
+// ethTxHashRegex matches a 0x-prefixed 32-byte transaction hash.
+var ethTxHashRegex = regexp.MustCompile(`^0x[0-9a-fA-F]{64}$`)
+
+// bsvTxIDRegex matches a 32-byte BSV transaction ID.
+var bsvTxIDRegex = regexp.MustCompile(`^[0-9a-fA-F]{64}$`)
+
+// txStatusCmd reports the on-chain state of a broadcast transaction.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var txStatusCmd = &cobra.Command{
+	Use:   "status <hash>",
+	Short: "Show the confirmations and final fee of a transaction",
+	Long: `Look up a broadcast transaction and report whether it was mined, how many
+confirmations it has, and the fee it paid.
+
+For ETH, the receipt gives the block number, whether the transaction succeeded
+or reverted, the gas used, the effective gas price, and the final fee. The fee
+shown by tx send is an estimate (gas limit × gas price); the final fee is
+usually lower.
+
+For BSV, WhatsOnChain gives the block height and confirmations. The fee is the
+value of the inputs minus the outputs, so it is known while the transaction is
+still in the mempool.
+
+A transaction is reported confirmed once it has the chain's confirmation
+target, set per chain under confirmations in the config (one by default).
+Until then a mined transaction is still pending, with its confirmations
+shown against the target. --confirmations overrides the target for one
+check. A reverted ETH transaction is reported as soon as it is mined.
+
+Use --wait to poll until the transaction reaches the target or --timeout
+passes.`,
+	Example: `  # Check an ETH transaction once
+  sigil tx status 0x5c50...a1f3
+
+  # Wait for it to be mined
+  sigil tx status 0x5c50...a1f3 --wait
+
+  # Wait for six confirmations
+  sigil tx status 0x5c50...a1f3 --wait --confirmations 6
+
+  # Check a BSV transaction
+  sigil tx status 4a5e...9b2c --chain bsv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runTxStatus,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	txCmd.AddCommand(txStatusCmd)
+
+	txStatusCmd.Flags().StringVar(&txStatusChain, "chain", "eth", "blockchain: eth, bsv")
+	txStatusCmd.Flags().BoolVar(&txStatusWait, "wait", false, "poll until the transaction is mined")
+	txStatusCmd.Flags().DurationVar(&txStatusTimeout, "timeout", 5*time.Minute, "how long --wait polls before giving up")
+	txStatusCmd.Flags().IntVar(&txStatusConfirmations, "confirmations", 0, "confirmations needed to count as confirmed (default: the chain's confirmations setting)")
+}
+
+// receiptReader fetches ETH transaction receipts.
+type receiptReader interface {
+	GetTransactionReceipt(ctx context.Context, txHash string) (*rpc.Receipt, error)
+	WaitForReceipt(ctx context.Context, txHash string, interval time.Duration) (*rpc.Receipt, error)
+}
+
+// ethStatusReader fetches ETH receipts and the chain tip.
+type ethStatusReader interface {
+	receiptReader
+	BlockNumber(ctx context.Context) (uint64, error)
+}
+
+// bsvStatusReader fetches BSV transaction status.
+type bsvStatusReader interface {
+	GetTxStatus(ctx context.Context, txid string) (*bsv.TxStatus, error)
+}
+
+// txStatusResult is the state of a transaction as reported by tx status.
+type txStatusResult struct {
+	Chain             chain.ID
+	Hash              string
+	Status            string
+	Confirmations     uint64
+	Target            uint64 // confirmations needed to count as confirmed
+	BlockNumber       uint64
+	GasUsed           uint64   // ETH only
+	EffectiveGasPrice *big.Int // ETH only
+	Size              int      // BSV only, in bytes
+	Fee               *big.Int // in the chain's smallest unit
+}
+
+func runTxStatus(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+
+	chainID, ok := chain.ParseChainID(txStatusChain)
+	if !ok || (chainID != chain.ETH && chainID != chain.BSV) {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("tx status does not support chain %s (use eth or bsv)", txStatusChain),
+		)
+	}
+
+	hash := strings.TrimSpace(args[0])
+	if err := validateTxStatusHash(chainID, hash); err != nil {
+		return err
+	}
+
+	target, err := confirmationTarget(cc.Cfg, chainID, txStatusConfirmations)
+	if err != nil {
+		return err
+	}
+
+	timeout := 30 * time.Second
+	if txStatusWait {
+		timeout = txStatusTimeout
+	}
+	ctx, cancel := contextWithTimeout(cmd, timeout)
+	defer cancel()
+
+	var status *txStatusResult
+	if chainID == chain.BSV {
+		client := bsv.NewClient(ctx, &bsv.ClientOptions{
+			APIKey:  cc.Cfg.GetBSVAPIKey(),
+			Network: bsvClientNetwork(bsvNetworkForCmd(cmd)),
+			Logger:  cc.Log,
+		})
+		status, err = fetchBSVTxStatus(ctx, client, strings.ToLower(hash), txStatusWait, target, bsvStatusPollInterval)
+	} else {
+		client, clientErr := eth.NewClient(cc.Cfg.GetETHRPC(), nil)
+		if clientErr != nil {
+			return fmt.Errorf("creating ETH client: %w", clientErr)
+		}
+		defer client.Close()
+		status, err = fetchTxStatus(ctx, client, hash, txStatusWait, target, ethStatusPollInterval)
+	}
+	if isCanceled(err) {
+		out(cmd.ErrOrStderr(), "Stopped waiting for %s before it was confirmed.\n", hash)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	displayTxStatus(cmd, status)
+	return nil
+}
+
+// confirmationTarget returns the confirmations a transaction on chainID needs
+// to count as confirmed: override when it is set, otherwise the chain's
+// confirmations setting.
+func confirmationTarget(cfg ConfigProvider, chainID chain.ID, override int) (uint64, error) {
+	if override < 0 {
+		return 0, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid --confirmations %d: use a positive number", override),
+		)
+	}
+	if override > 0 {
+		return uint64(override), nil
+	}
+	return cfg.GetConfirmations().Target(string(chainID)), nil
+}
+
+// validateTxStatusHash checks that hash is a transaction hash on chainID.
+func validateTxStatusHash(chainID chain.ID, hash string) error {
+	if chainID == chain.BSV {
+		if !bsvTxIDRegex.MatchString(hash) {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("invalid transaction ID: %s (expected 64 hex characters)", hash),
+			)
+		}
+		return nil
+	}
+
+	if !ethTxHashRegex.MatchString(hash) {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid transaction hash: %s (expected 0x followed by 64 hex characters)", hash),
+		)
+	}
+	return nil
+}
+
+// fetchTxStatus reads the receipt of hash and counts its confirmations.
+// Without wait, a transaction that has not been mined yet is reported as
+// pending rather than as an error. With wait, once mined it checks the tip
+// every interval until the transaction has target confirmations.
+func fetchTxStatus(ctx context.Context, client ethStatusReader, hash string, wait bool, target uint64, interval time.Duration) (*txStatusResult, error) {
+	var receipt *rpc.Receipt
+	var err error
+	if wait {
+		receipt, err = client.WaitForReceipt(ctx, hash, 0)
+	} else {
+		receipt, err = client.GetTransactionReceipt(ctx, hash)
+	}
+
+	switch {
+	case err == nil:
+	case !wait && errors.Is(err, rpc.ErrReceiptNotFound):
+		return &txStatusResult{Chain: chain.ETH, Hash: hash, Status: txStatusPending, Target: target}, nil
+	case wait && errors.Is(err, context.DeadlineExceeded):
+		return nil, txStatusTimeoutError(hash)
+	default:
+		return nil, fmt.Errorf("getting transaction receipt: %w", err)
+	}
+
+	status := newTxStatusResult(hash, receipt, target)
+	for {
+		tip, err := client.BlockNumber(ctx)
+		switch {
+		case err == nil:
+		case wait && errors.Is(err, context.DeadlineExceeded):
+			return nil, txStatusTimeoutError(hash)
+		default:
+			return nil, fmt.Errorf("getting block number: %w", err)
+		}
+
+		// A node behind the one that served the receipt may report an older tip.
+		status.Confirmations = 1
+		if tip > receipt.BlockNumber {
+			status.Confirmations = tip - receipt.BlockNumber + 1
+		}
+		if status.Status == txStatusReverted {
+			return status, nil
+		}
+		status.Status = txStatusPending
+		if status.Confirmations >= target {
+			status.Status = txStatusConfirmed
+		}
+		if !wait || status.Status == txStatusConfirmed {
+			return status, nil
+		}
+
+		if err := waitStatusPoll(ctx, interval); err != nil {
+			if isCanceled(err) {
+				return nil, err
+			}
+			return nil, txStatusTimeoutError(hash)
+		}
+	}
+}
+
+// fetchBSVTxStatus reads the status of txid. With wait, it polls every
+// interval until the transaction has target confirmations, treating a
+// transaction WhatsOnChain has not seen yet as not mined.
+func fetchBSVTxStatus(ctx context.Context, client bsvStatusReader, txid string, wait bool, target uint64, interval time.Duration) (*txStatusResult, error) {
+	for {
+		status, err := client.GetTxStatus(ctx, txid)
+		switch {
+		case err == nil && (!wait || uint64(max(status.Confirmations, 0)) >= target): //nolint:gosec // G115: clamped at zero
+			return newBSVTxStatusResult(status, target), nil
+		case err == nil, wait && errors.Is(err, sigilerr.ErrTransactionNotFound):
+		case wait && errors.Is(err, context.DeadlineExceeded):
+			return nil, txStatusTimeoutError(txid)
+		default:
+			return nil, fmt.Errorf("getting transaction status: %w", err)
+		}
+
+		if err := waitStatusPoll(ctx, interval); err != nil {
+			if isCanceled(err) {
+				return nil, err
+			}
+			return nil, txStatusTimeoutError(txid)
+		}
+	}
+}
+
+// waitStatusPoll waits interval before the next status check, returning the
+// context's error if it ends first.
+func waitStatusPoll(ctx context.Context, interval time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(interval):
+		return nil
+	}
+}
+
+// txStatusTimeoutError reports that --wait gave up on hash.
+func txStatusTimeoutError(hash string) error {
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrNetworkError,
+		fmt.Sprintf("transaction %s was not confirmed before the timeout; retry later or raise --timeout", hash),
+	)
+}
+
+// newTxStatusResult computes the final fee from a mined receipt. The caller
+// counts its confirmations against target.
+func newTxStatusResult(hash string, receipt *rpc.Receipt, target uint64) *txStatusResult {
+	status := txStatusConfirmed
+	if !receipt.Success {
+		status = txStatusReverted
+	}
+
+	gasPrice := receipt.EffectiveGasPrice
+	if gasPrice == nil {
+		gasPrice = new(big.Int)
+	}
+
+	return &txStatusResult{
+		Chain:             chain.ETH,
+		Hash:              hash,
+		Status:            status,
+		Target:            target,
+		BlockNumber:       receipt.BlockNumber,
+		GasUsed:           receipt.GasUsed,
+		EffectiveGasPrice: gasPrice,
+		Fee:               new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(receipt.GasUsed)),
+	}
+}
+
+// newBSVTxStatusResult converts a BSV transaction status. A transaction with
+// no confirmations is still in the mempool, and one with fewer than target is
+// mined but still pending.
+func newBSVTxStatusResult(status *bsv.TxStatus, target uint64) *txStatusResult {
+	result := &txStatusResult{
+		Chain:         chain.BSV,
+		Hash:          status.TxID,
+		Status:        txStatusPending,
+		Confirmations: uint64(max(status.Confirmations, 0)), //nolint:gosec // G115: clamped at zero
+		Target:        target,
+		Size:          status.Size,
+		Fee:           new(big.Int).SetUint64(status.Fee),
+	}
+	if result.Confirmations > 0 {
+		result.BlockNumber = uint64(status.BlockHeight) //nolint:gosec // G115: block heights are positive
+	}
+	if result.Confirmations >= max(target, 1) {
+		result.Status = txStatusConfirmed
+	}
+	return result
+}
+
+// txStatusFeeDecimals returns the decimal places of the fee on chainID.
+func txStatusFeeDecimals(chainID chain.ID) int {
+	if chainID == chain.BSV {
+		return 8
+	}
+	return 18
+}
+
+// displayTxStatus shows a transaction status in the configured format.
+func displayTxStatus(cmd *cobra.Command, status *txStatusResult) {
+	cc := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+
+	if cc.Fmt.Format() == output.FormatJSON {
+		displayTxStatusJSON(w, status)
+	} else {
+		displayTxStatusText(w, status)
+	}
+}
+
+// displayTxStatusText shows a transaction status in text format.
+func displayTxStatusText(w io.Writer, status *txStatusResult) {
+	out(w, "  Hash:          %s\n", status.Hash)
+	out(w, "  Status:        %s\n", status.Status)
+	mined := status.Confirmations > 0
+	if mined {
+		if status.Target > 1 {
+			out(w, "  Confirmations: %d of %d\n", status.Confirmations, status.Target)
+		} else {
+			out(w, "  Confirmations: %d\n", status.Confirmations)
+		}
+		out(w, "  Block:         %d\n", status.BlockNumber)
+	}
+	if status.Chain == chain.BSV {
+		out(w, "  Size:          %d bytes\n", status.Size)
+	} else if mined {
+		out(w, "  Gas Used:      %d\n", status.GasUsed)
+		out(w, "  Gas Price:     %s\n", eth.FormatGasPrice(status.EffectiveGasPrice))
+	}
+	if status.Fee != nil {
+		out(w, "  Fee:           %s %s\n", chain.FormatDecimalAmount(status.Fee, txStatusFeeDecimals(status.Chain)),
+			strings.ToUpper(string(status.Chain)))
+	}
+
+	switch {
+	case status.Status != txStatusPending:
+	case mined:
+		outln(w)
+		out(w, "Mined, waiting for %d more confirmation(s). Use --wait to poll until it has them.\n",
+			status.Target-status.Confirmations)
+	default:
+		outln(w)
+		outln(w, "Not mined yet. Use --wait to poll until it is confirmed.")
+	}
+}
+
+// displayTxStatusJSON shows a transaction status in JSON format.
+func displayTxStatusJSON(w io.Writer, status *txStatusResult) {
+	payload := struct {
+		Chain             string `json:"chain"`
+		Hash              string `json:"hash"`
+		Status            string `json:"status"`
+		Confirmations     uint64 `json:"confirmations"`
+		Target            uint64 `json:"target_confirmations,omitempty"`
+		BlockNumber       uint64 `json:"block_number,omitempty"`
+		GasUsed           uint64 `json:"gas_used,omitempty"`
+		EffectiveGasPrice string `json:"effective_gas_price,omitempty"`
+		Size              int    `json:"size,omitempty"`
+		Fee               string `json:"fee,omitempty"`
+	}{
+		Chain:         string(status.Chain),
+		Hash:          status.Hash,
+		Status:        status.Status,
+		Confirmations: status.Confirmations,
+		Target:        status.Target,
+		BlockNumber:   status.BlockNumber,
+		GasUsed:       status.GasUsed,
+		Size:          status.Size,
+	}
+	if status.EffectiveGasPrice != nil {
+		payload.EffectiveGasPrice = status.EffectiveGasPrice.String()
+	}
+	if status.Fee != nil {
+		payload.Fee = chain.FormatDecimalAmount(status.Fee, txStatusFeeDecimals(status.Chain))
+	}
+
+	_ = writeJSON(w, payload)
+}
@@ -0,0 +1,362 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	testTxHash  = "0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"
+	testBSVTxID = "4a5e1e4baab89f3a32518a88c31bc87f618f76673e2cc77ab2127b7afdeda33b"
+)
+
+// fakeReceiptReader returns a fixed receipt or error and records which call was made.
+type fakeReceiptReader struct {
+	receipt *rpc.Receipt
+	err     error
+	tip     uint64
+	tipStep uint64 // added to tip after each BlockNumber call
+	waited  bool
+}
+
+func (f *fakeReceiptReader) GetTransactionReceipt(_ context.Context, _ string) (*rpc.Receipt, error) {
+	return f.receipt, f.err
+}
+
+func (f *fakeReceiptReader) WaitForReceipt(_ context.Context, _ string, _ time.Duration) (*rpc.Receipt, error) {
+	f.waited = true
+	return f.receipt, f.err
+}
+
+func (f *fakeReceiptReader) BlockNumber(_ context.Context) (uint64, error) {
+	tip := f.tip
+	f.tip += f.tipStep
+	return tip, nil
+}
+
+// fakeBSVStatusReader returns queued statuses or errors, one per call.
+type fakeBSVStatusReader struct {
+	statuses []*bsv.TxStatus
+	errs     []error
+	calls    int
+}
+
+func (f *fakeBSVStatusReader) GetTxStatus(_ context.Context, _ string) (*bsv.TxStatus, error) {
+	i := min(f.calls, len(f.statuses)-1)
+	f.calls++
+	return f.statuses[i], f.errs[i]
+}
+
+func TestFetchTxStatus(t *testing.T) {
+	t.Parallel()
+
+	mined := &rpc.Receipt{Success: true, BlockNumber: 19000000, GasUsed: 21000, EffectiveGasPrice: big.NewInt(12_000_000_000)}
+	reverted := &rpc.Receipt{Success: false, BlockNumber: 19000001, GasUsed: 50000, EffectiveGasPrice: big.NewInt(10_000_000_000)}
+
+	tests := []struct {
+		name              string
+		reader            *fakeReceiptReader
+		wait              bool
+		target            uint64
+		wantStatus        string
+		wantConfirmations uint64
+		wantFee           *big.Int
+		wantErr           bool
+		wantErrIs         error
+	}{
+		{name: "confirmed", reader: &fakeReceiptReader{receipt: mined, tip: 19000011}, wantStatus: txStatusConfirmed, wantConfirmations: 12, wantFee: big.NewInt(252_000_000_000_000)},
+		{name: "tip behind receipt", reader: &fakeReceiptReader{receipt: mined, tip: 18999999}, wantStatus: txStatusConfirmed, wantConfirmations: 1},
+		{name: "reverted", reader: &fakeReceiptReader{receipt: reverted, tip: 19000001}, wantStatus: txStatusReverted, wantConfirmations: 1, wantFee: big.NewInt(500_000_000_000_000)},
+		{name: "pending", reader: &fakeReceiptReader{err: rpc.ErrReceiptNotFound}, wantStatus: txStatusPending},
+		{name: "wait mined", reader: &fakeReceiptReader{receipt: mined, tip: 19000000}, wait: true, wantStatus: txStatusConfirmed, wantConfirmations: 1, wantFee: big.NewInt(252_000_000_000_000)},
+		{name: "below target", reader: &fakeReceiptReader{receipt: mined, tip: 19000002}, target: 6, wantStatus: txStatusPending, wantConfirmations: 3},
+		{name: "at target", reader: &fakeReceiptReader{receipt: mined, tip: 19000005}, target: 6, wantStatus: txStatusConfirmed, wantConfirmations: 6},
+		{name: "reverted below target", reader: &fakeReceiptReader{receipt: reverted, tip: 19000001}, target: 6, wantStatus: txStatusReverted, wantConfirmations: 1},
+		{name: "wait for target", reader: &fakeReceiptReader{receipt: mined, tip: 19000000, tipStep: 1}, wait: true, target: 3, wantStatus: txStatusConfirmed, wantConfirmations: 3},
+		{name: "wait timeout", reader: &fakeReceiptReader{err: context.DeadlineExceeded}, wait: true, wantErr: true, wantErrIs: sigilerr.ErrNetworkError},
+		{name: "rpc failure", reader: &fakeReceiptReader{err: errors.New("boom")}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			status, err := fetchTxStatus(context.Background(), tc.reader, testTxHash, tc.wait, max(tc.target, 1), time.Millisecond)
+			assert.Equal(t, tc.wait, tc.reader.waited)
+			if tc.wantErr {
+				require.Error(t, err)
+				if tc.wantErrIs != nil {
+					require.ErrorIs(t, err, tc.wantErrIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, status.Status)
+			assert.Equal(t, tc.wantConfirmations, status.Confirmations)
+			if tc.wantFee != nil {
+				assert.Equal(t, tc.wantFee, status.Fee)
+			}
+		})
+	}
+}
+
+func TestFetchBSVTxStatus(t *testing.T) {
+	t.Parallel()
+
+	mempool := &bsv.TxStatus{TxID: testBSVTxID, Fee: 500, Size: 226}
+	mined := &bsv.TxStatus{TxID: testBSVTxID, Confirmations: 3, BlockHeight: 800000, Fee: 500, Size: 226}
+	notFound := sigilerr.WithDetails(sigilerr.ErrTransactionNotFound, map[string]string{"txid": testBSVTxID})
+
+	t.Run("confirmed", func(t *testing.T) {
+		t.Parallel()
+		reader := &fakeBSVStatusReader{statuses: []*bsv.TxStatus{mined}, errs: []error{nil}}
+		status, err := fetchBSVTxStatus(context.Background(), reader, testBSVTxID, false, 1, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txStatusConfirmed, status.Status)
+		assert.Equal(t, uint64(3), status.Confirmations)
+		assert.Equal(t, uint64(800000), status.BlockNumber)
+		assert.Equal(t, big.NewInt(500), status.Fee)
+	})
+
+	t.Run("mempool", func(t *testing.T) {
+		t.Parallel()
+		reader := &fakeBSVStatusReader{statuses: []*bsv.TxStatus{mempool}, errs: []error{nil}}
+		status, err := fetchBSVTxStatus(context.Background(), reader, testBSVTxID, false, 1, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txStatusPending, status.Status)
+		assert.Zero(t, status.BlockNumber)
+		assert.Equal(t, big.NewInt(500), status.Fee)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+		reader := &fakeBSVStatusReader{statuses: []*bsv.TxStatus{nil}, errs: []error{notFound}}
+		_, err := fetchBSVTxStatus(context.Background(), reader, testBSVTxID, false, 1, time.Millisecond)
+		require.ErrorIs(t, err, sigilerr.ErrTransactionNotFound)
+	})
+
+	t.Run("wait polls until mined", func(t *testing.T) {
+		t.Parallel()
+		reader := &fakeBSVStatusReader{
+			statuses: []*bsv.TxStatus{nil, mempool, mined},
+			errs:     []error{notFound, nil, nil},
+		}
+		status, err := fetchBSVTxStatus(context.Background(), reader, testBSVTxID, true, 1, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txStatusConfirmed, status.Status)
+		assert.Equal(t, 3, reader.calls)
+	})
+
+	t.Run("below target", func(t *testing.T) {
+		t.Parallel()
+		reader := &fakeBSVStatusReader{statuses: []*bsv.TxStatus{mined}, errs: []error{nil}}
+		status, err := fetchBSVTxStatus(context.Background(), reader, testBSVTxID, false, 6, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txStatusPending, status.Status)
+		assert.Equal(t, uint64(3), status.Confirmations)
+		assert.Equal(t, uint64(800000), status.BlockNumber)
+	})
+
+	t.Run("wait polls until target", func(t *testing.T) {
+		t.Parallel()
+		deeper := *mined
+		deeper.Confirmations = 6
+		reader := &fakeBSVStatusReader{
+			statuses: []*bsv.TxStatus{mined, &deeper},
+			errs:     []error{nil, nil},
+		}
+		status, err := fetchBSVTxStatus(context.Background(), reader, testBSVTxID, true, 6, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, txStatusConfirmed, status.Status)
+		assert.Equal(t, 2, reader.calls)
+	})
+
+	t.Run("wait timeout", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		reader := &fakeBSVStatusReader{statuses: []*bsv.TxStatus{mempool}, errs: []error{nil}}
+		_, err := fetchBSVTxStatus(ctx, reader, testBSVTxID, true, 1, time.Millisecond)
+		require.ErrorIs(t, err, sigilerr.ErrNetworkError)
+	})
+	t.Run("wait canceled", func(t *testing.T) {
+		t.Parallel()
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(20*time.Millisecond, cancel)
+		reader := &fakeBSVStatusReader{statuses: []*bsv.TxStatus{mempool}, errs: []error{nil}}
+		_, err := fetchBSVTxStatus(ctx, reader, testBSVTxID, true, 1, time.Millisecond)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestDisplayTxStatus(t *testing.T) {
+	t.Parallel()
+
+	confirmed := newTxStatusResult(testTxHash, &rpc.Receipt{
+		Success: true, BlockNumber: 19000000, GasUsed: 21000, EffectiveGasPrice: big.NewInt(12_000_000_000),
+	}, 1)
+	confirmed.Confirmations = 12
+	pending := &txStatusResult{Chain: chain.ETH, Hash: testTxHash, Status: txStatusPending}
+	bsvMempool := newBSVTxStatusResult(&bsv.TxStatus{TxID: testBSVTxID, Fee: 500, Size: 226}, 1)
+	bsvMined := newBSVTxStatusResult(&bsv.TxStatus{TxID: testBSVTxID, Confirmations: 3, BlockHeight: 800000, Fee: 500, Size: 226}, 1)
+	bsvShallow := newBSVTxStatusResult(&bsv.TxStatus{TxID: testBSVTxID, Confirmations: 2, BlockHeight: 800000, Fee: 500, Size: 226}, 6)
+
+	t.Run("text confirmed", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, confirmed)
+		assert.Contains(t, buf.String(), "Status:        confirmed")
+		assert.Contains(t, buf.String(), "Confirmations: 12")
+		assert.Contains(t, buf.String(), "Block:         19000000")
+		assert.Contains(t, buf.String(), "Fee:           0.000252 ETH")
+	})
+
+	t.Run("text pending", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, pending)
+		assert.Contains(t, buf.String(), "--wait")
+		assert.NotContains(t, buf.String(), "Fee:")
+	})
+
+	t.Run("text bsv mined", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, bsvMined)
+		assert.Contains(t, buf.String(), "Confirmations: 3")
+		assert.Contains(t, buf.String(), "Block:         800000")
+		assert.Contains(t, buf.String(), "Size:          226 bytes")
+		assert.Contains(t, buf.String(), "Fee:           0.000005 BSV")
+		assert.NotContains(t, buf.String(), "Gas")
+	})
+
+	t.Run("text bsv mempool shows fee", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, bsvMempool)
+		assert.Contains(t, buf.String(), "Status:        pending")
+		assert.Contains(t, buf.String(), "Fee:           0.000005 BSV")
+		assert.NotContains(t, buf.String(), "Block:")
+	})
+
+	t.Run("text below target", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatText)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, bsvShallow)
+		assert.Contains(t, buf.String(), "Status:        pending")
+		assert.Contains(t, buf.String(), "Confirmations: 2 of 6")
+		assert.Contains(t, buf.String(), "Block:         800000")
+		assert.Contains(t, buf.String(), "waiting for 4 more confirmation(s)")
+	})
+
+	t.Run("json below target", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatJSON)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, bsvShallow)
+		assert.Contains(t, buf.String(), `"status": "pending"`)
+		assert.Contains(t, buf.String(), `"confirmations": 2`)
+		assert.Contains(t, buf.String(), `"target_confirmations": 6`)
+	})
+
+	t.Run("json confirmed", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatJSON)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, confirmed)
+		assert.Contains(t, buf.String(), `"status": "confirmed"`)
+		assert.Contains(t, buf.String(), `"confirmations": 12`)
+		assert.Contains(t, buf.String(), `"effective_gas_price": "12000000000"`)
+		assert.Contains(t, buf.String(), `"fee": "0.000252"`)
+	})
+
+	t.Run("json pending", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatJSON)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, pending)
+		assert.Contains(t, buf.String(), `"status": "pending"`)
+		assert.NotContains(t, buf.String(), `"fee"`)
+	})
+
+	t.Run("json bsv", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		cmd := newTestCmdWithContext(output.FormatJSON)
+		cmd.SetOut(&buf)
+		displayTxStatus(cmd, bsvMined)
+		assert.Contains(t, buf.String(), `"chain": "bsv"`)
+		assert.Contains(t, buf.String(), `"confirmations": 3`)
+		assert.Contains(t, buf.String(), `"block_number": 800000`)
+		assert.Contains(t, buf.String(), `"size": 226`)
+		assert.Contains(t, buf.String(), `"fee": "0.000005"`)
+		assert.NotContains(t, buf.String(), "gas")
+	})
+}
+
+func TestRunTxStatus_InvalidHash(t *testing.T) {
+	t.Parallel()
+
+	cmd := newTestCmdWithContext(output.FormatText)
+	err := runTxStatus(cmd, []string{"0x" + strings.Repeat("z", 64)})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestConfirmationTarget(t *testing.T) {
+	t.Parallel()
+
+	cfg := &mockConfigProvider{confirmations: config.ConfirmationsConfig{BSV: 6}}
+
+	target, err := confirmationTarget(cfg, chain.BSV, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), target)
+
+	// Chains without a setting need one confirmation
+	target, err = confirmationTarget(cfg, chain.ETH, 0)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), target)
+
+	target, err = confirmationTarget(cfg, chain.BSV, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), target)
+
+	_, err = confirmationTarget(cfg, chain.BSV, -1)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestValidateTxStatusHash(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, validateTxStatusHash(chain.ETH, testTxHash))
+	require.NoError(t, validateTxStatusHash(chain.BSV, testBSVTxID))
+	require.ErrorIs(t, validateTxStatusHash(chain.ETH, testBSVTxID), sigilerr.ErrInvalidInput)
+	require.ErrorIs(t, validateTxStatusHash(chain.BSV, testTxHash), sigilerr.ErrInvalidInput)
+}
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
@@ -15,10 +17,13 @@ import (
 
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
 	"github.com/mrz1836/sigil/internal/chain/eth"
 	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // TestIsAmountAll tests the isAmountAll helper function.
@@ -377,14 +382,22 @@ func TestResolveToken(t *testing.T) {
 			wantErr:     true,
 		},
 		{
-			name:        "unsupported token USDT",
-			symbol:      "USDT",
-			wantAddress: false,
-			wantErr:     true,
+			name:         "built-in token USDT",
+			symbol:       "USDT",
+			wantAddress:  true,
+			wantDecimals: 6,
+			wantErr:      false,
+		},
+		{
+			name:         "built-in token dai",
+			symbol:       "dai",
+			wantAddress:  true,
+			wantDecimals: 18,
+			wantErr:      false,
 		},
 		{
-			name:        "unsupported token DAI",
-			symbol:      "DAI",
+			name:        "unsupported token UNI",
+			symbol:      "UNI",
 			wantAddress: false,
 			wantErr:     true,
 		},
@@ -467,7 +480,7 @@ func TestDisplayBSVTxResultText(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	displayBSVTxResultText(&buf, result, "main")
+	displayBSVTxResultText(&buf, result, "main", nil, nil)
 	out := buf.String()
 
 	assert.Contains(t, out, "Transaction broadcast successfully!")
@@ -478,6 +491,37 @@ func TestDisplayBSVTxResultText(t *testing.T) {
 	assert.Contains(t, out, "whatsonchain.com/tx/abc123def456")
 }
 
+func TestResolveCoinSelection(t *testing.T) {
+	t.Cleanup(func() { txCoinSelection = "" })
+
+	strategy, err := resolveCoinSelection(&mockConfigProvider{})
+	require.NoError(t, err)
+	assert.Equal(t, bsv.CoinSelectionLargestFirst, strategy, "falls back to the config")
+
+	txCoinSelection = "bnb"
+	strategy, err = resolveCoinSelection(&mockConfigProvider{})
+	require.NoError(t, err)
+	assert.Equal(t, bsv.CoinSelectionBranchAndBound, strategy)
+
+	txCoinSelection = "random"
+	_, err = resolveCoinSelection(&mockConfigProvider{})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestDisplayBSVTxResult_CoinSelection(t *testing.T) {
+	t.Parallel()
+
+	result := &chain.TransactionResult{Hash: "abc123", Status: "pending", CoinSelection: "branch-and-bound"}
+
+	var text bytes.Buffer
+	displayBSVTxResultText(&text, result, "main", nil, nil)
+	assert.Contains(t, text.String(), "Coins:  branch-and-bound selection")
+
+	var buf bytes.Buffer
+	displayBSVTxResultJSON(&buf, result, "", nil, nil)
+	assert.Contains(t, buf.String(), `"coin_selection": "branch-and-bound"`)
+}
+
 func TestDisplayBSVTxResultJSON(t *testing.T) {
 	t.Parallel()
 
@@ -491,11 +535,12 @@ func TestDisplayBSVTxResultJSON(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	displayBSVTxResultJSON(&buf, result)
+	displayBSVTxResultJSON(&buf, result, "https://whatsonchain.com/tx/abc123", nil, nil)
 
 	var parsed map[string]any
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
 	assert.Equal(t, "abc123", parsed["hash"])
+	assert.Equal(t, "https://whatsonchain.com/tx/abc123", parsed["explorer_url"])
 	assert.Equal(t, "1From", parsed["from"])
 	assert.Equal(t, "1To", parsed["to"])
 	assert.Equal(t, "0.5", parsed["amount"])
@@ -557,7 +602,7 @@ func TestDisplayTxDetails(t *testing.T) {
 			var buf bytes.Buffer
 			cmd := &cobra.Command{}
 			cmd.SetOut(&buf)
-			displayTxDetails(cmd, tc.from, tc.to, tc.amount, tc.token, tc.estimate)
+			displayTxDetails(cmd, tc.from, tc.to, tc.amount, tc.token, tc.estimate, nil)
 			result := buf.String()
 			for _, s := range tc.wantContains {
 				assert.Contains(t, result, s)
@@ -610,7 +655,7 @@ func TestDisplayTxResultText(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			var buf bytes.Buffer
-			displayTxResultText(&buf, tc.result)
+			displayTxResultText(&buf, "main", tc.result, nil, nil)
 			out := buf.String()
 			for _, s := range tc.wantContains {
 				assert.Contains(t, out, s)
@@ -677,7 +722,7 @@ func TestDisplayTxResultJSON(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			var buf bytes.Buffer
-			displayTxResultJSON(&buf, tc.result)
+			displayTxResultJSON(&buf, tc.result, "", nil, nil)
 
 			var parsed map[string]any
 			require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
@@ -718,7 +763,7 @@ func TestDisplayTxResultJSON_Escaping(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	displayTxResultJSON(&buf, result)
+	displayTxResultJSON(&buf, result, "", nil, nil)
 
 	var parsed chain.TransactionResult
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
@@ -757,7 +802,7 @@ func TestDisplayTxResult_TextAndJSON(t *testing.T) {
 		var buf bytes.Buffer
 		cmd := newTestCmdWithContext(output.FormatText)
 		cmd.SetOut(&buf)
-		displayTxResult(cmd, result)
+		displayTxResult(cmd, result, nil, nil)
 		assert.Contains(t, buf.String(), "Transaction broadcast successfully!")
 		assert.Contains(t, buf.String(), "etherscan.io/tx/0xabc")
 	})
@@ -767,7 +812,7 @@ func TestDisplayTxResult_TextAndJSON(t *testing.T) {
 		var buf bytes.Buffer
 		cmd := newTestCmdWithContext(output.FormatJSON)
 		cmd.SetOut(&buf)
-		displayTxResult(cmd, result)
+		displayTxResult(cmd, result, nil, nil)
 		assert.Contains(t, buf.String(), `"hash": "0xabc"`)
 		assert.Contains(t, buf.String(), `"gas_used": 21000`)
 	})
@@ -790,7 +835,7 @@ func TestDisplayBSVTxResult_TextAndJSON(t *testing.T) {
 		var buf bytes.Buffer
 		cmd := newTestCmdWithContext(output.FormatText)
 		cmd.SetOut(&buf)
-		displayBSVTxResult(cmd, result, "main")
+		displayBSVTxResult(cmd, result, "main", nil, nil)
 		assert.Contains(t, buf.String(), "Transaction broadcast successfully!")
 		assert.Contains(t, buf.String(), "whatsonchain.com/tx/bsvhash123")
 	})
@@ -800,7 +845,7 @@ func TestDisplayBSVTxResult_TextAndJSON(t *testing.T) {
 		var buf bytes.Buffer
 		cmd := newTestCmdWithContext(output.FormatJSON)
 		cmd.SetOut(&buf)
-		displayBSVTxResult(cmd, result, "main")
+		displayBSVTxResult(cmd, result, "main", nil, nil)
 		assert.Contains(t, buf.String(), `"hash": "bsvhash123"`)
 		assert.Contains(t, buf.String(), `"status": "pending"`)
 	})
@@ -1448,3 +1493,158 @@ func TestMarkSpentBSVUTXOs(t *testing.T) {
 		assert.NotEmpty(t, logger.errorCalls, "should log an error on save failure")
 	})
 }
+
+// fakeTokenBalanceReader returns a fixed token balance.
+type fakeTokenBalanceReader struct {
+	balance *big.Int
+	err     error
+	token   string
+}
+
+func (f *fakeTokenBalanceReader) GetTokenBalance(_ context.Context, _, tokenAddress string) (*big.Int, error) {
+	f.token = tokenAddress
+	return f.balance, f.err
+}
+
+func TestPreviewTokenAmount(t *testing.T) {
+	t.Parallel()
+
+	meta := &eth.TokenMetadata{Address: eth.USDCMainnet, Symbol: "USDC", Decimals: 6}
+
+	t.Run("uses the entered amount", func(t *testing.T) {
+		t.Parallel()
+		reader := &fakeTokenBalanceReader{}
+		amount, err := previewTokenAmount(context.Background(), reader, &transaction.SendRequest{AmountStr: "12.5", TokenMeta: meta})
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(12_500_000), amount)
+		assert.Empty(t, reader.token, "no balance lookup for a fixed amount")
+	})
+
+	t.Run("sweep uses the token balance", func(t *testing.T) {
+		t.Parallel()
+		reader := &fakeTokenBalanceReader{balance: big.NewInt(777)}
+		amount, err := previewTokenAmount(context.Background(), reader, &transaction.SendRequest{AmountStr: "all", TokenMeta: meta})
+		require.NoError(t, err)
+		assert.Equal(t, big.NewInt(777), amount)
+		assert.Equal(t, eth.USDCMainnet, reader.token)
+	})
+
+	t.Run("balance error", func(t *testing.T) {
+		t.Parallel()
+		reader := &fakeTokenBalanceReader{err: errors.New("rpc down")} //nolint:err113 // test error
+		_, err := previewTokenAmount(context.Background(), reader, &transaction.SendRequest{AmountStr: "all", TokenMeta: meta})
+		require.ErrorContains(t, err, "rpc down")
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		t.Parallel()
+		_, err := previewTokenAmount(context.Background(), &fakeTokenBalanceReader{}, &transaction.SendRequest{AmountStr: "1.2.3", TokenMeta: meta})
+		require.Error(t, err)
+	})
+}
+
+func TestDisplayTokenAmountWarning(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		token *eth.TokenMetadata
+		want  string
+	}{
+		{name: "native ETH", token: nil},
+		{name: "plain token", token: &eth.TokenMetadata{Symbol: "USDC"}},
+		{name: "fee-on-transfer", token: &eth.TokenMetadata{Symbol: "PAXG", FeeOnTransfer: true}, want: "PAXG is a fee-on-transfer token"},
+		{name: "rebasing", token: &eth.TokenMetadata{Symbol: "AMPL", Rebasing: true}, want: "AMPL is a rebasing token"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			displayTokenAmountWarning(&buf, tc.token)
+			if tc.want == "" {
+				assert.Empty(t, buf.String())
+				return
+			}
+			assert.Contains(t, buf.String(), tc.want)
+		})
+	}
+}
+
+func TestDisplayInternalTransferBanner(t *testing.T) {
+	t.Parallel()
+
+	wlt := &wallet.Wallet{
+		Name: "main",
+		Addresses: map[wallet.ChainID][]wallet.Address{
+			wallet.ChainBSV: {{Address: "1Own"}, {Address: "1Other"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	displayInternalTransferBanner(&buf, wlt, &transaction.SendRequest{ChainID: chain.BSV, To: "1Own", AmountStr: "all"})
+	assert.Contains(t, buf.String(), "INTERNAL TRANSFER")
+	assert.Contains(t, buf.String(), "wallet 'main'")
+
+	buf.Reset()
+	displayInternalTransferBanner(&buf, wlt, &transaction.SendRequest{ChainID: chain.BSV, To: "1Stranger", AmountStr: "1"})
+	assert.Empty(t, buf.String())
+}
+
+func TestDisplayTxResultText_Delivered(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	displayTxResultText(&buf, "main", &chain.TransactionResult{Hash: "0xabc", Amount: "1.0", Delivered: "0.98", Token: "PAXG", Status: "pending"}, nil, nil)
+	assert.Contains(t, buf.String(), "Received: 0.98 PAXG")
+}
+
+func TestResolveTxUTXOs(t *testing.T) {
+	t.Cleanup(func() { txUTXOs = nil })
+
+	txid := strings.Repeat("CD", 32)
+	txUTXOs = []string{txid + ":1"}
+	require.NoError(t, resolveTxUTXOs(chain.BSV))
+	assert.Equal(t, []string{strings.ToLower(txid) + ":1"}, txUTXOs)
+
+	require.ErrorIs(t, resolveTxUTXOs(chain.ETH), sigilerr.ErrInvalidInput)
+
+	txUTXOs = []string{"not-an-outpoint"}
+	require.ErrorIs(t, resolveTxUTXOs(chain.BSV), sigilerr.ErrInvalidInput)
+}
+
+func TestResolveTxNonce(t *testing.T) {
+	t.Cleanup(func() { txNonce, txPayments = 0, nil })
+
+	nonce, err := resolveTxNonce(chain.ETH, false)
+	require.NoError(t, err)
+	assert.Nil(t, nonce)
+
+	txNonce = 0
+	nonce, err = resolveTxNonce(chain.ETH, true)
+	require.NoError(t, err)
+	require.NotNil(t, nonce)
+	assert.Equal(t, uint64(0), *nonce, "nonce 0 is a valid override")
+
+	_, err = resolveTxNonce(chain.BSV, true)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	txPayments = []transaction.Payment{{To: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", AmountStr: "0.1"}}
+	_, err = resolveTxNonce(chain.ETH, true)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestResolveTxEnvelope(t *testing.T) {
+	t.Cleanup(func() { txEnvelope = "" })
+
+	require.NoError(t, resolveTxEnvelope(chain.ETH), "no envelope")
+
+	txEnvelope = " Rent "
+	require.NoError(t, resolveTxEnvelope(chain.BCH))
+	assert.Equal(t, "rent", txEnvelope)
+
+	require.ErrorIs(t, resolveTxEnvelope(chain.ETH), sigilerr.ErrInvalidInput)
+
+	txEnvelope = "bills:rent"
+	require.ErrorIs(t, resolveTxEnvelope(chain.BSV), sigilerr.ErrInvalidInput)
+}
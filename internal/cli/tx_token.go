@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/tokens"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// resolveTxToken resolves the --token value to its contract metadata.
+// Known symbols and addresses resolve locally. An unknown contract address
+// has its symbol and decimals read from the chain and shown on stderr for
+// confirmation (skipped when skipPrompt is set); with save, the token is
+// added to the config token list for future sends.
+// Returns false if the user declined the token.
+func resolveTxToken(ctx context.Context, cmd *cobra.Command, token string, skipPrompt, save bool) (*eth.TokenMetadata, bool, error) {
+	cc := GetCmdContext(cmd)
+	configured := cc.Cfg.GetETHTokens()
+
+	if meta, ok := transaction.LookupToken(configured, token); ok {
+		return meta, true, nil
+	}
+	if !eth.IsValidAddress(token) {
+		// Unknown symbol: let the resolver produce the error.
+		_, err := transaction.ResolveToken(ctx, nil, configured, token)
+		return nil, false, err
+	}
+
+	client, err := eth.NewClient(cc.Cfg.GetETHRPC(), nil)
+	if err != nil {
+		return nil, false, fmt.Errorf("creating ETH client for token lookup: %w", err)
+	}
+	defer client.Close()
+
+	meta, err := transaction.ResolveToken(ctx, client, configured, token)
+	if err != nil {
+		return nil, false, err
+	}
+
+	w := cmd.ErrOrStderr()
+	out(w, "Unknown token contract %s\n", meta.Address)
+	out(w, "  Symbol:   %s\n", meta.Symbol)
+	out(w, "  Decimals: %d\n", meta.Decimals)
+	if !skipPrompt && !promptConfirmFn() {
+		return nil, false, nil
+	}
+
+	if save {
+		if err := saveETHToken(cc.Cfg.GetHome(), ethNetwork(cc.Cfg), configured, meta); err != nil {
+			return nil, false, err
+		}
+		out(w, "Saved token %s to config\n", meta.Symbol)
+	}
+
+	return meta, true, nil
+}
+
+// saveETHToken adds a token to the config file's ETH token list for the
+// network. A symbol that already names a built-in or configured contract is
+// rejected.
+func saveETHToken(home, network string, configured []config.TokenConfig, meta *eth.TokenMetadata) error {
+	token := config.TokenConfig{
+		Symbol:   meta.Symbol,
+		Address:  meta.Address,
+		Decimals: meta.Decimals,
+	}
+	if err := tokens.Validate(configured, token); err != nil {
+		return sigilerr.WithSuggestion(err, "add the token under another symbol with: sigil tokens add "+meta.Address+" --symbol <SYMBOL>")
+	}
+	return updateConfigTokens(home, network, func(c *config.Config) { c.AddETHToken(token) })
+}
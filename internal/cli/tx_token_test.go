@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const testTokenContract = "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"
+
+// newTokenRPCServer answers decimals() with 18 and symbol() with "UNI".
+func newTokenRPCServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	word := func(n int) string { return fmt.Sprintf("%064x", n) }
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     any               `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			return
+		}
+
+		result := "0x1"
+		if req.Method == "eth_call" {
+			var msg struct {
+				Data string `json:"data"`
+			}
+			assert.NoError(t, json.Unmarshal(req.Params[0], &msg))
+			if strings.HasPrefix(msg.Data, "0x313ce567") {
+				result = "0x" + word(18)
+			} else {
+				// ABI string "UNI": offset 32, length 3, data
+				result = "0x" + word(32) + word(3) + "554e49" + strings.Repeat("0", 58)
+			}
+		}
+
+		assert.NoError(t, json.NewEncoder(w).Encode(map[string]any{
+			"jsonrpc": "2.0",
+			"id":      req.ID,
+			"result":  result,
+		}))
+	}))
+}
+
+func newTokenTestCmd(home, rpcURL string, tokens []config.TokenConfig) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{
+		Cfg: &mockConfigProvider{home: home, ethRPC: rpcURL, ethTokens: tokens},
+	})
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+	return cmd, &stderr
+}
+
+func TestResolveTxToken(t *testing.T) {
+	t.Parallel()
+
+	t.Run("built-in symbol", func(t *testing.T) {
+		t.Parallel()
+		cmd, stderr := newTokenTestCmd(t.TempDir(), "", nil)
+
+		meta, accepted, err := resolveTxToken(context.Background(), cmd, "usdc", false, false)
+		require.NoError(t, err)
+		assert.True(t, accepted)
+		assert.Equal(t, eth.USDCMainnet, meta.Address)
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("configured contract address", func(t *testing.T) {
+		t.Parallel()
+		configured := []config.TokenConfig{{Symbol: "UNI", Address: testTokenContract, Decimals: 18}}
+		cmd, _ := newTokenTestCmd(t.TempDir(), "", configured)
+
+		meta, accepted, err := resolveTxToken(context.Background(), cmd, strings.ToLower(testTokenContract), false, false)
+		require.NoError(t, err)
+		assert.True(t, accepted)
+		assert.Equal(t, "UNI", meta.Symbol)
+	})
+
+	t.Run("unknown symbol", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTokenTestCmd(t.TempDir(), "", nil)
+
+		_, _, err := resolveTxToken(context.Background(), cmd, "UNI", false, false)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	})
+
+	t.Run("unknown contract is fetched and saved", func(t *testing.T) {
+		t.Parallel()
+		server := newTokenRPCServer(t)
+		defer server.Close()
+		home := t.TempDir()
+		cmd, stderr := newTokenTestCmd(home, server.URL, nil)
+
+		meta, accepted, err := resolveTxToken(context.Background(), cmd, testTokenContract, true, true)
+		require.NoError(t, err)
+		assert.True(t, accepted)
+		assert.Equal(t, "UNI", meta.Symbol)
+		assert.Equal(t, 18, meta.Decimals)
+		assert.Contains(t, stderr.String(), "Unknown token contract "+testTokenContract)
+		assert.Contains(t, stderr.String(), "Saved token UNI to config")
+
+		saved, err := config.Load(config.Path(home))
+		require.NoError(t, err)
+		tok, ok := config.FindETHToken(saved.GetETHTokens(), testTokenContract)
+		require.True(t, ok)
+		assert.Equal(t, 18, tok.Decimals)
+	})
+}
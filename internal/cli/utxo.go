@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"math"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -23,10 +25,28 @@ var (
 	utxoWallet string
 	// utxoChain is the chain to list UTXOs for.
 	utxoChain string
-	// utxoAddresses is a list of specific addresses to refresh.
+	// utxoAddresses is a list of specific addresses to refresh or list.
 	utxoAddresses []string
+	// utxoMinAmount hides UTXOs worth less than this amount.
+	utxoMinAmount string
+	// utxoConfirmedOnly hides UTXOs below the confirmation target.
+	utxoConfirmedOnly bool
+	// utxoConfirmations overrides the BSV confirmation target.
+	utxoConfirmations int
+	// utxoIncludeSpent also lists UTXOs already marked spent.
+	utxoIncludeSpent bool
+	// utxoFilters refreshes from compact block filters instead of address queries.
+	utxoFilters bool
+	// utxoFromHeight is the block to start a filter sync from; -1 resumes.
+	utxoFromHeight int64
+	// utxoOlderThan is the age in days of the spent UTXOs to archive.
+	utxoOlderThan int
 )
 
+// defaultUTXOArchiveDays is the utxo archive --older-than default when
+// networks.bsv.utxo_archive_days is not set.
+const defaultUTXOArchiveDays = 90
+
 // utxoCmd is the parent command for UTXO operations.
 //
 //nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
@@ -45,8 +65,16 @@ Use 'utxo refresh' to re-scan from the network.`,
 var utxoListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List UTXOs for a wallet",
-	Long:  `List all unspent transaction outputs (UTXOs) for a BSV wallet address.`,
+	Long: `List the unspent transaction outputs (UTXOs) stored locally for a BSV
+wallet, largest first, with satoshi totals. These are the UTXOs sends choose
+inputs from; run 'utxo refresh' first to bring the store up to date.
+
+--min-amount accepts satoshis (50000 or 50000sat) or BSV (0.0005).
+Spendable excludes spent outputs and immature coinbase outputs.
+--include-spent also reads the archives written by 'utxo archive'.`,
 	Example: `  sigil utxo list --wallet main
+  sigil utxo list --wallet main --address 1ABC... --confirmed-only
+  sigil utxo list --wallet main --min-amount 10000 --include-spent
   sigil utxo list --wallet main -o json`,
 	RunE: runUTXOList,
 }
@@ -60,13 +88,42 @@ var utxoRefreshCmd = &cobra.Command{
 	Long: `Re-scan all known addresses and update stored UTXOs.
 New UTXOs are added; spent UTXOs are marked as spent.
 
-Use --address to refresh specific addresses instead of all.`,
+Use --address to refresh specific addresses instead of all.
+
+With --filters (experimental), the wallet's addresses are never sent to an
+API. Instead, the BIP158 compact block filter of every new block is fetched
+from the node in networks.bsv.node and matched against the wallet's scripts
+locally; only matching blocks are downloaded. The first sync needs
+--from-height, such as the height of the block before the wallet's first
+deposit; later syncs resume after the last block checked. Set
+networks.bsv.utxo_sync to "filters" to make this the default.`,
 	Example: `  sigil utxo refresh --wallet main
   sigil utxo refresh --wallet main --address 1ABC...
-  sigil utxo refresh --wallet main --address 1ABC... --address 1XYZ...`,
+  sigil utxo refresh --wallet main --address 1ABC... --address 1XYZ...
+  sigil utxo refresh --wallet main --filters --from-height 880000
+  sigil utxo refresh --wallet main --filters`,
 	RunE: runUTXORefresh,
 }
 
+// utxoArchiveCmd moves old spent UTXOs to archive files.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var utxoArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move old spent UTXOs to archive files",
+	Long: `Move UTXOs marked spent more than --older-than days ago out of
+utxos.json into per-year archive files (utxos-archive-YYYY.json) in the
+wallet directory. Sends and balances only read utxos.json, so archiving keeps
+them fast on wallets with a long history. Archived UTXOs are read on demand
+by 'utxo list --include-spent'.
+
+Set networks.bsv.utxo_archive_days to archive automatically after every
+'utxo refresh'; it is also the --older-than default.`,
+	Example: `  sigil utxo archive --wallet main
+  sigil utxo archive --wallet main --older-than 365`,
+	RunE: runUTXOArchive,
+}
+
 // utxoBalanceCmd shows offline balance from stored UTXOs.
 //
 //nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
@@ -86,28 +143,39 @@ func init() {
 	utxoCmd.AddCommand(utxoListCmd)
 	utxoCmd.AddCommand(utxoRefreshCmd)
 	utxoCmd.AddCommand(utxoBalanceCmd)
+	utxoCmd.AddCommand(utxoArchiveCmd)
 
 	// utxo list flags
-	utxoListCmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (required)")
+	utxoListCmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
 	utxoListCmd.Flags().StringVar(&utxoChain, "chain", "bsv", "blockchain (only bsv supported)")
-	_ = utxoListCmd.MarkFlagRequired("wallet")
+	utxoListCmd.Flags().StringArrayVar(&utxoAddresses, "address", nil, "only list UTXOs for these address(es) (repeatable)")
+	utxoListCmd.Flags().StringVar(&utxoMinAmount, "min-amount", "", "hide UTXOs worth less than this (satoshis, or BSV with a decimal point)")
+	utxoListCmd.Flags().BoolVar(&utxoConfirmedOnly, "confirmed-only", false, "hide UTXOs with fewer confirmations than the target")
+	utxoListCmd.Flags().IntVar(&utxoConfirmations, "confirmations", 0, "confirmation target (default: the confirmations.bsv setting)")
+	utxoListCmd.Flags().BoolVar(&utxoIncludeSpent, "include-spent", false, "also list UTXOs already marked spent")
 
 	// utxo refresh flags
-	utxoRefreshCmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (required)")
+	utxoRefreshCmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
 	utxoRefreshCmd.Flags().StringArrayVar(&utxoAddresses, "address", nil, "specific address(es) to refresh (optional, repeatable)")
-	_ = utxoRefreshCmd.MarkFlagRequired("wallet")
+	utxoRefreshCmd.Flags().BoolVar(&utxoFilters, "filters", false, "match compact block filters from the configured node instead of querying addresses (experimental)")
+	utxoRefreshCmd.Flags().Int64Var(&utxoFromHeight, "from-height", -1, "block height to start a filter sync from (default: after the last sync)")
 
 	// utxo balance flags
-	utxoBalanceCmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (required)")
-	_ = utxoBalanceCmd.MarkFlagRequired("wallet")
+	utxoBalanceCmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+
+	// utxo archive flags
+	utxoArchiveCmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+	utxoArchiveCmd.Flags().IntVar(&utxoOlderThan, "older-than", 0, "archive UTXOs spent more than this many days ago (default: networks.bsv.utxo_archive_days, or 90)")
 }
 
-//nolint:gocognit,nestif // Display logic with JSON/text format branching
 func runUTXOList(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &utxoWallet); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd) //nolint:govet // shadows package-level cmdCtx; consistent with addresses.go, balance.go
 
 	// Only BSV is supported for UTXOs
-	if utxoChain != "bsv" {
+	if id, ok := chain.ParseChainID(utxoChain); !ok || id != chain.BSV {
 		return sigilerr.WithSuggestion(
 			sigilerr.ErrInvalidInput,
 			"UTXO operations only supported for BSV chain",
@@ -135,55 +203,135 @@ func runUTXOList(cmd *cobra.Command, _ []string) error {
 		return fmt.Errorf("loading UTXO store: %w", err)
 	}
 
-	// Get all unspent UTXOs across all addresses
-	utxos := store.GetUTXOs(chain.BSV, "")
+	target, err := confirmationTarget(cmdCtx.Cfg, chain.BSV, utxoConfirmations)
+	if err != nil {
+		return err
+	}
+	minConfirmations := uint32(0)
+	if utxoConfirmedOnly {
+		minConfirmations = uint32(min(target, math.MaxUint32))
+	}
+
+	minAmount, err := parseSatAmount(utxoMinAmount)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid --min-amount %q: use satoshis (50000) or BSV (0.0005)", utxoMinAmount))
+	}
+
+	utxos, err := store.ListHistory(chain.BSV, utxostore.Filter{
+		Addresses:        utxoAddresses,
+		MinAmount:        minAmount,
+		MinConfirmations: minConfirmations,
+		IncludeSpent:     utxoIncludeSpent,
+	})
+	if err != nil {
+		return fmt.Errorf("loading UTXO archives: %w", err)
+	}
 
 	// Display results
 	w := cmd.OutOrStdout()
 	format := cmdCtx.Fmt.Format()
 
+	if format == output.FormatJSON {
+		displayUTXOsJSON(w, utxos)
+		return nil
+	}
+
 	if len(utxos) == 0 {
-		if format == output.FormatJSON {
-			if err := writeJSON(w, []any{}); err != nil {
-				return fmt.Errorf("writing JSON output: %w", err)
-			}
-		} else {
+		if store.IsEmpty() {
 			out(w, "No UTXOs stored for wallet '%s'.\n", utxoWallet)
 			out(w, "Run 'sigil utxo refresh --wallet %s' to fetch UTXOs from chain.\n", utxoWallet)
+		} else {
+			outln(w, "No stored UTXOs match the filters.")
 		}
 		return nil
 	}
 
-	if format == output.FormatJSON {
-		displayUTXOsJSON(w, utxos)
-	} else {
-		displayUTXOsText(w, utxos)
-	}
-
+	displayUTXOsText(w, utxos, target)
 	return nil
 }
 
-// displayUTXOsText shows UTXOs in text format as a table.
-func displayUTXOsText(w interface {
-	Write(p []byte) (n int, err error)
-}, utxos []*utxostore.StoredUTXO,
-) {
+// utxoTotals sums a UTXO listing in satoshis. Count and Total cover the
+// unspent outputs; Spendable leaves out immature coinbase outputs.
+type utxoTotals struct {
+	Count      int    `json:"count"`
+	Total      uint64 `json:"total"`
+	Spendable  uint64 `json:"spendable"`
+	Immature   uint64 `json:"immature,omitempty"`
+	SpentCount int    `json:"spent_count,omitempty"`
+	Spent      uint64 `json:"spent,omitempty"`
+}
+
+// sumUTXOs totals a UTXO listing.
+func sumUTXOs(utxos []*utxostore.StoredUTXO) utxoTotals {
+	var t utxoTotals
+	for _, utxo := range utxos {
+		switch {
+		case utxo.Spent:
+			t.SpentCount++
+			t.Spent += utxo.Amount
+			continue
+		case utxo.IsImmature():
+			t.Immature += utxo.Amount
+		default:
+			t.Spendable += utxo.Amount
+		}
+		t.Count++
+		t.Total += utxo.Amount
+	}
+	return t
+}
+
+// displayUTXOsText shows UTXOs in text format as a table, noting those with
+// fewer confirmations than target.
+func displayUTXOsText(w io.Writer, utxos []*utxostore.StoredUTXO, target uint64) {
 	outln(w, "TXID                                                              VOUT    AMOUNT (sats)  ADDRESS")
 	outln(w, "────────────────────────────────────────────────────────────────  ────    ─────────────  ───────────────────────────────────")
 
-	var total uint64
 	for _, utxo := range utxos {
-		out(w, "%-64s  %4d    %13d  %s\n",
-			utxo.TxID, utxo.Vout, utxo.Amount, utxo.Address)
-		total += utxo.Amount
+		note := ""
+		switch {
+		case utxo.Spent:
+			note = "  (spent"
+			if utxo.SpentTxID != "" {
+				note += " by " + utxo.SpentTxID
+			}
+			note += ")"
+		case utxo.IsImmature():
+			note = fmt.Sprintf("  (immature coinbase, %d more confirmations)", utxo.ConfirmationsToMaturity())
+		case utxo.Confirmations == 0:
+			note = "  (unconfirmed)"
+		case uint64(utxo.Confirmations) < target:
+			note = fmt.Sprintf("  (%d of %d confirmations)", utxo.Confirmations, target)
+		}
+		out(w, "%-64s  %4d    %13d  %s%s\n",
+			utxo.TxID, utxo.Vout, utxo.Amount, utxo.Address, note)
 	}
 
+	totals := sumUTXOs(utxos)
 	outln(w)
 	out(w, "Total: %d UTXOs, %d satoshis (%.8f BSV)\n",
-		len(utxos), total, float64(total)/100000000)
+		totals.Count, totals.Total, float64(totals.Total)/100000000)
+	if totals.Spendable != totals.Total {
+		out(w, "Spendable: %d satoshis (%.8f BSV)\n", totals.Spendable, float64(totals.Spendable)/100000000)
+	}
+	displayImmatureBalance(w, totals.Immature)
+	if totals.SpentCount > 0 {
+		out(w, "Spent: %d UTXOs, %d satoshis (not included above)\n", totals.SpentCount, totals.Spent)
+	}
+}
+
+// displayImmatureBalance notes the part of a balance held in coinbase outputs
+// that cannot be spent yet.
+func displayImmatureBalance(w io.Writer, immature uint64) {
+	if immature == 0 {
+		return
+	}
+	out(w, "Immature: %d satoshis (%.8f BSV) in coinbase outputs, spendable after %d confirmations\n",
+		immature, float64(immature)/100000000, utxostore.CoinbaseMaturity)
 }
 
-// displayUTXOsJSON shows UTXOs in JSON format.
+// displayUTXOsJSON shows UTXOs and their satoshi totals in JSON format.
 func displayUTXOsJSON(w io.Writer, utxos []*utxostore.StoredUTXO) {
 	type utxoJSON struct {
 		TxID          string `json:"txid"`
@@ -191,6 +339,11 @@ func displayUTXOsJSON(w io.Writer, utxos []*utxostore.StoredUTXO) {
 		Amount        uint64 `json:"amount"`
 		Address       string `json:"address"`
 		Confirmations uint32 `json:"confirmations"`
+		Coinbase      bool   `json:"coinbase,omitempty"`
+		Immature      bool   `json:"immature,omitempty"`
+		ToMaturity    uint32 `json:"confirmations_to_maturity,omitempty"`
+		Spent         bool   `json:"spent,omitempty"`
+		SpentTxID     string `json:"spent_txid,omitempty"`
 	}
 
 	outUTXOs := make([]utxoJSON, 0, len(utxos))
@@ -201,14 +354,25 @@ func displayUTXOsJSON(w io.Writer, utxos []*utxostore.StoredUTXO) {
 			Amount:        utxo.Amount,
 			Address:       utxo.Address,
 			Confirmations: utxo.Confirmations,
+			Coinbase:      utxo.Coinbase,
+			Immature:      utxo.IsImmature(),
+			ToMaturity:    utxo.ConfirmationsToMaturity(),
+			Spent:         utxo.Spent,
+			SpentTxID:     utxo.SpentTxID,
 		})
 	}
 
-	_ = writeJSON(w, outUTXOs)
+	_ = writeJSON(w, struct {
+		UTXOs []utxoJSON `json:"utxos"`
+		utxoTotals
+	}{outUTXOs, sumUTXOs(utxos)})
 }
 
 // runUTXORefresh re-scans addresses and updates stored UTXOs.
 func runUTXORefresh(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &utxoWallet); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd) //nolint:govet // shadows package-level cmdCtx; consistent with addresses.go, balance.go
 	ctx, cancel := contextWithTimeout(cmd, 60*time.Second)
 	defer cancel()
@@ -228,6 +392,12 @@ func runUTXORefresh(cmd *cobra.Command, _ []string) error {
 		)
 	}
 
+	lock, err := lockWallet(cmd, storage, utxoWallet, "utxo refresh")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
 	// Create UTXO store
 	store := utxostore.New(walletPath)
 	if loadErr := store.Load(); loadErr != nil {
@@ -236,7 +406,15 @@ func runUTXORefresh(cmd *cobra.Command, _ []string) error {
 
 	// Resolve the wallet's network from its metadata (falls back to config).
 	meta, _ := storage.LoadMetadata(utxoWallet)
-	warnNetworkConflict(cmd, meta)
+	applyWalletNetwork(cmd, meta)
+
+	if useFilterSync(cmd, cmdCtx.Cfg) {
+		if len(utxoAddresses) > 0 {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+				"--address cannot be used with a filter sync, which checks every wallet address")
+		}
+		return runUTXORefreshFilters(cmd, store, meta)
+	}
 
 	// Create BSV client
 	client := bsv.NewClient(ctx, &bsv.ClientOptions{
@@ -267,11 +445,105 @@ func runUTXORefresh(cmd *cobra.Command, _ []string) error {
 
 	result, err := store.Refresh(ctx, chain.BSV, adapter)
 	if err != nil {
+		if isCanceled(err) && result != nil {
+			displayRefreshResults(w, result)
+			out(w, "Refresh canceled after %d of %d address(es); their UTXOs were saved.\n", result.AddressesScanned, len(addresses))
+		}
 		return fmt.Errorf("refreshing UTXOs: %w", err)
 	}
 
 	// Display results
 	displayRefreshResults(w, result)
+
+	days := cmdCtx.Cfg.GetBSVUTXOArchiveDays()
+	if days == 0 {
+		return nil
+	}
+	archived, err := archiveSpentUTXOs(store, days)
+	if err != nil {
+		return err
+	}
+	if archived.Archived > 0 {
+		out(w, "Archived %d UTXO(s) spent more than %d days ago.\n", archived.Archived, days)
+	}
+	return nil
+}
+
+// archiveSpentUTXOs moves the UTXOs spent more than days ago out of store.
+func archiveSpentUTXOs(store *utxostore.Store, days int) (*utxostore.ArchiveResult, error) {
+	result, err := store.Archive(time.Now().AddDate(0, 0, -days))
+	if err != nil {
+		return nil, fmt.Errorf("archiving spent UTXOs: %w", err)
+	}
+	return result, nil
+}
+
+// runUTXOArchive moves old spent UTXOs to per-year archive files.
+func runUTXOArchive(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &utxoWallet); err != nil {
+		return err
+	}
+	cmdCtx := GetCmdContext(cmd) //nolint:govet // shadows package-level cmdCtx; consistent with addresses.go, balance.go
+
+	days := utxoOlderThan
+	if !cmd.Flags().Changed("older-than") {
+		days = cmdCtx.Cfg.GetBSVUTXOArchiveDays()
+		if days == 0 {
+			days = defaultUTXOArchiveDays
+		}
+	}
+	if days < 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--older-than must be 0 or more days")
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	walletPath := filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", utxoWallet)
+
+	exists, err := storage.Exists(utxoWallet)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sigilerr.WithSuggestion(
+			wallet.ErrWalletNotFound,
+			fmt.Sprintf("wallet '%s' not found. List wallets with: sigil wallet list", utxoWallet),
+		)
+	}
+
+	lock, err := lockWallet(cmd, storage, utxoWallet, "utxo archive")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	store := utxostore.New(walletPath)
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("loading UTXO store: %w", err)
+	}
+
+	result, err := archiveSpentUTXOs(store, days)
+	if err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if cmdCtx.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, struct {
+			Wallet    string `json:"wallet"`
+			OlderThan int    `json:"older_than_days"`
+			*utxostore.ArchiveResult
+		}{utxoWallet, days, result})
+	}
+
+	if result.Archived == 0 {
+		out(w, "No UTXOs spent more than %d days ago in wallet '%s'.\n", days, utxoWallet)
+		return nil
+	}
+	years := make([]string, len(result.Years))
+	for i, year := range result.Years {
+		years[i] = utxostore.ArchiveFileName(year)
+	}
+	out(w, "Archived %d UTXO(s) spent more than %d days ago to %s.\n", result.Archived, days, strings.Join(years, ", "))
 	return nil
 }
 
@@ -285,12 +557,14 @@ func refreshSpecificAddresses(ctx context.Context, cmd *cobra.Command, store *ut
 	totalResult := &utxostore.ScanResult{}
 
 	for _, addr := range addresses {
-		if ctx.Err() != nil {
-			return ctx.Err()
-		}
-
 		out(w, "  Scanning %s...\n", addr)
 		result, err := store.RefreshAddress(ctx, addr, chain.BSV, adapter)
+		if isCanceled(err) {
+			// Addresses refreshed before the cancellation are already saved
+			displayRefreshResults(w, totalResult)
+			out(w, "Refresh canceled after %d of %d address(es).\n", totalResult.AddressesScanned, len(addresses))
+			return err
+		}
 		if err != nil {
 			return fmt.Errorf("refreshing address %s: %w", addr, err)
 		}
@@ -333,6 +607,11 @@ func (a *bsvRefreshAdapter) ListUTXOs(ctx context.Context, address string) ([]ch
 	return result, nil
 }
 
+// CoinbaseStatus implements utxostore.CoinbaseClient.
+func (a *bsvRefreshAdapter) CoinbaseStatus(ctx context.Context, txid string) (bool, uint32, error) {
+	return a.client.CoinbaseStatus(ctx, txid)
+}
+
 // displayRefreshResults shows the results of a UTXO refresh.
 func displayRefreshResults(w interface {
 	Write(p []byte) (n int, err error)
@@ -357,6 +636,9 @@ func displayRefreshResults(w interface {
 //
 //nolint:gocognit,nestif // CLI display logic with JSON/text formats
 func runUTXOBalance(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &utxoWallet); err != nil {
+		return err
+	}
 	cmdCtx := GetCmdContext(cmd) //nolint:govet // shadows package-level cmdCtx; consistent with addresses.go, balance.go
 
 	// Load wallet path
@@ -406,17 +688,22 @@ func runUTXOBalance(cmd *cobra.Command, _ []string) error {
 
 	// Get balance from stored UTXOs
 	balance := store.GetBalance(chain.BSV)
+	immature := store.GetImmatureBalance(chain.BSV, "")
 	utxos := store.GetUTXOs(chain.BSV, "")
 
 	if format == output.FormatJSON {
 		payload := struct {
-			Balance uint64  `json:"balance"`
-			UTXOs   int     `json:"utxos"`
-			BSV     float64 `json:"bsv"`
+			Balance   uint64  `json:"balance"`
+			Spendable uint64  `json:"spendable"`
+			Immature  uint64  `json:"immature,omitempty"`
+			UTXOs     int     `json:"utxos"`
+			BSV       float64 `json:"bsv"`
 		}{
-			Balance: balance,
-			UTXOs:   len(utxos),
-			BSV:     float64(balance) / 100000000,
+			Balance:   balance,
+			Spendable: balance - immature,
+			Immature:  immature,
+			UTXOs:     len(utxos),
+			BSV:       float64(balance) / 100000000,
 		}
 		if err := writeJSON(w, payload); err != nil {
 			return fmt.Errorf("writing JSON output: %w", err)
@@ -426,6 +713,7 @@ func runUTXOBalance(cmd *cobra.Command, _ []string) error {
 		outln(w)
 		out(w, "UTXOs:   %d\n", len(utxos))
 		out(w, "Balance: %d satoshis (%.8f BSV)\n", balance, float64(balance)/100000000)
+		displayImmatureBalance(w, immature)
 		outln(w)
 		out(w, "Note: This is the locally stored balance. Run 'sigil utxo refresh' to update.\n")
 	}
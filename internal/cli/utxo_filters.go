@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// filterSyncTimeout bounds a filter sync. A sync stopped by the timeout
+// resumes from its last saved block on the next run.
+const filterSyncTimeout = 30 * time.Minute
+
+// utxoSyncFilters is the networks.bsv.utxo_sync value that makes utxo
+// refresh use compact block filters.
+const utxoSyncFilters = "filters"
+
+// useFilterSync reports whether utxo refresh should match compact block
+// filters: --filters was given, or utxo_sync is "filters" and --filters was
+// not turned off.
+func useFilterSync(cmd *cobra.Command, cfg ConfigProvider) bool {
+	if cmd.Flags().Changed("filters") {
+		return utxoFilters
+	}
+	return cfg.GetBSVUTXOSync() == utxoSyncFilters
+}
+
+// runUTXORefreshFilters updates the wallet's UTXOs from the compact block
+// filters of the configured node.
+func runUTXORefreshFilters(cmd *cobra.Command, store *utxostore.Store, meta *wallet.Wallet) error {
+	cc := GetCmdContext(cmd)
+	ctx, cancel := contextWithTimeout(cmd, filterSyncTimeout)
+	defer cancel()
+
+	if meta == nil {
+		return fmt.Errorf("loading wallet: %w", wallet.ErrWalletNotFound)
+	}
+	node := cc.Cfg.GetBSVNode()
+	client, err := bsv.NewNodeClient(bsv.NodeOptions{URL: node.RPC, User: node.User, Password: node.Password})
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("%v; set networks.bsv.node.rpc to a node that keeps a block filter index", err))
+	}
+
+	w := cmd.OutOrStdout()
+	format := cc.Fmt.Format()
+	if format != output.FormatJSON {
+		out(w, "Syncing UTXOs for wallet '%s' from compact block filters...\n", utxoWallet)
+	}
+
+	result, err := store.SyncFilters(ctx, meta, chain.BSV, client, utxoFromHeight)
+	switch {
+	case errors.Is(err, utxostore.ErrNoFilterStart):
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			"the first filter sync needs a start block; rerun with --from-height set to a height before the wallet's first deposit")
+	case errors.Is(err, utxostore.ErrFilterReorg):
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("%v; rerun with --from-height a few blocks lower to sync past the reorganization", err))
+	case err != nil:
+		if isCanceled(err) && result != nil && format != output.FormatJSON {
+			displayFilterSyncResult(cmd, result, store)
+			out(w, "Sync stopped at block %d; run the command again to continue.\n", result.ToHeight)
+		}
+		return fmt.Errorf("syncing UTXOs from filters: %w", err)
+	}
+
+	displayFilterSyncResult(cmd, result, store)
+	return nil
+}
+
+// displayFilterSyncResult shows a filter sync summary.
+func displayFilterSyncResult(cmd *cobra.Command, result *utxostore.FilterSyncResult, store *utxostore.Store) {
+	w := cmd.OutOrStdout()
+	balance := store.GetBalance(chain.BSV)
+
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		_ = writeJSON(w, struct {
+			FromHeight     int64  `json:"from_height"`
+			ToHeight       int64  `json:"to_height"`
+			BlocksChecked  int    `json:"blocks_checked"`
+			BlocksFetched  int    `json:"blocks_fetched"`
+			FalsePositives int    `json:"false_positives"`
+			UTXOsFound     int    `json:"utxos_found"`
+			UTXOsSpent     int    `json:"utxos_spent"`
+			Balance        uint64 `json:"balance"`
+		}{
+			FromHeight:     result.FromHeight,
+			ToHeight:       result.ToHeight,
+			BlocksChecked:  result.BlocksChecked,
+			BlocksFetched:  result.BlocksFetched,
+			FalsePositives: result.FalsePositives,
+			UTXOsFound:     result.UTXOsFound,
+			UTXOsSpent:     result.UTXOsSpent,
+			Balance:        balance,
+		})
+		return
+	}
+
+	outln(w)
+	if result.BlocksChecked == 0 {
+		out(w, "Already synced to block %d.\n", result.FromHeight-1)
+	} else {
+		out(w, "Blocks checked:    %d (%d to %d)\n", result.BlocksChecked, result.FromHeight, result.ToHeight)
+		out(w, "Blocks fetched:    %d (%d false positive)\n", result.BlocksFetched, result.FalsePositives)
+	}
+	out(w, "UTXOs found:       %d\n", result.UTXOsFound)
+	out(w, "UTXOs spent:       %d\n", result.UTXOsSpent)
+	out(w, "Total balance:     %d satoshis (%.8f BSV)\n", balance, float64(balance)/100000000)
+	outln(w, "Unconfirmed transactions are not in block filters and are not shown.")
+}
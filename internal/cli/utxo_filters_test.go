@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestUseFilterSync(t *testing.T) {
+	t.Parallel()
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("filters", false, "")
+		return cmd
+	}
+
+	assert.False(t, useFilterSync(newCmd(), &mockConfigProvider{}))
+	assert.True(t, useFilterSync(newCmd(), &mockConfigProvider{bsvUTXOSync: "filters"}))
+
+	cmd := newCmd()
+	require.NoError(t, cmd.Flags().Set("filters", "false"))
+	assert.False(t, useFilterSync(cmd, &mockConfigProvider{bsvUTXOSync: "filters"}), "--filters=false overrides the config")
+}
+
+//nolint:paralleltest // Mutates package-level utxo flags
+func TestRunUTXORefresh_FiltersErrors(t *testing.T) {
+	t.Cleanup(func() {
+		utxoWallet, utxoAddresses, utxoFilters, utxoFromHeight = "", nil, false, -1
+	})
+
+	home := t.TempDir()
+	createTestWallet(t, filepath.Join(home, "wallets"), "private")
+
+	newCmd := func(node config.BSVNodeConfig) *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("filters", false, "")
+		require.NoError(t, cmd.Flags().Set("filters", "true"))
+		cmd.SetContext(context.Background())
+		cmd.SetOut(&bytes.Buffer{})
+		SetCmdContext(cmd, &CommandContext{
+			Cfg: &mockConfigProvider{home: home, bsvNode: node},
+			Fmt: &mockFormatProvider{format: output.FormatText},
+		})
+		return cmd
+	}
+
+	utxoWallet, utxoFilters, utxoFromHeight = "private", true, -1
+
+	// No node configured
+	err := runUTXORefresh(newCmd(config.BSVNodeConfig{}), nil)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "networks.bsv.node.rpc")
+
+	// --address makes no sense for a sync of every address
+	utxoAddresses = []string{"1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"}
+	err = runUTXORefresh(newCmd(config.BSVNodeConfig{RPC: "http://127.0.0.1:1"}), nil)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// utxoLockReason is the reason recorded in the policy log.
+	utxoLockReason string
+)
+
+// utxoLockAddressCmd marks an address receive-only.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var utxoLockAddressCmd = &cobra.Command{
+	Use:   "lock-address <address>",
+	Short: "Mark an address receive-only so sends never spend its UTXOs",
+	Long: `Mark a BSV, BTC, or BCH address of the wallet receive-only. The address
+still receives funds, but coin selection, sweeps, and --utxo coin control
+never spend its UTXOs, so a balance held there, such as provably-held
+reserves, stays put until 'utxo unlock-address' lifts the restriction.
+
+Every lock and unlock is appended to the wallet's policy log with its time
+and --reason; 'utxo locks' shows it.`,
+	Example: `  sigil utxo lock-address 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --wallet main --reason "proof of reserves"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runUTXOLockAddress,
+}
+
+// utxoUnlockAddressCmd lifts the receive-only restriction of an address.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var utxoUnlockAddressCmd = &cobra.Command{
+	Use:   "unlock-address <address>",
+	Short: "Let sends spend the UTXOs of a receive-only address again",
+	Long: `Lift the receive-only restriction set by 'utxo lock-address'. Later sends
+may spend the address's UTXOs again. The change is appended to the policy
+log. Agents cannot unlock an address.`,
+	Example: `  sigil utxo unlock-address 1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa --wallet main --reason "reserves moved"`,
+	Args:    cobra.ExactArgs(1),
+	RunE:    runUTXOUnlockAddress,
+}
+
+// utxoLocksCmd lists receive-only addresses and the policy log.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var utxoLocksCmd = &cobra.Command{
+	Use:   "locks",
+	Short: "List receive-only addresses and the policy log",
+	Long: `List the wallet's receive-only addresses with the balance of their stored
+UTXOs, followed by every lock and unlock recorded in the policy log.`,
+	Example: `  sigil utxo locks --wallet main
+  sigil utxo locks --wallet main -o json`,
+	RunE: runUTXOLocks,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	utxoCmd.AddCommand(utxoLockAddressCmd)
+	utxoCmd.AddCommand(utxoUnlockAddressCmd)
+	utxoCmd.AddCommand(utxoLocksCmd)
+
+	for _, cmd := range []*cobra.Command{utxoLockAddressCmd, utxoUnlockAddressCmd} {
+		cmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+		cmd.Flags().StringVar(&utxoLockReason, "reason", "", "reason recorded in the policy log")
+	}
+	utxoLocksCmd.Flags().StringVar(&utxoWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+}
+
+// receiveOnlyChains are the chains whose addresses can be locked: those that
+// spend UTXOs.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var receiveOnlyChains = []chain.ID{chain.BSV, chain.BTC, chain.BCH}
+
+// addressLockResult is the output of the lock-address and unlock-address
+// commands.
+type addressLockResult struct {
+	Address     string `json:"address"`
+	Chain       string `json:"chain"`
+	ReceiveOnly bool   `json:"receive_only"`
+	Changed     bool   `json:"changed"`
+}
+
+func runUTXOLockAddress(cmd *cobra.Command, args []string) error {
+	return setAddressReceiveOnly(cmd, args[0], true)
+}
+
+func runUTXOUnlockAddress(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+	if cc.AgentXpub != "" || cc.AgentCred != nil {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrAgentPolicyViolation,
+			"unlocking a receive-only address needs the wallet owner; it cannot be done in agent mode",
+		)
+	}
+	return setAddressReceiveOnly(cmd, args[0], false)
+}
+
+// setAddressReceiveOnly locks or unlocks an address of the wallet in its UTXO
+// store, registering the address first if the store does not know it.
+func setAddressReceiveOnly(cmd *cobra.Command, addr string, receiveOnly bool) error {
+	if err := resolveWalletName(cmd, &utxoWallet); err != nil {
+		return err
+	}
+	cmdCtx := GetCmdContext(cmd)
+
+	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(utxoWallet)
+	if err != nil {
+		return err
+	}
+
+	lock, err := lockWallet(cmd, storage, utxoWallet, "utxo lock-address")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
+	store := utxostore.New(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", utxoWallet))
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("loading UTXO store: %w", err)
+	}
+
+	chainID, found := registerOwnedAddress(store, wlt, addr)
+	if !found {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("address %s is not a BSV, BTC, or BCH address of wallet '%s'", addr, utxoWallet),
+		)
+	}
+
+	changed, err := store.SetReceiveOnly(chainID, addr, receiveOnly, utxoLockReason, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("updating address policy: %w", err)
+	}
+	if changed {
+		if err := store.Save(); err != nil {
+			return fmt.Errorf("saving UTXO store: %w", err)
+		}
+	}
+
+	res := addressLockResult{Address: addr, Chain: string(chainID), ReceiveOnly: receiveOnly, Changed: changed}
+	w := cmd.OutOrStdout()
+	if cmdCtx.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, res)
+	}
+	switch {
+	case !changed && receiveOnly:
+		out(w, "Address %s is already receive-only\n", addr)
+	case !changed:
+		out(w, "Address %s is not receive-only\n", addr)
+	case receiveOnly:
+		out(w, "Address %s is now receive-only: sends will not spend its UTXOs\n", addr)
+	default:
+		out(w, "Address %s is spendable again\n", addr)
+	}
+	return nil
+}
+
+// registerOwnedAddress finds addr among the wallet's UTXO chain addresses
+// and adds it to the UTXO store if missing. It returns the address's chain.
+func registerOwnedAddress(store *utxostore.Store, wlt *wallet.Wallet, addr string) (chain.ID, bool) {
+	for _, chainID := range receiveOnlyChains {
+		owned := wlt.OwnedAddress(chainID, addr)
+		if owned == nil {
+			continue
+		}
+		if store.GetAddress(chainID, addr) == nil {
+			store.AddAddress(&utxostore.AddressMetadata{
+				Address:        owned.Address,
+				ChainID:        chainID,
+				DerivationPath: owned.Path,
+				Index:          owned.Index,
+				IsChange:       owned.IsChange,
+			})
+		}
+		return chainID, true
+	}
+	return "", false
+}
+
+func runUTXOLocks(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &utxoWallet); err != nil {
+		return err
+	}
+	cmdCtx := GetCmdContext(cmd)
+
+	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	exists, err := storage.Exists(utxoWallet)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sigilerr.WithSuggestion(
+			wallet.ErrWalletNotFound,
+			fmt.Sprintf("wallet '%s' not found. List wallets with: sigil wallet list", utxoWallet),
+		)
+	}
+
+	store := utxostore.New(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets", utxoWallet))
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("loading UTXO store: %w", err)
+	}
+
+	type lockedJSON struct {
+		Chain   string `json:"chain"`
+		Address string `json:"address"`
+		Balance uint64 `json:"balance"`
+	}
+	locked := make([]lockedJSON, 0)
+	for _, meta := range store.ReceiveOnlyAddresses() {
+		locked = append(locked, lockedJSON{
+			Chain:   string(meta.ChainID),
+			Address: meta.Address,
+			Balance: store.GetAddressBalance(meta.ChainID, meta.Address),
+		})
+	}
+	log := store.PolicyLog()
+	if log == nil {
+		log = []utxostore.PolicyEvent{}
+	}
+
+	w := cmd.OutOrStdout()
+	if cmdCtx.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, struct {
+			Wallet      string                  `json:"wallet"`
+			ReceiveOnly []lockedJSON            `json:"receive_only"`
+			PolicyLog   []utxostore.PolicyEvent `json:"policy_log"`
+		}{utxoWallet, locked, log})
+	}
+
+	if len(locked) == 0 {
+		out(w, "Wallet '%s' has no receive-only addresses.\n", utxoWallet)
+	} else {
+		out(w, "Receive-only addresses of wallet '%s':\n\n", utxoWallet)
+		for _, l := range locked {
+			out(w, "  %-4s %-42s %13d sats\n", l.Chain, l.Address, l.Balance)
+		}
+	}
+	if len(log) == 0 {
+		return nil
+	}
+	outln(w)
+	outln(w, "Policy log:")
+	for _, e := range log {
+		reason := ""
+		if e.Reason != "" {
+			reason = " (" + e.Reason + ")"
+		}
+		out(w, "  %s  %-6s %-4s %s%s\n", e.Time.Local().Format("2006-01-02 15:04"), e.Action, e.ChainID, e.Address, reason)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestRunUTXOLockAddress(t *testing.T) {
+	tmpDir, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	t.Cleanup(func() { utxoWallet, utxoLockReason = "", "" })
+
+	createTestWalletForAgent(t, tmpDir)
+	wlt, err := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets")).LoadMetadata("test-wallet")
+	require.NoError(t, err)
+	reserve := wlt.Addresses[chain.BSV][0].Address
+
+	// Locking registers the address, which was never refreshed
+	utxoWallet, utxoLockReason = "test-wallet", "proof of reserves"
+	cmd, buf := newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runUTXOLockAddress(cmd, []string{reserve}))
+	assert.Contains(t, buf.String(), "is now receive-only")
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runUTXOLockAddress(cmd, []string{reserve}))
+	assert.Contains(t, buf.String(), "already receive-only")
+
+	store := utxostore.New(filepath.Join(tmpDir, "wallets", "test-wallet"))
+	require.NoError(t, store.Load())
+	assert.True(t, store.IsReceiveOnly(chain.BSV, reserve))
+	assert.Equal(t, wlt.Addresses[chain.BSV][0].Path, store.GetAddress(chain.BSV, reserve).DerivationPath)
+
+	// Agents cannot unlock
+	cmd, _ = newBackupListTestCmd(tmpDir, output.FormatText)
+	GetCmdContext(cmd).AgentXpub = "xpub-test"
+	require.ErrorIs(t, runUTXOUnlockAddress(cmd, []string{reserve}), sigilerr.ErrAgentPolicyViolation)
+
+	utxoLockReason = ""
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatJSON)
+	require.NoError(t, runUTXOUnlockAddress(cmd, []string{reserve}))
+	var res addressLockResult
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &res))
+	assert.Equal(t, addressLockResult{Address: reserve, Chain: "bsv", ReceiveOnly: false, Changed: true}, res)
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatJSON)
+	require.NoError(t, runUTXOLocks(cmd, nil))
+	var locks struct {
+		ReceiveOnly []any                   `json:"receive_only"`
+		PolicyLog   []utxostore.PolicyEvent `json:"policy_log"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &locks))
+	assert.Empty(t, locks.ReceiveOnly)
+	require.Len(t, locks.PolicyLog, 2)
+	assert.Equal(t, utxostore.PolicyLock, locks.PolicyLog[0].Action)
+	assert.Equal(t, "proof of reserves", locks.PolicyLog[0].Reason)
+	assert.Equal(t, utxostore.PolicyUnlock, locks.PolicyLog[1].Action)
+
+	// ETH addresses and strangers cannot be locked
+	cmd, _ = newBackupListTestCmd(tmpDir, output.FormatText)
+	err = runUTXOLockAddress(cmd, []string{wlt.Addresses[chain.ETH][0].Address})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
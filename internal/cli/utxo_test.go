@@ -5,9 +5,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
@@ -31,6 +33,7 @@ func TestDisplayUTXOsText(t *testing.T) {
 	tests := []struct {
 		name     string
 		utxos    []*utxostore.StoredUTXO
+		target   uint64
 		contains []string
 	}{
 		{
@@ -99,6 +102,37 @@ func TestDisplayUTXOsText(t *testing.T) {
 				"1.00000000 BSV",
 			},
 		},
+		{
+			name: "immature coinbase",
+			utxos: []*utxostore.StoredUTXO{
+				{
+					TxID:          "coinbase11111111111111111111111111111111111111111111111111111111111",
+					Vout:          0,
+					Amount:        625000000,
+					Confirmations: 40,
+					Address:       "1MinerAddress",
+					Coinbase:      true,
+				},
+			},
+			contains: []string{
+				"(immature coinbase, 60 more confirmations)",
+				"Immature: 625000000 satoshis (6.25000000 BSV) in coinbase outputs, spendable after 100 confirmations",
+			},
+		},
+		{
+			name: "below confirmation target",
+			utxos: []*utxostore.StoredUTXO{
+				{
+					TxID:          "shallow1111111111111111111111111111111111111111111111111111111111111",
+					Vout:          0,
+					Amount:        50000,
+					Confirmations: 2,
+					Address:       "1ShallowAddress",
+				},
+			},
+			target:   6,
+			contains: []string{"(2 of 6 confirmations)"},
+		},
 	}
 
 	for _, tc := range tests {
@@ -106,7 +140,7 @@ func TestDisplayUTXOsText(t *testing.T) {
 			t.Parallel()
 
 			var buf bytes.Buffer
-			displayUTXOsText(&buf, tc.utxos)
+			displayUTXOsText(&buf, tc.utxos, tc.target)
 
 			result := buf.String()
 			for _, s := range tc.contains {
@@ -183,9 +217,13 @@ func TestDisplayUTXOsJSON(t *testing.T) {
 				assert.Contains(t, result, s)
 			}
 
-			var parsed []map[string]any
+			var parsed struct {
+				UTXOs []map[string]any `json:"utxos"`
+				Count int              `json:"count"`
+			}
 			require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
-			require.Len(t, parsed, len(tc.utxos))
+			require.Len(t, parsed.UTXOs, len(tc.utxos))
+			assert.Equal(t, len(tc.utxos), parsed.Count)
 		})
 	}
 }
@@ -203,11 +241,43 @@ func TestDisplayUTXOsJSON_ArrayFormat(t *testing.T) {
 	var buf bytes.Buffer
 	displayUTXOsJSON(&buf, utxos)
 
-	var parsed []map[string]any
+	var parsed struct {
+		UTXOs []map[string]any `json:"utxos"`
+	}
 	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
-	require.Len(t, parsed, 3)
-	assert.Equal(t, "tx1", parsed[0]["txid"])
-	assert.Equal(t, "tx3", parsed[2]["txid"])
+	require.Len(t, parsed.UTXOs, 3)
+	assert.Equal(t, "tx1", parsed.UTXOs[0]["txid"])
+	assert.Equal(t, "tx3", parsed.UTXOs[2]["txid"])
+}
+
+func TestDisplayUTXOs_Totals(t *testing.T) {
+	t.Parallel()
+
+	utxos := []*utxostore.StoredUTXO{
+		{TxID: "tx1", Amount: 70000, Confirmations: 6, Address: "1A"},
+		{TxID: "tx2", Amount: 20000, Confirmations: 0, Address: "1A"},
+		{TxID: "tx3", Amount: 5000, Confirmations: 10, Address: "1B", Coinbase: true},
+		{TxID: "tx4", Amount: 9000, Confirmations: 3, Address: "1B", Spent: true, SpentTxID: "tx9"},
+	}
+
+	var text bytes.Buffer
+	displayUTXOsText(&text, utxos, 1)
+	assert.Contains(t, text.String(), "(unconfirmed)")
+	assert.Contains(t, text.String(), "(spent by tx9)")
+	assert.Contains(t, text.String(), "Total: 3 UTXOs, 95000 satoshis")
+	assert.Contains(t, text.String(), "Spendable: 90000 satoshis")
+	assert.Contains(t, text.String(), "Spent: 1 UTXOs, 9000 satoshis")
+
+	var buf bytes.Buffer
+	displayUTXOsJSON(&buf, utxos)
+	var parsed struct {
+		UTXOs []map[string]any `json:"utxos"`
+		utxoTotals
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	assert.Len(t, parsed.UTXOs, 4)
+	assert.Equal(t, utxoTotals{Count: 3, Total: 95000, Spendable: 90000, Immature: 5000, SpentCount: 1, Spent: 9000}, parsed.utxoTotals)
+	assert.Equal(t, "tx9", parsed.UTXOs[3]["spent_txid"])
 }
 
 func TestDisplayRefreshResults(t *testing.T) {
@@ -396,3 +466,45 @@ func TestRunUTXOBalance_EmptyStore_JSON(t *testing.T) {
 	assert.InDelta(t, float64(0), parsed["balance"], 0)
 	assert.InDelta(t, float64(0), parsed["utxos"], 0)
 }
+
+//nolint:paralleltest // Mutates package-level flags
+func TestRunUTXOArchive(t *testing.T) {
+	tmpDir, testCleanup := setupTestEnv(t)
+	defer testCleanup()
+	t.Cleanup(func() { utxoWallet, utxoIncludeSpent = "", false })
+
+	walletsDir := filepath.Join(tmpDir, "wallets")
+	createTestWallet(t, walletsDir, "archive")
+
+	store := utxostore.New(filepath.Join(walletsDir, "archive"))
+	for i, amount := range []uint64{1000, 2000} {
+		store.AddUTXO(&utxostore.StoredUTXO{ChainID: "bsv", TxID: fmt.Sprintf("%064x", i+1), Amount: amount, Address: "1Test"})
+	}
+	require.True(t, store.MarkSpent("bsv", fmt.Sprintf("%064x", 1), 0, fmt.Sprintf("%064x", 9)))
+	old := store.List("bsv", utxostore.Filter{IncludeSpent: true})[1]
+	old.LastUpdated = time.Now().AddDate(0, 0, -120)
+	require.NoError(t, store.Save())
+
+	utxoWallet = "archive"
+	cmd, buf := newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runUTXOArchive(cmd, nil))
+	assert.Contains(t, buf.String(), "Archived 1 UTXO(s) spent more than 90 days ago to "+utxostore.ArchiveFileName(old.LastUpdated.UTC().Year()))
+
+	// Listing spent UTXOs reads the archive
+	utxoIncludeSpent = true
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatJSON)
+	require.NoError(t, runUTXOList(cmd, nil))
+	var parsed struct {
+		UTXOs []struct {
+			Spent bool `json:"spent"`
+		} `json:"utxos"`
+		SpentCount int `json:"spent_count"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	assert.Len(t, parsed.UTXOs, 2)
+	assert.Equal(t, 1, parsed.SpentCount)
+
+	cmd, buf = newBackupListTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runUTXOArchive(cmd, nil))
+	assert.Contains(t, buf.String(), "No UTXOs spent more than 90 days ago")
+}
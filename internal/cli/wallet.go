@@ -27,12 +27,22 @@ var (
 	createWords int
 	// createPassphrase indicates whether to prompt for BIP39 passphrase.
 	createPassphrase bool
+	// createPassphrasePrompt asks for the BIP39 passphrase on every unlock instead of storing its seed.
+	createPassphrasePrompt bool
 	// createScan indicates whether to scan for existing UTXOs after creation.
 	createScan bool
+	// createTemplate is the wallet template to create the wallet from.
+	createTemplate string
+	// createExtraEntropy prompts for user entropy to mix into the new seed.
+	createExtraEntropy bool
+	// createWordlist is the BIP39 wordlist for the new mnemonic.
+	createWordlist string
 	// restoreInput is the seed material for wallet restoration.
 	restoreInput string
 	// restorePassphrase indicates whether to prompt for BIP39 passphrase during restore.
 	restorePassphrase bool
+	// restorePassphrasePrompt asks for the BIP39 passphrase on every unlock instead of storing its seed.
+	restorePassphrasePrompt bool
 	// restoreScan indicates whether to scan for existing UTXOs after restore.
 	restoreScan bool
 	// createShamir indicates whether to use Shamir Secret Sharing.
@@ -43,6 +53,10 @@ var (
 	createShareCount int
 	// restoreShamir indicates whether to restore from Shamir shares.
 	restoreShamir bool
+	// createWeakPasswordOK accepts a wallet password that fails the strength check.
+	createWeakPasswordOK bool
+	// restoreWeakPasswordOK accepts a wallet password that fails the strength check.
+	restoreWeakPasswordOK bool
 )
 
 // walletCmd is the parent command for wallet operations.
@@ -66,10 +80,33 @@ var walletCreateCmd = &cobra.Command{
 	Long: `Create a new HD wallet with a BIP39 mnemonic phrase.
 
 The mnemonic will be displayed once - write it down and store it securely.
-You will be prompted for a password to encrypt the wallet file.`,
+You will be prompted for a password to encrypt the wallet file.
+
+Before the mnemonic is generated, the system random number generator is
+health checked; creation stops if it is unresponsive or its output looks
+broken. Use --extra-entropy to also mix in dice rolls or random typing.
+
+Use --wordlist to write the mnemonic in another BIP39 language: japanese,
+korean, spanish, chinese-simplified, chinese-traditional, french, italian,
+or czech. Restore detects the wordlist automatically.
+
+Use --passphrase to add a BIP39 passphrase (the "25th word"); the wallet
+file then stores the seed derived with it. Use --passphrase-prompt instead
+to keep a hidden wallet: the passphrase is never stored and is asked for
+after the password on every unlock, and the addresses it derives are
+checked against the wallet's before anything is signed.
+
+Use --template to create the wallet from a template that sets its chains,
+number of addresses, address labels, mnemonic length, fee defaults, and
+confirmation threshold. Flags given explicitly win over the template. Run
+"sigil wallet templates" to list the templates.`,
 	Example: `  sigil wallet create main
   sigil wallet create main --words 24
-  sigil wallet create main --passphrase`,
+  sigil wallet create main --passphrase
+  sigil wallet create hidden --passphrase-prompt
+  sigil wallet create main --extra-entropy
+  sigil wallet create main --wordlist japanese
+  sigil wallet create vault --template savings`,
 	Args: cobra.ExactArgs(1),
 	RunE: runWalletCreate,
 }
@@ -110,8 +147,13 @@ var walletRestoreCmd = &cobra.Command{
 	Long: `Restore a wallet from a BIP39 mnemonic phrase, WIF private key, or hex private key.
 
 The input format is automatically detected. You can provide the seed material
-via the --input flag or be guided through interactive prompts.`,
+via the --input flag or be guided through interactive prompts.
+
+For a mnemonic with a BIP39 passphrase, --passphrase stores the seed derived
+with it, while --passphrase-prompt restores a hidden wallet whose passphrase
+is asked for on every unlock, as with "wallet create --passphrase-prompt".`,
 	Example: `  sigil wallet restore backup --input "abandon abandon ... about"
+  sigil wallet restore hidden --passphrase-prompt
   sigil wallet restore imported --input "5HueCGU8rMjxEXxiPuD5BDku..."
   sigil wallet restore backup  # Interactive mode`,
 	Args: cobra.ExactArgs(1),
@@ -129,13 +171,23 @@ func init() {
 
 	walletCreateCmd.Flags().IntVar(&createWords, "words", 12, "mnemonic word count (12 or 24)")
 	walletCreateCmd.Flags().BoolVar(&createPassphrase, "passphrase", false, "use a BIP39 passphrase")
+	walletCreateCmd.Flags().BoolVar(&createPassphrasePrompt, "passphrase-prompt", false, "use a BIP39 passphrase that is asked for on every unlock and never stored")
+	walletCreateCmd.Flags().BoolVar(&createExtraEntropy, "extra-entropy", false, "mix in dice rolls or random typing entered at a prompt")
+	walletCreateCmd.Flags().StringVar(&createWordlist, "wordlist", "english", "BIP39 wordlist for the mnemonic (english, japanese, spanish, ...)")
 	walletCreateCmd.Flags().BoolVar(&createScan, "scan", false, "scan for existing UTXOs after creation")
 	walletCreateCmd.Flags().BoolVar(&createShamir, "shamir", false, "use Shamir Secret Sharing")
 	walletCreateCmd.Flags().IntVar(&createThreshold, "threshold", 3, "number of shares required to restore (default 3)")
 	walletCreateCmd.Flags().IntVar(&createShareCount, "shares", 5, "total number of shares to generate (default 5)")
+	walletCreateCmd.Flags().StringVar(&createTemplate, "template", "", "create from a wallet template (see wallet templates)")
+	walletCreateCmd.Flags().BoolVar(&createWeakPasswordOK, "weak-password-ok", false, "accept a weak or breached password with a warning")
+
+	walletCreateCmd.MarkFlagsMutuallyExclusive("passphrase", "passphrase-prompt")
 
 	walletRestoreCmd.Flags().StringVar(&restoreInput, "input", "", "seed material (mnemonic, WIF, or hex)")
 	walletRestoreCmd.Flags().BoolVar(&restorePassphrase, "passphrase", false, "use a BIP39 passphrase (for mnemonic only)")
+	walletRestoreCmd.Flags().BoolVar(&restorePassphrasePrompt, "passphrase-prompt", false, "use a BIP39 passphrase that is asked for on every unlock and never stored (for mnemonic only)")
 	walletRestoreCmd.Flags().BoolVar(&restoreScan, "scan", true, "scan for existing UTXOs after restore")
 	walletRestoreCmd.Flags().BoolVar(&restoreShamir, "shamir", false, "restore from Shamir shares")
+	walletRestoreCmd.Flags().BoolVar(&restoreWeakPasswordOK, "weak-password-ok", false, "accept a weak or breached password with a warning")
+	walletRestoreCmd.MarkFlagsMutuallyExclusive("passphrase", "passphrase-prompt")
 }
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/mrz1836/sigil/internal/chain/bsv"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
+	"github.com/mrz1836/sigil/internal/wallettemplate"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
@@ -39,9 +41,23 @@ func validateWalletCreationParams(name string, wordCount int, storage *wallet.Fi
 	return nil
 }
 
-// generateWalletSeed generates mnemonic and derives seed with optional passphrase.
-func generateWalletSeed(wordCount int, usePassphrase bool) (mnemonic string, seed []byte, err error) {
-	mnemonic, err = wallet.GenerateMnemonic(wordCount)
+// generateWalletSeed generates a mnemonic in the lang wordlist and derives seed
+// with optional passphrase. With extraEntropy, user entropy from a prompt is
+// mixed into the mnemonic.
+func generateWalletSeed(wordCount int, lang wallet.Language, usePassphrase, extraEntropy bool) (mnemonic string, seed []byte, err error) {
+	var extra []byte
+	if extraEntropy {
+		if extra, err = promptEntropyFn(); err != nil {
+			return "", nil, err
+		}
+		defer wallet.ZeroBytes(extra)
+	}
+
+	mnemonic, err = wallet.NewMnemonic(wallet.SystemSeedSource(), lang, wordCount, extra)
+	if errors.Is(err, wallet.ErrEntropyHealth) {
+		return "", nil, sigilerr.WithSuggestion(err,
+			"the system random number generator is not working; do not create wallets on this machine until it is fixed")
+	}
 	if err != nil {
 		return "", nil, err
 	}
@@ -62,28 +78,51 @@ func generateWalletSeed(wordCount int, usePassphrase bool) (mnemonic string, see
 	return mnemonic, seed, nil
 }
 
+// walletSpec describes the wallet createAndSaveWallet creates.
+type walletSpec struct {
+	// network stamps the wallet's BSV network ("main"/"test") before deriving
+	// so its addresses are encoded for the correct network.
+	network string
+	// chains are the wallet's enabled chains; nil means ETH and BSV.
+	chains []wallet.ChainID
+	// addresses is the number of receive addresses derived per chain; zero means 1.
+	addresses int
+	// settings are the wallet's per-wallet defaults, if any.
+	settings *wallet.Settings
+	// mnemonic is set for a --passphrase-prompt wallet, which stores the
+	// mnemonic instead of the seed so the passphrase is asked for on unlock.
+	mnemonic string
+}
+
 // createAndSaveWallet creates wallet, derives addresses, and saves to storage.
-// network stamps the wallet's BSV network ("main"/"test") before deriving so its
-// addresses are encoded for the correct network.
-func createAndSaveWallet(name string, seed []byte, storage *wallet.FileStorage, network string) (*wallet.Wallet, error) {
-	w, err := wallet.NewWallet(name, []wallet.ChainID{wallet.ChainETH, wallet.ChainBSV})
+// The new password must satisfy policy.
+func createAndSaveWallet(name string, seed []byte, storage *wallet.FileStorage, spec walletSpec, policy passwordPolicy) (*wallet.Wallet, error) {
+	w, err := wallet.NewWallet(name, spec.chains)
 	if err != nil {
 		return nil, err
 	}
-	w.Network = network
+	w.Network = spec.network
+	w.Settings = spec.settings
 
-	err = w.DeriveAddresses(seed, 1)
+	err = w.DeriveAddresses(seed, max(spec.addresses, 1))
 	if err != nil {
 		return nil, err
 	}
 
-	password, err := promptNewPasswordFn()
+	secret := seed
+	if spec.mnemonic != "" {
+		w.PassphrasePrompt = true
+		secret = []byte(spec.mnemonic)
+		defer wallet.ZeroBytes(secret)
+	}
+
+	password, err := promptNewPasswordFn(policy)
 	if err != nil {
 		return nil, err
 	}
 	defer wallet.ZeroBytes(password)
 
-	err = storage.Save(w, seed, password)
+	err = storage.Save(w, secret, password)
 	if err != nil {
 		return nil, err
 	}
@@ -118,10 +157,22 @@ func (a *bsvClientAdapter) ListUTXOs(ctx context.Context, address string) ([]cha
 	return chainUTXOs, nil
 }
 
-// scanWalletUTXOs scans a wallet for UTXOs and reports results.
+// CoinbaseStatus implements utxostore.CoinbaseClient.
+func (a *bsvClientAdapter) CoinbaseStatus(ctx context.Context, txid string) (bool, uint32, error) {
+	return a.client.CoinbaseStatus(ctx, txid)
+}
+
+// scanWalletUTXOs scans every account of a wallet for UTXOs and reports results.
 func scanWalletUTXOs(w *wallet.Wallet, cmd *cobra.Command) error {
 	ctx := GetCmdContext(cmd)
 	walletPath := filepath.Join(ctx.Cfg.GetHome(), "wallets", w.Name)
+
+	lock, err := lockWallet(cmd, wallet.NewFileStorage(filepath.Join(ctx.Cfg.GetHome(), "wallets")), w.Name, "utxo scan")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = lock.Release() }()
+
 	store := utxostore.New(walletPath)
 
 	// Load existing UTXO data if any
@@ -144,13 +195,30 @@ func scanWalletUTXOs(w *wallet.Wallet, cmd *cobra.Command) error {
 	// Wrap client in adapter
 	adapter := &bsvClientAdapter{client: client}
 
-	result, err := store.ScanWallet(scanCtx, w, wallet.ChainBSV, adapter)
-	if err != nil {
-		return fmt.Errorf("scanning wallet: %w", err)
-	}
+	// Each account is scanned separately, with its own gap limit
+	for _, n := range w.AccountIndexes() {
+		acct, err := w.Account(n)
+		if err != nil {
+			return err
+		}
+		if n != w.AccountIndex() {
+			out(cmd.OutOrStdout(), "\nAccount %d:\n", n)
+		}
 
-	// Display scan results
-	displayScanResults(result, cmd)
+		result, err := store.ScanWallet(scanCtx, acct, wallet.ChainBSV, adapter)
+		if err != nil {
+			if isCanceled(err) && result != nil {
+				// Not a failure: the checkpoint is saved and the next scan resumes from it
+				displayScanResults(result, cmd)
+				outln(cmd.OutOrStdout(), "  Scan canceled; progress was saved and the next scan resumes from it.")
+				return nil
+			}
+			return fmt.Errorf("scanning wallet: %w", err)
+		}
+
+		// Display scan results
+		displayScanResults(result, cmd)
+	}
 
 	return nil
 }
@@ -168,6 +236,9 @@ func displayScanResults(result *utxostore.ScanResult, cmd *cobra.Command) {
 
 	outln(w)
 	out(w, "Scan Results:\n")
+	if result.ResumedAt > 0 {
+		out(w, "  Resumed at address: %d (earlier progress was saved)\n", result.ResumedAt)
+	}
 	out(w, "  Addresses scanned: %d\n", result.AddressesScanned)
 	out(w, "  UTXOs found: %d\n", result.UTXOsFound)
 	out(w, "  Total balance: %d satoshis (%.8f BSV)\n",
@@ -179,24 +250,56 @@ func runWalletCreate(cmd *cobra.Command, args []string) error {
 	name := args[0]
 	storage := wallet.NewFileStorage(filepath.Join(ctx.Cfg.GetHome(), "wallets"))
 
+	// The wallet is stamped with the effective global BSV network.
+	spec := walletSpec{network: bsvNetworkForCmd(cmd), chains: walletChainsForCmd(cmd)}
+	words, usePassphrase := createWords, createPassphrase
+	var tmpl *wallettemplate.Template
+	if createTemplate != "" {
+		var err error
+		if tmpl, err = lookupWalletTemplate(ctx.Cfg, createTemplate); err != nil {
+			return err
+		}
+		if spec, err = applyWalletTemplate(cmd, tmpl, spec, &words, &usePassphrase); err != nil {
+			return err
+		}
+	}
+
 	// Validate inputs
-	if err := validateWalletCreationParams(name, createWords, storage); err != nil {
+	if err := validateWalletCreationParams(name, words, storage); err != nil {
 		return err
 	}
+	lang, err := wallet.ParseLanguage(createWordlist)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
 
 	// Generate mnemonic and seed
-	mnemonic, seed, err := generateWalletSeed(createWords, createPassphrase)
+	mnemonic, seed, err := generateWalletSeed(words, lang, usePassphrase && !createPassphrasePrompt, createExtraEntropy)
 	if err != nil {
 		return err
 	}
+	if createPassphrasePrompt {
+		wallet.ZeroBytes(seed)
+		if seed, err = passphrasePromptSeed(mnemonic); err != nil {
+			return err
+		}
+		spec.mnemonic = mnemonic
+	}
 	defer wallet.ZeroBytes(seed)
 
-	// Create and save wallet, stamped with the effective global BSV network.
-	w, err := createAndSaveWallet(name, seed, storage, bsvNetworkForCmd(cmd))
+	// Create and save wallet
+	w, err := createAndSaveWallet(name, seed, storage, spec, newPasswordPolicy(cmd, createWeakPasswordOK))
 	if err != nil {
 		return err
 	}
 
+	if tmpl != nil && len(tmpl.Labels) > 0 {
+		if err := labelTemplateAddresses(filepath.Join(ctx.Cfg.GetHome(), "wallets", name), w, tmpl.Labels); err != nil {
+			// The wallet is saved; labels can be set later with addresses label
+			out(cmd.ErrOrStderr(), "\nWarning: could not label addresses: %v\n", err)
+		}
+	}
+
 	// Display results
 	if createShamir {
 		if err := handleShamirCreation(mnemonic, cmd); err != nil {
@@ -217,8 +320,15 @@ func runWalletCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	outln(cmd.OutOrStdout())
-	out(cmd.OutOrStdout(), "Wallet '%s' created successfully.\n", name)
+	if tmpl != nil {
+		out(cmd.OutOrStdout(), "Wallet '%s' created successfully from template '%s'.\n", name, tmpl.Name)
+	} else {
+		out(cmd.OutOrStdout(), "Wallet '%s' created successfully.\n", name)
+	}
 	outln(cmd.OutOrStdout(), "Wallet file: "+filepath.Join(ctx.Cfg.GetHome(), "wallets", name+".wallet"))
+	if createPassphrasePrompt {
+		outln(cmd.OutOrStdout(), "The BIP39 passphrase is not stored; you will be asked for it after the password on every unlock.")
+	}
 
 	return nil
 }
@@ -239,6 +349,10 @@ func displayMnemonic(mnemonic string, cmd *cobra.Command) {
 	for i, word := range words {
 		out(w, "%2d. %s\n", i+1, word)
 	}
+	if lang, err := wallet.DetectLanguage(mnemonic); err == nil && lang != wallet.LanguageEnglish {
+		outln(w)
+		out(w, "Wordlist: %s (BIP39)\n", lang)
+	}
 
 	outln(w)
 	outln(w, "===================================================================")
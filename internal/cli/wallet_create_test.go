@@ -13,6 +13,7 @@ import (
 
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 var (
@@ -69,6 +70,15 @@ func TestDisplayScanResults(t *testing.T) {
 				"0 satoshis",
 				"0.00000000 BSV",
 			},
+			notContains: []string{"Resumed"},
+		},
+		{
+			name: "resumed scan",
+			result: &utxostore.ScanResult{
+				AddressesScanned: 30,
+				ResumedAt:        12,
+			},
+			contains: []string{"Resumed at address: 12"},
 		},
 	}
 
@@ -109,7 +119,7 @@ func TestCreateAndSaveWallet_HappyPath(t *testing.T) {
 	require.NoError(t, err)
 	defer wallet.ZeroBytes(seed)
 
-	w, err := createAndSaveWallet("create_test", seed, storage, "main")
+	w, err := createAndSaveWallet("create_test", seed, storage, walletSpec{network: "main"}, passwordPolicy{})
 	require.NoError(t, err)
 	require.NotNil(t, w)
 
@@ -123,6 +133,32 @@ func TestCreateAndSaveWallet_HappyPath(t *testing.T) {
 	assert.True(t, exists)
 }
 
+func TestCreateAndSaveWallet_PassphrasePrompt(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+	withMockPrompts(t, []byte("testpassword123"), true)
+
+	storage := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets"))
+
+	mnemonic, err := wallet.GenerateMnemonic(12)
+	require.NoError(t, err)
+	seed, err := passphrasePromptSeed(mnemonic)
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+
+	w, err := createAndSaveWallet("hidden_test", seed, storage, walletSpec{network: "main", mnemonic: mnemonic}, passwordPolicy{})
+	require.NoError(t, err)
+	assert.True(t, w.PassphrasePrompt)
+
+	// The file stores the mnemonic; the passphrase recovers the seed
+	loaded, secret, err := storage.Load("hidden_test", []byte("testpassword123"))
+	require.NoError(t, err)
+	assert.Equal(t, mnemonic, string(secret))
+	derived, err := loaded.SeedFromMnemonic(secret, "testpassphrase")
+	require.NoError(t, err)
+	assert.Equal(t, seed, derived)
+}
+
 func TestCreateAndSaveWallet_InvalidSeed(t *testing.T) {
 	tmpDir, cleanup := setupTestEnv(t)
 	defer cleanup()
@@ -131,7 +167,7 @@ func TestCreateAndSaveWallet_InvalidSeed(t *testing.T) {
 	storage := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets"))
 
 	// An empty seed should cause DeriveAddresses to fail
-	_, err := createAndSaveWallet("bad_seed", []byte{}, storage, "main")
+	_, err := createAndSaveWallet("bad_seed", []byte{}, storage, walletSpec{network: "main"}, passwordPolicy{})
 	require.Error(t, err)
 }
 
@@ -142,7 +178,7 @@ func TestGenerateWalletSeed_WithPassphrase(t *testing.T) {
 	defer cleanup()
 	withMockPrompts(t, []byte("testpassword123"), true)
 
-	mnemonic, seed, err := generateWalletSeed(12, true)
+	mnemonic, seed, err := generateWalletSeed(12, wallet.LanguageEnglish, true, false)
 	require.NoError(t, err)
 	defer wallet.ZeroBytes(seed)
 
@@ -157,3 +193,36 @@ func TestGenerateWalletSeed_WithPassphrase(t *testing.T) {
 
 	assert.NotEqual(t, seedNoPassphrase, seed, "passphrase should produce different seed")
 }
+
+func TestGenerateWalletSeed_ExtraEntropy(t *testing.T) {
+	orig := promptEntropyFn
+	t.Cleanup(func() { promptEntropyFn = orig })
+
+	prompted := false
+	promptEntropyFn = func() ([]byte, error) {
+		prompted = true
+		return []byte("4 2 6 1 3 5 5 2"), nil
+	}
+
+	mnemonic, seed, err := generateWalletSeed(24, wallet.LanguageEnglish, false, true)
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+	assert.True(t, prompted)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+	require.NoError(t, wallet.ValidateMnemonic(mnemonic))
+
+	promptEntropyFn = func() ([]byte, error) { return nil, sigilerr.ErrInvalidInput }
+	_, _, err = generateWalletSeed(12, wallet.LanguageEnglish, false, true)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestGenerateWalletSeed_Wordlist(t *testing.T) {
+	mnemonic, seed, err := generateWalletSeed(12, wallet.LanguageJapanese, false, false)
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+
+	assert.Len(t, strings.Split(mnemonic, "\u3000"), 12, "Japanese words are separated by ideographic spaces")
+	lang, err := wallet.DetectLanguage(mnemonic)
+	require.NoError(t, err)
+	assert.Equal(t, wallet.LanguageJapanese, lang)
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// discoverHardwareFn lists the connected hardware wallets. Tests replace it
+// with a fixed list.
+//
+//nolint:gochecknoglobals // Test seam for hardware wallet discovery
+var discoverHardwareFn = wallet.DiscoverHardware
+
+// walletDevicesCmd lists connected hardware wallets.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var walletDevicesCmd = &cobra.Command{
+	Use:   "devices",
+	Short: "List connected hardware wallets",
+	Long: `List the Ledger devices sigil can reach over USB (Linux).
+
+The ID column is the value to pass to "sigil tx send --signer hardware
+--device" when more than one device is connected. Connect and unlock the
+device before running this command.`,
+	Example: `  sigil wallet devices
+  sigil wallet devices -o json`,
+	Args: cobra.NoArgs,
+	RunE: runWalletDevices,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	walletCmd.AddCommand(walletDevicesCmd)
+}
+
+func runWalletDevices(cmd *cobra.Command, _ []string) error {
+	ctx, cancel := contextWithTimeout(cmd, 30*time.Second)
+	defer cancel()
+
+	devices, err := discoverHardwareFn(ctx)
+	if err != nil {
+		return hardwareError(err)
+	}
+
+	w := cmd.OutOrStdout()
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		if devices == nil {
+			devices = []wallet.HardwareDevice{}
+		}
+		return writeJSON(w, struct {
+			Devices []wallet.HardwareDevice `json:"devices"`
+		}{Devices: devices})
+	}
+
+	if len(devices) == 0 {
+		outln(w, "No hardware wallets found. Connect and unlock the device, then try again.")
+		return nil
+	}
+	out(w, "%-12s %-10s %-14s %s\n", "TRANSPORT", "VENDOR", "MODEL", "ID")
+	for _, d := range devices {
+		out(w, "%-12s %-10s %-14s %s\n", d.Transport, d.Vendor, d.Model, d.ID)
+	}
+	return nil
+}
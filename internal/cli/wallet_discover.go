@@ -241,8 +241,9 @@ func runWalletDiscover(cmd *cobra.Command, _ []string) error {
 	// Build response
 	response := buildDiscoverResponse(result)
 
-	// Handle migration if requested
-	if discoverMigrate && result.HasFunds() {
+	// Handle migration if requested; a canceled scan never migrates, since
+	// it may have missed funds
+	if discoverMigrate && result.HasFunds() && !commandCanceled(cmd) {
 		migrationResp, err := executeMigration(ctx, cmd, cc, seed, result, client)
 		if err != nil {
 			return err
@@ -0,0 +1,493 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/backup"
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/session"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// doctorStatus is the outcome of a single health check.
+type doctorStatus string
+
+// Health check outcomes, from best to worst. Skipped checks do not affect
+// the overall status.
+const (
+	doctorGreen   doctorStatus = "green"
+	doctorYellow  doctorStatus = "yellow"
+	doctorRed     doctorStatus = "red"
+	doctorSkipped doctorStatus = "skipped"
+)
+
+const (
+	// doctorCacheMaxAge is the age after which cached balances are reported as stale.
+	doctorCacheMaxAge = 24 * time.Hour
+
+	// doctorAgentExpiryWarning is how far ahead agent credential expiry is flagged.
+	doctorAgentExpiryWarning = 24 * time.Hour
+
+	// doctorBackupMaxAge is the age after which the newest backup is reported as old.
+	doctorBackupMaxAge = 30 * 24 * time.Hour
+
+	// doctorNetworkTimeout bounds the UTXO reconciliation against the network.
+	doctorNetworkTimeout = 60 * time.Second
+)
+
+// doctorCheck is one line of the health report.
+type doctorCheck struct {
+	Name    string       `json:"name"`
+	Status  doctorStatus `json:"status"`
+	Summary string       `json:"summary"`
+	Details []string     `json:"details,omitempty"`
+}
+
+// doctorReport is the full wallet health report.
+type doctorReport struct {
+	Wallet    string        `json:"wallet"`
+	Status    doctorStatus  `json:"status"`
+	CheckedAt time.Time     `json:"checked_at"`
+	Checks    []doctorCheck `json:"checks"`
+}
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// doctorWallet is the wallet name to check.
+	doctorWallet string
+	// doctorOffline skips checks that query the network.
+	doctorOffline bool
+	// doctorSkipAudit skips the derivation audit, which needs the wallet unlocked.
+	doctorSkipAudit bool
+)
+
+// walletDoctorCmd runs every wallet health check and prints a combined report.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var walletDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Run a full health check on a wallet",
+	Long: `Run every wallet health check and print a red/yellow/green report.
+
+Checks:
+  derivation  stored addresses re-derive from the seed (needs the wallet unlocked)
+  utxos       the local UTXO store matches the network (BSV)
+  cache       cached balances exist and are recent
+  session     session caching works and whether a session is active
+  agents      agent credentials that have expired or expire within a day
+  backups     a backup exists, is readable, and is recent
+
+Red means something is wrong and needs action; yellow means something is out
+of date or worth a look. The command exits with a non-zero status when any
+check is red.`,
+	Example: `  sigil wallet doctor --wallet main
+  sigil wallet doctor --wallet main --offline
+  sigil wallet doctor --wallet main --skip-audit -o json`,
+	RunE: runWalletDoctor,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	walletCmd.AddCommand(walletDoctorCmd)
+
+	walletDoctorCmd.Flags().StringVar(&doctorWallet, "wallet", "", "wallet name (defaults to config default_wallet)")
+	walletDoctorCmd.Flags().BoolVar(&doctorOffline, "offline", false, "skip checks that query the network")
+	walletDoctorCmd.Flags().BoolVar(&doctorSkipAudit, "skip-audit", false, "skip the derivation audit (does not unlock the wallet)")
+}
+
+func runWalletDoctor(cmd *cobra.Command, _ []string) error {
+	if err := resolveWalletName(cmd, &doctorWallet); err != nil {
+		return err
+	}
+	cc := GetCmdContext(cmd)
+	home := cc.Cfg.GetHome()
+
+	storage := wallet.NewFileStorage(filepath.Join(home, "wallets"))
+	exists, err := storage.Exists(doctorWallet)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrWalletNotFound,
+			fmt.Sprintf("wallet '%s' not found. List wallets with: sigil wallet list", doctorWallet),
+		)
+	}
+
+	now := time.Now()
+	report := &doctorReport{Wallet: doctorWallet, CheckedAt: now.UTC()}
+
+	// Session status is read before unlocking, which may start a session.
+	sessionCheck := checkDoctorSession(cc.SessionMgr, cc.Cfg.GetSecurity().SessionEnabled, doctorWallet)
+
+	var wlt *wallet.Wallet
+	if doctorSkipAudit {
+		if wlt, err = storage.LoadMetadata(doctorWallet); err != nil {
+			return err
+		}
+		report.add(doctorCheck{Name: "derivation", Status: doctorSkipped, Summary: "skipped (--skip-audit)"})
+	} else {
+		var seed []byte
+		if wlt, seed, err = loadWalletWithSession(doctorWallet, storage, cmd); err != nil {
+			return err
+		}
+		auditReport, auditErr := wlt.AuditAddresses(seed)
+		wallet.ZeroBytes(seed)
+		report.add(checkDoctorDerivation(auditReport, auditErr))
+	}
+
+	walletPath := filepath.Join(home, "wallets", doctorWallet)
+	if doctorOffline {
+		report.add(doctorCheck{Name: "utxos", Status: doctorSkipped, Summary: "skipped (--offline)"})
+	} else {
+		ctx, cancel := contextWithTimeout(cmd, doctorNetworkTimeout)
+		client := &bsvRefreshAdapter{client: bsv.NewClient(ctx, &bsv.ClientOptions{
+			APIKey:  cc.Cfg.GetBSVAPIKey(),
+			Network: bsvClientNetwork(effectiveBSVNetwork(wlt, cc.Cfg)),
+		})}
+		report.add(checkDoctorUTXOs(ctx, doctorWallet, utxostore.New(walletPath), client))
+		cancel()
+	}
+
+	balanceCache, cacheErr := cache.NewBalanceStorage(home, cc.Cfg.GetCache()).Load()
+	report.add(checkDoctorCache(balanceCache, cacheErr, wlt, now))
+
+	report.add(sessionCheck)
+
+	creds, agentErr := agent.NewFileStore(filepath.Join(home, "agents")).List(doctorWallet)
+	report.add(checkDoctorAgents(creds, agentErr, now))
+
+	backupSvc := backup.NewService(filepath.Join(home, "backups"), storage)
+	report.add(checkDoctorBackups(backupSvc, doctorWallet, now))
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		_ = writeJSON(w, report)
+	} else {
+		displayDoctorReportText(w, report)
+	}
+
+	if report.Status == doctorRed {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrGeneral,
+			fmt.Sprintf("wallet '%s' failed %d health check(s); see the report for how to fix each one",
+				doctorWallet, report.count(doctorRed)),
+		)
+	}
+	return nil
+}
+
+// add appends a check and folds its status into the overall status.
+func (r *doctorReport) add(c doctorCheck) {
+	r.Checks = append(r.Checks, c)
+	if r.Status == "" {
+		r.Status = doctorGreen
+	}
+	if doctorSeverity(c.Status) > doctorSeverity(r.Status) {
+		r.Status = c.Status
+	}
+}
+
+// count returns the number of checks with the given status.
+func (r *doctorReport) count(status doctorStatus) int {
+	n := 0
+	for _, c := range r.Checks {
+		if c.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// doctorSeverity orders statuses for computing the overall result.
+func doctorSeverity(s doctorStatus) int {
+	switch s {
+	case doctorRed:
+		return 3
+	case doctorYellow:
+		return 2
+	case doctorGreen:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// checkDoctorDerivation reports the result of the stored address audit.
+func checkDoctorDerivation(report *wallet.AuditReport, err error) doctorCheck {
+	c := doctorCheck{Name: "derivation"}
+	switch {
+	case err != nil:
+		c.Status = doctorRed
+		c.Summary = fmt.Sprintf("audit failed: %v", err)
+	case report.OK():
+		c.Status = doctorGreen
+		c.Summary = fmt.Sprintf("all %d stored addresses match the seed", report.Checked)
+	default:
+		c.Status = doctorRed
+		c.Summary = fmt.Sprintf("%d of %d stored addresses do not match the seed; restore the wallet from its mnemonic",
+			len(report.Mismatches), report.Checked)
+		for _, m := range report.Mismatches {
+			c.Details = append(c.Details, fmt.Sprintf("%s %s: %s differs", m.Chain, m.Path, m.Field))
+		}
+	}
+	return c
+}
+
+// checkDoctorUTXOs compares the local UTXO store with the network.
+func checkDoctorUTXOs(ctx context.Context, name string, store *utxostore.Store, client utxostore.ChainClient) doctorCheck {
+	c := doctorCheck{Name: "utxos"}
+	if err := store.Load(); err != nil {
+		c.Status = doctorRed
+		c.Summary = fmt.Sprintf("UTXO store cannot be read: %v", err)
+		return c
+	}
+	if len(store.GetAddresses(chain.BSV)) == 0 {
+		c.Status = doctorYellow
+		c.Summary = "no BSV addresses tracked; run: sigil utxo refresh --wallet " + name
+		return c
+	}
+
+	result, err := store.Reconcile(ctx, chain.BSV, client)
+	if err != nil {
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("reconciliation interrupted: %v", err)
+		return c
+	}
+	if result.AddressesChecked == 0 && len(result.Errors) > 0 {
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("could not reach the network: %v", result.Errors[0])
+		return c
+	}
+
+	for _, d := range result.MissingLocally {
+		c.Details = append(c.Details, fmt.Sprintf("on network, not in store: %s:%d (%d sats)", d.TxID, d.Vout, d.Amount))
+	}
+	for _, d := range result.SpentOnNetwork {
+		c.Details = append(c.Details, fmt.Sprintf("spent on network, unspent in store: %s:%d (%d sats)", d.TxID, d.Vout, d.Amount))
+	}
+	for _, e := range result.Errors {
+		c.Details = append(c.Details, e.Error())
+	}
+
+	switch {
+	case !result.InSync():
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("%d UTXO(s) out of sync across %d addresses; run: sigil utxo refresh --wallet %s",
+			len(result.MissingLocally)+len(result.SpentOnNetwork), result.AddressesChecked, name)
+	case len(result.Errors) > 0:
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("%d addresses match the network, %d could not be checked",
+			result.AddressesChecked, len(result.Errors))
+	default:
+		c.Status = doctorGreen
+		c.Summary = fmt.Sprintf("%d addresses match the network", result.AddressesChecked)
+	}
+	return c
+}
+
+// checkDoctorCache reports how fresh the cached balances of the wallet's
+// receive addresses are.
+func checkDoctorCache(bc *cache.BalanceCache, loadErr error, wlt *wallet.Wallet, now time.Time) doctorCheck {
+	c := doctorCheck{Name: "cache"}
+	if loadErr != nil {
+		c.Status = doctorYellow
+		c.Summary = "balance cache is unreadable and will be rebuilt; run: sigil balance show --refresh"
+		if errors.Is(loadErr, cache.ErrCorruptCache) {
+			c.Summary = "balance cache is corrupted and will be rebuilt; run: sigil balance show --refresh"
+		}
+		return c
+	}
+
+	var total, missing int
+	var oldest time.Duration
+	for _, chainID := range wlt.EnabledChains {
+		for _, addr := range wlt.Addresses[chainID] {
+			total++
+			entry, ok, _ := bc.Get(chainID, addr.Address, "")
+			if !ok {
+				missing++
+				continue
+			}
+			if age := now.Sub(entry.UpdatedAt); age > oldest {
+				oldest = age
+			}
+		}
+	}
+
+	switch {
+	case total == 0:
+		c.Status = doctorGreen
+		c.Summary = "no addresses to cache"
+	case missing == total:
+		c.Status = doctorYellow
+		c.Summary = "no cached balances; run: sigil balance show --wallet " + wlt.Name
+	case missing > 0:
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("%d of %d addresses have no cached balance; run: sigil balance show --wallet %s", missing, total, wlt.Name)
+	case oldest > doctorCacheMaxAge:
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("oldest cached balance is %s old; run: sigil balance show --wallet %s --refresh", formatDoctorAge(oldest), wlt.Name)
+	default:
+		c.Status = doctorGreen
+		c.Summary = fmt.Sprintf("%d addresses cached, oldest %s old", total, formatDoctorAge(oldest))
+	}
+	return c
+}
+
+// checkDoctorSession reports whether session caching works and whether the
+// wallet has an active session.
+func checkDoctorSession(mgr session.Manager, enabled bool, name string) doctorCheck {
+	c := doctorCheck{Name: "session", Status: doctorGreen}
+	switch {
+	case !enabled || mgr == nil:
+		c.Summary = "session caching is disabled"
+		return c
+	case !mgr.Available():
+		c.Status = doctorYellow
+		c.Summary = "session caching is enabled but the OS keyring is unavailable; every command will prompt for the password"
+		return c
+	}
+
+	sessions, err := mgr.ListSessions()
+	if err != nil {
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("sessions cannot be listed: %v", err)
+		return c
+	}
+	for _, s := range sessions {
+		if s.WalletName == name && s.IsValid() {
+			c.Summary = fmt.Sprintf("active, expires in %s", formatDoctorAge(s.TTL()))
+			return c
+		}
+	}
+	c.Summary = "no active session"
+	return c
+}
+
+// checkDoctorAgents reports agent credentials that have expired or expire soon.
+func checkDoctorAgents(creds []*agent.Credential, listErr error, now time.Time) doctorCheck {
+	c := doctorCheck{Name: "agents", Status: doctorGreen}
+	if listErr != nil {
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("agent credentials cannot be read: %v", listErr)
+		return c
+	}
+	if len(creds) == 0 {
+		c.Summary = "no agent credentials"
+		return c
+	}
+
+	var expired, expiring int
+	for _, cred := range creds {
+		left := cred.ExpiresAt.Sub(now)
+		switch {
+		case left <= 0:
+			expired++
+			c.Details = append(c.Details, fmt.Sprintf("%s (%s) expired %s ago; remove with: sigil agent revoke --wallet %s --id %s",
+				cred.ID, cred.Label, formatDoctorAge(-left), cred.WalletName, cred.ID))
+		case left <= doctorAgentExpiryWarning:
+			expiring++
+			c.Details = append(c.Details, fmt.Sprintf("%s (%s) expires in %s", cred.ID, cred.Label, formatDoctorAge(left)))
+		}
+	}
+
+	if expired+expiring == 0 {
+		c.Summary = fmt.Sprintf("%d active credential(s)", len(creds))
+		return c
+	}
+	c.Status = doctorYellow
+	c.Summary = fmt.Sprintf("%d expired, %d expiring within %s, of %d credential(s)",
+		expired, expiring, formatDoctorAge(doctorAgentExpiryWarning), len(creds))
+	return c
+}
+
+// checkDoctorBackups reports whether the wallet has a valid, recent backup.
+func checkDoctorBackups(svc *backup.Service, name string, now time.Time) doctorCheck {
+	c := doctorCheck{Name: "backups"}
+	files, err := svc.List()
+	if err != nil {
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("backups cannot be listed: %v", err)
+		return c
+	}
+
+	var newest time.Time
+	var count int
+	for _, file := range files {
+		manifest, verifyErr := svc.Verify(svc.BackupPath(file))
+		if verifyErr != nil {
+			// Only report corrupt files that look like they belong to this wallet.
+			if strings.HasPrefix(file, name+"-") {
+				c.Details = append(c.Details, fmt.Sprintf("%s failed verification: %v", file, verifyErr))
+			}
+			continue
+		}
+		if manifest.WalletName != name {
+			continue
+		}
+		count++
+		if manifest.CreatedAt.After(newest) {
+			newest = manifest.CreatedAt
+		}
+	}
+	sort.Strings(c.Details)
+
+	switch {
+	case count == 0:
+		c.Status = doctorRed
+		c.Summary = fmt.Sprintf("no backup found; create one with: sigil backup create --wallet %s", name)
+	case now.Sub(newest) > doctorBackupMaxAge:
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("newest backup is %s old; create a fresh one with: sigil backup create --wallet %s",
+			formatDoctorAge(now.Sub(newest)), name)
+	case len(c.Details) > 0:
+		c.Status = doctorYellow
+		c.Summary = fmt.Sprintf("%d valid backup(s), newest %s old, but some files failed verification",
+			count, formatDoctorAge(now.Sub(newest)))
+	default:
+		c.Status = doctorGreen
+		c.Summary = fmt.Sprintf("%d backup(s), newest %s old", count, formatDoctorAge(now.Sub(newest)))
+	}
+	return c
+}
+
+// formatDoctorAge renders a duration at a readable granularity.
+func formatDoctorAge(d time.Duration) string {
+	switch {
+	case d >= 48*time.Hour:
+		return fmt.Sprintf("%dd", int(d.Hours()/24))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
+// displayDoctorReportText shows a wallet health report in text format.
+func displayDoctorReportText(w io.Writer, report *doctorReport) {
+	out(w, "Wallet '%s' health: %s\n\n", report.Wallet, strings.ToUpper(string(report.Status)))
+	for _, c := range report.Checks {
+		out(w, "  %-8s %-10s %s\n", "["+strings.ToUpper(string(c.Status))+"]", c.Name, c.Summary)
+		for _, d := range c.Details {
+			out(w, "  %-8s %-10s   - %s\n", "", "", d)
+		}
+	}
+}
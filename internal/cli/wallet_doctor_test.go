@@ -0,0 +1,253 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/backup"
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/session"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+var errDoctorTestNetwork = errors.New("connection refused")
+
+// doctorChainClient returns fixed UTXOs per address.
+type doctorChainClient struct {
+	utxos map[string][]chain.UTXO
+	err   error
+}
+
+func (c *doctorChainClient) ListUTXOs(_ context.Context, address string) ([]chain.UTXO, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.utxos[address], nil
+}
+
+func TestDoctorReport_Status(t *testing.T) {
+	t.Parallel()
+
+	report := &doctorReport{}
+	report.add(doctorCheck{Name: "a", Status: doctorSkipped})
+	assert.Equal(t, doctorGreen, report.Status)
+
+	report.add(doctorCheck{Name: "b", Status: doctorYellow})
+	report.add(doctorCheck{Name: "c", Status: doctorGreen})
+	assert.Equal(t, doctorYellow, report.Status)
+
+	report.add(doctorCheck{Name: "d", Status: doctorRed})
+	assert.Equal(t, doctorRed, report.Status)
+	assert.Equal(t, 1, report.count(doctorRed))
+}
+
+func TestCheckDoctorDerivation(t *testing.T) {
+	t.Parallel()
+
+	ok := checkDoctorDerivation(&wallet.AuditReport{Checked: 4, Mismatches: []wallet.AddressMismatch{}}, nil)
+	assert.Equal(t, doctorGreen, ok.Status)
+
+	bad := checkDoctorDerivation(&wallet.AuditReport{Checked: 4, Mismatches: []wallet.AddressMismatch{{
+		Chain: wallet.ChainBSV, Path: "m/44'/236'/0'/0/1", Field: wallet.AuditFieldAddress,
+	}}}, nil)
+	assert.Equal(t, doctorRed, bad.Status)
+	assert.Equal(t, []string{"bsv m/44'/236'/0'/0/1: address differs"}, bad.Details)
+}
+
+func TestCheckDoctorUTXOs(t *testing.T) {
+	t.Parallel()
+
+	const addr = "1DoctorTestAddressAAAAAAAAAAAAAAAA"
+	txid := "aa" + string(bytes.Repeat([]byte("0"), 62))
+
+	newStore := func(t *testing.T) *utxostore.Store {
+		t.Helper()
+		store := utxostore.New(t.TempDir())
+		store.AddAddress(&utxostore.AddressMetadata{ChainID: chain.BSV, Address: addr})
+		store.AddUTXO(&utxostore.StoredUTXO{ChainID: chain.BSV, Address: addr, TxID: txid, Vout: 0, Amount: 500})
+		require.NoError(t, store.Save())
+		return store
+	}
+
+	t.Run("in sync", func(t *testing.T) {
+		t.Parallel()
+		client := &doctorChainClient{utxos: map[string][]chain.UTXO{addr: {{TxID: txid, Vout: 0, Amount: 500}}}}
+		c := checkDoctorUTXOs(context.Background(), "main", newStore(t), client)
+		assert.Equal(t, doctorGreen, c.Status)
+	})
+
+	t.Run("out of sync", func(t *testing.T) {
+		t.Parallel()
+		c := checkDoctorUTXOs(context.Background(), "main", newStore(t), &doctorChainClient{})
+		assert.Equal(t, doctorYellow, c.Status)
+		assert.Contains(t, c.Summary, "sigil utxo refresh --wallet main")
+		require.Len(t, c.Details, 1)
+		assert.Contains(t, c.Details[0], "spent on network")
+	})
+
+	t.Run("network down", func(t *testing.T) {
+		t.Parallel()
+		c := checkDoctorUTXOs(context.Background(), "main", newStore(t), &doctorChainClient{err: errDoctorTestNetwork})
+		assert.Equal(t, doctorYellow, c.Status)
+		assert.Contains(t, c.Summary, "could not reach the network")
+	})
+
+	t.Run("nothing tracked", func(t *testing.T) {
+		t.Parallel()
+		c := checkDoctorUTXOs(context.Background(), "main", utxostore.New(t.TempDir()), &doctorChainClient{})
+		assert.Equal(t, doctorYellow, c.Status)
+	})
+}
+
+func TestCheckDoctorCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	wlt := &wallet.Wallet{
+		Name:          "main",
+		EnabledChains: []wallet.ChainID{wallet.ChainBSV},
+		Addresses: map[wallet.ChainID][]wallet.Address{
+			wallet.ChainBSV: {{Address: "1A"}, {Address: "1B"}},
+		},
+	}
+	cached := func(ages ...time.Duration) *cache.BalanceCache {
+		bc := cache.NewBalanceCache()
+		for i, age := range ages {
+			addr := wlt.Addresses[wallet.ChainBSV][i].Address
+			// Set stamps entries with the current time, so backdate them directly
+			bc.Entries[cache.Key(chain.BSV, addr, "")] = cache.BalanceCacheEntry{Chain: chain.BSV, Address: addr, UpdatedAt: now.Add(-age)}
+		}
+		return bc
+	}
+
+	tests := []struct {
+		name   string
+		bc     *cache.BalanceCache
+		err    error
+		status doctorStatus
+	}{
+		{name: "fresh", bc: cached(time.Minute, time.Hour), status: doctorGreen},
+		{name: "stale", bc: cached(time.Minute, 48*time.Hour), status: doctorYellow},
+		{name: "partially cached", bc: cached(time.Minute), status: doctorYellow},
+		{name: "empty", bc: cache.NewBalanceCache(), status: doctorYellow},
+		{name: "corrupt", err: cache.ErrCorruptCache, status: doctorYellow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.status, checkDoctorCache(tt.bc, tt.err, wlt, now).Status)
+		})
+	}
+}
+
+func TestCheckDoctorSession(t *testing.T) {
+	t.Parallel()
+
+	active := &session.Session{WalletName: "main", ExpiresAt: time.Now().Add(10 * time.Minute)}
+
+	assert.Equal(t, "session caching is disabled", checkDoctorSession(nil, true, "main").Summary)
+	assert.Equal(t, doctorYellow, checkDoctorSession(&mockSessionManager{}, true, "main").Status)
+
+	c := checkDoctorSession(&mockSessionManager{available: true, sessions: []*session.Session{active}}, true, "main")
+	assert.Equal(t, doctorGreen, c.Status)
+	assert.Contains(t, c.Summary, "active, expires in")
+
+	c = checkDoctorSession(&mockSessionManager{available: true, sessions: []*session.Session{active}}, true, "other")
+	assert.Equal(t, "no active session", c.Summary)
+}
+
+func TestCheckDoctorAgents(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	creds := []*agent.Credential{
+		{ID: "agt_ok", WalletName: "main", ExpiresAt: now.Add(7 * 24 * time.Hour)},
+		{ID: "agt_soon", WalletName: "main", ExpiresAt: now.Add(2 * time.Hour)},
+		{ID: "agt_old", WalletName: "main", ExpiresAt: now.Add(-time.Hour)},
+	}
+
+	c := checkDoctorAgents(creds, nil, now)
+	assert.Equal(t, doctorYellow, c.Status)
+	assert.Contains(t, c.Summary, "1 expired, 1 expiring")
+	require.Len(t, c.Details, 2)
+	assert.Contains(t, c.Details[1], "sigil agent revoke --wallet main --id agt_old")
+
+	assert.Equal(t, doctorGreen, checkDoctorAgents(creds[:1], nil, now).Status)
+	assert.Equal(t, doctorGreen, checkDoctorAgents(nil, nil, now).Status)
+}
+
+func TestCheckDoctorBackups(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	writeBackup := func(t *testing.T, dir, file, walletName string, created time.Time) {
+		t.Helper()
+		bak := backup.NewBackup(backup.Manifest{WalletName: walletName, CreatedAt: created}, []byte("ciphertext"))
+		data, err := json.Marshal(bak)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), data, 0o600))
+	}
+
+	t.Run("none", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeBackup(t, dir, "other-1.sigil", "other", now)
+		c := checkDoctorBackups(backup.NewService(dir, nil), "main", now)
+		assert.Equal(t, doctorRed, c.Status)
+		assert.Contains(t, c.Summary, "sigil backup create --wallet main")
+	})
+
+	t.Run("recent", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeBackup(t, dir, "main-1.sigil", "main", now.Add(-60*24*time.Hour))
+		writeBackup(t, dir, "main-2.sigil", "main", now.Add(-24*time.Hour))
+		c := checkDoctorBackups(backup.NewService(dir, nil), "main", now)
+		assert.Equal(t, doctorGreen, c.Status)
+		assert.Contains(t, c.Summary, "2 backup(s), newest 24h old")
+	})
+
+	t.Run("old", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeBackup(t, dir, "main-1.sigil", "main", now.Add(-60*24*time.Hour))
+		assert.Equal(t, doctorYellow, checkDoctorBackups(backup.NewService(dir, nil), "main", now).Status)
+	})
+
+	t.Run("corrupt file", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeBackup(t, dir, "main-1.sigil", "main", now)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main-2.sigil"), []byte("{"), 0o600))
+		c := checkDoctorBackups(backup.NewService(dir, nil), "main", now)
+		assert.Equal(t, doctorYellow, c.Status)
+		require.Len(t, c.Details, 1)
+		assert.Contains(t, c.Details[0], "main-2.sigil failed verification")
+	})
+}
+
+func TestDisplayDoctorReportText(t *testing.T) {
+	t.Parallel()
+
+	report := &doctorReport{Wallet: "main"}
+	report.add(doctorCheck{Name: "backups", Status: doctorRed, Summary: "no backup found", Details: []string{"detail line"}})
+
+	var buf bytes.Buffer
+	displayDoctorReportText(&buf, report)
+	got := buf.String()
+	assert.Contains(t, got, "Wallet 'main' health: RED")
+	assert.Contains(t, got, "[RED]    backups    no backup found")
+	assert.Contains(t, got, "- detail line")
+}
@@ -0,0 +1,265 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/backup"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// exportEncrypted confirms the export is the encrypted bundle.
+	exportEncrypted bool
+	// exportOutput is the file the export bundle is written to.
+	exportOutput string
+	// exportWeakPasswordOK accepts an export passphrase that fails the strength check.
+	exportWeakPasswordOK bool
+	// importEncryptedFile is the export bundle a wallet is imported from.
+	importEncryptedFile string
+	// importWeakPasswordOK accepts a wallet password that fails the strength check.
+	importWeakPasswordOK bool
+)
+
+// walletExportCmd exports a wallet as an encrypted bundle.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var walletExportCmd = &cobra.Command{
+	Use:   "export <name>",
+	Short: "Export a wallet as an encrypted, portable bundle",
+	Long: `Export a wallet's seed and metadata as an encrypted JSON bundle to move
+it to another machine.
+
+The bundle is encrypted with a new export passphrase, not the wallet
+password, using age with scrypt key derivation. Give the passphrase to the
+receiving machine by a different route than the file. The mnemonic is never
+shown or written in plain text; --encrypted is required to make that
+explicit.
+
+The bundle is written to --output, which must not exist, or to stdout.
+Restore it with "sigil wallet import --encrypted-file". Watch-only wallets
+have no seed to export.`,
+	Example: `  sigil wallet export main --encrypted --output main.export.json
+  sigil wallet export main --encrypted > main.export.json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWalletExport,
+}
+
+// walletImportCmd imports a wallet from an encrypted export bundle.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var walletImportCmd = &cobra.Command{
+	Use:   "import [name]",
+	Short: "Import a wallet from an encrypted export bundle",
+	Long: `Import a wallet from a bundle made by "sigil wallet export --encrypted".
+
+You are asked for the export passphrase, then for a new password for the
+wallet file on this machine. The wallet keeps the name it was exported
+with unless a name is given. Addresses, labels, and wallet settings come
+from the bundle; run "sigil balance show" or "sigil utxo refresh" to fetch
+balances.`,
+	Example: `  sigil wallet import --encrypted-file main.export.json
+  sigil wallet import laptop-main --encrypted-file main.export.json`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWalletImport,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	walletCmd.AddCommand(walletExportCmd)
+	walletCmd.AddCommand(walletImportCmd)
+
+	walletExportCmd.Flags().BoolVar(&exportEncrypted, "encrypted", false, "export the seed encrypted with a new passphrase (required)")
+	walletExportCmd.Flags().StringVar(&exportOutput, "output", "", "write the bundle to this file instead of stdout")
+	walletExportCmd.Flags().BoolVar(&exportWeakPasswordOK, "weak-password-ok", false, "accept a weak or breached passphrase with a warning")
+
+	walletImportCmd.Flags().StringVar(&importEncryptedFile, "encrypted-file", "", "export bundle to import (required)")
+	walletImportCmd.Flags().BoolVar(&importWeakPasswordOK, "weak-password-ok", false, "accept a weak or breached password with a warning")
+	_ = walletImportCmd.MarkFlagRequired("encrypted-file")
+}
+
+func runWalletExport(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+	name := args[0]
+
+	if !exportEncrypted {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			"sigil only exports seeds encrypted; add --encrypted")
+	}
+	if exportOutput != "" {
+		if _, err := os.Stat(exportOutput); err == nil {
+			return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+				fmt.Sprintf("%s already exists; choose another --output", exportOutput))
+		}
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	exists, err := storage.Exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sigilerr.WithSuggestion(
+			wallet.ErrWalletNotFound,
+			fmt.Sprintf("wallet '%s' not found. List wallets with: sigil wallet list", name),
+		)
+	}
+
+	password, err := promptWalletPassword("Enter wallet password: ")
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(password)
+
+	wlt, seed, err := storage.Load(name, password)
+	if err != nil {
+		if errors.Is(err, wallet.ErrWatchOnly) {
+			return sigilerr.WithSuggestion(err,
+				fmt.Sprintf("'%s' is watch-only and has no seed; export the wallet that holds its keys", name))
+		}
+		return fmt.Errorf("loading wallet: %w", err)
+	}
+	defer wallet.ZeroBytes(seed)
+
+	outln(os.Stderr, "\nChoose a passphrase for the export. It is needed to import the wallet.")
+	passphrase, err := promptNewPasswordFn(newPasswordPolicy(cmd, exportWeakPasswordOK))
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(passphrase)
+
+	bundle, err := backup.NewExport(wlt, seed, passphrase)
+	if err != nil {
+		return fmt.Errorf("exporting wallet: %w", err)
+	}
+
+	if exportOutput == "" {
+		return writeJSON(cmd.OutOrStdout(), bundle)
+	}
+	if err := writeExportFile(exportOutput, bundle); err != nil {
+		return err
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]any{
+			"wallet":   bundle.WalletName,
+			"file":     exportOutput,
+			"chains":   bundle.Chains,
+			"checksum": bundle.Checksum,
+		})
+	}
+	outln(w, "Wallet exported successfully!")
+	outln(w)
+	out(w, "  File:     %s\n", exportOutput)
+	out(w, "  Wallet:   %s\n", bundle.WalletName)
+	out(w, "  Chains:   %v\n", bundle.Chains)
+	out(w, "  Checksum: %s\n", bundle.Checksum[:16]+"...")
+	outln(w)
+	outln(w, "Import it with: sigil wallet import --encrypted-file "+exportOutput)
+	return nil
+}
+
+// writeExportFile writes the bundle to a new owner-only file. An existing
+// file is never replaced.
+func writeExportFile(path string, bundle *backup.Export) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600) //nolint:gosec // Path is supplied by the user
+	if err != nil {
+		return fmt.Errorf("creating %s: %w", path, err)
+	}
+	if err := writeJSON(f, bundle); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing %s: %w", path, err)
+	}
+	return nil
+}
+
+func runWalletImport(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+
+	data, err := os.ReadFile(importEncryptedFile) //nolint:gosec // Path is supplied by the user
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("reading %s: %v", importEncryptedFile, err))
+	}
+	bundle, err := backup.ParseExport(data)
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("%s: %v; expected a file made by sigil wallet export --encrypted", importEncryptedFile, err))
+	}
+
+	name := bundle.WalletName
+	if len(args) > 0 {
+		name = args[0]
+	}
+	if err = wallet.ValidateWalletName(name); err != nil {
+		return sigilerr.WithSuggestion(err, "give the wallet a valid name: sigil wallet import <name> --encrypted-file "+importEncryptedFile)
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	exists, err := storage.Exists(name)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return sigilerr.WithSuggestion(
+			wallet.ErrWalletExists,
+			fmt.Sprintf("wallet '%s' already exists. Import under another name: sigil wallet import <name> --encrypted-file %s",
+				name, importEncryptedFile),
+		)
+	}
+
+	passphrase, err := promptPasswordFn("Enter export passphrase: ")
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(passphrase)
+
+	wlt, seed, err := bundle.Open(passphrase)
+	if err != nil {
+		if errors.Is(err, backup.ErrDecryptionFailed) {
+			return sigilerr.WithSuggestion(sigilerr.ErrAuthentication, "wrong export passphrase or corrupted export")
+		}
+		return fmt.Errorf("opening export: %w", err)
+	}
+	defer wallet.ZeroBytes(seed)
+	wlt.Name = name
+
+	outln(os.Stderr, "\nChoose a password for the wallet on this machine.")
+	password, err := promptNewPasswordFn(newPasswordPolicy(cmd, importWeakPasswordOK))
+	if err != nil {
+		return err
+	}
+	defer wallet.ZeroBytes(password)
+
+	if err := storage.Save(wlt, seed, password); err != nil {
+		return fmt.Errorf("saving imported wallet: %w", err)
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]any{
+			"wallet":      name,
+			"exported_as": bundle.WalletName,
+			"exported_at": bundle.CreatedAt,
+			"chains":      bundle.Chains,
+		})
+	}
+	outln(w, "Wallet imported successfully!")
+	outln(w)
+	out(w, "  Name:     %s\n", name)
+	out(w, "  Exported: %s\n", bundle.CreatedAt.Format("2006-01-02 15:04:05"))
+	out(w, "  Path:     %s\n", filepath.Join(cc.Cfg.GetHome(), "wallets", name+".wallet"))
+	outln(w)
+	outln(w, "Verify your addresses with: sigil wallet show "+name)
+	return nil
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/backup"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:paralleltest // Mutates package-level flags and prompt functions
+func TestRunWalletExportImport(t *testing.T) {
+	t.Cleanup(func() {
+		exportEncrypted, exportOutput, importEncryptedFile = false, "", ""
+	})
+
+	home := t.TempDir()
+	walletsDir := filepath.Join(home, "wallets")
+	createTestWallet(t, walletsDir, "main")
+	withMockPrompts(t, []byte("password"), true)
+
+	// --encrypted is required
+	cmd, _ := newBackupListTestCmd(home, output.FormatText)
+	err := runWalletExport(cmd, []string{"main"})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	exportEncrypted = true
+	exportOutput = filepath.Join(t.TempDir(), "main.export.json")
+	cmd, buf := newBackupListTestCmd(home, output.FormatText)
+	require.NoError(t, runWalletExport(cmd, []string{"main"}))
+	assert.Contains(t, buf.String(), "Wallet exported successfully")
+
+	info, err := os.Stat(exportOutput)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err := os.ReadFile(exportOutput)
+	require.NoError(t, err)
+	bundle, err := backup.ParseExport(data)
+	require.NoError(t, err)
+	assert.Equal(t, "main", bundle.WalletName)
+
+	// An existing file is never replaced
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	err = runWalletExport(cmd, []string{"main"})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	// Importing under the original name collides
+	importEncryptedFile = exportOutput
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	err = runWalletImport(cmd, nil)
+	require.ErrorIs(t, err, wallet.ErrWalletExists)
+
+	cmd, buf = newBackupListTestCmd(home, output.FormatJSON)
+	require.NoError(t, runWalletImport(cmd, []string{"copy"}))
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "copy", result["wallet"])
+	assert.Equal(t, "main", result["exported_as"])
+
+	storage := wallet.NewFileStorage(walletsDir)
+	orig, origSeed, err := storage.Load("main", []byte("password"))
+	require.NoError(t, err)
+	imported, importedSeed, err := storage.Load("copy", []byte("password"))
+	require.NoError(t, err)
+	assert.Equal(t, origSeed, importedSeed)
+	assert.Equal(t, orig.Addresses, imported.Addresses)
+	assert.Equal(t, "copy", imported.Name)
+}
+
+//nolint:paralleltest // Mutates package-level flags and prompt functions
+func TestRunWalletImport_Errors(t *testing.T) {
+	t.Cleanup(func() {
+		exportEncrypted, exportOutput, importEncryptedFile = false, "", ""
+	})
+
+	home := t.TempDir()
+	createTestWallet(t, filepath.Join(home, "wallets"), "main")
+	withMockPrompts(t, []byte("password"), true)
+
+	exportEncrypted = true
+	exportOutput = filepath.Join(t.TempDir(), "main.export.json")
+	cmd, _ := newBackupListTestCmd(home, output.FormatText)
+	require.NoError(t, runWalletExport(cmd, []string{"main"}))
+
+	// Not an export bundle
+	importEncryptedFile = filepath.Join(t.TempDir(), "other.json")
+	require.NoError(t, os.WriteFile(importEncryptedFile, []byte(`{"version":1}`), 0o600))
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	err := runWalletImport(cmd, []string{"copy"})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	// Wrong passphrase
+	importEncryptedFile = exportOutput
+	promptPasswordFn = func(_ string) ([]byte, error) { return []byte("wrong passphrase"), nil }
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	err = runWalletImport(cmd, []string{"copy"})
+	require.ErrorIs(t, err, sigilerr.ErrAuthentication)
+	exists, err := wallet.NewFileStorage(filepath.Join(home, "wallets")).Exists("copy")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
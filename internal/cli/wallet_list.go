@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/mrz1836/sigil/internal/metrics"
 	"github.com/mrz1836/sigil/internal/output"
 	walletservice "github.com/mrz1836/sigil/internal/service/wallet"
 	"github.com/mrz1836/sigil/internal/wallet"
@@ -69,7 +70,7 @@ func runWalletShow(cmd *cobra.Command, args []string) error {
 	storage := wallet.NewFileStorage(filepath.Join(ctx.Cfg.GetHome(), "wallets"))
 
 	// Load wallet (using session if available)
-	w, seed, err := loadWalletWithSession(name, storage, cmd)
+	w, seed, err := loadWalletForRead(name, storage, cmd)
 	if err != nil {
 		return err
 	}
@@ -90,7 +91,14 @@ func displayWalletText(wlt *wallet.Wallet, cmd *cobra.Command) {
 	w := cmd.OutOrStdout()
 	out(w, "Wallet: %s\n", wlt.Name)
 	out(w, "Created: %s\n", wlt.CreatedAt.Format("2006-01-02 15:04:05"))
-	out(w, "Version: %d\n", wlt.Version)
+	if wlt.IsNewerVersion() {
+		out(w, "Version: %d (newer than this sigil supports; read-only until you run 'sigil upgrade')\n", wlt.Version)
+	} else {
+		out(w, "Version: %d\n", wlt.Version)
+	}
+	if wlt.IsWatchOnly() {
+		out(w, "Type: %s (signing disabled)\n", wlt.Type)
+	}
 	outln(w)
 	outln(w, "Addresses:")
 	for chainID, addresses := range wlt.Addresses {
@@ -113,6 +121,8 @@ func displayWalletJSON(wlt *wallet.Wallet, cmd *cobra.Command) {
 		Name      string                   `json:"name"`
 		CreatedAt string                   `json:"created_at"`
 		Version   int                      `json:"version"`
+		ReadOnly  bool                     `json:"read_only,omitempty"`
+		Type      string                   `json:"type,omitempty"`
 		Addresses map[string][]addressJSON `json:"addresses"`
 	}
 
@@ -120,6 +130,8 @@ func displayWalletJSON(wlt *wallet.Wallet, cmd *cobra.Command) {
 		Name:      wlt.Name,
 		CreatedAt: wlt.CreatedAt.Format(time.RFC3339),
 		Version:   wlt.Version,
+		ReadOnly:  wlt.IsNewerVersion(),
+		Type:      wlt.Type,
 		Addresses: make(map[string][]addressJSON, len(wlt.Addresses)),
 	}
 	for chainID, addresses := range wlt.Addresses {
@@ -141,7 +153,21 @@ func displayWalletJSON(wlt *wallet.Wallet, cmd *cobra.Command) {
 // Uses cached session if available, otherwise prompts for password.
 // When SIGIL_AGENT_TOKEN is set, uses agent token authentication instead.
 // When SIGIL_AGENT_XPUB is set, uses xpub read-only mode (no seed).
+// A watch-only wallet is refused with ErrWalletWatchOnly.
 func loadWalletWithSession(name string, storage *wallet.FileStorage, cmd *cobra.Command) (*wallet.Wallet, []byte, error) {
+	return loadWallet(name, storage, cmd, false)
+}
+
+// loadWalletForRead loads a wallet like loadWalletWithSession but also
+// accepts a watch-only wallet, which it returns with a nil seed. Use it for
+// commands that never sign.
+func loadWalletForRead(name string, storage *wallet.FileStorage, cmd *cobra.Command) (*wallet.Wallet, []byte, error) {
+	return loadWallet(name, storage, cmd, true)
+}
+
+// loadWallet loads a wallet through the wallet service, accepting a
+// watch-only wallet when allowWatchOnly is set.
+func loadWallet(name string, storage *wallet.FileStorage, cmd *cobra.Command, allowWatchOnly bool) (*wallet.Wallet, []byte, error) {
 	ctx := GetCmdContext(cmd)
 
 	// Create wallet service
@@ -171,20 +197,38 @@ func loadWalletWithSession(name string, storage *wallet.FileStorage, cmd *cobra.
 		},
 	}
 
-	// Load wallet
+	// Load wallet. Time spent waiting on the password prompt is excluded
+	// from the recorded phase so --timings reflects decryption cost only.
+	start := time.Now()
+	var promptWait time.Duration
 	result, _, err := walletService.Load(&walletservice.LoadRequest{
-		Name: name,
+		Name:           name,
+		AllowWatchOnly: allowWatchOnly,
 		PasswordFunc: func(prompt string) (string, error) {
-			pwd, pwdErr := promptPasswordFn(prompt)
+			promptStart := time.Now()
+			pwd, pwdErr := promptWalletPassword(prompt)
+			promptWait += time.Since(promptStart)
 			if pwdErr != nil {
 				return "", pwdErr
 			}
 			return string(pwd), nil
 		},
+		PassphraseFunc: func(prompt string) (string, error) {
+			promptStart := time.Now()
+			passphrase, passErr := promptPasswordFn(prompt)
+			promptWait += time.Since(promptStart)
+			if passErr != nil {
+				return "", passErr
+			}
+			return string(passphrase), nil
+		},
 	}, loadCtx)
+	metrics.Global.RecordPhase(metrics.PhaseWalletLoad, time.Since(start)-promptWait)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	// Clients and caches created from here on serve the wallet's network
+	useWalletNetwork(cmd, result.Wallet)
 	return result.Wallet, result.Seed, nil
 }
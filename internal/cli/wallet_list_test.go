@@ -161,6 +161,32 @@ func TestDisplayWalletJSONMultipleChains(t *testing.T) {
 	require.Len(t, parsed.Addresses["eth"], 1)
 }
 
+// TestDisplayWalletNewerVersion tests that a wallet from a newer sigil is marked read-only.
+func TestDisplayWalletNewerVersion(t *testing.T) {
+	t.Parallel()
+
+	wlt := &wallet.Wallet{
+		Name:      "future",
+		CreatedAt: time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC),
+		Version:   wallet.CurrentVersion + 1,
+	}
+
+	cmd := &cobra.Command{}
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+
+	displayWalletText(wlt, cmd)
+	assert.Contains(t, buf.String(), "newer than this sigil supports; read-only until you run 'sigil upgrade'")
+
+	buf.Reset()
+	displayWalletJSON(wlt, cmd)
+	var parsed struct {
+		ReadOnly bool `json:"read_only"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &parsed))
+	assert.True(t, parsed.ReadOnly)
+}
+
 // --- Tests for runWalletList ---
 
 // newWalletListTestCmd creates a cobra.Command with CommandContext for runWalletList testing.
@@ -0,0 +1,20 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// lockWallet takes the wallet's advisory lock for an operation that reads
+// wallet state and writes it back, waiting up to wallet.DefaultLockTimeout for
+// another sigil process to finish. The caller must release the lock.
+func lockWallet(cmd *cobra.Command, storage *wallet.FileStorage, name, operation string) (*wallet.Lock, error) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return storage.Lock(ctx, name, operation, wallet.DefaultLockTimeout)
+}
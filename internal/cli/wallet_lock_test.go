@@ -0,0 +1,37 @@
+package cli
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+func TestLockWallet_BusyFromOtherOperation(t *testing.T) {
+	t.Parallel()
+
+	storage := wallet.NewFileStorage(t.TempDir())
+	held, err := storage.Lock(context.Background(), "main", "utxo refresh", time.Second)
+	require.NoError(t, err)
+	defer func() { _ = held.Release() }()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	cmd := &cobra.Command{}
+	cmd.SetContext(ctx)
+
+	_, err = lockWallet(cmd, storage, "main", "tx send")
+	require.ErrorIs(t, err, wallet.ErrWalletBusy)
+}
+
+func TestLockWallet_NoCommandContext(t *testing.T) {
+	t.Parallel()
+
+	lock, err := lockWallet(&cobra.Command{}, wallet.NewFileStorage(t.TempDir()), "main", "tx send")
+	require.NoError(t, err)
+	require.NoError(t, lock.Release())
+}
@@ -28,21 +28,37 @@ func runWalletRestore(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Get and process seed material
-	seed, err := getSeedForRestore(cmd)
-	if err != nil {
-		return err
+	// Get and process seed material. A --passphrase-prompt wallet stores its
+	// mnemonic rather than the seed.
+	var seed, secret []byte
+	if restorePassphrasePrompt {
+		mnemonic, err := getMnemonicForRestore(cmd)
+		if err != nil {
+			return err
+		}
+		if seed, err = passphrasePromptSeed(mnemonic); err != nil {
+			return err
+		}
+		secret = []byte(mnemonic)
+		defer wallet.ZeroBytes(secret)
+	} else {
+		var err error
+		if seed, err = getSeedForRestore(cmd); err != nil {
+			return err
+		}
+		secret = seed
 	}
 	defer wallet.ZeroBytes(seed)
 
 	// Create wallet with derived addresses, stamped with the effective global network.
-	w, err := createWalletWithAddresses(name, seed, bsvNetworkForCmd(cmd))
+	w, err := createWalletWithAddresses(name, seed, bsvNetworkForCmd(cmd), walletChainsForCmd(cmd))
 	if err != nil {
 		return err
 	}
+	w.PassphrasePrompt = restorePassphrasePrompt
 
 	// Get user confirmation and save
-	if err := confirmAndSaveWallet(w, seed, storage, cmd); err != nil {
+	if err := confirmAndSaveWallet(w, secret, storage, cmd); err != nil {
 		return err
 	}
 
@@ -93,8 +109,49 @@ func getSeedForRestore(cmd *cobra.Command) ([]byte, error) {
 	return processSeedInput(input, restorePassphrase, cmd)
 }
 
+// getMnemonicForRestore gets a mnemonic for a --passphrase-prompt restore,
+// from Shamir shares, the --input flag, or an interactive prompt.
+func getMnemonicForRestore(cmd *cobra.Command) (string, error) {
+	var input string
+	var err error
+	switch {
+	case restoreShamir:
+		input, err = combineShamirShares(cmd)
+	case restoreInput != "":
+		input = restoreInput
+	default:
+		input, err = promptSeedFn()
+	}
+	if err != nil {
+		return "", err
+	}
+
+	if wallet.DetectInputFormat(input) != wallet.FormatMnemonic {
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"--passphrase-prompt needs a mnemonic; a WIF or hex key has no BIP39 passphrase",
+		)
+	}
+	if err := validateRestoreMnemonic(input, cmd); err != nil {
+		return "", err
+	}
+	return input, nil
+}
+
 // processShamirRestore handles the interactive collection and combination of Shamir shares.
 func processShamirRestore(cmd *cobra.Command) ([]byte, error) {
+	mnemonic, err := combineShamirShares(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Treat the combined secret as the mnemonic
+	return processMnemonicInput(mnemonic, restorePassphrase, cmd)
+}
+
+// combineShamirShares reads Shamir shares interactively and returns the
+// combined mnemonic.
+func combineShamirShares(cmd *cobra.Command) (string, error) {
 	outln(cmd.OutOrStdout(), "Enter your Shamir shares one by one.")
 	outln(cmd.OutOrStdout(), "Press Enter on an empty line when finished.")
 	outln(cmd.OutOrStdout())
@@ -115,26 +172,25 @@ func processShamirRestore(cmd *cobra.Command) ([]byte, error) {
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading input: %w", err)
+		return "", fmt.Errorf("error reading input: %w", err)
 	}
 
 	if len(shares) < 2 {
-		return nil, ErrMinSharesRequired
+		return "", ErrMinSharesRequired
 	}
 
 	mnemonicBytes, err := shamir.Combine(shares)
 	if err != nil {
-		return nil, fmt.Errorf("failed to combine shares: %w", err)
+		return "", fmt.Errorf("failed to combine shares: %w", err)
 	}
-
-	// Treat the combined secret as the mnemonic
-	return processMnemonicInput(string(mnemonicBytes), restorePassphrase, cmd)
+	return string(mnemonicBytes), nil
 }
 
 // createWalletWithAddresses creates a new wallet and derives addresses.
-// network stamps the wallet's BSV network ("main"/"test") before deriving.
-func createWalletWithAddresses(name string, seed []byte, network string) (*wallet.Wallet, error) {
-	w, err := wallet.NewWallet(name, []wallet.ChainID{wallet.ChainETH, wallet.ChainBSV})
+// network stamps the wallet's BSV network ("main"/"test") before deriving;
+// chains are the wallet's enabled chains, nil meaning ETH and BSV.
+func createWalletWithAddresses(name string, seed []byte, network string, chains []wallet.ChainID) (*wallet.Wallet, error) {
+	w, err := wallet.NewWallet(name, chains)
 	if err != nil {
 		return nil, err
 	}
@@ -157,7 +213,7 @@ func confirmAndSaveWallet(w *wallet.Wallet, seed []byte, storage *wallet.FileSto
 		return nil
 	}
 
-	password, promptErr := promptNewPasswordFn()
+	password, promptErr := promptNewPasswordFn(newPasswordPolicy(cmd, restoreWeakPasswordOK))
 	if promptErr != nil {
 		return promptErr
 	}
@@ -170,6 +226,9 @@ func confirmAndSaveWallet(w *wallet.Wallet, seed []byte, storage *wallet.FileSto
 	outln(cmd.OutOrStdout())
 	out(cmd.OutOrStdout(), "Wallet '%s' restored successfully.\n", w.Name)
 	outln(cmd.OutOrStdout(), "Wallet file: "+filepath.Join(ctx.Cfg.GetHome(), "wallets", w.Name+".wallet"))
+	if w.PassphrasePrompt {
+		outln(cmd.OutOrStdout(), "The BIP39 passphrase is not stored; you will be asked for it after the password on every unlock.")
+	}
 
 	return nil
 }
@@ -200,15 +259,8 @@ func processSeedInput(input string, usePassphrase bool, cmd *cobra.Command) ([]b
 
 // processMnemonicInput validates and converts a mnemonic to seed.
 func processMnemonicInput(mnemonic string, usePassphrase bool, cmd *cobra.Command) ([]byte, error) {
-	// Check for and display typos
-	displayDetectedTypos(mnemonic, cmd)
-
-	// Validate mnemonic
-	if err := wallet.ValidateMnemonic(mnemonic); err != nil {
-		return nil, sigilerr.WithSuggestion(
-			err,
-			"the mnemonic phrase is not valid. Check for typos or missing words.",
-		)
+	if err := validateRestoreMnemonic(mnemonic, cmd); err != nil {
+		return nil, err
 	}
 
 	// Get passphrase if requested
@@ -221,6 +273,25 @@ func processMnemonicInput(mnemonic string, usePassphrase bool, cmd *cobra.Comman
 	return wallet.MnemonicToSeed(mnemonic, passphrase)
 }
 
+// validateRestoreMnemonic shows likely typos, validates the mnemonic, and
+// notes a non-English wordlist.
+func validateRestoreMnemonic(mnemonic string, cmd *cobra.Command) error {
+	// Check for and display typos
+	displayDetectedTypos(mnemonic, cmd)
+
+	// Validate mnemonic
+	if err := wallet.ValidateMnemonic(mnemonic); err != nil {
+		return sigilerr.WithSuggestion(
+			err,
+			"the mnemonic phrase is not valid. Check for typos or missing words.",
+		)
+	}
+	if lang, err := wallet.DetectLanguage(mnemonic); err == nil && lang != wallet.LanguageEnglish {
+		out(cmd.OutOrStdout(), "Detected %s wordlist.\n", lang)
+	}
+	return nil
+}
+
 // displayDetectedTypos shows any typos found in the mnemonic.
 func displayDetectedTypos(mnemonic string, cmd *cobra.Command) {
 	typos := wallet.DetectTypos(mnemonic)
@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	"github.com/mrz1836/sigil/internal/wallettemplate"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// walletTemplatesCmd lists the templates wallet create --template accepts.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires global command variables
+var walletTemplatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List wallet templates",
+	Long: `List the templates accepted by "sigil wallet create --template".
+
+Templates predefine a new wallet's chains, number of receive addresses,
+address labels, mnemonic length, default fee settings, and confirmation code
+threshold. sigil ships the savings, spending, and merchant templates; more can
+be defined under wallet_templates in config.yaml, and a configured template
+with a built-in name replaces the built-in one.`,
+	Example: `  sigil wallet templates
+  sigil wallet templates -o json`,
+	Args: cobra.NoArgs,
+	RunE: runWalletTemplates,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	walletCmd.AddCommand(walletTemplatesCmd)
+}
+
+// walletTemplateJSON is the JSON form of a template in wallet templates.
+type walletTemplateJSON struct {
+	Name                string   `json:"name"`
+	Source              string   `json:"source"`
+	Description         string   `json:"description,omitempty"`
+	Chains              []string `json:"chains"`
+	Addresses           int      `json:"addresses"`
+	Labels              []string `json:"labels,omitempty"`
+	Words               int      `json:"words"`
+	Passphrase          bool     `json:"passphrase"`
+	BSVFeeStrategy      string   `json:"bsv_fee_strategy,omitempty"`
+	ETHGasSpeed         string   `json:"eth_gas_speed,omitempty"`
+	RequireConfirmAbove float64  `json:"require_confirm_above,omitempty"`
+}
+
+func runWalletTemplates(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+
+	templates, err := wallettemplate.All(cc.Cfg.GetWalletTemplates())
+	if err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrConfigInvalid,
+			fmt.Sprintf("%v; fix wallet_templates in config.yaml", err))
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		payload := make([]walletTemplateJSON, len(templates))
+		for i := range templates {
+			t := &templates[i]
+			payload[i] = walletTemplateJSON{
+				Name:                t.Name,
+				Source:              string(t.Source),
+				Description:         t.Description,
+				Chains:              templateChainNames(t, cc.Cfg),
+				Addresses:           t.Addresses,
+				Labels:              t.Labels,
+				Words:               t.Words,
+				Passphrase:          t.Passphrase,
+				BSVFeeStrategy:      t.BSVFeeStrategy,
+				ETHGasSpeed:         t.ETHGasSpeed,
+				RequireConfirmAbove: t.RequireConfirmAbove,
+			}
+		}
+		return writeJSON(w, payload)
+	}
+
+	out(w, "%-12s %-9s %-9s %-9s %-5s %s\n", "NAME", "SOURCE", "CHAINS", "ADDRESSES", "WORDS", "DESCRIPTION")
+	for i := range templates {
+		t := &templates[i]
+		out(w, "%-12s %-9s %-9s %-9d %-5d %s\n",
+			t.Name, t.Source, strings.Join(templateChainNames(t, cc.Cfg), ","), t.Addresses, t.Words, t.Description)
+	}
+	return nil
+}
+
+// templateChainNames returns the chains a wallet created from t enables.
+func templateChainNames(t *wallettemplate.Template, cfg ConfigProvider) []string {
+	chains := t.Chains
+	if len(chains) == 0 {
+		chains = enabledChains(cfg)
+	}
+	names := make([]string, len(chains))
+	for i, id := range chains {
+		names[i] = string(id)
+	}
+	return names
+}
+
+// lookupWalletTemplate resolves a --template name, listing the available
+// templates when it is unknown.
+func lookupWalletTemplate(cfg ConfigProvider, name string) (*wallettemplate.Template, error) {
+	configured := cfg.GetWalletTemplates()
+	tmpl, err := wallettemplate.Lookup(configured, name)
+	switch {
+	case errors.Is(err, wallettemplate.ErrUnknownTemplate):
+		all, _ := wallettemplate.All(configured)
+		names := make([]string, len(all))
+		for i := range all {
+			names[i] = all[i].Name
+		}
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("unknown wallet template %q (available: %s)", name, strings.Join(names, ", ")))
+	case err != nil:
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrConfigInvalid,
+			fmt.Sprintf("%v; fix wallet_templates in config.yaml", err))
+	}
+	return tmpl, nil
+}
+
+// applyWalletTemplate layers tmpl over spec, words, and usePassphrase.
+// Flags the user set explicitly win over the template, and a template cannot
+// enable a chain the configuration disables.
+func applyWalletTemplate(cmd *cobra.Command, tmpl *wallettemplate.Template, spec walletSpec, words *int, usePassphrase *bool) (walletSpec, error) {
+	cc := GetCmdContext(cmd)
+
+	if !cmd.Flags().Changed("words") {
+		*words = tmpl.Words
+	}
+	*usePassphrase = *usePassphrase || tmpl.Passphrase
+
+	if len(tmpl.Chains) > 0 {
+		chains := make([]wallet.ChainID, 0, len(tmpl.Chains))
+		for _, id := range tmpl.Chains {
+			enabled, err := parseEnabledChain(cc.Cfg, string(id))
+			if err != nil {
+				return spec, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+					fmt.Sprintf("template %s uses chain %s, which is not enabled", tmpl.Name, id))
+			}
+			chains = append(chains, enabled)
+		}
+		spec.chains = chains
+	}
+
+	spec.addresses = tmpl.Addresses
+	spec.settings = &wallet.Settings{
+		Template:            tmpl.Name,
+		BSVFeeStrategy:      tmpl.BSVFeeStrategy,
+		ETHGasSpeed:         tmpl.ETHGasSpeed,
+		RequireConfirmAbove: tmpl.RequireConfirmAbove,
+	}
+	return spec, nil
+}
+
+// labelTemplateAddresses registers the first receive addresses of each chain
+// in the wallet's UTXO store under labels, in order.
+func labelTemplateAddresses(walletPath string, w *wallet.Wallet, labels []string) error {
+	store := utxostore.New(walletPath)
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("loading UTXO store: %w", err)
+	}
+
+	for _, chainID := range w.EnabledChains {
+		addresses := w.Addresses[chainID]
+		for i, label := range labels {
+			if i >= len(addresses) {
+				break
+			}
+			addr := addresses[i]
+			store.AddAddress(&utxostore.AddressMetadata{
+				Address:        addr.Address,
+				ChainID:        chainID,
+				DerivationPath: addr.Path,
+				Index:          addr.Index,
+				Label:          label,
+			})
+		}
+	}
+
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("saving UTXO store: %w", err)
+	}
+	return nil
+}
+
+// walletSettingsConfig overlays a wallet's own settings on the configuration.
+type walletSettingsConfig struct {
+	ConfigProvider
+
+	settings *wallet.Settings
+}
+
+// GetBSVFeeStrategy returns the wallet's fee strategy unless
+// SIGIL_BSV_FEE_STRATEGY overrides it for this run.
+func (c *walletSettingsConfig) GetBSVFeeStrategy() string {
+	if c.settings.BSVFeeStrategy == "" || os.Getenv(config.EnvBSVFeeStrategy) != "" {
+		return c.ConfigProvider.GetBSVFeeStrategy()
+	}
+	return c.settings.BSVFeeStrategy
+}
+
+// GetSecurity applies the wallet's confirmation code threshold when it is
+// stricter than the configured one.
+func (c *walletSettingsConfig) GetSecurity() config.SecurityConfig {
+	sec := c.ConfigProvider.GetSecurity()
+	limit := c.settings.RequireConfirmAbove
+	if limit > 0 && (sec.RequireConfirmAbove <= 0 || limit < sec.RequireConfirmAbove) {
+		sec.RequireConfirmAbove = limit
+	}
+	return sec
+}
+
+// applyWalletSettings makes the rest of a send use the loaded wallet's own
+// fee and confirmation settings. An explicit --gas flag still wins.
+func applyWalletSettings(cmd *cobra.Command, wlt *wallet.Wallet) {
+	s := wlt.Settings
+	if s == nil {
+		return
+	}
+
+	if s.ETHGasSpeed != "" && !cmd.Flags().Changed("gas") {
+		txGasSpeed = s.ETHGasSpeed
+	}
+
+	if s.BSVFeeStrategy == "" && s.RequireConfirmAbove == 0 {
+		return
+	}
+	cc := *GetCmdContext(cmd)
+	cc.Cfg = &walletSettingsConfig{ConfigProvider: cc.Cfg, settings: s}
+	SetCmdContext(cmd, &cc)
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	"github.com/mrz1836/sigil/internal/wallettemplate"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newTemplateTestCmd returns a command carrying the wallet create flags.
+func newTemplateTestCmd(cfg *mockConfigProvider, format output.Format) (*cobra.Command, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.Flags().Int("words", 12, "")
+	cmd.Flags().String("gas", "medium", "")
+	SetCmdContext(cmd, &CommandContext{Cfg: cfg, Fmt: &mockFormatProvider{format: format}})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	return cmd, &buf
+}
+
+func TestLookupWalletTemplate(t *testing.T) {
+	t.Parallel()
+
+	cfg := &mockConfigProvider{walletTemplates: map[string]config.WalletTemplateConfig{
+		"payroll": {Chains: []string{"eth"}, Addresses: 3},
+	}}
+
+	tmpl, err := lookupWalletTemplate(cfg, "Payroll")
+	require.NoError(t, err)
+	assert.Equal(t, "payroll", tmpl.Name)
+
+	_, err = lookupWalletTemplate(cfg, "nope")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "merchant, payroll, savings, spending")
+
+	cfg.walletTemplates["payroll"] = config.WalletTemplateConfig{Words: 18}
+	_, err = lookupWalletTemplate(cfg, "payroll")
+	require.ErrorIs(t, err, sigilerr.ErrConfigInvalid)
+}
+
+func TestApplyWalletTemplate(t *testing.T) {
+	t.Parallel()
+
+	savings, err := wallettemplate.Lookup(nil, "savings")
+	require.NoError(t, err)
+
+	t.Run("template fills unset flags", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTemplateTestCmd(&mockConfigProvider{}, output.FormatText)
+
+		words, passphrase := 12, false
+		spec, err := applyWalletTemplate(cmd, savings, walletSpec{network: "main"}, &words, &passphrase)
+		require.NoError(t, err)
+		assert.Equal(t, 24, words)
+		assert.False(t, passphrase)
+		assert.Equal(t, "main", spec.network)
+		assert.Equal(t, []wallet.ChainID{chain.BSV, chain.ETH}, spec.chains)
+		assert.Equal(t, 1, spec.addresses)
+		require.NotNil(t, spec.settings)
+		assert.Equal(t, "savings", spec.settings.Template)
+		assert.Equal(t, "economy", spec.settings.BSVFeeStrategy)
+		assert.InDelta(t, 100, spec.settings.RequireConfirmAbove, 0)
+	})
+
+	t.Run("explicit flags win", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTemplateTestCmd(&mockConfigProvider{}, output.FormatText)
+		require.NoError(t, cmd.Flags().Set("words", "12"))
+
+		words, passphrase := 12, true
+		_, err := applyWalletTemplate(cmd, savings, walletSpec{}, &words, &passphrase)
+		require.NoError(t, err)
+		assert.Equal(t, 12, words)
+		assert.True(t, passphrase)
+	})
+
+	t.Run("disabled chain", func(t *testing.T) {
+		t.Parallel()
+		cmd, _ := newTemplateTestCmd(&mockConfigProvider{}, output.FormatText)
+		tmpl := &wallettemplate.Template{Name: "btc", Chains: []chain.ID{chain.BTC}, Addresses: 1, Words: 12}
+
+		words, passphrase := 12, false
+		_, err := applyWalletTemplate(cmd, tmpl, walletSpec{}, &words, &passphrase)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	})
+}
+
+func TestCreateAndSaveWallet_Template(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+	withMockPrompts(t, []byte("testpassword123"), true)
+
+	storage := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets"))
+	mnemonic, err := wallet.GenerateMnemonic(12)
+	require.NoError(t, err)
+	seed, err := wallet.MnemonicToSeed(mnemonic, "")
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(seed)
+
+	spec := walletSpec{
+		network:   "main",
+		chains:    []wallet.ChainID{wallet.ChainBSV},
+		addresses: 3,
+		settings:  &wallet.Settings{Template: "merchant", BSVFeeStrategy: "priority"},
+	}
+	w, err := createAndSaveWallet("tmpl_test", seed, storage, spec, passwordPolicy{})
+	require.NoError(t, err)
+	assert.Len(t, w.Addresses[wallet.ChainBSV], 3)
+	assert.Empty(t, w.Addresses[wallet.ChainETH])
+
+	loaded, loadedSeed, err := storage.Load("tmpl_test", []byte("testpassword123"))
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(loadedSeed)
+	require.NotNil(t, loaded.Settings)
+	assert.Equal(t, "priority", loaded.Settings.BSVFeeStrategy)
+
+	walletPath := filepath.Join(tmpDir, "wallets", "tmpl_test")
+	require.NoError(t, labelTemplateAddresses(walletPath, w, []string{"till-1", "till-2"}))
+
+	store := utxostore.New(walletPath)
+	require.NoError(t, store.Load())
+	addrs := w.Addresses[wallet.ChainBSV]
+	assert.Equal(t, "till-1", store.GetAddress(chain.BSV, addrs[0].Address).Label)
+	assert.Equal(t, "till-2", store.GetAddress(chain.BSV, addrs[1].Address).Label)
+	assert.Nil(t, store.GetAddress(chain.BSV, addrs[2].Address))
+}
+
+func TestRunWalletTemplates(t *testing.T) {
+	t.Parallel()
+
+	cfg := &mockConfigProvider{walletTemplates: map[string]config.WalletTemplateConfig{
+		"payroll": {Description: "Monthly payroll", Chains: []string{"eth"}, Addresses: 10},
+	}}
+
+	t.Run("text", func(t *testing.T) {
+		t.Parallel()
+		cmd, buf := newTemplateTestCmd(cfg, output.FormatText)
+		require.NoError(t, runWalletTemplates(cmd, nil))
+		assert.Contains(t, buf.String(), "NAME")
+		assert.Contains(t, buf.String(), "payroll")
+		assert.Contains(t, buf.String(), "Monthly payroll")
+		assert.Contains(t, buf.String(), "savings")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+		cmd, buf := newTemplateTestCmd(cfg, output.FormatJSON)
+		require.NoError(t, runWalletTemplates(cmd, nil))
+
+		var got []walletTemplateJSON
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+		require.Len(t, got, 4)
+		assert.Equal(t, "payroll", got[1].Name)
+		assert.Equal(t, "config", got[1].Source)
+		assert.Equal(t, []string{"eth"}, got[1].Chains)
+		assert.Equal(t, 10, got[1].Addresses)
+	})
+}
+
+func TestWalletSettingsConfig(t *testing.T) {
+	t.Parallel()
+
+	base := &mockConfigProvider{
+		bsvFeeStrategy: "normal",
+		security:       config.SecurityConfig{RequireConfirmAbove: 500},
+	}
+
+	cfg := &walletSettingsConfig{ConfigProvider: base, settings: &wallet.Settings{BSVFeeStrategy: "economy", RequireConfirmAbove: 100}}
+	assert.Equal(t, "economy", cfg.GetBSVFeeStrategy())
+	assert.InDelta(t, 100, cfg.GetSecurity().RequireConfirmAbove, 0)
+
+	cfg.settings = &wallet.Settings{RequireConfirmAbove: 1000}
+	assert.Equal(t, "normal", cfg.GetBSVFeeStrategy())
+	assert.InDelta(t, 500, cfg.GetSecurity().RequireConfirmAbove, 0, "the stricter threshold applies")
+}
+
+func TestApplyWalletSettings(t *testing.T) {
+	origGas := txGasSpeed
+	t.Cleanup(func() { txGasSpeed = origGas })
+
+	base := &mockConfigProvider{bsvFeeStrategy: "normal"}
+	cmd, _ := newTemplateTestCmd(base, output.FormatText)
+	txGasSpeed = "medium"
+
+	applyWalletSettings(cmd, &wallet.Wallet{})
+	assert.Same(t, base, GetCmdContext(cmd).Cfg)
+
+	applyWalletSettings(cmd, &wallet.Wallet{Settings: &wallet.Settings{BSVFeeStrategy: "priority", ETHGasSpeed: "slow"}})
+	assert.Equal(t, "slow", txGasSpeed)
+	assert.Equal(t, "priority", GetCmdContext(cmd).Cfg.GetBSVFeeStrategy())
+
+	require.NoError(t, cmd.Flags().Set("gas", "fast"))
+	txGasSpeed = "fast"
+	applyWalletSettings(cmd, &wallet.Wallet{Settings: &wallet.Settings{ETHGasSpeed: "slow"}})
+	assert.Equal(t, "fast", txGasSpeed, "an explicit --gas wins")
+}
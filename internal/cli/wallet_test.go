@@ -167,7 +167,7 @@ func TestWalletCreateDuplicatePrevention(t *testing.T) {
 // TestGenerateWalletSeed tests mnemonic and seed generation.
 func TestGenerateWalletSeed(t *testing.T) {
 	// Test 12 word generation
-	mnemonic, seed, err := generateWalletSeed(12, false)
+	mnemonic, seed, err := generateWalletSeed(12, wallet.LanguageEnglish, false, false)
 	require.NoError(t, err)
 	defer wallet.ZeroBytes(seed)
 
@@ -179,7 +179,7 @@ func TestGenerateWalletSeed(t *testing.T) {
 	require.NoError(t, wallet.ValidateMnemonic(mnemonic))
 
 	// Test 24 word generation
-	mnemonic24, seed24, err := generateWalletSeed(24, false)
+	mnemonic24, seed24, err := generateWalletSeed(24, wallet.LanguageEnglish, false, false)
 	require.NoError(t, err)
 	defer wallet.ZeroBytes(seed24)
 
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// unlockPassphrasePrompt requires the wallet to be a --passphrase-prompt wallet.
+	unlockPassphrasePrompt bool
+)
+
+// walletUnlockCmd unlocks a wallet and caches it in a session.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var walletUnlockCmd = &cobra.Command{
+	Use:   "unlock <name>",
+	Short: "Unlock a wallet and start a session",
+	Long: `Unlock a wallet with its password and start a session, so the commands
+that follow do not ask again until the session expires. Any session already
+open for the wallet is ended first, so the password is always checked and
+the session gets its full lifetime.
+
+A wallet created or restored with --passphrase-prompt is hidden behind a
+BIP39 passphrase that is never stored. After the password, unlock asks for
+the passphrase, derives the seed, and checks the addresses it derives
+against the wallet's. A wrong passphrase fails with PASSPHRASE_MISMATCH
+rather than opening an empty wallet. The session caches the derived seed,
+so the passphrase is not asked for again while it lasts.
+
+Pass --passphrase-prompt to refuse wallets that do not prompt for a
+passphrase. Without sessions enabled (security.session_enabled) or an OS
+keychain, unlock only verifies the password and passphrase.`,
+	Example: `  sigil wallet unlock main
+  sigil wallet unlock hidden --passphrase-prompt`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWalletUnlock,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	walletCmd.AddCommand(walletUnlockCmd)
+
+	walletUnlockCmd.Flags().BoolVar(&unlockPassphrasePrompt, "passphrase-prompt", false, "require a wallet that asks for its BIP39 passphrase")
+}
+
+func runWalletUnlock(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+	name := args[0]
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	exists, err := storage.Exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sigilerr.WithSuggestion(
+			wallet.ErrWalletNotFound,
+			fmt.Sprintf("wallet '%s' not found. List wallets with: sigil wallet list", name),
+		)
+	}
+
+	meta, err := storage.LoadMetadata(name)
+	if err != nil {
+		return err
+	}
+	if unlockPassphrasePrompt && !meta.PassphrasePrompt {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("wallet '%s' has no prompted BIP39 passphrase; unlock it without --passphrase-prompt", name))
+	}
+
+	mgr := cc.SessionMgr
+	sessions := cc.Cfg.GetSecurity().SessionEnabled && mgr != nil && mgr.Available()
+	if sessions && mgr.HasValidSession(name) {
+		if err := mgr.EndSession(name); err != nil {
+			return fmt.Errorf("ending session: %w", err)
+		}
+	}
+
+	wlt, seed, err := loadWalletWithSession(name, storage, cmd)
+	if err != nil {
+		return err
+	}
+	wallet.ZeroBytes(seed)
+
+	expiresIn := ""
+	if sessions {
+		if list, listErr := mgr.ListSessions(); listErr == nil {
+			for _, s := range list {
+				if s.WalletName == name {
+					expiresIn = formatDuration(s.TTL())
+				}
+			}
+		}
+	}
+
+	w := cmd.OutOrStdout()
+	if cc.Fmt.Format() == output.FormatJSON {
+		return writeJSON(w, map[string]any{
+			"wallet":            name,
+			"passphrase_prompt": wlt.PassphrasePrompt,
+			"session":           expiresIn != "",
+			"expires_in":        expiresIn,
+		})
+	}
+	out(w, "Wallet '%s' unlocked.\n", name)
+	if wlt.PassphrasePrompt {
+		outln(w, "BIP39 passphrase verified against the wallet's addresses.")
+	}
+	switch {
+	case expiresIn != "":
+		out(w, "Session expires in %s. End it with: sigil session lock\n", expiresIn)
+	case !cc.Cfg.GetSecurity().SessionEnabled:
+		outln(w, "No session was started; set security.session_enabled to cache the unlock.")
+	default:
+		outln(w, "No session was started: session caching is not available (keyring unavailable).")
+	}
+	return nil
+}
+
+// passphrasePromptSeed asks for the BIP39 passphrase of a new
+// --passphrase-prompt wallet and derives its seed. An empty passphrase is
+// refused: the wallet would not be hidden, only slower to unlock.
+// The caller must zero the returned seed when done.
+func passphrasePromptSeed(mnemonic string) ([]byte, error) {
+	passphrase, err := promptPassphraseFn()
+	if err != nil {
+		return nil, err
+	}
+	if passphrase == "" {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			"--passphrase-prompt needs a BIP39 passphrase; leave the flag off for a wallet without one")
+	}
+	return wallet.MnemonicToSeed(mnemonic, passphrase)
+}
+
+// walletSeed returns the seed for the secret decrypted from a wallet file.
+// For a --passphrase-prompt wallet the secret is its mnemonic: the BIP39
+// passphrase is asked for, the seed derived and verified, and the secret
+// zeroed. Otherwise the secret is the seed and is returned as is.
+// The caller must zero the returned seed when done.
+func walletSeed(wlt *wallet.Wallet, secret []byte) ([]byte, error) {
+	if !wlt.PassphrasePrompt {
+		return secret, nil
+	}
+	defer wallet.ZeroBytes(secret)
+
+	passphrase, err := promptPasswordFn("Enter BIP39 passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	defer wallet.ZeroBytes(passphrase)
+
+	return wlt.SeedFromMnemonic(secret, string(passphrase))
+}
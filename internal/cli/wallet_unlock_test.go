@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// withBIP39Passphrase makes the password prompt answer "password" and the
+// BIP39 passphrase prompt answer passphrase.
+func withBIP39Passphrase(t *testing.T, passphrase string) {
+	t.Helper()
+	orig := promptPasswordFn
+	t.Cleanup(func() { promptPasswordFn = orig })
+	promptPasswordFn = func(prompt string) ([]byte, error) {
+		if prompt == "Enter BIP39 passphrase: " {
+			return []byte(passphrase), nil
+		}
+		return []byte("password"), nil
+	}
+}
+
+//nolint:paralleltest // Mutates package-level flags and prompt functions
+func TestRunWalletRestoreAndUnlock_PassphrasePrompt(t *testing.T) {
+	origInput, origPrompt, origScan := restoreInput, restorePassphrasePrompt, restoreScan
+	t.Cleanup(func() {
+		restoreInput, restorePassphrasePrompt, restoreScan = origInput, origPrompt, origScan
+		unlockPassphrasePrompt = false
+	})
+
+	home := t.TempDir()
+	walletsDir := filepath.Join(home, "wallets")
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	withMockPrompts(t, []byte("password"), true)
+
+	restoreInput, restorePassphrasePrompt, restoreScan = mnemonic, true, false
+	cmd, buf := newBackupListTestCmd(home, output.FormatText)
+	require.NoError(t, runWalletRestore(cmd, []string{"hidden"}))
+	assert.Contains(t, buf.String(), "The BIP39 passphrase is not stored")
+
+	// The wallet file holds the mnemonic and addresses derived with the passphrase
+	storage := wallet.NewFileStorage(walletsDir)
+	wlt, secret, err := storage.Load("hidden", []byte("password"))
+	require.NoError(t, err)
+	assert.True(t, wlt.PassphrasePrompt)
+	assert.Equal(t, mnemonic, string(secret))
+	seed, err := wallet.MnemonicToSeed(mnemonic, "testpassphrase")
+	require.NoError(t, err)
+	require.NoError(t, wlt.VerifySeed(seed))
+
+	// A WIF or hex key has no passphrase
+	restoreInput = "5HueCGU8rMjxEXxiPuD5BDku4MkFqeZyd4dZ1jvhTVqvbTLvyTJ"
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	err = runWalletRestore(cmd, []string{"wif"})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	// Unlock with the wrong passphrase
+	withBIP39Passphrase(t, "testpassphrasE")
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	err = runWalletUnlock(cmd, []string{"hidden"})
+	require.ErrorIs(t, err, sigilerr.ErrPassphraseMismatch)
+
+	// Unlock with the right one
+	withBIP39Passphrase(t, "testpassphrase")
+	unlockPassphrasePrompt = true
+	cmd, buf = newBackupListTestCmd(home, output.FormatJSON)
+	require.NoError(t, runWalletUnlock(cmd, []string{"hidden"}))
+	var result map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &result))
+	assert.Equal(t, "hidden", result["wallet"])
+	assert.Equal(t, true, result["passphrase_prompt"])
+	assert.Equal(t, false, result["session"])
+
+	// --passphrase-prompt refuses a wallet without a prompted passphrase
+	createTestWallet(t, walletsDir, "plain")
+	cmd, _ = newBackupListTestCmd(home, output.FormatText)
+	err = runWalletUnlock(cmd, []string{"plain"})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+	unlockPassphrasePrompt = false
+	cmd, buf = newBackupListTestCmd(home, output.FormatText)
+	require.NoError(t, runWalletUnlock(cmd, []string{"plain"}))
+	assert.Contains(t, buf.String(), "Wallet 'plain' unlocked.")
+}
+
+//nolint:paralleltest // Mutates package-level prompt functions
+func TestWalletSeed(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	seed, err := wallet.MnemonicToSeed(mnemonic, "hidden")
+	require.NoError(t, err)
+	wlt, err := createWalletWithAddresses("hidden", seed, "main", nil)
+	require.NoError(t, err)
+
+	// A seed wallet's secret is its seed
+	got, err := walletSeed(wlt, seed)
+	require.NoError(t, err)
+	assert.Equal(t, seed, got)
+
+	wlt.PassphrasePrompt = true
+	withBIP39Passphrase(t, "hidden")
+	got, err = walletSeed(wlt, []byte(mnemonic))
+	require.NoError(t, err)
+	assert.Equal(t, seed, got)
+
+	withBIP39Passphrase(t, "")
+	_, err = walletSeed(wlt, []byte(mnemonic))
+	require.ErrorIs(t, err, sigilerr.ErrPassphraseMismatch)
+}
+
+//nolint:paralleltest // Mutates package-level prompt functions
+func TestPassphrasePromptSeed_Empty(t *testing.T) {
+	orig := promptPassphraseFn
+	t.Cleanup(func() { promptPassphraseFn = orig })
+	promptPassphraseFn = func() (string, error) { return "", nil }
+
+	_, err := passphrasePromptSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
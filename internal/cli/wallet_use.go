@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// walletUseCmd sets or shows the default wallet.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var walletUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Short: "Set the default wallet",
+	Long: `Set the wallet used by commands when --wallet is omitted.
+
+The default is stored as default_wallet in config.yaml. Commands that fall back
+to it print "Using default wallet '<name>'" to stderr so it is always clear
+which wallet was used. Run without a name to show the current default.`,
+	Example: `  sigil wallet use main
+  sigil wallet use
+  sigil balance show          # uses the default wallet`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runWalletUse,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	walletCmd.AddCommand(walletUseCmd)
+}
+
+func runWalletUse(cmd *cobra.Command, args []string) error {
+	cmdCtx := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+	format := cmdCtx.Fmt.Format()
+
+	if len(args) == 0 {
+		name := cmdCtx.Cfg.GetDefaultWallet()
+		if format == output.FormatJSON {
+			return writeJSON(w, map[string]string{"default_wallet": name})
+		}
+		if name == "" {
+			outln(w, "No default wallet set.")
+			outln(w, "Set one with: sigil wallet use <name>")
+			return nil
+		}
+		out(w, "Default wallet: %s\n", name)
+		return nil
+	}
+
+	name := args[0]
+	storage := wallet.NewFileStorage(filepath.Join(cmdCtx.Cfg.GetHome(), "wallets"))
+	exists, err := storage.Exists(name)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return sigilerr.WithSuggestion(
+			wallet.ErrWalletNotFound,
+			fmt.Sprintf("wallet '%s' not found. List wallets with: sigil wallet list", name),
+		)
+	}
+
+	configPath := config.FindPath(cmdCtx.Cfg.GetHome())
+	currentCfg, err := config.Load(configPath)
+	if err != nil {
+		// If file doesn't exist, start with defaults
+		currentCfg = config.Defaults()
+		currentCfg.Home = cmdCtx.Cfg.GetHome()
+	}
+	currentCfg.DefaultWallet = name
+
+	if err := config.Save(currentCfg, configPath); err != nil {
+		if errors.Is(err, config.ErrEnvOnly) {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("configuration comes from the environment; set %s=%s instead", config.EnvName("default_wallet"), name),
+			)
+		}
+		return fmt.Errorf("saving config: %w", err)
+	}
+
+	if format == output.FormatJSON {
+		return writeJSON(w, map[string]string{"default_wallet": name})
+	}
+	out(w, "Default wallet set to '%s'\n", name)
+	return nil
+}
+
+// resolveWalletName fills in the wallet name from the configured default when
+// the --wallet flag was omitted. The fallback is announced on stderr so that
+// the choice of wallet is never silent, even in JSON output mode.
+func resolveWalletName(cmd *cobra.Command, name *string) error {
+	if *name != "" {
+		return nil
+	}
+
+	if cmdCtx := GetCmdContext(cmd); cmdCtx != nil && cmdCtx.Cfg != nil {
+		if def := cmdCtx.Cfg.GetDefaultWallet(); def != "" {
+			*name = def
+			out(cmd.ErrOrStderr(), "Using default wallet '%s'\n", def)
+			return nil
+		}
+	}
+
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrInvalidInput,
+		"--wallet is required (or set a default with: sigil wallet use <name>)",
+	)
+}
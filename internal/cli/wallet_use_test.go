@@ -0,0 +1,141 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newWalletUseTestCmd builds a command wired to a mock config rooted at home.
+func newWalletUseTestCmd(home, defaultWallet string, format output.Format) (*cobra.Command, *bytes.Buffer, *bytes.Buffer) {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{
+		Cfg: &mockConfigProvider{home: home, defaultWallet: defaultWallet},
+		Fmt: &mockFormatProvider{format: format},
+	})
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	return cmd, &stdout, &stderr
+}
+
+func TestResolveWalletName(t *testing.T) {
+	t.Parallel()
+
+	t.Run("explicit flag wins", func(t *testing.T) {
+		t.Parallel()
+		cmd, _, stderr := newWalletUseTestCmd(t.TempDir(), "main", output.FormatText)
+
+		name := "savings"
+		require.NoError(t, resolveWalletName(cmd, &name))
+		assert.Equal(t, "savings", name)
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("falls back to default", func(t *testing.T) {
+		t.Parallel()
+		cmd, stdout, stderr := newWalletUseTestCmd(t.TempDir(), "main", output.FormatJSON)
+
+		var name string
+		require.NoError(t, resolveWalletName(cmd, &name))
+		assert.Equal(t, "main", name)
+		assert.Equal(t, "Using default wallet 'main'\n", stderr.String())
+		assert.Empty(t, stdout.String(), "notice must not corrupt JSON on stdout")
+	})
+
+	t.Run("no flag and no default", func(t *testing.T) {
+		t.Parallel()
+		cmd, _, _ := newWalletUseTestCmd(t.TempDir(), "", output.FormatText)
+
+		var name string
+		err := resolveWalletName(cmd, &name)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, err, &se)
+		assert.Contains(t, se.Suggestion, "sigil wallet use")
+	})
+}
+
+func TestRunWalletUse(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sets default for existing wallet", func(t *testing.T) {
+		t.Parallel()
+		home := t.TempDir()
+		walletsDir := filepath.Join(home, "wallets")
+		require.NoError(t, os.MkdirAll(walletsDir, 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(walletsDir, "main.wallet"), []byte("{}"), 0o600))
+
+		cmd, stdout, _ := newWalletUseTestCmd(home, "", output.FormatText)
+		require.NoError(t, runWalletUse(cmd, []string{"main"}))
+		assert.Contains(t, stdout.String(), "Default wallet set to 'main'")
+
+		saved, err := config.Load(config.Path(home))
+		require.NoError(t, err)
+		assert.Equal(t, "main", saved.DefaultWallet)
+	})
+
+	t.Run("preserves existing config", func(t *testing.T) {
+		t.Parallel()
+		home := t.TempDir()
+		walletsDir := filepath.Join(home, "wallets")
+		require.NoError(t, os.MkdirAll(walletsDir, 0o750))
+		require.NoError(t, os.WriteFile(filepath.Join(walletsDir, "main.wallet"), []byte("{}"), 0o600))
+
+		existing := config.Defaults()
+		existing.Home = home
+		existing.Logging.Level = "debug"
+		require.NoError(t, config.Save(existing, config.Path(home)))
+
+		cmd, _, _ := newWalletUseTestCmd(home, "", output.FormatText)
+		require.NoError(t, runWalletUse(cmd, []string{"main"}))
+
+		saved, err := config.Load(config.Path(home))
+		require.NoError(t, err)
+		assert.Equal(t, "main", saved.DefaultWallet)
+		assert.Equal(t, "debug", saved.Logging.Level)
+	})
+
+	t.Run("unknown wallet", func(t *testing.T) {
+		t.Parallel()
+		home := t.TempDir()
+
+		cmd, _, _ := newWalletUseTestCmd(home, "", output.FormatText)
+		err := runWalletUse(cmd, []string{"missing"})
+		require.ErrorIs(t, err, wallet.ErrWalletNotFound)
+
+		_, statErr := os.Stat(config.Path(home))
+		assert.True(t, os.IsNotExist(statErr), "config must not be written for an unknown wallet")
+	})
+
+	t.Run("shows current default", func(t *testing.T) {
+		t.Parallel()
+		cmd, stdout, _ := newWalletUseTestCmd(t.TempDir(), "main", output.FormatText)
+		require.NoError(t, runWalletUse(cmd, nil))
+		assert.Equal(t, "Default wallet: main\n", stdout.String())
+	})
+
+	t.Run("shows unset default as JSON", func(t *testing.T) {
+		t.Parallel()
+		cmd, stdout, _ := newWalletUseTestCmd(t.TempDir(), "", output.FormatJSON)
+		require.NoError(t, runWalletUse(cmd, nil))
+
+		var got map[string]string
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &got))
+		assert.Empty(t, got["default_wallet"])
+	})
+}
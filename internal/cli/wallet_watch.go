@@ -0,0 +1,214 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level flag variables
+var (
+	// watchXpub is the account xpub a watch-only wallet is imported from.
+	watchXpub string
+	// watchAddresses is the file of addresses a watch-only wallet is imported from.
+	watchAddresses string
+	// watchChain is the chain of the imported xpub or addresses.
+	watchChain string
+	// watchCount is the number of receiving and change addresses derived from the xpub.
+	watchCount int
+)
+
+// walletImportWatchCmd imports a watch-only wallet.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var walletImportWatchCmd = &cobra.Command{
+	Use:   "import-watch <name>",
+	Short: "Import a watch-only wallet from an xpub or address list",
+	Long: `Import a wallet that has addresses but no seed.
+
+With --xpub, the BIP44 account xpub (m/44'/coin'/0') derives --count
+receiving and change addresses, and "sigil receive" derives more from it.
+A "tpub..." key creates a testnet wallet. Private keys (xprv) are refused.
+
+With --addresses, the wallet watches the addresses in the file, one per
+line, or read from stdin for "-". Blank lines and lines starting with "#"
+are skipped. Every address is checked against --chain before the wallet is
+created.
+
+A watch-only wallet needs no password. Balances, addresses, and
+"sigil tx build" work as for any wallet, but commands that sign, such as
+tx send, tx sign, and stamp, fail with WALLET_WATCH_ONLY. Sign its unsigned
+transactions with the wallet that holds the keys.`,
+	Example: `  sigil wallet import-watch cold --xpub xpub6C...
+  sigil wallet import-watch cold-eth --xpub xpub6D... --chain eth --count 5
+  sigil wallet import-watch donations --addresses addrs.txt
+  cat addrs.txt | sigil wallet import-watch donations --addresses -`,
+	Args: cobra.ExactArgs(1),
+	RunE: runWalletImportWatch,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for command registration
+func init() {
+	walletCmd.AddCommand(walletImportWatchCmd)
+
+	walletImportWatchCmd.Flags().StringVar(&watchXpub, "xpub", "", "BIP44 account xpub to derive addresses from")
+	walletImportWatchCmd.Flags().StringVar(&watchAddresses, "addresses", "", "file of addresses to watch, one per line (- for stdin)")
+	walletImportWatchCmd.Flags().StringVar(&watchChain, "chain", "bsv", "chain of the xpub or addresses")
+	walletImportWatchCmd.Flags().IntVar(&watchCount, "count", 20, "receiving and change addresses to derive from the xpub")
+	walletImportWatchCmd.MarkFlagsMutuallyExclusive("xpub", "addresses")
+	walletImportWatchCmd.MarkFlagsOneRequired("xpub", "addresses")
+}
+
+func runWalletImportWatch(cmd *cobra.Command, args []string) error {
+	cc := GetCmdContext(cmd)
+	name := args[0]
+
+	chainID, err := parseEnabledChain(cc.Cfg, watchChain)
+	if err != nil {
+		return err
+	}
+
+	var wlt *wallet.Wallet
+	if watchXpub != "" {
+		wlt, err = watchOnlyFromXpub(name, chainID, watchXpub, watchCount)
+	} else {
+		wlt, err = watchOnlyFromAddressFile(cmd, name, chainID, watchAddresses)
+	}
+	if err != nil {
+		return err
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	if err := storage.SaveWatchOnly(wlt); err != nil {
+		return err
+	}
+
+	displayWatchOnlyImport(cmd, wlt, chainID)
+	return nil
+}
+
+// watchOnlyFromXpub creates a watch-only wallet from an account xpub.
+func watchOnlyFromXpub(name string, chainID chain.ID, xpub string, count int) (*wallet.Wallet, error) {
+	wlt, err := wallet.NewWatchOnlyFromXpub(name, chainID, xpub, count)
+	switch {
+	case err == nil:
+		return wlt, nil
+	case errors.Is(err, wallet.ErrXpubIsPrivate):
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"that is a private key (xprv); a watch-only wallet needs the account xpub",
+		)
+	case errors.Is(err, wallet.ErrInvalidAddressCount):
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("--count must be between 1 and %d", wallet.MaxAddressDerivation),
+		)
+	case errors.Is(err, wallet.ErrInvalidWalletName):
+		return nil, err
+	default:
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+}
+
+// watchOnlyFromAddressFile creates a watch-only wallet from the addresses in
+// path, checking each against the chain.
+func watchOnlyFromAddressFile(cmd *cobra.Command, name string, chainID chain.ID, path string) (*wallet.Wallet, error) {
+	data, err := readOfflineInput(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	addresses := parseWatchAddresses(data)
+	if len(addresses) == 0 {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("no addresses found in %s", path))
+	}
+
+	network := chain.Mainnet
+	if chainID != chain.ETH {
+		network = bsvNetworkForCmd(cmd)
+	}
+	for _, addr := range addresses {
+		if err := chain.ValidateAddressOnNetwork(chainID, network, addr); err != nil {
+			return nil, transaction.InvalidAddressError(err)
+		}
+	}
+
+	wlt, err := wallet.NewWatchOnlyFromAddresses(name, chainID, addresses)
+	if err != nil {
+		return nil, err
+	}
+	if chainID != chain.ETH {
+		wlt.Network = network
+	}
+	return wlt, nil
+}
+
+// parseWatchAddresses returns the addresses in data, one per line, skipping
+// blank lines and "#" comments.
+func parseWatchAddresses(data []byte) []string {
+	var addresses []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		addresses = append(addresses, line)
+	}
+	return addresses
+}
+
+// displayWatchOnlyImport shows the imported watch-only wallet.
+func displayWatchOnlyImport(cmd *cobra.Command, wlt *wallet.Wallet, chainID chain.ID) {
+	w := cmd.OutOrStdout()
+	receive := len(wlt.Addresses[chainID])
+	change := len(wlt.ChangeAddresses[chainID])
+
+	if GetCmdContext(cmd).Fmt.Format() == output.FormatJSON {
+		_ = writeJSON(w, struct {
+			Name             string `json:"name"`
+			Type             string `json:"type"`
+			Chain            string `json:"chain"`
+			Source           string `json:"source"`
+			ReceiveAddresses int    `json:"receive_addresses"`
+			ChangeAddresses  int    `json:"change_addresses"`
+		}{
+			Name:             wlt.Name,
+			Type:             wlt.Type,
+			Chain:            string(chainID),
+			Source:           watchOnlySource(wlt, chainID),
+			ReceiveAddresses: receive,
+			ChangeAddresses:  change,
+		})
+		return
+	}
+
+	out(w, "Watch-only wallet '%s' imported.\n\n", wlt.Name)
+	out(w, "  Chain:     %s\n", strings.ToUpper(string(chainID)))
+	out(w, "  Source:    %s\n", watchOnlySource(wlt, chainID))
+	out(w, "  Addresses: %d receiving, %d change\n", receive, change)
+	if first, ok := wlt.GetPrimaryAddress(chainID); ok {
+		out(w, "  First:     %s\n", first)
+	}
+	outln(w)
+	outln(w, "Signing is disabled. Check balances with:")
+	out(w, "  sigil balance show --wallet %s\n", wlt.Name)
+}
+
+// watchOnlySource names what a watch-only wallet was imported from.
+func watchOnlySource(wlt *wallet.Wallet, chainID chain.ID) string {
+	if wlt.Xpubs[chainID] != "" {
+		return "xpub"
+	}
+	return "addresses"
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newWatchTestCmd returns a command whose context points at home.
+func newWatchTestCmd(home string, format output.Format) (*cobra.Command, *bytes.Buffer) {
+	var buf bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	cmd.SetOut(&buf)
+	cmd.SetErr(&bytes.Buffer{})
+	SetCmdContext(cmd, &CommandContext{
+		Cfg: &mockConfigProvider{home: home},
+		Fmt: &mockFormatProvider{format: format},
+		Log: config.NullLogger(),
+	})
+	return cmd, &buf
+}
+
+func TestParseWatchAddresses(t *testing.T) {
+	t.Parallel()
+
+	got := parseWatchAddresses([]byte("# donations\n 0xaaa \n\n0xbbb\r\n"))
+	assert.Equal(t, []string{"0xaaa", "0xbbb"}, got)
+	assert.Empty(t, parseWatchAddresses([]byte("\n# nothing\n")))
+}
+
+func TestWatchOnlyFromXpub(t *testing.T) {
+	t.Parallel()
+
+	seed, err := wallet.MnemonicToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	require.NoError(t, err)
+	xpub, err := wallet.DeriveAccountXpub(seed, chain.BSV, 0)
+	require.NoError(t, err)
+
+	wlt, err := watchOnlyFromXpub("cold", chain.BSV, xpub, 2)
+	require.NoError(t, err)
+	assert.True(t, wlt.IsWatchOnly())
+	assert.Len(t, wlt.Addresses[chain.BSV], 2)
+
+	_, err = watchOnlyFromXpub("cold", chain.BSV, xpub, 0)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "--count")
+
+	_, err = watchOnlyFromXpub("cold", chain.BSV, "not-an-xpub", 2)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestRunWalletImportWatch_Addresses(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	path := filepath.Join(tmpDir, "addrs.txt")
+	require.NoError(t, os.WriteFile(path, []byte("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed\n0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359\n"), 0o600))
+
+	watchXpub, watchAddresses, watchChain = "", path, "eth"
+	t.Cleanup(func() { watchAddresses, watchChain = "", "bsv" })
+
+	cmd, buf := newWatchTestCmd(tmpDir, output.FormatText)
+	require.NoError(t, runWalletImportWatch(cmd, []string{"donations"}))
+	assert.Contains(t, buf.String(), "Watch-only wallet 'donations' imported.")
+	assert.Contains(t, buf.String(), "Addresses: 2 receiving, 0 change")
+
+	storage := wallet.NewFileStorage(filepath.Join(tmpDir, "wallets"))
+	wlt, err := storage.LoadMetadata("donations")
+	require.NoError(t, err)
+	assert.True(t, wlt.IsWatchOnly())
+
+	// Signing commands refuse the wallet; read commands load it without a seed.
+	_, _, err = loadWalletWithSession("donations", storage, cmd)
+	require.ErrorIs(t, err, sigilerr.ErrWalletWatchOnly)
+
+	loaded, seed, err := loadWalletForRead("donations", storage, cmd)
+	require.NoError(t, err)
+	assert.Nil(t, seed)
+	assert.Equal(t, "donations", loaded.Name)
+
+	// A bad address stops the import.
+	require.NoError(t, os.WriteFile(path, []byte("0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed\nnot-an-address\n"), 0o600))
+	cmd, _ = newWatchTestCmd(tmpDir, output.FormatText)
+	err = runWalletImportWatch(cmd, []string{"bad"})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidAddress)
+	exists, err := storage.Exists("bad")
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestDisplayWatchOnlyImport_JSON(t *testing.T) {
+	t.Parallel()
+
+	wlt, err := wallet.NewWatchOnlyFromAddresses("donations", chain.ETH, []string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"})
+	require.NoError(t, err)
+
+	cmd, buf := newWatchTestCmd(t.TempDir(), output.FormatJSON)
+	displayWatchOnlyImport(cmd, wlt, chain.ETH)
+	assert.Contains(t, buf.String(), `"type": "watch-only"`)
+	assert.Contains(t, buf.String(), `"source": "addresses"`)
+	assert.Contains(t, buf.String(), `"receive_addresses": 1`)
+}
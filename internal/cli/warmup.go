@@ -0,0 +1,408 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/service/transaction"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// warmupStepTimeout bounds each fee, gas and price request of a warmup.
+const warmupStepTimeout = 30 * time.Second
+
+// warmup flags
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level state
+var (
+	warmupWallets []string
+	warmupMaxAge  time.Duration
+	warmupFiat    string
+)
+
+// warmupCmd prefetches the data interactive commands need.
+//
+//nolint:gochecknoglobals // Cobra CLI pattern requires package-level command variables
+var warmupCmd = &cobra.Command{
+	Use:   "warmup",
+	Short: "Prefetch fees, gas prices, exchange rates and balances",
+	Long: `Prefetch the data interactive commands need, so the first command of the
+day starts from fresh caches. Designed to run from cron.
+
+In one pass, warmup:
+  - fetches a BSV fee quote for each network the wallets use, recording it
+    in the fee table (~/.sigil/cache/fees.json)
+  - fetches ETH gas prices when a wallet uses ETH, recording them in the gas
+    table (~/.sigil/cache/gas.json)
+  - fetches the exchange rate of every wallet coin in one price request, when
+    a fiat currency is set with --fiat or price.fiat
+  - refreshes the balances of every wallet into the balance cache, as
+    "addresses refresh --all-wallets" does
+
+Each fee quote and gas price is fetched once however many wallets share it.
+Sends always try live fee and gas sources first; the recorded tables are the
+fallback when those are unreachable. "balance show --cached" and fiat values
+read the warmed balance and price caches.
+
+Warmup needs no password. It exits with an error only when every step
+failed, so cron is alerted to outages but not to one unreachable provider.`,
+	Example: `  # Warm every wallet
+  sigil warmup
+
+  # Only the main wallet, skipping balances refreshed in the last hour
+  sigil warmup --wallet main --max-age 1h
+
+  # Weekdays at 8:45, before market hours
+  45 8 * * 1-5  sigil warmup --fiat usd -o json >> ~/.sigil/warmup.log 2>&1`,
+	Args: cobra.NoArgs,
+	RunE: runWarmup,
+}
+
+//nolint:gochecknoinits // Cobra CLI pattern requires init for flag registration
+func init() {
+	warmupCmd.GroupID = "config"
+	rootCmd.AddCommand(warmupCmd)
+
+	warmupCmd.Flags().StringArrayVar(&warmupWallets, "wallet", nil, "wallet to warm (repeatable; default all wallets)")
+	warmupCmd.Flags().DurationVar(&warmupMaxAge, "max-age", 0, "skip addresses refreshed more recently than this (e.g. 1h)")
+	warmupCmd.Flags().StringVar(&warmupFiat, "fiat", "", "fetch exchange rates in this fiat currency (usd, eur; none to skip price.fiat)")
+}
+
+// warmupStep is the outcome of one fee, gas or price fetch.
+type warmupStep struct {
+	Step   string `json:"step"`
+	Target string `json:"target"`
+	Detail string `json:"detail,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// warmupPlan lists what the selected wallets need warmed.
+type warmupPlan struct {
+	names       []string
+	bsvNetworks []string
+	eth         bool
+	chains      []chain.ID
+}
+
+// warmupBSVFeeQuoteFn fetches a live BSV fee quote (replaceable in tests).
+//
+//nolint:gochecknoglobals // Allows tests to stub network calls
+var warmupBSVFeeQuoteFn = fetchWarmupBSVFeeQuote
+
+// warmupGasPricesFn fetches live ETH gas prices (replaceable in tests).
+//
+//nolint:gochecknoglobals // Allows tests to stub network calls
+var warmupGasPricesFn = fetchWarmupGasPrices
+
+func runWarmup(cmd *cobra.Command, _ []string) error {
+	cc := GetCmdContext(cmd)
+	w := cmd.OutOrStdout()
+	jsonOutput := cc.Fmt.Format() == output.FormatJSON
+
+	if warmupMaxAge < 0 {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "--max-age must not be negative")
+	}
+	currency, err := resolveFiatCurrency(cc.Cfg, warmupFiat)
+	if err != nil {
+		return err
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(cc.Cfg.GetHome(), "wallets"))
+	plan, err := planWarmup(cc.Cfg, storage, warmupWallets)
+	if err != nil {
+		return err
+	}
+	if len(plan.names) == 0 {
+		if jsonOutput {
+			displayWarmupJSON(w, nil, nil)
+		} else {
+			outln(w, "No wallets found to warm up.")
+		}
+		return nil
+	}
+
+	start := time.Now()
+	if !jsonOutput {
+		out(w, "Warming up %d wallet(s)...\n", len(plan.names))
+	}
+	report := func(step warmupStep) {
+		if !jsonOutput {
+			displayWarmupStepLine(w, step)
+		}
+	}
+
+	var steps []warmupStep
+	for _, network := range plan.bsvNetworks {
+		steps = append(steps, warmBSVFees(cmd, cc, network))
+		report(steps[len(steps)-1])
+	}
+	if plan.eth && cc.Cfg.GetETHRPC() != "" {
+		steps = append(steps, warmGasPrices(cmd, cc))
+		report(steps[len(steps)-1])
+	}
+	if currency != "" && len(plan.chains) > 0 {
+		steps = append(steps, warmPrices(cmd, cc, plan.chains, currency))
+		report(steps[len(steps)-1])
+	}
+
+	summaries := warmBalances(cmd, cc, storage, plan.names, func(s *walletRefreshSummary) {
+		if !jsonOutput {
+			out(w, "  balances (%s): ", s.Wallet)
+			displayWarmupRefresh(w, s)
+		}
+	})
+
+	if jsonOutput {
+		displayWarmupJSON(w, steps, summaries)
+	} else {
+		out(w, "Warmup finished in %s\n", time.Since(start).Round(time.Millisecond))
+	}
+
+	for _, s := range steps {
+		if s.Error == "" {
+			return nil
+		}
+	}
+	for _, s := range summaries {
+		if !s.failed() {
+			return nil
+		}
+	}
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrNetworkError,
+		fmt.Sprintf("warmup failed for all %d step(s)", len(steps)+len(summaries)),
+	)
+}
+
+// planWarmup selects the wallets to warm (all when names is empty) and
+// collects the BSV networks, ETH use and coins across them. Wallets whose
+// metadata cannot be read are kept so their balance refresh reports why.
+func planWarmup(cfg ConfigProvider, storage *wallet.FileStorage, names []string) (*warmupPlan, error) {
+	plan := &warmupPlan{names: names}
+	if len(names) == 0 {
+		all, err := storage.List()
+		if err != nil {
+			return nil, fmt.Errorf("listing wallets: %w", err)
+		}
+		plan.names = all
+	}
+
+	networks := make(map[string]bool)
+	chains := make(map[chain.ID]bool)
+	for _, name := range plan.names {
+		wlt, err := storage.LoadMetadata(name)
+		if err != nil {
+			continue
+		}
+		for _, id := range wlt.EnabledChains {
+			chains[id] = true
+			if id == chain.BSV {
+				networks[effectiveBSVNetwork(wlt, cfg)] = true
+			}
+			if id == chain.ETH {
+				plan.eth = true
+			}
+		}
+	}
+
+	for network := range networks {
+		plan.bsvNetworks = append(plan.bsvNetworks, network)
+	}
+	sort.Strings(plan.bsvNetworks)
+	for id := range chains {
+		plan.chains = append(plan.chains, id)
+	}
+	sort.Slice(plan.chains, func(i, j int) bool { return plan.chains[i] < plan.chains[j] })
+	return plan, nil
+}
+
+// warmBSVFees fetches a live fee quote for a BSV network, which the client
+// records in the fee table.
+func warmBSVFees(cmd *cobra.Command, cc *CommandContext, network string) warmupStep {
+	step := warmupStep{Step: "fees", Target: "bsv " + network}
+
+	ctx, cancel := contextWithTimeout(cmd, warmupStepTimeout)
+	defer cancel()
+
+	quote, err := warmupBSVFeeQuoteFn(ctx, cc, network)
+	switch {
+	case err != nil:
+		step.Error = err.Error()
+	case quote.IsFallback():
+		step.Error = "fee API unreachable"
+	default:
+		step.Detail = fmt.Sprintf("%d sat/KB from %s", quote.StandardRate, quote.Source)
+	}
+	return step
+}
+
+// fetchWarmupBSVFeeQuote fetches a BSV fee quote with the configured strategy,
+// recording it in the fee table.
+func fetchWarmupBSVFeeQuote(ctx context.Context, cc *CommandContext, network string) (*bsv.FeeQuote, error) {
+	client := bsv.NewClient(ctx, &bsv.ClientOptions{
+		APIKey:      cc.Cfg.GetBSVAPIKey(),
+		Network:     bsvClientNetwork(network),
+		Logger:      cc.Log,
+		FeeStrategy: bsv.FeeStrategy(cc.Cfg.GetBSVFeeStrategy()),
+		MinMiners:   cc.Cfg.GetBSVMinMiners(),
+		FeeTable:    bsv.NewFeeTableStore(transaction.FeeTablePath(cc.Cfg.GetHome())),
+	})
+	return client.GetFeeQuote(ctx)
+}
+
+// warmGasPrices fetches live ETH gas prices, which the client records in the
+// gas table.
+func warmGasPrices(cmd *cobra.Command, cc *CommandContext) warmupStep {
+	step := warmupStep{Step: "gas", Target: "eth"}
+
+	ctx, cancel := contextWithTimeout(cmd, warmupStepTimeout)
+	defer cancel()
+
+	prices, err := warmupGasPricesFn(ctx, cc)
+	switch {
+	case err != nil:
+		step.Error = err.Error()
+	case prices.Source == eth.GasSourceCached:
+		step.Error = "all RPC endpoints failed"
+	default:
+		step.Detail = fmt.Sprintf("%s / %s / %s from %s",
+			eth.FormatGasPrice(prices.Slow), eth.FormatGasPrice(prices.Medium), eth.FormatGasPrice(prices.Fast), prices.Source)
+	}
+	return step
+}
+
+// fetchWarmupGasPrices fetches ETH gas prices through the Etherscan oracle
+// when an API key is set, else the configured RPCs, recording them in the
+// gas table.
+func fetchWarmupGasPrices(ctx context.Context, cc *CommandContext) (*eth.GasPrices, error) {
+	opts := &eth.ClientOptions{
+		FallbackRPCs: cc.Cfg.GetETHFallbackRPCs(),
+		GasTable:     eth.NewGasTableStore(transaction.GasTablePath(cc.Cfg.GetHome())),
+	}
+	if apiKey := cc.Cfg.GetETHEtherscanAPIKey(); apiKey != "" {
+		if esClient, esErr := etherscan.NewClient(apiKey, etherscanOptions(cc.Cfg)); esErr == nil {
+			opts.GasPriceOracle = etherscan.NewGasPriceAdapter(esClient)
+		}
+	}
+	client, err := eth.NewClient(cc.Cfg.GetETHRPC(), opts)
+	if err != nil {
+		return nil, fmt.Errorf("creating ETH client: %w", err)
+	}
+	defer client.Close()
+	return client.GetGasPrices(ctx)
+}
+
+// warmPrices fetches the exchange rates of chains' coins in one request,
+// recording them in the price cache.
+func warmPrices(cmd *cobra.Command, cc *CommandContext, chains []chain.ID, currency string) warmupStep {
+	step := warmupStep{Step: "prices", Target: currency}
+
+	source, err := newFiatQuoteSourceFn(cc)
+	if err != nil {
+		step.Error = err.Error()
+		return step
+	}
+
+	ctx, cancel := contextWithTimeout(cmd, warmupStepTimeout)
+	defer cancel()
+
+	quotes, err := source.Quotes(ctx, chains, currency, false)
+	rates := make([]string, 0, len(quotes))
+	for _, id := range chains {
+		if q, ok := quotes[id]; ok {
+			rates = append(rates, fmt.Sprintf("%s %.2f", id, q.Rate))
+		}
+	}
+	step.Detail = strings.Join(rates, ", ")
+	if err != nil {
+		step.Error = err.Error()
+	}
+	return step
+}
+
+// warmBalances refreshes the balances of each wallet into one shared balance
+// cache, saved once at the end, calling done after each wallet.
+func warmBalances(cmd *cobra.Command, cc *CommandContext, storage *wallet.FileStorage, names []string, done func(*walletRefreshSummary)) []*walletRefreshSummary {
+	cacheStorage := cache.NewBalanceStorage(cc.Cfg.GetHome(), cc.Cfg.GetCache())
+	balanceCache := loadOrCreateBalanceCache(cacheStorage, false, cmd, cc.Log)
+
+	summaries := make([]*walletRefreshSummary, 0, len(names))
+	for _, name := range names {
+		if commandCanceled(cmd) {
+			break
+		}
+		summary := refreshWallet(cmd, cc, storage, name, balanceCache, "", warmupMaxAge)
+		summaries = append(summaries, summary)
+		done(summary)
+	}
+
+	if saveErr := cacheStorage.Save(balanceCache); saveErr != nil {
+		if cc.Log != nil {
+			cc.Log.Error("failed to save balance cache: %v", saveErr)
+		}
+	}
+	return summaries
+}
+
+// displayWarmupStepLine prints the one-line outcome of a fetch.
+func displayWarmupStepLine(w io.Writer, s warmupStep) {
+	switch {
+	case s.Error != "" && s.Detail != "":
+		out(w, "  %s (%s): %s (partial: %s)\n", s.Step, s.Target, s.Detail, s.Error)
+	case s.Error != "":
+		out(w, "  %s (%s): failed: %s\n", s.Step, s.Target, s.Error)
+	default:
+		out(w, "  %s (%s): %s\n", s.Step, s.Target, s.Detail)
+	}
+}
+
+// displayWarmupRefresh prints the rest of a wallet's balance refresh line.
+func displayWarmupRefresh(w io.Writer, s *walletRefreshSummary) {
+	if s.Error != "" {
+		out(w, "failed: %s\n", s.Error)
+		return
+	}
+	out(w, "%d refreshed, %d skipped, %d error(s)\n", s.Refreshed, s.Skipped, s.Errors)
+}
+
+// displayWarmupJSON writes the fetch outcomes and wallet refresh summaries as JSON.
+func displayWarmupJSON(w io.Writer, steps []warmupStep, summaries []*walletRefreshSummary) {
+	type responseJSON struct {
+		Steps   []warmupStep            `json:"steps"`
+		Wallets []*walletRefreshSummary `json:"wallets"`
+		Failed  int                     `json:"failed"`
+	}
+
+	resp := responseJSON{
+		Steps:   make([]warmupStep, 0, len(steps)),
+		Wallets: make([]*walletRefreshSummary, 0, len(summaries)),
+	}
+	for _, s := range steps {
+		resp.Steps = append(resp.Steps, s)
+		if s.Error != "" {
+			resp.Failed++
+		}
+	}
+	for _, s := range summaries {
+		resp.Wallets = append(resp.Wallets, s)
+		if s.failed() {
+			resp.Failed++
+		}
+	}
+
+	_ = writeJSON(w, resp)
+}
@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+var errWarmupOffline = errors.New("offline")
+
+// newWarmupTestCmd creates a command for runWarmup, stubbing the fee and gas
+// fetches and setting the warmup flags for the duration of the test.
+func newWarmupTestCmd(t *testing.T, cfg *mockConfigProvider, format output.Format, feeErr, gasErr error) (*cobra.Command, *bytes.Buffer, *[]string) {
+	t.Helper()
+
+	origFee, origGas := warmupBSVFeeQuoteFn, warmupGasPricesFn
+	origWallets, origMaxAge, origFiat := warmupWallets, warmupMaxAge, warmupFiat
+	t.Cleanup(func() {
+		warmupBSVFeeQuoteFn, warmupGasPricesFn = origFee, origGas
+		warmupWallets, warmupMaxAge, warmupFiat = origWallets, origMaxAge, origFiat
+	})
+	warmupWallets, warmupMaxAge, warmupFiat = nil, time.Hour, ""
+
+	var networks []string
+	warmupBSVFeeQuoteFn = func(_ context.Context, _ *CommandContext, network string) (*bsv.FeeQuote, error) {
+		networks = append(networks, network)
+		if feeErr != nil {
+			return nil, feeErr
+		}
+		return &bsv.FeeQuote{StandardRate: 100, Source: bsv.FeeSourceWhatsOnChain}, nil
+	}
+	warmupGasPricesFn = func(context.Context, *CommandContext) (*eth.GasPrices, error) {
+		if gasErr != nil {
+			return nil, gasErr
+		}
+		gwei := big.NewInt(1_000_000_000)
+		return &eth.GasPrices{Slow: gwei, Medium: gwei, Fast: gwei, Source: eth.GasSourceOracle}, nil
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	SetCmdContext(cmd, &CommandContext{Cfg: cfg, Fmt: &mockFormatProvider{format: format}})
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	return cmd, &buf, &networks
+}
+
+//nolint:paralleltest // Replaces package-level fetchers and flags
+func TestRunWarmup(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+	warmBalanceCache(t, tmpDir, "test-wallet")
+	withFiatQuoteSource(t, &stubFiatQuoteSource{rates: map[chain.ID]float64{chain.BSV: 48.25, chain.ETH: 3000}})
+
+	cfg := &mockConfigProvider{home: tmpDir, ethRPC: "http://localhost:8545", price: config.PriceConfig{Fiat: "usd"}}
+	cmd, buf, networks := newWarmupTestCmd(t, cfg, output.FormatText, nil, nil)
+	require.NoError(t, runWarmup(cmd, nil))
+
+	assert.Equal(t, []string{"main"}, *networks, "one fee quote per network")
+	text := buf.String()
+	assert.Contains(t, text, "Warming up 1 wallet(s)...")
+	assert.Contains(t, text, "  fees (bsv main): 100 sat/KB from whatsonchain\n")
+	assert.Contains(t, text, "  gas (eth): 1.00 Gwei / 1.00 Gwei / 1.00 Gwei from oracle\n")
+	assert.Contains(t, text, "  prices (USD): bsv 48.25, eth 3000.00\n")
+	assert.Contains(t, text, "  balances (test-wallet): 0 refreshed, 2 skipped, 0 error(s)\n")
+}
+
+//nolint:paralleltest // Replaces package-level fetchers and flags
+func TestRunWarmup_JSONAndFailures(t *testing.T) {
+	tmpDir, cleanup := setupTestEnv(t)
+	defer cleanup()
+
+	createTestWalletForAgent(t, tmpDir)
+	warmBalanceCache(t, tmpDir, "test-wallet")
+
+	// A fee outage alone is not fatal while balances are warm
+	cfg := &mockConfigProvider{home: tmpDir}
+	cmd, buf, _ := newWarmupTestCmd(t, cfg, output.FormatJSON, errWarmupOffline, nil)
+	require.NoError(t, runWarmup(cmd, nil))
+
+	var resp struct {
+		Steps  []warmupStep `json:"steps"`
+		Failed int          `json:"failed"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &resp))
+	require.Len(t, resp.Steps, 1, "no gas without eth.rpc, no prices without a currency")
+	assert.Equal(t, "offline", resp.Steps[0].Error)
+	assert.Equal(t, 1, resp.Failed)
+
+	// Every step failing is an error
+	warmupWallets = []string{"missing"}
+	buf.Reset()
+	err := runWarmup(cmd, nil)
+	require.ErrorIs(t, err, sigilerr.ErrNetworkError)
+}
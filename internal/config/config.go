@@ -2,8 +2,11 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -11,117 +14,377 @@ import (
 
 // Config represents the application configuration.
 type Config struct {
-	Version    int              `yaml:"version"`
-	Home       string           `yaml:"home"`
-	Encryption EncryptionConfig `yaml:"encryption"`
-	Networks   NetworksConfig   `yaml:"networks"`
-	Fees       FeesConfig       `yaml:"fees"`
-	Derivation DerivationConfig `yaml:"derivation"`
-	Security   SecurityConfig   `yaml:"security"`
-	Output     OutputConfig     `yaml:"output"`
-	Logging    LoggingConfig    `yaml:"logging"`
+	Version int    `yaml:"version" toml:"version"`
+	Home    string `yaml:"home" toml:"home"`
+	// DefaultWallet is used by commands that take --wallet when the flag is omitted.
+	DefaultWallet string              `yaml:"default_wallet,omitempty" toml:"default_wallet,omitempty"`
+	Encryption    EncryptionConfig    `yaml:"encryption" toml:"encryption"`
+	Networks      NetworksConfig      `yaml:"networks" toml:"networks"`
+	Fees          FeesConfig          `yaml:"fees" toml:"fees"`
+	Derivation    DerivationConfig    `yaml:"derivation" toml:"derivation"`
+	Security      SecurityConfig      `yaml:"security" toml:"security"`
+	Output        OutputConfig        `yaml:"output" toml:"output"`
+	Cache         CacheConfig         `yaml:"cache" toml:"cache"`
+	Logging       LoggingConfig       `yaml:"logging" toml:"logging"`
+	Hooks         HooksConfig         `yaml:"hooks" toml:"hooks"`
+	Quotas        QuotaConfig         `yaml:"quotas" toml:"quotas"`
+	HTTP          HTTPConfig          `yaml:"http" toml:"http"`
+	Price         PriceConfig         `yaml:"price" toml:"price"`
+	Outbox        OutboxConfig        `yaml:"outbox" toml:"outbox"`
+	Confirmations ConfirmationsConfig `yaml:"confirmations" toml:"confirmations"`
+	Concurrency   ConcurrencyConfig   `yaml:"concurrency" toml:"concurrency"`
+	// WalletTemplates are user-defined templates for wallet create --template,
+	// keyed by name. A template named like a built-in one replaces it.
+	WalletTemplates map[string]WalletTemplateConfig `yaml:"wallet_templates" toml:"wallet_templates"`
+	// Explorers replace the built-in block explorer links, keyed by chain and
+	// network such as "eth-test" or "bsv-main".
+	Explorers map[string]ExplorerConfig `yaml:"explorers" toml:"explorers"`
 
 	// Warnings collects non-fatal warnings from configuration loading/validation.
-	Warnings []string `yaml:"-"`
+	Warnings []string `yaml:"-" toml:"-"`
+
+	// ethMainnet holds the mainnet ETH settings while UseNetwork has
+	// switched Networks.ETH to a testnet.
+	ethMainnet *ETHNetworkConfig
 }
 
 // EncryptionConfig defines encryption settings.
 type EncryptionConfig struct {
-	Method        string `yaml:"method"`
-	IdentityFile  string `yaml:"identity_file"`
-	KeyDerivation string `yaml:"key_derivation"`
+	Method        string `yaml:"method" toml:"method"`
+	IdentityFile  string `yaml:"identity_file" toml:"identity_file"`
+	KeyDerivation string `yaml:"key_derivation" toml:"key_derivation"`
 }
 
 // NetworksConfig defines per-chain network settings.
 type NetworksConfig struct {
-	ETH ETHNetworkConfig `yaml:"eth"`
-	BSV BSVNetworkConfig `yaml:"bsv"`
-	BTC BTCNetworkConfig `yaml:"btc"`
-	BCH BCHNetworkConfig `yaml:"bch"`
+	ETH ETHNetworkConfig `yaml:"eth" toml:"eth"`
+	BSV BSVNetworkConfig `yaml:"bsv" toml:"bsv"`
+	BTC BTCNetworkConfig `yaml:"btc" toml:"btc"`
+	BCH BCHNetworkConfig `yaml:"bch" toml:"bch"`
 }
 
 // ETHNetworkConfig defines Ethereum network settings.
 type ETHNetworkConfig struct {
-	Enabled         bool          `yaml:"enabled"`
-	RPC             string        `yaml:"rpc"`
-	FallbackRPCs    []string      `yaml:"fallback_rpcs,omitempty"`
-	ChainID         int           `yaml:"chain_id"`
-	Tokens          []TokenConfig `yaml:"tokens"`
-	Provider        string        `yaml:"provider,omitempty"`          // "rpc" or "etherscan"; default "etherscan"
-	EtherscanAPIKey string        `yaml:"etherscan_api_key,omitempty"` // Etherscan API key
+	Enabled         bool          `yaml:"enabled" toml:"enabled"`
+	RPC             string        `yaml:"rpc" toml:"rpc"`
+	FallbackRPCs    []string      `yaml:"fallback_rpcs,omitempty" toml:"fallback_rpcs,omitempty"`
+	ChainID         int           `yaml:"chain_id" toml:"chain_id"`
+	Tokens          []TokenConfig `yaml:"tokens" toml:"tokens"`
+	Provider        string        `yaml:"provider,omitempty" toml:"provider,omitempty"`                                 // "rpc" or "etherscan"; default "etherscan"
+	EtherscanAPIKey string        `yaml:"etherscan_api_key,omitempty" toml:"etherscan_api_key,omitempty" secret:"true"` // Etherscan API key
+	// BalanceSources orders the balance read path ("etherscan", "rpc",
+	// "cache"); sources left out are never used. Empty derives the order
+	// from Provider, with the cache last.
+	BalanceSources []string `yaml:"balance_sources,omitempty" toml:"balance_sources,omitempty"`
+	// RPCProfiles names alternative RPC URLs, such as "mainnet-alchemy",
+	// that --rpc-profile or RPCProfile select in place of RPC.
+	RPCProfiles map[string]string `yaml:"rpc_profiles" toml:"rpc_profiles"`
+	// RPCProfile is the profile used when --rpc-profile is not given; empty
+	// uses RPC.
+	RPCProfile string `yaml:"rpc_profile,omitempty" toml:"rpc_profile,omitempty"`
+	// Sepolia replaces the RPCs, chain ID and tokens above on testnet.
+	Sepolia ETHSepoliaConfig `yaml:"sepolia" toml:"sepolia"`
+}
+
+// ETHSepoliaConfig defines the endpoints and tokens used in place of the
+// mainnet ones when a command runs on testnet.
+type ETHSepoliaConfig struct {
+	RPC          string        `yaml:"rpc" toml:"rpc"`
+	FallbackRPCs []string      `yaml:"fallback_rpcs,omitempty" toml:"fallback_rpcs,omitempty"`
+	Tokens       []TokenConfig `yaml:"tokens" toml:"tokens"`
 }
 
 // TokenConfig defines an ERC-20 token to track.
 type TokenConfig struct {
-	Symbol   string `yaml:"symbol"`
-	Address  string `yaml:"address"`
-	Decimals int    `yaml:"decimals"`
+	Symbol   string `yaml:"symbol" toml:"symbol"`
+	Address  string `yaml:"address" toml:"address"`
+	Decimals int    `yaml:"decimals" toml:"decimals"`
+	// FeeOnTransfer marks a token that deducts a fee from each transfer, so
+	// the recipient gets less than was sent.
+	FeeOnTransfer bool `yaml:"fee_on_transfer,omitempty" toml:"fee_on_transfer,omitempty"`
+	// Rebasing marks a token whose balances change without transfers, so the
+	// amount received may not match the amount sent.
+	Rebasing bool `yaml:"rebasing,omitempty" toml:"rebasing,omitempty"`
 }
 
 // BSVNetworkConfig defines BSV network settings.
 type BSVNetworkConfig struct {
-	Enabled bool `yaml:"enabled"`
+	Enabled bool `yaml:"enabled" toml:"enabled"`
 	// Network selects the BSV chain: "main" (default) or "test" (testnet).
-	Network string `yaml:"network"`
+	Network string `yaml:"network" toml:"network"`
 	// API and Broadcast are reserved provider selectors kept for config
 	// back-compat; they are not currently mapped to behavior.
-	API       string `yaml:"api"`
-	Broadcast string `yaml:"broadcast"`
-	APIKey    string `yaml:"api_key"`
+	API       string `yaml:"api" toml:"api"`
+	Broadcast string `yaml:"broadcast" toml:"broadcast"`
+	APIKey    string `yaml:"api_key" toml:"api_key" secret:"true"`
+	// BalanceSources orders the balance read path ("whatsonchain", "cache");
+	// sources left out are never used. Empty means whatsonchain, then cache.
+	BalanceSources []string `yaml:"balance_sources,omitempty" toml:"balance_sources,omitempty"`
+	// Node is a BSV node's JSON-RPC endpoint, read by utxo refresh --filters.
+	Node BSVNodeConfig `yaml:"node,omitempty" toml:"node,omitempty"`
+	// UTXOSync selects how utxo refresh finds UTXOs: "api" (default) queries
+	// WhatsOnChain per address; "filters" matches compact block filters from
+	// Node locally (experimental).
+	UTXOSync string `yaml:"utxo_sync,omitempty" toml:"utxo_sync,omitempty"`
+	// UTXOArchiveDays makes utxo refresh move UTXOs spent more than this many
+	// days ago to per-year archive files. Zero (default) never archives.
+	UTXOArchiveDays int `yaml:"utxo_archive_days,omitempty" toml:"utxo_archive_days,omitempty"`
+}
+
+// BSVNodeConfig defines a BSV node JSON-RPC endpoint. The node must keep a
+// BIP158 block filter index (getblockfilter).
+type BSVNodeConfig struct {
+	RPC      string `yaml:"rpc,omitempty" toml:"rpc,omitempty"`
+	User     string `yaml:"user,omitempty" toml:"user,omitempty"`
+	Password string `yaml:"password,omitempty" toml:"password,omitempty" secret:"true"`
 }
 
 // BTCNetworkConfig defines BTC network settings.
 type BTCNetworkConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	API     string `yaml:"api"`
+	Enabled bool   `yaml:"enabled" toml:"enabled"`
+	API     string `yaml:"api" toml:"api"`
 }
 
 // BCHNetworkConfig defines BCH network settings.
 type BCHNetworkConfig struct {
-	Enabled bool   `yaml:"enabled"`
-	API     string `yaml:"api"`
+	Enabled bool   `yaml:"enabled" toml:"enabled"`
+	API     string `yaml:"api" toml:"api"`
 }
 
 // FeesConfig defines fee estimation settings.
 type FeesConfig struct {
-	Provider            string `yaml:"provider"`
-	FallbackSatsPerByte int    `yaml:"fallback_sats_per_byte"`
-	MaxSatsPerByte      int    `yaml:"max_sats_per_byte"`
-	ETHGasStrategy      string `yaml:"eth_gas_strategy"`
-	BSVFeeStrategy      string `yaml:"bsv_fee_strategy"`
-	BSVMinMiners        int    `yaml:"bsv_min_miners"`
+	Provider            string `yaml:"provider" toml:"provider"`
+	FallbackSatsPerByte int    `yaml:"fallback_sats_per_byte" toml:"fallback_sats_per_byte"`
+	MaxSatsPerByte      int    `yaml:"max_sats_per_byte" toml:"max_sats_per_byte"`
+	ETHGasStrategy      string `yaml:"eth_gas_strategy" toml:"eth_gas_strategy"`
+	// ETHGasMarginPercent is the safety margin added to eth_estimateGas
+	// results. Zero uses the default of 20%.
+	ETHGasMarginPercent int    `yaml:"eth_gas_margin_percent" toml:"eth_gas_margin_percent"`
+	BSVFeeStrategy      string `yaml:"bsv_fee_strategy" toml:"bsv_fee_strategy"`
+	BSVMinMiners        int    `yaml:"bsv_min_miners" toml:"bsv_min_miners"`
+	// BSVCoinSelection is the default UTXO selection strategy for BSV sends
+	// (largest-first, smallest-first, branch-and-bound, manual).
+	BSVCoinSelection string `yaml:"bsv_coin_selection" toml:"bsv_coin_selection"`
 }
 
 // DerivationConfig defines key derivation settings.
 type DerivationConfig struct {
-	DefaultAccount int               `yaml:"default_account"`
-	AddressGap     int               `yaml:"address_gap"`
-	Paths          map[string]string `yaml:"paths"`
+	DefaultAccount int               `yaml:"default_account" toml:"default_account"`
+	AddressGap     int               `yaml:"address_gap" toml:"address_gap"`
+	Paths          map[string]string `yaml:"paths" toml:"paths"`
 }
 
 // SecurityConfig defines security settings.
 type SecurityConfig struct {
-	AutoLockSeconds     int     `yaml:"auto_lock_seconds"`
-	RequireConfirmAbove float64 `yaml:"require_confirm_above"`
-	MemoryLock          bool    `yaml:"memory_lock"`
-	SessionEnabled      bool    `yaml:"session_enabled"`
-	SessionTTLMinutes   int     `yaml:"session_ttl_minutes"`
+	AutoLockSeconds int `yaml:"auto_lock_seconds" toml:"auto_lock_seconds"`
+	// RequireConfirmAbove is the USD value at or above which tx send must be
+	// approved by typing the confirmation code from the review screen instead
+	// of answering y. Zero disables it.
+	RequireConfirmAbove float64 `yaml:"require_confirm_above" toml:"require_confirm_above"`
+	MemoryLock          bool    `yaml:"memory_lock" toml:"memory_lock"`
+	SessionEnabled      bool    `yaml:"session_enabled" toml:"session_enabled"`
+	SessionTTLMinutes   int     `yaml:"session_ttl_minutes" toml:"session_ttl_minutes"`
+	// MinPasswordEntropy is the minimum estimated strength, in bits, of a new
+	// wallet password. Zero disables the strength check.
+	MinPasswordEntropy float64 `yaml:"min_password_entropy" toml:"min_password_entropy"`
+	// BreachedPasswordList is an optional sorted SHA-1 breached password list
+	// (Have I Been Pwned format) that new wallet passwords are checked against.
+	BreachedPasswordList string `yaml:"breached_password_list" toml:"breached_password_list"`
+	// Cosign sends every transaction to an external policy service for a
+	// signed approval before it is broadcast.
+	Cosign CosignConfig `yaml:"cosign" toml:"cosign"`
+}
+
+// CosignConfig defines the external transaction approval service.
+type CosignConfig struct {
+	// URL is the policy service endpoint. Empty disables co-signing.
+	URL string `yaml:"url" toml:"url"`
+	// PublicKey is the hex ed25519 key the service signs decisions with.
+	PublicKey string `yaml:"public_key" toml:"public_key"`
+	// TimeoutSeconds is how long to wait for a decision before aborting.
+	TimeoutSeconds int `yaml:"timeout_seconds" toml:"timeout_seconds"`
+}
+
+// HooksConfig defines user scripts run around a send.
+type HooksConfig struct {
+	// PreSend is run before a transaction is signed; a non-zero exit aborts
+	// the send. Empty disables it.
+	PreSend string `yaml:"pre_send" toml:"pre_send"`
+	// PostSend is run after a transaction is broadcast. Its failure is only
+	// reported. Empty disables it.
+	PostSend string `yaml:"post_send" toml:"post_send"`
+	// TimeoutSeconds bounds each hook run.
+	TimeoutSeconds int `yaml:"timeout_seconds" toml:"timeout_seconds"`
+}
+
+// QuotaConfig defines the daily API quotas that usage warnings are based on.
+type QuotaConfig struct {
+	// EtherscanDaily is the daily request quota of the Etherscan API key.
+	// Zero disables the quota warning.
+	EtherscanDaily int `yaml:"etherscan_daily" toml:"etherscan_daily"`
+	// WhatsOnChainDaily is the daily request quota of the WhatsOnChain API
+	// key. Zero, the default, warns only from rate-limit headers.
+	WhatsOnChainDaily int `yaml:"whatsonchain_daily" toml:"whatsonchain_daily"`
+	// WarnPercent is the share of a quota at which sigil starts warning.
+	WarnPercent int `yaml:"warn_percent" toml:"warn_percent"`
+}
+
+// HTTPConfig defines how sigil identifies itself to provider APIs.
+type HTTPConfig struct {
+	// UserAgent replaces the User-Agent header of every provider request.
+	// Empty keeps each client's default.
+	UserAgent string `yaml:"user_agent" toml:"user_agent"`
+	// Signing signs provider requests so a self-hosted gateway can tell
+	// which sigil instance made each call.
+	Signing RequestSigningConfig `yaml:"signing" toml:"signing"`
+}
+
+// RequestSigningConfig defines HMAC-SHA256 signing of provider requests.
+// Requests are signed when both KeyID and Secret are set.
+type RequestSigningConfig struct {
+	// KeyID names this sigil instance to the gateway.
+	KeyID string `yaml:"key_id" toml:"key_id"`
+	// Secret is the HMAC key shared with the gateway.
+	Secret string `yaml:"secret" toml:"secret" secret:"true"`
+	// Hosts limits signing to these hosts; "*.example.com" matches any
+	// subdomain. Empty signs requests to every provider.
+	Hosts []string `yaml:"hosts,omitempty" toml:"hosts,omitempty"`
+}
+
+// PriceConfig defines the price API used to show fiat values next to coin
+// amounts.
+type PriceConfig struct {
+	// API is "coingecko" or the base URL of an API that serves CoinGecko's
+	// /simple/price endpoint.
+	API string `yaml:"api" toml:"api"`
+	// APIKey is an optional CoinGecko API key.
+	APIKey string `yaml:"api_key" toml:"api_key" secret:"true"`
+	// Fiat is the currency (usd or eur) that balance show and tx send value
+	// amounts in when --fiat is not given. Empty shows no fiat values.
+	Fiat string `yaml:"fiat" toml:"fiat"`
+	// CacheMinutes is how long a fetched price is reused, from
+	// ~/.sigil/cache/prices.json, before it is fetched again.
+	CacheMinutes int `yaml:"cache_minutes" toml:"cache_minutes"`
+}
+
+// OutboxConfig defines the broadcast outbox, where signed UTXO transactions
+// are queued when every broadcast provider is unreachable.
+type OutboxConfig struct {
+	// Enabled queues unreachable sends by default, as tx send --queue does.
+	Enabled bool `yaml:"enabled" toml:"enabled"`
+	// ExpiryHours is how long a queued transaction is retried before it is
+	// released and its coins can be spent again.
+	ExpiryHours int `yaml:"expiry_hours" toml:"expiry_hours"`
+	// FlushIntervalSeconds is how often sigil serve flushes the outbox.
+	// Zero disables the automatic flush.
+	FlushIntervalSeconds int `yaml:"flush_interval_seconds" toml:"flush_interval_seconds"`
+}
+
+// ConfirmationsConfig defines how many confirmations a transaction needs on
+// each chain before sigil treats it as confirmed. Zero means one.
+type ConfirmationsConfig struct {
+	ETH int `yaml:"eth" toml:"eth"`
+	BSV int `yaml:"bsv" toml:"bsv"`
+	BTC int `yaml:"btc" toml:"btc"`
+	BCH int `yaml:"bch" toml:"bch"`
+}
+
+// Target returns the confirmation target of a chain (eth, bsv, btc, or bch),
+// at least 1. Unknown chains need one confirmation.
+func (c ConfirmationsConfig) Target(chainName string) uint64 {
+	var n int
+	switch strings.ToLower(chainName) {
+	case "eth":
+		n = c.ETH
+	case "bsv":
+		n = c.BSV
+	case "btc":
+		n = c.BTC
+	case "bch":
+		n = c.BCH
+	}
+	if n < 1 {
+		return 1
+	}
+	return uint64(n)
+}
+
+// ConcurrencyConfig defines how many balance fetches run in parallel on
+// chains without a bulk balance API.
+type ConcurrencyConfig struct {
+	// Adaptive grows parallel fetches while providers answer quickly and
+	// halves them on errors or latency spikes. Off runs a fixed Max.
+	Adaptive bool `yaml:"adaptive" toml:"adaptive"`
+	// Initial is the number of parallel fetches an adaptive run starts with.
+	Initial int `yaml:"initial" toml:"initial"`
+	// Max caps parallel fetches.
+	Max int `yaml:"max" toml:"max"`
 }
 
 // OutputConfig defines output formatting settings.
 type OutputConfig struct {
-	DefaultFormat string `yaml:"default_format"`
-	Color         string `yaml:"color"`
-	Verbose       bool   `yaml:"verbose"`
+	DefaultFormat string `yaml:"default_format" toml:"default_format"`
+	Color         string `yaml:"color" toml:"color"`
+	Verbose       bool   `yaml:"verbose" toml:"verbose"`
+}
+
+// CacheConfig bounds the balance cache file, ~/.sigil/cache/balances.json
+// (balances-test.json on testnet).
+type CacheConfig struct {
+	// MaxAgeDays evicts balances not updated in this many days. Zero keeps
+	// them regardless of age.
+	MaxAgeDays int `yaml:"max_age_days" toml:"max_age_days"`
+	// MaxEntries caps the number of cached balances, evicting the least
+	// recently used first. Zero means no cap.
+	MaxEntries int `yaml:"max_entries" toml:"max_entries"`
+
+	// Network is the network whose balances are cached, set by UseNetwork.
+	// Testnet balances are kept in a file of their own, since an ETH
+	// address is the same on mainnet and Sepolia.
+	Network string `yaml:"-" toml:"-"`
+}
+
+// ExplorerConfig is a block explorer for one chain and network. The URLs
+// are templates: {txid} and {address} are replaced by the transaction ID or
+// address. A URL left empty keeps the built-in one.
+type ExplorerConfig struct {
+	Tx      string `yaml:"tx,omitempty" toml:"tx,omitempty"`
+	Address string `yaml:"address,omitempty" toml:"address,omitempty"`
+}
+
+// WalletTemplateConfig predefines the settings of a new wallet. Zero values
+// fall back to the wallet create defaults and the global configuration.
+type WalletTemplateConfig struct {
+	Description string `yaml:"description,omitempty" toml:"description,omitempty"`
+	// Chains are the wallet's enabled chains. Empty uses every enabled network.
+	Chains []string `yaml:"chains,omitempty" toml:"chains,omitempty"`
+	// Addresses is the number of receive addresses derived per chain.
+	Addresses int `yaml:"addresses,omitempty" toml:"addresses,omitempty"`
+	// Labels are applied in order to the first receive addresses of each chain.
+	Labels     []string `yaml:"labels,omitempty" toml:"labels,omitempty"`
+	Words      int      `yaml:"words,omitempty" toml:"words,omitempty"`
+	Passphrase bool     `yaml:"passphrase,omitempty" toml:"passphrase,omitempty"`
+	// BSVFeeStrategy and ETHGasSpeed are the wallet's default fee settings,
+	// overriding fees.bsv_fee_strategy and the tx send --gas default.
+	BSVFeeStrategy string `yaml:"bsv_fee_strategy,omitempty" toml:"bsv_fee_strategy,omitempty"`
+	ETHGasSpeed    string `yaml:"eth_gas_speed,omitempty" toml:"eth_gas_speed,omitempty"`
+	// RequireConfirmAbove overrides security.require_confirm_above for the wallet.
+	RequireConfirmAbove float64 `yaml:"require_confirm_above,omitempty" toml:"require_confirm_above,omitempty"`
 }
 
 // LoggingConfig defines logging settings.
 type LoggingConfig struct {
-	Level string `yaml:"level"`
-	File  string `yaml:"file"`
+	Level string `yaml:"level" toml:"level"`
+	File  string `yaml:"file" toml:"file"`
+	// Format is the log file encoding: "text" or "json".
+	Format string `yaml:"format,omitempty" toml:"format,omitempty"`
 }
 
-// Load reads configuration from the specified file.
+// ErrEnvOnly indicates a config write was attempted in environment-only mode.
+var ErrEnvOnly = errors.New("configuration is environment-only (SIGIL_CONFIG=env); set SIGIL_* variables instead")
+
+// Load reads configuration from the specified file. Files ending in .toml
+// are parsed as TOML; anything else is parsed as YAML.
 func Load(path string) (*Config, error) {
 	// #nosec G304 -- config file path is from validated user input
 	data, err := os.ReadFile(path)
@@ -130,22 +393,36 @@ func Load(path string) (*Config, error) {
 	}
 
 	cfg := Defaults()
-	if err := yaml.Unmarshal(data, cfg); err != nil {
+	if IsTOML(path) {
+		err = decodeTOML(data, cfg)
+	} else {
+		err = yaml.Unmarshal(data, cfg)
+	}
+	if err != nil {
 		return nil, err
 	}
 
 	return cfg, nil
 }
 
-// Save writes configuration to the specified file.
+// Save writes configuration to the specified file, as TOML if the path ends
+// in .toml and as YAML otherwise. It refuses to write in environment-only mode.
 func Save(cfg *Config, path string) error {
+	if EnvOnly() {
+		return ErrEnvOnly
+	}
+
 	// Ensure directory exists
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0o750); err != nil {
 		return err
 	}
 
-	data, err := yaml.Marshal(cfg)
+	format := FormatYAML
+	if IsTOML(path) {
+		format = FormatTOML
+	}
+	data, err := Marshal(cfg, format)
 	if err != nil {
 		return err
 	}
@@ -153,11 +430,143 @@ func Save(cfg *Config, path string) error {
 	return os.WriteFile(path, data, 0o600)
 }
 
+// Config file formats accepted by Marshal.
+const (
+	FormatYAML = "yaml"
+	FormatTOML = "toml"
+)
+
+// ErrUnknownFormat indicates an unsupported config file format.
+var ErrUnknownFormat = errors.New("unknown config format")
+
+// Marshal encodes cfg as a config file in the given format.
+func Marshal(cfg *Config, format string) ([]byte, error) {
+	switch format {
+	case FormatYAML:
+		return yaml.Marshal(cfg)
+	case FormatTOML:
+		return encodeTOML(cfg)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, format)
+	}
+}
+
+// Redact returns a copy of cfg in which every non-empty string field tagged
+// `secret:"true"` is replaced with placeholder. New secret fields only need the
+// tag to be kept out of printed configuration.
+func Redact(cfg *Config, placeholder string) *Config {
+	redacted := *cfg
+	redactSecrets(reflect.ValueOf(&redacted).Elem(), placeholder)
+	return &redacted
+}
+
+// redactSecrets walks the exported fields of a struct value. Slices of
+// structs are copied before they are changed so the original config keeps
+// its values.
+func redactSecrets(v reflect.Value, placeholder string) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field, sf := v.Field(i), t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		switch field.Kind() { //nolint:exhaustive // only strings and containers of structs can hold secrets
+		case reflect.String:
+			if sf.Tag.Get("secret") == "true" && field.String() != "" {
+				field.SetString(placeholder)
+			}
+		case reflect.Struct:
+			redactSecrets(field, placeholder)
+		case reflect.Slice:
+			if field.Len() == 0 || field.Type().Elem().Kind() != reflect.Struct {
+				continue
+			}
+			items := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+			reflect.Copy(items, field)
+			for j := range items.Len() {
+				redactSecrets(items.Index(j), placeholder)
+			}
+			field.Set(items)
+		}
+	}
+}
+
+// Secrets returns the non-empty values of every field tagged
+// `secret:"true"`, so they can be removed from text such as log files.
+func Secrets(cfg *Config) []string {
+	var values []string
+	collectSecrets(reflect.ValueOf(cfg).Elem(), &values)
+	return values
+}
+
+// collectSecrets walks the exported fields of a struct value like
+// redactSecrets, appending secret values instead of replacing them.
+func collectSecrets(v reflect.Value, values *[]string) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field, sf := v.Field(i), t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		switch field.Kind() { //nolint:exhaustive // only strings and containers of structs can hold secrets
+		case reflect.String:
+			if sf.Tag.Get("secret") == "true" && field.String() != "" {
+				*values = append(*values, field.String())
+			}
+		case reflect.Struct:
+			collectSecrets(field, values)
+		case reflect.Slice:
+			if field.Type().Elem().Kind() != reflect.Struct {
+				continue
+			}
+			for j := range field.Len() {
+				collectSecrets(field.Index(j), values)
+			}
+		}
+	}
+}
+
 // Path returns the default config file path.
 func Path(home string) string {
 	return filepath.Join(home, "config.yaml")
 }
 
+// TOMLPath returns the TOML config file path.
+func TOMLPath(home string) string {
+	return filepath.Join(home, "config.toml")
+}
+
+// FindPath returns the config file for a home directory. SIGIL_CONFIG wins
+// when it names a file; otherwise config.yaml is used, or config.toml when
+// only that one exists.
+func FindPath(home string) string {
+	if v := os.Getenv(EnvConfig); v != "" && v != EnvOnlyMode {
+		return v
+	}
+
+	if yamlPath := Path(home); !fileExists(yamlPath) && fileExists(TOMLPath(home)) {
+		return TOMLPath(home)
+	}
+	return Path(home)
+}
+
+// EnvOnly reports whether SIGIL_CONFIG=env selects environment-only mode,
+// where no config file is read or written.
+func EnvOnly() bool {
+	return os.Getenv(EnvConfig) == EnvOnlyMode
+}
+
+// IsTOML reports whether a config path names a TOML file.
+func IsTOML(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".toml")
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
 // GetHome returns the sigil home directory path.
 func (c *Config) GetHome() string {
 	return c.Home
@@ -186,25 +595,67 @@ func (c *Config) GetBSVNetwork() string {
 }
 
 // NormalizeBSVNetwork maps a user-supplied network string to its canonical form.
-// It accepts "main"/"mainnet" and "test"/"testnet" (case-insensitive), returning
-// the canonical "main" or "test". The empty string resolves to "main". The bool
-// is false only when the value was non-empty but unrecognized (invalid input).
+// It accepts "main"/"mainnet" and "test"/"testnet"/"sepolia" (case-insensitive),
+// returning the canonical "main" or "test". The empty string resolves to "main".
+// The bool is false only when the value was non-empty but unrecognized (invalid input).
 func NormalizeBSVNetwork(s string) (string, bool) {
 	switch strings.ToLower(strings.TrimSpace(s)) {
 	case "", "main", "mainnet":
 		return "main", true
-	case "test", "testnet":
+	case "test", "testnet", "sepolia":
 		return "test", true
 	default:
 		return "main", false
 	}
 }
 
+// GetBTCAPI returns the BTC Esplora API preset ("mempool", "blockstream")
+// or base URL.
+func (c *Config) GetBTCAPI() string {
+	return c.Networks.BTC.API
+}
+
+// IsBTCEnabled reports whether BTC is enabled for new wallets, balances,
+// and sends.
+func (c *Config) IsBTCEnabled() bool {
+	return c.Networks.BTC.Enabled
+}
+
+// GetBCHAPI returns the BCH API preset ("fullstack") or base URL.
+func (c *Config) GetBCHAPI() string {
+	return c.Networks.BCH.API
+}
+
+// IsBCHEnabled reports whether BCH is enabled for new wallets, balances,
+// and sends.
+func (c *Config) IsBCHEnabled() bool {
+	return c.Networks.BCH.Enabled
+}
+
 // GetBSVBroadcast returns the BSV broadcast provider or custom URL.
 func (c *Config) GetBSVBroadcast() string {
 	return c.Networks.BSV.Broadcast
 }
 
+// GetBSVNode returns the BSV node JSON-RPC endpoint.
+func (c *Config) GetBSVNode() BSVNodeConfig {
+	return c.Networks.BSV.Node
+}
+
+// GetBSVUTXOSync returns how utxo refresh finds UTXOs ("api" or "filters").
+func (c *Config) GetBSVUTXOSync() string {
+	if c.Networks.BSV.UTXOSync == "" {
+		return "api"
+	}
+	return c.Networks.BSV.UTXOSync
+}
+
+// GetBSVUTXOArchiveDays returns the age in days after which utxo refresh
+// archives spent UTXOs, or 0 when it does not archive.
+func (c *Config) GetBSVUTXOArchiveDays() int {
+	return max(c.Networks.BSV.UTXOArchiveDays, 0)
+}
+
 // GetBSVFeeStrategy returns the BSV fee strategy (economy, normal, priority).
 func (c *Config) GetBSVFeeStrategy() string {
 	return c.Fees.BSVFeeStrategy
@@ -215,6 +666,16 @@ func (c *Config) GetBSVMinMiners() int {
 	return c.Fees.BSVMinMiners
 }
 
+// GetBSVCoinSelection returns the BSV coin selection strategy.
+func (c *Config) GetBSVCoinSelection() string {
+	return c.Fees.BSVCoinSelection
+}
+
+// GetETHGasMarginPercent returns the percentage added to ETH gas estimates.
+func (c *Config) GetETHGasMarginPercent() int {
+	return c.Fees.ETHGasMarginPercent
+}
+
 // GetLoggingLevel returns the configured logging level.
 func (c *Config) GetLoggingLevel() string {
 	return c.Logging.Level
@@ -240,6 +701,51 @@ func (c *Config) GetSecurity() SecurityConfig {
 	return c.Security
 }
 
+// GetHooks returns the send hook configuration.
+func (c *Config) GetHooks() HooksConfig {
+	return c.Hooks
+}
+
+// GetQuotas returns the API quota configuration.
+func (c *Config) GetQuotas() QuotaConfig {
+	return c.Quotas
+}
+
+// GetHTTP returns the provider request identification settings.
+func (c *Config) GetHTTP() HTTPConfig {
+	return c.HTTP
+}
+
+// GetPrice returns the price API settings.
+func (c *Config) GetPrice() PriceConfig {
+	return c.Price
+}
+
+// GetOutbox returns the broadcast outbox settings.
+func (c *Config) GetOutbox() OutboxConfig {
+	return c.Outbox
+}
+
+// GetConfirmations returns the per-chain confirmation targets.
+func (c *Config) GetConfirmations() ConfirmationsConfig {
+	return c.Confirmations
+}
+
+// GetConcurrency returns the balance fetch concurrency settings.
+func (c *Config) GetConcurrency() ConcurrencyConfig {
+	return c.Concurrency
+}
+
+// GetCache returns the balance cache limits.
+func (c *Config) GetCache() CacheConfig {
+	return c.Cache
+}
+
+// GetWalletTemplates returns the user-defined wallet templates.
+func (c *Config) GetWalletTemplates() map[string]WalletTemplateConfig {
+	return c.WalletTemplates
+}
+
 // GetETHProvider returns the ETH balance provider ("rpc" or "etherscan").
 func (c *Config) GetETHProvider() string {
 	if c.Networks.ETH.Provider == "" {
@@ -248,6 +754,78 @@ func (c *Config) GetETHProvider() string {
 	return c.Networks.ETH.Provider
 }
 
+// GetBalanceSources returns the ordered balance sources for a chain ("eth"
+// or "bsv"), applying the default order when none is configured.
+func (c *Config) GetBalanceSources(chainName string) []string {
+	switch chainName {
+	case "eth":
+		if len(c.Networks.ETH.BalanceSources) > 0 {
+			return c.Networks.ETH.BalanceSources
+		}
+		if c.GetETHProvider() == "rpc" {
+			return []string{"rpc", "etherscan", "cache"}
+		}
+		return []string{"etherscan", "rpc", "cache"}
+	case "bsv":
+		if len(c.Networks.BSV.BalanceSources) > 0 {
+			return c.Networks.BSV.BalanceSources
+		}
+		return []string{"whatsonchain", "cache"}
+	default:
+		return nil
+	}
+}
+
+// GetDefaultWallet returns the wallet used when --wallet is omitted.
+func (c *Config) GetDefaultWallet() string {
+	return c.DefaultWallet
+}
+
+// GetAddressGap returns the number of unused receive addresses kept after
+// the last used one, or 0 when address lists are never extended.
+func (c *Config) GetAddressGap() int {
+	return max(c.Derivation.AddressGap, 0)
+}
+
+// GetETHTokens returns the ERC-20 tokens listed in the configuration.
+func (c *Config) GetETHTokens() []TokenConfig {
+	return c.Networks.ETH.Tokens
+}
+
+// AddETHToken records a token in the configured token list, replacing any
+// existing entry with the same contract address.
+func (c *Config) AddETHToken(token TokenConfig) {
+	for i, t := range c.Networks.ETH.Tokens {
+		if strings.EqualFold(t.Address, token.Address) {
+			c.Networks.ETH.Tokens[i] = token
+			return
+		}
+	}
+	c.Networks.ETH.Tokens = append(c.Networks.ETH.Tokens, token)
+}
+
+// RemoveETHToken removes the configured token with the symbol
+// (case-insensitive) or contract address, reporting whether one was found.
+func (c *Config) RemoveETHToken(symbolOrAddress string) bool {
+	for i, t := range c.Networks.ETH.Tokens {
+		if strings.EqualFold(t.Address, symbolOrAddress) || strings.EqualFold(t.Symbol, symbolOrAddress) {
+			c.Networks.ETH.Tokens = append(c.Networks.ETH.Tokens[:i], c.Networks.ETH.Tokens[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// FindETHToken looks up a token by symbol (case-insensitive) or contract address.
+func FindETHToken(tokens []TokenConfig, symbolOrAddress string) (TokenConfig, bool) {
+	for _, t := range tokens {
+		if strings.EqualFold(t.Address, symbolOrAddress) || strings.EqualFold(t.Symbol, symbolOrAddress) {
+			return t, true
+		}
+	}
+	return TokenConfig{}, false
+}
+
 // GetETHEtherscanAPIKey returns the Etherscan API key.
 func (c *Config) GetETHEtherscanAPIKey() string {
 	return c.Networks.ETH.EtherscanAPIKey
@@ -3,6 +3,8 @@ package config_test
 import (
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -58,6 +60,8 @@ func TestDefaults(t *testing.T) {
 	assert.True(t, cfg.Security.MemoryLock)
 	assert.True(t, cfg.Security.SessionEnabled)
 	assert.Equal(t, 15, cfg.Security.SessionTTLMinutes)
+	assert.InDelta(t, 40.0, cfg.Security.MinPasswordEntropy, 0)
+	assert.Empty(t, cfg.Security.BreachedPasswordList)
 	assert.Equal(t, "auto", cfg.Output.DefaultFormat)
 	assert.Equal(t, "error", cfg.Logging.Level)
 }
@@ -479,6 +483,22 @@ func TestConfig_GetETHProvider_RPC(t *testing.T) {
 	assert.Equal(t, "rpc", cfg.GetETHProvider())
 }
 
+func TestConfig_GetBalanceSources(t *testing.T) {
+	t.Parallel()
+	cfg := config.Defaults()
+	assert.Equal(t, []string{"etherscan", "rpc", "cache"}, cfg.GetBalanceSources("eth"))
+	assert.Equal(t, []string{"whatsonchain", "cache"}, cfg.GetBalanceSources("bsv"))
+	assert.Nil(t, cfg.GetBalanceSources("btc"))
+
+	cfg.Networks.ETH.Provider = "rpc"
+	assert.Equal(t, []string{"rpc", "etherscan", "cache"}, cfg.GetBalanceSources("eth"))
+
+	cfg.Networks.ETH.BalanceSources = []string{"rpc"}
+	cfg.Networks.BSV.BalanceSources = []string{"cache"}
+	assert.Equal(t, []string{"rpc"}, cfg.GetBalanceSources("eth"))
+	assert.Equal(t, []string{"cache"}, cfg.GetBalanceSources("bsv"))
+}
+
 func TestConfig_GetETHEtherscanAPIKey(t *testing.T) {
 	t.Parallel()
 	cfg := config.Defaults()
@@ -574,6 +594,7 @@ func TestDefaults_BSVMinMiners(t *testing.T) {
 	t.Parallel()
 	cfg := config.Defaults()
 	assert.Equal(t, 3, cfg.Fees.BSVMinMiners)
+	assert.Equal(t, "largest-first", cfg.GetBSVCoinSelection())
 }
 
 func TestLoadSave_RoundTrip_WithFeeStrategy(t *testing.T) {
@@ -889,3 +910,113 @@ func TestDefaults_DerivationDefaults(t *testing.T) {
 
 	assert.Equal(t, 20, cfg.Derivation.AddressGap, "AddressGap should default to 20")
 }
+
+// TestAddETHToken verifies tokens are appended or replaced by contract address.
+func TestAddETHToken(t *testing.T) {
+	t.Parallel()
+	cfg := config.Defaults()
+
+	dai := config.TokenConfig{Symbol: "DAI", Address: "0x6B175474E89094C44Da98b954EedeAC495271d0F", Decimals: 18}
+	cfg.AddETHToken(dai)
+	require.Len(t, cfg.GetETHTokens(), 2)
+
+	renamed := dai
+	renamed.Address = strings.ToLower(dai.Address)
+	renamed.Symbol = "XDAI"
+	cfg.AddETHToken(renamed)
+	require.Len(t, cfg.GetETHTokens(), 2)
+	assert.Equal(t, "XDAI", cfg.GetETHTokens()[1].Symbol)
+}
+
+// TestRemoveETHToken verifies tokens are removed by symbol or contract address.
+func TestRemoveETHToken(t *testing.T) {
+	t.Parallel()
+	cfg := config.Defaults()
+	cfg.AddETHToken(config.TokenConfig{Symbol: "UNI", Address: "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984", Decimals: 18})
+
+	assert.True(t, cfg.RemoveETHToken("uni"))
+	require.Len(t, cfg.GetETHTokens(), 1)
+	assert.True(t, cfg.RemoveETHToken("0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"))
+	assert.Empty(t, cfg.GetETHTokens())
+	assert.False(t, cfg.RemoveETHToken("UNI"))
+}
+
+// TestFindETHToken verifies lookup by symbol and by contract address.
+func TestFindETHToken(t *testing.T) {
+	t.Parallel()
+	tokens := config.Defaults().GetETHTokens()
+
+	tok, ok := config.FindETHToken(tokens, "usdc")
+	require.True(t, ok)
+	assert.Equal(t, 6, tok.Decimals)
+
+	tok, ok = config.FindETHToken(tokens, "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48")
+	require.True(t, ok)
+	assert.Equal(t, "USDC", tok.Symbol)
+
+	_, ok = config.FindETHToken(tokens, "DAI")
+	assert.False(t, ok)
+}
+
+func TestRedact(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Defaults()
+	cfg.Networks.ETH.EtherscanAPIKey = "eth-secret"
+	cfg.Networks.BSV.APIKey = ""
+
+	redacted := config.Redact(cfg, "<redacted>")
+	assert.Equal(t, "<redacted>", redacted.Networks.ETH.EtherscanAPIKey)
+	assert.Empty(t, redacted.Networks.BSV.APIKey, "unset secrets stay empty")
+	assert.Equal(t, cfg.Networks.ETH.RPC, redacted.Networks.ETH.RPC)
+	assert.Equal(t, "eth-secret", cfg.Networks.ETH.EtherscanAPIKey, "original config is unchanged")
+}
+
+func TestSecrets(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Defaults()
+	assert.Empty(t, config.Secrets(cfg))
+
+	cfg.Networks.ETH.EtherscanAPIKey = "eth-secret"
+	cfg.Networks.BSV.Node.Password = "node-secret"
+	assert.ElementsMatch(t, []string{"eth-secret", "node-secret"}, config.Secrets(cfg))
+}
+
+// TestSecretFieldsTagged guards against new credential fields being added
+// without the secret tag that keeps them out of config print.
+func TestSecretFieldsTagged(t *testing.T) {
+	t.Parallel()
+
+	var check func(typ reflect.Type, path string)
+	check = func(typ reflect.Type, path string) {
+		for i := range typ.NumField() {
+			sf := typ.Field(i)
+			switch sf.Type.Kind() { //nolint:exhaustive // only strings and nested structs are inspected
+			case reflect.String:
+				name := strings.ToLower(sf.Name)
+				if strings.Contains(name, "apikey") || strings.Contains(name, "secret") || strings.HasSuffix(name, "token") {
+					assert.Equal(t, "true", sf.Tag.Get("secret"), "%s.%s must be tagged secret", path, sf.Name)
+				}
+			case reflect.Struct:
+				check(sf.Type, path+"."+sf.Name)
+			case reflect.Slice:
+				if sf.Type.Elem().Kind() == reflect.Struct {
+					check(sf.Type.Elem(), path+"."+sf.Name)
+				}
+			}
+		}
+	}
+	check(reflect.TypeOf(config.Config{}), "Config")
+}
+
+func TestConfirmationsTarget(t *testing.T) {
+	t.Parallel()
+
+	c := config.ConfirmationsConfig{ETH: 12, BSV: 6}
+	assert.Equal(t, uint64(12), c.Target("eth"))
+	assert.Equal(t, uint64(6), c.Target("BSV"))
+	assert.Equal(t, uint64(1), c.Target("btc"), "zero needs one confirmation")
+	assert.Equal(t, uint64(1), c.Target("doge"), "unknown chains need one confirmation")
+	assert.Equal(t, uint64(1), config.Defaults().GetConfirmations().Target("eth"))
+}
@@ -13,6 +13,17 @@ var DefaultETHFallbackRPCs = []string{
 	"https://1rpc.io/eth",      // 1RPC - zero-trace privacy, burn-after-relaying
 }
 
+// DefaultSepoliaRPCURL is the default Ethereum RPC endpoint on testnet,
+// PublicNode's Sepolia endpoint.
+const DefaultSepoliaRPCURL = "https://ethereum-sepolia-rpc.publicnode.com"
+
+// DefaultSepoliaFallbackRPCs are backup Sepolia RPC endpoints.
+//
+//nolint:gochecknoglobals // Configuration default constant, same pattern as DefaultETHRPCURL
+var DefaultSepoliaFallbackRPCs = []string{
+	"https://1rpc.io/sepolia",
+}
+
 // Defaults returns the default configuration.
 func Defaults() *Config {
 	return &Config{
@@ -30,6 +41,7 @@ func Defaults() *Config {
 				FallbackRPCs: DefaultETHFallbackRPCs,
 				ChainID:      1,
 				Provider:     "etherscan",
+				RPCProfiles:  map[string]string{},
 				Tokens: []TokenConfig{
 					{
 						Symbol:   "USDC",
@@ -37,6 +49,17 @@ func Defaults() *Config {
 						Decimals: 6,
 					},
 				},
+				Sepolia: ETHSepoliaConfig{
+					RPC:          DefaultSepoliaRPCURL,
+					FallbackRPCs: DefaultSepoliaFallbackRPCs,
+					Tokens: []TokenConfig{
+						{
+							Symbol:   "USDC",
+							Address:  "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238",
+							Decimals: 6,
+						},
+					},
+				},
 			},
 			BSV: BSVNetworkConfig{
 				Enabled:   true,
@@ -59,8 +82,10 @@ func Defaults() *Config {
 			FallbackSatsPerByte: 1,
 			MaxSatsPerByte:      100,
 			ETHGasStrategy:      "medium",
+			ETHGasMarginPercent: 20,
 			BSVFeeStrategy:      "normal",
 			BSVMinMiners:        3,
+			BSVCoinSelection:    "largest-first",
 		},
 		Derivation: DerivationConfig{
 			DefaultAccount: 0,
@@ -69,19 +94,56 @@ func Defaults() *Config {
 		},
 		Security: SecurityConfig{
 			AutoLockSeconds:     0, // Disabled for MVP
-			RequireConfirmAbove: 0, // Disabled for MVP
+			RequireConfirmAbove: 0, // Typed confirmation codes are opt-in
 			MemoryLock:          true,
 			SessionEnabled:      true,
 			SessionTTLMinutes:   15,
+			MinPasswordEntropy:  40,
+			Cosign: CosignConfig{
+				TimeoutSeconds: 30,
+			},
 		},
 		Output: OutputConfig{
 			DefaultFormat: "auto",
 			Color:         "auto",
 			Verbose:       false,
 		},
+		Cache: CacheConfig{
+			MaxAgeDays: 30,
+			MaxEntries: 10000,
+		},
 		Logging: LoggingConfig{
-			Level: "error",
-			File:  "~/.sigil/sigil.log",
+			Level:  "error",
+			File:   "~/.sigil/sigil.log",
+			Format: "text",
+		},
+		Hooks: HooksConfig{
+			TimeoutSeconds: 30,
+		},
+		Quotas: QuotaConfig{
+			EtherscanDaily: 100000,
+			WarnPercent:    80,
+		},
+		Price: PriceConfig{
+			API:          "coingecko",
+			CacheMinutes: 5,
+		},
+		Outbox: OutboxConfig{
+			ExpiryHours:          24,
+			FlushIntervalSeconds: 60,
+		},
+		Confirmations: ConfirmationsConfig{
+			ETH: 1,
+			BSV: 1,
+			BTC: 1,
+			BCH: 1,
+		},
+		Concurrency: ConcurrencyConfig{
+			Adaptive: true,
+			Initial:  4,
+			Max:      16,
 		},
+		WalletTemplates: map[string]WalletTemplateConfig{},
+		Explorers:       map[string]ExplorerConfig{},
 	}
 }
@@ -25,6 +25,7 @@ const (
 	EnvOutputFormat    = "SIGIL_OUTPUT_FORMAT"
 	EnvVerbose         = "SIGIL_VERBOSE"
 	EnvLogLevel        = "SIGIL_LOG_LEVEL"
+	EnvLogFormat       = "SIGIL_LOG_FORMAT"
 	EnvNoColor         = "NO_COLOR"
 	EnvSessionTTL      = "SIGIL_SESSION_TTL"
 	EnvBSVFeeStrategy  = "SIGIL_BSV_FEE_STRATEGY"
@@ -32,12 +33,26 @@ const (
 	EnvBSVNetwork      = "SIGIL_BSV_NETWORK"
 	EnvAgentToken      = "SIGIL_AGENT_TOKEN" //nolint:gosec // G101 -- false positive, this is a const name not a credential
 	EnvAgentXpub       = "SIGIL_AGENT_XPUB"
+
+	// EnvAllowFaultInjection must be "1" for the hidden --fault-injection flag to take effect.
+	EnvAllowFaultInjection = "SIGIL_ALLOW_FAULT_INJECTION"
+
+	// EnvConfig names the config file to load, or is EnvOnlyMode to load none.
+	EnvConfig = "SIGIL_CONFIG"
 )
 
+// EnvOnlyMode is the SIGIL_CONFIG value that disables config files, so every
+// setting comes from defaults and SIGIL_* variables.
+const EnvOnlyMode = "env"
+
 // ApplyEnvironment applies environment variable overrides to the configuration.
+// Every config key can be set through its SIGIL_* variable (see EnvName); the
+// shorter variables below are applied afterwards and take precedence.
 //
 //nolint:gocognit,gocyclo // Environment variable overrides require sequential checks
 func ApplyEnvironment(cfg *Config) {
+	applyConfigEnv(cfg)
+
 	if v := os.Getenv(EnvHome); v != "" {
 		cfg.Home = v
 	}
@@ -59,11 +74,12 @@ func ApplyEnvironment(cfg *Config) {
 		}
 	}
 
-	if v := os.Getenv(EnvEtherscanAPIKey); v != "" {
+	// API keys may also come from secret files (see LookupSecret)
+	if v := lookupSecretEnv(cfg, EnvEtherscanAPIKey); v != "" {
 		cfg.Networks.ETH.EtherscanAPIKey = strings.TrimSpace(v)
 	}
 
-	if v := os.Getenv(EnvBSVAPIKey); v != "" {
+	if v := lookupSecretEnv(cfg, EnvBSVAPIKey); v != "" {
 		cfg.Networks.BSV.APIKey = v
 	}
 
@@ -95,7 +111,7 @@ func ApplyEnvironment(cfg *Config) {
 
 	// Fallback: accept the standard WhatsOnChain env var if sigil-specific one is not set
 	if cfg.Networks.BSV.APIKey == "" {
-		if v := os.Getenv(EnvWOCAPIKey); v != "" {
+		if v := lookupSecretEnv(cfg, EnvWOCAPIKey); v != "" {
 			cfg.Networks.BSV.APIKey = strings.TrimSpace(v)
 		}
 	}
@@ -112,6 +128,10 @@ func ApplyEnvironment(cfg *Config) {
 		cfg.Logging.Level = strings.ToLower(v)
 	}
 
+	if v := os.Getenv(EnvLogFormat); v != "" {
+		cfg.Logging.Format = strings.ToLower(v)
+	}
+
 	// NO_COLOR disables colored output
 	if _, ok := os.LookupEnv(EnvNoColor); ok {
 		cfg.Output.Color = "never"
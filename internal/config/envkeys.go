@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix prefixes the environment variable of every config key. A key's
+// variable is its dotted path in upper case with dots replaced by
+// underscores, e.g. networks.eth.rpc is SIGIL_NETWORKS_ETH_RPC.
+const EnvPrefix = "SIGIL_"
+
+// EnvVar is one config setting expressed as an environment variable.
+type EnvVar struct {
+	Name   string // Variable name, e.g. SIGIL_NETWORKS_ETH_RPC
+	Key    string // Dotted config key, e.g. networks.eth.rpc
+	Value  string
+	Secret bool // True for API keys and other credentials
+}
+
+// EnvName returns the environment variable for a dotted config key.
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.NewReplacer(".", "_", "-", "_").Replace(key))
+}
+
+// EnvVars returns every setting of cfg as SIGIL_* variables, in config file
+// order. Lists of strings are comma-separated; lists of tables and maps use
+// YAML flow syntax, e.g. [{symbol: USDC, address: 0x..., decimals: 6}].
+func EnvVars(cfg *Config) ([]EnvVar, error) {
+	var vars []EnvVar
+	var walkErr error
+	walkConfigFields(reflect.ValueOf(cfg).Elem(), "", func(key string, field reflect.Value) {
+		if walkErr != nil {
+			return
+		}
+		value, err := formatEnvValue(field)
+		if err != nil {
+			walkErr = fmt.Errorf("%s: %w", key, err)
+			return
+		}
+		vars = append(vars, EnvVar{
+			Name:   EnvName(key),
+			Key:    key,
+			Value:  value,
+			Secret: isSecretKey(key),
+		})
+	})
+	return vars, walkErr
+}
+
+// applyConfigEnv sets every config field whose SIGIL_* variable is set.
+// API keys may also come from secret files. Invalid values are skipped and
+// recorded in cfg.Warnings.
+func applyConfigEnv(cfg *Config) {
+	walkConfigFields(reflect.ValueOf(cfg).Elem(), "", func(key string, field reflect.Value) {
+		name := EnvName(key)
+		v, ok := os.LookupEnv(name)
+		if !ok && isSecretKey(key) {
+			v = lookupSecretEnv(cfg, name)
+			ok = v != ""
+		}
+		if !ok {
+			return
+		}
+		if err := setEnvValue(field, v); err != nil {
+			cfg.Warnings = append(cfg.Warnings, fmt.Sprintf("%s: %v", name, err))
+		}
+	})
+}
+
+// isSecretKey reports whether a config key holds a credential.
+func isSecretKey(key string) bool {
+	return strings.HasSuffix(key, "api_key") || key == "http.signing.secret"
+}
+
+// walkConfigFields calls fn for each leaf field of a config struct with its
+// dotted YAML key. Nested structs are descended; the version field and
+// fields without a YAML name are skipped.
+func walkConfigFields(v reflect.Value, prefix string, fn func(key string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if name == "" || name == "-" || (prefix == "" && name == "version") {
+			continue
+		}
+
+		key := name
+		if prefix != "" {
+			key = prefix + "." + name
+		}
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct {
+			walkConfigFields(field, key, fn)
+			continue
+		}
+		fn(key, field)
+	}
+}
+
+// formatEnvValue renders a config field as an environment variable value.
+func formatEnvValue(field reflect.Value) (string, error) {
+	//nolint:exhaustive // Config fields only use these kinds
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool()), nil
+	case reflect.Int, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64), nil
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.String {
+			return strings.Join(field.Interface().([]string), ","), nil
+		}
+	}
+	return flowYAML(field.Interface())
+}
+
+// flowYAML renders a value as single-line YAML flow syntax.
+func flowYAML(v any) (string, error) {
+	var node yaml.Node
+	if err := node.Encode(v); err != nil {
+		return "", err
+	}
+	setFlowStyle(&node)
+	data, err := yaml.Marshal(&node)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+func setFlowStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = yaml.FlowStyle
+	}
+	for _, child := range node.Content {
+		setFlowStyle(child)
+	}
+}
+
+// setEnvValue parses an environment variable value into a config field.
+func setEnvValue(field reflect.Value, v string) error {
+	v = strings.TrimSpace(v)
+
+	//nolint:exhaustive // Config fields only use these kinds
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(v)
+		return nil
+	case reflect.Bool:
+		b, ok := parseBoolStrict(v)
+		if !ok {
+			return fmt.Errorf("invalid boolean %q", v)
+		}
+		field.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", v)
+		}
+		field.SetInt(n)
+		return nil
+	case reflect.Float64:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", v)
+		}
+		field.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		if field.Type().Elem().Kind() == reflect.String && !strings.HasPrefix(v, "[") {
+			var items []string
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+			field.Set(reflect.ValueOf(items))
+			return nil
+		}
+	}
+
+	target := reflect.New(field.Type())
+	if err := yaml.Unmarshal([]byte(v), target.Interface()); err != nil {
+		return fmt.Errorf("invalid value: %w", err)
+	}
+	field.Set(target.Elem())
+	return nil
+}
+
+// parseBoolStrict parses a boolean, accepting yes/no and on/off as well.
+func parseBoolStrict(s string) (value, ok bool) {
+	switch strings.ToLower(s) {
+	case "yes", "on":
+		return true, true
+	case "no", "off":
+		return false, true
+	}
+	b, err := strconv.ParseBool(s)
+	return b, err == nil
+}
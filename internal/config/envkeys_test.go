@@ -0,0 +1,137 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "SIGIL_HOME", EnvName("home"))
+	assert.Equal(t, "SIGIL_NETWORKS_ETH_RPC", EnvName("networks.eth.rpc"))
+	assert.Equal(t, "SIGIL_NETWORKS_ETH_ETHERSCAN_API_KEY", EnvName("networks.eth.etherscan_api_key"))
+}
+
+func TestEnvVars(t *testing.T) {
+	t.Parallel()
+
+	cfg := Defaults()
+	cfg.Networks.ETH.FallbackRPCs = []string{"https://a.example", "https://b.example"}
+	cfg.Networks.BSV.APIKey = "secret"
+
+	vars, err := EnvVars(cfg)
+	require.NoError(t, err)
+
+	byName := make(map[string]EnvVar, len(vars))
+	for _, v := range vars {
+		byName[v.Name] = v
+	}
+
+	assert.NotContains(t, byName, "SIGIL_VERSION")
+	assert.Equal(t, "https://a.example,https://b.example", byName["SIGIL_NETWORKS_ETH_FALLBACK_RPCS"].Value)
+	assert.Equal(t, "networks.eth.fallback_rpcs", byName["SIGIL_NETWORKS_ETH_FALLBACK_RPCS"].Key)
+	assert.True(t, byName["SIGIL_NETWORKS_BSV_API_KEY"].Secret)
+	assert.Equal(t, "secret", byName["SIGIL_NETWORKS_BSV_API_KEY"].Value)
+	assert.False(t, byName["SIGIL_NETWORKS_ETH_RPC"].Secret)
+	assert.Contains(t, byName["SIGIL_NETWORKS_ETH_TOKENS"].Value, "symbol: USDC")
+}
+
+func TestApplyConfigEnv(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv("SIGIL_DEFAULT_WALLET", "agent")
+	t.Setenv("SIGIL_DERIVATION_ADDRESS_GAP", "40")
+	t.Setenv("SIGIL_NETWORKS_BTC_ENABLED", "yes")
+	t.Setenv("SIGIL_NETWORKS_ETH_FALLBACK_RPCS", "https://a.example, https://b.example")
+	t.Setenv("SIGIL_NETWORKS_ETH_TOKENS", "[{symbol: DAI, address: '0x6b17', decimals: 18}]")
+
+	cfg := Defaults()
+	applyConfigEnv(cfg)
+
+	assert.Equal(t, "agent", cfg.DefaultWallet)
+	assert.Equal(t, 40, cfg.Derivation.AddressGap)
+	assert.True(t, cfg.Networks.BTC.Enabled)
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, cfg.Networks.ETH.FallbackRPCs)
+	assert.Equal(t, []TokenConfig{{Symbol: "DAI", Address: "0x6b17", Decimals: 18}}, cfg.Networks.ETH.Tokens)
+	assert.Empty(t, cfg.Warnings)
+}
+
+func TestApplyConfigEnv_SigningSecretFile(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	path := filepath.Join(t.TempDir(), "signing_secret")
+	require.NoError(t, os.WriteFile(path, []byte("gateway-secret\n"), 0o600))
+	t.Setenv("SIGIL_HTTP_SIGNING_KEY_ID", "ops-1")
+	t.Setenv("SIGIL_HTTP_SIGNING_SECRET_FILE", path)
+
+	cfg := Defaults()
+	applyConfigEnv(cfg)
+
+	assert.Equal(t, "ops-1", cfg.HTTP.Signing.KeyID)
+	assert.Equal(t, "gateway-secret", cfg.HTTP.Signing.Secret)
+	assert.Empty(t, cfg.Warnings)
+}
+
+func TestApplyConfigEnv_InvalidValues(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv("SIGIL_DERIVATION_ADDRESS_GAP", "lots")
+	t.Setenv("SIGIL_NETWORKS_BTC_ENABLED", "maybe")
+
+	cfg := Defaults()
+	applyConfigEnv(cfg)
+
+	assert.Equal(t, 20, cfg.Derivation.AddressGap)
+	assert.False(t, cfg.Networks.BTC.Enabled)
+	require.Len(t, cfg.Warnings, 2)
+	assert.Contains(t, strings.Join(cfg.Warnings, "\n"), "SIGIL_DERIVATION_ADDRESS_GAP")
+}
+
+func TestEnvVars_RoundTrip(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	cfg := Defaults()
+	cfg.Networks.ETH.RPC = "https://rpc.example"
+	cfg.Derivation.AddressGap = 5
+
+	vars, err := EnvVars(cfg)
+	require.NoError(t, err)
+	for _, v := range vars {
+		t.Setenv(v.Name, v.Value)
+	}
+
+	loaded := Defaults()
+	loaded.Networks.ETH.Tokens = nil
+	applyConfigEnv(loaded)
+	assert.Empty(t, loaded.Warnings)
+	assert.Equal(t, cfg, loaded)
+}
+
+func TestFindPath(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	home := t.TempDir()
+	t.Setenv(EnvConfig, "")
+	assert.Equal(t, Path(home), FindPath(home), "defaults to config.yaml when nothing exists")
+
+	require.NoError(t, os.WriteFile(TOMLPath(home), []byte("home = \"x\"\n"), 0o600))
+	assert.Equal(t, TOMLPath(home), FindPath(home), "uses config.toml when it is the only file")
+
+	require.NoError(t, os.WriteFile(Path(home), []byte("home: x\n"), 0o600))
+	assert.Equal(t, Path(home), FindPath(home), "prefers config.yaml")
+
+	custom := filepath.Join(home, "custom.toml")
+	t.Setenv(EnvConfig, custom)
+	assert.Equal(t, custom, FindPath(home))
+}
+
+func TestEnvOnly(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv(EnvConfig, "")
+	assert.False(t, EnvOnly())
+
+	t.Setenv(EnvConfig, "env")
+	assert.True(t, EnvOnly())
+	require.ErrorIs(t, Save(Defaults(), filepath.Join(t.TempDir(), "config.yaml")), ErrEnvOnly)
+}
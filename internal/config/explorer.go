@@ -0,0 +1,78 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidExplorer indicates an explorers entry with a bad key or URL.
+var ErrInvalidExplorer = errors.New("invalid explorer")
+
+// explorerChains are the chains an explorers key may name.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var explorerChains = []string{"eth", "bsv", "btc", "bch"}
+
+// ParseExplorerKey splits an explorers key such as "eth-test" into its
+// chain and network ("main" or "test").
+func ParseExplorerKey(key string) (chainName, network string, err error) {
+	chainName, network, ok := strings.Cut(strings.ToLower(strings.TrimSpace(key)), "-")
+	if !ok || !containsFold(explorerChains, chainName) || (network != "main" && network != "test") {
+		return "", "", fmt.Errorf("%w: key %q (use <chain>-<network> with chain %s and network main or test)",
+			ErrInvalidExplorer, key, strings.Join(explorerChains, ", "))
+	}
+	return chainName, network, nil
+}
+
+// GetExplorers returns the configured block explorers.
+func (c *Config) GetExplorers() map[string]ExplorerConfig {
+	return c.Explorers
+}
+
+// checkExplorers validates the keys and URL templates of the explorers in a
+// YAML flow map.
+func checkExplorers(s string) error {
+	var explorers map[string]ExplorerConfig
+	if err := yaml.Unmarshal([]byte(s), &explorers); err != nil {
+		return fmt.Errorf("invalid explorers: %w", err)
+	}
+	keys := make([]string, 0, len(explorers))
+	for key := range explorers {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if _, _, err := ParseExplorerKey(key); err != nil {
+			return err
+		}
+		e := explorers[key]
+		if e.Tx == "" && e.Address == "" {
+			return fmt.Errorf("%w: %s needs a tx or address URL", ErrInvalidExplorer, key)
+		}
+		if err := checkExplorerURL(e.Tx, "{txid}"); err != nil {
+			return fmt.Errorf("%w: %s.tx: %w", ErrInvalidExplorer, key, err)
+		}
+		if err := checkExplorerURL(e.Address, "{address}"); err != nil {
+			return fmt.Errorf("%w: %s.address: %w", ErrInvalidExplorer, key, err)
+		}
+	}
+	return nil
+}
+
+// checkExplorerURL validates a URL template; empty is allowed.
+func checkExplorerURL(s, placeholder string) error {
+	if s == "" {
+		return nil
+	}
+	if err := checkHTTPURL(s); err != nil {
+		return err
+	}
+	if !strings.Contains(s, placeholder) {
+		return fmt.Errorf("%q has no %s placeholder", s, placeholder)
+	}
+	return nil
+}
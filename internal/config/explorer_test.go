@@ -0,0 +1,47 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseExplorerKey(t *testing.T) {
+	t.Parallel()
+
+	chainName, network, err := ParseExplorerKey("ETH-test")
+	require.NoError(t, err)
+	assert.Equal(t, "eth", chainName)
+	assert.Equal(t, "test", network)
+
+	for _, key := range []string{"eth", "eth-sepolia", "doge-main", "-main"} {
+		_, _, err = ParseExplorerKey(key)
+		require.ErrorIs(t, err, ErrInvalidExplorer, key)
+	}
+}
+
+func TestValidate_Explorers(t *testing.T) {
+	t.Parallel()
+
+	cfg := Defaults()
+	require.NoError(t, SetValue(cfg, "explorers",
+		"{bsv-test: {tx: 'http://explorer.lan/tx/{txid}', address: 'http://explorer.lan/address/{address}'}}"))
+	assert.Equal(t, "http://explorer.lan/tx/{txid}", cfg.GetExplorers()["bsv-test"].Tx)
+	require.NoError(t, Validate(cfg))
+
+	tests := map[string]string{
+		"bad key":        "{bsv: {tx: 'https://x/{txid}'}}",
+		"no URL":         "{bsv-main: {}}",
+		"no placeholder": "{bsv-main: {tx: 'https://x/tx'}}",
+		"wrong scheme":   "{eth-main: {address: 'ftp://x/{address}'}}",
+	}
+	for name, value := range tests {
+		err := SetValue(cfg, "explorers", value)
+		require.ErrorIs(t, err, ErrInvalidValue, name)
+	}
+	assert.Len(t, cfg.Explorers, 1, "invalid values leave the setting unchanged")
+
+	cfg.Explorers["btc-regtest"] = ExplorerConfig{Tx: "http://localhost/tx/{txid}"}
+	require.ErrorIs(t, Validate(cfg), ErrInvalidExplorer)
+}
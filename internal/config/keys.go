@@ -0,0 +1,288 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	// ErrUnknownKey indicates a dotted key that names no config setting.
+	ErrUnknownKey = errors.New("unknown config key")
+
+	// ErrInvalidValue indicates a value the config schema does not allow.
+	ErrInvalidValue = errors.New("invalid config value")
+)
+
+// keyAliases maps short key names to their full dotted paths.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var keyAliases = map[string]string{
+	"eth.rpc":               "networks.eth.rpc",
+	"eth.etherscan_api_key": "networks.eth.etherscan_api_key",
+	"bsv.api_key":           "networks.bsv.api_key",
+	"bsv.network":           "networks.bsv.network",
+	"output.format":         "output.default_format",
+	"security.session_ttl":  "security.session_ttl_minutes",
+}
+
+// keyRule limits the values of a string setting.
+type keyRule struct {
+	allowed []string           // Permitted values; empty is always allowed
+	check   func(string) error // Additional check of non-empty values
+}
+
+// keyRules are the schema constraints beyond a setting's type. Integer and
+// float settings must also not be negative.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var keyRules = map[string]keyRule{
+	"networks.eth.rpc":                   {check: ValidateRPCURL},
+	"networks.eth.provider":              {allowed: []string{"rpc", "etherscan"}},
+	"networks.bsv.network":               {check: checkBSVNetwork},
+	"networks.bsv.utxo_sync":             {allowed: []string{"api", "filters"}},
+	"fees.eth_gas_strategy":              {allowed: []string{"slow", "medium", "fast"}},
+	"fees.bsv_fee_strategy":              {allowed: []string{"economy", "normal", "priority"}},
+	"fees.bsv_coin_selection":            {allowed: []string{"largest-first", "smallest-first", "branch-and-bound", "manual"}},
+	"output.default_format":              {allowed: []string{"text", "json", "auto"}},
+	"output.color":                       {allowed: []string{"auto", "always", "never"}},
+	"logging.level":                      {allowed: []string{"off", "error", "debug"}},
+	"logging.format":                     {allowed: []string{"text", "json"}},
+	"price.api":                          {check: checkPriceAPI},
+	"price.fiat":                         {allowed: []string{"usd", "eur", "none"}},
+	"security.cosign.url":                {check: checkHTTPURL},
+	"networks.eth.fallback_rpcs":         {check: checkRPCList},
+	"networks.eth.rpc_profiles":          {check: checkRPCProfiles},
+	"networks.eth.sepolia.rpc":           {check: ValidateRPCURL},
+	"networks.eth.sepolia.fallback_rpcs": {check: checkRPCList},
+	"explorers":                          {check: checkExplorers},
+}
+
+// ResolveKey returns the full dotted path of a key, expanding short aliases
+// such as eth.rpc for networks.eth.rpc.
+func ResolveKey(key string) string {
+	key = strings.ToLower(strings.TrimSpace(key))
+	if full, ok := keyAliases[key]; ok {
+		return full
+	}
+	return key
+}
+
+// Keys returns the dotted path of every setting, in config file order.
+func Keys() []string {
+	var keys []string
+	walkConfigFields(reflect.ValueOf(Defaults()).Elem(), "", func(key string, _ reflect.Value) {
+		keys = append(keys, key)
+	})
+	return keys
+}
+
+// IsSecret reports whether a setting holds a credential.
+func IsSecret(key string) bool {
+	return isSecretKey(ResolveKey(key))
+}
+
+// GetValue returns a setting as text, formatted as its SIGIL_* variable
+// would be.
+func GetValue(cfg *Config, key string) (string, error) {
+	field, err := lookupField(cfg, key)
+	if err != nil {
+		return "", err
+	}
+	return formatEnvValue(field)
+}
+
+// SetValue parses value into a setting and checks it against the schema.
+// The setting is left unchanged when the value is invalid.
+func SetValue(cfg *Config, key, value string) error {
+	key = ResolveKey(key)
+	field, err := lookupField(cfg, key)
+	if err != nil {
+		return err
+	}
+
+	parsed := reflect.New(field.Type()).Elem()
+	if err := setEnvValue(parsed, value); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrInvalidValue, key, err)
+	}
+	if err := checkValue(key, parsed); err != nil {
+		return err
+	}
+	if key == "networks.bsv.network" {
+		network, _ := NormalizeBSVNetwork(parsed.String())
+		parsed.SetString(network)
+	}
+	field.Set(parsed)
+	return nil
+}
+
+// Validate checks every setting against the schema, returning all problems
+// joined into one error.
+func Validate(cfg *Config) error {
+	var problems []error
+	walkConfigFields(reflect.ValueOf(cfg).Elem(), "", func(key string, field reflect.Value) {
+		if err := checkValue(key, field); err != nil {
+			problems = append(problems, err)
+		}
+	})
+	if err := checkRPCProfileName(cfg); err != nil {
+		problems = append(problems, err)
+	}
+	return errors.Join(problems...)
+}
+
+// ValidateKey checks one setting of cfg against the schema.
+func ValidateKey(cfg *Config, key string) error {
+	key = ResolveKey(key)
+	field, err := lookupField(cfg, key)
+	if err != nil {
+		return err
+	}
+	if key == "networks.eth.rpc_profile" {
+		return checkRPCProfileName(cfg)
+	}
+	return checkValue(key, field)
+}
+
+// ValidateFile parses a config file strictly, reporting keys the schema does
+// not know, and validates the settings it contains.
+func ValidateFile(path string) error {
+	// #nosec G304 -- config file path is from validated user input
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	cfg := Defaults()
+	var problems []error
+	if IsTOML(path) {
+		md, decodeErr := toml.Decode(string(data), cfg)
+		if decodeErr != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTOML, decodeErr)
+		}
+		undecoded := md.Undecoded()
+		sort.Slice(undecoded, func(i, j int) bool { return undecoded[i].String() < undecoded[j].String() })
+		for _, k := range undecoded {
+			problems = append(problems, fmt.Errorf("%w: %s", ErrUnknownKey, k.String()))
+		}
+	} else {
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		decodeErr := dec.Decode(cfg)
+		var typeErr *yaml.TypeError
+		switch {
+		case errors.As(decodeErr, &typeErr):
+			// Unknown keys and mistyped values; the rest is still decoded
+			for _, msg := range typeErr.Errors {
+				problems = append(problems, fmt.Errorf("%w: %s", ErrInvalidValue, msg))
+			}
+		case decodeErr != nil && !errors.Is(decodeErr, io.EOF):
+			return fmt.Errorf("parsing %s: %w", path, decodeErr)
+		}
+	}
+
+	if err := Validate(cfg); err != nil {
+		problems = append(problems, err)
+	}
+	return errors.Join(problems...)
+}
+
+// lookupField finds the field of a dotted key.
+func lookupField(cfg *Config, key string) (reflect.Value, error) {
+	key = ResolveKey(key)
+	var found reflect.Value
+	walkConfigFields(reflect.ValueOf(cfg).Elem(), "", func(k string, field reflect.Value) {
+		if k == key {
+			found = field
+		}
+	})
+	if !found.IsValid() {
+		return found, fmt.Errorf("%w: %s", ErrUnknownKey, key)
+	}
+	return found, nil
+}
+
+// checkValue applies the schema rule of a setting to its value.
+func checkValue(key string, field reflect.Value) error {
+	//nolint:exhaustive // Only numbers are range-checked
+	switch field.Kind() {
+	case reflect.Int, reflect.Int64:
+		if field.Int() < 0 {
+			return fmt.Errorf("%w: %s: must not be negative (got %d)", ErrInvalidValue, key, field.Int())
+		}
+	case reflect.Float64:
+		if field.Float() < 0 {
+			return fmt.Errorf("%w: %s: must not be negative (got %g)", ErrInvalidValue, key, field.Float())
+		}
+	}
+
+	rule, ok := keyRules[key]
+	if !ok {
+		return nil
+	}
+	value, _ := formatEnvValue(field)
+	if value == "" {
+		return nil
+	}
+	if len(rule.allowed) > 0 && !containsFold(rule.allowed, value) {
+		return fmt.Errorf("%w: %s: %q (use %s)", ErrInvalidValue, key, value, strings.Join(rule.allowed, ", "))
+	}
+	if rule.check != nil {
+		if err := rule.check(value); err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrInvalidValue, key, err)
+		}
+	}
+	return nil
+}
+
+// containsFold reports whether values contains s, ignoring case.
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkBSVNetwork accepts the names NormalizeBSVNetwork understands.
+func checkBSVNetwork(s string) error {
+	if _, ok := NormalizeBSVNetwork(s); !ok {
+		return fmt.Errorf("%q (use main or test)", s)
+	}
+	return nil
+}
+
+// checkPriceAPI accepts "coingecko" or an http(s) URL.
+func checkPriceAPI(s string) error {
+	if strings.EqualFold(s, "coingecko") {
+		return nil
+	}
+	return checkHTTPURL(s)
+}
+
+// checkHTTPURL accepts an http or https URL.
+func checkHTTPURL(s string) error {
+	if strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") {
+		return nil
+	}
+	return fmt.Errorf("%q is not an http(s) URL", s)
+}
+
+// checkRPCList validates each comma-separated RPC URL.
+func checkRPCList(s string) error {
+	for _, u := range strings.Split(s, ",") {
+		if err := ValidateRPCURL(strings.TrimSpace(u)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
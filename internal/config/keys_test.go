@@ -0,0 +1,104 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveKey(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "networks.eth.rpc", ResolveKey("eth.rpc"))
+	assert.Equal(t, "output.default_format", ResolveKey(" Output.Format "))
+	assert.Equal(t, "security.session_ttl_minutes", ResolveKey("security.session_ttl"))
+	assert.Equal(t, "fees.bsv_min_miners", ResolveKey("fees.bsv_min_miners"))
+}
+
+func TestKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := Keys()
+	assert.Equal(t, "home", keys[0])
+	assert.Contains(t, keys, "networks.bsv.node.rpc")
+	assert.Contains(t, keys, "price.cache_minutes")
+	assert.NotContains(t, keys, "version")
+}
+
+func TestGetSetValue(t *testing.T) {
+	t.Parallel()
+
+	cfg := Defaults()
+	require.NoError(t, SetValue(cfg, "security.session_ttl", "30"))
+	assert.Equal(t, 30, cfg.Security.SessionTTLMinutes)
+
+	require.NoError(t, SetValue(cfg, "networks.eth.fallback_rpcs", "https://a.example, https://b.example"))
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, cfg.Networks.ETH.FallbackRPCs)
+
+	require.NoError(t, SetValue(cfg, "bsv.network", "testnet"))
+	assert.Equal(t, "test", cfg.Networks.BSV.Network)
+
+	value, err := GetValue(cfg, "networks.eth.fallback_rpcs")
+	require.NoError(t, err)
+	assert.Equal(t, "https://a.example,https://b.example", value)
+
+	tests := []struct {
+		key, value string
+	}{
+		{"security.session_ttl_minutes", "soon"},
+		{"security.session_ttl_minutes", "-5"},
+		{"fees.bsv_fee_strategy", "fastest"},
+		{"networks.eth.rpc", "http://eth.example.com"},
+		{"price.api", "kraken"},
+		{"security.memory_lock", "maybe"},
+	}
+	for _, tc := range tests {
+		err := SetValue(cfg, tc.key, tc.value)
+		require.ErrorIs(t, err, ErrInvalidValue, tc.key)
+	}
+	assert.Equal(t, 30, cfg.Security.SessionTTLMinutes, "invalid values leave the setting unchanged")
+
+	_, err = GetValue(cfg, "networks.eth.nope")
+	require.ErrorIs(t, err, ErrUnknownKey)
+	require.ErrorIs(t, SetValue(cfg, "nope", "1"), ErrUnknownKey)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, Validate(Defaults()))
+
+	cfg := Defaults()
+	cfg.Output.DefaultFormat = "yaml"
+	cfg.Quotas.WarnPercent = -1
+	err := Validate(cfg)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	assert.Contains(t, err.Error(), "output.default_format")
+	assert.Contains(t, err.Error(), "quotas.warn_percent")
+}
+
+func TestValidateFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	yamlPath := filepath.Join(dir, "config.yaml")
+	require.NoError(t, os.WriteFile(yamlPath, []byte("output:\n  default_format: json\n"), 0o600))
+	require.NoError(t, ValidateFile(yamlPath))
+
+	require.NoError(t, os.WriteFile(yamlPath, []byte("output:\n  format: json\n"), 0o600))
+	err := ValidateFile(yamlPath)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "field format not found")
+
+	tomlPath := filepath.Join(dir, "config.toml")
+	require.NoError(t, os.WriteFile(tomlPath, []byte("[logging]\nlevel = \"info\"\nlevl = \"x\"\n"), 0o600))
+	err = ValidateFile(tomlPath)
+	require.ErrorIs(t, err, ErrUnknownKey)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	assert.Contains(t, err.Error(), "logging.levl")
+	assert.Contains(t, err.Error(), "logging.level")
+}
@@ -0,0 +1,38 @@
+package config
+
+// ETHSepoliaChainID is the chain ID of the Sepolia testnet, used for ETH when
+// a command runs on testnet.
+const ETHSepoliaChainID = 11155111
+
+// UseNetwork points the ETH settings and the balance cache at a network,
+// "main" or "test" (see NormalizeBSVNetwork). On testnet the ETH RPCs and
+// tokens come from networks.eth.sepolia and the chain ID is Sepolia's. The
+// mainnet settings are kept, so a command can switch back when the wallet
+// it loads turns out to be a mainnet wallet.
+func (c *Config) UseNetwork(network string) {
+	if c.ethMainnet == nil {
+		mainnet := c.Networks.ETH
+		c.ethMainnet = &mainnet
+	}
+	network, _ = NormalizeBSVNetwork(network)
+
+	eth := *c.ethMainnet
+	if network == "test" {
+		eth.RPC = eth.Sepolia.RPC
+		eth.FallbackRPCs = eth.Sepolia.FallbackRPCs
+		eth.Tokens = eth.Sepolia.Tokens
+		eth.ChainID = ETHSepoliaChainID
+		eth.RPCProfile = ""
+	}
+	c.Networks.ETH = eth
+	c.Cache.Network = network
+}
+
+// GetETHChainID returns the chain ID of the ETH network in use, mainnet
+// when unset.
+func (c *Config) GetETHChainID() int {
+	if c.Networks.ETH.ChainID > 0 {
+		return c.Networks.ETH.ChainID
+	}
+	return 1
+}
@@ -21,6 +21,7 @@ func TestNormalizeBSVNetwork(t *testing.T) {
 		{"mainnet alias", "mainnet", "main", true},
 		{"test", "test", "test", true},
 		{"testnet alias", "testnet", "test", true},
+		{"sepolia alias", "Sepolia", "test", true},
 		{"uppercase TEST", "TEST", "test", true},
 		{"whitespace", "  test  ", "test", true},
 		{"invalid", "regtest", "main", false},
@@ -74,3 +75,28 @@ func TestApplyEnvironmentBSVNetwork(t *testing.T) {
 		})
 	}
 }
+
+func TestUseNetwork(t *testing.T) {
+	t.Parallel()
+
+	cfg := Defaults()
+	cfg.Networks.ETH.RPC = "https://mainnet.example"
+	assert.Equal(t, 1, cfg.GetETHChainID())
+
+	cfg.UseNetwork("testnet")
+	assert.Equal(t, DefaultSepoliaRPCURL, cfg.GetETHRPC())
+	assert.Equal(t, DefaultSepoliaFallbackRPCs, cfg.GetETHFallbackRPCs())
+	assert.Equal(t, ETHSepoliaChainID, cfg.GetETHChainID())
+	assert.Equal(t, "0x1c7D4B196Cb0C7B01d743Fbc6116a902379C7238", cfg.GetETHTokens()[0].Address)
+	assert.Equal(t, "test", cfg.Cache.Network)
+
+	// Switching back restores the mainnet settings
+	cfg.UseNetwork("main")
+	assert.Equal(t, "https://mainnet.example", cfg.GetETHRPC())
+	assert.Equal(t, 1, cfg.GetETHChainID())
+	assert.Equal(t, "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48", cfg.GetETHTokens()[0].Address)
+	assert.Equal(t, "main", cfg.Cache.Network)
+
+	cfg.Networks.ETH.ChainID = 0
+	assert.Equal(t, 1, cfg.GetETHChainID(), "unset means mainnet")
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrUnknownRPCProfile indicates an RPC profile name with no configured URL.
+var ErrUnknownRPCProfile = errors.New("unknown RPC profile")
+
+// RPCProfileNames returns the names of the configured ETH RPC profiles,
+// sorted.
+func (c *Config) RPCProfileNames() []string {
+	names := make([]string, 0, len(c.Networks.ETH.RPCProfiles))
+	for name := range c.Networks.ETH.RPCProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseRPCProfile makes the URL of the named profile the ETH RPC URL and
+// records the profile as the active one.
+func (c *Config) UseRPCProfile(name string) error {
+	rpcURL, ok := c.Networks.ETH.RPCProfiles[name]
+	if !ok {
+		return unknownRPCProfile(c, name)
+	}
+	c.Networks.ETH.RPC = rpcURL
+	c.Networks.ETH.RPCProfile = name
+	return nil
+}
+
+// ETHRPCFromEnv reports whether an environment variable sets the ETH RPC
+// URL. An explicit URL in the environment takes precedence over the default
+// profile of the config file.
+func ETHRPCFromEnv() bool {
+	return os.Getenv(EnvETHRPC) != "" || os.Getenv(EnvName("networks.eth.rpc")) != ""
+}
+
+// unknownRPCProfile returns the error for a profile that is not configured,
+// listing the profiles that are.
+func unknownRPCProfile(c *Config, name string) error {
+	names := c.RPCProfileNames()
+	if len(names) == 0 {
+		return fmt.Errorf("%w: %q (no profiles in networks.eth.rpc_profiles)", ErrUnknownRPCProfile, name)
+	}
+	return fmt.Errorf("%w: %q (use %s)", ErrUnknownRPCProfile, name, strings.Join(names, ", "))
+}
+
+// checkRPCProfiles validates the URL of each profile in a YAML flow map.
+func checkRPCProfiles(s string) error {
+	var profiles map[string]string
+	if err := yaml.Unmarshal([]byte(s), &profiles); err != nil {
+		return fmt.Errorf("invalid profiles: %w", err)
+	}
+	names := make([]string, 0, len(profiles))
+	for name := range profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if strings.TrimSpace(name) == "" || profiles[name] == "" {
+			return fmt.Errorf("profile %q needs a name and a URL", name)
+		}
+		if err := ValidateRPCURL(profiles[name]); err != nil {
+			return fmt.Errorf("profile %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// checkRPCProfileName reports a default RPC profile that is not configured.
+func checkRPCProfileName(c *Config) error {
+	name := c.Networks.ETH.RPCProfile
+	if name == "" {
+		return nil
+	}
+	if _, ok := c.Networks.ETH.RPCProfiles[name]; ok {
+		return nil
+	}
+	return fmt.Errorf("%w: networks.eth.rpc_profile: %w", ErrInvalidValue, unknownRPCProfile(c, name))
+}
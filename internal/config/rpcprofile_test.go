@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUseRPCProfile(t *testing.T) {
+	t.Parallel()
+
+	cfg := Defaults()
+	err := cfg.UseRPCProfile("mainnet-alchemy")
+	require.ErrorIs(t, err, ErrUnknownRPCProfile)
+	assert.Contains(t, err.Error(), "no profiles")
+
+	cfg.Networks.ETH.RPCProfiles = map[string]string{
+		"mainnet-infura":  "https://mainnet.infura.io/v3/key",
+		"mainnet-alchemy": "https://eth-mainnet.g.alchemy.com/v2/key",
+	}
+	assert.Equal(t, []string{"mainnet-alchemy", "mainnet-infura"}, cfg.RPCProfileNames())
+
+	require.NoError(t, cfg.UseRPCProfile("mainnet-infura"))
+	assert.Equal(t, "https://mainnet.infura.io/v3/key", cfg.GetETHRPC())
+	assert.Equal(t, "mainnet-infura", cfg.Networks.ETH.RPCProfile)
+
+	err = cfg.UseRPCProfile("mainnet")
+	require.ErrorIs(t, err, ErrUnknownRPCProfile)
+	assert.Contains(t, err.Error(), "use mainnet-alchemy, mainnet-infura")
+	assert.Equal(t, "https://mainnet.infura.io/v3/key", cfg.GetETHRPC(), "an unknown profile changes nothing")
+}
+
+func TestValidate_RPCProfiles(t *testing.T) {
+	t.Parallel()
+
+	cfg := Defaults()
+	require.NoError(t, SetValue(cfg, "networks.eth.rpc_profiles", "{local: 'http://localhost:8545', infura: 'https://mainnet.infura.io/v3/key'}"))
+	assert.Equal(t, "http://localhost:8545", cfg.Networks.ETH.RPCProfiles["local"])
+
+	require.ErrorIs(t, SetValue(cfg, "networks.eth.rpc_profiles", "{plain: 'http://eth.example.com'}"), ErrInvalidValue)
+	assert.Len(t, cfg.Networks.ETH.RPCProfiles, 2, "invalid values leave the setting unchanged")
+
+	cfg.Networks.ETH.RPCProfile = "infura"
+	require.NoError(t, Validate(cfg))
+	require.NoError(t, ValidateKey(cfg, "networks.eth.rpc_profile"))
+
+	cfg.Networks.ETH.RPCProfile = "alchemy"
+	err := Validate(cfg)
+	require.ErrorIs(t, err, ErrInvalidValue)
+	require.ErrorIs(t, err, ErrUnknownRPCProfile)
+	require.ErrorIs(t, ValidateKey(cfg, "networks.eth.rpc_profile"), ErrUnknownRPCProfile)
+}
+
+//nolint:paralleltest // Sets environment variables
+func TestETHRPCFromEnv(t *testing.T) {
+	t.Setenv(EnvETHRPC, "")
+	t.Setenv("SIGIL_NETWORKS_ETH_RPC", "")
+	assert.False(t, ETHRPCFromEnv())
+
+	t.Setenv("SIGIL_NETWORKS_ETH_RPC", "https://rpc.example.com")
+	assert.True(t, ETHRPCFromEnv())
+
+	t.Setenv("SIGIL_NETWORKS_ETH_RPC", "")
+	t.Setenv(EnvETHRPC, "https://rpc.example.com")
+	assert.True(t, ETHRPCFromEnv())
+}
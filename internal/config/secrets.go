@@ -0,0 +1,102 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Secret sources for container deployments. A secret variable NAME can also
+// be given as NAME_FILE, the path of a file holding the value (the Docker
+// secrets convention), or as a file named NAME (or name in lower case) in
+// the SIGIL_SECRETS_DIR directory, such as a mounted Kubernetes secret or
+// downward API volume.
+const (
+	// EnvFileSuffix marks a variable holding the path of a secret file.
+	EnvFileSuffix = "_FILE"
+
+	// EnvSecretsDir names a directory of secret files.
+	EnvSecretsDir = "SIGIL_SECRETS_DIR"
+
+	// EnvWalletPassword is the wallet password secret. It is only read from
+	// SIGIL_WALLET_PASSWORD_FILE or SIGIL_SECRETS_DIR, never the variable itself.
+	EnvWalletPassword = "SIGIL_WALLET_PASSWORD" //nolint:gosec // G101 -- false positive, this is a const name not a credential
+
+	// EnvAgentStorePassphrase is the agent store passphrase secret. Like the
+	// wallet password, it is only read from a secret file.
+	EnvAgentStorePassphrase = "SIGIL_AGENT_STORE_PASSPHRASE" //nolint:gosec // G101 -- false positive, this is a const name not a credential
+)
+
+// maxSecretFileSize bounds secret file reads; secrets are a few hundred bytes.
+const maxSecretFileSize = 64 << 10
+
+// ErrSecretFile indicates a configured secret file could not be used.
+var ErrSecretFile = errors.New("invalid secret file")
+
+// LookupSecret returns a secret from the environment variable name, falling
+// back to name_FILE and then SIGIL_SECRETS_DIR. It returns "" when the secret
+// is not configured anywhere.
+func LookupSecret(name string) (string, error) {
+	if v := os.Getenv(name); v != "" {
+		return v, nil
+	}
+	return LookupSecretFile(name)
+}
+
+// LookupSecretFile is LookupSecret without the plain environment variable,
+// for secrets that must never be passed in the environment.
+func LookupSecretFile(name string) (string, error) {
+	if path := os.Getenv(name + EnvFileSuffix); path != "" {
+		return readSecretFile(name+EnvFileSuffix, path)
+	}
+
+	dir := os.Getenv(EnvSecretsDir)
+	if dir == "" {
+		return "", nil
+	}
+	for _, file := range []string{name, strings.ToLower(name)} {
+		path := filepath.Join(dir, file)
+		if _, err := os.Stat(path); err == nil {
+			return readSecretFile(EnvSecretsDir, path)
+		}
+	}
+	return "", nil
+}
+
+// lookupSecretEnv is LookupSecret for config overrides: a bad secret file is
+// recorded as a warning and treated as unset.
+func lookupSecretEnv(cfg *Config, name string) string {
+	v, err := LookupSecret(name)
+	if err != nil {
+		cfg.Warnings = append(cfg.Warnings, err.Error())
+		return ""
+	}
+	return v
+}
+
+// readSecretFile reads a secret value, dropping the trailing newline that
+// editors and `kubectl create secret --from-file` usually leave behind.
+func readSecretFile(source, path string) (string, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path comes from the operator's secret configuration
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrSecretFile, source, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSecretFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s: %w", ErrSecretFile, source, err)
+	}
+	if len(data) > maxSecretFileSize {
+		return "", fmt.Errorf("%w: %s: %s is larger than %d bytes", ErrSecretFile, source, path, maxSecretFileSize)
+	}
+
+	value := strings.TrimRight(string(data), "\r\n")
+	if value == "" {
+		return "", fmt.Errorf("%w: %s: %s is empty", ErrSecretFile, source, path)
+	}
+	return value, nil
+}
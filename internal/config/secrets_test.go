@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSecret(t *testing.T, dir, name, value string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(value), 0o600))
+	return path
+}
+
+func TestLookupSecret(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	dir := t.TempDir()
+	fileDir := t.TempDir()
+
+	tests := []struct {
+		name     string
+		env      string
+		file     string
+		dirFile  string
+		expected string
+	}{
+		{name: "unset", expected: ""},
+		{name: "env wins", env: "from-env", file: "from-file", dirFile: "from-dir", expected: "from-env"},
+		{name: "file", file: "from-file\n", dirFile: "from-dir", expected: "from-file"},
+		{name: "secrets dir", dirFile: "from-dir\r\n", expected: "from-dir"},
+		{name: "inner whitespace kept", file: "  pass word \n", expected: "  pass word "},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv(EnvAgentToken, tc.env)
+			t.Setenv(EnvAgentToken+EnvFileSuffix, "")
+			t.Setenv(EnvSecretsDir, "")
+			_ = os.Remove(filepath.Join(dir, strings.ToLower(EnvAgentToken)))
+
+			if tc.file != "" {
+				t.Setenv(EnvAgentToken+EnvFileSuffix, writeSecret(t, fileDir, "token", tc.file))
+			}
+			if tc.dirFile != "" {
+				writeSecret(t, dir, strings.ToLower(EnvAgentToken), tc.dirFile)
+				t.Setenv(EnvSecretsDir, dir)
+			}
+
+			got, err := LookupSecret(EnvAgentToken)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestLookupSecretFile_IgnoresPlainEnv(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv(EnvWalletPassword, "plain")
+	t.Setenv(EnvWalletPassword+EnvFileSuffix, "")
+	t.Setenv(EnvSecretsDir, "")
+
+	got, err := LookupSecretFile(EnvWalletPassword)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	dir := t.TempDir()
+	writeSecret(t, dir, EnvWalletPassword, "mounted")
+	t.Setenv(EnvSecretsDir, dir)
+
+	got, err = LookupSecretFile(EnvWalletPassword)
+	require.NoError(t, err)
+	assert.Equal(t, "mounted", got)
+}
+
+func TestLookupSecretFile_Errors(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	dir := t.TempDir()
+	t.Setenv(EnvSecretsDir, "")
+
+	t.Setenv(EnvBSVAPIKey+EnvFileSuffix, filepath.Join(dir, "missing"))
+	_, err := LookupSecretFile(EnvBSVAPIKey)
+	require.ErrorIs(t, err, ErrSecretFile)
+	assert.Contains(t, err.Error(), EnvBSVAPIKey+EnvFileSuffix)
+
+	t.Setenv(EnvBSVAPIKey+EnvFileSuffix, writeSecret(t, dir, "empty", "\n"))
+	_, err = LookupSecretFile(EnvBSVAPIKey)
+	require.ErrorIs(t, err, ErrSecretFile)
+
+	t.Setenv(EnvBSVAPIKey+EnvFileSuffix, writeSecret(t, dir, "big", strings.Repeat("x", maxSecretFileSize+1)))
+	_, err = LookupSecretFile(EnvBSVAPIKey)
+	require.ErrorIs(t, err, ErrSecretFile)
+}
+
+func TestApplyEnvironment_SecretFiles(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	dir := t.TempDir()
+	t.Setenv(EnvSecretsDir, "")
+	t.Setenv(EnvEtherscanAPIKey, "")
+	t.Setenv(EnvBSVAPIKey, "")
+	t.Setenv(EnvWOCAPIKey, "")
+	t.Setenv(EnvEtherscanAPIKey+EnvFileSuffix, writeSecret(t, dir, "etherscan", "eth-key\n"))
+	t.Setenv(EnvBSVAPIKey+EnvFileSuffix, filepath.Join(dir, "missing"))
+
+	cfg := Defaults()
+	ApplyEnvironment(cfg)
+
+	assert.Equal(t, "eth-key", cfg.Networks.ETH.EtherscanAPIKey)
+	assert.Empty(t, cfg.Networks.BSV.APIKey)
+	require.NotEmpty(t, cfg.Warnings)
+	assert.Contains(t, strings.Join(cfg.Warnings, "\n"), EnvBSVAPIKey+EnvFileSuffix)
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ErrInvalidTOML indicates a TOML config file could not be parsed.
+var ErrInvalidTOML = errors.New("invalid TOML")
+
+// decodeTOML parses a TOML document into cfg. Keys use the same names as the
+// YAML config through the struct's toml tags; unknown keys are ignored, as
+// they are for YAML.
+func decodeTOML(data []byte, cfg *Config) error {
+	if _, err := toml.Decode(string(data), cfg); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTOML, err)
+	}
+	return nil
+}
+
+// encodeTOML renders cfg as a TOML document with the same keys as the YAML
+// config.
+func encodeTOML(cfg *Config) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := toml.NewEncoder(&buf)
+	enc.Indent = ""
+	if err := enc.Encode(cfg); err != nil {
+		return nil, fmt.Errorf("encoding TOML: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeTOML(t *testing.T) {
+	t.Parallel()
+
+	input := `
+# comment
+home = "/data"
+default_wallet = 'main' # trailing
+
+[networks.eth]
+rpc = "https://eth.example"
+fallback_rpcs = ["https://a.example", "https://b.example",]
+
+[[networks.eth.tokens]]
+symbol = "USDC"
+address = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+decimals = 6
+
+[networks.bsv]
+api_key = """
+multi-line"""
+
+[logging]
+level = "debug"
+unknown_key = 1970-01-01T00:00:00Z
+`
+
+	cfg := Defaults()
+	require.NoError(t, decodeTOML([]byte(input), cfg))
+	assert.Equal(t, "/data", cfg.Home)
+	assert.Equal(t, "main", cfg.DefaultWallet)
+	assert.Equal(t, "https://eth.example", cfg.Networks.ETH.RPC)
+	assert.Equal(t, []string{"https://a.example", "https://b.example"}, cfg.Networks.ETH.FallbackRPCs)
+	require.Len(t, cfg.Networks.ETH.Tokens, 1)
+	assert.Equal(t, "USDC", cfg.Networks.ETH.Tokens[0].Symbol)
+	assert.Equal(t, 6, cfg.Networks.ETH.Tokens[0].Decimals)
+	assert.Equal(t, "multi-line", cfg.Networks.BSV.APIKey)
+	assert.Equal(t, "debug", cfg.Logging.Level)
+	assert.Equal(t, Defaults().Fees, cfg.Fees, "keys not in the file keep their defaults")
+}
+
+func TestDecodeTOML_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"missing value", "home =\n"},
+		{"unterminated string", "home = \"abc\n"},
+		{"duplicate key", "home = \"a\"\nhome = \"b\"\n"},
+		{"unclosed table", "[networks\n"},
+		{"trailing garbage", "version = 1 2\n"},
+		{"wrong type", "version = \"one\"\n"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := decodeTOML([]byte(tc.input), Defaults())
+			require.ErrorIs(t, err, ErrInvalidTOML)
+		})
+	}
+}
+
+func TestEncodeTOML_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	cfg := Defaults()
+	cfg.Networks.ETH.EtherscanAPIKey = "key \"with\" quotes"
+	cfg.Networks.ETH.FallbackRPCs = []string{"https://a.example", "https://b.example"}
+
+	data, err := encodeTOML(cfg)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "[networks.eth]")
+	assert.Contains(t, string(data), "[[networks.eth.tokens]]")
+
+	decoded := &Config{}
+	require.NoError(t, decodeTOML(data, decoded))
+	assert.Equal(t, cfg, decoded)
+}
+
+func TestLoadSave_TOML(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	cfg := Defaults()
+	cfg.Logging.Level = "debug"
+	require.NoError(t, Save(cfg, path))
+
+	data, err := os.ReadFile(path) //nolint:gosec // test file path
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "level = \"debug\"")
+
+	loaded, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "debug", loaded.Logging.Level)
+	assert.Equal(t, cfg.Networks.ETH.Tokens, loaded.Networks.ETH.Tokens)
+}
@@ -0,0 +1,257 @@
+// Package cosign asks an external policy service to approve a transaction
+// before it is broadcast.
+//
+// Sigil POSTs a JSON summary of the transaction to the configured URL. The
+// service answers with a decision signed by its ed25519 key; the signature
+// binds the decision to the request ID and the SHA-256 of the exact request
+// body, so an approval cannot be replayed for a different transaction.
+// Anything other than a valid, signed approval aborts the send.
+package cosign
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/config"
+)
+
+const (
+	// DefaultTimeout bounds the wait for a decision when none is configured.
+	DefaultTimeout = 30 * time.Second
+
+	// signingDomain prefixes every signed decision.
+	signingDomain = "sigil-cosign/v1"
+
+	// maxResponseBytes caps how much of a response body is read.
+	maxResponseBytes = 64 << 10
+)
+
+var (
+	// ErrDenied is returned when the policy service rejects the transaction.
+	ErrDenied = errors.New("transaction denied by policy service")
+
+	// ErrTimeout is returned when no decision arrives within the timeout.
+	ErrTimeout = errors.New("policy service did not respond in time")
+
+	// ErrBadSignature is returned when the decision signature does not verify.
+	ErrBadSignature = errors.New("policy service decision signature is invalid")
+
+	// ErrBadResponse is returned for a malformed or mismatched response.
+	ErrBadResponse = errors.New("invalid policy service response")
+
+	// ErrInvalidPublicKey is returned when the configured key is not a hex
+	// encoded ed25519 public key.
+	ErrInvalidPublicKey = errors.New("cosign public key must be a 32-byte hex ed25519 key")
+
+	// ErrMissingPublicKey is returned when a URL is configured without a key.
+	ErrMissingPublicKey = errors.New("cosign public key is required when a cosign URL is set")
+)
+
+// Input is a UTXO spent by a transaction.
+type Input struct {
+	TxID   string `json:"txid"`
+	Vout   uint32 `json:"vout"`
+	Amount uint64 `json:"amount"`
+}
+
+// Output is a recipient of a multi-recipient transaction. Amount is in the
+// chain's smallest unit.
+type Output struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+}
+
+// Summary describes the transaction sent for approval. Amount and Fee are in
+// the chain's smallest unit (satoshis or wei).
+type Summary struct {
+	RequestID string    `json:"request_id"`
+	Chain     string    `json:"chain"`
+	Wallet    string    `json:"wallet"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Amount    string    `json:"amount"`
+	Display   string    `json:"display_amount"`
+	Token     string    `json:"token,omitempty"`
+	Fee       string    `json:"fee"`
+	FeeRate   uint64    `json:"fee_rate,omitempty"`
+	Inputs    []Input   `json:"inputs,omitempty"`
+	Outputs   []Output  `json:"outputs,omitempty"`
+	SweepAll  bool      `json:"sweep_all,omitempty"`
+	Agent     string    `json:"agent,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Decision is the policy service response.
+type Decision struct {
+	RequestID string `json:"request_id"`
+	Approved  bool   `json:"approved"`
+	Reason    string `json:"reason,omitempty"`
+	// Signature is the hex ed25519 signature over SigningMessage.
+	Signature string `json:"signature"`
+}
+
+// Client requests approvals from a policy service.
+type Client struct {
+	url        string
+	publicKey  ed25519.PublicKey
+	timeout    time.Duration
+	httpClient *http.Client
+}
+
+// New creates a client from the cosign configuration. It returns nil and no
+// error when no URL is configured, meaning co-signing is disabled.
+func New(cfg config.CosignConfig, httpClient *http.Client) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, nil //nolint:nilnil // nil client means co-signing is off
+	}
+	if err := config.ValidateRPCURL(cfg.URL); err != nil {
+		return nil, fmt.Errorf("cosign url: %w", err)
+	}
+	if cfg.PublicKey == "" {
+		return nil, ErrMissingPublicKey
+	}
+	key, err := ParsePublicKey(cfg.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := DefaultTimeout
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+	if httpClient == nil {
+		httpClient = &http.Client{}
+	}
+
+	return &Client{
+		url:        cfg.URL,
+		publicKey:  key,
+		timeout:    timeout,
+		httpClient: httpClient,
+	}, nil
+}
+
+// ParsePublicKey decodes a hex encoded ed25519 public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(s), "0x"))
+	if err != nil || len(raw) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	return ed25519.PublicKey(raw), nil
+}
+
+// SigningMessage returns the bytes the policy service signs for a decision.
+// body is the exact request body sigil sent.
+func SigningMessage(requestID string, body []byte, approved bool) []byte {
+	digest := sha256.Sum256(body)
+	decision := "denied"
+	if approved {
+		decision = "approved"
+	}
+	return []byte(signingDomain + "\n" + requestID + "\n" + hex.EncodeToString(digest[:]) + "\n" + decision)
+}
+
+// Approve sends the summary to the policy service and returns nil only for a
+// signed approval. A RequestID and CreatedAt are filled in when empty.
+func (c *Client) Approve(ctx context.Context, summary *Summary) error {
+	if err := summary.Identify(); err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(summary)
+	if err != nil {
+		return fmt.Errorf("encoding cosign request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	decision, err := c.post(ctx, body)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			return fmt.Errorf("%w after %s", ErrTimeout, c.timeout)
+		}
+		return err
+	}
+
+	if decision.RequestID != summary.RequestID {
+		return fmt.Errorf("%w: request_id mismatch", ErrBadResponse)
+	}
+	sig, err := hex.DecodeString(decision.Signature)
+	if err != nil || !ed25519.Verify(c.publicKey, SigningMessage(decision.RequestID, body, decision.Approved), sig) {
+		return ErrBadSignature
+	}
+	if !decision.Approved {
+		if decision.Reason != "" {
+			return fmt.Errorf("%w: %s", ErrDenied, decision.Reason)
+		}
+		return ErrDenied
+	}
+	return nil
+}
+
+// post sends the request body and decodes the decision.
+func (c *Client) post(ctx context.Context, body []byte) (*Decision, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("creating cosign request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("contacting policy service: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("reading policy service response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: HTTP %d", ErrBadResponse, resp.StatusCode)
+	}
+
+	var decision Decision
+	if err := json.Unmarshal(respBody, &decision); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadResponse, err)
+	}
+	return &decision, nil
+}
+
+// Identify fills in the RequestID and CreatedAt of the summary when empty,
+// so every approver that sees it reports the same request.
+func (s *Summary) Identify() error {
+	if s.RequestID == "" {
+		id, err := newRequestID()
+		if err != nil {
+			return err
+		}
+		s.RequestID = id
+	}
+	if s.CreatedAt.IsZero() {
+		s.CreatedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// newRequestID returns a random 128-bit hex identifier.
+func newRequestID() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generating cosign request id: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,247 @@
+package cosign
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+)
+
+// policyServer is a test policy service that signs its decisions.
+type policyServer struct {
+	priv     ed25519.PrivateKey
+	pub      ed25519.PublicKey
+	decide   func(s *Summary) (bool, string)
+	tamper   func(d *Decision)
+	status   int
+	delay    time.Duration
+	received []*Summary
+}
+
+func newPolicyServer(t *testing.T) (*policyServer, *httptest.Server) {
+	t.Helper()
+
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	ps := &policyServer{priv: priv, pub: pub, status: http.StatusOK}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var s Summary
+		if err := json.Unmarshal(body, &s); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ps.received = append(ps.received, &s)
+
+		if ps.delay > 0 {
+			select {
+			case <-time.After(ps.delay):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if ps.status != http.StatusOK {
+			w.WriteHeader(ps.status)
+			return
+		}
+
+		approved, reason := true, ""
+		if ps.decide != nil {
+			approved, reason = ps.decide(&s)
+		}
+		d := Decision{
+			RequestID: s.RequestID,
+			Approved:  approved,
+			Reason:    reason,
+			Signature: hex.EncodeToString(ed25519.Sign(ps.priv, SigningMessage(s.RequestID, body, approved))),
+		}
+		if ps.tamper != nil {
+			ps.tamper(&d)
+		}
+		_ = json.NewEncoder(w).Encode(d)
+	}))
+	t.Cleanup(srv.Close)
+
+	return ps, srv
+}
+
+func newTestClient(t *testing.T, ps *policyServer, srv *httptest.Server) *Client {
+	t.Helper()
+
+	c, err := New(config.CosignConfig{URL: srv.URL, PublicKey: hex.EncodeToString(ps.pub)}, srv.Client())
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	return c
+}
+
+func testSummary() *Summary {
+	return &Summary{Chain: "bsv", Wallet: "main", To: "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2", Amount: "10000", Fee: "50"}
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	key := hex.EncodeToString(pub)
+
+	c, err := New(config.CosignConfig{}, nil)
+	require.NoError(t, err)
+	assert.Nil(t, c, "empty URL disables co-signing")
+
+	tests := []struct {
+		name    string
+		cfg     config.CosignConfig
+		wantErr error
+	}{
+		{"plaintext remote", config.CosignConfig{URL: "http://policy.example.com", PublicKey: key}, config.ErrInsecureRPCURL},
+		{"missing key", config.CosignConfig{URL: "https://policy.example.com"}, ErrMissingPublicKey},
+		{"short key", config.CosignConfig{URL: "https://policy.example.com", PublicKey: "abcd"}, ErrInvalidPublicKey},
+		{"non-hex key", config.CosignConfig{URL: "https://policy.example.com", PublicKey: "zz"}, ErrInvalidPublicKey},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := New(tc.cfg, nil)
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+
+	c, err = New(config.CosignConfig{URL: "http://localhost:8080", PublicKey: "0x" + key, TimeoutSeconds: 5}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 5*time.Second, c.timeout)
+
+	c, err = New(config.CosignConfig{URL: "https://policy.example.com", PublicKey: key}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultTimeout, c.timeout)
+}
+
+func TestApprove_Approved(t *testing.T) {
+	t.Parallel()
+
+	ps, srv := newPolicyServer(t)
+	c := newTestClient(t, ps, srv)
+
+	summary := testSummary()
+	require.NoError(t, c.Approve(context.Background(), summary))
+
+	assert.Len(t, summary.RequestID, 32)
+	assert.False(t, summary.CreatedAt.IsZero())
+	require.Len(t, ps.received, 1)
+	assert.Equal(t, summary.RequestID, ps.received[0].RequestID)
+	assert.Equal(t, "10000", ps.received[0].Amount)
+}
+
+func TestApprove_Rejections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		setup   func(ps *policyServer)
+		wantErr error
+		msg     string
+	}{
+		{
+			name: "denied",
+			setup: func(ps *policyServer) {
+				ps.decide = func(*Summary) (bool, string) { return false, "amount over limit" }
+			},
+			wantErr: ErrDenied,
+			msg:     "amount over limit",
+		},
+		{
+			name: "forged approval",
+			setup: func(ps *policyServer) {
+				ps.decide = func(*Summary) (bool, string) { return false, "" }
+				ps.tamper = func(d *Decision) { d.Approved = true }
+			},
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "garbage signature",
+			setup:   func(ps *policyServer) { ps.tamper = func(d *Decision) { d.Signature = "nothex" } },
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "wrong key",
+			setup:   func(ps *policyServer) { _, ps.priv, _ = ed25519.GenerateKey(rand.Reader) },
+			wantErr: ErrBadSignature,
+		},
+		{
+			name:    "request id mismatch",
+			setup:   func(ps *policyServer) { ps.tamper = func(d *Decision) { d.RequestID = "other" } },
+			wantErr: ErrBadResponse,
+		},
+		{
+			name:    "server error",
+			setup:   func(ps *policyServer) { ps.status = http.StatusInternalServerError },
+			wantErr: ErrBadResponse,
+			msg:     "HTTP 500",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ps, srv := newPolicyServer(t)
+			c := newTestClient(t, ps, srv)
+			tc.setup(ps)
+
+			err := c.Approve(context.Background(), testSummary())
+			require.ErrorIs(t, err, tc.wantErr)
+			if tc.msg != "" {
+				assert.Contains(t, err.Error(), tc.msg)
+			}
+		})
+	}
+}
+
+func TestApprove_Timeout(t *testing.T) {
+	t.Parallel()
+
+	ps, srv := newPolicyServer(t)
+	ps.delay = time.Second
+	c := newTestClient(t, ps, srv)
+	c.timeout = 50 * time.Millisecond
+
+	err := c.Approve(context.Background(), testSummary())
+	require.ErrorIs(t, err, ErrTimeout)
+}
+
+func TestApprove_Unreachable(t *testing.T) {
+	t.Parallel()
+
+	ps, srv := newPolicyServer(t)
+	c := newTestClient(t, ps, srv)
+	srv.Close()
+
+	err := c.Approve(context.Background(), testSummary())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "contacting policy service")
+}
+
+func TestSigningMessage(t *testing.T) {
+	t.Parallel()
+
+	body := []byte(`{"request_id":"abc"}`)
+	approved := SigningMessage("abc", body, true)
+	denied := SigningMessage("abc", body, false)
+
+	assert.Contains(t, string(approved), "sigil-cosign/v1\nabc\n")
+	assert.Contains(t, string(approved), "\napproved")
+	assert.Contains(t, string(denied), "\ndenied")
+	assert.NotEqual(t, approved, SigningMessage("abc", []byte(`{}`), true), "digest covers the body")
+}
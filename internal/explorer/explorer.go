@@ -0,0 +1,144 @@
+// Package explorer builds block explorer links for transactions and
+// addresses.
+//
+// A Registry holds the explorers of each chain and network, the primary one
+// first. The built-in explorers are public sites; Set replaces them, for
+// example with a self-hosted explorer on an air-gapped network.
+package explorer
+
+import (
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// Placeholders in explorer URL templates.
+const (
+	TxPlaceholder      = "{txid}"
+	AddressPlaceholder = "{address}"
+)
+
+// Networks of a chain.
+const (
+	Mainnet = "main"
+	Testnet = "test"
+)
+
+// Explorer is a block explorer site. TxURL and AddressURL are templates
+// holding TxPlaceholder and AddressPlaceholder.
+type Explorer struct {
+	Name       string
+	TxURL      string
+	AddressURL string
+}
+
+// TxLink returns the URL of a transaction, or "" without a TxURL.
+func (e Explorer) TxLink(txid string) string {
+	if e.TxURL == "" {
+		return ""
+	}
+	return strings.ReplaceAll(e.TxURL, TxPlaceholder, txid)
+}
+
+// AddressLink returns the URL of an address, or "" without an AddressURL.
+func (e Explorer) AddressLink(address string) string {
+	if e.AddressURL == "" {
+		return ""
+	}
+	return strings.ReplaceAll(e.AddressURL, AddressPlaceholder, address)
+}
+
+// key identifies a chain and network.
+type key struct {
+	chain   chain.ID
+	network string
+}
+
+// Registry maps each chain and network to its explorers.
+type Registry struct {
+	explorers map[key][]Explorer
+}
+
+// New returns a registry of the built-in explorers.
+func New() *Registry {
+	r := &Registry{explorers: make(map[key][]Explorer)}
+	r.add(chain.ETH, Mainnet, Explorer{"Etherscan", "https://etherscan.io/tx/{txid}", "https://etherscan.io/address/{address}"})
+	r.add(chain.ETH, Testnet, Explorer{"Etherscan", "https://sepolia.etherscan.io/tx/{txid}", "https://sepolia.etherscan.io/address/{address}"})
+	r.add(chain.BSV, Mainnet, Explorer{"WhatsOnChain", "https://whatsonchain.com/tx/{txid}", "https://whatsonchain.com/address/{address}"})
+	r.add(chain.BSV, Testnet, Explorer{"WhatsOnChain", "https://test.whatsonchain.com/tx/{txid}", "https://test.whatsonchain.com/address/{address}"})
+	r.add(chain.BSV, Testnet, Explorer{"Bananablocks", "https://test.bananablocks.com/tx/{txid}", "https://test.bananablocks.com/address/{address}"})
+	r.add(chain.BTC, Mainnet, Explorer{"mempool.space", "https://mempool.space/tx/{txid}", "https://mempool.space/address/{address}"})
+	r.add(chain.BTC, Testnet, Explorer{"mempool.space", "https://mempool.space/testnet/tx/{txid}", "https://mempool.space/testnet/address/{address}"})
+	r.add(chain.BCH, Mainnet, Explorer{"Blockchair", "https://blockchair.com/bitcoin-cash/transaction/{txid}", "https://blockchair.com/bitcoin-cash/address/{address}"})
+	r.add(chain.BCH, Testnet, Explorer{"tbch.loping.net", "https://tbch.loping.net/tx/{txid}", "https://tbch.loping.net/address/{address}"})
+	return r
+}
+
+func (r *Registry) add(chainID chain.ID, network string, e Explorer) {
+	k := key{chainID, network}
+	r.explorers[k] = append(r.explorers[k], e)
+}
+
+// Set makes e the only explorer of a chain and network. A URL e leaves
+// empty is taken from the built-in primary explorer.
+func (r *Registry) Set(chainID chain.ID, network string, e Explorer) {
+	k := key{chainID, network}
+	if builtin := r.explorers[k]; len(builtin) > 0 {
+		if e.TxURL == "" {
+			e.TxURL = builtin[0].TxURL
+		}
+		if e.AddressURL == "" {
+			e.AddressURL = builtin[0].AddressURL
+		}
+	}
+	r.explorers[k] = []Explorer{e}
+}
+
+// Explorers returns the explorers of a chain and network, primary first.
+// An unknown network is mainnet.
+func (r *Registry) Explorers(chainID chain.ID, network string) []Explorer {
+	if network != Testnet {
+		network = Mainnet
+	}
+	return r.explorers[key{chainID, network}]
+}
+
+// TxLinks returns the URL of a transaction on every explorer.
+func (r *Registry) TxLinks(chainID chain.ID, network, txid string) []string {
+	var links []string
+	for _, e := range r.Explorers(chainID, network) {
+		if link := e.TxLink(txid); link != "" {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// TxLink returns the URL of a transaction on the primary explorer, or ""
+// when the chain has none.
+func (r *Registry) TxLink(chainID chain.ID, network, txid string) string {
+	if links := r.TxLinks(chainID, network, txid); len(links) > 0 {
+		return links[0]
+	}
+	return ""
+}
+
+// AddressLinks returns the URL of an address on every explorer.
+func (r *Registry) AddressLinks(chainID chain.ID, network, address string) []string {
+	var links []string
+	for _, e := range r.Explorers(chainID, network) {
+		if link := e.AddressLink(address); link != "" {
+			links = append(links, link)
+		}
+	}
+	return links
+}
+
+// AddressLink returns the URL of an address on the primary explorer, or ""
+// when the chain has none.
+func (r *Registry) AddressLink(chainID chain.ID, network, address string) string {
+	if links := r.AddressLinks(chainID, network, address); len(links) > 0 {
+		return links[0]
+	}
+	return ""
+}
@@ -0,0 +1,46 @@
+package explorer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestRegistry_BuiltIn(t *testing.T) {
+	t.Parallel()
+
+	r := New()
+	for _, id := range []chain.ID{chain.ETH, chain.BSV, chain.BTC, chain.BCH} {
+		for _, network := range []string{Mainnet, Testnet} {
+			list := r.Explorers(id, network)
+			if assert.NotEmpty(t, list, "%s %s", id, network) {
+				assert.NotEmpty(t, list[0].Name)
+				assert.Contains(t, list[0].TxURL, TxPlaceholder)
+				assert.Contains(t, list[0].AddressURL, AddressPlaceholder)
+			}
+		}
+	}
+
+	assert.Equal(t, "https://blockchair.com/bitcoin-cash/transaction/aa", r.TxLink(chain.BCH, Mainnet, "aa"))
+	assert.Equal(t, "https://mempool.space/testnet/address/tb1q", r.AddressLink(chain.BTC, Testnet, "tb1q"))
+	assert.Equal(t, "https://etherscan.io/tx/0xabc", r.TxLink(chain.ETH, "", "0xabc"), "unknown network is mainnet")
+	assert.Len(t, r.TxLinks(chain.BSV, Testnet, "aa"), 2)
+	assert.Empty(t, r.TxLinks(chain.LTC, Mainnet, "aa"))
+	assert.Empty(t, r.AddressLink(chain.LTC, Mainnet, "L1"))
+}
+
+func TestRegistry_Set(t *testing.T) {
+	t.Parallel()
+
+	r := New()
+	r.Set(chain.BSV, Testnet, Explorer{TxURL: "http://10.0.0.5/tx/{txid}"})
+	assert.Equal(t, []string{"http://10.0.0.5/tx/aa"}, r.TxLinks(chain.BSV, Testnet, "aa"))
+	assert.Equal(t, []string{"https://test.whatsonchain.com/address/mx"}, r.AddressLinks(chain.BSV, Testnet, "mx"),
+		"an empty URL keeps the built-in primary one")
+
+	r.Set(chain.LTC, Mainnet, Explorer{Name: "Litecoinspace", TxURL: "https://litecoinspace.org/tx/{txid}"})
+	assert.Equal(t, "https://litecoinspace.org/tx/aa", r.TxLink(chain.LTC, Mainnet, "aa"))
+	assert.Empty(t, r.AddressLink(chain.LTC, Mainnet, "L1"), "no address URL and no built-in")
+}
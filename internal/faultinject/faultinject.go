@@ -0,0 +1,320 @@
+// Package faultinject injects synthetic provider failures into chain client
+// HTTP traffic. It exists so users and CI can confirm that retry, fallback
+// and caching paths degrade gracefully before relying on them in production.
+//
+// Injection is process-wide and off by default. When it is disabled, Wrap
+// returns the transport it was given, so production traffic never passes
+// through this package.
+package faultinject
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Kind is a category of injected fault.
+type Kind string
+
+// Supported fault kinds.
+const (
+	// KindTimeout fails the request with a network timeout error.
+	KindTimeout Kind = "timeout"
+
+	// KindRateLimit answers with HTTP 429 Too Many Requests.
+	KindRateLimit Kind = "429"
+
+	// KindMalformed answers with HTTP 200 and a truncated JSON body.
+	KindMalformed Kind = "malformed"
+)
+
+// defaultRate is the fault probability used when the spec omits rate.
+const defaultRate = 0.2
+
+// malformedBody is a JSON document cut off mid-value.
+const malformedBody = `{"jsonrpc":"2.0","id":1,"result":{"bal`
+
+var (
+	// ErrInvalidSpec indicates a fault injection spec could not be parsed.
+	ErrInvalidSpec = errors.New("invalid fault injection spec")
+
+	// errTimeout is returned for injected timeouts.
+	errTimeout = &timeoutError{}
+)
+
+// AllKinds lists every supported fault kind.
+func AllKinds() []Kind {
+	return []Kind{KindTimeout, KindRateLimit, KindMalformed}
+}
+
+// Config controls which faults are injected and how often.
+type Config struct {
+	// Rate is the probability, from 0 to 1, that a request is faulted.
+	Rate float64
+
+	// Kinds are the faults to choose from. Empty means all kinds.
+	Kinds []Kind
+
+	// Seed makes the fault sequence reproducible. Zero uses the clock.
+	Seed int64
+
+	// Delay is how long an injected timeout waits before failing, bounded
+	// by the request context.
+	Delay time.Duration
+}
+
+// ParseSpec parses a spec of comma-separated key=value pairs:
+//
+//	rate=0.3,kinds=timeout+429+malformed,seed=42,delay=2s
+//
+// Every key is optional. A bare number is shorthand for rate.
+func ParseSpec(spec string) (*Config, error) {
+	cfg := &Config{Rate: defaultRate}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			key, value = "rate", part
+		}
+
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "rate":
+			rate, err := strconv.ParseFloat(value, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return nil, fmt.Errorf("%w: rate must be between 0 and 1, got %q", ErrInvalidSpec, value)
+			}
+			cfg.Rate = rate
+		case "kinds":
+			for _, k := range strings.Split(value, "+") {
+				kind, err := parseKind(k)
+				if err != nil {
+					return nil, err
+				}
+				cfg.Kinds = append(cfg.Kinds, kind)
+			}
+		case "seed":
+			seed, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: seed must be an integer, got %q", ErrInvalidSpec, value)
+			}
+			cfg.Seed = seed
+		case "delay":
+			delay, err := time.ParseDuration(value)
+			if err != nil || delay < 0 {
+				return nil, fmt.Errorf("%w: delay must be a duration, got %q", ErrInvalidSpec, value)
+			}
+			cfg.Delay = delay
+		default:
+			return nil, fmt.Errorf("%w: unknown key %q", ErrInvalidSpec, key)
+		}
+	}
+
+	return cfg, nil
+}
+
+// parseKind converts a spec token to a Kind.
+func parseKind(s string) (Kind, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "timeout":
+		return KindTimeout, nil
+	case "429", "ratelimit", "rate-limit":
+		return KindRateLimit, nil
+	case "malformed":
+		return KindMalformed, nil
+	default:
+		return "", fmt.Errorf("%w: unknown kind %q (use timeout, 429, malformed)", ErrInvalidSpec, s)
+	}
+}
+
+// Stats counts requests seen and faults injected while injection is enabled.
+type Stats struct {
+	Requests int64
+	Injected map[Kind]int64
+}
+
+// Total returns the number of injected faults across all kinds.
+func (s Stats) Total() int64 {
+	var n int64
+	for _, c := range s.Injected {
+		n += c
+	}
+	return n
+}
+
+// injector makes fault decisions for all wrapped transports.
+type injector struct {
+	cfg   Config
+	kinds []Kind
+
+	mu  sync.Mutex
+	rng *rand.Rand
+
+	requests atomic.Int64
+	injected map[Kind]*atomic.Int64
+}
+
+//nolint:gochecknoglobals // Process-wide switch, set once at startup
+var active atomic.Pointer[injector]
+
+// Enable turns on fault injection for transports wrapped after this call.
+func Enable(cfg *Config) {
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	kinds := cfg.Kinds
+	if len(kinds) == 0 {
+		kinds = AllKinds()
+	}
+
+	inj := &injector{
+		cfg:      *cfg,
+		kinds:    kinds,
+		rng:      rand.New(rand.NewPCG(uint64(seed), 0)), //nolint:gosec // G404, G115: fault selection is not security sensitive
+		injected: make(map[Kind]*atomic.Int64, len(kinds)),
+	}
+	for _, k := range AllKinds() {
+		inj.injected[k] = &atomic.Int64{}
+	}
+	active.Store(inj)
+}
+
+// Disable turns off fault injection for transports wrapped after this call.
+func Disable() {
+	active.Store(nil)
+}
+
+// Enabled reports whether fault injection is on.
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// CurrentStats returns counters for the active injector, and false when
+// injection is disabled.
+func CurrentStats() (Stats, bool) {
+	inj := active.Load()
+	if inj == nil {
+		return Stats{}, false
+	}
+
+	stats := Stats{
+		Requests: inj.requests.Load(),
+		Injected: make(map[Kind]int64, len(inj.injected)),
+	}
+	for k, c := range inj.injected {
+		if n := c.Load(); n > 0 {
+			stats.Injected[k] = n
+		}
+	}
+	return stats, true
+}
+
+// Wrap returns rt wrapped with fault injection when it is enabled, or rt
+// itself otherwise. A nil rt means http.DefaultTransport.
+func Wrap(rt http.RoundTripper) http.RoundTripper {
+	inj := active.Load()
+	if inj == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &Transport{base: rt, inj: inj}
+}
+
+// pick decides whether to fault the next request and with which kind.
+func (inj *injector) pick() (Kind, bool) {
+	inj.requests.Add(1)
+
+	inj.mu.Lock()
+	defer inj.mu.Unlock()
+	if inj.rng.Float64() >= inj.cfg.Rate {
+		return "", false
+	}
+	kind := inj.kinds[inj.rng.IntN(len(inj.kinds))]
+	inj.injected[kind].Add(1)
+	return kind, true
+}
+
+// Transport is an http.RoundTripper that fails a share of requests with
+// synthetic timeouts, rate limits, and malformed responses.
+type Transport struct {
+	base http.RoundTripper
+	inj  *injector
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	kind, fault := t.inj.pick()
+	if !fault {
+		return t.base.RoundTrip(req)
+	}
+
+	// RoundTrip must close the request body even when it does not send it.
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+
+	switch kind {
+	case KindTimeout:
+		if t.inj.cfg.Delay > 0 {
+			timer := time.NewTimer(t.inj.cfg.Delay)
+			defer timer.Stop()
+			select {
+			case <-req.Context().Done():
+				return nil, req.Context().Err()
+			case <-timer.C:
+			}
+		}
+		return nil, errTimeout
+	case KindRateLimit:
+		resp := syntheticResponse(req, http.StatusTooManyRequests, `{"error":"rate limit exceeded (fault injection)"}`)
+		resp.Header.Set("Retry-After", "1")
+		return resp, nil
+	case KindMalformed:
+		return syntheticResponse(req, http.StatusOK, malformedBody), nil
+	default:
+		return t.base.RoundTrip(req)
+	}
+}
+
+// CloseIdleConnections closes idle connections on the wrapped transport.
+func (t *Transport) CloseIdleConnections() {
+	if c, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		c.CloseIdleConnections()
+	}
+}
+
+// syntheticResponse builds a JSON response that never touched the network.
+func syntheticResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(strings.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
+
+// timeoutError is an injected net.Error that reports a timeout.
+type timeoutError struct{}
+
+func (*timeoutError) Error() string   { return "fault injection: simulated provider timeout" }
+func (*timeoutError) Timeout() bool   { return true }
+func (*timeoutError) Temporary() bool { return true }
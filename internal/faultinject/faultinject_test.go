@@ -0,0 +1,172 @@
+package faultinject
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSpec(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		spec    string
+		want    *Config
+		wantErr bool
+	}{
+		{name: "empty uses defaults", spec: "", want: &Config{Rate: defaultRate}},
+		{name: "bare rate", spec: "0.5", want: &Config{Rate: 0.5}},
+		{
+			name: "full spec",
+			spec: "rate=0.3, kinds=timeout+429+malformed, seed=42, delay=2s",
+			want: &Config{
+				Rate:  0.3,
+				Kinds: []Kind{KindTimeout, KindRateLimit, KindMalformed},
+				Seed:  42,
+				Delay: 2 * time.Second,
+			},
+		},
+		{name: "kind alias", spec: "kinds=ratelimit", want: &Config{Rate: defaultRate, Kinds: []Kind{KindRateLimit}}},
+		{name: "rate above one", spec: "rate=1.5", wantErr: true},
+		{name: "negative rate", spec: "-0.1", wantErr: true},
+		{name: "unknown kind", spec: "kinds=dns", wantErr: true},
+		{name: "bad seed", spec: "seed=abc", wantErr: true},
+		{name: "bad delay", spec: "delay=soon", wantErr: true},
+		{name: "unknown key", spec: "burst=3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseSpec(tt.spec)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidSpec)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func newOKServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func get(t *testing.T, rt http.RoundTripper, url string) (*http.Response, error) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	require.NoError(t, err)
+	return (&http.Client{Transport: rt}).Do(req)
+}
+
+func TestWrap_Disabled(t *testing.T) {
+	// Not parallel: Enable and Disable change process-wide state
+	Disable()
+
+	base := &http.Transport{}
+	assert.Same(t, base, Wrap(base))
+	assert.Nil(t, Wrap(nil))
+	assert.False(t, Enabled())
+
+	_, ok := CurrentStats()
+	assert.False(t, ok)
+}
+
+func TestTransport_InjectsFaults(t *testing.T) {
+	// Not parallel: Enable and Disable change process-wide state
+	server := newOKServer(t)
+	t.Cleanup(Disable)
+
+	t.Run("timeout", func(t *testing.T) {
+		Enable(&Config{Rate: 1, Kinds: []Kind{KindTimeout}, Seed: 1})
+
+		_, err := get(t, Wrap(nil), server.URL)
+		require.Error(t, err)
+		var netErr net.Error
+		require.ErrorAs(t, err, &netErr)
+		assert.True(t, netErr.Timeout())
+	})
+
+	t.Run("timeout honors context", func(t *testing.T) {
+		Enable(&Config{Rate: 1, Kinds: []Kind{KindTimeout}, Seed: 1, Delay: time.Minute})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+		_, err = (&http.Client{Transport: Wrap(nil)}).Do(req)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("rate limit", func(t *testing.T) {
+		Enable(&Config{Rate: 1, Kinds: []Kind{KindRateLimit}, Seed: 1})
+
+		resp, err := get(t, Wrap(nil), server.URL)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+		assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		Enable(&Config{Rate: 1, Kinds: []Kind{KindMalformed}, Seed: 1})
+
+		resp, err := get(t, Wrap(nil), server.URL)
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var v map[string]any
+		err = json.NewDecoder(resp.Body).Decode(&v)
+		require.Error(t, err)
+		assert.False(t, errors.Is(err, io.EOF))
+	})
+
+	t.Run("zero rate passes through", func(t *testing.T) {
+		Enable(&Config{Rate: 0, Seed: 1})
+
+		resp, err := get(t, Wrap(nil), server.URL)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"ok":true}`, string(body))
+	})
+}
+
+func TestCurrentStats(t *testing.T) {
+	// Not parallel: Enable and Disable change process-wide state
+	server := newOKServer(t)
+	t.Cleanup(Disable)
+
+	Enable(&Config{Rate: 0.5, Kinds: []Kind{KindRateLimit}, Seed: 7})
+	rt := Wrap(nil)
+	for range 20 {
+		resp, err := get(t, rt, server.URL)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+	}
+
+	stats, ok := CurrentStats()
+	require.True(t, ok)
+	assert.Equal(t, int64(20), stats.Requests)
+	assert.Equal(t, stats.Injected[KindRateLimit], stats.Total())
+	assert.Positive(t, stats.Total())
+	assert.Less(t, stats.Total(), int64(20))
+}
@@ -0,0 +1,202 @@
+// Package hooks runs user scripts before and after a send.
+//
+// A hook is an executable named in the hooks section of the configuration.
+// It receives the transaction as a JSON object on stdin and the event name
+// in the SIGIL_HOOK environment variable. The pre_send hook runs after the
+// transaction is priced and before it is signed; a non-zero exit aborts the
+// send. The post_send hook runs after the transaction is broadcast and can
+// only report a failure.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/cosign"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	// DefaultTimeout bounds a hook run when none is configured.
+	DefaultTimeout = 30 * time.Second
+
+	// EventPreSend is the event name of the pre_send hook.
+	EventPreSend = "pre_send"
+
+	// EventPostSend is the event name of the post_send hook.
+	EventPostSend = "post_send"
+
+	// EnvEvent is the environment variable that carries the event name.
+	EnvEvent = "SIGIL_HOOK"
+
+	// maxOutputBytes caps how much hook output is kept for error messages.
+	maxOutputBytes = 4 << 10
+
+	// waitDelay is how long a hook's output pipes may stay open after the
+	// hook is killed, for example by a background child it started.
+	waitDelay = 2 * time.Second
+)
+
+var (
+	// ErrHookFailed is returned when a hook exits non-zero or cannot be run.
+	ErrHookFailed = errors.New("hook failed")
+
+	// ErrTimeout is returned when a hook does not finish within the timeout.
+	ErrTimeout = errors.New("hook did not finish in time")
+)
+
+// Sent describes a broadcast transaction for the post_send hook. Amount and
+// Fee are formatted in the chain's display unit.
+type Sent struct {
+	Chain  string `json:"chain"`
+	Wallet string `json:"wallet"`
+	Hash   string `json:"hash"`
+	From   string `json:"from"`
+	To     string `json:"to,omitempty"`
+	Amount string `json:"amount,omitempty"`
+	Token  string `json:"token,omitempty"`
+	Fee    string `json:"fee"`
+	Status string `json:"status"`
+}
+
+// Runner runs the configured hooks.
+type Runner struct {
+	preSend  string
+	postSend string
+	timeout  time.Duration
+}
+
+// New creates a runner from the hooks configuration. It returns nil when no
+// hook is configured.
+func New(cfg config.HooksConfig) *Runner {
+	if cfg.PreSend == "" && cfg.PostSend == "" {
+		return nil
+	}
+	timeout := DefaultTimeout
+	if cfg.TimeoutSeconds > 0 {
+		timeout = time.Duration(cfg.TimeoutSeconds) * time.Second
+	}
+	return &Runner{
+		preSend:  expandHome(cfg.PreSend),
+		postSend: expandHome(cfg.PostSend),
+		timeout:  timeout,
+	}
+}
+
+// HasPreSend reports whether a pre_send hook is configured.
+func (r *Runner) HasPreSend() bool {
+	return r != nil && r.preSend != ""
+}
+
+// HasPostSend reports whether a post_send hook is configured.
+func (r *Runner) HasPostSend() bool {
+	return r != nil && r.postSend != ""
+}
+
+// Approve runs the pre_send hook with the transaction summary and returns
+// ErrHookRejected unless it exits zero. A RequestID and CreatedAt are filled
+// in when empty. It does nothing when no pre_send hook is configured.
+func (r *Runner) Approve(ctx context.Context, summary *cosign.Summary) error {
+	if !r.HasPreSend() {
+		return nil
+	}
+	if err := summary.Identify(); err != nil {
+		return err
+	}
+
+	err := r.run(ctx, r.preSend, EventPreSend, struct {
+		Event string `json:"event"`
+		*cosign.Summary
+	}{EventPreSend, summary})
+	if err != nil {
+		return sigilerr.WithDetails(sigilerr.ErrHookRejected, map[string]string{
+			"hook":   r.preSend,
+			"reason": err.Error(),
+		})
+	}
+	return nil
+}
+
+// PostSend runs the post_send hook with a broadcast transaction. It does
+// nothing when no post_send hook is configured.
+func (r *Runner) PostSend(ctx context.Context, sent *Sent) error {
+	if !r.HasPostSend() {
+		return nil
+	}
+	return r.run(ctx, r.postSend, EventPostSend, struct {
+		Event string `json:"event"`
+		*Sent
+	}{EventPostSend, sent})
+}
+
+// run executes path with payload as JSON on stdin. The hook's combined
+// output is kept, up to a limit, for the error message.
+func (r *Runner) run(ctx context.Context, path, event string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encoding %s hook input: %w", event, err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var output limitedBuffer
+	cmd := exec.CommandContext(ctx, path) //nolint:gosec // The hook path comes from the user's own config file
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.Env = append(os.Environ(), EnvEvent+"="+event)
+	cmd.WaitDelay = waitDelay
+
+	err = cmd.Run()
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Errorf("%s %w after %s", event, ErrTimeout, r.timeout)
+	}
+
+	msg := err.Error()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		msg = fmt.Sprintf("exited with status %d", exitErr.ExitCode())
+	}
+	if text := strings.TrimSpace(output.String()); text != "" {
+		msg += ": " + text
+	}
+	return fmt.Errorf("%w: %s %s", ErrHookFailed, event, msg)
+}
+
+// limitedBuffer keeps the first maxOutputBytes written to it and discards
+// the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+}
+
+// Write implements io.Writer. It never fails, so a chatty hook is not
+// killed by a broken pipe.
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := maxOutputBytes - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// expandHome replaces a leading ~/ with the user's home directory.
+func expandHome(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
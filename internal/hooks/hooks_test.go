@@ -0,0 +1,136 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/cosign"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// writeHook writes an executable shell script to dir and returns its path.
+func writeHook(t *testing.T, dir, name, body string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("hook scripts need a POSIX shell")
+	}
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("#!/bin/sh\n"+body+"\n"), 0o700)) //nolint:gosec // The test hook must be executable
+	return path
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, New(config.HooksConfig{}), "no hook configured")
+	assert.False(t, New(config.HooksConfig{}).HasPreSend())
+
+	r := New(config.HooksConfig{PostSend: "/bin/true"})
+	require.NotNil(t, r)
+	assert.False(t, r.HasPreSend())
+	assert.True(t, r.HasPostSend())
+	assert.Equal(t, DefaultTimeout, r.timeout)
+
+	r = New(config.HooksConfig{PreSend: "~/hooks/check", TimeoutSeconds: 5})
+	home, err := os.UserHomeDir()
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(home, "hooks", "check"), r.preSend)
+	assert.Equal(t, "5s", r.timeout.String())
+
+	// Without a pre_send hook, Approve approves.
+	require.NoError(t, New(config.HooksConfig{PostSend: "/bin/true"}).Approve(context.Background(), &cosign.Summary{}))
+}
+
+func TestRunner_Approve(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	captured := filepath.Join(dir, "input.json")
+	approve := writeHook(t, dir, "approve", `cat > "`+captured+`"; echo "$SIGIL_HOOK" > "`+captured+`.event"`)
+	reject := writeHook(t, dir, "reject", `echo "amount over 1 BSV" >&2; exit 3`)
+	ctx := context.Background()
+
+	summary := &cosign.Summary{Chain: "bsv", Wallet: "main", To: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", Amount: "150000000"}
+	require.NoError(t, New(config.HooksConfig{PreSend: approve}).Approve(ctx, summary))
+	assert.NotEmpty(t, summary.RequestID, "the request ID is filled in")
+
+	raw, err := os.ReadFile(captured) //nolint:gosec // Test file in t.TempDir
+	require.NoError(t, err)
+	var input map[string]any
+	require.NoError(t, json.Unmarshal(raw, &input))
+	assert.Equal(t, EventPreSend, input["event"])
+	assert.Equal(t, "bsv", input["chain"])
+	assert.Equal(t, "150000000", input["amount"])
+	assert.Equal(t, summary.RequestID, input["request_id"])
+
+	event, err := os.ReadFile(captured + ".event") //nolint:gosec // Test file in t.TempDir
+	require.NoError(t, err)
+	assert.Equal(t, EventPreSend, strings.TrimSpace(string(event)))
+
+	err = New(config.HooksConfig{PreSend: reject}).Approve(ctx, &cosign.Summary{Chain: "bsv"})
+	require.ErrorIs(t, err, sigilerr.ErrHookRejected)
+	assert.Equal(t, sigilerr.ExitPermission, sigilerr.ExitCode(err))
+	assert.Contains(t, err.Error(), "exited with status 3")
+	assert.Contains(t, err.Error(), "amount over 1 BSV")
+
+	err = New(config.HooksConfig{PreSend: filepath.Join(dir, "missing")}).Approve(ctx, &cosign.Summary{})
+	require.ErrorIs(t, err, sigilerr.ErrHookRejected, "a hook that cannot run rejects the send")
+}
+
+func TestRunner_Timeout(t *testing.T) {
+	t.Parallel()
+
+	slow := writeHook(t, t.TempDir(), "slow", "sleep 5")
+	r := New(config.HooksConfig{PreSend: slow, TimeoutSeconds: 1})
+
+	err := r.Approve(context.Background(), &cosign.Summary{})
+	require.ErrorIs(t, err, sigilerr.ErrHookRejected)
+	assert.Contains(t, err.Error(), ErrTimeout.Error())
+}
+
+func TestRunner_PostSend(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	captured := filepath.Join(dir, "input.json")
+	notify := writeHook(t, dir, "notify", `cat > "`+captured+`"`)
+	fail := writeHook(t, dir, "fail", `echo "webhook down"; exit 1`)
+	ctx := context.Background()
+
+	sent := &Sent{Chain: "eth", Wallet: "main", Hash: "0xabc", Amount: "0.5", Fee: "0.0001", Status: "pending"}
+	require.NoError(t, New(config.HooksConfig{PostSend: notify}).PostSend(ctx, sent))
+
+	raw, err := os.ReadFile(captured) //nolint:gosec // Test file in t.TempDir
+	require.NoError(t, err)
+	var input map[string]any
+	require.NoError(t, json.Unmarshal(raw, &input))
+	assert.Equal(t, EventPostSend, input["event"])
+	assert.Equal(t, "0xabc", input["hash"])
+	assert.Equal(t, "0.5", input["amount"])
+
+	err = New(config.HooksConfig{PostSend: fail}).PostSend(ctx, sent)
+	require.ErrorIs(t, err, ErrHookFailed)
+	assert.Contains(t, err.Error(), "webhook down")
+
+	// Without a post_send hook, nothing runs.
+	require.NoError(t, New(config.HooksConfig{PreSend: fail}).PostSend(ctx, sent))
+}
+
+func TestLimitedBuffer(t *testing.T) {
+	t.Parallel()
+
+	var b limitedBuffer
+	n, err := b.Write(make([]byte, maxOutputBytes+100))
+	require.NoError(t, err)
+	assert.Equal(t, maxOutputBytes+100, n)
+	assert.Equal(t, maxOutputBytes, b.Len())
+}
@@ -0,0 +1,244 @@
+// Package listen opens network listeners for sigil's serve modes.
+//
+// A listener binds to a configured address (IPv4, IPv6, or host name),
+// drops connections from clients outside an allowlist of CIDRs, and can
+// serve TLS with a provided or self-signed certificate, optionally
+// requiring client certificates (mutual TLS). Binding beyond loopback is
+// refused unless both TLS and a client allowlist are configured, so the
+// API is never exposed in the clear by accident.
+package listen
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"os"
+	"strings"
+)
+
+// DefaultAddr is the address serve modes bind to when none is configured.
+const DefaultAddr = "127.0.0.1:7420"
+
+var (
+	// ErrInvalidAddr is returned for a bind address that cannot be parsed.
+	ErrInvalidAddr = errors.New("invalid bind address")
+
+	// ErrInvalidCIDR is returned for an allowlist entry that is not an IP or CIDR.
+	ErrInvalidCIDR = errors.New("invalid allowed CIDR")
+
+	// ErrExposedWithoutTLS is returned when a non-loopback bind has no TLS.
+	ErrExposedWithoutTLS = errors.New("binding beyond loopback requires TLS")
+
+	// ErrExposedWithoutAllowlist is returned when a non-loopback bind has no client allowlist.
+	ErrExposedWithoutAllowlist = errors.New("binding beyond loopback requires allowed CIDRs")
+
+	// ErrTLSConfig is returned for an incomplete or unreadable TLS configuration.
+	ErrTLSConfig = errors.New("invalid TLS configuration")
+)
+
+// Options configures a listener.
+type Options struct {
+	// Addr is the host:port to bind. IPv6 hosts are bracketed, as in
+	// "[::1]:7420". Empty means DefaultAddr.
+	Addr string
+
+	// AllowedCIDRs lists the client networks that may connect, as CIDRs
+	// ("10.0.0.0/8", "fd00::/8") or single addresses. Empty allows every
+	// client, which is only accepted on a loopback bind.
+	AllowedCIDRs []string
+
+	// TLS enables TLS when set.
+	TLS *TLSOptions
+}
+
+// TLSOptions configures TLS for a listener.
+type TLSOptions struct {
+	// CertFile and KeyFile are a PEM certificate and key. Leave both empty
+	// and set SelfSignedDir to use a generated certificate instead.
+	CertFile string
+	KeyFile  string
+
+	// SelfSignedDir is where a self-signed certificate is generated, or
+	// reused while still valid, when CertFile and KeyFile are empty.
+	SelfSignedDir string
+
+	// ClientCAFile is a PEM bundle of CAs for client certificates. When
+	// set, clients must present a certificate signed by one of them.
+	ClientCAFile string
+}
+
+// Listen binds a listener as configured by opts.
+func Listen(ctx context.Context, opts Options) (net.Listener, error) {
+	addr := opts.Addr
+	if addr == "" {
+		addr = DefaultAddr
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidAddr, addr, err)
+	}
+
+	allowed, err := ParseCIDRs(opts.AllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsLoopbackHost(host) {
+		if opts.TLS == nil {
+			return nil, fmt.Errorf("%w: %s", ErrExposedWithoutTLS, addr)
+		}
+		if len(allowed) == 0 {
+			return nil, fmt.Errorf("%w: %s", ErrExposedWithoutAllowlist, addr)
+		}
+	}
+
+	var tlsConfig *tls.Config
+	if opts.TLS != nil {
+		if tlsConfig, err = opts.TLS.config(host); err != nil {
+			return nil, err
+		}
+	}
+
+	var lc net.ListenConfig
+	ln, err := lc.Listen(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("binding %s: %w", addr, err)
+	}
+
+	if len(allowed) > 0 {
+		ln = &allowlistListener{Listener: ln, allowed: allowed}
+	}
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+	return ln, nil
+}
+
+// ParseCIDRs parses allowlist entries. A bare address is taken as a
+// single-host network (/32 or /128).
+func ParseCIDRs(entries []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if strings.Contains(entry, "/") {
+			prefix, err := netip.ParsePrefix(entry)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidCIDR, entry)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		ip, err := netip.ParseAddr(entry)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidCIDR, entry)
+		}
+		ip = ip.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(ip, ip.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// IsLoopbackHost reports whether a bind host only accepts local
+// connections: "localhost" or a loopback address. The unspecified
+// addresses ("", "0.0.0.0", "::") bind every interface and are not.
+func IsLoopbackHost(host string) bool {
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip, err := netip.ParseAddr(host)
+	return err == nil && ip.IsLoopback()
+}
+
+// Allowed reports whether a client address falls in one of the prefixes.
+// IPv4-mapped IPv6 addresses match IPv4 prefixes.
+func Allowed(prefixes []netip.Prefix, addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowlistListener closes connections from clients outside its prefixes.
+type allowlistListener struct {
+	net.Listener
+
+	allowed []netip.Prefix
+}
+
+// Accept returns the next connection from an allowed client. Rejected
+// connections are closed before any data is read.
+func (l *allowlistListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if addrPort, parseErr := netip.ParseAddrPort(conn.RemoteAddr().String()); parseErr == nil && Allowed(l.allowed, addrPort.Addr()) {
+			return conn, nil
+		}
+		_ = conn.Close()
+	}
+}
+
+// config builds the server TLS configuration. host names the generated
+// certificate when a self-signed one is used.
+func (o *TLSOptions) config(host string) (*tls.Config, error) {
+	certFile, keyFile := o.CertFile, o.KeyFile
+	switch {
+	case certFile != "" && keyFile != "":
+	case certFile == "" && keyFile == "" && o.SelfSignedDir != "":
+		var err error
+		if certFile, keyFile, err = EnsureSelfSigned(o.SelfSignedDir, selfSignedHosts(host)); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: set both a certificate and key, or a directory for a self-signed certificate", ErrTLSConfig)
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("%w: loading certificate: %w", ErrTLSConfig, err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if o.ClientCAFile != "" {
+		pem, err := os.ReadFile(o.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: reading client CA: %w", ErrTLSConfig, err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: no certificates in client CA file %s", ErrTLSConfig, o.ClientCAFile)
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// selfSignedHosts returns the names a self-signed certificate for a bind
+// host should cover: always localhost and the loopback addresses, plus the
+// host itself when it is specific.
+func selfSignedHosts(host string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if ip, err := netip.ParseAddr(host); err == nil && ip.IsUnspecified() {
+		return hosts
+	}
+	if host != "" && !IsLoopbackHost(host) {
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
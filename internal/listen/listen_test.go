@@ -0,0 +1,262 @@
+package listen
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCIDRs(t *testing.T) {
+	t.Parallel()
+
+	prefixes, err := ParseCIDRs([]string{"10.1.2.3/8", " 192.168.1.5 ", "fd00::/8", "::1", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("192.168.1.5/32"),
+		netip.MustParsePrefix("fd00::/8"),
+		netip.MustParsePrefix("::1/128"),
+	}, prefixes)
+
+	_, err = ParseCIDRs([]string{"not-an-ip"})
+	require.ErrorIs(t, err, ErrInvalidCIDR)
+	_, err = ParseCIDRs([]string{"10.0.0.0/33"})
+	require.ErrorIs(t, err, ErrInvalidCIDR)
+}
+
+func TestAllowed(t *testing.T) {
+	t.Parallel()
+
+	prefixes, err := ParseCIDRs([]string{"10.0.0.0/8", "fd00::/8"})
+	require.NoError(t, err)
+
+	assert.True(t, Allowed(prefixes, netip.MustParseAddr("10.9.8.7")))
+	assert.True(t, Allowed(prefixes, netip.MustParseAddr("::ffff:10.9.8.7")), "IPv4-mapped addresses match IPv4 prefixes")
+	assert.True(t, Allowed(prefixes, netip.MustParseAddr("fd12::1")))
+	assert.False(t, Allowed(prefixes, netip.MustParseAddr("192.168.0.1")))
+	assert.False(t, Allowed(prefixes, netip.MustParseAddr("2001:db8::1")))
+}
+
+func TestIsLoopbackHost(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsLoopbackHost("localhost"))
+	assert.True(t, IsLoopbackHost("127.0.0.1"))
+	assert.True(t, IsLoopbackHost("::1"))
+	assert.False(t, IsLoopbackHost(""))
+	assert.False(t, IsLoopbackHost("0.0.0.0"))
+	assert.False(t, IsLoopbackHost("::"))
+	assert.False(t, IsLoopbackHost("192.168.1.10"))
+}
+
+func TestListen_ExposedBindRequiresTLSAndAllowlist(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	_, err := Listen(ctx, Options{Addr: "0.0.0.0:0", AllowedCIDRs: []string{"10.0.0.0/8"}})
+	require.ErrorIs(t, err, ErrExposedWithoutTLS)
+
+	_, err = Listen(ctx, Options{Addr: "[::]:0", TLS: &TLSOptions{SelfSignedDir: t.TempDir()}})
+	require.ErrorIs(t, err, ErrExposedWithoutAllowlist)
+
+	_, err = Listen(ctx, Options{Addr: "127.0.0.1"})
+	require.ErrorIs(t, err, ErrInvalidAddr)
+
+	_, err = Listen(ctx, Options{Addr: "127.0.0.1:0", TLS: &TLSOptions{CertFile: "cert.pem"}})
+	require.ErrorIs(t, err, ErrTLSConfig)
+}
+
+// serveEcho accepts connections on ln and echoes one line back.
+func serveEcho(ln net.Listener) {
+	for {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer func() { _ = conn.Close() }()
+			buf := make([]byte, 4)
+			if _, err := io.ReadFull(conn, buf); err == nil {
+				_, _ = conn.Write(buf)
+			}
+		}()
+	}
+}
+
+func TestListen_Allowlist(t *testing.T) {
+	t.Parallel()
+
+	t.Run("allowed client", func(t *testing.T) {
+		t.Parallel()
+		ln, err := Listen(context.Background(), Options{Addr: "127.0.0.1:0", AllowedCIDRs: []string{"127.0.0.0/8"}})
+		require.NoError(t, err)
+		defer func() { _ = ln.Close() }()
+		go serveEcho(ln)
+
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		_, err = conn.Write([]byte("ping"))
+		require.NoError(t, err)
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(conn, buf)
+		require.NoError(t, err)
+		assert.Equal(t, "ping", string(buf))
+	})
+
+	t.Run("rejected client", func(t *testing.T) {
+		t.Parallel()
+		ln, err := Listen(context.Background(), Options{Addr: "127.0.0.1:0", AllowedCIDRs: []string{"10.0.0.0/8"}})
+		require.NoError(t, err)
+		defer func() { _ = ln.Close() }()
+		go serveEcho(ln)
+
+		conn, err := net.Dial("tcp", ln.Addr().String())
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		_, _ = conn.Write([]byte("ping"))
+		_, err = io.ReadFull(conn, make([]byte, 4))
+		require.Error(t, err, "connection is closed without a reply")
+	})
+}
+
+func TestListen_SelfSignedTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ln, err := Listen(context.Background(), Options{Addr: "127.0.0.1:0", TLS: &TLSOptions{SelfSignedDir: dir}})
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	go serveEcho(ln)
+
+	pool := x509.NewCertPool()
+	certPEM, err := os.ReadFile(filepath.Join(dir, selfSignedCertFile))
+	require.NoError(t, err)
+	require.True(t, pool.AppendCertsFromPEM(certPEM))
+
+	conn, err := tls.Dial("tcp", ln.Addr().String(), &tls.Config{RootCAs: pool, ServerName: "localhost", MinVersion: tls.VersionTLS12})
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	_, err = conn.Write([]byte("ping"))
+	require.NoError(t, err)
+	buf := make([]byte, 4)
+	_, err = io.ReadFull(conn, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ping", string(buf))
+
+	info, err := os.Stat(filepath.Join(dir, selfSignedKeyFile))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(keyFilePermissions), info.Mode().Perm())
+}
+
+func TestEnsureSelfSigned_Reuse(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certPath, _, err := EnsureSelfSigned(dir, []string{"localhost"})
+	require.NoError(t, err)
+	first, err := os.ReadFile(certPath)
+	require.NoError(t, err)
+
+	_, _, err = EnsureSelfSigned(dir, []string{"localhost"})
+	require.NoError(t, err)
+	second, err := os.ReadFile(certPath)
+	require.NoError(t, err)
+	assert.Equal(t, first, second, "a valid certificate is reused")
+
+	_, _, err = EnsureSelfSigned(dir, []string{"localhost", "wallet.internal"})
+	require.NoError(t, err)
+	cert, err := readCertificate(certPath)
+	require.NoError(t, err)
+	assert.Contains(t, cert.DNSNames, "wallet.internal", "a certificate missing a host is regenerated")
+}
+
+// writeTestCA writes a CA certificate and returns it with its key.
+func writeTestCA(t *testing.T, path string) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	ca, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return ca, key
+}
+
+func TestListen_MutualTLS(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	ca, caKey := writeTestCA(t, caPath)
+
+	ln, err := Listen(context.Background(), Options{
+		Addr: "127.0.0.1:0",
+		TLS:  &TLSOptions{SelfSignedDir: dir, ClientCAFile: caPath},
+	})
+	require.NoError(t, err)
+	defer func() { _ = ln.Close() }()
+	go serveEcho(ln)
+
+	// Client certificate signed by the CA
+	clientKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca, &clientKey.PublicKey, caKey)
+	require.NoError(t, err)
+	clientCert := tls.Certificate{Certificate: [][]byte{der}, PrivateKey: clientKey}
+
+	exchange := func(certs []tls.Certificate) error {
+		conn, dialErr := tls.Dial("tcp", ln.Addr().String(), &tls.Config{
+			InsecureSkipVerify: true, //nolint:gosec // test only checks the client side of the handshake
+			Certificates:       certs,
+			MinVersion:         tls.VersionTLS12,
+		})
+		if dialErr != nil {
+			return dialErr
+		}
+		defer func() { _ = conn.Close() }()
+		_ = conn.SetDeadline(time.Now().Add(5 * time.Second))
+		if _, writeErr := conn.Write([]byte("ping")); writeErr != nil {
+			return writeErr
+		}
+		_, readErr := io.ReadFull(conn, make([]byte, 4))
+		return readErr
+	}
+
+	require.NoError(t, exchange([]tls.Certificate{clientCert}))
+	require.Error(t, exchange(nil), "clients without a certificate are refused")
+}
@@ -0,0 +1,133 @@
+package listen
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// selfSignedCertFile and selfSignedKeyFile are the generated file names.
+	selfSignedCertFile = "cert.pem"
+	selfSignedKeyFile  = "key.pem"
+
+	// selfSignedValidity is how long a generated certificate is valid.
+	selfSignedValidity = 365 * 24 * time.Hour
+
+	// selfSignedRenewBefore regenerates a certificate this close to expiry.
+	selfSignedRenewBefore = 7 * 24 * time.Hour
+
+	// Permissions for the certificate directory and files.
+	certDirPermissions  = 0o700
+	certFilePermissions = 0o644
+	keyFilePermissions  = 0o600
+)
+
+// EnsureSelfSigned returns the paths of a self-signed certificate and key in
+// dir covering hosts, generating a new pair unless the existing certificate
+// covers every host and is not about to expire.
+func EnsureSelfSigned(dir string, hosts []string) (string, string, error) {
+	certPath := filepath.Join(dir, selfSignedCertFile)
+	keyPath := filepath.Join(dir, selfSignedKeyFile)
+
+	if cert, err := readCertificate(certPath); err == nil && certUsable(cert, hosts, time.Now()) {
+		if _, statErr := os.Stat(keyPath); statErr == nil {
+			return certPath, keyPath, nil
+		}
+	}
+
+	certPEM, keyPEM, err := generateSelfSigned(hosts, time.Now())
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(dir, certDirPermissions); err != nil {
+		return "", "", fmt.Errorf("creating certificate directory: %w", err)
+	}
+	if err := fileutil.WriteAtomic(keyPath, keyPEM, keyFilePermissions); err != nil {
+		return "", "", fmt.Errorf("writing key: %w", err)
+	}
+	if err := fileutil.WriteAtomic(certPath, certPEM, certFilePermissions); err != nil {
+		return "", "", fmt.Errorf("writing certificate: %w", err)
+	}
+	return certPath, keyPath, nil
+}
+
+// readCertificate parses the first certificate in a PEM file.
+func readCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is the configured certificate directory
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%w: no certificate in %s", ErrTLSConfig, path)
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// certUsable reports whether cert is valid well past now and names every host.
+func certUsable(cert *x509.Certificate, hosts []string, now time.Time) bool {
+	if now.Before(cert.NotBefore) || now.Add(selfSignedRenewBefore).After(cert.NotAfter) {
+		return false
+	}
+	for _, host := range hosts {
+		if cert.VerifyHostname(host) != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// generateSelfSigned creates a PEM certificate and ECDSA P-256 key for hosts.
+func generateSelfSigned(hosts []string, now time.Time) ([]byte, []byte, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating key: %w", err)
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, fmt.Errorf("generating serial number: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"sigil"}, CommonName: "sigil self-signed"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	for _, host := range slices.Compact(slices.Sorted(slices.Values(hosts))) {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("creating certificate: %w", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encoding key: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return certPEM, keyPEM, nil
+}
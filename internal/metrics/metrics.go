@@ -4,10 +4,20 @@
 package metrics
 
 import (
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// Phase names used for per-command timing breakdowns.
+const (
+	PhaseWalletLoad = "wallet load"
+	PhaseUTXOFetch  = "utxo fetch"
+	PhaseFeeQuote   = "fee quote"
+	PhaseSign       = "sign"
+	PhaseBroadcast  = "broadcast"
+)
+
 // Metrics holds application metrics using atomic counters for thread safety.
 type Metrics struct {
 	// RPC metrics
@@ -26,6 +36,17 @@ type Metrics struct {
 	// Chain-specific RPC calls
 	ethRPCCalls atomic.Int64
 	bsvRPCCalls atomic.Int64
+
+	// Phase timings, in order of first occurrence
+	phaseMu sync.Mutex
+	phases  []PhaseTiming
+}
+
+// PhaseTiming is the accumulated wall time spent in one named phase of a command.
+type PhaseTiming struct {
+	Name     string
+	Duration time.Duration
+	Count    int
 }
 
 // Global is the global metrics instance.
@@ -70,6 +91,43 @@ func (m *Metrics) RecordCacheMiss() {
 	m.cacheMisses.Add(1)
 }
 
+// RecordPhase adds d to the named phase. Repeated phases accumulate, so a
+// command that fetches fee quotes twice reports their combined time.
+func (m *Metrics) RecordPhase(name string, d time.Duration) {
+	m.phaseMu.Lock()
+	defer m.phaseMu.Unlock()
+
+	for i := range m.phases {
+		if m.phases[i].Name == name {
+			m.phases[i].Duration += d
+			m.phases[i].Count++
+			return
+		}
+	}
+	m.phases = append(m.phases, PhaseTiming{Name: name, Duration: d, Count: 1})
+}
+
+// StartPhase starts timing the named phase and returns a function that
+// records it. Typical use is:
+//
+//	defer metrics.Global.StartPhase(metrics.PhaseBroadcast)()
+func (m *Metrics) StartPhase(name string) func() {
+	start := time.Now()
+	return func() {
+		m.RecordPhase(name, time.Since(start))
+	}
+}
+
+// Phases returns a copy of the recorded phase timings in order of first occurrence.
+func (m *Metrics) Phases() []PhaseTiming {
+	m.phaseMu.Lock()
+	defer m.phaseMu.Unlock()
+
+	out := make([]PhaseTiming, len(m.phases))
+	copy(out, m.phases)
+	return out
+}
+
 // Snapshot returns a point-in-time copy of all metrics.
 type Snapshot struct {
 	RPCCallsTotal   int64
@@ -143,4 +201,8 @@ func (m *Metrics) Reset() {
 	m.cacheMisses.Store(0)
 	m.ethRPCCalls.Store(0)
 	m.bsvRPCCalls.Store(0)
+
+	m.phaseMu.Lock()
+	m.phases = nil
+	m.phaseMu.Unlock()
 }
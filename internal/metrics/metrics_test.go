@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
@@ -100,6 +101,30 @@ func TestMetrics_Reset(t *testing.T) {
 	assert.Equal(t, int64(0), snap.WalletOpsTotal)
 }
 
+func TestMetrics_Phases(t *testing.T) {
+	t.Parallel()
+	m := &Metrics{}
+
+	m.RecordPhase(PhaseWalletLoad, 10*time.Millisecond)
+	m.RecordPhase(PhaseFeeQuote, 20*time.Millisecond)
+	m.RecordPhase(PhaseWalletLoad, 5*time.Millisecond)
+	m.StartPhase(PhaseBroadcast)()
+
+	phases := m.Phases()
+	require.Len(t, phases, 3)
+	assert.Equal(t, PhaseTiming{Name: PhaseWalletLoad, Duration: 15 * time.Millisecond, Count: 2}, phases[0])
+	assert.Equal(t, PhaseFeeQuote, phases[1].Name)
+	assert.Equal(t, PhaseBroadcast, phases[2].Name)
+	assert.Equal(t, 1, phases[2].Count)
+
+	// Returned slice is a copy
+	phases[0].Duration = 0
+	assert.Equal(t, 15*time.Millisecond, m.Phases()[0].Duration)
+
+	m.Reset()
+	assert.Empty(t, m.Phases())
+}
+
 func TestGlobal(t *testing.T) {
 	// Test that Global is initialized
 	assert.NotNil(t, Global)
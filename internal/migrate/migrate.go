@@ -0,0 +1,417 @@
+// Package migrate upgrades sigil's data files to the format this build
+// writes.
+//
+// Each kind of file (wallet files, UTXO stores, balance caches, agent
+// credentials and vaults) has a schema version in the file. A file older than
+// the current version is upgraded by the ordered migrations registered for
+// its kind, one version at a time, after the original is copied to a backup
+// directory. Files written by a newer sigil are reported and never rewritten.
+//
+// Migrations work on the decoded JSON document rather than on the Go types,
+// so they keep fields they do not know and describe the old format as it was.
+package migrate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// stateFileName records the versions the home was last migrated to.
+	stateFileName = "schema.json"
+
+	// backupPrefix names the backup directory of a run, under backups/.
+	backupPrefix = "migrate-"
+
+	// backupFilePermissions for backed up files and the state file.
+	backupFilePermissions = 0o600
+
+	// backupDirPermissions for the backup directories.
+	backupDirPermissions = 0o700
+)
+
+// ErrNoMigration is returned when a file's version has no migration to the
+// next one.
+var ErrNoMigration = errors.New("no migration for version")
+
+// Document is a data file decoded from JSON. Numbers are json.Number so
+// amounts keep their precision.
+type Document map[string]any
+
+// Migration upgrades a document of a store from version From to From+1.
+// The version field is set by Store.Upgrade; Apply changes everything else.
+type Migration struct {
+	From        int
+	Description string
+	Apply       func(doc Document) error
+}
+
+// Store is a kind of data file with a schema version.
+type Store struct {
+	// Name identifies the store, such as "wallet".
+	Name string
+
+	// Patterns are globs, relative to the sigil home, matching its files.
+	Patterns []string
+
+	// VersionPath is the path of keys to the version field.
+	VersionPath []string
+
+	// Current is the version this build writes.
+	Current int
+
+	// Migrations are the upgrades from each older version, in order.
+	Migrations []Migration
+}
+
+// States of a file reported by FileStatus.State.
+const (
+	StateCurrent = "current"
+	StatePending = "pending"
+	StateNewer   = "newer"
+	StateError   = "error"
+)
+
+// FileStatus is the schema version of one data file.
+type FileStatus struct {
+	Store   string   `json:"store"`
+	Path    string   `json:"path"`
+	Version int      `json:"version"`
+	Current int      `json:"current"`
+	Pending []string `json:"pending,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+// State returns StateCurrent, StatePending, StateNewer, or StateError.
+func (f FileStatus) State() string {
+	switch {
+	case f.Error != "":
+		return StateError
+	case f.Version > f.Current:
+		return StateNewer
+	case f.Version < f.Current:
+		return StatePending
+	default:
+		return StateCurrent
+	}
+}
+
+// Result is the outcome of Run.
+type Result struct {
+	// Migrated lists the files that were upgraded, as they were before.
+	Migrated []FileStatus `json:"migrated"`
+
+	// Failed lists the files that could not be read or upgraded.
+	Failed []FileStatus `json:"failed,omitempty"`
+
+	// BackupDir holds the originals of the migrated files; empty when
+	// nothing was migrated.
+	BackupDir string `json:"backup_dir,omitempty"`
+}
+
+// state is the content of the state file.
+type state struct {
+	Versions  map[string]int `json:"versions"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// Status reports the schema version of every data file under home, in
+// store order and then by path.
+func Status(home string) ([]FileStatus, error) {
+	var statuses []FileStatus
+	for i := range Stores() {
+		store := &Stores()[i]
+		paths, err := store.files(home)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			status, _ := store.inspect(home, path)
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses, nil
+}
+
+// Due reports whether home may hold files older than this build writes:
+// it has not been migrated since a store's version was raised.
+func Due(home string) bool {
+	st, err := readState(home)
+	if err != nil {
+		return true
+	}
+	for _, store := range Stores() {
+		if st.Versions[store.Name] < store.Current {
+			return true
+		}
+	}
+	return false
+}
+
+// Run upgrades every data file under home that is older than its store's
+// current version. The original of each file is first copied to
+// backups/migrate-<time>/ under home. A file that fails is left as it was
+// and reported in Result.Failed; the others are still migrated. Once no
+// file failed, the state file records the current versions so Due is false.
+func Run(home string, now time.Time) (*Result, error) {
+	result := &Result{}
+	backupDir := filepath.Join(home, "backups", backupPrefix+now.UTC().Format("20060102-150405"))
+
+	for i := range Stores() {
+		store := &Stores()[i]
+		paths, err := store.files(home)
+		if err != nil {
+			return nil, err
+		}
+		for _, path := range paths {
+			status, doc := store.inspect(home, path)
+			switch status.State() {
+			case StatePending:
+			case StateError:
+				result.Failed = append(result.Failed, status)
+				continue
+			default:
+				continue
+			}
+
+			if err := store.Upgrade(doc, status.Version); err != nil {
+				status.Error = err.Error()
+				result.Failed = append(result.Failed, status)
+				continue
+			}
+			if err := backupFile(home, path, backupDir); err != nil {
+				return result, err
+			}
+			result.BackupDir = backupDir
+			if err := writeDocument(path, doc); err != nil {
+				status.Error = err.Error()
+				result.Failed = append(result.Failed, status)
+				continue
+			}
+			result.Migrated = append(result.Migrated, status)
+		}
+	}
+
+	if len(result.Failed) == 0 {
+		if err := writeState(home, now); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// Upgrade applies the store's migrations to doc, from version to the
+// current one, and sets the version field.
+func (s *Store) Upgrade(doc Document, version int) error {
+	for version < s.Current {
+		m, ok := s.migration(version)
+		if !ok {
+			return fmt.Errorf("%w %d of %s", ErrNoMigration, version, s.Name)
+		}
+		if err := m.Apply(doc); err != nil {
+			return fmt.Errorf("%s version %d to %d: %w", s.Name, version, version+1, err)
+		}
+		version++
+		if err := setVersion(doc, s.VersionPath, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// migration returns the migration from version.
+func (s *Store) migration(version int) (Migration, bool) {
+	for _, m := range s.Migrations {
+		if m.From == version {
+			return m, true
+		}
+	}
+	return Migration{}, false
+}
+
+// files returns the store's files under home, sorted.
+func (s *Store) files(home string) ([]string, error) {
+	var paths []string
+	for _, pattern := range s.Patterns {
+		matches, err := filepath.Glob(filepath.Join(home, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("scanning for %s files: %w", s.Name, err)
+		}
+		paths = append(paths, matches...)
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// inspect reads a file and returns its status with the decoded document.
+func (s *Store) inspect(home, path string) (FileStatus, Document) {
+	status := FileStatus{Store: s.Name, Path: path, Current: s.Current}
+	if rel, err := filepath.Rel(home, path); err == nil {
+		status.Path = rel
+	}
+
+	doc, err := readDocument(path)
+	if err != nil {
+		status.Error = err.Error()
+		return status, nil
+	}
+	if status.Version, err = version(doc, s.VersionPath); err != nil {
+		status.Error = err.Error()
+		return status, nil
+	}
+	for v := status.Version; v < s.Current; v++ {
+		if m, ok := s.migration(v); ok {
+			status.Pending = append(status.Pending, m.Description)
+		}
+	}
+	return status, doc
+}
+
+// readDocument decodes a JSON file.
+func readDocument(path string) (Document, error) {
+	//nolint:gosec // G304: Path comes from a glob under the sigil home
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading file: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc Document
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("parsing file: %w", err)
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("parsing file: %w", errNotObject)
+	}
+	return doc, nil
+}
+
+// writeDocument writes doc over path atomically, keeping its permissions.
+func writeDocument(path string, doc Document) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding file: %w", err)
+	}
+	if err := fileutil.WriteAtomic(path, data, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("writing file: %w", err)
+	}
+	return nil
+}
+
+// backupFile copies path into backupDir at its path relative to home.
+func backupFile(home, path, backupDir string) error {
+	rel, err := filepath.Rel(home, path)
+	if err != nil {
+		return fmt.Errorf("backing up %s: %w", path, err)
+	}
+	//nolint:gosec // G304: Path comes from a glob under the sigil home
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("backing up %s: %w", rel, err)
+	}
+	dest := filepath.Join(backupDir, rel)
+	if err := os.MkdirAll(filepath.Dir(dest), backupDirPermissions); err != nil {
+		return fmt.Errorf("creating backup directory: %w", err)
+	}
+	if err := fileutil.WriteAtomic(dest, data, backupFilePermissions); err != nil {
+		return fmt.Errorf("backing up %s: %w", rel, err)
+	}
+	return nil
+}
+
+// readState reads the state file. A missing file is an error.
+func readState(home string) (*state, error) {
+	data, err := os.ReadFile(filepath.Join(home, stateFileName))
+	if err != nil {
+		return nil, err
+	}
+	var st state
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", stateFileName, err)
+	}
+	return &st, nil
+}
+
+// writeState records the current version of every store.
+func writeState(home string, now time.Time) error {
+	st := state{Versions: make(map[string]int), UpdatedAt: now.UTC()}
+	for _, store := range Stores() {
+		st.Versions[store.Name] = store.Current
+	}
+	data, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", stateFileName, err)
+	}
+	if _, err := os.Stat(home); os.IsNotExist(err) {
+		// Nothing to migrate in a home that does not exist yet
+		return nil
+	}
+	if err := fileutil.WriteAtomic(filepath.Join(home, stateFileName), data, backupFilePermissions); err != nil {
+		return fmt.Errorf("writing %s: %w", stateFileName, err)
+	}
+	return nil
+}
+
+// errNotObject is returned for a file that is not a JSON object.
+var errNotObject = errors.New("not a JSON object")
+
+// version reads the version field at path; a missing field is version 0.
+func version(doc Document, path []string) (int, error) {
+	var cur any = map[string]any(doc)
+	for _, key := range path {
+		obj, ok := asObject(cur)
+		if !ok {
+			return 0, fmt.Errorf("version: %q is %w", key, errNotObject)
+		}
+		if cur, ok = obj[key]; !ok || cur == nil {
+			return 0, nil
+		}
+	}
+	n, ok := cur.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("version is %v, not a number", cur)
+	}
+	v, err := n.Int64()
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid version %s", n)
+	}
+	return int(v), nil
+}
+
+// setVersion sets the version field at path.
+func setVersion(doc Document, path []string, v int) error {
+	obj := map[string]any(doc)
+	for _, key := range path[:len(path)-1] {
+		next, ok := asObject(obj[key])
+		if !ok {
+			return fmt.Errorf("version: %q is %w", key, errNotObject)
+		}
+		obj = next
+	}
+	obj[path[len(path)-1]] = json.Number(fmt.Sprint(v))
+	return nil
+}
+
+// asObject returns v as a JSON object.
+func asObject(v any) (map[string]any, bool) {
+	switch obj := v.(type) {
+	case map[string]any:
+		return obj, true
+	case Document:
+		return obj, true
+	default:
+		return nil, false
+	}
+}
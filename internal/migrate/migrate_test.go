@@ -0,0 +1,290 @@
+package migrate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// Version 0 files, as written before versions were recorded.
+const (
+	walletV0File = `{
+  "wallet": {
+    "name": "main",
+    "created_at": "2024-01-02T03:04:05Z",
+    "addresses": {
+      "eth": [{"path": "m/44'/60'/0'/0/0", "index": 0, "address": "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "public_key": "02aa"}],
+      "bsv": [{"path": "m/44'/236'/0'/0/0", "index": 0, "address": "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "public_key": "02bb"}]
+    },
+    "derivation_config": {"default_account": 0, "address_gap": 20}
+  },
+  "encrypted_seed": "AAEC"
+}`
+
+	utxostoreV0File = `{
+  "updated_at": "2024-01-02T03:04:05Z",
+  "utxos": {
+    "bsv:aa:0": {"chain_id": "bsv", "txid": "aa", "vout": 0, "amount": 12345678901234567, "script": "76a9", "address": "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", "confirmations": 3, "spent": false, "first_seen": "2024-01-02T03:04:05Z"}
+  }
+}`
+
+	cacheV0File = `{
+  "entries": {
+    "eth:0x742d35Cc6634C0532925a3b844Bc454e4438f44e": {"chain": "eth", "address": "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "balance": "1.5", "symbol": "ETH", "decimals": 18, "updated_at": "2024-01-02T03:04:05Z"},
+    "eth:0x742d35Cc6634C0532925a3b844Bc454e4438f44e:0xA0b8": {"chain": "eth", "address": "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "balance": "10", "symbol": "USDC", "decimals": 6, "token": "0xA0b8", "updated_at": "2024-01-02T03:04:05Z"}
+  }
+}`
+
+	agentV0File = `{
+  "id": "agt_0123456789abcdef",
+  "label": "bot",
+  "wallet_name": "main",
+  "chains": ["bsv"],
+  "encrypted_seed": "AAEC",
+  "policy": {"max_per_tx_sat": 1000, "max_per_tx_wei": "", "max_daily_sat": 5000, "max_daily_wei": ""},
+  "policy_hmac": "00",
+  "created_at": "2024-01-02T03:04:05Z",
+  "expires_at": "2099-01-02T03:04:05Z"
+}`
+)
+
+// writeHomeFile writes a file under home, creating its directory.
+func writeHomeFile(t *testing.T, home, rel, content string) string {
+	t.Helper()
+	path := filepath.Join(home, rel)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+// newV0Home returns a home holding a version 0 file of every store.
+func newV0Home(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	writeHomeFile(t, home, "wallets/main.wallet", walletV0File)
+	writeHomeFile(t, home, "wallets/main/utxos.json", utxostoreV0File)
+	writeHomeFile(t, home, "cache/balances.json", cacheV0File)
+	writeHomeFile(t, home, "agents/main-agt_0123456789abcdef.agent", agentV0File)
+	return home
+}
+
+func TestStores_CompleteChains(t *testing.T) {
+	t.Parallel()
+
+	names := map[string]bool{}
+	for _, store := range Stores() {
+		assert.False(t, names[store.Name], "duplicate store %s", store.Name)
+		names[store.Name] = true
+		assert.NotEmpty(t, store.Patterns, store.Name)
+		assert.NotEmpty(t, store.VersionPath, store.Name)
+		assert.Positive(t, store.Current, store.Name)
+
+		if len(store.Migrations) == 0 {
+			continue
+		}
+		// Every version from the oldest migration up needs the next step
+		oldest := store.Migrations[0].From
+		for v := oldest; v < store.Current; v++ {
+			m, ok := store.migration(v)
+			require.True(t, ok, "%s has no migration from version %d", store.Name, v)
+			assert.NotEmpty(t, m.Description)
+			assert.NotNil(t, m.Apply)
+		}
+		for _, m := range store.Migrations {
+			assert.Less(t, m.From, store.Current, "%s migration from %d is not below the current version", store.Name, m.From)
+		}
+	}
+}
+
+func TestStatus(t *testing.T) {
+	t.Parallel()
+
+	home := newV0Home(t)
+	writeHomeFile(t, home, "wallets/broken.wallet", "{not json")
+	writeHomeFile(t, home, "wallets/future.wallet", `{"wallet": {"name": "future", "version": 99}}`)
+
+	statuses, err := Status(home)
+	require.NoError(t, err)
+
+	byPath := map[string]FileStatus{}
+	for _, s := range statuses {
+		byPath[s.Path] = s
+	}
+	require.Len(t, byPath, 6)
+
+	main := byPath[filepath.Join("wallets", "main.wallet")]
+	assert.Equal(t, "wallet", main.Store)
+	assert.Equal(t, 0, main.Version)
+	assert.Equal(t, StatePending, main.State())
+	assert.Len(t, main.Pending, 1)
+
+	assert.Equal(t, StateError, byPath[filepath.Join("wallets", "broken.wallet")].State())
+	assert.Equal(t, StateNewer, byPath[filepath.Join("wallets", "future.wallet")].State())
+	assert.Equal(t, "cache", byPath[filepath.Join("cache", "balances.json")].Store)
+	assert.Equal(t, "agent", byPath[filepath.Join("agents", "main-agt_0123456789abcdef.agent")].Store)
+
+	// Store order: wallets first
+	assert.Equal(t, "wallet", statuses[0].Store)
+}
+
+func TestRun_UpgradesEveryStore(t *testing.T) {
+	t.Parallel()
+
+	home := newV0Home(t)
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.True(t, Due(home))
+
+	result, err := Run(home, now)
+	require.NoError(t, err)
+	assert.Empty(t, result.Failed)
+	assert.Len(t, result.Migrated, 4)
+	assert.Equal(t, filepath.Join(home, "backups", "migrate-20260102-030405"), result.BackupDir)
+	assert.False(t, Due(home), "state file records the current versions")
+
+	statuses, err := Status(home)
+	require.NoError(t, err)
+	for _, s := range statuses {
+		assert.Equal(t, StateCurrent, s.State(), s.Path)
+	}
+
+	t.Run("wallet", func(t *testing.T) {
+		t.Parallel()
+		w, err := wallet.NewFileStorage(filepath.Join(home, "wallets")).LoadMetadata("main")
+		require.NoError(t, err)
+		assert.Equal(t, wallet.CurrentVersion, w.Version)
+		assert.Equal(t, []wallet.ChainID{wallet.ChainBSV, wallet.ChainETH}, w.EnabledChains)
+		assert.Len(t, w.Addresses[wallet.ChainETH], 1)
+	})
+
+	t.Run("utxostore", func(t *testing.T) {
+		t.Parallel()
+		store := utxostore.New(filepath.Join(home, "wallets", "main"))
+		require.NoError(t, store.Load())
+		utxos := store.GetUTXOs(chain.BSV, "")
+		require.Len(t, utxos, 1)
+		assert.Equal(t, uint64(12345678901234567), utxos[0].Amount, "amounts keep their precision")
+	})
+
+	t.Run("cache", func(t *testing.T) {
+		t.Parallel()
+		c, err := cache.NewFileStorage(filepath.Join(home, "cache", "balances.json")).WithLimits(cache.Limits{}).Load()
+		require.NoError(t, err)
+		assert.Equal(t, cache.CurrentVersion, c.Version)
+		entry, ok, _ := c.Get(chain.ETH, "0x742d35Cc6634C0532925a3b844Bc454e4438f44e", "0xA0b8")
+		require.True(t, ok)
+		assert.Equal(t, "USDC", entry.Symbol)
+	})
+
+	t.Run("agent", func(t *testing.T) {
+		t.Parallel()
+		data, err := os.ReadFile(filepath.Join(home, "agents", "main-agt_0123456789abcdef.agent"))
+		require.NoError(t, err)
+		var cred agent.Credential
+		require.NoError(t, json.Unmarshal(data, &cred))
+		assert.Equal(t, agent.CredentialVersion, cred.Version)
+		assert.Equal(t, uint64(5000), cred.Policy.MaxDailySat)
+		assert.Equal(t, "00", cred.PolicyHMAC, "the policy is not re-signed")
+	})
+
+	t.Run("backups", func(t *testing.T) {
+		t.Parallel()
+		data, err := os.ReadFile(filepath.Join(result.BackupDir, "wallets", "main.wallet"))
+		require.NoError(t, err)
+		assert.Equal(t, walletV0File, string(data), "the original is backed up unchanged")
+
+		info, err := os.Stat(filepath.Join(result.BackupDir, "cache", "balances.json"))
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(backupFilePermissions), info.Mode().Perm())
+	})
+}
+
+func TestRun_NothingToDo(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	result, err := Run(home, time.Now())
+	require.NoError(t, err)
+	assert.Empty(t, result.Migrated)
+	assert.Empty(t, result.BackupDir)
+	assert.False(t, Due(home))
+
+	_, err = os.Stat(filepath.Join(home, "backups"))
+	assert.True(t, os.IsNotExist(err), "no backup directory without migrations")
+}
+
+func TestRun_SkipsNewerAndBrokenFiles(t *testing.T) {
+	t.Parallel()
+
+	home := t.TempDir()
+	future := `{"wallet": {"name": "future", "version": 99, "shiny": true}}`
+	futurePath := writeHomeFile(t, home, "wallets/future.wallet", future)
+	brokenPath := writeHomeFile(t, home, "wallets/broken.wallet", "{not json")
+	writeHomeFile(t, home, "cache/balances-test.json", cacheV0File)
+
+	result, err := Run(home, time.Now())
+	require.NoError(t, err)
+	require.Len(t, result.Migrated, 1)
+	assert.Equal(t, "cache", result.Migrated[0].Store)
+	require.Len(t, result.Failed, 1)
+	assert.Equal(t, filepath.Join("wallets", "broken.wallet"), result.Failed[0].Path)
+	assert.True(t, Due(home), "a failed file keeps the migration due")
+
+	data, err := os.ReadFile(futurePath)
+	require.NoError(t, err)
+	assert.Equal(t, future, string(data), "newer files are never rewritten")
+	data, err = os.ReadFile(brokenPath)
+	require.NoError(t, err)
+	assert.Equal(t, "{not json", string(data))
+}
+
+func TestStore_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	var steps []int
+	store := Store{
+		Name:        "test",
+		VersionPath: []string{"meta", "version"},
+		Current:     3,
+		Migrations: []Migration{
+			{From: 1, Description: "two", Apply: func(doc Document) error { steps = append(steps, 2); return nil }},
+			{From: 2, Description: "three", Apply: func(doc Document) error { steps = append(steps, 3); return nil }},
+		},
+	}
+
+	doc := Document{"meta": map[string]any{"version": json.Number("1")}}
+	require.NoError(t, store.Upgrade(doc, 1))
+	assert.Equal(t, []int{2, 3}, steps, "migrations run in order")
+	v, err := version(doc, store.VersionPath)
+	require.NoError(t, err)
+	assert.Equal(t, 3, v)
+
+	err = store.Upgrade(Document{"meta": map[string]any{}}, 0)
+	require.ErrorIs(t, err, ErrNoMigration)
+}
+
+func TestVersion(t *testing.T) {
+	t.Parallel()
+
+	path := []string{"wallet", "version"}
+	v, err := version(Document{"wallet": map[string]any{}}, path)
+	require.NoError(t, err)
+	assert.Equal(t, 0, v, "missing version is version 0")
+
+	_, err = version(Document{"wallet": "x"}, path)
+	require.Error(t, err)
+	_, err = version(Document{"wallet": map[string]any{"version": "1"}}, path)
+	require.Error(t, err)
+	_, err = version(Document{"wallet": map[string]any{"version": json.Number("-1")}}, path)
+	require.Error(t, err)
+}
@@ -0,0 +1,134 @@
+package migrate
+
+import (
+	"sort"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// Stores returns every versioned store, in the order Run migrates them.
+//
+// To change a file format: raise the package's version constant, register a
+// Migration from the previous version here, and add its upgrade test.
+func Stores() []Store {
+	return []Store{
+		{
+			Name:        "wallet",
+			Patterns:    []string{"wallets/*.wallet"},
+			VersionPath: []string{"wallet", "version"},
+			Current:     wallet.CurrentVersion,
+			Migrations: []Migration{
+				{From: 0, Description: "record the file version and the enabled chains", Apply: walletV0},
+			},
+		},
+		{
+			Name:        "utxostore",
+			Patterns:    []string{"wallets/*/utxos.json"},
+			VersionPath: []string{"version"},
+			Current:     utxostore.CurrentVersion,
+			Migrations: []Migration{
+				{From: 0, Description: "record the file version", Apply: utxostoreV0},
+			},
+		},
+		{
+			Name:        "cache",
+			Patterns:    []string{"cache/balances*.json"},
+			VersionPath: []string{"version"},
+			Current:     cache.CurrentVersion,
+			Migrations: []Migration{
+				{From: 0, Description: "re-key entries with collision-free keys", Apply: cacheV0},
+			},
+		},
+		{
+			Name:        "agent",
+			Patterns:    []string{"agents/*.agent"},
+			VersionPath: []string{"version"},
+			Current:     agent.CredentialVersion,
+			Migrations: []Migration{
+				{From: 0, Description: "record the file version", Apply: noChange},
+			},
+		},
+		{
+			// Vaults have carried their version since they were introduced
+			Name:        "agent-vault",
+			Patterns:    []string{"agents/*.vault"},
+			VersionPath: []string{"version"},
+			Current:     agent.VaultVersion,
+		},
+	}
+}
+
+// noChange is a migration that only records the new version.
+func noChange(Document) error {
+	return nil
+}
+
+// walletV0 upgrades a wallet file written before versions were recorded.
+// Such files may lack the address map and the enabled chains, which are
+// taken from the chains that have addresses.
+func walletV0(doc Document) error {
+	w, ok := asObject(doc["wallet"])
+	if !ok {
+		return errNotObject
+	}
+	addresses, ok := asObject(w["addresses"])
+	if !ok {
+		addresses = map[string]any{}
+		w["addresses"] = addresses
+	}
+	if chains, ok := w["enabled_chains"].([]any); !ok || len(chains) == 0 {
+		w["enabled_chains"] = sortedKeys(addresses)
+	}
+	return nil
+}
+
+// utxostoreV0 upgrades a UTXO store written before versions were recorded.
+func utxostoreV0(doc Document) error {
+	for _, key := range []string{"utxos", "addresses"} {
+		if _, ok := asObject(doc[key]); !ok {
+			doc[key] = map[string]any{}
+		}
+	}
+	return nil
+}
+
+// cacheV0 re-keys a balance cache written when keys were joined with ":",
+// which collides for tokens whose identifier holds one.
+func cacheV0(doc Document) error {
+	entries, ok := asObject(doc["entries"])
+	if !ok {
+		doc["entries"] = map[string]any{}
+		return nil
+	}
+	rekeyed := make(map[string]any, len(entries))
+	for _, value := range entries {
+		entry, ok := asObject(value)
+		if !ok {
+			continue
+		}
+		chainID, _ := entry["chain"].(string)
+		address, _ := entry["address"].(string)
+		token, _ := entry["token"].(string)
+		rekeyed[cache.Key(chain.ID(chainID), address, token)] = entry
+	}
+	doc["entries"] = rekeyed
+	return nil
+}
+
+// sortedKeys returns the keys of obj as a sorted JSON array.
+func sortedKeys(obj map[string]any) []any {
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	values := make([]any, len(keys))
+	for i, key := range keys {
+		values[i] = key
+	}
+	return values
+}
@@ -0,0 +1,241 @@
+// Package msgsign signs and verifies arbitrary messages with address keys,
+// so a holder can prove ownership of an address without moving funds. UTXO
+// chains use the Bitcoin Signed Message format; Ethereum uses EIP-191
+// personal_sign, or EIP-712 for typed data.
+package msgsign
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	ethcrypto "github.com/mrz1836/sigil/internal/chain/eth/crypto"
+	"github.com/mrz1836/sigil/internal/wallet/bitcoin"
+)
+
+// Signature schemes.
+const (
+	// SchemeBitcoin is the Bitcoin Signed Message format: a base64 compact
+	// signature over the double SHA-256 of the magic-prefixed message.
+	SchemeBitcoin = "bitcoin-signed-message"
+
+	// SchemeEthereum is EIP-191 personal_sign: a 0x-prefixed 65-byte
+	// [R || S || V] signature with V of 27 or 28.
+	SchemeEthereum = "eip-191"
+
+	// SchemeTypedData is EIP-712 typed data signing, as eth_signTypedData_v4:
+	// the same signature form as SchemeEthereum over the typed data digest.
+	SchemeTypedData = "eip-712"
+)
+
+// bitcoinMagic prefixes every Bitcoin Signed Message before hashing.
+const bitcoinMagic = "Bitcoin Signed Message:\n"
+
+// compactSigLength is the size of a recoverable signature.
+const compactSigLength = 65
+
+// P2PKH version bytes: Base58Check for mainnet and testnet, and the CashAddr
+// type.
+const (
+	mainnetP2PKH  byte = 0x00
+	testnetP2PKH  byte = 0x6f
+	cashAddrP2PKH byte = 0
+)
+
+var (
+	// ErrInvalidSignature indicates a signature that cannot be decoded or
+	// from which no public key can be recovered.
+	ErrInvalidSignature = errors.New("invalid signature")
+
+	// ErrSignatureMismatch indicates a valid signature made by a key other
+	// than the address's.
+	ErrSignatureMismatch = errors.New("signature does not match address")
+
+	// ErrUnsupportedAddress indicates an address no scheme can verify, such
+	// as a P2SH or segwit address.
+	ErrUnsupportedAddress = errors.New("unsupported address")
+)
+
+// SignBitcoin signs message with a private key in the Bitcoin Signed Message
+// format and returns the base64 signature. The signature references the
+// compressed public key, which is what wallet addresses are derived from.
+func SignBitcoin(privateKey, message []byte) (string, error) {
+	if len(privateKey) != 32 {
+		return "", ethcrypto.ErrInvalidPrivateKey
+	}
+	key := secp256k1.PrivKeyFromBytes(privateKey)
+	defer key.Zero()
+
+	sig := ecdsa.SignCompact(key, bitcoinHash(message), true)
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// SignEthereum signs message with a private key under EIP-191 personal_sign
+// and returns the 0x-prefixed hex signature.
+func SignEthereum(privateKey, message []byte) (string, error) {
+	return signEthereumHash(privateKey, eth.HashMessage(message))
+}
+
+// SignTypedData signs EIP-712 typed data with an Ethereum private key. It
+// returns the signature in the SchemeEthereum form.
+func SignTypedData(privateKey []byte, td *eth.TypedData) (string, error) {
+	digest, err := td.Hash()
+	if err != nil {
+		return "", err
+	}
+	return signEthereumHash(privateKey, digest)
+}
+
+// signEthereumHash signs a 32-byte digest as [R || S || V] with V of 27 or 28.
+func signEthereumHash(privateKey, hash []byte) (string, error) {
+	sig, err := ethcrypto.Sign(hash, privateKey)
+	if err != nil {
+		return "", err
+	}
+	sig[compactSigLength-1] += 27
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+// Verify checks that signature over message was made by the key of address.
+// An 0x address is verified as EIP-191; any other address must be a P2PKH
+// address, Base58Check or CashAddr, and is verified as a Bitcoin Signed
+// Message. It returns the scheme used; the error wraps ErrSignatureMismatch
+// when the signature is valid but made by another key.
+func Verify(address, signature string, message []byte) (string, error) {
+	if eth.IsValidAddress(address) {
+		return SchemeEthereum, verifyEthereum(address, signature, message)
+	}
+	return SchemeBitcoin, verifyBitcoin(address, signature, message)
+}
+
+// verifyBitcoin verifies a Bitcoin Signed Message against a P2PKH address.
+func verifyBitcoin(address, signature string, message []byte) error {
+	want, err := p2pkhHash(address)
+	if err != nil {
+		return err
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(signature))
+	if err != nil || len(sig) != compactSigLength {
+		return fmt.Errorf("%w: expected a base64 %d-byte signature", ErrInvalidSignature, compactSigLength)
+	}
+	pub, compressed, err := ecdsa.RecoverCompact(sig, bitcoinHash(message))
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+
+	serialized := pub.SerializeUncompressed()
+	if compressed {
+		serialized = pub.SerializeCompressed()
+	}
+	if !bytes.Equal(bitcoin.Hash160(serialized), want) {
+		return fmt.Errorf("%w: %s", ErrSignatureMismatch, address)
+	}
+	return nil
+}
+
+// VerifyTypedData checks that signature over EIP-712 typed data was made by
+// the key of an Ethereum address. The error wraps ErrSignatureMismatch when
+// the signature is valid but made by another key.
+func VerifyTypedData(address, signature string, td *eth.TypedData) error {
+	if !eth.IsValidAddress(address) {
+		return fmt.Errorf("%w: %s is not an Ethereum address", ErrUnsupportedAddress, address)
+	}
+	digest, err := td.Hash()
+	if err != nil {
+		return err
+	}
+	return verifyEthereumHash(address, signature, digest)
+}
+
+// verifyEthereum verifies an EIP-191 signature against an Ethereum address.
+func verifyEthereum(address, signature string, message []byte) error {
+	return verifyEthereumHash(address, signature, eth.HashMessage(message))
+}
+
+// verifyEthereumHash verifies an Ethereum signature over a digest.
+func verifyEthereumHash(address, signature string, hash []byte) error {
+	sig, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(signature), "0x"))
+	if err != nil || len(sig) != compactSigLength {
+		return fmt.Errorf("%w: expected a 0x-prefixed %d-byte hex signature", ErrInvalidSignature, compactSigLength)
+	}
+
+	// RecoverCompact takes [V || R || S] with V offset by 27; personal_sign
+	// puts V last, as 27/28 or, from some signers, 0/1.
+	v := sig[compactSigLength-1]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 1 {
+		return fmt.Errorf("%w: recovery id %d", ErrInvalidSignature, sig[compactSigLength-1])
+	}
+	compact := make([]byte, 0, compactSigLength)
+	compact = append(compact, v+27)
+	compact = append(compact, sig[:compactSigLength-1]...)
+
+	pub, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	signer, err := ethcrypto.PublicKeyToAddress(pub.SerializeUncompressed())
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidSignature, err)
+	}
+	if !strings.EqualFold(ethcrypto.BytesToAddress(signer).Hex(), address) {
+		return fmt.Errorf("%w: %s", ErrSignatureMismatch, address)
+	}
+	return nil
+}
+
+// bitcoinHash returns the double SHA-256 of message with the Bitcoin Signed
+// Message magic, each prefixed by its varint length.
+func bitcoinHash(message []byte) []byte {
+	var buf bytes.Buffer
+	writeVarBytes(&buf, []byte(bitcoinMagic))
+	writeVarBytes(&buf, message)
+	return bitcoin.DoubleSHA256(buf.Bytes())
+}
+
+// writeVarBytes writes b to buf prefixed by its length as a Bitcoin varint.
+func writeVarBytes(buf *bytes.Buffer, b []byte) {
+	n := uint64(len(b))
+	switch {
+	case n < 0xfd:
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.Write([]byte{0xfd, byte(n), byte(n >> 8)})
+	case n <= 0xffffffff:
+		buf.Write([]byte{0xfe, byte(n), byte(n >> 8), byte(n >> 16), byte(n >> 24)})
+	default:
+		buf.WriteByte(0xff)
+		for i := range 8 {
+			buf.WriteByte(byte(n >> (8 * i)))
+		}
+	}
+	buf.Write(b)
+}
+
+// p2pkhHash returns the public key hash of a Base58Check or CashAddr P2PKH
+// address on mainnet or testnet.
+func p2pkhHash(address string) ([]byte, error) {
+	if payload, err := bitcoin.Base58CheckDecode(address); err == nil {
+		if len(payload) == 21 && (payload[0] == mainnetP2PKH || payload[0] == testnetP2PKH) {
+			return payload[1:], nil
+		}
+		return nil, fmt.Errorf("%w: %s is not a P2PKH address", ErrUnsupportedAddress, address)
+	}
+	if _, addrType, hash, err := bitcoin.CashAddrDecode(address, "bitcoincash"); err == nil {
+		if addrType == cashAddrP2PKH && len(hash) == 20 {
+			return hash, nil
+		}
+		return nil, fmt.Errorf("%w: %s is not a P2PKH address", ErrUnsupportedAddress, address)
+	}
+	return nil, fmt.Errorf("%w: %s is not a P2PKH or Ethereum address", ErrUnsupportedAddress, address)
+}
@@ -0,0 +1,171 @@
+package msgsign
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+)
+
+const (
+	// bsvKey signs for bsvAddress; the vectors match the go-sdk BSM signer.
+	bsvKey     = "0499f8239bfe10eb0f5e53d543635a423c96529dd85fa4bad42049a0b435ebdd"
+	bsvAddress = "1KeiT9opiiEyqBjazSix8muc1JuFNNWEKe"
+	bsvMessage = "I own this address"
+	bsvSig     = "INBC2+Ok+GtbeAfvgYDaBkYPXh/nVNDfxAkXvar5+OQ6ZeMmxFA9ov5zpNBXeI0zVsKPuVj9bbZNnuVkzk6MLCc="
+
+	// ethKey is the first Hardhat development account.
+	ethKey     = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
+	ethAddress = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+	ethSig     = "0xf16ea9a3478698f695fd1401bfe27e9e4a7e8e3da94aa72b021125e31fa899cc573c48ea3fe1d4ab61a9db10c19032026e3ed2dbccba5a178235ac27f94504311c"
+)
+
+func TestSignBitcoin(t *testing.T) {
+	t.Parallel()
+
+	key, err := hex.DecodeString(bsvKey)
+	require.NoError(t, err)
+	sig, err := SignBitcoin(key, []byte(bsvMessage))
+	require.NoError(t, err)
+	assert.Equal(t, bsvSig, sig)
+
+	_, err = SignBitcoin(key[:31], []byte(bsvMessage))
+	require.Error(t, err)
+}
+
+func TestSignEthereum(t *testing.T) {
+	t.Parallel()
+
+	key, err := hex.DecodeString(ethKey)
+	require.NoError(t, err)
+	sig, err := SignEthereum(key, []byte("hello"))
+	require.NoError(t, err)
+	assert.Equal(t, ethSig, sig)
+}
+
+func TestVerify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		address   string
+		signature string
+		message   string
+		scheme    string
+		wantErr   error
+	}{
+		{name: "bitcoin", address: bsvAddress, signature: bsvSig, message: bsvMessage, scheme: SchemeBitcoin},
+		{
+			name: "bitcoin CashAddr", address: "bitcoincash:qrxfgsvnj9m9nhxcra29nk4hjz72pznpzv58x42hp4",
+			signature: bsvSig, message: bsvMessage, scheme: SchemeBitcoin,
+		},
+		{
+			name: "bitcoin other message", address: bsvAddress, signature: bsvSig, message: "I own that address",
+			scheme: SchemeBitcoin, wantErr: ErrSignatureMismatch,
+		},
+		{
+			name: "bitcoin other address", address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", signature: bsvSig,
+			message: bsvMessage, scheme: SchemeBitcoin, wantErr: ErrSignatureMismatch,
+		},
+		{
+			name: "bitcoin garbage signature", address: bsvAddress, signature: "not base64!",
+			message: bsvMessage, scheme: SchemeBitcoin, wantErr: ErrInvalidSignature,
+		},
+		{
+			name: "P2SH address", address: "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy", signature: bsvSig,
+			message: bsvMessage, scheme: SchemeBitcoin, wantErr: ErrUnsupportedAddress,
+		},
+		{name: "ethereum", address: ethAddress, signature: ethSig, message: "hello", scheme: SchemeEthereum},
+		{
+			name: "ethereum lowercase address without 0x signature", address: "0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266",
+			signature: ethSig[2:], message: "hello", scheme: SchemeEthereum,
+		},
+		{
+			name: "ethereum recovery id 0/1", address: ethAddress, signature: ethSig[:len(ethSig)-2] + "01",
+			message: "hello", scheme: SchemeEthereum,
+		},
+		{
+			name: "ethereum other message", address: ethAddress, signature: ethSig, message: "hello!",
+			scheme: SchemeEthereum, wantErr: ErrSignatureMismatch,
+		},
+		{
+			name: "ethereum short signature", address: ethAddress, signature: "0x1234", message: "hello",
+			scheme: SchemeEthereum, wantErr: ErrInvalidSignature,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			scheme, err := Verify(tc.address, tc.signature, []byte(tc.message))
+			assert.Equal(t, tc.scheme, scheme)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSignVerify_LongMessage(t *testing.T) {
+	t.Parallel()
+
+	// Messages of 253 bytes or more take a multi-byte varint length
+	message := make([]byte, 70000)
+	for i := range message {
+		message[i] = byte(i)
+	}
+	key, err := hex.DecodeString(bsvKey)
+	require.NoError(t, err)
+	sig, err := SignBitcoin(key, message)
+	require.NoError(t, err)
+	_, err = Verify(bsvAddress, sig, message)
+	require.NoError(t, err)
+}
+
+func TestSignTypedData(t *testing.T) {
+	t.Parallel()
+
+	// The EIP-712 specification example, signed with keccak256("cow")
+	td, err := eth.ParseTypedData([]byte(`{
+  "types": {
+    "EIP712Domain": [
+      {"name": "name", "type": "string"},
+      {"name": "version", "type": "string"},
+      {"name": "chainId", "type": "uint256"},
+      {"name": "verifyingContract", "type": "address"}
+    ],
+    "Person": [{"name": "name", "type": "string"}, {"name": "wallet", "type": "address"}],
+    "Mail": [{"name": "from", "type": "Person"}, {"name": "to", "type": "Person"}, {"name": "contents", "type": "string"}]
+  },
+  "primaryType": "Mail",
+  "domain": {"name": "Ether Mail", "version": "1", "chainId": 1, "verifyingContract": "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC"},
+  "message": {
+    "from": {"name": "Cow", "wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826"},
+    "to": {"name": "Bob", "wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB"},
+    "contents": "Hello, Bob!"
+  }
+}`))
+	require.NoError(t, err)
+
+	key, err := hex.DecodeString("c85ef7d79691fe79573b1a7064c19c1a9819ebdbd1faaab1a8ec92344438aaf4")
+	require.NoError(t, err)
+	sig, err := SignTypedData(key, td)
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+
+		"4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b91562"+
+		"1c", sig)
+
+	require.NoError(t, VerifyTypedData("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", sig, td))
+	require.ErrorIs(t, VerifyTypedData(ethAddress, sig, td), ErrSignatureMismatch)
+	require.ErrorIs(t, VerifyTypedData(bsvAddress, sig, td), ErrUnsupportedAddress)
+
+	// A typed data signature is not a personal_sign signature of anything
+	_, err = Verify("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826", sig, []byte("Hello, Bob!"))
+	require.ErrorIs(t, err, ErrSignatureMismatch)
+}
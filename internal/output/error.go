@@ -39,33 +39,30 @@ func FormatError(w io.Writer, err error, format Format) error {
 
 // formatErrorJSON outputs error in JSON format.
 func formatErrorJSON(w io.Writer, err error) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(ErrorOutput{Error: NewErrorDetail(err)})
+}
+
+// NewErrorDetail returns the structured details of an error.
+func NewErrorDetail(err error) ErrorDetail {
 	var se *sigilerr.SigilError
 	if errors.As(err, &se) {
-		output := ErrorOutput{
-			Error: ErrorDetail{
-				Code:       se.Code,
-				Message:    se.Message,
-				Details:    se.Details,
-				Suggestion: se.Suggestion,
-				ExitCode:   se.ExitCode,
-			},
+		return ErrorDetail{
+			Code:       se.Code,
+			Message:    se.Message,
+			Details:    se.Details,
+			Suggestion: se.Suggestion,
+			ExitCode:   se.ExitCode,
 		}
-		encoder := json.NewEncoder(w)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(output)
 	}
 
 	// Generic error
-	output := ErrorOutput{
-		Error: ErrorDetail{
-			Code:     "GENERAL_ERROR",
-			Message:  err.Error(),
-			ExitCode: sigilerr.ExitGeneral,
-		},
+	return ErrorDetail{
+		Code:     "GENERAL_ERROR",
+		Message:  err.Error(),
+		ExitCode: sigilerr.ExitGeneral,
 	}
-	encoder := json.NewEncoder(w)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
 }
 
 // formatErrorText outputs error in text format.
@@ -19,6 +19,10 @@ const (
 	FormatText Format = "text"
 	FormatJSON Format = "json"
 	FormatAuto Format = "auto"
+
+	// FormatCSV is for commands that export records, such as agent report.
+	// Other commands print text.
+	FormatCSV Format = "csv"
 )
 
 // Formatter handles output formatting.
@@ -116,6 +120,8 @@ func ParseFormat(s string) Format {
 		return FormatJSON
 	case "text":
 		return FormatText
+	case "csv":
+		return FormatCSV
 	default:
 		return FormatAuto
 	}
@@ -68,6 +68,7 @@ func TestParseFormat(t *testing.T) {
 		{"JSON", output.FormatJSON},
 		{"text", output.FormatText},
 		{"TEXT", output.FormatText},
+		{"csv", output.FormatCSV},
 		{"auto", output.FormatAuto},
 		{"", output.FormatAuto},
 		{"invalid", output.FormatAuto},
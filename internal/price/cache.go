@@ -0,0 +1,208 @@
+package price
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/fileutil"
+	"github.com/mrz1836/sigil/internal/reqlog"
+)
+
+const (
+	// quoteCacheFilePermissions is the permission mode for the price cache file.
+	quoteCacheFilePermissions = 0o600
+
+	// quoteCacheDirPermissions is the permission mode for the price cache directory.
+	quoteCacheDirPermissions = 0o700
+)
+
+// ErrCorruptQuoteCache indicates the price cache file is malformed JSON.
+var ErrCorruptQuoteCache = errors.New("price cache file is corrupted")
+
+// QuoteCachePath returns the location of the price cache under the sigil
+// home directory.
+func QuoteCachePath(home string) string {
+	return filepath.Join(home, "cache", "prices.json")
+}
+
+// quoteTable is the on-disk form of the price cache, keyed by quoteKey.
+type quoteTable struct {
+	Quotes map[string]Quote `json:"quotes"`
+}
+
+// quoteKey identifies a cached quote, e.g. "bsv:EUR".
+func quoteKey(chainID chain.ID, currency string) string {
+	return string(chainID) + ":" + strings.ToUpper(currency)
+}
+
+// QuoteCache persists the last quote for each coin and currency, so repeated
+// commands reuse a recent price instead of calling the price API each time.
+type QuoteCache struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewQuoteCache creates a price cache backed by the file at path.
+func NewQuoteCache(path string) *QuoteCache {
+	return &QuoteCache{path: path}
+}
+
+// Lookup returns the cached quote for a chain's coin in currency.
+func (s *QuoteCache) Lookup(chainID chain.ID, currency string) (*Quote, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.load()
+	if err != nil {
+		return nil, false
+	}
+	q, ok := table.Quotes[quoteKey(chainID, currency)]
+	if !ok || q.Rate <= 0 {
+		return nil, false
+	}
+	return &q, true
+}
+
+// Record stores quotes, replacing older ones for the same coin and currency.
+func (s *QuoteCache) Record(quotes ...*Quote) error {
+	if len(quotes) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	table, err := s.load()
+	if err != nil && !errors.Is(err, ErrCorruptQuoteCache) {
+		return err
+	}
+	for _, q := range quotes {
+		table.Quotes[quoteKey(q.Chain, q.Currency)] = *q
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), quoteCacheDirPermissions); err != nil {
+		return fmt.Errorf("creating price cache directory: %w", err)
+	}
+	data, err := json.MarshalIndent(table, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling price cache: %w", err)
+	}
+	if err := fileutil.WriteAtomic(s.path, data, quoteCacheFilePermissions); err != nil {
+		return fmt.Errorf("writing price cache: %w", err)
+	}
+	return nil
+}
+
+// load reads the price cache without locking. A corrupt file yields an empty
+// table together with ErrCorruptQuoteCache.
+func (s *QuoteCache) load() (*quoteTable, error) {
+	table := &quoteTable{Quotes: make(map[string]Quote)}
+
+	data, err := os.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return table, nil
+	}
+	if err != nil {
+		return table, fmt.Errorf("reading price cache: %w", err)
+	}
+	if err := json.Unmarshal(data, table); err != nil {
+		return &quoteTable{Quotes: make(map[string]Quote)}, fmt.Errorf("%w: %w", ErrCorruptQuoteCache, err)
+	}
+	if table.Quotes == nil {
+		table.Quotes = make(map[string]Quote)
+	}
+	return table, nil
+}
+
+// QuoteSource fetches the prices of several coins in one currency.
+type QuoteSource interface {
+	Quotes(ctx context.Context, chains []chain.ID, currency string) (map[chain.ID]*Quote, error)
+}
+
+// CachedQuotes serves display prices from the price cache, fetching from a
+// source only the quotes older than a time to live.
+type CachedQuotes struct {
+	source QuoteSource
+	cache  *QuoteCache
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewCachedQuotes creates a cached price provider. A zero ttl fetches on
+// every call and keeps the cache only as a fallback.
+func NewCachedQuotes(source QuoteSource, cache *QuoteCache, ttl time.Duration) *CachedQuotes {
+	return &CachedQuotes{source: source, cache: cache, ttl: ttl, now: time.Now}
+}
+
+// Quotes returns a quote for each chain's coin in currency. Quotes fetched
+// within the time to live come from the cache; the rest are fetched. When
+// the fetch fails, older cached quotes are returned along with the error,
+// which is nil only when every chain has a quote. With offline set, only
+// the cache is read, whatever the age of its quotes.
+func (p *CachedQuotes) Quotes(ctx context.Context, chains []chain.ID, currency string, offline bool) (map[chain.ID]*Quote, error) {
+	currency = strings.ToUpper(currency)
+	quotes := make(map[chain.ID]*Quote, len(chains))
+	var missing []chain.ID
+	for _, id := range chains {
+		q, ok := p.cache.Lookup(id, currency)
+		fresh := ok && (offline || p.now().Sub(q.FetchedAt) < p.ttl)
+		reqlog.Cache(ctx, "prices", string(id)+"/"+currency, fresh)
+		if fresh {
+			quotes[id] = q
+			continue
+		}
+		if ok {
+			quotes[id] = q // Kept if the fetch fails
+		}
+		missing = append(missing, id)
+	}
+	if len(missing) == 0 {
+		return quotes, nil
+	}
+	if offline {
+		return quotes, fmt.Errorf("%w: no cached %s rate for %s", ErrPriceAPI, currency, joinChains(missing))
+	}
+
+	fetched, err := p.source.Quotes(ctx, missing, currency)
+	if err != nil {
+		return quotes, err
+	}
+	recorded := make([]*Quote, 0, len(fetched))
+	for _, id := range missing {
+		if q, ok := fetched[id]; ok {
+			quotes[id] = q
+			recorded = append(recorded, q)
+		}
+	}
+	if err := p.cache.Record(recorded...); err != nil {
+		return quotes, err
+	}
+
+	var unpriced []chain.ID
+	for _, id := range chains {
+		if _, ok := quotes[id]; !ok {
+			unpriced = append(unpriced, id)
+		}
+	}
+	if len(unpriced) > 0 {
+		return quotes, fmt.Errorf("%w: no %s rate for %s", ErrPriceAPI, currency, joinChains(unpriced))
+	}
+	return quotes, nil
+}
+
+// joinChains lists chain IDs for messages, e.g. "bsv, eth".
+func joinChains(ids []chain.ID) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		names[i] = string(id)
+	}
+	return strings.Join(names, ", ")
+}
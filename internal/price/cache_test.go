@@ -0,0 +1,105 @@
+package price
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// stubQuoteSource counts fetches and returns fixed rates.
+type stubQuoteSource struct {
+	rates map[chain.ID]float64
+	err   error
+	calls int
+	asked []chain.ID
+}
+
+func (s *stubQuoteSource) Quotes(_ context.Context, chains []chain.ID, currency string) (map[chain.ID]*Quote, error) {
+	s.calls++
+	s.asked = chains
+	if s.err != nil {
+		return nil, s.err
+	}
+	quotes := make(map[chain.ID]*Quote)
+	for _, id := range chains {
+		if rate, ok := s.rates[id]; ok {
+			now := time.Now().UTC()
+			quotes[id] = &Quote{Chain: id, Currency: currency, Rate: rate, Time: now, FetchedAt: now, Source: "stub"}
+		}
+	}
+	return quotes, nil
+}
+
+func TestQuoteCache_RecordLookup(t *testing.T) {
+	t.Parallel()
+
+	path := QuoteCachePath(t.TempDir())
+	cache := NewQuoteCache(path)
+
+	_, ok := cache.Lookup(chain.BSV, CurrencyUSD)
+	assert.False(t, ok, "missing file is an empty cache")
+
+	require.NoError(t, cache.Record(
+		&Quote{Chain: chain.BSV, Currency: CurrencyUSD, Rate: 48},
+		&Quote{Chain: chain.BSV, Currency: CurrencyEUR, Rate: 41},
+	))
+	q, ok := NewQuoteCache(path).Lookup(chain.BSV, "eur")
+	require.True(t, ok)
+	assert.InDelta(t, 41.0, q.Rate, 0)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+	_, ok = cache.Lookup(chain.BSV, CurrencyUSD)
+	assert.False(t, ok)
+	require.NoError(t, cache.Record(&Quote{Chain: chain.ETH, Currency: CurrencyUSD, Rate: 3000}), "a corrupt cache is replaced")
+	_, ok = cache.Lookup(chain.ETH, CurrencyUSD)
+	assert.True(t, ok)
+}
+
+func TestCachedQuotes(t *testing.T) {
+	t.Parallel()
+
+	cache := NewQuoteCache(filepath.Join(t.TempDir(), "prices.json"))
+	source := &stubQuoteSource{rates: map[chain.ID]float64{chain.BSV: 48, chain.ETH: 3000}}
+	p := NewCachedQuotes(source, cache, 5*time.Minute)
+
+	quotes, err := p.Quotes(t.Context(), []chain.ID{chain.BSV}, "usd", false)
+	require.NoError(t, err)
+	assert.InDelta(t, 48.0, quotes[chain.BSV].Rate, 0)
+	assert.Equal(t, 1, source.calls)
+
+	// A fresh quote is reused; only the missing coin is fetched
+	quotes, err = p.Quotes(t.Context(), []chain.ID{chain.BSV, chain.ETH}, CurrencyUSD, false)
+	require.NoError(t, err)
+	assert.Len(t, quotes, 2)
+	assert.Equal(t, []chain.ID{chain.ETH}, source.asked)
+
+	// Stale quotes are refetched, and kept when the fetch fails
+	p.now = func() time.Time { return time.Now().Add(time.Hour) }
+	source.rates[chain.BSV] = 50
+	quotes, err = p.Quotes(t.Context(), []chain.ID{chain.BSV}, CurrencyUSD, false)
+	require.NoError(t, err)
+	assert.InDelta(t, 50.0, quotes[chain.BSV].Rate, 0)
+
+	source.err = errTestProvider
+	quotes, err = p.Quotes(t.Context(), []chain.ID{chain.BSV}, CurrencyUSD, false)
+	require.ErrorIs(t, err, errTestProvider)
+	assert.InDelta(t, 50.0, quotes[chain.BSV].Rate, 0)
+
+	// Offline reads the cache whatever its age and never fetches
+	calls := source.calls
+	quotes, err = p.Quotes(t.Context(), []chain.ID{chain.BSV, chain.BTC}, CurrencyUSD, true)
+	require.ErrorIs(t, err, ErrPriceAPI)
+	assert.Contains(t, quotes, chain.BSV)
+	assert.Equal(t, calls, source.calls)
+}
@@ -0,0 +1,214 @@
+package price
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/faultinject"
+	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/reqlog"
+	"github.com/mrz1836/sigil/internal/reqsign"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// SourceCoinGecko names quotes from the CoinGecko simple price API, or an
+// endpoint that serves the same API.
+const SourceCoinGecko = "coingecko"
+
+// CoinGeckoURL is the public CoinGecko API, used when price.api is empty or
+// "coingecko".
+const CoinGeckoURL = "https://api.coingecko.com/api/v3"
+
+const (
+	// coinGeckoTimeout bounds each price request.
+	coinGeckoTimeout = 15 * time.Second
+
+	// maxPriceResponse caps the size of a price response read into memory.
+	maxPriceResponse = 1 << 20
+)
+
+var (
+	// ErrUnknownPriceAPI indicates price.api is neither "coingecko" nor an
+	// http(s) URL.
+	ErrUnknownPriceAPI = errors.New("unknown price API (use coingecko or an http(s) URL)")
+
+	// ErrPriceAPI indicates the price API returned an error or no usable rate.
+	ErrPriceAPI = errors.New("price API error")
+)
+
+// coinGeckoIDs maps chains to the CoinGecko IDs of their native coins.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var coinGeckoIDs = map[chain.ID]string{
+	chain.BSV: "bitcoin-cash-sv",
+	chain.BTC: "bitcoin",
+	chain.BCH: "bitcoin-cash",
+	chain.ETH: "ethereum",
+	chain.LTC: "litecoin",
+}
+
+// CoinGeckoOptions configures a CoinGecko client.
+type CoinGeckoOptions struct {
+	// API is "coingecko" or the base URL of an API that serves
+	// /simple/price like CoinGecko does. Empty means "coingecko".
+	API string
+
+	// APIKey is sent as a CoinGecko demo key, or a pro key for
+	// pro-api.coingecko.com. Optional.
+	APIKey string
+
+	// HTTPClient overrides the default HTTP client.
+	HTTPClient *http.Client
+}
+
+// CoinGecko fetches spot prices from the CoinGecko simple price API.
+type CoinGecko struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewCoinGecko creates a CoinGecko client.
+func NewCoinGecko(opts *CoinGeckoOptions) (*CoinGecko, error) {
+	c := &CoinGecko{
+		httpClient: &http.Client{
+			Timeout: coinGeckoTimeout,
+			Transport: reqlog.Wrap(faultinject.Wrap(reqsign.Wrap(&http.Transport{
+				TLSClientConfig: &tls.Config{MinVersion: tls.VersionTLS12},
+			})), "coingecko"),
+		},
+		now: time.Now,
+	}
+
+	var api string
+	if opts != nil {
+		api = opts.API
+		c.apiKey = opts.APIKey
+		if opts.HTTPClient != nil {
+			c.httpClient = opts.HTTPClient
+		}
+	}
+
+	baseURL, err := ResolveAPIURL(api)
+	if err != nil {
+		return nil, err
+	}
+	c.baseURL = baseURL
+	return c, nil
+}
+
+// ResolveAPIURL maps a price.api value to a base URL.
+func ResolveAPIURL(api string) (string, error) {
+	api = strings.TrimSpace(api)
+	if api == "" || strings.EqualFold(api, SourceCoinGecko) {
+		return CoinGeckoURL, nil
+	}
+	if strings.HasPrefix(api, "https://") || strings.HasPrefix(api, "http://") {
+		return strings.TrimRight(api, "/"), nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownPriceAPI, api)
+}
+
+// Quotes fetches the price of each chain's native coin in currency with one
+// request. Chains CoinGecko does not list are left out of the result.
+func (c *CoinGecko) Quotes(ctx context.Context, chains []chain.ID, currency string) (map[chain.ID]*Quote, error) {
+	currency = strings.ToUpper(currency)
+	ids := make([]string, 0, len(chains))
+	for _, id := range chains {
+		if cgID, ok := coinGeckoIDs[id]; ok {
+			ids = append(ids, cgID)
+		}
+	}
+	if len(ids) == 0 {
+		return map[chain.ID]*Quote{}, nil
+	}
+	sort.Strings(ids)
+
+	query := url.Values{
+		"ids":                     {strings.Join(ids, ",")},
+		"vs_currencies":           {strings.ToLower(currency)},
+		"include_last_updated_at": {"true"},
+	}
+	start := time.Now()
+	body, err := c.get(ctx, "/simple/price?"+query.Encode())
+	metrics.Global.RecordRPCCall("price", time.Since(start), err)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp map[string]map[string]float64
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("%w: parsing response: %w", ErrPriceAPI, err)
+	}
+
+	fetchedAt := c.now().UTC()
+	quotes := make(map[chain.ID]*Quote, len(ids))
+	for _, id := range chains {
+		coin, ok := resp[coinGeckoIDs[id]]
+		if !ok {
+			continue
+		}
+		rate := coin[strings.ToLower(currency)]
+		if rate <= 0 {
+			continue
+		}
+		q := &Quote{
+			Chain:     id,
+			Currency:  currency,
+			Rate:      rate,
+			Time:      fetchedAt,
+			FetchedAt: fetchedAt,
+			Source:    SourceCoinGecko,
+		}
+		if updated := int64(coin["last_updated_at"]); updated > 0 {
+			q.Time = time.Unix(updated, 0).UTC()
+		}
+		quotes[id] = q
+	}
+	if len(quotes) == 0 {
+		return nil, fmt.Errorf("%w: no %s rate for %s", ErrPriceAPI, currency, strings.Join(ids, ", "))
+	}
+	return quotes, nil
+}
+
+// get performs a GET request against the API.
+func (c *CoinGecko) get(ctx context.Context, path string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.apiKey != "" {
+		header := "x-cg-demo-api-key"
+		if strings.Contains(c.baseURL, "://pro-api.coingecko.com") {
+			header = "x-cg-pro-api-key"
+		}
+		req.Header.Set(header, c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", sigilerr.ErrNetworkError, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPriceResponse))
+	if err != nil {
+		return nil, fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: HTTP %d", ErrPriceAPI, resp.StatusCode)
+	}
+	return data, nil
+}
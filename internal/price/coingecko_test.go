@@ -0,0 +1,84 @@
+package price
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestResolveAPIURL(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{
+		"":                               CoinGeckoURL,
+		"CoinGecko":                      CoinGeckoURL,
+		"https://prices.example.com/v3/": "https://prices.example.com/v3",
+	} {
+		got, err := ResolveAPIURL(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got)
+	}
+	_, err := ResolveAPIURL("kraken")
+	require.ErrorIs(t, err, ErrUnknownPriceAPI)
+}
+
+func TestCoinGeckoQuotes(t *testing.T) {
+	t.Parallel()
+
+	var gotPath, gotQuery, gotKey string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotQuery, gotKey = r.URL.Path, r.URL.RawQuery, r.Header.Get("x-cg-demo-api-key")
+		_, _ = w.Write([]byte(`{
+			"bitcoin-cash-sv": {"eur": 41.5, "last_updated_at": 1792000000},
+			"ethereum": {"eur": 2900.25}
+		}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cg, err := NewCoinGecko(&CoinGeckoOptions{API: srv.URL, APIKey: "demo-key", HTTPClient: srv.Client()})
+	require.NoError(t, err)
+	now := time.Unix(1_792_000_300, 0).UTC()
+	cg.now = func() time.Time { return now }
+
+	quotes, err := cg.Quotes(t.Context(), []chain.ID{chain.ETH, chain.BSV, chain.ID("doge")}, "eur")
+	require.NoError(t, err)
+
+	assert.Equal(t, "/simple/price", gotPath)
+	assert.Equal(t, "ids=bitcoin-cash-sv%2Cethereum&include_last_updated_at=true&vs_currencies=eur", gotQuery)
+	assert.Equal(t, "demo-key", gotKey)
+
+	require.Len(t, quotes, 2)
+	assert.Equal(t, &Quote{
+		Chain: chain.BSV, Currency: CurrencyEUR, Rate: 41.5,
+		Time: time.Unix(1_792_000_000, 0).UTC(), FetchedAt: now, Source: SourceCoinGecko,
+	}, quotes[chain.BSV])
+	assert.InDelta(t, 2900.25, quotes[chain.ETH].Rate, 0)
+	assert.Equal(t, now, quotes[chain.ETH].Time, "falls back to the fetch time")
+}
+
+func TestCoinGeckoQuotes_Errors(t *testing.T) {
+	t.Parallel()
+
+	status := http.StatusTooManyRequests
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	cg, err := NewCoinGecko(&CoinGeckoOptions{API: srv.URL, HTTPClient: srv.Client()})
+	require.NoError(t, err)
+
+	_, err = cg.Quotes(t.Context(), []chain.ID{chain.BSV}, CurrencyUSD)
+	require.ErrorIs(t, err, ErrPriceAPI)
+
+	status = http.StatusOK
+	_, err = cg.Quotes(t.Context(), []chain.ID{chain.BSV}, CurrencyUSD)
+	require.ErrorIs(t, err, ErrPriceAPI, "no rate in the response")
+}
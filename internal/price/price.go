@@ -0,0 +1,206 @@
+// Package price converts between coin and fiat amounts, using exchange rates
+// from the chain data providers sigil already talks to or from a price API.
+package price
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+)
+
+const (
+	// CurrencyUSD is the US dollar, the only currency fiat-denominated sends
+	// accept.
+	CurrencyUSD = "USD"
+
+	// CurrencyEUR is the euro, available for displaying fiat values.
+	CurrencyEUR = "EUR"
+)
+
+var (
+	// ErrUnsupportedCurrency indicates a fiat currency without a rate source.
+	ErrUnsupportedCurrency = errors.New("unsupported fiat currency")
+
+	// ErrUnsupportedChain indicates a chain without a configured rate source.
+	ErrUnsupportedChain = errors.New("no exchange rate source for chain")
+
+	// ErrInvalidFiatAmount indicates a fiat amount that is not a positive decimal.
+	ErrInvalidFiatAmount = errors.New("invalid fiat amount")
+
+	// ErrAmountTooSmall indicates a fiat amount that converts to zero base units.
+	ErrAmountTooSmall = errors.New("fiat amount converts to zero")
+)
+
+// BSVSource provides the BSV spot price.
+type BSVSource interface {
+	GetExchangeRate(ctx context.Context) (*bsv.ExchangeRate, error)
+}
+
+// ETHSource provides the ETH spot price.
+type ETHSource interface {
+	GetETHPrice(ctx context.Context) (*etherscan.ETHPrice, error)
+}
+
+// Quote is an exchange rate for one coin captured at a point in time.
+type Quote struct {
+	Chain    chain.ID `json:"chain"`
+	Currency string   `json:"currency"`
+	// Rate is fiat units per one whole coin.
+	Rate float64 `json:"rate"`
+	// Time is when the provider captured the rate. It falls back to
+	// FetchedAt when the provider does not report one.
+	Time      time.Time `json:"time"`
+	FetchedAt time.Time `json:"fetched_at"`
+	Source    string    `json:"source"`
+}
+
+// Age returns how old the quote was at the given time.
+func (q *Quote) Age(now time.Time) time.Duration {
+	return now.Sub(q.Time)
+}
+
+// CoinAmount converts a fiat amount into base units (satoshis, wei) of the
+// quoted coin. The result is rounded down so a send never exceeds the
+// requested fiat value.
+func (q *Quote) CoinAmount(fiat *FiatAmount, decimals int) (*big.Int, error) {
+	if q.Rate <= 0 {
+		return nil, fmt.Errorf("%w: rate %v", ErrUnsupportedChain, q.Rate)
+	}
+	rate, ok := new(big.Rat).SetString(strconv.FormatFloat(q.Rate, 'f', -1, 64))
+	if !ok {
+		return nil, fmt.Errorf("%w: rate %v", ErrUnsupportedChain, q.Rate)
+	}
+
+	units := new(big.Rat).Quo(fiat.Value, rate)
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	units.Mul(units, new(big.Rat).SetInt(scale))
+
+	// Integer division of a positive rational truncates toward zero.
+	result := new(big.Int).Quo(units.Num(), units.Denom())
+	if result.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrAmountTooSmall, fiat.String(), fiat.Currency)
+	}
+	return result, nil
+}
+
+// Value returns the fiat value of an amount in base units (satoshis, wei),
+// rounded to cents.
+func (q *Quote) Value(units *big.Int, decimals int) string {
+	rate, ok := new(big.Rat).SetString(strconv.FormatFloat(q.Rate, 'f', -1, 64))
+	if !ok || units == nil {
+		return "0.00"
+	}
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	value := new(big.Rat).SetFrac(units, scale)
+	return value.Mul(value, rate).FloatString(2)
+}
+
+// ParseCurrency validates a display currency such as "usd" or "EUR" and
+// returns its canonical code.
+func ParseCurrency(s string) (string, error) {
+	switch code := strings.ToUpper(strings.TrimSpace(s)); code {
+	case CurrencyUSD, CurrencyEUR:
+		return code, nil
+	default:
+		return "", fmt.Errorf("%w: %q (use usd or eur)", ErrUnsupportedCurrency, s)
+	}
+}
+
+// Slippage returns the relative move from one quote's rate to another's,
+// as an absolute percentage.
+func Slippage(from, to *Quote) float64 {
+	if from == nil || to == nil || from.Rate <= 0 {
+		return 0
+	}
+	diff := (to.Rate - from.Rate) / from.Rate * 100
+	if diff < 0 {
+		return -diff
+	}
+	return diff
+}
+
+// FiatAmount is a positive amount of a fiat currency.
+type FiatAmount struct {
+	Value    *big.Rat
+	Currency string
+}
+
+// String formats the amount with two decimal places.
+func (f *FiatAmount) String() string {
+	return f.Value.FloatString(2)
+}
+
+// ParseFiatAmount recognizes fiat-denominated amounts such as "50usd",
+// "12.5 USD" or "$50". It returns ok=false when the input carries no fiat
+// marker, so callers can fall back to parsing it as a coin amount.
+func ParseFiatAmount(s string) (amount *FiatAmount, ok bool, err error) {
+	s = strings.TrimSpace(s)
+	lower := strings.ToLower(s)
+
+	var number string
+	switch {
+	case strings.HasPrefix(s, "$"):
+		number = strings.TrimSpace(s[1:])
+	case strings.HasSuffix(lower, "usd"):
+		number = strings.TrimSpace(s[:len(s)-len("usd")])
+	default:
+		return nil, false, nil
+	}
+
+	value, valid := new(big.Rat).SetString(number)
+	if number == "" || !valid || value.Sign() <= 0 || strings.ContainsAny(number, "eE/+-") {
+		return nil, true, fmt.Errorf("%w: %q", ErrInvalidFiatAmount, s)
+	}
+	return &FiatAmount{Value: value, Currency: CurrencyUSD}, true, nil
+}
+
+// Provider fetches quotes from per-chain rate sources.
+type Provider struct {
+	bsv BSVSource
+	eth ETHSource
+	now func() time.Time
+}
+
+// NewProvider creates a provider. Either source may be nil, in which case
+// quotes for that chain fail with ErrUnsupportedChain.
+func NewProvider(bsvSource BSVSource, ethSource ETHSource) *Provider {
+	return &Provider{bsv: bsvSource, eth: ethSource, now: time.Now}
+}
+
+// Quote fetches the current rate for a chain's native coin in the given currency.
+func (p *Provider) Quote(ctx context.Context, chainID chain.ID, currency string) (*Quote, error) {
+	if !strings.EqualFold(currency, CurrencyUSD) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedCurrency, currency)
+	}
+
+	q := &Quote{Chain: chainID, Currency: CurrencyUSD, FetchedAt: p.now().UTC()}
+	switch {
+	case chainID == chain.BSV && p.bsv != nil:
+		rate, err := p.bsv.GetExchangeRate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		q.Rate, q.Time, q.Source = rate.Rate, rate.Time, "whatsonchain"
+	case chainID == chain.ETH && p.eth != nil:
+		ethPrice, err := p.eth.GetETHPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		q.Rate, q.Time, q.Source = ethPrice.USD, ethPrice.Time, "etherscan"
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChain, chainID)
+	}
+
+	if q.Time.IsZero() {
+		q.Time = q.FetchedAt
+	}
+	return q, nil
+}
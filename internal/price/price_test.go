@@ -0,0 +1,217 @@
+package price
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+)
+
+var errTestProvider = errors.New("provider down")
+
+type stubBSVSource struct {
+	rate *bsv.ExchangeRate
+	err  error
+}
+
+func (s *stubBSVSource) GetExchangeRate(_ context.Context) (*bsv.ExchangeRate, error) {
+	return s.rate, s.err
+}
+
+type stubETHSource struct {
+	price *etherscan.ETHPrice
+}
+
+func (s *stubETHSource) GetETHPrice(_ context.Context) (*etherscan.ETHPrice, error) {
+	return s.price, nil
+}
+
+func TestParseFiatAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input    string
+		wantOK   bool
+		wantErr  bool
+		expected string
+	}{
+		{input: "50usd", wantOK: true, expected: "50.00"},
+		{input: "50USD", wantOK: true, expected: "50.00"},
+		{input: "12.5 usd", wantOK: true, expected: "12.50"},
+		{input: "$50", wantOK: true, expected: "50.00"},
+		{input: "$ 0.99", wantOK: true, expected: "0.99"},
+		{input: "0.5", wantOK: false},
+		{input: "all", wantOK: false},
+		{input: "usd", wantOK: true, wantErr: true},
+		{input: "$", wantOK: true, wantErr: true},
+		{input: "-5usd", wantOK: true, wantErr: true},
+		{input: "0usd", wantOK: true, wantErr: true},
+		{input: "1e3usd", wantOK: true, wantErr: true},
+		{input: "1/2usd", wantOK: true, wantErr: true},
+		{input: "abcusd", wantOK: true, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			amount, ok, err := ParseFiatAmount(tc.input)
+			assert.Equal(t, tc.wantOK, ok)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidFiatAmount)
+				return
+			}
+			require.NoError(t, err)
+			if !tc.wantOK {
+				assert.Nil(t, amount)
+				return
+			}
+			assert.Equal(t, tc.expected, amount.String())
+			assert.Equal(t, CurrencyUSD, amount.Currency)
+		})
+	}
+}
+
+func TestQuoteCoinAmount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fiat     string
+		rate     float64
+		decimals int
+		expected string
+		wantErr  error
+	}{
+		{name: "exact bsv", fiat: "50usd", rate: 50, decimals: 8, expected: "100000000"},
+		{name: "rounds down", fiat: "10usd", rate: 3, decimals: 8, expected: "333333333"},
+		{name: "fractional rate", fiat: "1usd", rate: 52.31, decimals: 8, expected: "1911680"},
+		{name: "eth wei", fiat: "$100", rate: 4000, decimals: 18, expected: "25000000000000000"},
+		{name: "dust rounds to zero", fiat: "$0.01", rate: 100000000000, decimals: 8, wantErr: ErrAmountTooSmall},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			fiat, ok, err := ParseFiatAmount(tc.fiat)
+			require.True(t, ok)
+			require.NoError(t, err)
+
+			q := &Quote{Rate: tc.rate}
+			units, err := q.CoinAmount(fiat, tc.decimals)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			expected, _ := new(big.Int).SetString(tc.expected, 10)
+			assert.Equal(t, 0, expected.Cmp(units), "got %s", units)
+		})
+	}
+}
+
+func TestSlippage(t *testing.T) {
+	t.Parallel()
+
+	assert.InDelta(t, 2.0, Slippage(&Quote{Rate: 50}, &Quote{Rate: 51}), 1e-9)
+	assert.InDelta(t, 2.0, Slippage(&Quote{Rate: 50}, &Quote{Rate: 49}), 1e-9)
+	assert.InDelta(t, 0.0, Slippage(&Quote{Rate: 50}, &Quote{Rate: 50}), 1e-9)
+	assert.InDelta(t, 0.0, Slippage(nil, &Quote{Rate: 50}), 1e-9)
+}
+
+func TestProviderQuote(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	captured := now.Add(-time.Minute)
+
+	newProvider := func(b BSVSource, e ETHSource) *Provider {
+		p := NewProvider(b, e)
+		p.now = func() time.Time { return now }
+		return p
+	}
+
+	t.Run("bsv quote keeps provider timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(&stubBSVSource{rate: &bsv.ExchangeRate{Currency: "USD", Rate: 52.31, Time: captured}}, nil)
+		q, err := p.Quote(context.Background(), chain.BSV, "usd")
+		require.NoError(t, err)
+		assert.Equal(t, chain.BSV, q.Chain)
+		assert.InDelta(t, 52.31, q.Rate, 1e-9)
+		assert.Equal(t, captured, q.Time)
+		assert.Equal(t, now, q.FetchedAt)
+		assert.Equal(t, "whatsonchain", q.Source)
+		assert.Equal(t, time.Minute, q.Age(now))
+	})
+
+	t.Run("eth quote without timestamp uses fetch time", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(nil, &stubETHSource{price: &etherscan.ETHPrice{USD: 4000}})
+		q, err := p.Quote(context.Background(), chain.ETH, CurrencyUSD)
+		require.NoError(t, err)
+		assert.Equal(t, now, q.Time)
+		assert.Equal(t, "etherscan", q.Source)
+	})
+
+	t.Run("missing source", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(nil, nil)
+		_, err := p.Quote(context.Background(), chain.ETH, CurrencyUSD)
+		require.ErrorIs(t, err, ErrUnsupportedChain)
+	})
+
+	t.Run("unsupported currency", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(&stubBSVSource{}, nil)
+		_, err := p.Quote(context.Background(), chain.BSV, "EUR")
+		require.ErrorIs(t, err, ErrUnsupportedCurrency)
+	})
+
+	t.Run("source error is returned", func(t *testing.T) {
+		t.Parallel()
+
+		p := newProvider(&stubBSVSource{err: errTestProvider}, nil)
+		_, err := p.Quote(context.Background(), chain.BSV, CurrencyUSD)
+		require.ErrorIs(t, err, errTestProvider)
+	})
+}
+
+func TestQuoteValue(t *testing.T) {
+	t.Parallel()
+
+	q := &Quote{Chain: chain.BSV, Currency: CurrencyUSD, Rate: 48.25}
+	assert.Equal(t, "48.25", q.Value(big.NewInt(100_000_000), 8))
+	assert.Equal(t, "0.48", q.Value(big.NewInt(1_000_000), 8))
+	assert.Equal(t, "0.00", q.Value(big.NewInt(1), 8))
+
+	eth := &Quote{Chain: chain.ETH, Currency: CurrencyEUR, Rate: 3000}
+	wei, _ := new(big.Int).SetString("1500000000000000000", 10)
+	assert.Equal(t, "4500.00", eth.Value(wei, 18))
+}
+
+func TestParseCurrency(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]string{"usd": CurrencyUSD, " EUR ": CurrencyEUR, "Eur": CurrencyEUR} {
+		got, err := ParseCurrency(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got)
+	}
+	for _, in := range []string{"", "gbp", "$"} {
+		_, err := ParseCurrency(in)
+		require.ErrorIs(t, err, ErrUnsupportedCurrency, in)
+	}
+}
@@ -0,0 +1,242 @@
+// Package profile packages shareable configuration into signed bundles.
+//
+// A profile holds the settings a team wants every member to use: network
+// endpoints, token lists, fee settings, the co-signing service, and wallet
+// templates. Secrets such as API keys are never included. The exporter signs
+// the bundle with an ed25519 key; importers check the signature against the
+// signer key they were given out of band before applying anything, so a
+// bundle cannot be altered or forged in transit.
+package profile
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// Version is the current bundle format version.
+	Version = 1
+
+	// signingDomain prefixes every signed bundle.
+	signingDomain = "sigil-profile/v1"
+
+	// keyFilePermissions restricts the signing key to the owner.
+	keyFilePermissions = 0o600
+
+	// keyDirPermissions restricts the sigil home directory to the owner.
+	keyDirPermissions = 0o700
+)
+
+var (
+	// ErrInvalidBundle is returned for a bundle that cannot be parsed.
+	ErrInvalidBundle = errors.New("invalid profile bundle")
+
+	// ErrVersionTooNew is returned for a bundle newer than supported.
+	ErrVersionTooNew = errors.New("profile bundle version is newer than supported")
+
+	// ErrBadSignature is returned when the bundle signature does not verify.
+	ErrBadSignature = errors.New("profile bundle signature is invalid")
+
+	// ErrUntrustedSigner is returned when the bundle was signed by a key
+	// other than the one the importer expects.
+	ErrUntrustedSigner = errors.New("profile bundle was signed by an untrusted key")
+
+	// ErrInvalidPublicKey is returned for a signer key that is not a hex
+	// encoded ed25519 public key.
+	ErrInvalidPublicKey = errors.New("signer key must be a 32-byte hex ed25519 key")
+
+	// ErrInvalidKeyFile is returned for a malformed signing key file.
+	ErrInvalidKeyFile = errors.New("invalid profile signing key file")
+)
+
+// Settings are the shareable parts of a configuration.
+type Settings struct {
+	Networks config.NetworksConfig `yaml:"networks"`
+	Fees     config.FeesConfig     `yaml:"fees"`
+	Cosign   config.CosignConfig   `yaml:"cosign"`
+	// RequireConfirmAbove is security.require_confirm_above.
+	RequireConfirmAbove float64                                `yaml:"require_confirm_above"`
+	WalletTemplates     map[string]config.WalletTemplateConfig `yaml:"wallet_templates,omitempty"`
+}
+
+// FromConfig copies the shareable settings out of cfg, leaving out every
+// field tagged as a secret.
+func FromConfig(cfg *config.Config) *Settings {
+	clean := config.Redact(cfg, "")
+	return &Settings{
+		Networks:            clean.Networks,
+		Fees:                clean.Fees,
+		Cosign:              clean.Security.Cosign,
+		RequireConfirmAbove: clean.Security.RequireConfirmAbove,
+		WalletTemplates:     clean.WalletTemplates,
+	}
+}
+
+// Apply writes the settings into cfg. The API keys in cfg are kept, since a
+// profile never carries them, and wallet templates are merged by name.
+func (s *Settings) Apply(cfg *config.Config) {
+	etherscanKey, bsvKey := cfg.Networks.ETH.EtherscanAPIKey, cfg.Networks.BSV.APIKey
+	cfg.Networks = s.Networks
+	cfg.Networks.ETH.EtherscanAPIKey, cfg.Networks.BSV.APIKey = etherscanKey, bsvKey
+
+	cfg.Fees = s.Fees
+	cfg.Security.Cosign = s.Cosign
+	cfg.Security.RequireConfirmAbove = s.RequireConfirmAbove
+
+	if len(s.WalletTemplates) > 0 && cfg.WalletTemplates == nil {
+		cfg.WalletTemplates = make(map[string]config.WalletTemplateConfig, len(s.WalletTemplates))
+	}
+	for name, tmpl := range s.WalletTemplates {
+		cfg.WalletTemplates[name] = tmpl
+	}
+}
+
+// Bundle is a signed profile. Settings holds the settings as YAML so the
+// signed bytes are exactly the bytes that are applied.
+type Bundle struct {
+	Version   int       `json:"version"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+	// Signer is the hex ed25519 public key the bundle is signed with.
+	Signer    string `json:"signer"`
+	Settings  string `json:"settings"`
+	Signature string `json:"signature"`
+}
+
+// Sign builds a bundle named name from s, signed with key.
+func Sign(name string, s *Settings, key ed25519.PrivateKey, now time.Time) (*Bundle, error) {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("encoding settings: %w", err)
+	}
+
+	b := &Bundle{
+		Version:   Version,
+		Name:      name,
+		CreatedAt: now.UTC().Truncate(time.Second),
+		Signer:    hex.EncodeToString(key.Public().(ed25519.PublicKey)),
+		Settings:  string(data),
+	}
+	msg, err := b.signedMessage()
+	if err != nil {
+		return nil, err
+	}
+	b.Signature = hex.EncodeToString(ed25519.Sign(key, msg))
+	return b, nil
+}
+
+// Parse decodes a bundle without verifying it.
+func Parse(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	if b.Version > Version {
+		return nil, fmt.Errorf("%w: version %d", ErrVersionTooNew, b.Version)
+	}
+	if b.Version < 1 || b.Signer == "" || b.Signature == "" {
+		return nil, ErrInvalidBundle
+	}
+	return &b, nil
+}
+
+// Verify checks that the bundle was signed by the hex ed25519 key signer
+// and returns its settings.
+func (b *Bundle) Verify(signer string) (*Settings, error) {
+	want, err := ParsePublicKey(signer)
+	if err != nil {
+		return nil, err
+	}
+	got, err := ParsePublicKey(b.Signer)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidBundle, err)
+	}
+	if !want.Equal(got) {
+		return nil, ErrUntrustedSigner
+	}
+
+	sig, err := hex.DecodeString(b.Signature)
+	if err != nil {
+		return nil, ErrBadSignature
+	}
+	msg, err := b.signedMessage()
+	if err != nil {
+		return nil, err
+	}
+	if !ed25519.Verify(got, msg, sig) {
+		return nil, ErrBadSignature
+	}
+
+	var s Settings
+	if err := yaml.Unmarshal([]byte(b.Settings), &s); err != nil {
+		return nil, fmt.Errorf("%w: settings: %w", ErrInvalidBundle, err)
+	}
+	return &s, nil
+}
+
+// signedMessage returns the bytes covered by the signature: the signing
+// domain and the bundle without its signature.
+func (b *Bundle) signedMessage() ([]byte, error) {
+	unsigned := *b
+	unsigned.Signature = ""
+	data, err := json.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("encoding bundle: %w", err)
+	}
+	return append([]byte(signingDomain+"\n"), data...), nil
+}
+
+// ParsePublicKey decodes a hex ed25519 public key.
+func ParsePublicKey(s string) (ed25519.PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(s))
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return nil, ErrInvalidPublicKey
+	}
+	return ed25519.PublicKey(key), nil
+}
+
+// KeyPath returns the default signing key path in the sigil home directory.
+func KeyPath(home string) string {
+	return filepath.Join(home, "profile_signing.key")
+}
+
+// LoadOrCreateKey reads the hex ed25519 seed at path, generating and saving
+// a new key when the file does not exist. created reports a new key.
+func LoadOrCreateKey(path string) (key ed25519.PrivateKey, created bool, err error) {
+	data, err := os.ReadFile(path) //nolint:gosec // key path comes from the sigil home or the user
+	if err == nil {
+		seed, decodeErr := hex.DecodeString(strings.TrimSpace(string(data)))
+		if decodeErr != nil || len(seed) != ed25519.SeedSize {
+			return nil, false, fmt.Errorf("%w: %s", ErrInvalidKeyFile, path)
+		}
+		return ed25519.NewKeyFromSeed(seed), false, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, false, fmt.Errorf("reading signing key: %w", err)
+	}
+
+	_, key, err = ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, false, fmt.Errorf("generating signing key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), keyDirPermissions); err != nil {
+		return nil, false, fmt.Errorf("creating key directory: %w", err)
+	}
+	if err := fileutil.WriteAtomic(path, []byte(hex.EncodeToString(key.Seed())+"\n"), keyFilePermissions); err != nil {
+		return nil, false, fmt.Errorf("writing signing key: %w", err)
+	}
+	return key, true, nil
+}
@@ -0,0 +1,156 @@
+package profile
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/config"
+)
+
+func testKey(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return key
+}
+
+func signerHex(key ed25519.PrivateKey) string {
+	return hex.EncodeToString(key.Public().(ed25519.PublicKey))
+}
+
+func TestFromConfig_LeavesOutSecrets(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Defaults()
+	cfg.Networks.ETH.RPC = "https://rpc.team.example"
+	cfg.Networks.ETH.EtherscanAPIKey = "etherscan-secret"
+	cfg.Networks.BSV.APIKey = "woc-secret"
+	cfg.Fees.BSVFeeStrategy = "economy"
+
+	s := FromConfig(cfg)
+	assert.Equal(t, "https://rpc.team.example", s.Networks.ETH.RPC)
+	assert.Equal(t, "economy", s.Fees.BSVFeeStrategy)
+	assert.Empty(t, s.Networks.ETH.EtherscanAPIKey)
+	assert.Empty(t, s.Networks.BSV.APIKey)
+	assert.Equal(t, "etherscan-secret", cfg.Networks.ETH.EtherscanAPIKey, "the source config is unchanged")
+}
+
+func TestSettings_Apply(t *testing.T) {
+	t.Parallel()
+
+	source := config.Defaults()
+	source.Networks.ETH.RPC = "https://rpc.team.example"
+	source.Security.Cosign.URL = "https://cosign.team.example"
+	source.Security.RequireConfirmAbove = 500
+	source.WalletTemplates = map[string]config.WalletTemplateConfig{"treasury": {Addresses: 5}}
+
+	local := config.Defaults()
+	local.Networks.ETH.EtherscanAPIKey = "my-key"
+	local.Networks.BSV.APIKey = "my-woc-key"
+	local.Security.SessionEnabled = true
+	local.WalletTemplates = map[string]config.WalletTemplateConfig{"personal": {Addresses: 1}}
+
+	FromConfig(source).Apply(local)
+	assert.Equal(t, "https://rpc.team.example", local.Networks.ETH.RPC)
+	assert.Equal(t, "my-key", local.Networks.ETH.EtherscanAPIKey, "local API keys are kept")
+	assert.Equal(t, "my-woc-key", local.Networks.BSV.APIKey)
+	assert.Equal(t, "https://cosign.team.example", local.Security.Cosign.URL)
+	assert.InDelta(t, 500.0, local.Security.RequireConfirmAbove, 0)
+	assert.True(t, local.Security.SessionEnabled, "settings outside the profile are untouched")
+	assert.Len(t, local.WalletTemplates, 2, "templates are merged by name")
+}
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	key := testKey(t)
+	cfg := config.Defaults()
+	cfg.Networks.ETH.RPC = "https://rpc.team.example"
+
+	b, err := Sign("acme", FromConfig(cfg), key, time.Now())
+	require.NoError(t, err)
+	data, err := json.Marshal(b)
+	require.NoError(t, err)
+
+	parsed, err := Parse(data)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", parsed.Name)
+
+	s, err := parsed.Verify(signerHex(key))
+	require.NoError(t, err)
+	assert.Equal(t, "https://rpc.team.example", s.Networks.ETH.RPC)
+
+	t.Run("untrusted signer", func(t *testing.T) {
+		t.Parallel()
+		_, err := parsed.Verify(signerHex(testKey(t)))
+		require.ErrorIs(t, err, ErrUntrustedSigner)
+	})
+
+	t.Run("tampered settings", func(t *testing.T) {
+		t.Parallel()
+		tampered := *parsed
+		tampered.Settings += "\n# changed\n"
+		_, err := tampered.Verify(signerHex(key))
+		require.ErrorIs(t, err, ErrBadSignature)
+	})
+
+	t.Run("resigned by another key", func(t *testing.T) {
+		t.Parallel()
+		other := testKey(t)
+		forged, err := Sign("acme", FromConfig(cfg), other, time.Now())
+		require.NoError(t, err)
+		forged.Signer = parsed.Signer
+		_, err = forged.Verify(signerHex(key))
+		require.ErrorIs(t, err, ErrBadSignature)
+	})
+
+	t.Run("bad signer key", func(t *testing.T) {
+		t.Parallel()
+		_, err := parsed.Verify("not-hex")
+		require.ErrorIs(t, err, ErrInvalidPublicKey)
+	})
+}
+
+func TestParse_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := Parse([]byte("not json"))
+	require.ErrorIs(t, err, ErrInvalidBundle)
+
+	_, err = Parse([]byte(`{"version":99,"signer":"aa","signature":"bb"}`))
+	require.ErrorIs(t, err, ErrVersionTooNew)
+
+	_, err = Parse([]byte(`{"version":1}`))
+	require.ErrorIs(t, err, ErrInvalidBundle)
+}
+
+func TestLoadOrCreateKey(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "home", "profile_signing.key")
+	key, created, err := LoadOrCreateKey(path)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	again, created, err := LoadOrCreateKey(path)
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.True(t, key.Equal(again))
+
+	require.NoError(t, os.WriteFile(path, []byte("garbage"), 0o600))
+	_, _, err = LoadOrCreateKey(path)
+	require.ErrorIs(t, err, ErrInvalidKeyFile)
+}
@@ -0,0 +1,103 @@
+package pwstrength
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // G505: SHA-1 is the hash used by breached password lists
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// scanWindow is the span below which Breached stops bisecting and reads
+// lines sequentially. It must comfortably exceed the longest line.
+const scanWindow = 4096
+
+// Breached reports whether password appears in the breached password list at
+// path. The list uses the Have I Been Pwned download format: one uppercase
+// SHA-1 hex digest per line, optionally followed by ":count", sorted by hash.
+// Sorted order lets multi-gigabyte lists be searched without reading them.
+func Breached(path string, password []byte) (bool, error) {
+	sum := sha1.Sum(password) //nolint:gosec // G401: see import
+	target := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	f, err := os.Open(path) //nolint:gosec // G304: path comes from the user's config
+	if err != nil {
+		return false, fmt.Errorf("opening breached password list: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	info, err := f.Stat()
+	if err != nil {
+		return false, fmt.Errorf("reading breached password list: %w", err)
+	}
+
+	// Any line matching target starts within [lo, hi). lo is always the
+	// start of a line.
+	lo, hi := int64(0), info.Size()
+	for hi-lo > scanWindow {
+		mid := lo + (hi-lo)/2
+		start, line, err := lineAfter(f, mid, info.Size())
+		if err != nil {
+			return false, err
+		}
+		if start >= hi {
+			break
+		}
+
+		switch cmp := strings.Compare(lineHash(line), target); {
+		case cmp == 0:
+			return true, nil
+		case cmp < 0:
+			lo = start + int64(len(line))
+		default:
+			hi = start
+		}
+	}
+
+	r := bufio.NewReader(io.NewSectionReader(f, lo, info.Size()-lo))
+	for pos := lo; pos < hi; {
+		line, err := r.ReadString('\n')
+		if lineHash(line) == target {
+			return true, nil
+		}
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return false, nil
+			}
+			return false, fmt.Errorf("reading breached password list: %w", err)
+		}
+		pos += int64(len(line))
+	}
+	return false, nil
+}
+
+// lineAfter returns the first complete line starting after offset, with its
+// trailing newline, and the offset at which it starts.
+func lineAfter(r io.ReaderAt, offset, size int64) (int64, string, error) {
+	// Start one byte early so a line beginning exactly at offset is found.
+	from := offset - 1
+	br := bufio.NewReader(io.NewSectionReader(r, from, size-from))
+	skipped, err := br.ReadString('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return size, "", nil
+		}
+		return 0, "", fmt.Errorf("reading breached password list: %w", err)
+	}
+
+	start := from + int64(len(skipped))
+	line, err := br.ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return 0, "", fmt.Errorf("reading breached password list: %w", err)
+	}
+	return start, line, nil
+}
+
+// lineHash extracts the normalized hash from a list line.
+func lineHash(line string) string {
+	hash, _, _ := strings.Cut(strings.TrimSpace(line), ":")
+	return strings.ToUpper(hash)
+}
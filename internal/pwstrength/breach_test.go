@@ -0,0 +1,74 @@
+package pwstrength
+
+import (
+	"crypto/sha1" //nolint:gosec // test fixture uses the list's hash
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeBreachList writes a sorted HIBP-style list containing passwords plus
+// filler hashes, so the search has to bisect.
+func writeBreachList(t *testing.T, passwords []string, filler int) string {
+	t.Helper()
+
+	lines := make([]string, 0, len(passwords)+filler)
+	for i, p := range passwords {
+		sum := sha1.Sum([]byte(p)) //nolint:gosec // test fixture
+		lines = append(lines, fmt.Sprintf("%s:%d", strings.ToUpper(hex.EncodeToString(sum[:])), i+1))
+	}
+	for i := range filler {
+		sum := sha1.Sum(fmt.Appendf(nil, "filler-%d", i)) //nolint:gosec // test fixture
+		lines = append(lines, strings.ToUpper(hex.EncodeToString(sum[:]))+":1")
+	}
+	sort.Strings(lines)
+
+	path := filepath.Join(t.TempDir(), "pwned.txt")
+	require.NoError(t, os.WriteFile(path, []byte(strings.Join(lines, "\r\n")), 0o600))
+	return path
+}
+
+func TestBreached(t *testing.T) {
+	t.Parallel()
+
+	breached := []string{"hunter2", "correct horse battery staple", "Summer2024!"}
+	path := writeBreachList(t, breached, 5000)
+
+	for _, p := range breached {
+		found, err := Breached(path, []byte(p))
+		require.NoError(t, err)
+		assert.True(t, found, p)
+	}
+
+	found, err := Breached(path, []byte("zq8vnr2wlkpassmx"))
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestBreached_SmallList(t *testing.T) {
+	t.Parallel()
+
+	path := writeBreachList(t, []string{"hunter2"}, 0)
+
+	found, err := Breached(path, []byte("hunter2"))
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	found, err = Breached(path, []byte("hunter3"))
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func TestBreached_MissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := Breached(filepath.Join(t.TempDir(), "missing.txt"), []byte("hunter2"))
+	require.Error(t, err)
+}
@@ -0,0 +1,46 @@
+package pwstrength
+
+import "sync"
+
+// commonPasswords are frequently used passwords and password stems, most
+// common first. The list is deliberately short; a full breach corpus can be
+// checked with Breached.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var commonPasswords = []string{
+	"password", "123456", "12345678", "qwerty", "123456789", "12345", "1234", "111111",
+	"1234567", "dragon", "123123", "baseball", "abc123", "football", "monkey", "letmein",
+	"696969", "shadow", "master", "666666", "qwertyuiop", "123321", "mustang", "1234567890",
+	"michael", "654321", "superman", "1qaz2wsx", "7777777", "121212", "000000", "qazwsx",
+	"123qwe", "killer", "trustno1", "jordan", "jennifer", "zxcvbnm", "asdfgh", "hunter",
+	"buster", "soccer", "harley", "batman", "andrew", "tigger", "sunshine", "iloveyou",
+	"2000", "charlie", "robert", "thomas", "hockey", "ranger", "daniel", "starwars",
+	"klaster", "112233", "george", "computer", "michelle", "jessica", "pepper", "1111",
+	"zxcvbn", "555555", "11111111", "131313", "freedom", "777777", "pass", "maggie",
+	"159753", "aaaaaa", "ginger", "princess", "joshua", "cheese", "amanda", "summer",
+	"love", "ashley", "nicole", "chelsea", "biteme", "matthew", "access", "yankees",
+	"987654321", "dallas", "austin", "thunder", "taylor", "matrix", "welcome", "admin",
+	"secret", "passw0rd", "login", "solo", "flower", "hello", "whatever", "qwerty123",
+	"bitcoin", "ethereum", "satoshi", "crypto", "wallet", "blockchain", "moon", "hodl",
+	"sigil", "changeme", "default", "test", "testing", "guest", "root", "toor",
+}
+
+//nolint:gochecknoglobals // Lazily built index over commonPasswords
+var (
+	commonIndexOnce sync.Once
+	commonIndex     map[string]int
+)
+
+// commonRank returns the position of word in the common password list.
+func commonRank(word string) (int, bool) {
+	commonIndexOnce.Do(func() {
+		commonIndex = make(map[string]int, len(commonPasswords))
+		for i, p := range commonPasswords {
+			if _, dup := commonIndex[p]; !dup {
+				commonIndex[p] = i
+			}
+		}
+	})
+	rank, ok := commonIndex[word]
+	return rank, ok
+}
@@ -0,0 +1,216 @@
+// Package pwstrength estimates how hard a password is to guess and checks it
+// against a local list of breached passwords.
+//
+// The estimate follows the approach popularized by zxcvbn: rather than
+// crediting every character with the full size of its character set, runs of
+// repeated characters, sequences, keyboard walks, and common passwords are
+// scored by how quickly an attacker who tries those patterns first would reach
+// them. The result is a conservative number of bits, not a guarantee.
+package pwstrength
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// Character set sizes used to score characters that match no pattern.
+const (
+	lowerPool  = 26
+	upperPool  = 26
+	digitPool  = 10
+	symbolPool = 33
+	otherPool  = 100
+)
+
+// Bits credited to characters that continue a pattern.
+const (
+	repeatBits   = 1.0
+	sequenceBits = 1.0
+	keyboardBits = 2.0
+)
+
+// minDictionaryMatch is the shortest substring checked against the common
+// password list, so short words inside a long password are not penalized.
+const minDictionaryMatch = 4
+
+// Weaknesses reported in Estimate.Warning.
+const (
+	WarningCommon  = "password is, or is based on, a commonly used password"
+	WarningPattern = "password relies on repeated characters, sequences, or keyboard patterns"
+)
+
+// Estimate is the result of Evaluate.
+type Estimate struct {
+	// Bits is the estimated guessing entropy in bits.
+	Bits float64
+
+	// Warning names the weakness that lowered the estimate, if any.
+	Warning string
+}
+
+// Evaluate estimates the guessing entropy of password.
+func Evaluate(password []byte) Estimate {
+	runes := []rune(string(password))
+	if len(runes) == 0 {
+		return Estimate{}
+	}
+
+	charBits := math.Log2(float64(poolSize(runes)))
+	best, patterned := patternBits(runes, charBits)
+	est := Estimate{Bits: best}
+	if patterned*2 >= len(runes) {
+		est.Warning = WarningPattern
+	}
+
+	// A common password anywhere in the input replaces the characters it
+	// covers with its rank in the list.
+	for start := 0; start < len(runes); start++ {
+		for end := len(runes); end-start >= minDictionaryMatch; end-- {
+			bits, ok := dictionaryBits(runes[start:end])
+			if !ok {
+				continue
+			}
+			prefix, _ := patternBits(runes[:start], charBits)
+			suffix, _ := patternBits(runes[end:], charBits)
+			if total := prefix + bits + suffix; total < est.Bits {
+				est = Estimate{Bits: total, Warning: WarningCommon}
+			}
+			break
+		}
+	}
+
+	return est
+}
+
+// poolSize returns the number of symbols an attacker brute-forcing the
+// password's character classes would have to consider.
+func poolSize(runes []rune) int {
+	var lower, upper, digit, symbol, other bool
+	for _, r := range runes {
+		switch {
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= '0' && r <= '9':
+			digit = true
+		case r < unicode.MaxASCII && unicode.IsPrint(r):
+			symbol = true
+		default:
+			other = true
+		}
+	}
+
+	pool := 0
+	for _, c := range []struct {
+		present bool
+		size    int
+	}{{lower, lowerPool}, {upper, upperPool}, {digit, digitPool}, {symbol, symbolPool}, {other, otherPool}} {
+		if c.present {
+			pool += c.size
+		}
+	}
+	return pool
+}
+
+// patternBits scores runes character by character. A character that repeats,
+// continues a sequence, or sits next to the previous one on the keyboard earns
+// only a few bits; any other character earns charBits. It also returns how
+// many characters continued a pattern.
+func patternBits(runes []rune, charBits float64) (float64, int) {
+	var bits float64
+	patterned := 0
+	for i, r := range runes {
+		if i == 0 {
+			bits += charBits
+			continue
+		}
+
+		prev := runes[i-1]
+		switch {
+		case unicode.ToLower(r) == unicode.ToLower(prev):
+			bits += repeatBits
+		case isSequence(prev, r):
+			bits += sequenceBits
+		case keyboardAdjacent(prev, r):
+			bits += keyboardBits
+		default:
+			bits += charBits
+			continue
+		}
+		patterned++
+	}
+	return bits, patterned
+}
+
+// isSequence reports whether b follows or precedes a in the alphabet or digits.
+func isSequence(a, b rune) bool {
+	a, b = unicode.ToLower(a), unicode.ToLower(b)
+	sameClass := (a >= 'a' && a <= 'z' && b >= 'a' && b <= 'z') ||
+		(a >= '0' && a <= '9' && b >= '0' && b <= '9')
+	return sameClass && (b-a == 1 || a-b == 1)
+}
+
+// keyboardRows are the letter rows of a US QWERTY keyboard.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var keyboardRows = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// keyboardAdjacent reports whether a and b are neighbors on a keyboard row.
+func keyboardAdjacent(a, b rune) bool {
+	a, b = unicode.ToLower(a), unicode.ToLower(b)
+	for _, row := range keyboardRows {
+		i, j := strings.IndexRune(row, a), strings.IndexRune(row, b)
+		if i >= 0 && j >= 0 && (i-j == 1 || j-i == 1) {
+			return true
+		}
+	}
+	return false
+}
+
+// leetSubstitutions maps common character substitutions back to letters.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var leetSubstitutions = map[rune]rune{
+	'0': 'o', '1': 'i', '3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's', '!': 'i',
+}
+
+// dictionaryBits scores runes if they spell a common password, allowing for
+// capitalization and character substitutions.
+func dictionaryBits(runes []rune) (float64, bool) {
+	word := make([]rune, len(runes))
+	var capitalized, substituted bool
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			capitalized = true
+			r = unicode.ToLower(r)
+		}
+		word[i] = r
+	}
+
+	rank, ok := commonRank(string(word))
+	if !ok {
+		for i, r := range word {
+			if sub, found := leetSubstitutions[r]; found {
+				word[i] = sub
+				substituted = true
+			}
+		}
+		if !substituted {
+			return 0, false
+		}
+		if rank, ok = commonRank(string(word)); !ok {
+			return 0, false
+		}
+	}
+
+	bits := math.Log2(float64(rank + 1))
+	if capitalized {
+		bits++
+	}
+	if substituted {
+		bits++
+	}
+	return bits, true
+}
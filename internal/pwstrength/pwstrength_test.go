@@ -0,0 +1,50 @@
+package pwstrength
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		password    string
+		maxBits     float64
+		minBits     float64
+		wantWarning string
+	}{
+		{name: "empty", password: "", maxBits: 0},
+		{name: "most common password", password: "password", maxBits: 1, wantWarning: WarningCommon},
+		{name: "capitalized with suffix", password: "Password123!", maxBits: 25, wantWarning: WarningCommon},
+		{name: "substituted characters", password: "P@ssw0rd", maxBits: 10, wantWarning: WarningCommon},
+		{name: "repeated character", password: "aaaaaaaaaaaa", maxBits: 20, wantWarning: WarningPattern},
+		{name: "alphabet sequence", password: "abcdefgh", maxBits: 20, wantWarning: WarningPattern},
+		{name: "keyboard walk", password: "sdfghjkl", maxBits: 25, wantWarning: WarningPattern},
+		{name: "random mixed characters", password: "x7#kQ9!mZ2", minBits: 60},
+		{name: "passphrase", password: "correct horse battery staple", minBits: 100},
+		{name: "common word inside random password", password: "zq8vnr2wlkpassmx", minBits: 60, wantWarning: WarningCommon},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := Evaluate([]byte(tt.password))
+			if tt.maxBits > 0 || tt.password == "" {
+				assert.LessOrEqual(t, got.Bits, tt.maxBits)
+			}
+			assert.GreaterOrEqual(t, got.Bits, tt.minBits)
+			assert.Equal(t, tt.wantWarning, got.Warning)
+		})
+	}
+}
+
+func TestEvaluate_LongerIsStronger(t *testing.T) {
+	t.Parallel()
+
+	short := Evaluate([]byte("kd8fj3nq"))
+	long := Evaluate([]byte("kd8fj3nqw7x"))
+	assert.Greater(t, long.Bits, short.Bits)
+}
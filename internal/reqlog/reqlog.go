@@ -0,0 +1,293 @@
+// Package reqlog logs provider requests as structured events, so users can
+// see which provider is slow or failing without capturing packets.
+//
+// Each request a chain client or price source makes is logged with its
+// provider, endpoint, status, latency and retry count. Cache lookups that
+// stand in for provider requests are logged as hits or misses. Endpoints are
+// logged without credentials: long path segments and query values, and any
+// query value whose name suggests a key or token, are replaced with "*".
+//
+// Like fault injection, logging is process-wide and off by default, and the
+// clients apply it through Wrap as they build their HTTP transports. When it
+// is disabled, Wrap returns the transport it was given.
+package reqlog
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Event messages.
+const (
+	// MsgRequest is the message of a provider request event.
+	MsgRequest = "provider request"
+
+	// MsgCache is the message of a cache lookup event.
+	MsgCache = "provider cache"
+)
+
+// Cache lookup results.
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+const (
+	// maxSegment is the longest path segment or query value logged as is.
+	// Longer ones may be API keys, addresses or transaction IDs.
+	maxSegment = 16
+
+	// maxBody is the most of a request body read to identify the request.
+	maxBody = 64 << 10
+
+	// maxFailures bounds the failing requests remembered for retry counts.
+	maxFailures = 1024
+)
+
+// logState is the process-wide logger and the failure counts behind the
+// retry count of each request.
+type logState struct {
+	logger *slog.Logger
+
+	mu sync.Mutex
+	// failures counts the consecutive failures of each request, by requestID.
+	failures map[[sha256.Size]byte]int
+}
+
+//nolint:gochecknoglobals // Process-wide logger, set once at startup
+var active atomic.Pointer[logState]
+
+// Enable logs requests made through transports wrapped after this call to
+// logger. Events are logged at debug level. A nil logger disables logging.
+func Enable(logger *slog.Logger) {
+	if logger == nil {
+		Disable()
+		return
+	}
+	active.Store(&logState{logger: logger, failures: map[[sha256.Size]byte]int{}})
+}
+
+// Disable stops logging requests.
+func Disable() {
+	active.Store(nil)
+}
+
+// Enabled reports whether requests are logged.
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// Wrap returns rt with every request logged as coming from provider, or rt
+// itself when logging is disabled. A nil rt means http.DefaultTransport.
+func Wrap(rt http.RoundTripper, provider string) http.RoundTripper {
+	if active.Load() == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &Transport{base: rt, provider: provider}
+}
+
+// Cache logs a cache lookup made in place of a provider request. Name is the
+// cache, such as "balances", and key what was looked up, such as a chain.
+func Cache(ctx context.Context, name, key string, hit bool) {
+	s := active.Load()
+	if s == nil {
+		return
+	}
+	result := CacheMiss
+	if hit {
+		result = CacheHit
+	}
+	s.logger.LogAttrs(ctx, slog.LevelDebug, MsgCache,
+		slog.String("cache", name),
+		slog.String("key", key),
+		slog.String("result", result),
+	)
+}
+
+// Transport is an http.RoundTripper that logs each request.
+type Transport struct {
+	base     http.RoundTripper
+	provider string
+}
+
+// RoundTrip implements http.RoundTripper. A request counts as failed when it
+// fails in transit or the provider answers 429 or 5xx; the retry count of a
+// request is the number of times the identical request has failed in a row.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	s := active.Load()
+	if s == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	body := peekBody(req)
+	id := requestID(t.provider, req, body)
+	retry := s.failureCount(id)
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	latency := time.Since(start)
+
+	attrs := []slog.Attr{
+		slog.String("provider", t.provider),
+		slog.String("method", req.Method),
+		slog.String("endpoint", Endpoint(req.URL)),
+	}
+	if m := rpcMethod(body); m != "" {
+		attrs = append(attrs, slog.String("rpc_method", m))
+	}
+	failed := err != nil
+	if resp != nil {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		failed = failed || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+	}
+	attrs = append(attrs,
+		slog.Int64("latency_ms", latency.Milliseconds()),
+		slog.Int("retry", retry),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", ErrorText(err)))
+	}
+
+	s.setFailed(id, failed)
+	s.logger.LogAttrs(req.Context(), slog.LevelDebug, MsgRequest, attrs...)
+	return resp, err
+}
+
+// failureCount returns how many times in a row the request has failed.
+func (s *logState) failureCount(id [sha256.Size]byte) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.failures[id]
+}
+
+// setFailed counts a failure of the request, or forgets it on success.
+func (s *logState) setFailed(id [sha256.Size]byte, failed bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !failed {
+		delete(s.failures, id)
+		return
+	}
+	if _, ok := s.failures[id]; !ok && len(s.failures) >= maxFailures {
+		s.failures = map[[sha256.Size]byte]int{}
+	}
+	s.failures[id]++
+}
+
+// Endpoint returns u as logged: scheme, host and path, with long path
+// segments and secret-looking or long query values replaced with "*".
+func Endpoint(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	segments := strings.Split(u.EscapedPath(), "/")
+	for i, seg := range segments {
+		if len(seg) > maxSegment {
+			segments[i] = "*"
+		}
+	}
+	endpoint := u.Scheme + "://" + u.Host + strings.Join(segments, "/")
+
+	query := u.Query()
+	if len(query) == 0 {
+		return endpoint
+	}
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	params := make([]string, 0, len(names))
+	for _, name := range names {
+		value := query.Get(name)
+		if len(value) > maxSegment || secretParam(name) {
+			value = "*"
+		}
+		params = append(params, url.QueryEscape(name)+"="+url.QueryEscape(value))
+	}
+	return endpoint + "?" + strings.Join(params, "&")
+}
+
+// secretParam reports whether a query parameter name suggests a credential.
+func secretParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, s := range []string{"key", "token", "secret", "auth", "password"} {
+		if strings.Contains(name, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// peekBody returns up to maxBody bytes of the request body without consuming
+// it. Requests without GetBody are not read.
+func peekBody(req *http.Request) []byte {
+	if req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	rc, err := req.GetBody()
+	if err != nil {
+		return nil
+	}
+	defer func() { _ = rc.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(rc, maxBody))
+	return body
+}
+
+// requestID identifies a request by its provider, method, full URL and body,
+// so a retry of the same request has the same ID.
+func requestID(provider string, req *http.Request, body []byte) [sha256.Size]byte {
+	h := sha256.New()
+	for _, part := range []string{provider, req.Method, req.URL.String()} {
+		_, _ = h.Write([]byte(part))
+		_, _ = h.Write([]byte{0})
+	}
+	_, _ = h.Write(body)
+	var id [sha256.Size]byte
+	copy(id[:], h.Sum(nil))
+	return id
+}
+
+// rpcMethod returns the method of a JSON-RPC request body, "batch" for a
+// batch request, or "" for any other body.
+func rpcMethod(body []byte) string {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 {
+		return ""
+	}
+	if body[0] == '[' {
+		return "batch"
+	}
+	var call struct {
+		Method string `json:"method"`
+	}
+	if err := json.Unmarshal(body, &call); err != nil {
+		return ""
+	}
+	return call.Method
+}
+
+// ErrorText returns the message of a transport error without the request
+// URL, which may carry an API key.
+func ErrorText(err error) string {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err.Error()
+	}
+	return err.Error()
+}
@@ -0,0 +1,162 @@
+package reqlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// enableBuffer enables logging to a JSON buffer for the duration of the test.
+func enableBuffer(t *testing.T) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	Enable(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(Disable)
+	return &buf
+}
+
+// events decodes the logged JSON lines.
+func events(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var out []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var e map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &e))
+		out = append(out, e)
+	}
+	return out
+}
+
+//nolint:paralleltest // Enable changes process-wide state
+func TestWrap_Disabled(t *testing.T) {
+	Disable()
+	assert.False(t, Enabled())
+	assert.Nil(t, Wrap(nil, "test"))
+	assert.Equal(t, http.DefaultTransport, Wrap(http.DefaultTransport, "test"))
+
+	Enable(nil)
+	assert.False(t, Enabled())
+	Cache(context.Background(), "balances", "bsv", true) // No logger; must not panic
+}
+
+//nolint:paralleltest // Enable changes process-wide state
+func TestTransport_LogsRequests(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	buf := enableBuffer(t)
+	client := &http.Client{Transport: Wrap(nil, "eth-rpc")}
+	body := `{"jsonrpc":"2.0","id":1,"method":"eth_gasPrice","params":[]}`
+	for range 3 {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, srv.URL+"/v3/0123456789abcdef0123456789abcdef", strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+	}
+
+	logged := events(t, buf)
+	require.Len(t, logged, 3)
+	for i, e := range logged {
+		assert.Equal(t, MsgRequest, e["msg"])
+		assert.Equal(t, "DEBUG", e["level"])
+		assert.Equal(t, "eth-rpc", e["provider"])
+		assert.Equal(t, http.MethodPost, e["method"])
+		assert.Equal(t, srv.URL+"/v3/*", e["endpoint"], "the key in the path is not logged")
+		assert.Equal(t, "eth_gasPrice", e["rpc_method"])
+		assert.InDelta(t, float64(i), e["retry"], 0, "retry counts the failures before it")
+		assert.Contains(t, e, "latency_ms")
+	}
+	assert.InDelta(t, float64(http.StatusServiceUnavailable), logged[0]["status"], 0)
+	assert.InDelta(t, float64(http.StatusOK), logged[2]["status"], 0)
+
+	// Success resets the count
+	buf.Reset()
+	resp, err := client.Post(srv.URL+"/v3/0123456789abcdef0123456789abcdef", "application/json", strings.NewReader(body)) //nolint:noctx // Test request
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.InDelta(t, 0, events(t, buf)[0]["retry"], 0)
+}
+
+//nolint:paralleltest // Enable changes process-wide state
+func TestTransport_LogsErrorsWithoutURL(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close() // Refuses connections
+
+	buf := enableBuffer(t)
+	client := &http.Client{Transport: Wrap(nil, "whatsonchain")}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/v1/bsv/main/chain/info?apikey=secret-key", nil)
+	require.NoError(t, err)
+	_, err = client.Do(req) //nolint:bodyclose // The request fails
+	require.Error(t, err)
+
+	logged := events(t, buf)
+	require.Len(t, logged, 1)
+	assert.Equal(t, srv.URL+"/v1/bsv/main/chain/info?apikey=%2A", logged[0]["endpoint"])
+	assert.NotContains(t, logged[0], "status")
+	assert.NotEmpty(t, logged[0]["error"])
+	assert.NotContains(t, buf.String(), "secret-key")
+}
+
+//nolint:paralleltest // Enable changes process-wide state
+func TestCache(t *testing.T) {
+	buf := enableBuffer(t)
+	Cache(context.Background(), "prices", "bsv/USD", true)
+	Cache(context.Background(), "balances", "eth", false)
+
+	logged := events(t, buf)
+	require.Len(t, logged, 2)
+	assert.Equal(t, MsgCache, logged[0]["msg"])
+	assert.Equal(t, "prices", logged[0]["cache"])
+	assert.Equal(t, "bsv/USD", logged[0]["key"])
+	assert.Equal(t, CacheHit, logged[0]["result"])
+	assert.Equal(t, CacheMiss, logged[1]["result"])
+}
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw, want string
+	}{
+		{"https://api.etherscan.io/v2/api?module=account&action=balance&apikey=ABC", "https://api.etherscan.io/v2/api?action=balance&apikey=%2A&module=account"},
+		{"https://api.whatsonchain.com/v1/bsv/main/address/1BoatSLRHtKNngkdXEeobR76b53LETtpyT/balance", "https://api.whatsonchain.com/v1/bsv/main/address/*/balance"},
+		{"https://mainnet.infura.io/v3/0123456789abcdef0123456789abcdef", "https://mainnet.infura.io/v3/*"},
+		{"http://localhost:8545", "http://localhost:8545"},
+	}
+	for _, tc := range tests {
+		u, err := url.Parse(tc.raw)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, Endpoint(u), tc.raw)
+	}
+	assert.Empty(t, Endpoint(nil))
+}
+
+func TestRPCMethod(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "eth_call", rpcMethod([]byte(` {"method":"eth_call"}`)))
+	assert.Equal(t, "batch", rpcMethod([]byte(`[{"method":"eth_call"}]`)))
+	assert.Empty(t, rpcMethod([]byte("module=account")))
+	assert.Empty(t, rpcMethod(nil))
+}
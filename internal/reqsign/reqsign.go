@@ -0,0 +1,255 @@
+// Package reqsign identifies sigil to the provider APIs it calls. It sets a
+// configured User-Agent on every provider request and can sign requests with
+// HMAC-SHA256, so a self-hosted gateway in front of the providers can
+// authenticate which sigil instance made each call.
+//
+// Like fault injection, it is configured once at startup and applied by the
+// chain clients through Wrap as they build their HTTP transports.
+package reqsign
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Request headers added to signed requests.
+const (
+	// HeaderKeyID names the signing key, so a gateway can tell instances apart.
+	HeaderKeyID = "X-Sigil-Key-Id"
+
+	// HeaderTimestamp is the signing time in Unix seconds.
+	HeaderTimestamp = "X-Sigil-Timestamp"
+
+	// HeaderNonce is a random value unique to the request, for replay checks.
+	HeaderNonce = "X-Sigil-Nonce"
+
+	// HeaderContentSHA256 is the hex SHA-256 of the request body.
+	HeaderContentSHA256 = "X-Sigil-Content-Sha256"
+
+	// HeaderSignature is the hex HMAC-SHA256 of the canonical request.
+	HeaderSignature = "X-Sigil-Signature"
+)
+
+// Version is the first line of the canonical request and names the format.
+const Version = "sigil-hmac-v1"
+
+// ErrNoSecret indicates a signing key ID without a secret, or the reverse.
+var ErrNoSecret = errors.New("request signing needs both a key ID and a secret")
+
+// Config identifies sigil to providers.
+type Config struct {
+	// UserAgent replaces the User-Agent header of every provider request.
+	// Empty keeps each client's own.
+	UserAgent string
+
+	// KeyID and Secret sign every request to a signed host. Both empty
+	// disables signing.
+	KeyID  string
+	Secret []byte
+
+	// Hosts limits signing to these hosts. An entry "*.example.com" matches
+	// any subdomain. Empty signs requests to every host.
+	Hosts []string
+
+	// Now returns the signing time. Nil uses time.Now.
+	Now func() time.Time
+}
+
+// signs reports whether the configuration signs requests.
+func (c *Config) signs() bool {
+	return c.KeyID != "" && len(c.Secret) > 0
+}
+
+// signsHost reports whether requests to host are signed.
+func (c *Config) signsHost(host string) bool {
+	if !c.signs() {
+		return false
+	}
+	if len(c.Hosts) == 0 {
+		return true
+	}
+	host = strings.ToLower(host)
+	for _, h := range c.Hosts {
+		h = strings.ToLower(strings.TrimSpace(h))
+		if h == host || (strings.HasPrefix(h, "*.") && strings.HasSuffix(host, h[1:])) {
+			return true
+		}
+	}
+	return false
+}
+
+//nolint:gochecknoglobals // Process-wide configuration, set once at startup
+var active atomic.Pointer[Config]
+
+// Enable applies cfg to transports wrapped after this call. A configuration
+// that neither sets a User-Agent nor signs disables the package.
+func Enable(cfg *Config) error {
+	if (cfg.KeyID == "") != (len(cfg.Secret) == 0) {
+		return ErrNoSecret
+	}
+	if cfg.UserAgent == "" && !cfg.signs() {
+		Disable()
+		return nil
+	}
+	c := *cfg
+	if c.Now == nil {
+		c.Now = time.Now
+	}
+	active.Store(&c)
+	return nil
+}
+
+// Disable stops identifying requests in transports wrapped after this call.
+func Disable() {
+	active.Store(nil)
+}
+
+// Enabled reports whether requests are identified.
+func Enabled() bool {
+	return active.Load() != nil
+}
+
+// Wrap returns rt wrapped to set the User-Agent and sign requests when the
+// package is enabled, or rt itself otherwise. A nil rt means
+// http.DefaultTransport.
+func Wrap(rt http.RoundTripper) http.RoundTripper {
+	cfg := active.Load()
+	if cfg == nil {
+		return rt
+	}
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &Transport{base: rt, cfg: cfg}
+}
+
+// Transport is an http.RoundTripper that sets the User-Agent and signature
+// headers of each request.
+type Transport struct {
+	base http.RoundTripper
+	cfg  *Config
+}
+
+// RoundTrip implements http.RoundTripper. The request is cloned, so the
+// caller's request is left untouched.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	out := req.Clone(req.Context())
+	if t.cfg.UserAgent != "" {
+		out.Header.Set("User-Agent", t.cfg.UserAgent)
+	}
+	if t.cfg.signsHost(out.URL.Hostname()) {
+		if err := t.sign(out); err != nil {
+			closeBody(req)
+			return nil, err
+		}
+	}
+	return t.base.RoundTrip(out)
+}
+
+// sign adds the signature headers to req, reading its body to hash it.
+func (t *Transport) sign(req *http.Request) error {
+	body, err := readBody(req)
+	if err != nil {
+		return fmt.Errorf("signing request: %w", err)
+	}
+
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("signing request: %w", err)
+	}
+
+	sum := sha256.Sum256(body)
+	r := &Request{
+		Method:        req.Method,
+		Host:          req.URL.Host,
+		Path:          req.URL.EscapedPath(),
+		Query:         req.URL.RawQuery,
+		Timestamp:     strconv.FormatInt(t.cfg.Now().Unix(), 10),
+		Nonce:         hex.EncodeToString(nonce),
+		ContentSHA256: hex.EncodeToString(sum[:]),
+	}
+	req.Header.Set(HeaderKeyID, t.cfg.KeyID)
+	req.Header.Set(HeaderTimestamp, r.Timestamp)
+	req.Header.Set(HeaderNonce, r.Nonce)
+	req.Header.Set(HeaderContentSHA256, r.ContentSHA256)
+	req.Header.Set(HeaderSignature, r.Sign(t.cfg.Secret))
+	return nil
+}
+
+// readBody returns the request body and leaves req able to send it again.
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	if req.GetBody != nil {
+		rc, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = rc.Close() }()
+		return io.ReadAll(rc)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return body, nil
+}
+
+// closeBody closes the request body, as a RoundTripper must even on error.
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
+
+// Request holds the signed parts of a request. A gateway rebuilds it from the
+// received request and headers and compares Sign with HeaderSignature.
+type Request struct {
+	Method        string
+	Host          string // host[:port] as sent
+	Path          string // escaped path
+	Query         string // raw query, without "?"
+	Timestamp     string
+	Nonce         string
+	ContentSHA256 string
+}
+
+// Canonical returns the signed string: Version, then each field on its own
+// line in declaration order.
+func (r *Request) Canonical() string {
+	return strings.Join([]string{
+		Version,
+		strings.ToUpper(r.Method),
+		strings.ToLower(r.Host),
+		r.Path,
+		r.Query,
+		r.Timestamp,
+		r.Nonce,
+		r.ContentSHA256,
+	}, "\n")
+}
+
+// Sign returns the hex HMAC-SHA256 of the canonical request under secret.
+func (r *Request) Sign(secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(r.Canonical()))
+	return hex.EncodeToString(mac.Sum(nil))
+}
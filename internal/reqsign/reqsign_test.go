@@ -0,0 +1,142 @@
+package reqsign
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// captureServer records the headers and body of the last request it received.
+func captureServer(t *testing.T) (*httptest.Server, *http.Request, *[]byte) {
+	t.Helper()
+	var got http.Request
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = *r.Clone(r.Context())
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &got, &body
+}
+
+//nolint:paralleltest // Enable changes process-wide state
+func TestWrap_Disabled(t *testing.T) {
+	Disable()
+	require.NoError(t, Enable(&Config{}))
+	assert.False(t, Enabled())
+	assert.Nil(t, Wrap(nil))
+	assert.Equal(t, http.DefaultTransport, Wrap(http.DefaultTransport))
+}
+
+//nolint:paralleltest // Enable changes process-wide state
+func TestEnable_NeedsKeyAndSecret(t *testing.T) {
+	t.Cleanup(Disable)
+	require.ErrorIs(t, Enable(&Config{KeyID: "ops-1"}), ErrNoSecret)
+	require.ErrorIs(t, Enable(&Config{Secret: []byte("s")}), ErrNoSecret)
+}
+
+//nolint:paralleltest // Enable changes process-wide state
+func TestTransport_SignsRequest(t *testing.T) {
+	t.Cleanup(Disable)
+	srv, got, gotBody := captureServer(t)
+
+	secret := []byte("gateway-secret")
+	now := time.Unix(1_792_000_000, 0)
+	require.NoError(t, Enable(&Config{
+		UserAgent: "sigil/ops-eu-1",
+		KeyID:     "ops-eu-1",
+		Secret:    secret,
+		Now:       func() time.Time { return now },
+	}))
+	client := &http.Client{Transport: Wrap(nil)}
+
+	payload := []byte(`{"jsonrpc":"2.0","method":"eth_blockNumber"}`)
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPost, srv.URL+"/v1/rpc?chain=1", bytes.NewReader(payload))
+	require.NoError(t, err)
+	req.Header.Set("User-Agent", "go-client")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, payload, *gotBody, "body is still sent after hashing")
+	assert.Equal(t, "go-client", req.Header.Get("User-Agent"), "caller's request is not modified")
+	assert.Empty(t, req.Header.Get(HeaderSignature))
+
+	h := got.Header
+	assert.Equal(t, "sigil/ops-eu-1", h.Get("User-Agent"))
+	assert.Equal(t, "ops-eu-1", h.Get(HeaderKeyID))
+	assert.Equal(t, "1792000000", h.Get(HeaderTimestamp))
+	assert.Len(t, h.Get(HeaderNonce), 32)
+	sum := sha256.Sum256(payload)
+	assert.Equal(t, hex.EncodeToString(sum[:]), h.Get(HeaderContentSHA256))
+
+	// A gateway rebuilds the request from what it received
+	rebuilt := &Request{
+		Method:        got.Method,
+		Host:          got.Host,
+		Path:          got.URL.EscapedPath(),
+		Query:         got.URL.RawQuery,
+		Timestamp:     h.Get(HeaderTimestamp),
+		Nonce:         h.Get(HeaderNonce),
+		ContentSHA256: h.Get(HeaderContentSHA256),
+	}
+	assert.Equal(t, rebuilt.Sign(secret), h.Get(HeaderSignature))
+	assert.NotEqual(t, rebuilt.Sign([]byte("other")), h.Get(HeaderSignature))
+}
+
+//nolint:paralleltest // Enable changes process-wide state
+func TestTransport_Hosts(t *testing.T) {
+	t.Cleanup(Disable)
+	srv, got, _ := captureServer(t)
+
+	require.NoError(t, Enable(&Config{
+		UserAgent: "sigil/test",
+		KeyID:     "k",
+		Secret:    []byte("s"),
+		Hosts:     []string{"gateway.example.com"},
+	}))
+	client := &http.Client{Transport: Wrap(nil)}
+	resp, err := client.Get(srv.URL) //nolint:noctx // test request
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	assert.Equal(t, "sigil/test", got.Header.Get("User-Agent"), "the User-Agent applies to every host")
+	assert.Empty(t, got.Header.Get(HeaderSignature), "hosts outside the list are not signed")
+}
+
+func TestConfig_SignsHost(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{KeyID: "k", Secret: []byte("s"), Hosts: []string{"gw.example.com", "*.internal.example"}}
+	assert.True(t, cfg.signsHost("GW.example.com"))
+	assert.True(t, cfg.signsHost("eth.internal.example"))
+	assert.False(t, cfg.signsHost("internal.example"))
+	assert.False(t, cfg.signsHost("api.etherscan.io"))
+
+	assert.True(t, (&Config{KeyID: "k", Secret: []byte("s")}).signsHost("api.etherscan.io"))
+	assert.False(t, (&Config{UserAgent: "ua"}).signsHost("gw.example.com"))
+}
+
+func TestRequest_Canonical(t *testing.T) {
+	t.Parallel()
+
+	r := &Request{
+		Method: "get", Host: "GW.example.com:8443", Path: "/v1/tx/abc", Query: "a=1",
+		Timestamp: "1792000000", Nonce: "00ff", ContentSHA256: "e3b0",
+	}
+	want := strings.Join([]string{
+		"sigil-hmac-v1", "GET", "gw.example.com:8443", "/v1/tx/abc", "a=1", "1792000000", "00ff", "e3b0",
+	}, "\n")
+	assert.Equal(t, want, r.Canonical())
+	assert.Equal(t, "3924bb53e7a21291d2a9790d2ee60814884a2727d3c326fbd6d36c94d5a54587", r.Sign([]byte("gateway-secret")))
+}
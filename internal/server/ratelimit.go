@@ -0,0 +1,92 @@
+package server
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// maxLimiterKeys caps the keys a keyLimiter tracks, so clients cannot grow
+// its memory without bound by presenting new keys.
+const maxLimiterKeys = 4096
+
+// keyLimiter rate-limits requests by key, such as an agent ID or a client
+// address, with a token bucket per key.
+type keyLimiter struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	maxKeys int
+	buckets map[string]*keyBucket
+	now     func() time.Time
+}
+
+// keyBucket is the token bucket of one key and when it was last used.
+type keyBucket struct {
+	limiter *rate.Limiter
+	used    time.Time
+}
+
+// newKeyLimiter returns a limiter allowing perMinute requests a minute per
+// key, in bursts of up to the same number.
+func newKeyLimiter(perMinute int) *keyLimiter {
+	return &keyLimiter{
+		limit:   rate.Limit(float64(perMinute) / 60),
+		burst:   perMinute,
+		maxKeys: maxLimiterKeys,
+		buckets: make(map[string]*keyBucket),
+		now:     time.Now,
+	}
+}
+
+// allow takes a token from key's bucket, reporting false when it is empty.
+func (l *keyLimiter) allow(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	b := l.bucket(key, now)
+	b.used = now
+	return b.limiter.AllowN(now, 1)
+}
+
+// blocked reports whether key's bucket is empty, without taking a token.
+func (l *keyLimiter) blocked(key string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.buckets[key]
+	return ok && b.limiter.TokensAt(l.now()) < 1
+}
+
+// bucket returns key's bucket, adding one when key is new. The caller
+// holds l.mu.
+func (l *keyLimiter) bucket(key string, now time.Time) *keyBucket {
+	if b, ok := l.buckets[key]; ok {
+		return b
+	}
+	if len(l.buckets) >= l.maxKeys {
+		l.evict(now)
+	}
+	b := &keyBucket{limiter: rate.NewLimiter(l.limit, l.burst), used: now}
+	l.buckets[key] = b
+	return b
+}
+
+// evict makes room for a new key. Full buckets are dropped first, since a
+// new bucket behaves the same; if none is full, the least recently used
+// bucket goes. The caller holds l.mu.
+func (l *keyLimiter) evict(now time.Time) {
+	var oldest string
+	for key, b := range l.buckets {
+		if b.limiter.TokensAt(now) >= float64(l.burst) {
+			delete(l.buckets, key)
+			continue
+		}
+		if oldest == "" || b.used.Before(l.buckets[oldest].used) {
+			oldest = key
+		}
+	}
+	if len(l.buckets) >= l.maxKeys {
+		delete(l.buckets, oldest)
+	}
+}
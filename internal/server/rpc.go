@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// JSON-RPC 2.0 error codes. Errors from sigil itself use rpcCodeAuth when
+// authentication failed and rpcCodeServer otherwise, with the CLI's error
+// details as data.
+const (
+	rpcCodeParse          = -32700
+	rpcCodeInvalidRequest = -32600
+	rpcCodeMethodNotFound = -32601
+	rpcCodeInvalidParams  = -32602
+	rpcCodeServer         = -32000
+	rpcCodeAuth           = -32001
+)
+
+// rpcRequest is a JSON-RPC 2.0 request.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	ID      json.RawMessage `json:"id,omitempty"`
+}
+
+// rpcResponse is a JSON-RPC 2.0 response.
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+	ID      json.RawMessage `json:"id"`
+}
+
+// rpcError is the error of a JSON-RPC 2.0 response.
+type rpcError struct {
+	Code    int                 `json:"code"`
+	Message string              `json:"message"`
+	Data    *output.ErrorDetail `json:"data,omitempty"`
+}
+
+// handleRPC serves a JSON-RPC 2.0 call. Batches are not supported. A
+// notification, a request without an id, is answered all the same, since
+// a send's result should never be dropped.
+func (s *Server) handleRPC(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+	if err != nil {
+		writeRPC(w, nil, nil, &rpcError{Code: rpcCodeInvalidRequest, Message: "reading request body: " + err.Error()})
+		return
+	}
+
+	var req rpcRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		writeRPC(w, nil, nil, &rpcError{Code: rpcCodeParse, Message: "parse error: " + err.Error()})
+		return
+	}
+	id := req.ID
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		writeRPC(w, id, nil, &rpcError{Code: rpcCodeInvalidRequest, Message: `invalid request: "jsonrpc" must be "2.0" and "method" is required`})
+		return
+	}
+
+	result, err := s.authorizedCall(r, req.Method, req.Params)
+	if err != nil {
+		status := httpStatus(err)
+		s.logf(r, req.Method, status)
+		if status == http.StatusInternalServerError && s.cfg.Logger != nil {
+			s.cfg.Logger.Error("serve: %s: %v", req.Method, err)
+		}
+		writeRPC(w, id, nil, newRPCError(err))
+		return
+	}
+	s.logf(r, req.Method, http.StatusOK)
+	writeRPC(w, id, result, nil)
+}
+
+// newRPCError maps an error to a JSON-RPC error.
+func newRPCError(err error) *rpcError {
+	if errors.Is(err, errMethodNotFound) {
+		return &rpcError{Code: rpcCodeMethodNotFound, Message: err.Error()}
+	}
+	detail := output.NewErrorDetail(err)
+	code := rpcCodeServer
+	switch detail.ExitCode {
+	case sigilerr.ExitAuth:
+		code = rpcCodeAuth
+	case sigilerr.ExitInput:
+		code = rpcCodeInvalidParams
+	}
+	return &rpcError{Code: code, Message: detail.Message, Data: &detail}
+}
+
+// writeRPC writes a JSON-RPC response. Errors are reported in the body, so
+// the HTTP status is always 200.
+func writeRPC(w http.ResponseWriter, id json.RawMessage, result any, rpcErr *rpcError) {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	writeResult(w, http.StatusOK, rpcResponse{JSONRPC: "2.0", Result: result, Error: rpcErr, ID: id})
+}
@@ -0,0 +1,356 @@
+// Package server exposes a wallet's operations over a local HTTP API for
+// "sigil serve", so other tools can check balances and send without
+// shelling out to the CLI.
+//
+// Every request but the health check authenticates with an agent token
+// sent as a bearer token, so a client can do only what the agent's policy
+// allows. The same operations are served as REST routes under /v1 and as
+// JSON-RPC 2.0 methods at /v1/rpc.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/output"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Methods served by the API. The REST routes map onto them.
+const (
+	MethodHealth    = "health"
+	MethodBalance   = "balance"
+	MethodAddresses = "addresses"
+	MethodTxBuild   = "tx.build"
+	MethodTxSend    = "tx.send"
+)
+
+// maxBodyBytes caps the size of a request body.
+const maxBodyBytes = 1 << 20
+
+// authFailureLimit is how many failed authentications a minute each client
+// address may make, in bursts of up to the same number. Every attempt costs
+// a decryption, so it applies even when RateLimit is zero.
+const authFailureLimit = 10
+
+// Session is an authenticated client. Seed is the wallet seed decrypted
+// with the client's agent token; Close zeroes it.
+type Session struct {
+	Credential *agent.Credential
+	Token      string
+	Seed       []byte
+}
+
+// Close zeroes the session's seed.
+func (s *Session) Close() {
+	wallet.ZeroBytes(s.Seed)
+	s.Seed = nil
+}
+
+// Authenticator checks an agent token and opens a session for it.
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*Session, error)
+}
+
+// ChainParams selects the chain of a balance or addresses call; empty
+// selects every chain the agent may use.
+type ChainParams struct {
+	Chain string `json:"chain,omitempty"`
+}
+
+// SendParams describes a transaction to build or send.
+type SendParams struct {
+	Chain      string `json:"chain"`
+	To         string `json:"to"`
+	Amount     string `json:"amount"`
+	Token      string `json:"token,omitempty"`        // ERC-20 symbol or contract (ETH only)
+	Gas        string `json:"gas,omitempty"`          // slow, medium, or fast (ETH only)
+	MaxFeeRate uint64 `json:"max_fee_rate,omitempty"` // sat/KB cap (UTXO chains only)
+}
+
+// Backend performs the wallet operations of authenticated sessions.
+type Backend interface {
+	Balances(ctx context.Context, s *Session, p *ChainParams) (any, error)
+	Addresses(ctx context.Context, s *Session, p *ChainParams) (any, error)
+	Build(ctx context.Context, s *Session, p *SendParams) (any, error)
+	Send(ctx context.Context, s *Session, p *SendParams) (any, error)
+}
+
+// Logger records requests.
+type Logger interface {
+	Debug(format string, args ...any)
+	Error(format string, args ...any)
+}
+
+// Config configures a Server.
+type Config struct {
+	Wallet  string // Name of the served wallet, reported by the health check
+	Version string // Sigil version, reported by the health check
+	Auth    Authenticator
+	Backend Backend
+	Logger  Logger // Optional
+
+	// RateLimit is the most requests a minute each agent may make, in
+	// bursts of up to the same number. Zero disables the limit.
+	RateLimit int
+}
+
+// Server routes API requests to its backend.
+type Server struct {
+	cfg      Config
+	mux      *http.ServeMux
+	limiter  *keyLimiter // by agent ID; nil when requests are not limited
+	failures *keyLimiter // failed authentications by client address
+}
+
+// New returns a server for cfg.
+func New(cfg *Config) *Server {
+	s := &Server{cfg: *cfg, mux: http.NewServeMux(), failures: newKeyLimiter(authFailureLimit)}
+	if cfg.RateLimit > 0 {
+		s.limiter = newKeyLimiter(cfg.RateLimit)
+	}
+	s.mux.HandleFunc("GET /v1/health", s.handleHealth)
+	s.mux.HandleFunc("GET /v1/balance", s.handleREST(MethodBalance))
+	s.mux.HandleFunc("GET /v1/addresses", s.handleREST(MethodAddresses))
+	s.mux.HandleFunc("POST /v1/tx/build", s.handleREST(MethodTxBuild))
+	s.mux.HandleFunc("POST /v1/tx/send", s.handleREST(MethodTxSend))
+	s.mux.HandleFunc("POST /v1/rpc", s.handleRPC)
+	return s
+}
+
+// Handler returns the HTTP handler of the API.
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Cache-Control", "no-store")
+		s.mux.ServeHTTP(w, r)
+	})
+}
+
+// health is the result of the health method.
+type health struct {
+	Status  string `json:"status"`
+	Wallet  string `json:"wallet"`
+	Version string `json:"version,omitempty"`
+	Time    string `json:"time"`
+}
+
+func (s *Server) health() health {
+	return health{Status: "ok", Wallet: s.cfg.Wallet, Version: s.cfg.Version, Time: time.Now().UTC().Format(time.RFC3339)}
+}
+
+func (s *Server) handleHealth(w http.ResponseWriter, _ *http.Request) {
+	writeResult(w, http.StatusOK, s.health())
+}
+
+// handleREST serves a REST route: GET routes take their parameters from
+// the query string, POST routes from a JSON body.
+func (s *Server) handleREST(method string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params json.RawMessage
+		if r.Method == http.MethodGet {
+			raw, err := json.Marshal(ChainParams{Chain: r.URL.Query().Get("chain")})
+			if err != nil {
+				s.writeError(w, r, method, err)
+				return
+			}
+			params = raw
+		} else {
+			raw, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodyBytes))
+			if err != nil {
+				s.writeError(w, r, method, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("reading request body: %v", err)))
+				return
+			}
+			params = raw
+		}
+
+		result, err := s.authorizedCall(r, method, params)
+		if err != nil {
+			s.writeError(w, r, method, err)
+			return
+		}
+		s.logf(r, method, http.StatusOK)
+		writeResult(w, http.StatusOK, result)
+	}
+}
+
+// authorizedCall authenticates the request and calls method.
+func (s *Server) authorizedCall(r *http.Request, method string, params json.RawMessage) (any, error) {
+	if method == MethodHealth {
+		return s.health(), nil
+	}
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrAgentTokenInvalid,
+			"send an agent token in the Authorization header: Authorization: Bearer sigil_agt_...",
+		)
+	}
+	// A client that keeps presenting bad tokens is refused before the
+	// costly decryption of another session's seed
+	addr := clientAddr(r)
+	if s.failures.blocked(addr) {
+		return nil, rateLimited(
+			fmt.Sprintf("too many failed authentications from %s; retry in %d seconds", addr, retryAfter(authFailureLimit)),
+			authFailureLimit)
+	}
+	sess, err := s.cfg.Auth.Authenticate(r.Context(), token)
+	if err != nil {
+		if sigilerr.ExitCode(err) == sigilerr.ExitAuth {
+			s.failures.allow(addr)
+		}
+		return nil, err
+	}
+	defer sess.Close()
+
+	// Only authenticated agents get a bucket, so made-up tokens cannot
+	// create them
+	if s.limiter != nil && !s.limiter.allow(sess.Credential.ID) {
+		return nil, rateLimited(
+			fmt.Sprintf("this agent may make %d requests a minute; retry in %d seconds", s.cfg.RateLimit, retryAfter(s.cfg.RateLimit)),
+			s.cfg.RateLimit)
+	}
+	return s.call(r.Context(), sess, method, params)
+}
+
+// call dispatches method to the backend.
+func (s *Server) call(ctx context.Context, sess *Session, method string, params json.RawMessage) (any, error) {
+	switch method {
+	case MethodBalance, MethodAddresses:
+		var p ChainParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if method == MethodBalance {
+			return s.cfg.Backend.Balances(ctx, sess, &p)
+		}
+		return s.cfg.Backend.Addresses(ctx, sess, &p)
+	case MethodTxBuild, MethodTxSend:
+		var p SendParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		if p.Chain == "" || p.To == "" || p.Amount == "" {
+			return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "chain, to, and amount are required")
+		}
+		if method == MethodTxBuild {
+			return s.cfg.Backend.Build(ctx, sess, &p)
+		}
+		return s.cfg.Backend.Send(ctx, sess, &p)
+	default:
+		return nil, fmt.Errorf("%w: %s", errMethodNotFound, method)
+	}
+}
+
+// decodeParams decodes JSON params, rejecting unknown fields. Empty params
+// decode to the zero value.
+func decodeParams(params json.RawMessage, v any) error {
+	if len(strings.TrimSpace(string(params))) == 0 || string(params) == "null" {
+		return nil
+	}
+	dec := json.NewDecoder(strings.NewReader(string(params)))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("invalid params: %v", err))
+	}
+	return nil
+}
+
+// bearerToken returns the token of an "Authorization: Bearer" header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	token = strings.TrimSpace(token)
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+	return token, true
+}
+
+// errMethodNotFound is returned for an unknown JSON-RPC method.
+var errMethodNotFound = errors.New("method not found")
+
+// retryAfter returns the seconds until a client limited to perMinute
+// requests a minute may make another.
+func retryAfter(perMinute int) int {
+	return (60 + perMinute - 1) / perMinute
+}
+
+// rateLimited returns ErrAgentRateLimited with suggestion, recording the
+// Retry-After seconds for a limit of perMinute requests a minute.
+func rateLimited(suggestion string, perMinute int) error {
+	return sigilerr.WithSuggestion(
+		sigilerr.WithDetails(sigilerr.ErrAgentRateLimited, map[string]string{"retry_after": strconv.Itoa(retryAfter(perMinute))}),
+		suggestion,
+	)
+}
+
+// clientAddr returns the IP address of the client of r.
+func clientAddr(r *http.Request) string {
+	if addrPort, err := netip.ParseAddrPort(r.RemoteAddr); err == nil {
+		return addrPort.Addr().Unmap().String()
+	}
+	return r.RemoteAddr
+}
+
+// httpStatus maps an error to the HTTP status of its response.
+func httpStatus(err error) int {
+	if errors.Is(err, errMethodNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, sigilerr.ErrAgentRateLimited) {
+		return http.StatusTooManyRequests
+	}
+	switch sigilerr.ExitCode(err) {
+	case sigilerr.ExitInput:
+		return http.StatusBadRequest
+	case sigilerr.ExitAuth:
+		return http.StatusUnauthorized
+	case sigilerr.ExitNotFound:
+		return http.StatusNotFound
+	case sigilerr.ExitPermission:
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// writeError writes err in the CLI's JSON error format.
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, method string, err error) {
+	status := httpStatus(err)
+	s.logf(r, method, status)
+	if status == http.StatusInternalServerError && s.cfg.Logger != nil {
+		s.cfg.Logger.Error("serve: %s: %v", method, err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	var se *sigilerr.SigilError
+	if status == http.StatusTooManyRequests && errors.As(err, &se) && se.Details["retry_after"] != "" {
+		w.Header().Set("Retry-After", se.Details["retry_after"])
+	}
+	w.WriteHeader(status)
+	_ = output.FormatError(w, err, output.FormatJSON)
+}
+
+// writeResult writes v as indented JSON.
+func writeResult(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+// logf records a served request.
+func (s *Server) logf(r *http.Request, method string, status int) {
+	if s.cfg.Logger != nil {
+		s.cfg.Logger.Debug("serve: %s %s (%s) -> %d", r.Method, r.URL.Path, method, status)
+	}
+}
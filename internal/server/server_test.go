@@ -0,0 +1,315 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/agent"
+	"github.com/mrz1836/sigil/internal/output"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const testToken = "sigil_agt_test"
+
+type fakeAuth struct {
+	sessions []*Session
+}
+
+func (a *fakeAuth) Authenticate(_ context.Context, token string) (*Session, error) {
+	if token != testToken {
+		return nil, sigilerr.ErrAgentTokenInvalid
+	}
+	s := &Session{Credential: &agent.Credential{ID: "agt_1"}, Token: token, Seed: []byte{1, 2, 3}}
+	a.sessions = append(a.sessions, s)
+	return s, nil
+}
+
+type fakeBackend struct {
+	sent *SendParams
+}
+
+func (b *fakeBackend) Balances(_ context.Context, s *Session, p *ChainParams) (any, error) {
+	return map[string]string{"agent": s.Credential.ID, "chain": p.Chain}, nil
+}
+
+func (b *fakeBackend) Addresses(_ context.Context, _ *Session, p *ChainParams) (any, error) {
+	if p.Chain == "btc" {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, "agent is not authorized for chain btc")
+	}
+	return []string{"1abc"}, nil
+}
+
+func (b *fakeBackend) Build(_ context.Context, _ *Session, p *SendParams) (any, error) {
+	return map[string]string{"to": p.To}, nil
+}
+
+func (b *fakeBackend) Send(_ context.Context, _ *Session, p *SendParams) (any, error) {
+	b.sent = p
+	return map[string]string{"hash": "abc123"}, nil
+}
+
+func newTestServer() (*httptest.Server, *fakeAuth, *fakeBackend) {
+	auth, backend := &fakeAuth{}, &fakeBackend{}
+	srv := New(&Config{Wallet: "main", Version: "1.2.3", Auth: auth, Backend: backend})
+	return httptest.NewServer(srv.Handler()), auth, backend
+}
+
+func doRequest(t *testing.T, ts *httptest.Server, method, path, token, body string) (*http.Response, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(context.Background(), method, ts.URL+path, strings.NewReader(body))
+	require.NoError(t, err)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := ts.Client().Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+
+	var decoded map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	return resp, decoded
+}
+
+func TestServer_Health(t *testing.T) {
+	t.Parallel()
+	ts, _, _ := newTestServer()
+	defer ts.Close()
+
+	resp, body := doRequest(t, ts, http.MethodGet, "/v1/health", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "no-store", resp.Header.Get("Cache-Control"))
+	assert.Equal(t, "ok", body["status"])
+	assert.Equal(t, "main", body["wallet"])
+	assert.Equal(t, "1.2.3", body["version"])
+}
+
+func TestServer_REST(t *testing.T) {
+	t.Parallel()
+	ts, auth, backend := newTestServer()
+	defer ts.Close()
+
+	t.Run("missing token", func(t *testing.T) {
+		resp, body := doRequest(t, ts, http.MethodGet, "/v1/balance", "", "")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Contains(t, body, "error")
+	})
+
+	t.Run("wrong token", func(t *testing.T) {
+		resp, _ := doRequest(t, ts, http.MethodGet, "/v1/balance", "sigil_agt_wrong", "")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("balance", func(t *testing.T) {
+		resp, body := doRequest(t, ts, http.MethodGet, "/v1/balance?chain=bsv", testToken, "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, map[string]any{"agent": "agt_1", "chain": "bsv"}, body)
+	})
+
+	t.Run("backend error", func(t *testing.T) {
+		resp, body := doRequest(t, ts, http.MethodGet, "/v1/addresses?chain=btc", testToken, "")
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		errBody, ok := body["error"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "agent is not authorized for chain btc", errBody["suggestion"])
+	})
+
+	t.Run("send", func(t *testing.T) {
+		resp, body := doRequest(t, ts, http.MethodPost, "/v1/tx/send", testToken,
+			`{"chain":"bsv","to":"1dest","amount":"0.001","max_fee_rate":500}`)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "abc123", body["hash"])
+		require.NotNil(t, backend.sent)
+		assert.Equal(t, SendParams{Chain: "bsv", To: "1dest", Amount: "0.001", MaxFeeRate: 500}, *backend.sent)
+	})
+
+	t.Run("send rejects missing and unknown fields", func(t *testing.T) {
+		resp, _ := doRequest(t, ts, http.MethodPost, "/v1/tx/send", testToken, `{"chain":"bsv","to":"1dest"}`)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		resp, _ = doRequest(t, ts, http.MethodPost, "/v1/tx/build", testToken, `{"chain":"bsv","to":"1dest","amount":"1","fee":9}`)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+
+	t.Run("sessions are closed", func(t *testing.T) {
+		require.NotEmpty(t, auth.sessions)
+		for _, s := range auth.sessions {
+			assert.Nil(t, s.Seed)
+		}
+	})
+}
+
+func TestServer_RPC(t *testing.T) {
+	t.Parallel()
+	ts, _, _ := newTestServer()
+	defer ts.Close()
+
+	rpcError := func(body map[string]any) map[string]any {
+		t.Helper()
+		e, ok := body["error"].(map[string]any)
+		require.True(t, ok, "expected an error response: %v", body)
+		return e
+	}
+
+	t.Run("call", func(t *testing.T) {
+		resp, body := doRequest(t, ts, http.MethodPost, "/v1/rpc", testToken,
+			`{"jsonrpc":"2.0","id":7,"method":"tx.build","params":{"chain":"eth","to":"0xabc","amount":"1"}}`)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.InDelta(t, 7, body["id"], 0)
+		assert.Equal(t, map[string]any{"to": "0xabc"}, body["result"])
+		assert.NotContains(t, body, "error")
+	})
+
+	t.Run("health needs no token", func(t *testing.T) {
+		_, body := doRequest(t, ts, http.MethodPost, "/v1/rpc", "", `{"jsonrpc":"2.0","id":"h","method":"health"}`)
+		result, ok := body["result"].(map[string]any)
+		require.True(t, ok)
+		assert.Equal(t, "ok", result["status"])
+	})
+
+	tests := []struct {
+		name  string
+		token string
+		body  string
+		code  float64
+	}{
+		{"parse error", testToken, `{`, rpcCodeParse},
+		{"invalid request", testToken, `{"id":1,"method":"balance"}`, rpcCodeInvalidRequest},
+		{"unknown method", testToken, `{"jsonrpc":"2.0","id":1,"method":"wallet.delete"}`, rpcCodeMethodNotFound},
+		{"invalid params", testToken, `{"jsonrpc":"2.0","id":1,"method":"tx.send","params":{"chain":"bsv"}}`, rpcCodeInvalidParams},
+		{"auth", "", `{"jsonrpc":"2.0","id":1,"method":"balance"}`, rpcCodeAuth},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, body := doRequest(t, ts, http.MethodPost, "/v1/rpc", tc.token, tc.body)
+			assert.InDelta(t, tc.code, rpcError(body)["code"], 0)
+		})
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	t.Parallel()
+	auth := &fakeAuth{}
+	srv := New(&Config{Wallet: "main", Auth: auth, Backend: &fakeBackend{}, RateLimit: 2})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	for range 2 {
+		resp, _ := doRequest(t, ts, http.MethodGet, "/v1/balance", testToken, "")
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+	resp, body := doRequest(t, ts, http.MethodGet, "/v1/balance", testToken, "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+	errBody, ok := body["error"].(map[string]any)
+	require.True(t, ok)
+	assert.Equal(t, "AGENT_RATE_LIMITED", errBody["code"])
+
+	// The agent is limited once its token is authenticated
+	assert.Len(t, auth.sessions, 3)
+
+	// Bad tokens and the health check have their own allowance
+	resp, _ = doRequest(t, ts, http.MethodGet, "/v1/balance", "sigil_agt_other", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	resp, _ = doRequest(t, ts, http.MethodGet, "/v1/health", "", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, body = doRequest(t, ts, http.MethodPost, "/v1/rpc", testToken, `{"jsonrpc":"2.0","method":"balance","id":1}`)
+	rpcErr, ok := body["error"].(map[string]any)
+	require.True(t, ok)
+	assert.InDelta(t, rpcCodeServer, rpcErr["code"], 0)
+}
+
+func TestServer_AuthFailureLimit(t *testing.T) {
+	t.Parallel()
+	// Failed authentications are limited even with no per-agent limit
+	auth := &fakeAuth{}
+	srv := New(&Config{Wallet: "main", Auth: auth, Backend: &fakeBackend{}})
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	for i := range authFailureLimit {
+		resp, _ := doRequest(t, ts, http.MethodGet, "/v1/balance", fmt.Sprintf("sigil_agt_random%d", i), "")
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	}
+	resp, body := doRequest(t, ts, http.MethodGet, "/v1/balance", "sigil_agt_random", "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "6", resp.Header.Get("Retry-After"))
+	errBody, ok := body["error"].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, errBody["suggestion"], "failed authentications")
+
+	// Once blocked, even a valid token waits, so the address cannot keep
+	// guessing between valid requests
+	resp, _ = doRequest(t, ts, http.MethodGet, "/v1/balance", testToken, "")
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Empty(t, auth.sessions)
+}
+
+func TestKeyLimiter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1_700_000_000, 0)
+	l := newKeyLimiter(2)
+	l.now = func() time.Time { return now }
+	l.maxKeys = 3
+
+	assert.False(t, l.blocked("a"))
+	assert.True(t, l.allow("a"))
+	assert.True(t, l.allow("a"))
+	assert.True(t, l.blocked("a"))
+	assert.False(t, l.allow("a"))
+
+	// A bucket refills at the limit's rate
+	now = now.Add(30 * time.Second)
+	assert.False(t, l.blocked("a"))
+
+	// The map never grows past maxKeys: full buckets go first, then the
+	// least recently used
+	l.allow("b")
+	now = now.Add(time.Second)
+	l.allow("c")
+	l.allow("d")
+	assert.Len(t, l.buckets, 3)
+	assert.NotContains(t, l.buckets, "a")
+	l.allow("e")
+	assert.Len(t, l.buckets, 3)
+	assert.NotContains(t, l.buckets, "b")
+
+	for i := range 100 {
+		l.allow(fmt.Sprintf("k%d", i))
+	}
+	assert.Len(t, l.buckets, 3)
+}
+
+func TestHTTPStatus(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, http.StatusBadRequest, httpStatus(sigilerr.ErrInvalidInput))
+	assert.Equal(t, http.StatusUnauthorized, httpStatus(sigilerr.ErrAgentTokenInvalid))
+	assert.Equal(t, http.StatusNotFound, httpStatus(sigilerr.ErrNotFound))
+	assert.Equal(t, http.StatusNotFound, httpStatus(errMethodNotFound))
+	assert.Equal(t, http.StatusTooManyRequests, httpStatus(sigilerr.ErrAgentRateLimited))
+	assert.Equal(t, http.StatusInternalServerError, httpStatus(assert.AnError))
+}
+
+func TestNewRPCError(t *testing.T) {
+	t.Parallel()
+
+	rpcErr := newRPCError(sigilerr.WithSuggestion(sigilerr.ErrAgentTokenInvalid, "create a token"))
+	assert.Equal(t, rpcCodeAuth, rpcErr.Code)
+	require.NotNil(t, rpcErr.Data)
+	assert.Equal(t, output.ErrorDetail{
+		Code:       rpcErr.Data.Code,
+		Message:    rpcErr.Message,
+		Suggestion: "create a token",
+		ExitCode:   sigilerr.ExitAuth,
+	}, *rpcErr.Data)
+}
@@ -0,0 +1,72 @@
+package address
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+)
+
+// maxActivityFetches bounds concurrent activity lookups. The provider client
+// applies its own rate limit, so this only caps goroutines in flight.
+const maxActivityFetches = 4
+
+// ActivityReader fetches on-chain activity for an ETH address.
+type ActivityReader interface {
+	GetAddressActivity(ctx context.Context, address string) (*etherscan.AddressActivity, error)
+}
+
+// EnrichActivity populates nonce and first/last activity for ETH addresses
+// and marks addresses with on-chain history as used, even when their balance
+// is zero. Addresses on other chains are left untouched.
+//
+// Lookup failures leave the affected address unchanged; they are returned
+// joined so the caller can log them.
+func EnrichActivity(ctx context.Context, addresses []AddressInfo, reader ActivityReader) error {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs []error
+	)
+	sem := make(chan struct{}, maxActivityFetches)
+
+	for i := range addresses {
+		if addresses[i].ChainID != chain.ETH {
+			continue
+		}
+
+		wg.Add(1)
+		go func(info *AddressInfo) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			defer func() { <-sem }()
+
+			activity, err := reader.GetAddressActivity(ctx, info.Address)
+			if err != nil {
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("%s: %w", info.Address, err))
+				mu.Unlock()
+				return
+			}
+
+			// Each goroutine owns a distinct element, so no lock is needed here.
+			nonce := activity.Nonce
+			info.Nonce = &nonce
+			info.FirstActivity = activity.FirstSeen
+			info.LastActivity = activity.LastSeen
+			if activity.HasActivity() {
+				info.HasActivity = true
+			}
+		}(&addresses[i])
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,77 @@
+package address
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+)
+
+var errTestActivity = errors.New("provider unavailable")
+
+type mockActivityReader struct {
+	activity map[string]*etherscan.AddressActivity
+}
+
+func (m *mockActivityReader) GetAddressActivity(_ context.Context, address string) (*etherscan.AddressActivity, error) {
+	if a, ok := m.activity[address]; ok {
+		return a, nil
+	}
+	return nil, errTestActivity
+}
+
+func TestEnrichActivity(t *testing.T) {
+	t.Parallel()
+
+	first := time.Unix(1700000000, 0).UTC()
+	last := time.Unix(1710000000, 0).UTC()
+	reader := &mockActivityReader{activity: map[string]*etherscan.AddressActivity{
+		"0xsender":   {Nonce: 3, FirstSeen: first, LastSeen: last},
+		"0xreceiver": {Nonce: 0, FirstSeen: first, LastSeen: first},
+		"0xfresh":    {},
+	}}
+
+	addresses := []AddressInfo{
+		{ChainID: chain.ETH, Address: "0xsender"},
+		{ChainID: chain.ETH, Address: "0xreceiver"},
+		{ChainID: chain.ETH, Address: "0xfresh"},
+		{ChainID: chain.ETH, Address: "0xbroken"},
+		{ChainID: chain.BSV, Address: "1bsv"},
+	}
+
+	err := EnrichActivity(context.Background(), addresses, reader)
+	require.ErrorIs(t, err, errTestActivity)
+	assert.Contains(t, err.Error(), "0xbroken")
+
+	require.NotNil(t, addresses[0].Nonce)
+	assert.Equal(t, uint64(3), *addresses[0].Nonce)
+	assert.Equal(t, first, addresses[0].FirstActivity)
+	assert.Equal(t, last, addresses[0].LastActivity)
+	assert.True(t, addresses[0].HasActivity)
+
+	require.NotNil(t, addresses[1].Nonce)
+	assert.Equal(t, uint64(0), *addresses[1].Nonce)
+	assert.True(t, addresses[1].HasActivity, "received funds count as activity")
+
+	require.NotNil(t, addresses[2].Nonce)
+	assert.False(t, addresses[2].HasActivity)
+
+	assert.Nil(t, addresses[3].Nonce)
+	assert.Nil(t, addresses[4].Nonce)
+}
+
+func TestEnrichActivity_KeepsExistingActivity(t *testing.T) {
+	t.Parallel()
+
+	reader := &mockActivityReader{activity: map[string]*etherscan.AddressActivity{"0xa": {}}}
+	addresses := []AddressInfo{{ChainID: chain.ETH, Address: "0xa", HasActivity: true}}
+
+	require.NoError(t, EnrichActivity(context.Background(), addresses, reader))
+	assert.True(t, addresses[0].HasActivity)
+}
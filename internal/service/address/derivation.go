@@ -56,3 +56,47 @@ func (s *Service) DeriveNext(req *DerivationRequest) (*wallet.Address, error) {
 		"no seed or xpub available for address derivation",
 	)
 }
+
+// ExtendGap derives new receive addresses when the newest one has activity,
+// so the chain again ends in GapLimit unused addresses. Wallets that scan
+// with the same gap limit find funds sent to any of them.
+//
+// Nothing is derived when the newest address is unused, the chain has no
+// addresses yet, or GapLimit is not positive. Derived addresses are added to
+// the wallet's address list and returned; the caller must persist the wallet
+// metadata. Addresses derived before an error are returned with it.
+func (s *Service) ExtendGap(req *GapRequest) ([]wallet.Address, error) {
+	addresses := req.Wallet.Addresses[req.ChainID]
+	if req.GapLimit <= 0 || len(addresses) == 0 {
+		return nil, nil
+	}
+
+	used := req.Used
+	if used == nil {
+		used = func(addr string) bool {
+			if s.metadata == nil {
+				return false
+			}
+			meta := s.metadata.GetAddress(req.ChainID, addr)
+			return meta != nil && meta.HasActivity
+		}
+	}
+	if !used(addresses[len(addresses)-1].Address) {
+		return nil, nil
+	}
+
+	derived := make([]wallet.Address, 0, req.GapLimit)
+	for range req.GapLimit {
+		addr, err := s.DeriveNext(&DerivationRequest{
+			Wallet:  req.Wallet,
+			Seed:    req.Seed,
+			ChainID: req.ChainID,
+			Xpub:    req.Xpub,
+		})
+		if err != nil {
+			return derived, err
+		}
+		derived = append(derived, *addr)
+	}
+	return derived, nil
+}
@@ -1,7 +1,10 @@
 package address
 
 import (
+	"slices"
+
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
 )
 
@@ -59,7 +62,9 @@ func (s *Service) buildAddressInfo(addrType AddressType, addr *wallet.Address, c
 	if s.metadata != nil {
 		if meta := s.metadata.GetAddress(chainID, addr.Address); meta != nil {
 			info.Label = meta.Label
+			info.Tags = meta.Tags
 			info.HasActivity = meta.HasActivity
+			info.Pending = meta.PendingReceive
 		}
 	}
 
@@ -85,3 +90,30 @@ func FilterUsage(addresses []AddressInfo, onlyUsed, onlyUnused bool) []AddressIn
 	}
 	return filtered
 }
+
+// FilterTags keeps addresses that match every tag filter. A filter matches an
+// address tag equal to it or nested under it, so "customer" matches
+// "customer:acme". Used for implementing the --tag CLI flag.
+func FilterTags(addresses []AddressInfo, filters []string) []AddressInfo {
+	if len(filters) == 0 {
+		return addresses // No filter
+	}
+
+	var filtered []AddressInfo
+	for _, addr := range addresses {
+		if HasTags(addr.Tags, filters) {
+			filtered = append(filtered, addr)
+		}
+	}
+	return filtered
+}
+
+// HasTags reports whether tags match every filter (see FilterTags).
+func HasTags(tags, filters []string) bool {
+	for _, filter := range filters {
+		if !slices.ContainsFunc(tags, func(tag string) bool { return utxostore.TagMatches(tag, filter) }) {
+			return false
+		}
+	}
+	return true
+}
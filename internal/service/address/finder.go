@@ -4,7 +4,8 @@ import (
 	"github.com/mrz1836/sigil/internal/wallet"
 )
 
-// FindUnused returns the first receiving address with no activity.
+// FindUnused returns the first receiving address with no activity, passing
+// over those awaiting a payment when req.SkipPending is set.
 // Returns nil if all addresses have been used.
 func (s *Service) FindUnused(req *FindRequest) *wallet.Address {
 	addresses := req.Wallet.Addresses[req.ChainID]
@@ -15,7 +16,10 @@ func (s *Service) FindUnused(req *FindRequest) *wallet.Address {
 			return addr
 		}
 		meta := s.metadata.GetAddress(req.ChainID, addr.Address)
-		if meta == nil || !meta.HasActivity {
+		if meta == nil {
+			return addr
+		}
+		if !meta.HasActivity && (!req.SkipPending || !meta.PendingReceive) {
 			return addr
 		}
 	}
@@ -14,4 +14,9 @@ type MetadataProvider interface {
 type AddressMetadata struct {
 	HasActivity bool
 	Label       string
+	Tags        []string
+
+	// PendingReceive is true while the address awaits a payment it was
+	// handed out for by `sigil receive`.
+	PendingReceive bool
 }
@@ -1,6 +1,8 @@
 package address
 
 import (
+	"slices"
+
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/utxostore"
 )
@@ -28,7 +30,9 @@ func (a *MetadataAdapter) GetAddress(chainID chain.ID, address string) *AddressM
 	}
 
 	return &AddressMetadata{
-		HasActivity: storeMeta.HasActivity,
-		Label:       storeMeta.Label,
+		HasActivity:    storeMeta.HasActivity,
+		Label:          storeMeta.Label,
+		Tags:           slices.Clone(storeMeta.Tags),
+		PendingReceive: storeMeta.AwaitingReceive(),
 	}
 }
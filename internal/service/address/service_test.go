@@ -286,6 +286,7 @@ func TestCollect_WithMetadata(t *testing.T) {
 			string(chain.BSV) + ":1ABC": {
 				HasActivity: true,
 				Label:       "Savings",
+				Tags:        []string{"purpose:savings"},
 			},
 		},
 	}
@@ -303,6 +304,7 @@ func TestCollect_WithMetadata(t *testing.T) {
 	assert.Equal(t, "1ABC", results[0].Address)
 	assert.True(t, results[0].HasActivity)
 	assert.Equal(t, "Savings", results[0].Label)
+	assert.Equal(t, []string{"purpose:savings"}, results[0].Tags)
 
 	// Second address should have no metadata
 	assert.Equal(t, "1DEF", results[1].Address)
@@ -358,6 +360,67 @@ func TestCollect_ChainFilter(t *testing.T) {
 	assert.Equal(t, "1ABC", results[0].Address)
 }
 
+func TestExtendGap(t *testing.T) {
+	t.Parallel()
+
+	seed := getTestSeed(t)
+	w := &wallet.Wallet{
+		Name:          "test",
+		EnabledChains: []chain.ID{chain.BSV},
+		Addresses:     make(map[chain.ID][]wallet.Address),
+	}
+	service := NewService(nil)
+	first, err := service.DeriveNext(&DerivationRequest{Wallet: w, Seed: seed, ChainID: chain.BSV})
+	require.NoError(t, err)
+
+	// An unused tail needs no new addresses
+	req := &GapRequest{Wallet: w, Seed: seed, ChainID: chain.BSV, GapLimit: 3}
+	derived, err := service.ExtendGap(req)
+	require.NoError(t, err)
+	assert.Empty(t, derived)
+
+	// Activity on the newest address extends the chain by the gap limit
+	req.Used = func(addr string) bool { return addr == first.Address }
+	derived, err = service.ExtendGap(req)
+	require.NoError(t, err)
+	require.Len(t, derived, 3)
+	assert.Equal(t, uint32(1), derived[0].Index)
+	assert.Equal(t, uint32(3), derived[2].Index)
+	assert.Len(t, w.Addresses[chain.BSV], 4)
+
+	// The new tail is unused, so a second call derives nothing
+	derived, err = service.ExtendGap(req)
+	require.NoError(t, err)
+	assert.Empty(t, derived)
+
+	req.GapLimit = 0
+	req.Used = func(string) bool { return true }
+	derived, err = service.ExtendGap(req)
+	require.NoError(t, err)
+	assert.Empty(t, derived, "a zero gap limit disables extension")
+}
+
+func TestExtendGap_NoKeyMaterial(t *testing.T) {
+	t.Parallel()
+
+	w := &wallet.Wallet{
+		Name:          "test",
+		EnabledChains: []chain.ID{chain.BSV},
+		Addresses: map[chain.ID][]wallet.Address{
+			chain.BSV: {{Address: "1ABC", Index: 0, Path: "m/44'/236'/0'/0/0"}},
+		},
+	}
+	metadata := &mockMetadataProvider{
+		metadata: map[string]*AddressMetadata{
+			string(chain.BSV) + ":1ABC": {HasActivity: true},
+		},
+	}
+
+	_, err := NewService(metadata).ExtendGap(&GapRequest{Wallet: w, ChainID: chain.BSV, GapLimit: 5})
+	require.Error(t, err)
+	assert.Len(t, w.Addresses[chain.BSV], 1)
+}
+
 func TestFindUnused_HasUnused(t *testing.T) {
 	t.Parallel()
 
@@ -391,6 +454,36 @@ func TestFindUnused_HasUnused(t *testing.T) {
 	assert.Equal(t, uint32(1), addr.Index)
 }
 
+func TestFindUnused_SkipPending(t *testing.T) {
+	t.Parallel()
+
+	w := &wallet.Wallet{
+		Name:          "test",
+		EnabledChains: []chain.ID{chain.BSV},
+		Addresses: map[chain.ID][]wallet.Address{
+			chain.BSV: {
+				{Address: "1ABC", Index: 0, Path: "m/44'/0'/0'/0/0"},
+				{Address: "1DEF", Index: 1, Path: "m/44'/0'/0'/0/1"},
+			},
+		},
+	}
+
+	// The first address was handed out and awaits a payment
+	service := NewService(&mockMetadataProvider{
+		metadata: map[string]*AddressMetadata{
+			string(chain.BSV) + ":1ABC": {PendingReceive: true},
+		},
+	})
+
+	addr := service.FindUnused(&FindRequest{Wallet: w, ChainID: chain.BSV})
+	require.NotNil(t, addr)
+	assert.Equal(t, "1ABC", addr.Address, "a pending address is still unused")
+
+	addr = service.FindUnused(&FindRequest{Wallet: w, ChainID: chain.BSV, SkipPending: true})
+	require.NotNil(t, addr)
+	assert.Equal(t, "1DEF", addr.Address)
+}
+
 func TestFindUnused_AllUsed(t *testing.T) {
 	t.Parallel()
 
@@ -506,6 +599,42 @@ func TestFilterUsage_NoFilter(t *testing.T) {
 	assert.Len(t, filtered, 2)
 }
 
+func TestFilterTags(t *testing.T) {
+	t.Parallel()
+
+	addresses := []AddressInfo{
+		{Address: "1ABC", Tags: []string{"customer:acme", "purpose:invoices"}},
+		{Address: "1DEF", Tags: []string{"customer:acme:eu"}},
+		{Address: "1GHI", Tags: []string{"customers"}},
+		{Address: "1JKL"},
+	}
+
+	tests := []struct {
+		name    string
+		filters []string
+		want    []string
+	}{
+		{"no filter", nil, []string{"1ABC", "1DEF", "1GHI", "1JKL"}},
+		{"namespace", []string{"customer"}, []string{"1ABC", "1DEF"}},
+		{"exact and nested", []string{"customer:acme"}, []string{"1ABC", "1DEF"}},
+		{"leaf", []string{"customer:acme:eu"}, []string{"1DEF"}},
+		{"all filters must match", []string{"customer", "purpose:invoices"}, []string{"1ABC"}},
+		{"no match", []string{"vendor"}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, addr := range FilterTags(addresses, tc.filters) {
+				got = append(got, addr.Address)
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
 // Mock implementation for testing
 type mockMetadataProvider struct {
 	metadata map[string]*AddressMetadata
@@ -1,7 +1,10 @@
 package address
 
 import (
+	"time"
+
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/txjournal"
 	"github.com/mrz1836/sigil/internal/wallet"
 )
 
@@ -14,10 +17,20 @@ type AddressInfo struct {
 	Index       uint32
 	Path        string
 	Label       string
-	Balance     string // Formatted confirmed balance (e.g. "0.00070422") or ""
-	Unconfirmed string // Formatted unconfirmed delta (e.g. "-0.00070422") or ""
-	Stale       bool   // True if balance data is stale
-	HasActivity bool   // True if address has been used on-chain
+	Tags        []string // Hierarchical tags such as "customer:acme"
+	Balance     string   // Formatted confirmed balance (e.g. "0.00070422") or ""
+	Unconfirmed string   // Formatted unconfirmed delta (e.g. "-0.00070422") or ""
+	Stale       bool     // True if balance data is stale
+	HasActivity bool     // True if address has been used on-chain
+	Pending     bool     // True if handed out by receive and awaiting a payment
+
+	// On-chain activity, populated by EnrichActivity for account-based chains (ETH).
+	Nonce         *uint64   // Transactions sent from the address, nil if not fetched
+	FirstActivity time.Time // Oldest transaction, zero if none or not fetched
+	LastActivity  time.Time // Newest transaction, zero if none or not fetched
+
+	// Sends recorded in the wallet's transaction journal, nil if not requested.
+	Spending *txjournal.Spending
 }
 
 // AddressType represents the type of address (receive or change).
@@ -61,8 +74,24 @@ type DerivationRequest struct {
 	Xpub    string // Optional: for xpub-based derivation (read-only mode)
 }
 
+// GapRequest specifies parameters for extending the receive addresses of a
+// chain past a used tail.
+type GapRequest struct {
+	Wallet   *wallet.Wallet
+	Seed     []byte // May be nil for xpub mode
+	ChainID  chain.ID
+	Xpub     string // Optional: for xpub-based derivation (read-only mode)
+	GapLimit int    // Unused addresses to keep after the last used one
+	// Used reports whether an address has activity. Nil uses the metadata.
+	Used func(address string) bool
+}
+
 // FindRequest specifies parameters for finding an unused address.
 type FindRequest struct {
 	Wallet  *wallet.Wallet
 	ChainID chain.ID
+
+	// SkipPending also passes over addresses awaiting a payment they were
+	// handed out for, so each caller gets a fresh address.
+	SkipPending bool
 }
@@ -0,0 +1,155 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Adaptive concurrency tuning.
+const (
+	// DefaultInitialConcurrent is the number of parallel fetches an adaptive
+	// limiter starts with.
+	DefaultInitialConcurrent = 4
+	// DefaultMaxConcurrent caps parallel fetches when no maximum is given.
+	DefaultMaxConcurrent = 8
+
+	// latencySpikeFactor is how many times the average latency a response
+	// may take before it counts as a spike.
+	latencySpikeFactor = 2.0
+	// latencyWeight is the weight of the newest response in the average.
+	latencyWeight = 0.2
+	// backoffFactor is what the limit is multiplied by on an error or spike.
+	backoffFactor = 0.5
+)
+
+// LimiterConfig configures a Limiter.
+type LimiterConfig struct {
+	// Adaptive grows the limit while providers answer quickly and backs off
+	// on errors and latency spikes. Without it the limit stays at Max.
+	Adaptive bool
+	// Initial is the limit an adaptive limiter starts at; zero uses
+	// DefaultInitialConcurrent.
+	Initial int
+	// Max caps the limit; zero uses DefaultMaxConcurrent.
+	Max int
+}
+
+// Limiter bounds the number of provider requests in flight. An adaptive
+// limiter uses additive increase, multiplicative decrease (AIMD): each
+// success adds 1/limit, so the limit grows by about one per round of
+// requests, and an error or a response slower than latencySpikeFactor times
+// the average halves it, down to one.
+type Limiter struct {
+	mu       sync.Mutex
+	adaptive bool
+	limit    float64
+	max      float64
+	inflight int
+	// average is the moving average latency of successful requests.
+	average time.Duration
+	// generation counts backoffs. Requests started before the latest
+	// backoff do not back off again, so one slow round halves the limit once.
+	generation uint64
+	// changed is closed and replaced whenever a slot may have opened.
+	changed chan struct{}
+	now     func() time.Time
+}
+
+// Permit is a slot held by one request, returned by Acquire.
+type Permit struct {
+	start      time.Time
+	generation uint64
+}
+
+// NewLimiter creates a limiter from cfg.
+func NewLimiter(cfg LimiterConfig) *Limiter {
+	maxLimit := cfg.Max
+	if maxLimit <= 0 {
+		maxLimit = DefaultMaxConcurrent
+	}
+	initial := maxLimit
+	if cfg.Adaptive {
+		initial = cfg.Initial
+		if initial <= 0 {
+			initial = DefaultInitialConcurrent
+		}
+		initial = min(initial, maxLimit)
+	}
+
+	return &Limiter{
+		adaptive: cfg.Adaptive,
+		limit:    float64(initial),
+		max:      float64(maxLimit),
+		changed:  make(chan struct{}),
+		now:      time.Now,
+	}
+}
+
+// Limit returns the number of requests currently allowed in flight.
+func (l *Limiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// Acquire waits for a free slot or for ctx to end.
+func (l *Limiter) Acquire(ctx context.Context) (Permit, error) {
+	for {
+		l.mu.Lock()
+		if l.inflight < int(l.limit) {
+			l.inflight++
+			p := Permit{start: l.now(), generation: l.generation}
+			l.mu.Unlock()
+			return p, nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return Permit{}, ctx.Err()
+		}
+	}
+}
+
+// Release frees the slot of p. err is the outcome of the request: an adaptive
+// limiter backs off on an error, other than cancellation, or on a latency
+// spike, and otherwise grows the limit.
+func (l *Limiter) Release(p Permit, err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.inflight--
+	defer l.signal()
+	if !l.adaptive || errors.Is(err, context.Canceled) {
+		return
+	}
+
+	latency := l.now().Sub(p.start)
+	spike := l.average > 0 && float64(latency) > latencySpikeFactor*float64(l.average)
+	if err == nil {
+		if l.average == 0 {
+			l.average = latency
+		} else {
+			l.average = time.Duration((1-latencyWeight)*float64(l.average) + latencyWeight*float64(latency))
+		}
+	}
+
+	if err != nil || spike {
+		if p.generation == l.generation {
+			l.limit = max(1, l.limit*backoffFactor)
+			l.generation++
+		}
+		return
+	}
+	l.limit = min(l.max, l.limit+1/l.limit)
+}
+
+// signal wakes the requests waiting in Acquire. Callers hold l.mu.
+func (l *Limiter) signal() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
@@ -0,0 +1,139 @@
+package balance
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock is a settable time source for a Limiter.
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+// newTestLimiter creates a limiter driven by a fake clock.
+func newTestLimiter(cfg LimiterConfig) (*Limiter, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)}
+	l := NewLimiter(cfg)
+	l.now = clock.now
+	return l, clock
+}
+
+// request acquires a permit, advances the clock by latency, and releases it.
+func request(t *testing.T, l *Limiter, clock *fakeClock, latency time.Duration, err error) {
+	t.Helper()
+	p, acquireErr := l.Acquire(context.Background())
+	require.NoError(t, acquireErr)
+	clock.t = clock.t.Add(latency)
+	l.Release(p, err)
+}
+
+func TestNewLimiter(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultMaxConcurrent, NewLimiter(LimiterConfig{}).Limit())
+	assert.Equal(t, 12, NewLimiter(LimiterConfig{Max: 12}).Limit())
+	assert.Equal(t, DefaultInitialConcurrent, NewLimiter(LimiterConfig{Adaptive: true, Max: 12}).Limit())
+	assert.Equal(t, 2, NewLimiter(LimiterConfig{Adaptive: true, Initial: 6, Max: 2}).Limit(), "initial is capped at max")
+}
+
+func TestLimiter_FixedIgnoresOutcomes(t *testing.T) {
+	t.Parallel()
+
+	l, clock := newTestLimiter(LimiterConfig{Max: 3})
+	request(t, l, clock, time.Second, errors.New("rate limited"))
+	request(t, l, clock, time.Minute, nil)
+	assert.Equal(t, 3, l.Limit())
+}
+
+func TestLimiter_AdditiveIncrease(t *testing.T) {
+	t.Parallel()
+
+	l, clock := newTestLimiter(LimiterConfig{Adaptive: true, Initial: 2, Max: 4})
+	// Each fast success adds 1/limit: 2, 2.5, 2.9, 3.24
+	for range 3 {
+		request(t, l, clock, 100*time.Millisecond, nil)
+	}
+	assert.Equal(t, 3, l.Limit())
+
+	for range 20 {
+		request(t, l, clock, 100*time.Millisecond, nil)
+	}
+	assert.Equal(t, 4, l.Limit(), "limit stops at max")
+}
+
+func TestLimiter_ErrorBacksOffOncePerRound(t *testing.T) {
+	t.Parallel()
+
+	l, clock := newTestLimiter(LimiterConfig{Adaptive: true, Initial: 8, Max: 8})
+	permits := make([]Permit, 4)
+	for i := range permits {
+		p, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+		permits[i] = p
+	}
+	clock.t = clock.t.Add(time.Second)
+	for _, p := range permits {
+		l.Release(p, errors.New("429 too many requests"))
+	}
+	assert.Equal(t, 4, l.Limit(), "requests in flight together back off once")
+
+	// A later failure backs off again, down to one
+	request(t, l, clock, time.Second, errors.New("timeout"))
+	request(t, l, clock, time.Second, errors.New("timeout"))
+	request(t, l, clock, time.Second, errors.New("timeout"))
+	assert.Equal(t, 1, l.Limit())
+}
+
+func TestLimiter_LatencySpikeBacksOff(t *testing.T) {
+	t.Parallel()
+
+	l, clock := newTestLimiter(LimiterConfig{Adaptive: true, Initial: 4, Max: 4})
+	for range 3 {
+		request(t, l, clock, 100*time.Millisecond, nil)
+	}
+	require.Equal(t, 4, l.Limit())
+
+	request(t, l, clock, time.Second, nil)
+	assert.Equal(t, 2, l.Limit())
+}
+
+func TestLimiter_CancellationIsNotAFailure(t *testing.T) {
+	t.Parallel()
+
+	l, clock := newTestLimiter(LimiterConfig{Adaptive: true, Initial: 4, Max: 4})
+	request(t, l, clock, time.Second, context.Canceled)
+	assert.Equal(t, 4, l.Limit())
+}
+
+func TestLimiter_AcquireWaitsForSlot(t *testing.T) {
+	t.Parallel()
+
+	l := NewLimiter(LimiterConfig{Max: 1})
+	held, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan error, 1)
+	go func() {
+		_, acquireErr := l.Acquire(context.Background())
+		acquired <- acquireErr
+	}()
+	l.Release(held, nil)
+	select {
+	case err := <-acquired:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Acquire did not return after Release")
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/tokens"
 )
 
 // CacheAdapter adapts cache.BalanceCache to the CacheProvider interface.
@@ -59,9 +60,10 @@ func (a *CacheAdapter) Set(entry CacheEntry) {
 }
 
 // getCachedBalancesForAddress retrieves all cached balances for an address.
+// For ETH, tracked tokens are included, and other registry tokens when held.
 // Returns empty slice if no cache entries found.
 // This is a helper function used by the service.
-func getCachedBalancesForAddress(chainID chain.ID, address string, cache CacheProvider) []CacheEntry {
+func getCachedBalancesForAddress(chainID chain.ID, address string, cache CacheProvider, registry []tokens.Token) []CacheEntry {
 	var results []CacheEntry
 
 	// Check native balance
@@ -69,12 +71,14 @@ func getCachedBalancesForAddress(chainID chain.ID, address string, cache CachePr
 		results = append(results, *entry)
 	}
 
-	// For ETH, also check USDC
+	// For ETH, also check the token registry
 	if chainID == chain.ETH {
-		if entry, exists, _ := cache.Get(chainID, address, "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"); exists {
-			results = append(results, *entry)
+		for _, token := range registry {
+			if entry, exists, _ := cache.Get(chainID, address, token.Address); exists && (token.Tracked || isNonZeroBalance(entry.Balance)) {
+				results = append(results, *entry)
+			}
 		}
 	}
 
-	return results
+	return withSource(results, SourceCache)
 }
@@ -9,6 +9,8 @@ import (
 
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/tokens"
 )
 
 // TestCacheAdapter_Get_Exists tests retrieving an existing cache entry.
@@ -187,7 +189,7 @@ func TestGetCachedBalancesForAddress_NativeOnly(t *testing.T) {
 	}
 	provider.entries[string(chain.BSV)+":1ABC"] = nativeEntry
 
-	results := getCachedBalancesForAddress(chain.BSV, "1ABC", provider)
+	results := getCachedBalancesForAddress(chain.BSV, "1ABC", provider, nil)
 
 	require.Len(t, results, 1)
 	assert.Equal(t, chain.BSV, results[0].Chain)
@@ -225,7 +227,7 @@ func TestGetCachedBalancesForAddress_ETH_WithUSDC(t *testing.T) {
 	}
 	provider.entries[string(chain.ETH)+":0x123:"+usdcAddr] = usdcEntry
 
-	results := getCachedBalancesForAddress(chain.ETH, "0x123", provider)
+	results := getCachedBalancesForAddress(chain.ETH, "0x123", provider, tokens.All(config.Defaults().GetETHTokens()))
 
 	require.Len(t, results, 2)
 
@@ -259,7 +261,7 @@ func TestGetCachedBalancesForAddress_ETH_OnlyNative(t *testing.T) {
 	}
 	provider.entries[string(chain.ETH)+":0x456"] = ethEntry
 
-	results := getCachedBalancesForAddress(chain.ETH, "0x456", provider)
+	results := getCachedBalancesForAddress(chain.ETH, "0x456", provider, tokens.All(config.Defaults().GetETHTokens()))
 
 	require.Len(t, results, 1)
 	assert.Equal(t, chain.ETH, results[0].Chain)
@@ -273,7 +275,7 @@ func TestGetCachedBalancesForAddress_NoCacheEntries(t *testing.T) {
 
 	provider := newMockCacheProvider()
 
-	results := getCachedBalancesForAddress(chain.BSV, "1NOTFOUND", provider)
+	results := getCachedBalancesForAddress(chain.BSV, "1NOTFOUND", provider, nil)
 
 	assert.Empty(t, results, "should return empty slice when no cache entries")
 }
@@ -307,8 +309,31 @@ func TestGetCachedBalancesForAddress_NonETH_IgnoresUSDC(t *testing.T) {
 	}
 	provider.entries[string(chain.BSV)+":1BSV:"+usdcAddr] = usdcEntry
 
-	results := getCachedBalancesForAddress(chain.BSV, "1BSV", provider)
+	results := getCachedBalancesForAddress(chain.BSV, "1BSV", provider, nil)
 
 	require.Len(t, results, 1, "should only return native balance for non-ETH chains")
 	assert.Equal(t, "BSV", results[0].Symbol)
 }
+
+// TestGetCachedBalancesForAddress_ETH_UntrackedToken tests that built-in
+// tokens missing from the config are only returned when held.
+func TestGetCachedBalancesForAddress_ETH_UntrackedToken(t *testing.T) {
+	t.Parallel()
+
+	provider := newMockCacheProvider()
+	registry := tokens.All(nil)
+	daiAddr := "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+	usdtAddr := "0xdAC17F958D2ee523a2206206994597C13D831ec7"
+
+	provider.entries[string(chain.ETH)+":0x789:"+daiAddr] = &CacheEntry{
+		Chain: chain.ETH, Address: "0x789", Balance: "2.5", Symbol: "DAI", Token: daiAddr, Decimals: 18,
+	}
+	provider.entries[string(chain.ETH)+":0x789:"+usdtAddr] = &CacheEntry{
+		Chain: chain.ETH, Address: "0x789", Balance: "0.0", Symbol: "USDT", Token: usdtAddr, Decimals: 6,
+	}
+
+	results := getCachedBalancesForAddress(chain.ETH, "0x789", provider, registry)
+
+	require.Len(t, results, 1)
+	assert.Equal(t, "DAI", results[0].Symbol)
+}
@@ -5,14 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bch"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/chain/btc"
 	"github.com/mrz1836/sigil/internal/chain/eth"
 	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
 	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/tokens"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
@@ -31,6 +35,8 @@ type Fetcher struct {
 	newETHClient       func(rpcURL string, opts *eth.ClientOptions) (ethRPCBalanceClient, error)
 	newEtherscanClient func(apiKey string, opts *etherscan.ClientOptions) (ethBalanceReader, error)
 	newBSVClient       func(ctx context.Context, opts *bsv.ClientOptions) bsvBalanceClient
+	newBTCClient       func(opts *btc.ClientOptions) (btcBalanceClient, error)
+	newBCHClient       func(opts *bch.ClientOptions) (bchBalanceClient, error)
 	retryETHBalance    func(ctx context.Context, operation func() (*eth.Balance, error)) (*eth.Balance, error)
 
 	fetchETHViaRPCOverride       func(ctx context.Context, address string) ([]CacheEntry, bool, error)
@@ -45,13 +51,15 @@ func NewFetcher(cfg ConfigProvider, cache CacheProvider) *Fetcher {
 		newETHClient:       defaultETHClientFactory,
 		newEtherscanClient: defaultEtherscanClientFactory,
 		newBSVClient:       defaultBSVClientFactory,
+		newBTCClient:       defaultBTCClientFactory,
+		newBCHClient:       defaultBCHClientFactory,
 		retryETHBalance:    chain.Retry[*eth.Balance],
 	}
 }
 
 type ethBalanceReader interface {
 	GetNativeBalance(ctx context.Context, address string) (*eth.Balance, error)
-	GetUSDCBalance(ctx context.Context, address string) (*eth.Balance, error)
+	GetERC20Balance(ctx context.Context, address string, token *eth.TokenMetadata) (*eth.Balance, error)
 }
 
 type ethRPCBalanceClient interface {
@@ -64,6 +72,14 @@ type bsvBalanceClient interface {
 	GetBulkNativeBalance(ctx context.Context, addresses []string) (map[string]*bsv.Balance, error)
 }
 
+type btcBalanceClient interface {
+	GetNativeBalance(ctx context.Context, address string) (*btc.Balance, error)
+}
+
+type bchBalanceClient interface {
+	GetNativeBalance(ctx context.Context, address string) (*bch.Balance, error)
+}
+
 func defaultETHClientFactory(rpcURL string, opts *eth.ClientOptions) (ethRPCBalanceClient, error) {
 	return eth.NewClient(rpcURL, opts)
 }
@@ -76,6 +92,14 @@ func defaultBSVClientFactory(ctx context.Context, opts *bsv.ClientOptions) bsvBa
 	return bsv.NewClient(ctx, opts)
 }
 
+func defaultBTCClientFactory(opts *btc.ClientOptions) (btcBalanceClient, error) {
+	return btc.NewClient(opts)
+}
+
+func defaultBCHClientFactory(opts *bch.ClientOptions) (bchBalanceClient, error) {
+	return bch.NewClient(opts)
+}
+
 // postSendCacheTrust is the duration after a send during which locally-computed
 // cached balances are trusted over network queries. This covers the window
 // where the blockchain indexer may not yet reflect the broadcast transaction.
@@ -89,8 +113,12 @@ func (f *Fetcher) FetchForChain(ctx context.Context, chainID chain.ID, address s
 		return f.fetchETH(ctx, address)
 	case chain.BSV:
 		return f.fetchBSV(ctx, address)
-	case chain.BTC, chain.BCH, chain.LTC:
-		// BTC, BCH, and LTC not supported in MVP
+	case chain.BTC:
+		return f.fetchBTC(ctx, address)
+	case chain.BCH:
+		return f.fetchBCH(ctx, address)
+	case chain.LTC:
+		// LTC not supported in MVP
 		return nil, false, nil
 	default:
 		return nil, false, fmt.Errorf("%w: %s", ErrUnsupportedChain, chainID)
@@ -106,55 +134,50 @@ func (f *Fetcher) bsvNetworkString() string {
 	return f.cfg.GetBSVNetwork()
 }
 
-// fetchETH fetches ETH and USDC balances using the configured provider with failover.
+// fetchETH fetches ETH and token balances from the configured sources in
+// order, returning the first that succeeds.
 func (f *Fetcher) fetchETH(ctx context.Context, address string) ([]CacheEntry, bool, error) {
-	provider := f.cfg.GetETHProvider()
-
-	type fetchFn func() ([]CacheEntry, bool, error)
+	sources, err := f.balanceSources(chain.ETH)
+	if err != nil {
+		return nil, true, err
+	}
 
-	etherscanFn := func() ([]CacheEntry, bool, error) {
-		apiKey := f.cfg.GetETHEtherscanAPIKey()
-		if apiKey == "" {
-			return nil, true, etherscan.ErrAPIKeyRequired
+	var firstErr error
+	for _, source := range sources {
+		var entries []CacheEntry
+		var stale bool
+		switch source {
+		case SourceEtherscan:
+			apiKey := f.cfg.GetETHEtherscanAPIKey()
+			switch {
+			case apiKey == "":
+				err = etherscan.ErrAPIKeyRequired
+			case f.fetchETHViaEtherscanOverride != nil:
+				entries, stale, err = f.fetchETHViaEtherscanOverride(ctx, address, apiKey)
+			default:
+				entries, stale, err = f.fetchETHViaEtherscan(ctx, address, apiKey)
+			}
+		case SourceRPC:
+			if f.fetchETHViaRPCOverride != nil {
+				entries, stale, err = f.fetchETHViaRPCOverride(ctx, address)
+			} else {
+				entries, stale, err = f.fetchETHViaRPC(ctx, address)
+			}
+		case SourceCache:
+			entries, stale, err = f.getCachedETHBalances(address)
 		}
-		if f.fetchETHViaEtherscanOverride != nil {
-			return f.fetchETHViaEtherscanOverride(ctx, address, apiKey)
+		if err == nil {
+			return entries, stale, nil
 		}
-		return f.fetchETHViaEtherscan(ctx, address, apiKey)
-	}
-
-	rpcFn := func() ([]CacheEntry, bool, error) {
-		if f.fetchETHViaRPCOverride != nil {
-			return f.fetchETHViaRPCOverride(ctx, address)
+		if firstErr == nil {
+			firstErr = err
 		}
-		return f.fetchETHViaRPC(ctx, address)
-	}
-
-	// Default: etherscan primary, rpc secondary
-	primaryFn, secondaryFn := fetchFn(etherscanFn), fetchFn(rpcFn)
-	if provider == "rpc" {
-		primaryFn, secondaryFn = rpcFn, etherscanFn
-	}
-
-	// Try primary
-	entries, stale, err := primaryFn()
-	if err == nil {
-		return entries, stale, nil
-	}
-
-	// Primary failed: try secondary (failover)
-	fallbackEntries, fallbackStale, fallbackErr := secondaryFn()
-	if fallbackErr == nil {
-		return fallbackEntries, fallbackStale, nil
 	}
 
-	// Both providers failed: return cached data
-	cached, cachedStale, cacheErr := f.getCachedETHBalances(address)
-	if cacheErr == nil {
-		return cached, cachedStale, nil
+	if firstErr == nil {
+		firstErr = fmt.Errorf("%w: no balance sources enabled for %s", ErrUnsupportedChain, chain.ETH)
 	}
-
-	return nil, true, err
+	return nil, true, firstErr
 }
 
 func (f *Fetcher) newETHBalanceClient(rpcURL string, opts *eth.ClientOptions) (ethRPCBalanceClient, error) {
@@ -185,7 +208,7 @@ func (f *Fetcher) retryETHBalanceCall(ctx context.Context, operation func() (*et
 	return chain.Retry(ctx, operation)
 }
 
-// fetchETHViaEtherscan fetches ETH and USDC balances using the Etherscan API.
+// fetchETHViaEtherscan fetches ETH and token balances using the Etherscan API.
 func (f *Fetcher) fetchETHViaEtherscan(ctx context.Context, address, apiKey string) ([]CacheEntry, bool, error) {
 	// Trust very fresh cache entries (set by a recent tx send).
 	if _, exists, age := f.cache.Get(chain.ETH, address, ""); exists && age < postSendCacheTrust {
@@ -194,7 +217,9 @@ func (f *Fetcher) fetchETHViaEtherscan(ctx context.Context, address, apiKey stri
 
 	var entries []CacheEntry
 
-	client, err := f.newEtherscanBalanceClient(apiKey, nil)
+	client, err := f.newEtherscanBalanceClient(apiKey, &etherscan.ClientOptions{
+		ChainID: strconv.Itoa(f.cfg.GetETHChainID()),
+	})
 	if err != nil {
 		return nil, true, err
 	}
@@ -212,30 +237,17 @@ func (f *Fetcher) fetchETHViaEtherscan(ctx context.Context, address, apiKey stri
 		Symbol:    ethBalance.Symbol,
 		Decimals:  ethBalance.Decimals,
 		UpdatedAt: time.Now().UTC(),
+		Source:    SourceEtherscan,
 	}
 	f.cache.Set(ethEntry)
 	entries = append(entries, ethEntry)
 
-	// Fetch USDC balance
-	usdcBalance, err := client.GetUSDCBalance(ctx, address)
-	if err == nil {
-		usdcEntry := CacheEntry{
-			Chain:     chain.ETH,
-			Address:   address,
-			Balance:   chain.FormatDecimalAmount(usdcBalance.Amount, usdcBalance.Decimals),
-			Symbol:    usdcBalance.Symbol,
-			Token:     usdcBalance.Token,
-			Decimals:  usdcBalance.Decimals,
-			UpdatedAt: time.Now().UTC(),
-		}
-		f.cache.Set(usdcEntry)
-		entries = append(entries, usdcEntry)
-	}
+	entries = append(entries, f.fetchTokenBalances(ctx, client, address, SourceEtherscan)...)
 
 	return entries, false, nil
 }
 
-// fetchETHViaRPC fetches ETH and USDC balances using JSON-RPC.
+// fetchETHViaRPC fetches ETH and token balances using JSON-RPC.
 func (f *Fetcher) fetchETHViaRPC(ctx context.Context, address string) ([]CacheEntry, bool, error) {
 	// Trust very fresh cache entries (set by a recent tx send).
 	if _, exists, age := f.cache.Get(chain.ETH, address, ""); exists && age < postSendCacheTrust {
@@ -282,27 +294,51 @@ func (f *Fetcher) fetchETHViaRPC(ctx context.Context, address string) ([]CacheEn
 		Symbol:      ethBalance.Symbol,
 		Decimals:    ethBalance.Decimals,
 		UpdatedAt:   time.Now().UTC(),
+		Source:      SourceRPC,
 	}
 	f.cache.Set(ethEntry)
 	entries = append(entries, ethEntry)
 
-	// Fetch USDC balance
-	usdcBalance, err := client.GetUSDCBalance(ctx, address)
-	if err == nil {
-		usdcEntry := CacheEntry{
+	entries = append(entries, f.fetchTokenBalances(ctx, client, address, SourceRPC)...)
+
+	return entries, stale, nil
+}
+
+// registryTokens returns the ERC-20 tokens whose balances are fetched.
+// Without a fetcher or configuration only the built-in tokens are known.
+func (f *Fetcher) registryTokens() []tokens.Token {
+	if f == nil || f.cfg == nil {
+		return tokens.All(nil)
+	}
+	return tokens.All(f.cfg.GetETHTokens())
+}
+
+// fetchTokenBalances reads and caches each registry token's balance.
+// Tracked tokens are always returned; other built-in tokens only when held.
+// A token that cannot be read is skipped: the ETH balance already succeeded.
+func (f *Fetcher) fetchTokenBalances(ctx context.Context, client ethBalanceReader, address, source string) []CacheEntry {
+	var entries []CacheEntry
+	for _, token := range f.registryTokens() {
+		balance, err := client.GetERC20Balance(ctx, address, &token.TokenMetadata)
+		if err != nil {
+			continue
+		}
+		entry := CacheEntry{
 			Chain:     chain.ETH,
 			Address:   address,
-			Balance:   chain.FormatDecimalAmount(usdcBalance.Amount, usdcBalance.Decimals),
-			Symbol:    usdcBalance.Symbol,
-			Token:     usdcBalance.Token,
-			Decimals:  usdcBalance.Decimals,
+			Balance:   chain.FormatDecimalAmount(balance.Amount, balance.Decimals),
+			Symbol:    balance.Symbol,
+			Token:     balance.Token,
+			Decimals:  balance.Decimals,
 			UpdatedAt: time.Now().UTC(),
+			Source:    source,
+		}
+		f.cache.Set(entry)
+		if token.Tracked || balance.Amount.Sign() > 0 {
+			entries = append(entries, entry)
 		}
-		f.cache.Set(usdcEntry)
-		entries = append(entries, usdcEntry)
 	}
-
-	return entries, stale, nil
+	return entries
 }
 
 // connectETHClient attempts to connect to the primary RPC, falling back to alternates on failure.
@@ -375,31 +411,47 @@ func (f *Fetcher) getCachedETHBalances(address string) ([]CacheEntry, bool, erro
 		metrics.Global.RecordCacheMiss()
 	}
 
-	// Check for USDC
-	usdcEntry, exists, age := f.cache.Get(chain.ETH, address, eth.USDCMainnet)
-	if exists {
-		metrics.Global.RecordCacheHit()
-		entries = append(entries, *usdcEntry)
-		if age > cache.DefaultStaleness {
-			stale = true
+	// Check for tokens; untracked ones only count when held
+	for _, token := range f.registryTokens() {
+		tokenEntry, exists, age := f.cache.Get(chain.ETH, address, token.Address)
+		switch {
+		case exists && (token.Tracked || isNonZeroBalance(tokenEntry.Balance)):
+			metrics.Global.RecordCacheHit()
+			entries = append(entries, *tokenEntry)
+			if age > cache.DefaultStaleness {
+				stale = true
+			}
+		case !exists && token.Tracked:
+			metrics.Global.RecordCacheMiss()
 		}
-	} else {
-		metrics.Global.RecordCacheMiss()
 	}
 
 	if len(entries) == 0 {
 		return nil, true, sigilerr.ErrCacheNotFound
 	}
 
-	return entries, stale, nil
+	return withSource(entries, SourceCache), stale, nil
 }
 
 // fetchBSV fetches BSV balances.
 func (f *Fetcher) fetchBSV(ctx context.Context, address string) ([]CacheEntry, bool, error) {
+	sources, err := f.balanceSources(chain.BSV)
+	if err != nil {
+		return nil, true, err
+	}
+	useCache := containsSource(sources, SourceCache)
+
 	// Trust very fresh cache entries (set by a recent tx send) over the
 	// network, which may not have indexed the transaction yet.
-	if entry, exists, age := f.cache.Get(chain.BSV, address, ""); exists && age < postSendCacheTrust {
-		return []CacheEntry{*entry}, false, nil
+	if entry, exists, age := f.cache.Get(chain.BSV, address, ""); useCache && exists && age < postSendCacheTrust {
+		return withSource([]CacheEntry{*entry}, SourceCache), false, nil
+	}
+
+	if !containsSource(sources, SourceWhatsOnChain) {
+		if useCache {
+			return f.getCachedBSVBalances(address)
+		}
+		return nil, true, fmt.Errorf("%w: no balance sources enabled for %s", ErrUnsupportedChain, chain.BSV)
 	}
 
 	entries := make([]CacheEntry, 0, 1)
@@ -410,6 +462,9 @@ func (f *Fetcher) fetchBSV(ctx context.Context, address string) ([]CacheEntry, b
 	// Fetch BSV balance
 	bsvBalance, err := client.GetNativeBalance(ctx, address)
 	if err != nil {
+		if !useCache {
+			return nil, true, err
+		}
 		// Fall back to cache
 		return f.getCachedBSVBalances(address)
 	}
@@ -429,6 +484,7 @@ func (f *Fetcher) fetchBSV(ctx context.Context, address string) ([]CacheEntry, b
 		Symbol:      bsvBalance.Symbol,
 		Decimals:    bsvBalance.Decimals,
 		UpdatedAt:   time.Now().UTC(),
+		Source:      SourceWhatsOnChain,
 	}
 	f.cache.Set(entry)
 	entries = append(entries, entry)
@@ -446,7 +502,107 @@ func (f *Fetcher) getCachedBSVBalances(address string) ([]CacheEntry, bool, erro
 	metrics.Global.RecordCacheHit()
 
 	stale := age > cache.DefaultStaleness
-	return []CacheEntry{*entry}, stale, nil
+	return withSource([]CacheEntry{*entry}, SourceCache), stale, nil
+}
+
+// fetchBTC fetches a BTC balance from the configured Esplora API, falling
+// back to the cache when the API is unreachable.
+func (f *Fetcher) fetchBTC(ctx context.Context, address string) ([]CacheEntry, bool, error) {
+	// Trust very fresh cache entries (set by a recent tx send) over the
+	// network, which may not have indexed the transaction yet.
+	if entry, exists, age := f.cache.Get(chain.BTC, address, ""); exists && age < postSendCacheTrust {
+		return withSource([]CacheEntry{*entry}, SourceCache), false, nil
+	}
+
+	factory := f.newBTCClient
+	if factory == nil {
+		factory = defaultBTCClientFactory
+	}
+	client, err := factory(&btc.ClientOptions{
+		API:     f.cfg.GetBTCAPI(),
+		Network: btc.Network(f.bsvNetworkString()),
+	})
+	if err != nil {
+		return nil, true, err
+	}
+
+	balance, err := client.GetNativeBalance(ctx, address)
+	if err != nil {
+		entry, exists, age := f.cache.Get(chain.BTC, address, "")
+		if !exists {
+			metrics.Global.RecordCacheMiss()
+			return nil, true, err
+		}
+		metrics.Global.RecordCacheHit()
+		return withSource([]CacheEntry{*entry}, SourceCache), age > cache.DefaultStaleness, nil
+	}
+
+	var unconfirmedStr string
+	if balance.Unconfirmed != nil && balance.Unconfirmed.Sign() != 0 {
+		unconfirmedStr = chain.FormatSignedDecimalAmount(balance.Unconfirmed, balance.Decimals)
+	}
+	entry := CacheEntry{
+		Chain:       chain.BTC,
+		Address:     address,
+		Balance:     chain.FormatDecimalAmount(balance.Amount, balance.Decimals),
+		Unconfirmed: unconfirmedStr,
+		Symbol:      balance.Symbol,
+		Decimals:    balance.Decimals,
+		UpdatedAt:   time.Now().UTC(),
+		Source:      SourceEsplora,
+	}
+	f.cache.Set(entry)
+	return []CacheEntry{entry}, false, nil
+}
+
+// fetchBCH fetches a BCH balance from the configured bch-api server, falling
+// back to the cache when the API is unreachable.
+func (f *Fetcher) fetchBCH(ctx context.Context, address string) ([]CacheEntry, bool, error) {
+	// Trust very fresh cache entries (set by a recent tx send) over the
+	// network, which may not have indexed the transaction yet.
+	if entry, exists, age := f.cache.Get(chain.BCH, address, ""); exists && age < postSendCacheTrust {
+		return withSource([]CacheEntry{*entry}, SourceCache), false, nil
+	}
+
+	factory := f.newBCHClient
+	if factory == nil {
+		factory = defaultBCHClientFactory
+	}
+	client, err := factory(&bch.ClientOptions{
+		API:     f.cfg.GetBCHAPI(),
+		Network: bch.Network(f.bsvNetworkString()),
+	})
+	if err != nil {
+		return nil, true, err
+	}
+
+	balance, err := client.GetNativeBalance(ctx, address)
+	if err != nil {
+		entry, exists, age := f.cache.Get(chain.BCH, address, "")
+		if !exists {
+			metrics.Global.RecordCacheMiss()
+			return nil, true, err
+		}
+		metrics.Global.RecordCacheHit()
+		return withSource([]CacheEntry{*entry}, SourceCache), age > cache.DefaultStaleness, nil
+	}
+
+	var unconfirmedStr string
+	if balance.Unconfirmed != nil && balance.Unconfirmed.Sign() != 0 {
+		unconfirmedStr = chain.FormatSignedDecimalAmount(balance.Unconfirmed, balance.Decimals)
+	}
+	entry := CacheEntry{
+		Chain:       chain.BCH,
+		Address:     address,
+		Balance:     chain.FormatDecimalAmount(balance.Amount, balance.Decimals),
+		Unconfirmed: unconfirmedStr,
+		Symbol:      balance.Symbol,
+		Decimals:    balance.Decimals,
+		UpdatedAt:   time.Now().UTC(),
+		Source:      SourceFullStack,
+	}
+	f.cache.Set(entry)
+	return []CacheEntry{entry}, false, nil
 }
 
 // fetchBSVBulk fetches balances for multiple BSV addresses using bulk API.
@@ -458,14 +614,20 @@ func (f *Fetcher) fetchBSVBulk(ctx context.Context, addresses []string) (map[str
 		return make(map[string][]CacheEntry), nil
 	}
 
+	sources, err := f.balanceSources(chain.BSV)
+	if err != nil {
+		return make(map[string][]CacheEntry), err
+	}
+	useCache := containsSource(sources, SourceCache)
+
 	// Check post-send cache trust for all addresses
 	addressesToFetch := make([]string, 0, len(addresses))
 	results := make(map[string][]CacheEntry)
 
 	for _, addr := range addresses {
-		if entry, exists, age := f.cache.Get(chain.BSV, addr, ""); exists && age < postSendCacheTrust {
+		if entry, exists, age := f.cache.Get(chain.BSV, addr, ""); useCache && exists && age < postSendCacheTrust {
 			// Use trusted fresh cache
-			results[addr] = []CacheEntry{*entry}
+			results[addr] = withSource([]CacheEntry{*entry}, SourceCache)
 		} else {
 			addressesToFetch = append(addressesToFetch, addr)
 		}
@@ -476,9 +638,17 @@ func (f *Fetcher) fetchBSVBulk(ctx context.Context, addresses []string) (map[str
 	}
 
 	// Bulk fetch remaining addresses
-	client := f.newBSVBalanceClient(ctx, &bsv.ClientOptions{Network: bsv.Network(f.bsvNetworkString())})
-	bulkBalances, err := client.GetBulkNativeBalance(ctx, addressesToFetch)
+	var bulkBalances map[string]*bsv.Balance
+	if containsSource(sources, SourceWhatsOnChain) {
+		client := f.newBSVBalanceClient(ctx, &bsv.ClientOptions{Network: bsv.Network(f.bsvNetworkString())})
+		bulkBalances, err = client.GetBulkNativeBalance(ctx, addressesToFetch)
+	} else {
+		err = fmt.Errorf("%w: %s disabled in balance_sources", ErrUnsupportedChain, SourceWhatsOnChain)
+	}
 	if err != nil {
+		if !useCache {
+			return results, err
+		}
 		// On error, fall back to cached data for all addresses
 		for _, addr := range addressesToFetch {
 			if cachedEntries, _, cacheErr := f.getCachedBSVBalances(addr); cacheErr == nil {
@@ -503,6 +673,7 @@ func (f *Fetcher) fetchBSVBulk(ctx context.Context, addresses []string) (map[str
 			Symbol:      balance.Symbol,
 			Decimals:    balance.Decimals,
 			UpdatedAt:   time.Now().UTC(),
+			Source:      SourceWhatsOnChain,
 		}
 
 		f.cache.Set(entry)
@@ -527,6 +698,9 @@ func (f *Fetcher) fetchBSVBulk(ctx context.Context, addresses []string) (map[str
 		}
 
 		// If individual fetch also fails, try to use cached data
+		if !useCache {
+			continue
+		}
 		if cachedEntries, _, cacheErr := f.getCachedBSVBalances(addr); cacheErr == nil {
 			results[addr] = cachedEntries
 		}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math/big"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,8 +13,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bch"
+	"github.com/mrz1836/sigil/internal/chain/btc"
 	"github.com/mrz1836/sigil/internal/chain/eth"
 	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/config"
 )
 
 var (
@@ -21,7 +25,7 @@ var (
 	errEtherscanFailed     = errors.New("etherscan failed")
 	errExpectedCanceledCtx = errors.New("expected canceled context")
 	errGetNativeNotImpl    = errors.New("GetNativeBalance not implemented")
-	errGetUSDCNotImpl      = errors.New("GetUSDCBalance not implemented")
+	errGetUSDCNotImpl      = errors.New("GetERC20Balance not implemented")
 )
 
 // Mock ConfigProvider for testing
@@ -31,6 +35,10 @@ type mockConfigProvider struct {
 	ethFallbackRPCs    []string
 	ethEtherscanAPIKey string
 	bsvNetwork         string
+	btcAPI             string
+	bchAPI             string
+	balanceSources     map[string][]string
+	ethTokens          []config.TokenConfig
 }
 
 func newMockConfigProvider() *mockConfigProvider {
@@ -40,6 +48,7 @@ func newMockConfigProvider() *mockConfigProvider {
 		ethFallbackRPCs:    []string{"https://eth-fallback.example.com"},
 		ethEtherscanAPIKey: "test-api-key",
 		bsvNetwork:         "main",
+		ethTokens:          config.Defaults().GetETHTokens(),
 	}
 }
 
@@ -50,6 +59,14 @@ func (m *mockConfigProvider) GetBSVNetwork() string {
 	return m.bsvNetwork
 }
 
+func (m *mockConfigProvider) GetBTCAPI() string {
+	return m.btcAPI
+}
+
+func (m *mockConfigProvider) GetBCHAPI() string {
+	return m.bchAPI
+}
+
 func (m *mockConfigProvider) GetETHProvider() string {
 	return m.ethProvider
 }
@@ -62,10 +79,27 @@ func (m *mockConfigProvider) GetETHFallbackRPCs() []string {
 	return m.ethFallbackRPCs
 }
 
+func (m *mockConfigProvider) GetETHChainID() int {
+	return 1
+}
+
 func (m *mockConfigProvider) GetETHEtherscanAPIKey() string {
 	return m.ethEtherscanAPIKey
 }
 
+func (m *mockConfigProvider) GetBalanceSources(chainName string) []string {
+	if sources, ok := m.balanceSources[chainName]; ok {
+		return sources
+	}
+	cfg := &config.Config{}
+	cfg.Networks.ETH.Provider = m.ethProvider
+	return cfg.GetBalanceSources(chainName)
+}
+
+func (m *mockConfigProvider) GetETHTokens() []config.TokenConfig {
+	return m.ethTokens
+}
+
 // TestNewFetcher tests the fetcher constructor.
 func TestNewFetcher(t *testing.T) {
 	t.Parallel()
@@ -100,15 +134,9 @@ func TestFetchForChain_Dispatch(t *testing.T) {
 		errType error
 	}{
 		{
-			name:    "BTC not supported",
-			chainID: chain.BTC,
-			address: "1BTC",
-			wantErr: false, // Returns nil, not error
-		},
-		{
-			name:    "BCH not supported",
-			chainID: chain.BCH,
-			address: "1BCH",
+			name:    "LTC not supported",
+			chainID: chain.LTC,
+			address: "LLTC",
 			wantErr: false, // Returns nil, not error
 		},
 		{
@@ -854,6 +882,7 @@ func TestFetchETHViaEtherscan_NoAPIKey(t *testing.T) {
 type mockETHBalanceClient struct {
 	getNativeBalanceFunc func(ctx context.Context, address string) (*eth.Balance, error)
 	getUSDCBalanceFunc   func(ctx context.Context, address string) (*eth.Balance, error)
+	tokenBalances        map[string]*big.Int // by symbol, for tokens other than USDC
 }
 
 func (m *mockETHBalanceClient) GetNativeBalance(ctx context.Context, address string) (*eth.Balance, error) {
@@ -863,10 +892,15 @@ func (m *mockETHBalanceClient) GetNativeBalance(ctx context.Context, address str
 	return nil, errGetNativeNotImpl
 }
 
-func (m *mockETHBalanceClient) GetUSDCBalance(ctx context.Context, address string) (*eth.Balance, error) {
-	if m.getUSDCBalanceFunc != nil {
+func (m *mockETHBalanceClient) GetERC20Balance(ctx context.Context, address string, token *eth.TokenMetadata) (*eth.Balance, error) {
+	if strings.EqualFold(token.Address, eth.USDCMainnet) && m.getUSDCBalanceFunc != nil {
 		return m.getUSDCBalanceFunc(ctx, address)
 	}
+	if m.tokenBalances != nil {
+		if amount, ok := m.tokenBalances[token.Symbol]; ok {
+			return &eth.Balance{Address: address, Amount: amount, Symbol: token.Symbol, Decimals: token.Decimals, Token: token.Address}, nil
+		}
+	}
 	return nil, errGetUSDCNotImpl
 }
 
@@ -879,3 +913,106 @@ func mustBigInt(value string) *big.Int {
 	}
 	return out
 }
+
+// mockBTCBalanceClient returns fixed BTC balances by address.
+type mockBTCBalanceClient struct {
+	balances map[string]*btc.Balance
+}
+
+func (m *mockBTCBalanceClient) GetNativeBalance(_ context.Context, address string) (*btc.Balance, error) {
+	if balance, ok := m.balances[address]; ok {
+		return balance, nil
+	}
+	return nil, errMissingBalance
+}
+
+func TestFetchBTC(t *testing.T) {
+	t.Parallel()
+
+	cfg := newMockConfigProvider()
+	cfg.btcAPI = "blockstream"
+	cfg.bsvNetwork = "test"
+	cache := newMockCacheProvider()
+	f := NewFetcher(cfg, cache)
+
+	var gotOpts *btc.ClientOptions
+	f.newBTCClient = func(opts *btc.ClientOptions) (btcBalanceClient, error) {
+		gotOpts = opts
+		return &mockBTCBalanceClient{balances: map[string]*btc.Balance{
+			"mabc": {Address: "mabc", Amount: big.NewInt(150000), Unconfirmed: big.NewInt(-2000), Symbol: "BTC", Decimals: 8},
+		}}, nil
+	}
+
+	entries, stale, err := f.FetchForChain(context.Background(), chain.BTC, "mabc")
+	require.NoError(t, err)
+	assert.False(t, stale)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "0.0015", entries[0].Balance)
+	assert.Equal(t, "-0.00002", entries[0].Unconfirmed)
+	assert.Equal(t, SourceEsplora, entries[0].Source)
+	assert.Equal(t, "blockstream", gotOpts.API)
+	assert.Equal(t, btc.NetworkTestnet, gotOpts.Network)
+
+	// A missing balance with no cached entry is an error
+	_, _, err = f.FetchForChain(context.Background(), chain.BTC, "mnone")
+	require.ErrorIs(t, err, errMissingBalance)
+}
+
+func TestFetchBTC_FallsBackToCache(t *testing.T) {
+	t.Parallel()
+
+	cache := newMockCacheProvider()
+	cache.Set(CacheEntry{Chain: chain.BTC, Address: "1abc", Balance: "0.25", UpdatedAt: time.Now().Add(-time.Hour)})
+	f := NewFetcher(newMockConfigProvider(), cache)
+	f.newBTCClient = func(_ *btc.ClientOptions) (btcBalanceClient, error) {
+		return &mockBTCBalanceClient{}, nil
+	}
+
+	entries, _, err := f.FetchForChain(context.Background(), chain.BTC, "1abc")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "0.25", entries[0].Balance)
+	assert.Equal(t, SourceCache, entries[0].Source)
+}
+
+type mockBCHBalanceClient struct {
+	balances map[string]*bch.Balance
+}
+
+func (m *mockBCHBalanceClient) GetNativeBalance(_ context.Context, address string) (*bch.Balance, error) {
+	if balance, ok := m.balances[address]; ok {
+		return balance, nil
+	}
+	return nil, errMissingBalance
+}
+
+func TestFetchBCH(t *testing.T) {
+	t.Parallel()
+
+	cfg := newMockConfigProvider()
+	cfg.bchAPI = "https://bch-api.example/v5"
+	cache := newMockCacheProvider()
+	f := NewFetcher(cfg, cache)
+
+	var gotOpts *bch.ClientOptions
+	f.newBCHClient = func(opts *bch.ClientOptions) (bchBalanceClient, error) {
+		gotOpts = opts
+		return &mockBCHBalanceClient{balances: map[string]*bch.Balance{
+			"1abc": {Address: "1abc", Amount: big.NewInt(250000), Symbol: "BCH", Decimals: 8},
+		}}, nil
+	}
+
+	entries, stale, err := f.FetchForChain(context.Background(), chain.BCH, "1abc")
+	require.NoError(t, err)
+	assert.False(t, stale)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "0.0025", entries[0].Balance)
+	assert.Empty(t, entries[0].Unconfirmed)
+	assert.Equal(t, SourceFullStack, entries[0].Source)
+	assert.Equal(t, "https://bch-api.example/v5", gotOpts.API)
+	assert.Equal(t, bch.NetworkMainnet, gotOpts.Network)
+
+	// A missing balance with no cached entry is an error
+	_, _, err = f.FetchForChain(context.Background(), chain.BCH, "1none")
+	require.ErrorIs(t, err, errMissingBalance)
+}
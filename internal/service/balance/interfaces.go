@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
 )
 
 // ConfigProvider provides configuration access.
@@ -11,9 +12,14 @@ import (
 type ConfigProvider interface {
 	GetETHRPC() string
 	GetETHFallbackRPCs() []string
+	GetETHChainID() int
 	GetETHProvider() string
 	GetETHEtherscanAPIKey() string
 	GetBSVNetwork() string
+	GetBTCAPI() string
+	GetBCHAPI() string
+	GetBalanceSources(chainName string) []string
+	GetETHTokens() []config.TokenConfig
 }
 
 // CacheProvider provides balance cache operations.
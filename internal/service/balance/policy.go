@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
 )
 
 // RefreshPolicy determines when to fetch fresh balance data vs using cached data.
@@ -118,11 +119,13 @@ func (p *RefreshPolicy) isNewlyCreatedAddress(addressMeta *AddressMetadata) bool
 func (p *RefreshPolicy) hasNonZeroBalance(chainID chain.ID, address string, nativeEntry *CacheEntry) bool {
 	hasBalance := isNonZeroBalance(nativeEntry.Balance)
 
-	// Check for token balances (ETH/USDC case)
+	// Check for built-in token balances (ETH case)
 	if chainID == chain.ETH {
-		usdcEntry, usdcExists, _ := p.cache.Get(chainID, address, "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48")
-		if usdcExists && isNonZeroBalance(usdcEntry.Balance) {
-			hasBalance = true
+		for _, token := range eth.KnownTokens() {
+			tokenEntry, exists, _ := p.cache.Get(chainID, address, token.Address)
+			if exists && isNonZeroBalance(tokenEntry.Balance) {
+				hasBalance = true
+			}
 		}
 	}
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/reqlog"
 )
 
 // ErrNoCachedBalance is returned when no cached balance exists for an address.
@@ -53,27 +54,45 @@ func NewService(cfg *Config) *Service {
 }
 
 // FetchBalance fetches balance for a single address.
-//
-//nolint:gocognit,gocyclo // Complex business logic for balance fetching with caching and refresh policy
 func (s *Service) FetchBalance(ctx context.Context, req *FetchRequest) (*FetchResult, error) {
+	if result, ok := s.cachedBalance(ctx, req); ok {
+		return result, nil
+	}
+	return s.fetchFromNetwork(ctx, req)
+}
+
+// cachedBalance returns the cached balances of an address when the refresh
+// policy says they are still current.
+func (s *Service) cachedBalance(ctx context.Context, req *FetchRequest) (*FetchResult, bool) {
+	// Check refresh policy (unless force refresh or the cache is disabled)
+	if s.policy == nil || req.ForceRefresh || s.force || !s.fetcher.sourceEnabled(req.ChainID, SourceCache) {
+		return nil, false
+	}
+	decision := s.policy.ShouldRefresh(req.ChainID, req.Address)
+	reqlog.Cache(ctx, "balances", string(req.ChainID), decision == CacheOK)
+	if decision != CacheOK {
+		return nil, false
+	}
+
 	result := &FetchResult{
 		ChainID: req.ChainID,
 		Address: req.Address,
 	}
+	cachedBalances := getCachedBalancesForAddress(req.ChainID, req.Address, s.cache, s.fetcher.registryTokens())
+	for _, cached := range cachedBalances {
+		result.Balances = append(result.Balances, cacheEntryToBalanceEntry(cached))
+	}
+	return result, true
+}
 
-	// Check refresh policy (unless force refresh)
-	if s.policy != nil && !req.ForceRefresh && !s.force {
-		decision := s.policy.ShouldRefresh(req.ChainID, req.Address)
-		if decision == CacheOK {
-			// Use cached data
-			cachedBalances := getCachedBalancesForAddress(req.ChainID, req.Address, s.cache)
-			for _, cached := range cachedBalances {
-				result.Balances = append(result.Balances, cacheEntryToBalanceEntry(cached))
-			}
-			result.Stale = false
-			return result, nil
-		}
+// fetchFromNetwork fetches the balances of an address from its providers,
+// falling back to cached balances marked stale when they fail.
+func (s *Service) fetchFromNetwork(ctx context.Context, req *FetchRequest) (*FetchResult, error) {
+	result := &FetchResult{
+		ChainID: req.ChainID,
+		Address: req.Address,
 	}
+	useCache := s.fetcher.sourceEnabled(req.ChainID, SourceCache)
 
 	// Fetch from network
 	fetchCtx := ctx
@@ -86,7 +105,10 @@ func (s *Service) FetchBalance(ctx context.Context, req *FetchRequest) (*FetchRe
 	entries, stale, err := s.fetcher.FetchForChain(fetchCtx, req.ChainID, req.Address)
 	if err != nil {
 		// On error, try to return cached data
-		cachedBalances := getCachedBalancesForAddress(req.ChainID, req.Address, s.cache)
+		var cachedBalances []CacheEntry
+		if useCache {
+			cachedBalances = getCachedBalancesForAddress(req.ChainID, req.Address, s.cache, s.fetcher.registryTokens())
+		}
 		if len(cachedBalances) > 0 {
 			for _, cached := range cachedBalances {
 				result.Balances = append(result.Balances, cacheEntryToBalanceEntry(cached))
@@ -144,7 +166,7 @@ func (s *Service) FetchBalances(ctx context.Context, req *FetchBatchRequest) (*F
 	// Apply refresh policy to BSV addresses before bulk fetch
 	bsvAddressesToFetch := make([]string, 0, len(bsvAddresses))
 	for _, addr := range bsvAddresses {
-		needsFetch, cachedResult := s.processBSVAddress(addr, req.ForceRefresh)
+		needsFetch, cachedResult := s.processBSVAddress(ctx, addr, req.ForceRefresh)
 		if needsFetch {
 			bsvAddressesToFetch = append(bsvAddressesToFetch, addr)
 		} else if cachedResult != nil {
@@ -214,30 +236,17 @@ func (s *Service) FetchBalances(ctx context.Context, req *FetchBatchRequest) (*F
 			})
 		}
 
-		maxConcurrent := req.MaxConcurrent
-		if maxConcurrent <= 0 {
-			maxConcurrent = 8
+		limiter := req.Limiter
+		if limiter == nil {
+			limiter = NewLimiter(LimiterConfig{Max: req.MaxConcurrent})
 		}
 
-		sem := make(chan struct{}, maxConcurrent)
-
 		for _, addr := range otherAddresses {
 			wg.Add(1)
 
 			go func(input AddressInput) {
 				defer wg.Done()
 
-				// Acquire semaphore
-				select {
-				case sem <- struct{}{}:
-				case <-ctx.Done():
-					return
-				}
-				defer func() {
-					<-sem
-				}()
-
-				// Fetch balance
 				fetchReq := &FetchRequest{
 					ChainID:      input.ChainID,
 					Address:      input.Address,
@@ -245,7 +254,18 @@ func (s *Service) FetchBalances(ctx context.Context, req *FetchBatchRequest) (*F
 					Timeout:      req.Timeout,
 				}
 
-				result, err := s.FetchBalance(ctx, fetchReq)
+				// Cached balances skip the limiter, so they neither wait for
+				// a slot nor count as provider responses
+				result, cached := s.cachedBalance(ctx, fetchReq)
+				var err error
+				if !cached {
+					permit, acquireErr := limiter.Acquire(ctx)
+					if acquireErr != nil {
+						return
+					}
+					result, err = s.fetchFromNetwork(ctx, fetchReq)
+					limiter.Release(permit, fetchOutcome(result, err))
+				}
 
 				mu.Lock()
 				defer mu.Unlock()
@@ -277,6 +297,15 @@ func (s *Service) FetchBalances(ctx context.Context, req *FetchBatchRequest) (*F
 	return batchResult, nil
 }
 
+// fetchOutcome is the provider error behind a fetch, including one hidden
+// behind a stale cached fallback.
+func fetchOutcome(result *FetchResult, err error) error {
+	if err == nil && result != nil {
+		return result.Error
+	}
+	return err
+}
+
 // FetchCachedBalances fetches balances from cache only, without network calls.
 // Returns cached data with stale markers. Returns error if no cache exists for any address.
 func (s *Service) FetchCachedBalances(_ context.Context, req *FetchBatchRequest) (*FetchBatchResult, error) {
@@ -291,7 +320,7 @@ func (s *Service) FetchCachedBalances(_ context.Context, req *FetchBatchRequest)
 		}
 
 		// Get cached balances
-		cachedBalances := getCachedBalancesForAddress(addr.ChainID, addr.Address, s.cache)
+		cachedBalances := getCachedBalancesForAddress(addr.ChainID, addr.Address, s.cache, s.fetcher.registryTokens())
 
 		if len(cachedBalances) == 0 {
 			// No cache for this address
@@ -316,9 +345,9 @@ func (s *Service) FetchCachedBalances(_ context.Context, req *FetchBatchRequest)
 
 // processBSVAddress determines if a BSV address needs fetching or can use cached data.
 // Returns (needsFetch, cachedResult).
-func (s *Service) processBSVAddress(addr string, forceRefresh bool) (bool, *FetchResult) {
-	// Skip policy check if forcing refresh or no policy configured
-	if s.policy == nil || forceRefresh || s.force {
+func (s *Service) processBSVAddress(ctx context.Context, addr string, forceRefresh bool) (bool, *FetchResult) {
+	// Skip policy check if forcing refresh, no policy configured, or the cache is disabled
+	if s.policy == nil || forceRefresh || s.force || !s.fetcher.sourceEnabled("bsv", SourceCache) {
 		return true, nil
 	}
 
@@ -326,11 +355,13 @@ func (s *Service) processBSVAddress(addr string, forceRefresh bool) (bool, *Fetc
 	decision := s.policy.ShouldRefresh("bsv", addr)
 	if decision != CacheOK {
 		// RefreshRequired
+		reqlog.Cache(ctx, "balances", "bsv", false)
 		return true, nil
 	}
 
 	// Use cached data
-	cachedBalances := getCachedBalancesForAddress("bsv", addr, s.cache)
+	cachedBalances := getCachedBalancesForAddress("bsv", addr, s.cache, nil)
+	reqlog.Cache(ctx, "balances", "bsv", len(cachedBalances) > 0)
 	if len(cachedBalances) == 0 {
 		// No cache exists, need to fetch
 		return true, nil
@@ -348,8 +379,13 @@ func (s *Service) processBSVAddress(addr string, forceRefresh bool) (bool, *Fetc
 }
 
 // cacheEntryToBalanceEntry converts a CacheEntry to a BalanceEntry.
+// Entries without a source were read back from the cache.
 func cacheEntryToBalanceEntry(entry CacheEntry) BalanceEntry {
 	age := time.Since(entry.UpdatedAt)
+	source := entry.Source
+	if source == "" {
+		source = SourceCache
+	}
 	return BalanceEntry{
 		Chain:       entry.Chain,
 		Address:     entry.Address,
@@ -360,5 +396,6 @@ func cacheEntryToBalanceEntry(entry CacheEntry) BalanceEntry {
 		Decimals:    entry.Decimals,
 		Stale:       age > cache.DefaultStaleness,
 		UpdatedAt:   entry.UpdatedAt,
+		Source:      source,
 	}
 }
@@ -420,7 +420,7 @@ func TestGetCachedBalancesForAddress(t *testing.T) {
 	})
 
 	// Test the cache retrieval function directly
-	cached := getCachedBalancesForAddress(chain.BSV, addr, cache)
+	cached := getCachedBalancesForAddress(chain.BSV, addr, cache, nil)
 
 	if len(cached) != 1 {
 		t.Fatalf("expected 1 cached balance, got %d", len(cached))
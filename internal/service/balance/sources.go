@@ -0,0 +1,96 @@
+package balance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// Balance sources, as named in networks.<chain>.balance_sources.
+const (
+	SourceEtherscan    = "etherscan"
+	SourceRPC          = "rpc"
+	SourceWhatsOnChain = "whatsonchain"
+	SourceCache        = "cache"
+
+	// SourceEsplora marks BTC balances read from the networks.btc.api
+	// server. BTC has no configurable read order; the cache is its fallback.
+	SourceEsplora = "esplora"
+
+	// SourceFullStack marks BCH balances read from the networks.bch.api
+	// server, which likewise falls back only to the cache.
+	SourceFullStack = "fullstack"
+)
+
+// ErrUnknownBalanceSource is returned when balance_sources names a source the chain does not have.
+var ErrUnknownBalanceSource = errors.New("unknown balance source")
+
+// chainBalanceSources lists the sources each chain supports.
+//
+//nolint:gochecknoglobals // Read-only lookup table
+var chainBalanceSources = map[chain.ID][]string{
+	chain.ETH: {SourceEtherscan, SourceRPC, SourceCache},
+	chain.BSV: {SourceWhatsOnChain, SourceCache},
+}
+
+// balanceSources returns the configured read order for a chain, normalized
+// and de-duplicated. "woc" is accepted for whatsonchain. Without a config
+// every supported source is used in its default order.
+func (f *Fetcher) balanceSources(chainID chain.ID) ([]string, error) {
+	supported := chainBalanceSources[chainID]
+	configured := supported
+	if f != nil && f.cfg != nil {
+		configured = f.cfg.GetBalanceSources(string(chainID))
+	}
+
+	sources := make([]string, 0, len(configured))
+	seen := make(map[string]bool, len(configured))
+	for _, name := range configured {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "woc" {
+			name = SourceWhatsOnChain
+		}
+		if !containsSource(supported, name) {
+			return nil, sigilerr.WithSuggestion(
+				fmt.Errorf("%w: %q for %s", ErrUnknownBalanceSource, name, chainID),
+				fmt.Sprintf("networks.%s.balance_sources accepts: %s", chainID, strings.Join(supported, ", ")),
+			)
+		}
+		if !seen[name] {
+			seen[name] = true
+			sources = append(sources, name)
+		}
+	}
+	return sources, nil
+}
+
+// sourceEnabled reports whether a source is in the chain's read order.
+// Chains without configurable sources always fall back to the cache. An
+// invalid configuration enables nothing; fetches report the error.
+func (f *Fetcher) sourceEnabled(chainID chain.ID, source string) bool {
+	if _, ok := chainBalanceSources[chainID]; !ok {
+		return source == SourceCache
+	}
+	sources, err := f.balanceSources(chainID)
+	return err == nil && containsSource(sources, source)
+}
+
+func containsSource(sources []string, source string) bool {
+	for _, s := range sources {
+		if s == source {
+			return true
+		}
+	}
+	return false
+}
+
+// withSource labels entries with the source that served them.
+func withSource(entries []CacheEntry, source string) []CacheEntry {
+	for i := range entries {
+		entries[i].Source = source
+	}
+	return entries
+}
@@ -0,0 +1,171 @@
+package balance
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+)
+
+func TestBalanceSources(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		chainID    chain.ID
+		configured []string
+		expected   []string
+		wantErr    bool
+	}{
+		{"eth default", chain.ETH, nil, []string{SourceEtherscan, SourceRPC, SourceCache}, false},
+		{"bsv default", chain.BSV, nil, []string{SourceWhatsOnChain, SourceCache}, false},
+		{"normalized and deduplicated", chain.ETH, []string{" RPC", "cache", "rpc"}, []string{SourceRPC, SourceCache}, false},
+		{"woc alias", chain.BSV, []string{"woc"}, []string{SourceWhatsOnChain}, false},
+		{"unknown source", chain.ETH, []string{"rpc", "infura"}, nil, true},
+		{"source from another chain", chain.BSV, []string{"etherscan"}, nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := newMockConfigProvider()
+			if tc.configured != nil {
+				cfg.balanceSources = map[string][]string{string(tc.chainID): tc.configured}
+			}
+			f := NewFetcher(cfg, newMockCacheProvider())
+
+			got, err := f.balanceSources(tc.chainID)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrUnknownBalanceSource)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestFetchETH_ConfiguredSourceOrder(t *testing.T) {
+	t.Parallel()
+
+	cache := newMockCacheProvider()
+	cache.Set(CacheEntry{Chain: chain.ETH, Address: "0x1234", Balance: "1.0", Symbol: "ETH", UpdatedAt: time.Now().Add(-time.Hour)})
+
+	tests := []struct {
+		name          string
+		sources       []string
+		expectCalls   []string
+		expectSource  string
+		expectFailure bool
+	}{
+		{"rpc then cache", []string{"rpc", "cache"}, []string{"rpc"}, SourceCache, false},
+		{"rpc only skips etherscan and cache", []string{"rpc"}, []string{"rpc"}, "", true},
+		{"etherscan then rpc", []string{"etherscan", "rpc"}, []string{"etherscan", "rpc"}, SourceRPC, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := newMockConfigProvider()
+			cfg.balanceSources = map[string][]string{"eth": tc.sources}
+			f := NewFetcher(cfg, cache)
+
+			var calls []string
+			f.fetchETHViaRPCOverride = func(_ context.Context, address string) ([]CacheEntry, bool, error) {
+				calls = append(calls, "rpc")
+				if tc.expectSource == SourceRPC {
+					return []CacheEntry{{Chain: chain.ETH, Address: address, Source: SourceRPC}}, false, nil
+				}
+				return nil, true, errRPCFailed
+			}
+			f.fetchETHViaEtherscanOverride = func(_ context.Context, _, _ string) ([]CacheEntry, bool, error) {
+				calls = append(calls, "etherscan")
+				return nil, true, errEtherscanFailed
+			}
+
+			entries, _, err := f.fetchETH(context.Background(), "0x1234")
+			assert.Equal(t, tc.expectCalls, calls)
+			if tc.expectFailure {
+				require.ErrorIs(t, err, errRPCFailed)
+				return
+			}
+			require.NoError(t, err)
+			require.NotEmpty(t, entries)
+			assert.Equal(t, tc.expectSource, entries[0].Source)
+		})
+	}
+}
+
+func TestFetchBSV_CacheDisabled(t *testing.T) {
+	t.Parallel()
+
+	cache := newMockCacheProvider()
+	cache.Set(CacheEntry{Chain: chain.BSV, Address: "1abc", Balance: "0.5", UpdatedAt: time.Now()})
+
+	cfg := newMockConfigProvider()
+	cfg.balanceSources = map[string][]string{"bsv": {"whatsonchain"}}
+	f := NewFetcher(cfg, cache)
+	f.newBSVClient = func(_ context.Context, _ *bsv.ClientOptions) bsvBalanceClient {
+		return &mockBSVBalanceClient{}
+	}
+
+	// Neither the post-send trust window nor the error fallback may use the cache
+	_, _, err := f.fetchBSV(context.Background(), "1abc")
+	require.ErrorIs(t, err, errMissingBalance)
+
+	results, err := f.fetchBSVBulk(context.Background(), []string{"1abc"})
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestFetchBSV_SourceLabels(t *testing.T) {
+	t.Parallel()
+
+	cache := newMockCacheProvider()
+	f := NewFetcher(newMockConfigProvider(), cache)
+	f.newBSVClient = func(_ context.Context, _ *bsv.ClientOptions) bsvBalanceClient {
+		return &mockBSVBalanceClient{bulkBalances: map[string]*bsv.Balance{
+			"1abc": {Address: "1abc", Amount: big.NewInt(1000), Symbol: "BSV", Decimals: 8},
+		}}
+	}
+
+	entries, _, err := f.fetchBSV(context.Background(), "1abc")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, SourceWhatsOnChain, entries[0].Source)
+
+	// The fresh entry is now inside the post-send trust window
+	entries, _, err = f.fetchBSV(context.Background(), "1abc")
+	require.NoError(t, err)
+	assert.Equal(t, SourceCache, entries[0].Source)
+}
+
+func TestService_FetchBalance_CacheDisabledNoFallback(t *testing.T) {
+	t.Parallel()
+
+	cache := newMockCacheProvider()
+	cache.Set(CacheEntry{Chain: chain.ETH, Address: "0x1234", Balance: "1.0", UpdatedAt: time.Now().Add(-time.Hour)})
+
+	cfg := newMockConfigProvider()
+	cfg.balanceSources = map[string][]string{"eth": {"rpc"}}
+	svc := NewService(&Config{ConfigProvider: cfg, CacheProvider: cache})
+	svc.fetcher.fetchETHViaRPCOverride = func(_ context.Context, _ string) ([]CacheEntry, bool, error) {
+		return nil, true, errRPCFailed
+	}
+
+	_, err := svc.FetchBalance(context.Background(), &FetchRequest{ChainID: chain.ETH, Address: "0x1234"})
+	require.ErrorIs(t, err, errRPCFailed)
+}
+
+func TestCacheEntryToBalanceEntry_Source(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, SourceCache, cacheEntryToBalanceEntry(CacheEntry{}).Source)
+	assert.Equal(t, SourceRPC, cacheEntryToBalanceEntry(CacheEntry{Source: SourceRPC}).Source)
+}
@@ -24,6 +24,8 @@ type BalanceEntry struct {
 	Decimals    int
 	Stale       bool
 	UpdatedAt   time.Time
+	// Source names the balance source that served the value, e.g. "rpc" or "cache".
+	Source string
 }
 
 // FetchRequest represents a request to fetch balances for a single address.
@@ -45,9 +47,14 @@ type FetchResult struct {
 
 // FetchBatchRequest represents a request to fetch balances for multiple addresses concurrently.
 type FetchBatchRequest struct {
-	Addresses        []AddressInput
-	ForceRefresh     bool
-	MaxConcurrent    int
+	Addresses    []AddressInput
+	ForceRefresh bool
+	// MaxConcurrent is the fixed number of parallel fetches when Limiter
+	// is nil; zero uses DefaultMaxConcurrent.
+	MaxConcurrent int
+	// Limiter bounds parallel fetches of chains without a bulk API in
+	// place of MaxConcurrent, such as an adaptive one from NewLimiter.
+	Limiter          *Limiter
 	Timeout          time.Duration
 	ProgressCallback ProgressCallback
 }
@@ -93,6 +100,9 @@ type CacheEntry struct {
 	Token       string
 	Decimals    int
 	UpdatedAt   time.Time
+	// Source is set on freshly fetched entries; entries read back from the
+	// cache leave it empty.
+	Source string
 }
 
 // AddressMetadata contains metadata about an address for refresh policy decisions.
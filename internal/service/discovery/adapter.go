@@ -51,3 +51,11 @@ type utxoChainClientAdapter struct {
 func (a *utxoChainClientAdapter) ListUTXOs(ctx context.Context, address string) ([]chain.UTXO, error) {
 	return a.client.ListUTXOs(ctx, address)
 }
+
+// CoinbaseStatus implements utxostore.CoinbaseClient when the wrapped client does.
+func (a *utxoChainClientAdapter) CoinbaseStatus(ctx context.Context, txid string) (bool, uint32, error) {
+	if cc, ok := a.client.(utxostore.CoinbaseClient); ok {
+		return cc.CoinbaseStatus(ctx, txid)
+	}
+	return false, 0, utxostore.ErrCoinbaseUnsupported
+}
@@ -91,6 +91,11 @@ func (a *bsvRefreshAdapter) ListUTXOs(ctx context.Context, address string) ([]ch
 	return result, nil
 }
 
+// CoinbaseStatus reports whether a transaction is a coinbase and its confirmations.
+func (a *bsvRefreshAdapter) CoinbaseStatus(ctx context.Context, txid string) (bool, uint32, error) {
+	return a.client.CoinbaseStatus(ctx, txid)
+}
+
 // RefreshAddress performs chain-specific address refresh.
 func (s *Service) refreshAddress(ctx context.Context, chainID chain.ID, address string) error {
 	switch chainID {
@@ -0,0 +1,138 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// SendBatch pays every recipient of req. BSV pays them all from one
+// transaction; ETH sends one transaction per recipient from a single client,
+// so they take sequential nonces. Results are in recipient order. If an ETH
+// send fails part way, the results of the transactions already broadcast are
+// returned along with the error.
+func (s *Service) SendBatch(ctx context.Context, req *SendRequest) ([]*SendResult, error) {
+	if req.ChainID == chain.ETH && len(req.Payments) > 0 {
+		if err := checkPayments(req); err != nil {
+			return nil, err
+		}
+		return s.sendETHBatch(ctx, req)
+	}
+
+	result, err := s.Send(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return []*SendResult{result}, nil
+}
+
+// sendETHBatch sends one ETH transaction per recipient. Sharing one client
+// lets its nonce manager hand out sequential nonces before the earlier
+// transactions are visible to the RPC node.
+func (s *Service) sendETHBatch(ctx context.Context, req *SendRequest) ([]*SendResult, error) {
+	payments := req.AllPayments()
+	for i := range payments {
+		to, err := NormalizeETHRecipient(payments[i].To, req.AllowNonChecksum)
+		if err != nil {
+			return nil, err
+		}
+		payments[i].To = to
+	}
+
+	client, err := s.newETHClient()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	results := make([]*SendResult, 0, len(payments))
+	nonce := req.Nonce
+	for i, p := range payments {
+		single := *req
+		single.To, single.AmountStr, single.Payments, single.Nonce = p.To, p.AmountStr, nil, nonce
+
+		result, sendErr := s.sendETHWith(ctx, client, &single)
+		if sendErr != nil {
+			if s.logger != nil {
+				s.logger.Error("eth batch: payment %d of %d failed: %v", i+1, len(payments), sendErr)
+			}
+			return results, fmt.Errorf("payment %d of %d to %s: %w", i+1, len(payments), p.To, sendErr)
+		}
+		if result.DryRun() {
+			// A dry run releases its nonce, so the next preview takes the one after it
+			next := result.Preview.Nonce + 1
+			nonce = &next
+			results = append(results, result)
+			continue
+		}
+		s.recordJournal(&single, result)
+		s.clearIntent(&single, result)
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// checkPayments rejects a multi-recipient request on a chain that cannot
+// batch payments, or one that sweeps the balance, and a split sweep that is
+// not a BSV sweep.
+func checkPayments(req *SendRequest) error {
+	if len(req.Split) > 0 {
+		return checkSplit(req)
+	}
+	if len(req.Payments) == 0 {
+		return nil
+	}
+	if req.ChainID != chain.BSV && req.ChainID != chain.ETH {
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("multi-recipient sends are only supported for BSV and ETH, not %s", req.ChainID),
+		)
+	}
+	for _, p := range req.AllPayments() {
+		if IsAmountAll(p.AmountStr) {
+			return sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				"'all' cannot be used in a multi-recipient send; give each recipient an amount",
+			)
+		}
+	}
+	return nil
+}
+
+// checkSplit rejects a split sweep that is not a BSV sweep of the balance
+// to Split, or that also has Payments.
+func checkSplit(req *SendRequest) error {
+	switch {
+	case req.ChainID != chain.BSV:
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("split sweeps are only supported for BSV, not %s", req.ChainID),
+		)
+	case !req.SweepAll() || len(req.Payments) > 0 || req.To != req.Split[0].To:
+		return sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"a split sweep sends 'all' to its split recipients and has no other payments",
+		)
+	}
+	return nil
+}
+
+// PaymentsTotal parses the amount of every payment with parse and returns
+// their sum in the smallest unit.
+func PaymentsTotal(parse func(string) (*big.Int, error), payments []Payment) (*big.Int, error) {
+	total := new(big.Int)
+	for _, p := range payments {
+		amount, err := parse(p.AmountStr)
+		if err != nil {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("invalid amount: %s", p.AmountStr),
+			)
+		}
+		total.Add(total, amount)
+	}
+	return total, nil
+}
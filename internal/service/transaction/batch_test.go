@@ -0,0 +1,129 @@
+package transaction
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestSendBatch_Rejections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		req  *SendRequest
+		want error
+	}{
+		{
+			name: "sweep with payments",
+			req: &SendRequest{
+				ChainID: chain.BSV, To: validBSVAddress, AmountStr: "all",
+				Payments: []Payment{{To: validBSVAddress, AmountStr: "0.001"}},
+			},
+			want: sigilerr.ErrInvalidInput,
+		},
+		{
+			name: "sweep in a later payment",
+			req: &SendRequest{
+				ChainID: chain.ETH, To: validETHAddress, AmountStr: "0.1",
+				Payments: []Payment{{To: validETHAddress, AmountStr: "ALL"}},
+			},
+			want: sigilerr.ErrInvalidInput,
+		},
+		{
+			name: "unsupported chain",
+			req: &SendRequest{
+				ChainID: chain.BTC, To: validBSVAddress, AmountStr: "0.001",
+				Payments: []Payment{{To: validBSVAddress, AmountStr: "0.001"}},
+			},
+			want: sigilerr.ErrInvalidInput,
+		},
+		{
+			name: "split sweep on ETH",
+			req: &SendRequest{
+				ChainID: chain.ETH, To: validETHAddress, AmountStr: "all",
+				Split: []Payment{{To: validETHAddress, AmountStr: "60%"}, {To: validETHAddress, AmountStr: "40%"}},
+			},
+			want: sigilerr.ErrInvalidInput,
+		},
+		{
+			name: "split without sweep",
+			req: &SendRequest{
+				ChainID: chain.BSV, To: validBSVAddress, AmountStr: "0.1",
+				Split: []Payment{{To: validBSVAddress, AmountStr: "60%"}, {To: validBSVAddress, AmountStr: "40%"}},
+			},
+			want: sigilerr.ErrInvalidInput,
+		},
+		{
+			name: "bad ETH recipient stops before any send",
+			req: &SendRequest{
+				ChainID: chain.ETH, To: validETHAddress, AmountStr: "0.1",
+				Payments: []Payment{{To: strings.ToLower(validETHAddress), AmountStr: "0.2"}},
+			},
+			want: sigilerr.ErrInvalidChecksum,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			service := NewService(&Config{
+				Config:  newMockConfigProvider(),
+				Storage: newMockStorageProvider(),
+				Logger:  newMockLogWriter(),
+			})
+
+			results, err := service.SendBatch(context.Background(), tc.req)
+			require.ErrorIs(t, err, tc.want)
+			assert.Empty(t, results)
+		})
+	}
+}
+
+func TestSend_RejectsETHPayments(t *testing.T) {
+	t.Parallel()
+
+	service := NewService(&Config{
+		Config:  newMockConfigProvider(),
+		Storage: newMockStorageProvider(),
+		Logger:  newMockLogWriter(),
+	})
+
+	_, err := service.Send(context.Background(), &SendRequest{
+		ChainID: chain.ETH, To: validETHAddress, AmountStr: "0.1",
+		Payments: []Payment{{To: validETHAddress, AmountStr: "0.2"}},
+	})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestSendRequest_AllPayments(t *testing.T) {
+	t.Parallel()
+
+	req := &SendRequest{
+		To: "a", AmountStr: "1",
+		Payments: []Payment{{To: "b", AmountStr: "2"}},
+	}
+	assert.Equal(t, []Payment{{To: "a", AmountStr: "1"}, {To: "b", AmountStr: "2"}}, req.AllPayments())
+	assert.Len(t, req.Payments, 1, "AllPayments must not modify the request")
+}
+
+func TestPaymentsTotal(t *testing.T) {
+	t.Parallel()
+
+	parse := func(s string) (*big.Int, error) { return parseDecimalAmount(s, 8) }
+
+	total, err := PaymentsTotal(parse, []Payment{{AmountStr: "0.001"}, {AmountStr: "0.0005"}})
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(150000), total)
+
+	_, err = PaymentsTotal(parse, []Payment{{AmountStr: "0.001"}, {AmountStr: "abc"}})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
@@ -0,0 +1,51 @@
+package transaction
+
+import (
+	"context"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bch"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// NewBCHClient creates a BCH client for the configured API on the given
+// wallet network ("main" or "test"); an empty network falls back to config.
+func NewBCHClient(cfg ConfigProvider, network string, logger LogWriter) (*bch.Client, error) {
+	if network == "" {
+		network = cfg.GetBSVNetwork()
+	}
+	opts := &bch.ClientOptions{
+		API:     cfg.GetBCHAPI(),
+		Network: bch.Network(network),
+	}
+	if logger != nil {
+		opts.Logger = logger
+	}
+	return bch.NewClient(opts)
+}
+
+// sendBCH handles the Bitcoin Cash transaction flow (see sendUTXOChain).
+func (s *Service) sendBCH(ctx context.Context, req *SendRequest) (*SendResult, error) {
+	client, err := NewBCHClient(s.config, req.Network, s.logger)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendUTXOChain(ctx, req, &utxoSendChain{
+		id:           chain.BCH,
+		network:      string(client.Network()),
+		client:       client,
+		checkFeeRate: CheckBTCFeeRate,
+		sweepAmount:  bch.CalculateSweepAmount,
+		estimateFee:  bch.EstimateFeeForTx,
+	})
+}
+
+// AggregateBCHUTXOs fetches UTXOs from all wallet addresses concurrently and merges them.
+func AggregateBCHUTXOs(ctx context.Context, client *bch.Client, addresses []wallet.Address) ([]chain.UTXO, error) {
+	return aggregateUTXOs(ctx, client, addresses)
+}
+
+// FilterSpentBCHUTXOs removes UTXOs the local store knows are spent or immature.
+func FilterSpentBCHUTXOs(utxos []chain.UTXO, store UTXOProvider) []chain.UTXO {
+	return filterSpentUTXOs(chain.BCH, utxos, store)
+}
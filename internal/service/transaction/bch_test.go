@@ -0,0 +1,108 @@
+package transaction
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bch"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newBCHTestServer serves one UTXO per CashAddr in utxos and accepts any
+// broadcast, echoing a txid.
+func newBCHTestServer(t *testing.T, utxos map[string]uint64) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rawtransactions/sendRawTransaction" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			sum := sha256.Sum256(body)
+			_, _ = w.Write([]byte(`["` + hex.EncodeToString(sum[:]) + `"]`))
+		case strings.HasPrefix(r.URL.Path, "/electrumx/utxos/"):
+			addr := strings.TrimPrefix(r.URL.Path, "/electrumx/utxos/")
+			if amount, ok := utxos[addr]; ok {
+				_, _ = w.Write([]byte(`{"success":true,"utxos":[{"height":1,"tx_hash":"` + strings.Repeat("ab", 32) +
+					`","tx_pos":0,"value":` + strconv.FormatUint(amount, 10) + `}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"success":true,"utxos":[]}`))
+		case r.URL.Path == "/blockchain/getBlockCount":
+			_, _ = w.Write([]byte("10"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSendBCH_SweepAll(t *testing.T) {
+	t.Parallel()
+
+	seed := getTestSeed(t)
+	addr, err := wallet.DeriveAddress(seed, wallet.ChainBCH, 0, 0)
+	require.NoError(t, err)
+	cashAddr, err := bch.ToCashAddr(addr.Address, bch.NetworkMainnet)
+	require.NoError(t, err)
+
+	server := newBCHTestServer(t, map[string]uint64{cashAddr: 100000})
+	cfg := newMockConfigProvider()
+	cfg.home = t.TempDir()
+	cfg.bchAPI = server.URL
+
+	service := NewService(&Config{Config: cfg, Storage: newMockStorageProvider(), Logger: newMockLogWriter()})
+	result, err := service.Send(context.Background(), &SendRequest{
+		ChainID:     chain.BCH,
+		To:          "bitcoincash:qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2h",
+		AmountStr:   "all",
+		Wallet:      "test",
+		FromAddress: addr.Address,
+		Addresses:   []wallet.Address{*addr},
+		Seed:        seed,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, chain.BCH, result.ChainID)
+	assert.Len(t, result.Hash, 64)
+	assert.Equal(t, 1, result.UTXOsSpent)
+	assert.Equal(t, uint64(bch.DefaultFeeRate), result.FeeRate)
+	require.NotNil(t, result.Changes)
+	require.Len(t, result.Changes.UTXOsConsumed, 1)
+	assert.Contains(t, result.Amount, "(sweep all)")
+}
+
+func TestSendBCH_Rejects(t *testing.T) {
+	t.Parallel()
+
+	server := newBCHTestServer(t, nil)
+	cfg := newMockConfigProvider()
+	cfg.home = t.TempDir()
+	cfg.bchAPI = server.URL
+	service := NewService(&Config{Config: cfg, Storage: newMockStorageProvider(), Logger: newMockLogWriter()})
+
+	_, err := service.Send(context.Background(), &SendRequest{
+		ChainID:   chain.BCH,
+		To:        "bchtest:qp63uahgrxged4z5jswyt5dn5v3lzsem6cq85x00dt",
+		AmountStr: "0.001",
+	})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidAddress, "testnet recipient on a mainnet wallet")
+
+	_, err = service.Send(context.Background(), &SendRequest{
+		ChainID:   chain.BCH,
+		To:        "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		AmountStr: "0.001",
+		Addresses: []wallet.Address{{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"}},
+	})
+	require.ErrorIs(t, err, sigilerr.ErrInsufficientFunds)
+}
@@ -3,13 +3,16 @@ package transaction
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"path/filepath"
+	"strconv"
 
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/cosign"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
@@ -27,20 +30,29 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 	if network == "" {
 		network = s.config.GetBSVNetwork()
 	}
-	if err := bsv.ValidateBase58CheckAddressForNetwork(req.To, bsv.Network(network)); err != nil {
-		return nil, sigilerr.WithSuggestion(
-			sigilerr.ErrInvalidAddress,
-			fmt.Sprintf("invalid BSV %s address: %s", network, req.To),
-		)
+	if err := chain.ValidateAddressOnNetwork(chain.BSV, network, req.To); err != nil {
+		return nil, InvalidAddressError(err)
+	}
+	for _, p := range append(req.Payments, req.Split...) {
+		if err := chain.ValidateAddressOnNetwork(chain.BSV, network, p.To); err != nil {
+			return nil, InvalidAddressError(err)
+		}
+	}
+
+	coinSelection, err := s.bsvCoinSelection(req)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create BSV client
 	opts := &bsv.ClientOptions{
-		APIKey:      s.config.GetBSVAPIKey(),
-		Network:     bsv.Network(network),
-		Logger:      s.logger,
-		FeeStrategy: bsv.FeeStrategy(s.config.GetBSVFeeStrategy()),
-		MinMiners:   s.config.GetBSVMinMiners(),
+		APIKey:        s.config.GetBSVAPIKey(),
+		Network:       bsv.Network(network),
+		Logger:        s.logger,
+		FeeStrategy:   bsv.FeeStrategy(s.config.GetBSVFeeStrategy()),
+		MinMiners:     s.config.GetBSVMinMiners(),
+		FeeTable:      bsv.NewFeeTableStore(FeeTablePath(s.config.GetHome())),
+		CoinSelection: coinSelection,
 	}
 	client := bsv.NewClient(ctx, opts)
 
@@ -60,10 +72,11 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 		s.logger.Debug("bsv send: to=%s amount=%s sweep=%v", req.To, req.AmountStr, sweepAll)
 	}
 
-	// Parse amount (skip for sweep — amount is calculated from balance minus fees)
-	var amount *big.Int
+	// Parse amounts (skip for sweep — amount is calculated from balance minus fees).
+	// amount is paid to req.To and total covers every recipient.
+	var amount, total *big.Int
+	var payments []chain.Payment
 	if !sweepAll {
-		var err error
 		amount, err = client.ParseAmount(req.AmountStr)
 		if err != nil {
 			return nil, sigilerr.WithSuggestion(
@@ -71,16 +84,24 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 				fmt.Sprintf("invalid amount: %s", req.AmountStr),
 			)
 		}
+		total = new(big.Int).Set(amount)
+		for _, p := range req.Payments {
+			paid, parseErr := client.ParseAmount(p.AmountStr)
+			if parseErr != nil {
+				return nil, sigilerr.WithSuggestion(
+					sigilerr.ErrInvalidInput,
+					fmt.Sprintf("invalid amount: %s", p.AmountStr),
+				)
+			}
+			payments = append(payments, chain.Payment{To: p.To, Amount: paid})
+			total.Add(total, paid)
+		}
 	}
 
-	// Get fee quote
-	feeQuote, err := client.GetFeeQuote(ctx)
+	// Get fee quote (live, last-known-good, or default) capped by MaxFeeRate
+	feeQuote, err := s.bsvFeeQuote(ctx, client, req.MaxFeeRate)
 	if err != nil {
-		// Use default if fee quote fails
-		feeQuote = &bsv.FeeQuote{StandardRate: bsv.DefaultFeeRate}
-	}
-	if s.logger != nil {
-		s.logger.Debug("bsv send: fee rate=%d sat/KB source=%s", feeQuote.StandardRate, feeQuote.Source)
+		return nil, err
 	}
 
 	// Aggregate UTXOs from ALL wallet addresses for this chain
@@ -95,6 +116,18 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 	if utxoStore != nil {
 		allUTXOs = filterSpentBSVUTXOs(allUTXOs, utxoStore)
 	}
+	// Skip UTXOs held by unresolved send intents (see tx recover)
+	allUTXOs = filterReservedUTXOs(s.logger, walletPath, chain.BSV, allUTXOs)
+	// Manual coin control: spend only the chosen outpoints
+	if len(req.Outpoints) > 0 {
+		var spentStore UTXOProvider
+		if utxoStore != nil {
+			spentStore = utxoStore
+		}
+		if allUTXOs, err = restrictToOutpoints(allUTXOs, req.Outpoints, spentStore); err != nil {
+			return nil, err
+		}
+	}
 
 	// Validate UTXOs if requested (for sweep transactions)
 	if req.ValidateUTXOs && sweepAll {
@@ -148,6 +181,7 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 	var displayAmount string
 	var estimatedFee uint64
 	var sendUTXOs []chain.UTXO // UTXOs that will be used in the transaction
+	var splitPaid []Payment    // What each recipient of a split sweep is paid
 
 	//nolint:nestif // Sweep vs normal send have distinct balance check and fee estimation paths
 	if sweepAll {
@@ -161,14 +195,31 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 			totalInputs += u.Amount
 		}
 
-		sweepAmount, sweepErr := bsv.CalculateSweepAmount(totalInputs, len(allUTXOs), feeQuote.StandardRate)
-		if sweepErr != nil {
-			return nil, sweepErr
-		}
+		if len(req.Split) > 0 {
+			// Split sweep: the fee covers one output per recipient
+			paid, amounts, fee, splitErr := SplitSweep(req.Split, totalInputs, len(allUTXOs), feeQuote.StandardRate)
+			if splitErr != nil {
+				return nil, splitErr
+			}
+			amount = chain.AmountToBigInt(amounts[0])
+			for i, p := range paid[1:] {
+				payments = append(payments, chain.Payment{To: p.To, Amount: chain.AmountToBigInt(amounts[i+1])})
+			}
+			total = chain.AmountToBigInt(totalInputs - fee)
+			estimatedFee = fee
+			displayAmount = client.FormatAmount(total) + " (split sweep)"
+			splitPaid = paid
+		} else {
+			sweepAmount, sweepErr := bsv.CalculateSweepAmount(totalInputs, len(allUTXOs), feeQuote.StandardRate)
+			if sweepErr != nil {
+				return nil, sweepErr
+			}
 
-		amount = chain.AmountToBigInt(sweepAmount)
-		estimatedFee = totalInputs - sweepAmount
-		displayAmount = client.FormatAmount(amount) + " (sweep all)"
+			amount = chain.AmountToBigInt(sweepAmount)
+			total = amount
+			estimatedFee = totalInputs - sweepAmount
+			displayAmount = client.FormatAmount(amount) + " (sweep all)"
+		}
 		sendUTXOs = allUTXOs
 	} else {
 		// Normal send: select UTXOs across all addresses to cover amount + fee
@@ -189,10 +240,14 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 			}
 		}
 
-		selected, _, selErr := client.SelectUTXOs(bsvUTXOs, amount.Uint64(), feeQuote.StandardRate)
+		sel, selErr := client.SelectCoins(coinSelection, bsvUTXOs, total.Uint64(), feeQuote.StandardRate, 1+len(payments))
 		if selErr != nil {
+			if len(req.Outpoints) > 0 && errors.Is(selErr, bsv.ErrInsufficientFunds) {
+				return nil, InsufficientOutpoints(selErr, len(allUTXOs))
+			}
 			return nil, selErr
 		}
+		selected := sel.UTXOs
 
 		// Convert selected back to chain.UTXO
 		sendUTXOs = make([]chain.UTXO, len(selected))
@@ -207,8 +262,11 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 			}
 		}
 
-		estimatedFee = bsv.EstimateFeeForTx(len(selected), 2, feeQuote.StandardRate)
+		estimatedFee = sel.Fee(total.Uint64())
 		displayAmount = req.AmountStr
+		if len(payments) > 0 {
+			displayAmount = client.FormatAmount(total)
+		}
 	}
 	if s.logger != nil {
 		s.logger.Debug("bsv send: using %d UTXOs, estimated fee=%d sat", len(sendUTXOs), estimatedFee)
@@ -216,28 +274,49 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 
 	// Agent policy enforcement is handled at CLI layer via AgentToken/AgentCounterPath fields
 
+	if err := s.approveSend(ctx, req, &cosign.Summary{
+		Chain:    string(chain.BSV),
+		Wallet:   req.Wallet,
+		From:     req.FromAddress,
+		To:       req.To,
+		Amount:   total.String(),
+		Display:  displayAmount,
+		Fee:      strconv.FormatUint(estimatedFee, 10),
+		FeeRate:  feeQuote.StandardRate,
+		Inputs:   cosignInputs(sendUTXOs),
+		Outputs:  cosignOutputs(req.To, amount, payments),
+		SweepAll: sweepAll,
+		Agent:    req.AgentCredID,
+	}); err != nil {
+		return nil, err
+	}
+
 	// Derive change address only for non-sweep (sweep has no change output)
 	var changeAddress string
 	if !sweepAll {
-		// Load wallet to derive change address
-		storage := wallet.NewFileStorage(filepath.Join(s.config.GetHome(), "wallets"))
-		wlt, loadErr := storage.LoadMetadata(req.Wallet)
+		// Load the sending account to derive its change address
+		wlt, loadErr := s.loadSendAccount(req)
 		if loadErr != nil {
-			return nil, fmt.Errorf("loading wallet metadata: %w", loadErr)
+			return nil, loadErr
 		}
 
 		changeAddr, changeErr := wlt.DeriveNextChangeAddress(req.Seed, wallet.ChainBSV)
 		if changeErr != nil {
 			return nil, fmt.Errorf("deriving change address: %w", changeErr)
 		}
-		if updateErr := s.storage.UpdateMetadata(wlt); updateErr != nil {
-			return nil, fmt.Errorf("persisting wallet metadata: %w", updateErr)
+		// A dry run leaves the change address for the real send
+		if !req.DryRun {
+			if updateErr := s.storage.UpdateMetadata(wlt); updateErr != nil {
+				return nil, fmt.Errorf("persisting wallet metadata: %w", updateErr)
+			}
 		}
 		changeAddress = changeAddr.Address
 	}
 
 	// Derive private keys for all addresses that have UTXOs being spent
-	privateKeys, keyErr := deriveKeysForUTXOs(sendUTXOs, req.Addresses, req.Seed)
+	keyCache, releaseKeys := requestKeyCache(req.Keys, req.Seed)
+	defer releaseKeys()
+	privateKeys, keyErr := deriveKeysForUTXOs(sendUTXOs, req.Addresses, keyCache)
 	if keyErr != nil {
 		return nil, fmt.Errorf("deriving private keys: %w", keyErr)
 	}
@@ -257,54 +336,132 @@ func (s *Service) sendBSV(ctx context.Context, req *SendRequest) (*SendResult, e
 		FeeRate:       feeQuote.StandardRate,
 		ChangeAddress: changeAddress,
 		SweepAll:      sweepAll,
+		Payments:      payments,
 	}
 
+	// Record a send intent before broadcast so a crash mid-send can be recovered
+	intent := s.newSendIntent(req, chain.BSV, network, total)
+	var dry dryRun
+	sendReq.BeforeBroadcast = dry.hook(req, intent.hook())
+
 	// Send transaction
 	result, err := client.Send(ctx, sendReq)
+	if dry.signed != nil {
+		preview := dry.utxoResult(chain.BSV, req, client.FormatAmount, total, displayAmount, changeAddress)
+		preview.Payments = splitPaid
+		if !sweepAll {
+			preview.CoinSelection = string(coinSelection)
+		}
+		preview.FeeRate = feeQuote.StandardRate
+		preview.FeeSource = feeQuote.Source
+		preview.FeeAge = feeQuote.Age()
+		return preview, nil
+	}
 	if err != nil {
+		if queued := s.queueSend(req, intent, err, displayAmount, len(sendUTXOs)); queued != nil {
+			return queued, nil
+		}
+		intent.abandon(err)
 		if s.logger != nil {
 			s.logger.Error("bsv send failed: %v", err)
 		}
 		return nil, fmt.Errorf("sending transaction: %w", err)
 	}
 	if s.logger != nil {
-		s.logger.Debug("bsv send: success hash=%s", result.Hash)
+		s.logger.Debug("bsv send: success hash=%s coin_selection=%s", result.Hash, result.CoinSelection)
 	}
 
 	// Mark spent UTXOs in the local store to prevent double-spend on subsequent sends
 	markSpentBSVUTXOs(s.logger, utxoStore, sendUTXOs, result.Hash)
 
 	// Invalidate balance cache for all addresses that contributed UTXOs
-	cachePath := filepath.Join(s.config.GetHome(), "cache", "balances.json")
-	cacheProvider := cache.NewFileStorage(cachePath)
+	invalidator := &cacheInvalidator{logger: s.logger, provider: cache.NewBalanceStorage(s.config.GetHome(), s.config.GetCache())}
 
 	involvedAddrs := uniqueUTXOAddrs(sendUTXOs)
 	if sweepAll {
 		// Sweep: all addresses are now empty
 		for _, addr := range req.Addresses {
-			invalidateBalanceCache(s.logger, cacheProvider, chain.BSV, addr.Address, "", "0.0")
+			invalidator.invalidate(chain.BSV, addr.Address, "", "0.0")
 		}
 	} else {
 		// Partial send: invalidate addresses that contributed inputs
 		for addr := range involvedAddrs {
-			invalidateBalanceCache(s.logger, cacheProvider, chain.BSV, addr, "", "")
+			invalidator.invalidate(chain.BSV, addr, "", "")
 		}
 	}
 
 	// Record agent spending (if in agent mode)
 	if req.AgentToken != "" && req.AgentCounterPath != "" {
-		recordAgentSpend(s.logger, req.AgentCounterPath, req.AgentToken, chain.BSV, amount)
+		recordAgentSpend(s.logger, req.AgentCounterPath, req.AgentToken, agentSpend(req, chain.BSV, "", total))
 	}
 
 	// Convert to service result
 	return &SendResult{
-		Hash:       result.Hash,
-		From:       result.From,
-		To:         result.To,
-		Amount:     displayAmount,
-		Fee:        result.Fee,
-		Status:     result.Status,
-		ChainID:    chain.BSV,
-		UTXOsSpent: len(sendUTXOs),
+		Hash:    result.Hash,
+		From:    result.From,
+		To:      result.To,
+		Amount:  displayAmount,
+		Fee:     result.Fee,
+		Status:  result.Status,
+		ChainID: chain.BSV,
+
+		AmountUnits: total,
+		FeeUnits:    parseFeeUnits(client.ParseAmount, result.Fee),
+		Decimals:    8,
+
+		UTXOsSpent:    len(sendUTXOs),
+		Payments:      splitPaid,
+		CoinSelection: result.CoinSelection,
+		FeeRate:       feeQuote.StandardRate,
+		FeeSource:     feeQuote.Source,
+		FeeAge:        feeQuote.Age(),
+		Changes:       bsvSendChanges(client.FormatAmount, allUTXOs, sendUTXOs, result, req.Addresses, changeAddress, invalidator.touched),
 	}, nil
 }
+
+// bsvSendChanges assembles the post-send summary for a BSV transaction.
+// The client's record of spent and created outputs is preferred; when it is
+// unavailable the selected UTXOs stand in for the spent set.
+func bsvSendChanges(format func(*big.Int) string, known, selected []chain.UTXO, result *chain.TransactionResult, addresses []wallet.Address, changeAddress string, touched []CacheEntryRef) *SendChanges {
+	spent := result.Spent
+	if len(spent) == 0 {
+		spent = selected
+	}
+
+	walletAddrs := make(map[string]bool, len(addresses)+1)
+	for _, a := range addresses {
+		walletAddrs[a.Address] = true
+	}
+	if changeAddress != "" {
+		walletAddrs[changeAddress] = true
+	}
+
+	changes := &SendChanges{
+		Balances:         bsvBalanceChanges(known, spent, result.Created, walletAddrs, format),
+		UTXOsConsumed:    spent,
+		UTXOsCreated:     result.Created,
+		CacheInvalidated: touched,
+	}
+	// A change address is only reported when the change output was not dropped as dust.
+	for _, u := range result.Created {
+		if changeAddress != "" && u.Address == changeAddress {
+			changes.ChangeAddress = changeAddress
+			break
+		}
+	}
+	return changes
+}
+
+// bsvCoinSelection resolves the request's coin selection strategy, falling
+// back to fees.bsv_coin_selection.
+func (s *Service) bsvCoinSelection(req *SendRequest) (bsv.CoinSelection, error) {
+	name := req.CoinSelection
+	if name == "" {
+		name = s.config.GetBSVCoinSelection()
+	}
+	strategy, err := bsv.ParseCoinSelection(name)
+	if err != nil {
+		return "", sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+	}
+	return strategy, nil
+}
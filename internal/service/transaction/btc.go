@@ -0,0 +1,70 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/btc"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// NewBTCClient creates a BTC client for the configured API on the given
+// wallet network ("main" or "test"); an empty network falls back to config.
+func NewBTCClient(cfg ConfigProvider, network string, logger LogWriter) (*btc.Client, error) {
+	if network == "" {
+		network = cfg.GetBSVNetwork()
+	}
+	opts := &btc.ClientOptions{
+		API:     cfg.GetBTCAPI(),
+		Network: btc.Network(network),
+	}
+	if logger != nil {
+		opts.Logger = logger
+	}
+	return btc.NewClient(opts)
+}
+
+// sendBTC handles the Bitcoin transaction flow (see sendUTXOChain).
+func (s *Service) sendBTC(ctx context.Context, req *SendRequest) (*SendResult, error) {
+	client, err := NewBTCClient(s.config, req.Network, s.logger)
+	if err != nil {
+		return nil, err
+	}
+	return s.sendUTXOChain(ctx, req, &utxoSendChain{
+		id:           chain.BTC,
+		network:      string(client.Network()),
+		client:       client,
+		checkFeeRate: CheckBTCFeeRate,
+		sweepAmount:  btc.CalculateSweepAmount,
+		estimateFee:  btc.EstimateFeeForTx,
+	})
+}
+
+// CheckBTCFeeRate rejects a BTC or BCH fee rate (sat/KB) above maxFeeRate.
+// A zero maxFeeRate disables the check.
+func CheckBTCFeeRate(rate, maxFeeRate uint64) error {
+	if maxFeeRate == 0 || rate <= maxFeeRate {
+		return nil
+	}
+	return sigilerr.WithSuggestion(
+		sigilerr.WithDetails(sigilerr.ErrFeeRateTooHigh, map[string]string{
+			"rate": strconv.FormatUint(rate, 10),
+			"max":  strconv.FormatUint(maxFeeRate, 10),
+		}),
+		fmt.Sprintf("fee rate %d sat/KB exceeds --max-fee-rate %d sat/KB; raise --max-fee-rate to accept the current network rate",
+			rate, maxFeeRate),
+	)
+}
+
+// AggregateBTCUTXOs fetches UTXOs from all wallet addresses concurrently and merges them.
+func AggregateBTCUTXOs(ctx context.Context, client *btc.Client, addresses []wallet.Address) ([]chain.UTXO, error) {
+	return aggregateUTXOs(ctx, client, addresses)
+}
+
+// FilterSpentBTCUTXOs removes UTXOs the local store knows are spent or immature.
+func FilterSpentBTCUTXOs(utxos []chain.UTXO, store UTXOProvider) []chain.UTXO {
+	return filterSpentUTXOs(chain.BTC, utxos, store)
+}
@@ -0,0 +1,115 @@
+package transaction
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newBTCTestServer serves one UTXO per address in utxos and accepts any
+// broadcast, echoing a txid.
+func newBTCTestServer(t *testing.T, utxos map[string]uint64) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/fees/recommended":
+			_, _ = w.Write([]byte(`{"fastestFee":3,"halfHourFee":2,"hourFee":1,"economyFee":1,"minimumFee":1}`))
+		case r.URL.Path == "/tx" && r.Method == http.MethodPost:
+			body, _ := io.ReadAll(r.Body)
+			sum := sha256.Sum256(body)
+			_, _ = w.Write([]byte(hex.EncodeToString(sum[:])))
+		case strings.HasSuffix(r.URL.Path, "/utxo"):
+			addr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/address/"), "/utxo")
+			if amount, ok := utxos[addr]; ok {
+				_, _ = w.Write([]byte(`[{"txid":"` + strings.Repeat("ab", 32) + `","vout":0,"value":` +
+					strconv.FormatUint(amount, 10) + `,"status":{"confirmed":true,"block_height":1}}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[]`))
+		case r.URL.Path == "/blocks/tip/height":
+			_, _ = w.Write([]byte("10"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSendBTC_SweepAll(t *testing.T) {
+	t.Parallel()
+
+	seed := getTestSeed(t)
+	addr, err := wallet.DeriveAddress(seed, wallet.ChainBTC, 0, 0)
+	require.NoError(t, err)
+
+	server := newBTCTestServer(t, map[string]uint64{addr.Address: 100000})
+	cfg := newMockConfigProvider()
+	cfg.home = t.TempDir()
+	cfg.btcAPI = server.URL
+
+	service := NewService(&Config{Config: cfg, Storage: newMockStorageProvider(), Logger: newMockLogWriter()})
+	result, err := service.Send(context.Background(), &SendRequest{
+		ChainID:     chain.BTC,
+		To:          "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+		AmountStr:   "all",
+		Wallet:      "test",
+		FromAddress: addr.Address,
+		Addresses:   []wallet.Address{*addr},
+		Seed:        seed,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, chain.BTC, result.ChainID)
+	assert.Len(t, result.Hash, 64)
+	assert.Equal(t, 1, result.UTXOsSpent)
+	assert.Equal(t, uint64(2000), result.FeeRate)
+	require.NotNil(t, result.Changes)
+	require.Len(t, result.Changes.UTXOsConsumed, 1)
+	assert.Contains(t, result.Amount, "(sweep all)")
+}
+
+func TestSendBTC_Rejects(t *testing.T) {
+	t.Parallel()
+
+	server := newBTCTestServer(t, nil)
+	cfg := newMockConfigProvider()
+	cfg.home = t.TempDir()
+	cfg.btcAPI = server.URL
+	service := NewService(&Config{Config: cfg, Storage: newMockStorageProvider(), Logger: newMockLogWriter()})
+
+	_, err := service.Send(context.Background(), &SendRequest{
+		ChainID:   chain.BTC,
+		To:        "mrCDrCybB6J1vRfbwM5hemdJz73FwDBC8r",
+		AmountStr: "0.001",
+	})
+	require.ErrorIs(t, err, sigilerr.ErrInvalidAddress, "testnet recipient on a mainnet wallet")
+
+	_, err = service.Send(context.Background(), &SendRequest{
+		ChainID:    chain.BTC,
+		To:         "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		AmountStr:  "0.001",
+		MaxFeeRate: 1000,
+	})
+	require.ErrorIs(t, err, sigilerr.ErrFeeRateTooHigh)
+
+	_, err = service.Send(context.Background(), &SendRequest{
+		ChainID:   chain.BTC,
+		To:        "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH",
+		AmountStr: "0.001",
+		Addresses: []wallet.Address{{Address: "1BgGZ9tcN4rm9KBzDn7KprQz87SZ26SAMH"}},
+	})
+	require.ErrorIs(t, err, sigilerr.ErrInsufficientFunds)
+}
@@ -0,0 +1,108 @@
+package transaction
+
+import (
+	"math/big"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// SendChanges summarizes what a successful send changed: the expected balance
+// movement of each affected wallet address, the UTXOs consumed and created, the
+// change address used, and the balance cache entries that were refreshed or dropped.
+type SendChanges struct {
+	Balances         []BalanceChange
+	UTXOsConsumed    []chain.UTXO
+	UTXOsCreated     []chain.UTXO
+	ChangeAddress    string
+	CacheInvalidated []CacheEntryRef
+}
+
+// BalanceChange is an address balance before the send and the balance expected
+// once the transaction confirms. Amounts are formatted in the asset's units.
+type BalanceChange struct {
+	Address  string
+	Symbol   string
+	Previous string
+	Expected string
+}
+
+// CacheEntryRef identifies a balance cache entry touched after a send.
+type CacheEntryRef struct {
+	Chain   chain.ID
+	Address string
+	Token   string
+}
+
+// cacheInvalidator applies post-send balance cache updates and records which
+// entries were touched so they can be reported back to the user.
+type cacheInvalidator struct {
+	logger   LogWriter
+	provider CacheProvider
+	touched  []CacheEntryRef
+}
+
+// invalidate updates or deletes one cache entry; see invalidateBalanceCache.
+func (ci *cacheInvalidator) invalidate(chainID chain.ID, address, token, expectedBalance string) {
+	invalidateBalanceCache(ci.logger, ci.provider, chainID, address, token, expectedBalance)
+	ci.touched = append(ci.touched, CacheEntryRef{Chain: chainID, Address: address, Token: token})
+}
+
+// bsvBalanceChanges computes per-address balance deltas for a BSV send.
+// known holds every UTXO the wallet held before the send and determines the
+// previous balances; spent and created are the transaction's inputs and
+// outputs. Only outputs paying a wallet address count toward expected balances.
+// Addresses are reported in the order they are first touched.
+func bsvBalanceChanges(known, spent, created []chain.UTXO, walletAddrs map[string]bool, format func(*big.Int) string) []BalanceChange {
+	previous := make(map[string]uint64)
+	for _, u := range known {
+		previous[u.Address] += u.Amount
+	}
+
+	var order []string
+	seen := make(map[string]bool)
+	out := make(map[string]uint64)
+	in := make(map[string]uint64)
+	touch := func(addr string) {
+		if !seen[addr] {
+			seen[addr] = true
+			order = append(order, addr)
+		}
+	}
+	for _, u := range spent {
+		touch(u.Address)
+		out[u.Address] += u.Amount
+	}
+	for _, u := range created {
+		if !walletAddrs[u.Address] {
+			continue
+		}
+		touch(u.Address)
+		in[u.Address] += u.Amount
+	}
+
+	changes := make([]BalanceChange, 0, len(order))
+	for _, addr := range order {
+		prev := previous[addr]
+		expected := prev + in[addr]
+		if out[addr] >= expected {
+			expected = 0
+		} else {
+			expected -= out[addr]
+		}
+		changes = append(changes, BalanceChange{
+			Address:  addr,
+			Symbol:   "BSV",
+			Previous: format(chain.AmountToBigInt(prev)),
+			Expected: format(chain.AmountToBigInt(expected)),
+		})
+	}
+	return changes
+}
+
+// nonNegative returns x, or zero when x is negative.
+func nonNegative(x *big.Int) *big.Int {
+	if x.Sign() < 0 {
+		return new(big.Int)
+	}
+	return x
+}
@@ -0,0 +1,179 @@
+package transaction
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// satFormat renders satoshi amounts as plain integers for readable assertions.
+func satFormat(v *big.Int) string { return v.String() }
+
+func TestBSVBalanceChanges(t *testing.T) {
+	t.Parallel()
+
+	known := []chain.UTXO{
+		{TxID: "a", Vout: 0, Amount: 5000, Address: "1ADDR"},
+		{TxID: "b", Vout: 0, Amount: 3000, Address: "1ADDR"},
+		{TxID: "c", Vout: 1, Amount: 7000, Address: "1OTHER"},
+	}
+
+	tests := []struct {
+		name    string
+		spent   []chain.UTXO
+		created []chain.UTXO
+		wallet  map[string]bool
+		want    []BalanceChange
+	}{
+		{
+			name:  "partial send with change",
+			spent: known[:2],
+			created: []chain.UTXO{
+				{TxID: "tx", Vout: 0, Amount: 6000, Address: "1EXTERNAL"},
+				{TxID: "tx", Vout: 1, Amount: 1900, Address: "1CHANGE"},
+			},
+			wallet: map[string]bool{"1ADDR": true, "1OTHER": true, "1CHANGE": true},
+			want: []BalanceChange{
+				{Address: "1ADDR", Symbol: "BSV", Previous: "8000", Expected: "0"},
+				{Address: "1CHANGE", Symbol: "BSV", Previous: "0", Expected: "1900"},
+			},
+		},
+		{
+			name:  "send to own address",
+			spent: known[2:],
+			created: []chain.UTXO{
+				{TxID: "tx", Vout: 0, Amount: 6900, Address: "1ADDR"},
+			},
+			wallet: map[string]bool{"1ADDR": true, "1OTHER": true},
+			want: []BalanceChange{
+				{Address: "1OTHER", Symbol: "BSV", Previous: "7000", Expected: "0"},
+				{Address: "1ADDR", Symbol: "BSV", Previous: "8000", Expected: "14900"},
+			},
+		},
+		{
+			name:    "sweep",
+			spent:   known,
+			created: []chain.UTXO{{TxID: "tx", Vout: 0, Amount: 14800, Address: "1EXTERNAL"}},
+			wallet:  map[string]bool{"1ADDR": true, "1OTHER": true},
+			want: []BalanceChange{
+				{Address: "1ADDR", Symbol: "BSV", Previous: "8000", Expected: "0"},
+				{Address: "1OTHER", Symbol: "BSV", Previous: "7000", Expected: "0"},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := bsvBalanceChanges(known, tc.spent, tc.created, tc.wallet, satFormat)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestBSVSendChanges(t *testing.T) {
+	t.Parallel()
+
+	addresses := []wallet.Address{{Address: "1ADDR"}}
+	known := []chain.UTXO{{TxID: "a", Vout: 0, Amount: 5000, Address: "1ADDR"}}
+	touched := []CacheEntryRef{{Chain: chain.BSV, Address: "1ADDR"}}
+
+	t.Run("change output created", func(t *testing.T) {
+		t.Parallel()
+		result := &chain.TransactionResult{
+			Spent: known,
+			Created: []chain.UTXO{
+				{TxID: "tx", Vout: 0, Amount: 3000, Address: "1EXTERNAL"},
+				{TxID: "tx", Vout: 1, Amount: 1900, Address: "1CHANGE"},
+			},
+		}
+
+		changes := bsvSendChanges(satFormat, known, known, result, addresses, "1CHANGE", touched)
+		assert.Equal(t, "1CHANGE", changes.ChangeAddress)
+		assert.Equal(t, known, changes.UTXOsConsumed)
+		assert.Len(t, changes.UTXOsCreated, 2)
+		assert.Equal(t, touched, changes.CacheInvalidated)
+		require.Len(t, changes.Balances, 2)
+		assert.Equal(t, "1900", changes.Balances[1].Expected)
+	})
+
+	t.Run("dust change dropped", func(t *testing.T) {
+		t.Parallel()
+		result := &chain.TransactionResult{
+			Created: []chain.UTXO{{TxID: "tx", Vout: 0, Amount: 4990, Address: "1EXTERNAL"}},
+		}
+
+		changes := bsvSendChanges(satFormat, known, known, result, addresses, "1CHANGE", nil)
+		assert.Empty(t, changes.ChangeAddress)
+		// Without a spent list from the client, the selected UTXOs are reported.
+		assert.Equal(t, known, changes.UTXOsConsumed)
+	})
+}
+
+func TestETHBalanceChanges(t *testing.T) {
+	t.Parallel()
+
+	gas := big.NewInt(100)
+
+	t.Run("native", func(t *testing.T) {
+		t.Parallel()
+		req := &SendRequest{FromAddress: "0xabc"}
+		before := &ethBalances{ETH: big.NewInt(1000)}
+
+		got := ethBalanceChanges(satFormat, req, before, big.NewInt(400), gas, nil)
+		assert.Equal(t, []BalanceChange{
+			{Address: "0xabc", Symbol: "ETH", Previous: "1000", Expected: "500"},
+		}, got)
+	})
+
+	t.Run("token", func(t *testing.T) {
+		t.Parallel()
+		req := &SendRequest{FromAddress: "0xabc", Token: "usdc"}
+		token := &eth.TokenMetadata{Address: eth.USDCMainnet, Symbol: "USDC", Decimals: 6}
+		before := &ethBalances{ETH: big.NewInt(1000), Token: big.NewInt(2_500_000)}
+
+		got := ethBalanceChanges(satFormat, req, before, big.NewInt(1_000_000), gas, token)
+		require.Len(t, got, 2)
+		assert.Equal(t, BalanceChange{Address: "0xabc", Symbol: "ETH", Previous: "1000", Expected: "900"}, got[0])
+		assert.Equal(t, "USDC", got[1].Symbol)
+		assert.Equal(t, chain.FormatDecimalAmount(big.NewInt(2_500_000), 6), got[1].Previous)
+		assert.Equal(t, chain.FormatDecimalAmount(big.NewInt(1_500_000), 6), got[1].Expected)
+	})
+
+	t.Run("never negative", func(t *testing.T) {
+		t.Parallel()
+		req := &SendRequest{FromAddress: "0xabc"}
+		before := &ethBalances{ETH: big.NewInt(50)}
+
+		got := ethBalanceChanges(satFormat, req, before, big.NewInt(10), gas, nil)
+		require.Len(t, got, 1)
+		assert.Equal(t, "0", got[0].Expected)
+	})
+
+	t.Run("no balances read", func(t *testing.T) {
+		t.Parallel()
+		assert.Nil(t, ethBalanceChanges(satFormat, &SendRequest{}, nil, big.NewInt(1), gas, nil))
+	})
+}
+
+func TestCacheInvalidator_RecordsEntries(t *testing.T) {
+	t.Parallel()
+
+	provider := newMockCacheProvider()
+	ci := &cacheInvalidator{provider: provider}
+
+	ci.invalidate(chain.ETH, "0xabc", "", "")
+	ci.invalidate(chain.ETH, "0xabc", "0xtoken", "0.0")
+
+	assert.Equal(t, []CacheEntryRef{
+		{Chain: chain.ETH, Address: "0xabc"},
+		{Chain: chain.ETH, Address: "0xabc", Token: "0xtoken"},
+	}, ci.touched)
+	assert.Equal(t, 2, provider.saveCalled)
+}
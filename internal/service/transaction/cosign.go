@@ -0,0 +1,92 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/cosign"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// requestApproval asks the configured approver, if any, to approve the
+// transaction. It runs after the transaction is fully priced but before any
+// key is derived or wallet state changes, so a rejection leaves nothing behind.
+func (s *Service) requestApproval(ctx context.Context, summary *cosign.Summary) error {
+	return requestApproval(ctx, s.approver, s.logger, summary)
+}
+
+// approveSend asks for approval of the transaction of req like
+// requestApproval. A dry run is never broadcast, so it is not sent for
+// approval.
+func (s *Service) approveSend(ctx context.Context, req *SendRequest, summary *cosign.Summary) error {
+	if req.DryRun {
+		return nil
+	}
+	return s.requestApproval(ctx, summary)
+}
+
+// Approve asks the configured approver, if any, to approve a transaction
+// the service does not sign itself, such as one signed on a hardware wallet.
+func (s *Service) Approve(ctx context.Context, summary *cosign.Summary) error {
+	return s.requestApproval(ctx, summary)
+}
+
+// WithApprover returns a copy of the service that asks approver to approve
+// every transaction. A nil approver returns the service unchanged.
+func (s *Service) WithApprover(approver Approver) *Service {
+	if approver == nil {
+		return s
+	}
+	svc := *s
+	svc.approver = approver
+	return &svc
+}
+
+// requestApproval asks approver, if not nil, to approve the transaction and
+// maps a rejection to ErrCosignRejected. An approver that rejects with a
+// SigilError keeps its own code.
+func requestApproval(ctx context.Context, approver Approver, logger LogWriter, summary *cosign.Summary) error {
+	if approver == nil {
+		return nil
+	}
+	if logger != nil {
+		logger.Debug("%s send: requesting co-sign approval", summary.Chain)
+	}
+
+	if err := approver.Approve(ctx, summary); err != nil {
+		if logger != nil {
+			logger.Error("%s send: approval rejected request %s: %v", summary.Chain, summary.RequestID, err)
+		}
+		var se *sigilerr.SigilError
+		if errors.As(err, &se) {
+			return err
+		}
+		return sigilerr.WithDetails(sigilerr.ErrCosignRejected, map[string]string{"reason": err.Error()})
+	}
+	return nil
+}
+
+// cosignInputs lists the UTXOs a transaction spends for the approval summary.
+func cosignInputs(utxos []chain.UTXO) []cosign.Input {
+	inputs := make([]cosign.Input, len(utxos))
+	for i, u := range utxos {
+		inputs[i] = cosign.Input{TxID: u.TxID, Vout: u.Vout, Amount: u.Amount}
+	}
+	return inputs
+}
+
+// cosignOutputs lists every recipient of a multi-recipient send. It returns
+// nil for a single recipient, which the summary's To and Amount describe.
+func cosignOutputs(to string, amount *big.Int, payments []chain.Payment) []cosign.Output {
+	if len(payments) == 0 {
+		return nil
+	}
+	outputs := make([]cosign.Output, 0, len(payments)+1)
+	outputs = append(outputs, cosign.Output{Address: to, Amount: amount.String()})
+	for _, p := range payments {
+		outputs = append(outputs, cosign.Output{Address: p.To, Amount: p.Amount.String()})
+	}
+	return outputs
+}
@@ -0,0 +1,150 @@
+package transaction
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/cosign"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// mockApprover records approval requests and returns a canned decision.
+type mockApprover struct {
+	err       error
+	summaries []*cosign.Summary
+}
+
+func (m *mockApprover) Approve(_ context.Context, summary *cosign.Summary) error {
+	m.summaries = append(m.summaries, summary)
+	return m.err
+}
+
+func TestRequestApproval(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no approver", func(t *testing.T) {
+		t.Parallel()
+		service := NewService(&Config{Config: newMockConfigProvider()})
+		require.NoError(t, service.requestApproval(context.Background(), &cosign.Summary{Chain: "bsv"}))
+	})
+
+	t.Run("approved", func(t *testing.T) {
+		t.Parallel()
+		approver := &mockApprover{}
+		service := NewService(&Config{Config: newMockConfigProvider(), Logger: newMockLogWriter(), Approver: approver})
+
+		require.NoError(t, service.requestApproval(context.Background(), &cosign.Summary{Chain: "bsv", Amount: "1000"}))
+		require.Len(t, approver.summaries, 1)
+		assert.Equal(t, "1000", approver.summaries[0].Amount)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		t.Parallel()
+		approver := &mockApprover{err: errors.Join(cosign.ErrDenied, errors.New("over limit"))} //nolint:err113 // test error
+		service := NewService(&Config{Config: newMockConfigProvider(), Logger: newMockLogWriter(), Approver: approver})
+
+		err := service.requestApproval(context.Background(), &cosign.Summary{Chain: "eth"})
+		require.ErrorIs(t, err, sigilerr.ErrCosignRejected)
+		assert.Contains(t, err.Error(), "over limit")
+		assert.Equal(t, sigilerr.ExitPermission, sigilerr.ExitCode(err))
+	})
+
+	t.Run("rejected with its own code", func(t *testing.T) {
+		t.Parallel()
+		approver := &mockApprover{err: sigilerr.WithDetails(sigilerr.ErrHookRejected, map[string]string{"reason": "exit 1"})}
+		service := NewService(&Config{Config: newMockConfigProvider(), Logger: newMockLogWriter(), Approver: approver})
+
+		err := service.Approve(context.Background(), &cosign.Summary{Chain: "bsv"})
+		require.ErrorIs(t, err, sigilerr.ErrHookRejected)
+		assert.NotErrorIs(t, err, sigilerr.ErrCosignRejected)
+	})
+}
+
+func TestService_WithApprover(t *testing.T) {
+	t.Parallel()
+
+	base := NewService(&Config{Config: newMockConfigProvider()})
+	assert.Same(t, base, base.WithApprover(nil))
+
+	approver := &mockApprover{}
+	withApprover := base.WithApprover(approver)
+	require.NoError(t, withApprover.requestApproval(context.Background(), &cosign.Summary{Chain: "bsv"}))
+	assert.Len(t, approver.summaries, 1)
+	assert.Nil(t, base.approver, "original service is not modified")
+}
+
+func TestSweepService_Sweep_Cosign(t *testing.T) {
+	t.Parallel()
+
+	newSweep := func(approver Approver, sent *bool) *SweepService {
+		client := &mockBSVClientForSweep{
+			sendFunc: func(_ context.Context, _ chain.SendRequest) (*chain.TransactionResult, error) {
+				*sent = true
+				return &chain.TransactionResult{Hash: "tx123"}, nil
+			},
+		}
+		bulkOps := &mockBulkOpsForSweep{
+			fetchFunc: func(_ context.Context, _ []string) ([]bsv.BulkUTXOResult, error) {
+				return []bsv.BulkUTXOResult{{
+					Address:        "addr1",
+					ConfirmedUTXOs: []bsv.UTXO{{TxID: "tx1", Vout: 0, Amount: 50000, Address: "addr1"}},
+				}}, nil
+			},
+		}
+		return NewSweepService(client, bulkOps, nil).WithApprover(approver)
+	}
+	opts := &SweepOptions{
+		Wallet:      "main",
+		Destination: "dest_addr",
+		Addresses:   []wallet.Address{{Address: "addr1"}},
+		Seed:        []byte{1},
+		FeeRate:     100,
+	}
+
+	t.Run("rejected before signing", func(t *testing.T) {
+		t.Parallel()
+
+		var sent bool
+		approver := &mockApprover{err: cosign.ErrDenied}
+		_, err := newSweep(approver, &sent).Sweep(context.Background(), opts)
+		require.ErrorIs(t, err, sigilerr.ErrCosignRejected)
+		assert.False(t, sent)
+
+		require.Len(t, approver.summaries, 1)
+		summary := approver.summaries[0]
+		assert.Equal(t, "main", summary.Wallet)
+		assert.Equal(t, "dest_addr", summary.To)
+		assert.True(t, summary.SweepAll)
+		assert.Equal(t, []cosign.Input{{TxID: "tx1", Vout: 0, Amount: 50000}}, summary.Inputs)
+	})
+
+	t.Run("dry run skips approval", func(t *testing.T) {
+		t.Parallel()
+
+		var sent bool
+		approver := &mockApprover{err: cosign.ErrDenied}
+		dryRun := *opts
+		dryRun.DryRun = true
+		_, err := newSweep(approver, &sent).Sweep(context.Background(), &dryRun)
+		require.NoError(t, err)
+		assert.Empty(t, approver.summaries)
+	})
+}
+
+func TestCosignInputs(t *testing.T) {
+	t.Parallel()
+
+	inputs := cosignInputs([]chain.UTXO{
+		{TxID: "aa", Vout: 1, Amount: 500, Address: "1A"},
+		{TxID: "bb", Vout: 0, Amount: 700, Address: "1B"},
+	})
+	assert.Equal(t, []cosign.Input{{TxID: "aa", Vout: 1, Amount: 500}, {TxID: "bb", Vout: 0, Amount: 700}}, inputs)
+	assert.Empty(t, cosignInputs(nil))
+}
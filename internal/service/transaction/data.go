@@ -0,0 +1,176 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/cosign"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// DataRequest publishes data in a BSV OP_RETURN output, paid for by the wallet.
+type DataRequest struct {
+	// Wallet is the wallet name.
+	Wallet string
+
+	// Data holds the pushes of the data carrier output.
+	Data [][]byte
+
+	// Addresses are the wallet's BSV addresses used to fund the fee.
+	Addresses []wallet.Address
+
+	// Network is the BSV network ("main"/"test"); empty falls back to config.
+	Network string
+
+	// Seed is the wallet seed used to derive signing and change keys.
+	Seed []byte
+
+	// Keys caches signing keys derived from Seed for the command run.
+	// When nil, keys are derived for this request only.
+	Keys *wallet.KeyCache
+}
+
+// DataResult is the outcome of a data carrier transaction.
+type DataResult struct {
+	Hash       string
+	Fee        string
+	Status     string
+	UTXOsSpent int
+}
+
+// SendData funds, signs and broadcasts a BSV transaction carrying req.Data.
+// Spent inputs are marked in the local UTXO store and the balance cache of
+// the funding addresses is invalidated, as with a regular send.
+func (s *Service) SendData(ctx context.Context, req *DataRequest) (*DataResult, error) {
+	network := req.Network
+	if network == "" {
+		network = s.config.GetBSVNetwork()
+	}
+
+	client := bsv.NewClient(ctx, &bsv.ClientOptions{
+		APIKey:      s.config.GetBSVAPIKey(),
+		Network:     bsv.Network(network),
+		Logger:      s.logger,
+		FeeStrategy: bsv.FeeStrategy(s.config.GetBSVFeeStrategy()),
+		MinMiners:   s.config.GetBSVMinMiners(),
+		FeeTable:    bsv.NewFeeTableStore(FeeTablePath(s.config.GetHome())),
+	})
+
+	utxoStore := utxostore.New(filepath.Join(s.config.GetHome(), "wallets", req.Wallet))
+	if err := utxoStore.Load(); err != nil {
+		if s.logger != nil {
+			s.logger.Error("bsv data: failed to load utxo store: %v", err)
+		}
+		utxoStore = nil
+	}
+
+	feeQuote, err := s.bsvFeeQuote(ctx, client, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	allUTXOs, err := aggregateBSVUTXOs(ctx, client, req.Addresses)
+	if err != nil {
+		return nil, fmt.Errorf("listing UTXOs: %w", err)
+	}
+	if utxoStore != nil {
+		allUTXOs = filterSpentBSVUTXOs(allUTXOs, utxoStore)
+	}
+	if len(allUTXOs) == 0 {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInsufficientFunds, "no UTXOs found across any wallet address")
+	}
+
+	// Select inputs up front so only the UTXOs actually spent are marked afterwards
+	candidates := make([]bsv.UTXO, len(allUTXOs))
+	byOutpoint := make(map[string]chain.UTXO, len(allUTXOs))
+	for i, u := range allUTXOs {
+		candidates[i] = bsv.UTXO{
+			TxID:          u.TxID,
+			Vout:          u.Vout,
+			Amount:        u.Amount,
+			ScriptPubKey:  u.ScriptPubKey,
+			Address:       u.Address,
+			Confirmations: u.Confirmations,
+		}
+		byOutpoint[fmt.Sprintf("%s:%d", u.TxID, u.Vout)] = u
+	}
+	dataSize := bsv.DataOutputSize(req.Data)
+	selected, change, err := bsv.SelectDataUTXOs(candidates, dataSize, feeQuote.StandardRate)
+	if err != nil {
+		return nil, err
+	}
+	sendUTXOs := make([]chain.UTXO, len(selected))
+	var totalInputs uint64
+	for i, u := range selected {
+		sendUTXOs[i] = byOutpoint[fmt.Sprintf("%s:%d", u.TxID, u.Vout)]
+		totalInputs += u.Amount
+	}
+
+	if err = s.requestApproval(ctx, &cosign.Summary{
+		Chain:   string(chain.BSV),
+		Wallet:  req.Wallet,
+		Amount:  "0",
+		Display: fmt.Sprintf("data output (%d bytes)", dataSize),
+		Fee:     strconv.FormatUint(totalInputs-change, 10),
+		FeeRate: feeQuote.StandardRate,
+		Inputs:  cosignInputs(sendUTXOs),
+	}); err != nil {
+		return nil, err
+	}
+
+	storage := wallet.NewFileStorage(filepath.Join(s.config.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(req.Wallet)
+	if err != nil {
+		return nil, fmt.Errorf("loading wallet metadata: %w", err)
+	}
+	changeAddr, err := wlt.DeriveNextChangeAddress(req.Seed, wallet.ChainBSV)
+	if err != nil {
+		return nil, fmt.Errorf("deriving change address: %w", err)
+	}
+	if err = s.storage.UpdateMetadata(wlt); err != nil {
+		return nil, fmt.Errorf("persisting wallet metadata: %w", err)
+	}
+
+	keyCache, releaseKeys := requestKeyCache(req.Keys, req.Seed)
+	defer releaseKeys()
+	privateKeys, err := deriveKeysForUTXOs(sendUTXOs, req.Addresses, keyCache)
+	if err != nil {
+		return nil, fmt.Errorf("deriving private keys: %w", err)
+	}
+	defer zeroKeyMap(privateKeys)
+
+	result, err := client.SendData(ctx, bsv.DataRequest{
+		Data:          req.Data,
+		UTXOs:         sendUTXOs,
+		PrivateKeys:   privateKeys,
+		ChangeAddress: changeAddr.Address,
+		FeeRate:       feeQuote.StandardRate,
+	})
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("bsv data send failed: %v", err)
+		}
+		return nil, fmt.Errorf("sending transaction: %w", err)
+	}
+
+	markSpentBSVUTXOs(s.logger, utxoStore, sendUTXOs, result.Hash)
+
+	cacheProvider := cache.NewBalanceStorage(s.config.GetHome(), s.config.GetCache())
+	for addr := range uniqueUTXOAddrs(sendUTXOs) {
+		invalidateBalanceCache(s.logger, cacheProvider, chain.BSV, addr, "", "")
+	}
+
+	return &DataResult{
+		Hash:       result.Hash,
+		Fee:        result.Fee,
+		Status:     result.Status,
+		UTXOsSpent: len(sendUTXOs),
+	}, nil
+}
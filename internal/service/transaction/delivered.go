@@ -0,0 +1,45 @@
+package transaction
+
+import (
+	"context"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+)
+
+// deliveredWaitTimeout bounds how long a send waits for the receipt of a
+// fee-on-transfer or rebasing token transfer.
+const deliveredWaitTimeout = 60 * time.Second
+
+// receiptWaiter waits for a transaction to be mined.
+type receiptWaiter interface {
+	WaitForReceipt(ctx context.Context, txHash string, interval time.Duration) (*rpc.Receipt, error)
+}
+
+// deliveredAmount waits for the receipt of a token transfer and returns the
+// formatted amount the recipient actually received, read from the token's
+// Transfer events. It returns "" when the receipt does not arrive in time or
+// holds no matching event; the send itself has already succeeded.
+func (s *Service) deliveredAmount(ctx context.Context, w receiptWaiter, txHash string, token *eth.TokenMetadata, to string) string {
+	ctx, cancel := context.WithTimeout(ctx, deliveredWaitTimeout)
+	defer cancel()
+
+	receipt, err := w.WaitForReceipt(ctx, txHash, 0)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Debug("eth send: no receipt for delivered amount of %s: %v", txHash, err)
+		}
+		return ""
+	}
+
+	delivered, ok := eth.DeliveredAmount(receipt, token.Address, to)
+	if !ok {
+		if s.logger != nil {
+			s.logger.Error("eth send: receipt for %s has no %s transfer to %s", txHash, token.Symbol, to)
+		}
+		return ""
+	}
+	return chain.FormatDecimalAmount(delivered, token.Decimals)
+}
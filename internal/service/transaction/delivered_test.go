@@ -0,0 +1,61 @@
+package transaction
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/chain/eth/rpc"
+)
+
+// fakeReceiptWaiter returns a fixed receipt or error.
+type fakeReceiptWaiter struct {
+	receipt *rpc.Receipt
+	err     error
+}
+
+func (f *fakeReceiptWaiter) WaitForReceipt(_ context.Context, _ string, _ time.Duration) (*rpc.Receipt, error) {
+	return f.receipt, f.err
+}
+
+func TestDeliveredAmount(t *testing.T) {
+	t.Parallel()
+
+	const recipient = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	token := &eth.TokenMetadata{Address: testUNIAddress, Symbol: "UNI", Decimals: 18, FeeOnTransfer: true}
+
+	// 0.98 UNI delivered after a 2% transfer fee.
+	amount := new(big.Int).Mul(big.NewInt(98), big.NewInt(1e16))
+	transfer := rpc.Log{
+		Address: testUNIAddress,
+		Topics: []string{
+			"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+			"0x0000000000000000000000001111111111111111111111111111111111111111",
+			"0x000000000000000000000000742d35cc6634c0532925a3b844bc454e4438f44e",
+		},
+		Data: amount.FillBytes(make([]byte, 32)),
+	}
+
+	tests := []struct {
+		name   string
+		waiter *fakeReceiptWaiter
+		want   string
+	}{
+		{name: "transfer event", waiter: &fakeReceiptWaiter{receipt: &rpc.Receipt{Success: true, Logs: []rpc.Log{transfer}}}, want: "0.98"},
+		{name: "no transfer event", waiter: &fakeReceiptWaiter{receipt: &rpc.Receipt{}}},
+		{name: "receipt timeout", waiter: &fakeReceiptWaiter{err: context.DeadlineExceeded}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			service := NewService(&Config{Config: newMockConfigProvider(), Logger: newMockLogWriter()})
+			got := service.deliveredAmount(context.Background(), tc.waiter, "0xabc", token, recipient)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package transaction
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// errDryRun aborts a dry-run send in its BeforeBroadcast hook, so the signed
+// transaction never reaches the network.
+var errDryRun = errors.New("dry run: transaction not broadcast")
+
+// SendPreview is the signed transaction of a dry-run send.
+type SendPreview struct {
+	Raw []byte // Serialized signed transaction
+
+	// Inputs are the UTXOs the transaction spends, and Outputs the UTXOs it
+	// creates in output order (UTXO chains only). An output paying
+	// ChangeAddress is the change.
+	Inputs        []chain.UTXO
+	Outputs       []chain.UTXO
+	ChangeAddress string
+
+	// Nonce is the account nonce of the transaction (ETH only).
+	Nonce uint64
+}
+
+// dryRun captures the signed transaction of a dry-run send.
+type dryRun struct {
+	signed *chain.SignedTx
+}
+
+// hook returns the BeforeBroadcast hook of the send of req: for a dry run,
+// one that captures the signed transaction and aborts the broadcast;
+// otherwise next.
+func (d *dryRun) hook(req *SendRequest, next func(*chain.SignedTx) error) func(*chain.SignedTx) error {
+	if !req.DryRun {
+		return next
+	}
+	return func(tx *chain.SignedTx) error {
+		d.signed = tx
+		return errDryRun
+	}
+}
+
+// utxoResult returns the result of a dry-run send on a UTXO chain: what the
+// send would have reported, without what only a broadcast changes. format
+// formats the fee and amount is the total paid to the recipients.
+func (d *dryRun) utxoResult(chainID chain.ID, req *SendRequest, format func(*big.Int) string, amount *big.Int, displayAmount, changeAddress string) *SendResult {
+	tx := d.signed
+	fee := chain.AmountToBigInt(tx.Fee)
+	return &SendResult{
+		Hash:    tx.Hash,
+		From:    req.FromAddress,
+		To:      req.To,
+		Amount:  displayAmount,
+		Fee:     format(fee),
+		Status:  StatusDryRun,
+		ChainID: chainID,
+
+		AmountUnits: amount,
+		FeeUnits:    fee,
+		Decimals:    8,
+
+		UTXOsSpent: len(tx.Spent),
+		Preview: &SendPreview{
+			Raw:           tx.Raw,
+			Inputs:        tx.Spent,
+			Outputs:       tx.Created,
+			ChangeAddress: changeAddress,
+		},
+	}
+}
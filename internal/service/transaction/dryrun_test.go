@@ -0,0 +1,82 @@
+package transaction
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/cosign"
+	"github.com/mrz1836/sigil/internal/txintent"
+	"github.com/mrz1836/sigil/internal/txjournal"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+func TestSendBTC_DryRun(t *testing.T) {
+	t.Parallel()
+
+	seed := getTestSeed(t)
+	addr, err := wallet.DeriveAddress(seed, wallet.ChainBTC, 0, 0)
+	require.NoError(t, err)
+
+	// Every broadcast is rejected and the approver refuses everything, so
+	// only a send that does neither succeeds
+	home := t.TempDir()
+	cfg := newMockConfigProvider()
+	cfg.home = home
+	cfg.btcAPI = newBTCBroadcastFailServer(t, addr.Address, true).URL
+	approver := &mockApprover{err: errors.Join(cosign.ErrDenied, errors.New("no"))} //nolint:err113 // test error
+	service := NewService(&Config{Config: cfg, Storage: newMockStorageProvider(), Logger: newMockLogWriter(), Approver: approver})
+
+	req := &SendRequest{
+		ChainID:     chain.BTC,
+		To:          "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+		AmountStr:   "all",
+		Wallet:      "test",
+		FromAddress: addr.Address,
+		Addresses:   []wallet.Address{*addr},
+		Seed:        seed,
+		DryRun:      true,
+	}
+	result, err := service.Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.True(t, result.DryRun())
+	assert.Equal(t, StatusDryRun, result.Status)
+	assert.Len(t, result.Hash, 64)
+	assert.Empty(t, approver.summaries)
+
+	preview := result.Preview
+	require.NotNil(t, preview)
+	assert.NotEmpty(t, preview.Raw)
+	require.Len(t, preview.Inputs, 1)
+	assert.Equal(t, uint64(100000), preview.Inputs[0].Amount)
+	require.Len(t, preview.Outputs, 1)
+	assert.Equal(t, req.To, preview.Outputs[0].Address)
+	assert.Equal(t, result.Hash, preview.Outputs[0].TxID)
+	assert.Equal(t, uint64(100000), preview.Outputs[0].Amount+result.FeeUnits.Uint64())
+	assert.Empty(t, preview.ChangeAddress, "a sweep has no change")
+
+	// Nothing was recorded, so the same UTXO can be previewed again
+	walletPath := filepath.Join(home, "wallets", "test")
+	intents, err := txintent.New(walletPath).Intents()
+	require.NoError(t, err)
+	assert.Empty(t, intents)
+	entries, err := txjournal.New(walletPath).Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	again, err := service.Send(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(preview.Raw), hex.EncodeToString(again.Preview.Raw))
+
+	// The real send still goes through approval
+	req.DryRun = false
+	_, err = service.Send(context.Background(), req)
+	require.Error(t, err)
+	assert.Len(t, approver.summaries, 1)
+}
@@ -6,30 +6,53 @@ import (
 	"math/big"
 	"path/filepath"
 	"runtime"
+	"strconv"
 
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/eth"
 	"github.com/mrz1836/sigil/internal/chain/eth/etherscan"
+	"github.com/mrz1836/sigil/internal/cosign"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // sendETH handles the complete Ethereum transaction flow.
 // Migrated from cli/tx.go lines 183-395
-//
-//nolint:gocognit,gocyclo // Transaction flow is inherently complex (migrated from CLI)
 func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, error) {
-	// Validate ETH address
-	if err := eth.ValidateChecksumAddress(req.To); err != nil {
-		if !eth.IsValidAddress(req.To) {
-			return nil, sigilerr.WithSuggestion(
-				sigilerr.ErrInvalidAddress,
-				fmt.Sprintf("invalid Ethereum address: %s", req.To),
-			)
-		}
+	// Validate ETH address; the recipient must be EIP-55 checksummed unless
+	// the caller explicitly allows single-case input.
+	to, err := NormalizeETHRecipient(req.To, req.AllowNonChecksum)
+	if err != nil {
+		return nil, err
 	}
+	req.To = to
+
+	client, err := s.newETHClient()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
 
+	return s.sendETHWith(ctx, client, req)
+}
+
+// NonceStorePath returns the location of the pending ETH nonce store under
+// the sigil home directory.
+func NonceStorePath(home string) string {
+	return filepath.Join(home, "cache", "nonces.json")
+}
+
+// GasTablePath returns the location of the last-known-good ETH gas table
+// under the sigil home directory.
+func GasTablePath(home string) string {
+	return filepath.Join(home, "cache", "gas.json")
+}
+
+// newETHClient creates an ETH client from the configured RPC, with broadcast
+// failover, the Etherscan gas price oracle when an API key is set, the
+// pending nonce store and the gas table.
+func (s *Service) newETHClient() (*eth.Client, error) {
 	// Get RPC URL from config
 	rpcURL := s.config.GetETHRPC()
 	if rpcURL == "" {
@@ -41,10 +64,15 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 
 	// Create ETH client with broadcast failover
 	clientOpts := &eth.ClientOptions{
-		FallbackRPCs: s.config.GetETHFallbackRPCs(),
+		FallbackRPCs:     s.config.GetETHFallbackRPCs(),
+		GasMarginPercent: s.config.GetETHGasMarginPercent(),
+		NonceStore:       eth.NewNonceStore(NonceStorePath(s.config.GetHome())),
+		GasTable:         eth.NewGasTableStore(GasTablePath(s.config.GetHome())),
 	}
 	if apiKey := s.config.GetETHEtherscanAPIKey(); apiKey != "" {
-		if esClient, esErr := etherscan.NewClient(apiKey, nil); esErr == nil {
+		if esClient, esErr := etherscan.NewClient(apiKey, &etherscan.ClientOptions{
+			ChainID: strconv.Itoa(s.config.GetETHChainID()),
+		}); esErr == nil {
 			clientOpts.BroadcastFallback = esClient
 			clientOpts.GasPriceOracle = etherscan.NewGasPriceAdapter(esClient)
 		}
@@ -53,8 +81,14 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 	if err != nil {
 		return nil, fmt.Errorf("creating ETH client: %w", err)
 	}
-	defer client.Close()
+	return client, nil
+}
 
+// sendETHWith sends one ETH or ERC-20 transaction with client. req.To must
+// already be normalized.
+//
+//nolint:gocognit,gocyclo // Transaction flow is inherently complex (migrated from CLI)
+func (s *Service) sendETHWith(ctx context.Context, client *eth.Client, req *SendRequest) (*SendResult, error) {
 	// Parse gas speed
 	speed, err := eth.ParseGasSpeed(req.GasSpeed)
 	if err != nil {
@@ -62,13 +96,15 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 	}
 
 	// Resolve token if specified
+	var token *eth.TokenMetadata
 	var tokenAddress string
 	var decimals int
 	if req.Token != "" {
-		tokenAddress, decimals, err = resolveToken(req.Token)
+		token, err = s.resolveSendToken(ctx, client, req)
 		if err != nil {
 			return nil, err
 		}
+		tokenAddress, decimals = token.Address, token.Decimals
 	}
 
 	// Parse amount (skip for sweep — calculated from balance)
@@ -93,6 +129,7 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 	// for sweeps — ETH transfer gas does not depend on the transfer value.
 	var estimate *eth.GasEstimate
 	var displayAmount string
+	var before *ethBalances
 
 	//nolint:nestif // Gas estimation + sweep calculation branches by token type and sweep mode
 	if tokenAddress != "" {
@@ -106,12 +143,13 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 				return nil, sigilerr.WithDetails(
 					sigilerr.ErrInsufficientFunds,
 					map[string]string{
-						"symbol": req.Token,
+						"symbol": token.Symbol,
 						"reason": "zero token balance",
 					},
 				)
 			}
 			amount = tokenBalance
+			before = &ethBalances{Token: tokenBalance}
 		}
 
 		// Build ERC-20 call data for gas estimation
@@ -142,8 +180,9 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 					},
 				)
 			}
+			before.ETH = ethBalance
 		} else {
-			err = checkETHBalance(ctx, client, req.FromAddress, amount, estimate.Total, tokenAddress)
+			before, err = checkETHBalance(ctx, client, req.FromAddress, amount, estimate.Total, tokenAddress)
 			if err != nil {
 				return nil, err
 			}
@@ -162,6 +201,7 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 				return nil, fmt.Errorf("estimating gas: %w", err)
 			}
 			amount = new(big.Int).Sub(ethBalance, estimate.Total)
+			before = &ethBalances{ETH: ethBalance}
 			if amount.Sign() <= 0 {
 				return nil, sigilerr.WithDetails(
 					sigilerr.ErrInsufficientFunds,
@@ -179,7 +219,7 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 			if err != nil {
 				return nil, fmt.Errorf("estimating gas: %w", err)
 			}
-			err = checkETHBalance(ctx, client, req.FromAddress, amount, estimate.Total, tokenAddress)
+			before, err = checkETHBalance(ctx, client, req.FromAddress, amount, estimate.Total, tokenAddress)
 			if err != nil {
 				return nil, err
 			}
@@ -189,8 +229,25 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 
 	// Agent policy enforcement is handled at CLI layer via AgentToken/AgentCounterPath fields
 
-	// Derive private key from seed
-	privateKey, err := wallet.DerivePrivateKeyForChain(req.Seed, wallet.ChainETH, 0)
+	if err := s.approveSend(ctx, req, &cosign.Summary{
+		Chain:    string(chain.ETH),
+		Wallet:   req.Wallet,
+		From:     req.FromAddress,
+		To:       req.To,
+		Amount:   amount.String(),
+		Display:  displayAmount,
+		Token:    tokenAddress,
+		Fee:      estimate.Total.String(),
+		SweepAll: req.SweepAll(),
+		Agent:    req.AgentCredID,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Derive private key from seed, for the first address of the sending account
+	keyCache, releaseKeys := requestKeyCache(req.Keys, req.Seed)
+	defer releaseKeys()
+	privateKey, err := keyCache.PrivateKey(wallet.ChainETH, req.Account, wallet.ExternalChain, 0)
 	if err != nil {
 		return nil, fmt.Errorf("deriving private key: %w", err)
 	}
@@ -206,49 +263,130 @@ func (s *Service) sendETH(ctx context.Context, req *SendRequest) (*SendResult, e
 		Token:      tokenAddress,
 		GasLimit:   estimate.GasLimit,
 		GasPrice:   estimate.GasPrice,
+		Nonce:      req.Nonce,
 	}
+	var dry dryRun
+	sendReq.BeforeBroadcast = dry.hook(req, nil)
 
 	// Send transaction
 	result, err := client.Send(ctx, sendReq)
+	if dry.signed != nil {
+		return &SendResult{
+			Hash:    dry.signed.Hash,
+			From:    req.FromAddress,
+			To:      req.To,
+			Amount:  displayAmount,
+			Fee:     client.FormatAmount(estimate.Total),
+			Token:   tokenSymbol(token),
+			Status:  StatusDryRun,
+			ChainID: chain.ETH,
+
+			AmountUnits:  amount,
+			FeeUnits:     estimate.Total,
+			Decimals:     ethResultDecimals(token),
+			TokenAddress: tokenAddress,
+
+			GasUsed:  estimate.GasLimit,
+			GasPrice: eth.FormatGasPrice(estimate.GasPrice),
+			Preview:  &SendPreview{Raw: dry.signed.Raw, Nonce: dry.signed.Nonce},
+		}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("sending transaction: %w", err)
 	}
 
 	// Invalidate balance cache
-	cachePath := filepath.Join(s.config.GetHome(), "cache", "balances.json")
-	cacheProvider := cache.NewFileStorage(cachePath)
+	invalidator := &cacheInvalidator{logger: s.logger, provider: cache.NewBalanceStorage(s.config.GetHome(), s.config.GetCache())}
 
 	if req.SweepAll() && tokenAddress == "" {
 		// Native ETH sweep: balance is now 0
-		invalidateBalanceCache(s.logger, cacheProvider, chain.ETH, req.FromAddress, "", "0.0")
+		invalidator.invalidate(chain.ETH, req.FromAddress, "", "0.0")
 	} else if req.SweepAll() && tokenAddress != "" {
 		// Token sweep: token balance is 0, ETH balance changed (gas spent)
-		invalidateBalanceCache(s.logger, cacheProvider, chain.ETH, req.FromAddress, tokenAddress, "0.0")
-		invalidateBalanceCache(s.logger, cacheProvider, chain.ETH, req.FromAddress, "", "")
+		invalidator.invalidate(chain.ETH, req.FromAddress, tokenAddress, "0.0")
+		invalidator.invalidate(chain.ETH, req.FromAddress, "", "")
 	} else {
 		// Partial send: delete entries to force fresh fetch
-		invalidateBalanceCache(s.logger, cacheProvider, chain.ETH, req.FromAddress, "", "")
+		invalidator.invalidate(chain.ETH, req.FromAddress, "", "")
 		if tokenAddress != "" {
-			invalidateBalanceCache(s.logger, cacheProvider, chain.ETH, req.FromAddress, tokenAddress, "")
+			invalidator.invalidate(chain.ETH, req.FromAddress, tokenAddress, "")
 		}
 	}
 
 	// Record agent spending (if in agent mode)
 	if req.AgentToken != "" && req.AgentCounterPath != "" {
-		recordAgentSpend(s.logger, req.AgentCounterPath, req.AgentToken, chain.ETH, amount)
+		recordAgentSpend(s.logger, req.AgentCounterPath, req.AgentToken, agentSpend(req, chain.ETH, tokenAddress, amount))
+	}
+
+	// Fee-on-transfer and rebasing tokens may deliver less than was sent;
+	// read the real amount from the receipt.
+	var delivered string
+	if token != nil && token.AmountMayDiffer() {
+		delivered = s.deliveredAmount(ctx, client, result.Hash, token, req.To)
 	}
 
 	// Convert to service result
 	return &SendResult{
-		Hash:     result.Hash,
-		From:     result.From,
-		To:       result.To,
-		Amount:   displayAmount,
-		Fee:      result.Fee,
-		Token:    req.Token,
-		Status:   result.Status,
-		ChainID:  chain.ETH,
+		Hash:      result.Hash,
+		From:      result.From,
+		To:        result.To,
+		Amount:    displayAmount,
+		Delivered: delivered,
+		Fee:       result.Fee,
+		Token:     tokenSymbol(token),
+		Status:    result.Status,
+		ChainID:   chain.ETH,
+
+		AmountUnits:  amount,
+		FeeUnits:     estimate.Total,
+		Decimals:     ethResultDecimals(token),
+		TokenAddress: tokenAddress,
+
 		GasUsed:  result.GasUsed,
 		GasPrice: result.GasPrice,
+		Changes: &SendChanges{
+			Balances:         ethBalanceChanges(client.FormatAmount, req, before, amount, estimate.Total, token),
+			CacheInvalidated: invalidator.touched,
+		},
 	}, nil
 }
+
+// ethResultDecimals returns the decimals of the asset an ETH send moved.
+func ethResultDecimals(token *eth.TokenMetadata) int {
+	if token != nil {
+		return token.Decimals
+	}
+	return 18
+}
+
+// ethBalanceChanges returns the sender's balance before an ETH or ERC-20 send
+// and the balance expected afterwards. The gas estimate is used as the fee,
+// so the expected ETH balance is a lower bound until the receipt is known.
+func ethBalanceChanges(format func(*big.Int) string, req *SendRequest, before *ethBalances, amount, gasCost *big.Int, token *eth.TokenMetadata) []BalanceChange {
+	if before == nil {
+		return nil
+	}
+
+	var changes []BalanceChange
+	if before.ETH != nil {
+		expected := new(big.Int).Sub(before.ETH, gasCost)
+		if token == nil {
+			expected.Sub(expected, amount)
+		}
+		changes = append(changes, BalanceChange{
+			Address:  req.FromAddress,
+			Symbol:   "ETH",
+			Previous: format(before.ETH),
+			Expected: format(nonNegative(expected)),
+		})
+	}
+	if token != nil && before.Token != nil {
+		changes = append(changes, BalanceChange{
+			Address:  req.FromAddress,
+			Symbol:   token.Symbol,
+			Previous: chain.FormatDecimalAmount(before.Token, token.Decimals),
+			Expected: chain.FormatDecimalAmount(nonNegative(new(big.Int).Sub(before.Token, amount)), token.Decimals),
+		})
+	}
+	return changes
+}
@@ -98,9 +98,16 @@ func TestSendETH_TokenResolution(t *testing.T) {
 			wantDecimals: eth.USDCDecimals,
 			wantErr:      false,
 		},
+		{
+			name:         "Built-in WBTC token",
+			token:        "WBTC",
+			wantAddress:  "0x2260FAC5E5542a773Aa44fBCfeDf7C193bc2C599",
+			wantDecimals: 8,
+			wantErr:      false,
+		},
 		{
 			name:    "Unsupported token",
-			token:   "DAI",
+			token:   "UNI",
 			wantErr: true,
 		},
 		{
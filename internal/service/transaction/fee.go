@@ -0,0 +1,76 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// FeeTablePath returns the location of the last-known-good BSV fee table
+// under the sigil home directory.
+func FeeTablePath(home string) string {
+	return filepath.Join(home, "cache", "fees.json")
+}
+
+// CheckMaxFeeRate rejects a quote whose standard rate exceeds maxFeeRate
+// (sat/KB). A zero maxFeeRate disables the check.
+func CheckMaxFeeRate(quote *bsv.FeeQuote, maxFeeRate uint64) error {
+	if maxFeeRate == 0 || quote.StandardRate <= maxFeeRate {
+		return nil
+	}
+
+	suggestion := fmt.Sprintf("fee rate %d sat/KB (source: %s) exceeds --max-fee-rate %d sat/KB",
+		quote.StandardRate, quote.Source, maxFeeRate)
+	if quote.IsFallback() {
+		suggestion += "; fee providers are unreachable, retry when they are back online or raise --max-fee-rate"
+	} else {
+		suggestion += "; raise --max-fee-rate to accept the current network rate"
+	}
+
+	return sigilerr.WithSuggestion(
+		sigilerr.WithDetails(sigilerr.ErrFeeRateTooHigh, map[string]string{
+			"rate":   fmt.Sprintf("%d", quote.StandardRate),
+			"max":    fmt.Sprintf("%d", maxFeeRate),
+			"source": quote.Source,
+		}),
+		suggestion,
+	)
+}
+
+// DescribeFeeQuote returns a short human-readable origin for a fee quote,
+// e.g. "live", "cached, 3h0m0s old", or "default".
+func DescribeFeeQuote(quote *bsv.FeeQuote) string {
+	switch quote.Source {
+	case bsv.FeeSourceCached:
+		return fmt.Sprintf("cached, %s old", quote.Age().Round(time.Minute))
+	case bsv.FeeSourceDefault:
+		return "default"
+	default:
+		return "live"
+	}
+}
+
+// bsvFeeQuote fetches the fee quote for a BSV send and enforces maxFeeRate.
+// Fallback quotes are logged, since they mean the fee API was unreachable.
+func (s *Service) bsvFeeQuote(ctx context.Context, client *bsv.Client, maxFeeRate uint64) (*bsv.FeeQuote, error) {
+	feeQuote, err := client.GetFeeQuote(ctx)
+	if err != nil {
+		feeQuote = &bsv.FeeQuote{StandardRate: bsv.DefaultFeeRate, DataRate: bsv.DefaultFeeRate, Source: bsv.FeeSourceDefault}
+	}
+
+	if s.logger != nil {
+		s.logger.Debug("bsv fee: rate=%d sat/KB source=%s", feeQuote.StandardRate, feeQuote.Source)
+		if feeQuote.IsFallback() {
+			s.logger.Error("bsv fee: providers unreachable, using %s rate %d sat/KB", DescribeFeeQuote(feeQuote), feeQuote.StandardRate)
+		}
+	}
+
+	if capErr := CheckMaxFeeRate(feeQuote, maxFeeRate); capErr != nil {
+		return nil, capErr
+	}
+	return feeQuote, nil
+}
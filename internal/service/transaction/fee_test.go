@@ -0,0 +1,63 @@
+package transaction
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestCheckMaxFeeRate(t *testing.T) {
+	t.Parallel()
+
+	live := &bsv.FeeQuote{StandardRate: 500, Source: bsv.FeeSourceWhatsOnChain}
+	cached := &bsv.FeeQuote{StandardRate: 500, Source: bsv.FeeSourceCached, Timestamp: time.Now().Add(-time.Hour)}
+
+	tests := []struct {
+		name    string
+		quote   *bsv.FeeQuote
+		max     uint64
+		wantErr bool
+	}{
+		{"no limit", live, 0, false},
+		{"below limit", live, 1000, false},
+		{"at limit", live, 500, false},
+		{"live above limit", live, 250, true},
+		{"cached above limit", cached, 250, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckMaxFeeRate(tc.quote, tc.max)
+			if !tc.wantErr {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, sigilerr.ErrFeeRateTooHigh)
+			assert.Contains(t, err.Error(), "source: "+tc.quote.Source)
+		})
+	}
+}
+
+func TestDescribeFeeQuote(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "live", DescribeFeeQuote(&bsv.FeeQuote{Source: bsv.FeeSourceWhatsOnChain}))
+	assert.Equal(t, "default", DescribeFeeQuote(&bsv.FeeQuote{Source: bsv.FeeSourceDefault}))
+	assert.Equal(t, "cached, 2h0m0s old", DescribeFeeQuote(&bsv.FeeQuote{
+		Source:    bsv.FeeSourceCached,
+		Timestamp: time.Now().Add(-2 * time.Hour),
+	}))
+}
+
+func TestFeeTablePath(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "/home/u/.sigil/cache/fees.json", FeeTablePath("/home/u/.sigil"))
+}
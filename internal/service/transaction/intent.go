@@ -0,0 +1,403 @@
+package transaction
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/txintent"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// IntentState is what the network shows for a send intent's inputs.
+type IntentState string
+
+// Send intent states reported by CheckIntent.
+const (
+	// IntentBroadcast means at least one input is spent: the transaction
+	// reached the network.
+	IntentBroadcast IntentState = "broadcast"
+
+	// IntentUnbroadcast means every input is still unspent: the transaction
+	// never reached the network, or has been dropped from it.
+	IntentUnbroadcast IntentState = "unbroadcast"
+)
+
+// sendIntent records a UTXO send in the wallet's intent log before it is
+// broadcast, so a crash mid-send leaves a trace that tx recover can resolve.
+type sendIntent struct {
+	log     *txintent.Log
+	logger  LogWriter
+	chainID chain.ID
+	network string
+	from    string
+	to      string
+	amount  *big.Int
+	agent   string
+
+	// recorded is the intent as written to the log; its Hash is set once
+	// the intent has been recorded.
+	recorded txintent.Intent
+}
+
+// newSendIntent returns the intent tracker for a send, or nil when the
+// request is not tied to a wallet.
+func (s *Service) newSendIntent(req *SendRequest, chainID chain.ID, network string, amount *big.Int) *sendIntent {
+	if req.Wallet == "" {
+		return nil
+	}
+	return &sendIntent{
+		log:     s.intentLog(req.Wallet),
+		logger:  s.logger,
+		chainID: chainID,
+		network: network,
+		from:    req.FromAddress,
+		to:      req.To,
+		amount:  amount,
+		agent:   req.AgentCredID,
+	}
+}
+
+// hook returns the chain.SendRequest BeforeBroadcast hook, or nil when there
+// is nothing to track.
+func (i *sendIntent) hook() func(*chain.SignedTx) error {
+	if i == nil {
+		return nil
+	}
+	return i.record
+}
+
+// record durably writes the signed transaction as an intent. A failure
+// aborts the send before anything reaches the network.
+func (i *sendIntent) record(tx *chain.SignedTx) error {
+	inputs := make([]txintent.Input, len(tx.Spent))
+	for j, u := range tx.Spent {
+		inputs[j] = txintent.Input{TxID: u.TxID, Vout: u.Vout, Address: u.Address, Amount: u.Amount}
+	}
+
+	intent := txintent.Intent{
+		Hash:    tx.Hash,
+		Chain:   i.chainID,
+		Network: i.network,
+		From:    i.from,
+		To:      i.to,
+		Amount:  unitsString(i.amount),
+		Fee:     new(big.Int).SetUint64(tx.Fee).String(),
+		Inputs:  inputs,
+		RawTx:   hex.EncodeToString(tx.Raw),
+		Agent:   i.agent,
+	}
+	if err := i.log.Record(intent); err != nil {
+		return fmt.Errorf("recording send intent: %w", err)
+	}
+	i.recorded = intent
+	return nil
+}
+
+// abandon clears the intent of a send that failed with sendErr. The intent
+// is kept when the failure leaves it unknown whether the transaction reached
+// the network.
+func (i *sendIntent) abandon(sendErr error) {
+	hash := i.hash()
+	if hash == "" {
+		return
+	}
+	if broadcastUncertain(sendErr) {
+		if i.logger != nil {
+			i.logger.Error("%s send: keeping intent %s, broadcast outcome unknown: %v", i.chainID, hash, sendErr)
+		}
+		return
+	}
+	if err := i.log.Clear(hash); err != nil && i.logger != nil {
+		i.logger.Error("%s send: failed to clear intent %s: %v", i.chainID, hash, err)
+	}
+}
+
+// hash returns the recorded intent's transaction hash, or "" when nothing
+// was recorded.
+func (i *sendIntent) hash() string {
+	if i == nil {
+		return ""
+	}
+	return i.recorded.Hash
+}
+
+// queue moves the intent of a send whose broadcast failed with sendErr into
+// the outbox for expiry. It reports false, leaving the intent to abandon,
+// when expiry is zero or a broadcast provider was reached.
+func (i *sendIntent) queue(sendErr error, expiry time.Duration) bool {
+	if i.hash() == "" || expiry <= 0 || !broadcastUnreachable(sendErr) {
+		return false
+	}
+	now := time.Now().UTC()
+	queued := i.recorded
+	queued.QueuedAt, queued.ExpiresAt = now, now.Add(expiry)
+	queued.Attempts, queued.LastError = 1, sendErr.Error()
+	if err := i.log.Record(queued); err != nil {
+		if i.logger != nil {
+			i.logger.Error("%s send: failed to queue intent %s: %v", i.chainID, queued.Hash, err)
+		}
+		return false
+	}
+	i.recorded = queued
+	return true
+}
+
+// queueSend queues a send that failed with sendErr in the wallet's outbox
+// when the request allows it, returning its queued result, or nil when the
+// send was not queued. The agent's spend is recorded as for a broadcast
+// send, since the signed transaction may still reach the network.
+func (s *Service) queueSend(req *SendRequest, intent *sendIntent, sendErr error, displayAmount string, utxosSpent int) *SendResult {
+	if !intent.queue(sendErr, req.QueueExpiry) {
+		return nil
+	}
+	queued := &intent.recorded
+	if s.logger != nil {
+		s.logger.Error("%s send: no broadcast provider reachable, queued %s until %s: %v",
+			intent.chainID, queued.Hash, queued.ExpiresAt.Format(time.RFC3339), sendErr)
+	}
+	if req.AgentToken != "" && req.AgentCounterPath != "" {
+		recordAgentSpend(s.logger, req.AgentCounterPath, req.AgentToken, agentSpend(req, intent.chainID, "", intent.amount))
+	}
+
+	fee, _ := new(big.Int).SetString(queued.Fee, 10)
+	return &SendResult{
+		Hash:    queued.Hash,
+		From:    queued.From,
+		To:      queued.To,
+		Amount:  displayAmount,
+		Fee:     chain.FormatDecimalAmount(fee, 8),
+		Status:  StatusQueued,
+		ChainID: intent.chainID,
+
+		AmountUnits: intent.amount,
+		FeeUnits:    fee,
+		Decimals:    8,
+
+		UTXOsSpent:  utxosSpent,
+		QueuedUntil: queued.ExpiresAt,
+	}
+}
+
+// broadcastUncertain reports whether a broadcast error may have happened
+// after the transaction reached the network.
+func broadcastUncertain(err error) bool {
+	return errors.Is(err, sigilerr.ErrNetworkError) ||
+		errors.Is(err, context.DeadlineExceeded) ||
+		errors.Is(err, context.Canceled)
+}
+
+// broadcastUnreachable reports whether a broadcast error means no provider
+// could be reached, so the transaction is worth queueing for a later retry.
+func broadcastUnreachable(err error) bool {
+	return errors.Is(err, sigilerr.ErrNetworkError) ||
+		errors.Is(err, context.DeadlineExceeded)
+}
+
+// intentLog returns the send intent log of the named wallet.
+func (s *Service) intentLog(walletName string) *txintent.Log {
+	return txintent.New(filepath.Join(s.config.GetHome(), "wallets", walletName))
+}
+
+// clearIntent removes a send's intent once its outcome is recorded in the
+// UTXO store and journal. The transaction is already on the network, so a
+// failure here is logged and never returned.
+func (s *Service) clearIntent(req *SendRequest, result *SendResult) {
+	if req.Wallet == "" || s.config == nil {
+		return
+	}
+	if err := s.intentLog(req.Wallet).Clear(result.Hash); err != nil && s.logger != nil {
+		s.logger.Error("failed to clear send intent %s: %v", result.Hash, err)
+	}
+}
+
+// filterReservedUTXOs removes UTXOs spent by unresolved send intents in the
+// wallet at walletPath. An unreadable intent log is logged and ignored.
+func filterReservedUTXOs(logger LogWriter, walletPath string, chainID chain.ID, utxos []chain.UTXO) []chain.UTXO {
+	intents, err := txintent.New(walletPath).Intents()
+	if err != nil {
+		if logger != nil {
+			logger.Error("%s send: failed to load send intents: %v", chainID, err)
+		}
+		return utxos
+	}
+	reserved := txintent.Reserved(intents, chainID)
+	if len(reserved) == 0 {
+		return utxos
+	}
+
+	filtered := make([]chain.UTXO, 0, len(utxos))
+	for _, u := range utxos {
+		if !reserved[txintent.OutpointKey(u.TxID, u.Vout)] {
+			filtered = append(filtered, u)
+		}
+	}
+	return filtered
+}
+
+// FilterReservedUTXOs is the exported version for external use.
+func FilterReservedUTXOs(logger LogWriter, walletPath string, chainID chain.ID, utxos []chain.UTXO) []chain.UTXO {
+	return filterReservedUTXOs(logger, walletPath, chainID, utxos)
+}
+
+// intentBackend is the chain access intent recovery needs.
+type intentBackend struct {
+	listUTXOs func(ctx context.Context, addresses []wallet.Address) ([]chain.UTXO, error)
+	broadcast func(ctx context.Context, rawTx []byte) (string, error)
+}
+
+// newIntentBackend builds a client for the intent's chain and network.
+func (s *Service) newIntentBackend(ctx context.Context, intent *txintent.Intent) (*intentBackend, error) {
+	switch intent.Chain {
+	case chain.BSV:
+		network := intent.Network
+		if network == "" {
+			network = s.config.GetBSVNetwork()
+		}
+		client := bsv.NewClient(ctx, &bsv.ClientOptions{
+			APIKey:  s.config.GetBSVAPIKey(),
+			Network: bsv.Network(network),
+			Logger:  s.logger,
+		})
+		return &intentBackend{
+			listUTXOs: func(ctx context.Context, addresses []wallet.Address) ([]chain.UTXO, error) {
+				return aggregateBSVUTXOs(ctx, client, addresses)
+			},
+			broadcast: client.BroadcastTransaction,
+		}, nil
+	case chain.BTC:
+		client, err := NewBTCClient(s.config, intent.Network, s.logger)
+		if err != nil {
+			return nil, err
+		}
+		return &intentBackend{
+			listUTXOs: func(ctx context.Context, addresses []wallet.Address) ([]chain.UTXO, error) {
+				return aggregateUTXOs(ctx, client, addresses)
+			},
+			broadcast: client.Broadcast,
+		}, nil
+	case chain.BCH:
+		client, err := NewBCHClient(s.config, intent.Network, s.logger)
+		if err != nil {
+			return nil, err
+		}
+		return &intentBackend{
+			listUTXOs: func(ctx context.Context, addresses []wallet.Address) ([]chain.UTXO, error) {
+				return aggregateUTXOs(ctx, client, addresses)
+			},
+			broadcast: client.Broadcast,
+		}, nil
+	case chain.ETH, chain.LTC:
+		return nil, sigilerr.ErrNotImplemented
+	default:
+		return nil, sigilerr.ErrNotImplemented
+	}
+}
+
+// CheckIntent reports whether an intent's transaction reached the network
+// by looking up its inputs: a spent input means it did.
+func (s *Service) CheckIntent(ctx context.Context, intent *txintent.Intent) (IntentState, error) {
+	backend, err := s.newIntentBackend(ctx, intent)
+	if err != nil {
+		return "", err
+	}
+	return checkIntent(ctx, backend, intent)
+}
+
+// checkIntent is CheckIntent against a given backend.
+func checkIntent(ctx context.Context, backend *intentBackend, intent *txintent.Intent) (IntentState, error) {
+	var addresses []wallet.Address
+	seen := make(map[string]bool)
+	for _, in := range intent.Inputs {
+		if !seen[in.Address] {
+			seen[in.Address] = true
+			addresses = append(addresses, wallet.Address{Address: in.Address})
+		}
+	}
+
+	utxos, err := backend.listUTXOs(ctx, addresses)
+	if err != nil {
+		return "", fmt.Errorf("listing UTXOs: %w", err)
+	}
+	unspent := make(map[string]bool, len(utxos))
+	for _, u := range utxos {
+		unspent[txintent.OutpointKey(u.TxID, u.Vout)] = true
+	}
+	for _, in := range intent.Inputs {
+		if !unspent[in.Key()] {
+			return IntentBroadcast, nil
+		}
+	}
+	return IntentUnbroadcast, nil
+}
+
+// RebroadcastIntent resubmits an intent's signed transaction and, once the
+// network accepts it, finalizes the intent.
+func (s *Service) RebroadcastIntent(ctx context.Context, walletName string, intent *txintent.Intent) (string, error) {
+	rawTx, err := hex.DecodeString(intent.RawTx)
+	if err != nil {
+		return "", fmt.Errorf("decoding intent %s: %w", intent.Hash, err)
+	}
+	backend, err := s.newIntentBackend(ctx, intent)
+	if err != nil {
+		return "", err
+	}
+	hash, err := backend.broadcast(ctx, rawTx)
+	if err != nil {
+		return "", fmt.Errorf("rebroadcasting %s: %w", intent.Hash, err)
+	}
+	if !strings.EqualFold(hash, intent.Hash) && s.logger != nil {
+		s.logger.Error("rebroadcast of %s returned txid %s", intent.Hash, hash)
+	}
+	return intent.Hash, s.FinalizeIntent(walletName, intent)
+}
+
+// FinalizeIntent records the outcome of an intent whose transaction reached
+// the network: its inputs are marked spent, a pending journal entry is
+// added, and the intent is cleared.
+func (s *Service) FinalizeIntent(walletName string, intent *txintent.Intent) error {
+	walletPath := filepath.Join(s.config.GetHome(), "wallets", walletName)
+
+	spent := make([]chain.UTXO, len(intent.Inputs))
+	for i, in := range intent.Inputs {
+		spent[i] = chain.UTXO{TxID: in.TxID, Vout: in.Vout, Amount: in.Amount, Address: in.Address}
+	}
+	store := utxostore.New(walletPath)
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("loading utxo store: %w", err)
+	}
+	markSpentUTXOs(s.logger, intent.Chain, store, spent, intent.Hash)
+
+	amount, _ := new(big.Int).SetString(intent.Amount, 10)
+	fee, _ := new(big.Int).SetString(intent.Fee, 10)
+	s.recordJournal(&SendRequest{Wallet: walletName, AgentCredID: intent.Agent}, &SendResult{
+		Hash:        intent.Hash,
+		From:        intent.From,
+		To:          intent.To,
+		ChainID:     intent.Chain,
+		AmountUnits: amount,
+		FeeUnits:    fee,
+		Decimals:    8,
+	})
+
+	return s.ReleaseIntent(walletName, intent.Hash)
+}
+
+// ReleaseIntent drops an intent without recording it, returning its inputs
+// to coin selection. Use it only for a transaction that never reached the
+// network.
+func (s *Service) ReleaseIntent(walletName, hash string) error {
+	if err := s.intentLog(walletName).Clear(hash); err != nil {
+		return fmt.Errorf("clearing send intent: %w", err)
+	}
+	return nil
+}
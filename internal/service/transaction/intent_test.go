@@ -0,0 +1,207 @@
+package transaction
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/txintent"
+	"github.com/mrz1836/sigil/internal/txjournal"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// newBTCBroadcastFailServer serves one UTXO for addr and fails every
+// broadcast: with a 400 when reject is set, otherwise by dropping the
+// connection so the outcome is unknown.
+func newBTCBroadcastFailServer(t *testing.T, addr string, reject bool) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v1/fees/recommended":
+			_, _ = w.Write([]byte(`{"fastestFee":1,"halfHourFee":1,"hourFee":1,"economyFee":1,"minimumFee":1}`))
+		case r.URL.Path == "/tx" && reject:
+			http.Error(w, "bad-txns-inputs-missingorspent", http.StatusBadRequest)
+		case r.URL.Path == "/tx":
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				_ = conn.Close()
+			}
+		case r.URL.Path == "/address/"+addr+"/utxo":
+			_, _ = w.Write([]byte(`[{"txid":"` + strings.Repeat("ab", 32) + `","vout":0,"value":100000,"status":{"confirmed":true,"block_height":1}}]`))
+		case strings.HasSuffix(r.URL.Path, "/utxo"):
+			_, _ = w.Write([]byte(`[]`))
+		case r.URL.Path == "/blocks/tip/height":
+			_, _ = w.Write([]byte("10"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSendBTC_Intent(t *testing.T) {
+	t.Parallel()
+
+	seed := getTestSeed(t)
+	addr, err := wallet.DeriveAddress(seed, wallet.ChainBTC, 0, 0)
+	require.NoError(t, err)
+
+	send := func(t *testing.T, home, api string, queueExpiry time.Duration) (*SendResult, error) {
+		t.Helper()
+		cfg := newMockConfigProvider()
+		cfg.home = home
+		cfg.btcAPI = api
+		service := NewService(&Config{Config: cfg, Storage: newMockStorageProvider(), Logger: newMockLogWriter()})
+		return service.Send(context.Background(), &SendRequest{
+			ChainID:     chain.BTC,
+			To:          "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4",
+			AmountStr:   "all",
+			Wallet:      "test",
+			FromAddress: addr.Address,
+			Addresses:   []wallet.Address{*addr},
+			Seed:        seed,
+			QueueExpiry: queueExpiry,
+		})
+	}
+
+	t.Run("rejected broadcast clears the intent", func(t *testing.T) {
+		t.Parallel()
+		home := t.TempDir()
+		_, err := send(t, home, newBTCBroadcastFailServer(t, addr.Address, true).URL, time.Hour)
+		require.Error(t, err, "a rejected send is never queued")
+
+		intents, err := txintent.New(filepath.Join(home, "wallets", "test")).Intents()
+		require.NoError(t, err)
+		assert.Empty(t, intents)
+	})
+
+	t.Run("unknown outcome keeps the inputs reserved", func(t *testing.T) {
+		t.Parallel()
+		home := t.TempDir()
+		server := newBTCBroadcastFailServer(t, addr.Address, false)
+		_, err := send(t, home, server.URL, 0)
+		require.ErrorIs(t, err, sigilerr.ErrNetworkError)
+
+		intents, err := txintent.New(filepath.Join(home, "wallets", "test")).Intents()
+		require.NoError(t, err)
+		require.Len(t, intents, 1)
+		assert.Equal(t, chain.BTC, intents[0].Chain)
+		assert.Equal(t, "main", intents[0].Network)
+		require.Len(t, intents[0].Inputs, 1)
+		assert.Equal(t, addr.Address, intents[0].Inputs[0].Address)
+		assert.NotEmpty(t, intents[0].RawTx)
+		assert.False(t, intents[0].Queued())
+
+		_, err = send(t, home, server.URL, 0)
+		require.ErrorIs(t, err, sigilerr.ErrInsufficientFunds,
+			"the reserved UTXO must not be selected again")
+	})
+
+	t.Run("unreachable providers queue the send", func(t *testing.T) {
+		t.Parallel()
+		home := t.TempDir()
+		before := time.Now()
+		result, err := send(t, home, newBTCBroadcastFailServer(t, addr.Address, false).URL, time.Hour)
+		require.NoError(t, err)
+		assert.True(t, result.Queued())
+		assert.Equal(t, StatusQueued, result.Status)
+		assert.WithinRange(t, result.QueuedUntil, before.Add(time.Hour), time.Now().Add(time.Hour))
+		assert.Equal(t, 1, result.UTXOsSpent)
+		assert.Positive(t, result.FeeUnits.Sign())
+
+		walletPath := filepath.Join(home, "wallets", "test")
+		intents, err := txintent.New(walletPath).Intents()
+		require.NoError(t, err)
+		require.Len(t, intents, 1)
+		assert.Equal(t, result.Hash, intents[0].Hash)
+		assert.True(t, intents[0].Queued())
+		assert.Equal(t, 1, intents[0].Attempts)
+		assert.NotEmpty(t, intents[0].LastError)
+
+		// Not broadcast yet, so nothing is journaled
+		entries, err := txjournal.New(walletPath).Entries()
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestCheckIntent(t *testing.T) {
+	t.Parallel()
+
+	intent := &txintent.Intent{Hash: "cc", Inputs: []txintent.Input{
+		{TxID: "AA", Vout: 0, Address: "1A"},
+		{TxID: "bb", Vout: 1, Address: "1B"},
+	}}
+	backend := func(utxos ...chain.UTXO) *intentBackend {
+		return &intentBackend{listUTXOs: func(context.Context, []wallet.Address) ([]chain.UTXO, error) {
+			return utxos, nil
+		}}
+	}
+
+	state, err := checkIntent(context.Background(), backend(
+		chain.UTXO{TxID: "aa", Vout: 0}, chain.UTXO{TxID: "bb", Vout: 1},
+	), intent)
+	require.NoError(t, err)
+	assert.Equal(t, IntentUnbroadcast, state)
+
+	state, err = checkIntent(context.Background(), backend(chain.UTXO{TxID: "aa", Vout: 0}), intent)
+	require.NoError(t, err)
+	assert.Equal(t, IntentBroadcast, state)
+}
+
+func TestFinalizeIntent(t *testing.T) {
+	t.Parallel()
+
+	cfg := newMockConfigProvider()
+	cfg.home = t.TempDir()
+	service := NewService(&Config{Config: cfg, Logger: newMockLogWriter()})
+	walletPath := filepath.Join(cfg.home, "wallets", "main")
+
+	intent := txintent.Intent{
+		Hash: "cc", Chain: chain.BSV, From: "1From", To: "1To", Amount: "5000", Fee: "20",
+		Inputs: []txintent.Input{{TxID: "aa", Vout: 1, Address: "1From", Amount: 5020}},
+	}
+	require.NoError(t, txintent.New(walletPath).Record(intent))
+	require.NoError(t, service.FinalizeIntent("main", &intent))
+
+	store := utxostore.New(walletPath)
+	require.NoError(t, store.Load())
+	assert.True(t, store.IsSpent(chain.BSV, "aa", 1))
+
+	entries, err := txjournal.New(walletPath).Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "cc", entries[0].Hash)
+	assert.Equal(t, "5000", entries[0].Amount)
+	assert.Equal(t, "20", entries[0].Fee)
+
+	intents, err := txintent.New(walletPath).Intents()
+	require.NoError(t, err)
+	assert.Empty(t, intents)
+}
+
+func TestFilterReservedUTXOs(t *testing.T) {
+	t.Parallel()
+
+	walletPath := t.TempDir()
+	require.NoError(t, txintent.New(walletPath).Record(txintent.Intent{
+		Hash: "cc", Chain: chain.BTC, Inputs: []txintent.Input{{TxID: "aa", Vout: 0}},
+	}))
+
+	utxos := []chain.UTXO{{TxID: "AA", Vout: 0}, {TxID: "aa", Vout: 1}}
+	filtered := filterReservedUTXOs(nil, walletPath, chain.BTC, utxos)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, uint32(1), filtered[0].Vout)
+	assert.Len(t, filterReservedUTXOs(nil, walletPath, chain.BSV, utxos), 2)
+}
@@ -1,11 +1,14 @@
 package transaction
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/mrz1836/sigil/internal/agent"
 	"github.com/mrz1836/sigil/internal/cache"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/cosign"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
 )
@@ -15,11 +18,18 @@ type ConfigProvider interface {
 	GetHome() string
 	GetETHRPC() string
 	GetETHFallbackRPCs() []string
+	GetETHChainID() int
 	GetETHEtherscanAPIKey() string
+	GetETHTokens() []config.TokenConfig
+	GetETHGasMarginPercent() int
 	GetBSVAPIKey() string
 	GetBSVNetwork() string
 	GetBSVFeeStrategy() string
 	GetBSVMinMiners() int
+	GetBSVCoinSelection() string
+	GetBTCAPI() string
+	GetBCHAPI() string
+	GetCache() config.CacheConfig
 }
 
 // CacheProvider provides balance cache operations.
@@ -33,6 +43,8 @@ type UTXOProvider interface {
 	Load() error
 	Save() error
 	IsSpent(chainID chain.ID, txid string, vout uint32) bool
+	IsImmature(chainID chain.ID, txid string, vout uint32) bool
+	IsReceiveOnly(chainID chain.ID, address string) bool
 	AddUTXO(utxo *utxostore.StoredUTXO)
 	MarkSpent(chainID chain.ID, txid string, vout uint32, spentTxID string) bool
 }
@@ -48,6 +60,12 @@ type LogWriter interface {
 	Error(format string, args ...any)
 }
 
+// Approver asks an external policy service to approve a transaction before
+// it is signed and broadcast. A nil error means approved.
+type Approver interface {
+	Approve(ctx context.Context, summary *cosign.Summary) error
+}
+
 // AgentCredential represents agent mode credentials for policy enforcement.
 type AgentCredential interface {
 	HasChain(chainID chain.ID) bool
@@ -0,0 +1,89 @@
+package transaction
+
+import (
+	"math/big"
+	"path/filepath"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/txjournal"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// recordJournal adds a broadcast transaction to the wallet's journal as
+// pending. The transaction is already on the network, so a failure here is
+// logged and never returned.
+func (s *Service) recordJournal(req *SendRequest, result *SendResult) {
+	if req.Wallet == "" || s.config == nil {
+		return
+	}
+
+	entry := journalEntry(result)
+	entry.Agent = req.AgentCredID
+	if wlt, err := wallet.NewFileStorage(filepath.Join(s.config.GetHome(), "wallets")).LoadMetadata(req.Wallet); err == nil {
+		entry.Internal = IsInternalTransfer(wlt, req)
+	}
+
+	journal := txjournal.New(filepath.Join(s.config.GetHome(), "wallets", req.Wallet))
+	if err := journal.Record(entry); err != nil && s.logger != nil {
+		s.logger.Error("failed to record transaction %s in journal: %v", result.Hash, err)
+	}
+}
+
+// IsInternalTransfer reports whether every recipient of req is an address
+// of wlt, in any of its accounts, so the send only moves coins between the
+// wallet's own addresses.
+func IsInternalTransfer(wlt *wallet.Wallet, req *SendRequest) bool {
+	recipients := req.AllPayments()
+	if len(req.Split) > 0 {
+		recipients = req.Split
+	}
+	if len(recipients) == 0 {
+		return false
+	}
+	for _, p := range recipients {
+		if !wlt.OwnsAddress(req.ChainID, p.To) {
+			return false
+		}
+	}
+	return true
+}
+
+// journalEntry converts a send result to a pending journal entry.
+func journalEntry(result *SendResult) txjournal.Entry {
+	symbol := result.Token
+	if symbol == "" {
+		symbol = strings.ToUpper(string(result.ChainID))
+	}
+
+	return txjournal.Entry{
+		Hash:      result.Hash,
+		Chain:     result.ChainID,
+		From:      result.From,
+		To:        result.To,
+		Amount:    unitsString(result.AmountUnits),
+		Token:     result.TokenAddress,
+		Symbol:    symbol,
+		Decimals:  result.Decimals,
+		Fee:       unitsString(result.FeeUnits),
+		Status:    txjournal.StatusPending,
+		Direction: txjournal.DirectionSent,
+	}
+}
+
+// unitsString formats a smallest-unit amount, treating nil as zero.
+func unitsString(n *big.Int) string {
+	if n == nil {
+		return "0"
+	}
+	return n.String()
+}
+
+// parseFeeUnits converts a formatted fee back to the smallest unit, or nil
+// when it cannot be parsed.
+func parseFeeUnits(parse func(string) (*big.Int, error), fee string) *big.Int {
+	units, err := parse(fee)
+	if err != nil {
+		return nil
+	}
+	return units
+}
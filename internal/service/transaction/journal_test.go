@@ -0,0 +1,92 @@
+package transaction
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/txjournal"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+func TestRecordJournal(t *testing.T) {
+	t.Parallel()
+
+	cfg := newMockConfigProvider()
+	cfg.home = t.TempDir()
+	service := NewService(&Config{Config: cfg, Logger: newMockLogWriter()})
+
+	service.recordJournal(&SendRequest{Wallet: "main", AgentCredID: "agt_1"}, &SendResult{
+		Hash:         "0xabc",
+		From:         "0xfrom",
+		To:           "0xto",
+		Token:        "USDC",
+		ChainID:      chain.ETH,
+		AmountUnits:  big.NewInt(1_500_000),
+		FeeUnits:     big.NewInt(42),
+		Decimals:     6,
+		TokenAddress: "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48",
+	})
+	service.recordJournal(&SendRequest{}, &SendResult{Hash: "0xskipped"})
+
+	entries, err := txjournal.New(filepath.Join(cfg.home, "wallets", "main")).Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, "0xabc", e.Hash)
+	assert.Equal(t, "1500000", e.Amount)
+	assert.Equal(t, "42", e.Fee)
+	assert.Equal(t, "USDC", e.Symbol)
+	assert.Equal(t, txjournal.StatusPending, e.Status)
+	assert.Equal(t, txjournal.DirectionSent, e.Direction)
+	assert.Equal(t, "agt_1", e.Agent)
+}
+
+func TestJournalEntry_NativeSymbol(t *testing.T) {
+	t.Parallel()
+
+	e := journalEntry(&SendResult{Hash: "abc", ChainID: chain.BSV, AmountUnits: big.NewInt(5000), Decimals: 8})
+	assert.Equal(t, "BSV", e.Symbol)
+	assert.Equal(t, "5000", e.Amount)
+	assert.Equal(t, "0", e.Fee, "missing fee recorded as zero")
+}
+
+func TestRecordJournal_Internal(t *testing.T) {
+	t.Parallel()
+
+	cfg := newMockConfigProvider()
+	cfg.home = t.TempDir()
+	service := NewService(&Config{Config: cfg, Logger: newMockLogWriter()})
+
+	seed := getTestSeed(t)
+	wlt, err := wallet.NewWallet("main", []wallet.ChainID{wallet.ChainBSV})
+	require.NoError(t, err)
+	require.NoError(t, wlt.DeriveAddresses(seed, 2))
+	storage := wallet.NewFileStorage(filepath.Join(cfg.home, "wallets"))
+	require.NoError(t, storage.Save(wlt, seed, []byte("test-password-123")))
+
+	own := wlt.Addresses[wallet.ChainBSV][1].Address
+	req := &SendRequest{Wallet: "main", ChainID: chain.BSV, To: own, AmountStr: "all"}
+	assert.True(t, IsInternalTransfer(wlt, req))
+	service.recordJournal(req, &SendResult{Hash: "internal", ChainID: chain.BSV, To: own})
+
+	external := &SendRequest{
+		Wallet: "main", ChainID: chain.BSV, To: own, AmountStr: "0.1",
+		Payments: []Payment{{To: validBSVAddress, AmountStr: "0.1"}},
+	}
+	assert.False(t, IsInternalTransfer(wlt, external), "one outside recipient makes the send external")
+	service.recordJournal(external, &SendResult{Hash: "external", ChainID: chain.BSV, To: own})
+
+	entries, err := txjournal.New(filepath.Join(cfg.home, "wallets", "main")).Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	internal := map[string]bool{}
+	for _, e := range entries {
+		internal[e.Hash] = e.Internal
+	}
+	assert.Equal(t, map[string]bool{"internal": true, "external": false}, internal)
+}
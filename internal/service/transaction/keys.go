@@ -13,13 +13,20 @@ import (
 var errAddressNotInWallet = errors.New("address not found in wallet")
 
 // deriveKeysForUTXOs derives private keys for each unique address that appears in the UTXO set.
-// Returns a map of address → private key. The caller must zero all keys after use.
+// Keys are taken from the key cache so repeated lookups within one command reuse earlier
+// derivations. Returns a map of address → private key. The caller must zero all keys after use.
 // Migrated from cli/tx.go lines 1046-1070
-func deriveKeysForUTXOs(utxos []chain.UTXO, addresses []wallet.Address, seed []byte) (map[string][]byte, error) {
-	// Build address → index lookup
-	addrIndex := make(map[string]uint32, len(addresses))
+func deriveKeysForUTXOs(utxos []chain.UTXO, addresses []wallet.Address, keyCache *wallet.KeyCache) (map[string][]byte, error) {
+	return deriveChainKeysForUTXOs(wallet.ChainBSV, utxos, addresses, keyCache)
+}
+
+// deriveChainKeysForUTXOs is deriveKeysForUTXOs for any UTXO chain, whose
+// coin type selects the derivation path.
+func deriveChainKeysForUTXOs(chainID chain.ID, utxos []chain.UTXO, addresses []wallet.Address, keyCache *wallet.KeyCache) (map[string][]byte, error) {
+	// Build address lookup
+	byAddr := make(map[string]wallet.Address, len(addresses))
 	for _, addr := range addresses {
-		addrIndex[addr.Address] = addr.Index
+		byAddr[addr.Address] = addr
 	}
 
 	// Collect unique addresses from UTXOs
@@ -28,7 +35,7 @@ func deriveKeysForUTXOs(utxos []chain.UTXO, addresses []wallet.Address, seed []b
 	// Derive private key for each unique address
 	keys := make(map[string][]byte, len(needed))
 	for addr := range needed {
-		key, err := deriveKeyForAddress(addr, addrIndex, seed)
+		key, err := deriveKeyForAddress(chainID, addr, byAddr, keyCache)
 		if err != nil {
 			zeroKeyMap(keys)
 			return nil, err
@@ -40,24 +47,39 @@ func deriveKeysForUTXOs(utxos []chain.UTXO, addresses []wallet.Address, seed []b
 }
 
 // DeriveKeysForUTXOs is the exported version for external use.
+// It derives from seed without caching across calls.
 func DeriveKeysForUTXOs(utxos []chain.UTXO, addresses []wallet.Address, seed []byte) (map[string][]byte, error) {
-	return deriveKeysForUTXOs(utxos, addresses, seed)
+	keyCache := wallet.NewKeyCache(seed)
+	defer keyCache.Zero()
+	return deriveKeysForUTXOs(utxos, addresses, keyCache)
 }
 
-// deriveKeyForAddress derives a private key for a single address using the index lookup.
+// deriveKeyForAddress derives a chain's private key for a single address using
+// the address lookup, under the BIP44 account the address was derived in.
 // Migrated from cli/tx.go lines 1072-1083
-func deriveKeyForAddress(addr string, addrIndex map[string]uint32, seed []byte) ([]byte, error) {
-	index, ok := addrIndex[addr]
+func deriveKeyForAddress(chainID chain.ID, addr string, byAddr map[string]wallet.Address, keyCache *wallet.KeyCache) ([]byte, error) {
+	stored, ok := byAddr[addr]
 	if !ok {
 		return nil, fmt.Errorf("%w: %s", errAddressNotInWallet, addr)
 	}
-	privKey, err := wallet.DerivePrivateKeyForChain(seed, wallet.ChainBSV, index)
+	privKey, err := keyCache.PrivateKey(chainID, stored.Account, wallet.ExternalChain, stored.Index)
 	if err != nil {
-		return nil, fmt.Errorf("deriving key for address %s (index %d): %w", addr, index, err)
+		return nil, fmt.Errorf("deriving key for address %s (index %d): %w", addr, stored.Index, err)
 	}
 	return privKey, nil
 }
 
+// requestKeyCache returns the key cache supplied with a request, or a new cache
+// scoped to the current call when none was given. release must be called once
+// the derived keys are no longer needed; it only zeroes caches created here.
+func requestKeyCache(keyCache *wallet.KeyCache, seed []byte) (kc *wallet.KeyCache, release func()) {
+	if keyCache != nil {
+		return keyCache, func() {}
+	}
+	kc = wallet.NewKeyCache(seed)
+	return kc, kc.Zero
+}
+
 // zeroKeyMap zeros all private keys in the map.
 // Migrated from cli/tx.go lines 1085-1090
 func zeroKeyMap(keys map[string][]byte) {
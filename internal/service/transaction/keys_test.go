@@ -25,7 +25,7 @@ func TestDeriveKeysForUTXOs_SingleAddress(t *testing.T) {
 
 	seed := getTestSeed(t)
 
-	keys, err := deriveKeysForUTXOs(utxos, addresses, seed)
+	keys, err := deriveKeysForUTXOs(utxos, addresses, wallet.NewKeyCache(seed))
 	require.NoError(t, err)
 	require.NotNil(t, keys)
 	assert.Len(t, keys, 1, "should derive exactly one key for one unique address")
@@ -61,7 +61,7 @@ func TestDeriveKeysForUTXOs_MultipleAddresses(t *testing.T) {
 
 	seed := getTestSeed(t)
 
-	keys, err := deriveKeysForUTXOs(utxos, addresses, seed)
+	keys, err := deriveKeysForUTXOs(utxos, addresses, wallet.NewKeyCache(seed))
 	require.NoError(t, err)
 	require.NotNil(t, keys)
 	assert.Len(t, keys, 3, "should derive three keys for three unique addresses")
@@ -99,7 +99,7 @@ func TestDeriveKeysForUTXOs_DuplicateAddresses(t *testing.T) {
 
 	seed := getTestSeed(t)
 
-	keys, err := deriveKeysForUTXOs(utxos, addresses, seed)
+	keys, err := deriveKeysForUTXOs(utxos, addresses, wallet.NewKeyCache(seed))
 	require.NoError(t, err)
 	require.NotNil(t, keys)
 	assert.Len(t, keys, 2, "should derive only 2 keys despite 5 UTXOs")
@@ -126,7 +126,7 @@ func TestDeriveKeysForUTXOs_AddressNotInWallet(t *testing.T) {
 
 	seed := getTestSeed(t)
 
-	keys, err := deriveKeysForUTXOs(utxos, addresses, seed)
+	keys, err := deriveKeysForUTXOs(utxos, addresses, wallet.NewKeyCache(seed))
 	require.Error(t, err)
 	assert.Nil(t, keys, "should return nil keys on error")
 	assert.Contains(t, err.Error(), "1NOTFOUND")
@@ -145,7 +145,7 @@ func TestDeriveKeysForUTXOs_EmptyUTXOs(t *testing.T) {
 
 	seed := getTestSeed(t)
 
-	keys, err := deriveKeysForUTXOs(utxos, addresses, seed)
+	keys, err := deriveKeysForUTXOs(utxos, addresses, wallet.NewKeyCache(seed))
 	require.NoError(t, err)
 	require.NotNil(t, keys)
 	assert.Empty(t, keys, "should return empty map for empty UTXO list")
@@ -166,7 +166,7 @@ func TestDeriveKeysForUTXOs_InvalidSeed(t *testing.T) {
 	// Invalid seed (too short)
 	invalidSeed := []byte{0x01, 0x02}
 
-	keys, err := deriveKeysForUTXOs(utxos, addresses, invalidSeed)
+	keys, err := deriveKeysForUTXOs(utxos, addresses, wallet.NewKeyCache(invalidSeed))
 	require.Error(t, err)
 	assert.Nil(t, keys, "should return nil keys on derivation error")
 	assert.Contains(t, err.Error(), "deriving key")
@@ -190,7 +190,7 @@ func TestDeriveKeysForUTXOs_KeysZeroedOnError(t *testing.T) {
 
 	seed := getTestSeed(t)
 
-	keys, err := deriveKeysForUTXOs(utxos, addresses, seed)
+	keys, err := deriveKeysForUTXOs(utxos, addresses, wallet.NewKeyCache(seed))
 	require.Error(t, err)
 	assert.Nil(t, keys, "should return nil keys on error")
 
@@ -202,14 +202,14 @@ func TestDeriveKeysForUTXOs_KeysZeroedOnError(t *testing.T) {
 func TestDeriveKeyForAddress_Success(t *testing.T) {
 	t.Parallel()
 
-	addrIndex := map[string]uint32{
-		"1ABC": 0,
-		"1DEF": 1,
+	byAddr := map[string]wallet.Address{
+		"1ABC": {Address: "1ABC", Index: 0},
+		"1DEF": {Address: "1DEF", Index: 1},
 	}
 
 	seed := getTestSeed(t)
 
-	key, err := deriveKeyForAddress("1ABC", addrIndex, seed)
+	key, err := deriveKeyForAddress(wallet.ChainBSV, "1ABC", byAddr, wallet.NewKeyCache(seed))
 	require.NoError(t, err)
 	assert.NotEmpty(t, key, "derived key should not be empty")
 
@@ -222,17 +222,42 @@ func TestDeriveKeyForAddress_Success(t *testing.T) {
 	}
 }
 
+// TestDeriveKeyForAddress_Account tests that an address of another BIP44
+// account is signed with that account's key.
+func TestDeriveKeyForAddress_Account(t *testing.T) {
+	t.Parallel()
+
+	seed := getTestSeed(t)
+	byAddr := map[string]wallet.Address{
+		"1ABC": {Address: "1ABC", Index: 3, Account: 1},
+	}
+
+	key, err := deriveKeyForAddress(wallet.ChainBSV, "1ABC", byAddr, wallet.NewKeyCache(seed))
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(key)
+
+	want, err := wallet.DerivePrivateKey(seed, wallet.ChainBSV, 1, 3)
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(want)
+	assert.Equal(t, want, key)
+
+	defaultKey, err := wallet.DerivePrivateKey(seed, wallet.ChainBSV, 0, 3)
+	require.NoError(t, err)
+	defer wallet.ZeroBytes(defaultKey)
+	assert.NotEqual(t, defaultKey, key)
+}
+
 // TestDeriveKeyForAddress_NotInIndex tests error handling when address is not in index.
 func TestDeriveKeyForAddress_NotInIndex(t *testing.T) {
 	t.Parallel()
 
-	addrIndex := map[string]uint32{
-		"1ABC": 0,
+	byAddr := map[string]wallet.Address{
+		"1ABC": {Address: "1ABC", Index: 0},
 	}
 
 	seed := getTestSeed(t)
 
-	key, err := deriveKeyForAddress("1NOTFOUND", addrIndex, seed)
+	key, err := deriveKeyForAddress(wallet.ChainBSV, "1NOTFOUND", byAddr, wallet.NewKeyCache(seed))
 	require.Error(t, err)
 	assert.Nil(t, key)
 	assert.Contains(t, err.Error(), "address not found in wallet")
@@ -243,14 +268,14 @@ func TestDeriveKeyForAddress_NotInIndex(t *testing.T) {
 func TestDeriveKeyForAddress_DerivationError(t *testing.T) {
 	t.Parallel()
 
-	addrIndex := map[string]uint32{
-		"1ABC": 0,
+	byAddr := map[string]wallet.Address{
+		"1ABC": {Address: "1ABC", Index: 0},
 	}
 
 	// Invalid seed
 	invalidSeed := []byte{0x01, 0x02}
 
-	key, err := deriveKeyForAddress("1ABC", addrIndex, invalidSeed)
+	key, err := deriveKeyForAddress(wallet.ChainBSV, "1ABC", byAddr, wallet.NewKeyCache(invalidSeed))
 	require.Error(t, err)
 	assert.Nil(t, key)
 	assert.Contains(t, err.Error(), "deriving key for address")
@@ -320,3 +345,65 @@ func TestZeroKeyMap_NilKeyInMap(t *testing.T) {
 	assert.Nil(t, keys["addr2"])
 	assert.Equal(t, []byte{0, 0, 0}, keys["addr3"])
 }
+
+// TestDeriveKeysForUTXOs_SharedCache verifies repeated derivations reuse the command's key cache.
+func TestDeriveKeysForUTXOs_SharedCache(t *testing.T) {
+	t.Parallel()
+
+	addresses := []wallet.Address{
+		{Address: "1ABC", Index: 0},
+		{Address: "1DEF", Index: 4},
+	}
+	utxos := []chain.UTXO{
+		{Address: "1ABC", TxID: "tx1", Vout: 0, Amount: 100000},
+		{Address: "1DEF", TxID: "tx2", Vout: 0, Amount: 200000},
+	}
+
+	seed := getTestSeed(t)
+	keyCache := wallet.NewKeyCache(seed)
+	defer keyCache.Zero()
+
+	first, err := deriveKeysForUTXOs(utxos, addresses, keyCache)
+	require.NoError(t, err)
+	want := append([]byte(nil), first["1DEF"]...)
+	zeroKeyMap(first)
+
+	second, err := deriveKeysForUTXOs(utxos, addresses, keyCache)
+	require.NoError(t, err)
+	defer zeroKeyMap(second)
+
+	assert.Equal(t, want, second["1DEF"], "zeroing returned keys must not corrupt the cache")
+	assert.Equal(t, 2, keyCache.Len())
+}
+
+// TestRequestKeyCache tests that caller-supplied caches survive release.
+func TestRequestKeyCache(t *testing.T) {
+	t.Parallel()
+
+	seed := getTestSeed(t)
+
+	t.Run("supplied cache is reused", func(t *testing.T) {
+		t.Parallel()
+		supplied := wallet.NewKeyCache(seed)
+		defer supplied.Zero()
+
+		kc, release := requestKeyCache(supplied, seed)
+		assert.Same(t, supplied, kc)
+		_, err := kc.PrivateKey(wallet.ChainBSV, 0, wallet.ExternalChain, 0)
+		require.NoError(t, err)
+
+		release()
+		assert.Equal(t, 1, supplied.Len(), "release must not wipe a caller-owned cache")
+	})
+
+	t.Run("nil creates a scoped cache", func(t *testing.T) {
+		t.Parallel()
+		kc, release := requestKeyCache(nil, seed)
+		require.NotNil(t, kc)
+		_, err := kc.PrivateKey(wallet.ChainBSV, 0, wallet.ExternalChain, 0)
+		require.NoError(t, err)
+
+		release()
+		assert.Equal(t, 0, kc.Len())
+	})
+}
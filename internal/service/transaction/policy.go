@@ -43,12 +43,12 @@ func EnforceAgentPolicy(cred *agent.Credential, counterPath, token string, chain
 // recordAgentSpend records a completed transaction in the agent's daily spending counter.
 // No-op if not in agent mode.
 // Migrated from cli/tx.go lines 642-655
-func recordAgentSpend(logger LogWriter, counterPath, token string, chainID chain.ID, amount *big.Int) {
+func recordAgentSpend(logger LogWriter, counterPath, token string, spend agent.Spend) {
 	if counterPath == "" || token == "" {
 		return // Not in agent mode
 	}
 
-	if err := agent.RecordSpend(counterPath, token, chainID, amount); err != nil {
+	if err := agent.RecordAssetSpend(counterPath, token, spend); err != nil {
 		if logger != nil {
 			logger.Debug("failed to record agent spending: %v", err)
 		}
@@ -56,6 +56,18 @@ func recordAgentSpend(logger LogWriter, counterPath, token string, chainID chain
 }
 
 // RecordAgentSpend is the exported version for external use.
-func RecordAgentSpend(logger LogWriter, counterPath, token string, chainID chain.ID, amount *big.Int) {
-	recordAgentSpend(logger, counterPath, token, chainID, amount)
+func RecordAgentSpend(logger LogWriter, counterPath, token string, spend agent.Spend) {
+	recordAgentSpend(logger, counterPath, token, spend)
+}
+
+// agentSpend returns the spend of amount of an asset by req for the agent's
+// ledger. contract is the ERC-20 contract, or "" for the native coin. The
+// destination is left out of a send to several recipients, which then counts
+// toward every policy rule of the asset.
+func agentSpend(req *SendRequest, chainID chain.ID, contract string, amount *big.Int) agent.Spend {
+	spend := agent.Spend{Chain: chainID, Token: contract, Amount: amount.String()}
+	if len(req.Payments) == 0 && len(req.Split) == 0 {
+		spend.To = req.To
+	}
+	return spend
 }
@@ -196,7 +196,7 @@ func TestRecordAgentSpend_NotInAgentMode(t *testing.T) {
 	logger := newMockLogWriter()
 
 	// Empty counterPath and token means not in agent mode
-	recordAgentSpend(logger, "", "", chain.BSV, big.NewInt(50000))
+	recordAgentSpend(logger, "", "", agent.Spend{Chain: chain.BSV, Amount: "50000"})
 
 	// Should return immediately without error or logging
 	assert.Empty(t, logger.debugMessages)
@@ -213,7 +213,7 @@ func TestRecordAgentSpend_Success(t *testing.T) {
 	logger := newMockLogWriter()
 
 	// Record a spend
-	recordAgentSpend(logger, counterPath, token, chain.BSV, big.NewInt(50000))
+	recordAgentSpend(logger, counterPath, token, agent.Spend{Chain: chain.BSV, Amount: "50000"})
 
 	// Verify counter file was created and spend recorded
 	counter, err := os.ReadFile(counterPath) //nolint:gosec // Test file path
@@ -232,7 +232,7 @@ func TestRecordAgentSpend_Error(t *testing.T) {
 	logger := newMockLogWriter()
 
 	// Should handle error gracefully
-	recordAgentSpend(logger, invalidPath, token, chain.BSV, big.NewInt(50000))
+	recordAgentSpend(logger, invalidPath, token, agent.Spend{Chain: chain.BSV, Amount: "50000"})
 
 	// Verify error was logged
 	require.Len(t, logger.debugMessages, 1)
@@ -247,7 +247,7 @@ func TestRecordAgentSpend_NilLogger(t *testing.T) {
 	token := "test-token"
 
 	// Should not panic with nil logger
-	recordAgentSpend(nil, invalidPath, token, chain.BSV, big.NewInt(50000))
+	recordAgentSpend(nil, invalidPath, token, agent.Spend{Chain: chain.BSV, Amount: "50000"})
 }
 
 // TestRecordAgentSpend_MultipleSpends tests recording multiple spends.
@@ -260,14 +260,13 @@ func TestRecordAgentSpend_MultipleSpends(t *testing.T) {
 	logger := newMockLogWriter()
 
 	// Record multiple spends
-	recordAgentSpend(logger, counterPath, token, chain.BSV, big.NewInt(10000))
-	recordAgentSpend(logger, counterPath, token, chain.BSV, big.NewInt(20000))
-	recordAgentSpend(logger, counterPath, token, chain.BSV, big.NewInt(30000))
+	recordAgentSpend(logger, counterPath, token, agent.Spend{Chain: chain.BSV, Amount: "10000"})
+	recordAgentSpend(logger, counterPath, token, agent.Spend{Chain: chain.BSV, Amount: "20000"})
+	recordAgentSpend(logger, counterPath, token, agent.Spend{Chain: chain.BSV, Amount: "30000"})
 
-	// Load counter and verify total
-	counter, err := os.ReadFile(counterPath) //nolint:gosec // Test file path
-	require.NoError(t, err)
-	assert.Contains(t, string(counter), "60000", "total should be 60000")
+	// Verify the total in the rolling window
+	spentSat, _ := agent.GetDailySpent(counterPath, token)
+	assert.Equal(t, uint64(60000), spentSat, "total should be 60000")
 	assert.Empty(t, logger.debugMessages)
 }
 
@@ -282,7 +281,7 @@ func TestRecordAgentSpend_ETHWithWei(t *testing.T) {
 
 	// Record ETH spend
 	amount, _ := new(big.Int).SetString("1000000000000000000", 10) // 1 ETH
-	recordAgentSpend(logger, counterPath, token, chain.ETH, amount)
+	recordAgentSpend(logger, counterPath, token, agent.Spend{Chain: chain.ETH, Amount: amount.String()})
 
 	// Verify counter file contains wei amount
 	counter, err := os.ReadFile(counterPath) //nolint:gosec // Test file path
@@ -298,7 +297,7 @@ func TestRecordAgentSpend_EmptyCounterPath(t *testing.T) {
 	logger := newMockLogWriter()
 
 	// Empty counter path with non-empty token
-	recordAgentSpend(logger, "", "token", chain.BSV, big.NewInt(50000))
+	recordAgentSpend(logger, "", "token", agent.Spend{Chain: chain.BSV, Amount: "50000"})
 
 	// Should return immediately without logging
 	assert.Empty(t, logger.debugMessages)
@@ -312,7 +311,7 @@ func TestRecordAgentSpend_EmptyToken(t *testing.T) {
 	logger := newMockLogWriter()
 
 	// Non-empty counter path with empty token
-	recordAgentSpend(logger, "/tmp/counter.json", "", chain.BSV, big.NewInt(50000))
+	recordAgentSpend(logger, "/tmp/counter.json", "", agent.Spend{Chain: chain.BSV, Amount: "50000"})
 
 	// Should return immediately without logging
 	assert.Empty(t, logger.debugMessages)
@@ -2,14 +2,18 @@ package transaction
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/mrz1836/sigil/internal/agent"
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 const (
@@ -36,6 +40,47 @@ func TestSend_Dispatch_ETH_InvalidAddress(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestSend_Dispatch_ETH_ChecksumEnforcement(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		to               string
+		allowNonChecksum bool
+		wantChecksumErr  bool
+	}{
+		{"lowercase rejected", strings.ToLower(validETHAddress), false, true},
+		{"lowercase allowed with flag", strings.ToLower(validETHAddress), true, false},
+		{"bad mixed case rejected even with flag", "0x742d35cc6634C0532925a3b844Bc454e4438f44e", true, true},
+		{"checksummed accepted", validETHAddress, false, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			cfg := newMockConfigProvider()
+			cfg.ethRPC = "" // stop right after address validation
+
+			service := NewService(&Config{
+				Config:  cfg,
+				Storage: newMockStorageProvider(),
+				Logger:  newMockLogWriter(),
+			})
+
+			_, err := service.Send(context.Background(), &SendRequest{
+				ChainID:          chain.ETH,
+				To:               tc.to,
+				AmountStr:        "1.0",
+				AllowNonChecksum: tc.allowNonChecksum,
+			})
+
+			require.Error(t, err)
+			assert.Equal(t, tc.wantChecksumErr, errors.Is(err, sigilerr.ErrInvalidChecksum))
+		})
+	}
+}
+
 func TestSend_Dispatch_ETH_MissingRPCConfig(t *testing.T) {
 	t.Parallel()
 
@@ -164,5 +209,5 @@ func TestExportedWrapperFunctions(t *testing.T) {
 	require.NoError(t, err)
 	assert.Empty(t, aggregated)
 
-	RecordAgentSpend(logger, "", "", chain.BSV, nil)
+	RecordAgentSpend(logger, "", "", agent.Spend{Chain: chain.BSV})
 }
@@ -2,8 +2,11 @@ package transaction
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
 
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
@@ -12,6 +15,8 @@ type Service struct {
 	config  ConfigProvider
 	storage StorageProvider
 	logger  LogWriter
+
+	approver Approver
 }
 
 // Config holds dependencies for the transaction service.
@@ -19,6 +24,9 @@ type Config struct {
 	Config  ConfigProvider
 	Storage StorageProvider
 	Logger  LogWriter
+
+	// Approver, when set, must approve every transaction before broadcast.
+	Approver Approver
 }
 
 // NewService creates a new transaction service.
@@ -27,7 +35,20 @@ func NewService(cfg *Config) *Service {
 		config:  cfg.Config,
 		storage: cfg.Storage,
 		logger:  cfg.Logger,
+
+		approver: cfg.Approver,
+	}
+}
+
+// loadSendAccount loads the sending wallet's metadata as seen from the
+// request's BIP44 account, for deriving change addresses.
+func (s *Service) loadSendAccount(req *SendRequest) (*wallet.Wallet, error) {
+	storage := wallet.NewFileStorage(filepath.Join(s.config.GetHome(), "wallets"))
+	wlt, err := storage.LoadMetadata(req.Wallet)
+	if err != nil {
+		return nil, fmt.Errorf("loading wallet metadata: %w", err)
 	}
+	return wlt.Account(req.Account)
 }
 
 // Send dispatches a transaction send request to the appropriate chain handler.
@@ -36,17 +57,47 @@ func (s *Service) Send(ctx context.Context, req *SendRequest) (*SendResult, erro
 	// This check is typically done by CLI, but we enforce it here too
 	// AgentXpub detection would need to be passed in req if needed
 
+	// ETH batches need one transaction per recipient (see SendBatch)
+	if err := checkPayments(req); err != nil {
+		return nil, err
+	}
+	if req.ChainID == chain.ETH && len(req.Payments) > 0 {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			"ETH cannot pay several recipients in one transaction; send them as a batch",
+		)
+	}
+
 	// Dispatch to chain-specific handler
+	var result *SendResult
+	var err error
 	switch req.ChainID {
 	case chain.ETH:
-		return s.sendETH(ctx, req)
+		result, err = s.sendETH(ctx, req)
 	case chain.BSV:
-		return s.sendBSV(ctx, req)
-	case chain.BTC, chain.BCH, chain.LTC:
+		result, err = s.sendBSV(ctx, req)
+	case chain.BTC:
+		result, err = s.sendBTC(ctx, req)
+	case chain.BCH:
+		result, err = s.sendBCH(ctx, req)
+	case chain.LTC:
 		return nil, sigilerr.ErrNotImplemented
 	default:
 		return nil, sigilerr.ErrNotImplemented
 	}
+	if err != nil {
+		return nil, err
+	}
+	// A queued send is journaled when the outbox broadcasts it, and a dry
+	// run never is
+	if result.Queued() || result.DryRun() {
+		return result, nil
+	}
+
+	s.recordJournal(req, result)
+	s.clearIntent(req, result)
+	return result, nil
 }
 
-// sendETH and sendBSV are implemented in eth.go and bsv.go files
+// sendETH, sendBSV, sendBTC, and sendBCH are implemented in eth.go, bsv.go,
+// btc.go, and bch.go; BTC and BCH share the flow in utxosend.go
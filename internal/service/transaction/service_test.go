@@ -10,8 +10,10 @@ import (
 
 	"github.com/mrz1836/sigil/internal/agent"
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
@@ -33,7 +35,7 @@ func TestSend_Dispatch_UnsupportedChain(t *testing.T) {
 	})
 
 	req := &SendRequest{
-		ChainID:   chain.BTC, // Not implemented yet
+		ChainID:   chain.LTC, // Not implemented yet
 		To:        "1ABC",
 		AmountStr: "0.001",
 	}
@@ -326,6 +328,42 @@ func TestFilterSpentBSVUTXOs(t *testing.T) {
 	}
 }
 
+func TestFilterSpentBSVUTXOs_Immature(t *testing.T) {
+	t.Parallel()
+
+	utxos := []chain.UTXO{
+		{TxID: "coinbase", Vout: 0, Amount: 625000000, Address: "1ABC"},
+		{TxID: "tx2", Vout: 0, Amount: 200000, Address: "1DEF"},
+	}
+
+	store := newMockUTXOProvider()
+	store.immature["bsv:coinbase:0"] = true
+
+	filtered := FilterSpentBSVUTXOs(utxos, store)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "tx2", filtered[0].TxID)
+}
+
+func TestFilterSpentBSVUTXOs_ReceiveOnly(t *testing.T) {
+	t.Parallel()
+
+	utxos := []chain.UTXO{
+		{TxID: "reserve", Vout: 0, Amount: 500000000, Address: "1Reserve"},
+		{TxID: "tx2", Vout: 0, Amount: 200000, Address: "1DEF"},
+	}
+
+	store := newMockUTXOProvider()
+	store.receiveOnly["bsv:1Reserve"] = true
+
+	filtered := FilterSpentBSVUTXOs(utxos, store)
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "tx2", filtered[0].TxID)
+
+	// Coin control cannot pick the reserve either
+	_, err := RestrictToOutpoints(filtered, []string{"reserve:0"}, store)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
 func TestUniqueUTXOAddrs(t *testing.T) {
 	t.Parallel()
 
@@ -414,6 +452,10 @@ type mockConfigProvider struct {
 	bsvAPIKey          string
 	bsvFeeStrategy     string
 	bsvMinMiners       int
+	bsvCoinSelection   string
+	btcAPI             string
+	bchAPI             string
+	ethTokens          []config.TokenConfig
 }
 
 func newMockConfigProvider() *mockConfigProvider {
@@ -427,14 +469,21 @@ func newMockConfigProvider() *mockConfigProvider {
 	}
 }
 
-func (m *mockConfigProvider) GetHome() string               { return m.home }
-func (m *mockConfigProvider) GetETHRPC() string             { return m.ethRPC }
-func (m *mockConfigProvider) GetETHFallbackRPCs() []string  { return m.ethFallbackRPCs }
-func (m *mockConfigProvider) GetETHEtherscanAPIKey() string { return m.ethEtherscanAPIKey }
-func (m *mockConfigProvider) GetBSVAPIKey() string          { return m.bsvAPIKey }
-func (m *mockConfigProvider) GetBSVNetwork() string         { return "main" }
-func (m *mockConfigProvider) GetBSVFeeStrategy() string     { return m.bsvFeeStrategy }
-func (m *mockConfigProvider) GetBSVMinMiners() int          { return m.bsvMinMiners }
+func (m *mockConfigProvider) GetHome() string                    { return m.home }
+func (m *mockConfigProvider) GetETHRPC() string                  { return m.ethRPC }
+func (m *mockConfigProvider) GetETHFallbackRPCs() []string       { return m.ethFallbackRPCs }
+func (m *mockConfigProvider) GetETHChainID() int                 { return 1 }
+func (m *mockConfigProvider) GetETHEtherscanAPIKey() string      { return m.ethEtherscanAPIKey }
+func (m *mockConfigProvider) GetBSVAPIKey() string               { return m.bsvAPIKey }
+func (m *mockConfigProvider) GetBSVNetwork() string              { return "main" }
+func (m *mockConfigProvider) GetBSVFeeStrategy() string          { return m.bsvFeeStrategy }
+func (m *mockConfigProvider) GetBSVMinMiners() int               { return m.bsvMinMiners }
+func (m *mockConfigProvider) GetBSVCoinSelection() string        { return m.bsvCoinSelection }
+func (m *mockConfigProvider) GetBTCAPI() string                  { return m.btcAPI }
+func (m *mockConfigProvider) GetBCHAPI() string                  { return m.bchAPI }
+func (m *mockConfigProvider) GetETHTokens() []config.TokenConfig { return m.ethTokens }
+func (m *mockConfigProvider) GetETHGasMarginPercent() int        { return 0 }
+func (m *mockConfigProvider) GetCache() config.CacheConfig       { return config.CacheConfig{} }
 
 type mockStorageProvider struct {
 	updateMetaErr error
@@ -469,12 +518,16 @@ func (m *mockLogWriter) Error(format string, _ ...any) {
 }
 
 type mockUTXOProvider struct {
-	spent map[string]bool
+	spent       map[string]bool
+	immature    map[string]bool
+	receiveOnly map[string]bool
 }
 
 func newMockUTXOProvider() *mockUTXOProvider {
 	return &mockUTXOProvider{
-		spent: make(map[string]bool),
+		spent:       make(map[string]bool),
+		immature:    make(map[string]bool),
+		receiveOnly: make(map[string]bool),
 	}
 }
 
@@ -491,6 +544,15 @@ func (m *mockUTXOProvider) IsSpent(chainID chain.ID, txid string, vout uint32) b
 	return m.spent[key]
 }
 
+func (m *mockUTXOProvider) IsImmature(chainID chain.ID, txid string, vout uint32) bool {
+	key := string(chainID) + ":" + txid + ":" + string(rune(vout+'0')) //nolint:gosec // G115: vout is a small index value
+	return m.immature[key]
+}
+
+func (m *mockUTXOProvider) IsReceiveOnly(chainID chain.ID, address string) bool {
+	return m.receiveOnly[string(chainID)+":"+address]
+}
+
 func (m *mockUTXOProvider) AddUTXO(_ *utxostore.StoredUTXO) {
 	// Not used in these tests
 }
@@ -0,0 +1,75 @@
+package transaction
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// percentDecimals is the precision of a split sweep percentage: 0.01%, one
+// basis point.
+const percentDecimals = 2
+
+// ParseSweepShares converts the recipients of a split sweep to shares. An
+// AmountStr ending in % is a percentage with at most two decimals; anything
+// else is a fixed BSV amount.
+func ParseSweepShares(split []Payment) ([]bsv.SweepShare, error) {
+	shares := make([]bsv.SweepShare, len(split))
+	for i, p := range split {
+		shares[i].To = p.To
+		value := SanitizeAmount(p.AmountStr)
+		if percent, ok := strings.CutSuffix(value, "%"); ok {
+			if dot := strings.IndexByte(percent, '.'); dot >= 0 && len(percent)-dot-1 > percentDecimals {
+				return nil, invalidShare(p, "use at most two decimals in a percentage")
+			}
+			bp, err := parseDecimalAmount(percent, percentDecimals)
+			if err != nil || !bp.IsUint64() {
+				return nil, invalidShare(p, "give a percentage like 70% or a BSV amount")
+			}
+			shares[i].BasisPoints = bp.Uint64()
+			continue
+		}
+		amount, err := parseDecimalAmount(value, 8)
+		if err != nil || !amount.IsUint64() {
+			return nil, invalidShare(p, "give a percentage like 70% or a BSV amount")
+		}
+		shares[i].Fixed = amount.Uint64()
+	}
+	return shares, nil
+}
+
+// invalidShare reports an unparsable share of a split sweep.
+func invalidShare(p Payment, hint string) error {
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrInvalidInput,
+		fmt.Sprintf("invalid split share %q for %s: %s", p.AmountStr, p.To, hint),
+	)
+}
+
+// SplitSweep divides totalInputs, less the fee of spending numInputs UTXOs
+// at feeRate, among the recipients of a split sweep (see bsv.SplitSweep).
+// It returns each recipient with the coin amount it is paid, the amounts in
+// satoshis, and the fee.
+func SplitSweep(split []Payment, totalInputs uint64, numInputs int, feeRate uint64) ([]Payment, []uint64, uint64, error) {
+	shares, err := ParseSweepShares(split)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+	amounts, fee, err := bsv.SplitSweep(totalInputs, numInputs, feeRate, shares)
+	if err != nil {
+		if errors.Is(err, bsv.ErrInvalidSplit) {
+			return nil, nil, 0, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, err.Error())
+		}
+		return nil, nil, 0, err
+	}
+
+	paid := make([]Payment, len(split))
+	for i, p := range split {
+		paid[i] = Payment{To: p.To, AmountStr: chain.FormatDecimalAmount(chain.AmountToBigInt(amounts[i]), 8)}
+	}
+	return paid, amounts, fee, nil
+}
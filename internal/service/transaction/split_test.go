@@ -0,0 +1,56 @@
+package transaction
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+func TestParseSweepShares(t *testing.T) {
+	t.Parallel()
+
+	shares, err := ParseSweepShares([]Payment{
+		{To: "1A", AmountStr: "0.001"},
+		{To: "1B", AmountStr: "33.33%"},
+		{To: "1C", AmountStr: " 66.67% "},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []bsv.SweepShare{
+		{To: "1A", Fixed: 100000},
+		{To: "1B", BasisPoints: 3333},
+		{To: "1C", BasisPoints: 6667},
+	}, shares)
+
+	for _, bad := range []string{"33.333%", "%", "abc%", "-5%", "1.2.3", ""} {
+		_, err := ParseSweepShares([]Payment{{To: "1A", AmountStr: bad}})
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput, bad)
+	}
+}
+
+func TestSplitSweep(t *testing.T) {
+	t.Parallel()
+
+	fee := bsv.EstimateFeeForTx(3, 2, bsv.DefaultFeeRate)
+	paid, amounts, gotFee, err := SplitSweep([]Payment{
+		{To: "1A", AmountStr: "70%"},
+		{To: "1B", AmountStr: "30%"},
+	}, 1000000+fee, 3, bsv.DefaultFeeRate)
+	require.NoError(t, err)
+	assert.Equal(t, fee, gotFee)
+	assert.Equal(t, []uint64{700000, 300000}, amounts)
+	assert.Equal(t, []Payment{{To: "1A", AmountStr: "0.007"}, {To: "1B", AmountStr: "0.003"}}, paid)
+
+	// Shares that do not add up are an input error, caught before confirmation
+	_, _, _, err = SplitSweep([]Payment{
+		{To: "1A", AmountStr: "70%"},
+		{To: "1B", AmountStr: "20%"},
+	}, 1000000, 3, bsv.DefaultFeeRate)
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	var se *sigilerr.SigilError
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "percentages total 90%")
+}
@@ -4,9 +4,11 @@ package transaction
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/cosign"
 	"github.com/mrz1836/sigil/internal/wallet"
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
@@ -29,10 +31,15 @@ type SweepService struct {
 	client  BSVClient
 	bulkOps BulkOperations
 	logger  Logger
+
+	approver Approver
 }
 
 // SweepOptions configures the sweep operation.
 type SweepOptions struct {
+	// Wallet is the wallet name reported to the co-sign approver.
+	Wallet string
+
 	// Destination is the address to sweep funds to.
 	Destination string
 
@@ -42,6 +49,10 @@ type SweepOptions struct {
 	// Seed is used to derive private keys for signing.
 	Seed []byte
 
+	// Keys caches private keys derived from Seed. Reusing one cache across
+	// repeated sweeps in a command avoids re-deriving the same indices.
+	Keys *wallet.KeyCache
+
 	// ValidateUTXOs enables UTXO validation before building transaction.
 	ValidateUTXOs bool
 
@@ -100,6 +111,13 @@ func NewSweepService(client BSVClient, bulkOps BulkOperations, logger Logger) *S
 	}
 }
 
+// WithApprover sets the approver that must approve every sweep before keys
+// are derived and the transaction is broadcast.
+func (s *SweepService) WithApprover(approver Approver) *SweepService {
+	s.approver = approver
+	return s
+}
+
 // Sweep consolidates all UTXOs from multiple addresses into a single address.
 //
 //nolint:gocognit,gocyclo,nestif // Sweep logic with validation inherently complex
@@ -202,8 +220,25 @@ func (s *SweepService) Sweep(ctx context.Context, opts *SweepOptions) (*SweepRes
 		return result, nil
 	}
 
+	if err = requestApproval(ctx, s.approver, s.logger, &cosign.Summary{
+		Chain:    string(chain.BSV),
+		Wallet:   opts.Wallet,
+		From:     opts.Addresses[0].Address,
+		To:       opts.Destination,
+		Amount:   strconv.FormatUint(result.NetAmount, 10),
+		Display:  chain.FormatDecimalAmount(chain.AmountToBigInt(result.NetAmount), 8) + " (sweep all)",
+		Fee:      strconv.FormatUint(result.Fee, 10),
+		FeeRate:  feeRate,
+		Inputs:   cosignInputs(allUTXOs),
+		SweepAll: true,
+	}); err != nil {
+		return nil, err
+	}
+
 	// Derive private keys for all addresses
-	privateKeys, err := deriveKeysForUTXOs(allUTXOs, opts.Addresses, opts.Seed)
+	keyCache, releaseKeys := requestKeyCache(opts.Keys, opts.Seed)
+	defer releaseKeys()
+	privateKeys, err := deriveKeysForUTXOs(allUTXOs, opts.Addresses, keyCache)
 	if err != nil {
 		return nil, fmt.Errorf("deriving private keys: %w", err)
 	}
@@ -0,0 +1,62 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
+	"github.com/mrz1836/sigil/internal/tokens"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// TokenMetadataReader reads ERC-20 metadata from a token contract.
+type TokenMetadataReader interface {
+	GetTokenMetadata(ctx context.Context, tokenAddress string) (*eth.TokenMetadata, error)
+}
+
+// LookupToken resolves a token symbol or contract address against the
+// built-in and configured tokens without touching the network.
+func LookupToken(configured []config.TokenConfig, token string) (*eth.TokenMetadata, bool) {
+	return tokens.Lookup(configured, token)
+}
+
+// ResolveToken resolves a token symbol or contract address to its metadata.
+// Known tokens are returned directly; an unknown contract address has its
+// decimals() and symbol() read from the chain.
+func ResolveToken(ctx context.Context, reader TokenMetadataReader, configured []config.TokenConfig, token string) (*eth.TokenMetadata, error) {
+	if meta, ok := LookupToken(configured, token); ok {
+		return meta, nil
+	}
+
+	if !eth.IsValidAddress(token) {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("unknown token: %s (use a configured symbol or pass the contract address with --token 0x...)", token),
+		)
+	}
+
+	meta, err := reader.GetTokenMetadata(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("reading token metadata for %s: %w", token, err)
+	}
+	return meta, nil
+}
+
+// resolveSendToken returns the metadata for req.Token, preferring metadata
+// already confirmed by the caller when it matches the requested contract.
+func (s *Service) resolveSendToken(ctx context.Context, reader TokenMetadataReader, req *SendRequest) (*eth.TokenMetadata, error) {
+	if req.TokenMeta != nil && strings.EqualFold(req.TokenMeta.Address, req.Token) {
+		return req.TokenMeta, nil
+	}
+	return ResolveToken(ctx, reader, s.config.GetETHTokens(), req.Token)
+}
+
+// tokenSymbol returns the display symbol for a resolved token, or "" for native ETH.
+func tokenSymbol(token *eth.TokenMetadata) string {
+	if token == nil {
+		return ""
+	}
+	return token.Symbol
+}
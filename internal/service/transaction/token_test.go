@@ -0,0 +1,124 @@
+package transaction
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const testUNIAddress = "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"
+
+// mockTokenReader returns fixed metadata and counts contract reads.
+type mockTokenReader struct {
+	meta  *eth.TokenMetadata
+	err   error
+	calls int
+}
+
+func (m *mockTokenReader) GetTokenMetadata(_ context.Context, _ string) (*eth.TokenMetadata, error) {
+	m.calls++
+	return m.meta, m.err
+}
+
+func TestLookupToken(t *testing.T) {
+	t.Parallel()
+
+	configured := []config.TokenConfig{{Symbol: "UNI", Address: testUNIAddress, Decimals: 18}}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantSymbol string
+		wantFound  bool
+	}{
+		{name: "built-in symbol", token: "usdc", wantSymbol: "USDC", wantFound: true},
+		{name: "built-in address", token: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", wantSymbol: "USDC", wantFound: true},
+		{name: "built-in LINK symbol", token: "link", wantSymbol: "LINK", wantFound: true},
+		{name: "configured symbol", token: "uni", wantSymbol: "UNI", wantFound: true},
+		{name: "configured address", token: testUNIAddress, wantSymbol: "UNI", wantFound: true},
+		{name: "unknown", token: "0x0000000000000000000000000000000000000001"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			meta, ok := LookupToken(configured, tc.token)
+			assert.Equal(t, tc.wantFound, ok)
+			if tc.wantFound {
+				assert.Equal(t, tc.wantSymbol, meta.Symbol)
+			}
+		})
+	}
+}
+
+func TestResolveToken_Metadata(t *testing.T) {
+	t.Parallel()
+
+	t.Run("known token skips contract read", func(t *testing.T) {
+		t.Parallel()
+		reader := &mockTokenReader{}
+		meta, err := ResolveToken(context.Background(), reader, nil, "USDC")
+		require.NoError(t, err)
+		assert.Equal(t, eth.USDCMainnet, meta.Address)
+		assert.Zero(t, reader.calls)
+	})
+
+	t.Run("unknown address reads contract", func(t *testing.T) {
+		t.Parallel()
+		reader := &mockTokenReader{meta: &eth.TokenMetadata{Address: testUNIAddress, Symbol: "UNI", Decimals: 18}}
+		meta, err := ResolveToken(context.Background(), reader, nil, testUNIAddress)
+		require.NoError(t, err)
+		assert.Equal(t, "UNI", meta.Symbol)
+		assert.Equal(t, 18, meta.Decimals)
+		assert.Equal(t, 1, reader.calls)
+	})
+
+	t.Run("unknown symbol", func(t *testing.T) {
+		t.Parallel()
+		reader := &mockTokenReader{}
+		_, err := ResolveToken(context.Background(), reader, nil, "UNI")
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+		assert.Zero(t, reader.calls)
+	})
+
+	t.Run("contract read fails", func(t *testing.T) {
+		t.Parallel()
+		reader := &mockTokenReader{err: eth.ErrInvalidTokenMetadata}
+		_, err := ResolveToken(context.Background(), reader, nil, testUNIAddress)
+		require.ErrorIs(t, err, eth.ErrInvalidTokenMetadata)
+	})
+}
+
+func TestResolveSendToken_UsesConfirmedMetadata(t *testing.T) {
+	t.Parallel()
+
+	svc := &Service{config: newMockConfigProvider()}
+	confirmed := &eth.TokenMetadata{Address: testUNIAddress, Symbol: "UNI", Decimals: 18}
+	reader := &mockTokenReader{}
+
+	meta, err := svc.resolveSendToken(context.Background(), reader, &SendRequest{Token: testUNIAddress, TokenMeta: confirmed})
+	require.NoError(t, err)
+	assert.Same(t, confirmed, meta)
+	assert.Zero(t, reader.calls)
+}
+
+func TestLookupToken_AmountFlags(t *testing.T) {
+	t.Parallel()
+
+	configured := []config.TokenConfig{{Symbol: "UNI", Address: testUNIAddress, Decimals: 18, FeeOnTransfer: true}}
+
+	meta, ok := LookupToken(configured, "UNI")
+	require.True(t, ok)
+	assert.True(t, meta.FeeOnTransfer)
+	assert.True(t, meta.AmountMayDiffer())
+
+	meta, ok = LookupToken(configured, "USDC")
+	require.True(t, ok)
+	assert.False(t, meta.AmountMayDiffer())
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/price"
 	"github.com/mrz1836/sigil/internal/wallet"
 )
 
@@ -17,13 +19,63 @@ type SendRequest struct {
 	Wallet      string
 	FromAddress string
 
+	// Account is the BIP44 account of the wallet that sends; change
+	// addresses are derived in it too.
+	Account uint32
+
+	// Payments are further recipients after To and AmountStr. BSV pays them
+	// all from one transaction; ETH sends one transaction per recipient
+	// (see Service.SendBatch). Sweeps cannot have payments; see Split.
+	Payments []Payment
+
+	// Split divides a BSV sweep among several recipients, To first. Each
+	// AmountStr is a fixed coin amount or a percentage like "70%" of what is
+	// left after the fee and the fixed amounts (see ParseSweepShares).
+	Split []Payment
+
 	// ETH-specific
-	Token    string // ERC-20 token symbol (e.g., "USDC")
+	Token    string // ERC-20 token symbol or contract address (e.g., "USDC")
 	GasSpeed string // "slow", "medium", "fast"
 
+	// TokenMeta carries metadata the caller already resolved and confirmed for
+	// Token; nil resolves it from the known tokens or the contract.
+	TokenMeta *eth.TokenMetadata
+
+	// AllowNonChecksum accepts an all-lowercase or all-uppercase ETH recipient.
+	// By default the recipient must be in EIP-55 checksummed form.
+	AllowNonChecksum bool
+
+	// Nonce overrides the nonce of a single ETH transaction; nil uses the
+	// next free nonce.
+	Nonce *uint64
+
 	// BSV-specific (populated by service layer)
-	Addresses []wallet.Address // All wallet addresses for BSV multi-address support
-	Network   string           // BSV network ("main"/"test"); empty falls back to config
+	Addresses  []wallet.Address // All wallet addresses for BSV multi-address support
+	Network    string           // BSV network ("main"/"test"); empty falls back to config
+	MaxFeeRate uint64           // Refuse to send above this rate in sat/KB; zero disables
+
+	// CoinSelection names the UTXO selection strategy (see
+	// bsv.ParseCoinSelection); empty uses fees.bsv_coin_selection.
+	CoinSelection string
+
+	// Outpoints are "txid:vout" keys (see ParseOutpoint) that restrict BSV
+	// input selection to those UTXOs; empty selects from every UTXO.
+	Outpoints []string
+
+	// Fiat is set when the amount was entered in fiat (e.g. "50usd") and
+	// AmountStr holds the converted coin amount; nil for coin amounts.
+	Fiat *FiatConversion
+
+	// QueueExpiry queues a signed BSV, BTC, or BCH transaction in the
+	// wallet's outbox when no broadcast provider can be reached, to be
+	// retried until it expires (see StatusQueued). Zero fails the send
+	// instead.
+	QueueExpiry time.Duration
+
+	// DryRun builds and signs the transaction without broadcasting it or
+	// changing any wallet state. The result's Status is StatusDryRun and its
+	// Preview holds the signed transaction.
+	DryRun bool
 
 	// Flags
 	Confirm       bool // If false, prompt user for confirmation
@@ -36,6 +88,22 @@ type SendRequest struct {
 
 	// Internal (populated by CLI layer)
 	Seed []byte
+	// Keys caches keys derived from Seed for the command run; nil derives per call.
+	Keys *wallet.KeyCache
+}
+
+// Payment is one recipient of a multi-recipient send.
+type Payment struct {
+	To        string
+	AmountStr string
+}
+
+// FiatConversion records a fiat-denominated amount and the exchange rate
+// used to convert it into the coin amount that is sent.
+type FiatConversion struct {
+	Amount   string       // Fiat amount with two decimals (e.g. "50.00")
+	Currency string       // Fiat currency code (e.g. "USD")
+	Quote    *price.Quote // Rate captured when the amount was converted
 }
 
 // SweepAll returns true if the amount is "all".
@@ -43,6 +111,23 @@ func (r *SendRequest) SweepAll() bool {
 	return IsAmountAll(r.AmountStr)
 }
 
+// AllPayments returns every recipient of the request, To first.
+func (r *SendRequest) AllPayments() []Payment {
+	return append([]Payment{{To: r.To, AmountStr: r.AmountStr}}, r.Payments...)
+}
+
+// Send statuses set by the service rather than the chain client.
+const (
+	// StatusQueued is the status of a send whose signed transaction is
+	// waiting in the wallet's outbox for a broadcast provider to become
+	// reachable.
+	StatusQueued = "queued"
+
+	// StatusDryRun is the status of a dry-run send, whose signed transaction
+	// was not broadcast.
+	StatusDryRun = "dry_run"
+)
+
 // SendResult represents the outcome of a transaction send operation.
 type SendResult struct {
 	Hash    string
@@ -54,12 +139,49 @@ type SendResult struct {
 	Status  string
 	ChainID chain.ID
 
+	// AmountUnits and FeeUnits are Amount and Fee in the smallest unit
+	// (satoshis, wei, or token base units), with Decimals places.
+	AmountUnits  *big.Int
+	FeeUnits     *big.Int
+	Decimals     int
+	TokenAddress string // ERC-20 contract; empty for native currency
+
 	// ETH-specific
 	GasUsed  uint64
 	GasPrice string
+	// Delivered is the formatted token amount the recipient received, read
+	// from the receipt. Set only for fee-on-transfer or rebasing tokens.
+	Delivered string
 
 	// BSV-specific
-	UTXOsSpent int
+	UTXOsSpent    int
+	Payments      []Payment     // Coin amount paid to each recipient of a split sweep; nil otherwise
+	CoinSelection string        // Strategy that chose the inputs; empty for sweeps
+	FeeRate       uint64        // Fee rate used, in sat/KB
+	FeeSource     string        // Origin of the fee rate (see bsv.FeeSource* constants)
+	FeeAge        time.Duration // Age of the fee quote when the transaction was built
+
+	// QueuedUntil is when a queued send expires from the outbox; zero
+	// unless Status is StatusQueued.
+	QueuedUntil time.Time
+
+	// Preview is the signed transaction of a dry run; nil unless Status is
+	// StatusDryRun.
+	Preview *SendPreview
+
+	// Changes summarizes balances, UTXOs, and cache entries affected by the send.
+	Changes *SendChanges
+}
+
+// Queued reports whether the send was queued in the outbox rather than
+// broadcast.
+func (r *SendResult) Queued() bool {
+	return r.Status == StatusQueued
+}
+
+// DryRun reports whether the send was a dry run that was not broadcast.
+func (r *SendResult) DryRun() bool {
+	return r.Status == StatusDryRun
 }
 
 // ValidationError represents a validation error with context.
@@ -2,18 +2,26 @@ package transaction
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/txintent"
 	"github.com/mrz1836/sigil/internal/utxostore"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // aggregateBSVUTXOs fetches UTXOs from all wallet addresses concurrently and merges them.
 // Migrated from cli/tx.go lines 998-1041
 func aggregateBSVUTXOs(ctx context.Context, client *bsv.Client, addresses []wallet.Address) ([]chain.UTXO, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseUTXOFetch)()
+
 	type result struct {
 		utxos []chain.UTXO
 		err   error
@@ -62,17 +70,25 @@ func AggregateBSVUTXOs(ctx context.Context, client *bsv.Client, addresses []wall
 	return aggregateBSVUTXOs(ctx, client, addresses)
 }
 
-// filterSpentBSVUTXOs removes UTXOs that are marked as spent in the local store.
+// filterSpentBSVUTXOs removes UTXOs that are marked as spent in the local store,
+// coinbase outputs the store knows have not reached maturity, and the UTXOs
+// of receive-only addresses, which are never spent.
 // UTXOs not present in the store are kept (unknown is not known-spent).
 // Migrated from cli/tx.go lines 1101-1111
 func filterSpentBSVUTXOs(utxos []chain.UTXO, store UTXOProvider) []chain.UTXO {
+	return filterSpentUTXOs(chain.BSV, utxos, store)
+}
+
+// filterSpentUTXOs is filterSpentBSVUTXOs for any UTXO chain.
+func filterSpentUTXOs(chainID chain.ID, utxos []chain.UTXO, store UTXOProvider) []chain.UTXO {
 	if store == nil {
 		return utxos
 	}
 
 	filtered := make([]chain.UTXO, 0, len(utxos))
 	for _, u := range utxos {
-		if !store.IsSpent(chain.BSV, u.TxID, u.Vout) {
+		if !store.IsSpent(chainID, u.TxID, u.Vout) && !store.IsImmature(chainID, u.TxID, u.Vout) &&
+			!store.IsReceiveOnly(chainID, u.Address) {
 			filtered = append(filtered, u)
 		}
 	}
@@ -88,6 +104,11 @@ func FilterSpentBSVUTXOs(utxos []chain.UTXO, store UTXOProvider) []chain.UTXO {
 // Errors are logged but never returned — the broadcast already succeeded.
 // Migrated from cli/tx.go lines 1113-1138
 func markSpentBSVUTXOs(logger LogWriter, store UTXOProvider, utxos []chain.UTXO, spentTxID string) {
+	markSpentUTXOs(logger, chain.BSV, store, utxos, spentTxID)
+}
+
+// markSpentUTXOs is markSpentBSVUTXOs for any UTXO chain.
+func markSpentUTXOs(logger LogWriter, chainID chain.ID, store UTXOProvider, utxos []chain.UTXO, spentTxID string) {
 	if store == nil {
 		return
 	}
@@ -96,7 +117,7 @@ func markSpentBSVUTXOs(logger LogWriter, store UTXOProvider, utxos []chain.UTXO,
 		// Ensure the UTXO exists in the store before marking it spent.
 		// The API may return UTXOs not yet tracked locally.
 		store.AddUTXO(&utxostore.StoredUTXO{
-			ChainID:       chain.BSV,
+			ChainID:       chainID,
 			TxID:          u.TxID,
 			Vout:          u.Vout,
 			Amount:        u.Amount,
@@ -104,12 +125,12 @@ func markSpentBSVUTXOs(logger LogWriter, store UTXOProvider, utxos []chain.UTXO,
 			Address:       u.Address,
 			Confirmations: u.Confirmations,
 		})
-		store.MarkSpent(chain.BSV, u.TxID, u.Vout, spentTxID)
+		store.MarkSpent(chainID, u.TxID, u.Vout, spentTxID)
 	}
 
 	if err := store.Save(); err != nil {
 		if logger != nil {
-			logger.Error("bsv send: failed to save utxo store: %v", err)
+			logger.Error("%s send: failed to save utxo store: %v", chainID, err)
 		}
 	}
 }
@@ -133,3 +154,86 @@ func uniqueUTXOAddrs(utxos []chain.UTXO) map[string]struct{} {
 func UniqueUTXOAddrs(utxos []chain.UTXO) map[string]struct{} {
 	return uniqueUTXOAddrs(utxos)
 }
+
+// ParseOutpoint parses a "txid:vout" outpoint and returns it in the form
+// txintent.OutpointKey produces.
+func ParseOutpoint(s string) (string, error) {
+	txid, voutStr, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok || len(txid) != 64 {
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid outpoint %q: expected txid:vout with a 64-character hex txid", s),
+		)
+	}
+	if _, err := hex.DecodeString(txid); err != nil {
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid outpoint %q: txid is not hex", s),
+		)
+	}
+	vout, err := strconv.ParseUint(voutStr, 10, 32)
+	if err != nil {
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("invalid outpoint %q: vout is not an output index", s),
+		)
+	}
+	return txintent.OutpointKey(txid, uint32(vout)), nil
+}
+
+// restrictToOutpoints returns the UTXOs named by outpoints, in their order,
+// for manual coin control. utxos must already be filtered for spent,
+// immature, and reserved outputs, so an outpoint missing from them cannot be
+// spent; store, when not nil, is used to explain why.
+func restrictToOutpoints(utxos []chain.UTXO, outpoints []string, store UTXOProvider) ([]chain.UTXO, error) {
+	byKey := make(map[string]chain.UTXO, len(utxos))
+	for _, u := range utxos {
+		byKey[txintent.OutpointKey(u.TxID, u.Vout)] = u
+	}
+
+	restricted := make([]chain.UTXO, 0, len(outpoints))
+	seen := make(map[string]bool, len(outpoints))
+	for _, op := range outpoints {
+		if seen[op] {
+			continue
+		}
+		seen[op] = true
+		u, ok := byKey[op]
+		if !ok {
+			return nil, unspendableOutpoint(op, store)
+		}
+		restricted = append(restricted, u)
+	}
+	return restricted, nil
+}
+
+// RestrictToOutpoints is the exported version for external use.
+func RestrictToOutpoints(utxos []chain.UTXO, outpoints []string, store UTXOProvider) ([]chain.UTXO, error) {
+	return restrictToOutpoints(utxos, outpoints, store)
+}
+
+// unspendableOutpoint explains why an outpoint chosen for coin control is
+// not among the wallet's spendable UTXOs.
+func unspendableOutpoint(op string, store UTXOProvider) error {
+	reason := "is not an unspent output of this wallet, is held at a receive-only address (see utxo unlock-address), or is reserved by a pending send (see tx recover)"
+	if store != nil {
+		txid, voutStr, _ := strings.Cut(op, ":")
+		vout, _ := strconv.ParseUint(voutStr, 10, 32)
+		switch {
+		case store.IsSpent(chain.BSV, txid, uint32(vout)):
+			reason = "is marked spent in the local UTXO store"
+		case store.IsImmature(chain.BSV, txid, uint32(vout)):
+			reason = "is a coinbase output that has not matured"
+		}
+	}
+	return sigilerr.WithSuggestion(sigilerr.ErrInvalidInput, fmt.Sprintf("UTXO %s %s", op, reason))
+}
+
+// InsufficientOutpoints reports that the UTXOs chosen for coin control
+// cannot pay for the send.
+func InsufficientOutpoints(err error, count int) error {
+	return sigilerr.WithSuggestion(
+		sigilerr.ErrInsufficientFunds,
+		fmt.Sprintf("the %d chosen UTXOs cannot cover the amount and fee (%v); add more --utxo outpoints or lower the amount", count, err),
+	)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/mrz1836/sigil/internal/chain"
 	"github.com/mrz1836/sigil/internal/chain/bsv"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 // mockWOCClient for BSV client testing
@@ -48,6 +50,14 @@ func (m *mockWOCClient) BroadcastTx(_ context.Context, _ string) (string, error)
 	return "", nil
 }
 
+func (m *mockWOCClient) GetTxByHash(_ context.Context, _ string) (*whatsonchain.TxInfo, error) {
+	return &whatsonchain.TxInfo{}, nil
+}
+
+func (m *mockWOCClient) GetExchangeRate(_ context.Context) (*whatsonchain.ExchangeRate, error) {
+	return &whatsonchain.ExchangeRate{}, nil
+}
+
 func (m *mockWOCClient) BulkAddressConfirmedBalance(_ context.Context, _ *whatsonchain.AddressList) (whatsonchain.AddressBalances, error) {
 	return whatsonchain.AddressBalances{}, nil
 }
@@ -518,3 +528,46 @@ type mockUTXOProviderWithSaveError struct {
 func (m *mockUTXOProviderWithSaveError) Save() error {
 	return m.saveError
 }
+
+func TestParseOutpoint(t *testing.T) {
+	t.Parallel()
+
+	txid := strings.Repeat("ab", 32)
+	key, err := ParseOutpoint(" " + strings.ToUpper(txid) + ":3 ")
+	require.NoError(t, err)
+	assert.Equal(t, txid+":3", key)
+
+	for _, bad := range []string{"", txid, txid + ":", txid + ":-1", "abc:0", strings.Repeat("zz", 32) + ":0"} {
+		_, err := ParseOutpoint(bad)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput, bad)
+	}
+}
+
+func TestRestrictToOutpoints(t *testing.T) {
+	t.Parallel()
+
+	txid := strings.Repeat("ab", 32)
+	utxos := []chain.UTXO{
+		{TxID: txid, Vout: 0, Amount: 1000},
+		{TxID: txid, Vout: 1, Amount: 2000},
+		{TxID: txid, Vout: 2, Amount: 3000},
+	}
+
+	restricted, err := restrictToOutpoints(utxos, []string{txid + ":2", txid + ":0", txid + ":2"}, nil)
+	require.NoError(t, err)
+	require.Len(t, restricted, 2, "duplicates are spent once")
+	assert.Equal(t, uint32(2), restricted[0].Vout)
+	assert.Equal(t, uint32(0), restricted[1].Vout)
+
+	var se *sigilerr.SigilError
+	_, err = restrictToOutpoints(utxos, []string{txid + ":5"}, nil)
+	require.ErrorAs(t, err, &se)
+	assert.Equal(t, sigilerr.ErrInvalidInput.Code, se.Code)
+	assert.Contains(t, se.Suggestion, "not an unspent output")
+
+	store := newMockUTXOProvider()
+	store.MarkSpent(chain.BSV, txid, 5, "spender")
+	_, err = restrictToOutpoints(utxos, []string{txid + ":5"}, store)
+	require.ErrorAs(t, err, &se)
+	assert.Contains(t, se.Suggestion, "marked spent")
+}
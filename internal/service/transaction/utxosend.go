@@ -0,0 +1,276 @@
+package transaction
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/mrz1836/sigil/internal/cache"
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/cosign"
+	"github.com/mrz1836/sigil/internal/metrics"
+	"github.com/mrz1836/sigil/internal/utxostore"
+	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// utxoLister lists the UTXOs of an address.
+type utxoLister interface {
+	ListUTXOs(ctx context.Context, address string) ([]chain.UTXO, error)
+}
+
+// utxoSendClient is a client of a BTC-family chain: BTC or BCH.
+type utxoSendClient interface {
+	utxoLister
+	chain.AmountFormatter
+	GetFeeRate(ctx context.Context) uint64
+	SelectUTXOs(utxos []chain.UTXO, amount, feeRate uint64) ([]chain.UTXO, uint64, error)
+	Send(ctx context.Context, req chain.SendRequest) (*chain.TransactionResult, error)
+}
+
+// utxoSendChain is a BTC-family chain to send on: its client, its network,
+// and the fee rules of its chain package.
+type utxoSendChain struct {
+	id      chain.ID
+	network string
+	client  utxoSendClient
+
+	// checkFeeRate rejects a fee rate (sat/KB) above the request's cap
+	checkFeeRate func(rate, maxFeeRate uint64) error
+	// sweepAmount returns what is left of totalInputs after the fee of a
+	// one-output transaction
+	sweepAmount func(totalInputs uint64, numInputs int, feeRate uint64) (uint64, error)
+	// estimateFee estimates the fee of a transaction
+	estimateFee func(numInputs, numOutputs int, feeRate uint64) uint64
+}
+
+// sendUTXOChain handles the transaction flow of a BTC-family chain. It
+// mirrors sendBSV: UTXOs are aggregated across every wallet address,
+// filtered against the local store, and change goes to a fresh
+// internal-chain address.
+//
+//nolint:gocognit,gocyclo // Transaction flow is inherently complex
+func (s *Service) sendUTXOChain(ctx context.Context, req *SendRequest, c *utxoSendChain) (*SendResult, error) {
+	client := c.client
+	if err := chain.ValidateAddressOnNetwork(c.id, c.network, req.To); err != nil {
+		return nil, InvalidAddressError(err)
+	}
+
+	// Load local UTXO store for spent-UTXO filtering and post-broadcast marking
+	// (non-fatal: without it, only API UTXOs are used)
+	var utxoStore UTXOProvider
+	walletPath := filepath.Join(s.config.GetHome(), "wallets", req.Wallet)
+	store := utxostore.New(walletPath)
+	if err := store.Load(); err != nil {
+		if s.logger != nil {
+			s.logger.Error("%s send: failed to load utxo store: %v", c.id, err)
+		}
+	} else {
+		utxoStore = store
+	}
+
+	sweepAll := req.SweepAll()
+	var amount *big.Int
+	if !sweepAll {
+		var err error
+		amount, err = client.ParseAmount(req.AmountStr)
+		if err != nil {
+			return nil, sigilerr.WithSuggestion(
+				sigilerr.ErrInvalidInput,
+				fmt.Sprintf("invalid amount: %s", req.AmountStr),
+			)
+		}
+	}
+
+	feeRate := client.GetFeeRate(ctx)
+	if err := c.checkFeeRate(feeRate, req.MaxFeeRate); err != nil {
+		return nil, err
+	}
+
+	allUTXOs, err := aggregateUTXOs(ctx, client, req.Addresses)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error("%s send: utxo aggregation failed: %v", c.id, err)
+		}
+		return nil, fmt.Errorf("listing UTXOs: %w", err)
+	}
+	allUTXOs = filterSpentUTXOs(c.id, allUTXOs, utxoStore)
+	allUTXOs = filterReservedUTXOs(s.logger, walletPath, c.id, allUTXOs)
+	if len(allUTXOs) == 0 {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInsufficientFunds, "no UTXOs found across any wallet address")
+	}
+
+	var displayAmount string
+	var estimatedFee uint64
+	var sendUTXOs []chain.UTXO
+	if sweepAll {
+		var totalInputs uint64
+		for _, u := range allUTXOs {
+			totalInputs += u.Amount
+		}
+		sweepAmount, sweepErr := c.sweepAmount(totalInputs, len(allUTXOs), feeRate)
+		if sweepErr != nil {
+			return nil, sweepErr
+		}
+		amount = chain.AmountToBigInt(sweepAmount)
+		estimatedFee = totalInputs - sweepAmount
+		displayAmount = client.FormatAmount(amount) + " (sweep all)"
+		sendUTXOs = allUTXOs
+	} else {
+		selected, _, selErr := client.SelectUTXOs(allUTXOs, amount.Uint64(), feeRate)
+		if selErr != nil {
+			return nil, selErr
+		}
+		sendUTXOs = selected
+		estimatedFee = c.estimateFee(len(selected), 2, feeRate)
+		displayAmount = req.AmountStr
+	}
+	if s.logger != nil {
+		s.logger.Debug("%s send: using %d of %d UTXOs, rate=%d sat/KB, estimated fee=%d sat",
+			c.id, len(sendUTXOs), len(allUTXOs), feeRate, estimatedFee)
+	}
+
+	if err := s.approveSend(ctx, req, &cosign.Summary{
+		Chain:    string(c.id),
+		Wallet:   req.Wallet,
+		From:     req.FromAddress,
+		To:       req.To,
+		Amount:   amount.String(),
+		Display:  displayAmount,
+		Fee:      strconv.FormatUint(estimatedFee, 10),
+		FeeRate:  feeRate,
+		Inputs:   cosignInputs(sendUTXOs),
+		SweepAll: sweepAll,
+		Agent:    req.AgentCredID,
+	}); err != nil {
+		return nil, err
+	}
+
+	// Derive change address only for non-sweep (sweep has no change output)
+	var changeAddress string
+	if !sweepAll {
+		// Load the sending account to derive its change address
+		wlt, loadErr := s.loadSendAccount(req)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		changeAddr, changeErr := wlt.DeriveNextChangeAddress(req.Seed, c.id)
+		if changeErr != nil {
+			return nil, fmt.Errorf("deriving change address: %w", changeErr)
+		}
+		// A dry run leaves the change address for the real send
+		if !req.DryRun {
+			if updateErr := s.storage.UpdateMetadata(wlt); updateErr != nil {
+				return nil, fmt.Errorf("persisting wallet metadata: %w", updateErr)
+			}
+		}
+		changeAddress = changeAddr.Address
+	}
+
+	// The client zeroes these keys once the transaction is signed
+	keyCache, releaseKeys := requestKeyCache(req.Keys, req.Seed)
+	defer releaseKeys()
+	privateKeys, err := deriveChainKeysForUTXOs(c.id, sendUTXOs, req.Addresses, keyCache)
+	if err != nil {
+		return nil, fmt.Errorf("deriving private keys: %w", err)
+	}
+
+	intent := s.newSendIntent(req, c.id, c.network, amount)
+	var dry dryRun
+	result, err := client.Send(ctx, chain.SendRequest{
+		From:          req.FromAddress,
+		To:            req.To,
+		Amount:        amount,
+		UTXOs:         sendUTXOs,
+		PrivateKeys:   privateKeys,
+		FeeRate:       feeRate,
+		ChangeAddress: changeAddress,
+		SweepAll:      sweepAll,
+
+		BeforeBroadcast: dry.hook(req, intent.hook()),
+	})
+	if dry.signed != nil {
+		preview := dry.utxoResult(c.id, req, client.FormatAmount, amount, displayAmount, changeAddress)
+		preview.FeeRate = feeRate
+		return preview, nil
+	}
+	if err != nil {
+		if queued := s.queueSend(req, intent, err, displayAmount, len(sendUTXOs)); queued != nil {
+			return queued, nil
+		}
+		intent.abandon(err)
+		if s.logger != nil {
+			s.logger.Error("%s send failed: %v", c.id, err)
+		}
+		return nil, fmt.Errorf("sending transaction: %w", err)
+	}
+
+	markSpentUTXOs(s.logger, c.id, utxoStore, sendUTXOs, result.Hash)
+
+	invalidator := &cacheInvalidator{logger: s.logger, provider: cache.NewBalanceStorage(s.config.GetHome(), s.config.GetCache())}
+	if sweepAll {
+		for _, addr := range req.Addresses {
+			invalidator.invalidate(c.id, addr.Address, "", "0.0")
+		}
+	} else {
+		for addr := range uniqueUTXOAddrs(sendUTXOs) {
+			invalidator.invalidate(c.id, addr, "", "")
+		}
+	}
+
+	if req.AgentToken != "" && req.AgentCounterPath != "" {
+		recordAgentSpend(s.logger, req.AgentCounterPath, req.AgentToken, agentSpend(req, c.id, "", amount))
+	}
+
+	return &SendResult{
+		Hash:    result.Hash,
+		From:    result.From,
+		To:      result.To,
+		Amount:  displayAmount,
+		Fee:     result.Fee,
+		Status:  result.Status,
+		ChainID: c.id,
+
+		AmountUnits: amount,
+		FeeUnits:    parseFeeUnits(client.ParseAmount, result.Fee),
+		Decimals:    8,
+
+		UTXOsSpent: len(sendUTXOs),
+		FeeRate:    feeRate,
+		Changes:    bsvSendChanges(client.FormatAmount, allUTXOs, sendUTXOs, result, req.Addresses, changeAddress, invalidator.touched),
+	}, nil
+}
+
+// aggregateUTXOs fetches UTXOs from all wallet addresses concurrently and merges them.
+func aggregateUTXOs(ctx context.Context, client utxoLister, addresses []wallet.Address) ([]chain.UTXO, error) {
+	defer metrics.Global.StartPhase(metrics.PhaseUTXOFetch)()
+
+	results := make([][]chain.UTXO, len(addresses))
+	errs := make([]error, len(addresses))
+	var wg sync.WaitGroup
+	for i, addr := range addresses {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			utxos, err := client.ListUTXOs(ctx, addr.Address)
+			if err != nil {
+				errs[i] = fmt.Errorf("listing UTXOs for %s: %w", addr.Address, err)
+				return
+			}
+			results[i] = utxos
+		}()
+	}
+	wg.Wait()
+
+	var allUTXOs []chain.UTXO
+	for i, utxos := range results {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		allUTXOs = append(allUTXOs, utxos...)
+	}
+	return allUTXOs, nil
+}
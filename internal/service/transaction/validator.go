@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -11,18 +12,55 @@ import (
 	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
-// resolveToken resolves a token symbol to its contract address and decimals.
+// resolveToken resolves a built-in token symbol to its contract address and decimals.
 // Migrated from cli/tx.go lines 729-740
 func resolveToken(symbol string) (address string, decimals int, err error) {
-	switch strings.ToUpper(symbol) {
-	case "USDC":
-		return eth.USDCMainnet, eth.USDCDecimals, nil
-	default:
-		return "", 0, sigilerr.WithSuggestion(
-			sigilerr.ErrInvalidInput,
-			fmt.Sprintf("unsupported token: %s (only USDC is supported)", symbol),
+	if token, ok := eth.LookupKnownToken(symbol); ok {
+		return token.Address, token.Decimals, nil
+	}
+	return "", 0, sigilerr.WithSuggestion(
+		sigilerr.ErrInvalidInput,
+		fmt.Sprintf("unsupported token: %s (built-in tokens are %s; see sigil tokens list)", symbol, strings.Join(eth.KnownTokenSymbols(), ", ")),
+	)
+}
+
+// NormalizeETHRecipient validates an ETH destination address and returns it
+// in EIP-55 checksummed form. Single-case input is rejected unless
+// allowNonChecksum is set; a mixed-case address with a bad checksum is always
+// rejected because it most likely contains a typo.
+func NormalizeETHRecipient(to string, allowNonChecksum bool) (string, error) {
+	normalized, err := eth.NormalizeRecipient(to, allowNonChecksum)
+	if err == nil {
+		return normalized, nil
+	}
+
+	if !eth.IsValidAddress(to) {
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidAddress,
+			fmt.Sprintf("invalid Ethereum address: %s", to),
+		)
+	}
+	if eth.ValidateChecksumAddress(to) != nil {
+		return "", sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidChecksum,
+			fmt.Sprintf("address %s has an invalid EIP-55 checksum and may contain a typo; double-check it with the recipient", to),
 		)
 	}
+	return "", sigilerr.WithSuggestion(
+		sigilerr.ErrInvalidChecksum,
+		fmt.Sprintf("address %s is not checksummed; use %s or pass --no-checksum", to, eth.ToChecksumAddress(to)),
+	)
+}
+
+// InvalidAddressError returns an address rejected by chain.ValidateAddress
+// with a suggestion saying whether its format, checksum, or network is wrong.
+// Other errors are returned unchanged.
+func InvalidAddressError(err error) error {
+	var addrErr *chain.AddressError
+	if errors.As(err, &addrErr) {
+		return sigilerr.WithSuggestion(err, addrErr.Suggestion())
+	}
+	return err
 }
 
 // amountAll is the special value for sending the entire balance.
@@ -88,20 +126,28 @@ func ParseDecimalAmount(amount string, decimals int) (*big.Int, error) {
 	return parseDecimalAmount(amount, decimals)
 }
 
-// checkETHBalance verifies sufficient balance for the transaction.
+// ethBalances holds the balances read while validating an ETH send.
+type ethBalances struct {
+	ETH   *big.Int
+	Token *big.Int // nil for native ETH sends
+}
+
+// checkETHBalance verifies sufficient balance for the transaction and returns
+// the balances it read.
 // Migrated from cli/tx.go lines 792-847
-func checkETHBalance(ctx context.Context, client *eth.Client, address string, amount, gasCost *big.Int, tokenAddress string) error {
+func checkETHBalance(ctx context.Context, client *eth.Client, address string, amount, gasCost *big.Int, tokenAddress string) (*ethBalances, error) {
 	// Check ETH balance for gas
 	ethBalance, err := client.GetBalance(ctx, address)
 	if err != nil {
-		return fmt.Errorf("getting ETH balance: %w", err)
+		return nil, fmt.Errorf("getting ETH balance: %w", err)
 	}
+	balances := &ethBalances{ETH: ethBalance}
 
 	//nolint:nestif // Balance checking logic is necessarily complex
 	if tokenAddress != "" {
 		// For ERC-20: need ETH for gas only
 		if ethBalance.Cmp(gasCost) < 0 {
-			return sigilerr.WithDetails(
+			return nil, sigilerr.WithDetails(
 				sigilerr.ErrInsufficientFunds,
 				map[string]string{
 					"required":  client.FormatAmount(gasCost),
@@ -115,11 +161,12 @@ func checkETHBalance(ctx context.Context, client *eth.Client, address string, am
 		// Check token balance
 		tokenBalance, err := client.GetTokenBalance(ctx, address, tokenAddress)
 		if err != nil {
-			return fmt.Errorf("getting token balance: %w", err)
+			return nil, fmt.Errorf("getting token balance: %w", err)
 		}
+		balances.Token = tokenBalance
 
 		if tokenBalance.Cmp(amount) < 0 {
-			return sigilerr.WithDetails(
+			return nil, sigilerr.WithDetails(
 				sigilerr.ErrInsufficientFunds,
 				map[string]string{
 					"required":  chain.FormatDecimalAmount(amount, eth.USDCDecimals),
@@ -132,7 +179,7 @@ func checkETHBalance(ctx context.Context, client *eth.Client, address string, am
 		// For native ETH: need amount + gas
 		totalRequired := new(big.Int).Add(amount, gasCost)
 		if ethBalance.Cmp(totalRequired) < 0 {
-			return sigilerr.WithDetails(
+			return nil, sigilerr.WithDetails(
 				sigilerr.ErrInsufficientFunds,
 				map[string]string{
 					"required":  client.FormatAmount(totalRequired),
@@ -143,10 +190,11 @@ func checkETHBalance(ctx context.Context, client *eth.Client, address string, am
 		}
 	}
 
-	return nil
+	return balances, nil
 }
 
 // ValidateETHBalance is the exported version for external use.
 func ValidateETHBalance(ctx context.Context, client *eth.Client, address string, amount, gasCost *big.Int, tokenAddress string) error {
-	return checkETHBalance(ctx, client, address, amount, gasCost, tokenAddress)
+	_, err := checkETHBalance(ctx, client, address, amount, gasCost, tokenAddress)
+	return err
 }
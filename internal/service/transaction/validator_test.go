@@ -80,16 +80,18 @@ func TestResolveToken(t *testing.T) {
 			errContains: "",
 		},
 		{
-			name:        "USDT not supported",
-			symbol:      "USDT",
-			wantErr:     true,
-			errContains: "",
+			name:         "USDT built-in",
+			symbol:       "usdt",
+			wantAddress:  "0xdAC17F958D2ee523a2206206994597C13D831ec7",
+			wantDecimals: 6,
+			wantErr:      false,
 		},
 		{
-			name:        "DAI not supported",
-			symbol:      "DAI",
-			wantErr:     true,
-			errContains: "",
+			name:         "DAI built-in",
+			symbol:       "DAI",
+			wantAddress:  "0x6B175474E89094C44Da98b954EedeAC495271d0F",
+			wantDecimals: 18,
+			wantErr:      false,
 		},
 		{
 			name:        "Empty string",
@@ -36,7 +36,14 @@ type AgentSessionInfo struct {
 // 1. Agent token (SIGIL_AGENT_TOKEN environment variable)
 // 2. Agent xpub (SIGIL_AGENT_XPUB environment variable - read-only mode)
 // 3. Cached session (if sessions are enabled and a valid session exists)
-// 4. Password prompt (via req.PasswordFunc)
+// 4. Password prompt (via req.PasswordFunc), followed by the BIP39
+//    passphrase prompt (via req.PassphraseFunc) for a wallet created with
+//    --passphrase-prompt. Its derived seed is what the session caches.
+//
+// A watch-only wallet skips all of them: it loads with a nil seed when
+// req.AllowWatchOnly is set and fails with ErrWalletWatchOnly otherwise.
+// So does a wallet file written by a newer sigil, which fails with
+// ErrWalletVersionTooNew when the caller needs the seed.
 //
 // The caller must zero the seed after use: wallet.ZeroBytes(result.Seed)
 //
@@ -47,8 +54,23 @@ func (s *Service) Load(req *LoadRequest, ctx *LoadContext) (*LoadResult, *Sessio
 		return nil, nil, err
 	}
 
+	// A watch-only wallet has no seed for any method to unlock, and a newer
+	// wallet file is only opened read-only
+	if wlt, metaErr := s.storage.LoadMetadata(req.Name); metaErr == nil && wlt != nil {
+		if wlt.IsWatchOnly() {
+			return s.loadWatchOnly(wlt, req.AllowWatchOnly, ctx)
+		}
+		if wlt.IsNewerVersion() {
+			return s.loadNewerVersion(wlt, req.AllowWatchOnly, ctx)
+		}
+	}
+
 	// Try agent token authentication first
-	if token := CheckAgentToken(); token != "" {
+	token, err := LookupAgentToken()
+	if err != nil {
+		return nil, nil, err
+	}
+	if token != "" {
 		return s.loadWithAgentToken(req.Name, token, ctx)
 	}
 
@@ -105,6 +127,11 @@ func (s *Service) Load(req *LoadRequest, ctx *LoadContext) (*LoadResult, *Sessio
 	if loadErr != nil {
 		return nil, nil, loadErr
 	}
+	if wlt.PassphrasePrompt {
+		if seed, loadErr = s.seedWithPassphrase(wlt, seed, req.PassphraseFunc); loadErr != nil {
+			return nil, nil, loadErr
+		}
+	}
 
 	// Start a new session if sessions are enabled
 	//nolint:nestif // Session creation flow requires nested conditionals
@@ -135,6 +162,26 @@ func (s *Service) Load(req *LoadRequest, ctx *LoadContext) (*LoadResult, *Sessio
 		}, nil
 }
 
+// seedWithPassphrase derives the seed of a --passphrase-prompt wallet from
+// its decrypted mnemonic, which it zeroes, and the prompted BIP39 passphrase.
+func (s *Service) seedWithPassphrase(wlt *wallet.Wallet, mnemonic []byte, passphraseFunc func(string) (string, error)) ([]byte, error) {
+	defer wallet.ZeroBytes(mnemonic)
+
+	if passphraseFunc == nil {
+		return nil, sigilerr.WithSuggestion(
+			sigilerr.ErrInvalidInput,
+			fmt.Sprintf("wallet '%s' needs its BIP39 passphrase but no passphrase function was provided", wlt.Name),
+		)
+	}
+	passphrase, err := passphraseFunc("Enter BIP39 passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+	defer wallet.ZeroBytes([]byte(passphrase))
+
+	return wlt.SeedFromMnemonic(mnemonic, passphrase)
+}
+
 // loadWithAgentToken authenticates using an agent token from SIGIL_AGENT_TOKEN.
 // Finds the matching agent file, decrypts the seed, validates expiry and policy.
 func (s *Service) loadWithAgentToken(name, token string, ctx *LoadContext) (*LoadResult, *SessionInfo, error) {
@@ -227,6 +274,53 @@ func (s *Service) loadWithXpub(name, xpub string, ctx *LoadContext) (*LoadResult
 		}, nil
 }
 
+// loadWatchOnly returns a watch-only wallet with a nil seed when the caller
+// allows it, or ErrWalletWatchOnly when the caller needs to sign.
+func (s *Service) loadWatchOnly(wlt *wallet.Wallet, allow bool, ctx *LoadContext) (*LoadResult, *SessionInfo, error) {
+	if !allow {
+		return nil, nil, sigilerr.WithSuggestion(
+			sigilerr.ErrWalletWatchOnly,
+			fmt.Sprintf("wallet '%s' was imported without a seed; build an unsigned transaction with "+
+				"'sigil tx build --wallet %s' and sign it with 'sigil tx sign --wallet <keys>'", wlt.Name, wlt.Name),
+		)
+	}
+
+	if ctx != nil && ctx.OnAuthMessage != nil {
+		ctx.OnAuthMessage("[watch-only wallet — signing disabled]")
+	}
+
+	return &LoadResult{
+			Wallet: wlt,
+			Seed:   nil,
+		}, &SessionInfo{
+			Mode:    AuthWatchOnly,
+			Message: "watch-only wallet — signing disabled",
+		}, nil
+}
+
+// loadNewerVersion returns a wallet written by a newer sigil with a nil seed
+// when the caller only reads, or ErrWalletVersionTooNew when the caller needs
+// the seed. Unlocking it with this build could sign or save it wrongly.
+func (s *Service) loadNewerVersion(wlt *wallet.Wallet, allow bool, ctx *LoadContext) (*LoadResult, *SessionInfo, error) {
+	if !allow {
+		return nil, nil, wlt.CheckVersion()
+	}
+
+	msg := fmt.Sprintf("wallet file version %d is newer than this sigil supports (%d) — read-only; run 'sigil upgrade' to sign or change it",
+		wlt.Version, wallet.CurrentVersion)
+	if ctx != nil && ctx.OnAuthMessage != nil {
+		ctx.OnAuthMessage("[" + msg + "]")
+	}
+
+	return &LoadResult{
+			Wallet: wlt,
+			Seed:   nil,
+		}, &SessionInfo{
+			Mode:    AuthReadOnly,
+			Message: msg,
+		}, nil
+}
+
 // formatDuration formats a duration as a human-readable string.
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
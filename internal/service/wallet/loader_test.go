@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -15,6 +16,7 @@ import (
 	"github.com/mrz1836/sigil/internal/config"
 	"github.com/mrz1836/sigil/internal/session"
 	"github.com/mrz1836/sigil/internal/wallet"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
@@ -321,6 +323,75 @@ func TestLoad_SessionCreation(t *testing.T) {
 	assert.True(t, sessionMgr.HasValidSession("test"))
 }
 
+func TestLoad_PassphrasePrompt(t *testing.T) {
+	t.Parallel()
+
+	// Ensure no agent environment variables are set
+	_ = os.Unsetenv(config.EnvAgentToken)
+	_ = os.Unsetenv(config.EnvAgentXpub)
+
+	hiddenSeed, err := wallet.MnemonicToSeed(testMnemonic, "hidden")
+	require.NoError(t, err)
+	newHiddenWallet := func() (*mockStorageProvider, *wallet.Wallet) {
+		w, wErr := wallet.NewWallet("hidden", []wallet.ChainID{wallet.ChainBSV})
+		require.NoError(t, wErr)
+		require.NoError(t, w.DeriveAddresses(hiddenSeed, 1))
+		w.PassphrasePrompt = true
+		storage := newMockStorageProvider()
+		storage.addWallet(w, []byte(testMnemonic))
+		return storage, w
+	}
+	password := func(_ string) (string, error) { return "password", nil }
+
+	t.Run("derives and caches the seed", func(t *testing.T) {
+		t.Parallel()
+		storage, _ := newHiddenWallet()
+		sessionMgr := newMockSessionManager()
+		cfg := newMockConfigProvider()
+		cfg.security.SessionEnabled = true
+		cfg.security.SessionTTLMinutes = 30
+
+		var prompts []string
+		service := NewService(&Config{Storage: storage, SessionMgr: sessionMgr, Config: cfg})
+		result, _, err := service.Load(&LoadRequest{
+			Name:         "hidden",
+			PasswordFunc: password,
+			PassphraseFunc: func(prompt string) (string, error) {
+				prompts = append(prompts, prompt)
+				return "hidden", nil
+			},
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, hiddenSeed, result.Seed)
+		assert.Equal(t, []string{"Enter BIP39 passphrase: "}, prompts)
+
+		cached, _, err := sessionMgr.GetSession("hidden")
+		require.NoError(t, err)
+		assert.Equal(t, hiddenSeed, cached, "the session caches the derived seed, not the mnemonic")
+	})
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		t.Parallel()
+		storage, _ := newHiddenWallet()
+		service := NewService(&Config{Storage: storage})
+		_, _, err := service.Load(&LoadRequest{
+			Name:           "hidden",
+			PasswordFunc:   password,
+			PassphraseFunc: func(_ string) (string, error) { return "hiddne", nil },
+		}, nil)
+		require.ErrorIs(t, err, sigilerr.ErrPassphraseMismatch)
+		assert.Equal(t, sigilerr.ExitAuth, sigilerr.ExitCode(err))
+	})
+
+	t.Run("no passphrase function", func(t *testing.T) {
+		t.Parallel()
+		storage, _ := newHiddenWallet()
+		service := NewService(&Config{Storage: storage})
+		_, _, err := service.Load(&LoadRequest{Name: "hidden", PasswordFunc: password}, nil)
+		require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+	})
+}
+
 func TestLoad_AgentToken_Success(t *testing.T) {
 	// Not using t.Parallel() because this test modifies environment variables
 
@@ -507,6 +578,92 @@ func TestLoad_AgentXpub_Success(t *testing.T) {
 	assert.Contains(t, sessInfo.Message, "read-only")
 }
 
+func TestLoad_WatchOnly(t *testing.T) {
+	t.Parallel()
+
+	storage := newMockStorageProvider()
+	storage.addWallet(&wallet.Wallet{
+		Name:          "watch",
+		Type:          wallet.TypeWatchOnly,
+		EnabledChains: []chain.ID{chain.BSV},
+	}, nil)
+
+	service := NewService(&Config{
+		Storage: storage,
+	})
+
+	passwordFunc := func(_ string) (string, error) {
+		t.Fatal("watch-only wallet must not prompt for a password")
+		return "", nil
+	}
+
+	t.Run("refused when signing", func(t *testing.T) {
+		t.Parallel()
+		result, sessInfo, err := service.Load(&LoadRequest{Name: "watch", PasswordFunc: passwordFunc}, nil)
+		require.ErrorIs(t, err, sigilerr.ErrWalletWatchOnly)
+		assert.Nil(t, result)
+		assert.Nil(t, sessInfo)
+	})
+
+	t.Run("allowed for reads", func(t *testing.T) {
+		t.Parallel()
+		var messages []string
+		ctx := &LoadContext{OnAuthMessage: func(msg string) { messages = append(messages, msg) }}
+
+		result, sessInfo, err := service.Load(&LoadRequest{Name: "watch", PasswordFunc: passwordFunc, AllowWatchOnly: true}, ctx)
+		require.NoError(t, err)
+		assert.Equal(t, AuthWatchOnly, sessInfo.Mode)
+		assert.Nil(t, result.Seed)
+		assert.Equal(t, "watch", result.Wallet.Name)
+		assert.Equal(t, []string{"[watch-only wallet — signing disabled]"}, messages)
+	})
+}
+
+func TestLoad_NewerVersion(t *testing.T) {
+	t.Parallel()
+
+	storage := newMockStorageProvider()
+	storage.addWallet(&wallet.Wallet{
+		Name:          "future",
+		EnabledChains: []chain.ID{chain.BSV},
+		Version:       wallet.CurrentVersion + 1,
+	}, getTestSeed(t))
+
+	service := NewService(&Config{
+		Storage: storage,
+	})
+
+	passwordFunc := func(_ string) (string, error) {
+		t.Fatal("a newer wallet must not prompt for a password")
+		return "", nil
+	}
+
+	t.Run("refused when signing", func(t *testing.T) {
+		t.Parallel()
+		result, sessInfo, err := service.Load(&LoadRequest{Name: "future", PasswordFunc: passwordFunc}, nil)
+		require.ErrorIs(t, err, sigilerr.ErrWalletVersionTooNew)
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, err, &se)
+		assert.Contains(t, se.Suggestion, "sigil upgrade")
+		assert.Nil(t, result)
+		assert.Nil(t, sessInfo)
+	})
+
+	t.Run("read-only for reads", func(t *testing.T) {
+		t.Parallel()
+		var messages []string
+		ctx := &LoadContext{OnAuthMessage: func(msg string) { messages = append(messages, msg) }}
+
+		result, sessInfo, err := service.Load(&LoadRequest{Name: "future", PasswordFunc: passwordFunc, AllowWatchOnly: true}, ctx)
+		require.NoError(t, err)
+		assert.Equal(t, AuthReadOnly, sessInfo.Mode)
+		assert.Equal(t, "read_only", sessInfo.Mode.String())
+		assert.Nil(t, result.Seed)
+		require.Len(t, messages, 1)
+		assert.Contains(t, messages[0], "read-only")
+	})
+}
+
 func TestLoad_OnAuthMessage_Callback(t *testing.T) {
 	t.Parallel()
 
@@ -566,6 +723,16 @@ func TestCheckAgentToken(t *testing.T) {
 	assert.Equal(t, "test-token", token)
 }
 
+func TestLookupAgentToken_BadSecretFile(t *testing.T) {
+	// Can't use t.Parallel() with t.Setenv()
+	t.Setenv(config.EnvAgentToken, "")
+	t.Setenv(config.EnvAgentToken+config.EnvFileSuffix, filepath.Join(t.TempDir(), "missing"))
+
+	_, err := LookupAgentToken()
+	require.ErrorIs(t, err, config.ErrSecretFile)
+	assert.Empty(t, CheckAgentToken(), "CheckAgentToken treats an unreadable file as unset")
+}
+
 func TestCheckAgentXpub(t *testing.T) {
 	// Test with no xpub
 	_ = os.Unsetenv(config.EnvAgentXpub)
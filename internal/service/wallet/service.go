@@ -65,9 +65,22 @@ func (s *Service) LoadMetadata(name string) (*wallet.Wallet, error) {
 	return s.storage.LoadMetadata(name)
 }
 
-// CheckAgentToken checks if an agent token is configured in the environment.
+// CheckAgentToken checks if an agent token is configured in the environment
+// or a mounted secret file. An unreadable secret file is treated as unset;
+// use LookupAgentToken where the token is actually consumed.
 func CheckAgentToken() string {
-	return os.Getenv(config.EnvAgentToken)
+	token, _ := config.LookupSecret(config.EnvAgentToken)
+	return token
+}
+
+// LookupAgentToken returns the configured agent token, reporting a
+// configured but unreadable SIGIL_AGENT_TOKEN_FILE or SIGIL_SECRETS_DIR file.
+func LookupAgentToken() (string, error) {
+	token, err := config.LookupSecret(config.EnvAgentToken)
+	if err != nil {
+		return "", sigilerr.WithSuggestion(err, "check the secret file mount and its permissions")
+	}
+	return token, nil
 }
 
 // CheckAgentXpub checks if an agent xpub is configured in the environment.
@@ -10,6 +10,13 @@ import (
 type LoadRequest struct {
 	Name         string
 	PasswordFunc func(string) (string, error) // Injected password prompt function
+	// PassphraseFunc prompts for the BIP39 passphrase of a wallet created
+	// with --passphrase-prompt. It is only called after the password.
+	PassphraseFunc func(string) (string, error)
+	// AllowWatchOnly lets a watch-only wallet, or one written by a newer
+	// sigil, load with a nil seed. Callers that sign or change the wallet
+	// leave it unset so such wallets are refused.
+	AllowWatchOnly bool
 }
 
 // LoadResult contains the loaded wallet and seed material.
@@ -31,6 +38,10 @@ const (
 	AuthXpub
 	// AuthPassword uses password-based authentication.
 	AuthPassword
+	// AuthWatchOnly loads a watch-only wallet, which has no seed.
+	AuthWatchOnly
+	// AuthReadOnly loads a wallet written by a newer sigil without its seed.
+	AuthReadOnly
 )
 
 // String returns the string representation of the auth mode.
@@ -44,6 +55,10 @@ func (a AuthMode) String() string {
 		return "xpub"
 	case AuthPassword:
 		return "password"
+	case AuthWatchOnly:
+		return "watch_only"
+	case AuthReadOnly:
+		return "read_only"
 	default:
 		return "unknown"
 	}
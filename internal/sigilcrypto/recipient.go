@@ -0,0 +1,185 @@
+package sigilcrypto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+)
+
+// Recipient schemes supported by EncryptToRecipient.
+const (
+	SchemeAge = "age"
+	SchemeGPG = "gpg"
+)
+
+// gpgPrefix explicitly selects GPG for a recipient, e.g. "gpg:ops@example.com".
+const gpgPrefix = "gpg:"
+
+// pgpArmorHeader starts ASCII-armored GPG ciphertext.
+const pgpArmorHeader = "-----BEGIN PGP MESSAGE-----"
+
+var (
+	// ErrEmptyRecipient indicates no recipient was given.
+	ErrEmptyRecipient = errors.New("recipient is empty")
+
+	// ErrGPGUnavailable indicates the gpg binary could not be found.
+	ErrGPGUnavailable = errors.New("gpg not found in PATH")
+
+	// ErrNoIdentity indicates age ciphertext was given without an identity file.
+	ErrNoIdentity = errors.New("an age identity file is required to decrypt")
+)
+
+// gpgLookPath locates the gpg binary. Replaced in tests.
+//
+//nolint:gochecknoglobals // Test seam for external binary lookup
+var gpgLookPath = exec.LookPath
+
+// Recipient is a public key that data can be encrypted to.
+type Recipient struct {
+	// Scheme is SchemeAge or SchemeGPG.
+	Scheme string
+	// Key is the age public key, or the GPG key ID, fingerprint, or email.
+	Key string
+
+	age age.Recipient
+}
+
+// ParseRecipient parses an encryption recipient. Strings starting with
+// "age1" are age public keys (X25519 or post-quantum hybrid). Anything else
+// is a GPG key ID, fingerprint, or email; a "gpg:" prefix may be used to make
+// that explicit. GPG recipients require the gpg binary, which is checked here
+// so callers can fail before doing any irreversible work.
+func ParseRecipient(s string) (*Recipient, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, ErrEmptyRecipient
+	}
+
+	if strings.HasPrefix(s, "age1") {
+		recs, err := age.ParseRecipients(strings.NewReader(s))
+		if err != nil {
+			return nil, fmt.Errorf("parsing age recipient: %w", err)
+		}
+		return &Recipient{Scheme: SchemeAge, Key: s, age: recs[0]}, nil
+	}
+
+	key := strings.TrimSpace(strings.TrimPrefix(s, gpgPrefix))
+	if key == "" {
+		return nil, ErrEmptyRecipient
+	}
+	if _, err := gpgLookPath("gpg"); err != nil {
+		return nil, ErrGPGUnavailable
+	}
+	return &Recipient{Scheme: SchemeGPG, Key: key}, nil
+}
+
+// Encrypt encrypts plaintext to the recipient and returns ASCII-armored
+// ciphertext suitable for printing in a terminal or CI log. Decrypt with
+// "age -d -i <identity>" or "gpg -d" on the target machine.
+func (r *Recipient) Encrypt(ctx context.Context, plaintext []byte) ([]byte, error) {
+	if r.Scheme == SchemeGPG {
+		return encryptGPG(ctx, r.Key, plaintext)
+	}
+
+	buf := &bytes.Buffer{}
+	aw := armor.NewWriter(buf)
+	w, err := age.Encrypt(aw, r.age)
+	if err != nil {
+		return nil, fmt.Errorf("initializing encryption: %w", err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, fmt.Errorf("writing encrypted data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("finalizing encryption: %w", err)
+	}
+	if err := aw.Close(); err != nil {
+		return nil, fmt.Errorf("finalizing armor: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecryptFromRecipient decrypts ciphertext produced by Recipient.Encrypt.
+// GPG ciphertext is passed to gpg, which finds the key in the local keyring;
+// age ciphertext, armored or binary, is decrypted with the identities in
+// identityFile.
+func DecryptFromRecipient(ctx context.Context, ciphertext []byte, identityFile string) ([]byte, error) {
+	trimmed := bytes.TrimSpace(ciphertext)
+	if bytes.HasPrefix(trimmed, []byte(pgpArmorHeader)) {
+		return decryptGPG(ctx, trimmed)
+	}
+	if identityFile == "" {
+		return nil, ErrNoIdentity
+	}
+
+	f, err := os.Open(identityFile) //nolint:gosec // identity path is supplied by the user on purpose
+	if err != nil {
+		return nil, fmt.Errorf("opening identity file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("parsing identity file: %w", err)
+	}
+
+	var src io.Reader = bytes.NewReader(trimmed)
+	if bytes.HasPrefix(trimmed, []byte(armor.Header)) {
+		src = armor.NewReader(src)
+	}
+	r, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, fmt.Errorf("decrypting: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading decrypted data: %w", err)
+	}
+	return plaintext, nil
+}
+
+// decryptGPG decrypts ciphertext with a key in the local GPG keyring.
+func decryptGPG(ctx context.Context, ciphertext []byte) ([]byte, error) {
+	path, err := gpgLookPath("gpg")
+	if err != nil {
+		return nil, ErrGPGUnavailable
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, "--batch", "--decrypt") //nolint:gosec // Fixed arguments, ciphertext is passed on stdin
+	cmd.Stdin = bytes.NewReader(ciphertext)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("gpg decryption failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// encryptGPG encrypts plaintext to a GPG public key in the local keyring.
+// The plaintext is passed on stdin so it never appears in the process list.
+func encryptGPG(ctx context.Context, key string, plaintext []byte) ([]byte, error) {
+	path, err := gpgLookPath("gpg")
+	if err != nil {
+		return nil, ErrGPGUnavailable
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, path, "--batch", "--armor", "--encrypt", "--recipient", key) //nolint:gosec // Recipient is passed as a single argument, not through a shell
+	cmd.Stdin = bytes.NewReader(plaintext)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("gpg encryption for %s failed: %w: %s", key, err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
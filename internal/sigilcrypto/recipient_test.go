@@ -0,0 +1,105 @@
+package sigilcrypto
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNoGPG = errors.New("executable file not found")
+
+func TestRecipient_AgeRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+
+	r, err := ParseRecipient("  " + identity.Recipient().String() + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, SchemeAge, r.Scheme)
+
+	ciphertext, err := r.Encrypt(context.Background(), []byte("sigil_agent_token"))
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(ciphertext, []byte("-----BEGIN AGE ENCRYPTED FILE-----")))
+	assert.NotContains(t, string(ciphertext), "sigil_agent_token")
+
+	dr, err := age.Decrypt(armor.NewReader(bytes.NewReader(ciphertext)), identity)
+	require.NoError(t, err)
+	plaintext, err := io.ReadAll(dr)
+	require.NoError(t, err)
+	assert.Equal(t, "sigil_agent_token", string(plaintext))
+}
+
+func TestDecryptFromRecipient_Age(t *testing.T) {
+	t.Parallel()
+
+	identity, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	identityFile := filepath.Join(t.TempDir(), "identity.age")
+	require.NoError(t, os.WriteFile(identityFile, []byte(identity.String()+"\n"), 0o600))
+
+	r, err := ParseRecipient(identity.Recipient().String())
+	require.NoError(t, err)
+	ciphertext, err := r.Encrypt(context.Background(), []byte("profile bundle"))
+	require.NoError(t, err)
+
+	plaintext, err := DecryptFromRecipient(context.Background(), ciphertext, identityFile)
+	require.NoError(t, err)
+	assert.Equal(t, "profile bundle", string(plaintext))
+
+	_, err = DecryptFromRecipient(context.Background(), ciphertext, "")
+	require.ErrorIs(t, err, ErrNoIdentity)
+
+	other, err := age.GenerateX25519Identity()
+	require.NoError(t, err)
+	otherFile := filepath.Join(t.TempDir(), "other.age")
+	require.NoError(t, os.WriteFile(otherFile, []byte(other.String()+"\n"), 0o600))
+	_, err = DecryptFromRecipient(context.Background(), ciphertext, otherFile)
+	require.Error(t, err, "a different identity cannot decrypt")
+}
+
+func TestParseRecipient_Errors(t *testing.T) {
+	t.Parallel()
+
+	_, err := ParseRecipient("   ")
+	require.ErrorIs(t, err, ErrEmptyRecipient)
+
+	_, err = ParseRecipient("gpg:")
+	require.ErrorIs(t, err, ErrEmptyRecipient)
+
+	_, err = ParseRecipient("age1notavalidkey")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "parsing age recipient")
+}
+
+// Not parallel: mutates the package-level gpgLookPath seam.
+func TestParseRecipient_GPG(t *testing.T) {
+	orig := gpgLookPath
+	t.Cleanup(func() { gpgLookPath = orig })
+
+	gpgLookPath = func(string) (string, error) { return "/usr/bin/gpg", nil }
+
+	r, err := ParseRecipient("gpg:ops@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, SchemeGPG, r.Scheme)
+	assert.Equal(t, "ops@example.com", r.Key)
+
+	r, err = ParseRecipient("0xDEADBEEFCAFEBABE")
+	require.NoError(t, err)
+	assert.Equal(t, SchemeGPG, r.Scheme)
+	assert.Equal(t, "0xDEADBEEFCAFEBABE", r.Key)
+
+	gpgLookPath = func(string) (string, error) { return "", errTestNoGPG }
+
+	_, err = ParseRecipient("ops@example.com")
+	require.ErrorIs(t, err, ErrGPGUnavailable)
+}
@@ -0,0 +1,173 @@
+// Package stamp implements file timestamping by anchoring a file's SHA-256
+// digest in a BSV OP_RETURN output and keeping a local record of the proof.
+package stamp
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// Protocol is the first push of every stamp payload, identifying it as a sigil stamp.
+	Protocol = "sigil-stamp"
+
+	// Algorithm is the hash algorithm tag stored in the payload.
+	Algorithm = "sha256"
+
+	// storeFilePermissions restricts the proof file to the owner.
+	storeFilePermissions = 0o600
+
+	// storeDirPermissions restricts the sigil home directory to the owner.
+	storeDirPermissions = 0o700
+)
+
+// ErrCorruptStore indicates the proof file is malformed JSON.
+var ErrCorruptStore = errors.New("stamp store is corrupted")
+
+// HashFile returns the SHA-256 digest of the file at path.
+func HashFile(path string) ([]byte, error) {
+	f, err := os.Open(path) //nolint:gosec // path is supplied by the user on purpose
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, fmt.Errorf("hashing file: %w", err)
+	}
+
+	return h.Sum(nil), nil
+}
+
+// Payload returns the OP_RETURN pushes for a digest: protocol, algorithm, digest.
+func Payload(digest []byte) [][]byte {
+	return [][]byte{[]byte(Protocol), []byte(Algorithm), digest}
+}
+
+// Matches reports whether any of the data outputs is a stamp payload for digest.
+func Matches(dataOutputs [][][]byte, digest []byte) bool {
+	for _, pushes := range dataOutputs {
+		if len(pushes) != 3 {
+			continue
+		}
+		if string(pushes[0]) == Protocol &&
+			string(pushes[1]) == Algorithm &&
+			bytes.Equal(pushes[2], digest) {
+			return true
+		}
+	}
+	return false
+}
+
+// Proof is the local record of a stamp transaction.
+type Proof struct {
+	File      string    `json:"file"`
+	SHA256    string    `json:"sha256"`
+	TxID      string    `json:"txid"`
+	Wallet    string    `json:"wallet"`
+	Network   string    `json:"network"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// NewProof builds a proof record for a broadcast stamp.
+func NewProof(file string, digest []byte, txid, walletName, network string) Proof {
+	return Proof{
+		File:      filepath.Base(file),
+		SHA256:    hex.EncodeToString(digest),
+		TxID:      txid,
+		Wallet:    walletName,
+		Network:   network,
+		CreatedAt: time.Now().UTC(),
+	}
+}
+
+// FileStore persists stamp proofs as a JSON array.
+type FileStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileStore creates a proof store backed by the file at path.
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Add appends a proof to the store.
+func (s *FileStore) Add(p Proof) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	proofs, err := s.load()
+	if err != nil {
+		return err
+	}
+	proofs = append(proofs, p)
+
+	if err := os.MkdirAll(filepath.Dir(s.path), storeDirPermissions); err != nil {
+		return fmt.Errorf("creating stamp directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(proofs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling stamps: %w", err)
+	}
+
+	if err := fileutil.WriteAtomic(s.path, data, storeFilePermissions); err != nil {
+		return fmt.Errorf("writing stamp store: %w", err)
+	}
+
+	return nil
+}
+
+// List returns all stored proofs in the order they were added.
+func (s *FileStore) List() ([]Proof, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.load()
+}
+
+// FindByHash returns the proofs recorded for a hex-encoded SHA-256 digest.
+func (s *FileStore) FindByHash(sha string) ([]Proof, error) {
+	proofs, err := s.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []Proof
+	for _, p := range proofs {
+		if p.SHA256 == sha {
+			matches = append(matches, p)
+		}
+	}
+	return matches, nil
+}
+
+// load reads the proof file. A missing file yields an empty list.
+func (s *FileStore) load() ([]Proof, error) {
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading stamp store: %w", err)
+	}
+
+	var proofs []Proof
+	if err := json.Unmarshal(data, &proofs); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCorruptStore, err)
+	}
+	return proofs, nil
+}
@@ -0,0 +1,107 @@
+package stamp
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashFile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hashes contents", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "doc.txt")
+		require.NoError(t, os.WriteFile(path, []byte("hello"), 0o600))
+
+		digest, err := HashFile(path)
+		require.NoError(t, err)
+
+		want := sha256.Sum256([]byte("hello"))
+		assert.Equal(t, want[:], digest)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := HashFile(filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+}
+
+func TestMatches(t *testing.T) {
+	t.Parallel()
+
+	digest := sha256.Sum256([]byte("a"))
+	other := sha256.Sum256([]byte("b"))
+
+	tests := []struct {
+		name    string
+		outputs [][][]byte
+		want    bool
+	}{
+		{"exact payload", [][][]byte{Payload(digest[:])}, true},
+		{"payload among others", [][][]byte{{[]byte("x")}, Payload(digest[:])}, true},
+		{"different digest", [][][]byte{Payload(other[:])}, false},
+		{"wrong protocol", [][][]byte{{[]byte("other"), []byte(Algorithm), digest[:]}}, false},
+		{"no outputs", nil, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, Matches(tc.outputs, digest[:]))
+		})
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty store", func(t *testing.T) {
+		t.Parallel()
+
+		s := NewFileStore(filepath.Join(t.TempDir(), "stamps.json"))
+		proofs, err := s.List()
+		require.NoError(t, err)
+		assert.Empty(t, proofs)
+	})
+
+	t.Run("add and find", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "nested", "stamps.json")
+		s := NewFileStore(path)
+
+		digest := sha256.Sum256([]byte("doc"))
+		p := NewProof("/tmp/doc.pdf", digest[:], "abc", "main", "mainnet")
+		require.NoError(t, s.Add(p))
+		require.NoError(t, s.Add(NewProof("other.pdf", []byte{1}, "def", "main", "mainnet")))
+
+		found, err := s.FindByHash(hex.EncodeToString(digest[:]))
+		require.NoError(t, err)
+		require.Len(t, found, 1)
+		assert.Equal(t, "doc.pdf", found[0].File)
+		assert.Equal(t, "abc", found[0].TxID)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	})
+
+	t.Run("corrupt store", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "stamps.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		_, err := NewFileStore(path).List()
+		require.ErrorIs(t, err, ErrCorruptStore)
+	})
+}
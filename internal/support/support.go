@@ -0,0 +1,187 @@
+// Package support builds the diagnostic bundles users attach to bug reports.
+//
+// A Bundle is a set of named files written as one gzipped tar archive. The
+// files are collected by the caller; this package only sanitizes text, reads
+// the tail of log files, and writes the archive.
+package support
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fileMode is the mode of files inside the archive.
+const fileMode = 0o600
+
+// File is one file of a bundle.
+type File struct {
+	// Name is the path inside the archive, relative to the bundle directory.
+	Name string
+	Data []byte
+}
+
+// Bundle is a support bundle being assembled.
+type Bundle struct {
+	// Name is the directory the files are archived under, and the base name
+	// of the default archive file.
+	Name      string
+	CreatedAt time.Time
+	Files     []File
+}
+
+// New returns an empty bundle named for createdAt.
+func New(createdAt time.Time) *Bundle {
+	return &Bundle{
+		Name:      "sigil-support-" + createdAt.UTC().Format("20060102-150405"),
+		CreatedAt: createdAt,
+	}
+}
+
+// Add adds a file, replacing any file of the same name.
+func (b *Bundle) Add(name string, data []byte) {
+	for i := range b.Files {
+		if b.Files[i].Name == name {
+			b.Files[i].Data = data
+			return
+		}
+	}
+	b.Files = append(b.Files, File{Name: name, Data: data})
+}
+
+// AddJSON adds v as an indented JSON file.
+func (b *Bundle) AddJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", name, err)
+	}
+	b.Add(name, append(data, '\n'))
+	return nil
+}
+
+// Archive returns the bundle as a gzipped tar archive, with every file under
+// the bundle's Name directory.
+func (b *Bundle) Archive() ([]byte, error) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+
+	for _, f := range b.Files {
+		hdr := &tar.Header{
+			Name:    b.Name + "/" + f.Name,
+			Mode:    fileMode,
+			Size:    int64(len(f.Data)),
+			ModTime: b.CreatedAt,
+			Format:  tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, fmt.Errorf("archiving %s: %w", f.Name, err)
+		}
+		if _, err := tw.Write(f.Data); err != nil {
+			return nil, fmt.Errorf("archiving %s: %w", f.Name, err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("closing archive: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("compressing archive: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Tail returns the last n lines of the file at path. A missing file returns
+// an error wrapping os.ErrNotExist.
+func Tail(path string, n int) ([]byte, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is the configured log file
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	lines := make([]string, 0, n)
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, sc.Text())
+	}
+	if err := sc.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if len(lines) == 0 {
+		return nil, nil
+	}
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+// urlPattern matches http(s) and ws(s) URLs. Group 1 is the scheme, group 2
+// any user info, group 3 the host and group 4 the rest.
+var urlPattern = regexp.MustCompile(`(?i)\b(https?|wss?)://([^\s/"'@<>]+@)?([^\s/"'?#<>]+)([^\s"'<>]*)`)
+
+// Sanitizer removes secrets from text before it is bundled.
+type Sanitizer struct {
+	placeholder  string
+	replacements map[string]string
+}
+
+// NewSanitizer returns a sanitizer that writes placeholder in place of
+// whatever it removes.
+func NewSanitizer(placeholder string) *Sanitizer {
+	return &Sanitizer{placeholder: placeholder, replacements: map[string]string{}}
+}
+
+// Secret registers a value to remove wherever it appears. Values shorter
+// than four characters are ignored, since they would match ordinary text.
+func (s *Sanitizer) Secret(value string) {
+	if len(value) >= 4 {
+		s.replacements[value] = s.placeholder
+	}
+}
+
+// Replace registers old to be shown as replacement, such as the user's home
+// directory as "~".
+func (s *Sanitizer) Replace(old, replacement string) {
+	if old != "" {
+		s.replacements[old] = replacement
+	}
+}
+
+// Sanitize returns data with registered values replaced, and every URL cut
+// down to its scheme and host, since paths and queries of provider URLs
+// often carry API keys.
+func (s *Sanitizer) Sanitize(data []byte) []byte {
+	// Longer values first, so a secret containing another is removed whole
+	olds := make([]string, 0, len(s.replacements))
+	for old := range s.replacements {
+		olds = append(olds, old)
+	}
+	sort.Slice(olds, func(i, j int) bool { return len(olds[i]) > len(olds[j]) })
+	pairs := make([]string, 0, 2*len(olds))
+	for _, old := range olds {
+		pairs = append(pairs, old, s.replacements[old])
+	}
+	text := strings.NewReplacer(pairs...).Replace(string(data))
+
+	text = urlPattern.ReplaceAllStringFunc(text, func(u string) string {
+		m := urlPattern.FindStringSubmatch(u)
+		out := m[1] + "://" + m[3]
+		if m[2] != "" || (m[4] != "" && m[4] != "/") {
+			out += "/" + s.placeholder
+		}
+		return out
+	})
+	return []byte(text)
+}
@@ -0,0 +1,158 @@
+// Package tokens is the ERC-20 token registry: the tokens built into sigil
+// plus those listed under networks.eth.tokens in config.yaml.
+//
+// Built-in tokens always win over configured ones, so a config entry cannot
+// redirect a well-known symbol such as USDC to another contract.
+package tokens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
+)
+
+const (
+	// maxSymbolLen bounds user-supplied token symbols.
+	maxSymbolLen = 32
+
+	// maxDecimals is the largest decimals value an ERC-20 token can have (uint8).
+	maxDecimals = 255
+)
+
+var (
+	// ErrBuiltIn is returned when a change targets a built-in token.
+	ErrBuiltIn = errors.New("token is built in")
+
+	// ErrSymbolInUse is returned when a symbol already names another contract.
+	ErrSymbolInUse = errors.New("token symbol is already used by another contract")
+
+	// ErrInvalidToken is returned for a malformed token entry.
+	ErrInvalidToken = errors.New("invalid token")
+)
+
+// Source says where a registry entry comes from.
+type Source string
+
+// Registry entry sources.
+const (
+	// SourceBuiltIn marks a token built into sigil.
+	SourceBuiltIn Source = "built-in"
+	// SourceConfig marks a token added in config.yaml.
+	SourceConfig Source = "config"
+)
+
+// Token is an entry in the registry.
+type Token struct {
+	eth.TokenMetadata
+
+	Source Source
+
+	// Tracked tokens are listed in config.yaml and always shown in balances.
+	// Untracked built-in tokens are shown only when held.
+	Tracked bool
+}
+
+// All returns the built-in tokens followed by the configured ones.
+func All(configured []config.TokenConfig) []Token {
+	known := eth.KnownTokens()
+	all := make([]Token, 0, len(known)+len(configured))
+	for _, meta := range known {
+		token := Token{TokenMetadata: meta, Source: SourceBuiltIn}
+		if c, ok := findByAddress(configured, meta.Address); ok {
+			token.Tracked = true
+			token.FeeOnTransfer = c.FeeOnTransfer
+			token.Rebasing = c.Rebasing
+		}
+		all = append(all, token)
+	}
+	for _, c := range configured {
+		if _, builtIn := eth.LookupKnownToken(c.Address); builtIn {
+			continue
+		}
+		all = append(all, Token{TokenMetadata: metadata(c), Source: SourceConfig, Tracked: true})
+	}
+	return all
+}
+
+// Lookup finds a token by symbol (case-insensitive) or contract address,
+// checking the built-in tokens first.
+func Lookup(configured []config.TokenConfig, symbolOrAddress string) (*eth.TokenMetadata, bool) {
+	if meta, ok := eth.LookupKnownToken(symbolOrAddress); ok {
+		if c, found := findByAddress(configured, meta.Address); found {
+			meta.FeeOnTransfer = c.FeeOnTransfer
+			meta.Rebasing = c.Rebasing
+		}
+		return meta, true
+	}
+	if c, ok := config.FindETHToken(configured, symbolOrAddress); ok {
+		meta := metadata(c)
+		return &meta, true
+	}
+	return nil, false
+}
+
+// Validate checks a token before it is added to the configured list. The
+// contract must not be built in, and its symbol must not name a different
+// built-in or configured contract.
+func Validate(configured []config.TokenConfig, token config.TokenConfig) error {
+	if !eth.IsValidAddress(token.Address) {
+		return fmt.Errorf("%w: contract address %q is not a 0x-prefixed 20-byte address", ErrInvalidToken, token.Address)
+	}
+	if err := validateSymbol(token.Symbol); err != nil {
+		return err
+	}
+	if token.Decimals < 0 || token.Decimals > maxDecimals {
+		return fmt.Errorf("%w: decimals must be between 0 and %d", ErrInvalidToken, maxDecimals)
+	}
+
+	if known, ok := eth.LookupKnownToken(token.Address); ok {
+		return fmt.Errorf("%w: %s", ErrBuiltIn, known.Symbol)
+	}
+	if known, ok := eth.LookupKnownToken(token.Symbol); ok {
+		return fmt.Errorf("%w: %s is %s", ErrSymbolInUse, known.Symbol, known.Address)
+	}
+	for _, c := range configured {
+		if strings.EqualFold(c.Symbol, token.Symbol) && !strings.EqualFold(c.Address, token.Address) {
+			return fmt.Errorf("%w: %s is %s", ErrSymbolInUse, c.Symbol, c.Address)
+		}
+	}
+	return nil
+}
+
+// validateSymbol rejects empty, overlong, or non-printable symbols.
+func validateSymbol(symbol string) error {
+	if symbol == "" || len(symbol) > maxSymbolLen {
+		return fmt.Errorf("%w: symbol must be 1 to %d characters", ErrInvalidToken, maxSymbolLen)
+	}
+	for _, r := range symbol {
+		if !unicode.IsPrint(r) || unicode.IsSpace(r) {
+			return fmt.Errorf("%w: symbol %q contains spaces or control characters", ErrInvalidToken, symbol)
+		}
+	}
+	return nil
+}
+
+// findByAddress returns the configured token with the contract address.
+func findByAddress(configured []config.TokenConfig, address string) (config.TokenConfig, bool) {
+	for _, c := range configured {
+		if strings.EqualFold(c.Address, address) {
+			return c, true
+		}
+	}
+	return config.TokenConfig{}, false
+}
+
+// metadata converts a configured token to its metadata.
+func metadata(c config.TokenConfig) eth.TokenMetadata {
+	return eth.TokenMetadata{
+		Address:       c.Address,
+		Symbol:        c.Symbol,
+		Decimals:      c.Decimals,
+		FeeOnTransfer: c.FeeOnTransfer,
+		Rebasing:      c.Rebasing,
+	}
+}
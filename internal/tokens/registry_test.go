@@ -0,0 +1,108 @@
+package tokens
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/config"
+)
+
+const (
+	testUNIAddress = "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"
+	testDAIAddress = "0x6B175474E89094C44Da98b954EedeAC495271d0F"
+)
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	configured := []config.TokenConfig{
+		{Symbol: "DAI", Address: strings.ToLower(testDAIAddress), Decimals: 18, Rebasing: true},
+		{Symbol: "UNI", Address: testUNIAddress, Decimals: 18},
+	}
+	all := All(configured)
+	require.Len(t, all, len(eth.KnownTokens())+1)
+
+	byName := make(map[string]Token, len(all))
+	for _, token := range all {
+		byName[token.Symbol] = token
+	}
+
+	assert.Equal(t, SourceBuiltIn, byName["USDC"].Source)
+	assert.False(t, byName["USDC"].Tracked)
+
+	dai := byName["DAI"]
+	assert.Equal(t, SourceBuiltIn, dai.Source)
+	assert.True(t, dai.Tracked)
+	assert.True(t, dai.Rebasing)
+	assert.Equal(t, testDAIAddress, dai.Address, "built-in metadata wins over config")
+
+	uni := byName["UNI"]
+	assert.Equal(t, SourceConfig, uni.Source)
+	assert.True(t, uni.Tracked)
+	assert.Equal(t, "UNI", all[len(all)-1].Symbol, "configured tokens follow the built-ins")
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	configured := []config.TokenConfig{
+		{Symbol: "UNI", Address: testUNIAddress, Decimals: 18},
+		{Symbol: "USDT", Address: "0xdAC17F958D2ee523a2206206994597C13D831ec7", Decimals: 6, FeeOnTransfer: true},
+	}
+
+	meta, ok := Lookup(configured, "uni")
+	require.True(t, ok)
+	assert.Equal(t, testUNIAddress, meta.Address)
+
+	meta, ok = Lookup(configured, "usdt")
+	require.True(t, ok)
+	assert.True(t, meta.FeeOnTransfer, "config flags apply to built-in tokens")
+
+	meta, ok = Lookup(nil, "LINK")
+	require.True(t, ok)
+	assert.Equal(t, 18, meta.Decimals)
+
+	_, ok = Lookup(nil, "UNI")
+	assert.False(t, ok)
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	configured := []config.TokenConfig{{Symbol: "UNI", Address: testUNIAddress, Decimals: 18}}
+	other := "0x0000000000000000000000000000000000000001"
+
+	tests := []struct {
+		name    string
+		token   config.TokenConfig
+		wantErr error
+	}{
+		{name: "new token", token: config.TokenConfig{Symbol: "FOO", Address: other, Decimals: 9}},
+		{name: "same symbol, same contract", token: config.TokenConfig{Symbol: "uni", Address: strings.ToLower(testUNIAddress), Decimals: 18}},
+		{name: "bad address", token: config.TokenConfig{Symbol: "FOO", Address: "0x1234", Decimals: 9}, wantErr: ErrInvalidToken},
+		{name: "empty symbol", token: config.TokenConfig{Address: other, Decimals: 9}, wantErr: ErrInvalidToken},
+		{name: "symbol with space", token: config.TokenConfig{Symbol: "FOO BAR", Address: other, Decimals: 9}, wantErr: ErrInvalidToken},
+		{name: "symbol too long", token: config.TokenConfig{Symbol: strings.Repeat("A", maxSymbolLen+1), Address: other}, wantErr: ErrInvalidToken},
+		{name: "negative decimals", token: config.TokenConfig{Symbol: "FOO", Address: other, Decimals: -1}, wantErr: ErrInvalidToken},
+		{name: "decimals too large", token: config.TokenConfig{Symbol: "FOO", Address: other, Decimals: 256}, wantErr: ErrInvalidToken},
+		{name: "built-in contract", token: config.TokenConfig{Symbol: "MYDAI", Address: testDAIAddress, Decimals: 18}, wantErr: ErrBuiltIn},
+		{name: "built-in symbol", token: config.TokenConfig{Symbol: "usdc", Address: other, Decimals: 6}, wantErr: ErrSymbolInUse},
+		{name: "configured symbol", token: config.TokenConfig{Symbol: "UNI", Address: other, Decimals: 18}, wantErr: ErrSymbolInUse},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(configured, tc.token)
+			if tc.wantErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
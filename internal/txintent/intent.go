@@ -0,0 +1,315 @@
+// Package txintent keeps a per-wallet write-ahead log of UTXO sends.
+//
+// A send records an intent, holding the signed transaction and the inputs it
+// spends, before broadcasting, and clears it once the outcome is recorded in
+// the UTXO store and transaction journal. An intent that is still present
+// later means sigil stopped mid-send: the transaction may or may not have
+// reached the network. Its inputs stay reserved until the intent is resolved
+// by finding the transaction on chain, rebroadcasting it, or releasing it.
+//
+// An intent can also be queued: when no broadcast provider could be reached,
+// the send keeps its signed transaction in the log as the wallet's outbox,
+// to be broadcast once connectivity returns or dropped when it expires.
+package txintent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// fileName is the name of the intent log in the wallet directory.
+	fileName = "intents.json"
+
+	// currentVersion is the current file format version.
+	currentVersion = 1
+
+	// filePermissions for intents.json.
+	filePermissions = 0o600
+
+	// dirPermissions for the wallet directory when the log creates it.
+	dirPermissions = 0o700
+)
+
+var (
+	// ErrVersionTooNew is returned when intents.json is newer than supported.
+	ErrVersionTooNew = errors.New("intents.json version is newer than supported")
+
+	// ErrIntentNotFound is returned when no intent has the given hash.
+	ErrIntentNotFound = errors.New("send intent not found")
+)
+
+// Input is an outpoint an intent spends.
+type Input struct {
+	TxID    string `json:"txid"`
+	Vout    uint32 `json:"vout"`
+	Address string `json:"address"`
+	Amount  uint64 `json:"amount"`
+}
+
+// Key returns the "txid:vout" outpoint key.
+func (in Input) Key() string {
+	return OutpointKey(in.TxID, in.Vout)
+}
+
+// Intent is a signed send that may not have been broadcast. Amount and Fee
+// are in satoshis.
+type Intent struct {
+	Hash      string    `json:"hash"`
+	Chain     chain.ID  `json:"chain"`
+	Network   string    `json:"network,omitempty"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	Amount    string    `json:"amount"`
+	Fee       string    `json:"fee"`
+	Inputs    []Input   `json:"inputs"`
+	RawTx     string    `json:"raw_tx"` // hex-encoded signed transaction
+	CreatedAt time.Time `json:"created_at"`
+	// Agent is the ID of the agent credential that made the send, if any.
+	Agent string `json:"agent,omitempty"`
+
+	// QueuedAt is set when the send was queued in the outbox because no
+	// broadcast provider could be reached. ExpiresAt is when the outbox
+	// stops trying to broadcast it.
+	QueuedAt  time.Time `json:"queued_at,omitzero"`
+	ExpiresAt time.Time `json:"expires_at,omitzero"`
+	// Attempts counts the broadcasts tried, and LastError is why the latest
+	// one failed.
+	Attempts  int    `json:"attempts,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+}
+
+// Queued reports whether the intent is in the outbox.
+func (i *Intent) Queued() bool {
+	return !i.QueuedAt.IsZero()
+}
+
+// Expired reports whether a queued intent's expiry has passed at now.
+func (i *Intent) Expired(now time.Time) bool {
+	return i.Queued() && !i.ExpiresAt.IsZero() && !now.Before(i.ExpiresAt)
+}
+
+// File is the on-disk intent log format.
+type File struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Intents   []Intent  `json:"intents"`
+}
+
+// Log reads and writes a wallet's intents.json.
+type Log struct {
+	mu         sync.Mutex
+	walletPath string
+}
+
+// New creates an intent log for the wallet directory walletPath
+// (~/.sigil/wallets/<name>).
+func New(walletPath string) *Log {
+	return &Log{walletPath: walletPath}
+}
+
+// Path returns the intent log file path.
+func (l *Log) Path() string {
+	return filepath.Join(l.walletPath, fileName)
+}
+
+// Intents returns all intents, oldest first. A missing file yields none.
+func (l *Log) Intents() ([]Intent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	intents := file.Intents
+	sort.SliceStable(intents, func(a, b int) bool {
+		return intents[a].CreatedAt.Before(intents[b].CreatedAt)
+	})
+	return intents, nil
+}
+
+// Record durably adds an intent, replacing any existing intent with the same
+// hash. It returns only once the log is synced to disk.
+func (l *Log) Record(intent Intent) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := l.load()
+	if err != nil {
+		return err
+	}
+	if intent.CreatedAt.IsZero() {
+		intent.CreatedAt = time.Now().UTC()
+	}
+
+	for i := range file.Intents {
+		if strings.EqualFold(file.Intents[i].Hash, intent.Hash) {
+			file.Intents[i] = intent
+			return l.save(file)
+		}
+	}
+	file.Intents = append(file.Intents, intent)
+	return l.save(file)
+}
+
+// Get returns the intent with the given hash.
+func (l *Log) Get(hash string) (*Intent, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := l.load()
+	if err != nil {
+		return nil, err
+	}
+	for i := range file.Intents {
+		if strings.EqualFold(file.Intents[i].Hash, hash) {
+			return &file.Intents[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrIntentNotFound, hash)
+}
+
+// Clear removes the intent with the given hash. Clearing an unknown hash is
+// not an error. The file is removed once no intents remain.
+func (l *Log) Clear(hash string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := l.load()
+	if err != nil {
+		return err
+	}
+	kept := file.Intents[:0]
+	for _, intent := range file.Intents {
+		if !strings.EqualFold(intent.Hash, hash) {
+			kept = append(kept, intent)
+		}
+	}
+	if len(kept) == len(file.Intents) {
+		return nil
+	}
+	if len(kept) == 0 {
+		if err := os.Remove(l.Path()); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing %s: %w", fileName, err)
+		}
+		return nil
+	}
+	file.Intents = kept
+	return l.save(file)
+}
+
+// Reserved returns the outpoint keys ("txid:vout") spent by intents on the
+// chain. Selection must skip them until their intent is resolved.
+func Reserved(intents []Intent, chainID chain.ID) map[string]bool {
+	reserved := make(map[string]bool)
+	for _, intent := range intents {
+		if intent.Chain != chainID {
+			continue
+		}
+		for _, in := range intent.Inputs {
+			reserved[in.Key()] = true
+		}
+	}
+	return reserved
+}
+
+// Pending counts the interrupted sends in each wallet under walletsDir
+// (~/.sigil/wallets), keyed by wallet name. Queued intents are counted by
+// Outbox instead. Wallets without intents are left out, and unreadable logs
+// are counted as one intent so they are not silently ignored.
+func Pending(walletsDir string) (map[string]int, error) {
+	return count(walletsDir, true, func(intent *Intent) bool { return !intent.Queued() })
+}
+
+// Outbox counts the queued intents in each wallet under walletsDir, keyed
+// by wallet name. Unreadable logs are left to Pending.
+func Outbox(walletsDir string) (map[string]int, error) {
+	return count(walletsDir, false, (*Intent).Queued)
+}
+
+// count counts the intents matching keep in each wallet under walletsDir,
+// counting an unreadable log as one intent when countUnreadable is set.
+func count(walletsDir string, countUnreadable bool, keep func(*Intent) bool) (map[string]int, error) {
+	matches, err := filepath.Glob(filepath.Join(walletsDir, "*", fileName))
+	if err != nil {
+		return nil, fmt.Errorf("scanning for %s: %w", fileName, err)
+	}
+
+	counts := make(map[string]int)
+	for _, path := range matches {
+		walletPath := filepath.Dir(path)
+		intents, loadErr := New(walletPath).Intents()
+		if loadErr != nil {
+			if countUnreadable {
+				counts[filepath.Base(walletPath)] = 1
+			}
+			continue
+		}
+		n := 0
+		for i := range intents {
+			if keep(&intents[i]) {
+				n++
+			}
+		}
+		if n > 0 {
+			counts[filepath.Base(walletPath)] = n
+		}
+	}
+	return counts, nil
+}
+
+// OutpointKey returns the "txid:vout" key Reserved uses for an outpoint.
+func OutpointKey(txid string, vout uint32) string {
+	return strings.ToLower(txid) + ":" + strconv.FormatUint(uint64(vout), 10)
+}
+
+// load reads the log without locking. A missing file yields an empty one.
+func (l *Log) load() (*File, error) {
+	file := &File{Version: currentVersion}
+
+	data, err := os.ReadFile(l.Path())
+	if os.IsNotExist(err) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", fileName, err)
+	}
+
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", fileName, err)
+	}
+	if file.Version > currentVersion {
+		return nil, fmt.Errorf("%w: version %d (supported %d)", ErrVersionTooNew, file.Version, currentVersion)
+	}
+	return file, nil
+}
+
+// save writes the log atomically without locking.
+func (l *Log) save(file *File) error {
+	file.Version = currentVersion
+	file.UpdatedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling %s: %w", fileName, err)
+	}
+	if err := os.MkdirAll(l.walletPath, dirPermissions); err != nil {
+		return fmt.Errorf("creating wallet directory: %w", err)
+	}
+	if err := fileutil.WriteAtomic(l.Path(), data, filePermissions); err != nil {
+		return fmt.Errorf("writing %s: %w", fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package txintent
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func testIntent(hash string, inputs ...Input) Intent {
+	return Intent{Hash: hash, Chain: chain.BSV, From: "1From", To: "1To", Amount: "1000", Fee: "10", Inputs: inputs, RawTx: "00"}
+}
+
+func TestLog_RecordAndClear(t *testing.T) {
+	t.Parallel()
+
+	l := New(filepath.Join(t.TempDir(), "main"))
+
+	intents, err := l.Intents()
+	require.NoError(t, err)
+	assert.Empty(t, intents, "missing file is an empty log")
+
+	older := time.Now().Add(-time.Hour).UTC()
+	first := testIntent("aa")
+	first.CreatedAt = older
+	require.NoError(t, l.Record(testIntent("bb")))
+	require.NoError(t, l.Record(first))
+
+	intents, err = l.Intents()
+	require.NoError(t, err)
+	require.Len(t, intents, 2)
+	assert.Equal(t, "aa", intents[0].Hash, "oldest first")
+	assert.False(t, intents[1].CreatedAt.IsZero())
+
+	info, err := os.Stat(l.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(filePermissions), info.Mode().Perm())
+
+	got, err := l.Get("AA")
+	require.NoError(t, err)
+	assert.Equal(t, "1000", got.Amount)
+	_, err = l.Get("cc")
+	require.ErrorIs(t, err, ErrIntentNotFound)
+
+	require.NoError(t, l.Clear("cc"), "clearing an unknown hash is a no-op")
+	require.NoError(t, l.Clear("aa"))
+	intents, err = l.Intents()
+	require.NoError(t, err)
+	require.Len(t, intents, 1)
+
+	require.NoError(t, l.Clear("bb"))
+	_, err = os.Stat(l.Path())
+	assert.True(t, os.IsNotExist(err), "file is removed once empty")
+}
+
+func TestLog_LoadErrors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	l := New(dir)
+
+	require.NoError(t, os.WriteFile(l.Path(), []byte("{"), 0o600))
+	_, err := l.Intents()
+	require.Error(t, err)
+
+	require.NoError(t, os.WriteFile(l.Path(), []byte(`{"version":99}`), 0o600))
+	_, err = l.Intents()
+	require.ErrorIs(t, err, ErrVersionTooNew)
+}
+
+func TestReserved(t *testing.T) {
+	t.Parallel()
+
+	btcIntent := testIntent("cc", Input{TxID: "03", Vout: 0})
+	btcIntent.Chain = chain.BTC
+	intents := []Intent{
+		testIntent("aa", Input{TxID: "01", Vout: 0}, Input{TxID: "01", Vout: 2}),
+		testIntent("bb", Input{TxID: "02", Vout: 1}),
+		btcIntent,
+	}
+
+	reserved := Reserved(intents, chain.BSV)
+	assert.Len(t, reserved, 3)
+	assert.True(t, reserved[OutpointKey("01", 2)])
+	assert.True(t, reserved[OutpointKey("02", 1)])
+	assert.False(t, reserved[OutpointKey("03", 0)], "other chains are not reserved")
+}
+
+func TestPending(t *testing.T) {
+	t.Parallel()
+
+	walletsDir := t.TempDir()
+	require.NoError(t, New(filepath.Join(walletsDir, "main")).Record(testIntent("aa")))
+	require.NoError(t, New(filepath.Join(walletsDir, "main")).Record(testIntent("bb")))
+	require.NoError(t, os.MkdirAll(filepath.Join(walletsDir, "empty"), 0o700))
+	require.NoError(t, os.MkdirAll(filepath.Join(walletsDir, "broken"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(walletsDir, "broken", fileName), []byte("{"), 0o600))
+
+	queued := testIntent("cc")
+	queued.QueuedAt = time.Now().UTC()
+	require.NoError(t, New(filepath.Join(walletsDir, "main")).Record(queued))
+	require.NoError(t, New(filepath.Join(walletsDir, "offline")).Record(queued))
+
+	counts, err := Pending(walletsDir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"main": 2, "broken": 1}, counts)
+
+	counts, err = Outbox(walletsDir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"main": 1, "offline": 1}, counts)
+}
+
+func TestIntent_Expired(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now().UTC()
+	intent := testIntent("aa")
+	assert.False(t, intent.Queued())
+	assert.False(t, intent.Expired(now), "an interrupted send never expires")
+
+	intent.QueuedAt, intent.ExpiresAt = now.Add(-time.Hour), now.Add(time.Hour)
+	assert.True(t, intent.Queued())
+	assert.False(t, intent.Expired(now))
+	assert.True(t, intent.Expired(now.Add(time.Hour)))
+}
@@ -0,0 +1,418 @@
+// Package txjournal keeps a per-wallet log of broadcast transactions.
+//
+// Every send is recorded as pending when it is broadcast and moved to
+// confirmed or reverted once the chain reports it. Pending entries are the
+// wallet's outgoing amounts that the chain may not reflect yet.
+package txjournal
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+const (
+	// fileName is the name of the journal file in the wallet directory.
+	fileName = "txhistory.json"
+
+	// currentVersion is the current file format version.
+	currentVersion = 1
+
+	// filePermissions for txhistory.json.
+	filePermissions = 0o600
+
+	// dirPermissions for the wallet directory when the journal creates it.
+	dirPermissions = 0o700
+)
+
+// Transaction statuses.
+const (
+	StatusPending   = "pending"
+	StatusConfirmed = "confirmed"
+	StatusReverted  = "reverted"
+)
+
+// Transaction directions relative to the wallet.
+const (
+	DirectionSent     = "sent"
+	DirectionReceived = "received"
+
+	// DirectionInternal is how listings and exports show a send with
+	// Internal set; the entry itself keeps DirectionSent.
+	DirectionInternal = "internal"
+)
+
+var (
+	// ErrVersionTooNew is returned when txhistory.json is newer than supported.
+	ErrVersionTooNew = errors.New("txhistory.json version is newer than supported")
+
+	// ErrEntryNotFound is returned when no entry has the given hash.
+	ErrEntryNotFound = errors.New("transaction not found in journal")
+)
+
+// Entry is one broadcast transaction. Amount and Fee are in the smallest
+// unit: satoshis, wei, or token base units for Amount when Token is set.
+type Entry struct {
+	Hash     string   `json:"hash"`
+	Chain    chain.ID `json:"chain"`
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Amount   string   `json:"amount"`
+	Token    string   `json:"token,omitempty"`
+	Symbol   string   `json:"symbol"`
+	Decimals int      `json:"decimals"`
+	Fee      string   `json:"fee"`
+	Status   string   `json:"status"`
+
+	// Direction is DirectionSent or DirectionReceived, or empty when a
+	// backfilled entry does not say. Entries written before directions
+	// were recorded are all sends.
+	Direction string `json:"direction,omitempty"`
+	// Internal marks a send whose recipients are all addresses of the same
+	// wallet, such as a consolidation. Only its fee leaves the wallet.
+	Internal bool `json:"internal,omitempty"`
+	// Backfilled marks entries imported from a block explorer rather than
+	// recorded when sigil broadcast them.
+	Backfilled bool `json:"backfilled,omitempty"`
+	// Agent is the ID of the agent token that made the send, empty for
+	// sends by the wallet owner.
+	Agent string `json:"agent,omitempty"`
+
+	BlockNumber uint64    `json:"block_number,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	ConfirmedAt time.Time `json:"confirmed_at,omitempty"`
+}
+
+// Pending reports whether the entry is still awaiting confirmation.
+func (e *Entry) Pending() bool {
+	return e.Status == StatusPending
+}
+
+// Sent reports whether the entry is a send from the wallet. Entries without
+// a direction are sends unless they were backfilled.
+func (e *Entry) Sent() bool {
+	return e.Direction == DirectionSent || (e.Direction == "" && !e.Backfilled)
+}
+
+// File is the on-disk journal format.
+type File struct {
+	Version   int       `json:"version"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Entries   []Entry   `json:"entries"`
+}
+
+// Journal reads and writes a wallet's txhistory.json.
+type Journal struct {
+	mu         sync.Mutex
+	walletPath string
+}
+
+// New creates a journal for the wallet directory walletPath
+// (~/.sigil/wallets/<name>).
+func New(walletPath string) *Journal {
+	return &Journal{walletPath: walletPath}
+}
+
+// Path returns the journal file path.
+func (j *Journal) Path() string {
+	return filepath.Join(j.walletPath, fileName)
+}
+
+// Entries returns all entries, newest first. A missing file yields none.
+func (j *Journal) Entries() ([]Entry, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := j.load()
+	if err != nil {
+		return nil, err
+	}
+	entries := file.Entries
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].CreatedAt.After(entries[b].CreatedAt)
+	})
+	return entries, nil
+}
+
+// Record adds an entry, replacing any existing entry with the same hash.
+func (j *Journal) Record(entry Entry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := j.load()
+	if err != nil {
+		return err
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	if entry.Status == "" {
+		entry.Status = StatusPending
+	}
+
+	for i := range file.Entries {
+		if strings.EqualFold(file.Entries[i].Hash, entry.Hash) {
+			file.Entries[i] = entry
+			return j.save(file)
+		}
+	}
+	file.Entries = append(file.Entries, entry)
+	return j.save(file)
+}
+
+// Merge adds entries whose hash is not in the journal yet and returns how
+// many were added. Existing entries, including sends recorded at broadcast,
+// are left as they are.
+func (j *Journal) Merge(entries []Entry) (int, error) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := j.load()
+	if err != nil {
+		return 0, err
+	}
+
+	known := make(map[string]bool, len(file.Entries))
+	for _, e := range file.Entries {
+		known[strings.ToLower(e.Hash)] = true
+	}
+
+	added := 0
+	for _, entry := range entries {
+		key := strings.ToLower(entry.Hash)
+		if entry.Hash == "" || known[key] {
+			continue
+		}
+		known[key] = true
+		if entry.Status == "" {
+			entry.Status = StatusPending
+		}
+		file.Entries = append(file.Entries, entry)
+		added++
+	}
+	if added == 0 {
+		return 0, nil
+	}
+	return added, j.save(file)
+}
+
+// Update applies fn to the entry with the given hash and saves the result.
+func (j *Journal) Update(hash string, fn func(*Entry)) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	file, err := j.load()
+	if err != nil {
+		return err
+	}
+	for i := range file.Entries {
+		if strings.EqualFold(file.Entries[i].Hash, hash) {
+			fn(&file.Entries[i])
+			return j.save(file)
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrEntryNotFound, hash)
+}
+
+// StatusChecker reports the on-chain status of a transaction: StatusPending
+// while unmined, otherwise StatusConfirmed or StatusReverted and its block.
+type StatusChecker interface {
+	TxStatus(ctx context.Context, hash string) (status string, block uint64, err error)
+}
+
+// Reconcile asks the checker for each chain about its pending entries and
+// records any that have confirmed or reverted. Chains without a checker are
+// left alone, and lookup failures leave the entry pending for the next run.
+// Returns the number of entries updated.
+func (j *Journal) Reconcile(ctx context.Context, checkers map[chain.ID]StatusChecker) (int, error) {
+	entries, err := j.Entries()
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, e := range entries {
+		if !e.Pending() {
+			continue
+		}
+		checker, ok := checkers[e.Chain]
+		if !ok {
+			continue
+		}
+		status, block, checkErr := checker.TxStatus(ctx, e.Hash)
+		if checkErr != nil || status == StatusPending {
+			continue
+		}
+		if err := j.Update(e.Hash, func(entry *Entry) {
+			entry.Status = status
+			entry.BlockNumber = block
+			entry.ConfirmedAt = time.Now().UTC()
+		}); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// Reserved sums what pending entries will take from an address: the amount
+// of the given asset (token contract, or "" for the native coin), plus the
+// fees when the asset is native. The count is the number of entries included.
+func Reserved(entries []Entry, chainID chain.ID, address, token string) (*big.Int, int) {
+	total := new(big.Int)
+	count := 0
+
+	for i := range entries {
+		e := &entries[i]
+		if !e.Pending() || e.Chain != chainID || !strings.EqualFold(e.From, address) {
+			continue
+		}
+
+		included := false
+		if strings.EqualFold(e.Token, token) {
+			addUnits(total, e.Amount)
+			included = true
+		}
+		if token == "" {
+			addUnits(total, e.Fee)
+			included = true
+		}
+		if included {
+			count++
+		}
+	}
+	return total, count
+}
+
+// Spending summarizes the sends recorded from one address.
+type Spending struct {
+	// Sends is the number of outgoing transactions, excluding reverted ones.
+	Sends int
+	// Native is the native coin spent in the smallest unit: the amounts of
+	// native sends plus the fees of every send.
+	Native *big.Int
+	// Tokens holds the token amounts sent, one per token contract, sorted
+	// by symbol.
+	Tokens []TokenSpend
+	// LastDay and LastWeek count the sends made in the 24 hours and 7 days
+	// before the time the summary was taken.
+	LastDay  int
+	LastWeek int
+	// LastSent is when the newest send was recorded, zero if none.
+	LastSent time.Time
+}
+
+// TokenSpend is the total of one token sent from an address, in base units.
+type TokenSpend struct {
+	Token    string
+	Symbol   string
+	Decimals int
+	Amount   *big.Int
+}
+
+// SpendingFrom summarizes the sends from an address on chainID, counting
+// the sends in the day and week before now. Reverted entries spent nothing
+// and are skipped.
+func SpendingFrom(entries []Entry, chainID chain.ID, address string, now time.Time) *Spending {
+	s := &Spending{Native: new(big.Int)}
+	tokens := make(map[string]*TokenSpend)
+
+	for i := range entries {
+		e := &entries[i]
+		if !e.Sent() || e.Status == StatusReverted || e.Chain != chainID || !strings.EqualFold(e.From, address) {
+			continue
+		}
+
+		s.Sends++
+		addUnits(s.Native, e.Fee)
+		if e.Token == "" {
+			addUnits(s.Native, e.Amount)
+		} else {
+			key := strings.ToLower(e.Token)
+			t, ok := tokens[key]
+			if !ok {
+				t = &TokenSpend{Token: e.Token, Symbol: e.Symbol, Decimals: e.Decimals, Amount: new(big.Int)}
+				tokens[key] = t
+			}
+			addUnits(t.Amount, e.Amount)
+		}
+
+		if e.CreatedAt.After(s.LastSent) {
+			s.LastSent = e.CreatedAt
+		}
+		if age := now.Sub(e.CreatedAt); age < 7*24*time.Hour {
+			s.LastWeek++
+			if age < 24*time.Hour {
+				s.LastDay++
+			}
+		}
+	}
+
+	for _, t := range tokens {
+		s.Tokens = append(s.Tokens, *t)
+	}
+	sort.Slice(s.Tokens, func(a, b int) bool {
+		if s.Tokens[a].Symbol != s.Tokens[b].Symbol {
+			return s.Tokens[a].Symbol < s.Tokens[b].Symbol
+		}
+		return s.Tokens[a].Token < s.Tokens[b].Token
+	})
+	return s
+}
+
+// addUnits adds a base-10 integer string to total, ignoring malformed values.
+func addUnits(total *big.Int, units string) {
+	if n, ok := new(big.Int).SetString(units, 10); ok {
+		total.Add(total, n)
+	}
+}
+
+// load reads the journal without locking. A missing file yields an empty one.
+func (j *Journal) load() (*File, error) {
+	file := &File{Version: currentVersion}
+
+	data, err := os.ReadFile(j.Path())
+	if os.IsNotExist(err) {
+		return file, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", fileName, err)
+	}
+
+	if err := json.Unmarshal(data, file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", fileName, err)
+	}
+	if file.Version > currentVersion {
+		return nil, fmt.Errorf("%w: version %d (supported %d)", ErrVersionTooNew, file.Version, currentVersion)
+	}
+	return file, nil
+}
+
+// save writes the journal atomically without locking.
+func (j *Journal) save(file *File) error {
+	file.Version = currentVersion
+	file.UpdatedAt = time.Now().UTC()
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling %s: %w", fileName, err)
+	}
+	if err := os.MkdirAll(j.walletPath, dirPermissions); err != nil {
+		return fmt.Errorf("creating wallet directory: %w", err)
+	}
+	if err := fileutil.WriteAtomic(j.Path(), data, filePermissions); err != nil {
+		return fmt.Errorf("writing %s: %w", fileName, err)
+	}
+	return nil
+}
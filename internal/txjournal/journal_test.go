@@ -0,0 +1,235 @@
+package txjournal
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+const (
+	testFrom  = "0x742d35Cc6634C0532925a3b844Bc454e4438f44e"
+	testToken = "0xA0b86991c6218b36c1d19D4a2e9Eb0cE3606eB48"
+)
+
+func TestJournal_RecordAndEntries(t *testing.T) {
+	t.Parallel()
+
+	j := New(filepath.Join(t.TempDir(), "main"))
+
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	assert.Empty(t, entries, "missing file is an empty journal")
+
+	older := time.Now().Add(-time.Hour).UTC()
+	require.NoError(t, j.Record(Entry{Hash: "0x01", Chain: chain.ETH, Amount: "1", CreatedAt: older}))
+	require.NoError(t, j.Record(Entry{Hash: "0x02", Chain: chain.ETH, Amount: "2"}))
+
+	entries, err = j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "0x02", entries[0].Hash, "newest first")
+	assert.Equal(t, StatusPending, entries[0].Status, "status defaults to pending")
+	assert.False(t, entries[0].CreatedAt.IsZero())
+
+	// Recording the same hash replaces the entry
+	require.NoError(t, j.Record(Entry{Hash: "0X01", Chain: chain.ETH, Amount: "5", CreatedAt: older}))
+	entries, err = j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "5", entries[1].Amount)
+
+	info, err := os.Stat(j.Path())
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(filePermissions), info.Mode().Perm())
+}
+
+func TestJournal_Update(t *testing.T) {
+	t.Parallel()
+
+	j := New(t.TempDir())
+	require.NoError(t, j.Record(Entry{Hash: "0x01", Chain: chain.ETH}))
+
+	require.NoError(t, j.Update("0x01", func(e *Entry) { e.Status = StatusConfirmed }))
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	assert.Equal(t, StatusConfirmed, entries[0].Status)
+
+	err = j.Update("0x99", func(*Entry) {})
+	require.ErrorIs(t, err, ErrEntryNotFound)
+}
+
+func TestJournal_Merge(t *testing.T) {
+	t.Parallel()
+
+	j := New(t.TempDir())
+	require.NoError(t, j.Record(Entry{Hash: "0xAA", Chain: chain.ETH, Amount: "7", Direction: DirectionSent}))
+
+	added, err := j.Merge([]Entry{
+		{Hash: "0xaa", Chain: chain.ETH, Amount: "1", Status: StatusConfirmed, Backfilled: true},
+		{Hash: "0xbb", Chain: chain.ETH, Amount: "2", Status: StatusConfirmed, Backfilled: true},
+		{Hash: "0xbb", Chain: chain.ETH, Amount: "2", Status: StatusConfirmed, Backfilled: true},
+		{Hash: "", Chain: chain.ETH},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	for _, e := range entries {
+		if e.Hash == "0xAA" {
+			assert.Equal(t, "7", e.Amount, "recorded send is kept")
+			assert.False(t, e.Backfilled)
+		}
+	}
+
+	added, err = j.Merge([]Entry{{Hash: "0xbb"}})
+	require.NoError(t, err)
+	assert.Zero(t, added)
+}
+
+func TestJournal_LoadErrors(t *testing.T) {
+	t.Parallel()
+
+	t.Run("corrupt", func(t *testing.T) {
+		t.Parallel()
+		j := New(t.TempDir())
+		require.NoError(t, os.WriteFile(j.Path(), []byte("{not json"), 0o600))
+		_, err := j.Entries()
+		require.Error(t, err)
+		require.Error(t, j.Record(Entry{Hash: "0x01"}), "a corrupt journal is not overwritten")
+	})
+
+	t.Run("version too new", func(t *testing.T) {
+		t.Parallel()
+		j := New(t.TempDir())
+		require.NoError(t, os.WriteFile(j.Path(), []byte(`{"version": 99, "entries": []}`), 0o600))
+		_, err := j.Entries()
+		require.ErrorIs(t, err, ErrVersionTooNew)
+	})
+}
+
+// fakeChecker returns a fixed status per hash.
+type fakeChecker struct {
+	statuses map[string]string
+	err      error
+}
+
+func (f *fakeChecker) TxStatus(_ context.Context, hash string) (string, uint64, error) {
+	if f.err != nil {
+		return "", 0, f.err
+	}
+	return f.statuses[hash], 100, nil
+}
+
+func TestJournal_Reconcile(t *testing.T) {
+	t.Parallel()
+
+	j := New(t.TempDir())
+	require.NoError(t, j.Record(Entry{Hash: "mined", Chain: chain.ETH}))
+	require.NoError(t, j.Record(Entry{Hash: "reverted", Chain: chain.ETH}))
+	require.NoError(t, j.Record(Entry{Hash: "waiting", Chain: chain.ETH}))
+	require.NoError(t, j.Record(Entry{Hash: "bsv", Chain: chain.BSV}))
+
+	checkers := map[chain.ID]StatusChecker{
+		chain.ETH: &fakeChecker{statuses: map[string]string{
+			"mined":    StatusConfirmed,
+			"reverted": StatusReverted,
+			"waiting":  StatusPending,
+		}},
+	}
+	updated, err := j.Reconcile(context.Background(), checkers)
+	require.NoError(t, err)
+	assert.Equal(t, 2, updated)
+
+	byHash := make(map[string]Entry)
+	entries, err := j.Entries()
+	require.NoError(t, err)
+	for _, e := range entries {
+		byHash[e.Hash] = e
+	}
+	assert.Equal(t, StatusConfirmed, byHash["mined"].Status)
+	assert.Equal(t, uint64(100), byHash["mined"].BlockNumber)
+	assert.False(t, byHash["mined"].ConfirmedAt.IsZero())
+	assert.Equal(t, StatusReverted, byHash["reverted"].Status)
+	assert.Equal(t, StatusPending, byHash["waiting"].Status)
+	assert.Equal(t, StatusPending, byHash["bsv"].Status, "no checker for the chain")
+
+	// Lookup failures leave entries pending
+	updated, err = j.Reconcile(context.Background(), map[chain.ID]StatusChecker{chain.BSV: &fakeChecker{err: errors.New("offline")}})
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+}
+
+func TestReserved(t *testing.T) {
+	t.Parallel()
+
+	entries := []Entry{
+		{Hash: "a", Chain: chain.ETH, From: testFrom, Amount: "1000", Fee: "21", Status: StatusPending},
+		{Hash: "b", Chain: chain.ETH, From: testFrom, Amount: "500", Token: testToken, Fee: "65", Status: StatusPending},
+		{Hash: "c", Chain: chain.ETH, From: testFrom, Amount: "9999", Fee: "1", Status: StatusConfirmed},
+		{Hash: "d", Chain: chain.ETH, From: "0x0000000000000000000000000000000000000001", Amount: "7", Fee: "1", Status: StatusPending},
+		{Hash: "e", Chain: chain.BSV, From: testFrom, Amount: "3", Fee: "1", Status: StatusPending},
+	}
+
+	tests := []struct {
+		name      string
+		address   string
+		token     string
+		want      int64
+		wantCount int
+	}{
+		{name: "native includes token fees", address: testFrom, want: 1000 + 21 + 65, wantCount: 2},
+		{name: "case-insensitive address", address: "0x742d35cc6634c0532925a3b844bc454e4438f44e", want: 1086, wantCount: 2},
+		{name: "token amount only", address: testFrom, token: "0xa0b86991c6218b36c1d19d4a2e9eb0ce3606eb48", want: 500, wantCount: 1},
+		{name: "no pending", address: "0x0000000000000000000000000000000000000002", want: 0, wantCount: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got, count := Reserved(entries, chain.ETH, tc.address, tc.token)
+			assert.Equal(t, big.NewInt(tc.want), got)
+			assert.Equal(t, tc.wantCount, count)
+		})
+	}
+}
+
+func TestSpendingFrom(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	entries := []Entry{
+		{Hash: "a", Chain: chain.ETH, From: testFrom, Amount: "1000", Fee: "21", Status: StatusConfirmed, CreatedAt: now.Add(-time.Hour)},
+		{Hash: "b", Chain: chain.ETH, From: testFrom, Amount: "500", Token: testToken, Symbol: "USDC", Decimals: 6, Fee: "65", Status: StatusPending, CreatedAt: now.Add(-48 * time.Hour)},
+		{Hash: "c", Chain: chain.ETH, From: testFrom, Amount: "250", Token: testToken, Symbol: "USDC", Decimals: 6, Fee: "5", CreatedAt: now.Add(-30 * 24 * time.Hour)},
+		{Hash: "d", Chain: chain.ETH, From: testFrom, Amount: "9999", Fee: "1", Status: StatusReverted, CreatedAt: now},
+		{Hash: "e", Chain: chain.ETH, From: testFrom, Amount: "7", Direction: DirectionReceived, CreatedAt: now},
+		{Hash: "f", Chain: chain.ETH, From: testFrom, Amount: "7", Backfilled: true, CreatedAt: now},
+		{Hash: "g", Chain: chain.BSV, From: testFrom, Amount: "3", Fee: "1", CreatedAt: now},
+	}
+
+	s := SpendingFrom(entries, chain.ETH, "0x742d35cc6634c0532925a3b844bc454e4438f44e", now)
+	assert.Equal(t, 3, s.Sends, "reverted, received and undirected backfilled entries are skipped")
+	assert.Equal(t, big.NewInt(1000+21+65+5), s.Native)
+	require.Len(t, s.Tokens, 1)
+	assert.Equal(t, "USDC", s.Tokens[0].Symbol)
+	assert.Equal(t, big.NewInt(750), s.Tokens[0].Amount)
+	assert.Equal(t, 1, s.LastDay)
+	assert.Equal(t, 2, s.LastWeek)
+	assert.Equal(t, now.Add(-time.Hour), s.LastSent)
+
+	none := SpendingFrom(entries, chain.ETH, "0x0000000000000000000000000000000000000002", now)
+	assert.Zero(t, none.Sends)
+	assert.Zero(t, none.Native.Sign())
+	assert.True(t, none.LastSent.IsZero())
+}
@@ -0,0 +1,207 @@
+package utxostore
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/fileutil"
+)
+
+// archiveFilePattern names the archive of the UTXOs spent in one year.
+const archiveFilePattern = "utxos-archive-%d.json"
+
+// ArchiveFile is the JSON structure of one year's archive of spent UTXOs.
+type ArchiveFile struct {
+	Version   int                    `json:"version"`
+	Year      int                    `json:"year"`
+	UpdatedAt time.Time              `json:"updated_at"`
+	UTXOs     map[string]*StoredUTXO `json:"utxos"` // key: chainID:txid:vout
+}
+
+// ArchiveResult reports what Archive moved out of the store.
+type ArchiveResult struct {
+	// Archived is the number of spent UTXOs moved to archive files.
+	Archived int `json:"archived"`
+	// Years lists the archive years written, in ascending order.
+	Years []int `json:"years,omitempty"`
+}
+
+// Archive moves UTXOs marked spent before cutoff out of utxos.json into
+// per-year archive files, keeping the store that sends read small. A UTXO
+// belongs to the year it was marked spent in, which is its LastUpdated time.
+//
+// The archives are written before utxos.json, so an interrupted archive
+// leaves a UTXO in both files rather than in neither; ListHistory reports it
+// once.
+func (s *Store) Archive(cutoff time.Time) (*ArchiveResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	byYear := map[int][]*StoredUTXO{}
+	for _, utxo := range s.data.UTXOs {
+		if utxo.Spent && utxo.LastUpdated.Before(cutoff) {
+			year := utxo.LastUpdated.UTC().Year()
+			byYear[year] = append(byYear[year], utxo)
+		}
+	}
+
+	result := &ArchiveResult{}
+	if len(byYear) == 0 {
+		return result, nil
+	}
+
+	for year, utxos := range byYear {
+		if err := s.appendArchive(year, utxos); err != nil {
+			return nil, err
+		}
+		result.Years = append(result.Years, year)
+	}
+	sort.Ints(result.Years)
+
+	for _, utxos := range byYear {
+		for _, utxo := range utxos {
+			delete(s.data.UTXOs, utxo.Key())
+			result.Archived++
+		}
+	}
+	if err := s.saveUnlocked(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListArchived returns the archived UTXOs for a chain that match f. Archive
+// files are read on every call; the store never keeps them in memory.
+func (s *Store) ListArchived(chainID chain.ID, f Filter) ([]*StoredUTXO, error) {
+	archives, err := s.loadArchives()
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*StoredUTXO
+	for _, archive := range archives {
+		for _, utxo := range archive.UTXOs {
+			if utxo.ChainID == chainID && f.matches(utxo) {
+				result = append(result, utxo)
+			}
+		}
+	}
+	sortUTXOs(result)
+	return result, nil
+}
+
+// ListHistory is List for history queries: when f includes spent UTXOs, the
+// archived ones are added to those in the store.
+func (s *Store) ListHistory(chainID chain.ID, f Filter) ([]*StoredUTXO, error) {
+	result := s.List(chainID, f)
+	if !f.IncludeSpent {
+		return result, nil
+	}
+
+	archived, err := s.ListArchived(chainID, f)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(result))
+	for _, utxo := range result {
+		seen[utxo.Key()] = true
+	}
+	for _, utxo := range archived {
+		if !seen[utxo.Key()] {
+			result = append(result, utxo)
+		}
+	}
+	sortUTXOs(result)
+	return result, nil
+}
+
+// appendArchive adds utxos to the archive file of year.
+// Caller must hold s.mu.Lock().
+func (s *Store) appendArchive(year int, utxos []*StoredUTXO) error {
+	path := s.archivePath(year)
+	archive, err := readArchive(path)
+	if err != nil {
+		return err
+	}
+	if archive == nil {
+		archive = &ArchiveFile{Year: year, UTXOs: make(map[string]*StoredUTXO)}
+	}
+	for _, utxo := range utxos {
+		archive.UTXOs[utxo.Key()] = utxo
+	}
+	archive.Version = CurrentVersion
+	archive.UpdatedAt = time.Now()
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling utxo archive: %w", err)
+	}
+	if err := os.MkdirAll(s.walletPath, 0o750); err != nil {
+		return fmt.Errorf("creating utxo directory: %w", err)
+	}
+	if err := fileutil.WriteAtomic(path, data, filePermissions); err != nil {
+		return fmt.Errorf("writing %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
+
+// loadArchives reads every archive file of the wallet, oldest year first.
+func (s *Store) loadArchives() ([]*ArchiveFile, error) {
+	paths, err := filepath.Glob(filepath.Join(s.walletPath, "utxos-archive-*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("listing utxo archives: %w", err)
+	}
+	sort.Strings(paths)
+
+	archives := make([]*ArchiveFile, 0, len(paths))
+	for _, path := range paths {
+		archive, err := readArchive(path)
+		if err != nil {
+			return nil, err
+		}
+		if archive != nil {
+			archives = append(archives, archive)
+		}
+	}
+	return archives, nil
+}
+
+// readArchive reads an archive file, returning nil if it does not exist.
+func readArchive(path string) (*ArchiveFile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is built from the wallet directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil //nolint:nilnil // a missing archive is not an error
+		}
+		return nil, fmt.Errorf("reading %s: %w", filepath.Base(path), err)
+	}
+
+	var archive ArchiveFile
+	if err := json.Unmarshal(data, &archive); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Base(path), err)
+	}
+	if archive.Version > CurrentVersion {
+		return nil, fmt.Errorf("%w: %s version %d (supported %d)", ErrVersionTooNew, filepath.Base(path), archive.Version, CurrentVersion)
+	}
+	if archive.UTXOs == nil {
+		archive.UTXOs = make(map[string]*StoredUTXO)
+	}
+	return &archive, nil
+}
+
+// ArchiveFileName returns the name of the archive file of the UTXOs spent
+// in year.
+func ArchiveFileName(year int) string {
+	return fmt.Sprintf(archiveFilePattern, year)
+}
+
+// archivePath returns the full path to the archive file of year.
+func (s *Store) archivePath(year int) string {
+	return filepath.Join(s.walletPath, ArchiveFileName(year))
+}
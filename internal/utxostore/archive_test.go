@@ -0,0 +1,102 @@
+package utxostore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// archiveTestKey returns the store key of output 0 of testTxID(n).
+func archiveTestKey(n int) string {
+	return (&StoredUTXO{ChainID: chain.BSV, TxID: testTxID(n)}).Key()
+}
+
+func TestStore_Archive(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	store := New(tmpDir)
+
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	spentAt := map[int]time.Time{
+		1: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		2: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC),
+		3: now.AddDate(0, 0, -10), // too recent
+	}
+	for i := 1; i <= 4; i++ {
+		store.AddUTXO(createTestUTXO(chain.BSV, "1TestAddress", testTxID(i), 0, uint64(i)*1000, false)) //nolint:gosec // G115: test amount is small
+	}
+	for i, at := range spentAt {
+		require.True(t, store.MarkSpent(chain.BSV, testTxID(i), 0, testTxID(100+i)))
+		store.data.UTXOs[archiveTestKey(i)].LastUpdated = at
+	}
+	require.NoError(t, store.Save())
+
+	result, err := store.Archive(now.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Archived)
+	assert.Equal(t, []int{2024, 2025}, result.Years)
+	assert.FileExists(t, filepath.Join(tmpDir, "utxos-archive-2024.json"))
+	assert.FileExists(t, filepath.Join(tmpDir, "utxos-archive-2025.json"))
+	info, err := os.Stat(filepath.Join(tmpDir, "utxos-archive-2024.json"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(filePermissions), info.Mode().Perm())
+
+	// The hot store keeps the unspent and recently spent UTXOs
+	reloaded := New(tmpDir)
+	require.NoError(t, reloaded.Load())
+	assert.Len(t, reloaded.List(chain.BSV, Filter{IncludeSpent: true}), 2)
+	assert.Equal(t, uint64(4000), reloaded.GetBalance(chain.BSV))
+
+	// History queries read the archives on demand
+	history, err := reloaded.ListHistory(chain.BSV, Filter{IncludeSpent: true})
+	require.NoError(t, err)
+	require.Len(t, history, 4)
+	assert.Equal(t, testTxID(4), history[0].TxID, "largest first")
+	assert.Equal(t, testTxID(101), history[3].SpentTxID)
+
+	unspent, err := reloaded.ListHistory(chain.BSV, Filter{})
+	require.NoError(t, err)
+	assert.Len(t, unspent, 1, "archives only hold spent UTXOs")
+
+	// Archiving again appends to the year's file
+	require.True(t, reloaded.MarkSpent(chain.BSV, testTxID(4), 0, testTxID(104)))
+	reloaded.data.UTXOs[archiveTestKey(4)].LastUpdated = time.Date(2025, 9, 1, 0, 0, 0, 0, time.UTC)
+	result, err = reloaded.Archive(now.AddDate(0, 0, -30))
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Archived)
+	archived, err := reloaded.ListArchived(chain.BSV, Filter{IncludeSpent: true})
+	require.NoError(t, err)
+	assert.Len(t, archived, 3)
+}
+
+func TestStore_ListHistory_InterruptedArchive(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	store := New(tmpDir)
+
+	utxo := createTestUTXO(chain.BSV, "1TestAddress", testTxID(1), 0, 1000, false)
+	store.AddUTXO(utxo)
+	require.True(t, store.MarkSpent(chain.BSV, utxo.TxID, 0, testTxID(2)))
+	utxo.LastUpdated = time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	// An archive written before utxos.json was saved leaves the UTXO in both
+	require.NoError(t, store.appendArchive(2024, []*StoredUTXO{utxo}))
+	history, err := store.ListHistory(chain.BSV, Filter{IncludeSpent: true})
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
+
+func TestStore_ListArchived_VersionTooNew(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(tmpDir, ArchiveFileName(2024)), []byte(`{"version":99,"utxos":{}}`), 0o600))
+
+	_, err := New(tmpDir).ListArchived(chain.BSV, Filter{IncludeSpent: true})
+	require.ErrorIs(t, err, ErrVersionTooNew)
+}
@@ -0,0 +1,190 @@
+package utxostore
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// checkpointInterval is how many addresses are scanned between checkpoint saves.
+const checkpointInterval = 10
+
+// ScanCheckpoint records how far an unfinished wallet scan got. UTXOs found
+// before the checkpoint are already in the store, so the next ScanWallet or
+// ScanWalletBulk call resumes from NextIndex instead of starting over.
+type ScanCheckpoint struct {
+	// NextIndex is the position in the wallet's address list to resume from.
+	NextIndex int `json:"next_index"`
+
+	// LastAddress is the address at NextIndex-1. A checkpoint whose
+	// LastAddress no longer matches the wallet is discarded.
+	LastAddress string `json:"last_address"`
+
+	// ConsecutiveEmpty is the gap limit counter at the checkpoint.
+	ConsecutiveEmpty int `json:"consecutive_empty"`
+
+	// Totals for the addresses scanned before the checkpoint.
+	AddressesScanned int    `json:"addresses_scanned"`
+	UTXOsFound       int    `json:"utxos_found"`
+	TotalBalance     uint64 `json:"total_balance"`
+
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ScanProgress reports progress through a wallet scan.
+type ScanProgress struct {
+	ChainID chain.ID
+
+	// Index is the position of the address just scanned in the wallet's
+	// address list, and Total is the length of that list. The scan may stop
+	// before Total when the gap limit is reached.
+	Index   int
+	Total   int
+	Address string
+
+	// Running totals, including any addresses scanned before a resume.
+	AddressesScanned int
+	UTXOsFound       int
+	TotalBalance     uint64
+}
+
+// ScanProgressCallback receives a ScanProgress after each address is scanned.
+type ScanProgressCallback func(ScanProgress)
+
+// SetScanProgressCallback registers a callback for ScanWallet and
+// ScanWalletBulk progress. Pass nil to remove it.
+func (s *Store) SetScanProgressCallback(cb ScanProgressCallback) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onScanProgress = cb
+}
+
+// GetScanCheckpoint returns a copy of the unfinished scan checkpoint for a
+// chain, or nil when there is none. Scans of accounts other than account 0
+// are checkpointed under "chain/account", such as "bsv/1".
+func (s *Store) GetScanCheckpoint(chainID chain.ID) *ScanCheckpoint {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	cp, ok := s.data.ScanCheckpoints[chainID]
+	if !ok {
+		return nil
+	}
+	cpCopy := *cp
+	return &cpCopy
+}
+
+// ClearScanCheckpoint discards the scan checkpoint for a chain so the next
+// scan starts from the first address. Call Save to persist the change.
+func (s *Store) ClearScanCheckpoint(chainID chain.ID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.data.ScanCheckpoints, chainID)
+}
+
+// checkpointKey returns the ScanCheckpoints key for a scan of one account's
+// addresses on a chain: the chain ID for account 0, so checkpoints saved
+// before accounts existed still resume, and "chain/account" otherwise.
+func checkpointKey(chainID chain.ID, account uint32) chain.ID {
+	if account == 0 {
+		return chainID
+	}
+	return chain.ID(fmt.Sprintf("%s/%d", chainID, account))
+}
+
+// resumeScan returns the checkpoint to continue a scan of addresses from,
+// or a fresh one when there is no usable checkpoint.
+func (s *Store) resumeScan(key chain.ID, addresses []wallet.Address) *ScanCheckpoint {
+	cp := s.GetScanCheckpoint(key)
+	if cp == nil || cp.NextIndex <= 0 || cp.NextIndex > len(addresses) ||
+		addresses[cp.NextIndex-1].Address != cp.LastAddress {
+		return &ScanCheckpoint{}
+	}
+	return cp
+}
+
+// result returns a ScanResult holding the totals carried over from the checkpoint.
+func (cp *ScanCheckpoint) result() *ScanResult {
+	return &ScanResult{
+		AddressesScanned: cp.AddressesScanned,
+		UTXOsFound:       cp.UTXOsFound,
+		TotalBalance:     cp.TotalBalance,
+		ResumedAt:        cp.NextIndex,
+	}
+}
+
+// advance moves the checkpoint past the address at index.
+func (cp *ScanCheckpoint) advance(index int, address string, consecutiveEmpty int, result *ScanResult) {
+	cp.NextIndex = index + 1
+	cp.LastAddress = address
+	cp.ConsecutiveEmpty = consecutiveEmpty
+	cp.AddressesScanned = result.AddressesScanned
+	cp.UTXOsFound = result.UTXOsFound
+	cp.TotalBalance = result.TotalBalance
+}
+
+// saveScanCheckpoint persists the checkpoint under key (see checkpointKey)
+// together with the UTXOs found so far.
+func (s *Store) saveScanCheckpoint(key chain.ID, cp *ScanCheckpoint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data.ScanCheckpoints == nil {
+		s.data.ScanCheckpoints = make(map[chain.ID]*ScanCheckpoint)
+	}
+	cpCopy := *cp
+	cpCopy.UpdatedAt = time.Now()
+	s.data.ScanCheckpoints[key] = &cpCopy
+
+	if err := s.saveUnlocked(); err != nil {
+		return fmt.Errorf("saving scan checkpoint: %w", err)
+	}
+	return nil
+}
+
+// interruptScan saves the checkpoint of a scan stopped by err and returns err.
+func (s *Store) interruptScan(key chain.ID, cp *ScanCheckpoint, err error) error {
+	if saveErr := s.saveScanCheckpoint(key, cp); saveErr != nil {
+		return fmt.Errorf("%w (%w)", err, saveErr)
+	}
+	return err
+}
+
+// finishScan clears the checkpoint of a completed scan and saves the store.
+func (s *Store) finishScan(key chain.ID) error {
+	s.ClearScanCheckpoint(key)
+	if err := s.Save(); err != nil {
+		return fmt.Errorf("saving UTXOs: %w", err)
+	}
+	return nil
+}
+
+// scanProgressed records that the address at index was scanned: it advances
+// the checkpoint, reports progress, and saves a checkpoint every
+// checkpointInterval addresses under key.
+func (s *Store) scanProgressed(chainID, key chain.ID, addresses []wallet.Address, index, consecutiveEmpty int, cp *ScanCheckpoint, result *ScanResult) error {
+	address := addresses[index].Address
+	cp.advance(index, address, consecutiveEmpty, result)
+
+	s.mu.RLock()
+	cb := s.onScanProgress
+	s.mu.RUnlock()
+	if cb != nil {
+		cb(ScanProgress{
+			ChainID:          chainID,
+			Index:            index,
+			Total:            len(addresses),
+			Address:          address,
+			AddressesScanned: result.AddressesScanned,
+			UTXOsFound:       result.UTXOsFound,
+			TotalBalance:     result.TotalBalance,
+		})
+	}
+
+	if cp.NextIndex%checkpointInterval == 0 {
+		return s.saveScanCheckpoint(key, cp)
+	}
+	return nil
+}
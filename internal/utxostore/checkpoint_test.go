@@ -0,0 +1,204 @@
+package utxostore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// cancelingChainClient cancels the scan context when cancelAt is looked up.
+type cancelingChainClient struct {
+	*mockChainClient
+
+	cancelAt string
+	cancel   context.CancelFunc
+	scanned  []string
+}
+
+func (c *cancelingChainClient) ListUTXOs(ctx context.Context, address string) ([]chain.UTXO, error) {
+	c.scanned = append(c.scanned, address)
+	if address == c.cancelAt {
+		c.cancel()
+		return nil, ctx.Err()
+	}
+	return c.mockChainClient.ListUTXOs(ctx, address)
+}
+
+// checkpointTestWallet returns a BSV wallet with n addresses, where every
+// fifth address holds one 1000 satoshi UTXO.
+func checkpointTestWallet(n int) (*wallet.Wallet, *mockChainClient) {
+	client := newMockClient()
+	addresses := make([]wallet.Address, n)
+	for i := range n {
+		addr := fmt.Sprintf("addr%d", i)
+		addresses[i] = wallet.Address{Address: addr, Index: uint32(i)} //nolint:gosec // G115: test index is small
+		if i%5 == 0 {
+			client.setUTXOs(addr, []chain.UTXO{{TxID: testTxID(i), Vout: 0, Amount: 1000, Address: addr}})
+		}
+	}
+	return &wallet.Wallet{Addresses: map[chain.ID][]wallet.Address{chain.BSV: addresses}}, client
+}
+
+func TestScanWallet_ResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	w, mock := checkpointTestWallet(30)
+
+	// First scan is interrupted while looking up addr12
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := &cancelingChainClient{mockChainClient: mock, cancelAt: "addr12", cancel: cancel}
+
+	store := New(tmpDir)
+	_, err := store.ScanWallet(ctx, w, chain.BSV, interrupted)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The checkpoint and the UTXOs found so far survive a reload
+	reloaded := New(tmpDir)
+	require.NoError(t, reloaded.Load())
+	cp := reloaded.GetScanCheckpoint(chain.BSV)
+	require.NotNil(t, cp)
+	assert.Equal(t, 12, cp.NextIndex)
+	assert.Equal(t, "addr11", cp.LastAddress)
+	assert.Equal(t, 12, cp.AddressesScanned)
+	assert.Equal(t, 3, cp.UTXOsFound)
+	assertBalanceEquals(t, reloaded, chain.BSV, 3000)
+
+	// The second scan starts at addr12 and reports totals for the whole scan
+	resumed := &cancelingChainClient{mockChainClient: mock}
+	result, err := reloaded.ScanWallet(context.Background(), w, chain.BSV, resumed)
+	require.NoError(t, err)
+	assert.Equal(t, "addr12", resumed.scanned[0])
+	assert.Len(t, resumed.scanned, 18)
+	assert.Equal(t, 12, result.ResumedAt)
+	assert.Equal(t, 30, result.AddressesScanned)
+	assert.Equal(t, 6, result.UTXOsFound)
+	assert.Equal(t, uint64(6000), result.TotalBalance)
+	assert.Nil(t, reloaded.GetScanCheckpoint(chain.BSV), "completed scan clears its checkpoint")
+}
+
+func TestScanWallet_DiscardsStaleCheckpoint(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+	w, client := checkpointTestWallet(5)
+
+	require.NoError(t, store.saveScanCheckpoint(chain.BSV, &ScanCheckpoint{
+		NextIndex:        3,
+		LastAddress:      "some-other-wallet-address",
+		AddressesScanned: 3,
+	}))
+
+	result, err := store.ScanWallet(context.Background(), w, chain.BSV, client)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.ResumedAt)
+	assert.Equal(t, 5, result.AddressesScanned)
+	assert.Equal(t, 5, client.callCount)
+}
+
+func TestScanWallet_ProgressCallback(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+	w, client := checkpointTestWallet(6)
+
+	var updates []ScanProgress
+	store.SetScanProgressCallback(func(p ScanProgress) { updates = append(updates, p) })
+
+	_, err := store.ScanWallet(context.Background(), w, chain.BSV, client)
+	require.NoError(t, err)
+
+	require.Len(t, updates, 6)
+	for i, u := range updates {
+		assert.Equal(t, chain.BSV, u.ChainID)
+		assert.Equal(t, i, u.Index)
+		assert.Equal(t, 6, u.Total)
+		assert.Equal(t, fmt.Sprintf("addr%d", i), u.Address)
+		assert.Equal(t, i+1, u.AddressesScanned)
+	}
+	assert.Equal(t, 2, updates[5].UTXOsFound)
+	assert.Equal(t, uint64(2000), updates[5].TotalBalance)
+}
+
+func TestScanWalletBulk_ResumesFromCheckpoint(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+	w, mock := checkpointTestWallet(15)
+	client := &mockBulkChainClient{mockChainClient: mock}
+
+	require.NoError(t, store.saveScanCheckpoint(chain.BSV, &ScanCheckpoint{
+		NextIndex:        10,
+		LastAddress:      "addr9",
+		AddressesScanned: 10,
+		UTXOsFound:       2,
+		TotalBalance:     2000,
+	}))
+
+	var fetched []string
+	client.setBulkFetchFunc(func(addresses []string) ([]BulkUTXOResult, error) {
+		fetched = addresses
+		results := make([]BulkUTXOResult, len(addresses))
+		for i, addr := range addresses {
+			results[i] = BulkUTXOResult{Address: addr, ConfirmedUTXOs: mock.utxosByAddress[addr]}
+		}
+		return results, nil
+	})
+
+	result, err := store.ScanWalletBulk(context.Background(), w, chain.BSV, client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"addr10", "addr11", "addr12", "addr13", "addr14"}, fetched)
+	assert.Equal(t, 10, result.ResumedAt)
+	assert.Equal(t, 15, result.AddressesScanned)
+	assert.Equal(t, 3, result.UTXOsFound)
+	assert.Equal(t, uint64(3000), result.TotalBalance)
+	assert.Nil(t, store.GetScanCheckpoint(chain.BSV))
+}
+
+func TestClearScanCheckpoint(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+
+	require.NoError(t, store.saveScanCheckpoint(chain.BSV, &ScanCheckpoint{NextIndex: 1, LastAddress: "addr0"}))
+	require.NotNil(t, store.GetScanCheckpoint(chain.BSV))
+
+	store.ClearScanCheckpoint(chain.BSV)
+	assert.Nil(t, store.GetScanCheckpoint(chain.BSV))
+}
+
+func TestScanWallet_AccountCheckpoints(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+	w, mock := checkpointTestWallet(30)
+
+	acct, err := w.Account(1)
+	require.NoError(t, err)
+	acct.Addresses[chain.BSV] = []wallet.Address{
+		{Address: "acct1-addr0", Index: 0, Account: 1},
+		{Address: "acct1-addr1", Index: 1, Account: 1},
+	}
+	mock.setUTXOs("acct1-addr1", []chain.UTXO{{TxID: testTxID(100), Vout: 0, Amount: 500, Address: "acct1-addr1"}})
+
+	// Account 0 is interrupted and leaves its checkpoint
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	interrupted := &cancelingChainClient{mockChainClient: mock, cancelAt: "addr12", cancel: cancel}
+	_, err = store.ScanWallet(ctx, w, chain.BSV, interrupted)
+	require.ErrorIs(t, err, context.Canceled)
+	require.NotNil(t, store.GetScanCheckpoint(chain.BSV))
+
+	// Account 1 scans its own addresses with its own gap counter
+	result, err := store.ScanWallet(context.Background(), acct, chain.BSV, mock)
+	require.NoError(t, err)
+	assert.Equal(t, 0, result.ResumedAt)
+	assert.Equal(t, 2, result.AddressesScanned)
+	assert.Equal(t, uint64(500), result.TotalBalance)
+	assert.Nil(t, store.GetScanCheckpoint("bsv/1"))
+
+	cp := store.GetScanCheckpoint(chain.BSV)
+	require.NotNil(t, cp, "account 0 still resumes where it stopped")
+	assert.Equal(t, 12, cp.NextIndex)
+}
@@ -0,0 +1,111 @@
+package utxostore
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// ErrInvalidEnvelope is returned when an envelope name is empty or invalid.
+var ErrInvalidEnvelope = errors.New("invalid envelope")
+
+// NormalizeEnvelope trims and lower-cases an envelope name and checks that
+// it uses only letters, digits, '-', '_' and '.'.
+func NormalizeEnvelope(name string) (string, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		return "", fmt.Errorf("%w: empty", ErrInvalidEnvelope)
+	}
+	for _, r := range name {
+		if !isTagRune(r) {
+			return "", fmt.Errorf("%w: %q contains %q", ErrInvalidEnvelope, name, r)
+		}
+	}
+	return name, nil
+}
+
+// SetAddressEnvelope assigns an address to an envelope, or removes it from
+// its envelope when envelope is empty. The envelope must already be
+// normalized. Assigning the envelope an address already has changes nothing
+// and returns false.
+// Returns error if the address is not found.
+func (s *Store) SetAddressEnvelope(chainID chain.ID, address, envelope string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addr, exists := s.data.Addresses[fmt.Sprintf("%s:%s", chainID, address)]
+	if !exists {
+		return false, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+	if addr.Envelope == envelope {
+		return false, nil
+	}
+	addr.Envelope = envelope
+	return true, nil
+}
+
+// AddressEnvelope returns the envelope of an address, or "" when the address
+// is unknown or in no envelope.
+func (s *Store) AddressEnvelope(chainID chain.ID, address string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if addr, exists := s.data.Addresses[fmt.Sprintf("%s:%s", chainID, address)]; exists {
+		return addr.Envelope
+	}
+	return ""
+}
+
+// EnvelopeAddresses returns the addresses of a chain assigned to an
+// envelope, sorted.
+func (s *Store) EnvelopeAddresses(chainID chain.ID, envelope string) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []string
+	for _, addr := range s.data.Addresses {
+		if addr.ChainID == chainID && addr.Envelope == envelope {
+			result = append(result, addr.Address)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// Envelopes returns the names of every envelope with an address assigned,
+// sorted.
+func (s *Store) Envelopes() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []string
+	for _, addr := range s.data.Addresses {
+		if addr.Envelope != "" && !slices.Contains(result, addr.Envelope) {
+			result = append(result, addr.Envelope)
+		}
+	}
+	slices.Sort(result)
+	return result
+}
+
+// GetEnvelopeBalance returns the total of the unspent UTXOs held by the
+// addresses of a chain assigned to an envelope.
+func (s *Store) GetEnvelopeBalance(chainID chain.ID, envelope string) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var total uint64
+	for _, utxo := range s.data.UTXOs {
+		if utxo.ChainID != chainID || utxo.Spent {
+			continue
+		}
+		addr, exists := s.data.Addresses[fmt.Sprintf("%s:%s", chainID, utxo.Address)]
+		if exists && addr.Envelope == envelope {
+			total += utxo.Amount
+		}
+	}
+	return total
+}
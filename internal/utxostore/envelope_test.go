@@ -0,0 +1,82 @@
+package utxostore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestNormalizeEnvelope(t *testing.T) {
+	t.Parallel()
+
+	name, err := NormalizeEnvelope("  Rent ")
+	require.NoError(t, err)
+	assert.Equal(t, "rent", name)
+
+	name, err = NormalizeEnvelope("savings_2026.q4")
+	require.NoError(t, err)
+	assert.Equal(t, "savings_2026.q4", name)
+
+	for _, bad := range []string{"", "   ", "bills:rent", "two words"} {
+		_, err = NormalizeEnvelope(bad)
+		require.ErrorIs(t, err, ErrInvalidEnvelope, bad)
+	}
+}
+
+func TestStore_SetAddressEnvelope(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	store := New(tmpDir)
+
+	rent1, rent2, savings, loose := testAddressN(0), testAddressN(1), testAddressN(2), testAddressN(3)
+	for i, addr := range []string{rent1, rent2, savings, loose} {
+		store.AddAddress(createTestAddress(chain.BSV, addr, uint32(i), false))
+	}
+	store.AddUTXO(createTestUTXO(chain.BSV, rent1, "tx1", 0, 1000, false))
+	store.AddUTXO(createTestUTXO(chain.BSV, rent2, "tx2", 0, 500, false))
+	store.AddUTXO(createTestUTXO(chain.BSV, rent2, "tx3", 0, 700, true))
+	store.AddUTXO(createTestUTXO(chain.BSV, savings, "tx4", 0, 9000, false))
+	store.AddUTXO(createTestUTXO(chain.BSV, loose, "tx5", 0, 42, false))
+
+	for addr, envelope := range map[string]string{rent1: "rent", rent2: "rent", savings: "savings"} {
+		changed, err := store.SetAddressEnvelope(chain.BSV, addr, envelope)
+		require.NoError(t, err)
+		assert.True(t, changed)
+	}
+
+	// Assigning the same envelope again changes nothing
+	changed, err := store.SetAddressEnvelope(chain.BSV, rent1, "rent")
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	assert.Equal(t, []string{"rent", "savings"}, store.Envelopes())
+	assert.Equal(t, []string{rent1, rent2}, store.EnvelopeAddresses(chain.BSV, "rent"))
+	assert.Equal(t, []string{loose}, store.EnvelopeAddresses(chain.BSV, ""))
+	assert.Empty(t, store.EnvelopeAddresses(chain.BTC, "rent"))
+	assert.Equal(t, uint64(1500), store.GetEnvelopeBalance(chain.BSV, "rent"))
+	assert.Equal(t, uint64(9000), store.GetEnvelopeBalance(chain.BSV, "savings"))
+	assert.Equal(t, uint64(42), store.GetEnvelopeBalance(chain.BSV, ""))
+	assert.Equal(t, "rent", store.AddressEnvelope(chain.BSV, rent2))
+	assert.Empty(t, store.AddressEnvelope(chain.BSV, "unknown"))
+
+	// A refresh rewriting the metadata keeps the envelope
+	store.AddAddress(createTestAddress(chain.BSV, savings, 2, false))
+	assert.Equal(t, "savings", store.AddressEnvelope(chain.BSV, savings))
+	require.NoError(t, store.Save())
+
+	reloaded := New(tmpDir)
+	require.NoError(t, reloaded.Load())
+	assert.Equal(t, []string{rent1, rent2}, reloaded.EnvelopeAddresses(chain.BSV, "rent"))
+
+	// Removing an address from its envelope
+	changed, err = reloaded.SetAddressEnvelope(chain.BSV, savings, "")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, []string{"rent"}, reloaded.Envelopes())
+
+	_, err = reloaded.SetAddressEnvelope(chain.BSV, "unknown", "rent")
+	require.ErrorIs(t, err, ErrAddressNotFound)
+}
@@ -0,0 +1,79 @@
+package utxostore
+
+import (
+	"sort"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// Filter selects stored UTXOs for Store.List. The zero value matches every
+// unspent UTXO.
+type Filter struct {
+	// Addresses limits results to these addresses; empty matches all.
+	Addresses []string
+	// MinAmount drops UTXOs worth fewer satoshis.
+	MinAmount uint64
+	// ConfirmedOnly drops UTXOs with no confirmations.
+	ConfirmedOnly bool
+	// MinConfirmations drops UTXOs with fewer confirmations; zero keeps all.
+	MinConfirmations uint32
+	// IncludeSpent keeps UTXOs already marked spent.
+	IncludeSpent bool
+}
+
+// matches reports whether the filter selects u.
+func (f Filter) matches(u *StoredUTXO) bool {
+	if u.Spent && !f.IncludeSpent {
+		return false
+	}
+	if u.Amount < f.MinAmount {
+		return false
+	}
+	if f.ConfirmedOnly && u.Confirmations == 0 {
+		return false
+	}
+	if u.Confirmations < f.MinConfirmations {
+		return false
+	}
+	if len(f.Addresses) == 0 {
+		return true
+	}
+	for _, addr := range f.Addresses {
+		if u.Address == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// List returns the UTXOs for a chain that match f, largest first, with ties
+// ordered by txid and output index.
+func (s *Store) List(chainID chain.ID, f Filter) []*StoredUTXO {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*StoredUTXO
+	for _, utxo := range s.data.UTXOs {
+		if utxo.ChainID == chainID && f.matches(utxo) {
+			result = append(result, utxo)
+		}
+	}
+
+	sortUTXOs(result)
+	return result
+}
+
+// sortUTXOs orders UTXOs largest first, with ties ordered by txid and
+// output index.
+func sortUTXOs(utxos []*StoredUTXO) {
+	sort.Slice(utxos, func(i, j int) bool {
+		a, b := utxos[i], utxos[j]
+		if a.Amount != b.Amount {
+			return a.Amount > b.Amount
+		}
+		if a.TxID != b.TxID {
+			return a.TxID < b.TxID
+		}
+		return a.Vout < b.Vout
+	})
+}
@@ -0,0 +1,49 @@
+package utxostore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestStoreList(t *testing.T) {
+	t.Parallel()
+
+	store := New(t.TempDir())
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: "aa", Vout: 0, Amount: 5000, Address: "1A", Confirmations: 3})
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: "bb", Vout: 1, Amount: 800, Address: "1A", Confirmations: 0})
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: "cc", Vout: 0, Amount: 9000, Address: "1B", Confirmations: 10})
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: "dd", Vout: 2, Amount: 7000, Address: "1B", Confirmations: 10})
+	store.AddUTXO(&StoredUTXO{ChainID: chain.ETH, TxID: "ee", Vout: 0, Amount: 1, Address: "1A"})
+	store.MarkSpent(chain.BSV, "dd", 2, "ff")
+
+	txids := func(utxos []*StoredUTXO) []string {
+		ids := make([]string, 0, len(utxos))
+		for _, u := range utxos {
+			ids = append(ids, u.TxID)
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"unspent largest first", Filter{}, []string{"cc", "aa", "bb"}},
+		{"include spent", Filter{IncludeSpent: true}, []string{"cc", "dd", "aa", "bb"}},
+		{"by address", Filter{Addresses: []string{"1A"}}, []string{"aa", "bb"}},
+		{"min amount", Filter{MinAmount: 5000}, []string{"cc", "aa"}},
+		{"confirmed only", Filter{ConfirmedOnly: true, Addresses: []string{"1A", "1B"}}, []string{"cc", "aa"}},
+		{"min confirmations", Filter{MinConfirmations: 6}, []string{"cc"}},
+		{"no match", Filter{Addresses: []string{"1Z"}}, []string{}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.want, txids(store.List(chain.BSV, tc.filter)))
+		})
+	}
+}
@@ -0,0 +1,328 @@
+package utxostore
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// filterSaveInterval is how many blocks are checked between saves of the
+// filter sync height.
+const filterSaveInterval = 500
+
+var (
+	// ErrNoFilterStart is returned when a filter sync has no height to start
+	// from: the wallet has never been synced and no start height was given.
+	ErrNoFilterStart = errors.New("no filter sync start height")
+
+	// ErrFilterReorg is returned when the block at the last synced height is
+	// no longer on the node's best chain.
+	ErrFilterReorg = errors.New("block at filter sync height was reorganized")
+)
+
+// FilterSource reads blocks and their BIP158 basic filters, such as a BSV
+// node (bsv.NodeClient).
+type FilterSource interface {
+	BlockCount(ctx context.Context) (int64, error)
+	BlockHash(ctx context.Context, height int64) (string, error)
+	BlockFilter(ctx context.Context, blockHash string) ([]byte, error)
+	Block(ctx context.Context, blockHash string) ([]*transaction.Transaction, error)
+}
+
+// FilterSyncState records the last block a filter sync processed.
+type FilterSyncState struct {
+	Height    int64     `json:"height"`
+	BlockHash string    `json:"block_hash"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// FilterSyncResult summarizes a filter sync.
+type FilterSyncResult struct {
+	// FromHeight and ToHeight are the first and last blocks checked. When
+	// the wallet was already at the tip, FromHeight is ToHeight+1.
+	FromHeight int64
+	ToHeight   int64
+
+	// BlocksChecked is the number of filters matched against the wallet.
+	BlocksChecked int
+
+	// BlocksFetched is the number of blocks whose filter matched and that
+	// were downloaded; FalsePositives of them touched no wallet script.
+	BlocksFetched  int
+	FalsePositives int
+
+	// UTXOsFound and UTXOsSpent count the wallet outputs created and spent
+	// in the fetched blocks. AmountFound is the sum of UTXOsFound.
+	UTXOsFound  int
+	UTXOsSpent  int
+	AmountFound uint64
+}
+
+// GetFilterSync returns a copy of the filter sync state for a chain, or nil
+// when the wallet has never been synced from filters.
+func (s *Store) GetFilterSync(chainID chain.ID) *FilterSyncState {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.data.FilterSync[chainID]
+	if !ok {
+		return nil
+	}
+	stateCopy := *state
+	return &stateCopy
+}
+
+// SyncFilters finds the wallet's UTXOs by matching its receiving and change
+// scripts against each block's compact filter, downloading only the blocks
+// that match. No address is sent to src. New outputs are added and spent
+// ones marked, from fromHeight, or from the block after the last sync when
+// fromHeight is negative, up to the node's tip.
+//
+// Filters cover mined blocks only, so unconfirmed UTXOs are not found.
+// Progress is saved as the sync goes, so a canceled sync resumes where it
+// stopped.
+func (s *Store) SyncFilters(ctx context.Context, w *wallet.Wallet, chainID chain.ID, src FilterSource, fromHeight int64) (*FilterSyncResult, error) {
+	scripts, err := walletScripts(w, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := s.filterStart(ctx, chainID, src, fromHeight)
+	if err != nil {
+		return nil, err
+	}
+	tip, err := src.BlockCount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting block count: %w", err)
+	}
+
+	result := &FilterSyncResult{FromHeight: start, ToHeight: start - 1}
+	items := make([][]byte, 0, len(scripts))
+	for _, addr := range scripts {
+		items = append(items, addr.script)
+	}
+
+	for height := start; height <= tip; height++ {
+		if ctx.Err() != nil {
+			return result, s.saveFilterSync(ctx.Err())
+		}
+
+		hash, err := s.syncFilterBlock(ctx, chainID, src, height, tip, scripts, items, result)
+		if err != nil {
+			return result, s.saveFilterSync(err)
+		}
+		s.setFilterSync(chainID, height, hash)
+		result.ToHeight = height
+
+		if result.BlocksChecked%filterSaveInterval == 0 {
+			if err := s.saveFilterSync(nil); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	return result, s.saveFilterSync(nil)
+}
+
+// filterStart returns the first height to sync. A resumed sync checks that
+// the last synced block is still on the best chain.
+func (s *Store) filterStart(ctx context.Context, chainID chain.ID, src FilterSource, fromHeight int64) (int64, error) {
+	if fromHeight >= 0 {
+		return fromHeight, nil
+	}
+	state := s.GetFilterSync(chainID)
+	if state == nil {
+		return 0, ErrNoFilterStart
+	}
+	hash, err := src.BlockHash(ctx, state.Height)
+	if err != nil {
+		return 0, fmt.Errorf("checking block %d: %w", state.Height, err)
+	}
+	if hash != state.BlockHash {
+		return 0, fmt.Errorf("%w: block %d is now %s, was %s", ErrFilterReorg, state.Height, hash, state.BlockHash)
+	}
+	return state.Height + 1, nil
+}
+
+// syncFilterBlock checks the filter of the block at height and, if it
+// matches, applies the block's wallet outputs and spends. It returns the
+// block hash.
+func (s *Store) syncFilterBlock(ctx context.Context, chainID chain.ID, src FilterSource, height, tip int64,
+	scripts map[string]walletScript, items [][]byte, result *FilterSyncResult,
+) (string, error) {
+	hash, err := src.BlockHash(ctx, height)
+	if err != nil {
+		return "", fmt.Errorf("getting block hash %d: %w", height, err)
+	}
+	raw, err := src.BlockFilter(ctx, hash)
+	if err != nil {
+		return "", fmt.Errorf("getting filter for block %d: %w", height, err)
+	}
+	filter, err := bsv.ParseBlockFilter(hash, raw)
+	if err != nil {
+		return "", fmt.Errorf("block %d: %w", height, err)
+	}
+	result.BlocksChecked++
+
+	match, err := filter.MatchAny(items)
+	if err != nil {
+		return "", fmt.Errorf("block %d: %w", height, err)
+	}
+	if !match {
+		return hash, nil
+	}
+
+	txs, err := src.Block(ctx, hash)
+	if err != nil {
+		return "", fmt.Errorf("getting block %d: %w", height, err)
+	}
+	result.BlocksFetched++
+	//nolint:gosec // G115: tip >= height, and block heights fit in uint32
+	confirmations := uint32(tip - height + 1)
+	if !s.applyBlock(chainID, txs, confirmations, scripts, result) {
+		result.FalsePositives++
+	}
+	return hash, nil
+}
+
+// applyBlock adds the wallet outputs of txs and marks the stored UTXOs they
+// spend. It reports whether any transaction touched the wallet.
+func (s *Store) applyBlock(chainID chain.ID, txs []*transaction.Transaction, confirmations uint32,
+	scripts map[string]walletScript, result *FilterSyncResult,
+) bool {
+	touched := false
+	for _, tx := range txs {
+		txid := tx.TxID().String()
+
+		if !tx.IsCoinbase() {
+			for _, in := range tx.Inputs {
+				if in.SourceTXID == nil {
+					continue
+				}
+				prev := in.SourceTXID.String()
+				if !s.hasUTXO(chainID, prev, in.SourceTxOutIndex) {
+					continue
+				}
+				touched = true
+				if !s.IsSpent(chainID, prev, in.SourceTxOutIndex) {
+					s.MarkSpent(chainID, prev, in.SourceTxOutIndex, txid)
+					result.UTXOsSpent++
+				}
+			}
+		}
+
+		for vout, out := range tx.Outputs {
+			if out.LockingScript == nil {
+				continue
+			}
+			addr, ok := scripts[hex.EncodeToString(*out.LockingScript)]
+			if !ok {
+				continue
+			}
+			touched = true
+			//nolint:gosec // G115: output index fits in uint32
+			index := uint32(vout)
+			if s.hasUTXO(chainID, txid, index) && s.IsSpent(chainID, txid, index) {
+				// Already seen and spent by a later block in an earlier sync
+				continue
+			}
+			s.AddUTXO(&StoredUTXO{
+				ChainID:         chainID,
+				TxID:            txid,
+				Vout:            index,
+				Amount:          out.Satoshis,
+				ScriptPubKey:    hex.EncodeToString(*out.LockingScript),
+				Address:         addr.address.Address,
+				Confirmations:   confirmations,
+				Coinbase:        tx.IsCoinbase(),
+				CoinbaseChecked: true,
+			})
+			s.markFilterActivity(chainID, addr.address)
+			result.UTXOsFound++
+			result.AmountFound += out.Satoshis
+		}
+	}
+	return touched
+}
+
+// hasUTXO reports whether the store holds the output, spent or not.
+func (s *Store) hasUTXO(chainID chain.ID, txid string, vout uint32) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	_, ok := s.data.UTXOs[fmt.Sprintf("%s:%s:%d", chainID, txid, vout)]
+	return ok
+}
+
+// markFilterActivity records that addr received funds, adding its metadata
+// if the store does not know it yet.
+func (s *Store) markFilterActivity(chainID chain.ID, addr wallet.Address) {
+	if s.GetAddress(chainID, addr.Address) == nil {
+		s.AddAddress(&AddressMetadata{
+			Address:        addr.Address,
+			ChainID:        chainID,
+			DerivationPath: addr.Path,
+			Index:          addr.Index,
+			IsChange:       addr.IsChange,
+		})
+	}
+	s.MarkAddressUsed(chainID, addr.Address)
+}
+
+// setFilterSync records height as the last synced block.
+func (s *Store) setFilterSync(chainID chain.ID, height int64, hash string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.data.FilterSync == nil {
+		s.data.FilterSync = make(map[chain.ID]*FilterSyncState)
+	}
+	s.data.FilterSync[chainID] = &FilterSyncState{Height: height, BlockHash: hash, UpdatedAt: time.Now()}
+}
+
+// saveFilterSync saves the store, including the filter sync height, and
+// returns err, or the save error when err is nil.
+func (s *Store) saveFilterSync(err error) error {
+	if saveErr := s.Save(); saveErr != nil {
+		if err != nil {
+			return fmt.Errorf("%w (%w)", err, saveErr)
+		}
+		return fmt.Errorf("saving UTXOs: %w", saveErr)
+	}
+	return err
+}
+
+// walletScript is a wallet address and its locking script.
+type walletScript struct {
+	address wallet.Address
+	script  []byte
+}
+
+// walletScripts returns the P2PKH locking scripts of the wallet's receiving
+// and change addresses, keyed by hex script.
+func walletScripts(w *wallet.Wallet, chainID chain.ID) (map[string]walletScript, error) {
+	scripts := make(map[string]walletScript)
+	for _, list := range [][]wallet.Address{w.Addresses[chainID], w.ChangeAddresses[chainID]} {
+		for _, a := range list {
+			addr, err := script.NewAddressFromString(a.Address)
+			if err != nil {
+				return nil, fmt.Errorf("address %s: %w", a.Address, err)
+			}
+			lock, err := p2pkh.Lock(addr)
+			if err != nil {
+				return nil, fmt.Errorf("address %s: %w", a.Address, err)
+			}
+			scripts[hex.EncodeToString(*lock)] = walletScript{address: a, script: *lock}
+		}
+	}
+	return scripts, nil
+}
@@ -0,0 +1,185 @@
+package utxostore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/bsv-blockchain/go-sdk/chainhash"
+	"github.com/bsv-blockchain/go-sdk/script"
+	"github.com/bsv-blockchain/go-sdk/transaction"
+	"github.com/bsv-blockchain/go-sdk/transaction/template/p2pkh"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/bsv"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// fakeFilterSource serves blocks from memory, building each block's filter
+// from its output scripts and the scripts its inputs spend.
+type fakeFilterSource struct {
+	hashes  map[int64]string
+	blocks  map[string][]*transaction.Transaction
+	scripts map[string][]byte // "txid:vout" -> locking script
+	fetched int
+}
+
+func newFakeFilterSource() *fakeFilterSource {
+	return &fakeFilterSource{
+		hashes:  make(map[int64]string),
+		blocks:  make(map[string][]*transaction.Transaction),
+		scripts: make(map[string][]byte),
+	}
+}
+
+func (f *fakeFilterSource) addBlock(height int64, txs ...*transaction.Transaction) {
+	hash := fmt.Sprintf("%064x", height)
+	f.hashes[height] = hash
+	f.blocks[hash] = txs
+	for _, tx := range txs {
+		for i, out := range tx.Outputs {
+			f.scripts[fmt.Sprintf("%s:%d", tx.TxID(), i)] = *out.LockingScript
+		}
+	}
+}
+
+func (f *fakeFilterSource) BlockCount(context.Context) (int64, error) {
+	var tip int64
+	for h := range f.hashes {
+		tip = max(tip, h)
+	}
+	return tip, nil
+}
+
+func (f *fakeFilterSource) BlockHash(_ context.Context, height int64) (string, error) {
+	hash, ok := f.hashes[height]
+	if !ok {
+		return "", fmt.Errorf("no block %d", height)
+	}
+	return hash, nil
+}
+
+func (f *fakeFilterSource) BlockFilter(_ context.Context, hash string) ([]byte, error) {
+	var items [][]byte
+	for _, tx := range f.blocks[hash] {
+		for _, out := range tx.Outputs {
+			items = append(items, *out.LockingScript)
+		}
+		for _, in := range tx.Inputs {
+			items = append(items, f.scripts[fmt.Sprintf("%s:%d", in.SourceTXID, in.SourceTxOutIndex)])
+		}
+	}
+	return bsv.BuildBlockFilter(hash, items)
+}
+
+func (f *fakeFilterSource) Block(_ context.Context, hash string) ([]*transaction.Transaction, error) {
+	f.fetched++
+	return f.blocks[hash], nil
+}
+
+// filterPay is an output of a test transaction.
+type filterPay struct {
+	address string
+	amount  uint64
+}
+
+// filterTestTx spends prev:vout and creates an output for each payment, in order.
+func filterTestTx(t *testing.T, prev *transaction.Transaction, vout uint32, pays ...filterPay) *transaction.Transaction {
+	t.Helper()
+	tx := transaction.NewTransaction()
+	source := &chainhash.Hash{0xee}
+	if prev != nil {
+		source = prev.TxID()
+	}
+	tx.AddInput(&transaction.TransactionInput{
+		SourceTXID:       source,
+		SourceTxOutIndex: vout,
+		UnlockingScript:  &script.Script{},
+		SequenceNumber:   0xffffffff,
+	})
+	for _, pay := range pays {
+		a, err := script.NewAddressFromString(pay.address)
+		require.NoError(t, err)
+		lock, err := p2pkh.Lock(a)
+		require.NoError(t, err)
+		tx.AddOutput(&transaction.TransactionOutput{Satoshis: pay.amount, LockingScript: lock})
+	}
+	return tx
+}
+
+func TestSyncFilters(t *testing.T) {
+	t.Parallel()
+
+	seed, err := wallet.MnemonicToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	require.NoError(t, err)
+	recv, err := wallet.DeriveAddress(seed, wallet.ChainBSV, 0, 0)
+	require.NoError(t, err)
+	change, err := wallet.DeriveAddressWithChange(seed, wallet.ChainBSV, 0, 1, 0)
+	require.NoError(t, err)
+	w := &wallet.Wallet{
+		Name:            "filters",
+		Addresses:       map[wallet.ChainID][]wallet.Address{wallet.ChainBSV: {*recv}},
+		ChangeAddresses: map[wallet.ChainID][]wallet.Address{wallet.ChainBSV: {*change}},
+	}
+	stranger := "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+
+	deposit := filterTestTx(t, nil, 0, filterPay{recv.Address, 5000})
+	spend := filterTestTx(t, deposit, 0, filterPay{stranger, 2500}, filterPay{change.Address, 2000})
+
+	src := newFakeFilterSource()
+	src.addBlock(100, filterTestTx(t, nil, 1, filterPay{stranger, 1}))
+	src.addBlock(101, deposit)
+	src.addBlock(102, spend)
+	src.addBlock(103, filterTestTx(t, nil, 2, filterPay{stranger, 1}))
+
+	ctx := context.Background()
+	store := createTestStore(t)
+
+	_, err = store.SyncFilters(ctx, w, chain.BSV, src, -1)
+	require.ErrorIs(t, err, ErrNoFilterStart)
+
+	result, err := store.SyncFilters(ctx, w, chain.BSV, src, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), result.FromHeight)
+	assert.Equal(t, int64(103), result.ToHeight)
+	assert.Equal(t, 4, result.BlocksChecked)
+	assert.Equal(t, 2, result.BlocksFetched, "only blocks touching the wallet are downloaded")
+	assert.Equal(t, 2, src.fetched)
+	assert.Equal(t, 2, result.UTXOsFound)
+	assert.Equal(t, 1, result.UTXOsSpent)
+	assertBalanceEquals(t, store, chain.BSV, 2000)
+	assert.True(t, store.IsSpent(chain.BSV, deposit.TxID().String(), 0))
+	assert.True(t, store.GetAddress(chain.BSV, change.Address).HasActivity)
+
+	// The sync height is saved with the store.
+	reloaded := New(store.walletPath)
+	require.NoError(t, reloaded.Load())
+	state := reloaded.GetFilterSync(chain.BSV)
+	require.NotNil(t, state)
+	assert.Equal(t, int64(103), state.Height)
+
+	// A later sync resumes after the saved height.
+	src.addBlock(104, filterTestTx(t, spend, 1, filterPay{recv.Address, 1900}))
+	result, err = reloaded.SyncFilters(ctx, w, chain.BSV, src, -1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(104), result.FromHeight)
+	assert.Equal(t, 1, result.BlocksChecked)
+	assertBalanceEquals(t, reloaded, chain.BSV, 1900)
+
+	// Already at the tip
+	result, err = reloaded.SyncFilters(ctx, w, chain.BSV, src, -1)
+	require.NoError(t, err)
+	assert.Zero(t, result.BlocksChecked)
+
+	// A rescan does not revive spent outputs.
+	_, err = reloaded.SyncFilters(ctx, w, chain.BSV, src, 100)
+	require.NoError(t, err)
+	assertBalanceEquals(t, reloaded, chain.BSV, 1900)
+
+	// The saved block was replaced.
+	src.hashes[104] = fmt.Sprintf("%064x", 9104)
+	_, err = reloaded.SyncFilters(ctx, w, chain.BSV, src, -1)
+	require.ErrorIs(t, err, ErrFilterReorg)
+}
@@ -0,0 +1,134 @@
+package utxostore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// CoinbaseMaturity is the number of confirmations a coinbase output needs
+// before it can be spent.
+const CoinbaseMaturity = 100
+
+// ErrCoinbaseUnsupported is returned by a CoinbaseClient that cannot look up
+// transactions. Scans treat it as "maturity unknown" rather than a failure.
+var ErrCoinbaseUnsupported = errors.New("coinbase lookup not supported")
+
+// CoinbaseClient is implemented by chain clients that can tell whether a
+// transaction is a coinbase. Scans and refreshes with such a client record
+// maturity information for each UTXO; with other clients every UTXO is
+// treated as a regular output.
+type CoinbaseClient interface {
+	// CoinbaseStatus reports whether txid is a coinbase transaction and how
+	// many confirmations it has.
+	CoinbaseStatus(ctx context.Context, txid string) (coinbase bool, confirmations uint32, err error)
+}
+
+// IsImmature returns true for a coinbase output with fewer than
+// CoinbaseMaturity confirmations.
+func (u *StoredUTXO) IsImmature() bool {
+	return u.Coinbase && u.Confirmations < CoinbaseMaturity
+}
+
+// ConfirmationsToMaturity returns how many more confirmations an immature
+// coinbase output needs, or 0 when it is spendable.
+func (u *StoredUTXO) ConfirmationsToMaturity() uint32 {
+	if !u.IsImmature() {
+		return 0
+	}
+	return CoinbaseMaturity - u.Confirmations
+}
+
+// IsImmature returns true if the UTXO is a known coinbase output that has not
+// reached CoinbaseMaturity confirmations. Unknown UTXOs are not immature.
+func (s *Store) IsImmature(chainID chain.ID, txid string, vout uint32) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	key := fmt.Sprintf("%s:%s:%d", chainID, txid, vout)
+	utxo, exists := s.data.UTXOs[key]
+	return exists && !utxo.Spent && utxo.IsImmature()
+}
+
+// GetImmatureBalance returns the total of unspent immature coinbase outputs
+// for a chain and optional address filter. GetBalance includes this amount.
+func (s *Store) GetImmatureBalance(chainID chain.ID, address string) uint64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var total uint64
+	for _, utxo := range s.data.UTXOs {
+		if utxo.ChainID != chainID || utxo.Spent || !utxo.IsImmature() {
+			continue
+		}
+		if address == "" || utxo.Address == address {
+			total += utxo.Amount
+		}
+	}
+	return total
+}
+
+// updateMaturity looks up the coinbase status of UTXOs that have not been
+// checked yet, and refreshes the confirmations of immature coinbase outputs,
+// when client implements CoinbaseClient. Each transaction is looked up once.
+func (s *Store) updateMaturity(ctx context.Context, chainID chain.ID, client ChainClient, result *ScanResult) {
+	cc, ok := client.(CoinbaseClient)
+	if !ok {
+		return
+	}
+
+	for _, txid := range s.maturityCandidates(chainID) {
+		if ctx.Err() != nil {
+			return
+		}
+
+		coinbase, confirmations, err := cc.CoinbaseStatus(ctx, txid)
+		if errors.Is(err, ErrCoinbaseUnsupported) {
+			return
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("coinbase check %s: %w", txid, err))
+			continue
+		}
+		s.setCoinbaseStatus(chainID, txid, coinbase, confirmations)
+	}
+}
+
+// maturityCandidates returns the transactions whose unspent outputs need a
+// coinbase lookup.
+func (s *Store) maturityCandidates(chainID chain.ID) []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	seen := make(map[string]bool)
+	var txids []string
+	for _, utxo := range s.data.UTXOs {
+		if utxo.ChainID != chainID || utxo.Spent || seen[utxo.TxID] {
+			continue
+		}
+		if !utxo.CoinbaseChecked || utxo.IsImmature() {
+			seen[utxo.TxID] = true
+			txids = append(txids, utxo.TxID)
+		}
+	}
+	return txids
+}
+
+// setCoinbaseStatus records the coinbase lookup result on every output of txid.
+func (s *Store) setCoinbaseStatus(chainID chain.ID, txid string, coinbase bool, confirmations uint32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, utxo := range s.data.UTXOs {
+		if utxo.ChainID != chainID || utxo.TxID != txid {
+			continue
+		}
+		utxo.CoinbaseChecked = true
+		utxo.Coinbase = coinbase
+		if coinbase {
+			utxo.Confirmations = confirmations
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package utxostore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// coinbaseStatus is a canned CoinbaseStatus answer.
+type coinbaseStatus struct {
+	coinbase      bool
+	confirmations uint32
+	err           error
+}
+
+// mockCoinbaseClient is a mockChainClient that also implements CoinbaseClient.
+type mockCoinbaseClient struct {
+	*mockChainClient
+
+	status  map[string]coinbaseStatus
+	lookups map[string]int
+}
+
+func newMockCoinbaseClient() *mockCoinbaseClient {
+	return &mockCoinbaseClient{
+		mockChainClient: newMockClient(),
+		status:          make(map[string]coinbaseStatus),
+		lookups:         make(map[string]int),
+	}
+}
+
+func (m *mockCoinbaseClient) CoinbaseStatus(_ context.Context, txid string) (bool, uint32, error) {
+	m.lookups[txid]++
+	st := m.status[txid]
+	return st.coinbase, st.confirmations, st.err
+}
+
+func TestStoredUTXO_Maturity(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		utxo       StoredUTXO
+		immature   bool
+		toMaturity uint32
+	}{
+		{"regular output", StoredUTXO{Confirmations: 1}, false, 0},
+		{"fresh coinbase", StoredUTXO{Coinbase: true, Confirmations: 1}, true, 99},
+		{"coinbase one short", StoredUTXO{Coinbase: true, Confirmations: 99}, true, 1},
+		{"mature coinbase", StoredUTXO{Coinbase: true, Confirmations: CoinbaseMaturity}, false, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.immature, tc.utxo.IsImmature())
+			assert.Equal(t, tc.toMaturity, tc.utxo.ConfirmationsToMaturity())
+		})
+	}
+}
+
+func TestScanWallet_CoinbaseMaturity(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+	client := newMockCoinbaseClient()
+
+	reward := testTxID(1)
+	payment := testTxID(2)
+	client.setUTXOs("addr0", []chain.UTXO{
+		{TxID: reward, Vout: 0, Amount: 625000000, Address: "addr0"},
+		{TxID: payment, Vout: 1, Amount: 5000, Address: "addr0"},
+	})
+	client.status[reward] = coinbaseStatus{coinbase: true, confirmations: 10}
+	client.status[payment] = coinbaseStatus{confirmations: 3}
+
+	w := &wallet.Wallet{Addresses: map[chain.ID][]wallet.Address{chain.BSV: {{Address: "addr0"}}}}
+
+	_, err := store.ScanWallet(context.Background(), w, chain.BSV, client)
+	require.NoError(t, err)
+
+	assert.True(t, store.IsImmature(chain.BSV, reward, 0))
+	assert.False(t, store.IsImmature(chain.BSV, payment, 1))
+	assert.Equal(t, uint64(625000000), store.GetImmatureBalance(chain.BSV, ""))
+	assert.Equal(t, uint64(625000000), store.GetImmatureBalance(chain.BSV, "addr0"))
+	assert.Equal(t, uint64(0), store.GetImmatureBalance(chain.BSV, "addr1"))
+	assert.Equal(t, uint64(625005000), store.GetBalance(chain.BSV), "total balance includes immature outputs")
+
+	// A later refresh re-checks only the immature coinbase, which has now matured
+	client.status[reward] = coinbaseStatus{coinbase: true, confirmations: CoinbaseMaturity}
+	_, err = store.Refresh(context.Background(), chain.BSV, client)
+	require.NoError(t, err)
+
+	assert.False(t, store.IsImmature(chain.BSV, reward, 0))
+	assert.Equal(t, uint64(0), store.GetImmatureBalance(chain.BSV, ""))
+	assert.Equal(t, 2, client.lookups[reward])
+	assert.Equal(t, 1, client.lookups[payment], "regular outputs are looked up once")
+
+	utxos := store.GetUTXOs(chain.BSV, "addr0")
+	for _, u := range utxos {
+		if u.TxID == reward {
+			assert.True(t, u.Coinbase, "coinbase flag survives refresh")
+			assert.Equal(t, uint32(CoinbaseMaturity), u.Confirmations)
+		}
+	}
+}
+
+func TestScanWallet_CoinbaseLookupError(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+	client := newMockCoinbaseClient()
+
+	txid := testTxID(1)
+	client.setUTXOs("addr0", []chain.UTXO{{TxID: txid, Vout: 0, Amount: 1000, Address: "addr0"}})
+	client.status[txid] = coinbaseStatus{err: errNetwork}
+
+	w := &wallet.Wallet{Addresses: map[chain.ID][]wallet.Address{chain.BSV: {{Address: "addr0"}}}}
+
+	result, err := store.ScanWallet(context.Background(), w, chain.BSV, client)
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0].Error(), "coinbase check")
+
+	// The output stays unchecked and is looked up again on the next scan
+	client.status[txid] = coinbaseStatus{}
+	_, err = store.ScanWallet(context.Background(), w, chain.BSV, client)
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.lookups[txid])
+}
+
+func TestScanWallet_CoinbaseUnsupported(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+	client := newMockCoinbaseClient()
+
+	client.setUTXOs("addr0", []chain.UTXO{
+		{TxID: testTxID(1), Vout: 0, Amount: 1000, Address: "addr0"},
+		{TxID: testTxID(2), Vout: 0, Amount: 1000, Address: "addr0"},
+	})
+	client.status[testTxID(1)] = coinbaseStatus{err: ErrCoinbaseUnsupported}
+	client.status[testTxID(2)] = coinbaseStatus{err: ErrCoinbaseUnsupported}
+
+	w := &wallet.Wallet{Addresses: map[chain.ID][]wallet.Address{chain.BSV: {{Address: "addr0"}}}}
+
+	result, err := store.ScanWallet(context.Background(), w, chain.BSV, client)
+	require.NoError(t, err)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, client.lookups[testTxID(1)]+client.lookups[testTxID(2)], "lookups stop after the first unsupported answer")
+}
+
+func TestAddUTXO_KeepsMaturity(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: testTxID(1), Amount: 1000, Coinbase: true, CoinbaseChecked: true, Confirmations: 50})
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: testTxID(1), Amount: 1000})
+
+	assert.True(t, store.IsImmature(chain.BSV, testTxID(1), 0))
+	utxos := store.GetUTXOs(chain.BSV, "")
+	require.Len(t, utxos, 1)
+	assert.True(t, utxos[0].CoinbaseChecked)
+	assert.Equal(t, uint32(50), utxos[0].Confirmations)
+}
@@ -0,0 +1,99 @@
+package utxostore
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// Actions recorded in the policy log.
+const (
+	// PolicyLock marks an address receive-only.
+	PolicyLock = "lock"
+	// PolicyUnlock lifts the receive-only restriction of an address.
+	PolicyUnlock = "unlock"
+)
+
+// PolicyEvent is one change to the spending policy of an address. The store
+// only appends them, so the log shows who could spend what and when.
+type PolicyEvent struct {
+	Time    time.Time `json:"time"`
+	ChainID chain.ID  `json:"chain_id"`
+	Address string    `json:"address"`
+	Action  string    `json:"action"` // PolicyLock or PolicyUnlock
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// SetReceiveOnly marks an address receive-only, or lifts the restriction,
+// and appends the change to the policy log with reason. Sends never spend
+// the UTXOs of a receive-only address. Setting the state an address already
+// has changes nothing and returns false.
+// Returns error if the address is not found.
+func (s *Store) SetReceiveOnly(chainID chain.ID, address string, receiveOnly bool, reason string, at time.Time) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addr, exists := s.data.Addresses[fmt.Sprintf("%s:%s", chainID, address)]
+	if !exists {
+		return false, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+	if addr.ReceiveOnly == receiveOnly {
+		return false, nil
+	}
+
+	addr.ReceiveOnly = receiveOnly
+	action := PolicyUnlock
+	if receiveOnly {
+		action = PolicyLock
+	}
+	s.data.PolicyLog = append(s.data.PolicyLog, PolicyEvent{
+		Time:    at,
+		ChainID: chainID,
+		Address: address,
+		Action:  action,
+		Reason:  strings.TrimSpace(reason),
+	})
+	return true, nil
+}
+
+// IsReceiveOnly returns true if the address is marked receive-only.
+// Unknown addresses are not.
+func (s *Store) IsReceiveOnly(chainID chain.ID, address string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	addr, exists := s.data.Addresses[fmt.Sprintf("%s:%s", chainID, address)]
+	return exists && addr.ReceiveOnly
+}
+
+// ReceiveOnlyAddresses returns the receive-only addresses of every chain,
+// sorted by chain and address.
+func (s *Store) ReceiveOnlyAddresses() []*AddressMetadata {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*AddressMetadata
+	for _, addr := range s.data.Addresses {
+		if addr.ReceiveOnly {
+			result = append(result, addr)
+		}
+	}
+	slices.SortFunc(result, func(a, b *AddressMetadata) int {
+		if c := strings.Compare(string(a.ChainID), string(b.ChainID)); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Address, b.Address)
+	})
+	return result
+}
+
+// PolicyLog returns a copy of the policy log, oldest first.
+func (s *Store) PolicyLog() []PolicyEvent {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return slices.Clone(s.data.PolicyLog)
+}
@@ -0,0 +1,61 @@
+package utxostore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestStore_SetReceiveOnly(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	store := New(tmpDir)
+
+	reserve, other := testAddressN(0), testAddressN(1)
+	store.AddAddress(createTestAddress(chain.BSV, reserve, 0, false))
+	store.AddAddress(createTestAddress(chain.BSV, other, 1, false))
+
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	changed, err := store.SetReceiveOnly(chain.BSV, reserve, true, " proof of reserves ", at)
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, store.IsReceiveOnly(chain.BSV, reserve))
+	assert.False(t, store.IsReceiveOnly(chain.BSV, other))
+	assert.False(t, store.IsReceiveOnly(chain.BSV, "unknown"))
+
+	// Locking again changes nothing and logs nothing
+	changed, err = store.SetReceiveOnly(chain.BSV, reserve, true, "", at)
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	// A refresh rewriting the metadata keeps the restriction
+	store.AddAddress(createTestAddress(chain.BSV, reserve, 0, false))
+	assert.True(t, store.IsReceiveOnly(chain.BSV, reserve))
+	require.NoError(t, store.Save())
+
+	reloaded := New(tmpDir)
+	require.NoError(t, reloaded.Load())
+	locked := reloaded.ReceiveOnlyAddresses()
+	require.Len(t, locked, 1)
+	assert.Equal(t, reserve, locked[0].Address)
+
+	changed, err = reloaded.SetReceiveOnly(chain.BSV, reserve, false, "", at.Add(time.Hour))
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.False(t, reloaded.IsReceiveOnly(chain.BSV, reserve))
+
+	log := reloaded.PolicyLog()
+	require.Len(t, log, 2)
+	assert.Equal(t, PolicyLock, log[0].Action)
+	assert.Equal(t, "proof of reserves", log[0].Reason)
+	assert.True(t, log[0].Time.Equal(at))
+	assert.Equal(t, PolicyUnlock, log[1].Action)
+	assert.Equal(t, reserve, log[1].Address)
+
+	_, err = reloaded.SetReceiveOnly(chain.BSV, "unknown", true, "", at)
+	require.ErrorIs(t, err, ErrAddressNotFound)
+}
@@ -0,0 +1,105 @@
+package utxostore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// UTXODiscrepancy identifies a UTXO on which the store and the network disagree.
+type UTXODiscrepancy struct {
+	Address string `json:"address"`
+	TxID    string `json:"txid"`
+	Vout    uint32 `json:"vout"`
+	Amount  uint64 `json:"amount"`
+}
+
+// ReconcileResult compares the stored UTXO set with the network.
+type ReconcileResult struct {
+	// AddressesChecked is the number of addresses queried.
+	AddressesChecked int `json:"addresses_checked"`
+
+	// MissingLocally lists UTXOs the network reports that the store lacks
+	// or has marked spent.
+	MissingLocally []UTXODiscrepancy `json:"missing_locally"`
+
+	// SpentOnNetwork lists UTXOs the store holds as unspent that the network
+	// no longer reports.
+	SpentOnNetwork []UTXODiscrepancy `json:"spent_on_network"`
+
+	// Errors contains per-address lookup failures. Addresses that fail are
+	// left out of the comparison.
+	Errors []error `json:"-"`
+}
+
+// InSync reports whether every checked address matched the network.
+func (r *ReconcileResult) InSync() bool {
+	return len(r.MissingLocally) == 0 && len(r.SpentOnNetwork) == 0
+}
+
+// Reconcile queries the network for every tracked address and reports where
+// the stored UTXO set differs. Unlike Refresh it never modifies the store.
+func (s *Store) Reconcile(ctx context.Context, chainID chain.ID, client ChainClient) (*ReconcileResult, error) {
+	result := &ReconcileResult{
+		MissingLocally: []UTXODiscrepancy{},
+		SpentOnNetwork: []UTXODiscrepancy{},
+	}
+
+	for _, addr := range s.getAddressesForChain(chainID) {
+		if ctx.Err() != nil {
+			return result, ctx.Err()
+		}
+
+		utxos, err := client.ListUTXOs(ctx, addr.Address)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Errorf("address %s: %w", addr.Address, err))
+			continue
+		}
+		result.AddressesChecked++
+
+		seen := make(map[string]bool, len(utxos))
+		for _, u := range utxos {
+			seen[fmt.Sprintf("%s:%d", u.TxID, u.Vout)] = true
+			if !s.isUnspent(chainID, u.TxID, u.Vout) {
+				result.MissingLocally = append(result.MissingLocally, UTXODiscrepancy{
+					Address: addr.Address, TxID: u.TxID, Vout: u.Vout, Amount: u.Amount,
+				})
+			}
+		}
+
+		for _, stored := range s.GetUTXOs(chainID, addr.Address) {
+			if !seen[fmt.Sprintf("%s:%d", stored.TxID, stored.Vout)] {
+				result.SpentOnNetwork = append(result.SpentOnNetwork, UTXODiscrepancy{
+					Address: stored.Address, TxID: stored.TxID, Vout: stored.Vout, Amount: stored.Amount,
+				})
+			}
+		}
+	}
+
+	sortDiscrepancies(result.MissingLocally)
+	sortDiscrepancies(result.SpentOnNetwork)
+	return result, nil
+}
+
+// isUnspent reports whether the store holds a UTXO and has not marked it spent.
+func (s *Store) isUnspent(chainID chain.ID, txid string, vout uint32) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	utxo, ok := s.data.UTXOs[fmt.Sprintf("%s:%s:%d", chainID, txid, vout)]
+	return ok && !utxo.Spent
+}
+
+// sortDiscrepancies orders discrepancies by address, then outpoint.
+func sortDiscrepancies(d []UTXODiscrepancy) {
+	sort.Slice(d, func(i, j int) bool {
+		if d[i].Address != d[j].Address {
+			return d[i].Address < d[j].Address
+		}
+		if d[i].TxID != d[j].TxID {
+			return d[i].TxID < d[j].TxID
+		}
+		return d[i].Vout < d[j].Vout
+	})
+}
@@ -0,0 +1,90 @@
+package utxostore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestReconcile(t *testing.T) {
+	t.Parallel()
+
+	const (
+		addrA = "1AddressAAAAAAAAAAAAAAAAAAAAAAAAAA"
+		addrB = "1AddressBBBBBBBBBBBBBBBBBBBBBBBBBB"
+		addrC = "1AddressCCCCCCCCCCCCCCCCCCCCCCCCCC"
+	)
+
+	store := createTestStore(t)
+	store.AddAddress(createTestAddress(chain.BSV, addrA, 0, false))
+	store.AddAddress(createTestAddress(chain.BSV, addrB, 1, false))
+	store.AddAddress(createTestAddress(chain.BSV, addrC, 2, false))
+
+	store.AddUTXO(createTestUTXO(chain.BSV, addrA, testTxID(1), 0, 1000, false)) // in sync
+	store.AddUTXO(createTestUTXO(chain.BSV, addrA, testTxID(2), 0, 2000, false)) // spent on network
+	store.AddUTXO(createTestUTXO(chain.BSV, addrB, testTxID(3), 1, 3000, true))  // spent locally, still on network
+
+	client := newMockClient()
+	client.setUTXOs(addrA, []chain.UTXO{
+		{TxID: testTxID(1), Vout: 0, Amount: 1000, Address: addrA},
+		{TxID: testTxID(4), Vout: 0, Amount: 4000, Address: addrA}, // unknown locally
+	})
+	client.setUTXOs(addrB, []chain.UTXO{
+		{TxID: testTxID(3), Vout: 1, Amount: 3000, Address: addrB},
+	})
+	client.setError(addrC, errNetwork)
+
+	result, err := store.Reconcile(context.Background(), chain.BSV, client)
+	require.NoError(t, err)
+
+	assert.False(t, result.InSync())
+	assert.Equal(t, 2, result.AddressesChecked)
+	require.Len(t, result.Errors, 1)
+	require.ErrorIs(t, result.Errors[0], errNetwork)
+
+	assert.Equal(t, []UTXODiscrepancy{
+		{Address: addrA, TxID: testTxID(4), Vout: 0, Amount: 4000},
+		{Address: addrB, TxID: testTxID(3), Vout: 1, Amount: 3000},
+	}, result.MissingLocally)
+	assert.Equal(t, []UTXODiscrepancy{
+		{Address: addrA, TxID: testTxID(2), Vout: 0, Amount: 2000},
+	}, result.SpentOnNetwork)
+
+	// The store is not modified
+	assert.Len(t, store.GetUTXOs(chain.BSV, addrA), 2)
+	assert.True(t, store.IsSpent(chain.BSV, testTxID(3), 1))
+}
+
+func TestReconcile_InSync(t *testing.T) {
+	t.Parallel()
+
+	const addr = "1AddressAAAAAAAAAAAAAAAAAAAAAAAAAA"
+	store := createTestStore(t)
+	store.AddAddress(createTestAddress(chain.BSV, addr, 0, false))
+	store.AddUTXO(createTestUTXO(chain.BSV, addr, testTxID(1), 0, 1000, false))
+
+	client := newMockClient()
+	client.setUTXOs(addr, []chain.UTXO{{TxID: testTxID(1), Vout: 0, Amount: 1000, Address: addr}})
+
+	result, err := store.Reconcile(context.Background(), chain.BSV, client)
+	require.NoError(t, err)
+	assert.True(t, result.InSync())
+	assert.Equal(t, 1, result.AddressesChecked)
+	assert.Empty(t, result.Errors)
+}
+
+func TestReconcile_Canceled(t *testing.T) {
+	t.Parallel()
+
+	store := createTestStore(t)
+	store.AddAddress(createTestAddress(chain.BSV, "1AddressAAAAAAAAAAAAAAAAAAAAAAAAAA", 0, false))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := store.Reconcile(ctx, chain.BSV, newMockClient())
+	require.ErrorIs(t, err, context.Canceled)
+}
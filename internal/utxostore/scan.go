@@ -3,6 +3,7 @@ package utxostore
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/mrz1836/sigil/internal/chain"
@@ -47,38 +48,56 @@ type ScanResult struct {
 	TotalBalance uint64
 
 	// Errors contains any errors encountered during scanning.
-	// Scanning continues even if some addresses fail.
+	// Scanning continues even if some addresses fail. Errors from before a
+	// resumed checkpoint are not included.
 	Errors []error
+
+	// ResumedAt is the address index the scan resumed from, or 0 when it
+	// started from the first address.
+	ResumedAt int
 }
 
 // ScanWallet scans a wallet's addresses and stores discovered UTXOs.
 // Uses gap limit: stops after DefaultGapLimit consecutive addresses with no UTXOs.
 // This implements BIP44 address discovery.
+//
+// Only the addresses of w's account are scanned; pass an account view (see
+// wallet.Wallet.Account) to scan another account. Each account keeps its own
+// gap counter and checkpoint.
+//
+// Progress is checkpointed in the store, so a scan that is cancelled or
+// fails to save resumes from the checkpoint on the next call.
 func (s *Store) ScanWallet(ctx context.Context, w *wallet.Wallet, chainID chain.ID, client ChainClient) (*ScanResult, error) {
 	addresses, ok := w.Addresses[chainID]
 	if !ok || len(addresses) == 0 {
 		return &ScanResult{}, nil
 	}
 
-	result := &ScanResult{}
-	consecutiveEmpty := 0
+	key := checkpointKey(chainID, w.AccountIndex())
+	cp := s.resumeScan(key, addresses)
+	result := cp.result()
+	consecutiveEmpty := cp.ConsecutiveEmpty
 
-	for _, addr := range addresses {
+	for i := cp.NextIndex; i < len(addresses) && consecutiveEmpty < DefaultGapLimit; i++ {
 		if ctx.Err() != nil {
-			return result, ctx.Err()
+			return result, s.interruptScan(key, cp, ctx.Err())
 		}
 
-		hasActivity := s.scanAddress(ctx, addr, chainID, client, result)
+		hasActivity := s.scanAddress(ctx, addresses[i], chainID, client, result)
+		if ctx.Err() != nil {
+			// The lookup may have been cut short; scan this address again on resume
+			return result, s.interruptScan(key, cp, ctx.Err())
+		}
 		consecutiveEmpty = s.updateGapCounter(consecutiveEmpty, hasActivity)
 
-		if consecutiveEmpty >= DefaultGapLimit {
-			break
+		if err := s.scanProgressed(chainID, key, addresses, i, consecutiveEmpty, cp, result); err != nil {
+			return result, err
 		}
 	}
 
-	// Save after scan
-	if err := s.Save(); err != nil {
-		return result, fmt.Errorf("saving UTXOs: %w", err)
+	s.updateMaturity(ctx, chainID, client, result)
+	if err := s.finishScan(key); err != nil {
+		return result, err
 	}
 
 	return result, nil
@@ -151,6 +170,9 @@ func (s *Store) updateGapCounter(current int, hasActivity bool) int {
 
 // Refresh re-scans all known addresses and merges changes.
 // New UTXOs are added; UTXOs no longer in chain response are marked spent.
+//
+// A cancelled refresh saves the UTXOs found so far but marks nothing spent,
+// since the addresses it did not reach were not seen.
 func (s *Store) Refresh(ctx context.Context, chainID chain.ID, client ChainClient) (*ScanResult, error) {
 	// Get addresses to scan (copy under lock)
 	addresses := s.getAddressesForChain(chainID)
@@ -164,14 +186,18 @@ func (s *Store) Refresh(ctx context.Context, chainID chain.ID, client ChainClien
 	// Scan all known addresses
 	for _, addr := range addresses {
 		if ctx.Err() != nil {
-			return result, ctx.Err()
+			return result, s.interruptRefresh(ctx.Err())
 		}
 
 		s.refreshAddress(ctx, addr, chainID, client, result, seenUTXOs)
 	}
+	if ctx.Err() != nil {
+		return result, s.interruptRefresh(ctx.Err())
+	}
 
 	// Mark UTXOs not seen in this scan as spent
 	s.markMissingAsSpent(chainID, seenUTXOs)
+	s.updateMaturity(ctx, chainID, client, result)
 
 	// Save changes
 	if err := s.Save(); err != nil {
@@ -181,6 +207,14 @@ func (s *Store) Refresh(ctx context.Context, chainID chain.ID, client ChainClien
 	return result, nil
 }
 
+// interruptRefresh saves a refresh stopped by err and returns err.
+func (s *Store) interruptRefresh(err error) error {
+	if saveErr := s.Save(); saveErr != nil {
+		return fmt.Errorf("%w (%w)", err, saveErr)
+	}
+	return err
+}
+
 // getAddressesForChain returns a copy of addresses for a chain.
 func (s *Store) getAddressesForChain(chainID chain.ID) []*AddressMetadata {
 	s.mu.RLock()
@@ -214,6 +248,7 @@ func (s *Store) refreshAddress(ctx context.Context, addr *AddressMetadata, chain
 		DerivationPath: addr.DerivationPath,
 		Index:          addr.Index,
 		Label:          addr.Label,
+		Tags:           slices.Clone(addr.Tags),
 		LastScanned:    time.Now(),
 		HasActivity:    addr.HasActivity || len(utxos) > 0,
 	}
@@ -258,9 +293,14 @@ func (s *Store) RefreshAddress(ctx context.Context, address string, chainID chai
 
 	// Refresh the single address
 	s.refreshAddress(ctx, addr, chainID, client, result, seenUTXOs)
+	if ctx.Err() != nil {
+		// The address was not seen, so none of its UTXOs can be marked spent
+		return result, ctx.Err()
+	}
 
 	// Mark UTXOs for this address that weren't seen as spent
 	s.markAddressUTXOsAsSpent(address, chainID, seenUTXOs)
+	s.updateMaturity(ctx, chainID, client, result)
 
 	// Save changes
 	if err := s.Save(); err != nil {
@@ -325,37 +365,50 @@ func (s *Store) ScanWalletBulk(ctx context.Context, w *wallet.Wallet, chainID ch
 		return &ScanResult{}, nil
 	}
 
-	result := &ScanResult{}
-	consecutiveEmpty := 0
+	key := checkpointKey(chainID, w.AccountIndex())
+	cp := s.resumeScan(key, addresses)
+	result := cp.result()
+	consecutiveEmpty := cp.ConsecutiveEmpty
+	if consecutiveEmpty >= DefaultGapLimit {
+		return result, s.finishScan(key)
+	}
 
-	// Extract address strings
-	addrStrings := make([]string, 0, len(addresses))
-	addrMap := make(map[string]wallet.Address)
-	for _, addr := range addresses {
-		addrStrings = append(addrStrings, addr.Address)
-		addrMap[addr.Address] = addr
+	// Extract address strings, skipping those scanned before the checkpoint
+	addrStrings := make([]string, 0, len(addresses)-cp.NextIndex)
+	addrIndex := make(map[string]int)
+	for i := cp.NextIndex; i < len(addresses); i++ {
+		addrStrings = append(addrStrings, addresses[i].Address)
+		addrIndex[addresses[i].Address] = i
 	}
 
 	// Fetch UTXOs using bulk operations
 	bulkResults, err := bulkClient.BulkAddressUTXOFetch(ctx, addrStrings)
 	if err != nil {
-		// Fall back to individual scanning
+		// Fall back to individual scanning, which resumes from the same checkpoint
 		return s.ScanWallet(ctx, w, chainID, bulkClient)
 	}
 
 	// Process bulk results
 	for _, bulkResult := range bulkResults {
 		if ctx.Err() != nil {
-			return result, ctx.Err()
+			return result, s.interruptScan(key, cp, ctx.Err())
+		}
+
+		i, ok := addrIndex[bulkResult.Address]
+		if !ok {
+			continue
 		}
 
 		if bulkResult.Error != nil {
 			result.Errors = append(result.Errors, fmt.Errorf("address %s: %w", bulkResult.Address, bulkResult.Error))
 			consecutiveEmpty++
+			if err := s.scanProgressed(chainID, key, addresses, i, consecutiveEmpty, cp, result); err != nil {
+				return result, err
+			}
 			continue
 		}
 
-		addr := addrMap[bulkResult.Address]
+		addr := addresses[i]
 		result.AddressesScanned++
 
 		// Combine confirmed and unconfirmed UTXOs
@@ -372,22 +425,27 @@ func (s *Store) ScanWalletBulk(ctx context.Context, w *wallet.Wallet, chainID ch
 			s.storeUTXOs(allUTXOs, chainID, result)
 		}
 
+		if err := s.scanProgressed(chainID, key, addresses, i, consecutiveEmpty, cp, result); err != nil {
+			return result, err
+		}
+
 		// Check gap limit
 		if consecutiveEmpty >= DefaultGapLimit {
 			break
 		}
 	}
 
-	// Save after scan
-	if err := s.Save(); err != nil {
-		return result, fmt.Errorf("saving UTXOs: %w", err)
+	s.updateMaturity(ctx, chainID, bulkClient, result)
+	if err := s.finishScan(key); err != nil {
+		return result, err
 	}
 
 	return result, nil
 }
 
 // RefreshBulk re-scans addresses using bulk operations.
-// Much faster than Refresh for many addresses.
+// Much faster than Refresh for many addresses. Like Refresh, a cancelled
+// refresh saves what it found without marking anything spent.
 //
 //nolint:gocognit // Bulk refresh logic inherently complex
 func (s *Store) RefreshBulk(ctx context.Context, chainID chain.ID, bulkClient BulkChainClient) (*ScanResult, error) {
@@ -418,7 +476,7 @@ func (s *Store) RefreshBulk(ctx context.Context, chainID chain.ID, bulkClient Bu
 	// Process bulk results
 	for _, bulkResult := range bulkResults {
 		if ctx.Err() != nil {
-			return result, ctx.Err()
+			return result, s.interruptRefresh(ctx.Err())
 		}
 
 		if bulkResult.Error != nil {
@@ -436,6 +494,7 @@ func (s *Store) RefreshBulk(ctx context.Context, chainID chain.ID, bulkClient Bu
 			DerivationPath: addr.DerivationPath,
 			Index:          addr.Index,
 			Label:          addr.Label,
+			Tags:           slices.Clone(addr.Tags),
 			LastScanned:    time.Now(),
 			HasActivity:    addr.HasActivity,
 		}
@@ -472,6 +531,7 @@ func (s *Store) RefreshBulk(ctx context.Context, chainID chain.ID, bulkClient Bu
 
 	// Mark UTXOs not seen in this scan as spent
 	s.markMissingAsSpent(chainID, seenUTXOs)
+	s.updateMaturity(ctx, chainID, bulkClient, result)
 
 	// Save changes
 	if err := s.Save(); err != nil {
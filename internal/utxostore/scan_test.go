@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -280,6 +281,50 @@ func TestRefreshNoAddresses(t *testing.T) {
 	assert.Equal(t, 0, result.UTXOsFound)
 }
 
+func TestRefresh_CanceledSavesProgress(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	store := New(tmpDir)
+	mock := newMockClient()
+
+	store.AddAddress(&AddressMetadata{ChainID: chain.BSV, Address: "addr0"})
+	store.AddAddress(&AddressMetadata{ChainID: chain.BSV, Address: "addr1"})
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: "old1", Vout: 0, Amount: 1000, Address: "addr0"})
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: "old2", Vout: 0, Amount: 2000, Address: "addr1"})
+	mock.setUTXOs("addr0", []chain.UTXO{{TxID: "new1", Vout: 0, Amount: 500, Address: "addr0"}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &cancelingChainClient{mockChainClient: mock, cancelAt: "addr1", cancel: cancel}
+
+	_, err := store.Refresh(ctx, chain.BSV, client)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The progress is on disk, and nothing was marked spent
+	reloaded := New(tmpDir)
+	require.NoError(t, reloaded.Load())
+	balance := uint64(3000)
+	if slices.Contains(client.scanned, "addr0") {
+		balance += 500
+	}
+	assert.Equal(t, balance, reloaded.GetBalance(chain.BSV))
+}
+
+func TestRefreshAddress_Canceled(t *testing.T) {
+	t.Parallel()
+	store := New(t.TempDir())
+	mock := newMockClient()
+	store.AddUTXO(&StoredUTXO{ChainID: chain.BSV, TxID: "old1", Vout: 0, Amount: 1000, Address: "addr0"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := &cancelingChainClient{mockChainClient: mock, cancelAt: "addr0", cancel: cancel}
+
+	_, err := store.RefreshAddress(ctx, "addr0", chain.BSV, client)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, uint64(1000), store.GetBalance(chain.BSV), "an unseen address keeps its UTXOs")
+}
+
 func TestRefreshPreservesSpentHistory(t *testing.T) {
 	t.Parallel()
 	tmpDir := t.TempDir()
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -351,6 +352,38 @@ func TestState_AddressLabelUpdate(t *testing.T) {
 	require.ErrorIs(t, err, ErrAddressNotFound)
 }
 
+// TestState_MarkPendingReceive tests marking an address handed out to receive.
+func TestState_MarkPendingReceive(t *testing.T) {
+	t.Parallel()
+	store := createTestStore(t)
+
+	addr := testAddressN(0)
+	store.AddAddress(createTestAddress(chain.BSV, addr, 0, false))
+	assert.False(t, store.GetAddress(chain.BSV, addr).AwaitingReceive())
+
+	at := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, store.MarkPendingReceive(chain.BSV, addr, at))
+	require.NoError(t, store.Save())
+
+	reloaded := New(store.walletPath)
+	require.NoError(t, reloaded.Load())
+	meta := reloaded.GetAddress(chain.BSV, addr)
+	require.NotNil(t, meta)
+	assert.True(t, meta.PendingReceive.Equal(at))
+	assert.True(t, meta.AwaitingReceive())
+
+	// A refresh rewriting the metadata keeps the mark
+	reloaded.AddAddress(createTestAddress(chain.BSV, addr, 0, false))
+	assert.True(t, reloaded.GetAddress(chain.BSV, addr).AwaitingReceive())
+
+	// A payment ends the wait
+	meta = reloaded.GetAddress(chain.BSV, addr)
+	meta.HasActivity = true
+	assert.False(t, meta.AwaitingReceive())
+
+	require.ErrorIs(t, store.MarkPendingReceive(chain.BSV, "nonexistent", at), ErrAddressNotFound)
+}
+
 // TestState_UTXOUpdateInPlace tests updating an existing UTXO.
 func TestState_UTXOUpdateInPlace(t *testing.T) {
 	t.Parallel()
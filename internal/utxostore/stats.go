@@ -0,0 +1,74 @@
+package utxostore
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// ChainStats counts what the store holds for one chain.
+type ChainStats struct {
+	Chain         chain.ID `json:"chain"`
+	Unspent       int      `json:"unspent"`
+	Spent         int      `json:"spent"`
+	Balance       uint64   `json:"balance"` // satoshis in unspent outputs
+	Addresses     int      `json:"addresses"`
+	UsedAddresses int      `json:"used_addresses"`
+
+	// ScanInProgress is true when an unfinished scan left a checkpoint.
+	ScanInProgress bool `json:"scan_in_progress,omitempty"`
+}
+
+// Stats summarizes the store without any addresses or transaction IDs.
+type Stats struct {
+	UpdatedAt time.Time    `json:"updated_at"`
+	Chains    []ChainStats `json:"chains"`
+}
+
+// Stats returns per-chain counts of the store's outputs and addresses,
+// ordered by chain.
+func (s *Store) Stats() Stats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byChain := map[chain.ID]*ChainStats{}
+	get := func(id chain.ID) *ChainStats {
+		cs, ok := byChain[id]
+		if !ok {
+			cs = &ChainStats{Chain: id}
+			byChain[id] = cs
+		}
+		return cs
+	}
+
+	for _, u := range s.data.UTXOs {
+		cs := get(u.ChainID)
+		if u.Spent {
+			cs.Spent++
+			continue
+		}
+		cs.Unspent++
+		cs.Balance += u.Amount
+	}
+	for _, a := range s.data.Addresses {
+		cs := get(a.ChainID)
+		cs.Addresses++
+		if a.HasActivity {
+			cs.UsedAddresses++
+		}
+	}
+	for key := range s.data.ScanCheckpoints {
+		// Account scans are keyed "chain/account"
+		id, _, _ := strings.Cut(string(key), "/")
+		get(chain.ID(id)).ScanInProgress = true
+	}
+
+	stats := Stats{UpdatedAt: s.data.UpdatedAt, Chains: make([]ChainStats, 0, len(byChain))}
+	for _, cs := range byChain {
+		stats.Chains = append(stats.Chains, *cs)
+	}
+	sort.Slice(stats.Chains, func(i, j int) bool { return stats.Chains[i].Chain < stats.Chains[j].Chain })
+	return stats
+}
@@ -0,0 +1,36 @@
+package utxostore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestStore_Stats(t *testing.T) {
+	t.Parallel()
+
+	store := createTestStore(t)
+	assert.Empty(t, store.Stats().Chains)
+
+	store.AddUTXO(createTestUTXO(chain.BSV, testAddressN(0), testTxID(1), 0, 1000, false))
+	store.AddUTXO(createTestUTXO(chain.BSV, testAddressN(0), testTxID(2), 1, 500, false))
+	store.AddUTXO(createTestUTXO(chain.BSV, testAddressN(1), testTxID(3), 0, 700, true))
+	store.AddUTXO(createTestUTXO(chain.BTC, testAddressN(2), testTxID(4), 0, 42, false))
+	used := createTestAddress(chain.BSV, testAddressN(0), 0, false)
+	used.HasActivity = true
+	store.AddAddress(used)
+	store.AddAddress(createTestAddress(chain.BSV, testAddressN(1), 1, false))
+	require.NoError(t, store.saveScanCheckpoint(chain.BTC, &ScanCheckpoint{NextIndex: 1, LastAddress: testAddressN(2)}))
+
+	stats := store.Stats()
+	require.Len(t, stats.Chains, 2)
+	assert.Equal(t, ChainStats{
+		Chain: chain.BSV, Unspent: 2, Spent: 1, Balance: 1500, Addresses: 2, UsedAddresses: 1,
+	}, stats.Chains[0])
+	assert.Equal(t, chain.BTC, stats.Chains[1].Chain)
+	assert.Equal(t, uint64(42), stats.Chains[1].Balance)
+	assert.True(t, stats.Chains[1].ScanInProgress)
+}
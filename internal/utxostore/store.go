@@ -25,8 +25,8 @@ const (
 	// utxoFileName is the name of the UTXO storage file.
 	utxoFileName = "utxos.json"
 
-	// currentVersion is the current file format version.
-	currentVersion = 1
+	// CurrentVersion is the utxos.json format version this build writes.
+	CurrentVersion = 1
 
 	// filePermissions for utxos.json
 	filePermissions = 0o600
@@ -47,7 +47,11 @@ type StoredUTXO struct {
 	Spent       bool      `json:"spent"`
 	SpentTxID   string    `json:"spent_txid,omitempty"` // txid that spent this UTXO
 	FirstSeen   time.Time `json:"first_seen"`
-	LastUpdated time.Time `json:"last_updated"`
+	LastUpdated time.Time `json:"last_updated"` // for spent UTXOs, when they were marked spent
+
+	// Maturity: coinbase outputs cannot be spent before CoinbaseMaturity confirmations
+	Coinbase        bool `json:"coinbase,omitempty"`         // Output of a coinbase (block reward) transaction
+	CoinbaseChecked bool `json:"coinbase_checked,omitempty"` // Coinbase status has been looked up
 }
 
 // Key returns the unique identifier for this UTXO (chainID:txid:vout)
@@ -61,12 +65,25 @@ type AddressMetadata struct {
 	ChainID        chain.ID `json:"chain_id"`
 	DerivationPath string   `json:"derivation_path"`
 	Index          uint32   `json:"index"`
-	Label          string   `json:"label,omitempty"`     // User-defined label
-	IsChange       bool     `json:"is_change,omitempty"` // True for change addresses (internal chain)
+	Label          string   `json:"label,omitempty"`        // User-defined label
+	Tags           []string `json:"tags,omitempty"`         // Hierarchical tags such as "customer:acme", sorted
+	IsChange       bool     `json:"is_change,omitempty"`    // True for change addresses (internal chain)
+	ReceiveOnly    bool     `json:"receive_only,omitempty"` // Sends never spend its UTXOs (see SetReceiveOnly)
+	Envelope       string   `json:"envelope,omitempty"`     // Budget envelope the address's funds belong to (see SetAddressEnvelope)
 
 	// Scan state
 	LastScanned time.Time `json:"last_scanned,omitempty"`
 	HasActivity bool      `json:"has_activity"` // Has ever received funds
+
+	// PendingReceive is when `sigil receive` last handed the address out,
+	// zero if never. The address awaits a payment until it has activity.
+	PendingReceive time.Time `json:"pending_receive,omitempty"`
+}
+
+// AwaitingReceive reports whether the address was handed out to receive a
+// payment that has not arrived yet.
+func (a *AddressMetadata) AwaitingReceive() bool {
+	return !a.PendingReceive.IsZero() && !a.HasActivity
 }
 
 // Key returns the unique identifier for this address (chainID:address)
@@ -80,13 +97,23 @@ type UTXOFile struct {
 	UpdatedAt time.Time                   `json:"updated_at"`
 	UTXOs     map[string]*StoredUTXO      `json:"utxos"`     // key: chainID:txid:vout
 	Addresses map[string]*AddressMetadata `json:"addresses"` // key: chainID:address
+
+	// ScanCheckpoints holds the progress of unfinished wallet scans.
+	ScanCheckpoints map[chain.ID]*ScanCheckpoint `json:"scan_checkpoints,omitempty"`
+
+	// FilterSync holds the last block matched against compact block filters.
+	FilterSync map[chain.ID]*FilterSyncState `json:"filter_sync,omitempty"`
+
+	// PolicyLog records every change to the receive-only addresses.
+	PolicyLog []PolicyEvent `json:"policy_log,omitempty"`
 }
 
 // Store manages UTXO persistence for a single wallet.
 type Store struct {
-	walletPath string
-	mu         sync.RWMutex
-	data       *UTXOFile
+	walletPath     string
+	mu             sync.RWMutex
+	data           *UTXOFile
+	onScanProgress ScanProgressCallback
 }
 
 // New creates a new UTXOStore for the given wallet directory.
@@ -95,7 +122,7 @@ func New(walletPath string) *Store {
 	return &Store{
 		walletPath: walletPath,
 		data: &UTXOFile{
-			Version:   currentVersion,
+			Version:   CurrentVersion,
 			UpdatedAt: time.Now(),
 			UTXOs:     make(map[string]*StoredUTXO),
 			Addresses: make(map[string]*AddressMetadata),
@@ -123,8 +150,8 @@ func (s *Store) Load() error {
 	}
 
 	// Version migration (future-proofing)
-	if file.Version > currentVersion {
-		return fmt.Errorf("%w: version %d (supported %d)", ErrVersionTooNew, file.Version, currentVersion)
+	if file.Version > CurrentVersion {
+		return fmt.Errorf("%w: version %d (supported %d)", ErrVersionTooNew, file.Version, CurrentVersion)
 	}
 
 	s.data = &file
@@ -160,7 +187,8 @@ func (s *Store) GetUTXOs(chainID chain.ID, address string) []*StoredUTXO {
 	return result
 }
 
-// GetBalance returns total unspent balance for a chain.
+// GetBalance returns total unspent balance for a chain, including immature
+// coinbase outputs (see GetImmatureBalance).
 func (s *Store) GetBalance(chainID chain.ID) uint64 {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
@@ -223,14 +251,33 @@ func (s *Store) AddUTXO(utxo *StoredUTXO) {
 		utxo.FirstSeen = utxo.LastUpdated
 	}
 
+	// Keep maturity information; UTXO listings do not carry it
+	if existing, ok := s.data.UTXOs[utxo.Key()]; ok && existing.CoinbaseChecked && !utxo.CoinbaseChecked {
+		utxo.Coinbase = existing.Coinbase
+		utxo.CoinbaseChecked = true
+		utxo.Confirmations = max(utxo.Confirmations, existing.Confirmations)
+	}
+
 	s.data.UTXOs[utxo.Key()] = utxo
 }
 
-// AddAddress adds or updates address metadata.
+// AddAddress adds or updates address metadata. An update keeps the
+// pending-receive mark of the stored address, so a refresh does not hand the
+// address out again, and its receive-only restriction, which only
+// SetReceiveOnly lifts.
 func (s *Store) AddAddress(addr *AddressMetadata) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
+	if old, exists := s.data.Addresses[addr.Key()]; exists {
+		if old.PendingReceive.After(addr.PendingReceive) {
+			addr.PendingReceive = old.PendingReceive
+		}
+		addr.ReceiveOnly = addr.ReceiveOnly || old.ReceiveOnly
+		if addr.Envelope == "" {
+			addr.Envelope = old.Envelope
+		}
+	}
 	s.data.Addresses[addr.Key()] = addr
 }
 
@@ -250,6 +297,22 @@ func (s *Store) SetAddressLabel(chainID chain.ID, address, label string) error {
 	return nil
 }
 
+// MarkPendingReceive records that an address was handed out at at to
+// receive a payment.
+func (s *Store) MarkPendingReceive(chainID chain.ID, address string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := fmt.Sprintf("%s:%s", chainID, address)
+	addr, exists := s.data.Addresses[key]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+
+	addr.PendingReceive = at
+	return nil
+}
+
 // GetAddressBalance returns the total unspent balance for a specific address.
 func (s *Store) GetAddressBalance(chainID chain.ID, address string) uint64 {
 	s.mu.RLock()
@@ -330,7 +393,7 @@ func (s *Store) IsSpent(chainID chain.ID, txid string, vout uint32) bool {
 // Caller must hold s.mu.Lock().
 func (s *Store) saveUnlocked() error {
 	s.data.UpdatedAt = time.Now()
-	s.data.Version = currentVersion
+	s.data.Version = CurrentVersion
 
 	data, err := json.MarshalIndent(s.data, "", "  ")
 	if err != nil {
@@ -18,7 +18,7 @@ func TestNew(t *testing.T) {
 	assert.NotNil(t, store)
 	assert.Equal(t, "/tmp/test-wallet", store.walletPath)
 	assert.NotNil(t, store.data)
-	assert.Equal(t, currentVersion, store.data.Version)
+	assert.Equal(t, CurrentVersion, store.data.Version)
 	assert.NotNil(t, store.data.UTXOs)
 	assert.NotNil(t, store.data.Addresses)
 	assert.True(t, store.IsEmpty())
@@ -0,0 +1,120 @@
+package utxostore
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// ErrInvalidTag is returned when a tag is empty or has an empty or invalid segment.
+var ErrInvalidTag = errors.New("invalid tag")
+
+// TagSeparator separates the namespaces of a hierarchical tag, as in
+// "customer:acme".
+const TagSeparator = ":"
+
+// NormalizeTag trims and lower-cases a tag and checks that every segment
+// between separators is non-empty and uses only letters, digits, '-', '_'
+// and '.'.
+func NormalizeTag(tag string) (string, error) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", fmt.Errorf("%w: empty", ErrInvalidTag)
+	}
+	for _, segment := range strings.Split(tag, TagSeparator) {
+		if segment == "" {
+			return "", fmt.Errorf("%w: %q has an empty segment", ErrInvalidTag, tag)
+		}
+		for _, r := range segment {
+			if !isTagRune(r) {
+				return "", fmt.Errorf("%w: %q contains %q", ErrInvalidTag, tag, r)
+			}
+		}
+	}
+	return tag, nil
+}
+
+// isTagRune reports whether r may appear in a tag segment.
+func isTagRune(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' || r == '_' || r == '.'
+}
+
+// TagMatches reports whether tag equals filter or lies under it, so the
+// filter "customer" matches "customer:acme" but not "customers".
+func TagMatches(tag, filter string) bool {
+	return tag == filter || strings.HasPrefix(tag, filter+TagSeparator)
+}
+
+// TagPrefixes returns tag and every namespace above it, outermost first:
+// "customer:acme:eu" gives "customer", "customer:acme", "customer:acme:eu".
+func TagPrefixes(tag string) []string {
+	segments := strings.Split(tag, TagSeparator)
+	prefixes := make([]string, len(segments))
+	for i := range segments {
+		prefixes[i] = strings.Join(segments[:i+1], TagSeparator)
+	}
+	return prefixes
+}
+
+// AddAddressTags adds tags to an address, keeping its tags sorted and
+// unique. Tags must already be normalized.
+// Returns error if the address is not found.
+func (s *Store) AddAddressTags(chainID chain.ID, address string, tags ...string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addr, exists := s.data.Addresses[fmt.Sprintf("%s:%s", chainID, address)]
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+
+	for _, tag := range tags {
+		if !slices.Contains(addr.Tags, tag) {
+			addr.Tags = append(addr.Tags, tag)
+		}
+	}
+	slices.Sort(addr.Tags)
+	return nil
+}
+
+// RemoveAddressTags removes tags from an address and returns how many were
+// removed. Tags the address does not carry are ignored.
+// Returns error if the address is not found.
+func (s *Store) RemoveAddressTags(chainID chain.ID, address string, tags ...string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	addr, exists := s.data.Addresses[fmt.Sprintf("%s:%s", chainID, address)]
+	if !exists {
+		return 0, fmt.Errorf("%w: %s", ErrAddressNotFound, address)
+	}
+
+	before := len(addr.Tags)
+	addr.Tags = slices.DeleteFunc(addr.Tags, func(tag string) bool {
+		return slices.Contains(tags, tag)
+	})
+	if len(addr.Tags) == 0 {
+		addr.Tags = nil
+	}
+	return before - len(addr.Tags), nil
+}
+
+// GetAddressesByTag returns addresses with a tag matching filter (see TagMatches).
+func (s *Store) GetAddressesByTag(chainID chain.ID, filter string) []*AddressMetadata {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []*AddressMetadata
+	for _, addr := range s.data.Addresses {
+		if addr.ChainID != chainID {
+			continue
+		}
+		if slices.ContainsFunc(addr.Tags, func(tag string) bool { return TagMatches(tag, filter) }) {
+			result = append(result, addr)
+		}
+	}
+	return result
+}
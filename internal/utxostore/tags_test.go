@@ -0,0 +1,105 @@
+package utxostore
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{input: "customer:acme", want: "customer:acme"},
+		{input: "  Purpose:Invoices ", want: "purpose:invoices"},
+		{input: "team_a.eu-1", want: "team_a.eu-1"},
+		{input: "", wantErr: true},
+		{input: "customer:", wantErr: true},
+		{input: ":acme", wantErr: true},
+		{input: "customer::acme", wantErr: true},
+		{input: "customer acme", wantErr: true},
+		{input: "customer/acme", wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.input, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NormalizeTag(tc.input)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidTag)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTagMatches(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, TagMatches("customer:acme", "customer:acme"))
+	assert.True(t, TagMatches("customer:acme", "customer"))
+	assert.True(t, TagMatches("customer:acme:eu", "customer:acme"))
+	assert.False(t, TagMatches("customers", "customer"))
+	assert.False(t, TagMatches("customer", "customer:acme"))
+}
+
+func TestTagPrefixes(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"customer", "customer:acme", "customer:acme:eu"}, TagPrefixes("customer:acme:eu"))
+	assert.Equal(t, []string{"savings"}, TagPrefixes("savings"))
+}
+
+func TestAddressTags(t *testing.T) {
+	t.Parallel()
+	store := New(t.TempDir())
+	store.AddAddress(&AddressMetadata{ChainID: chain.BSV, Address: "addr1", Label: "main"})
+	store.AddAddress(&AddressMetadata{ChainID: chain.BSV, Address: "addr2"})
+
+	require.NoError(t, store.AddAddressTags(chain.BSV, "addr1", "purpose:invoices", "customer:acme"))
+	require.NoError(t, store.AddAddressTags(chain.BSV, "addr1", "customer:acme"))
+	require.NoError(t, store.AddAddressTags(chain.BSV, "addr2", "customer:globex"))
+	assert.Equal(t, []string{"customer:acme", "purpose:invoices"}, store.GetAddress(chain.BSV, "addr1").Tags)
+	assert.Equal(t, "main", store.GetAddress(chain.BSV, "addr1").Label, "label is kept")
+
+	assert.Len(t, store.GetAddressesByTag(chain.BSV, "customer"), 2)
+	assert.Len(t, store.GetAddressesByTag(chain.BSV, "customer:acme"), 1)
+	assert.Empty(t, store.GetAddressesByTag(chain.ETH, "customer"))
+
+	removed, err := store.RemoveAddressTags(chain.BSV, "addr1", "customer:acme", "unknown")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, []string{"purpose:invoices"}, store.GetAddress(chain.BSV, "addr1").Tags)
+
+	removed, err = store.RemoveAddressTags(chain.BSV, "addr2", "customer:globex")
+	require.NoError(t, err)
+	assert.Equal(t, 1, removed)
+	assert.Nil(t, store.GetAddress(chain.BSV, "addr2").Tags)
+
+	require.ErrorIs(t, store.AddAddressTags(chain.BSV, "missing", "x"), ErrAddressNotFound)
+	_, err = store.RemoveAddressTags(chain.BSV, "missing", "x")
+	require.ErrorIs(t, err, ErrAddressNotFound)
+}
+
+func TestAddressTags_Persist(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	store := New(dir)
+	store.AddAddress(&AddressMetadata{ChainID: chain.BSV, Address: "addr1"})
+	require.NoError(t, store.AddAddressTags(chain.BSV, "addr1", "customer:acme"))
+	require.NoError(t, store.Save())
+
+	loaded := New(dir)
+	require.NoError(t, loaded.Load())
+	assert.Equal(t, []string{"customer:acme"}, loaded.GetAddress(chain.BSV, "addr1").Tags)
+}
@@ -0,0 +1,146 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// MaxAccount is the highest BIP44 account index. Account indexes are
+// hardened, so they must stay below 2^31.
+const MaxAccount = 1<<31 - 1
+
+// AccountAddresses holds the derived addresses of one BIP44 account other
+// than the wallet's default account.
+type AccountAddresses struct {
+	// Addresses contains derived receiving addresses per chain.
+	Addresses map[ChainID][]Address `json:"addresses"`
+
+	// ChangeAddresses contains derived change addresses per chain.
+	ChangeAddresses map[ChainID][]Address `json:"change_addresses,omitempty"`
+}
+
+// Account returns the wallet as seen from BIP44 account n.
+//
+// The default account is the wallet itself. Any other account is a view
+// whose Addresses and ChangeAddresses are that account's, so existing code
+// that reads or derives addresses works on the account unchanged. Addresses
+// derived through a view are kept in Accounts, and FileStorage.UpdateMetadata
+// saves the whole wallet when given a view.
+//
+// Watch-only wallets hold a single account and return ErrWatchOnly for any
+// other.
+func (w *Wallet) Account(n uint32) (*Wallet, error) {
+	root := w.root()
+	if n == root.DerivationConfig.DefaultAccount {
+		return root, nil
+	}
+	if n > MaxAccount {
+		return nil, sigilerr.WithSuggestion(sigilerr.ErrInvalidInput,
+			fmt.Sprintf("account %d exceeds the maximum BIP44 account %d", n, MaxAccount))
+	}
+	if root.IsWatchOnly() {
+		return nil, sigilerr.WithSuggestion(ErrWatchOnly,
+			fmt.Sprintf("watch-only wallet '%s' only holds the account it was imported from", root.Name))
+	}
+
+	if root.Accounts == nil {
+		root.Accounts = make(map[uint32]*AccountAddresses)
+	}
+	acct, ok := root.Accounts[n]
+	if !ok {
+		acct = &AccountAddresses{}
+		root.Accounts[n] = acct
+	}
+	if acct.Addresses == nil {
+		acct.Addresses = make(map[ChainID][]Address)
+	}
+	if acct.ChangeAddresses == nil {
+		acct.ChangeAddresses = make(map[ChainID][]Address)
+	}
+
+	view := *root
+	view.Addresses = acct.Addresses
+	view.ChangeAddresses = acct.ChangeAddresses
+	view.DerivationConfig.DefaultAccount = n
+	view.parent = root
+	return &view, nil
+}
+
+// AccountIndex returns the BIP44 account the wallet's addresses belong to.
+func (w *Wallet) AccountIndex() uint32 {
+	return w.DerivationConfig.DefaultAccount
+}
+
+// AccountIndexes returns the wallet's default account followed by every
+// other account that has addresses, in ascending order.
+func (w *Wallet) AccountIndexes() []uint32 {
+	root := w.root()
+	indexes := []uint32{root.DerivationConfig.DefaultAccount}
+	others := make([]uint32, 0, len(root.Accounts))
+	for n, acct := range root.Accounts {
+		if n != root.DerivationConfig.DefaultAccount && acct != nil && acct.hasAddresses() {
+			others = append(others, n)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	return append(indexes, others...)
+}
+
+// OwnsAddress reports whether addr is a receive or change address of any
+// account of the wallet on chain. ETH addresses match in any letter case.
+func (w *Wallet) OwnsAddress(chain ChainID, addr string) bool {
+	return w.OwnedAddress(chain, addr) != nil
+}
+
+// OwnedAddress returns the receive or change address addr of any account of
+// the wallet on chain, or nil if the wallet has no such address.
+func (w *Wallet) OwnedAddress(chain ChainID, addr string) *Address {
+	find := func(addrs []Address) *Address {
+		for i := range addrs {
+			if addrs[i].Address == addr || (chain == ChainETH && strings.EqualFold(addrs[i].Address, addr)) {
+				return &addrs[i]
+			}
+		}
+		return nil
+	}
+
+	root := w.root()
+	lists := [][]Address{root.Addresses[chain], root.ChangeAddresses[chain]}
+	for _, acct := range root.Accounts {
+		if acct != nil {
+			lists = append(lists, acct.Addresses[chain], acct.ChangeAddresses[chain])
+		}
+	}
+	for _, addrs := range lists {
+		if found := find(addrs); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// root returns the wallet an account view was taken from, or w itself.
+func (w *Wallet) root() *Wallet {
+	if w.parent != nil {
+		return w.parent
+	}
+	return w
+}
+
+// hasAddresses reports whether any chain of the account has addresses.
+func (a *AccountAddresses) hasAddresses() bool {
+	for _, addrs := range a.Addresses {
+		if len(addrs) > 0 {
+			return true
+		}
+	}
+	for _, addrs := range a.ChangeAddresses {
+		if len(addrs) > 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWallet_Account(t *testing.T) {
+	t.Parallel()
+
+	seed := testSeed()
+	w, err := NewWallet("accounts", []ChainID{ChainBSV})
+	require.NoError(t, err)
+	require.NoError(t, w.DeriveAddresses(seed, 1))
+
+	def, err := w.Account(0)
+	require.NoError(t, err)
+	assert.Same(t, w, def, "the default account is the wallet itself")
+
+	acct, err := w.Account(1)
+	require.NoError(t, err)
+	assert.Empty(t, acct.Addresses[ChainBSV])
+	assert.Equal(t, []uint32{0}, w.AccountIndexes(), "an account without addresses is not listed")
+
+	addr, err := acct.DeriveNextReceiveAddress(seed, ChainBSV)
+	require.NoError(t, err)
+	change, err := acct.DeriveNextChangeAddress(seed, ChainBSV)
+	require.NoError(t, err)
+	assert.Equal(t, "m/44'/236'/1'/0/0", addr.Path)
+	assert.Equal(t, "m/44'/236'/1'/1/0", change.Path)
+	assert.Equal(t, uint32(1), addr.Account)
+
+	expected, err := DeriveAddressForNetwork(seed, ChainBSV, 1, 0, Mainnet)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Address, addr.Address)
+	assert.NotEqual(t, w.Addresses[ChainBSV][0].Address, addr.Address)
+
+	// The wallet keeps the account's addresses apart from the default account
+	require.Len(t, w.Addresses[ChainBSV], 1)
+	require.Len(t, w.Accounts[1].Addresses[ChainBSV], 1)
+	assert.Equal(t, []uint32{0, 1}, w.AccountIndexes())
+	again, err := acct.Account(1)
+	require.NoError(t, err)
+	assert.Len(t, again.GetAllAddresses(ChainBSV), 2)
+
+	_, err = w.Account(MaxAccount + 1)
+	require.Error(t, err)
+
+	watch, err := NewWatchOnlyFromAddresses("watch", ChainBSV, []string{addr.Address})
+	require.NoError(t, err)
+	_, err = watch.Account(1)
+	require.ErrorIs(t, err, ErrWatchOnly)
+}
+
+func TestStorage_UpdateMetadata_AccountView(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	storage := NewFileStorage(dir)
+	seed := testSeed()
+	password := []byte("test-password-123")
+
+	w, err := NewWallet("accounts", []ChainID{ChainBSV})
+	require.NoError(t, err)
+	require.NoError(t, w.DeriveAddresses(seed, 1))
+	require.NoError(t, storage.Save(w, seed, password))
+
+	acct, err := w.Account(2)
+	require.NoError(t, err)
+	_, err = acct.DeriveNextReceiveAddress(seed, ChainBSV)
+	require.NoError(t, err)
+	require.NoError(t, storage.UpdateMetadata(acct))
+
+	loaded, err := storage.LoadMetadata("accounts")
+	require.NoError(t, err)
+	assert.Len(t, loaded.Addresses[ChainBSV], 1, "the default account is saved too")
+	assert.Equal(t, uint32(0), loaded.DerivationConfig.DefaultAccount)
+	require.Contains(t, loaded.Accounts, uint32(2))
+	assert.Equal(t, "m/44'/236'/2'/0/0", loaded.Accounts[2].Addresses[ChainBSV][0].Path)
+
+	report, err := loaded.AuditAddresses(seed)
+	require.NoError(t, err)
+	assert.Equal(t, 2, report.Checked)
+	assert.True(t, report.OK())
+}
+
+func TestWallet_OwnsAddress(t *testing.T) {
+	t.Parallel()
+
+	seed := testSeed()
+	w, err := NewWallet("owns", []ChainID{ChainETH, ChainBSV})
+	require.NoError(t, err)
+	require.NoError(t, w.DeriveAddresses(seed, 1))
+	acct, err := w.Account(1)
+	require.NoError(t, err)
+	change, err := acct.DeriveNextChangeAddress(seed, ChainBSV)
+	require.NoError(t, err)
+
+	eth := w.Addresses[ChainETH][0].Address
+	assert.True(t, w.OwnsAddress(ChainETH, strings.ToLower(eth)), "ETH addresses match in any case")
+	assert.True(t, w.OwnsAddress(ChainBSV, change.Address), "another account's change address")
+	assert.True(t, acct.OwnsAddress(ChainBSV, w.Addresses[ChainBSV][0].Address), "an account view sees the whole wallet")
+	assert.False(t, w.OwnsAddress(ChainBSV, strings.ToLower(change.Address)))
+	assert.False(t, w.OwnsAddress(ChainBSV, eth))
+
+	owned := w.OwnedAddress(ChainBSV, change.Address)
+	require.NotNil(t, owned)
+	assert.Equal(t, change.Path, owned.Path)
+	assert.Nil(t, w.OwnedAddress(ChainBSV, eth))
+}
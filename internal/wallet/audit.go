@@ -0,0 +1,148 @@
+package wallet
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Audit field names reported in AddressMismatch.Field.
+const (
+	AuditFieldAddress   = "address"
+	AuditFieldPublicKey = "public_key"
+	AuditFieldPath      = "path"
+	AuditFieldIndex     = "index"
+)
+
+// AddressMismatch describes a stored address that disagrees with the value
+// re-derived from the seed.
+type AddressMismatch struct {
+	// Chain is the chain the address belongs to.
+	Chain ChainID `json:"chain"`
+
+	// Path is the derivation path the address should have been derived from.
+	Path string `json:"path"`
+
+	// Index is the address index as stored in the wallet file.
+	Index uint32 `json:"index"`
+
+	// IsChange indicates the address is on the internal (change) chain.
+	IsChange bool `json:"is_change"`
+
+	// Field names the mismatching attribute (address, public_key, path, index).
+	Field string `json:"field"`
+
+	// Stored is the value found in the wallet file.
+	Stored string `json:"stored"`
+
+	// Expected is the value derived from the seed.
+	Expected string `json:"expected"`
+}
+
+// AuditReport is the result of re-deriving a wallet's stored addresses.
+type AuditReport struct {
+	// Wallet is the audited wallet name.
+	Wallet string `json:"wallet"`
+
+	// Checked is the number of stored addresses that were re-derived.
+	Checked int `json:"checked"`
+
+	// Mismatches lists every disagreement found, ordered by account, chain and path.
+	Mismatches []AddressMismatch `json:"mismatches"`
+}
+
+// OK reports whether every stored address matched its derivation.
+func (r *AuditReport) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+// AuditAddresses re-derives every stored receive and change address from the
+// seed and compares it with the persisted metadata. Addresses are expected at
+// the BIP44 path for the account they are stored under, with the index
+// matching the address's position in its list. A mismatch means the wallet
+// file was corrupted or edited, or the seed does not belong to this wallet.
+func (w *Wallet) AuditAddresses(seed []byte) (*AuditReport, error) {
+	report := &AuditReport{Wallet: w.Name, Mismatches: []AddressMismatch{}}
+
+	for _, n := range w.AccountIndexes() {
+		acct, err := w.Account(n)
+		if err != nil {
+			return nil, err
+		}
+		for _, chain := range auditChains(acct) {
+			if err := acct.auditList(report, seed, chain, acct.Addresses[chain], ExternalChain); err != nil {
+				return nil, err
+			}
+			if acct.ChangeAddresses != nil {
+				if err := acct.auditList(report, seed, chain, acct.ChangeAddresses[chain], InternalChain); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// auditList checks one receive or change address list.
+func (w *Wallet) auditList(report *AuditReport, seed []byte, chain ChainID, addrs []Address, change uint32) error {
+	account := w.DerivationConfig.DefaultAccount
+
+	for pos, stored := range addrs {
+		if pos >= MaxAddressDerivation {
+			return fmt.Errorf("%w: %s has more than %d addresses",
+				ErrInvalidAddressCount, chain, MaxAddressDerivation)
+		}
+		idx := uint32(pos) //nolint:gosec // G115: bounded by MaxAddressDerivation above
+
+		expected, err := DeriveAddressWithChangeForNetwork(seed, chain, account, change, idx, w.Net())
+		if err != nil {
+			return fmt.Errorf("deriving %s address %d: %w", chain, idx, err)
+		}
+		report.Checked++
+
+		mismatch := func(field, got, want string) {
+			report.Mismatches = append(report.Mismatches, AddressMismatch{
+				Chain:    chain,
+				Path:     expected.Path,
+				Index:    stored.Index,
+				IsChange: change == InternalChain,
+				Field:    field,
+				Stored:   got,
+				Expected: want,
+			})
+		}
+
+		if stored.Index != idx {
+			mismatch(AuditFieldIndex, fmt.Sprintf("%d", stored.Index), fmt.Sprintf("%d", idx))
+		}
+		if stored.Path != expected.Path {
+			mismatch(AuditFieldPath, stored.Path, expected.Path)
+		}
+		if stored.Address != expected.Address {
+			mismatch(AuditFieldAddress, stored.Address, expected.Address)
+		}
+		if stored.PublicKey != "" && stored.PublicKey != expected.PublicKey {
+			mismatch(AuditFieldPublicKey, stored.PublicKey, expected.PublicKey)
+		}
+	}
+
+	return nil
+}
+
+// auditChains returns every chain with stored addresses, in a stable order.
+func auditChains(w *Wallet) []ChainID {
+	seen := make(map[ChainID]struct{})
+	for chain := range w.Addresses {
+		seen[chain] = struct{}{}
+	}
+	for chain := range w.ChangeAddresses {
+		seen[chain] = struct{}{}
+	}
+
+	chains := make([]ChainID, 0, len(seen))
+	for chain := range seen {
+		chains = append(chains, chain)
+	}
+	sort.Slice(chains, func(i, j int) bool { return chains[i] < chains[j] })
+	return chains
+}
@@ -0,0 +1,85 @@
+package wallet
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAuditTestWallet returns a wallet with 3 receive and 1 change address per chain.
+func newAuditTestWallet(t *testing.T) (*Wallet, []byte) {
+	t.Helper()
+
+	seed, err := MnemonicToSeed("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	require.NoError(t, err)
+
+	w, err := NewWallet("audit", []ChainID{ChainETH, ChainBSV})
+	require.NoError(t, err)
+	require.NoError(t, w.DeriveAddresses(seed, 3))
+	for _, c := range w.EnabledChains {
+		_, err = w.DeriveNextChangeAddress(seed, c)
+		require.NoError(t, err)
+	}
+	return w, seed
+}
+
+func TestWallet_AuditAddresses(t *testing.T) {
+	t.Parallel()
+
+	t.Run("clean wallet", func(t *testing.T) {
+		t.Parallel()
+
+		w, seed := newAuditTestWallet(t)
+		report, err := w.AuditAddresses(seed)
+		require.NoError(t, err)
+		assert.True(t, report.OK())
+		assert.Equal(t, 8, report.Checked)
+	})
+
+	t.Run("tampered address", func(t *testing.T) {
+		t.Parallel()
+
+		w, seed := newAuditTestWallet(t)
+		original := w.Addresses[ChainBSV][1].Address
+		w.Addresses[ChainBSV][1].Address = "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"
+
+		report, err := w.AuditAddresses(seed)
+		require.NoError(t, err)
+		require.Len(t, report.Mismatches, 1)
+
+		m := report.Mismatches[0]
+		assert.Equal(t, ChainBSV, m.Chain)
+		assert.Equal(t, AuditFieldAddress, m.Field)
+		assert.Equal(t, original, m.Expected)
+		assert.Equal(t, "m/44'/236'/0'/0/1", m.Path)
+	})
+
+	t.Run("tampered change path and index", func(t *testing.T) {
+		t.Parallel()
+
+		w, seed := newAuditTestWallet(t)
+		w.ChangeAddresses[ChainETH][0].Path = "m/44'/60'/0'/1/7"
+		w.ChangeAddresses[ChainETH][0].Index = 7
+
+		report, err := w.AuditAddresses(seed)
+		require.NoError(t, err)
+		require.Len(t, report.Mismatches, 2)
+		for _, m := range report.Mismatches {
+			assert.True(t, m.IsChange)
+			assert.Equal(t, "m/44'/60'/0'/1/0", m.Path)
+		}
+	})
+
+	t.Run("wrong seed", func(t *testing.T) {
+		t.Parallel()
+
+		w, _ := newAuditTestWallet(t)
+		other, err := MnemonicToSeed("legal winner thank year wave sausage worth useful legal winner thank yellow", "")
+		require.NoError(t, err)
+
+		report, err := w.AuditAddresses(other)
+		require.NoError(t, err)
+		assert.False(t, report.OK())
+	})
+}
@@ -136,3 +136,81 @@ func SegwitEncode(hrp string, version byte, program []byte) (string, error) {
 	data := append([]byte{version}, conv...)
 	return Bech32Encode(hrp, data)
 }
+
+// bech32mConst is the checksum constant of bech32m (BIP-350), used by
+// witness version 1 and later. Plain bech32 (BIP-173) uses 1.
+const bech32mConst = 0x2bc830a3
+
+// ErrInvalidBech32 indicates a malformed bech32 string or checksum.
+var ErrInvalidBech32 = errors.New("invalid bech32 string")
+
+// bech32Decode splits a bech32 or bech32m string into its HRP and 5-bit data
+// values (checksum removed), returning the checksum constant it verified.
+func bech32Decode(s string) (string, []byte, uint32, error) {
+	if len(s) > 90 || (strings.ToLower(s) != s && strings.ToUpper(s) != s) {
+		return "", nil, 0, ErrInvalidBech32
+	}
+	s = strings.ToLower(s)
+
+	sep := strings.LastIndexByte(s, '1')
+	if sep < 1 || sep+7 > len(s) {
+		return "", nil, 0, ErrInvalidBech32
+	}
+	hrp := s[:sep]
+	for _, c := range hrp {
+		if c < 33 || c > 126 {
+			return "", nil, 0, ErrInvalidBech32
+		}
+	}
+
+	data := make([]byte, 0, len(s)-sep-1)
+	for i := sep + 1; i < len(s); i++ {
+		idx := strings.IndexByte(bech32Charset, s[i])
+		if idx < 0 {
+			return "", nil, 0, ErrInvalidBech32
+		}
+		data = append(data, byte(idx))
+	}
+
+	polymod := bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	if polymod != 1 && polymod != bech32mConst {
+		return "", nil, 0, fmt.Errorf("%w: %w", ErrInvalidBech32, ErrBadChecksum)
+	}
+	return hrp, data[:len(data)-6], polymod, nil
+}
+
+// SegwitDecode decodes a segwit address for the expected HRP ("bc", "tb",
+// "ltc", ...) and returns its witness version and program. Version 0 must use
+// the bech32 checksum and later versions bech32m.
+func SegwitDecode(hrp, address string) (byte, []byte, error) {
+	gotHRP, data, polymod, err := bech32Decode(address)
+	if err != nil {
+		return 0, nil, err
+	}
+	if gotHRP != hrp {
+		return 0, nil, fmt.Errorf("%w: prefix %q, want %q", ErrInvalidBech32, gotHRP, hrp)
+	}
+	if len(data) < 1 {
+		return 0, nil, ErrInvalidBech32
+	}
+
+	version := data[0]
+	if version > 16 {
+		return 0, nil, fmt.Errorf("%w: %d", ErrInvalidWitnessVersion, version)
+	}
+	if (version == 0) != (polymod == 1) {
+		return 0, nil, fmt.Errorf("%w: %w: wrong variant for version %d", ErrInvalidBech32, ErrBadChecksum, version)
+	}
+
+	program, err := ConvertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return 0, nil, fmt.Errorf("convert bits: %w", err)
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return 0, nil, fmt.Errorf("%w: %d", ErrInvalidWitnessProgram, len(program))
+	}
+	if version == 0 && len(program) != 20 && len(program) != 32 {
+		return 0, nil, fmt.Errorf("%w: v0 requires 20 or 32 bytes, got %d", ErrInvalidWitnessProgram, len(program))
+	}
+	return version, program, nil
+}
@@ -137,3 +137,63 @@ func TestSegwitEncode_P2WPKH_KnownVector(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", addr)
 }
+
+func TestSegwitDecode_Vectors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		hrp     string
+		address string
+		version byte
+		program string
+	}{
+		{"BIP173 P2WPKH uppercase", "bc", "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4", 0, "751e76e8199196d454941c45d1b3a323f1433bd6"},
+		{"BIP350 P2TR", "bc", "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0", 1, "79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			version, program, err := SegwitDecode(tc.hrp, tc.address)
+			require.NoError(t, err)
+			assert.Equal(t, tc.version, version)
+			assert.Equal(t, tc.program, hex.EncodeToString(program))
+		})
+	}
+}
+
+func TestSegwitDecode_RoundTrip(t *testing.T) {
+	t.Parallel()
+	program, _ := hex.DecodeString("1863143c14c5166804bd19203356da136c985678cd4d27a1b8c6329604903262")
+	addr, err := SegwitEncode("tb", 0, program)
+	require.NoError(t, err)
+
+	version, decoded, err := SegwitDecode("tb", addr)
+	require.NoError(t, err)
+	assert.Equal(t, byte(0), version)
+	assert.Equal(t, program, decoded)
+}
+
+func TestSegwitDecode_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		hrp     string
+		address string
+	}{
+		{"bad checksum", "bc", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t5"},
+		{"v0 with bech32m checksum", "bc", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kemeawh"},
+		{"wrong prefix", "tb", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{"mixed case", "bc", "bc1qW508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{"no separator", "bc", "bcqw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"},
+		{"invalid character", "bc", "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3tb"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, err := SegwitDecode(tc.hrp, tc.address)
+			require.Error(t, err)
+		})
+	}
+}
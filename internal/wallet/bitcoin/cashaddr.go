@@ -6,8 +6,13 @@ import (
 	"strings"
 )
 
-// ErrInvalidCashAddrHash indicates an unsupported hash length for cashaddr.
-var ErrInvalidCashAddrHash = errors.New("invalid cashaddr hash length")
+var (
+	// ErrInvalidCashAddrHash indicates an unsupported hash length for cashaddr.
+	ErrInvalidCashAddrHash = errors.New("invalid cashaddr hash length")
+
+	// ErrInvalidCashAddr indicates a malformed cashaddr string or bad checksum.
+	ErrInvalidCashAddr = errors.New("invalid cashaddr")
+)
 
 // CashAddr type constants.
 const (
@@ -136,3 +141,55 @@ func CashAddrEncodeShort(prefix string, addrType byte, hash []byte) (string, err
 	}
 	return full[idx+1:], nil
 }
+
+// CashAddrDecode decodes a cashaddr address and returns its prefix, address
+// type, and hash. An address without a prefix is checked against
+// defaultPrefix. Mixed-case addresses are rejected.
+func CashAddrDecode(address, defaultPrefix string) (string, byte, []byte, error) {
+	if strings.ToLower(address) != address && strings.ToUpper(address) != address {
+		return "", 0, nil, fmt.Errorf("%w: mixed case", ErrInvalidCashAddr)
+	}
+	address = strings.ToLower(address)
+
+	prefix, payload := defaultPrefix, address
+	if idx := strings.LastIndexByte(address, ':'); idx >= 0 {
+		prefix, payload = address[:idx], address[idx+1:]
+	}
+	if prefix == "" || len(payload) <= 8 {
+		return "", 0, nil, ErrInvalidCashAddr
+	}
+
+	values := cashAddrPrefixExpand(prefix)
+	data := make([]byte, 0, len(payload))
+	for i := range len(payload) {
+		idx := strings.IndexByte(cashAddrCharset, payload[i])
+		if idx < 0 {
+			return "", 0, nil, fmt.Errorf("%w: character %q", ErrInvalidCashAddr, payload[i])
+		}
+		data = append(data, byte(idx))
+		values = append(values, uint64(idx))
+	}
+	if cashAddrPolymod(values) != 0 {
+		return "", 0, nil, fmt.Errorf("%w: %w", ErrInvalidCashAddr, ErrBadChecksum)
+	}
+
+	decoded, err := ConvertBits(data[:len(data)-8], 5, 8, false)
+	if err != nil {
+		return "", 0, nil, fmt.Errorf("convert bits: %w", err)
+	}
+	if len(decoded) < 2 {
+		return "", 0, nil, ErrInvalidCashAddr
+	}
+	version, hash := decoded[0], decoded[1:]
+	if version&0x80 != 0 {
+		return "", 0, nil, fmt.Errorf("%w: reserved version bit set", ErrInvalidCashAddr)
+	}
+	sizeBits, err := cashAddrSizeBits(len(hash))
+	if err != nil {
+		return "", 0, nil, err
+	}
+	if version&0x07 != sizeBits {
+		return "", 0, nil, fmt.Errorf("%w: size bits do not match hash length", ErrInvalidCashAddr)
+	}
+	return prefix, version >> 3, hash, nil
+}
@@ -110,3 +110,69 @@ func TestCashAddrSizeBits(t *testing.T) {
 		assert.Error(t, err, "hashLen=%d should be invalid", bad)
 	}
 }
+
+func TestCashAddrDecode(t *testing.T) {
+	t.Parallel()
+
+	const hashHex = "751e76e8199196d454941c45d1b3a323f1433bd6"
+	tests := []struct {
+		name       string
+		address    string
+		wantPrefix string
+		wantType   byte
+	}{
+		{"full P2PKH", "bitcoincash:qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2h", "bitcoincash", CashAddrTypeP2PKH},
+		{"short P2PKH", "qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2h", "bitcoincash", CashAddrTypeP2PKH},
+		{"upper case", "BITCOINCASH:QP63UAHGRXGED4Z5JSWYT5DN5V3LZSEM6CY4SPDC2H", "bitcoincash", CashAddrTypeP2PKH},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			prefix, addrType, hash, err := CashAddrDecode(tt.address, "bitcoincash")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPrefix, prefix)
+			assert.Equal(t, tt.wantType, addrType)
+			assert.Equal(t, hashHex, hex.EncodeToString(hash))
+		})
+	}
+}
+
+func TestCashAddrDecode_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	hash, _ := hex.DecodeString("751e76e8199196d454941c45d1b3a323f1433bd6")
+	for _, addrType := range []byte{CashAddrTypeP2PKH, CashAddrTypeP2SH} {
+		addr, err := CashAddrEncode("bchtest", addrType, hash)
+		require.NoError(t, err)
+
+		prefix, gotType, gotHash, err := CashAddrDecode(addr, "bitcoincash")
+		require.NoError(t, err)
+		assert.Equal(t, "bchtest", prefix)
+		assert.Equal(t, addrType, gotType)
+		assert.Equal(t, hash, gotHash)
+	}
+}
+
+func TestCashAddrDecode_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		address string
+		prefix  string
+	}{
+		{"bad checksum", "bitcoincash:qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2j", "bitcoincash"},
+		{"wrong prefix for short form", "qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2h", "bchtest"},
+		{"mixed case", "bitcoincash:Qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdc2h", "bitcoincash"},
+		{"invalid character", "bitcoincash:qp63uahgrxged4z5jswyt5dn5v3lzsem6cy4spdcbh", "bitcoincash"},
+		{"too short", "bitcoincash:qqqq", "bitcoincash"},
+		{"empty", "", "bitcoincash"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, _, _, err := CashAddrDecode(tt.address, tt.prefix)
+			require.Error(t, err)
+		})
+	}
+}
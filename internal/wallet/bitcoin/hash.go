@@ -5,6 +5,8 @@ package bitcoin
 
 import (
 	"crypto/sha256"
+	"errors"
+	"fmt"
 
 	// RIPEMD160 is deprecated but REQUIRED by Bitcoin protocol (BIP-13, BIP-16).
 	// Bitcoin P2PKH addresses use Hash160 = RIPEMD160(SHA256(pubkey)).
@@ -44,6 +46,11 @@ type base58Error struct{}
 
 func (*base58Error) Error() string { return "invalid Base58 encoding" }
 
+// ErrBadChecksum indicates a well-formed Base58Check, bech32, or cashaddr
+// string whose checksum does not match. It is wrapped together with the
+// encoding's own error.
+var ErrBadChecksum = errors.New("bad checksum")
+
 // Base58Decode decodes a Base58-encoded string.
 func Base58Decode(s string) ([]byte, error) {
 	if len(s) == 0 {
@@ -126,7 +133,7 @@ func Base58CheckDecode(s string) ([]byte, error) {
 
 	for i := range 4 {
 		if checksum[i] != expectedChecksum[i] {
-			return nil, ErrInvalidBase58
+			return nil, fmt.Errorf("%w: %w", ErrInvalidBase58, ErrBadChecksum)
 		}
 	}
 
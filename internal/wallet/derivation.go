@@ -25,7 +25,7 @@ const (
 	ChainETH = chain.ETH
 	// ChainBSV is the Bitcoin SV chain.
 	ChainBSV = chain.BSV
-	// ChainBTC is the Bitcoin chain (future).
+	// ChainBTC is the Bitcoin chain.
 	ChainBTC = chain.BTC
 	// ChainBCH is the Bitcoin Cash chain.
 	ChainBCH = chain.BCH
@@ -76,6 +76,9 @@ type Address struct {
 	// IsChange indicates if this is a change address (internal chain).
 	// False for receiving addresses (external chain).
 	IsChange bool `json:"is_change,omitempty"`
+
+	// Account is the BIP44 account index the address was derived under.
+	Account uint32 `json:"account,omitempty"`
 }
 
 // GetDerivationPath returns the full BIP44 derivation path for a chain.
@@ -143,6 +146,7 @@ func DeriveAddressWithChangeForNetwork(seed []byte, chain ChainID, account, chan
 		Address:   address,
 		PublicKey: pubKeyHex,
 		IsChange:  change == InternalChain,
+		Account:   account,
 	}, nil
 }
 
@@ -468,6 +472,33 @@ func (mc *MnemonicContext) DeriveAddressWithCoinType(coinType, account, change,
 	return address, pubKeyHex, path, nil
 }
 
+// DerivePrivateKey derives the private key at m/44'/coinType'/account'/change/index.
+// Uses cached intermediate keys. The returned key must be zeroed by the caller after use.
+func (mc *MnemonicContext) DerivePrivateKey(coinType, account, change, index uint32) ([]byte, error) {
+	accountKey, err := mc.getAccountKey(coinType, account)
+	if err != nil {
+		return nil, err
+	}
+	changeKey, err := accountKey.ChildBIP32Std(change)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive change key: %w", err)
+	}
+	defer changeKey.Zero()
+	indexKey, err := changeKey.ChildBIP32Std(index)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive index key: %w", err)
+	}
+	defer indexKey.Zero()
+
+	serialized, err := indexKey.SerializedPrivKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize private key: %w", err)
+	}
+	privKey := make([]byte, 32)
+	copy(privKey, serialized)
+	return privKey, nil
+}
+
 // DeriveLegacyAddress derives an address using the legacy HandCash 1.x path (m/0'/index).
 // Uses cached m/0' key for efficiency.
 func (mc *MnemonicContext) DeriveLegacyAddress(index uint32) (string, string, string, error) {
@@ -0,0 +1,156 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"time"
+)
+
+// ErrEntropyHealth indicates a random source failed its health check.
+var ErrEntropyHealth = errors.New("random source failed health check")
+
+const (
+	// entropyCheckTimeout is how long a source may take to produce the
+	// health check sample before it is considered unresponsive.
+	entropyCheckTimeout = 5 * time.Second
+
+	// entropySampleSize is the health check sample: 20000 bits, the size
+	// of the FIPS 140-2 monobit test.
+	entropySampleSize = 2500
+
+	// monobitMin and monobitMax bound the number of one bits in the sample.
+	monobitMin = 9725
+	monobitMax = 10275
+
+	// entropyMixDomain separates entropy mixing from other uses of SHA-256.
+	entropyMixDomain = "sigil-entropy-mix/v1"
+)
+
+// SeedSource is a source of random bytes for new seeds. The operating
+// system CSPRNG is the default; a hardware RNG can be used by implementing
+// SeedSource and passing it to NewMnemonic.
+type SeedSource interface {
+	io.Reader
+
+	// Name identifies the source in errors.
+	Name() string
+}
+
+// systemSeedSource reads from the operating system CSPRNG.
+type systemSeedSource struct{}
+
+// Read fills p from crypto/rand.
+func (systemSeedSource) Read(p []byte) (int, error) {
+	return rand.Read(p)
+}
+
+// Name identifies the operating system CSPRNG.
+func (systemSeedSource) Name() string {
+	return "system CSPRNG"
+}
+
+// SystemSeedSource returns the operating system CSPRNG.
+func SystemSeedSource() SeedSource {
+	return systemSeedSource{}
+}
+
+// CheckSeedSource runs a startup health test on src. It fails when src
+// errors or does not answer within a few seconds, returns a sample with a
+// repeated block, or returns a sample whose balance of one and zero bits
+// fails the FIPS 140-2 monobit test. Passing does not prove src is random;
+// it catches sources that are stuck or broken.
+func CheckSeedSource(src SeedSource) error {
+	type result struct {
+		sample []byte
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		sample := make([]byte, entropySampleSize)
+		_, err := io.ReadFull(src, sample)
+		done <- result{sample, err}
+	}()
+
+	var sample []byte
+	select {
+	case r := <-done:
+		if r.err != nil {
+			return fmt.Errorf("%w: %s: %w", ErrEntropyHealth, src.Name(), r.err)
+		}
+		sample = r.sample
+	case <-time.After(entropyCheckTimeout):
+		return fmt.Errorf("%w: %s did not respond within %s", ErrEntropyHealth, src.Name(), entropyCheckTimeout)
+	}
+	defer ZeroBytes(sample)
+
+	// Repetition test: no 32-byte block may repeat the one before it
+	const block = 32
+	for i := block; i+block <= len(sample); i += block {
+		if bytes.Equal(sample[i-block:i], sample[i:i+block]) {
+			return fmt.Errorf("%w: %s returned a repeated block", ErrEntropyHealth, src.Name())
+		}
+	}
+
+	ones := 0
+	for _, b := range sample {
+		ones += bits.OnesCount8(b)
+	}
+	if ones <= monobitMin || ones >= monobitMax {
+		return fmt.Errorf("%w: %s returned %d one bits in %d (monobit test)", ErrEntropyHealth, src.Name(), ones, len(sample)*8)
+	}
+	return nil
+}
+
+// MixEntropy combines entropy with user-provided extra entropy, such as dice
+// rolls or random typing, and returns len(entropy) bytes. The result is at
+// least as unpredictable as the stronger of the two inputs, so weak extra
+// entropy never weakens the seed.
+func MixEntropy(entropy, extra []byte) []byte {
+	h := sha256.New()
+	h.Write([]byte(entropyMixDomain))
+	h.Write(entropy)
+	h.Write(extra)
+	sum := h.Sum(nil)
+	defer ZeroBytes(sum)
+
+	mixed := make([]byte, len(entropy))
+	copy(mixed, sum)
+	return mixed
+}
+
+// NewMnemonic creates a BIP39 mnemonic of wordCount words (12 or 24) in the
+// lang wordlist from src after checking its health. Non-empty extra entropy
+// is mixed in with MixEntropy.
+func NewMnemonic(src SeedSource, lang Language, wordCount int, extra []byte) (string, error) {
+	var size int
+	switch wordCount {
+	case 12:
+		size = 16
+	case 24:
+		size = 32
+	default:
+		return "", ErrInvalidWordCount
+	}
+
+	if err := CheckSeedSource(src); err != nil {
+		return "", err
+	}
+
+	entropy := make([]byte, size)
+	defer ZeroBytes(entropy)
+	if _, err := io.ReadFull(src, entropy); err != nil {
+		return "", fmt.Errorf("reading entropy from %s: %w", src.Name(), err)
+	}
+	if len(extra) > 0 {
+		mixed := MixEntropy(entropy, extra)
+		defer ZeroBytes(mixed)
+		entropy = mixed
+	}
+
+	return entropyToMnemonic(entropy, lang)
+}
@@ -0,0 +1,89 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSourceFailed = errors.New("device unplugged")
+
+// testSeedSource is a SeedSource backed by a reader.
+type testSeedSource struct {
+	r interface{ Read([]byte) (int, error) }
+}
+
+func (s testSeedSource) Read(p []byte) (int, error) { return s.r.Read(p) }
+func (s testSeedSource) Name() string               { return "test source" }
+
+// failingReader returns err from every read.
+type failingReader struct{ err error }
+
+func (f failingReader) Read([]byte) (int, error) { return 0, f.err }
+
+func TestCheckSeedSource(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, CheckSeedSource(SystemSeedSource()))
+
+	tests := []struct {
+		name string
+		src  SeedSource
+		want string
+	}{
+		{"stuck at zero", testSeedSource{bytes.NewReader(make([]byte, entropySampleSize))}, "repeated block"},
+		{"biased", testSeedSource{bytes.NewReader(biasedSample())}, "monobit"},
+		{"read error", testSeedSource{failingReader{errSourceFailed}}, "device unplugged"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := CheckSeedSource(tc.src)
+			require.ErrorIs(t, err, ErrEntropyHealth)
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+// biasedSample returns random bytes with their top two bits forced on, so no
+// block repeats but about 5 bits in 8 are one.
+func biasedSample() []byte {
+	sample := make([]byte, entropySampleSize)
+	_, _ = rand.Read(sample)
+	for i := range sample {
+		sample[i] |= 0xC0
+	}
+	return sample
+}
+
+func TestMixEntropy(t *testing.T) {
+	t.Parallel()
+
+	entropy := bytes.Repeat([]byte{1}, 16)
+	a := MixEntropy(entropy, []byte("3 5 1 6 2 4"))
+	b := MixEntropy(entropy, []byte("3 5 1 6 2 5"))
+	assert.Len(t, a, 16)
+	assert.NotEqual(t, a, b, "the extra entropy changes the result")
+	assert.NotEqual(t, entropy, a)
+	assert.Equal(t, a, MixEntropy(entropy, []byte("3 5 1 6 2 4")), "mixing is deterministic")
+}
+
+func TestNewMnemonic(t *testing.T) {
+	t.Parallel()
+
+	mnemonic, err := NewMnemonic(SystemSeedSource(), LanguageEnglish, 24, []byte("dice 16253"))
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+	require.NoError(t, ValidateMnemonic(mnemonic))
+
+	_, err = NewMnemonic(SystemSeedSource(), LanguageEnglish, 15, nil)
+	require.ErrorIs(t, err, ErrInvalidWordCount)
+
+	_, err = NewMnemonic(testSeedSource{failingReader{errSourceFailed}}, LanguageEnglish, 12, nil)
+	require.ErrorIs(t, err, ErrEntropyHealth)
+}
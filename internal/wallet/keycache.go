@@ -0,0 +1,80 @@
+package wallet
+
+import "sync"
+
+// KeyCache memoizes BIP44 private keys derived from a seed, keyed by derivation path.
+// It is meant to live for a single command execution: create it once the seed is
+// unlocked and call Zero (typically via defer) before the command returns.
+//
+// Large multi-address sweeps and retried fee calculations derive the same keys
+// repeatedly; the cache avoids rebuilding the master key and re-running the
+// hardened derivations for every lookup. Keys handed out are copies, so callers
+// keep zeroing them as before without affecting the cached material.
+type KeyCache struct {
+	mu   sync.Mutex
+	seed []byte
+	mc   *MnemonicContext
+	keys map[string][]byte
+}
+
+// NewKeyCache creates an empty cache for seed. The seed is not copied and must
+// remain valid until Zero is called. The master key is built on first use.
+func NewKeyCache(seed []byte) *KeyCache {
+	return &KeyCache{
+		seed: seed,
+		keys: make(map[string][]byte),
+	}
+}
+
+// PrivateKey returns the private key at m/44'/coinType'/account'/change/index for chain.
+// The returned slice is a copy that must be zeroed by the caller after use.
+func (c *KeyCache) PrivateKey(chain ChainID, account, change, index uint32) ([]byte, error) {
+	path := GetDerivationPathFull(chain, account, change, index)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := c.keys[path]
+	if !ok {
+		if c.mc == nil {
+			mc, err := NewMnemonicContext(c.seed)
+			if err != nil {
+				return nil, err
+			}
+			c.mc = mc
+		}
+
+		var err error
+		key, err = c.mc.DerivePrivateKey(chain.CoinType(), account, change, index)
+		if err != nil {
+			return nil, err
+		}
+		c.keys[path] = key
+	}
+
+	out := make([]byte, len(key))
+	copy(out, key)
+	return out, nil
+}
+
+// Len returns the number of cached keys.
+func (c *KeyCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.keys)
+}
+
+// Zero clears all cached private keys and intermediate key material.
+func (c *KeyCache) Zero() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for path, key := range c.keys {
+		ZeroBytes(key)
+		delete(c.keys, path)
+	}
+	if c.mc != nil {
+		c.mc.Zero()
+		c.mc = nil
+	}
+}
@@ -0,0 +1,116 @@
+package wallet
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyCache_MatchesDirectDerivation(t *testing.T) {
+	t.Parallel()
+	seed := getTestSeed(t)
+
+	cache := NewKeyCache(seed)
+	defer cache.Zero()
+
+	tests := []struct {
+		name   string
+		chain  ChainID
+		change uint32
+		index  uint32
+	}{
+		{name: "bsv receive 0", chain: ChainBSV, change: ExternalChain, index: 0},
+		{name: "bsv receive 7", chain: ChainBSV, change: ExternalChain, index: 7},
+		{name: "bsv change 3", chain: ChainBSV, change: InternalChain, index: 3},
+		{name: "eth receive 0", chain: ChainETH, change: ExternalChain, index: 0},
+	}
+
+	for _, tc := range tests {
+		want, err := DerivePrivateKeyWithChange(seed, tc.chain, 0, tc.change, tc.index)
+		require.NoError(t, err, tc.name)
+
+		got, err := cache.PrivateKey(tc.chain, 0, tc.change, tc.index)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, want, got, tc.name)
+	}
+	assert.Equal(t, len(tests), cache.Len())
+}
+
+func TestKeyCache_ReusesPath(t *testing.T) {
+	t.Parallel()
+	seed := getTestSeed(t)
+
+	cache := NewKeyCache(seed)
+	defer cache.Zero()
+
+	first, err := cache.PrivateKey(ChainBSV, 0, ExternalChain, 5)
+	require.NoError(t, err)
+	want := append([]byte(nil), first...)
+
+	// Zeroing a returned key must not affect the cached copy.
+	ZeroBytes(first)
+
+	second, err := cache.PrivateKey(ChainBSV, 0, ExternalChain, 5)
+	require.NoError(t, err)
+	assert.Equal(t, want, second)
+	assert.Equal(t, 1, cache.Len())
+}
+
+func TestKeyCache_Zero(t *testing.T) {
+	t.Parallel()
+	seed := getTestSeed(t)
+
+	cache := NewKeyCache(seed)
+	_, err := cache.PrivateKey(ChainBSV, 0, ExternalChain, 0)
+	require.NoError(t, err)
+
+	cached := cache.keys[GetDerivationPathFull(ChainBSV, 0, ExternalChain, 0)]
+	require.NotNil(t, cached)
+
+	cache.Zero()
+	assert.Equal(t, 0, cache.Len())
+	assert.Equal(t, make([]byte, len(cached)), cached, "cached key bytes must be wiped")
+	assert.Nil(t, cache.mc)
+}
+
+func TestKeyCache_Concurrent(t *testing.T) {
+	t.Parallel()
+	seed := getTestSeed(t)
+
+	cache := NewKeyCache(seed)
+	defer cache.Zero()
+
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func(index uint32) {
+			defer wg.Done()
+			key, err := cache.PrivateKey(ChainBSV, 0, ExternalChain, index%4)
+			assert.NoError(t, err)
+			ZeroBytes(key)
+		}(uint32(i)) //nolint:gosec // small loop bound
+	}
+	wg.Wait()
+
+	assert.Equal(t, 4, cache.Len())
+}
+
+func TestMnemonicContext_DerivePrivateKey(t *testing.T) {
+	t.Parallel()
+	seed := getTestSeed(t)
+
+	mc, err := NewMnemonicContext(seed)
+	require.NoError(t, err)
+	defer mc.Zero()
+
+	for _, index := range []uint32{0, 1, 19} {
+		want, err := DerivePrivateKeyWithCoinType(seed, 236, 0, InternalChain, index)
+		require.NoError(t, err)
+
+		got, err := mc.DerivePrivateKey(236, 0, InternalChain, index)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,104 @@
+package ledger
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Ledger devices carry APDUs over 64-byte HID reports. Each report starts
+// with the channel, the APDU tag, and a sequence number; the first report
+// of a message also carries the message length.
+const (
+	hidReportSize = 64
+	hidChannel    = 0x0101
+	hidTagAPDU    = 0x05
+)
+
+// errBadFrame indicates a HID report that is not part of the expected response.
+var errBadFrame = errors.New("unexpected HID report from device")
+
+// hidDevice is an open Ledger HID interface. Each Write sends one report and
+// each Read returns one.
+type hidDevice interface {
+	io.ReadWriteCloser
+
+	// SetReadDeadline makes a blocked Read return once t has passed.
+	SetReadDeadline(t time.Time) error
+}
+
+// frameReports splits a message into the HID reports that carry it.
+func frameReports(msg []byte) [][]byte {
+	var reports [][]byte
+	data := binary.BigEndian.AppendUint16(nil, uint16(len(msg))) //nolint:gosec // G115: APDUs are far below 64 KiB
+	data = append(data, msg...)
+	for seq := 0; len(data) > 0; seq++ {
+		report := make([]byte, hidReportSize)
+		binary.BigEndian.PutUint16(report, hidChannel)
+		report[2] = hidTagAPDU
+		binary.BigEndian.PutUint16(report[3:], uint16(seq)) //nolint:gosec // G115: bounded by the message length
+		n := copy(report[5:], data)
+		data = data[n:]
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// readMessage reads the HID reports of one message and returns the message.
+func readMessage(dev hidDevice) ([]byte, error) {
+	var msg []byte
+	size := -1
+	report := make([]byte, hidReportSize)
+	for seq := 0; size < 0 || len(msg) < size; seq++ {
+		n, err := dev.Read(report)
+		if err != nil {
+			return nil, err
+		}
+		if n < 5 || binary.BigEndian.Uint16(report) != hidChannel || report[2] != hidTagAPDU ||
+			int(binary.BigEndian.Uint16(report[3:])) != seq {
+			return nil, errBadFrame
+		}
+		payload := report[5:n]
+		if seq == 0 {
+			if len(payload) < 2 {
+				return nil, errBadFrame
+			}
+			size = int(binary.BigEndian.Uint16(payload))
+			payload = payload[2:]
+		}
+		msg = append(msg, payload...)
+	}
+	return msg[:size], nil
+}
+
+// exchange sends an APDU and returns the response data once the status word
+// reports success. The read is abandoned when ctx ends, which also covers the
+// user never answering a prompt on the device.
+func exchange(ctx context.Context, dev hidDevice, apdu []byte) ([]byte, error) {
+	for _, report := range frameReports(apdu) {
+		if _, err := dev.Write(report); err != nil {
+			return nil, fmt.Errorf("writing to device: %w", err)
+		}
+	}
+
+	stop := context.AfterFunc(ctx, func() { _ = dev.SetReadDeadline(time.Now()) })
+	defer stop()
+	resp, err := readMessage(dev)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("waiting for device: %w", ctxErr)
+		}
+		return nil, fmt.Errorf("reading from device: %w", err)
+	}
+	if len(resp) < 2 {
+		return nil, errBadFrame
+	}
+	data, sw := resp[:len(resp)-2], binary.BigEndian.Uint16(resp[len(resp)-2:])
+	if sw != swOK {
+		return nil, statusError(sw)
+	}
+	return data, nil
+}
@@ -0,0 +1,194 @@
+//go:build linux
+
+package ledger
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// ledgerVendorID is Ledger's USB vendor ID.
+const ledgerVendorID = 0x2c97
+
+// genericUsagePage opens the report descriptor of the HID interface that
+// carries APDUs (usage page 0xffa0). The device's other interfaces, such as
+// FIDO, use different pages.
+//
+//nolint:gochecknoglobals // Constant byte prefix
+var genericUsagePage = []byte{0x06, 0xa0, 0xff}
+
+//nolint:gochecknoinits // Registers the Ledger transport behind wallet.OpenHardware
+func init() {
+	wallet.RegisterHardwareTransport(&hidrawTransport{sysfs: "/sys/class/hidraw", dev: "/dev"})
+}
+
+// hidrawTransport reaches Ledger devices through the Linux hidraw driver.
+type hidrawTransport struct {
+	sysfs string // directory listing hidraw nodes
+	dev   string // directory holding their device files
+}
+
+// Name identifies the transport.
+func (t *hidrawTransport) Name() string {
+	return "ledger-hid"
+}
+
+// hidrawNode is a Ledger HID interface found in sysfs.
+type hidrawNode struct {
+	name   string // hidraw node, e.g. hidraw3
+	device wallet.HardwareDevice
+}
+
+// Enumerate lists the connected Ledger devices.
+func (t *hidrawTransport) Enumerate(_ context.Context) ([]wallet.HardwareDevice, error) {
+	nodes, err := t.nodes()
+	if err != nil {
+		return nil, err
+	}
+	devices := make([]wallet.HardwareDevice, len(nodes))
+	for i, n := range nodes {
+		devices[i] = n.device
+	}
+	return devices, nil
+}
+
+// Open connects to a device Enumerate returned.
+func (t *hidrawTransport) Open(_ context.Context, device wallet.HardwareDevice) (wallet.HardwareSigner, error) {
+	nodes, err := t.nodes()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range nodes {
+		if n.device.ID != device.ID {
+			continue
+		}
+		path := filepath.Join(t.dev, n.name)
+		f, err := os.OpenFile(path, os.O_RDWR, 0) //nolint:gosec // G304: hidraw node found in sysfs
+		if err != nil {
+			if os.IsPermission(err) {
+				return nil, fmt.Errorf("opening %s: %w; install Ledger's udev rules to allow access", path, err)
+			}
+			return nil, fmt.Errorf("opening %s: %w", path, err)
+		}
+		return &Signer{dev: &hidrawDevice{f: f}, device: n.device}, nil
+	}
+	return nil, fmt.Errorf("%w: no device with ID %q", wallet.ErrNoHardwareDevice, device.ID)
+}
+
+// nodes lists the hidraw nodes of Ledger APDU interfaces.
+func (t *hidrawTransport) nodes() ([]hidrawNode, error) {
+	entries, err := os.ReadDir(t.sysfs)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("listing HID devices: %w", err)
+	}
+
+	var nodes []hidrawNode
+	for _, e := range entries {
+		dir := filepath.Join(t.sysfs, e.Name(), "device")
+		uevent, err := readUevent(filepath.Join(dir, "uevent"))
+		if err != nil {
+			continue
+		}
+		vendor, product, ok := parseHIDID(uevent["HID_ID"])
+		if !ok || vendor != ledgerVendorID {
+			continue
+		}
+		desc, err := os.ReadFile(filepath.Join(dir, "report_descriptor")) //nolint:gosec // G304: sysfs path
+		if err != nil || !bytes.HasPrefix(desc, genericUsagePage) {
+			continue
+		}
+
+		id := e.Name()
+		if phys := uevent["HID_PHYS"]; phys != "" {
+			id = phys
+			if i := strings.LastIndex(phys, "/input"); i > 0 {
+				id = phys[:i]
+			}
+		}
+		nodes = append(nodes, hidrawNode{
+			name: e.Name(),
+			device: wallet.HardwareDevice{
+				Transport: t.Name(),
+				ID:        id,
+				Vendor:    "ledger",
+				Model:     modelName(product),
+			},
+		})
+	}
+	return nodes, nil
+}
+
+// readUevent parses the KEY=value lines of a sysfs uevent file.
+func readUevent(path string) (map[string]string, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: sysfs path
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	values := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if key, value, ok := strings.Cut(scanner.Text(), "="); ok {
+			values[key] = value
+		}
+	}
+	return values, scanner.Err()
+}
+
+// parseHIDID parses a HID_ID value such as 0003:00002C97:00005011 into the
+// vendor and product IDs.
+func parseHIDID(s string) (vendor, product uint16, ok bool) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, 0, false
+	}
+	v, err := strconv.ParseUint(parts[1], 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	p, err := strconv.ParseUint(parts[2], 16, 16)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint16(v), uint16(p), true
+}
+
+// hidrawDevice is an open hidraw node.
+type hidrawDevice struct {
+	f *os.File
+}
+
+// Write sends one report. hidraw expects the report ID first, which is 0
+// for Ledger devices.
+func (d *hidrawDevice) Write(report []byte) (int, error) {
+	n, err := d.f.Write(append([]byte{0x00}, report...))
+	return max(n-1, 0), err
+}
+
+// Read returns one report.
+func (d *hidrawDevice) Read(report []byte) (int, error) {
+	return d.f.Read(report)
+}
+
+// SetReadDeadline bounds a blocked Read.
+func (d *hidrawDevice) SetReadDeadline(t time.Time) error {
+	return d.f.SetReadDeadline(t)
+}
+
+// Close closes the node.
+func (d *hidrawDevice) Close() error {
+	return d.f.Close()
+}
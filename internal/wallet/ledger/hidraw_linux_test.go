@@ -0,0 +1,83 @@
+//go:build linux
+
+package ledger
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// writeHidrawNode adds a hidraw node to a fake sysfs tree.
+func writeHidrawNode(t *testing.T, sysfs, name, uevent string, descriptor []byte) {
+	t.Helper()
+	dir := filepath.Join(sysfs, name, "device")
+	require.NoError(t, os.MkdirAll(dir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "uevent"), []byte(uevent), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "report_descriptor"), descriptor, 0o600))
+}
+
+func TestHidrawTransport(t *testing.T) {
+	t.Parallel()
+
+	sysfs := t.TempDir()
+	dev := t.TempDir()
+	writeHidrawNode(t, sysfs, "hidraw0",
+		"DRIVER=hid-generic\nHID_ID=0003:00002C97:00005011\nHID_NAME=Ledger Nano S Plus\nHID_PHYS=usb-0000:00:14.0-2/input0\n",
+		[]byte{0x06, 0xa0, 0xff, 0x09, 0x01})
+	// The FIDO interface of the same device
+	writeHidrawNode(t, sysfs, "hidraw1",
+		"HID_ID=0003:00002C97:00005011\nHID_PHYS=usb-0000:00:14.0-2/input1\n",
+		[]byte{0x06, 0xd0, 0xf1, 0x09, 0x01})
+	// A keyboard
+	writeHidrawNode(t, sysfs, "hidraw2", "HID_ID=0003:0000046D:0000C31C\n", []byte{0x05, 0x01})
+	require.NoError(t, os.WriteFile(filepath.Join(dev, "hidraw0"), nil, 0o600))
+
+	tr := &hidrawTransport{sysfs: sysfs, dev: dev}
+	devices, err := tr.Enumerate(context.Background())
+	require.NoError(t, err)
+	require.Len(t, devices, 1)
+	assert.Equal(t, wallet.HardwareDevice{
+		Transport: "ledger-hid",
+		ID:        "usb-0000:00:14.0-2",
+		Vendor:    "ledger",
+		Model:     "Nano S Plus",
+	}, devices[0])
+
+	signer, err := tr.Open(context.Background(), devices[0])
+	require.NoError(t, err)
+	assert.Equal(t, devices[0], signer.Device())
+	require.NoError(t, signer.Close())
+
+	_, err = tr.Open(context.Background(), wallet.HardwareDevice{ID: "gone"})
+	require.ErrorIs(t, err, wallet.ErrNoHardwareDevice)
+
+	// No hidraw class at all means no devices
+	devices, err = (&hidrawTransport{sysfs: filepath.Join(sysfs, "missing")}).Enumerate(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, devices)
+}
+
+func TestParseHIDID(t *testing.T) {
+	t.Parallel()
+
+	vendor, product, ok := parseHIDID("0003:00002C97:00004011")
+	require.True(t, ok)
+	assert.Equal(t, uint16(ledgerVendorID), vendor)
+	assert.Equal(t, uint16(0x4011), product)
+
+	_, _, ok = parseHIDID("garbage")
+	assert.False(t, ok)
+}
+
+func TestTransportRegistered(t *testing.T) {
+	t.Parallel()
+
+	assert.Contains(t, wallet.HardwareTransports(), "ledger-hid")
+}
@@ -0,0 +1,290 @@
+// Package ledger signs transactions on Ledger hardware wallets through the
+// Ethereum app. Importing it registers its transport with the wallet
+// package on platforms where it can reach the device.
+package ledger
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// Ethereum app instructions.
+const (
+	claETH        = 0xe0
+	insGetAddress = 0x02
+	insSignTx     = 0x04
+
+	p1NoDisplay = 0x00
+	p1Display   = 0x01
+	p1FirstData = 0x00
+	p1MoreData  = 0x80
+
+	// signChunkSize is the largest transaction chunk sent in one APDU, as
+	// used by Ledger's own libraries.
+	signChunkSize = 150
+)
+
+// Status words returned by the device.
+const (
+	swOK          = 0x9000
+	swRejected    = 0x6985
+	swInvalidData = 0x6a80
+	swLocked      = 0x5515
+	swWrongApp    = 0x6511
+	swNoApp       = 0x6e01
+	swINSNotFound = 0x6d00
+	swCLANotFound = 0x6e00
+)
+
+// BIP32 path limits.
+const (
+	maxPathDepth   = 10
+	hardenedOffset = 0x80000000
+)
+
+var (
+	// ErrRejected indicates the user rejected the request on the device.
+	ErrRejected = errors.New("rejected on the device")
+
+	// ErrLocked indicates the device is locked.
+	ErrLocked = errors.New("device is locked; unlock it with its PIN")
+
+	// ErrAppNotOpen indicates the Ethereum app is not open on the device.
+	ErrAppNotOpen = errors.New("open the Ethereum app on the device")
+
+	// ErrUnsupportedChain indicates a chain the Ledger transport cannot sign for.
+	ErrUnsupportedChain = errors.New("the Ledger transport only signs ETH")
+
+	// ErrInvalidPath indicates a malformed BIP32 derivation path.
+	ErrInvalidPath = errors.New("invalid derivation path")
+
+	// errBadResponse indicates a response too short for its declared fields.
+	errBadResponse = errors.New("malformed response from device")
+)
+
+// StatusError is a status word the device answered with that has no more
+// specific error.
+type StatusError struct {
+	Code uint16
+}
+
+// Error implements error.
+func (e *StatusError) Error() string {
+	if e.Code == swInvalidData {
+		return "device refused the transaction data (status 0x6a80); contract calls such as token " +
+			"transfers need blind signing enabled in the Ethereum app settings"
+	}
+	return fmt.Sprintf("device returned status 0x%04x", e.Code)
+}
+
+// statusError maps a failing status word to an error.
+func statusError(sw uint16) error {
+	switch sw {
+	case swRejected:
+		return ErrRejected
+	case swLocked:
+		return ErrLocked
+	case swWrongApp, swNoApp, swINSNotFound, swCLANotFound:
+		return ErrAppNotOpen
+	default:
+		return &StatusError{Code: sw}
+	}
+}
+
+// Signer is an open connection to a Ledger device. It implements
+// wallet.HardwareSigner.
+type Signer struct {
+	mu     sync.Mutex
+	dev    hidDevice
+	device wallet.HardwareDevice
+}
+
+// Device returns the device the signer is connected to.
+func (s *Signer) Device() wallet.HardwareDevice {
+	return s.device
+}
+
+// Address returns the ETH address of the key at path. With display set the
+// device shows the path and address and waits for the user to approve them.
+func (s *Signer) Address(ctx context.Context, chainID wallet.ChainID, path string, display bool) (string, error) {
+	if chainID != wallet.ChainETH {
+		return "", fmt.Errorf("%w, not %s", ErrUnsupportedChain, strings.ToUpper(string(chainID)))
+	}
+	data, err := encodePath(path)
+	if err != nil {
+		return "", err
+	}
+	p1 := byte(p1NoDisplay)
+	if display {
+		p1 = p1Display
+	}
+
+	resp, err := s.exchange(ctx, apdu(insGetAddress, p1, data))
+	if err != nil {
+		return "", err
+	}
+	return parseAddressResponse(resp)
+}
+
+// Sign has the device sign an ETH transaction after the user reviews it on
+// the device screen.
+func (s *Signer) Sign(ctx context.Context, req *wallet.HardwareSignRequest) (*chain.SignedRawTx, error) {
+	tx := req.Tx
+	if tx.Chain != chain.ETH || tx.ETH == nil {
+		return nil, fmt.Errorf("%w, not %s", ErrUnsupportedChain, strings.ToUpper(string(tx.Chain)))
+	}
+	path, ok := req.Paths[tx.From]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", wallet.ErrNoSigningPath, tx.From)
+	}
+	pathData, err := encodePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	raw, hash, err := eth.SignUnsignedWith(tx.ETH, tx.From, func(payload []byte) ([]byte, []byte, error) {
+		return s.signPayload(ctx, pathData, payload)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &chain.SignedRawTx{
+		Version:  chain.OfflineTxVersion,
+		Chain:    tx.Chain,
+		Network:  tx.Network,
+		Hash:     hash,
+		Hex:      hex.EncodeToString(raw),
+		From:     tx.From,
+		To:       tx.To,
+		Amount:   tx.Amount,
+		Fee:      tx.Fee,
+		Token:    tx.Token,
+		Symbol:   tx.Symbol,
+		Decimals: tx.Decimals,
+	}, nil
+}
+
+// signPayload streams the RLP payload to the device in chunks, the first
+// prefixed with the key's path, and returns the R and S of the signature.
+func (s *Signer) signPayload(ctx context.Context, pathData, payload []byte) ([]byte, []byte, error) {
+	var resp []byte
+	first := true
+	for len(payload) > 0 || first {
+		p1 := byte(p1MoreData)
+		var data []byte
+		size := signChunkSize
+		if first {
+			p1 = p1FirstData
+			data = append(data, pathData...)
+			size -= len(pathData)
+		}
+		n := min(size, len(payload))
+		data = append(data, payload[:n]...)
+		payload = payload[n:]
+		first = false
+
+		var err error
+		if resp, err = s.exchange(ctx, apdu(insSignTx, p1, data)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// The response is V || R || S; V is recomputed from the recovered key
+	if len(resp) != 65 {
+		return nil, nil, errBadResponse
+	}
+	return resp[1:33], resp[33:65], nil
+}
+
+// exchange sends one APDU, serializing access to the device.
+func (s *Signer) exchange(ctx context.Context, msg []byte) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return exchange(ctx, s.dev, msg)
+}
+
+// Close releases the device.
+func (s *Signer) Close() error {
+	return s.dev.Close()
+}
+
+// modelName names a Ledger model from its USB product ID. Current firmware
+// puts the model in the high byte; older firmware used small IDs.
+func modelName(product uint16) string {
+	model := product >> 8
+	if product < 0x100 {
+		model = product << 4
+	}
+	switch model {
+	case 0x10:
+		return "Nano S"
+	case 0x40:
+		return "Nano X"
+	case 0x50:
+		return "Nano S Plus"
+	case 0x60:
+		return "Stax"
+	case 0x70:
+		return "Flex"
+	default:
+		return fmt.Sprintf("0x%04x", product)
+	}
+}
+
+// apdu builds an Ethereum app APDU.
+func apdu(ins, p1 byte, data []byte) []byte {
+	msg := []byte{claETH, ins, p1, 0x00, byte(len(data))} //nolint:gosec // G115: chunks stay under signChunkSize
+	return append(msg, data...)
+}
+
+// encodePath encodes a path such as m/44'/60'/0'/0/0 as the device expects:
+// the number of components, then each as a big-endian uint32.
+func encodePath(path string) ([]byte, error) {
+	parts := strings.Split(strings.TrimPrefix(strings.TrimSpace(path), "m/"), "/")
+	if len(parts) == 0 || len(parts) > maxPathDepth || parts[0] == "" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+
+	data := []byte{byte(len(parts))}
+	for _, part := range parts {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		n, err := strconv.ParseUint(strings.TrimRight(part, "'h"), 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		index := uint32(n) //nolint:gosec // G115: parsed as 31 bits
+		if hardened {
+			index += hardenedOffset
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+	}
+	return data, nil
+}
+
+// parseAddressResponse extracts the address from a get address response:
+// the public key and the address, each preceded by its length.
+func parseAddressResponse(resp []byte) (string, error) {
+	if len(resp) < 1 {
+		return "", errBadResponse
+	}
+	offset := 1 + int(resp[0])
+	if len(resp) < offset+1 {
+		return "", errBadResponse
+	}
+	size := int(resp[offset])
+	offset++
+	if size != 40 || len(resp) < offset+size {
+		return "", errBadResponse
+	}
+	return "0x" + string(resp[offset:offset+size]), nil
+}
@@ -0,0 +1,231 @@
+package ledger
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/chain/eth"
+	ethcrypto "github.com/mrz1836/sigil/internal/chain/eth/crypto"
+	"github.com/mrz1836/sigil/internal/wallet"
+)
+
+// fakeDevice is a hidDevice that answers each APDU written to it with the
+// response of handle.
+type fakeDevice struct {
+	handle  func(apdu []byte) []byte
+	written bytes.Buffer
+	pending [][]byte
+	apdus   [][]byte
+	closed  bool
+}
+
+func (f *fakeDevice) Write(report []byte) (int, error) {
+	f.written.Write(report)
+	msg, err := readMessage(&reportReader{buf: bytes.NewReader(f.written.Bytes())})
+	if err != nil {
+		return len(report), nil //nolint:nilerr // the APDU is not complete yet
+	}
+	f.written.Reset()
+	f.apdus = append(f.apdus, msg)
+	f.pending = append(f.pending, frameReports(f.handle(msg))...)
+	return len(report), nil
+}
+
+func (f *fakeDevice) Read(report []byte) (int, error) {
+	if len(f.pending) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(report, f.pending[0])
+	f.pending = f.pending[1:]
+	return n, nil
+}
+
+func (f *fakeDevice) SetReadDeadline(time.Time) error { return nil }
+
+func (f *fakeDevice) Close() error {
+	f.closed = true
+	return nil
+}
+
+// reportReader reads the reports of a buffer one at a time.
+type reportReader struct{ buf *bytes.Reader }
+
+func (r *reportReader) Read(report []byte) (int, error) {
+	return io.ReadFull(r.buf, report[:hidReportSize])
+}
+
+func (r *reportReader) Write([]byte) (int, error)       { return 0, io.ErrClosedPipe }
+func (r *reportReader) SetReadDeadline(time.Time) error { return nil }
+func (r *reportReader) Close() error                    { return nil }
+
+// withStatus appends a status word to response data.
+func withStatus(data []byte, sw uint16) []byte {
+	return binary.BigEndian.AppendUint16(append([]byte(nil), data...), sw)
+}
+
+func TestFrameReports(t *testing.T) {
+	t.Parallel()
+
+	msg := bytes.Repeat([]byte{0xab}, 200)
+	reports := frameReports(msg)
+	require.Len(t, reports, 4) // 57 + 59 + 59 + 25 bytes
+	for i, r := range reports {
+		assert.Len(t, r, hidReportSize)
+		assert.Equal(t, []byte{0x01, 0x01, 0x05, 0x00, byte(i)}, r[:5])
+	}
+	assert.Equal(t, []byte{0x00, 200}, reports[0][5:7])
+
+	var buf bytes.Buffer
+	for _, r := range reports {
+		buf.Write(r)
+	}
+	got, err := readMessage(&reportReader{buf: bytes.NewReader(buf.Bytes())})
+	require.NoError(t, err)
+	assert.Equal(t, msg, got)
+
+	// A report from another channel is rejected
+	bad := append([]byte(nil), reports[0]...)
+	bad[0] = 0x02
+	_, err = readMessage(&reportReader{buf: bytes.NewReader(bad)})
+	require.ErrorIs(t, err, errBadFrame)
+}
+
+func TestEncodePath(t *testing.T) {
+	t.Parallel()
+
+	got, err := encodePath("m/44'/60'/0'/0/7")
+	require.NoError(t, err)
+	assert.Equal(t, "058000002c8000003c800000000000000000000007", hex.EncodeToString(got))
+
+	for _, bad := range []string{"", "m/", "m/44'/x", "m/2147483648", strings.Repeat("0/", 10) + "0"} {
+		_, err := encodePath(bad)
+		require.ErrorIs(t, err, ErrInvalidPath, bad)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	t.Parallel()
+
+	assert.ErrorIs(t, statusError(swRejected), ErrRejected)
+	assert.ErrorIs(t, statusError(swLocked), ErrLocked)
+	assert.ErrorIs(t, statusError(swCLANotFound), ErrAppNotOpen)
+	assert.Contains(t, statusError(swInvalidData).Error(), "blind signing")
+	assert.Equal(t, "device returned status 0x6700", statusError(0x6700).Error())
+}
+
+func TestModelName(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "Nano S Plus", modelName(0x5011))
+	assert.Equal(t, "Nano X", modelName(0x0004))
+	assert.Equal(t, "0x9999", modelName(0x9999))
+}
+
+func TestSigner_Address(t *testing.T) {
+	t.Parallel()
+
+	const addr = "5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"
+	dev := &fakeDevice{handle: func(apdu []byte) []byte {
+		resp := append([]byte{65}, bytes.Repeat([]byte{0x04}, 65)...)
+		resp = append(resp, 40)
+		return withStatus(append(resp, addr...), swOK)
+	}}
+	s := &Signer{dev: dev}
+
+	got, err := s.Address(context.Background(), wallet.ChainETH, "m/44'/60'/0'/0/0", true)
+	require.NoError(t, err)
+	assert.Equal(t, "0x"+addr, got)
+	require.Len(t, dev.apdus, 1)
+	assert.Equal(t, []byte{claETH, insGetAddress, p1Display, 0x00, 21}, dev.apdus[0][:5])
+
+	_, err = s.Address(context.Background(), wallet.ChainETH, "m/44'/60'/0'/0/0", false)
+	require.NoError(t, err)
+	assert.Equal(t, byte(p1NoDisplay), dev.apdus[1][2])
+
+	_, err = s.Address(context.Background(), wallet.ChainBSV, "m/44'/236'/0'/0/0", true)
+	require.ErrorIs(t, err, ErrUnsupportedChain)
+
+	dev.handle = func([]byte) []byte { return withStatus(nil, swRejected) }
+	_, err = s.Address(context.Background(), wallet.ChainETH, "m/44'/60'/0'/0/0", true)
+	require.ErrorIs(t, err, ErrRejected)
+
+	require.NoError(t, s.Close())
+	assert.True(t, dev.closed)
+}
+
+func TestSigner_Sign(t *testing.T) {
+	t.Parallel()
+
+	privateKey := bytes.Repeat([]byte{0x11}, 32)
+	from, err := eth.DeriveAddress(privateKey)
+	require.NoError(t, err)
+
+	// The device signs the payload streamed to it so far
+	var payload []byte
+	dev := &fakeDevice{handle: func(apdu []byte) []byte {
+		data := apdu[5:]
+		if apdu[2] == p1FirstData {
+			payload = nil
+			data = data[1+4*int(data[0]):]
+		}
+		payload = append(payload, data...)
+		sig, signErr := ethcrypto.Sign(ethcrypto.Keccak256(payload), privateKey)
+		if signErr != nil {
+			return withStatus(nil, 0x6f00)
+		}
+		return withStatus(append([]byte{sig[64] + 37}, sig[:64]...), swOK)
+	}}
+	s := &Signer{dev: dev}
+
+	tx := &chain.UnsignedTx{
+		Chain: chain.ETH,
+		From:  from,
+		To:    "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		ETH: &chain.UnsignedETH{
+			ChainID:  "1",
+			Nonce:    2,
+			To:       "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+			Value:    "0",
+			GasLimit: 90000,
+			GasPrice: "1000000000",
+			Data:     strings.Repeat("ab", 300), // long enough to need several chunks
+		},
+	}
+	paths := map[string]string{from: "m/44'/60'/0'/0/0"}
+
+	signed, err := s.Sign(context.Background(), &wallet.HardwareSignRequest{Tx: tx, Paths: paths})
+	require.NoError(t, err)
+
+	wantRaw, wantHash, err := eth.SignUnsigned(tx.ETH, from, privateKey)
+	require.NoError(t, err)
+	assert.Equal(t, hex.EncodeToString(wantRaw), signed.Hex)
+	assert.Equal(t, wantHash, signed.Hash)
+
+	require.Greater(t, len(dev.apdus), 1)
+	assert.Equal(t, byte(p1FirstData), dev.apdus[0][2])
+	for _, a := range dev.apdus[1:] {
+		assert.Equal(t, byte(p1MoreData), a[2])
+		assert.LessOrEqual(t, len(a)-5, signChunkSize)
+	}
+
+	// A device holding another seed signs with the wrong key
+	otherKey := bytes.Repeat([]byte{0x22}, 32)
+	otherFrom, err := eth.DeriveAddress(otherKey)
+	require.NoError(t, err)
+	tx.From = otherFrom
+	_, err = s.Sign(context.Background(), &wallet.HardwareSignRequest{Tx: tx, Paths: map[string]string{otherFrom: "m/44'/60'/0'/0/0"}})
+	require.ErrorIs(t, err, eth.ErrSignerMismatch)
+
+	_, err = s.Sign(context.Background(), &wallet.HardwareSignRequest{Tx: &chain.UnsignedTx{Chain: chain.BSV}})
+	require.ErrorIs(t, err, ErrUnsupportedChain)
+}
@@ -0,0 +1,180 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const (
+	// lockFileExtension is the suffix of a wallet's advisory lock file.
+	lockFileExtension = ".lock"
+
+	// DefaultLockTimeout is how long an operation waits for a busy wallet.
+	DefaultLockTimeout = 30 * time.Second
+
+	// lockPollInterval is how often a waiting operation retries the lock.
+	lockPollInterval = 100 * time.Millisecond
+)
+
+// ErrWalletBusy indicates another sigil process holds the wallet lock.
+var ErrWalletBusy = sigilerr.ErrWalletBusy
+
+// lockInfo is the content of a wallet lock file.
+type lockInfo struct {
+	PID       int       `json:"pid"`
+	Operation string    `json:"operation"`
+	Acquired  time.Time `json:"acquired"`
+}
+
+// Lock is an advisory, cross-process lock on a single wallet. It is held for
+// operations that read wallet state and then write it back — sends, UTXO
+// refreshes and scans — so two processes cannot, for example, select the same
+// UTXOs before either records them as spent.
+//
+// The lock is an OS file lock (flock on Unix, LockFileEx on Windows) on the
+// wallet's lock file. The operating system drops it when the holding process
+// exits, so a crashed holder never leaves the wallet locked and there is no
+// stale-lock takeover for two processes to race on.
+type Lock struct {
+	file *os.File
+}
+
+// Lock acquires the advisory lock for the named wallet, waiting up to timeout
+// for a concurrent holder to finish. The operation name is recorded in the
+// lock file and reported to anyone who finds the wallet busy.
+// The returned lock must be released with Release.
+func (s *FileStorage) Lock(ctx context.Context, name, operation string, timeout time.Duration) (*Lock, error) {
+	if err := ValidateWalletName(name); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(s.basePath, walletDirPermissions); err != nil {
+		return nil, fmt.Errorf("creating wallet directory: %w", err)
+	}
+
+	path := filepath.Join(s.basePath, name+lockFileExtension)
+	deadline := time.Now().Add(timeout)
+
+	for {
+		lock, err := tryLock(path, operation)
+		if err != nil {
+			return nil, err
+		}
+		if lock != nil {
+			return lock, nil
+		}
+
+		if time.Now().After(deadline) {
+			return nil, walletBusyError(name, readLock(path))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, walletBusyError(name, readLock(path))
+		case <-time.After(lockPollInterval):
+		}
+	}
+}
+
+// Release clears the holder record and drops the lock. The lock file itself
+// is left in place for the next holder. It is safe to call on a nil lock and
+// more than once.
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	f := l.file
+	l.file = nil
+
+	truncErr := f.Truncate(0)
+	unlockErr := unlockFile(f)
+	closeErr := f.Close()
+	if err := errors.Join(truncErr, unlockErr, closeErr); err != nil {
+		return fmt.Errorf("releasing wallet lock: %w", err)
+	}
+	return nil
+}
+
+// tryLock opens the lock file and attempts to take the OS lock without
+// blocking. It returns a nil lock when another process holds it.
+func tryLock(path, operation string) (*Lock, error) {
+	//nolint:gosec // G304: path is built from a validated wallet name
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, walletFilePermissions)
+	if err != nil {
+		return nil, fmt.Errorf("opening wallet lock: %w", err)
+	}
+
+	acquired, err := lockFile(f)
+	if err != nil || !acquired {
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("locking wallet: %w", err)
+		}
+		return nil, nil
+	}
+
+	if err := writeLock(f, operation); err != nil {
+		_ = unlockFile(f)
+		_ = f.Close()
+		return nil, err
+	}
+	return &Lock{file: f}, nil
+}
+
+// writeLock records the current process as the lock holder.
+func writeLock(f *os.File, operation string) error {
+	data, _ := json.Marshal(lockInfo{PID: os.Getpid(), Operation: operation, Acquired: time.Now().UTC()})
+	if err := f.Truncate(0); err != nil {
+		return fmt.Errorf("writing wallet lock: %w", err)
+	}
+	if _, err := f.WriteAt(data, 0); err != nil {
+		return fmt.Errorf("writing wallet lock: %w", err)
+	}
+	return nil
+}
+
+// readLock returns the holder recorded in the lock file, or nil when it is
+// missing or unreadable. It is only used to describe a busy wallet.
+func readLock(path string) *lockInfo {
+	//nolint:gosec // G304: path is built from a validated wallet name
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var info lockInfo
+	if json.Unmarshal(data, &info) != nil || info.PID <= 0 {
+		return nil
+	}
+	return &info
+}
+
+// walletBusyError builds the "wallet busy" error for a held lock.
+func walletBusyError(name string, held *lockInfo) error {
+	details := map[string]string{"wallet": name}
+	holder := "another sigil process"
+	if held != nil && held.PID > 0 {
+		details["pid"] = strconv.Itoa(held.PID)
+		if held.Operation != "" {
+			details["operation"] = held.Operation
+			holder = fmt.Sprintf("'%s' (pid %d)", held.Operation, held.PID)
+		} else {
+			holder = fmt.Sprintf("pid %d", held.PID)
+		}
+	}
+
+	return sigilerr.WithDetails(
+		sigilerr.WithSuggestion(
+			ErrWalletBusy,
+			fmt.Sprintf("wallet '%s' is in use by %s. Wait for it to finish and try again", name, holder),
+		),
+		details,
+	)
+}
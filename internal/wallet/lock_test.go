@@ -0,0 +1,186 @@
+package wallet
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// deadPID is far above any real PID limit, so no process can own it.
+const deadPID = 0x7ffffffe
+
+func writeTestLock(t *testing.T, dir, name string, info lockInfo) string {
+	t.Helper()
+	path := filepath.Join(dir, name+lockFileExtension)
+	data, err := json.Marshal(info)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestFileStorage_Lock(t *testing.T) {
+	t.Parallel()
+
+	t.Run("acquire and release", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		storage := NewFileStorage(dir)
+
+		lock, err := storage.Lock(context.Background(), "main", "tx send", time.Second)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(dir, "main.lock")) //nolint:gosec // test path
+		require.NoError(t, err)
+		var info lockInfo
+		require.NoError(t, json.Unmarshal(data, &info))
+		assert.Equal(t, os.Getpid(), info.PID)
+		assert.Equal(t, "tx send", info.Operation)
+
+		require.NoError(t, lock.Release())
+		require.NoError(t, lock.Release(), "release is idempotent")
+		data, err = os.ReadFile(filepath.Join(dir, "main.lock")) //nolint:gosec // test path
+		require.NoError(t, err)
+		assert.Empty(t, data, "holder record is cleared on release")
+
+		again, err := storage.Lock(context.Background(), "main", "tx send", 0)
+		require.NoError(t, err)
+		require.NoError(t, again.Release())
+	})
+
+	t.Run("busy while held", func(t *testing.T) {
+		t.Parallel()
+		storage := NewFileStorage(t.TempDir())
+
+		lock, err := storage.Lock(context.Background(), "main", "utxo refresh", time.Second)
+		require.NoError(t, err)
+		defer func() { _ = lock.Release() }()
+
+		_, err = storage.Lock(context.Background(), "main", "tx send", 150*time.Millisecond)
+		require.ErrorIs(t, err, ErrWalletBusy)
+
+		var se *sigilerr.SigilError
+		require.ErrorAs(t, err, &se)
+		assert.Equal(t, "utxo refresh", se.Details["operation"])
+		assert.Contains(t, se.Suggestion, "in use by 'utxo refresh'")
+	})
+
+	t.Run("other wallets are independent", func(t *testing.T) {
+		t.Parallel()
+		storage := NewFileStorage(t.TempDir())
+
+		lock, err := storage.Lock(context.Background(), "main", "tx send", time.Second)
+		require.NoError(t, err)
+		defer func() { _ = lock.Release() }()
+
+		other, err := storage.Lock(context.Background(), "savings", "tx send", time.Second)
+		require.NoError(t, err)
+		require.NoError(t, other.Release())
+	})
+
+	t.Run("waits for release", func(t *testing.T) {
+		t.Parallel()
+		storage := NewFileStorage(t.TempDir())
+
+		lock, err := storage.Lock(context.Background(), "main", "tx send", time.Second)
+		require.NoError(t, err)
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			_ = lock.Release()
+		}()
+
+		next, err := storage.Lock(context.Background(), "main", "tx send", 5*time.Second)
+		require.NoError(t, err)
+		require.NoError(t, next.Release())
+	})
+
+	t.Run("leftover lock file from dead process is taken over", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTestLock(t, dir, "main", lockInfo{PID: deadPID, Operation: "tx send", Acquired: time.Now()})
+
+		lock, err := NewFileStorage(dir).Lock(context.Background(), "main", "utxo refresh", 0)
+		require.NoError(t, err)
+		require.NoError(t, lock.Release())
+	})
+
+	t.Run("unreadable lock file is taken over", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "main.lock"), []byte("garbage"), 0o600))
+
+		lock, err := NewFileStorage(dir).Lock(context.Background(), "main", "tx send", 0)
+		require.NoError(t, err)
+		require.NoError(t, lock.Release())
+	})
+
+	t.Run("concurrent takeover of a leftover lock has one winner", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		writeTestLock(t, dir, "main", lockInfo{PID: deadPID, Operation: "tx send", Acquired: time.Now()})
+		storage := NewFileStorage(dir)
+
+		const contenders = 8
+		var (
+			wg      sync.WaitGroup
+			winners atomic.Int32
+			start   = make(chan struct{})
+			done    = make(chan struct{})
+		)
+		for range contenders {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				<-start
+				lock, err := storage.Lock(context.Background(), "main", "tx send", 0)
+				if err != nil {
+					return
+				}
+				winners.Add(1)
+				<-done
+				_ = lock.Release()
+			}()
+		}
+		close(start)
+		time.Sleep(200 * time.Millisecond)
+		assert.Equal(t, int32(1), winners.Load())
+		close(done)
+		wg.Wait()
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		t.Parallel()
+		storage := NewFileStorage(t.TempDir())
+
+		lock, err := storage.Lock(context.Background(), "main", "tx send", time.Second)
+		require.NoError(t, err)
+		defer func() { _ = lock.Release() }()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err = storage.Lock(ctx, "main", "tx send", time.Minute)
+		require.ErrorIs(t, err, ErrWalletBusy)
+	})
+
+	t.Run("invalid wallet name", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewFileStorage(t.TempDir()).Lock(context.Background(), "../evil", "tx send", 0)
+		require.Error(t, err)
+	})
+}
+
+func TestLock_ReleaseNil(t *testing.T) {
+	t.Parallel()
+
+	var lock *Lock
+	assert.NoError(t, lock.Release())
+}
@@ -0,0 +1,33 @@
+//go:build windows
+
+package wallet
+
+import (
+	"errors"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// lockRangeOffsetHigh places the locked byte far past the holder record.
+// Windows byte-range locks are mandatory, so locking the record itself would
+// stop a waiting process from reading who holds the wallet.
+const lockRangeOffsetHigh = 0x7fffffff
+
+// lockFile takes an exclusive LockFileEx lock on f without blocking. It
+// reports false when another handle already holds the lock.
+func lockFile(f *os.File) (bool, error) {
+	ol := &windows.Overlapped{OffsetHigh: lockRangeOffsetHigh}
+	err := windows.LockFileEx(windows.Handle(f.Fd()),
+		windows.LOCKFILE_EXCLUSIVE_LOCK|windows.LOCKFILE_FAIL_IMMEDIATELY, 0, 1, 0, ol)
+	if errors.Is(err, windows.ERROR_LOCK_VIOLATION) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// unlockFile drops the lock held on f.
+func unlockFile(f *os.File) error {
+	ol := &windows.Overlapped{OffsetHigh: lockRangeOffsetHigh}
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, ol)
+}
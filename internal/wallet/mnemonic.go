@@ -3,6 +3,8 @@
 package wallet
 
 import (
+	"crypto/pbkdf2"
+	"crypto/sha512"
 	"errors"
 	"math"
 	"regexp"
@@ -29,34 +31,15 @@ var (
 	bulletListRegex = regexp.MustCompile(`(?m)^\s*[-*•]\s*`)
 )
 
-// GenerateMnemonic creates a new BIP39 mnemonic phrase.
-// wordCount must be 12 (128 bits entropy) or 24 (256 bits entropy).
+// GenerateMnemonic creates a new English BIP39 mnemonic phrase from the
+// system CSPRNG. wordCount must be 12 (128 bits entropy) or 24 (256 bits entropy).
 func GenerateMnemonic(wordCount int) (string, error) {
-	var bitSize int
-	switch wordCount {
-	case 12:
-		bitSize = 128
-	case 24:
-		bitSize = 256
-	default:
-		return "", ErrInvalidWordCount
-	}
-
-	entropy, err := bip39.NewEntropy(bitSize)
-	if err != nil {
-		return "", err
-	}
-
-	mnemonic, err := bip39.NewMnemonic(entropy)
-	if err != nil {
-		return "", err
-	}
-
-	return mnemonic, nil
+	return NewMnemonic(SystemSeedSource(), DefaultLanguage, wordCount, nil)
 }
 
 // ValidateMnemonic checks if a mnemonic phrase is valid according to BIP39.
-// It verifies word count, word validity, and checksum.
+// It verifies word count, word validity, and checksum against every
+// supported wordlist.
 func ValidateMnemonic(mnemonic string) error {
 	if mnemonic == "" {
 		return ErrInvalidMnemonic
@@ -72,8 +55,8 @@ func ValidateMnemonic(mnemonic string) error {
 		return ErrInvalidMnemonic
 	}
 
-	// MnemonicToByteArray validates word count, word validity, AND checksum
-	if _, err := bip39.MnemonicToByteArray(normalized); err != nil {
+	// DetectLanguage validates word validity AND checksum
+	if _, err := DetectLanguage(normalized); err != nil {
 		return ErrInvalidMnemonic
 	}
 
@@ -81,6 +64,7 @@ func ValidateMnemonic(mnemonic string) error {
 }
 
 // NormalizeMnemonicInput cleans and normalizes mnemonic input by:
+// - Converting to Unicode NFKD form (as BIP39 requires)
 // - Converting to lowercase
 // - Removing numbered list prefixes (1. 2) 3: etc.)
 // - Removing bullet prefixes (- * •)
@@ -88,6 +72,9 @@ func ValidateMnemonic(mnemonic string) error {
 // - Trimming leading and trailing whitespace
 // - Collapsing multiple whitespace characters to single spaces
 func NormalizeMnemonicInput(input string) string {
+	// NFKD also turns the ideographic space between Japanese words into a space
+	input = nfkd(input)
+
 	// Convert to lowercase
 	input = strings.ToLower(input)
 
@@ -109,19 +96,20 @@ func NormalizeMnemonicInput(input string) string {
 	return input
 }
 
-// MnemonicToSeed converts a BIP39 mnemonic phrase to a 64-byte seed.
-// The passphrase is optional (can be empty string).
+// MnemonicToSeed converts a BIP39 mnemonic phrase in any supported wordlist
+// to a 64-byte seed. The passphrase is optional (can be empty string). Both
+// are NFKD-normalized as BIP39 specifies, so a passphrase with accented or
+// full-width characters derives the same seed as in other BIP39 wallets.
 // The returned seed should be handled securely and zeroed after use.
 func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
 	normalized := NormalizeMnemonicInput(mnemonic)
 
-	// MnemonicToByteArray validates word count, word validity, AND checksum
-	if _, err := bip39.MnemonicToByteArray(normalized); err != nil {
+	// DetectLanguage validates word validity AND checksum
+	if _, err := DetectLanguage(normalized); err != nil {
 		return nil, ErrInvalidMnemonic
 	}
 
-	seed := bip39.NewSeed(normalized, passphrase)
-	return seed, nil
+	return pbkdf2.Key(sha512.New, normalized, []byte("mnemonic"+nfkd(passphrase)), 2048, 64)
 }
 
 // GetWordList returns the BIP39 English word list.
@@ -129,11 +117,11 @@ func GetWordList() []string {
 	return bip39.WordList
 }
 
-// IsValidWord checks if a word is in the BIP39 word list.
+// IsValidWord checks if a word is in any supported BIP39 word list.
 func IsValidWord(word string) bool {
-	word = strings.ToLower(word)
-	for _, w := range bip39.WordList {
-		if w == word {
+	word = strings.ToLower(nfkd(word))
+	for _, lang := range Languages() {
+		if _, ok := loadWordlist(lang).index[word]; ok {
 			return true
 		}
 	}
@@ -156,11 +144,16 @@ type TypoInfo struct {
 	Distance int
 }
 
-// SuggestWord finds the closest BIP39 word to the input using Levenshtein distance.
+// SuggestWord finds the closest BIP39 English word to the input using Levenshtein distance.
 // Returns empty string if no word is close enough (distance > MaxTypoDistance).
 func SuggestWord(input string) string {
-	input = strings.ToLower(input)
-	wordList := bip39.WordList
+	return suggestWordIn(DefaultLanguage, input)
+}
+
+// suggestWordIn is SuggestWord for the given wordlist.
+func suggestWordIn(lang Language, input string) string {
+	input = strings.ToLower(nfkd(input))
+	wordList := lang.Words()
 
 	minDist := math.MaxInt
 	var suggestion string
@@ -184,7 +177,8 @@ func SuggestWord(input string) string {
 }
 
 // DetectTypos scans a mnemonic phrase and returns information about detected typos.
-// It identifies words that are not in the BIP39 word list and suggests corrections.
+// It identifies words that are not in the BIP39 word list the phrase is
+// closest to and suggests corrections from that list.
 func DetectTypos(mnemonic string) []TypoInfo {
 	if mnemonic == "" {
 		return nil
@@ -192,11 +186,13 @@ func DetectTypos(mnemonic string) []TypoInfo {
 
 	normalized := NormalizeMnemonicInput(mnemonic)
 	words := strings.Fields(normalized)
+	lang := closestLanguage(words)
+	list := loadWordlist(lang)
 	var typos []TypoInfo
 
 	for i, word := range words {
-		if !IsValidWord(word) {
-			suggestion := SuggestWord(word)
+		if _, ok := list.index[word]; !ok {
+			suggestion := suggestWordIn(lang, word)
 			distance := 0
 			if suggestion != "" {
 				distance = levenshtein.ComputeDistance(word, suggestion)
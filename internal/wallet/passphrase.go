@@ -0,0 +1,63 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// ErrPassphraseMismatch indicates a BIP39 passphrase derived a seed whose
+// addresses are not the wallet's.
+// Uses SigilError for proper exit code (ExitAuth = 3).
+var ErrPassphraseMismatch = sigilerr.ErrPassphraseMismatch
+
+// SeedFromMnemonic derives the seed of a PassphrasePrompt wallet from its
+// stored mnemonic and the BIP39 passphrase, then checks it with VerifySeed.
+// Any passphrase derives a valid seed, so the check is what tells a typo
+// apart from the wallet. The caller must zero the returned seed when done.
+func (w *Wallet) SeedFromMnemonic(mnemonic []byte, passphrase string) ([]byte, error) {
+	if !w.PassphrasePrompt {
+		return nil, fmt.Errorf("%w: %s does not prompt for a BIP39 passphrase", sigilerr.ErrInvalidInput, w.Name)
+	}
+
+	seed, err := MnemonicToSeed(string(mnemonic), passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("deriving seed: %w", err)
+	}
+	if err := w.VerifySeed(seed); err != nil {
+		ZeroBytes(seed)
+		return nil, err
+	}
+	return seed, nil
+}
+
+// VerifySeed re-derives the first receive address of every chain with stored
+// addresses and returns ErrPassphraseMismatch unless each matches. It is a
+// quick check that the seed belongs to this wallet; AuditAddresses checks
+// every stored address.
+func (w *Wallet) VerifySeed(seed []byte) error {
+	checked := 0
+	for _, chain := range auditChains(w) {
+		addrs := w.Addresses[chain]
+		if len(addrs) == 0 {
+			continue
+		}
+
+		expected, err := DeriveAddressForNetwork(seed, chain, w.DerivationConfig.DefaultAccount, addrs[0].Index, w.Net())
+		if err != nil {
+			return fmt.Errorf("deriving %s address: %w", chain, err)
+		}
+		if !strings.EqualFold(expected.Address, addrs[0].Address) {
+			return sigilerr.WithSuggestion(ErrPassphraseMismatch,
+				fmt.Sprintf("the passphrase does not derive this wallet's %s address %s; check it and try again",
+					chain, addrs[0].Address))
+		}
+		checked++
+	}
+
+	if checked == 0 {
+		return fmt.Errorf("%w: %s has no stored addresses to verify against", sigilerr.ErrInvalidInput, w.Name)
+	}
+	return nil
+}
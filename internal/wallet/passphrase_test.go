@@ -0,0 +1,67 @@
+package wallet
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+const passphraseTestMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// newHiddenTestWallet returns a --passphrase-prompt wallet derived with passphrase "TREZOR".
+func newHiddenTestWallet(t *testing.T) (*Wallet, []byte) {
+	t.Helper()
+
+	seed, err := MnemonicToSeed(passphraseTestMnemonic, "TREZOR")
+	require.NoError(t, err)
+
+	w, err := NewWallet("hidden", []ChainID{ChainETH, ChainBSV})
+	require.NoError(t, err)
+	require.NoError(t, w.DeriveAddresses(seed, 2))
+	w.PassphrasePrompt = true
+	return w, seed
+}
+
+func TestWallet_SeedFromMnemonic(t *testing.T) {
+	t.Parallel()
+
+	w, want := newHiddenTestWallet(t)
+
+	seed, err := w.SeedFromMnemonic([]byte(passphraseTestMnemonic), "TREZOR")
+	require.NoError(t, err)
+	assert.Equal(t, want, seed)
+
+	_, err = w.SeedFromMnemonic([]byte(passphraseTestMnemonic), "trezor")
+	require.ErrorIs(t, err, ErrPassphraseMismatch)
+	assert.Equal(t, sigilerr.ExitAuth, sigilerr.ExitCode(err))
+
+	_, err = w.SeedFromMnemonic([]byte(passphraseTestMnemonic), "")
+	require.ErrorIs(t, err, ErrPassphraseMismatch, "the empty passphrase is a different wallet")
+
+	w.PassphrasePrompt = false
+	_, err = w.SeedFromMnemonic([]byte(passphraseTestMnemonic), "TREZOR")
+	require.ErrorIs(t, err, sigilerr.ErrInvalidInput)
+}
+
+func TestWallet_VerifySeed(t *testing.T) {
+	t.Parallel()
+
+	w, seed := newHiddenTestWallet(t)
+	require.NoError(t, w.VerifySeed(seed))
+
+	// ETH addresses are compared without regard to checksum case.
+	w.Addresses[ChainETH][0].Address = strings.ToLower(w.Addresses[ChainETH][0].Address)
+	require.NoError(t, w.VerifySeed(seed))
+
+	other, err := MnemonicToSeed(passphraseTestMnemonic, "")
+	require.NoError(t, err)
+	require.ErrorIs(t, w.VerifySeed(other), ErrPassphraseMismatch)
+
+	empty, err := NewWallet("empty", []ChainID{ChainBSV})
+	require.NoError(t, err)
+	require.ErrorIs(t, empty.VerifySeed(seed), sigilerr.ErrInvalidInput)
+}
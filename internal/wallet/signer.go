@@ -0,0 +1,253 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+// SignerKind selects what signs a wallet's transactions.
+type SignerKind string
+
+const (
+	// SignerSeed signs with keys derived from the encrypted seed file.
+	SignerSeed SignerKind = "seed"
+
+	// SignerHardware signs on a connected hardware wallet, such as a Ledger.
+	// The seed never leaves the device; the wallet file only needs the
+	// addresses, so a watch-only wallet imported from the device's xpub works.
+	SignerHardware SignerKind = "hardware"
+)
+
+var (
+	// ErrInvalidSigner indicates an unknown signer kind.
+	ErrInvalidSigner = errors.New("invalid signer")
+
+	// ErrNoHardwareTransport indicates no hardware wallet transport is
+	// registered, so no device can be reached.
+	ErrNoHardwareTransport = errors.New("no hardware wallet transport available")
+
+	// ErrNoHardwareDevice indicates no connected hardware wallet was found.
+	ErrNoHardwareDevice = errors.New("no hardware wallet connected")
+
+	// ErrHardwareDeviceAmbiguous indicates several hardware wallets are
+	// connected and none was chosen.
+	ErrHardwareDeviceAmbiguous = errors.New("more than one hardware wallet connected")
+
+	// ErrNoSigningPath indicates a wallet has no derivation path for an
+	// address a transaction spends from.
+	ErrNoSigningPath = errors.New("no derivation path for address")
+)
+
+// ParseSignerKind parses a --signer value. Empty means SignerSeed.
+func ParseSignerKind(s string) (SignerKind, error) {
+	switch kind := SignerKind(strings.ToLower(strings.TrimSpace(s))); kind {
+	case "", SignerSeed:
+		return SignerSeed, nil
+	case SignerHardware:
+		return SignerHardware, nil
+	default:
+		return "", fmt.Errorf("%w: %q (use seed or hardware)", ErrInvalidSigner, s)
+	}
+}
+
+// HardwareDevice identifies a connected hardware wallet.
+type HardwareDevice struct {
+	// Transport is the name of the transport that found the device.
+	Transport string `json:"transport"`
+
+	// ID identifies the device within its transport, such as a USB path or
+	// serial number. It is what --device selects.
+	ID string `json:"id"`
+
+	// Vendor is the device maker ("ledger", "trezor").
+	Vendor string `json:"vendor"`
+
+	// Model is the device model ("Nano S Plus", "Model T").
+	Model string `json:"model"`
+}
+
+// String names the device for display.
+func (d HardwareDevice) String() string {
+	name := strings.TrimSpace(d.Vendor + " " + d.Model)
+	if name == "" {
+		name = "hardware wallet"
+	}
+	return fmt.Sprintf("%s (%s)", name, d.ID)
+}
+
+// HardwareSignRequest is a transaction for a hardware wallet to sign.
+type HardwareSignRequest struct {
+	// Tx is the unsigned transaction, as built by tx build.
+	Tx *chain.UnsignedTx
+
+	// Paths maps each address the transaction spends from to the BIP44
+	// derivation path of its key.
+	Paths map[string]string
+}
+
+// HardwareSigner is an open connection to a hardware wallet.
+type HardwareSigner interface {
+	// Device returns the device the signer is connected to.
+	Device() HardwareDevice
+
+	// Address returns the address of the key at path. With display set the
+	// device shows the path and address and waits for the user to confirm
+	// them, failing if they reject.
+	Address(ctx context.Context, chainID ChainID, path string, display bool) (string, error)
+
+	// Sign has the device sign the transaction after the user reviews it on
+	// the device screen.
+	Sign(ctx context.Context, req *HardwareSignRequest) (*chain.SignedRawTx, error)
+
+	// Close releases the device.
+	Close() error
+}
+
+// HardwareTransport finds and opens hardware wallets of one kind, such as
+// Ledger devices over USB HID.
+type HardwareTransport interface {
+	// Name identifies the transport ("ledger-hid").
+	Name() string
+
+	// Enumerate lists the connected devices.
+	Enumerate(ctx context.Context) ([]HardwareDevice, error)
+
+	// Open connects to a device Enumerate returned.
+	Open(ctx context.Context, device HardwareDevice) (HardwareSigner, error)
+}
+
+// hardwareTransports holds the transports registered by device packages.
+//
+//nolint:gochecknoglobals // Package-level registry filled by device packages
+var hardwareTransports = struct {
+	mu sync.RWMutex
+	m  map[string]HardwareTransport
+}{m: make(map[string]HardwareTransport)}
+
+// RegisterHardwareTransport makes a transport available to DiscoverHardware
+// and OpenHardware. Device packages register theirs when they are imported.
+func RegisterHardwareTransport(t HardwareTransport) {
+	hardwareTransports.mu.Lock()
+	defer hardwareTransports.mu.Unlock()
+	hardwareTransports.m[t.Name()] = t
+}
+
+// HardwareTransports returns the names of the registered transports, sorted.
+func HardwareTransports() []string {
+	hardwareTransports.mu.RLock()
+	defer hardwareTransports.mu.RUnlock()
+
+	names := make([]string, 0, len(hardwareTransports.m))
+	for name := range hardwareTransports.m {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DiscoverHardware lists the hardware wallets every registered transport can
+// reach. A transport that fails to enumerate is skipped unless no devices
+// are found, in which case its error is returned.
+func DiscoverHardware(ctx context.Context) ([]HardwareDevice, error) {
+	names := HardwareTransports()
+	if len(names) == 0 {
+		return nil, ErrNoHardwareTransport
+	}
+
+	var devices []HardwareDevice
+	var errs []error
+	for _, name := range names {
+		hardwareTransports.mu.RLock()
+		t := hardwareTransports.m[name]
+		hardwareTransports.mu.RUnlock()
+
+		found, err := t.Enumerate(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			continue
+		}
+		devices = append(devices, found...)
+	}
+	if len(devices) == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return devices, nil
+}
+
+// SelectHardwareDevice picks the device whose ID is id, or the only device
+// when id is empty.
+func SelectHardwareDevice(devices []HardwareDevice, id string) (HardwareDevice, error) {
+	if len(devices) == 0 {
+		return HardwareDevice{}, ErrNoHardwareDevice
+	}
+	if id == "" {
+		if len(devices) > 1 {
+			return HardwareDevice{}, fmt.Errorf("%w: %d found", ErrHardwareDeviceAmbiguous, len(devices))
+		}
+		return devices[0], nil
+	}
+	for _, d := range devices {
+		if d.ID == id {
+			return d, nil
+		}
+	}
+	return HardwareDevice{}, fmt.Errorf("%w: no device with ID %q", ErrNoHardwareDevice, id)
+}
+
+// OpenHardware discovers the connected hardware wallets and opens the one
+// SelectHardwareDevice picks for id.
+func OpenHardware(ctx context.Context, id string) (HardwareSigner, error) {
+	devices, err := DiscoverHardware(ctx)
+	if err != nil {
+		return nil, err
+	}
+	device, err := SelectHardwareDevice(devices, id)
+	if err != nil {
+		return nil, err
+	}
+
+	hardwareTransports.mu.RLock()
+	t, ok := hardwareTransports.m[device.Transport]
+	hardwareTransports.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNoHardwareTransport, device.Transport)
+	}
+	return t.Open(ctx, device)
+}
+
+// SigningPaths returns the derivation path of every address tx spends from:
+// the sender for ETH, each input address for BSV.
+func (w *Wallet) SigningPaths(tx *chain.UnsignedTx) (map[string]string, error) {
+	known := make(map[string]string)
+	for _, list := range [][]Address{w.Addresses[tx.Chain], w.ChangeAddresses[tx.Chain]} {
+		for _, a := range list {
+			known[a.Address] = a.Path
+		}
+	}
+
+	var spends []string
+	switch {
+	case tx.BSV != nil:
+		for _, in := range tx.BSV.Inputs {
+			spends = append(spends, in.Address)
+		}
+	default:
+		spends = []string{tx.From}
+	}
+
+	paths := make(map[string]string, len(spends))
+	for _, addr := range spends {
+		path := known[addr]
+		if path == "" {
+			return nil, fmt.Errorf("%w %s in wallet %s", ErrNoSigningPath, addr, w.Name)
+		}
+		paths[addr] = path
+	}
+	return paths, nil
+}
@@ -0,0 +1,153 @@
+package wallet
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+)
+
+func TestParseSignerKind(t *testing.T) {
+	t.Parallel()
+
+	for in, want := range map[string]SignerKind{"": SignerSeed, "seed": SignerSeed, " Hardware ": SignerHardware} {
+		got, err := ParseSignerKind(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	_, err := ParseSignerKind("ledger")
+	require.ErrorIs(t, err, ErrInvalidSigner)
+}
+
+func TestSelectHardwareDevice(t *testing.T) {
+	t.Parallel()
+
+	a := HardwareDevice{Transport: "test", ID: "a", Vendor: "ledger", Model: "Nano X"}
+	b := HardwareDevice{Transport: "test", ID: "b", Vendor: "trezor", Model: "Model T"}
+
+	_, err := SelectHardwareDevice(nil, "")
+	require.ErrorIs(t, err, ErrNoHardwareDevice)
+
+	got, err := SelectHardwareDevice([]HardwareDevice{a}, "")
+	require.NoError(t, err)
+	assert.Equal(t, a, got)
+
+	_, err = SelectHardwareDevice([]HardwareDevice{a, b}, "")
+	require.ErrorIs(t, err, ErrHardwareDeviceAmbiguous)
+
+	got, err = SelectHardwareDevice([]HardwareDevice{a, b}, "b")
+	require.NoError(t, err)
+	assert.Equal(t, b, got)
+
+	_, err = SelectHardwareDevice([]HardwareDevice{a, b}, "c")
+	require.ErrorIs(t, err, ErrNoHardwareDevice)
+
+	assert.Equal(t, "ledger Nano X (a)", a.String())
+	assert.Equal(t, "hardware wallet (x)", HardwareDevice{ID: "x"}.String())
+}
+
+// fakeTransport is a HardwareTransport with a fixed device list.
+type fakeTransport struct {
+	name    string
+	devices []HardwareDevice
+	err     error
+}
+
+func (f *fakeTransport) Name() string { return f.name }
+
+func (f *fakeTransport) Enumerate(context.Context) ([]HardwareDevice, error) {
+	return f.devices, f.err
+}
+
+func (f *fakeTransport) Open(_ context.Context, d HardwareDevice) (HardwareSigner, error) {
+	return &fakeSigner{device: d}, nil
+}
+
+// fakeSigner is a HardwareSigner that signs nothing.
+type fakeSigner struct{ device HardwareDevice }
+
+func (f *fakeSigner) Device() HardwareDevice { return f.device }
+
+func (f *fakeSigner) Address(context.Context, ChainID, string, bool) (string, error) {
+	return "", nil
+}
+
+func (f *fakeSigner) Sign(context.Context, *HardwareSignRequest) (*chain.SignedRawTx, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f *fakeSigner) Close() error { return nil }
+
+//nolint:paralleltest // Mutates the package transport registry
+func TestHardwareRegistry(t *testing.T) {
+	hardwareTransports.mu.Lock()
+	saved := hardwareTransports.m
+	hardwareTransports.m = make(map[string]HardwareTransport)
+	hardwareTransports.mu.Unlock()
+	t.Cleanup(func() {
+		hardwareTransports.mu.Lock()
+		hardwareTransports.m = saved
+		hardwareTransports.mu.Unlock()
+	})
+
+	_, err := DiscoverHardware(context.Background())
+	require.ErrorIs(t, err, ErrNoHardwareTransport)
+	_, err = OpenHardware(context.Background(), "")
+	require.ErrorIs(t, err, ErrNoHardwareTransport)
+
+	broken := &fakeTransport{name: "broken", err: errors.New("usb busy")}
+	RegisterHardwareTransport(broken)
+	_, err = DiscoverHardware(context.Background())
+	require.ErrorContains(t, err, "broken: usb busy")
+
+	device := HardwareDevice{Transport: "test", ID: "1", Vendor: "ledger"}
+	RegisterHardwareTransport(&fakeTransport{name: "test", devices: []HardwareDevice{device}})
+	assert.Equal(t, []string{"broken", "test"}, HardwareTransports())
+
+	devices, err := DiscoverHardware(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []HardwareDevice{device}, devices)
+
+	signer, err := OpenHardware(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, device, signer.Device())
+	require.NoError(t, signer.Close())
+}
+
+func TestWallet_SigningPaths(t *testing.T) {
+	t.Parallel()
+
+	w := &Wallet{
+		Name: "hw",
+		Addresses: map[ChainID][]Address{
+			ChainETH: {{Address: "0xaaa", Path: "m/44'/60'/0'/0/0"}},
+			ChainBSV: {{Address: "1aaa", Path: "m/44'/236'/0'/0/0"}},
+		},
+		ChangeAddresses: map[ChainID][]Address{
+			ChainBSV: {{Address: "1ccc", Path: "m/44'/236'/0'/1/0", IsChange: true}},
+		},
+	}
+
+	paths, err := w.SigningPaths(&chain.UnsignedTx{Chain: chain.ETH, From: "0xaaa"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"0xaaa": "m/44'/60'/0'/0/0"}, paths)
+
+	paths, err = w.SigningPaths(&chain.UnsignedTx{Chain: chain.BSV, BSV: &chain.UnsignedBSV{
+		Inputs: []chain.OfflineInput{{Address: "1aaa"}, {Address: "1ccc"}, {Address: "1aaa"}},
+	}})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"1aaa": "m/44'/236'/0'/0/0", "1ccc": "m/44'/236'/0'/1/0"}, paths)
+
+	_, err = w.SigningPaths(&chain.UnsignedTx{Chain: chain.ETH, From: "0xbbb"})
+	require.ErrorIs(t, err, ErrNoSigningPath)
+
+	// An address imported without a path cannot be signed for.
+	w.Addresses[ChainETH] = append(w.Addresses[ChainETH], Address{Address: "0xddd"})
+	_, err = w.SigningPaths(&chain.UnsignedTx{Chain: chain.ETH, From: "0xddd"})
+	require.ErrorIs(t, err, ErrNoSigningPath)
+}
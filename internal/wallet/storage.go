@@ -10,6 +10,7 @@ import (
 
 	"github.com/mrz1836/sigil/internal/fileutil"
 	"github.com/mrz1836/sigil/internal/sigilcrypto"
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
 )
 
 const (
@@ -95,9 +96,9 @@ func (s *FileStorage) Save(wallet *Wallet, seed, password []byte) error {
 		return fmt.Errorf("encrypting seed: %w", err)
 	}
 
-	// Create wallet file structure
+	// Create wallet file structure; an account view saves its whole wallet
 	wf := walletFile{
-		Wallet:        wallet,
+		Wallet:        wallet.root(),
 		EncryptedSeed: encryptedSeed,
 	}
 
@@ -116,10 +117,50 @@ func (s *FileStorage) Save(wallet *Wallet, seed, password []byte) error {
 	return nil
 }
 
+// SaveWatchOnly writes a watch-only wallet to storage. The file holds only
+// the wallet metadata, so no password is needed.
+func (s *FileStorage) SaveWatchOnly(wallet *Wallet) error {
+	if wallet == nil {
+		return ErrNilWallet
+	}
+	if !wallet.IsWatchOnly() {
+		return fmt.Errorf("%w: %s is not a watch-only wallet", sigilerr.ErrInvalidInput, wallet.Name)
+	}
+
+	if err := ValidateWalletName(wallet.Name); err != nil {
+		return err
+	}
+
+	exists, err := s.Exists(wallet.Name)
+	if err != nil {
+		return fmt.Errorf("checking wallet existence: %w", err)
+	}
+	if exists {
+		return ErrWalletExists
+	}
+
+	if err = os.MkdirAll(s.basePath, walletDirPermissions); err != nil {
+		return fmt.Errorf("creating wallet directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(walletFile{Wallet: wallet}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling wallet: %w", err)
+	}
+
+	if err := fileutil.WriteAtomic(s.walletPath(wallet.Name), data, walletFilePermissions); err != nil {
+		return fmt.Errorf("writing wallet file: %w", err)
+	}
+
+	return nil
+}
+
 // ErrNilWallet indicates a nil wallet was provided.
 var ErrNilWallet = errors.New("wallet is nil")
 
 // UpdateMetadata updates wallet metadata while preserving encrypted seed.
+// Given an account view (see Wallet.Account), it saves the whole wallet.
+// A wallet file written by a newer sigil is refused with ErrVersionTooNew.
 func (s *FileStorage) UpdateMetadata(wallet *Wallet) error {
 	if wallet == nil {
 		return ErrNilWallet
@@ -145,7 +186,17 @@ func (s *FileStorage) UpdateMetadata(wallet *Wallet) error {
 		return fmt.Errorf("parsing wallet file: %w", err)
 	}
 
-	wf.Wallet = wallet
+	// Rewriting a newer file would drop the fields this build does not know
+	if wf.Wallet != nil {
+		if err = wf.Wallet.CheckVersion(); err != nil {
+			return err
+		}
+	}
+	if err = wallet.CheckVersion(); err != nil {
+		return err
+	}
+
+	wf.Wallet = wallet.root()
 
 	updatedData, err := json.MarshalIndent(wf, "", "  ")
 	if err != nil {
@@ -161,6 +212,8 @@ func (s *FileStorage) UpdateMetadata(wallet *Wallet) error {
 
 // Load reads and decrypts a wallet from storage.
 // The password should be zeroed by the caller after this call returns.
+// A wallet file written by a newer sigil is refused with ErrVersionTooNew;
+// LoadMetadata still reads it.
 func (s *FileStorage) Load(name string, password []byte) (*Wallet, []byte, error) {
 	// Validate wallet name
 	if err := ValidateWalletName(name); err != nil {
@@ -191,6 +244,18 @@ func (s *FileStorage) Load(name string, password []byte) (*Wallet, []byte, error
 		return nil, nil, fmt.Errorf("parsing wallet file: %w", err)
 	}
 
+	// A watch-only wallet has no seed to decrypt
+	if wf.Wallet != nil && wf.Wallet.IsWatchOnly() {
+		return nil, nil, ErrWatchOnly
+	}
+
+	// A newer file may derive or sign differently; refuse to unlock it
+	if wf.Wallet != nil {
+		if err = wf.Wallet.CheckVersion(); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Decrypt the seed
 	seed, err := sigilcrypto.Decrypt(wf.EncryptedSeed, string(password))
 	if err != nil {
@@ -0,0 +1,35 @@
+package wallet
+
+import (
+	"fmt"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// CurrentVersion is the wallet file format version this build writes. A file
+// with a higher version was written by a newer sigil and may hold fields
+// this build does not know, which rewriting it would drop.
+const CurrentVersion = 1
+
+// ErrVersionTooNew indicates a wallet file was written by a newer sigil.
+// Uses SigilError for proper exit code (ExitPermission = 5).
+var ErrVersionTooNew = sigilerr.ErrWalletVersionTooNew
+
+// IsNewerVersion reports whether the wallet file was written by a newer
+// sigil. Such a wallet can be read but not signed with or changed.
+func (w *Wallet) IsNewerVersion() bool {
+	return w.root().Version > CurrentVersion
+}
+
+// CheckVersion returns ErrVersionTooNew, with an upgrade suggestion, when the
+// wallet file was written by a newer sigil.
+func (w *Wallet) CheckVersion() error {
+	if !w.IsNewerVersion() {
+		return nil
+	}
+	root := w.root()
+	return sigilerr.WithSuggestion(ErrVersionTooNew,
+		fmt.Sprintf("wallet '%s' has file version %d but this sigil supports up to version %d; "+
+			"upgrade sigil with 'sigil upgrade' to sign with or change it (balances and addresses can still be viewed)",
+			root.Name, root.Version, CurrentVersion))
+}
@@ -0,0 +1,89 @@
+package wallet
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeNewerWallet saves a wallet, then rewrites its file as a newer sigil
+// would: a higher version and a field this build does not know.
+func writeNewerWallet(t *testing.T, storage *FileStorage, dir string, password []byte) string {
+	t.Helper()
+
+	w, err := NewWallet("future", []ChainID{ChainBSV})
+	require.NoError(t, err)
+	mnemonic, err := GenerateMnemonic(12)
+	require.NoError(t, err)
+	seed, err := MnemonicToSeed(mnemonic, "")
+	require.NoError(t, err)
+	defer ZeroBytes(seed)
+	require.NoError(t, w.DeriveAddresses(seed, 1))
+	require.NoError(t, storage.Save(w, seed, password))
+
+	path := filepath.Join(dir, "future.wallet")
+	data, err := os.ReadFile(path) //nolint:gosec // G304: Test path from controlled test input
+	require.NoError(t, err)
+	var raw struct {
+		Wallet        map[string]any `json:"wallet"`
+		EncryptedSeed []byte         `json:"encrypted_seed"`
+	}
+	require.NoError(t, json.Unmarshal(data, &raw))
+	raw.Wallet["version"] = CurrentVersion + 1
+	raw.Wallet["taproot_paths"] = map[string]string{"btc": "m/86'/0'/0'"}
+	data, err = json.Marshal(raw)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestStorage_NewerVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	storage := NewFileStorage(dir)
+	password := []byte("test-password-123")
+	path := writeNewerWallet(t, storage, dir, password)
+	before, err := os.ReadFile(path) //nolint:gosec // G304: Test path from controlled test input
+	require.NoError(t, err)
+
+	// Metadata is still readable
+	meta, err := storage.LoadMetadata("future")
+	require.NoError(t, err)
+	assert.True(t, meta.IsNewerVersion())
+	assert.NotEmpty(t, meta.Addresses[ChainBSV])
+
+	// Unlocking and saving are refused
+	_, _, err = storage.Load("future", password)
+	require.ErrorIs(t, err, ErrVersionTooNew)
+
+	meta.EnabledChains = append(meta.EnabledChains, ChainETH)
+	require.ErrorIs(t, storage.UpdateMetadata(meta), ErrVersionTooNew)
+
+	current, err := NewWallet("future", []ChainID{ChainBSV})
+	require.NoError(t, err)
+	require.ErrorIs(t, storage.UpdateMetadata(current), ErrVersionTooNew, "the file on disk decides")
+
+	after, err := os.ReadFile(path) //nolint:gosec // G304: Test path from controlled test input
+	require.NoError(t, err)
+	assert.Equal(t, before, after, "the newer file is left untouched")
+}
+
+func TestWallet_CheckVersion(t *testing.T) {
+	t.Parallel()
+
+	w, err := NewWallet("main", []ChainID{ChainBSV})
+	require.NoError(t, err)
+	assert.Equal(t, CurrentVersion, w.Version)
+	assert.False(t, w.IsNewerVersion())
+	require.NoError(t, w.CheckVersion())
+
+	w.Version = CurrentVersion + 1
+	assert.True(t, w.IsNewerVersion())
+	err = w.CheckVersion()
+	require.ErrorIs(t, err, ErrVersionTooNew)
+}
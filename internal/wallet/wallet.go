@@ -48,20 +48,61 @@ type Wallet struct {
 	// network its balances/transactions are queried and broadcast on.
 	Network string `json:"network,omitempty"`
 
+	// Type is TypeWatchOnly for a wallet imported without a seed. Empty means
+	// a seed wallet.
+	Type string `json:"type,omitempty"`
+
+	// Xpubs holds the account xpub a watch-only wallet was imported from, per
+	// chain. Seed wallets leave it empty.
+	Xpubs map[ChainID]string `json:"xpubs,omitempty"`
+
+	// PassphrasePrompt marks a wallet whose BIP39 passphrase is asked for on
+	// every unlock instead of being baked into the stored seed. The wallet
+	// file then holds the encrypted mnemonic; see SeedFromMnemonic.
+	PassphrasePrompt bool `json:"passphrase_prompt,omitempty"`
+
 	// Addresses contains derived receiving addresses per chain (external chain).
 	Addresses map[ChainID][]Address `json:"addresses"`
 
 	// ChangeAddresses contains derived change addresses per chain (internal chain).
 	ChangeAddresses map[ChainID][]Address `json:"change_addresses,omitempty"`
 
+	// Accounts holds the addresses of BIP44 accounts other than the default
+	// one, keyed by account index. Use Account to work with one of them.
+	Accounts map[uint32]*AccountAddresses `json:"accounts,omitempty"`
+
 	// EnabledChains lists which chains are active for this wallet.
 	EnabledChains []ChainID `json:"enabled_chains"`
 
 	// DerivationConfig holds chain-specific derivation settings.
 	DerivationConfig DerivationConfig `json:"derivation_config"`
 
+	// Settings are per-wallet defaults, set from the template the wallet was
+	// created with. Nil means the global configuration applies.
+	Settings *Settings `json:"settings,omitempty"`
+
 	// Version is the wallet file format version.
 	Version int `json:"version"`
+
+	// parent is the wallet an account view was taken from; nil for the
+	// wallet itself. See Account.
+	parent *Wallet
+}
+
+// Settings overrides configuration defaults for a single wallet. Zero values
+// keep the global configuration.
+type Settings struct {
+	// Template is the name of the template the wallet was created from.
+	Template string `json:"template,omitempty"`
+
+	// BSVFeeStrategy overrides fees.bsv_fee_strategy ("economy", "normal", "priority").
+	BSVFeeStrategy string `json:"bsv_fee_strategy,omitempty"`
+
+	// ETHGasSpeed is the default tx send --gas ("slow", "medium", "fast").
+	ETHGasSpeed string `json:"eth_gas_speed,omitempty"`
+
+	// RequireConfirmAbove overrides security.require_confirm_above, in USD.
+	RequireConfirmAbove float64 `json:"require_confirm_above,omitempty"`
 }
 
 // DerivationConfig holds derivation settings for a wallet.
@@ -135,7 +176,7 @@ func NewWallet(name string, enabledChains []ChainID) (*Wallet, error) {
 			AddressGap:     20,
 			Paths:          make(map[ChainID]string),
 		},
-		Version: 1,
+		Version: CurrentVersion,
 	}, nil
 }
 
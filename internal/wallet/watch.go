@@ -0,0 +1,111 @@
+package wallet
+
+import (
+	"fmt"
+	"strings"
+
+	sigilerr "github.com/mrz1836/sigil/pkg/errors"
+)
+
+// TypeWatchOnly marks a wallet that has addresses but no seed. Its balances
+// and history can be read and unsigned transactions built for it, but it
+// cannot sign. Seed wallets leave Type empty.
+const TypeWatchOnly = "watch-only"
+
+// ErrWatchOnly indicates an operation needed the seed of a watch-only wallet.
+// Uses SigilError for proper exit code (ExitPermission = 5).
+var ErrWatchOnly = sigilerr.ErrWalletWatchOnly
+
+// IsWatchOnly reports whether the wallet was imported without a seed.
+func (w *Wallet) IsWatchOnly() bool {
+	return w.Type == TypeWatchOnly
+}
+
+// NewWatchOnlyFromXpub creates a watch-only wallet for one chain from a BIP44
+// account xpub, deriving count receiving and count change addresses. A
+// "tpub..." key creates a testnet wallet.
+func NewWatchOnlyFromXpub(name string, chain ChainID, xpub string, count int) (*Wallet, error) {
+	if count < 1 || count > MaxAddressDerivation {
+		return nil, fmt.Errorf("%w: %d must be between 1 and %d",
+			ErrInvalidAddressCount, count, MaxAddressDerivation)
+	}
+
+	w, err := newWatchOnly(name, chain)
+	if err != nil {
+		return nil, err
+	}
+	xpub = strings.TrimSpace(xpub)
+	if strings.HasPrefix(xpub, "tpub") || strings.HasPrefix(xpub, "tprv") {
+		w.Network = NetworkTestnet
+	}
+
+	receive := make([]Address, 0, count)
+	change := make([]Address, 0, count)
+	for i := range count {
+		//nolint:gosec // G115: Safe - count validated against MaxAddressDerivation
+		idx := uint32(i)
+
+		addr, err := DeriveAddressFromXpub(xpub, chain, ExternalChain, idx)
+		if err != nil {
+			return nil, err
+		}
+		receive = append(receive, *addr)
+
+		addr, err = DeriveAddressFromXpub(xpub, chain, InternalChain, idx)
+		if err != nil {
+			return nil, err
+		}
+		change = append(change, *addr)
+	}
+
+	w.Xpubs[chain] = xpub
+	w.Addresses[chain] = receive
+	w.ChangeAddresses[chain] = change
+	return w, nil
+}
+
+// NewWatchOnlyFromAddresses creates a watch-only wallet for one chain from a
+// list of addresses. The addresses are not validated; callers check them
+// against the chain first. Blank entries and duplicates are dropped.
+func NewWatchOnlyFromAddresses(name string, chain ChainID, addresses []string) (*Wallet, error) {
+	w, err := newWatchOnly(name, chain)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(addresses))
+	list := make([]Address, 0, len(addresses))
+	for _, a := range addresses {
+		a = strings.TrimSpace(a)
+		if a == "" || seen[a] {
+			continue
+		}
+		seen[a] = true
+		//nolint:gosec // G115: Safe - bounded by the checks below
+		list = append(list, Address{Index: uint32(len(list)), Address: a})
+	}
+	if len(list) == 0 {
+		return nil, fmt.Errorf("%w: no addresses given", ErrInvalidAddressCount)
+	}
+	if len(list) > MaxAddressDerivation {
+		return nil, fmt.Errorf("%w: %d exceeds maximum %d",
+			ErrInvalidAddressCount, len(list), MaxAddressDerivation)
+	}
+
+	w.Addresses[chain] = list
+	return w, nil
+}
+
+// newWatchOnly creates an empty watch-only wallet for chain.
+func newWatchOnly(name string, chain ChainID) (*Wallet, error) {
+	if !chain.IsValid() {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedChain, chain)
+	}
+	w, err := NewWallet(name, []ChainID{chain})
+	if err != nil {
+		return nil, err
+	}
+	w.Type = TypeWatchOnly
+	w.Xpubs = make(map[ChainID]string)
+	return w, nil
+}
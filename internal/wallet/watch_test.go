@@ -0,0 +1,110 @@
+package wallet
+
+import (
+	"os"
+	"testing"
+
+	"github.com/decred/dcrd/hdkeychain/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWatchOnlyFromXpub(t *testing.T) {
+	t.Parallel()
+
+	seed := testSeed()
+	xpub, err := DeriveAccountXpubForNetwork(seed, ChainBSV, 0, Mainnet)
+	require.NoError(t, err)
+
+	w, err := NewWatchOnlyFromXpub("watch", ChainBSV, xpub, 3)
+	require.NoError(t, err)
+	assert.True(t, w.IsWatchOnly())
+	assert.Equal(t, []ChainID{ChainBSV}, w.EnabledChains)
+	assert.Equal(t, xpub, w.Xpubs[ChainBSV])
+	assert.Empty(t, w.Network)
+	require.Len(t, w.Addresses[ChainBSV], 3)
+	require.Len(t, w.ChangeAddresses[ChainBSV], 3)
+
+	// The addresses match those the seed derives.
+	for i := range 3 {
+		//nolint:gosec // G115: test index is small
+		want, err := DeriveAddress(seed, ChainBSV, 0, uint32(i))
+		require.NoError(t, err)
+		assert.Equal(t, want.Address, w.Addresses[ChainBSV][i].Address)
+		assert.True(t, w.ChangeAddresses[ChainBSV][i].IsChange)
+	}
+
+	t.Run("testnet xpub", func(t *testing.T) {
+		t.Parallel()
+		tpub, err := DeriveAccountXpubForNetwork(seed, ChainBSV, 0, Testnet)
+		require.NoError(t, err)
+		w, err := NewWatchOnlyFromXpub("watch", ChainBSV, tpub, 1)
+		require.NoError(t, err)
+		assert.Equal(t, NetworkTestnet, w.Network)
+	})
+
+	t.Run("rejects private key", func(t *testing.T) {
+		t.Parallel()
+		master, err := hdkeychain.NewMaster(seed, hdNetParams{Mainnet})
+		require.NoError(t, err)
+		_, err = NewWatchOnlyFromXpub("watch", ChainBSV, master.String(), 1)
+		require.ErrorIs(t, err, ErrXpubIsPrivate)
+	})
+
+	t.Run("rejects bad count", func(t *testing.T) {
+		t.Parallel()
+		_, err := NewWatchOnlyFromXpub("watch", ChainBSV, xpub, 0)
+		require.ErrorIs(t, err, ErrInvalidAddressCount)
+	})
+}
+
+func TestNewWatchOnlyFromAddresses(t *testing.T) {
+	t.Parallel()
+
+	w, err := NewWatchOnlyFromAddresses("watch", ChainETH, []string{
+		" 0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"",
+		"0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+	})
+	require.NoError(t, err)
+	assert.True(t, w.IsWatchOnly())
+	assert.Empty(t, w.Xpubs)
+	require.Len(t, w.Addresses[ChainETH], 2)
+	assert.Equal(t, "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed", w.Addresses[ChainETH][0].Address)
+	assert.Equal(t, uint32(1), w.Addresses[ChainETH][1].Index)
+
+	_, err = NewWatchOnlyFromAddresses("watch", ChainETH, []string{" "})
+	require.ErrorIs(t, err, ErrInvalidAddressCount)
+
+	_, err = NewWatchOnlyFromAddresses("watch", ChainID("doge"), []string{"D1"})
+	require.ErrorIs(t, err, ErrUnsupportedChain)
+}
+
+func TestStorage_WatchOnly(t *testing.T) {
+	t.Parallel()
+
+	storage := NewFileStorage(t.TempDir())
+	w, err := NewWatchOnlyFromAddresses("watch", ChainETH, []string{"0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"})
+	require.NoError(t, err)
+
+	require.NoError(t, storage.SaveWatchOnly(w))
+	require.ErrorIs(t, storage.SaveWatchOnly(w), ErrWalletExists)
+
+	loaded, err := storage.LoadMetadata("watch")
+	require.NoError(t, err)
+	assert.True(t, loaded.IsWatchOnly())
+	assert.Equal(t, w.Addresses, loaded.Addresses)
+
+	_, seed, err := storage.Load("watch", []byte("any-password"))
+	require.ErrorIs(t, err, ErrWatchOnly)
+	assert.Nil(t, seed)
+
+	info, err := os.Stat(storage.walletPath("watch"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(walletFilePermissions), info.Mode().Perm())
+
+	seedWallet, err := NewWallet("seeded", []ChainID{ChainETH})
+	require.NoError(t, err)
+	require.Error(t, storage.SaveWatchOnly(seedWallet))
+}
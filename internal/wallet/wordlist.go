@@ -0,0 +1,237 @@
+package wallet
+
+import (
+	"crypto/sha256"
+	"embed"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cosmos/go-bip39"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Language identifies a BIP39 wordlist.
+type Language string
+
+// Supported BIP39 wordlists, from the BIP39 specification.
+const (
+	LanguageEnglish            Language = "english"
+	LanguageJapanese           Language = "japanese"
+	LanguageKorean             Language = "korean"
+	LanguageSpanish            Language = "spanish"
+	LanguageChineseSimplified  Language = "chinese-simplified"
+	LanguageChineseTraditional Language = "chinese-traditional"
+	LanguageFrench             Language = "french"
+	LanguageItalian            Language = "italian"
+	LanguageCzech              Language = "czech"
+
+	// DefaultLanguage is the wordlist used when none is chosen.
+	DefaultLanguage = LanguageEnglish
+)
+
+// ErrUnknownLanguage indicates an unsupported wordlist name.
+var ErrUnknownLanguage = errors.New("unknown mnemonic wordlist")
+
+// wordlistFiles holds every wordlist except English, which comes from
+// go-bip39. The files are the BIP39 lists, already in NFKD form.
+//
+//go:embed wordlists/*.txt
+var wordlistFiles embed.FS
+
+// wordlist is a loaded BIP39 wordlist with a reverse index.
+type wordlist struct {
+	words []string
+	index map[string]int
+}
+
+//nolint:gochecknoglobals // Wordlists are loaded once and shared read-only
+var (
+	wordlistsOnce sync.Once
+	wordlists     map[Language]*wordlist
+)
+
+// Languages returns the supported wordlists. English is first, so it wins
+// when a mnemonic is valid in more than one.
+func Languages() []Language {
+	return []Language{
+		LanguageEnglish, LanguageJapanese, LanguageKorean, LanguageSpanish,
+		LanguageChineseSimplified, LanguageChineseTraditional,
+		LanguageFrench, LanguageItalian, LanguageCzech,
+	}
+}
+
+// ParseLanguage parses a wordlist name. An empty name is English, and
+// underscores may stand in for hyphens.
+func ParseLanguage(name string) (Language, error) {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "_", "-")
+	if name == "" {
+		return DefaultLanguage, nil
+	}
+	for _, lang := range Languages() {
+		if string(lang) == name {
+			return lang, nil
+		}
+	}
+	names := make([]string, 0, len(Languages()))
+	for _, lang := range Languages() {
+		names = append(names, string(lang))
+	}
+	return "", fmt.Errorf("%w: %q (use %s)", ErrUnknownLanguage, name, strings.Join(names, ", "))
+}
+
+// Words returns the language's 2048 words in NFKD form.
+func (l Language) Words() []string {
+	if list := loadWordlist(l); list != nil {
+		return list.words
+	}
+	return nil
+}
+
+// separator returns the word separator for mnemonics in the language.
+// BIP39 joins Japanese words with an ideographic space.
+func (l Language) separator() string {
+	if l == LanguageJapanese {
+		return "\u3000"
+	}
+	return " "
+}
+
+// loadWordlist returns the wordlist for l, or nil for an unknown language.
+func loadWordlist(l Language) *wordlist {
+	wordlistsOnce.Do(func() {
+		wordlists = make(map[Language]*wordlist, len(Languages()))
+		for _, lang := range Languages() {
+			words := bip39.WordList
+			if lang != LanguageEnglish {
+				data, err := wordlistFiles.ReadFile("wordlists/" + string(lang) + ".txt")
+				if err != nil {
+					panic(err)
+				}
+				words = strings.Split(strings.TrimSpace(string(data)), "\n")
+			}
+			index := make(map[string]int, len(words))
+			for i, w := range words {
+				index[w] = i
+			}
+			wordlists[lang] = &wordlist{words: words, index: index}
+		}
+	})
+	return wordlists[l]
+}
+
+// entropyToMnemonic encodes entropy as a mnemonic in the language, with the
+// BIP39 checksum in the last word.
+func entropyToMnemonic(entropy []byte, lang Language) (string, error) {
+	list := loadWordlist(lang)
+	if list == nil {
+		return "", fmt.Errorf("%w: %q", ErrUnknownLanguage, lang)
+	}
+
+	// The checksum is at most 8 bits, one byte after the entropy
+	sum := sha256.Sum256(entropy)
+	data := make([]byte, len(entropy)+1)
+	defer ZeroBytes(data)
+	copy(data, entropy)
+	data[len(entropy)] = sum[0]
+
+	bitLen := len(entropy)*8 + len(entropy)/4
+	words := make([]string, bitLen/11)
+	for i := range words {
+		idx := 0
+		for b := i * 11; b < (i+1)*11; b++ {
+			idx = idx<<1 | int(data[b/8]>>(7-b%8)&1)
+		}
+		words[i] = list.words[idx]
+	}
+	return strings.Join(words, lang.separator()), nil
+}
+
+// mnemonicEntropy decodes words with the wordlist and verifies the checksum.
+// The caller must zero the returned entropy.
+func mnemonicEntropy(words []string, list *wordlist) ([]byte, error) {
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, ErrInvalidMnemonic
+	}
+
+	bitLen := len(words) * 11
+	checksumBits := bitLen / 33
+	entropyLen := (bitLen - checksumBits) / 8
+
+	data := make([]byte, entropyLen+1)
+	defer ZeroBytes(data)
+	for i, w := range words {
+		idx, ok := list.index[w]
+		if !ok {
+			return nil, ErrInvalidMnemonic
+		}
+		for j := range 11 {
+			if idx>>(10-j)&1 == 1 {
+				b := i*11 + j
+				data[b/8] |= 1 << (7 - b%8)
+			}
+		}
+	}
+
+	sum := sha256.Sum256(data[:entropyLen])
+	mask := byte(0xff << (8 - checksumBits))
+	if data[entropyLen]&mask != sum[0]&mask {
+		return nil, ErrInvalidMnemonic
+	}
+
+	entropy := make([]byte, entropyLen)
+	copy(entropy, data)
+	return entropy, nil
+}
+
+// DetectLanguage returns the wordlist of a valid mnemonic. Input is
+// normalized with NormalizeMnemonicInput first, so any BIP39 wordlist and
+// any Unicode normalization form is accepted. A mnemonic valid in more than
+// one wordlist derives the same seed in each, and the first in Languages
+// is returned.
+func DetectLanguage(mnemonic string) (Language, error) {
+	normalized := NormalizeMnemonicInput(mnemonic)
+	if normalized == "" {
+		return "", ErrInvalidMnemonic
+	}
+	// Words are split on the single spaces normalization leaves, as in the
+	// seed derivation, so other Unicode spaces cannot pass validation
+	words := strings.Split(normalized, " ")
+	for _, lang := range Languages() {
+		list := loadWordlist(lang)
+		if _, ok := list.index[words[0]]; !ok {
+			continue
+		}
+		if entropy, err := mnemonicEntropy(words, list); err == nil {
+			ZeroBytes(entropy)
+			return lang, nil
+		}
+	}
+	return "", ErrInvalidMnemonic
+}
+
+// closestLanguage returns the language containing the most of words, for
+// suggesting corrections to an invalid mnemonic. Ties go to English.
+func closestLanguage(words []string) Language {
+	best, bestCount := DefaultLanguage, 0
+	for _, lang := range Languages() {
+		list := loadWordlist(lang)
+		count := 0
+		for _, w := range words {
+			if _, ok := list.index[w]; ok {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = lang, count
+		}
+	}
+	return best
+}
+
+// nfkd returns s in Unicode normalization form KD, which BIP39 requires for
+// mnemonics and passphrases.
+func nfkd(s string) string {
+	return norm.NFKD.String(s)
+}
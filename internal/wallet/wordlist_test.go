@@ -0,0 +1,108 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/cosmos/go-bip39"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/unicode/norm"
+)
+
+func TestParseLanguage(t *testing.T) {
+	t.Parallel()
+
+	lang, err := ParseLanguage("")
+	require.NoError(t, err)
+	assert.Equal(t, LanguageEnglish, lang)
+
+	lang, err = ParseLanguage(" Chinese_Simplified ")
+	require.NoError(t, err)
+	assert.Equal(t, LanguageChineseSimplified, lang)
+
+	_, err = ParseLanguage("klingon")
+	require.ErrorIs(t, err, ErrUnknownLanguage)
+}
+
+func TestWordlists(t *testing.T) {
+	t.Parallel()
+
+	for _, lang := range Languages() {
+		words := lang.Words()
+		require.Len(t, words, 2048, lang)
+		assert.Len(t, loadWordlist(lang).index, 2048, "%s words are unique", lang)
+		for _, w := range words {
+			require.True(t, norm.NFKD.IsNormalString(w), "%s word %q is NFKD", lang, w)
+		}
+	}
+}
+
+func TestEntropyToMnemonic_English(t *testing.T) {
+	t.Parallel()
+
+	for _, tv := range bip39TestVectors {
+		entropy, err := hex.DecodeString(tv.entropy)
+		require.NoError(t, err)
+		mnemonic, err := entropyToMnemonic(entropy, LanguageEnglish)
+		require.NoError(t, err)
+		assert.Equal(t, tv.mnemonic, mnemonic)
+
+		seed, err := MnemonicToSeed(mnemonic, "TREZOR")
+		require.NoError(t, err)
+		assert.Equal(t, bip39.NewSeed(mnemonic, "TREZOR"), seed, "English seeds are unchanged")
+	}
+}
+
+// TestMnemonicToSeed_Japanese checks the first Japanese BIP39 test vector,
+// whose passphrase only derives the right seed after NFKD normalization.
+func TestMnemonicToSeed_Japanese(t *testing.T) {
+	t.Parallel()
+
+	mnemonic, err := entropyToMnemonic(make([]byte, 16), LanguageJapanese)
+	require.NoError(t, err)
+	assert.Equal(t, strings.Repeat("あいこくしん\u3000", 11)+norm.NFKD.String("あおぞら"), mnemonic)
+
+	seed, err := MnemonicToSeed(mnemonic, "㍍ガバヴァぱばぐゞちぢ十人十色")
+	require.NoError(t, err)
+	assert.Equal(t, "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55", hex.EncodeToString(seed))
+}
+
+func TestDetectLanguage(t *testing.T) {
+	t.Parallel()
+
+	entropy, err := hex.DecodeString("0c1e24e5917779d297e14d45f14e1a1a")
+	require.NoError(t, err)
+	for _, lang := range Languages() {
+		mnemonic, err := entropyToMnemonic(entropy, lang)
+		require.NoError(t, err)
+
+		detected, err := DetectLanguage(mnemonic)
+		require.NoError(t, err)
+		assert.Equal(t, lang, detected)
+
+		// Pasted words are often in composed (NFC) form
+		detected, err = DetectLanguage(norm.NFC.String(mnemonic))
+		require.NoError(t, err, lang)
+		assert.Equal(t, lang, detected)
+		require.NoError(t, ValidateMnemonic(norm.NFC.String(mnemonic)), lang)
+	}
+
+	_, err = DetectLanguage("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon")
+	require.ErrorIs(t, err, ErrInvalidMnemonic, "bad checksum")
+}
+
+func TestDetectTypos_Spanish(t *testing.T) {
+	t.Parallel()
+
+	mnemonic, err := entropyToMnemonic(make([]byte, 16), LanguageSpanish)
+	require.NoError(t, err)
+	words := strings.Fields(mnemonic)
+	words[3] = "abdomem"
+
+	typos := DetectTypos(strings.Join(words, " "))
+	require.Len(t, typos, 1)
+	assert.Equal(t, 3, typos[0].Index)
+	assert.Equal(t, "abdomen", typos[0].Suggestion)
+}
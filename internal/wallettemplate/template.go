@@ -0,0 +1,226 @@
+// Package wallettemplate resolves the templates used by wallet create
+// --template: the templates built into sigil plus those defined under
+// wallet_templates in config.yaml.
+//
+// A configured template named like a built-in one replaces it, so a user can
+// tune "savings" without inventing a new name.
+package wallettemplate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+)
+
+const (
+	// MaxAddresses bounds the receive addresses a template derives per chain.
+	MaxAddresses = 1000
+
+	// defaultWords is the mnemonic length when a template does not set one.
+	defaultWords = 12
+)
+
+var (
+	// ErrUnknownTemplate is returned when no template has the requested name.
+	ErrUnknownTemplate = errors.New("unknown wallet template")
+
+	// ErrInvalidTemplate is returned for a malformed template.
+	ErrInvalidTemplate = errors.New("invalid wallet template")
+)
+
+// Source says where a template comes from.
+type Source string
+
+// Template sources.
+const (
+	// SourceBuiltIn marks a template built into sigil.
+	SourceBuiltIn Source = "built-in"
+	// SourceConfig marks a template defined in config.yaml.
+	SourceConfig Source = "config"
+)
+
+// Template predefines the settings of a new wallet.
+type Template struct {
+	Name        string
+	Description string
+
+	// Chains are the wallet's enabled chains. Empty uses every enabled network.
+	Chains []chain.ID
+
+	// Addresses is the number of receive addresses derived per chain.
+	Addresses int
+
+	// Labels are applied in order to the first receive addresses of each chain.
+	Labels []string
+
+	Words      int
+	Passphrase bool
+
+	// BSVFeeStrategy and ETHGasSpeed are the wallet's default fee settings.
+	// Empty keeps the global configuration.
+	BSVFeeStrategy string
+	ETHGasSpeed    string
+
+	// RequireConfirmAbove is the wallet's confirmation code threshold in USD.
+	// Zero keeps the global configuration.
+	RequireConfirmAbove float64
+
+	Source Source
+}
+
+// builtIn returns the templates shipped with sigil.
+func builtIn() []Template {
+	return []Template{
+		{
+			Name:                "savings",
+			Description:         "Long-term storage: 24 words, economy fees, confirmation code at $100",
+			Chains:              []chain.ID{chain.BSV, chain.ETH},
+			Addresses:           1,
+			Labels:              []string{"savings"},
+			Words:               24,
+			BSVFeeStrategy:      "economy",
+			ETHGasSpeed:         "slow",
+			RequireConfirmAbove: 100,
+			Source:              SourceBuiltIn,
+		},
+		{
+			Name:        "spending",
+			Description: "Everyday payments: 5 addresses per chain, normal fees",
+			Chains:      []chain.ID{chain.BSV, chain.ETH},
+			Addresses:   5,
+			Words:       defaultWords,
+			Source:      SourceBuiltIn,
+		},
+		{
+			Name:           "merchant",
+			Description:    "BSV point of sale: 20 receive addresses, priority fees",
+			Chains:         []chain.ID{chain.BSV},
+			Addresses:      20,
+			Words:          defaultWords,
+			BSVFeeStrategy: "priority",
+			Source:         SourceBuiltIn,
+		},
+	}
+}
+
+// All returns every template sorted by name, with configured templates
+// replacing built-in ones of the same name.
+func All(configured map[string]config.WalletTemplateConfig) ([]Template, error) {
+	byName := make(map[string]Template)
+	for _, t := range builtIn() {
+		byName[t.Name] = t
+	}
+	for name, c := range configured {
+		t, err := FromConfig(name, c)
+		if err != nil {
+			return nil, err
+		}
+		byName[t.Name] = *t
+	}
+
+	all := make([]Template, 0, len(byName))
+	for _, t := range byName {
+		all = append(all, t)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all, nil
+}
+
+// Lookup returns the template called name. Names are case-insensitive.
+func Lookup(configured map[string]config.WalletTemplateConfig, name string) (*Template, error) {
+	key := normalizeName(name)
+	for configName, c := range configured {
+		if normalizeName(configName) == key {
+			return FromConfig(configName, c)
+		}
+	}
+	for _, t := range builtIn() {
+		if t.Name == key {
+			return &t, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownTemplate, name)
+}
+
+// FromConfig validates a configured template and fills in defaults.
+func FromConfig(name string, c config.WalletTemplateConfig) (*Template, error) {
+	t := &Template{
+		Name:                normalizeName(name),
+		Description:         c.Description,
+		Addresses:           c.Addresses,
+		Labels:              c.Labels,
+		Words:               c.Words,
+		Passphrase:          c.Passphrase,
+		BSVFeeStrategy:      strings.ToLower(strings.TrimSpace(c.BSVFeeStrategy)),
+		ETHGasSpeed:         strings.ToLower(strings.TrimSpace(c.ETHGasSpeed)),
+		RequireConfirmAbove: c.RequireConfirmAbove,
+		Source:              SourceConfig,
+	}
+	if t.Name == "" {
+		return nil, fmt.Errorf("%w: empty name", ErrInvalidTemplate)
+	}
+	if t.Addresses == 0 {
+		t.Addresses = 1
+	}
+	if t.Words == 0 {
+		t.Words = defaultWords
+	}
+
+	for _, s := range c.Chains {
+		id, ok := chain.ParseChainID(s)
+		if !ok {
+			return nil, fmt.Errorf("%w %s: unknown chain %q", ErrInvalidTemplate, t.Name, s)
+		}
+		if !containsChain(t.Chains, id) {
+			t.Chains = append(t.Chains, id)
+		}
+	}
+
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// validate checks the template's settings.
+func (t *Template) validate() error {
+	switch {
+	case t.Addresses < 1 || t.Addresses > MaxAddresses:
+		return fmt.Errorf("%w %s: addresses must be between 1 and %d", ErrInvalidTemplate, t.Name, MaxAddresses)
+	case len(t.Labels) > t.Addresses:
+		return fmt.Errorf("%w %s: %d labels for %d addresses", ErrInvalidTemplate, t.Name, len(t.Labels), t.Addresses)
+	case t.Words != 12 && t.Words != 24:
+		return fmt.Errorf("%w %s: words must be 12 or 24", ErrInvalidTemplate, t.Name)
+	case t.RequireConfirmAbove < 0:
+		return fmt.Errorf("%w %s: require_confirm_above cannot be negative", ErrInvalidTemplate, t.Name)
+	}
+
+	switch t.BSVFeeStrategy {
+	case "", "economy", "normal", "priority":
+	default:
+		return fmt.Errorf("%w %s: bsv_fee_strategy must be economy, normal, or priority", ErrInvalidTemplate, t.Name)
+	}
+	switch t.ETHGasSpeed {
+	case "", "slow", "medium", "fast":
+	default:
+		return fmt.Errorf("%w %s: eth_gas_speed must be slow, medium, or fast", ErrInvalidTemplate, t.Name)
+	}
+	return nil
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+func containsChain(chains []chain.ID, id chain.ID) bool {
+	for _, c := range chains {
+		if c == id {
+			return true
+		}
+	}
+	return false
+}
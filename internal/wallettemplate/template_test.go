@@ -0,0 +1,109 @@
+package wallettemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mrz1836/sigil/internal/chain"
+	"github.com/mrz1836/sigil/internal/config"
+)
+
+func TestAll(t *testing.T) {
+	t.Parallel()
+
+	all, err := All(map[string]config.WalletTemplateConfig{
+		"Savings": {Chains: []string{"bsv"}, Words: 24},
+		"payroll": {Chains: []string{"ethereum"}, Addresses: 10},
+	})
+	require.NoError(t, err)
+
+	names := make([]string, len(all))
+	for i, tmpl := range all {
+		names[i] = tmpl.Name
+	}
+	assert.Equal(t, []string{"merchant", "payroll", "savings", "spending"}, names)
+
+	savings := all[2]
+	assert.Equal(t, SourceConfig, savings.Source, "config replaces the built-in template")
+	assert.Equal(t, []chain.ID{chain.BSV}, savings.Chains)
+	assert.Empty(t, savings.BSVFeeStrategy, "replaced templates do not inherit built-in settings")
+	assert.Equal(t, SourceBuiltIn, all[0].Source)
+
+	_, err = All(map[string]config.WalletTemplateConfig{"bad": {Words: 18}})
+	require.ErrorIs(t, err, ErrInvalidTemplate)
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+
+	configured := map[string]config.WalletTemplateConfig{
+		"cold": {Chains: []string{"bsv", "bitcoin-sv"}, Labels: []string{"vault"}, Passphrase: true},
+	}
+
+	tmpl, err := Lookup(configured, "COLD")
+	require.NoError(t, err)
+	assert.Equal(t, "cold", tmpl.Name)
+	assert.Equal(t, []chain.ID{chain.BSV}, tmpl.Chains, "duplicate chains are dropped")
+	assert.Equal(t, 1, tmpl.Addresses)
+	assert.Equal(t, defaultWords, tmpl.Words)
+	assert.True(t, tmpl.Passphrase)
+
+	tmpl, err = Lookup(nil, "savings")
+	require.NoError(t, err)
+	assert.Equal(t, 24, tmpl.Words)
+	assert.InDelta(t, 100, tmpl.RequireConfirmAbove, 0)
+
+	_, err = Lookup(nil, "nope")
+	require.ErrorIs(t, err, ErrUnknownTemplate)
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cfg     config.WalletTemplateConfig
+		wantErr bool
+	}{
+		{name: "defaults", cfg: config.WalletTemplateConfig{}},
+		{name: "full", cfg: config.WalletTemplateConfig{
+			Chains: []string{"eth", "bsv"}, Addresses: 3, Labels: []string{"a", "b"}, Words: 24,
+			BSVFeeStrategy: "Priority", ETHGasSpeed: "fast", RequireConfirmAbove: 50,
+		}},
+		{name: "unknown chain", cfg: config.WalletTemplateConfig{Chains: []string{"doge"}}, wantErr: true},
+		{name: "testnet chain", cfg: config.WalletTemplateConfig{Chains: []string{"eth:sepolia"}}, wantErr: true},
+		{name: "negative addresses", cfg: config.WalletTemplateConfig{Addresses: -1}, wantErr: true},
+		{name: "too many addresses", cfg: config.WalletTemplateConfig{Addresses: MaxAddresses + 1}, wantErr: true},
+		{name: "more labels than addresses", cfg: config.WalletTemplateConfig{Labels: []string{"a", "b"}}, wantErr: true},
+		{name: "bad words", cfg: config.WalletTemplateConfig{Words: 15}, wantErr: true},
+		{name: "bad fee strategy", cfg: config.WalletTemplateConfig{BSVFeeStrategy: "cheap"}, wantErr: true},
+		{name: "bad gas speed", cfg: config.WalletTemplateConfig{ETHGasSpeed: "turbo"}, wantErr: true},
+		{name: "negative confirm limit", cfg: config.WalletTemplateConfig{RequireConfirmAbove: -1}, wantErr: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tmpl, err := FromConfig("test", tc.cfg)
+			if tc.wantErr {
+				require.ErrorIs(t, err, ErrInvalidTemplate)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, SourceConfig, tmpl.Source)
+		})
+	}
+
+	_, err := FromConfig(" ", config.WalletTemplateConfig{})
+	require.ErrorIs(t, err, ErrInvalidTemplate)
+}
+
+func TestBuiltInTemplatesAreValid(t *testing.T) {
+	t.Parallel()
+
+	for _, tmpl := range builtIn() {
+		require.NoError(t, tmpl.validate(), tmpl.Name)
+	}
+}
@@ -11,12 +11,13 @@ import (
 
 // Exit codes per FR-006.
 const (
-	ExitSuccess    = 0 // Successful execution
-	ExitGeneral    = 1 // General/unknown error
-	ExitInput      = 2 // Invalid input
-	ExitAuth       = 3 // Authentication failed
-	ExitNotFound   = 4 // Resource not found
-	ExitPermission = 5 // Permission denied or insufficient funds
+	ExitSuccess    = 0   // Successful execution
+	ExitGeneral    = 1   // General/unknown error
+	ExitInput      = 2   // Invalid input
+	ExitAuth       = 3   // Authentication failed
+	ExitNotFound   = 4   // Resource not found
+	ExitPermission = 5   // Permission denied or insufficient funds
+	ExitCanceled   = 130 // Canceled by SIGINT or SIGTERM
 )
 
 // SigilError is the structured error type for Sigil.
@@ -71,6 +72,12 @@ var (
 		ExitCode: ExitGeneral,
 	}
 
+	ErrCanceled = &SigilError{
+		Code:     "CANCELED",
+		Message:  "operation canceled",
+		ExitCode: ExitCanceled,
+	}
+
 	ErrInvalidInput = &SigilError{
 		Code:     "INVALID_INPUT",
 		Message:  "invalid input",
@@ -126,6 +133,36 @@ var (
 		ExitCode: ExitAuth,
 	}
 
+	ErrWalletIntegrity = &SigilError{
+		Code:     "WALLET_INTEGRITY",
+		Message:  "wallet metadata does not match addresses derived from the seed",
+		ExitCode: ExitGeneral,
+	}
+
+	ErrWalletBusy = &SigilError{
+		Code:     "WALLET_BUSY",
+		Message:  "wallet is busy with another operation",
+		ExitCode: ExitGeneral,
+	}
+
+	ErrWalletWatchOnly = &SigilError{
+		Code:     "WALLET_WATCH_ONLY",
+		Message:  "wallet is watch-only and cannot sign",
+		ExitCode: ExitPermission,
+	}
+
+	ErrWalletVersionTooNew = &SigilError{
+		Code:     "WALLET_VERSION_TOO_NEW",
+		Message:  "wallet file was written by a newer version of sigil",
+		ExitCode: ExitPermission,
+	}
+
+	ErrHardwareUnavailable = &SigilError{
+		Code:     "HARDWARE_UNAVAILABLE",
+		Message:  "no hardware wallet available",
+		ExitCode: ExitGeneral,
+	}
+
 	// Chain-specific errors.
 	ErrInvalidAddress = &SigilError{
 		Code:     "INVALID_ADDRESS",
@@ -221,6 +258,36 @@ var (
 		ExitCode: ExitInput,
 	}
 
+	ErrFeeRateTooHigh = &SigilError{
+		Code:     "FEE_RATE_TOO_HIGH",
+		Message:  "fee rate exceeds the configured maximum",
+		ExitCode: ExitInput,
+	}
+
+	ErrPriceSlippage = &SigilError{
+		Code:     "PRICE_SLIPPAGE",
+		Message:  "exchange rate moved beyond the allowed slippage",
+		ExitCode: ExitGeneral,
+	}
+
+	ErrCosignRejected = &SigilError{
+		Code:     "COSIGN_REJECTED",
+		Message:  "transaction not approved by policy service",
+		ExitCode: ExitPermission,
+	}
+
+	ErrHookRejected = &SigilError{
+		Code:     "HOOK_REJECTED",
+		Message:  "transaction rejected by pre_send hook",
+		ExitCode: ExitPermission,
+	}
+
+	ErrPassphraseMismatch = &SigilError{
+		Code:     "PASSPHRASE_MISMATCH",
+		Message:  "BIP39 passphrase does not match this wallet",
+		ExitCode: ExitAuth,
+	}
+
 	ErrInvalidValue = &SigilError{
 		Code:     "INVALID_VALUE",
 		Message:  "invalid value",
@@ -348,6 +415,24 @@ var (
 		ExitCode: ExitInput,
 	}
 
+	ErrAgentAssetDenied = &SigilError{
+		Code:     "AGENT_ASSET_DENIED",
+		Message:  "asset not in agent allowlist",
+		ExitCode: ExitInput,
+	}
+
+	ErrAgentHostDenied = &SigilError{
+		Code:     "AGENT_HOST_DENIED",
+		Message:  "agent token is bound to another host",
+		ExitCode: ExitPermission,
+	}
+
+	ErrAgentRateLimited = &SigilError{
+		Code:     "AGENT_RATE_LIMITED",
+		Message:  "too many requests for this agent token",
+		ExitCode: ExitPermission,
+	}
+
 	ErrAgentXpubInvalid = &SigilError{
 		Code:     "AGENT_XPUB_INVALID",
 		Message:  "xpub string is malformed or wrong format",
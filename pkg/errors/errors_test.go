@@ -31,6 +31,7 @@ func TestExitCodes(t *testing.T) {
 		{"not found error", sigilerr.ErrNotFound, sigilerr.ExitNotFound},
 		{"permission error", sigilerr.ErrPermission, sigilerr.ExitPermission},
 		{"insufficient funds", sigilerr.ErrInsufficientFunds, sigilerr.ExitPermission},
+		{"canceled", sigilerr.ErrCanceled, sigilerr.ExitCanceled},
 	}
 
 	for _, tt := range tests {